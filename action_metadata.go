@@ -3,7 +3,6 @@ package actionlint
 import (
 	"fmt"
 	"io"
-	"os"
 	"path/filepath"
 	"strings"
 	"sync"
@@ -12,6 +11,7 @@ import (
 )
 
 //go:generate go run ./scripts/generate-popular-actions ./popular_actions.go
+//go:generate go run ./scripts/generate-popular-actions -f data ./popular_actions.jsonl
 
 // ActionMetadataInput is input metadata in "inputs" section in action.yml metadata file.
 // https://docs.github.com/en/actions/creating-actions/metadata-syntax-for-github-actions#inputs
@@ -24,6 +24,8 @@ type ActionMetadataInput struct {
 	Deprecated bool `json:"deprecated"`
 	// DeprecationMessage is a deprecation message for the deprecated input.
 	DeprecationMessage string `json:"deprecation-message"`
+	// Default is the default value of this input. It is empty when the default value is not set.
+	Default string `json:"default,omitempty"`
 }
 
 // ActionMetadataInputs is a map from input ID to its metadata. Keys are in lower case since input
@@ -76,7 +78,11 @@ func (inputs *ActionMetadataInputs) UnmarshalYAML(n *yaml.Node) error {
 			}
 		}
 
-		md[id] = &ActionMetadataInput{k, m.Required && m.Default == nil, dep, strings.TrimSpace(m.DeprecationMessage)}
+		def := ""
+		if m.Default != nil {
+			def = *m.Default
+		}
+		md[id] = &ActionMetadataInput{k, m.Required && m.Default == nil, dep, strings.TrimSpace(m.DeprecationMessage), def}
 	}
 
 	*inputs = md
@@ -188,6 +194,18 @@ func (md *ActionMetadata) Path() string {
 	return filepath.Join(md.dir, md.file)
 }
 
+// DeprecatedAction is information of a deprecated action. An action is deprecated when its
+// repository is archived or when its authors announced the deprecation.
+type DeprecatedAction struct {
+	// Archived is true when the repository of the action is archived.
+	Archived bool `json:"archived,omitempty"`
+	// Message is the deprecation notice of the action. It is empty when no notice is known.
+	Message string `json:"message,omitempty"`
+	// Replacement is the action recommended instead like "actions/upload-artifact@v4". It is empty
+	// when no replacement is known.
+	Replacement string `json:"replacement,omitempty"`
+}
+
 // LocalActionsCache is cache for local actions' metadata. It avoids repeating to find/read/parse
 // local action's metadata file (action.yml).
 // This cache is not available across multiple repositories. One LocalActionsCache instance needs
@@ -291,7 +309,7 @@ func (c *LocalActionsCache) FindMetadata(spec string) (*ActionMetadata, bool, er
 func (c *LocalActionsCache) readLocalActionMetadataFile(dir string) ([]byte, string, bool) {
 	for _, f := range []string{"action.yaml", "action.yml"} {
 		p := filepath.Join(dir, f)
-		if b, err := os.ReadFile(p); err == nil {
+		if b, err := c.proj.readFile(p); err == nil {
 			return b, f, true
 		}
 	}
@@ -0,0 +1,185 @@
+package actionlint
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// actionMetadataLine is a line of action metadata file in JSONL format. The format is the same as
+// the output of ./scripts/generate-popular-actions with `-f jsonl`.
+type actionMetadataLine struct {
+	Spec        string          `json:"spec"`
+	Meta        *ActionMetadata `json:"metadata"`
+	Outdated    bool            `json:"outdated"`
+	Deprecation *struct {
+		DeprecatedAction
+		AllVersions bool `json:"all_versions,omitempty"`
+	} `json:"deprecation,omitempty"`
+}
+
+// ActionMetadataSet is a data set of action metadata read from action metadata files. It describes
+// actions which are not in the popular actions data set such as private or internal actions in an
+// organization. The files are set at "action-metadata" in the configuration file.
+type ActionMetadataSet struct {
+	// Actions is the metadata of the actions. Keys are specs of the actions like "org/action@v1".
+	Actions map[string]*ActionMetadata
+	// Outdated is the specs of the actions whose runners are outdated such as "node16". This is the
+	// same as OutdatedPopularActionSpecs.
+	Outdated map[string]struct{}
+	// Deprecated is the deprecated actions. Keys are specs of actions, or names of actions without
+	// ref when all versions of the actions are deprecated. This is the same as
+	// DeprecatedPopularActions.
+	Deprecated map[string]*DeprecatedAction
+}
+
+// ParseActionMetadataSet parses the action metadata file in JSONL format. Each line is a JSON object
+// describing one version of the action. The file can be generated by ./scripts/generate-popular-actions
+// with `-f jsonl` flag.
+func ParseActionMetadataSet(b []byte) (*ActionMetadataSet, error) {
+	s := &ActionMetadataSet{
+		Actions:    map[string]*ActionMetadata{},
+		Outdated:   map[string]struct{}{},
+		Deprecated: map[string]*DeprecatedAction{},
+	}
+
+	sc := bufio.NewScanner(bytes.NewReader(b))
+	sc.Buffer(nil, 16*1024*1024) // One line can be very long
+	for n := 1; sc.Scan(); n++ {
+		l := bytes.TrimSpace(sc.Bytes())
+		if len(l) == 0 {
+			continue
+		}
+
+		var j actionMetadataLine
+		if err := json.Unmarshal(l, &j); err != nil {
+			return nil, fmt.Errorf("could not parse line %d as JSON: %w", n, err)
+		}
+		name, ref, ok := strings.Cut(j.Spec, "@")
+		if !ok || name == "" || ref == "" {
+			return nil, fmt.Errorf("spec %q at line %d is invalid. it must be in \"owner/repo@ref\" format", j.Spec, n)
+		}
+		if j.Meta == nil && !j.Outdated {
+			return nil, fmt.Errorf("metadata of action %q is missing at line %d", j.Spec, n)
+		}
+
+		if j.Outdated {
+			s.Outdated[j.Spec] = struct{}{}
+		} else {
+			s.Actions[j.Spec] = j.Meta
+		}
+		if d := j.Deprecation; d != nil {
+			k := j.Spec
+			if d.AllVersions {
+				k = name
+			}
+			s.Deprecated[k] = &d.DeprecatedAction
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("could not read action metadata: %w", err)
+	}
+
+	return s, nil
+}
+
+// merge merges other set into the set. Entries in the other set have higher priority.
+func (s *ActionMetadataSet) merge(other *ActionMetadataSet) {
+	for k, v := range other.Actions {
+		s.Actions[k] = v
+		delete(s.Outdated, k)
+	}
+	for k := range other.Outdated {
+		s.Outdated[k] = struct{}{}
+		delete(s.Actions, k)
+	}
+	for k, v := range other.Deprecated {
+		s.Deprecated[k] = v
+	}
+}
+
+// metadata returns the metadata of the action spec. Calling this method on nil is safe.
+func (s *ActionMetadataSet) metadata(spec string) (*ActionMetadata, bool) {
+	if s == nil {
+		return nil, false
+	}
+	m, ok := s.Actions[spec]
+	return m, ok
+}
+
+// outdated returns whether the runner of the action spec is outdated. Calling this method on nil is
+// safe.
+func (s *ActionMetadataSet) outdated(spec string) bool {
+	if s == nil {
+		return false
+	}
+	_, ok := s.Outdated[spec]
+	return ok
+}
+
+// deprecated returns the deprecation of the action spec or the action name. Calling this method on
+// nil is safe.
+func (s *ActionMetadataSet) deprecated(spec, name string) (*DeprecatedAction, bool) {
+	if s == nil {
+		return nil, false
+	}
+	if d, ok := s.Deprecated[spec]; ok {
+		return d, true
+	}
+	d, ok := s.Deprecated[name]
+	return d, ok
+}
+
+// actionMetadataFiles is a cache of action metadata files set at "action-metadata" in the
+// configuration file. Each file is read only once even if it is used by multiple workflow files.
+// This is thread-safe.
+type actionMetadataFiles struct {
+	files sync.Map // file path -> func() (*ActionMetadataSet, error)
+}
+
+func (c *actionMetadataFiles) read(path string, proj *Project) (*ActionMetadataSet, error) {
+	f, ok := c.files.Load(path)
+	if !ok {
+		f, _ = c.files.LoadOrStore(path, sync.OnceValues(func() (*ActionMetadataSet, error) {
+			b, err := proj.readFile(path)
+			if err != nil {
+				return nil, fmt.Errorf("could not read action metadata file %q set at \"action-metadata\" in config: %w", path, err)
+			}
+			s, err := ParseActionMetadataSet(b)
+			if err != nil {
+				return nil, fmt.Errorf("could not parse action metadata file %q set at \"action-metadata\" in config: %w", path, err)
+			}
+			return s, nil
+		}))
+	}
+	return f.(func() (*ActionMetadataSet, error))()
+}
+
+// load reads all action metadata files in the config and merges them into one set. Relative file
+// paths are resolved from the root directory of the project. It returns nil when no file is set.
+func (c *actionMetadataFiles) load(cfg *Config, proj *Project) (*ActionMetadataSet, error) {
+	if cfg == nil || len(cfg.ActionMetadata) == 0 {
+		return nil, nil
+	}
+
+	ret := &ActionMetadataSet{
+		Actions:    map[string]*ActionMetadata{},
+		Outdated:   map[string]struct{}{},
+		Deprecated: map[string]*DeprecatedAction{},
+	}
+	for _, p := range cfg.ActionMetadata {
+		if proj != nil && !filepath.IsAbs(p) {
+			p = filepath.Join(proj.RootDir(), filepath.FromSlash(p))
+		}
+		s, err := c.read(p, proj)
+		if err != nil {
+			return nil, err
+		}
+		ret.merge(s)
+	}
+	return ret, nil
+}
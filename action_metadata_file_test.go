@@ -0,0 +1,195 @@
+package actionlint
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseActionMetadataSetOK(t *testing.T) {
+	src := `
+{"spec":"my-org/private-action@v1","metadata":{"name":"Private","inputs":{"token":{"name":"token","required":true}},"outputs":{"result":{"name":"result"}}},"outdated":false}
+{"spec":"my-org/private-action@v0","metadata":{"name":"Private"},"outdated":true}
+
+{"spec":"my-org/old-action@v2","metadata":{"name":"Old"},"outdated":false,"deprecation":{"replacement":"my-org/new-action@v1","all_versions":true}}
+{"spec":"my-org/other-action@v1","metadata":{"name":"Other"},"outdated":false,"deprecation":{"message":"do not use this"}}
+`
+	s, err := ParseActionMetadataSet([]byte(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m, ok := s.metadata("my-org/private-action@v1")
+	if !ok {
+		t.Fatalf("metadata was not found: %v", s.Actions)
+	}
+	if i, ok := m.Inputs["token"]; !ok || !i.Required {
+		t.Errorf("unexpected inputs: %v", m.Inputs)
+	}
+	if _, ok := m.Outputs["result"]; !ok {
+		t.Errorf("unexpected outputs: %v", m.Outputs)
+	}
+
+	if _, ok := s.metadata("my-org/private-action@v0"); ok {
+		t.Error("outdated action should not have metadata")
+	}
+	if !s.outdated("my-org/private-action@v0") || s.outdated("my-org/private-action@v1") {
+		t.Errorf("unexpected outdated actions: %v", s.Outdated)
+	}
+
+	if d, ok := s.deprecated("my-org/old-action@v1", "my-org/old-action"); !ok || d.Replacement != "my-org/new-action@v1" {
+		t.Errorf("all versions of action should be deprecated: %v", d)
+	}
+	if d, ok := s.deprecated("my-org/other-action@v1", "my-org/other-action"); !ok || d.Message != "do not use this" {
+		t.Errorf("action should be deprecated: %v", d)
+	}
+	if _, ok := s.deprecated("my-org/other-action@v2", "my-org/other-action"); ok {
+		t.Error("other version of action should not be deprecated")
+	}
+}
+
+func TestParseActionMetadataSetNil(t *testing.T) {
+	var s *ActionMetadataSet
+	if _, ok := s.metadata("actions/checkout@v4"); ok {
+		t.Error("nil set should not have metadata")
+	}
+	if s.outdated("actions/checkout@v1") {
+		t.Error("nil set should not have outdated action")
+	}
+	if _, ok := s.deprecated("actions/checkout@v1", "actions/checkout"); ok {
+		t.Error("nil set should not have deprecated action")
+	}
+}
+
+func TestParseActionMetadataSetError(t *testing.T) {
+	tests := []struct {
+		what string
+		in   string
+		want string
+	}{
+		{
+			what: "broken JSON",
+			in:   `{"spec":"my-org/action@v1",`,
+			want: "could not parse line 1 as JSON",
+		},
+		{
+			what: "spec without ref",
+			in:   `{"spec":"my-org/action","metadata":{"name":"Action"}}`,
+			want: `spec "my-org/action" at line 1 is invalid`,
+		},
+		{
+			what: "empty ref",
+			in:   `{"spec":"my-org/action@","metadata":{"name":"Action"}}`,
+			want: `spec "my-org/action@" at line 1 is invalid`,
+		},
+		{
+			what: "missing metadata",
+			in:   "\n" + `{"spec":"my-org/action@v1"}`,
+			want: `metadata of action "my-org/action@v1" is missing at line 2`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.what, func(t *testing.T) {
+			_, err := ParseActionMetadataSet([]byte(tc.in))
+			if err == nil {
+				t.Fatal("error did not occur")
+			}
+			if msg := err.Error(); !strings.Contains(msg, tc.want) {
+				t.Fatalf("wanted error message %q to contain %q", msg, tc.want)
+			}
+		})
+	}
+}
+
+func TestActionMetadataFilesMerge(t *testing.T) {
+	d := t.TempDir()
+	a := `{"spec":"my-org/action@v1","metadata":{"name":"A"},"outdated":false}`
+	b := `{"spec":"my-org/action@v1","metadata":{"name":"B"},"outdated":false}`
+	if err := os.WriteFile(filepath.Join(d, "a.jsonl"), []byte(a), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(d, "b.jsonl"), []byte(b), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	c := &actionMetadataFiles{}
+	proj := &Project{root: d}
+	s, err := c.load(&Config{ActionMetadata: []string{"a.jsonl", filepath.Join(d, "b.jsonl")}}, proj)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m, ok := s.metadata("my-org/action@v1"); !ok || m.Name != "B" {
+		t.Fatalf("metadata in later file should be prioritized: %v", m)
+	}
+
+	s, err = c.load(&Config{}, proj)
+	if err != nil || s != nil {
+		t.Fatalf("no set should be loaded when no file is set: %v, %v", s, err)
+	}
+
+	_, err = c.load(&Config{ActionMetadata: []string{"missing.jsonl"}}, proj)
+	if err == nil || !strings.Contains(err.Error(), "could not read action metadata file") {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestLinterActionMetadataFromConfig(t *testing.T) {
+	d := t.TempDir()
+	testEnsureDotGitDir(d)
+	w := filepath.Join(d, ".github", "workflows")
+	if err := os.MkdirAll(w, 0750); err != nil {
+		t.Fatal(err)
+	}
+	src := `on: push
+jobs:
+  test:
+    runs-on: ubuntu-latest
+    steps:
+      - uses: my-org/private-action@v1
+        id: private
+        with:
+          tokn: foo
+      - run: echo ${{ steps.private.outputs.result }} ${{ steps.private.outputs.reslt }}
+      - uses: my-org/private-action@v0
+`
+	if err := os.WriteFile(filepath.Join(w, "test.yaml"), []byte(src), 0644); err != nil {
+		t.Fatal(err)
+	}
+	meta := `{"spec":"my-org/private-action@v1","metadata":{"name":"Private","inputs":{"token":{"name":"token","required":true}},"outputs":{"result":{"name":"result"}}},"outdated":false}
+{"spec":"my-org/private-action@v0","metadata":{"name":"Private"},"outdated":true}
+`
+	if err := os.WriteFile(filepath.Join(d, "actions.jsonl"), []byte(meta), 0644); err != nil {
+		t.Fatal(err)
+	}
+	cfg := filepath.Join(d, "actionlint.yaml")
+	if err := os.WriteFile(cfg, []byte("action-metadata:\n  - actions.jsonl\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	l, err := NewLinter(io.Discard, &LinterOptions{ConfigFile: cfg})
+	if err != nil {
+		t.Fatal(err)
+	}
+	errs, err := l.LintRepository(d)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{
+		`missing input "token" which is required by action "my-org/private-action@v1"`,
+		`input "tokn" is not defined in action "my-org/private-action@v1"`,
+		`property "reslt" is not defined in object type {result: string}`,
+		`the runner of "my-org/private-action@v0" action is too old to run on GitHub Actions`,
+	}
+	if len(errs) != len(want) {
+		t.Fatalf("wanted %d errors but got %d: %v", len(want), len(errs), errs)
+	}
+	for i, err := range errs {
+		if !strings.Contains(err.Message, want[i]) {
+			t.Errorf("error #%d %q does not contain %q", i, err.Message, want[i])
+		}
+	}
+}
@@ -42,3 +42,151 @@ var AllWebhookTypes = map[string][]string{
 	"workflow_dispatch":           {},
 	"workflow_run":                {"completed", "requested", "in_progress"},
 }
+
+// AllWebhookEvents is a table of all webhook events with their metadata. This variable was
+// generated by script at ./scripts/generate-webhook-events based on
+// https://docs.github.com/en/actions/reference/workflows-and-actions/events-that-trigger-workflows
+var AllWebhookEvents = map[string]*WebhookEventMetadata{
+	"branch_protection_rule": {
+		Types: []string{"created", "edited", "deleted"},
+		URL:   "https://docs.github.com/en/actions/reference/workflows-and-actions/events-that-trigger-workflows#branch_protection_rule",
+	},
+	"check_run": {
+		Types: []string{"created", "rerequested", "completed", "requested_action"},
+		URL:   "https://docs.github.com/en/actions/reference/workflows-and-actions/events-that-trigger-workflows#check_run",
+	},
+	"check_suite": {
+		Types: []string{"completed"},
+		URL:   "https://docs.github.com/en/actions/reference/workflows-and-actions/events-that-trigger-workflows#check_suite",
+	},
+	"create": {
+		Types: []string{},
+		URL:   "https://docs.github.com/en/actions/reference/workflows-and-actions/events-that-trigger-workflows#create",
+	},
+	"delete": {
+		Types: []string{},
+		URL:   "https://docs.github.com/en/actions/reference/workflows-and-actions/events-that-trigger-workflows#delete",
+	},
+	"deployment": {
+		Types: []string{},
+		URL:   "https://docs.github.com/en/actions/reference/workflows-and-actions/events-that-trigger-workflows#deployment",
+	},
+	"deployment_status": {
+		Types: []string{},
+		URL:   "https://docs.github.com/en/actions/reference/workflows-and-actions/events-that-trigger-workflows#deployment_status",
+	},
+	"discussion": {
+		Types: []string{"created", "edited", "deleted", "transferred", "pinned", "unpinned", "labeled", "unlabeled", "locked", "unlocked", "category_changed", "answered", "unanswered"},
+		URL:   "https://docs.github.com/en/actions/reference/workflows-and-actions/events-that-trigger-workflows#discussion",
+		GHES:  &GHESVersion{Major: 3, Minor: 6},
+	},
+	"discussion_comment": {
+		Types: []string{"created", "edited", "deleted"},
+		URL:   "https://docs.github.com/en/actions/reference/workflows-and-actions/events-that-trigger-workflows#discussion_comment",
+		GHES:  &GHESVersion{Major: 3, Minor: 6},
+	},
+	"fork": {
+		Types: []string{},
+		URL:   "https://docs.github.com/en/actions/reference/workflows-and-actions/events-that-trigger-workflows#fork",
+	},
+	"gollum": {
+		Types: []string{},
+		URL:   "https://docs.github.com/en/actions/reference/workflows-and-actions/events-that-trigger-workflows#gollum",
+	},
+	"image_version": {
+		Types: []string{},
+		URL:   "https://docs.github.com/en/actions/reference/workflows-and-actions/events-that-trigger-workflows#image_version",
+		GHES:  &GHESVersion{},
+	},
+	"issue_comment": {
+		Types: []string{"created", "edited", "deleted"},
+		URL:   "https://docs.github.com/en/actions/reference/workflows-and-actions/events-that-trigger-workflows#issue_comment",
+	},
+	"issues": {
+		Types: []string{"opened", "edited", "deleted", "transferred", "pinned", "unpinned", "closed", "reopened", "assigned", "unassigned", "labeled", "unlabeled", "locked", "unlocked", "milestoned", "demilestoned", "typed", "untyped"},
+		URL:   "https://docs.github.com/en/actions/reference/workflows-and-actions/events-that-trigger-workflows#issues",
+	},
+	"label": {
+		Types: []string{"created", "edited", "deleted"},
+		URL:   "https://docs.github.com/en/actions/reference/workflows-and-actions/events-that-trigger-workflows#label",
+	},
+	"merge_group": {
+		Types:   []string{"checks_requested"},
+		Filters: []string{"branches", "branches-ignore"},
+		URL:     "https://docs.github.com/en/actions/reference/workflows-and-actions/events-that-trigger-workflows#merge_group",
+		GHES:    &GHESVersion{Major: 3, Minor: 12},
+	},
+	"milestone": {
+		Types: []string{"created", "closed", "opened", "edited", "deleted"},
+		URL:   "https://docs.github.com/en/actions/reference/workflows-and-actions/events-that-trigger-workflows#milestone",
+	},
+	"page_build": {
+		Types: []string{},
+		URL:   "https://docs.github.com/en/actions/reference/workflows-and-actions/events-that-trigger-workflows#page_build",
+	},
+	"public": {
+		Types: []string{},
+		URL:   "https://docs.github.com/en/actions/reference/workflows-and-actions/events-that-trigger-workflows#public",
+	},
+	"pull_request": {
+		Types:   []string{"assigned", "unassigned", "labeled", "unlabeled", "opened", "edited", "closed", "reopened", "synchronize", "converted_to_draft", "locked", "unlocked", "enqueued", "dequeued", "milestoned", "demilestoned", "ready_for_review", "review_requested", "review_request_removed", "auto_merge_enabled", "auto_merge_disabled"},
+		Filters: []string{"branches", "branches-ignore", "paths", "paths-ignore"},
+		URL:     "https://docs.github.com/en/actions/reference/workflows-and-actions/events-that-trigger-workflows#pull_request",
+	},
+	"pull_request_review": {
+		Types: []string{"submitted", "edited", "dismissed"},
+		URL:   "https://docs.github.com/en/actions/reference/workflows-and-actions/events-that-trigger-workflows#pull_request_review",
+	},
+	"pull_request_review_comment": {
+		Types: []string{"created", "edited", "deleted"},
+		URL:   "https://docs.github.com/en/actions/reference/workflows-and-actions/events-that-trigger-workflows#pull_request_review_comment",
+	},
+	"pull_request_target": {
+		Types:   []string{"assigned", "unassigned", "labeled", "unlabeled", "opened", "edited", "closed", "reopened", "synchronize", "converted_to_draft", "locked", "unlocked", "enqueued", "dequeued", "milestoned", "demilestoned", "ready_for_review", "review_requested", "review_request_removed", "auto_merge_enabled", "auto_merge_disabled"},
+		Filters: []string{"branches", "branches-ignore", "paths", "paths-ignore"},
+		URL:     "https://docs.github.com/en/actions/reference/workflows-and-actions/events-that-trigger-workflows#pull_request_target",
+	},
+	"push": {
+		Types:   []string{},
+		Filters: []string{"branches", "branches-ignore", "paths", "paths-ignore", "tags", "tags-ignore"},
+		URL:     "https://docs.github.com/en/actions/reference/workflows-and-actions/events-that-trigger-workflows#push",
+	},
+	"registry_package": {
+		Types: []string{"published", "updated"},
+		URL:   "https://docs.github.com/en/actions/reference/workflows-and-actions/events-that-trigger-workflows#registry_package",
+	},
+	"release": {
+		Types: []string{"published", "unpublished", "created", "edited", "deleted", "prereleased", "released"},
+		URL:   "https://docs.github.com/en/actions/reference/workflows-and-actions/events-that-trigger-workflows#release",
+	},
+	"repository_dispatch": {
+		Types: nil,
+		URL:   "https://docs.github.com/en/actions/reference/workflows-and-actions/events-that-trigger-workflows#repository_dispatch",
+	},
+	"schedule": {
+		Types: []string{},
+		URL:   "https://docs.github.com/en/actions/reference/workflows-and-actions/events-that-trigger-workflows#schedule",
+	},
+	"status": {
+		Types: []string{},
+		URL:   "https://docs.github.com/en/actions/reference/workflows-and-actions/events-that-trigger-workflows#status",
+	},
+	"watch": {
+		Types: []string{"started"},
+		URL:   "https://docs.github.com/en/actions/reference/workflows-and-actions/events-that-trigger-workflows#watch",
+	},
+	"workflow_call": {
+		Types: []string{},
+		URL:   "https://docs.github.com/en/actions/reference/workflows-and-actions/events-that-trigger-workflows#workflow_call",
+		GHES:  &GHESVersion{Major: 3, Minor: 4},
+	},
+	"workflow_dispatch": {
+		Types: []string{},
+		URL:   "https://docs.github.com/en/actions/reference/workflows-and-actions/events-that-trigger-workflows#workflow_dispatch",
+	},
+	"workflow_run": {
+		Types:   []string{"completed", "requested", "in_progress"},
+		Filters: []string{"branches", "branches-ignore"},
+		URL:     "https://docs.github.com/en/actions/reference/workflows-and-actions/events-that-trigger-workflows#workflow_run",
+	},
+}
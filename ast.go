@@ -9,12 +9,25 @@ import (
 	"go.yaml.in/yaml/v4"
 )
 
-// Pos represents position in the file.
+// Pos represents position in the file. When the position is a start of some range like a string
+// value, it may also have the end of the range.
 type Pos struct {
 	// Line is a line number of the position. This value is 1-based.
 	Line int
 	// Col is a column number of the position. This value is 1-based.
 	Col int
+	// EndLine is a line number where the range starting at this position ends. This value is
+	// 1-based. 0 means the end of the range is unknown.
+	EndLine int
+	// EndCol is a column number where the range starting at this position ends. The character at
+	// this column is not included in the range. This value is 1-based. 0 means the end of the range
+	// is unknown.
+	EndCol int
+}
+
+// HasEnd returns whether the end of the range starting at the position is known.
+func (p *Pos) HasEnd() bool {
+	return p.EndLine > 0 && p.EndCol > 0
 }
 
 func (p *Pos) String() string {
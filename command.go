@@ -1,12 +1,26 @@
 package actionlint
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
 	"io"
+	"math"
+	"os"
+	"path/filepath"
 	"regexp"
 	"runtime"
 	"runtime/debug"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rhysd/actionlint/expr"
+	"go.yaml.in/yaml/v4"
 )
 
 // These variables might be modified by ldflags on building release binaries by GoReleaser. Do not modify manually
@@ -51,11 +65,69 @@ func printUsageHeader(out io.Writer) {
 
     $ actionlint -
 
+  To check multiple contents at once (e.g. unsaved buffers in editor), pass
+  JSON array of {"path": ..., "content": ...} objects via stdin with
+  -stdin-batch flag:
+
+    $ actionlint -stdin-batch -format '{{json .}}'
+
   To serialize errors into JSON, use -format option. It allows to format error
   messages flexibly with Go template syntax.
 
     $ actionlint -format '{{json .}}'
 
+  To format workflow files in the canonical style, use fmt subcommand. See
+  'actionlint fmt -help' for more details.
+
+    $ actionlint fmt -w
+
+  To output the dependency graph of jobs, use graph subcommand. See
+  'actionlint graph -help' for more details.
+
+    $ actionlint graph -format mermaid
+
+  To upgrade popular actions to their latest major versions, use upgrade
+  subcommand. See 'actionlint upgrade -help' for more details.
+
+    $ actionlint upgrade -w
+
+  To find duplicated steps across jobs and extract them into a composite
+  action, use dedup subcommand. See 'actionlint dedup -help' for more details.
+
+    $ actionlint dedup
+
+  To find actions and reusable workflows referenced at different refs across
+  workflows, use drift subcommand. See 'actionlint drift -help' for more
+  details.
+
+    $ actionlint drift
+
+  To list all actions, reusable workflows, and Docker images used in
+  workflows with their refs and call sites, use inventory subcommand. See
+  'actionlint inventory -help' for more details.
+
+    $ actionlint inventory
+
+  To check the type of an expression and evaluate it with mock context
+  values, use expr subcommand. See 'actionlint expr -help' for more details.
+
+    $ actionlint expr -context ctx.json "github.ref_name == 'main'"
+
+  To generate a starter workflow such as tests of Go project, use new
+  subcommand. See 'actionlint new -help' for more details.
+
+    $ actionlint new -o .github/workflows/ci.yaml go-test
+
+  To render documentation of all rules including plugins in Markdown, use
+  docs subcommand. See 'actionlint docs -help' for more details.
+
+    $ actionlint docs > rules.md
+
+  To output JSON Schema of workflow files for editors, use schema subcommand.
+  See 'actionlint schema -help' for more details.
+
+    $ actionlint schema > workflow.schema.json
+
 Documents:
 
   - List of checks: https://github.com/rhysd/actionlint/tree/%s/docs/checks.md
@@ -66,6 +138,264 @@ Flags:
 `, b, b, b)
 }
 
+func printFormatUsageHeader(out io.Writer) {
+	fmt.Fprint(out, `Usage: actionlint fmt [FLAGS] [FILES...] [-]
+
+  actionlint fmt formats workflow files in the canonical style. Indentation,
+  order of keys in steps, and quotes of strings are normalized. Comments are
+  preserved.
+
+  To print all formatted workflow files in current repository, run it without
+  arguments. It automatically finds the nearest '.github/workflows' directory:
+
+    $ actionlint fmt
+
+  To overwrite the files with the formatted ones, use -w flag:
+
+    $ actionlint fmt -w file1.yaml file2.yaml
+
+  To check the files are formatted, use -l flag. It lists the files which are
+  not formatted and exits with non-zero status when some file is listed:
+
+    $ actionlint fmt -l
+
+  To format content which is not saved in file yet, pass - argument. It reads
+  stdin and outputs the formatted content:
+
+    $ actionlint fmt -
+
+Flags:
+`)
+}
+
+func printUpgradeUsageHeader(out io.Writer) {
+	fmt.Fprint(out, `Usage: actionlint upgrade [FLAGS] [FILES...] [-]
+
+  actionlint upgrade upgrades popular actions at "uses:" to their latest major
+  versions. Inputs which were renamed or removed in the new versions are also
+  migrated. Notes of the upgrades such as inputs which are newly required are
+  printed to stderr.
+
+  To print the upgrades of all workflow files in current repository as
+  unified diff, run it without arguments. Exit status is 1 when some upgrade
+  is available:
+
+    $ actionlint upgrade
+
+  To overwrite the files with the upgraded ones, use -w flag:
+
+    $ actionlint upgrade -w file1.yaml file2.yaml
+
+  To upgrade content which is not saved in file yet, pass - argument. It
+  reads stdin and outputs the diff:
+
+    $ actionlint upgrade -
+
+Flags:
+`)
+}
+
+func printDedupUsageHeader(out io.Writer) {
+	fmt.Fprint(out, `Usage: actionlint dedup [FLAGS] [FILES...]
+
+  actionlint dedup finds step sequences which are duplicated across jobs and
+  workflows. Names of steps are ignored on comparing steps. The duplicated
+  step sequences are reported as clusters with their numbers. Exit status is
+  1 when some duplication is found.
+
+  To report the duplicated steps in all workflow files in current repository,
+  run it without arguments:
+
+    $ actionlint dedup
+
+  To also find near-identical step sequences which differ in details such as
+  versions of actions and input values, use -near flag. Steps which differ
+  across the places are shown in the report:
+
+    $ actionlint dedup -near
+
+  To extract the duplicated steps of some cluster into a local composite
+  action at '.github/actions/{name}/action.yml', use -extract and -name flags.
+  The duplicated steps are replaced with the step which runs the action. The
+  changes are printed as unified diff:
+
+    $ actionlint dedup -extract 1 -name setup
+
+  To write the composite action and overwrite the workflow files, use -w flag:
+
+    $ actionlint dedup -extract 1 -name setup -w
+
+Flags:
+`)
+}
+
+func printDriftUsageHeader(out io.Writer) {
+	fmt.Fprint(out, `Usage: actionlint drift [FLAGS] [FILES...]
+
+  actionlint drift finds actions and reusable workflows at "uses:" which are
+  referenced at different refs across workflows, and reports the refs in use
+  with their locations. The most referenced ref is reported first. Local
+  actions, local reusable workflows, and Docker images are not checked. Exit
+  status is 1 when some drift is found.
+
+  To report the drifts in all workflow files in current repository, run it
+  without arguments:
+
+    $ actionlint drift
+
+  To report the drifts in specific files, pass the file paths as arguments:
+
+    $ actionlint drift file1.yaml file2.yaml
+
+Flags:
+`)
+}
+
+func printInventoryUsageHeader(out io.Writer) {
+	fmt.Fprint(out, `Usage: actionlint inventory [FLAGS] [FILES...]
+
+  actionlint inventory lists all actions, reusable workflows, and Docker
+  images used at "uses:" in workflows and local composite actions. Each item
+  is reported with its ref, whether it is pinned to a full commit SHA or a
+  digest, the number of call sites, and the files using it. Local actions
+  used by the workflows are followed and their steps are also listed.
+
+  To list the items in all workflow files in current repository, run it
+  without arguments:
+
+    $ actionlint inventory
+
+  To list the items in specific files, pass the file paths as arguments.
+  Files named action.yml or action.yaml are read as action metadata files:
+
+    $ actionlint inventory file1.yaml path/to/action.yml
+
+  To output the inventory as JSON for other tools, use -format flag:
+
+    $ actionlint inventory -format json
+
+  To list only unpinned items, use -unpinned flag. Exit status is 1 when
+  some item is listed:
+
+    $ actionlint inventory -unpinned
+
+Flags:
+`)
+}
+
+func printExprUsageHeader(out io.Writer) {
+	fmt.Fprint(out, `Usage: actionlint expr [FLAGS] EXPRESSION
+
+  actionlint expr parses and type-checks the expression and prints the type
+  of its value. The expression can be written with or without ${{ }}:
+
+    $ actionlint expr "startsWith(github.ref, 'refs/tags/')"
+
+  To evaluate the expression, give mock values of contexts as a JSON or YAML
+  file with -context flag. The keys of the top-level mapping are context
+  names like "github" or "matrix". - reads the values from stdin:
+
+    $ actionlint expr -context ctx.yaml "matrix.os == 'ubuntu-latest'"
+
+  Expressions which only consist of constants are evaluated without
+  -context flag. Exit status is 1 when some error is found in the expression.
+
+Flags:
+`)
+}
+
+func printGraphUsageHeader(out io.Writer) {
+	fmt.Fprint(out, `Usage: actionlint graph [FLAGS] [FILES...] [-]
+
+  actionlint graph outputs the dependency graph of jobs in workflow files.
+  Dependencies at "needs:", reusable workflow calls, and local action usages
+  are included in the graph.
+
+  To output the graph of all workflow files in current repository in DOT
+  format, run it without arguments. The output can be rendered by Graphviz:
+
+    $ actionlint graph | dot -Tsvg -o graph.svg
+
+  To output the graph of specific files in Mermaid flowchart or JSON format,
+  use -format flag:
+
+    $ actionlint graph -format mermaid file1.yaml file2.yaml
+
+  To output the graph of content which is not saved in file yet, pass -
+  argument. It reads stdin as workflow source:
+
+    $ actionlint graph -
+
+Flags:
+`)
+}
+
+func printNewUsageHeader(out io.Writer) {
+	fmt.Fprint(out, `Usage: actionlint new [FLAGS] NAME
+
+  actionlint new generates a starter workflow which has no error reported by
+  actionlint. Actions in it are pinned to their latest major versions and
+  permissions of GITHUB_TOKEN are minimal. To list the starter workflows and
+  their parameters, use -list flag:
+
+    $ actionlint new -list
+
+  To write a starter workflow to a file, use -o flag:
+
+    $ actionlint new -o .github/workflows/ci.yaml go-test
+
+  To customize the starter workflow, set its parameters with -set flag:
+
+    $ actionlint new -set go-version=1.24 -set branch=develop go-test
+
+  To pin the actions to full commit SHAs, use -pin flag. The SHAs are
+  resolved with GitHub API:
+
+    $ actionlint new -pin release
+
+Flags:
+`)
+}
+
+func printDocsUsageHeader(out io.Writer) {
+	fmt.Fprint(out, `Usage: actionlint docs [FLAGS]
+
+  actionlint docs renders documentation of all rules in Markdown to stdout.
+  Built-in rules, custom rules registered in Go, and plugins declared in the
+  config file are documented with their descriptions, configuration options,
+  and examples. Documentation of a plugin is written at "docs" of the plugin
+  in the config file:
+
+    $ actionlint docs > rules.md
+
+  To document only custom rules and plugins such as your organization's rule
+  set, use -builtin=false:
+
+    $ actionlint docs -builtin=false -title "Our rules" -o rules.md
+
+Flags:
+`)
+}
+
+func printSchemaUsageHeader(out io.Writer) {
+	fmt.Fprint(out, `Usage: actionlint schema [FLAGS]
+
+  actionlint schema outputs JSON Schema of workflow files to stdout. The
+  schema is generated from the knowledge of actionlint such as keys of each
+  section, webhook events, permission scopes, and shell names. It is useful
+  for editors which validate YAML files with JSON Schema such as
+  yaml-language-server:
+
+    $ actionlint schema > workflow.schema.json
+
+  Then put the following comment at the top of workflow files:
+
+    # yaml-language-server: $schema=./workflow.schema.json
+
+Flags:
+`)
+}
+
 func getCommandVersion() string {
 	if version != "" {
 		return version
@@ -89,103 +419,1436 @@ type Command struct {
 	Stderr io.Writer
 }
 
-func (cmd *Command) runLinter(args []string, opts *LinterOptions, initConfig bool) ([]*Error, error) {
+// runLinter runs the linter and returns the errors. The returned bool value is true when some fix
+// is available in the diff output by -diff flag.
+func (cmd *Command) runLinter(args []string, opts *LinterOptions, initConfig, stdinBatch bool) ([]*Error, bool, error) {
 	l, err := NewLinter(cmd.Stdout, opts)
 	if err != nil {
-		return nil, err
+		return nil, false, err
 	}
 
 	if initConfig {
-		return nil, l.GenerateDefaultConfig("")
+		return nil, false, l.GenerateDefaultConfig("")
 	}
 
-	if len(args) == 0 {
-		return l.LintRepository("")
+	var errs []*Error
+	if stdinBatch {
+		errs, err = l.LintStdinBatch(cmd.Stdin)
+	} else if len(args) == 0 {
+		errs, err = l.LintRepository("")
+	} else if len(args) == 1 && args[0] == "-" {
+		errs, err = l.LintStdin(cmd.Stdin)
+	} else {
+		errs, err = l.LintFiles(args, nil)
 	}
+	return errs, l.fixable, err
+}
 
-	if len(args) == 1 && args[0] == "-" {
-		return l.LintStdin(cmd.Stdin)
+// findWorkflowFilesInRepository finds all workflow files in the repository of the current working
+// directory. The returned paths are relative to the current working directory.
+func findWorkflowFilesInRepository() ([]string, error) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return nil, fmt.Errorf("could not get current working directory: %w", err)
 	}
-
-	return l.LintFiles(args, nil)
+	p, err := findProject(cwd)
+	if err != nil {
+		return nil, err
+	}
+	if p == nil {
+		return nil, fmt.Errorf("no project was found in any parent directories of %q. check workflows directory is put correctly in your Git repository", cwd)
+	}
+	var platform Platform
+	if c := p.Config(); c != nil {
+		platform = c.Platform
+	}
+	fs, err := findYAMLFiles(p.workflowsDirOf(platform), p)
+	if err != nil {
+		return nil, err
+	}
+	for i, f := range fs {
+		if r, err := filepath.Rel(cwd, f); err == nil {
+			fs[i] = r // Show relative paths in output
+		}
+	}
+	return fs, nil
 }
 
-type ignorePatternFlags []string
+// formatFiles formats the workflow files and returns whether some file is not formatted yet.
+func (cmd *Command) formatFiles(args []string, write, list bool) (bool, error) {
+	if len(args) == 1 && args[0] == "-" {
+		src, err := io.ReadAll(cmd.Stdin)
+		if err != nil {
+			return false, fmt.Errorf("could not read stdin: %w", err)
+		}
+		out, err := FormatWorkflow(src)
+		if err != nil {
+			return false, fmt.Errorf("could not format <stdin>: %w", err)
+		}
+		changed := !bytes.Equal(src, out)
+		if list {
+			if changed {
+				fmt.Fprintln(cmd.Stdout, "<stdin>")
+			}
+		} else {
+			cmd.Stdout.Write(out)
+		}
+		return changed, nil
+	}
 
-func (i *ignorePatternFlags) String() string {
-	return "option for ignore patterns"
-}
-func (i *ignorePatternFlags) Set(v string) error {
-	*i = append(*i, v)
-	return nil
+	if len(args) == 0 {
+		fs, err := findWorkflowFilesInRepository()
+		if err != nil {
+			return false, err
+		}
+		args = fs
+	}
+
+	unformatted := false
+	for _, path := range args {
+		src, err := os.ReadFile(path)
+		if err != nil {
+			return false, fmt.Errorf("could not read %q: %w", path, err)
+		}
+		out, err := FormatWorkflow(src)
+		if err != nil {
+			return false, fmt.Errorf("could not format %q: %w", path, err)
+		}
+		changed := !bytes.Equal(src, out)
+		if changed {
+			unformatted = true
+		}
+		if list && changed {
+			fmt.Fprintln(cmd.Stdout, path)
+		}
+		if write && changed {
+			info, err := os.Stat(path)
+			if err != nil {
+				return false, fmt.Errorf("could not get file info of %q: %w", path, err)
+			}
+			if err := os.WriteFile(path, out, info.Mode().Perm()); err != nil {
+				return false, fmt.Errorf("could not write formatted workflow to %q: %w", path, err)
+			}
+		}
+		if !list && !write {
+			cmd.Stdout.Write(out)
+		}
+	}
+	return unformatted, nil
 }
 
-// Main is main function of actionlint. It takes command line arguments as string slice and returns
-// exit status. The args should be entire arguments including the program name, usually given via
-// os.Args.
-func (cmd *Command) Main(args []string) int {
-	var ver bool
-	var opts LinterOptions
-	var ignorePats ignorePatternFlags
-	var initConfig bool
-	var noColor bool
-	var color bool
+// formatMain is main function of "actionlint fmt" subcommand. The args should be entire arguments
+// including the program name.
+func (cmd *Command) formatMain(args []string) int {
+	var write bool
+	var list bool
 
-	flags := flag.NewFlagSet(args[0], flag.ContinueOnError)
+	flags := flag.NewFlagSet(args[0]+" fmt", flag.ContinueOnError)
 	flags.SetOutput(cmd.Stderr)
-	flags.Var(&ignorePats, "ignore", "Regular expression matching to error messages you want to ignore. This flag is repeatable")
-	flags.StringVar(&opts.Shellcheck, "shellcheck", "shellcheck", "Command name or file path of \"shellcheck\" external command. If empty, shellcheck integration will be disabled")
-	flags.StringVar(&opts.Pyflakes, "pyflakes", "pyflakes", "Command name or file path of \"pyflakes\" external command. If empty, pyflakes integration will be disabled")
-	flags.BoolVar(&opts.Oneline, "oneline", false, "Use one line per one error. Useful for reading error messages from programs")
-	flags.StringVar(&opts.Format, "format", "", "Custom template to format error messages in Go template syntax. See the usage documentation for more details")
-	flags.StringVar(&opts.ConfigFile, "config-file", "", "File path to config file")
-	flags.BoolVar(&initConfig, "init-config", false, "Generate default config file at .github/actionlint.yaml in current project")
-	flags.BoolVar(&noColor, "no-color", false, "Disable colorful output")
-	flags.BoolVar(&color, "color", false, "Always enable colorful output. This is useful to force colorful outputs")
-	flags.BoolVar(&opts.Verbose, "verbose", false, "Enable verbose output")
-	flags.BoolVar(&opts.Debug, "debug", false, "Enable debug output (for development)")
-	flags.BoolVar(&ver, "version", false, "Show version and how this binary was installed")
-	flags.StringVar(&opts.StdinFileName, "stdin-filename", "<stdin>", "File name when reading input from stdin")
+	flags.BoolVar(&write, "w", false, "Overwrite the files with the formatted ones instead of printing them to stdout")
+	flags.BoolVar(&list, "l", false, "List the files which are not formatted instead of printing the formatted ones. Exit status is 1 when some file is listed")
 	flags.Usage = func() {
-		printUsageHeader(cmd.Stderr)
+		printFormatUsageHeader(cmd.Stderr)
 		flags.PrintDefaults()
 	}
-	if err := flags.Parse(args[1:]); err != nil {
+	if err := flags.Parse(args[2:]); err != nil {
 		if err == flag.ErrHelp {
-			// When -h or -help
 			return ExitStatusSuccessNoProblem
 		}
 		return ExitStatusInvalidCommandOption
 	}
 
-	if ver {
-		fmt.Fprintf(
-			cmd.Stdout,
-			"%s\n%s\nbuilt with %s compiler for %s/%s\n",
-			getCommandVersion(),
-			installedFrom,
-			runtime.Version(),
-			runtime.GOOS,
-			runtime.GOARCH,
-		)
-		return ExitStatusSuccessNoProblem
+	unformatted, err := cmd.formatFiles(flags.Args(), write, list)
+	if err != nil {
+		fmt.Fprintln(cmd.Stderr, err.Error())
+		return ExitStatusFailure
+	}
+	if list && unformatted {
+		return ExitStatusSuccessProblemFound
 	}
+	return ExitStatusSuccessNoProblem
+}
 
-	opts.IgnorePatterns = ignorePats
-	opts.LogWriter = cmd.Stderr
+// upgradeFiles upgrades actions in the workflow files and returns whether some upgrade is available.
+func (cmd *Command) upgradeFiles(args []string, write bool) (bool, error) {
+	upgrade := func(path string, src []byte) ([]byte, error) {
+		out, us, err := UpgradeActions(src)
+		if err != nil {
+			return nil, fmt.Errorf("could not upgrade actions in %q: %w", path, err)
+		}
+		for _, u := range us {
+			fmt.Fprintf(cmd.Stderr, "%s:%d:%d: %s\n", path, u.Pos.Line, u.Pos.Col, u)
+		}
+		if !write {
+			io.WriteString(cmd.Stdout, unifiedDiff(filepath.ToSlash(path), src, out))
+		}
+		return out, nil
+	}
 
-	if color {
-		opts.Color = ColorOptionKindAlways
+	if len(args) == 1 && args[0] == "-" {
+		src, err := io.ReadAll(cmd.Stdin)
+		if err != nil {
+			return false, fmt.Errorf("could not read stdin: %w", err)
+		}
+		out, err := upgrade("<stdin>", src)
+		if err != nil {
+			return false, err
+		}
+		return !bytes.Equal(src, out), nil
 	}
-	if noColor {
-		opts.Color = ColorOptionKindNever
+
+	if len(args) == 0 {
+		fs, err := findWorkflowFilesInRepository()
+		if err != nil {
+			return false, err
+		}
+		args = fs
+	}
+
+	upgraded := false
+	for _, path := range args {
+		src, err := os.ReadFile(path)
+		if err != nil {
+			return false, fmt.Errorf("could not read %q: %w", path, err)
+		}
+		out, err := upgrade(path, src)
+		if err != nil {
+			return false, err
+		}
+		if bytes.Equal(src, out) {
+			continue
+		}
+		upgraded = true
+		if write {
+			info, err := os.Stat(path)
+			if err != nil {
+				return false, fmt.Errorf("could not get file info of %q: %w", path, err)
+			}
+			if err := os.WriteFile(path, out, info.Mode().Perm()); err != nil {
+				return false, fmt.Errorf("could not write upgraded workflow to %q: %w", path, err)
+			}
+		}
+	}
+	return upgraded, nil
+}
+
+// upgradeMain is main function of "actionlint upgrade" subcommand. The args should be entire
+// arguments including the program name.
+func (cmd *Command) upgradeMain(args []string) int {
+	var write bool
+
+	flags := flag.NewFlagSet(args[0]+" upgrade", flag.ContinueOnError)
+	flags.SetOutput(cmd.Stderr)
+	flags.BoolVar(&write, "w", false, "Overwrite the files with the upgraded ones instead of printing unified diff to stdout")
+	flags.Usage = func() {
+		printUpgradeUsageHeader(cmd.Stderr)
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args[2:]); err != nil {
+		if err == flag.ErrHelp {
+			return ExitStatusSuccessNoProblem
+		}
+		return ExitStatusInvalidCommandOption
 	}
 
-	errs, err := cmd.runLinter(flags.Args(), &opts, initConfig)
+	upgraded, err := cmd.upgradeFiles(flags.Args(), write)
 	if err != nil {
 		fmt.Fprintln(cmd.Stderr, err.Error())
 		return ExitStatusFailure
 	}
+	if !write && upgraded {
+		return ExitStatusSuccessProblemFound
+	}
+	return ExitStatusSuccessNoProblem
+}
+
+// dedupFiles finds the duplicated steps in the workflow files and reports them. When extract is
+// not 0, the duplicated steps of the cluster are extracted into the composite action. It returns
+// whether some duplication is found.
+func (cmd *Command) dedupFiles(args []string, min, extract int, name string, near, write bool) (bool, error) {
+	if len(args) == 0 {
+		fs, err := findWorkflowFilesInRepository()
+		if err != nil {
+			return false, err
+		}
+		args = fs
+	}
+
+	f := NewDuplicateStepsFinder(min)
+	f.SetNear(near)
+	for _, path := range args {
+		src, err := os.ReadFile(path)
+		if err != nil {
+			return false, fmt.Errorf("could not read %q: %w", path, err)
+		}
+		if err := f.Add(path, src); err != nil {
+			return false, err
+		}
+	}
+	ds := f.Find()
+
+	if extract == 0 {
+		for i, d := range ds {
+			ss := d.Summary()
+			for i, s := range ss {
+				ss[i] = strconv.Quote(s)
+			}
+			if ds := d.Differences(); len(ds) > 0 {
+				ns := make([]string, 0, len(ds))
+				for _, i := range ds {
+					ns = append(ns, strconv.Itoa(i+1))
+				}
+				differ := "step " + ns[0] + " differs"
+				if len(ns) > 1 {
+					differ = "steps " + strings.Join(ns, ", ") + " differ"
+				}
+				fmt.Fprintf(cmd.Stdout, "#%d: %d steps are nearly duplicated in %d places: %s (%s)\n", i+1, d.Len(), len(d.Locations), strings.Join(ss, ", "), differ)
+			} else {
+				fmt.Fprintf(cmd.Stdout, "#%d: %d steps are duplicated in %d places: %s\n", i+1, d.Len(), len(d.Locations), strings.Join(ss, ", "))
+			}
+			for _, l := range d.Locations {
+				p := l.Pos()
+				fmt.Fprintf(cmd.Stdout, "  %s:%d:%d: job %q\n", l.Path, p.Line, p.Col, l.Job.ID.Value)
+			}
+		}
+		return len(ds) > 0, nil
+	}
+
+	if extract < 0 || len(ds) < extract {
+		return false, fmt.Errorf("cluster #%d of duplicated steps was not found. %d clusters were found", extract, len(ds))
+	}
+	x, err := ds[extract-1].Extract(name)
+	if err != nil {
+		return false, err
+	}
+
+	first := ds[extract-1].Locations[0].Path
+	p, err := findProject(first)
+	if err != nil {
+		return false, err
+	}
+	if p == nil {
+		return false, fmt.Errorf("no project was found in any parent directories of %q to put the composite action", first)
+	}
+	action := filepath.Join(p.RootDir(), filepath.FromSlash(x.ActionPath))
+	if cwd, err := os.Getwd(); err == nil {
+		if r, err := filepath.Rel(cwd, action); err == nil {
+			action = r
+		}
+	}
+	if _, err := os.Stat(action); err == nil {
+		return false, fmt.Errorf("composite action %q already exists", action)
+	}
+
+	if !write {
+		d := unifiedDiff(filepath.ToSlash(action), nil, x.Action)
+		d = strings.Replace(d, "--- a/"+filepath.ToSlash(action), "--- /dev/null", 1) // New file
+		io.WriteString(cmd.Stdout, d)
+		for _, path := range args {
+			if b, ok := x.Workflows[path]; ok {
+				src, err := os.ReadFile(path)
+				if err != nil {
+					return false, fmt.Errorf("could not read %q: %w", path, err)
+				}
+				io.WriteString(cmd.Stdout, unifiedDiff(filepath.ToSlash(path), src, b))
+			}
+		}
+		return true, nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(action), 0755); err != nil {
+		return false, fmt.Errorf("could not create directory for composite action %q: %w", action, err)
+	}
+	if err := os.WriteFile(action, x.Action, 0644); err != nil {
+		return false, fmt.Errorf("could not write composite action to %q: %w", action, err)
+	}
+	for _, path := range args {
+		b, ok := x.Workflows[path]
+		if !ok {
+			continue
+		}
+		info, err := os.Stat(path)
+		if err != nil {
+			return false, fmt.Errorf("could not get file info of %q: %w", path, err)
+		}
+		if err := os.WriteFile(path, b, info.Mode().Perm()); err != nil {
+			return false, fmt.Errorf("could not write workflow to %q: %w", path, err)
+		}
+	}
+	fmt.Fprintf(cmd.Stderr, "extracted %d steps into composite action %q\n", x.Steps, action)
+	return true, nil
+}
+
+// dedupMain is main function of "actionlint dedup" subcommand. The args should be entire arguments
+// including the program name.
+func (cmd *Command) dedupMain(args []string) int {
+	var min int
+	var extract int
+	var name string
+	var near bool
+	var write bool
+
+	flags := flag.NewFlagSet(args[0]+" dedup", flag.ContinueOnError)
+	flags.SetOutput(cmd.Stderr)
+	flags.IntVar(&min, "min", DuplicateStepsMinLen, "Minimum number of steps in duplicated step sequences")
+	flags.IntVar(&extract, "extract", 0, "Number of the cluster of duplicated steps to extract into a composite action")
+	flags.StringVar(&name, "name", "", "Name of the composite action to extract the duplicated steps into. This flag is required with -extract flag")
+	flags.BoolVar(&near, "near", false, "Find near-identical step sequences which differ in details such as versions of actions, input values, and environment variable values")
+	flags.BoolVar(&write, "w", false, "Write the composite action and overwrite the workflow files instead of printing unified diff to stdout")
+	flags.Usage = func() {
+		printDedupUsageHeader(cmd.Stderr)
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args[2:]); err != nil {
+		if err == flag.ErrHelp {
+			return ExitStatusSuccessNoProblem
+		}
+		return ExitStatusInvalidCommandOption
+	}
+	if extract != 0 && name == "" {
+		fmt.Fprintln(cmd.Stderr, "-name flag is required with -extract flag")
+		return ExitStatusInvalidCommandOption
+	}
+	if extract == 0 && (name != "" || write) {
+		fmt.Fprintln(cmd.Stderr, "-name and -w flags are only available with -extract flag")
+		return ExitStatusInvalidCommandOption
+	}
+
+	found, err := cmd.dedupFiles(flags.Args(), min, extract, name, near, write)
+	if err != nil {
+		fmt.Fprintln(cmd.Stderr, err.Error())
+		return ExitStatusFailure
+	}
+	if !write && found {
+		return ExitStatusSuccessProblemFound
+	}
+	return ExitStatusSuccessNoProblem
+}
+
+// driftFiles reports the actions and the reusable workflows referenced at different refs across the
+// workflow files. It returns whether some drift is found.
+func (cmd *Command) driftFiles(args []string) (bool, error) {
+	if len(args) == 0 {
+		fs, err := findWorkflowFilesInRepository()
+		if err != nil {
+			return false, err
+		}
+		args = fs
+	}
+
+	f := NewRefDriftFinder()
+	for _, path := range args {
+		src, err := os.ReadFile(path)
+		if err != nil {
+			return false, fmt.Errorf("could not read %q: %w", path, err)
+		}
+		if err := f.Add(path, src); err != nil {
+			return false, err
+		}
+	}
+	ds := f.Find()
+
+	for _, d := range ds {
+		fmt.Fprintf(cmd.Stdout, "%s %q is referenced at %d different refs: %s\n", d.Kind(), d.Name, len(d.Versions), d.Summary())
+		for _, v := range d.Versions {
+			for _, l := range v.Locations {
+				fmt.Fprintf(cmd.Stdout, "  %s:%d:%d: %q\n", l.Path, l.Pos.Line, l.Pos.Col, v.Ref)
+			}
+		}
+	}
+	return len(ds) > 0, nil
+}
+
+// inventoryFiles writes the inventory of actions, reusable workflows, and Docker images used in the
+// workflow files and the local composite actions used by them. Files named action.yml or
+// action.yaml in arguments are read as action metadata files. It returns whether some unpinned item
+// is written when unpinned is true.
+func (cmd *Command) inventoryFiles(args []string, format InventoryFormat, unpinned bool) (bool, error) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return false, fmt.Errorf("could not get current working directory: %w", err)
+	}
+	if len(args) == 0 {
+		fs, err := findWorkflowFilesInRepository()
+		if err != nil {
+			return false, err
+		}
+		args = fs
+	}
+
+	inv := NewInventory()
+	for _, path := range args {
+		src, err := os.ReadFile(path)
+		if err != nil {
+			return false, fmt.Errorf("could not read %q: %w", path, err)
+		}
+		switch filepath.Base(path) {
+		case "action.yml", "action.yaml":
+			err = inv.AddAction(path, src)
+		default:
+			err = inv.AddWorkflow(path, src)
+		}
+		if err != nil {
+			return false, err
+		}
+	}
+
+	// Paths of local actions are relative to the root of the repository
+	root := cwd
+	if p, err := findProject(cwd); err == nil && p != nil {
+		root = p.RootDir()
+	}
+	done := map[string]struct{}{}
+	for {
+		added := false
+		for _, a := range inv.LocalActions() {
+			if _, ok := done[a]; ok {
+				continue
+			}
+			done[a] = struct{}{}
+			for _, f := range []string{"action.yml", "action.yaml"} {
+				path := filepath.Join(root, filepath.FromSlash(a), f)
+				src, err := os.ReadFile(path)
+				if err != nil {
+					continue
+				}
+				if r, err := filepath.Rel(cwd, path); err == nil {
+					path = r // Show relative paths in output
+				}
+				if err := inv.AddAction(path, src); err != nil {
+					return false, err
+				}
+				added = true
+				break
+			}
+		}
+		if !added {
+			break
+		}
+	}
+
+	if err := inv.Write(cmd.Stdout, format, unpinned); err != nil {
+		return false, err
+	}
+	return slices.ContainsFunc(inv.Items(), func(i *InventoryItem) bool { return !i.Pinned }), nil
+}
+
+// inventoryMain is main function of "actionlint inventory" subcommand. The args should be entire
+// arguments including the program name.
+func (cmd *Command) inventoryMain(args []string) int {
+	var format string
+	var unpinned bool
+
+	flags := flag.NewFlagSet(args[0]+" inventory", flag.ContinueOnError)
+	flags.SetOutput(cmd.Stderr)
+	flags.StringVar(&format, "format", "text", "Format of the inventory. \"text\" or \"json\"")
+	flags.BoolVar(&unpinned, "unpinned", false, "List only actions and Docker images which are not pinned to full commit SHAs or digests. Exit status is 1 when some item is listed")
+	flags.Usage = func() {
+		printInventoryUsageHeader(cmd.Stderr)
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args[2:]); err != nil {
+		if err == flag.ErrHelp {
+			return ExitStatusSuccessNoProblem
+		}
+		return ExitStatusInvalidCommandOption
+	}
+
+	f, err := ParseInventoryFormat(format)
+	if err != nil {
+		fmt.Fprintln(cmd.Stderr, err.Error())
+		return ExitStatusInvalidCommandOption
+	}
+
+	found, err := cmd.inventoryFiles(flags.Args(), f, unpinned)
+	if err != nil {
+		fmt.Fprintln(cmd.Stderr, err.Error())
+		return ExitStatusFailure
+	}
+	if unpinned && found {
+		return ExitStatusSuccessProblemFound
+	}
+	return ExitStatusSuccessNoProblem
+}
+
+// driftMain is main function of "actionlint drift" subcommand. The args should be entire arguments
+// including the program name.
+func (cmd *Command) driftMain(args []string) int {
+	flags := flag.NewFlagSet(args[0]+" drift", flag.ContinueOnError)
+	flags.SetOutput(cmd.Stderr)
+	flags.Usage = func() {
+		printDriftUsageHeader(cmd.Stderr)
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args[2:]); err != nil {
+		if err == flag.ErrHelp {
+			return ExitStatusSuccessNoProblem
+		}
+		return ExitStatusInvalidCommandOption
+	}
+
+	found, err := cmd.driftFiles(flags.Args())
+	if err != nil {
+		fmt.Fprintln(cmd.Stderr, err.Error())
+		return ExitStatusFailure
+	}
+	if found {
+		return ExitStatusSuccessProblemFound
+	}
+	return ExitStatusSuccessNoProblem
+}
+
+// readExprContext reads the mock values of contexts from JSON or YAML source. Numbers are converted
+// into float64 and keys of mappings are converted into strings as if the values were decoded from
+// JSON.
+func readExprContext(src []byte) (map[string]any, error) {
+	var v any
+	if err := yaml.Unmarshal(src, &v); err != nil {
+		return nil, fmt.Errorf("could not parse context values as JSON or YAML: %w", err)
+	}
+	if v == nil {
+		return map[string]any{}, nil
+	}
+	m, ok := normalizeExprContextValue(v).(map[string]any)
+	if !ok {
+		return nil, errors.New("context values must be a mapping from context names to their values")
+	}
+	return m, nil
+}
+
+func normalizeExprContextValue(v any) any {
+	switch v := v.(type) {
+	case int:
+		return float64(v)
+	case int64:
+		return float64(v)
+	case uint64:
+		return float64(v)
+	case float64, bool, string, nil:
+		return v
+	case []any:
+		a := make([]any, 0, len(v))
+		for _, e := range v {
+			a = append(a, normalizeExprContextValue(e))
+		}
+		return a
+	case map[string]any:
+		m := make(map[string]any, len(v))
+		for k, e := range v {
+			m[k] = normalizeExprContextValue(e)
+		}
+		return m
+	case map[any]any:
+		m := make(map[string]any, len(v))
+		for k, e := range v {
+			m[fmt.Sprint(k)] = normalizeExprContextValue(e)
+		}
+		return m
+	default:
+		return fmt.Sprint(v) // Timestamps and so on
+	}
+}
+
+// evalExpr type-checks the expression and evaluates it with the context values. When ctx is nil,
+// the expression is evaluated only when it is constant. It returns whether some error was found.
+func (cmd *Command) evalExpr(src string, ctx map[string]any) bool {
+	s := strings.TrimSpace(src)
+	if strings.HasPrefix(s, "${{") && strings.HasSuffix(s, "}}") {
+		s = strings.TrimSpace(s[3 : len(s)-2])
+	}
+
+	report := func(col int, msg string) {
+		fmt.Fprintf(cmd.Stderr, "%d: %s\n  %s\n  %s^\n", col, msg, s, strings.Repeat(" ", col-1))
+	}
+
+	l := expr.NewLexer(s + "}}") // }} is necessary since lexer lexes it as end of tokens
+	n, err := expr.NewParser().Parse(l)
+	l.Release()
+	if err != nil {
+		report(err.Column, err.Message)
+		return true
+	}
+
+	c := expr.NewSemanticsChecker(false, nil)
+	// The expression is not placed at any workflow key. Allow all contexts and special functions
+	ctxs := make([]string, 0, len(expr.BuiltinGlobalVariableTypes))
+	for n := range expr.BuiltinGlobalVariableTypes {
+		ctxs = append(ctxs, n)
+	}
+	c.SetContextAvailability(ctxs)
+	funcs := make([]string, 0, len(expr.SpecialFunctionNames))
+	for n := range expr.SpecialFunctionNames {
+		funcs = append(funcs, n)
+	}
+	c.SetSpecialFunctionAvailability(funcs)
+	// Contexts which depend on workflows are typed from the given values. Otherwise any property
+	// can be accessed.
+	for name, update := range map[string]func(*expr.ObjectType){
+		"matrix": c.UpdateMatrix,
+		"steps":  c.UpdateSteps,
+		"needs":  c.UpdateNeeds,
+		"inputs": c.UpdateInputs,
+		"jobs":   c.UpdateJobs,
+	} {
+		var ty *expr.ObjectType = expr.NewEmptyObjectType()
+		for k, v := range ctx {
+			if strings.EqualFold(k, name) {
+				if o, ok := expr.TypeOfValue(v).(*expr.ObjectType); ok {
+					ty = o
+				}
+			}
+		}
+		update(ty)
+	}
+
+	ty, errs := c.Check(n)
+	for _, err := range errs {
+		report(err.Column, err.Message)
+	}
+	if len(errs) > 0 {
+		return true
+	}
+	fmt.Fprintf(cmd.Stdout, "type: %s\n", ty.String())
+
+	if ctx == nil && !c.IsConstant(n) {
+		return false
+	}
+	v, verr := expr.Evaluate(n, ctx)
+	if verr != nil {
+		if e, ok := verr.(*expr.EvalError); ok {
+			report(e.Node.Token().Column, e.Message)
+		} else {
+			fmt.Fprintln(cmd.Stderr, verr.Error())
+		}
+		return true
+	}
+	b, merr := json.MarshalIndent(v, "", "  ")
+	if merr != nil {
+		fmt.Fprintln(cmd.Stderr, merr.Error())
+		return true
+	}
+	fmt.Fprintf(cmd.Stdout, "value: %s\n", b)
+	return false
+}
+
+// exprMain is main function of "actionlint expr" subcommand. The args should be entire arguments
+// including the program name.
+func (cmd *Command) exprMain(args []string) int {
+	var ctxPath string
+
+	flags := flag.NewFlagSet(args[0]+" expr", flag.ContinueOnError)
+	flags.SetOutput(cmd.Stderr)
+	flags.StringVar(&ctxPath, "context", "", "JSON or YAML file of mock context values to evaluate the expression. - reads stdin")
+	flags.Usage = func() {
+		printExprUsageHeader(cmd.Stderr)
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args[2:]); err != nil {
+		if err == flag.ErrHelp {
+			return ExitStatusSuccessNoProblem
+		}
+		return ExitStatusInvalidCommandOption
+	}
+	if flags.NArg() != 1 {
+		fmt.Fprintf(cmd.Stderr, "expr subcommand takes exactly one expression argument but got %d arguments\n", flags.NArg())
+		return ExitStatusInvalidCommandOption
+	}
+
+	var ctx map[string]any
+	if ctxPath != "" {
+		var src []byte
+		var err error
+		if ctxPath == "-" {
+			src, err = io.ReadAll(cmd.Stdin)
+		} else {
+			src, err = os.ReadFile(ctxPath)
+		}
+		if err != nil {
+			fmt.Fprintf(cmd.Stderr, "could not read context values: %s\n", err)
+			return ExitStatusFailure
+		}
+		ctx, err = readExprContext(src)
+		if err != nil {
+			fmt.Fprintln(cmd.Stderr, err.Error())
+			return ExitStatusFailure
+		}
+	}
+
+	if cmd.evalExpr(flags.Arg(0), ctx) {
+		return ExitStatusSuccessProblemFound
+	}
+	return ExitStatusSuccessNoProblem
+}
+
+// graphFiles outputs the dependency graph of the workflow files in the format.
+func (cmd *Command) graphFiles(args []string, format GraphFormat) error {
+	g := &Graph{Workflows: []*GraphWorkflow{}}
+	add := func(path string, src []byte) error {
+		w, errs := Parse(src)
+		if w == nil {
+			msg := "unknown error"
+			if len(errs) > 0 {
+				msg = errs[0].Error()
+			}
+			return fmt.Errorf("could not parse workflow %q: %s", path, msg)
+		}
+		g.Add(path, w)
+		return nil
+	}
+
+	if len(args) == 1 && args[0] == "-" {
+		src, err := io.ReadAll(cmd.Stdin)
+		if err != nil {
+			return fmt.Errorf("could not read stdin: %w", err)
+		}
+		if err := add("<stdin>", src); err != nil {
+			return err
+		}
+		return g.Write(cmd.Stdout, format)
+	}
+
+	if len(args) == 0 {
+		fs, err := findWorkflowFilesInRepository()
+		if err != nil {
+			return err
+		}
+		args = fs
+	}
+
+	for _, path := range args {
+		src, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("could not read %q: %w", path, err)
+		}
+		if err := add(path, src); err != nil {
+			return err
+		}
+	}
+	return g.Write(cmd.Stdout, format)
+}
+
+// graphMain is main function of "actionlint graph" subcommand. The args should be entire arguments
+// including the program name.
+func (cmd *Command) graphMain(args []string) int {
+	var format string
+
+	flags := flag.NewFlagSet(args[0]+" graph", flag.ContinueOnError)
+	flags.SetOutput(cmd.Stderr)
+	flags.StringVar(&format, "format", "dot", "Format of the graph. One of \"dot\", \"mermaid\", or \"json\"")
+	flags.Usage = func() {
+		printGraphUsageHeader(cmd.Stderr)
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args[2:]); err != nil {
+		if err == flag.ErrHelp {
+			return ExitStatusSuccessNoProblem
+		}
+		return ExitStatusInvalidCommandOption
+	}
+
+	f, err := ParseGraphFormat(format)
+	if err != nil {
+		fmt.Fprintln(cmd.Stderr, err.Error())
+		return ExitStatusInvalidCommandOption
+	}
+
+	if err := cmd.graphFiles(flags.Args(), f); err != nil {
+		fmt.Fprintln(cmd.Stderr, err.Error())
+		return ExitStatusFailure
+	}
+	return ExitStatusSuccessNoProblem
+}
+
+// schemaMain is main function of "actionlint schema" subcommand. The args should be entire
+// arguments including the program name.
+func (cmd *Command) schemaMain(args []string) int {
+	flags := flag.NewFlagSet(args[0]+" schema", flag.ContinueOnError)
+	flags.SetOutput(cmd.Stderr)
+	flags.Usage = func() {
+		printSchemaUsageHeader(cmd.Stderr)
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args[2:]); err != nil {
+		if err == flag.ErrHelp {
+			return ExitStatusSuccessNoProblem
+		}
+		return ExitStatusInvalidCommandOption
+	}
+	if flags.NArg() > 0 {
+		fmt.Fprintln(cmd.Stderr, "schema subcommand takes no argument")
+		return ExitStatusInvalidCommandOption
+	}
+
+	if err := WriteWorkflowSchema(cmd.Stdout); err != nil {
+		fmt.Fprintln(cmd.Stderr, err.Error())
+		return ExitStatusFailure
+	}
+	return ExitStatusSuccessNoProblem
+}
+
+// newWorkflow generates the starter workflow and writes it to the file at the path. When the path
+// is empty, the workflow is written to stdout.
+func (cmd *Command) newWorkflow(name string, params map[string]string, out string, pin bool) error {
+	w, ok := FindStarterWorkflow(name)
+	if !ok {
+		names := []string{}
+		for _, w := range StarterWorkflows() {
+			names = append(names, w.Name)
+		}
+		return fmt.Errorf("unknown starter workflow %q. available starter workflows are %s", name, quotes(names))
+	}
+
+	var f StarterWorkflowPinFunc
+	if pin {
+		token := os.Getenv("GITHUB_TOKEN")
+		if token == "" {
+			token = os.Getenv("GH_TOKEN")
+		}
+		r := NewRemoteRepository("", "", os.Getenv("GITHUB_API_URL"), token, "", cmd.Stderr, nil)
+		f = func(action, ref string) (string, error) {
+			owner, repo, _ := strings.Cut(action, "/")
+			return r.commitSHA(owner, repo, ref)
+		}
+	}
+
+	src, err := w.Generate(params, f)
+	if err != nil {
+		return err
+	}
+	if out == "" {
+		_, err := cmd.Stdout.Write(src)
+		return err
+	}
+
+	if _, err := os.Stat(out); err == nil {
+		return fmt.Errorf("file %q already exists", out)
+	}
+	if err := os.MkdirAll(filepath.Dir(out), 0755); err != nil {
+		return fmt.Errorf("could not create directory for %q: %w", out, err)
+	}
+	if err := os.WriteFile(out, src, 0644); err != nil {
+		return fmt.Errorf("could not write starter workflow to %q: %w", out, err)
+	}
+	return nil
+}
+
+// newMain is main function of "actionlint new" subcommand. The args should be entire arguments
+// including the program name.
+func (cmd *Command) newMain(args []string) int {
+	var list bool
+	var out string
+	var pin bool
+	params := starterWorkflowParamFlags{}
+
+	flags := flag.NewFlagSet(args[0]+" new", flag.ContinueOnError)
+	flags.SetOutput(cmd.Stderr)
+	flags.BoolVar(&list, "list", false, "List starter workflows and their parameters")
+	flags.Var(params, "set", "Parameter of the starter workflow in \"name=value\" format. This flag can be specified multiple times")
+	flags.StringVar(&out, "o", "", "File path to write the generated workflow. The workflow is written to stdout by default")
+	flags.BoolVar(&pin, "pin", false, "Pin actions to full commit SHAs resolved with GitHub API. API token is read from GITHUB_TOKEN or GH_TOKEN environment variable")
+	flags.Usage = func() {
+		printNewUsageHeader(cmd.Stderr)
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args[2:]); err != nil {
+		if err == flag.ErrHelp {
+			return ExitStatusSuccessNoProblem
+		}
+		return ExitStatusInvalidCommandOption
+	}
+
+	if list {
+		for _, w := range StarterWorkflows() {
+			fmt.Fprintf(cmd.Stdout, "%s: %s\n", w.Name, w.Description)
+			for _, p := range w.Params {
+				fmt.Fprintf(cmd.Stdout, "  -set %s=...: %s (default %q)\n", p.Name, p.Description, p.Default)
+			}
+		}
+		return ExitStatusSuccessNoProblem
+	}
+	if flags.NArg() != 1 {
+		fmt.Fprintln(cmd.Stderr, "new subcommand takes exactly one argument of starter workflow name. see -list flag for available starter workflows")
+		return ExitStatusInvalidCommandOption
+	}
+
+	if err := cmd.newWorkflow(flags.Arg(0), params, out, pin); err != nil {
+		fmt.Fprintln(cmd.Stderr, err.Error())
+		return ExitStatusFailure
+	}
+	return ExitStatusSuccessNoProblem
+}
+
+// writeRuleDocs renders the documentation of the rules enabled with the config file. When the
+// config file is empty, the config file of the project at the current directory is used.
+func (cmd *Command) writeRuleDocs(configFile, out, title string, builtin bool) error {
+	var cfg *Config
+	if configFile != "" {
+		c, err := ReadConfigFile(configFile)
+		if err != nil {
+			return err
+		}
+		cfg = c
+	} else {
+		cwd, err := os.Getwd()
+		if err != nil {
+			return fmt.Errorf("could not get current working directory: %w", err)
+		}
+		p, err := NewProjects().At(cwd)
+		if err != nil {
+			return err
+		}
+		if p != nil {
+			cfg = p.Config()
+		}
+	}
+
+	docs := RuleDocs(cfg)
+	if !builtin {
+		docs = slices.DeleteFunc(docs, func(d *RuleDoc) bool { return d.Builtin })
+	}
+
+	if out == "" {
+		return WriteRuleDocs(cmd.Stdout, title, docs)
+	}
+	var b bytes.Buffer
+	if err := WriteRuleDocs(&b, title, docs); err != nil {
+		return err
+	}
+	if err := os.WriteFile(out, b.Bytes(), 0644); err != nil {
+		return fmt.Errorf("could not write documentation of rules to %q: %w", out, err)
+	}
+	return nil
+}
+
+// docsMain is main function of "actionlint docs" subcommand. The args should be entire arguments
+// including the program name.
+func (cmd *Command) docsMain(args []string) int {
+	var configFile string
+	var out string
+	var title string
+	var builtin bool
+
+	flags := flag.NewFlagSet(args[0]+" docs", flag.ContinueOnError)
+	flags.SetOutput(cmd.Stderr)
+	flags.StringVar(&configFile, "config-file", "", "File path to config file. Plugins declared in it are documented. By default, the config file of the current repository is used")
+	flags.StringVar(&out, "o", "", "File path to write the documentation. The documentation is written to stdout by default")
+	flags.StringVar(&title, "title", "Rules", "Title of the documentation")
+	flags.BoolVar(&builtin, "builtin", true, "Include built-in rules. \"-builtin=false\" documents only custom rules and plugins")
+	flags.Usage = func() {
+		printDocsUsageHeader(cmd.Stderr)
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args[2:]); err != nil {
+		if err == flag.ErrHelp {
+			return ExitStatusSuccessNoProblem
+		}
+		return ExitStatusInvalidCommandOption
+	}
+	if flags.NArg() > 0 {
+		fmt.Fprintln(cmd.Stderr, "docs subcommand takes no argument")
+		return ExitStatusInvalidCommandOption
+	}
+
+	if err := cmd.writeRuleDocs(configFile, out, title, builtin); err != nil {
+		fmt.Fprintln(cmd.Stderr, err.Error())
+		return ExitStatusFailure
+	}
+	return ExitStatusSuccessNoProblem
+}
+
+type ignorePatternFlags []string
+
+func (i *ignorePatternFlags) String() string {
+	return "option for ignore patterns"
+}
+func (i *ignorePatternFlags) Set(v string) error {
+	*i = append(*i, v)
+	return nil
+}
+
+// starterWorkflowParamFlags is a flag for parameters of starter workflows in "name=value" format.
+type starterWorkflowParamFlags map[string]string
+
+func (p starterWorkflowParamFlags) String() string {
+	return "option for parameters of starter workflow"
+}
+func (p starterWorkflowParamFlags) Set(s string) error {
+	k, v, ok := strings.Cut(s, "=")
+	if !ok || k == "" {
+		return fmt.Errorf("parameter must be in \"name=value\" format but got %q", s)
+	}
+	p[k] = v
+	return nil
+}
+
+type allowEnvFlags []string
+
+func (a *allowEnvFlags) String() string {
+	return "option for names of environment variables"
+}
+func (a *allowEnvFlags) Set(v string) error {
+	*a = append(*a, v)
+	return nil
+}
+
+// perfFlag is a flag for the format of the performance report. It can be given without a value like
+// "-perf" to print the report as tables.
+type perfFlag string
+
+func (p *perfFlag) String() string {
+	return string(*p)
+}
+func (p *perfFlag) Set(v string) error {
+	switch v {
+	case "true", "table":
+		*p = "table"
+	case "false":
+		*p = ""
+	case "json":
+		*p = "json"
+	default:
+		return fmt.Errorf("invalid format of performance report %q. it must be \"table\" or \"json\"", v)
+	}
+	return nil
+}
+func (p *perfFlag) IsBoolFlag() bool {
+	return true
+}
+
+// byteSizeFlag is a flag for a size in bytes with an optional unit suffix like "512M" or "2G". Units
+// are powers of 1024.
+type byteSizeFlag uint64
+
+func (b *byteSizeFlag) String() string {
+	return strconv.FormatUint(uint64(*b), 10)
+}
+func (b *byteSizeFlag) Set(v string) error {
+	s := strings.TrimSuffix(strings.ToUpper(v), "B")
+	shift := 0
+	if s != "" {
+		switch s[len(s)-1] {
+		case 'K':
+			shift = 10
+		case 'M':
+			shift = 20
+		case 'G':
+			shift = 30
+		}
+	}
+	if shift > 0 {
+		s = s[:len(s)-1]
+	}
+	n, err := strconv.ParseUint(s, 10, 64)
+	if err != nil || n > math.MaxUint64>>shift {
+		return fmt.Errorf("invalid size %q. it must be a number of bytes optionally followed by a unit K, M, or G", v)
+	}
+	*b = byteSizeFlag(n << shift)
+	return nil
+}
+
+// applyDatasets applies the datasets cached in the default cache directory. When 'refresh' is true,
+// the datasets are downloaded before loading them. Failing to download or load the datasets is not
+// fatal since the embedded ones are still available. They are reported as warnings.
+func (cmd *Command) applyDatasets(refresh bool, dbg io.Writer) {
+	dir, err := DefaultDatasetCacheDir()
+	if err != nil {
+		fmt.Fprintf(cmd.Stderr, "warning: %s. embedded datasets are used\n", err)
+		return
+	}
+	c := NewDatasetCache(dir, dbg)
+
+	if refresh {
+		if err := c.Refresh(context.Background()); err != nil {
+			fmt.Fprintf(cmd.Stderr, "warning: could not refresh datasets: %s. cached or embedded datasets are used\n", err)
+		}
+	}
+
+	d, err := c.Load()
+	if err != nil {
+		fmt.Fprintf(cmd.Stderr, "warning: %s. embedded datasets are used\n", err)
+		return
+	}
+	d.Apply()
+}
+
+// Main is main function of actionlint. It takes command line arguments as string slice and returns
+// exit status. The args should be entire arguments including the program name, usually given via
+// os.Args.
+func (cmd *Command) Main(args []string) int {
+	if len(args) > 1 {
+		switch args[1] {
+		case "fmt":
+			return cmd.formatMain(args)
+		case "graph":
+			return cmd.graphMain(args)
+		case "upgrade":
+			return cmd.upgradeMain(args)
+		case "dedup":
+			return cmd.dedupMain(args)
+		case "drift":
+			return cmd.driftMain(args)
+		case "inventory":
+			return cmd.inventoryMain(args)
+		case "expr":
+			return cmd.exprMain(args)
+		case "schema":
+			return cmd.schemaMain(args)
+		case "new":
+			return cmd.newMain(args)
+		case "docs":
+			return cmd.docsMain(args)
+		}
+	}
+
+	var ver bool
+	var opts LinterOptions
+	var ignorePats ignorePatternFlags
+	var memLimit byteSizeFlag
+	var allowEnv allowEnvFlags
+	var initConfig bool
+	var noColor bool
+	var color bool
+	var githubChecks bool
+	var refreshDatasets bool
+	var cachedDatasets bool
+	var dataFile string
+	var compileDataFile string
+	var stdinBatch bool
+	var debugAddr string
+	var perf perfFlag
+
+	flags := flag.NewFlagSet(args[0], flag.ContinueOnError)
+	flags.SetOutput(cmd.Stderr)
+	flags.Var(&ignorePats, "ignore", "Regular expression matching to error messages you want to ignore. This flag is repeatable")
+	flags.StringVar(&opts.Shellcheck, "shellcheck", "shellcheck", "Command name or file path of \"shellcheck\" external command. If empty, shellcheck integration will be disabled")
+	flags.StringVar(&opts.Pyflakes, "pyflakes", "pyflakes", "Command name or file path of \"pyflakes\" external command. If empty, pyflakes integration will be disabled")
+	flags.StringVar(&opts.PSScriptAnalyzer, "psscriptanalyzer", "", "Command name or file path of \"pwsh\" or \"powershell\" to check PowerShell scripts with PSScriptAnalyzer module. If empty, PSScriptAnalyzer integration is disabled")
+	flags.StringVar(&opts.Hadolint, "hadolint", "", "Command name or file path of \"hadolint\" external command to check Dockerfile of Docker actions in action metadata files. If empty, hadolint integration is disabled")
+	flags.StringVar(&opts.Act, "act", "", "Command name or file path of \"act\" external command to run workflows in dry-run mode. If empty, act integration is disabled")
+	flags.StringVar(&opts.Zizmor, "zizmor", "", "Command name or file path of \"zizmor\" external command. Security findings of zizmor are merged into errors. If empty, zizmor integration is disabled")
+	flags.StringVar(&opts.ZizmorResults, "zizmor-results", "", "File path to JSON output of \"zizmor --format json\". Findings in the file are merged into errors instead of running zizmor")
+	flags.IntVar(&opts.CommandRetries, "command-retries", 2, "Maximum number of retries of an external command which failed to start due to a temporary shortage of OS resources like \"text file busy\". Failures of the command itself are not retried")
+	flags.IntVar(&opts.MaxProcesses, "max-procs", 0, "Maximum number of external command processes running at the same time. If zero, the number of CPUs is used")
+	flags.IntVar(&opts.Jobs, "jobs", 0, "Maximum number of workflow files checked at the same time. If zero, the number of CPUs is used")
+	flags.IntVar(&opts.RuleJobs, "rule-jobs", 0, "Number of goroutines to run rules in parallel while checking one workflow file. This reduces the latency of checking a very large workflow file. If zero or one, rules run serially")
+	flags.IntVar(&opts.ProcessNice, "nice", 0, "Niceness of external command processes from -20 to 19. Larger value means lower priority. If zero, the niceness is not changed")
+	flags.Var(&memLimit, "memory-limit", "Maximum size of virtual memory of each external command process like \"512M\" or \"2G\". This is only available on Linux. If zero, no limit is set")
+	flags.Var(&allowEnv, "allow-env", "Name of environment variable passed to external commands in addition to PATH, HOME, locale variables, and so on. Other variables are not passed not to leak secrets. A name ending with \"*\" matches the prefix and \"*\" passes all variables. This flag is repeatable")
+	flags.DurationVar(&opts.CommandTimeout, "command-timeout", 0, "Timeout of each external command execution such as shellcheck, pyflakes, and plugins like \"30s\". A command running longer is killed and linting fails. If zero, no timeout is set")
+	flags.BoolVar(&opts.Oneline, "oneline", false, "Use one line per one error. Useful for reading error messages from programs")
+	flags.StringVar(&opts.Format, "format", "", "Custom template to format error messages in Go template syntax. See the usage documentation for more details")
+	flags.StringVar(&opts.Locale, "locale", "", "Locale of error messages like \"ja\" or path to JSON file of message catalog. $ACTIONLINT_LOCALE is used when this flag is not specified. Rule names are not translated (default English)")
+	flags.StringVar(&opts.ConfigFile, "config-file", "", "File path to config file")
+	flags.BoolVar(&opts.TrustConfig, "trust-config", false, "Trust the config file and use plugins, containers, remotes, and credentials of Docker registries declared in it. They run arbitrary commands or send scripts and secrets to other hosts so use this flag only when you trust the repository")
+	flags.BoolVar(&initConfig, "init-config", false, "Generate default config file at .github/actionlint.yaml in current project")
+	flags.BoolVar(&noColor, "no-color", false, "Disable colorful output")
+	flags.BoolVar(&color, "color", false, "Always enable colorful output. This is useful to force colorful outputs")
+	flags.BoolVar(&opts.Verbose, "verbose", false, "Enable verbose output")
+	flags.BoolVar(&opts.Debug, "debug", false, "Enable debug output (for development)")
+	flags.StringVar(&opts.LogFormat, "log-format", "text", "Format of verbose and debug logs. \"text\" or \"json\"")
+	flags.Var(&perf, "perf", "Print time spent by each rule, each file, and external commands to stderr after checking files. \"-perf\" prints tables and \"-perf=json\" prints JSON")
+	flags.StringVar(&debugAddr, "debug-addr", "", "Address like \"localhost:6060\" to serve runtime metrics at /debug/metrics and profiles for \"go tool pprof\" at /debug/pprof/ while running. This is useful for inspecting a long run such as -stdin-batch")
+	flags.BoolVar(&ver, "version", false, "Show version and how this binary was installed")
+	flags.StringVar(&opts.StdinFileName, "stdin-filename", "<stdin>", "File name when reading input from stdin")
+	flags.BoolVar(&stdinBatch, "stdin-batch", false, "Read multiple files from stdin as JSON array of {\"path\": ..., \"content\": ...} objects and check them at once. The contents are used instead of the files on reading other files such as called reusable workflows")
+	flags.BoolVar(&opts.Online, "online", false, "Enable online checks using GitHub REST API. API token is read from GITHUB_TOKEN or GH_TOKEN environment variable")
+	flags.StringVar(&opts.CacheDir, "cache-dir", "", "Directory to cache data fetched over network in online checks such as responses of GitHub REST API. If empty, .git/actionlint/cache directory of the repository is used")
+	flags.DurationVar(&opts.CacheTTL, "cache-ttl", 0, "Duration while data cached by online checks is used without fetching it again like \"1h\". Expired data is still used when fetching it fails due to network errors or rate limit. If zero, 10 minutes is used")
+	flags.StringVar(&opts.GitHubRepository, "github-repo", "", "GitHub repository in \"owner/repo\" format for online checks. If empty, it is detected from \"origin\" remote")
+	flags.IntVar(&opts.OnlineRetries, "online-retries", 2, "Maximum number of retries of a request in online checks which failed due to network errors, server errors, or rate limit. Further requests to a host still unreachable after the retries are skipped")
+	flags.DurationVar(&opts.OnlineInterval, "online-interval", 0, "Minimum interval between requests in online checks like \"100ms\". If zero, requests are not throttled")
+	flags.BoolVar(&opts.Fix, "fix", false, "Fix errors automatically and overwrite the workflow files. Deprecated workflow commands are rewritten, typos in names are fixed, retired runner images are replaced, fixes suggested by shellcheck are applied, and third-party actions at \"uses:\" are pinned to commit SHAs with -online flag")
+	flags.BoolVar(&opts.Diff, "diff", false, "Print unified diff of fixes instead of overwriting the workflow files with -fix flag. Errors are not printed. Exit status is 1 when some fix is available")
+	flags.StringVar(&opts.GHESVersion, "ghes", "", "Version of GitHub Enterprise Server like \"3.12\". Features not available in the version are reported")
+	flags.StringVar(&opts.GHESURL, "ghes-url", "", "URL of GitHub Enterprise Server like \"https://github.example.com\" for online checks. API token is read from GH_ENTERPRISE_TOKEN or GITHUB_ENTERPRISE_TOKEN environment variable")
+	flags.BoolVar(&githubChecks, "github-checks", false, "Publish errors as annotations of a check run via GitHub Checks API. This is intended to be used on GitHub Actions. See the usage documentation for more details")
+	flags.StringVar(&opts.Platform, "platform", "", "Platform which runs workflows. One of \"github\", \"gitea\", or \"forgejo\". Checks are adjusted to the platform")
+	flags.BoolVar(&opts.Cache, "cache", false, "Cache errors of each workflow file in .git/actionlint/lint directory and skip checking unchanged files on subsequent runs")
+	flags.BoolVar(&refreshDatasets, "refresh-datasets", false, "Download the latest datasets of webhook events, runner labels, and popular actions into the cache directory and use them instead of the embedded ones")
+	flags.BoolVar(&cachedDatasets, "cached-datasets", false, "Use the datasets downloaded by -refresh-datasets flag previously instead of the embedded ones. The embedded ones are used when nothing is cached")
+	flags.StringVar(&dataFile, "data-file", "", "File path to the data file compiled by -compile-data-file flag. The config and the datasets compiled in it are used instead of loading them. This makes startup faster when checking a file at once from editors and Git hooks. An outdated data file is ignored with warning")
+	flags.StringVar(&compileDataFile, "compile-data-file", "", "Compile the config file and the datasets into the data file at the path for -data-file flag and exit. -config-file and -cached-datasets flags are respected")
+	flags.Usage = func() {
+		printUsageHeader(cmd.Stderr)
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args[1:]); err != nil {
+		if err == flag.ErrHelp {
+			// When -h or -help
+			return ExitStatusSuccessNoProblem
+		}
+		return ExitStatusInvalidCommandOption
+	}
+
+	if ver {
+		fmt.Fprintf(
+			cmd.Stdout,
+			"%s\n%s\nbuilt with %s compiler for %s/%s\n",
+			getCommandVersion(),
+			installedFrom,
+			runtime.Version(),
+			runtime.GOOS,
+			runtime.GOARCH,
+		)
+		return ExitStatusSuccessNoProblem
+	}
+
+	if stdinBatch {
+		if as := flags.Args(); len(as) > 1 || len(as) == 1 && as[0] != "-" {
+			fmt.Fprintln(cmd.Stderr, "file arguments cannot be specified with -stdin-batch flag")
+			return ExitStatusInvalidCommandOption
+		}
+	}
+
+	if dataFile != "" && (compileDataFile != "" || refreshDatasets) {
+		fmt.Fprintln(cmd.Stderr, "-data-file flag cannot be used with -compile-data-file and -refresh-datasets flags")
+		return ExitStatusInvalidCommandOption
+	}
+
+	if opts.Diff && !opts.Fix {
+		fmt.Fprintln(cmd.Stderr, "-diff flag is only available with -fix flag")
+		return ExitStatusInvalidCommandOption
+	}
+
+	if opts.GHESURL != "" && !opts.Online {
+		fmt.Fprintln(cmd.Stderr, "-ghes-url flag is only available with -online flag")
+		return ExitStatusInvalidCommandOption
+	}
+
+	opts.IgnorePatterns = ignorePats
+	opts.ProcessMemoryLimit = uint64(memLimit)
+	opts.AllowEnv = allowEnv
+	opts.LogWriter = cmd.Stderr
+	if opts.Locale == "" {
+		opts.Locale = os.Getenv("ACTIONLINT_LOCALE")
+	}
+
+	if opts.Online {
+		opts.GitHubToken = os.Getenv("GITHUB_TOKEN")
+		if opts.GitHubToken == "" {
+			opts.GitHubToken = os.Getenv("GH_TOKEN")
+		}
+		opts.GitHubAPIURL = os.Getenv("GITHUB_API_URL")
+		if opts.GHESURL != "" {
+			opts.GHESToken = os.Getenv("GH_ENTERPRISE_TOKEN")
+			if opts.GHESToken == "" {
+				opts.GHESToken = os.Getenv("GITHUB_ENTERPRISE_TOKEN")
+			}
+		}
+	}
+
+	if color {
+		opts.Color = ColorOptionKindAlways
+	}
+	if noColor {
+		opts.Color = ColorOptionKindNever
+	}
+
+	if dataFile != "" {
+		f, err := LoadDataFile(dataFile)
+		if err != nil {
+			fmt.Fprintf(cmd.Stderr, "warning: %s. config and datasets are loaded as usual\n", err)
+		} else {
+			f.Apply()
+			opts.DataFile = f
+		}
+	}
+
+	var datasetsDir string
+	if opts.DataFile == nil && (refreshDatasets || cachedDatasets) {
+		var dbg io.Writer
+		if opts.Debug {
+			dbg = cmd.Stderr
+		}
+		cmd.applyDatasets(refreshDatasets, dbg)
+		datasetsDir, _ = DefaultDatasetCacheDir()
+	}
+
+	if compileDataFile != "" {
+		srcs := &DataFileSources{ConfigFile: opts.ConfigFile, DatasetCacheDir: datasetsDir}
+		if err := CompileDataFile(compileDataFile, srcs); err != nil {
+			fmt.Fprintln(cmd.Stderr, err.Error())
+			return ExitStatusFailure
+		}
+		return ExitStatusSuccessNoProblem
+	}
+
+	var checks *gitHubChecksPublisher
+	if githubChecks && !initConfig {
+		var dbg io.Writer
+		if opts.Debug {
+			dbg = cmd.Stderr
+		}
+		p, err := newGitHubChecksPublisherFromEnv(dbg)
+		if err != nil {
+			fmt.Fprintln(cmd.Stderr, err.Error())
+			return ExitStatusInvalidCommandOption
+		}
+		checks = p
+	}
+
+	if debugAddr != "" {
+		opts.Metrics = NewMetrics()
+		s, err := startDebugServer(debugAddr, opts.Metrics)
+		if err != nil {
+			fmt.Fprintln(cmd.Stderr, err.Error())
+			return ExitStatusInvalidCommandOption
+		}
+		defer s.close()
+		fmt.Fprintln(cmd.Stderr, "Serving metrics and profiles at", s.url())
+	}
+
+	var report *perfReport
+	var start time.Time
+	if perf != "" {
+		report = newPerfReport()
+		report.setHooks(&opts)
+		start = time.Now()
+	}
+
+	var dbg io.Writer
+	if opts.Debug {
+		dbg = cmd.Stderr
+	}
+	tracer, err := newOTelTracerFromEnv(os.Getenv, getCommandVersion(), cmd.Stderr, dbg)
+	if err != nil {
+		fmt.Fprintf(cmd.Stderr, "warning: tracing with OpenTelemetry is disabled: %v\n", err)
+	}
+	if tracer != nil {
+		tracer.setHooks(&opts)
+	}
+
+	errs, fixable, err := cmd.runLinter(flags.Args(), &opts, initConfig, stdinBatch)
+	if tracer != nil {
+		tracer.shutdown(len(errs), err)
+	}
+	if err != nil {
+		fmt.Fprintln(cmd.Stderr, err.Error())
+		return ExitStatusFailure
+	}
+	if report != nil {
+		report.total = time.Since(start)
+		print := report.printTable
+		if perf == "json" {
+			print = report.printJSON
+		}
+		if err := print(cmd.Stderr); err != nil {
+			fmt.Fprintln(cmd.Stderr, err.Error())
+			return ExitStatusFailure
+		}
+	}
+	if checks != nil {
+		if err := checks.publish(errs); err != nil {
+			fmt.Fprintln(cmd.Stderr, err.Error())
+			return ExitStatusFailure
+		}
+	}
+	if opts.Diff {
+		if fixable {
+			return ExitStatusSuccessProblemFound // Some fix is available
+		}
+		return ExitStatusSuccessNoProblem
+	}
 	if len(errs) > 0 {
 		return ExitStatusSuccessProblemFound // Linter found some issues, yay!
 	}
@@ -2,10 +2,13 @@ package actionlint
 
 import (
 	"bytes"
+	"encoding/json"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
+
+	"github.com/google/go-cmp/cmp"
 )
 
 func TestCommandMain(t *testing.T) {
@@ -42,3 +45,703 @@ func TestCommandMain(t *testing.T) {
 		t.Errorf("runner-label rule should be ignored by -ignore but it is included in output: %q", out)
 	}
 }
+
+func TestCommandFormat(t *testing.T) {
+	src, err := os.ReadFile(filepath.Join("testdata", "fmt", "basic.yaml"))
+	if err != nil {
+		panic(err)
+	}
+	want, err := os.ReadFile(filepath.Join("testdata", "fmt", "basic.out"))
+	if err != nil {
+		panic(err)
+	}
+	f := filepath.Join(t.TempDir(), "test.yaml")
+	if err := os.WriteFile(f, src, 0644); err != nil {
+		panic(err)
+	}
+
+	run := func(args ...string) (int, string) {
+		var stdout, stderr bytes.Buffer
+		cmd := Command{
+			Stdin:  bytes.NewReader(src),
+			Stdout: &stdout,
+			Stderr: &stderr,
+		}
+		status := cmd.Main(append([]string{"actionlint", "fmt"}, args...))
+		if stderr.Len() > 0 {
+			t.Errorf("stderr is not empty for %v: %q", args, stderr.String())
+		}
+		return status, stdout.String()
+	}
+
+	if status, out := run(f); status != 0 || out != string(want) {
+		t.Fatalf("unexpected output of printing formatted workflow with status %d: %q", status, out)
+	}
+	if status, out := run("-"); status != 0 || out != string(want) {
+		t.Fatalf("unexpected output of formatting stdin with status %d: %q", status, out)
+	}
+	if status, out := run("-l", f); status != 1 || out != f+"\n" {
+		t.Fatalf("unexpected output of listing unformatted files with status %d: %q", status, out)
+	}
+	if status, out := run("-w", f); status != 0 || out != "" {
+		t.Fatalf("unexpected output of overwriting files with status %d: %q", status, out)
+	}
+	b, err := os.ReadFile(f)
+	if err != nil {
+		panic(err)
+	}
+	if string(b) != string(want) {
+		t.Fatalf("file was not overwritten with formatted workflow: %q", b)
+	}
+	if status, out := run("-l", f); status != 0 || out != "" {
+		t.Fatalf("unexpected output of listing formatted files with status %d: %q", status, out)
+	}
+}
+
+func TestCommandFormatError(t *testing.T) {
+	var output bytes.Buffer
+	cmd := Command{
+		Stdin:  os.Stdin,
+		Stdout: &output,
+		Stderr: &output,
+	}
+	f := filepath.Join("testdata", "examples", "broken_yaml.yaml")
+	if status := cmd.Main([]string{"actionlint", "fmt", f}); status != 3 {
+		t.Fatal("exit status should be 3 but got", status)
+	}
+	if out := output.String(); !strings.Contains(out, "could not format") {
+		t.Fatalf("unexpected error output: %q", out)
+	}
+}
+
+func TestCommandGraph(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	cmd := Command{
+		Stdin:  os.Stdin,
+		Stdout: &stdout,
+		Stderr: &stderr,
+	}
+	f := filepath.Join("testdata", "graph", "test.yaml")
+	if status := cmd.Main([]string{"actionlint", "graph", "-format", "mermaid", f}); status != 0 {
+		t.Fatalf("exit status should be 0 but got %d: %q", status, stderr.String())
+	}
+	if out := stdout.String(); !strings.HasPrefix(out, "flowchart LR\n") || !strings.Contains(out, "n0 --> n1") {
+		t.Fatalf("unexpected output: %q", out)
+	}
+
+	stderr.Reset()
+	if status := cmd.Main([]string{"actionlint", "graph", "-format", "svg", f}); status != 2 {
+		t.Fatal("exit status should be 2 but got", status)
+	}
+	if out := stderr.String(); !strings.Contains(out, "graph format must be one of") {
+		t.Fatalf("unexpected error output: %q", out)
+	}
+}
+
+func TestCommandSchema(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	cmd := Command{
+		Stdin:  os.Stdin,
+		Stdout: &stdout,
+		Stderr: &stderr,
+	}
+	if status := cmd.Main([]string{"actionlint", "schema"}); status != 0 {
+		t.Fatalf("exit status should be 0 but got %d: %q", status, stderr.String())
+	}
+	var s map[string]any
+	if err := json.Unmarshal(stdout.Bytes(), &s); err != nil {
+		t.Fatalf("output is not JSON: %v: %q", err, stdout.String())
+	}
+	if s["$schema"] != workflowSchemaDraft {
+		t.Fatalf("unexpected $schema: %v", s["$schema"])
+	}
+
+	if status := cmd.Main([]string{"actionlint", "schema", "foo.yaml"}); status != 2 {
+		t.Fatal("exit status should be 2 but got", status)
+	}
+	if out := stderr.String(); !strings.Contains(out, "schema subcommand takes no argument") {
+		t.Fatalf("unexpected error output: %q", out)
+	}
+}
+
+func TestCommandFixDiff(t *testing.T) {
+	src := "on: pul_request\njobs:\n  test:\n    runs-on: ubuntu-latest\n    steps:\n      - run: echo hello\n"
+	f := filepath.Join(t.TempDir(), "test.yaml")
+	if err := os.WriteFile(f, []byte(src), 0644); err != nil {
+		panic(err)
+	}
+
+	run := func(args ...string) (int, string, string) {
+		var stdout, stderr bytes.Buffer
+		cmd := Command{
+			Stdin:  strings.NewReader(src),
+			Stdout: &stdout,
+			Stderr: &stderr,
+		}
+		status := cmd.Main(append([]string{"actionlint", "-shellcheck=", "-pyflakes="}, args...))
+		return status, stdout.String(), stderr.String()
+	}
+
+	hunk := "@@ -1,4 +1,4 @@\n-on: pul_request\n+on: pull_request\n jobs:\n   test:\n     runs-on: ubuntu-latest\n"
+	if status, out, _ := run("-fix", "-diff", f); status != 1 || !strings.HasPrefix(out, "--- a/") || !strings.HasSuffix(out, "/test.yaml\n"+hunk) {
+		t.Fatalf("unexpected output of diff with status %d: %q", status, out)
+	}
+	b, err := os.ReadFile(f)
+	if err != nil {
+		panic(err)
+	}
+	if string(b) != src {
+		t.Fatalf("file was modified: %q", b)
+	}
+
+	want := "--- a/<stdin>\n+++ b/<stdin>\n" + hunk
+	if status, out, _ := run("-fix", "-diff", "-"); status != 1 || out != want {
+		t.Fatalf("unexpected output of diff for stdin with status %d: %q", status, out)
+	}
+
+	if err := os.WriteFile(f, []byte(strings.Replace(src, "pul_request", "pull_request", 1)), 0644); err != nil {
+		panic(err)
+	}
+	if status, out, _ := run("-fix", "-diff", f); status != 0 || out != "" {
+		t.Fatalf("unexpected output of diff without fix with status %d: %q", status, out)
+	}
+
+	if status, _, stderr := run("-diff", f); status != 2 || !strings.Contains(stderr, "-diff flag is only available with -fix flag") {
+		t.Fatalf("unexpected error output with status %d: %q", status, stderr)
+	}
+}
+
+func TestCommandUpgrade(t *testing.T) {
+	src, err := os.ReadFile(filepath.Join("testdata", "upgrade", "basic.yaml"))
+	if err != nil {
+		panic(err)
+	}
+	want, err := os.ReadFile(filepath.Join("testdata", "upgrade", "basic.out"))
+	if err != nil {
+		panic(err)
+	}
+	f := filepath.Join(t.TempDir(), "test.yaml")
+	if err := os.WriteFile(f, src, 0644); err != nil {
+		panic(err)
+	}
+
+	run := func(args ...string) (int, string, string) {
+		var stdout, stderr bytes.Buffer
+		cmd := Command{
+			Stdin:  bytes.NewReader(src),
+			Stdout: &stdout,
+			Stderr: &stderr,
+		}
+		status := cmd.Main(append([]string{"actionlint", "upgrade"}, args...))
+		return status, stdout.String(), stderr.String()
+	}
+
+	status, out, notes := run("-")
+	if status != 1 || !strings.HasPrefix(out, "--- a/<stdin>\n+++ b/<stdin>\n") || !strings.Contains(out, "\n-      - uses: actions/checkout@v3 # comment\n+      - uses: actions/checkout@v6 # comment\n") {
+		t.Fatalf("unexpected output of diff with status %d: %q", status, out)
+	}
+	if !strings.HasPrefix(notes, `<stdin>:7:15: upgrade "actions/checkout@v3" to "actions/checkout@v6"`+"\n") {
+		t.Fatalf("unexpected notes: %q", notes)
+	}
+
+	if status, out, _ := run("-w", f); status != 0 || out != "" {
+		t.Fatalf("unexpected output of overwriting files with status %d: %q", status, out)
+	}
+	b, err := os.ReadFile(f)
+	if err != nil {
+		panic(err)
+	}
+	if diff := cmp.Diff(string(want), string(b)); diff != "" {
+		t.Fatal(diff)
+	}
+
+	if status, out, notes := run(f); status != 0 || out != "" || notes != "" {
+		t.Fatalf("unexpected output of upgraded file with status %d: stdout=%q stderr=%q", status, out, notes)
+	}
+}
+
+func TestCommandDedup(t *testing.T) {
+	root := t.TempDir()
+	wfs := filepath.Join(root, ".github", "workflows")
+	for _, d := range []string{filepath.Join(root, ".git"), wfs} {
+		if err := os.MkdirAll(d, 0755); err != nil {
+			panic(err)
+		}
+	}
+	files := []string{}
+	for _, n := range []string{"ci.yaml", "release.yaml"} {
+		b, err := os.ReadFile(filepath.Join("testdata", "dedup", n))
+		if err != nil {
+			panic(err)
+		}
+		f := filepath.Join(wfs, n)
+		if err := os.WriteFile(f, b, 0644); err != nil {
+			panic(err)
+		}
+		files = append(files, f)
+	}
+
+	run := func(args ...string) (int, string, string) {
+		var stdout, stderr bytes.Buffer
+		cmd := Command{
+			Stdin:  nil,
+			Stdout: &stdout,
+			Stderr: &stderr,
+		}
+		status := cmd.Main(append(append([]string{"actionlint", "dedup"}, args...), files...))
+		return status, stdout.String(), stderr.String()
+	}
+
+	status, out, _ := run()
+	if status != 1 || !strings.HasPrefix(out, `#1: 3 steps are duplicated in 2 places: "actions/checkout@v4", "Set up Go", "go mod download"`+"\n") {
+		t.Fatalf("unexpected report with status %d: %q", status, out)
+	}
+
+	status, out, _ = run("-near")
+	if status != 1 || !strings.HasPrefix(out, `#1: 3 steps are nearly duplicated in 3 places: "actions/checkout@v4", "Install Go", "go mod download" (step 3 differs)`+"\n") {
+		t.Fatalf("unexpected report of near-identical steps with status %d: %q", status, out)
+	}
+	if status, _, stderr := run("-near", "-extract", "1", "-name", "setup"); status != 3 || !strings.Contains(stderr, "differs from the near-identical steps") {
+		t.Fatalf("unexpected error with status %d: %q", status, stderr)
+	}
+
+	status, out, _ = run("-extract", "1", "-name", "setup")
+	if status != 1 || !strings.Contains(out, "--- /dev/null\n") || !strings.Contains(out, "\n+      - uses: ./.github/actions/setup\n") {
+		t.Fatalf("unexpected diff with status %d: %q", status, out)
+	}
+
+	if status, _, stderr := run("-extract", "2", "-name", "setup"); status != 3 || !strings.Contains(stderr, "cluster #2 of duplicated steps was not found") {
+		t.Fatalf("unexpected error with status %d: %q", status, stderr)
+	}
+	if status, _, stderr := run("-extract", "1"); status != 2 || !strings.Contains(stderr, "-name flag is required") {
+		t.Fatalf("unexpected error with status %d: %q", status, stderr)
+	}
+
+	if status, out, _ := run("-extract", "1", "-name", "setup", "-w"); status != 0 || out != "" {
+		t.Fatalf("unexpected output of extraction with status %d: %q", status, out)
+	}
+	want, err := os.ReadFile(filepath.Join("testdata", "dedup", "extracted", "action.yml"))
+	if err != nil {
+		panic(err)
+	}
+	have, err := os.ReadFile(filepath.Join(root, ".github", "actions", "setup", "action.yml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(string(want), string(have)); diff != "" {
+		t.Fatal(diff)
+	}
+
+	// Only the checkout step and the step running the extracted action remain
+	if status, out, _ := run("-min", "3"); status != 0 || out != "" {
+		t.Fatalf("duplicates still exist after extraction with status %d: %q", status, out)
+	}
+}
+
+func TestCommandNew(t *testing.T) {
+	run := func(args ...string) (int, string, string) {
+		var stdout, stderr bytes.Buffer
+		cmd := Command{
+			Stdin:  os.Stdin,
+			Stdout: &stdout,
+			Stderr: &stderr,
+		}
+		status := cmd.Main(append([]string{"actionlint", "new"}, args...))
+		return status, stdout.String(), stderr.String()
+	}
+
+	status, out, stderr := run("-list")
+	if status != 0 {
+		t.Fatalf("exit status should be 0 but got %d: %q", status, stderr)
+	}
+	for _, w := range StarterWorkflows() {
+		if !strings.Contains(out, w.Name+": "+w.Description) {
+			t.Errorf("starter workflow %q is not listed: %q", w.Name, out)
+		}
+	}
+
+	status, out, stderr = run("-set", "go-version=1.24", "go-test")
+	if status != 0 {
+		t.Fatalf("exit status should be 0 but got %d: %q", status, stderr)
+	}
+	if !strings.Contains(out, "go-version: '1.24'") {
+		t.Fatalf("parameter was not applied: %q", out)
+	}
+
+	p := filepath.Join(t.TempDir(), ".github", "workflows", "release.yaml")
+	if status, _, stderr := run("-o", p, "release"); status != 0 {
+		t.Fatalf("exit status should be 0 but got %d: %q", status, stderr)
+	}
+	b, err := os.ReadFile(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(b), "generate_release_notes: true") {
+		t.Fatalf("unexpected workflow was written: %q", b)
+	}
+	if status, _, stderr := run("-o", p, "release"); status != 3 || !strings.Contains(stderr, "already exists") {
+		t.Fatalf("existing file should not be overwritten: %d %q", status, stderr)
+	}
+
+	if status, _, stderr := run("unknown"); status != 3 || !strings.Contains(stderr, `unknown starter workflow "unknown"`) {
+		t.Fatalf("unexpected result for unknown starter workflow: %d %q", status, stderr)
+	}
+	if status, _, stderr := run("-set", "foo", "go-test"); status != 2 || !strings.Contains(stderr, `"name=value" format`) {
+		t.Fatalf("unexpected result for invalid parameter: %d %q", status, stderr)
+	}
+	if status, _, _ := run(); status != 2 {
+		t.Fatal("exit status should be 2 but got", status)
+	}
+}
+
+func TestCommandDocs(t *testing.T) {
+	run := func(args ...string) (int, string, string) {
+		var stdout, stderr bytes.Buffer
+		cmd := Command{
+			Stdin:  os.Stdin,
+			Stdout: &stdout,
+			Stderr: &stderr,
+		}
+		status := cmd.Main(append([]string{"actionlint", "docs"}, args...))
+		return status, stdout.String(), stderr.String()
+	}
+
+	dir := t.TempDir()
+	cfg := filepath.Join(dir, "actionlint.yaml")
+	src := `plugins:
+  - name: org-policy
+    command: ./org-policy
+    docs:
+      description: Checks for the policies of our organization
+`
+	if err := os.WriteFile(cfg, []byte(src), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	status, out, stderr := run("-config-file", cfg)
+	if status != 0 {
+		t.Fatalf("exit status should be 0 but got %d: %q", status, stderr)
+	}
+	for _, want := range []string{"## `expression`", "## `org-policy`", "Checks for the policies of our organization"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("%q is not included in the documentation: %q", want, out)
+		}
+	}
+
+	p := filepath.Join(dir, "rules.md")
+	if status, _, stderr := run("-config-file", cfg, "-builtin=false", "-title", "Org rules", "-o", p); status != 0 {
+		t.Fatalf("exit status should be 0 but got %d: %q", status, stderr)
+	}
+	b, err := os.ReadFile(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	out = string(b)
+	if !strings.HasPrefix(out, "Org rules\n") || !strings.Contains(out, "## `org-policy`") || strings.Contains(out, "## `expression`") {
+		t.Fatalf("unexpected documentation was written: %q", out)
+	}
+
+	if status, _, _ := run("foo"); status != 2 {
+		t.Fatal("exit status should be 2 but got", status)
+	}
+}
+
+func TestCommandDrift(t *testing.T) {
+	files := []string{}
+	for _, n := range []string{"ci.yaml", "release.yaml", "nightly.yaml"} {
+		files = append(files, filepath.Join("testdata", "drift", n))
+	}
+
+	run := func(files ...string) (int, string, string) {
+		var stdout, stderr bytes.Buffer
+		cmd := Command{
+			Stdin:  nil,
+			Stdout: &stdout,
+			Stderr: &stderr,
+		}
+		status := cmd.Main(append([]string{"actionlint", "drift"}, files...))
+		return status, stdout.String(), stderr.String()
+	}
+
+	status, out, _ := run(files...)
+	want := `action "actions/checkout" is referenced at 2 different refs: "v4" (2 places), "v3" (1 place)` + "\n"
+	if status != 1 || !strings.HasPrefix(out, want) {
+		t.Fatalf("unexpected report with status %d: %q", status, out)
+	}
+	loc := "  " + filepath.Join("testdata", "drift", "release.yaml") + `:13:11: "main"` + "\n"
+	if !strings.HasSuffix(out, loc) {
+		t.Fatalf("location of reusable workflow call is not reported: %q", out)
+	}
+
+	if status, out, _ := run(files[0]); status != 0 || out != "" {
+		t.Fatalf("unexpected output with status %d: %q", status, out)
+	}
+
+	if status, _, stderr := run(filepath.Join("testdata", "drift", "not-existing.yaml")); status != 3 || !strings.Contains(stderr, "could not read") {
+		t.Fatalf("unexpected error with status %d: %q", status, stderr)
+	}
+}
+
+func TestCommandInventory(t *testing.T) {
+	files := []string{}
+	for _, n := range []string{"ci.yaml", "release.yaml", "nightly.yaml"} {
+		files = append(files, filepath.Join("testdata", "drift", n))
+	}
+
+	run := func(args ...string) (int, string, string) {
+		var stdout, stderr bytes.Buffer
+		cmd := Command{
+			Stdin:  nil,
+			Stdout: &stdout,
+			Stderr: &stderr,
+		}
+		status := cmd.Main(append([]string{"actionlint", "inventory"}, args...))
+		return status, stdout.String(), stderr.String()
+	}
+
+	status, out, stderr := run(files...)
+	if status != 0 {
+		t.Fatalf("exit status should be 0 but got %d: %q", status, stderr)
+	}
+	if !strings.HasPrefix(out, "Name ") || !strings.Contains(out, "docker://alpine") {
+		t.Fatalf("unexpected inventory: %q", out)
+	}
+
+	status, out, stderr = run(append([]string{"-unpinned", "-format", "json"}, files...)...)
+	if status != 1 {
+		t.Fatalf("exit status should be 1 but got %d: %q", status, stderr)
+	}
+	if !strings.Contains(out, `"name": "actions/checkout"`) || strings.Contains(out, `"kind": "local action"`) {
+		t.Fatalf("unexpected unpinned items: %q", out)
+	}
+
+	if status, _, stderr := run("-format", "csv"); status != 2 || !strings.Contains(stderr, "inventory format") {
+		t.Fatalf("unexpected result for invalid format: %d %q", status, stderr)
+	}
+	if status, _, stderr := run(filepath.Join("testdata", "drift", "not-existing.yaml")); status != 3 || !strings.Contains(stderr, "could not read") {
+		t.Fatalf("unexpected error with status %d: %q", status, stderr)
+	}
+}
+
+func TestCommandExpr(t *testing.T) {
+	run := func(stdin string, args ...string) (int, string, string) {
+		var stdout, stderr bytes.Buffer
+		cmd := Command{
+			Stdin:  strings.NewReader(stdin),
+			Stdout: &stdout,
+			Stderr: &stderr,
+		}
+		status := cmd.Main(append([]string{"actionlint", "expr"}, args...))
+		return status, stdout.String(), stderr.String()
+	}
+
+	status, out, stderr := run("", "${{ format('{0}-{1}', 'v', 1) }}")
+	if want := "type: string\nvalue: \"v-1\"\n"; status != 0 || out != want {
+		t.Fatalf("unexpected output with status %d: %q (stderr: %q)", status, out, stderr)
+	}
+
+	status, out, stderr = run("", "startsWith(github.ref, 'refs/tags/')")
+	if want := "type: bool\n"; status != 0 || out != want {
+		t.Fatalf("unexpected output with status %d: %q (stderr: %q)", status, out, stderr)
+	}
+
+	ctx := "github:\n  ref: refs/tags/v1\nmatrix:\n  node: [18, 20]\n"
+	status, out, stderr = run(ctx, "-context", "-", "startsWith(github.ref, 'refs/tags/') && matrix.node[1]")
+	if want := "type: any\nvalue: 20\n"; status != 0 || out != want {
+		t.Fatalf("unexpected output with status %d: %q (stderr: %q)", status, out, stderr)
+	}
+
+	status, _, stderr = run(ctx, "-context", "-", "matrix.os")
+	if status != 1 || !strings.Contains(stderr, `property "os" is not defined in object type {node: array<number>}`) {
+		t.Fatalf("unexpected error with status %d: %q", status, stderr)
+	}
+
+	if status, _, stderr := run("", "1 == 2 ||"); status != 1 || !strings.Contains(stderr, "unexpected end of input") {
+		t.Fatalf("unexpected error with status %d: %q", status, stderr)
+	}
+
+	if status, _, stderr := run("[1, 2]", "-context", "-", "1"); status != 3 || !strings.Contains(stderr, "must be a mapping") {
+		t.Fatalf("unexpected error with status %d: %q", status, stderr)
+	}
+
+	if status, _, _ := run(""); status != 2 {
+		t.Fatalf("unexpected status %d without expression", status)
+	}
+}
+
+func TestCommandCachedDatasets(t *testing.T) {
+	cache := t.TempDir()
+	t.Setenv("XDG_CACHE_HOME", cache)
+	t.Setenv("HOME", cache) // UserCacheDir() on macOS
+	dir, err := DefaultDatasetCacheDir()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	labels := `[{"label": "ubuntu-26.04", "os": "linux", "arch": "x64", "image": "ubuntu-26.04"}]`
+	if err := os.WriteFile(filepath.Join(dir, datasetRunnerLabelsFile), []byte(labels), 0644); err != nil {
+		t.Fatal(err)
+	}
+	testRestoreDatasets(t)
+
+	src := "on: push\njobs:\n  test:\n    runs-on: ubuntu-26.04\n    steps:\n      - run: echo\n"
+	var output bytes.Buffer
+	cmd := Command{
+		Stdin:  strings.NewReader(src),
+		Stdout: &output,
+		Stderr: &output,
+	}
+	if status := cmd.Main([]string{"actionlint", "-shellcheck=", "-pyflakes=", "-cached-datasets", "-"}); status != 0 {
+		t.Fatalf("exit status should be 0 but got %d: %q", status, output.String())
+	}
+}
+
+func TestCommandDataFile(t *testing.T) {
+	testRestoreDatasets(t)
+	dir := t.TempDir()
+	cfg := filepath.Join(dir, "actionlint.yaml")
+	if err := os.WriteFile(cfg, []byte("self-hosted-runner:\n  labels: [my-runner]\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	data := filepath.Join(dir, "actionlint.data")
+
+	var output bytes.Buffer
+	cmd := Command{Stdout: &output, Stderr: &output}
+	if status := cmd.Main([]string{"actionlint", "-config-file", cfg, "-compile-data-file", data}); status != 0 {
+		t.Fatalf("exit status should be 0 but got %d: %q", status, output.String())
+	}
+
+	src := "on: push\njobs:\n  test:\n    runs-on: my-runner\n    steps:\n      - run: echo\n"
+	cmd = Command{Stdin: strings.NewReader(src), Stdout: &output, Stderr: &output}
+	if status := cmd.Main([]string{"actionlint", "-shellcheck=", "-pyflakes=", "-data-file", data, "-"}); status != 0 {
+		t.Fatalf("exit status should be 0 but got %d: %q", status, output.String())
+	}
+
+	// Outdated data file is ignored and the config file is read as usual
+	if err := os.WriteFile(cfg, []byte("self-hosted-runner:\n  labels: [other-runner]\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	output.Reset()
+	cmd = Command{Stdin: strings.NewReader(src), Stdout: &output, Stderr: &output}
+	if status := cmd.Main([]string{"actionlint", "-shellcheck=", "-pyflakes=", "-config-file", cfg, "-data-file", data, "-"}); status != 1 {
+		t.Fatalf("exit status should be 1 but got %d: %q", status, output.String())
+	}
+	if out := output.String(); !strings.Contains(out, "is outdated since") || !strings.Contains(out, `label "my-runner" is unknown`) {
+		t.Fatalf("unexpected output: %q", out)
+	}
+
+	cmd = Command{Stdout: &output, Stderr: &output}
+	if status := cmd.Main([]string{"actionlint", "-data-file", data, "-compile-data-file", data}); status != ExitStatusInvalidCommandOption {
+		t.Fatalf("exit status should be %d but got %d", ExitStatusInvalidCommandOption, status)
+	}
+}
+
+func TestCommandStdinBatch(t *testing.T) {
+	in := `[{"path": "a.yaml", "content": "on: foo\njobs:\n  test:\n    runs-on: ubuntu-latest\n    steps:\n      - run: echo\n"}, {"path": "b.yaml", "content": "on: push\njobs:\n  test:\n    steps:\n      - run: echo\n"}]`
+	var stdout, stderr bytes.Buffer
+	cmd := Command{
+		Stdin:  strings.NewReader(in),
+		Stdout: &stdout,
+		Stderr: &stderr,
+	}
+	if status := cmd.Main([]string{"actionlint", "-shellcheck=", "-pyflakes=", "-stdin-batch", "-oneline", "-"}); status != 1 {
+		t.Fatalf("exit status should be 1 but got %d: %q", status, stderr.String())
+	}
+	out := stdout.String()
+	for _, s := range []string{"a.yaml:1:5: unknown Webhook event \"foo\"", "b.yaml:3:3: \"runs-on\" section is missing"} {
+		if !strings.Contains(out, s) {
+			t.Errorf("output should contain %q: %q", s, out)
+		}
+	}
+
+	stderr.Reset()
+	cmd.Stdin = strings.NewReader(in)
+	if status := cmd.Main([]string{"actionlint", "-stdin-batch", "a.yaml"}); status != 2 {
+		t.Fatalf("exit status should be 2 with file arguments but got %d", status)
+	}
+	if msg := stderr.String(); !strings.Contains(msg, "file arguments cannot be specified with -stdin-batch flag") {
+		t.Fatalf("unexpected error message: %q", msg)
+	}
+}
+
+func TestCommandByteSizeFlag(t *testing.T) {
+	testCases := []struct {
+		input string
+		want  uint64
+	}{
+		{"0", 0},
+		{"1024", 1024},
+		{"4k", 4 * 1024},
+		{"512M", 512 * 1024 * 1024},
+		{"2G", 2 * 1024 * 1024 * 1024},
+		{"2GB", 2 * 1024 * 1024 * 1024},
+	}
+	for _, tc := range testCases {
+		var b byteSizeFlag
+		if err := b.Set(tc.input); err != nil {
+			t.Fatalf("%q: %v", tc.input, err)
+		}
+		if uint64(b) != tc.want {
+			t.Errorf("%q: wanted %d but got %d", tc.input, tc.want, b)
+		}
+	}
+
+	for _, input := range []string{"", "M", "-1", "1T", "1.5G", "99999999999999999999G"} {
+		var b byteSizeFlag
+		if err := b.Set(input); err == nil {
+			t.Errorf("error did not occur for %q", input)
+		}
+	}
+}
+
+func TestCommandPerf(t *testing.T) {
+	workflow := filepath.Join("testdata", "examples", "main.yaml")
+
+	var stdout, stderr bytes.Buffer
+	cmd := Command{
+		Stdin:  os.Stdin,
+		Stdout: &stdout,
+		Stderr: &stderr,
+	}
+	if status := cmd.Main([]string{"actionlint", "-shellcheck=", "-pyflakes=", "-perf", workflow}); status != 1 {
+		t.Fatalf("exit status should be 1 but got %d: %q", status, stderr.String())
+	}
+	out := stderr.String()
+	for _, s := range []string{"Total time: ", "for 1 files", "\nRule ", "\nexpression ", "\nFile ", workflow} {
+		if !strings.Contains(out, s) {
+			t.Errorf("report should contain %q: %q", s, out)
+		}
+	}
+	if strings.Contains(stdout.String(), "Total time") {
+		t.Errorf("report should not be printed to stdout: %q", stdout.String())
+	}
+
+	stderr.Reset()
+	if status := cmd.Main([]string{"actionlint", "-shellcheck=", "-pyflakes=", "-perf=json", workflow}); status != 1 {
+		t.Fatalf("exit status should be 1 but got %d: %q", status, stderr.String())
+	}
+	var report struct {
+		Total int64        `json:"total_ns"`
+		Rules []*perfEntry `json:"rules"`
+		Files []*perfEntry `json:"files"`
+	}
+	if err := json.Unmarshal(stderr.Bytes(), &report); err != nil {
+		t.Fatalf("report is not JSON: %v: %q", err, stderr.String())
+	}
+	if report.Total <= 0 || len(report.Rules) == 0 {
+		t.Errorf("unexpected report: %q", stderr.String())
+	}
+	if len(report.Files) != 1 || report.Files[0].Name != workflow || report.Files[0].Errors == 0 {
+		t.Errorf("unexpected files in report: %q", stderr.String())
+	}
+
+	stderr.Reset()
+	if status := cmd.Main([]string{"actionlint", "-perf=csv", workflow}); status != 2 {
+		t.Fatalf("exit status should be 2 with invalid format but got %d", status)
+	}
+	if msg := stderr.String(); !strings.Contains(msg, `invalid format of performance report "csv"`) {
+		t.Fatalf("unexpected error message: %q", msg)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"path"
 	"path/filepath"
 	"regexp"
 	"strings"
@@ -12,6 +13,8 @@ import (
 	"go.yaml.in/yaml/v4"
 )
 
+var pluginNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9-]*$`)
+
 // IgnorePatterns is a list of regular expressions. These patterns are used for filtering errors by
 // matching the error messages.
 type IgnorePatterns []*regexp.Regexp
@@ -51,6 +54,134 @@ type PathConfig struct {
 	Ignore IgnorePatterns `yaml:"ignore"`
 }
 
+// TimeoutMinutesConfig is a configuration for the "timeout-minutes" rule. This is for the value of
+// the "timeout-minutes" mapping in the configuration file. The rule is enabled only when this
+// mapping exists.
+type TimeoutMinutesConfig struct {
+	// Max is the maximum value of "timeout-minutes:" allowed for jobs and steps. 0 means no maximum.
+	Max float64 `yaml:"max"`
+	// RunLines is the number of lines of a script at "run:". Steps whose scripts have the number of
+	// lines or more must set "timeout-minutes:". 0 means steps are not checked.
+	RunLines int `yaml:"run-lines"`
+}
+
+// YAMLStyleConfig is a configuration for the "yaml-style" rule. This is for the value of the
+// "yaml-style" mapping in the configuration file. The rule is enabled only when this mapping exists.
+type YAMLStyleConfig struct {
+	// Indent is the number of spaces of one indentation level. 0 means any number of spaces is
+	// allowed as long as the indentation is consistent in each workflow file.
+	Indent int `yaml:"indent"`
+	// ForbidAnchors is a flag to report YAML anchors and aliases.
+	ForbidAnchors bool `yaml:"forbid-anchors"`
+}
+
+// InlineScriptConfig is a configuration for the "inline-script" rule. This is for the value of the
+// "inline-script" mapping in the configuration file. The rule is enabled only when this mapping
+// exists.
+type InlineScriptConfig struct {
+	// MaxLines is the maximum number of lines of a script at "run:". 0 means no maximum.
+	MaxLines int `yaml:"max-lines"`
+	// MaxBytes is the maximum size of a script at "run:" in bytes. 0 means no maximum.
+	MaxBytes int `yaml:"max-bytes"`
+}
+
+// DeploymentEnvironmentConfig is a configuration for the "deployment-environment" rule. This is for
+// the value of the "deployment-environment" mapping in the configuration file. The rule is enabled
+// only when this mapping exists.
+type DeploymentEnvironmentConfig struct {
+	// Actions is names of actions which deploy something like "my-org/deploy-action" in addition to
+	// the well-known deploy actions. Refs after "@" should not be included.
+	Actions []string `yaml:"actions"`
+}
+
+// NamingConventionConfig is a configuration for the "naming-convention" rule. This is for the value
+// of the "naming-convention" mapping in the configuration file. The rule is enabled only when this
+// mapping exists. Each value is a regular expression which names must match. An empty value means
+// the names are not checked.
+type NamingConventionConfig struct {
+	// WorkflowName is a pattern of workflow names at "name:".
+	WorkflowName string `yaml:"workflow-name"`
+	// JobID is a pattern of job IDs.
+	JobID string `yaml:"job-id"`
+	// StepID is a pattern of step IDs at "id:".
+	StepID string `yaml:"step-id"`
+	// InputName is a pattern of input names of "workflow_dispatch" and "workflow_call" events.
+	InputName string `yaml:"input-name"`
+	// OutputName is a pattern of output names of jobs and "workflow_call" event.
+	OutputName string `yaml:"output-name"`
+}
+
+// OutdatedActionsConfig is a configuration for the "outdated-action" rule. This is for the value of
+// the "outdated-actions" mapping in the configuration file. The rule is enabled only when this
+// mapping exists and online checks are enabled.
+type OutdatedActionsConfig struct {
+	// MajorOnly is a flag to report only actions whose major versions are behind the latest ones.
+	// Actions behind only in minor or patch versions are not reported.
+	MajorOnly bool `yaml:"major-only"`
+	// Allow is patterns of actions which are not checked like "actions/checkout" or "my-org/*".
+	// Patterns are matched with action names without refs case-insensitively.
+	Allow []string `yaml:"allow"`
+}
+
+// DockerRegistryConfig is a configuration of credentials for a Docker registry. This is for values of
+// the "docker-registries" mapping in the configuration file.
+type DockerRegistryConfig struct {
+	// Username is a user name to log in to the registry.
+	Username string `yaml:"username"`
+	// PasswordEnv is a name of environment variable which has a password or an access token to log in
+	// to the registry. The password itself should not be written in the configuration file.
+	PasswordEnv string `yaml:"password-env"`
+}
+
+// PluginConfig is a configuration of an external plugin command which checks workflows. This is for
+// elements of the "plugins" array in the configuration file.
+type PluginConfig struct {
+	// Name is a name of the plugin. It is used as the rule name of errors reported by the plugin.
+	Name string `yaml:"name"`
+	// Command is a command to run the plugin. It can be a command name, a file path, or a command
+	// line with arguments. A relative file path starting with "./" or "../" is resolved from the
+	// root directory of the repository.
+	Command string `yaml:"command"`
+	// Container is a configuration to run the plugin command in a container. When this value is not
+	// nil, Command is run in the container and "command" of the container must be empty.
+	Container *ContainerConfig `yaml:"container"`
+	// Remote is a configuration to run the plugin command on a remote machine. When this value is not
+	// nil, Command is run on the remote machine and "command" of the remote must be empty.
+	Remote *RemoteConfig `yaml:"remote"`
+	// Persistent is a flag to keep running the plugin command across workflow files. Each input is
+	// written to stdin of the process as a single line of JSON and the process must write the output
+	// as a single line of JSON to stdout. The process should exit when stdin is closed.
+	Persistent bool `yaml:"persistent"`
+	// Docs is the documentation of the plugin rendered by "actionlint docs" subcommand. When this
+	// value is nil, only the name and the command of the plugin are documented.
+	Docs *RuleDoc `yaml:"docs"`
+}
+
+// ContainerConfig is a configuration to run an external command in a container image instead of
+// the command installed on the host.
+type ContainerConfig struct {
+	// Image is a container image to run like "koalaman/shellcheck:v0.10.0".
+	Image string `yaml:"image"`
+	// Runtime is a command of container runtime like "docker" or "podman". When this value is empty,
+	// "docker" is used.
+	Runtime string `yaml:"runtime"`
+	// Command is a command line run in the container. When this value is empty, the entrypoint of the
+	// image is run.
+	Command string `yaml:"command"`
+}
+
+// RemoteConfig is a configuration to run an external command on a remote machine via SSH instead of
+// the command installed on the host.
+type RemoteConfig struct {
+	// Host is a destination of SSH like "user@lint-farm.example.com" or a host name in ~/.ssh/config.
+	Host string `yaml:"host"`
+	// SSH is a command line of SSH client. When this value is empty, "ssh" is used.
+	SSH string `yaml:"ssh"`
+	// Command is a command line run on the remote machine. When this value is empty, the name of the
+	// external command like "shellcheck" is used.
+	Command string `yaml:"command"`
+}
+
 // Config is configuration of actionlint. This struct instance is parsed from "actionlint.yaml"
 // file usually put in ".github" directory.
 type Config struct {
@@ -58,15 +189,120 @@ type Config struct {
 	SelfHostedRunner struct {
 		// Labels is label names for self-hosted runner.
 		Labels []string `yaml:"labels"`
+		// Groups is names of runner groups available for the repository. When this value is nil, group
+		// names at "runs-on.group" will not be checked.
+		// https://docs.github.com/en/actions/hosting-your-own-runners/managing-self-hosted-runners/managing-access-to-self-hosted-runners-using-groups
+		Groups []string `yaml:"groups"`
 	} `yaml:"self-hosted-runner"`
 	// ConfigVariables is names of configuration variables used in the checked workflows. When this value is nil,
 	// property names of `vars` context will not be checked. Otherwise actionlint will report a name which is not
 	// listed here as undefined config variables.
 	// https://docs.github.com/en/actions/learn-github-actions/variables
 	ConfigVariables []string `yaml:"config-variables"`
+	// EnvironmentConfigVariables is names of configuration variables defined in each environment. Keys are
+	// environment names. Variables in an environment are only available in jobs which set the environment at
+	// "environment:". This value is used only when ConfigVariables is not nil.
+	// https://docs.github.com/en/actions/learn-github-actions/variables#creating-configuration-variables-for-an-environment
+	EnvironmentConfigVariables map[string][]string `yaml:"environment-config-variables"`
+	// Secrets is names of secrets available in the repository. When this value is nil, secrets
+	// inherited by reusable workflow calls with "secrets: inherit" will not be checked. Otherwise
+	// actionlint will report secrets required by the called workflows which are not listed here.
+	// https://docs.github.com/en/actions/security-for-github-actions/security-guides/using-secrets-in-github-actions
+	Secrets []string `yaml:"secrets"`
 	// Paths is a "paths" mapping in the configuration file. The keys are glob patterns to match file paths.
 	// And the values are corresponding configurations applied to the file paths.
 	Paths map[string]PathConfig `yaml:"paths"`
+	// TimeoutMinutes is a "timeout-minutes" mapping in the configuration file. When this value is nil,
+	// the "timeout-minutes" rule is disabled.
+	TimeoutMinutes *TimeoutMinutesConfig `yaml:"timeout-minutes"`
+	// YAMLStyle is a "yaml-style" mapping in the configuration file. When this value is nil, the
+	// "yaml-style" rule is disabled.
+	YAMLStyle *YAMLStyleConfig `yaml:"yaml-style"`
+	// InlineScript is an "inline-script" mapping in the configuration file. When this value is nil,
+	// the "inline-script" rule is disabled.
+	InlineScript *InlineScriptConfig `yaml:"inline-script"`
+	// DeploymentEnvironment is a "deployment-environment" mapping in the configuration file. When
+	// this value is nil, the "deployment-environment" rule is disabled.
+	DeploymentEnvironment *DeploymentEnvironmentConfig `yaml:"deployment-environment"`
+	// NamingConvention is a "naming-convention" mapping in the configuration file. When this value
+	// is nil, the "naming-convention" rule is disabled.
+	NamingConvention *NamingConventionConfig `yaml:"naming-convention"`
+	// OutdatedActions is an "outdated-actions" mapping in the configuration file. When this value is
+	// nil, the "outdated-action" rule is disabled.
+	OutdatedActions *OutdatedActionsConfig `yaml:"outdated-actions"`
+	// RequirePermissions is a flag to report workflows whose jobs don't set "permissions:" at both
+	// workflow level and job level. Default permissions of GITHUB_TOKEN may be broader than necessary.
+	RequirePermissions bool `yaml:"require-permissions"`
+	// OSCommands is a flag to report commands in "run:" scripts which are not available on the OS of
+	// the runner. The "os-command" rule is disabled unless this flag is true since it is heuristic.
+	OSCommands bool `yaml:"os-commands"`
+	// DuplicateStepNames is a flag to report steps sharing the same name in a job. The "step-name"
+	// rule is disabled unless this flag is true since duplicate step names are not errors.
+	DuplicateStepNames bool `yaml:"duplicate-step-names"`
+	// DockerRegistries is a "docker-registries" mapping in the configuration file. The keys are host
+	// names of Docker registries like "ghcr.io". The credentials are used for checking Docker images
+	// at "uses:" exist in online checks.
+	DockerRegistries map[string]*DockerRegistryConfig `yaml:"docker-registries"`
+	// GHES is a version of GitHub Enterprise Server which runs the workflows. When this value is not
+	// nil, features not available in the version are reported.
+	GHES *GHESVersion `yaml:"ghes"`
+	// Platform is a platform which runs the workflows. One of "github", "gitea", or "forgejo". When
+	// this value is empty, GitHub Actions is assumed.
+	Platform Platform `yaml:"platform"`
+	// Plugins is a "plugins" array in the configuration file. Each plugin command receives the parsed
+	// workflow as JSON via stdin and outputs errors as JSON to stdout.
+	Plugins []*PluginConfig `yaml:"plugins"`
+	// Containers is a "containers" mapping in the configuration file. The keys are names of external
+	// commands, "shellcheck" or "pyflakes", and the values are configurations to run the commands in
+	// containers instead of the commands installed on the host.
+	Containers map[string]*ContainerConfig `yaml:"containers"`
+	// Remotes is a "remotes" mapping in the configuration file. The keys are names of external
+	// commands, "shellcheck" or "pyflakes", and the values are configurations to run the commands on
+	// remote machines via SSH.
+	Remotes map[string]*RemoteConfig `yaml:"remotes"`
+	// ActionMetadata is file paths of action metadata files describing actions which are not in the
+	// popular actions data set such as private actions. Each file is in JSONL format generated by
+	// ./scripts/generate-popular-actions with `-f jsonl`. Relative paths are resolved from the root
+	// directory of the repository.
+	ActionMetadata []string `yaml:"action-metadata"`
+}
+
+// untrustedConfigKeys returns the keys in the config file which are ignored unless the config file is
+// trusted since they run arbitrary commands or send secrets to other hosts.
+func untrustedConfigKeys(cfg *Config) []string {
+	ks := []string{}
+	if len(cfg.Plugins) > 0 {
+		ks = append(ks, "plugins")
+	}
+	if len(cfg.Containers) > 0 {
+		ks = append(ks, "containers")
+	}
+	if len(cfg.Remotes) > 0 {
+		ks = append(ks, "remotes")
+	}
+	if len(cfg.DockerRegistries) > 0 {
+		ks = append(ks, "docker-registries")
+	}
+	return ks
+}
+
+// executor returns the executor and the command line to run the external command in a container or
+// on a remote machine. It returns false when the command is run on the host as usual.
+func (cfg *Config) executor(name string) (commandExecutor, string, bool) {
+	if cfg == nil {
+		return nil, "", false
+	}
+	if c, ok := cfg.Containers[name]; ok {
+		return newContainerExecutor(c), c.Command, true
+	}
+	if r, ok := cfg.Remotes[name]; ok {
+		cmd := r.Command
+		if cmd == "" {
+			cmd = name
+		}
+		return newSSHExecutor(r), cmd, true
+	}
+	return nil, "", false
 }
 
 // PathConfigs returns a list of all PathConfig values matching to the given file path. The path must
@@ -99,6 +335,116 @@ func ParseConfig(b []byte) (*Config, error) {
 			return nil, fmt.Errorf("invalid glob pattern %q in \"paths\"", pat)
 		}
 	}
+	if t := c.TimeoutMinutes; t != nil {
+		if t.Max < 0 {
+			return nil, fmt.Errorf("\"max\" in \"timeout-minutes\" must not be negative but got %g", t.Max)
+		}
+		if t.RunLines < 0 {
+			return nil, fmt.Errorf("\"run-lines\" in \"timeout-minutes\" must not be negative but got %d", t.RunLines)
+		}
+	}
+	if y := c.YAMLStyle; y != nil && y.Indent < 0 {
+		return nil, fmt.Errorf("\"indent\" in \"yaml-style\" must not be negative but got %d", y.Indent)
+	}
+	if i := c.InlineScript; i != nil {
+		if i.MaxLines < 0 {
+			return nil, fmt.Errorf("\"max-lines\" in \"inline-script\" must not be negative but got %d", i.MaxLines)
+		}
+		if i.MaxBytes < 0 {
+			return nil, fmt.Errorf("\"max-bytes\" in \"inline-script\" must not be negative but got %d", i.MaxBytes)
+		}
+	}
+	if n := c.NamingConvention; n != nil {
+		for _, p := range []struct{ key, pat string }{
+			{"workflow-name", n.WorkflowName},
+			{"job-id", n.JobID},
+			{"step-id", n.StepID},
+			{"input-name", n.InputName},
+			{"output-name", n.OutputName},
+		} {
+			if _, err := regexp.Compile(p.pat); err != nil {
+				return nil, fmt.Errorf("invalid regular expression %q at %q in \"naming-convention\": %w", p.pat, p.key, err)
+			}
+		}
+	}
+	if o := c.OutdatedActions; o != nil {
+		for _, pat := range o.Allow {
+			if _, err := path.Match(pat, ""); err != nil {
+				return nil, fmt.Errorf("invalid pattern %q at \"allow\" in \"outdated-actions\": %w", pat, err)
+			}
+		}
+	}
+	names := map[string]struct{}{}
+	for i, p := range c.Plugins {
+		if p == nil || p.Name == "" {
+			return nil, fmt.Errorf("\"name\" is missing in plugin #%d in \"plugins\"", i+1)
+		}
+		if !pluginNamePattern.MatchString(p.Name) {
+			return nil, fmt.Errorf("invalid plugin name %q in \"plugins\". it must consist of lower case alphabets, digits, and '-'", p.Name)
+		}
+		if _, ok := names[p.Name]; ok {
+			return nil, fmt.Errorf("plugin name %q is duplicated in \"plugins\"", p.Name)
+		}
+		names[p.Name] = struct{}{}
+		if p.Command == "" {
+			return nil, fmt.Errorf("\"command\" is missing in plugin %q in \"plugins\"", p.Name)
+		}
+		if c := p.Container; c != nil {
+			if c.Image == "" {
+				return nil, fmt.Errorf("\"image\" is missing in \"container\" of plugin %q in \"plugins\"", p.Name)
+			}
+			if c.Command != "" {
+				return nil, fmt.Errorf("\"command\" in \"container\" of plugin %q must be empty. \"command\" of the plugin is run in the container", p.Name)
+			}
+		}
+		if r := p.Remote; r != nil {
+			if p.Container != nil {
+				return nil, fmt.Errorf("both \"container\" and \"remote\" are set in plugin %q", p.Name)
+			}
+			if r.Host == "" {
+				return nil, fmt.Errorf("\"host\" is missing in \"remote\" of plugin %q in \"plugins\"", p.Name)
+			}
+			if r.Command != "" {
+				return nil, fmt.Errorf("\"command\" in \"remote\" of plugin %q must be empty. \"command\" of the plugin is run on the remote machine", p.Name)
+			}
+		}
+		if d := p.Docs; d != nil {
+			for j, o := range d.Options {
+				if o == nil || o.Name == "" {
+					return nil, fmt.Errorf("\"name\" is missing in option #%d in \"docs\" of plugin %q", j+1, p.Name)
+				}
+			}
+			for j, e := range d.Examples {
+				if e == nil || e.Workflow == "" {
+					return nil, fmt.Errorf("\"workflow\" is missing in example #%d in \"docs\" of plugin %q", j+1, p.Name)
+				}
+			}
+		}
+	}
+	for n, c := range c.Containers {
+		if n != "shellcheck" && n != "pyflakes" {
+			return nil, fmt.Errorf("unknown command %q in \"containers\". it must be \"shellcheck\" or \"pyflakes\"", n)
+		}
+		if c == nil || c.Image == "" {
+			return nil, fmt.Errorf("\"image\" is missing in %q in \"containers\"", n)
+		}
+	}
+	for n, r := range c.Remotes {
+		if n != "shellcheck" && n != "pyflakes" {
+			return nil, fmt.Errorf("unknown command %q in \"remotes\". it must be \"shellcheck\" or \"pyflakes\"", n)
+		}
+		if _, ok := c.Containers[n]; ok {
+			return nil, fmt.Errorf("%q is set in both \"containers\" and \"remotes\"", n)
+		}
+		if r == nil || r.Host == "" {
+			return nil, fmt.Errorf("\"host\" is missing in %q in \"remotes\"", n)
+		}
+	}
+	for i, p := range c.ActionMetadata {
+		if p == "" {
+			return nil, fmt.Errorf("file path #%d in \"action-metadata\" must not be empty", i+1)
+		}
+	}
 	return &c, nil
 }
 
@@ -137,12 +483,26 @@ func writeDefaultConfigFile(path string) error {
 	b := []byte(`self-hosted-runner:
   # Labels of self-hosted runner in array of strings.
   labels: []
+  # Runner groups available for the repository in array of strings. ` + "`null`" + `
+  # means disabling runner group names check.
+  groups: null
 
 # Configuration variables in array of strings defined in your repository or
 # organization. ` + "`null`" + ` means disabling configuration variables check.
 # Empty array means no configuration variable is allowed.
 config-variables: null
 
+# Configuration variables defined in each environment. The keys are environment
+# names and the values are variable names in array of strings. Variables in an
+# environment are only available in jobs which set the environment at
+# "environment:". This is used only when "config-variables" is set.
+environment-config-variables: null
+
+# Secrets in array of strings defined in your repository or organization. They
+# are used for checking secrets inherited by reusable workflow calls with
+# "secrets: inherit". ` + "`null`" + ` means disabling the check.
+secrets: null
+
 # Configuration for file paths. The keys are glob patterns to match to file
 # paths relative to the repository root. The values are the configurations for
 # the file paths. Note that the path separator is always '/'.
@@ -153,6 +513,87 @@ config-variables: null
 paths:
 #  .github/workflows/**/*.yml:
 #    ignore: []
+
+# Uncomment to require "timeout-minutes:" for jobs. "max" is the maximum value
+# allowed for "timeout-minutes:" (0 means no maximum). Steps whose "run:"
+# scripts have "run-lines" lines or more also require "timeout-minutes:" (0
+# means steps are not checked).
+#timeout-minutes:
+#  max: 0
+#  run-lines: 0
+
+# Uncomment to check style of YAML such as indentation and unquoted booleans like
+# "yes". "indent" is the number of spaces of one indentation level (0 means it
+# only needs to be consistent in each file). "forbid-anchors" reports anchors
+# and aliases.
+#yaml-style:
+#  indent: 0
+#  forbid-anchors: false
+
+# Uncomment to report large scripts at "run:" which should be moved to script
+# files. "max-lines" is the maximum number of lines and "max-bytes" is the
+# maximum size in bytes of a script (0 means no maximum).
+#inline-script:
+#  max-lines: 50
+#  max-bytes: 0
+
+# Uncomment to require "environment:" for jobs which look deploying something
+# such as jobs using well-known deploy actions or steps named "deploy". "actions"
+# is an array of your own deploy actions like "my-org/deploy-action".
+#deployment-environment:
+#  actions: []
+
+# Uncomment to check names follow the naming convention of your organization.
+# Each value is a regular expression which names must match. An empty value
+# means the names are not checked.
+#naming-convention:
+#  workflow-name: ""
+#  job-id: "^[a-z][a-z0-9-]*$"
+#  step-id: "^[a-z][a-z0-9-]*$"
+#  input-name: "^[a-z][a-z0-9_]*$"
+#  output-name: "^[a-z][a-z0-9_]*$"
+
+# Uncomment to require "permissions:" at workflow level or job level. Default
+# permissions of GITHUB_TOKEN may be broader than necessary.
+#require-permissions: true
+
+# Uncomment to report commands in "run:" scripts which are not available on the
+# OS of the runner like "apt-get" on Windows. This check is heuristic.
+#os-commands: true
+
+# Uncomment to report steps sharing the same name in a job. They are hard to
+# distinguish in logs of the workflow run.
+#duplicate-step-names: true
+
+# Credentials of Docker registries used for checking Docker images at "uses:"
+# exist in online checks. The keys are host names of the registries. The
+# password or access token is read from the environment variable specified by
+# "password-env".
+#docker-registries:
+#  ghcr.io:
+#    username: user
+#    password-env: GHCR_TOKEN
+
+# Uncomment to check workflows are compatible with the version of GitHub
+# Enterprise Server. Features not available in the version are reported.
+#ghes: "3.12"
+
+# Uncomment to check workflows for Gitea Actions or Forgejo Actions. Checks are
+# adjusted to their deviations from GitHub Actions.
+#platform: gitea
+
+# Uncomment to check workflows with external plugin commands. Each command
+# receives the parsed workflow as JSON via stdin and outputs errors as JSON to
+# stdout. "name" is used as the rule name of the errors.
+#plugins:
+#  - name: my-rule
+#    command: ./scripts/actionlint-plugin
+
+# Uncomment to check inputs and outputs of private actions. Each file describes
+# metadata of actions in JSONL format generated by generate-popular-actions
+# script. A relative path is resolved from the repository root.
+#action-metadata:
+#  - ./.github/actions-metadata.jsonl
 `)
 	if err := os.WriteFile(path, b, 0644); err != nil {
 		return fmt.Errorf("could not write default configuration file at %q: %w", path, err)
@@ -56,6 +56,33 @@ func TestConfigParseSelfHostedRunnerOK(t *testing.T) {
 	}
 }
 
+func TestConfigParseGHESVersion(t *testing.T) {
+	for _, in := range []string{`ghes: "3.10"`, `ghes: 3.10`, `ghes: 3.10.2`} {
+		t.Run(in, func(t *testing.T) {
+			c, err := ParseConfig([]byte(in))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if c.GHES == nil {
+				t.Fatal("GHES version was not parsed")
+			}
+			if s := c.GHES.String(); s != "3.10" {
+				t.Fatalf("wanted version 3.10 but got %s", s)
+			}
+		})
+	}
+}
+
+func TestConfigParsePlatform(t *testing.T) {
+	c, err := ParseConfig([]byte(`platform: forgejo`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Platform != PlatformForgejo {
+		t.Fatalf("wanted platform %q but got %q", PlatformForgejo, c.Platform)
+	}
+}
+
 func TestConfigParseError(t *testing.T) {
 	tests := []struct {
 		in   string
@@ -88,6 +115,204 @@ paths:
 `,
 			want: `invalid glob pattern`,
 		},
+		{
+			in: `
+timeout-minutes:
+  max: -1
+`,
+			want: `"max" in "timeout-minutes" must not be negative`,
+		},
+		{
+			in: `
+timeout-minutes:
+  run-lines: -1
+`,
+			want: `"run-lines" in "timeout-minutes" must not be negative`,
+		},
+		{
+			in: `
+yaml-style:
+  indent: -2
+`,
+			want: `"indent" in "yaml-style" must not be negative`,
+		},
+		{
+			in: `
+inline-script:
+  max-lines: -1
+`,
+			want: `"max-lines" in "inline-script" must not be negative`,
+		},
+		{
+			in: `
+inline-script:
+  max-bytes: -1
+`,
+			want: `"max-bytes" in "inline-script" must not be negative`,
+		},
+		{
+			in: `
+naming-convention:
+  job-id: '^[a-z'
+`,
+			want: `invalid regular expression "^[a-z" at "job-id" in "naming-convention"`,
+		},
+		{
+			in: `
+outdated-actions:
+  allow: ['my-org/[']
+`,
+			want: `invalid pattern "my-org/[" at "allow" in "outdated-actions"`,
+		},
+		{
+			in:   `ghes: latest`,
+			want: `invalid "ghes" at line:1,col:7`,
+		},
+		{
+			in:   `ghes: [3, 12]`,
+			want: `"ghes" must be a string like "3.12"`,
+		},
+		{
+			in:   `platform: gitlab`,
+			want: `invalid "platform" at line:1,col:11`,
+		},
+		{
+			in: `
+plugins:
+  - command: ./plugin
+`,
+			want: `"name" is missing in plugin #1 in "plugins"`,
+		},
+		{
+			in: `
+plugins:
+  - name: My_Rule
+    command: ./plugin
+`,
+			want: `invalid plugin name "My_Rule" in "plugins"`,
+		},
+		{
+			in: `
+plugins:
+  - name: foo
+    command: ./foo
+  - name: foo
+    command: ./bar
+`,
+			want: `plugin name "foo" is duplicated in "plugins"`,
+		},
+		{
+			in: `
+plugins:
+  - name: foo
+`,
+			want: `"command" is missing in plugin "foo" in "plugins"`,
+		},
+		{
+			in: `
+plugins:
+  - name: foo
+    command: ./foo
+    container:
+      runtime: podman
+`,
+			want: `"image" is missing in "container" of plugin "foo" in "plugins"`,
+		},
+		{
+			in: `
+plugins:
+  - name: foo
+    command: ./foo
+    container:
+      image: foo
+      command: ./bar
+`,
+			want: `"command" in "container" of plugin "foo" must be empty`,
+		},
+		{
+			in: `
+plugins:
+  - name: foo
+    command: ./foo
+    remote:
+      ssh: ssh -p 2222
+`,
+			want: `"host" is missing in "remote" of plugin "foo" in "plugins"`,
+		},
+		{
+			in: `
+plugins:
+  - name: foo
+    command: ./foo
+    remote:
+      host: lint-farm
+      command: ./bar
+`,
+			want: `"command" in "remote" of plugin "foo" must be empty`,
+		},
+		{
+			in: `
+plugins:
+  - name: foo
+    command: ./foo
+    container:
+      image: foo
+    remote:
+      host: lint-farm
+`,
+			want: `both "container" and "remote" are set in plugin "foo"`,
+		},
+		{
+			in: `
+remotes:
+  yamllint:
+    host: lint-farm
+`,
+			want: `unknown command "yamllint" in "remotes"`,
+		},
+		{
+			in: `
+remotes:
+  pyflakes:
+    command: pyflakes
+`,
+			want: `"host" is missing in "pyflakes" in "remotes"`,
+		},
+		{
+			in: `
+containers:
+  shellcheck:
+    image: koalaman/shellcheck
+remotes:
+  shellcheck:
+    host: lint-farm
+`,
+			want: `"shellcheck" is set in both "containers" and "remotes"`,
+		},
+		{
+			in: `
+containers:
+  yamllint:
+    image: foo
+`,
+			want: `unknown command "yamllint" in "containers"`,
+		},
+		{
+			in: `
+containers:
+  shellcheck:
+    command: shellcheck
+`,
+			want: `"image" is missing in "shellcheck" in "containers"`,
+		},
+		{
+			in: `
+action-metadata:
+  - ./foo.jsonl
+  - ""
+`,
+			want: `file path #2 in "action-metadata" must not be empty`,
+		},
 	}
 
 	for _, tc := range tests {
@@ -269,12 +494,27 @@ func TestConfigGenerateDefaultConfigFileOK(t *testing.T) {
 	if len(c.SelfHostedRunner.Labels) != 0 {
 		t.Fatal(c.SelfHostedRunner.Labels)
 	}
+	if c.SelfHostedRunner.Groups != nil {
+		t.Fatal(c.SelfHostedRunner.Groups)
+	}
 	if c.ConfigVariables != nil {
 		t.Fatal(c.SelfHostedRunner.Labels)
 	}
+	if c.EnvironmentConfigVariables != nil {
+		t.Fatal(c.EnvironmentConfigVariables)
+	}
+	if c.Secrets != nil {
+		t.Fatal(c.Secrets)
+	}
 	if len(c.Paths) != 0 {
 		t.Fatal(c.Paths)
 	}
+	if c.TimeoutMinutes != nil {
+		t.Fatal(c.TimeoutMinutes)
+	}
+	if c.DeploymentEnvironment != nil {
+		t.Fatal(c.DeploymentEnvironment)
+	}
 }
 
 func TestConfigGenerateDefaultConfigFileError(t *testing.T) {
@@ -0,0 +1,407 @@
+package actionlint
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync"
+)
+
+// dataFileMagic is the magic bytes at the head of data files compiled by CompileDataFile.
+const dataFileMagic = "\x00actionlint-data"
+
+// dataFileFormatVersion is the version of the format of data files. Increment this value when the
+// format is changed so that old data files are not used.
+const dataFileFormatVersion = 1
+
+// dataFileHeaderSize is the size of the header of data files. The header consists of the magic
+// bytes, the format version, and the SHA-256 digest of the body following the header.
+const dataFileHeaderSize = len(dataFileMagic) + 4 + sha256.Size
+
+// Names of the sections in data files.
+const (
+	dataFileSectionConfig         = "config"
+	dataFileSectionActionMetadata = "action-metadata"
+	dataFileSectionWebhookEvents  = "webhook-events"
+	dataFileSectionRunners        = "runners"
+	dataFileSectionPopularActions = "popular-actions"
+)
+
+// dataFileSource is a file compiled into a data file. The data file is outdated when the content of
+// the file is changed.
+type dataFileSource struct {
+	Path string `json:"path"`
+	// Hash is the SHA-256 hash of the file content. It is empty when the file does not exist.
+	Hash string `json:"hash"`
+}
+
+// dataFileSection is the range of a section in the sections area of a data file.
+type dataFileSection struct {
+	Offset int `json:"offset"`
+	Size   int `json:"size"`
+}
+
+// dataFileIndex is the index of a data file. The body of a data file consists of the size of the
+// index (4 bytes), the index in JSON, and the sections area. Each section is encoded in JSON
+// separately so that sections can be decoded lazily.
+type dataFileIndex struct {
+	// Version identifies the build of actionlint which compiled the data file.
+	Version string            `json:"version"`
+	Sources []*dataFileSource `json:"sources"`
+	// ConfigFile is the absolute path of the config file given via DataFileSources.ConfigFile.
+	ConfigFile string `json:"config_file,omitempty"`
+	// ProjectRoot is the root directory of the repository whose config file was compiled. It is empty
+	// when DataFileSources.ConfigFile was given or no repository was found.
+	ProjectRoot string                     `json:"project_root,omitempty"`
+	Sections    map[string]dataFileSection `json:"sections"`
+}
+
+// DataFileSources is the inputs compiled into a data file by CompileDataFile.
+type DataFileSources struct {
+	// ConfigFile is a path to the config file. When it is empty, the config file of the repository
+	// at WorkingDir such as ".github/actionlint.yaml" is compiled.
+	ConfigFile string
+	// WorkingDir is a directory path to find the repository. When it is empty, the current working
+	// directory is used.
+	WorkingDir string
+	// DatasetCacheDir is the cache directory of DatasetCache. The datasets cached in the directory are
+	// compiled instead of the embedded ones. When it is empty, the embedded datasets are compiled.
+	DatasetCacheDir string
+}
+
+type dataFileBuilder struct {
+	index    dataFileIndex
+	sections bytes.Buffer
+}
+
+// readSource reads the file and records it as a source of the data file. A file which does not
+// exist is also recorded so that creating the file later makes the data file outdated.
+func (b *dataFileBuilder) readSource(path string) ([]byte, error) {
+	c, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+	b.index.Sources = append(b.index.Sources, &dataFileSource{path, hashFileContent(path)})
+	return c, err
+}
+
+func (b *dataFileBuilder) section(name string, v any) error {
+	j, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("could not encode %s section of data file: %w", name, err)
+	}
+	b.index.Sections[name] = dataFileSection{b.sections.Len(), len(j)}
+	b.sections.Write(j)
+	return nil
+}
+
+func (b *dataFileBuilder) build() ([]byte, error) {
+	idx, err := json.Marshal(&b.index)
+	if err != nil {
+		return nil, fmt.Errorf("could not encode index of data file: %w", err)
+	}
+	body := make([]byte, 0, 4+len(idx)+b.sections.Len())
+	body = binary.LittleEndian.AppendUint32(body, uint32(len(idx)))
+	body = append(body, idx...)
+	body = append(body, b.sections.Bytes()...)
+
+	h := sha256.Sum256(body)
+	ret := make([]byte, 0, dataFileHeaderSize+len(body))
+	ret = append(ret, dataFileMagic...)
+	ret = binary.LittleEndian.AppendUint32(ret, dataFileFormatVersion)
+	ret = append(ret, h[:]...)
+	return append(ret, body...), nil
+}
+
+// CompileDataFile compiles the config file, the action metadata files set at "action-metadata" in
+// the config, and the datasets of webhook events, GitHub-hosted runner labels, and popular actions
+// into one data file at the path. Loading the data file with LoadDataFile is much faster than
+// reading and parsing all of them. This is useful when actionlint is run for each file from editors
+// or Git hooks.
+func CompileDataFile(path string, srcs *DataFileSources) error {
+	b := &dataFileBuilder{
+		index: dataFileIndex{
+			Version:  executableVersionKey(),
+			Sources:  []*dataFileSource{},
+			Sections: map[string]dataFileSection{},
+		},
+	}
+
+	wd := srcs.WorkingDir
+	if wd == "" {
+		wd = "."
+	}
+	proj, err := findProject(wd)
+	if err != nil {
+		return err
+	}
+	root := ""
+	if proj != nil {
+		root = proj.RootDir()
+	}
+
+	var cfg *Config
+	if srcs.ConfigFile != "" {
+		p := absPath(srcs.ConfigFile)
+		c, err := b.readSource(p)
+		if err != nil {
+			return fmt.Errorf("could not read config file %q: %w", srcs.ConfigFile, err)
+		}
+		cfg, err = ParseConfig(c)
+		if err != nil {
+			return fmt.Errorf("could not parse config file %q: %w", srcs.ConfigFile, err)
+		}
+		b.index.ConfigFile = p
+	} else if proj != nil {
+		// The config was already parsed on finding the project
+		cfg = proj.Config()
+		for _, f := range []string{"actionlint.yaml", "actionlint.yml"} {
+			if _, err := b.readSource(filepath.Join(root, ".github", f)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+				return fmt.Errorf("could not read config file: %w", err)
+			}
+		}
+		b.index.ProjectRoot = root
+	}
+	if cfg != nil {
+		if err := b.section(dataFileSectionConfig, cfg); err != nil {
+			return err
+		}
+	}
+
+	if cfg != nil && len(cfg.ActionMetadata) > 0 {
+		sets := map[string]*ActionMetadataSet{}
+		for _, p := range cfg.ActionMetadata {
+			if !filepath.IsAbs(p) {
+				if root == "" {
+					continue // The file is read when linting since its path cannot be resolved
+				}
+				p = filepath.Join(root, filepath.FromSlash(p))
+			}
+			c, err := b.readSource(p)
+			if err != nil {
+				return fmt.Errorf("could not read action metadata file %q set at \"action-metadata\" in config: %w", p, err)
+			}
+			s, err := ParseActionMetadataSet(c)
+			if err != nil {
+				return fmt.Errorf("could not parse action metadata file %q set at \"action-metadata\" in config: %w", p, err)
+			}
+			sets[p] = s
+		}
+		if err := b.section(dataFileSectionActionMetadata, sets); err != nil {
+			return err
+		}
+	}
+
+	actions, err := LoadPopularActions()
+	if err != nil {
+		return err
+	}
+	events, runners := AllWebhookEvents, GitHubHostedRunners
+	if dir := srcs.DatasetCacheDir; dir != "" {
+		for _, f := range []string{datasetWebhookEventsFile, datasetRunnerLabelsFile, datasetPopularActionsFile} {
+			if _, err := b.readSource(filepath.Join(dir, f)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+				return fmt.Errorf("could not read cached dataset: %w", err)
+			}
+		}
+		d, err := NewDatasetCache(dir, nil).Load()
+		if err != nil {
+			return err
+		}
+		if d.WebhookEvents != nil {
+			events = d.WebhookEvents
+		}
+		if d.Runners != nil {
+			runners = d.Runners
+		}
+		if len(d.Actions) > 0 {
+			actions = maps.Clone(actions)
+			for spec, meta := range d.Actions {
+				if _, ok := actions[spec]; !ok {
+					actions[spec] = meta
+				}
+			}
+		}
+	}
+	if err := b.section(dataFileSectionWebhookEvents, events); err != nil {
+		return err
+	}
+	if err := b.section(dataFileSectionRunners, runners); err != nil {
+		return err
+	}
+	if err := b.section(dataFileSectionPopularActions, actions); err != nil {
+		return err
+	}
+
+	data, err := b.build()
+	if err != nil {
+		return err
+	}
+
+	// Replace the file atomically. Since the file may be mapped in memory by other actionlint
+	// processes, modifying the existing file in place is not allowed.
+	f, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("could not create data file: %w", err)
+	}
+	tmp := f.Name()
+	_, err = f.Write(data)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		err = os.Rename(tmp, path)
+	}
+	if err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("could not write data file %q: %w", path, err)
+	}
+	return nil
+}
+
+// DataFile is a data file compiled by CompileDataFile. The config compiled in the data file is used
+// by setting this instance to LinterOptions.DataFile, and the datasets are applied by Apply method.
+type DataFile struct {
+	path           string
+	data           []byte // Mapped in memory
+	index          *dataFileIndex
+	sections       []byte
+	config         *Config
+	actionMetadata map[string]*ActionMetadataSet
+	datasets       *Datasets
+}
+
+// LoadDataFile loads the data file compiled by CompileDataFile. The file is mapped in memory and only
+// the sections needed at startup are decoded. It returns an error when the data file is broken or
+// outdated, that is, some file compiled into it was changed after compiling it or it was compiled by
+// other version of actionlint. In the case, compile the data file again.
+func LoadDataFile(path string) (*DataFile, error) {
+	data, err := mapDataFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not read data file %q: %w", path, err)
+	}
+	f, err := parseDataFile(path, data)
+	if err != nil {
+		unmapDataFile(data)
+		return nil, err
+	}
+	// The memory is still used by the popular actions dataset which is decoded lazily after Apply
+	// method is called. Unmap it when nothing refers to the instance.
+	runtime.AddCleanup(f, unmapDataFile, data)
+	return f, nil
+}
+
+func parseDataFile(path string, data []byte) (*DataFile, error) {
+	if len(data) < dataFileHeaderSize+4 || string(data[:len(dataFileMagic)]) != dataFileMagic {
+		return nil, fmt.Errorf("%q is not a data file of actionlint", path)
+	}
+	if v := binary.LittleEndian.Uint32(data[len(dataFileMagic):]); v != dataFileFormatVersion {
+		return nil, fmt.Errorf("format version %d of data file %q is not supported. compile it again", v, path)
+	}
+	body := data[dataFileHeaderSize:]
+	h := sha256.Sum256(body)
+	if !bytes.Equal(h[:], data[dataFileHeaderSize-sha256.Size:dataFileHeaderSize]) {
+		return nil, fmt.Errorf("data file %q is broken since its hash does not match to the content. compile it again", path)
+	}
+
+	n := int(binary.LittleEndian.Uint32(body))
+	if n > len(body)-4 {
+		return nil, fmt.Errorf("data file %q is broken since the size of index is too large", path)
+	}
+	var idx dataFileIndex
+	if err := json.Unmarshal(body[4:4+n], &idx); err != nil {
+		return nil, fmt.Errorf("could not decode index of data file %q: %w", path, err)
+	}
+	sections := body[4+n:]
+	for name, s := range idx.Sections {
+		if s.Offset < 0 || s.Size < 0 || s.Offset > len(sections)-s.Size {
+			return nil, fmt.Errorf("data file %q is broken since %s section is out of range", path, name)
+		}
+	}
+
+	if idx.Version != executableVersionKey() {
+		return nil, fmt.Errorf("data file %q is outdated since it was compiled by other version of actionlint %s. compile it again", path, idx.Version)
+	}
+	for _, s := range idx.Sources {
+		if hashFileContent(s.Path) != s.Hash {
+			return nil, fmt.Errorf("data file %q is outdated since %q was changed. compile it again", path, s.Path)
+		}
+	}
+
+	f := &DataFile{path: path, data: data, index: &idx, sections: sections, datasets: &Datasets{}}
+	if err := f.decode(dataFileSectionConfig, &f.config); err != nil {
+		return nil, err
+	}
+	if err := f.decode(dataFileSectionActionMetadata, &f.actionMetadata); err != nil {
+		return nil, err
+	}
+	if err := f.decode(dataFileSectionWebhookEvents, &f.datasets.WebhookEvents); err != nil {
+		return nil, err
+	}
+	if err := f.decode(dataFileSectionRunners, &f.datasets.Runners); err != nil {
+		return nil, err
+	}
+	// The popular actions section is decoded lazily. Only check its syntax here so that decoding it
+	// later does not fail
+	if s, ok := idx.Sections[dataFileSectionPopularActions]; ok && !json.Valid(sections[s.Offset:s.Offset+s.Size]) {
+		return nil, fmt.Errorf("data file %q is broken since %s section is not valid JSON", path, dataFileSectionPopularActions)
+	}
+	return f, nil
+}
+
+// decode decodes the section into the value. It does nothing when the section does not exist.
+func (f *DataFile) decode(name string, v any) error {
+	s, ok := f.index.Sections[name]
+	if !ok {
+		return nil
+	}
+	if err := json.Unmarshal(f.sections[s.Offset:s.Offset+s.Size], v); err != nil {
+		return fmt.Errorf("could not decode %s section of data file %q: %w", name, f.path, err)
+	}
+	return nil
+}
+
+// Config returns the config compiled in the data file. It returns nil when no config file was
+// compiled.
+func (f *DataFile) Config() *Config {
+	return f.config
+}
+
+// Apply replaces the global tables such as AllWebhookEvents, GitHubHostedRunners, and the popular
+// actions data set with the datasets compiled in the data file. The popular actions dataset is
+// decoded on the first lookup and the decode error is returned from LoadPopularActions. Since the
+// global tables are not guarded by any lock, this method must be called before linting workflows.
+func (f *DataFile) Apply() {
+	f.datasets.Apply()
+	if _, ok := f.index.Sections[dataFileSectionPopularActions]; !ok {
+		return
+	}
+	popularActions = sync.OnceValues(func() (map[string]*ActionMetadata, error) {
+		var m map[string]*ActionMetadata
+		if err := f.decode(dataFileSectionPopularActions, &m); err != nil {
+			return nil, err
+		}
+		PopularActions = m
+		return m, nil
+	})
+	popularActionRefs = sync.OnceValue(collectPopularActionRefs)
+	popularActionLatestMajors = sync.OnceValue(collectPopularActionLatestMajors)
+}
+
+// preload registers the config and the action metadata files compiled in the data file so that the
+// linter does not read and parse them again.
+func (f *DataFile) preload(projects *Projects, files *actionMetadataFiles) {
+	if f.index.ProjectRoot != "" {
+		projects.known = append(projects.known, &Project{root: f.index.ProjectRoot, config: f.config})
+	}
+	for p, s := range f.actionMetadata {
+		files.files.Store(p, func() (*ActionMetadataSet, error) { return s, nil })
+	}
+}
@@ -0,0 +1,12 @@
+//go:build !unix
+
+package actionlint
+
+import "os"
+
+// mapDataFile reads the content of the file. Memory-mapped files are not supported on this platform.
+func mapDataFile(path string) ([]byte, error) {
+	return os.ReadFile(path)
+}
+
+func unmapDataFile(b []byte) {}
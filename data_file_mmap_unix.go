@@ -0,0 +1,32 @@
+//go:build unix
+
+package actionlint
+
+import (
+	"os"
+	"syscall"
+)
+
+// mapDataFile maps the content of the file in memory as read-only.
+func mapDataFile(path string) ([]byte, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	s, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	if s.Size() == 0 {
+		return []byte{}, nil // Mapping an empty file fails
+	}
+	return syscall.Mmap(int(f.Fd()), 0, int(s.Size()), syscall.PROT_READ, syscall.MAP_SHARED)
+}
+
+func unmapDataFile(b []byte) {
+	if len(b) > 0 {
+		syscall.Munmap(b)
+	}
+}
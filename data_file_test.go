@@ -0,0 +1,271 @@
+package actionlint
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+const testDataFileActionMetadata = `{"spec":"org/private@v1","metadata":{"name":"Private","inputs":{"foo":{"name":"foo","required":true}},"outputs":{"bar":{"name":"bar"}},"runs":{"using":"node20"}}}` + "\n"
+
+func testDataFileRepo(t *testing.T) string {
+	root := t.TempDir()
+	testWriteFiles(t, root, map[string]string{
+		".git/HEAD":                   "ref: refs/heads/main\n",
+		".github/workflows/test.yaml": "on: push\njobs:\n  test:\n    runs-on: ubuntu-latest\n    steps:\n      - run: echo ${{ vars.FOO }}\n      - uses: org/private@v1\n",
+		".github/actionlint.yaml":     "config-variables: []\npaths:\n  .github/workflows/*.yaml:\n    ignore: ['^foo$']\naction-metadata:\n  - ./meta.jsonl\n",
+		"meta.jsonl":                  testDataFileActionMetadata,
+	})
+	return root
+}
+
+func testCompileDataFile(t *testing.T, srcs *DataFileSources) string {
+	t.Helper()
+	p := filepath.Join(t.TempDir(), "actionlint.data")
+	if err := CompileDataFile(p, srcs); err != nil {
+		t.Fatal(err)
+	}
+	return p
+}
+
+func TestDataFileCompileAndLoad(t *testing.T) {
+	testRestoreDatasets(t)
+	root := testDataFileRepo(t)
+	f, err := LoadDataFile(testCompileDataFile(t, &DataFileSources{WorkingDir: root}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := ReadConfigFile(filepath.Join(root, ".github", "actionlint.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	have := f.Config()
+	if have.ConfigVariables == nil {
+		t.Fatal("empty config variables should not be nil")
+	}
+	opt := cmp.Comparer(func(a, b *regexp.Regexp) bool { return a.String() == b.String() })
+	if diff := cmp.Diff(cfg, have, opt); diff != "" {
+		t.Fatal(diff)
+	}
+	if f.index.ProjectRoot != root {
+		t.Fatalf("wanted project root %q but got %q", root, f.index.ProjectRoot)
+	}
+
+	meta, err := ParseActionMetadataSet([]byte(testDataFileActionMetadata))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]*ActionMetadataSet{filepath.Join(root, "meta.jsonl"): meta}
+	if diff := cmp.Diff(want, f.actionMetadata, cmpopts.IgnoreUnexported(ActionMetadata{})); diff != "" {
+		t.Fatal(diff)
+	}
+
+	embedded, err := decodePopularActions(popularActionsData)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.Apply()
+	if diff := cmp.Diff(embedded, knownPopularActions(), cmpopts.IgnoreUnexported(ActionMetadata{})); diff != "" {
+		t.Fatal(diff)
+	}
+	if diff := cmp.Diff(GitHubHostedRunners, f.datasets.Runners); diff != "" {
+		t.Fatal(diff)
+	}
+	if diff := cmp.Diff(AllWebhookEvents, f.datasets.WebhookEvents); diff != "" {
+		t.Fatal(diff)
+	}
+}
+
+func TestDataFileConfigFileAndDatasets(t *testing.T) {
+	testRestoreDatasets(t)
+	dir := t.TempDir()
+	testWriteFiles(t, dir, map[string]string{
+		"config.yaml":             "self-hosted-runner:\n  labels: [my-runner]\n",
+		datasetRunnerLabelsFile:   `[{"label": "ubuntu-26.04", "os": "linux", "arch": "x64", "image": "ubuntu-26.04"}]`,
+		datasetPopularActionsFile: `{"actions/checkout@v99": {"name": "Checkout", "inputs": {}, "outputs": {}, "runs": {"using": "node24"}}}`,
+	})
+	cfgPath := filepath.Join(dir, "config.yaml")
+	f, err := LoadDataFile(testCompileDataFile(t, &DataFileSources{ConfigFile: cfgPath, WorkingDir: dir, DatasetCacheDir: dir}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f.index.ConfigFile != cfgPath || f.index.ProjectRoot != "" {
+		t.Fatalf("unexpected index: %+v", f.index)
+	}
+	if diff := cmp.Diff([]string{"my-runner"}, f.Config().SelfHostedRunner.Labels); diff != "" {
+		t.Fatal(diff)
+	}
+
+	f.Apply()
+	if len(GitHubHostedRunners) != 1 || GitHubHostedRunners[0].Label != "ubuntu-26.04" {
+		t.Fatalf("runner labels dataset was not applied: %v", GitHubHostedRunners)
+	}
+	if _, ok := knownPopularActions()["actions/checkout@v99"]; !ok {
+		t.Fatal("popular actions dataset was not applied")
+	}
+	if _, ok := knownPopularActions()["actions/checkout@v4"]; !ok {
+		t.Fatal("embedded popular actions were not compiled")
+	}
+
+	l, err := NewLinter(io.Discard, &LinterOptions{Shellcheck: "", Pyflakes: "", DataFile: f})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if l.defaultConfig != f.Config() {
+		t.Fatal("config in data file was not used")
+	}
+}
+
+func TestDataFileLinterUsesCompiledConfig(t *testing.T) {
+	root := testDataFileRepo(t)
+	f, err := LoadDataFile(testCompileDataFile(t, &DataFileSources{WorkingDir: root}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	l, err := NewLinter(io.Discard, &LinterOptions{Shellcheck: "", Pyflakes: "", DataFile: f})
+	if err != nil {
+		t.Fatal(err)
+	}
+	p, err := l.projects.At(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.Config() != f.Config() {
+		t.Fatal("project was not created with the config in data file")
+	}
+
+	errs, err := l.LintRepository(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	msgs := []string{}
+	for _, e := range errs {
+		msgs = append(msgs, e.Message)
+	}
+	// Config variables and inputs of the private action are checked with the compiled config
+	if len(errs) != 2 || !strings.Contains(msgs[0], `no configuration variable is allowed`) || !strings.Contains(msgs[1], `missing input "foo"`) {
+		t.Fatalf("unexpected errors: %#v", msgs)
+	}
+}
+
+func TestDataFileOutdated(t *testing.T) {
+	for _, tc := range []struct {
+		what string
+		path string
+	}{
+		{"config file", ".github/actionlint.yaml"},
+		{"new config file", ".github/actionlint.yml"},
+		{"action metadata", "meta.jsonl"},
+	} {
+		t.Run(tc.what, func(t *testing.T) {
+			root := testDataFileRepo(t)
+			p := testCompileDataFile(t, &DataFileSources{WorkingDir: root})
+			testWriteFiles(t, root, map[string]string{tc.path: "config-variables: [FOO]\n"})
+			_, err := LoadDataFile(p)
+			if err == nil || !strings.Contains(err.Error(), "is outdated since") {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestDataFileBroken(t *testing.T) {
+	p := testCompileDataFile(t, &DataFileSources{WorkingDir: t.TempDir()})
+	b, err := os.ReadFile(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range []struct {
+		what string
+		data []byte
+		want string
+	}{
+		{"empty", []byte{}, "is not a data file"},
+		{"magic", append([]byte("foo"), b[3:]...), "is not a data file"},
+		{"format version", append(append([]byte{}, b[:len(dataFileMagic)]...), append([]byte{0xff}, b[len(dataFileMagic)+1:]...)...), "is not supported"},
+		{"truncated", b[:len(b)-1], "hash does not match"},
+		{"modified", append(bytes.Clone(b[:len(b)-1]), b[len(b)-1]+1), "hash does not match"},
+	} {
+		t.Run(tc.what, func(t *testing.T) {
+			p := filepath.Join(t.TempDir(), "broken.data")
+			if err := os.WriteFile(p, tc.data, 0644); err != nil {
+				t.Fatal(err)
+			}
+			_, err := LoadDataFile(p)
+			if err == nil || !strings.Contains(err.Error(), tc.want) {
+				t.Fatalf("wanted error containing %q but got %v", tc.want, err)
+			}
+		})
+	}
+
+	if _, err := LoadDataFile(filepath.Join(t.TempDir(), "missing.data")); err == nil || !strings.Contains(err.Error(), "could not read data file") {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestDataFileBrokenPopularActions(t *testing.T) {
+	b := &dataFileBuilder{
+		index: dataFileIndex{
+			Version:  executableVersionKey(),
+			Sources:  []*dataFileSource{},
+			Sections: map[string]dataFileSection{},
+		},
+	}
+	b.index.Sections[dataFileSectionPopularActions] = dataFileSection{0, 8}
+	b.sections.WriteString(`{"foo":[`)
+	data, err := b.build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = parseDataFile("test.data", data)
+	if err == nil || !strings.Contains(err.Error(), "section is not valid JSON") {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Valid JSON which cannot be decoded as the data set is reported on loading the data set
+	testRestoreDatasets(t)
+	sections := []byte(`{"actions/checkout@v4":{"name":42}}`)
+	f := &DataFile{
+		path:     "test.data",
+		index:    &dataFileIndex{Sections: map[string]dataFileSection{dataFileSectionPopularActions: {0, len(sections)}}},
+		sections: sections,
+		datasets: &Datasets{},
+	}
+	f.Apply()
+	if _, err := LoadPopularActions(); err == nil || !strings.Contains(err.Error(), "could not decode popular-actions section") {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(knownPopularActions()) != 0 {
+		t.Fatal("data set should be empty when it could not be decoded")
+	}
+}
+
+func TestDataFileCompileError(t *testing.T) {
+	dir := t.TempDir()
+	testWriteFiles(t, dir, map[string]string{"broken.yaml": "paths: [\n"})
+	for _, tc := range []struct {
+		what string
+		srcs *DataFileSources
+		want string
+	}{
+		{"missing config", &DataFileSources{ConfigFile: filepath.Join(dir, "missing.yaml"), WorkingDir: dir}, "could not read config file"},
+		{"broken config", &DataFileSources{ConfigFile: filepath.Join(dir, "broken.yaml"), WorkingDir: dir}, "could not parse config file"},
+	} {
+		t.Run(tc.what, func(t *testing.T) {
+			err := CompileDataFile(filepath.Join(dir, "out.data"), tc.srcs)
+			if err == nil || !strings.Contains(err.Error(), tc.want) {
+				t.Fatalf("wanted error containing %q but got %v", tc.want, err)
+			}
+		})
+	}
+}
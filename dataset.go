@@ -0,0 +1,433 @@
+package actionlint
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"maps"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"go.yaml.in/yaml/v4"
+	"golang.org/x/sync/errgroup"
+)
+
+// defaultDatasetsURL is the URL of the repository contents where the latest datasets are published.
+// The datasets are generated by the scripts in ./scripts directory.
+const defaultDatasetsURL = "https://raw.githubusercontent.com/rhysd/actionlint/main"
+
+// defaultActionsRawURL is the URL to fetch action metadata files of popular actions.
+const defaultActionsRawURL = "https://raw.githubusercontent.com"
+
+// File names of the datasets in the cache directory.
+const (
+	datasetWebhookEventsFile  = "webhook_events.json"
+	datasetRunnerLabelsFile   = "runner_labels.json"
+	datasetPopularActionsFile = "popular_actions.json"
+)
+
+// datasetMaxNewMajors is the maximum number of new major versions of each popular action to look
+// for when refreshing the popular actions dataset.
+const datasetMaxNewMajors = 3
+
+// datasetWebhookEvent is an entry of the webhook events dataset generated by the script at
+// ./scripts/generate-webhook-events.
+type datasetWebhookEvent struct {
+	Types   []string `json:"types"`
+	Filters []string `json:"filters,omitempty"`
+	URL     string   `json:"url"`
+	GHES    *string  `json:"ghes,omitempty"`
+}
+
+// datasetRunnerLabel is an entry of the runner labels dataset which is the data source of the script
+// at ./scripts/generate-runner-labels.
+type datasetRunnerLabel struct {
+	Label string     `json:"label"`
+	OS    RunnerOS   `json:"os"`
+	Arch  RunnerArch `json:"arch"`
+	Image string     `json:"image"`
+}
+
+// Datasets is a set of the datasets which are refreshed at runtime. They are merged into the
+// embedded datasets such as AllWebhookEvents, GitHubHostedRunners, and LoadPopularActions() by Apply
+// method. Nil field means the dataset is not available and the embedded one is used as-is.
+type Datasets struct {
+	// WebhookEvents is the table of all webhook events. It replaces AllWebhookEvents.
+	WebhookEvents map[string]*WebhookEventMetadata
+	// Runners is the catalog of GitHub-hosted runner labels. It replaces GitHubHostedRunners.
+	Runners []*GitHubHostedRunner
+	// Actions is the metadata of new versions of popular actions which are not in LoadPopularActions().
+	// Keys are specs of the actions like "actions/checkout@v9".
+	Actions map[string]*ActionMetadata
+}
+
+// Apply merges the datasets into the global tables such as AllWebhookEvents, GitHubHostedRunners,
+// and LoadPopularActions(). Since the global tables are not guarded by any lock, this method must be
+// called before linting workflows.
+func (d *Datasets) Apply() {
+	if d.WebhookEvents != nil {
+		AllWebhookEvents = d.WebhookEvents
+		types := make(map[string][]string, len(d.WebhookEvents))
+		for n, e := range d.WebhookEvents {
+			types[n] = e.Types
+		}
+		AllWebhookTypes = types
+	}
+
+	if d.Runners != nil {
+		GitHubHostedRunners = d.Runners
+		allGitHubHostedRunnerLabels = gitHubHostedRunnerLabels(d.Runners)
+		gitHubHostedRunnerIndex = sync.OnceValue(indexGitHubHostedRunners)
+	}
+
+	if len(d.Actions) > 0 {
+		actions := knownPopularActions()
+		for spec, meta := range d.Actions {
+			if _, ok := actions[spec]; !ok {
+				actions[spec] = meta
+			}
+		}
+		popularActionRefs = sync.OnceValue(collectPopularActionRefs)
+		popularActionLatestMajors = sync.OnceValue(collectPopularActionLatestMajors)
+	}
+}
+
+// DatasetCache is a local cache of the datasets of webhook events, GitHub-hosted runner labels, and
+// popular actions. The datasets embedded in the executable become outdated as time goes. Refresh
+// method downloads the latest datasets from upstream into the cache directory, and Load method reads
+// them so that they are used instead of the embedded ones.
+type DatasetCache struct {
+	dir        string
+	datasets   string
+	actionsRaw string
+	client     *http.Client
+	dbg        io.Writer
+}
+
+// NewDatasetCache creates a new DatasetCache instance with the cache directory. The 'dbg' argument
+// is a writer for debug output. It can be nil.
+func NewDatasetCache(dir string, dbg io.Writer) *DatasetCache {
+	return &DatasetCache{
+		dir:        dir,
+		datasets:   defaultDatasetsURL,
+		actionsRaw: defaultActionsRawURL,
+		client:     &http.Client{Timeout: 30 * time.Second},
+		dbg:        dbg,
+	}
+}
+
+// DefaultDatasetCacheDir returns the default cache directory of the datasets. It is "actionlint/datasets"
+// in the user cache directory such as "$XDG_CACHE_HOME" on Linux.
+func DefaultDatasetCacheDir() (string, error) {
+	d, err := os.UserCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("could not determine cache directory for datasets: %w", err)
+	}
+	return filepath.Join(d, "actionlint", "datasets"), nil
+}
+
+// Dir returns the path of the cache directory.
+func (c *DatasetCache) Dir() string {
+	return c.dir
+}
+
+func (c *DatasetCache) debug(format string, args ...interface{}) {
+	if c.dbg == nil {
+		return
+	}
+	format = "[DatasetCache] " + format + "\n"
+	fmt.Fprintf(c.dbg, format, args...)
+}
+
+// fetch sends GET request to the URL and returns the response body. The second return value is false
+// when the resource was not found.
+func (c *DatasetCache) fetch(ctx context.Context, url string) ([]byte, bool, error) {
+	c.debug("GET %s", url)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, false, err
+	}
+
+	res, err := c.client.Do(req)
+	if err != nil {
+		return nil, false, fmt.Errorf("could not send request to %s: %w", url, err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusNotFound {
+		return nil, false, nil
+	}
+	if res.StatusCode < 200 || 300 <= res.StatusCode {
+		return nil, false, fmt.Errorf("request to %s was not successful: %s", url, res.Status)
+	}
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, false, fmt.Errorf("could not read response body from %s: %w", url, err)
+	}
+	return body, true, nil
+}
+
+// write writes the dataset file into the cache directory. The file is replaced atomically so that
+// a broken file is never left even if the process is interrupted.
+func (c *DatasetCache) write(name string, b []byte) error {
+	if err := os.MkdirAll(c.dir, 0755); err != nil {
+		return fmt.Errorf("could not create cache directory for datasets: %w", err)
+	}
+	f, err := os.CreateTemp(c.dir, name+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("could not create cache file for dataset %s: %w", name, err)
+	}
+	tmp := f.Name()
+	_, err = f.Write(b)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		err = os.Rename(tmp, filepath.Join(c.dir, name))
+	}
+	if err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("could not write cache file for dataset %s: %w", name, err)
+	}
+	c.debug("Wrote dataset %s (%d bytes) to %s", name, len(b), c.dir)
+	return nil
+}
+
+// Refresh downloads the latest datasets from upstream and stores them in the cache directory. The
+// webhook events and the runner labels are downloaded from the actionlint repository where they are
+// generated. New major versions of popular actions are detected by fetching their action metadata
+// files. When some dataset could not be downloaded, the cached one is kept as-is.
+func (c *DatasetCache) Refresh(ctx context.Context) error {
+	b, err := fetchDataset(ctx, c, "scripts/generate-webhook-events/"+datasetWebhookEventsFile, parseDatasetWebhookEvents)
+	if err != nil {
+		return err
+	}
+	if err := c.write(datasetWebhookEventsFile, b); err != nil {
+		return err
+	}
+
+	b, err = fetchDataset(ctx, c, "scripts/generate-runner-labels/"+datasetRunnerLabelsFile, parseDatasetRunnerLabels)
+	if err != nil {
+		return err
+	}
+	if err := c.write(datasetRunnerLabelsFile, b); err != nil {
+		return err
+	}
+
+	actions, err := c.fetchNewPopularActions(ctx)
+	if err != nil {
+		return err
+	}
+	b, err = json.Marshal(actions)
+	if err != nil {
+		return fmt.Errorf("could not encode popular actions dataset: %w", err)
+	}
+	return c.write(datasetPopularActionsFile, b)
+}
+
+// fetchDataset downloads the dataset at the path in the actionlint repository and validates it with
+// the parse function.
+func fetchDataset[T any](ctx context.Context, c *DatasetCache, path string, parse func([]byte) (T, error)) ([]byte, error) {
+	url := c.datasets + "/" + path
+	b, ok, err := c.fetch(ctx, url)
+	if err != nil {
+		return nil, fmt.Errorf("could not download dataset: %w", err)
+	}
+	if !ok {
+		return nil, fmt.Errorf("dataset was not found at %s", url)
+	}
+	if _, err := parse(b); err != nil {
+		return nil, fmt.Errorf("dataset downloaded from %s is broken: %w", url, err)
+	}
+	return b, nil
+}
+
+// fetchNewPopularActions fetches the metadata of major versions of popular actions which are newer
+// than the latest ones in LoadPopularActions().
+func (c *DatasetCache) fetchNewPopularActions(ctx context.Context) (map[string]*ActionMetadata, error) {
+	latest := popularActionLatestMajors()
+	names := slices.Sorted(maps.Keys(latest))
+
+	var mu sync.Mutex
+	ret := map[string]*ActionMetadata{}
+
+	eg, ctx := errgroup.WithContext(ctx)
+	eg.SetLimit(4)
+	for _, name := range names {
+		eg.Go(func() error {
+			for v := latest[name] + 1; v <= latest[name]+datasetMaxNewMajors; v++ {
+				spec := fmt.Sprintf("%s@v%d", name, v)
+				meta, err := c.fetchActionMetadata(ctx, spec)
+				if err != nil {
+					return err
+				}
+				if meta == nil {
+					break
+				}
+				c.debug("Found new version of popular action %s", spec)
+				mu.Lock()
+				ret[spec] = meta
+				mu.Unlock()
+			}
+			return nil
+		})
+	}
+	if err := eg.Wait(); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+// fetchActionMetadata fetches action.yml or action.yaml of the action. It returns nil when the
+// metadata file was not found.
+func (c *DatasetCache) fetchActionMetadata(ctx context.Context, spec string) (*ActionMetadata, error) {
+	owner, repo, dir, ref, ok := parseRepoActionSpec(spec)
+	if !ok {
+		return nil, nil
+	}
+	if dir != "" {
+		dir += "/"
+	}
+
+	for _, f := range []string{"action.yml", "action.yaml"} {
+		b, ok, err := c.fetch(ctx, fmt.Sprintf("%s/%s/%s/%s/%s%s", c.actionsRaw, owner, repo, ref, dir, f))
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			continue
+		}
+		var meta ActionMetadata
+		if err := yaml.Unmarshal(b, &meta); err != nil {
+			return nil, fmt.Errorf("could not parse action metadata %s of action %q: %s", f, spec, strings.ReplaceAll(err.Error(), "\n", " "))
+		}
+		return &meta, nil
+	}
+
+	return nil, nil
+}
+
+// Load reads the datasets in the cache directory. Datasets which have not been downloaded yet are nil
+// in the returned value so that the embedded ones are used. It returns an error when some cached
+// dataset is broken.
+func (c *DatasetCache) Load() (*Datasets, error) {
+	d := &Datasets{}
+
+	if err := loadDataset(c, datasetWebhookEventsFile, parseDatasetWebhookEvents, &d.WebhookEvents); err != nil {
+		return nil, err
+	}
+	if err := loadDataset(c, datasetRunnerLabelsFile, parseDatasetRunnerLabels, &d.Runners); err != nil {
+		return nil, err
+	}
+	if err := loadDataset(c, datasetPopularActionsFile, parseDatasetPopularActions, &d.Actions); err != nil {
+		return nil, err
+	}
+
+	return d, nil
+}
+
+func loadDataset[T any](c *DatasetCache, name string, parse func([]byte) (T, error), dst *T) error {
+	p := filepath.Join(c.dir, name)
+	b, err := os.ReadFile(p)
+	if errors.Is(err, fs.ErrNotExist) {
+		c.debug("Dataset %s is not cached. The embedded one is used", name)
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("could not read cached dataset: %w", err)
+	}
+	v, err := parse(b)
+	if err != nil {
+		return fmt.Errorf("cached dataset %s is broken. run with -refresh-datasets to download it again: %w", p, err)
+	}
+	c.debug("Loaded cached dataset %s", p)
+	*dst = v
+	return nil
+}
+
+func parseDatasetWebhookEvents(b []byte) (map[string]*WebhookEventMetadata, error) {
+	var events map[string]*datasetWebhookEvent
+	if err := json.Unmarshal(b, &events); err != nil {
+		return nil, fmt.Errorf("could not parse webhook events dataset: %w", err)
+	}
+	if len(events) == 0 {
+		return nil, errors.New("no webhook event was found in the dataset")
+	}
+
+	ret := make(map[string]*WebhookEventMetadata, len(events))
+	for n, e := range events {
+		if e == nil {
+			return nil, fmt.Errorf("metadata of webhook event %q is null", n)
+		}
+		m := &WebhookEventMetadata{Types: e.Types, Filters: e.Filters, URL: e.URL}
+		if e.GHES != nil {
+			m.GHES = &GHESVersion{}
+			if *e.GHES != "" {
+				v, err := ParseGHESVersion(*e.GHES)
+				if err != nil {
+					return nil, fmt.Errorf("GHES version of webhook event %q is invalid: %w", n, err)
+				}
+				m.GHES = v
+			}
+		}
+		ret[n] = m
+	}
+	return ret, nil
+}
+
+func parseDatasetRunnerLabels(b []byte) ([]*GitHubHostedRunner, error) {
+	var labels []*datasetRunnerLabel
+	if err := json.Unmarshal(b, &labels); err != nil {
+		return nil, fmt.Errorf("could not parse runner labels dataset: %w", err)
+	}
+	if len(labels) == 0 {
+		return nil, errors.New("no runner label was found in the dataset")
+	}
+
+	ret := make([]*GitHubHostedRunner, 0, len(labels))
+	for _, l := range labels {
+		if l == nil || l.Label == "" || l.Image == "" {
+			return nil, errors.New("label and image of runner must not be empty")
+		}
+		switch l.OS {
+		case RunnerOSLinux, RunnerOSMacOS, RunnerOSWindows:
+		default:
+			return nil, fmt.Errorf("OS %q of runner label %q is unknown", l.OS, l.Label)
+		}
+		switch l.Arch {
+		case RunnerArchX64, RunnerArchARM64:
+		default:
+			return nil, fmt.Errorf("architecture %q of runner label %q is unknown", l.Arch, l.Label)
+		}
+		ret = append(ret, &GitHubHostedRunner{
+			Label: strings.ToLower(l.Label),
+			OS:    l.OS,
+			Arch:  l.Arch,
+			Image: strings.ToLower(l.Image),
+		})
+	}
+	return ret, nil
+}
+
+func parseDatasetPopularActions(b []byte) (map[string]*ActionMetadata, error) {
+	var actions map[string]*ActionMetadata
+	if err := json.Unmarshal(b, &actions); err != nil {
+		return nil, fmt.Errorf("could not parse popular actions dataset: %w", err)
+	}
+	for spec, meta := range actions {
+		if meta == nil {
+			return nil, fmt.Errorf("metadata of action %q is null", spec)
+		}
+	}
+	return actions, nil
+}
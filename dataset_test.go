@@ -0,0 +1,196 @@
+package actionlint
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func testRestoreDatasets(t *testing.T) {
+	events, types, runners, decode := AllWebhookEvents, AllWebhookTypes, GitHubHostedRunners, popularActions
+	actions := make(map[string]*ActionMetadata, len(knownPopularActions()))
+	for k, v := range knownPopularActions() {
+		actions[k] = v
+	}
+	t.Cleanup(func() {
+		AllWebhookEvents, AllWebhookTypes, GitHubHostedRunners, popularActions = events, types, runners, decode
+		for k := range knownPopularActions() {
+			if _, ok := actions[k]; !ok {
+				delete(knownPopularActions(), k)
+			}
+		}
+		PopularActions = knownPopularActions()
+		allGitHubHostedRunnerLabels = gitHubHostedRunnerLabels(runners)
+		gitHubHostedRunnerIndex = sync.OnceValue(indexGitHubHostedRunners)
+		popularActionRefs = sync.OnceValue(collectPopularActionRefs)
+		popularActionLatestMajors = sync.OnceValue(collectPopularActionLatestMajors)
+	})
+}
+
+func TestDatasetWebhookEventsConsistentWithEmbedded(t *testing.T) {
+	b, err := os.ReadFile(filepath.Join("scripts", "generate-webhook-events", "webhook_events.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	have, err := parseDatasetWebhookEvents(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(AllWebhookEvents, have); diff != "" {
+		t.Fatalf("webhook_events.json is not consistent with AllWebhookEvents. run `go generate`: %s", diff)
+	}
+}
+
+func TestDatasetRunnerLabelsConsistentWithEmbedded(t *testing.T) {
+	b, err := os.ReadFile(filepath.Join("scripts", "generate-runner-labels", "runner_labels.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	have, err := parseDatasetRunnerLabels(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(GitHubHostedRunners, have); diff != "" {
+		t.Fatalf("runner_labels.json is not consistent with GitHubHostedRunners. run `go generate`: %s", diff)
+	}
+}
+
+func TestDatasetCacheRefreshAndLoad(t *testing.T) {
+	events := `{
+  "push": {"types": [], "filters": ["branches"], "url": "https://example.com#push"},
+  "new_event": {"types": ["created"], "url": "https://example.com#new_event", "ghes": "3.20"}
+}`
+	labels := `[
+  {"label": "ubuntu-latest", "os": "linux", "arch": "x64", "image": "ubuntu-26.04"},
+  {"label": "ubuntu-26.04", "os": "linux", "arch": "x64", "image": "ubuntu-26.04"}
+]`
+	action := `name: Checkout
+description: Checkout
+inputs:
+  new-input:
+    description: New input
+runs:
+  using: node24
+  main: index.js
+`
+	latest := popularActionLatestMajors()["actions/checkout"]
+	next := "v" + strconv.Itoa(latest+1)
+
+	requested := sync.Map{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requested.Store(r.URL.Path, struct{}{})
+		switch r.URL.Path {
+		case "/datasets/scripts/generate-webhook-events/webhook_events.json":
+			w.Write([]byte(events))
+		case "/datasets/scripts/generate-runner-labels/runner_labels.json":
+			w.Write([]byte(labels))
+		case "/actions/actions/checkout/" + next + "/action.yml":
+			w.Write([]byte(action))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	dir := filepath.Join(t.TempDir(), "datasets")
+	c := NewDatasetCache(dir, nil)
+	c.datasets = srv.URL + "/datasets"
+	c.actionsRaw = srv.URL + "/actions"
+
+	d, err := c.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d.WebhookEvents != nil || d.Runners != nil || d.Actions != nil {
+		t.Fatalf("datasets should be empty before refresh: %+v", d)
+	}
+
+	if err := c.Refresh(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := requested.Load("/actions/actions/checkout/v" + strconv.Itoa(latest+2) + "/action.yaml"); !ok {
+		t.Error("next major version after the new version was not checked")
+	}
+
+	d, err = c.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testRestoreDatasets(t)
+	d.Apply()
+
+	e, ok := AllWebhookEvents["new_event"]
+	if !ok {
+		t.Fatal("new webhook event was not applied")
+	}
+	if e.GHES == nil || *e.GHES != (GHESVersion{3, 20}) {
+		t.Errorf("unexpected GHES version %v", e.GHES)
+	}
+	if ts := AllWebhookTypes["new_event"]; len(ts) != 1 || ts[0] != "created" {
+		t.Errorf("unexpected activity types %v", ts)
+	}
+
+	r, ok := LookupGitHubHostedRunner("ubuntu-26.04")
+	if !ok || r.OS != RunnerOSLinux {
+		t.Fatalf("new runner label was not applied: %v", r)
+	}
+	if !isGitHubHostedLabel("ubuntu-26.04") || isGitHubHostedLabel("ubuntu-24.04") {
+		t.Errorf("runner labels were not replaced: %v", allGitHubHostedRunnerLabels)
+	}
+
+	spec := "actions/checkout@" + next
+	meta, ok := knownPopularActions()[spec]
+	if !ok {
+		t.Fatalf("new version %s was not applied", spec)
+	}
+	if _, ok := meta.Inputs["new-input"]; !ok {
+		t.Errorf("unexpected inputs %v", meta.Inputs)
+	}
+	a, ok := LookupPopularAction("actions", "checkout", next)
+	if !ok || a.Latest != next {
+		t.Errorf("latest version was not updated: %+v", a)
+	}
+}
+
+func TestDatasetCacheRefreshError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "webhook_events.json") {
+			w.Write([]byte(`{"push": `))
+			return
+		}
+		http.NotFound(w, r)
+	}))
+	defer srv.Close()
+
+	dir := t.TempDir()
+	c := NewDatasetCache(dir, nil)
+	c.datasets = srv.URL
+
+	err := c.Refresh(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "is broken") {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, datasetWebhookEventsFile)); err == nil {
+		t.Fatal("broken dataset was cached")
+	}
+}
+
+func TestDatasetCacheLoadBrokenCache(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, datasetRunnerLabelsFile), []byte(`[{"label": "foo", "os": "plan9", "arch": "x64", "image": "foo"}]`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	_, err := NewDatasetCache(dir, nil).Load()
+	if err == nil || !strings.Contains(err.Error(), `OS "plan9" of runner label "foo" is unknown`) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,94 @@
+package actionlint
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"runtime/pprof"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// newDebugHandler creates an HTTP handler to inspect the running process. It serves the metrics at
+// /debug/metrics and the profiles compatible with `go tool pprof` at /debug/pprof/. net/http/pprof
+// package is not used since importing it registers the handlers to http.DefaultServeMux of
+// applications which use actionlint as a library.
+func newDebugHandler(m *Metrics) http.Handler {
+	mux := http.NewServeMux()
+	mux.Handle("GET /debug/metrics", m)
+	mux.HandleFunc("GET /debug/pprof/{$}", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		fmt.Fprintln(w, "profile")
+		for _, p := range pprof.Profiles() {
+			fmt.Fprintln(w, p.Name())
+		}
+	})
+	mux.HandleFunc("GET /debug/pprof/profile", serveCPUProfile)
+	mux.HandleFunc("GET /debug/pprof/{name}", func(w http.ResponseWriter, r *http.Request) {
+		p := pprof.Lookup(r.PathValue("name"))
+		if p == nil {
+			http.Error(w, "unknown profile", http.StatusNotFound)
+			return
+		}
+		debug, _ := strconv.Atoi(r.FormValue("debug"))
+		if debug == 0 {
+			w.Header().Set("Content-Type", "application/octet-stream")
+		} else {
+			w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		}
+		p.WriteTo(w, debug)
+	})
+	return mux
+}
+
+// serveCPUProfile profiles CPU usage for the duration given by "seconds" query parameter (30 seconds
+// by default) and responds the profile.
+func serveCPUProfile(w http.ResponseWriter, r *http.Request) {
+	secs, err := strconv.Atoi(r.FormValue("seconds"))
+	if err != nil || secs <= 0 {
+		secs = 30
+	}
+	w.Header().Set("Content-Type", "application/octet-stream")
+	if err := pprof.StartCPUProfile(w); err != nil {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		http.Error(w, fmt.Sprintf("could not start CPU profile: %s", err), http.StatusInternalServerError)
+		return
+	}
+	select {
+	case <-time.After(time.Duration(secs) * time.Second):
+	case <-r.Context().Done():
+	}
+	pprof.StopCPUProfile()
+}
+
+// debugServer is an HTTP server to serve the handler created by newDebugHandler while the command is
+// running.
+type debugServer struct {
+	lis net.Listener
+	srv *http.Server
+}
+
+// startDebugServer starts the debug server listening at the address like "localhost:6060".
+func startDebugServer(addr string, m *Metrics) (*debugServer, error) {
+	lis, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("could not start debug server at %q: %w", addr, err)
+	}
+	srv := &http.Server{Handler: newDebugHandler(m), ReadHeaderTimeout: 10 * time.Second}
+	go srv.Serve(lis)
+	return &debugServer{lis, srv}, nil
+}
+
+// url returns the base URL of the debug endpoints.
+func (s *debugServer) url() string {
+	a := s.lis.Addr().String()
+	if strings.HasPrefix(a, "[::]:") || strings.HasPrefix(a, "0.0.0.0:") {
+		a = "localhost" + a[strings.LastIndexByte(a, ':'):]
+	}
+	return fmt.Sprintf("http://%s/debug/", a)
+}
+
+func (s *debugServer) close() {
+	s.srv.Close()
+}
@@ -0,0 +1,194 @@
+package actionlint
+
+import (
+	"fmt"
+	"strings"
+)
+
+// diffContextLines is the number of unchanged lines around changes in hunks of unified diff.
+const diffContextLines = 3
+
+type diffOpKind byte
+
+const (
+	diffOpEqual  diffOpKind = ' '
+	diffOpDelete diffOpKind = '-'
+	diffOpInsert diffOpKind = '+'
+)
+
+// diffOp is an operation of edit script. Index 'a' is the line index in the old lines and index 'b'
+// is the line index in the new lines.
+type diffOp struct {
+	kind diffOpKind
+	a, b int
+}
+
+// diffLines computes the shortest edit script from the old lines to the new lines with Myers'
+// algorithm. Common prefix and suffix are skipped before running the algorithm since fixes usually
+// change a few lines in a file.
+func diffLines(a, b []string) []diffOp {
+	pre := 0
+	for pre < len(a) && pre < len(b) && a[pre] == b[pre] {
+		pre++
+	}
+	suf := 0
+	for suf < len(a)-pre && suf < len(b)-pre && a[len(a)-1-suf] == b[len(b)-1-suf] {
+		suf++
+	}
+	ma, mb := a[pre:len(a)-suf], b[pre:len(b)-suf]
+
+	ops := make([]diffOp, 0, len(a)+len(b))
+	for i := 0; i < pre; i++ {
+		ops = append(ops, diffOp{diffOpEqual, i, i})
+	}
+	for _, op := range diffMyers(ma, mb) {
+		ops = append(ops, diffOp{op.kind, op.a + pre, op.b + pre})
+	}
+	for i := 0; i < suf; i++ {
+		ops = append(ops, diffOp{diffOpEqual, len(a) - suf + i, len(b) - suf + i})
+	}
+	return ops
+}
+
+func diffMyers(a, b []string) []diffOp {
+	n, m := len(a), len(b)
+	limit := n + m
+	off := limit + 1
+	v := make([]int, 2*limit+3)
+	trace := [][]int{}
+
+Outer:
+	for d := 0; d <= limit; d++ {
+		trace = append(trace, append([]int(nil), v...))
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || k != d && v[off+k-1] < v[off+k+1] {
+				x = v[off+k+1] // Move down (insertion)
+			} else {
+				x = v[off+k-1] + 1 // Move right (deletion)
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			v[off+k] = x
+			if x >= n && y >= m {
+				break Outer
+			}
+		}
+	}
+
+	// Backtrack the trace to build the edit script in reverse order
+	ops := make([]diffOp, 0, n+m)
+	x, y := n, m
+	for d := len(trace) - 1; d >= 0; d-- {
+		v := trace[d]
+		k := x - y
+		var pk int
+		if k == -d || k != d && v[off+k-1] < v[off+k+1] {
+			pk = k + 1
+		} else {
+			pk = k - 1
+		}
+		px := v[off+pk]
+		py := px - pk
+		for x > px && y > py {
+			x--
+			y--
+			ops = append(ops, diffOp{diffOpEqual, x, y})
+		}
+		if d > 0 {
+			if x == px {
+				y--
+				ops = append(ops, diffOp{diffOpInsert, x, y})
+			} else {
+				x--
+				ops = append(ops, diffOp{diffOpDelete, x, y})
+			}
+		}
+		x, y = px, py
+	}
+
+	for i, j := 0, len(ops)-1; i < j; i, j = i+1, j-1 {
+		ops[i], ops[j] = ops[j], ops[i]
+	}
+	return ops
+}
+
+// diffSplitLines splits the source into lines. Each line keeps its newline character.
+func diffSplitLines(src string) []string {
+	ls := strings.SplitAfter(src, "\n")
+	if ls[len(ls)-1] == "" {
+		ls = ls[:len(ls)-1]
+	}
+	return ls
+}
+
+// unifiedDiff returns the unified diff between the old source and the new source of the file at the
+// path. The paths in the header have "a/" and "b/" prefixes as well as `git diff` so that the diff
+// can be applied with `git apply`. It returns an empty string when the sources are the same.
+func unifiedDiff(path string, before, after []byte) string {
+	if string(before) == string(after) {
+		return ""
+	}
+	a, b := diffSplitLines(string(before)), diffSplitLines(string(after))
+	ops := diffLines(a, b)
+
+	var out strings.Builder
+	fmt.Fprintf(&out, "--- a/%s\n+++ b/%s\n", path, path)
+
+	for i := 0; i < len(ops); {
+		if ops[i].kind == diffOpEqual {
+			i++
+			continue
+		}
+
+		// Extend the hunk while the next change is close enough to share the context lines
+		start, end := max(i-diffContextLines, 0), i
+		for j := i; j < len(ops); j++ {
+			if ops[j].kind != diffOpEqual {
+				end = j + 1
+			} else if j-end >= 2*diffContextLines {
+				break
+			}
+		}
+		end = min(end+diffContextLines, len(ops))
+
+		la, lb := 0, 0
+		for _, op := range ops[start:end] {
+			if op.kind != diffOpInsert {
+				la++
+			}
+			if op.kind != diffOpDelete {
+				lb++
+			}
+		}
+		// When the hunk has no line, the start is the line before the hunk
+		sa, sb := ops[start].a, ops[start].b
+		if la > 0 {
+			sa++
+		}
+		if lb > 0 {
+			sb++
+		}
+		fmt.Fprintf(&out, "@@ -%d,%d +%d,%d @@\n", sa, la, sb, lb)
+
+		for _, op := range ops[start:end] {
+			var l string
+			if op.kind == diffOpInsert {
+				l = b[op.b]
+			} else {
+				l = a[op.a]
+			}
+			out.WriteByte(byte(op.kind))
+			out.WriteString(l)
+			if !strings.HasSuffix(l, "\n") {
+				out.WriteString("\n\\ No newline at end of file\n")
+			}
+		}
+		i = end
+	}
+
+	return out.String()
+}
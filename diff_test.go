@@ -0,0 +1,80 @@
+package actionlint
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestDiffUnifiedDiff(t *testing.T) {
+	tests := []struct {
+		what   string
+		before string
+		after  string
+		want   string
+	}{
+		{
+			what:   "no change",
+			before: "a\nb\n",
+			after:  "a\nb\n",
+			want:   "",
+		},
+		{
+			what:   "replace line",
+			before: "a\nb\nc\n",
+			after:  "a\nB\nc\n",
+			want:   "--- a/test.yaml\n+++ b/test.yaml\n@@ -1,3 +1,3 @@\n a\n-b\n+B\n c\n",
+		},
+		{
+			what:   "insert lines",
+			before: "a\nb\n",
+			after:  "a\nx\ny\nb\n",
+			want:   "--- a/test.yaml\n+++ b/test.yaml\n@@ -1,2 +1,4 @@\n a\n+x\n+y\n b\n",
+		},
+		{
+			what:   "delete line",
+			before: "a\nb\nc\n",
+			after:  "a\nc\n",
+			want:   "--- a/test.yaml\n+++ b/test.yaml\n@@ -1,3 +1,2 @@\n a\n-b\n c\n",
+		},
+		{
+			what:   "context lines",
+			before: "1\n2\n3\n4\n5\n6\n7\n8\n9\n",
+			after:  "1\n2\n3\n4\nx\n6\n7\n8\n9\n",
+			want:   "--- a/test.yaml\n+++ b/test.yaml\n@@ -2,7 +2,7 @@\n 2\n 3\n 4\n-5\n+x\n 6\n 7\n 8\n",
+		},
+		{
+			what:   "separate hunks",
+			before: "1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\n12\n",
+			after:  "x\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\ny\n",
+			want:   "--- a/test.yaml\n+++ b/test.yaml\n@@ -1,4 +1,4 @@\n-1\n+x\n 2\n 3\n 4\n@@ -9,4 +9,4 @@\n 9\n 10\n 11\n-12\n+y\n",
+		},
+		{
+			what:   "merged hunks",
+			before: "1\n2\n3\n4\n5\n6\n7\n8\n",
+			after:  "x\n2\n3\n4\n5\n6\n7\ny\n",
+			want:   "--- a/test.yaml\n+++ b/test.yaml\n@@ -1,8 +1,8 @@\n-1\n+x\n 2\n 3\n 4\n 5\n 6\n 7\n-8\n+y\n",
+		},
+		{
+			what:   "insert into empty",
+			before: "",
+			after:  "a\n",
+			want:   "--- a/test.yaml\n+++ b/test.yaml\n@@ -0,0 +1,1 @@\n+a\n",
+		},
+		{
+			what:   "no newline at end of file",
+			before: "a\nb",
+			after:  "a\nc",
+			want:   "--- a/test.yaml\n+++ b/test.yaml\n@@ -1,2 +1,2 @@\n a\n-b\n\\ No newline at end of file\n+c\n\\ No newline at end of file\n",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.what, func(t *testing.T) {
+			have := unifiedDiff("test.yaml", []byte(tc.before), []byte(tc.after))
+			if diff := cmp.Diff(tc.want, have); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,339 @@
+package actionlint
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Grammar of Docker image references
+// https://github.com/distribution/reference/blob/main/reference.go
+var (
+	dockerImageDomainPattern   = regexp.MustCompile(`^(?:[a-zA-Z0-9]|[a-zA-Z0-9][a-zA-Z0-9-]*[a-zA-Z0-9])(?:\.(?:[a-zA-Z0-9]|[a-zA-Z0-9][a-zA-Z0-9-]*[a-zA-Z0-9]))*(?::[0-9]+)?$`)
+	dockerImagePathCompPattern = regexp.MustCompile(`^[a-z0-9]+(?:(?:[._]|__|-+)[a-z0-9]+)*$`)
+	dockerImageTagPattern      = regexp.MustCompile(`^[\w][\w.-]{0,127}$`)
+	dockerImageDigestPattern   = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9]*(?:[-_+.][A-Za-z][A-Za-z0-9]*)*:[0-9a-fA-F]{32,}$`)
+)
+
+const dockerHubRegistry = "registry-1.docker.io"
+
+// dockerImageRef is a parsed reference of Docker image like "ghcr.io/owner/image:tag".
+type dockerImageRef struct {
+	// domain is a domain of the registry. It is empty when omitted (Docker Hub).
+	domain string
+	// path is a path of the image in the registry like "owner/image".
+	path string
+	// tag is a tag of the image. It is empty when omitted.
+	tag string
+	// digest is a digest of the image like "sha256:...". It is empty when omitted.
+	digest string
+}
+
+// parseDockerImageRef parses the Docker image reference. "docker://" prefix is not included in the
+// argument.
+func parseDockerImageRef(s string) (*dockerImageRef, error) {
+	if s == "" {
+		return nil, errors.New("image name is empty")
+	}
+
+	r := &dockerImageRef{}
+	if name, digest, ok := strings.Cut(s, "@"); ok {
+		if !dockerImageDigestPattern.MatchString(digest) {
+			return nil, fmt.Errorf("digest %q is invalid. digest must be in the format \"{algorithm}:{hex}\" like \"sha256:0123abcd...\"", digest)
+		}
+		s, r.digest = name, digest
+	}
+
+	if i := strings.LastIndexByte(s, ':'); i > strings.LastIndexByte(s, '/') {
+		t := s[i+1:]
+		if !dockerImageTagPattern.MatchString(t) {
+			return nil, fmt.Errorf("tag %q is invalid. tag must consist of alphanumeric characters, '_', '.', and '-' and must be at most 128 characters", t)
+		}
+		s, r.tag = s[:i], t
+	}
+
+	if d, p, ok := strings.Cut(s, "/"); ok && (strings.ContainsAny(d, ".:") || d == "localhost" || strings.ToLower(d) != d) {
+		if !dockerImageDomainPattern.MatchString(d) {
+			return nil, fmt.Errorf("domain %q of registry is invalid", d)
+		}
+		r.domain, s = d, p
+	}
+
+	if len(s) > 255 {
+		return nil, fmt.Errorf("repository name must not be longer than 255 characters but it has %d characters", len(s))
+	}
+	for _, c := range strings.Split(s, "/") {
+		if !dockerImagePathCompPattern.MatchString(c) {
+			return nil, fmt.Errorf("repository name %q is invalid. each component separated by '/' must consist of lower-case alphanumeric characters and separators '.', '_', '__', or '-'", s)
+		}
+	}
+	r.path = s
+
+	return r, nil
+}
+
+// registry returns the host of the registry.
+func (r *dockerImageRef) registry() string {
+	switch r.domain {
+	case "", "docker.io", "index.docker.io":
+		return dockerHubRegistry
+	default:
+		return r.domain
+	}
+}
+
+// repository returns the repository path in the registry.
+func (r *dockerImageRef) repository() string {
+	if r.registry() == dockerHubRegistry && !strings.Contains(r.path, "/") {
+		return "library/" + r.path // Official images on Docker Hub
+	}
+	return r.path
+}
+
+// reference returns the tag or the digest of the image. "latest" is returned when both are omitted.
+func (r *dockerImageRef) reference() string {
+	if r.digest != "" {
+		return r.digest
+	}
+	if r.tag != "" {
+		return r.tag
+	}
+	return "latest"
+}
+
+func (r *dockerImageRef) String() string {
+	s := r.path
+	if r.domain != "" {
+		s = r.domain + "/" + s
+	}
+	if r.tag != "" {
+		s += ":" + r.tag
+	}
+	if r.digest != "" {
+		s += "@" + r.digest
+	}
+	return s
+}
+
+var wwwAuthenticateParamPattern = regexp.MustCompile(`(\w+)="([^"]*)"`)
+
+// dockerRegistryClient is a client of Docker Registry HTTP API V2 to check images exist in online
+// checks. Results are cached in memory and also on disk when the cache is given. Calling methods of
+// this struct is thread-safe.
+// https://distribution.github.io/distribution/spec/api/
+type dockerRegistryClient struct {
+	client    *http.Client
+	manifests sync.Map // Image reference -> func() (bool, error)
+	cache     *remoteCache
+	warn      io.Writer
+	warned    sync.Map
+	dbg       io.Writer
+}
+
+func newDockerRegistryClient(cache *remoteCache, warn, dbg io.Writer) *dockerRegistryClient {
+	return &dockerRegistryClient{
+		client: &http.Client{Timeout: 30 * time.Second},
+		cache:  cache,
+		warn:   warn,
+		dbg:    dbg,
+	}
+}
+
+func (c *dockerRegistryClient) debug(format string, args ...interface{}) {
+	if c.dbg == nil {
+		return
+	}
+	format = "[DockerRegistryClient] " + format + "\n"
+	fmt.Fprintf(c.dbg, format, args...)
+}
+
+// credentials returns the user name and the password for the registry from the config.
+func credentialsOfDockerRegistry(cfg *Config, registry string) (string, string) {
+	if cfg == nil {
+		return "", ""
+	}
+	for h, r := range cfg.DockerRegistries {
+		if h == registry || (registry == dockerHubRegistry && (h == "docker.io" || h == "index.docker.io")) {
+			return r.Username, os.Getenv(r.PasswordEnv)
+		}
+	}
+	return "", ""
+}
+
+func (c *dockerRegistryClient) send(method, u, user, pass, token string) (*http.Response, error) {
+	c.debug("%s %s", method, u)
+	req, err := http.NewRequest(method, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", strings.Join([]string{
+		"application/vnd.oci.image.index.v1+json",
+		"application/vnd.oci.image.manifest.v1+json",
+		"application/vnd.docker.distribution.manifest.list.v2+json",
+		"application/vnd.docker.distribution.manifest.v2+json",
+	}, ", "))
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	} else if user != "" || pass != "" {
+		req.SetBasicAuth(user, pass)
+	}
+	res, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	res.Body.Close()
+	return res, nil
+}
+
+// token gets a bearer token for pulling the repository following the WWW-Authenticate header.
+// https://distribution.github.io/distribution/spec/auth/token/
+func (c *dockerRegistryClient) token(challenge, repo, user, pass string) (string, error) {
+	params := map[string]string{}
+	for _, m := range wwwAuthenticateParamPattern.FindAllStringSubmatch(challenge, -1) {
+		params[m[1]] = m[2]
+	}
+	realm := params["realm"]
+	if realm == "" {
+		return "", fmt.Errorf("realm is missing in WWW-Authenticate header %q", challenge)
+	}
+	q := url.Values{}
+	if s := params["service"]; s != "" {
+		q.Set("service", s)
+	}
+	q.Set("scope", "repository:"+repo+":pull")
+	u := realm + "?" + q.Encode()
+
+	c.debug("GET %s", u)
+	req, err := http.NewRequest(http.MethodGet, u, nil)
+	if err != nil {
+		return "", err
+	}
+	if user != "" || pass != "" {
+		req.SetBasicAuth(user, pass)
+	}
+	res, err := c.client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("could not get token from %s: status %d", realm, res.StatusCode)
+	}
+
+	var t struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&t); err != nil {
+		return "", fmt.Errorf("could not parse token response from %s: %w", realm, err)
+	}
+	if t.Token != "" {
+		return t.Token, nil
+	}
+	return t.AccessToken, nil
+}
+
+func (c *dockerRegistryClient) fetchManifestExists(img *dockerImageRef, user, pass string) (bool, error) {
+	host := img.registry()
+	scheme := "https"
+	if strings.HasPrefix(host, "localhost") || strings.HasPrefix(host, "127.") {
+		scheme = "http" // Docker allows insecure local registries by default
+	}
+	repo := img.repository()
+	u := fmt.Sprintf("%s://%s/v2/%s/manifests/%s", scheme, host, repo, img.reference())
+
+	res, err := c.send(http.MethodHead, u, user, pass, "")
+	if err != nil {
+		return false, err
+	}
+	if res.StatusCode == http.StatusUnauthorized {
+		challenge := res.Header.Get("WWW-Authenticate")
+		if strings.HasPrefix(strings.ToLower(challenge), "bearer ") {
+			t, err := c.token(challenge, repo, user, pass)
+			if err != nil {
+				return false, err
+			}
+			res, err = c.send(http.MethodHead, u, "", "", t)
+			if err != nil {
+				return false, err
+			}
+		}
+	}
+
+	switch res.StatusCode {
+	case http.StatusOK:
+		return true, nil
+	case http.StatusNotFound:
+		return false, nil
+	default:
+		return false, fmt.Errorf("request to %s failed with status %d", u, res.StatusCode)
+	}
+}
+
+// manifestExists returns whether the manifest of the image exists in the registry. When the
+// existence could not be confirmed due to some error such as network error or lack of credentials,
+// it returns an error.
+func (c *dockerRegistryClient) manifestExists(img *dockerImageRef, cfg *Config) (bool, error) {
+	k := img.String()
+	f, ok := c.manifests.Load(k)
+	if !ok {
+		f, _ = c.manifests.LoadOrStore(k, sync.OnceValues(func() (bool, error) {
+			user, pass := credentialsOfDockerRegistry(cfg, img.registry())
+			return c.cachedManifestExists(img, user, pass)
+		}))
+	}
+	return f.(func() (bool, error))()
+}
+
+// cachedManifestExists is the same as fetchManifestExists but the result is cached on disk. When the
+// registry is not available, the expired result is used as fallback.
+func (c *dockerRegistryClient) cachedManifestExists(img *dockerImageRef, user, pass string) (bool, error) {
+	// The result may depend on the credentials
+	key := fmt.Sprintf("docker-manifest %s@%s", user, img)
+	cached := func(stale bool) (bool, bool) {
+		e, ok := c.cache.load(key, stale)
+		if !ok {
+			return false, false
+		}
+		var b bool
+		if err := json.Unmarshal(e.Body, &b); err != nil {
+			return false, false
+		}
+		return b, true
+	}
+
+	if b, ok := cached(false); ok {
+		return b, nil
+	}
+	b, err := c.fetchManifestExists(img, user, pass)
+	if err != nil {
+		if b, ok := cached(true); ok {
+			c.debug("Use expired cached result since fetching manifest of %s failed: %v", img, err)
+			return b, nil
+		}
+		return false, err
+	}
+	c.cache.store(key, &remoteCacheEntry{Body: json.RawMessage(strconv.FormatBool(b))})
+	return b, nil
+}
+
+// warnOnce outputs the warning once per image.
+func (c *dockerRegistryClient) warnOnce(img *dockerImageRef, err error) {
+	if c.warn == nil {
+		return
+	}
+	k := img.String()
+	if _, loaded := c.warned.LoadOrStore(k, struct{}{}); loaded {
+		return
+	}
+	fmt.Fprintf(c.warn, "warning: could not fetch manifest of Docker image %q for online checks. the check is skipped: %v\n", k, err)
+}
@@ -0,0 +1,275 @@
+package actionlint
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestDockerImageParseRefOK(t *testing.T) {
+	tests := []struct {
+		input      string
+		registry   string
+		repository string
+		reference  string
+	}{
+		{"alpine", "registry-1.docker.io", "library/alpine", "latest"},
+		{"alpine:3.20", "registry-1.docker.io", "library/alpine", "3.20"},
+		{"owner/image:v1", "registry-1.docker.io", "owner/image", "v1"},
+		{"docker.io/owner/image:v1", "registry-1.docker.io", "owner/image", "v1"},
+		{"ghcr.io/owner/image:v1.2.3", "ghcr.io", "owner/image", "v1.2.3"},
+		{"localhost:5000/foo/bar:latest", "localhost:5000", "foo/bar", "latest"},
+		{"example.com:latest", "registry-1.docker.io", "library/example.com", "latest"},
+		{"gcr.io/project/a_b__c-d/e:tag", "gcr.io", "project/a_b__c-d/e", "tag"},
+		{
+			"alpine@sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef",
+			"registry-1.docker.io",
+			"library/alpine",
+			"sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef",
+		},
+		{
+			"alpine:3@sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef",
+			"registry-1.docker.io",
+			"library/alpine",
+			"sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.input, func(t *testing.T) {
+			r, err := parseDockerImageRef(tc.input)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if s := r.registry(); s != tc.registry {
+				t.Errorf("wanted registry %q but got %q", tc.registry, s)
+			}
+			if s := r.repository(); s != tc.repository {
+				t.Errorf("wanted repository %q but got %q", tc.repository, s)
+			}
+			if s := r.reference(); s != tc.reference {
+				t.Errorf("wanted reference %q but got %q", tc.reference, s)
+			}
+			if s := r.String(); s != tc.input {
+				t.Errorf("wanted %q as string representation but got %q", tc.input, s)
+			}
+		})
+	}
+}
+
+func TestDockerImageParseRefError(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"", "image name is empty"},
+		{"Alpine:3", `repository name "Alpine" is invalid`},
+		{"owner/Image", `repository name "owner/Image" is invalid`},
+		{"owner//image", `repository name "owner//image" is invalid`},
+		{"alpine:-foo", `tag "-foo" is invalid`},
+		{"alpine:a b", `tag "a b" is invalid`},
+		{"alpine@sha256:xyz", `digest "sha256:xyz" is invalid`},
+		{"-bad.io/owner/image", `domain "-bad.io" of registry is invalid`},
+		{strings.Repeat("a", 256), "must not be longer than 255 characters"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.input, func(t *testing.T) {
+			_, err := parseDockerImageRef(tc.input)
+			if err == nil {
+				t.Fatal("error did not occur")
+			}
+			if msg := err.Error(); !strings.Contains(msg, tc.want) {
+				t.Fatalf("error message %q does not contain %q", msg, tc.want)
+			}
+		})
+	}
+}
+
+func TestDockerImageRegistryManifestExists(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		switch req.URL.Path {
+		case "/v2/owner/image/manifests/v1":
+			w.WriteHeader(http.StatusOK)
+		case "/v2/owner/broken/manifests/v1":
+			w.WriteHeader(http.StatusInternalServerError)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+	host := strings.TrimPrefix(srv.URL, "http://")
+
+	var warn strings.Builder
+	c := newDockerRegistryClient(nil, &warn, nil)
+
+	tests := []struct {
+		image string
+		want  bool
+		err   bool
+	}{
+		{"owner/image:v1", true, false},
+		{"owner/image:v2", false, false},
+		{"owner/broken:v1", false, true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.image, func(t *testing.T) {
+			img, err := parseDockerImageRef(host + "/" + tc.image)
+			if err != nil {
+				t.Fatal(err)
+			}
+			ok, err := c.manifestExists(img, nil)
+			if tc.err {
+				if err == nil {
+					t.Fatal("error did not occur")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if ok != tc.want {
+				t.Fatalf("wanted %v but got %v", tc.want, ok)
+			}
+		})
+	}
+
+	img, _ := parseDockerImageRef(host + "/owner/broken:v1")
+	_, err := c.manifestExists(img, nil)
+	c.warnOnce(img, err)
+	c.warnOnce(img, err)
+	if n := strings.Count(warn.String(), "could not fetch manifest of Docker image"); n != 1 {
+		t.Fatalf("warning should be output once but it was output %d times: %q", n, warn.String())
+	}
+}
+
+func TestDockerImageRegistryBearerToken(t *testing.T) {
+	t.Setenv("TEST_DOCKER_REGISTRY_PASSWORD", "secret")
+
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		switch req.URL.Path {
+		case "/token":
+			if u, p, ok := req.BasicAuth(); !ok || u != "user" || p != "secret" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			if s := req.URL.Query().Get("scope"); s != "repository:owner/image:pull" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			fmt.Fprint(w, `{"token":"test-token"}`)
+		case "/v2/owner/image/manifests/v1":
+			if req.Header.Get("Authorization") != "Bearer test-token" {
+				w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm="%s/token",service="test"`, srv.URL))
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+	host := strings.TrimPrefix(srv.URL, "http://")
+
+	cfg := &Config{
+		DockerRegistries: map[string]*DockerRegistryConfig{
+			host: {Username: "user", PasswordEnv: "TEST_DOCKER_REGISTRY_PASSWORD"},
+		},
+	}
+	img, err := parseDockerImageRef(host + "/owner/image:v1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c := newDockerRegistryClient(nil, nil, nil)
+	ok, err := c.manifestExists(img, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ok {
+		t.Fatal("manifest should exist")
+	}
+
+	// Without credentials, getting token fails
+	c = newDockerRegistryClient(nil, nil, nil)
+	if _, err := c.manifestExists(img, nil); err == nil {
+		t.Fatal("error did not occur without credentials")
+	}
+
+	// Credentials in config are used by the rule only when the config is trusted
+	uses := "docker://" + host + "/owner/image:v1"
+	for _, trusted := range []bool{false, true} {
+		var warn strings.Builder
+		r := NewRuleAction(nil)
+		r.registry = newDockerRegistryClient(nil, &warn, nil)
+		r.credentials = trusted
+		r.SetConfig(cfg)
+		r.checkDockerAction(uses, &ExecAction{Uses: &String{Value: uses, Pos: &Pos{}}})
+		if errs := r.Errs(); len(errs) > 0 {
+			t.Fatalf("no error should be reported but got %v", errs)
+		}
+		if warned := strings.Contains(warn.String(), "could not fetch manifest"); warned == trusted {
+			t.Fatalf("credentials should be used only when config is trusted. trusted=%v, warning=%q", trusted, warn.String())
+		}
+	}
+}
+
+func TestDockerImageRegistryManifestCacheOnDisk(t *testing.T) {
+	var calls atomic.Int32
+	var down atomic.Bool
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		calls.Add(1)
+		if down.Load() {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		if req.URL.Path == "/v2/owner/image/manifests/v1" {
+			w.WriteHeader(http.StatusOK)
+		} else {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+	host := strings.TrimPrefix(srv.URL, "http://")
+
+	check := func(c *dockerRegistryClient, image string, want bool) {
+		t.Helper()
+		img, err := parseDockerImageRef(host + "/" + image)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ok, err := c.manifestExists(img, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ok != want {
+			t.Fatalf("wanted %v for %s but got %v", want, image, ok)
+		}
+	}
+
+	dir := t.TempDir()
+	for i := 0; i < 2; i++ {
+		// Create a new instance each time so that the in-memory cache is not used
+		c := newDockerRegistryClient(newRemoteCache(dir, time.Hour, nil), nil, nil)
+		check(c, "owner/image:v1", true)
+		check(c, "owner/image:v2", false)
+	}
+	if n := calls.Load(); n != 2 {
+		t.Fatalf("registry should be called twice but called %d times", n)
+	}
+
+	// Expired cache is used when the registry is not available
+	down.Store(true)
+	c := newDockerRegistryClient(newRemoteCache(dir, time.Nanosecond, nil), nil, nil)
+	check(c, "owner/image:v1", true)
+	check(c, "owner/image:v2", false)
+	if n := calls.Load(); n != 4 {
+		t.Fatalf("registry should be called again after the cache expired but called %d times", n)
+	}
+}
@@ -0,0 +1,697 @@
+package actionlint
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// DuplicateStepsMinLen is the default minimum number of steps in duplicated step sequences.
+const DuplicateStepsMinLen = 2
+
+// duplicateStepsActionNamePattern matches names of composite actions which can be used as directory
+// names safely.
+var duplicateStepsActionNamePattern = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_.-]*$`)
+
+// duplicateStepsContexts is the set of contexts which are available in the steps of composite
+// actions and have the same values as in the steps of workflows.
+var duplicateStepsContexts = map[string]struct{}{
+	"github": {},
+	"runner": {},
+	"env":    {},
+}
+
+// DuplicateStepsLocation is a location of duplicated step sequence.
+type DuplicateStepsLocation struct {
+	// Path is the file path of the workflow.
+	Path string
+	// Job is the job which contains the steps.
+	Job *Job
+	// Steps is the duplicated steps in the job.
+	Steps []*Step
+	file  *duplicateStepsFile
+	start int // Index of the first step in the job
+}
+
+// Pos returns the position of the first step.
+func (l *DuplicateStepsLocation) Pos() *Pos {
+	return l.Steps[0].Pos
+}
+
+// DuplicateSteps is a cluster of step sequences which are duplicated across jobs and workflows. The
+// steps in the sequences are the same except for their names. When near-identical sequences are
+// searched, some steps in the sequences may differ in their details.
+type DuplicateSteps struct {
+	// Locations is the locations of the duplicated step sequences in the order of the added
+	// workflows and their job IDs.
+	Locations []*DuplicateStepsLocation
+	differ    []int
+}
+
+// Differences returns the 0-based indices of the steps which are not identical across the locations.
+// It returns nil when all the steps in the sequences are identical.
+func (d *DuplicateSteps) Differences() []int {
+	return d.differ
+}
+
+// Len returns the number of steps in the duplicated step sequence.
+func (d *DuplicateSteps) Len() int {
+	return len(d.Locations[0].Steps)
+}
+
+// Summary returns the short descriptions of the duplicated steps such as their names, actions at
+// "uses:", or the first lines of scripts at "run:".
+func (d *DuplicateSteps) Summary() []string {
+	ss := make([]string, 0, d.Len())
+	for _, s := range d.Locations[0].Steps {
+		ss = append(ss, duplicateStepsDescribe(s))
+	}
+	return ss
+}
+
+func duplicateStepsDescribe(s *Step) string {
+	if s.Name != nil && s.Name.Value != "" {
+		return s.Name.Value
+	}
+	switch e := s.Exec.(type) {
+	case *ExecAction:
+		if e.Uses != nil {
+			return e.Uses.Value
+		}
+	case *ExecRun:
+		if e.Run != nil {
+			l, _, _ := strings.Cut(strings.TrimSpace(e.Run.Value), "\n")
+			if len(l) > 40 {
+				l = l[:37] + "..."
+			}
+			return l
+		}
+	}
+	return "(unknown step)"
+}
+
+type duplicateStepsFile struct {
+	path     string
+	src      []byte
+	lines    []string
+	workflow *Workflow
+}
+
+// duplicateStepsJob is steps of a job with the keys to compare them. The exact keys are different
+// from the keys only when near-identical steps are searched.
+type duplicateStepsJob struct {
+	file    *duplicateStepsFile
+	job     *Job
+	keys    []int
+	exact   []int
+	covered []bool
+}
+
+// DuplicateStepsFinder finds step sequences which are duplicated across jobs and workflows. Such
+// sequences can be extracted into a local composite action with DuplicateSteps.Extract.
+type DuplicateStepsFinder struct {
+	min  int
+	near bool
+	jobs []*duplicateStepsJob
+	keys map[string]int
+}
+
+// NewDuplicateStepsFinder creates a new DuplicateStepsFinder instance. The min argument is the
+// minimum number of steps in the duplicated sequences to find. When it is less than 1,
+// DuplicateStepsMinLen is used.
+func NewDuplicateStepsFinder(min int) *DuplicateStepsFinder {
+	if min < 1 {
+		min = DuplicateStepsMinLen
+	}
+	return &DuplicateStepsFinder{min: min, keys: map[string]int{}}
+}
+
+// SetNear sets whether near-identical step sequences are also searched. Steps are near-identical when
+// they run the same action with the same input names or run the same script ignoring whitespaces
+// and comments, even if versions of the action, input values, "if:", "env:" values, or working
+// directories differ. This method must be called before Add.
+func (f *DuplicateStepsFinder) SetNear(near bool) {
+	f.near = near
+}
+
+// Add parses the workflow source at the path and adds its jobs to the targets to find duplicated
+// steps.
+func (f *DuplicateStepsFinder) Add(path string, src []byte) error {
+	w, errs := Parse(src)
+	if w == nil {
+		if len(errs) > 0 {
+			return fmt.Errorf("could not parse workflow %q: %w", path, errs[0])
+		}
+		return fmt.Errorf("could not parse workflow %q", path)
+	}
+
+	file := &duplicateStepsFile{
+		path:     path,
+		src:      src,
+		lines:    strings.Split(string(src), "\n"),
+		workflow: w,
+	}
+	ids := make([]string, 0, len(w.Jobs))
+	for id := range w.Jobs {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+	for _, id := range ids {
+		j := w.Jobs[id]
+		if len(j.Steps) < f.min {
+			continue
+		}
+		keys := make([]int, 0, len(j.Steps))
+		var exact []int
+		for _, s := range j.Steps {
+			k := duplicateStepsKey(s, w, j)
+			if f.near {
+				exact = append(exact, f.intern(k))
+				k = duplicateStepsNearKey(s, w, j)
+			}
+			keys = append(keys, f.intern(k))
+		}
+		if !f.near {
+			exact = keys
+		}
+		f.jobs = append(f.jobs, &duplicateStepsJob{file, j, keys, exact, make([]bool, len(keys))})
+	}
+	return nil
+}
+
+func (f *DuplicateStepsFinder) intern(k string) int {
+	n, ok := f.keys[k]
+	if !ok {
+		n = len(f.keys)
+		f.keys[k] = n
+	}
+	return n
+}
+
+// duplicateStepsKey returns the key to compare the step with other steps. Names of steps are not
+// included since they are not related to the behavior. Shell and working directory of "run:" step
+// are resolved with the default values of its job and workflow.
+func duplicateStepsKey(s *Step, w *Workflow, j *Job) string {
+	var b strings.Builder
+	str := func(k string, s *String) {
+		if s != nil {
+			fmt.Fprintf(&b, "%s=%q\n", k, s.Value)
+		}
+	}
+
+	str("id", s.ID)
+	str("if", s.If)
+	switch e := s.Exec.(type) {
+	case *ExecRun:
+		str("run", e.Run)
+		fmt.Fprintf(&b, "shell=%q\n", duplicateStepsShell(e, w, j))
+		str("working-directory", duplicateStepsWorkingDir(e, w, j))
+	case *ExecAction:
+		str("uses", e.Uses)
+		ids := make([]string, 0, len(e.Inputs))
+		for id := range e.Inputs {
+			ids = append(ids, id)
+		}
+		slices.Sort(ids)
+		for _, id := range ids {
+			str("with."+id, e.Inputs[id].Value)
+		}
+		str("entrypoint", e.Entrypoint)
+		str("args", e.Args)
+	}
+	if s.Env != nil {
+		str("env", s.Env.Expression)
+		names := make([]string, 0, len(s.Env.Vars))
+		for n := range s.Env.Vars {
+			names = append(names, n)
+		}
+		slices.Sort(names)
+		for _, n := range names {
+			str("env."+n, s.Env.Vars[n].Value)
+		}
+	}
+	if c := s.ContinueOnError; c != nil {
+		if c.Expression != nil {
+			str("continue-on-error", c.Expression)
+		} else {
+			fmt.Fprintf(&b, "continue-on-error=%v\n", c.Value)
+		}
+	}
+	if t := s.TimeoutMinutes; t != nil {
+		if t.Expression != nil {
+			str("timeout-minutes", t.Expression)
+		} else {
+			fmt.Fprintf(&b, "timeout-minutes=%v\n", t.Value)
+		}
+	}
+	return b.String()
+}
+
+// duplicateStepsNearKey returns the key to compare the step with other steps loosely. Refs of actions,
+// values of inputs and environment variables, "id:", "if:", and working directories are not included.
+// Scripts at "run:" are compared ignoring whitespaces, blank lines, and comment lines.
+func duplicateStepsNearKey(s *Step, w *Workflow, j *Job) string {
+	var b strings.Builder
+	switch e := s.Exec.(type) {
+	case *ExecRun:
+		if e.Run != nil {
+			b.WriteString("run=")
+			for _, l := range strings.Split(e.Run.Value, "\n") {
+				l = strings.Join(strings.Fields(l), " ")
+				if l == "" || strings.HasPrefix(l, "#") {
+					continue
+				}
+				fmt.Fprintf(&b, "%q;", l)
+			}
+			b.WriteByte('\n')
+		}
+		fmt.Fprintf(&b, "shell=%q\n", duplicateStepsShell(e, w, j))
+	case *ExecAction:
+		if e.Uses != nil {
+			n, _, _ := strings.Cut(e.Uses.Value, "@")
+			fmt.Fprintf(&b, "uses=%q\n", strings.ToLower(n))
+		}
+		ids := make([]string, 0, len(e.Inputs))
+		for id := range e.Inputs {
+			ids = append(ids, id)
+		}
+		slices.Sort(ids)
+		fmt.Fprintf(&b, "with=%q\n", ids)
+	}
+	if s.Env != nil {
+		names := make([]string, 0, len(s.Env.Vars))
+		for n := range s.Env.Vars {
+			names = append(names, n)
+		}
+		slices.Sort(names)
+		fmt.Fprintf(&b, "env=%q\n", names)
+	}
+	return b.String()
+}
+
+// duplicateStepsShell returns the shell to run the script at "run:". It falls back to the default
+// shell of the runner. Note that the default shell on non-Windows runners is "bash -e {0}", which is
+// different from "bash" in the "pipefail" option.
+func duplicateStepsShell(e *ExecRun, w *Workflow, j *Job) string {
+	if e.Shell != nil {
+		return e.Shell.Value
+	}
+	if j.Defaults != nil && j.Defaults.Run != nil && j.Defaults.Run.Shell != nil {
+		return j.Defaults.Run.Shell.Value
+	}
+	if w.Defaults != nil && w.Defaults.Run != nil && w.Defaults.Run.Shell != nil {
+		return w.Defaults.Run.Shell.Value
+	}
+	if j.RunsOn != nil {
+		for _, label := range j.RunsOn.Labels {
+			l := strings.ToLower(label.Value)
+			// Default shell on Windows is PowerShell
+			if l == "windows" || strings.HasPrefix(l, "windows-") {
+				return "pwsh"
+			}
+		}
+	}
+	return "bash -e {0}"
+}
+
+func duplicateStepsWorkingDir(e *ExecRun, w *Workflow, j *Job) *String {
+	if e.WorkingDirectory != nil {
+		return e.WorkingDirectory
+	}
+	if j.Defaults != nil && j.Defaults.Run != nil && j.Defaults.Run.WorkingDirectory != nil {
+		return j.Defaults.Run.WorkingDirectory
+	}
+	if w.Defaults != nil && w.Defaults.Run != nil {
+		return w.Defaults.Run.WorkingDirectory
+	}
+	return nil
+}
+
+// Find finds the duplicated step sequences in the added workflows. Longer sequences are found
+// first and steps in them are not included in shorter sequences. The sequences at the same job do
+// not overlap. The returned clusters are sorted by the number of duplicated steps in descending
+// order.
+func (f *DuplicateStepsFinder) Find() []*DuplicateSteps {
+	longest := 0
+	for _, j := range f.jobs {
+		longest = max(longest, len(j.keys))
+	}
+
+	ret := []*DuplicateSteps{}
+	for n := longest; n >= f.min; n-- {
+		windows := map[string][]*DuplicateStepsLocation{}
+		order := []string{}
+		for _, j := range f.jobs {
+		Windows:
+			for i := 0; i+n <= len(j.keys); i++ {
+				for _, c := range j.covered[i : i+n] {
+					if c {
+						continue Windows
+					}
+				}
+				k := fmt.Sprint(j.keys[i : i+n])
+				if _, ok := windows[k]; !ok {
+					order = append(order, k)
+				}
+				l := &DuplicateStepsLocation{
+					Path:  j.file.path,
+					Job:   j.job,
+					Steps: j.job.Steps[i : i+n],
+					file:  j.file,
+					start: i,
+				}
+				windows[k] = append(windows[k], l)
+			}
+		}
+
+		for _, k := range order {
+			ls := f.pick(windows[k], n)
+			if len(ls) < 2 {
+				continue
+			}
+			for _, l := range ls {
+				j := f.jobOf(l)
+				for i := l.start; i < l.start+n; i++ {
+					j.covered[i] = true
+				}
+			}
+			ret = append(ret, &DuplicateSteps{ls, f.differences(ls, n)})
+		}
+	}
+	return ret
+}
+
+// pick picks the locations of the sequence which do not overlap with each other and with the steps
+// already covered by other sequences.
+func (f *DuplicateStepsFinder) pick(cands []*DuplicateStepsLocation, n int) []*DuplicateStepsLocation {
+	ret := []*DuplicateStepsLocation{}
+	ends := map[*Job]int{}
+Cands:
+	for _, l := range cands {
+		if e, ok := ends[l.Job]; ok && l.start < e {
+			continue
+		}
+		j := f.jobOf(l)
+		for _, c := range j.covered[l.start : l.start+n] {
+			if c {
+				continue Cands
+			}
+		}
+		ends[l.Job] = l.start + n
+		ret = append(ret, l)
+	}
+	return ret
+}
+
+// differences returns the indices of the steps which are not identical across the locations.
+func (f *DuplicateStepsFinder) differences(ls []*DuplicateStepsLocation, n int) []int {
+	var ret []int
+	first := f.jobOf(ls[0])
+	for i := 0; i < n; i++ {
+		k := first.exact[ls[0].start+i]
+		for _, l := range ls[1:] {
+			if f.jobOf(l).exact[l.start+i] != k {
+				ret = append(ret, i)
+				break
+			}
+		}
+	}
+	return ret
+}
+
+func (f *DuplicateStepsFinder) jobOf(l *DuplicateStepsLocation) *duplicateStepsJob {
+	for _, j := range f.jobs {
+		if j.job == l.Job {
+			return j
+		}
+	}
+	panic("unreachable")
+}
+
+// DuplicateStepsExtraction is the result of extracting duplicated steps into a local composite
+// action.
+type DuplicateStepsExtraction struct {
+	// Steps is the number of the extracted steps. Leading steps running actions/checkout are not
+	// extracted so this may be smaller than the number of the duplicated steps.
+	Steps int
+	// Uses is the value of "uses:" to run the extracted composite action like
+	// "./.github/actions/setup".
+	Uses string
+	// ActionPath is the file path of the composite action relative to the repository root like
+	// ".github/actions/setup/action.yml".
+	ActionPath string
+	// Action is the content of action.yml of the composite action.
+	Action []byte
+	// Workflows is the mapping from the file paths of the workflows to their rewritten sources.
+	Workflows map[string][]byte
+}
+
+// Extract extracts the duplicated steps into a local composite action with the name. The steps of
+// the first location are copied to the action. The duplicated steps in the workflows are replaced
+// with a step which runs the action. Shell and working directory of "run:" steps are explicitly set
+// in the action since composite actions do not have the default values.
+//
+// It returns an error when the steps cannot be moved to the composite action safely. For example,
+// steps with "id:" cannot be extracted since their outputs may be referenced by other steps, and
+// steps using contexts which have different values in composite actions such as "secrets" or
+// "matrix" cannot be extracted.
+func (d *DuplicateSteps) Extract(name string) (*DuplicateStepsExtraction, error) {
+	if len(d.differ) > 0 {
+		l := d.Locations[0]
+		p := l.Steps[d.differ[0]].Pos
+		return nil, fmt.Errorf("steps cannot be extracted since step at %s:%d:%d differs from the near-identical steps in other places. make the steps identical before extracting them", l.Path, p.Line, p.Col)
+	}
+	if !duplicateStepsActionNamePattern.MatchString(name) {
+		return nil, fmt.Errorf("invalid name of composite action %q. it must consist of alphabets, digits, '_', '.', and '-'", name)
+	}
+
+	// Local actions cannot be run before checking out the repository. Leading checkout steps are
+	// kept in the workflows.
+	skip := 0
+	for skip < d.Len() && duplicateStepsIsCheckout(d.Locations[0].Steps[skip]) {
+		skip++
+	}
+	if skip == d.Len() {
+		return nil, errors.New("no step can be extracted since all the duplicated steps check out the repository with actions/checkout")
+	}
+	for _, s := range d.Locations[0].Steps[skip:] {
+		if duplicateStepsIsCheckout(s) {
+			return nil, fmt.Errorf("step at %s cannot be extracted since local composite action cannot be run before checking out the repository with actions/checkout", s.Pos)
+		}
+		if err := duplicateStepsCheckExtractable(s); err != nil {
+			return nil, err
+		}
+	}
+
+	uses := "./.github/actions/" + name
+	action, err := d.compositeAction(name, skip)
+	if err != nil {
+		return nil, err
+	}
+
+	writers := map[*duplicateStepsFile]*WorkflowWriter{}
+	order := []*duplicateStepsFile{}
+	for _, l := range d.Locations {
+		w, ok := writers[l.file]
+		if !ok {
+			w = NewWorkflowWriter(l.file.src)
+			writers[l.file] = w
+			order = append(order, l.file)
+		}
+		start, _, err := duplicateStepsRange(l.file.lines, l.Steps[skip])
+		if err != nil {
+			return nil, err
+		}
+		_, end, err := duplicateStepsRange(l.file.lines, l.Steps[len(l.Steps)-1])
+		if err != nil {
+			return nil, err
+		}
+		indent := strings.Index(l.file.lines[start-1], "-")
+		text := fmt.Sprintf("%s- uses: %s", strings.Repeat(" ", indent), uses)
+		if end < len(l.file.lines) {
+			w.Edit(&TextEdit{Start: &Pos{Line: start, Col: 1}, End: &Pos{Line: end + 1, Col: 1}, NewText: text + "\n"})
+		} else {
+			w.Edit(&TextEdit{Start: &Pos{Line: start, Col: 1}, End: &Pos{Line: end, Col: len(l.file.lines[end-1]) + 1}, NewText: text})
+		}
+	}
+
+	ws := make(map[string][]byte, len(order))
+	for _, f := range order {
+		b, err := writers[f].Bytes()
+		if err != nil {
+			return nil, err
+		}
+		ws[f.path] = b
+	}
+
+	return &DuplicateStepsExtraction{
+		Steps:      d.Len() - skip,
+		Uses:       uses,
+		ActionPath: ".github/actions/" + name + "/action.yml",
+		Action:     action,
+		Workflows:  ws,
+	}, nil
+}
+
+// compositeAction generates action.yml of the composite action from the steps at the first location.
+// The first 'skip' steps are not included.
+func (d *DuplicateSteps) compositeAction(name string, skip int) ([]byte, error) {
+	l := d.Locations[0]
+	var b strings.Builder
+	fmt.Fprintf(&b, "name: %s\n", name)
+	fmt.Fprintf(&b, "description: Steps extracted from job %q in %s\n", l.Job.ID.Value, filepath.Base(l.Path))
+	b.WriteString("runs:\n  using: composite\n  steps:\n")
+
+	const indent = "    "
+	for _, s := range l.Steps[skip:] {
+		start, end, err := duplicateStepsRange(l.file.lines, s)
+		if err != nil {
+			return nil, err
+		}
+		dash := strings.Index(l.file.lines[start-1], "-")
+		for _, line := range l.file.lines[start-1 : end] {
+			if strings.TrimSpace(line) == "" {
+				b.WriteByte('\n')
+				continue
+			}
+			b.WriteString(indent)
+			b.WriteString(line[dash:])
+			b.WriteByte('\n')
+		}
+
+		e, ok := s.Exec.(*ExecRun)
+		if !ok {
+			continue
+		}
+		key := indent + strings.Repeat(" ", s.Pos.Col-1-dash)
+		if e.Shell == nil {
+			fmt.Fprintf(&b, "%sshell: %s\n", key, duplicateStepsYAMLString(duplicateStepsShell(e, l.file.workflow, l.Job)))
+		}
+		if e.WorkingDirectory == nil {
+			if d := duplicateStepsWorkingDir(e, l.file.workflow, l.Job); d != nil {
+				fmt.Fprintf(&b, "%sworking-directory: %s\n", key, duplicateStepsYAMLString(d.Value))
+			}
+		}
+	}
+	return []byte(b.String()), nil
+}
+
+func duplicateStepsYAMLString(v string) string {
+	if workflowWriterPlainSafe(v) {
+		return v
+	}
+	return strconv.Quote(v)
+}
+
+func duplicateStepsIsCheckout(s *Step) bool {
+	e, ok := s.Exec.(*ExecAction)
+	return ok && e.Uses != nil && strings.HasPrefix(strings.ToLower(e.Uses.Value), "actions/checkout@")
+}
+
+// duplicateStepsRange returns the range of lines of the step in the source. Both the start and the
+// end are inclusive and 1-based. The "-" of the step must be at the same line as its first key.
+func duplicateStepsRange(lines []string, s *Step) (int, int, error) {
+	start := s.Pos.Line
+	if start < 1 || len(lines) < start {
+		return 0, 0, fmt.Errorf("position of step at %s is out of source", s.Pos)
+	}
+	l := lines[start-1]
+	dash := strings.Index(l, "-")
+	if dash < 0 || s.Pos.Col-1 > len(l) || strings.TrimSpace(l[:s.Pos.Col-1]) != "-" || strings.TrimSpace(l[:dash]) != "" {
+		return 0, 0, fmt.Errorf("step at %s cannot be extracted since \"-\" is not at the same line as its first key", s.Pos)
+	}
+
+	// Lines which are more indented than "-" or blank are the content of the step
+	end := start
+	for i := start; i < len(lines); i++ {
+		l := lines[i]
+		if strings.TrimSpace(l) == "" {
+			continue
+		}
+		if len(l)-len(strings.TrimLeft(l, " ")) <= dash {
+			break
+		}
+		end = i + 1
+	}
+	return start, end, nil
+}
+
+// duplicateStepsCheckExtractable checks the step can be moved to a composite action without
+// changing its behavior.
+func duplicateStepsCheckExtractable(s *Step) error {
+	if s.ID != nil {
+		return fmt.Errorf("step at %s cannot be extracted since it has \"id:\" and its outputs may be referenced by other steps", s.Pos)
+	}
+	if s.TimeoutMinutes != nil {
+		return fmt.Errorf("step at %s cannot be extracted since \"timeout-minutes:\" is not available in composite actions", s.Pos)
+	}
+
+	strs := []*String{s.Name}
+	if s.If != nil {
+		v := s.If.Value
+		if !strings.Contains(v, "${{") {
+			v = "${{ " + v + " }}" // Condition at "if:" is always evaluated as expression
+		}
+		strs = append(strs, &String{Value: v, Pos: s.If.Pos})
+	}
+	switch e := s.Exec.(type) {
+	case *ExecRun:
+		strs = append(strs, e.Run, e.Shell, e.WorkingDirectory)
+	case *ExecAction:
+		strs = append(strs, e.Uses, e.Entrypoint, e.Args)
+		for _, i := range e.Inputs {
+			strs = append(strs, i.Value)
+		}
+	}
+	if s.Env != nil {
+		strs = append(strs, s.Env.Expression)
+		for _, v := range s.Env.Vars {
+			strs = append(strs, v.Value)
+		}
+	}
+	if s.ContinueOnError != nil {
+		strs = append(strs, s.ContinueOnError.Expression)
+	}
+
+	for _, str := range strs {
+		if str == nil {
+			continue
+		}
+		for _, c := range duplicateStepsReferencedContexts(str.Value) {
+			if _, ok := duplicateStepsContexts[c]; !ok {
+				return fmt.Errorf("step at %s cannot be extracted since %q context is not available or has a different value in composite actions", s.Pos, c)
+			}
+		}
+	}
+	return nil
+}
+
+// duplicateStepsReferencedContexts returns the names of contexts referenced in the ${{ }}
+// placeholders in the string. A placeholder which cannot be parsed is treated as "(unknown)" context.
+func duplicateStepsReferencedContexts(s string) []string {
+	ret := []string{}
+	for {
+		i := strings.Index(s, "${{")
+		if i < 0 {
+			return ret
+		}
+		s = s[i+3:]
+		l := NewExprLexer(s)
+		e, err := NewExprParser().Parse(l)
+		o := l.Offset()
+		l.Release()
+		if err != nil {
+			return append(ret, "(unknown)")
+		}
+		VisitExprNode(e, func(n, _ ExprNode, entering bool) {
+			if v, ok := n.(*VariableNode); ok && entering {
+				ret = append(ret, v.Name)
+			}
+		})
+		s = s[o:]
+	}
+}
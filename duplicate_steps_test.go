@@ -0,0 +1,303 @@
+package actionlint
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"go.yaml.in/yaml/v4"
+)
+
+func testDuplicateStepsFinder(t *testing.T) *DuplicateStepsFinder {
+	t.Helper()
+	f := NewDuplicateStepsFinder(0)
+	for _, n := range []string{"ci.yaml", "release.yaml"} {
+		src, err := os.ReadFile(filepath.Join("testdata", "dedup", n))
+		if err != nil {
+			panic(err)
+		}
+		if err := f.Add(".github/workflows/"+n, src); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return f
+}
+
+func TestDuplicateStepsFind(t *testing.T) {
+	ds := testDuplicateStepsFinder(t).Find()
+	if len(ds) != 1 {
+		t.Fatalf("wanted 1 cluster but got %d clusters", len(ds))
+	}
+	d := ds[0]
+	if ds := d.Differences(); ds != nil {
+		t.Fatalf("identical steps have differences: %v", ds)
+	}
+
+	want := []string{"actions/checkout@v4", "Set up Go", "go mod download"}
+	if diff := cmp.Diff(want, d.Summary()); diff != "" {
+		t.Fatal(diff)
+	}
+
+	have := []string{}
+	for _, l := range d.Locations {
+		have = append(have, l.Path+":"+l.Pos().String()+":"+l.Job.ID.Value)
+	}
+	// Steps in "lint" job are not duplicated since the working directory of the "run:" step differs
+	want = []string{
+		".github/workflows/ci.yaml:line:7,col:9:test",
+		".github/workflows/release.yaml:line:9,col:9:release",
+	}
+	if diff := cmp.Diff(want, have); diff != "" {
+		t.Fatal(diff)
+	}
+}
+
+func TestDuplicateStepsFindNear(t *testing.T) {
+	src := `on: push
+jobs:
+  a:
+    runs-on: ubuntu-latest
+    steps:
+      - uses: actions/setup-node@v3
+        with:
+          node-version: 18
+      - run: |
+          # Install dependencies
+          npm ci
+          npm test
+      - run: echo done
+  b:
+    runs-on: ubuntu-latest
+    steps:
+      - uses: actions/setup-node@v4
+        with:
+          node-version: 20
+      - run: |
+          npm   ci
+
+          npm test
+        working-directory: ./app
+      - run: echo done
+  c:
+    runs-on: ubuntu-latest
+    steps:
+      - uses: actions/setup-node@v4
+        with:
+          node-version: 20
+          cache: npm
+      - run: npm ci && npm test
+`
+	f := NewDuplicateStepsFinder(0)
+	f.SetNear(true)
+	if err := f.Add("test.yaml", []byte(src)); err != nil {
+		t.Fatal(err)
+	}
+	ds := f.Find()
+	if len(ds) != 1 {
+		t.Fatalf("wanted 1 cluster but got %d clusters", len(ds))
+	}
+	d := ds[0]
+
+	have := []string{}
+	for _, l := range d.Locations {
+		have = append(have, l.Job.ID.Value+":"+l.Pos().String())
+	}
+	// Steps in "c" job are not near-identical since the input names and the script differ
+	want := []string{"a:line:6,col:9", "b:line:17,col:9"}
+	if diff := cmp.Diff(want, have); diff != "" {
+		t.Fatal(diff)
+	}
+	if diff := cmp.Diff([]int{0, 1}, d.Differences()); diff != "" {
+		t.Fatal(diff)
+	}
+
+	_, err := d.Extract("setup")
+	if err == nil || !strings.Contains(err.Error(), "step at test.yaml:6:9 differs from the near-identical steps") {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	f = NewDuplicateStepsFinder(0)
+	if err := f.Add("test.yaml", []byte(src)); err != nil {
+		t.Fatal(err)
+	}
+	if ds := f.Find(); len(ds) != 0 {
+		t.Fatalf("near-identical steps were found without SetNear: %v", ds[0].Summary())
+	}
+}
+
+func TestDuplicateStepsFindMinLen(t *testing.T) {
+	src := `on: push
+jobs:
+  a:
+    runs-on: ubuntu-latest
+    steps:
+      - run: echo 1
+      - run: echo 2
+      - run: echo 3
+      - run: echo 1
+      - run: echo 2
+  b:
+    runs-on: ubuntu-latest
+    steps:
+      - run: echo 2
+      - run: echo 3
+`
+	for _, tc := range []struct {
+		min  int
+		want []string
+	}{
+		{
+			// "echo 2" and "echo 3" in "a" are already covered by the earlier cluster
+			min:  2,
+			want: []string{"2 a:line:6,col:9 a:line:9,col:9"},
+		},
+		{
+			min:  1,
+			want: []string{"2 a:line:6,col:9 a:line:9,col:9", "1 a:line:8,col:9 b:line:15,col:9"},
+		},
+		{
+			min:  3,
+			want: []string{},
+		},
+	} {
+		f := NewDuplicateStepsFinder(tc.min)
+		if err := f.Add("test.yaml", []byte(src)); err != nil {
+			t.Fatal(err)
+		}
+		have := []string{}
+		for _, d := range f.Find() {
+			s := []string{}
+			for _, l := range d.Locations {
+				s = append(s, l.Job.ID.Value+":"+l.Pos().String())
+			}
+			have = append(have, strings.Join(append([]string{string(rune('0' + d.Len()))}, s...), " "))
+		}
+		if diff := cmp.Diff(tc.want, have); diff != "" {
+			t.Errorf("min=%d: %s", tc.min, diff)
+		}
+	}
+}
+
+func TestDuplicateStepsExtract(t *testing.T) {
+	ds := testDuplicateStepsFinder(t).Find()
+	x, err := ds[0].Extract("setup")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if x.Steps != 2 {
+		t.Errorf("leading checkout step should not be extracted but %d steps were extracted", x.Steps)
+	}
+	if x.Uses != "./.github/actions/setup" {
+		t.Errorf("unexpected uses: %q", x.Uses)
+	}
+	if x.ActionPath != ".github/actions/setup/action.yml" {
+		t.Errorf("unexpected action path: %q", x.ActionPath)
+	}
+
+	dir := filepath.Join("testdata", "dedup", "extracted")
+	want, err := os.ReadFile(filepath.Join(dir, "action.yml"))
+	if err != nil {
+		panic(err)
+	}
+	if diff := cmp.Diff(string(want), string(x.Action)); diff != "" {
+		t.Fatal(diff)
+	}
+	if len(x.Workflows) != 2 {
+		t.Fatalf("wanted 2 rewritten workflows but got %d", len(x.Workflows))
+	}
+	for _, n := range []string{"ci.yaml", "release.yaml"} {
+		want, err := os.ReadFile(filepath.Join(dir, n))
+		if err != nil {
+			panic(err)
+		}
+		if diff := cmp.Diff(string(want), string(x.Workflows[".github/workflows/"+n])); diff != "" {
+			t.Fatalf("%s: %s", n, diff)
+		}
+	}
+
+	// Generated action can be parsed as action metadata
+	var md ActionMetadata
+	if err := yaml.Unmarshal(x.Action, &md); err != nil {
+		t.Fatal(err)
+	}
+	if md.Name != "setup" || md.Runs.Using != "composite" {
+		t.Fatalf("unexpected action metadata: %#v", md)
+	}
+}
+
+func TestDuplicateStepsExtractError(t *testing.T) {
+	for _, tc := range []struct {
+		what  string
+		steps string
+		name  string
+		want  string
+	}{
+		{
+			what:  "invalid name",
+			steps: "      - run: echo 1\n      - run: echo 2\n",
+			name:  "foo/bar",
+			want:  `invalid name of composite action "foo/bar"`,
+		},
+		{
+			what:  "id",
+			steps: "      - run: echo 1\n        id: one\n      - run: echo 2\n",
+			want:  `step at line:6,col:9 cannot be extracted since it has "id:"`,
+		},
+		{
+			what:  "timeout-minutes",
+			steps: "      - run: echo 1\n      - run: echo 2\n        timeout-minutes: 5\n",
+			want:  `step at line:7,col:9 cannot be extracted since "timeout-minutes:" is not available in composite actions`,
+		},
+		{
+			what:  "secrets",
+			steps: "      - run: echo 1\n      - run: echo ${{ secrets.TOKEN }}\n",
+			want:  `step at line:7,col:9 cannot be extracted since "secrets" context is not available`,
+		},
+		{
+			what:  "matrix in condition",
+			steps: "      - run: echo 1\n        if: matrix.os == 'linux'\n      - run: echo 2\n",
+			want:  `step at line:6,col:9 cannot be extracted since "matrix" context is not available`,
+		},
+		{
+			what:  "checkout only",
+			steps: "      - uses: actions/checkout@v4\n      - uses: actions/checkout@v4\n        with:\n          path: foo\n",
+			want:  "no step can be extracted since all the duplicated steps check out the repository",
+		},
+		{
+			what:  "checkout in middle",
+			steps: "      - run: echo 1\n      - uses: actions/checkout@v4\n",
+			want:  "step at line:7,col:9 cannot be extracted since local composite action cannot be run before checking out",
+		},
+		{
+			what:  "dash at separate line",
+			steps: "      -\n        run: echo 1\n      - run: echo 2\n",
+			want:  `step at line:7,col:9 cannot be extracted since "-" is not at the same line as its first key`,
+		},
+	} {
+		t.Run(tc.what, func(t *testing.T) {
+			src := "on: push\njobs:\n  a:\n    runs-on: ubuntu-latest\n    steps:\n" + tc.steps
+			src += strings.Replace(src, "  a:\n", "  b:\n", 1)[len("on: push\njobs:\n"):]
+			f := NewDuplicateStepsFinder(0)
+			if err := f.Add("test.yaml", []byte(src)); err != nil {
+				t.Fatal(err)
+			}
+			ds := f.Find()
+			if len(ds) != 1 {
+				t.Fatalf("wanted 1 cluster but got %d clusters", len(ds))
+			}
+			name := tc.name
+			if name == "" {
+				name = "test"
+			}
+			_, err := ds[0].Extract(name)
+			if err == nil {
+				t.Fatal("error did not occur")
+			}
+			if !strings.Contains(err.Error(), tc.want) {
+				t.Fatalf("error %q does not contain %q", err.Error(), tc.want)
+			}
+		})
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"strings"
 	"sync"
 	"text/template"
+	"text/template/parse"
 
 	"github.com/fatih/color"
 	"github.com/mattn/go-runewidth"
@@ -22,6 +23,61 @@ var (
 	gray   = color.New(color.FgHiBlack)
 )
 
+// Severity is a severity of an error.
+type Severity uint8
+
+const (
+	// SeverityError is a severity of errors which should be fixed. This is the default severity of
+	// errors reported by actionlint.
+	SeverityError Severity = iota
+	// SeverityWarning is a severity of errors which are likely to be problems.
+	SeverityWarning
+	// SeverityInfo is a severity of informational errors such as style suggestions.
+	SeverityInfo
+)
+
+func (s Severity) String() string {
+	switch s {
+	case SeverityError:
+		return "error"
+	case SeverityWarning:
+		return "warning"
+	case SeverityInfo:
+		return "info"
+	default:
+		return fmt.Sprintf("Severity(%d)", s)
+	}
+}
+
+// ParseSeverity parses the severity name like "warning". The name is case insensitive. It returns
+// false as the 2nd return value when the name is unknown.
+func ParseSeverity(name string) (Severity, bool) {
+	switch strings.ToLower(name) {
+	case "error":
+		return SeverityError, true
+	case "warning":
+		return SeverityWarning, true
+	case "info":
+		return SeverityInfo, true
+	default:
+		return SeverityError, false
+	}
+}
+
+// RelatedLocation is a location related to an error. For example, the location where the duplicated
+// name was previously defined.
+type RelatedLocation struct {
+	// Filepath is a file path of the location. When this value is empty, the location is in the same
+	// file as the error.
+	Filepath string
+	// Line is a line number of the location. This value is 1-based.
+	Line int
+	// Column is a column number of the location. This value is 1-based.
+	Column int
+	// Message is a message to describe the location.
+	Message string
+}
+
 // Error represents an error detected by actionlint rules
 type Error struct {
 	// Message is an error message.
@@ -32,8 +88,26 @@ type Error struct {
 	Line int
 	// Column is a column number where the error occurred. This value is 1-based.
 	Column int
-	// Kind is a string to represent kind of the error. Usually rule name which found the error.
+	// Kind is a string to represent kind of the error. Usually rule name which found the error. This
+	// is the ID of the rule.
 	Kind string
+	// Code is a diagnostic code to categorize the error within the rule such as "SC2086" reported
+	// by shellcheck. This value is empty when the rule does not categorize its errors.
+	Code string
+	// Severity is a severity of the error. Most of errors are SeverityError.
+	Severity Severity
+	// EndLine is a line number where the range of the error ends. This value is 1-based. 0 means
+	// the end of the range is unknown.
+	EndLine int
+	// EndColumn is a column number where the range of the error ends. The character at this column
+	// is not included in the range. This value is 1-based. 0 means the end of the range is unknown.
+	EndColumn int
+	// Related is locations related to the error. This value is empty when there is no related
+	// location.
+	Related []*RelatedLocation
+	// Fixes is edits to fix the error. This value is empty when the error cannot be fixed
+	// automatically. The edits are applied when LinterOptions.Fix is enabled.
+	Fixes []*TextEdit
 }
 
 // Error returns summary of the error as string.
@@ -45,22 +119,25 @@ func (e *Error) String() string {
 	return e.Error()
 }
 
+// errorAt creates a new error at the position. When the end of the range starting at the position
+// is known, the error also has the range.
 func errorAt(pos *Pos, kind string, msg string) *Error {
 	return &Error{
-		Message: msg,
-		Line:    pos.Line,
-		Column:  pos.Col,
-		Kind:    kind,
+		Message:   msg,
+		Line:      pos.Line,
+		Column:    pos.Col,
+		Kind:      kind,
+		EndLine:   pos.EndLine,
+		EndColumn: pos.EndCol,
 	}
 }
 
 func errorfAt(pos *Pos, kind string, format string, args ...interface{}) *Error {
-	return &Error{
-		Message: fmt.Sprintf(format, args...),
-		Line:    pos.Line,
-		Column:  pos.Col,
-		Kind:    kind,
-	}
+	return errorAt(pos, kind, fmt.Sprintf(format, args...))
+}
+
+func relatedAt(pos *Pos, msg string) []*RelatedLocation {
+	return []*RelatedLocation{{Line: pos.Line, Column: pos.Col, Message: msg}}
 }
 
 // GetTemplateFields fields for formatting this error with Go template.
@@ -79,14 +156,52 @@ func (e *Error) GetTemplateFields(source []byte) *ErrorTemplateFields {
 		}
 	}
 
+	if e.EndLine == e.Line && e.EndColumn > e.Column {
+		end = e.EndColumn
+	}
+	endLine := e.Line
+	if e.EndLine > 0 {
+		endLine = e.EndLine
+	}
+
+	var related []*RelatedLocationTemplateFields
+	for _, r := range e.Related {
+		p := r.Filepath
+		if p == "" {
+			p = e.Filepath
+		}
+		related = append(related, &RelatedLocationTemplateFields{
+			Filepath: p,
+			Line:     r.Line,
+			Column:   r.Column,
+			Message:  r.Message,
+		})
+	}
+
+	var fixes []*TextEditTemplateFields
+	for _, f := range e.Fixes {
+		fixes = append(fixes, &TextEditTemplateFields{
+			Line:      f.Start.Line,
+			Column:    f.Start.Col,
+			EndLine:   f.End.Line,
+			EndColumn: f.End.Col,
+			NewText:   f.NewText,
+		})
+	}
+
 	return &ErrorTemplateFields{
 		Message:   e.Message,
 		Filepath:  e.Filepath,
 		Line:      e.Line,
 		Column:    e.Column,
 		Kind:      e.Kind,
+		Code:      e.Code,
+		Severity:  e.Severity.String(),
 		Snippet:   snippet,
+		EndLine:   endLine,
 		EndColumn: end,
+		Related:   related,
+		Fixes:     fixes,
 	}
 }
 
@@ -139,12 +254,17 @@ func (e *Error) getIndicator(line string) string {
 
 	start := e.Column - 1 // Column is 1-based
 
-	// Count width of non-space characters after '^' for underline
+	// Count width of characters in the range of the error for underline. When the range is unknown,
+	// count width of non-space characters after '^'
+	ranged := e.EndLine == e.Line && e.EndColumn > e.Column
 	uw := 0
 	r := strings.NewReader(line[start:])
-	for {
+	for col := e.Column; !ranged || col < e.EndColumn; col++ {
 		c, s, err := r.ReadRune()
-		if err != nil || s == 0 || c == ' ' || c == '\t' || c == '\n' || c == '\r' {
+		if err != nil || s == 0 || c == '\n' || c == '\r' {
+			break
+		}
+		if !ranged && (c == ' ' || c == '\t') {
 			break
 		}
 		uw += runewidth.RuneWidth(c)
@@ -191,12 +311,56 @@ type ErrorTemplateFields struct {
 	Column int `json:"column"`
 	// Kind is a rule name the error belongs to.
 	Kind string `json:"kind"`
+	// Code is a diagnostic code of the error within the rule. When encoding into JSON, this field is
+	// omitted when the code is empty.
+	Code string `json:"code,omitempty"`
+	// Severity is a severity of the error. One of "error", "warning", or "info".
+	Severity string `json:"severity"`
 	// Snippet is a code snippet and indicator to indicate where the error occurred.
 	// When encoding into JSON, this field may be omitted when the snippet is empty.
 	Snippet string `json:"snippet,omitempty"`
-	// EndColumn is a column number where the error indicator (^~~~~~~) ends. When no indicator
-	// can be shown, EndColumn is equal to Column.
+	// EndLine is a line number where the range of the error ends. When the end of the range is
+	// unknown, EndLine is equal to Line.
+	EndLine int `json:"end_line"`
+	// EndColumn is a column number where the error indicator (^~~~~~~) ends. When the range of the
+	// error ends at the same line, this is the column where the range ends. When no indicator can be
+	// shown, EndColumn is equal to Column.
 	EndColumn int `json:"end_column"`
+	// Related is locations related to the error. When encoding into JSON, this field is omitted
+	// when there is no related location.
+	Related []*RelatedLocationTemplateFields `json:"related,omitempty"`
+	// Fixes is edits to fix the error. When encoding into JSON, this field is omitted when the error
+	// cannot be fixed automatically.
+	Fixes []*TextEditTemplateFields `json:"fixes,omitempty"`
+}
+
+// RelatedLocationTemplateFields holds all fields of a location related to an error. This is used for
+// formatting errors with Go template.
+type RelatedLocationTemplateFields struct {
+	// Filepath is a file path of the location.
+	Filepath string `json:"filepath,omitempty"`
+	// Line is a line number of the location.
+	Line int `json:"line"`
+	// Column is a column number of the location.
+	Column int `json:"column"`
+	// Message is a message to describe the location.
+	Message string `json:"message,omitempty"`
+}
+
+// TextEditTemplateFields holds all fields of an edit to fix an error. This is used for formatting
+// errors with Go template.
+type TextEditTemplateFields struct {
+	// Line is a line number where the replaced range starts.
+	Line int `json:"line"`
+	// Column is a column number where the replaced range starts.
+	Column int `json:"column"`
+	// EndLine is a line number where the replaced range ends.
+	EndLine int `json:"end_line"`
+	// EndColumn is a column number where the replaced range ends. The character at this column is
+	// not replaced.
+	EndColumn int `json:"end_column"`
+	// NewText is a text to replace the range with.
+	NewText string `json:"new_text"`
 }
 
 func unescapeBackslash(s string) string {
@@ -297,6 +461,81 @@ func (f *ErrorFormatter) Print(out io.Writer, t []*ErrorTemplateFields) error {
 	return nil
 }
 
+// streamable returns whether the template formats each error independently like
+// '{{range $err := .}}{{json $err}}{{end}}'. Formatting errors in multiple batches with such template
+// outputs the same result as formatting all errors at once so that errors can be printed as soon as
+// they are found.
+func (f *ErrorFormatter) streamable() bool {
+	ns := f.temp.Tree.Root.Nodes
+	if len(ns) != 1 {
+		return false
+	}
+	// The index of '{{range $i, $err := .}}' and the root data '$' depend on the batch
+	r, ok := ns[0].(*parse.RangeNode)
+	if !ok || r.ElseList != nil || len(r.Pipe.Decl) > 1 || len(r.Pipe.Cmds) != 1 {
+		return false
+	}
+	args := r.Pipe.Cmds[0].Args
+	if len(args) != 1 {
+		return false
+	}
+	if _, ok := args[0].(*parse.DotNode); !ok {
+		return false
+	}
+	// '$' is shadowed by each error in '{{range $ := .}}'
+	shadowed := len(r.Pipe.Decl) == 1 && r.Pipe.Decl[0].Ident[0] == "$"
+	return !dependsOnAllErrors(r.List, !shadowed)
+}
+
+// dependsOnAllErrors returns whether the template node refers to the root data with '$' variable or
+// calls allKinds function whose result depends on the rules registered while checking all files. The
+// root parameter is false when '$' variable does not refer to the root data.
+func dependsOnAllErrors(n parse.Node, root bool) bool {
+	switch n := n.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return false
+		}
+		for _, c := range n.Nodes {
+			if dependsOnAllErrors(c, root) {
+				return true
+			}
+		}
+	case *parse.ActionNode:
+		return dependsOnAllErrors(n.Pipe, root)
+	case *parse.IfNode:
+		return dependsOnAllErrors(n.Pipe, root) || dependsOnAllErrors(n.List, root) || dependsOnAllErrors(n.ElseList, root)
+	case *parse.RangeNode:
+		return dependsOnAllErrors(n.Pipe, root) || dependsOnAllErrors(n.List, root) || dependsOnAllErrors(n.ElseList, root)
+	case *parse.WithNode:
+		return dependsOnAllErrors(n.Pipe, root) || dependsOnAllErrors(n.List, root) || dependsOnAllErrors(n.ElseList, root)
+	case *parse.TemplateNode:
+		return dependsOnAllErrors(n.Pipe, root)
+	case *parse.PipeNode:
+		if n == nil {
+			return false
+		}
+		for _, c := range n.Cmds {
+			if dependsOnAllErrors(c, root) {
+				return true
+			}
+		}
+	case *parse.CommandNode:
+		for _, a := range n.Args {
+			if dependsOnAllErrors(a, root) {
+				return true
+			}
+		}
+	case *parse.ChainNode:
+		return dependsOnAllErrors(n.Node, root)
+	case *parse.VariableNode:
+		return root && n.Ident[0] == "$"
+	case *parse.IdentifierNode:
+		return n.Ident == "allKinds"
+	}
+	return false
+}
+
 // PrintErrors prints the errors after formatting them with template.
 func (f *ErrorFormatter) PrintErrors(out io.Writer, errs []*Error, src []byte) error {
 	t := make([]*ErrorTemplateFields, 0, len(errs))
@@ -30,7 +30,7 @@ func (w testErrorWriter) Write(b []byte) (int, error) {
 func TestErrorErrorAt(t *testing.T) {
 	m := "message"
 	k := "kind"
-	err := errorAt(&Pos{1, 2}, k, m)
+	err := errorAt(&Pos{Line: 1, Col: 2}, k, m)
 	if err.Message != m {
 		t.Errorf("wanted %q but got %q", m, err.Message)
 	}
@@ -51,7 +51,7 @@ func TestErrorErrorAt(t *testing.T) {
 func TestErrorErrorfAt(t *testing.T) {
 	m := "this is message"
 	k := "kind"
-	err := errorfAt(&Pos{1, 2}, k, "%s is %s", "this", "message")
+	err := errorfAt(&Pos{Line: 1, Col: 2}, k, "%s is %s", "this", "message")
 	if err.Message != m {
 		t.Errorf("wanted %q but got %q", m, err.Message)
 	}
@@ -212,7 +212,7 @@ func TestErrorPrettyPrint(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.message, func(t *testing.T) {
-			err := errorAt(&Pos{tc.line, tc.column}, "kind", tc.message)
+			err := errorAt(&Pos{Line: tc.line, Col: tc.column}, "kind", tc.message)
 			err.Filepath = "filename.txt"
 
 			var buf bytes.Buffer
@@ -367,7 +367,7 @@ func TestErrorGetTemplateFieldsOK(t *testing.T) {
 
 	for i, tc := range testCases {
 		t.Run(strconv.Itoa(i), func(t *testing.T) {
-			err := errorAt(&Pos{1, tc.column}, "kind", tc.message)
+			err := errorAt(&Pos{Line: 1, Col: tc.column}, "kind", tc.message)
 			err.Filepath = "filename.txt"
 			f := err.GetTemplateFields([]byte(tc.source))
 			if f.Message != tc.message {
@@ -395,9 +395,82 @@ func TestErrorGetTemplateFieldsOK(t *testing.T) {
 	}
 }
 
+func TestErrorGetTemplateFieldsFixes(t *testing.T) {
+	err := errorAt(&Pos{Line: 1, Col: 3}, "kind", "message")
+	err.Fixes = []*TextEdit{
+		{Start: &Pos{Line: 1, Col: 3}, End: &Pos{Line: 1, Col: 5}, NewText: "foo"},
+		{Start: &Pos{Line: 2, Col: 1}, End: &Pos{Line: 2, Col: 1}, NewText: "bar\n"},
+	}
+	f := err.GetTemplateFields([]byte("this is source"))
+	want := []*TextEditTemplateFields{
+		{Line: 1, Column: 3, EndLine: 1, EndColumn: 5, NewText: "foo"},
+		{Line: 2, Column: 1, EndLine: 2, EndColumn: 1, NewText: "bar\n"},
+	}
+	if diff := cmp.Diff(want, f.Fixes); diff != "" {
+		t.Fatal(diff)
+	}
+}
+
+func TestErrorGetTemplateFieldsStructuredFields(t *testing.T) {
+	err := errorAt(&Pos{Line: 1, Col: 6}, "kind", "message")
+	err.Filepath = "test.yaml"
+	err.Code = "E001"
+	err.Severity = SeverityWarning
+	err.EndLine = 1
+	err.EndColumn = 8
+	err.Related = []*RelatedLocation{
+		{Line: 2, Column: 3, Message: "related here"},
+		{Filepath: "other.yaml", Line: 4, Column: 5},
+	}
+	f := err.GetTemplateFields([]byte("this is source\nfoo bar"))
+
+	want := &ErrorTemplateFields{
+		Message:   "message",
+		Filepath:  "test.yaml",
+		Line:      1,
+		Column:    6,
+		Kind:      "kind",
+		Code:      "E001",
+		Severity:  "warning",
+		Snippet:   "this is source\n     ^~",
+		EndLine:   1,
+		EndColumn: 8,
+		Related: []*RelatedLocationTemplateFields{
+			{Filepath: "test.yaml", Line: 2, Column: 3, Message: "related here"},
+			{Filepath: "other.yaml", Line: 4, Column: 5},
+		},
+	}
+	if diff := cmp.Diff(want, f); diff != "" {
+		t.Fatal(diff)
+	}
+
+	// When the end position is unknown, the end of the indicator is used
+	err = errorAt(&Pos{Line: 1, Col: 6}, "kind", "message")
+	f = err.GetTemplateFields([]byte("this is source\nfoo bar"))
+	if f.Severity != "error" || f.EndLine != 1 || f.EndColumn != 7 {
+		t.Fatalf("unexpected fields: %#v", f)
+	}
+}
+
+func TestErrorSeverity(t *testing.T) {
+	for _, s := range []Severity{SeverityError, SeverityWarning, SeverityInfo} {
+		p, ok := ParseSeverity(strings.ToUpper(s.String()))
+		if !ok {
+			t.Errorf("could not parse severity %q", s)
+			continue
+		}
+		if p != s {
+			t.Errorf("wanted %v but got %v", s, p)
+		}
+	}
+	if _, ok := ParseSeverity("critical"); ok {
+		t.Error("unknown severity was parsed")
+	}
+}
+
 // Regression test for #128
 func TestErrorGetTemplateFieldsColumnIsOutOfBounds(t *testing.T) {
-	err := errorAt(&Pos{1, 9999}, "kind", "this is message")
+	err := errorAt(&Pos{Line: 1, Col: 9999}, "kind", "this is message")
 	err.Filepath = "filename.yaml"
 	f := err.GetTemplateFields([]byte("this is source"))
 	if strings.Contains(f.Snippet, "\n") {
@@ -437,6 +510,9 @@ var testErrorTemplateFields = []*ErrorTemplateFields{
 		EndColumn: 5,
 		Snippet:   "snippet 2",
 		Kind:      "kind2",
+		Fixes: []*TextEditTemplateFields{
+			{Line: 3, Column: 4, EndLine: 3, EndColumn: 6, NewText: "fixed"},
+		},
 	},
 }
 
@@ -487,8 +563,8 @@ func TestErrorPrintFormattedWithTemplateFields(t *testing.T) {
 
 func TestErrorPrintFormattedErrors(t *testing.T) {
 	errs := []*Error{
-		errorAt(&Pos{1, 1}, "kind1", "error1"),
-		errorAt(&Pos{1, 0}, "kind2", "error2"),
+		errorAt(&Pos{Line: 1, Col: 1}, "kind1", "error1"),
+		errorAt(&Pos{Line: 1, Col: 0}, "kind2", "error2"),
 	}
 
 	f, err := NewErrorFormatter("{{range $ = .}}({{$.Message | printf \"%q\"}},{{$.Snippet | printf \"%q\"}}){{end}}")
@@ -606,6 +682,38 @@ func TestErrorFormatterPrintError(t *testing.T) {
 	}
 }
 
+func TestErrorFormatterStreamable(t *testing.T) {
+	testCases := []struct {
+		temp string
+		want bool
+	}{
+		{"{{range $err := .}}{{json $err}}{{end}}", true},
+		{"{{range .}}{{.Filepath}}:{{.Line}}:{{.Column}}: {{.Message}}\n{{end}}", true},
+		{"{{range $ := .}}{{$.Message}}{{end}}", true},
+		{"{{range $err := .}}{{if $err.Message}}{{with $err.Kind}}{{.}}{{end}}{{end}}{{end}}", true},
+		{"{{json .}}", false},
+		{"{{range $err := .}}{{json $err}}{{end}}\n", false},
+		{"[{{range $err := .}}{{json $err}}{{end}}]", false},
+		{"{{range $i, $err := .}}{{if $i}},{{end}}{{json $err}}{{end}}", false},
+		{"{{range $err := .}}{{len $}}{{end}}", false},
+		{"{{range $err := .}}{{$err.Message}}{{else}}no error{{end}}", false},
+		{"{{range $err := .}}{{range $k := allKinds}}{{$k.Name}}{{end}}{{end}}", false},
+		{"{{range $err := .Errors}}{{end}}", false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.temp, func(t *testing.T) {
+			f, err := NewErrorFormatter(tc.temp)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if have := f.streamable(); have != tc.want {
+				t.Fatalf("wanted %v but got %v", tc.want, have)
+			}
+		})
+	}
+}
+
 func TestErrorFormatterPrintJSONEncodeError(t *testing.T) {
 	f, err := NewErrorFormatter("{{json .}}")
 	if err != nil {
@@ -718,3 +826,19 @@ func TestErrorFormatterRegisterRuleInParallel(t *testing.T) {
 		t.Fatalf("not all rules were registered. %d rules were registered", len(f.rules))
 	}
 }
+
+func TestErrorGetTemplateFieldsWithRange(t *testing.T) {
+	src := "    run: 'echo hello'"
+	err := errorAt(&Pos{Line: 1, Col: 10, EndLine: 1, EndCol: 22}, "kind", "message")
+	if err.EndLine != 1 || err.EndColumn != 22 {
+		t.Fatalf("range of position was not set to error: %d:%d", err.EndLine, err.EndColumn)
+	}
+	f := err.GetTemplateFields([]byte(src))
+	want := src + "\n         ^~~~~~~~~~~~"
+	if f.Snippet != want {
+		t.Fatalf("wanted snippet %q but got %q", want, f.Snippet)
+	}
+	if f.EndLine != 1 || f.EndColumn != 22 {
+		t.Fatalf("unexpected end position %d:%d", f.EndLine, f.EndColumn)
+	}
+}
@@ -35,6 +35,7 @@ func ExampleLinter() {
 	// - LintDir: Check all workflow files in the given single directory recursively
 	// - LintRepository: Check all workflow files under .github/workflows in the given repository
 	// - LintStdin: Check the given workflow content read from STDIN
+	// - LintStdinBatch: Check the multiple workflow contents read from STDIN as JSON array
 	// - Lint: Check the given workflow content assuming the given file path
 	errs, err := l.LintFile(f, nil)
 	if err != nil {
@@ -1,24 +1,185 @@
 package actionlint
 
-import "fmt"
+// The lexer, the parser, and the semantics checker of expressions in ${{ }} were moved to the
+// "github.com/rhysd/actionlint/expr" package so that other tools can use them without the linter.
+// The declarations in this file are aliases of them kept for compatibility.
 
-// ExprError is an error type caused by lexing/parsing expression syntax. For more details, see
-// https://docs.github.com/en/actions/learn-github-actions/expressions
-type ExprError struct {
-	// Message is an error message
-	Message string
-	// Offset is byte offset position which caused the error
-	Offset int
-	// Offset is line number position which caused the error. Note that this value is 1-based.
-	Line int
-	// Column is column number position which caused the error. Note that this value is 1-based.
-	Column int
+import (
+	"fmt"
+
+	"github.com/rhysd/actionlint/expr"
+)
+
+type (
+	ExprError = expr.Error
+
+	TokenKind = expr.TokenKind
+	Token     = expr.Token
+	ExprLexer = expr.Lexer
+
+	ExprNode          = expr.Node
+	VariableNode      = expr.VariableNode
+	NullNode          = expr.NullNode
+	BoolNode          = expr.BoolNode
+	IntNode           = expr.IntNode
+	FloatNode         = expr.FloatNode
+	StringNode        = expr.StringNode
+	ObjectDerefNode   = expr.ObjectDerefNode
+	ArrayDerefNode    = expr.ArrayDerefNode
+	IndexAccessNode   = expr.IndexAccessNode
+	NotOpNode         = expr.NotOpNode
+	CompareOpNodeKind = expr.CompareOpNodeKind
+	CompareOpNode     = expr.CompareOpNode
+	LogicalOpNodeKind = expr.LogicalOpNodeKind
+	LogicalOpNode     = expr.LogicalOpNode
+	FuncCallNode      = expr.FuncCallNode
+	VisitExprNodeFunc = expr.VisitNodeFunc
+
+	ExprParser = expr.Parser
+
+	ExprType   = expr.Type
+	AnyType    = expr.AnyType
+	NullType   = expr.NullType
+	NumberType = expr.NumberType
+	BoolType   = expr.BoolType
+	StringType = expr.StringType
+	ObjectType = expr.ObjectType
+	ArrayType  = expr.ArrayType
+
+	FuncSignature        = expr.FuncSignature
+	ExprSemanticsChecker = expr.SemanticsChecker
+
+	UntrustedInputMap         = expr.UntrustedInputMap
+	UntrustedInputSearchRoots = expr.UntrustedInputSearchRoots
+	UntrustedInputChecker     = expr.UntrustedInputChecker
+)
+
+const (
+	TokenKindUnknown      = expr.TokenKindUnknown
+	TokenKindEnd          = expr.TokenKindEnd
+	TokenKindIdent        = expr.TokenKindIdent
+	TokenKindString       = expr.TokenKindString
+	TokenKindInt          = expr.TokenKindInt
+	TokenKindFloat        = expr.TokenKindFloat
+	TokenKindLeftParen    = expr.TokenKindLeftParen
+	TokenKindRightParen   = expr.TokenKindRightParen
+	TokenKindLeftBracket  = expr.TokenKindLeftBracket
+	TokenKindRightBracket = expr.TokenKindRightBracket
+	TokenKindDot          = expr.TokenKindDot
+	TokenKindNot          = expr.TokenKindNot
+	TokenKindLess         = expr.TokenKindLess
+	TokenKindLessEq       = expr.TokenKindLessEq
+	TokenKindGreater      = expr.TokenKindGreater
+	TokenKindGreaterEq    = expr.TokenKindGreaterEq
+	TokenKindEq           = expr.TokenKindEq
+	TokenKindNotEq        = expr.TokenKindNotEq
+	TokenKindAnd          = expr.TokenKindAnd
+	TokenKindOr           = expr.TokenKindOr
+	TokenKindStar         = expr.TokenKindStar
+	TokenKindComma        = expr.TokenKindComma
+
+	CompareOpNodeKindInvalid   = expr.CompareOpNodeKindInvalid
+	CompareOpNodeKindLess      = expr.CompareOpNodeKindLess
+	CompareOpNodeKindLessEq    = expr.CompareOpNodeKindLessEq
+	CompareOpNodeKindGreater   = expr.CompareOpNodeKindGreater
+	CompareOpNodeKindGreaterEq = expr.CompareOpNodeKindGreaterEq
+	CompareOpNodeKindEq        = expr.CompareOpNodeKindEq
+	CompareOpNodeKindNotEq     = expr.CompareOpNodeKindNotEq
+	LogicalOpNodeKindInvalid   = expr.LogicalOpNodeKindInvalid
+	LogicalOpNodeKindAnd       = expr.LogicalOpNodeKindAnd
+	LogicalOpNodeKindOr        = expr.LogicalOpNodeKindOr
+)
+
+var (
+	BuiltinFuncSignatures      = expr.BuiltinFuncSignatures
+	BuiltinGlobalVariableTypes = expr.BuiltinGlobalVariableTypes
+
+	BuiltinUntrustedInputs = expr.BuiltinUntrustedInputs
+
+	SpecialFunctionNames = expr.SpecialFunctionNames
+	AllContexts          = expr.AllContexts
+)
+
+// NewExprLexer is an alias of expr.NewLexer.
+func NewExprLexer(src string) *ExprLexer {
+	return expr.NewLexer(src)
+}
+
+// LexExpression is an alias of expr.LexExpression.
+func LexExpression(src string) ([]*Token, int, *ExprError) {
+	return expr.LexExpression(src)
+}
+
+// VisitExprNode is an alias of expr.VisitNode.
+func VisitExprNode(n ExprNode, f VisitExprNodeFunc) {
+	expr.VisitNode(n, f)
+}
+
+// NewExprParser is an alias of expr.NewParser.
+func NewExprParser() *ExprParser {
+	return expr.NewParser()
+}
+
+// NewEmptyObjectType is an alias of expr.NewEmptyObjectType.
+func NewEmptyObjectType() *ObjectType {
+	return expr.NewEmptyObjectType()
+}
+
+// NewObjectType is an alias of expr.NewObjectType.
+func NewObjectType(props map[string]ExprType) *ObjectType {
+	return expr.NewObjectType(props)
+}
+
+// NewEmptyStrictObjectType is an alias of expr.NewEmptyStrictObjectType.
+func NewEmptyStrictObjectType() *ObjectType {
+	return expr.NewEmptyStrictObjectType()
+}
+
+// NewStrictObjectType is an alias of expr.NewStrictObjectType.
+func NewStrictObjectType(props map[string]ExprType) *ObjectType {
+	return expr.NewStrictObjectType(props)
+}
+
+// NewMapObjectType is an alias of expr.NewMapObjectType.
+func NewMapObjectType(t ExprType) *ObjectType {
+	return expr.NewMapObjectType(t)
+}
+
+// EqualTypes is an alias of expr.EqualTypes.
+func EqualTypes(l, r ExprType) bool {
+	return expr.EqualTypes(l, r)
+}
+
+// NewExprSemanticsChecker is an alias of expr.NewSemanticsChecker.
+func NewExprSemanticsChecker(checkUntrustedInput bool, configVars []string) *ExprSemanticsChecker {
+	return expr.NewSemanticsChecker(checkUntrustedInput, configVars)
+}
+
+// NewUntrustedInputMap is an alias of expr.NewUntrustedInputMap.
+func NewUntrustedInputMap(name string, children ...*UntrustedInputMap) *UntrustedInputMap {
+	return expr.NewUntrustedInputMap(name, children...)
+}
+
+// NewUntrustedInputChecker is an alias of expr.NewUntrustedInputChecker.
+func NewUntrustedInputChecker(roots UntrustedInputSearchRoots) *UntrustedInputChecker {
+	return expr.NewUntrustedInputChecker(roots)
+}
+
+// WorkflowKeyAvailability is an alias of expr.WorkflowKeyAvailability.
+func WorkflowKeyAvailability(key string) ([]string, []string) {
+	return expr.WorkflowKeyAvailability(key)
 }
 
-func (e *ExprError) Error() string {
-	return fmt.Sprintf("%d:%d:%d: %s", e.Line, e.Column, e.Offset, e.Message)
+func errorAtExpr(e ExprNode, msg string) *ExprError {
+	t := e.Token()
+	return &ExprError{
+		Message: msg,
+		Offset:  t.Offset,
+		Line:    t.Line,
+		Column:  t.Column,
+	}
 }
 
-func (e *ExprError) String() string {
-	return e.Error()
+func errorfAtExpr(e ExprNode, format string, args ...interface{}) *ExprError {
+	return errorAtExpr(e, fmt.Sprintf(format, args...))
 }
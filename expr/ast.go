@@ -1,8 +1,8 @@
-package actionlint
+package expr
 
-// ExprNode is a node of expression syntax tree. To know the syntax, see
+// Node is a node of expression syntax tree. To know the syntax, see
 // https://docs.github.com/en/actions/learn-github-actions/expressions
-type ExprNode interface {
+type Node interface {
 	// Token returns the first token of the node. This method is useful to get position of this node.
 	Token() *Token
 }
@@ -87,7 +87,7 @@ func (n *StringNode) Token() *Token {
 // ObjectDerefNode represents property dereference of object like 'foo.bar'.
 type ObjectDerefNode struct {
 	// Receiver is an expression at receiver of property dereference.
-	Receiver ExprNode
+	Receiver Node
 	// Property is a name of property to access.
 	Property string
 }
@@ -100,7 +100,7 @@ func (n ObjectDerefNode) Token() *Token {
 // ArrayDerefNode represents elements dereference of arrays like '*' in 'foo.bar.*.piyo'.
 type ArrayDerefNode struct {
 	// Receiver is an expression at receiver of array element dereference.
-	Receiver ExprNode
+	Receiver Node
 }
 
 // Token returns the first token of the node. This method is useful to get position of this node.
@@ -112,9 +112,9 @@ func (n ArrayDerefNode) Token() *Token {
 // array index access.
 type IndexAccessNode struct {
 	// Operand is an expression at operand of index access, which should be array or object.
-	Operand ExprNode
+	Operand Node
 	// Index is an expression at index, which should be integer or string.
-	Index ExprNode
+	Index Node
 }
 
 // Token returns the first token of the node. This method is useful to get position of this node.
@@ -127,7 +127,7 @@ func (n *IndexAccessNode) Token() *Token {
 // NotOpNode is node for unary ! operator.
 type NotOpNode struct {
 	// Operand is an expression at operand of ! operator.
-	Operand ExprNode
+	Operand Node
 	tok     *Token
 }
 
@@ -185,9 +185,9 @@ type CompareOpNode struct {
 	// Kind is a kind of this expression to show which operator is used.
 	Kind CompareOpNodeKind
 	// Left is an expression for left hand side of the binary operator.
-	Left ExprNode
+	Left Node
 	// Right is an expression for right hand side of the binary operator.
-	Right ExprNode
+	Right Node
 }
 
 // Token returns the first token of the node. This method is useful to get position of this node.
@@ -223,9 +223,9 @@ type LogicalOpNode struct {
 	// Kind is a kind to show which operator is used.
 	Kind LogicalOpNodeKind
 	// Left is an expression for left hand side of the binary operator.
-	Left ExprNode
+	Left Node
 	// Right is an expression for right hand side of the binary operator.
-	Right ExprNode
+	Right Node
 }
 
 // Token returns the first token of the node. This method is useful to get position of this node.
@@ -240,7 +240,7 @@ type FuncCallNode struct {
 	// functions can be called.
 	Callee string
 	// Args is arguments of the function call.
-	Args []ExprNode
+	Args []Node
 	tok  *Token
 }
 
@@ -249,13 +249,13 @@ func (n *FuncCallNode) Token() *Token {
 	return n.tok
 }
 
-// VisitExprNodeFunc is a visitor function for VisitExprNode(). The entering argument is set to
+// VisitNodeFunc is a visitor function for VisitNode(). The entering argument is set to
 // true when it is called before visiting children. It is set to false when it is called after
 // visiting children. It means that this function is called twice for the same node. The parent
 // argument is the parent of the node. When the node is root, its parent is nil.
-type VisitExprNodeFunc func(node, parent ExprNode, entering bool)
+type VisitNodeFunc func(node, parent Node, entering bool)
 
-func visitExprNode(n, p ExprNode, f VisitExprNodeFunc) {
+func visitExprNode(n, p Node, f VisitNodeFunc) {
 	f(n, p, true)
 	switch n := n.(type) {
 	case *ObjectDerefNode:
@@ -282,7 +282,7 @@ func visitExprNode(n, p ExprNode, f VisitExprNodeFunc) {
 	f(n, p, false)
 }
 
-// VisitExprNode visits the given expression syntax tree with given function f.
-func VisitExprNode(n ExprNode, f VisitExprNodeFunc) {
+// VisitNode visits the given expression syntax tree with given function f.
+func VisitNode(n Node, f VisitNodeFunc) {
 	visitExprNode(n, nil, f)
 }
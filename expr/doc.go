@@ -0,0 +1,20 @@
+/*
+Package expr is the implementation of expressions embedded by ${{ }} placeholder in GitHub Actions
+workflow files. It provides the lexer, the parser, and the semantics checker (type checker) of the
+expressions, and the information of contexts and special functions available at each workflow key.
+
+https://docs.github.com/en/actions/learn-github-actions/expressions
+
+This package does not depend on the linter implemented in the github.com/rhysd/actionlint package.
+Tools other than linters such as policy engines and template generators can parse and type-check
+expressions with this package. Please see the example.
+
+The actionlint package provides aliases of the declarations in this package with "Expr" prefix
+like ExprLexer and ExprParser for compatibility.
+
+# Library versioning
+
+This package follows the versioning of actionlint. See the document of the actionlint package for
+more details.
+*/
+package expr
@@ -0,0 +1,498 @@
+package expr
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// filteredArray is an array created by object filter `.*`. Dereferencing a property of this array
+// maps the elements to their properties.
+type filteredArray []any
+
+// EvalError is an error caused while evaluating an expression.
+type EvalError struct {
+	// Message is an error message.
+	Message string
+	// Node is the node of expression which caused the error.
+	Node Node
+}
+
+func (e *EvalError) Error() string {
+	t := e.Node.Token()
+	return fmt.Sprintf("%d:%d:%d: %s", t.Line, t.Column, t.Offset, e.Message)
+}
+
+func evalErrorf(n Node, format string, args ...any) *EvalError {
+	return &EvalError{fmt.Sprintf(format, args...), n}
+}
+
+// Evaluate evaluates the expression with the context values in the same way as the runner.
+// Context values are the values decoded from JSON. Their types are nil, bool, float64, string,
+// []any, or map[string]any. The keys of ctx are context names like "github" or "matrix". Names of
+// contexts and properties are looked up case-insensitively.
+//
+// Functions which depend on the state of the runner are evaluated as if the job runs without any
+// failure. success() and always() return true, and failure() and cancelled() return false.
+// hashFiles() cannot be evaluated and causes an error.
+// https://docs.github.com/en/actions/writing-workflows/choosing-what-your-workflow-does/evaluate-expressions-in-workflows-and-actions
+func Evaluate(n Node, ctx map[string]any) (any, error) {
+	v, err := eval(n, ctx)
+	if err != nil {
+		return nil, err
+	}
+	if a, ok := v.(filteredArray); ok {
+		v = []any(a)
+	}
+	return v, nil
+}
+
+func eval(n Node, ctx map[string]any) (any, *EvalError) {
+	switch n := n.(type) {
+	case *NullNode:
+		return nil, nil
+	case *BoolNode:
+		return n.Value, nil
+	case *IntNode:
+		return float64(n.Value), nil
+	case *FloatNode:
+		return n.Value, nil
+	case *StringNode:
+		return n.Value, nil
+	case *VariableNode:
+		return lookupProp(ctx, n.Name), nil
+	case *ObjectDerefNode:
+		r, err := eval(n.Receiver, ctx)
+		if err != nil {
+			return nil, err
+		}
+		return derefProp(r, n.Property), nil
+	case *ArrayDerefNode:
+		r, err := eval(n.Receiver, ctx)
+		if err != nil {
+			return nil, err
+		}
+		switch r := r.(type) {
+		case filteredArray:
+			return r, nil
+		case []any:
+			return filteredArray(slices.Clone(r)), nil
+		case map[string]any:
+			ks := make([]string, 0, len(r))
+			for k := range r {
+				ks = append(ks, k)
+			}
+			slices.Sort(ks)
+			a := make(filteredArray, 0, len(ks))
+			for _, k := range ks {
+				a = append(a, r[k])
+			}
+			return a, nil
+		default:
+			return filteredArray{}, nil
+		}
+	case *IndexAccessNode:
+		r, err := eval(n.Operand, ctx)
+		if err != nil {
+			return nil, err
+		}
+		i, err := eval(n.Index, ctx)
+		if err != nil {
+			return nil, err
+		}
+		return indexValue(r, i), nil
+	case *NotOpNode:
+		v, err := eval(n.Operand, ctx)
+		if err != nil {
+			return nil, err
+		}
+		return !truthy(v), nil
+	case *CompareOpNode:
+		l, err := eval(n.Left, ctx)
+		if err != nil {
+			return nil, err
+		}
+		r, err := eval(n.Right, ctx)
+		if err != nil {
+			return nil, err
+		}
+		return compareValues(n.Kind, l, r), nil
+	case *LogicalOpNode:
+		l, err := eval(n.Left, ctx)
+		if err != nil {
+			return nil, err
+		}
+		// Operands are not coerced to bool. The value of the operand is returned
+		if truthy(l) == (n.Kind == LogicalOpNodeKindOr) {
+			return l, nil
+		}
+		return eval(n.Right, ctx)
+	case *FuncCallNode:
+		return evalFuncCall(n, ctx)
+	default:
+		panic(fmt.Sprintf("unknown node %T", n)) // Unreachable
+	}
+}
+
+func lookupProp(obj map[string]any, name string) any {
+	if v, ok := obj[name]; ok {
+		return v
+	}
+	for k, v := range obj {
+		if strings.EqualFold(k, name) {
+			return v
+		}
+	}
+	return nil
+}
+
+func derefProp(v any, name string) any {
+	switch v := v.(type) {
+	case map[string]any:
+		return lookupProp(v, name)
+	case filteredArray:
+		a := filteredArray{}
+		for _, e := range v {
+			if o, ok := e.(map[string]any); ok {
+				if p := lookupProp(o, name); p != nil {
+					a = append(a, p)
+				}
+			}
+		}
+		return a
+	default:
+		return nil
+	}
+}
+
+func indexValue(v, idx any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		return lookupProp(v, toString(idx))
+	case []any:
+		return indexArray(v, idx)
+	case filteredArray:
+		if s, ok := idx.(string); ok {
+			return derefProp(v, s)
+		}
+		return indexArray(v, idx)
+	default:
+		return nil
+	}
+}
+
+func indexArray(a []any, idx any) any {
+	f := math.Floor(toNumber(idx))
+	if math.IsNaN(f) || f < 0 || f >= float64(len(a)) {
+		return nil
+	}
+	return a[int(f)]
+}
+
+func truthy(v any) bool {
+	switch v := v.(type) {
+	case nil:
+		return false
+	case bool:
+		return v
+	case float64:
+		return v != 0 && !math.IsNaN(v)
+	case string:
+		return v != ""
+	default:
+		return true // Objects and arrays
+	}
+}
+
+func toNumber(v any) float64 {
+	switch v := v.(type) {
+	case nil:
+		return 0
+	case bool:
+		if v {
+			return 1
+		}
+		return 0
+	case float64:
+		return v
+	case string:
+		return CoerceStringToNumber(v)
+	default:
+		return math.NaN() // Objects and arrays
+	}
+}
+
+func toString(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case bool:
+		return strconv.FormatBool(v)
+	case float64:
+		switch {
+		case math.IsNaN(v):
+			return "NaN"
+		case math.IsInf(v, 1):
+			return "Infinity"
+		case math.IsInf(v, -1):
+			return "-Infinity"
+		default:
+			return strconv.FormatFloat(v, 'f', -1, 64)
+		}
+	case string:
+		return v
+	case map[string]any:
+		return "Object"
+	default:
+		return "Array"
+	}
+}
+
+func sameKind(l, r any) bool {
+	switch l.(type) {
+	case []any, filteredArray:
+		switch r.(type) {
+		case []any, filteredArray:
+			return true
+		}
+		return false
+	default:
+		return reflect.TypeOf(l) == reflect.TypeOf(r)
+	}
+}
+
+func looseEqual(l, r any) bool {
+	if !sameKind(l, r) {
+		return toNumber(l) == toNumber(r) // NaN is not equal to any number
+	}
+	switch l := l.(type) {
+	case nil:
+		return true
+	case bool:
+		return l == r.(bool)
+	case float64:
+		return l == r.(float64)
+	case string:
+		return strings.EqualFold(l, r.(string))
+	default:
+		// Objects and arrays are only equal when they are the same instance
+		return reflect.ValueOf(l).UnsafePointer() == reflect.ValueOf(r).UnsafePointer()
+	}
+}
+
+func compareValues(kind CompareOpNodeKind, l, r any) bool {
+	switch kind {
+	case CompareOpNodeKindEq:
+		return looseEqual(l, r)
+	case CompareOpNodeKindNotEq:
+		return !looseEqual(l, r)
+	}
+
+	ls, lok := l.(string)
+	rs, rok := r.(string)
+	if !lok || !rok {
+		return compareNumbers(kind, toNumber(l), toNumber(r))
+	}
+
+	// Strings are compared case-insensitively
+	c := strings.Compare(strings.ToUpper(ls), strings.ToUpper(rs))
+	switch kind {
+	case CompareOpNodeKindLess:
+		return c < 0
+	case CompareOpNodeKindLessEq:
+		return c <= 0
+	case CompareOpNodeKindGreater:
+		return c > 0
+	default:
+		return c >= 0
+	}
+}
+
+func compareNumbers(kind CompareOpNodeKind, l, r float64) bool {
+	switch kind {
+	case CompareOpNodeKindLess:
+		return l < r
+	case CompareOpNodeKindLessEq:
+		return l <= r
+	case CompareOpNodeKindGreater:
+		return l > r
+	default:
+		return l >= r
+	}
+}
+
+func evalFuncCall(n *FuncCallNode, ctx map[string]any) (any, *EvalError) {
+	callee := strings.ToLower(n.Callee)
+
+	// case() evaluates its arguments lazily
+	if callee == "case" {
+		for i := 0; i+1 < len(n.Args); i += 2 {
+			p, err := eval(n.Args[i], ctx)
+			if err != nil {
+				return nil, err
+			}
+			if truthy(p) {
+				return eval(n.Args[i+1], ctx)
+			}
+		}
+		if len(n.Args) == 0 {
+			return nil, nil
+		}
+		return eval(n.Args[len(n.Args)-1], ctx)
+	}
+
+	args := make([]any, 0, len(n.Args))
+	for _, a := range n.Args {
+		v, err := eval(a, ctx)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, v)
+	}
+	arity := func(min, max int) *EvalError {
+		if len(args) < min || max >= 0 && len(args) > max {
+			return evalErrorf(n, "wrong number of arguments %d for function %s()", len(args), n.Callee)
+		}
+		return nil
+	}
+
+	switch callee {
+	case "contains":
+		if err := arity(2, 2); err != nil {
+			return nil, err
+		}
+		switch s := args[0].(type) {
+		case []any:
+			return slices.ContainsFunc(s, func(e any) bool { return looseEqual(e, args[1]) }), nil
+		case filteredArray:
+			return slices.ContainsFunc(s, func(e any) bool { return looseEqual(e, args[1]) }), nil
+		default:
+			return strings.Contains(strings.ToLower(toString(s)), strings.ToLower(toString(args[1]))), nil
+		}
+	case "startswith":
+		if err := arity(2, 2); err != nil {
+			return nil, err
+		}
+		return strings.HasPrefix(strings.ToLower(toString(args[0])), strings.ToLower(toString(args[1]))), nil
+	case "endswith":
+		if err := arity(2, 2); err != nil {
+			return nil, err
+		}
+		return strings.HasSuffix(strings.ToLower(toString(args[0])), strings.ToLower(toString(args[1]))), nil
+	case "format":
+		if err := arity(1, -1); err != nil {
+			return nil, err
+		}
+		return evalFormat(n, toString(args[0]), args[1:])
+	case "join":
+		if err := arity(1, 2); err != nil {
+			return nil, err
+		}
+		sep := ","
+		if len(args) == 2 {
+			sep = toString(args[1])
+		}
+		var elems []any
+		switch a := args[0].(type) {
+		case []any:
+			elems = a
+		case filteredArray:
+			elems = a
+		default:
+			return toString(a), nil
+		}
+		ss := make([]string, 0, len(elems))
+		for _, e := range elems {
+			ss = append(ss, toString(e))
+		}
+		return strings.Join(ss, sep), nil
+	case "tojson":
+		if err := arity(1, 1); err != nil {
+			return nil, err
+		}
+		b, err := json.MarshalIndent(args[0], "", "  ")
+		if err != nil {
+			return nil, evalErrorf(n, "could not convert value to JSON: %s", err)
+		}
+		return string(b), nil
+	case "fromjson":
+		if err := arity(1, 1); err != nil {
+			return nil, err
+		}
+		var v any
+		if err := json.Unmarshal([]byte(toString(args[0])), &v); err != nil {
+			return nil, evalErrorf(n, "could not parse JSON string passed to fromJSON(): %s", err)
+		}
+		return v, nil
+	case "success", "always":
+		return true, nil
+	case "failure", "cancelled":
+		return false, nil
+	case "hashfiles":
+		return nil, evalErrorf(n, "hashFiles() cannot be evaluated since it depends on files in the workspace")
+	default:
+		return nil, evalErrorf(n, "undefined function %q", n.Callee)
+	}
+}
+
+func evalFormat(n *FuncCallNode, f string, args []any) (any, *EvalError) {
+	if msg := checkFormatFuncString(f); msg != "" {
+		return nil, evalErrorf(n, "format string %q is invalid. %s", f, msg)
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(f); i++ {
+		c := f[i]
+		if c == '}' {
+			i++ // Escaped '}}'
+		} else if c == '{' {
+			if f[i+1] == '{' {
+				i++ // Escaped '{{'
+			} else {
+				j := i + 1 + strings.IndexByte(f[i+1:], '}')
+				idx, _ := strconv.Atoi(f[i+1 : j])
+				if idx >= len(args) {
+					return nil, evalErrorf(n, "format string %q contains placeholder {%d} but only %d arguments are given to format", f, idx, len(args))
+				}
+				b.WriteString(toString(args[idx]))
+				i = j
+				continue
+			}
+		}
+		b.WriteByte(c)
+	}
+	return b.String(), nil
+}
+
+// TypeOfValue returns the type of the value decoded from JSON. Names of properties are converted to
+// lower case since they are accessed case-insensitively.
+func TypeOfValue(v any) Type {
+	switch v := v.(type) {
+	case []any:
+		var elem Type
+		for _, e := range v {
+			t := TypeOfValue(e)
+			if elem == nil {
+				elem = t
+			} else {
+				elem = elem.Merge(t)
+			}
+		}
+		if elem == nil {
+			elem = AnyType{}
+		}
+		return &ArrayType{Elem: elem}
+	case map[string]any:
+		props := make(map[string]Type, len(v))
+		for k, v := range v {
+			props[strings.ToLower(k)] = TypeOfValue(v)
+		}
+		return NewStrictObjectType(props)
+	default:
+		return typeOfJSONValue(v)
+	}
+}
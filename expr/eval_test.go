@@ -0,0 +1,135 @@
+package expr
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestEvaluateOK(t *testing.T) {
+	ctx := map[string]any{
+		"github": map[string]any{
+			"ref":        "refs/tags/v1.2.3",
+			"event_name": "push",
+			"event": map[string]any{
+				"commits": []any{
+					map[string]any{"message": "first"},
+					map[string]any{"message": "second"},
+				},
+			},
+		},
+		"matrix": map[string]any{
+			"os":      "ubuntu-latest",
+			"version": 20.0,
+		},
+		"env": map[string]any{
+			"DEBUG": "",
+		},
+	}
+
+	testCases := []struct {
+		input string
+		want  any
+	}{
+		{"null", nil},
+		{"true", true},
+		{"42", 42.0},
+		{"-1.5", -1.5},
+		{"0x10", 16.0},
+		{"'it''s'", "it's"},
+		{"github.ref", "refs/tags/v1.2.3"},
+		{"GitHub.Event_Name", "push"},
+		{"github['ref']", "refs/tags/v1.2.3"},
+		{"github.unknown", nil},
+		{"github.unknown.foo", nil},
+		{"matrix.version > 18", true},
+		{"matrix.version == '20'", true},
+		{"matrix.os == 'UBUNTU-LATEST'", true},
+		{"'abc' < 'abd'", true},
+		{"null == 0", true},
+		{"null == ''", true},
+		{"null == 'null'", false},
+		{"true == 1", true},
+		{"'' == 0", true},
+		{"'foo' == 0", false},
+		{"!env.DEBUG", true},
+		{"env.DEBUG || 'default'", "default"},
+		{"matrix.os && matrix.version", 20.0},
+		{"github.event.commits.*.message", []any{"first", "second"}},
+		{"github.event.commits[1].message", "second"},
+		{"github.event.commits[5]", nil},
+		{"contains(github.event.commits.*.message, 'FIRST')", true},
+		{"contains(github.ref, 'tags')", true},
+		{"startsWith(github.ref, 'refs/tags/')", true},
+		{"endsWith(github.ref, '.4')", false},
+		{"format('{0}-{1}-{{x}}', matrix.os, matrix.version)", "ubuntu-latest-20-{x}"},
+		{"join(github.event.commits.*.message)", "first,second"},
+		{"join(github.event.commits.*.message, ', ')", "first, second"},
+		{"join('single', ', ')", "single"},
+		{"fromJSON('{\"a\": [1, true]}').a[1]", true},
+		{"toJSON(matrix.version)", "20"},
+		{"case(matrix.os == 'windows', 'win', matrix.os == 'ubuntu-latest', 'linux', 'other')", "linux"},
+		{"success()", true},
+		{"failure()", false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.input, func(t *testing.T) {
+			l := NewLexer(tc.input + "}}")
+			n, err := NewParser().Parse(l)
+			if err != nil {
+				t.Fatal(err)
+			}
+			have, eerr := Evaluate(n, ctx)
+			if eerr != nil {
+				t.Fatal(eerr)
+			}
+			if !cmp.Equal(tc.want, have) {
+				t.Fatal(cmp.Diff(tc.want, have))
+			}
+		})
+	}
+}
+
+func TestEvaluateError(t *testing.T) {
+	testCases := []struct {
+		input string
+		want  string
+	}{
+		{"format('{1}', 1)", "placeholder {1}"},
+		{"format('{0', 1)", "format string"},
+		{"fromJSON('{')", "fromJSON"},
+		{"hashFiles('**/go.sum')", "hashFiles"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.input, func(t *testing.T) {
+			l := NewLexer(tc.input + "}}")
+			n, err := NewParser().Parse(l)
+			if err != nil {
+				t.Fatal(err)
+			}
+			_, have := Evaluate(n, map[string]any{})
+			if have == nil {
+				t.Fatal("error did not occur")
+			}
+			if !strings.Contains(have.Error(), tc.want) {
+				t.Fatalf("wanted %q in error message but got %q", tc.want, have.Error())
+			}
+		})
+	}
+}
+
+func TestTypeOfValue(t *testing.T) {
+	v := map[string]any{
+		"OS":    "linux",
+		"Items": []any{1.0, 2.0},
+		"Opt":   nil,
+	}
+	have := TypeOfValue(v).String()
+	want := "{items: array<number>; opt: null; os: string}"
+	if have != want {
+		t.Fatalf("wanted %q but got %q", want, have)
+	}
+}
@@ -0,0 +1,50 @@
+package expr_test
+
+import (
+	"fmt"
+
+	"github.com/rhysd/actionlint/expr"
+)
+
+func Example() {
+	// Expression to parse and check. The lexer requires the closing "}}"
+	src := "startsWith(github.ref, 'refs/tags/') && inputs.version != '' }}"
+
+	// Parse the expression into syntax tree
+	l := expr.NewLexer(src)
+	p := expr.NewParser()
+	n, err := p.Parse(l)
+	if err != nil {
+		fmt.Println("parse error:", err)
+		return
+	}
+
+	// Contexts available at "if:" of jobs are allowed. Type of "inputs" context can be defined by
+	// the caller
+	c := expr.NewSemanticsChecker(false, nil)
+	ctx, _ := expr.WorkflowKeyAvailability("jobs.<job_id>.if")
+	c.SetContextAvailability(ctx)
+	c.UpdateInputs(expr.NewStrictObjectType(map[string]expr.Type{
+		"version": expr.StringType{},
+	}))
+	ty, errs := c.Check(n)
+	fmt.Println("type:", ty)
+	fmt.Println("errors:", len(errs))
+
+	// Undefined property and unavailable context are reported as errors
+	l = expr.NewLexer("inputs.verison == secrets.TOKEN }}")
+	n, err = p.Parse(l)
+	if err != nil {
+		fmt.Println("parse error:", err)
+		return
+	}
+	_, errs = c.Check(n)
+	for _, err := range errs {
+		fmt.Printf("%d:%d: %s\n", err.Line, err.Column, err.Message)
+	}
+	// Output:
+	// type: bool
+	// errors: 0
+	// 1:1: property "verison" is not defined in object type {version: string}
+	// 1:19: context "secrets" is not allowed here. available contexts are "github", "inputs", "needs", "vars". see https://docs.github.com/en/actions/learn-github-actions/contexts#context-availability for more details
+}
@@ -1,4 +1,4 @@
-package actionlint
+package expr
 
 import (
 	"bufio"
@@ -17,14 +17,14 @@ func TestExprSemanticsCheckRealWorld(t *testing.T) {
 	s := bufio.NewScanner(f)
 	for s.Scan() {
 		expr := s.Text()
-		l := NewExprLexer(expr + "}}")
-		p := NewExprParser()
+		l := NewLexer(expr + "}}")
+		p := NewParser()
 		root, err := p.Parse(l)
 		if err != nil {
 			t.Errorf("%q caused parse error: %v", expr, err)
 			continue
 		}
-		c := NewExprSemanticsChecker(true, nil)
+		c := NewSemanticsChecker(true, nil)
 		c.Check(root)
 	}
 	if err := s.Err(); err != nil {
@@ -51,7 +51,7 @@ func BenchmarkExprRealWorld(b *testing.B) {
 	b.Run("Lex", func(b *testing.B) {
 		for i := 0; i < b.N; i++ {
 			for _, expr := range exprs {
-				l := NewExprLexer(expr)
+				l := NewLexer(expr)
 				for {
 					t := l.Next()
 					if l.lexErr != nil {
@@ -68,7 +68,7 @@ func BenchmarkExprRealWorld(b *testing.B) {
 	b.Run("LexParse", func(b *testing.B) {
 		for i := 0; i < b.N; i++ {
 			for _, expr := range exprs {
-				if _, err := NewExprParser().Parse(NewExprLexer(expr)); err != nil {
+				if _, err := NewParser().Parse(NewLexer(expr)); err != nil {
 					b.Fatal(err)
 				}
 			}
@@ -78,19 +78,19 @@ func BenchmarkExprRealWorld(b *testing.B) {
 	b.Run("LexParseSema", func(b *testing.B) {
 		for i := 0; i < b.N; i++ {
 			for _, expr := range exprs {
-				root, err := NewExprParser().Parse(NewExprLexer(expr + "}}"))
+				root, err := NewParser().Parse(NewLexer(expr + "}}"))
 				if err != nil {
 					b.Fatalf("%q caused parse error: %v", expr, err)
 				}
-				NewExprSemanticsChecker(true, nil).Check(root)
+				NewSemanticsChecker(true, nil).Check(root)
 			}
 		}
 	})
 
-	trees := []ExprNode{}
+	trees := []Node{}
 	for i := 0; i < b.N; i++ {
 		for _, expr := range exprs {
-			t, err := NewExprParser().Parse(NewExprLexer(expr))
+			t, err := NewParser().Parse(NewLexer(expr))
 			if err != nil {
 				b.Fatal(err)
 			}
@@ -101,7 +101,7 @@ func BenchmarkExprRealWorld(b *testing.B) {
 	b.Run("Sema-untrust", func(b *testing.B) {
 		for i := 0; i < b.N; i++ {
 			for _, t := range trees {
-				NewExprSemanticsChecker(true, nil).Check(t)
+				NewSemanticsChecker(true, nil).Check(t)
 			}
 		}
 	})
@@ -109,7 +109,7 @@ func BenchmarkExprRealWorld(b *testing.B) {
 	b.Run("Sema-trust", func(b *testing.B) {
 		for i := 0; i < b.N; i++ {
 			for _, t := range trees {
-				NewExprSemanticsChecker(false, nil).Check(t)
+				NewSemanticsChecker(false, nil).Check(t)
 			}
 		}
 	})
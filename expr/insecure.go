@@ -1,4 +1,4 @@
-package actionlint
+package expr
 
 import (
 	"strings"
@@ -151,8 +151,8 @@ type UntrustedInputChecker struct {
 	roots           UntrustedInputSearchRoots
 	filteringObject bool
 	cur             []*UntrustedInputMap
-	start           ExprNode
-	errs            []*ExprError
+	start           Node
+	errs            []*Error
 	safeCalls       int
 }
 
@@ -161,7 +161,7 @@ type UntrustedInputChecker struct {
 func NewUntrustedInputChecker(roots UntrustedInputSearchRoots) *UntrustedInputChecker {
 	return &UntrustedInputChecker{
 		roots: roots,
-		errs:  []*ExprError{},
+		errs:  []*Error{},
 	}
 }
 
@@ -295,14 +295,14 @@ func (u *UntrustedInputChecker) end() {
 	u.reset()
 }
 
-func (u *UntrustedInputChecker) OnVisitNodeEnter(n ExprNode) {
+func (u *UntrustedInputChecker) OnVisitNodeEnter(n Node) {
 	if f, ok := n.(*FuncCallNode); ok && isSafeFuncCall(f) {
 		u.safeCalls++
 	}
 }
 
 // OnVisitNodeLeave is a callback which should be called on visiting node after visiting its children.
-func (u *UntrustedInputChecker) OnVisitNodeLeave(n ExprNode) {
+func (u *UntrustedInputChecker) OnVisitNodeLeave(n Node) {
 	// Skip unsafe checks if we are inside of safe function call expression
 	if u.safeCalls > 0 {
 		if f, ok := n.(*FuncCallNode); ok && isSafeFuncCall(f) {
@@ -339,7 +339,7 @@ func (u *UntrustedInputChecker) OnVisitEnd() {
 
 // Errs returns errors detected by this checker. This method should be called after visiting all
 // nodes in a syntax tree.
-func (u *UntrustedInputChecker) Errs() []*ExprError {
+func (u *UntrustedInputChecker) Errs() []*Error {
 	return u.errs
 }
 
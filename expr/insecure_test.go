@@ -1,4 +1,4 @@
-package actionlint
+package expr
 
 import (
 	"regexp"
@@ -70,11 +70,11 @@ func TestExprInsecureBuiltinUntrustedInputs(t *testing.T) {
 
 func testRunTrustedInputsCheckerForNode(t *testing.T, c *UntrustedInputChecker, input string) {
 	t.Helper()
-	n, err := NewExprParser().Parse(NewExprLexer(input + "}}"))
+	n, err := NewParser().Parse(NewLexer(input + "}}"))
 	if err != nil {
 		t.Fatal(err)
 	}
-	VisitExprNode(n, func(n, p ExprNode, entering bool) {
+	VisitNode(n, func(n, p Node, entering bool) {
 		if entering {
 			c.OnVisitNodeEnter(n)
 		} else {
@@ -606,11 +606,11 @@ func TestExprInsecureNoUntrustedObjectFiltering(t *testing.T) {
 }
 
 func BenchmarkInsecureDetectUntrustedInputs(b *testing.B) {
-	parseNodes := func(exprs []string) []ExprNode {
-		ns := make([]ExprNode, 0, len(exprs))
-		p := NewExprParser()
+	parseNodes := func(exprs []string) []Node {
+		ns := make([]Node, 0, len(exprs))
+		p := NewParser()
 		for _, e := range exprs {
-			n, err := p.Parse(NewExprLexer(e + "}}"))
+			n, err := p.Parse(NewLexer(e + "}}"))
 			if err != nil {
 				b.Fatal(err)
 			}
@@ -667,7 +667,7 @@ func BenchmarkInsecureDetectUntrustedInputs(b *testing.B) {
 			c := NewUntrustedInputChecker(BuiltinUntrustedInputs)
 			for j, n := range untrustedNodes {
 				c.Init()
-				VisitExprNode(n, func(n, p ExprNode, entering bool) {
+				VisitNode(n, func(n, p Node, entering bool) {
 					if !entering {
 						c.OnVisitNodeLeave(n)
 					}
@@ -723,7 +723,7 @@ func BenchmarkInsecureDetectUntrustedInputs(b *testing.B) {
 			c := NewUntrustedInputChecker(BuiltinUntrustedInputs)
 			for j, n := range trustedNodes {
 				c.Init()
-				VisitExprNode(n, func(n, p ExprNode, entering bool) {
+				VisitNode(n, func(n, p Node, entering bool) {
 					if !entering {
 						c.OnVisitNodeLeave(n)
 					}
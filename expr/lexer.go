@@ -1,9 +1,10 @@
-package actionlint
+package expr
 
 import (
 	"fmt"
 	"strconv"
 	"strings"
+	"sync"
 	"text/scanner"
 )
 
@@ -153,36 +154,60 @@ const expectedDigitChars = "'0'..'9'"
 const expectedAlphaChars = "'a'..'z', 'A'..'Z', '_'"
 const expectedAllChars = expectedAlphaChars + ", " + expectedDigitChars + ", " + expectedPunctChars
 
-// ExprLexer is a struct to lex expression syntax. To know the syntax, see
+// Lexer is a struct to lex expression syntax. To know the syntax, see
 // https://docs.github.com/en/actions/learn-github-actions/expressions
-type ExprLexer struct {
-	src    string
-	scan   scanner.Scanner
-	lexErr *ExprError
-	start  scanner.Position
-}
-
-// NewExprLexer makes new ExprLexer instance.
-func NewExprLexer(src string) *ExprLexer {
-	l := &ExprLexer{
-		src: src,
-		start: scanner.Position{
-			Offset: 0,
-			Line:   1,
-			Column: 1,
-		},
-	}
-	l.scan.Init(strings.NewReader(src))
-	l.scan.Error = func(_ *scanner.Scanner, m string) {
-		l.error(fmt.Sprintf("scan error while lexing expression: %s", m))
+type Lexer struct {
+	src     string
+	scan    scanner.Scanner
+	lexErr  *Error
+	start   scanner.Position
+	reader  strings.Reader
+	onError func(*scanner.Scanner, string)
+}
+
+// lexerPool pools Lexer instances. Since scanner.Scanner has a buffer of 1KB, allocating a lexer for
+// each expression in workflows occupies a large part of allocations.
+var lexerPool = sync.Pool{
+	New: func() any {
+		l := &Lexer{}
+		l.onError = func(_ *scanner.Scanner, m string) {
+			l.error(fmt.Sprintf("scan error while lexing expression: %s", m))
+		}
+		return l
+	},
+}
+
+// NewLexer makes new Lexer instance. Call Release method when the lexer is no longer used so that
+// it is reused by the next call of this function.
+func NewLexer(src string) *Lexer {
+	l := lexerPool.Get().(*Lexer)
+	l.src = src
+	l.lexErr = nil
+	l.start = scanner.Position{
+		Offset: 0,
+		Line:   1,
+		Column: 1,
 	}
+	l.reader.Reset(src)
+	l.scan.Init(&l.reader)
+	l.scan.Error = l.onError
 	return l
 }
 
-func (lex *ExprLexer) error(msg string) {
+// Release puts the lexer back to the pool to reuse it. The lexer must not be used after calling this
+// method. Calling this method is optional. Tokens and errors returned from the lexer are still
+// available after the call.
+func (lex *Lexer) Release() {
+	lex.src = ""
+	lex.lexErr = nil
+	lex.reader.Reset("")
+	lexerPool.Put(lex)
+}
+
+func (lex *Lexer) error(msg string) {
 	if lex.lexErr == nil {
 		p := lex.scan.Pos()
-		lex.lexErr = &ExprError{
+		lex.lexErr = &Error{
 			Message: msg,
 			Offset:  p.Offset,
 			Line:    p.Line,
@@ -191,7 +216,7 @@ func (lex *ExprLexer) error(msg string) {
 	}
 }
 
-func (lex *ExprLexer) token(kind TokenKind) *Token {
+func (lex *Lexer) token(kind TokenKind) *Token {
 	p := lex.scan.Pos()
 	s := lex.start
 	t := &Token{
@@ -205,7 +230,7 @@ func (lex *ExprLexer) token(kind TokenKind) *Token {
 	return t
 }
 
-func (lex *ExprLexer) eof() *Token {
+func (lex *Lexer) eof() *Token {
 	return &Token{
 		Kind:   TokenKindEnd,
 		Value:  "",
@@ -215,12 +240,12 @@ func (lex *ExprLexer) eof() *Token {
 	}
 }
 
-func (lex *ExprLexer) eat() rune {
+func (lex *Lexer) eat() rune {
 	lex.scan.Next()
 	return lex.scan.Peek() // unlike lex.scan.Next(), return top char *after* eating
 }
 
-func (lex *ExprLexer) skipWhite() {
+func (lex *Lexer) skipWhite() {
 	for {
 		if r := lex.scan.Peek(); !isWhitespace(r) {
 			return
@@ -230,7 +255,7 @@ func (lex *ExprLexer) skipWhite() {
 	}
 }
 
-func (lex *ExprLexer) unexpected(r rune, where string, expected string) *Token {
+func (lex *Lexer) unexpected(r rune, where string, expected string) *Token {
 	var what string
 	if r == scanner.EOF {
 		what = "EOF"
@@ -255,12 +280,12 @@ func (lex *ExprLexer) unexpected(r rune, where string, expected string) *Token {
 	return lex.eof()
 }
 
-func (lex *ExprLexer) unexpectedEOF() *Token {
+func (lex *Lexer) unexpectedEOF() *Token {
 	lex.error("unexpected EOF while lexing expression")
 	return lex.eof()
 }
 
-func (lex *ExprLexer) lexIdent() *Token {
+func (lex *Lexer) lexIdent() *Token {
 	for {
 		// a-z, A-Z, 0-9, - or _
 		// https://docs.github.com/en/actions/learn-github-actions/contexts
@@ -270,7 +295,7 @@ func (lex *ExprLexer) lexIdent() *Token {
 	}
 }
 
-func (lex *ExprLexer) lexNum() *Token {
+func (lex *Lexer) lexNum() *Token {
 	// The official document says number literals are 'Any number format supported by JSON' but actually
 	// hex numbers starting with 0x are supported.
 
@@ -349,7 +374,7 @@ func (lex *ExprLexer) lexNum() *Token {
 	return lex.token(k)
 }
 
-func (lex *ExprLexer) lexHexInt() *Token {
+func (lex *Lexer) lexHexInt() *Token {
 	r := lex.scan.Peek()
 
 	if r == '0' {
@@ -377,7 +402,7 @@ func (lex *ExprLexer) lexHexInt() *Token {
 	return lex.token(TokenKindInt)
 }
 
-func (lex *ExprLexer) lexString() *Token {
+func (lex *Lexer) lexString() *Token {
 	// precond: current char is '
 	for {
 		switch lex.eat() {
@@ -391,7 +416,7 @@ func (lex *ExprLexer) lexString() *Token {
 	}
 }
 
-func (lex *ExprLexer) lexEnd() *Token {
+func (lex *Lexer) lexEnd() *Token {
 	r := lex.eat() // eat the first '}'
 	if r != '}' {
 		return lex.unexpected(r, "end marker }}", "'}'")
@@ -401,7 +426,7 @@ func (lex *ExprLexer) lexEnd() *Token {
 	return lex.token(TokenKindEnd)
 }
 
-func (lex *ExprLexer) lexLess() *Token {
+func (lex *Lexer) lexLess() *Token {
 	k := TokenKindLess
 	if lex.eat() == '=' { // eat '<'
 		k = TokenKindLessEq
@@ -410,7 +435,7 @@ func (lex *ExprLexer) lexLess() *Token {
 	return lex.token(k)
 }
 
-func (lex *ExprLexer) lexGreater() *Token {
+func (lex *Lexer) lexGreater() *Token {
 	k := TokenKindGreater
 	if lex.eat() == '=' { // eat '>'
 		k = TokenKindGreaterEq
@@ -419,7 +444,7 @@ func (lex *ExprLexer) lexGreater() *Token {
 	return lex.token(k)
 }
 
-func (lex *ExprLexer) lexEq() *Token {
+func (lex *Lexer) lexEq() *Token {
 	if r := lex.eat(); r != '=' { // eat '='
 		return lex.unexpected(r, "== operator", "'='")
 	}
@@ -427,7 +452,7 @@ func (lex *ExprLexer) lexEq() *Token {
 	return lex.token(TokenKindEq)
 }
 
-func (lex *ExprLexer) lexBang() *Token {
+func (lex *Lexer) lexBang() *Token {
 	k := TokenKindNot
 	if lex.eat() == '=' { // eat '!'
 		lex.scan.Next() // eat '='
@@ -436,7 +461,7 @@ func (lex *ExprLexer) lexBang() *Token {
 	return lex.token(k)
 }
 
-func (lex *ExprLexer) lexAnd() *Token {
+func (lex *Lexer) lexAnd() *Token {
 	if r := lex.eat(); r != '&' { // eat the first '&'
 		return lex.unexpected(r, "&& operator", "'&'")
 	}
@@ -444,7 +469,7 @@ func (lex *ExprLexer) lexAnd() *Token {
 	return lex.token(TokenKindAnd)
 }
 
-func (lex *ExprLexer) lexOr() *Token {
+func (lex *Lexer) lexOr() *Token {
 	if r := lex.eat(); r != '|' { // eat the first '|'
 		return lex.unexpected(r, "|| operator", "'|'")
 	}
@@ -452,7 +477,7 @@ func (lex *ExprLexer) lexOr() *Token {
 	return lex.token(TokenKindOr)
 }
 
-func (lex *ExprLexer) lexChar(k TokenKind) *Token {
+func (lex *Lexer) lexChar(k TokenKind) *Token {
 	lex.scan.Next()
 	return lex.token(k)
 }
@@ -460,7 +485,7 @@ func (lex *ExprLexer) lexChar(k TokenKind) *Token {
 // Next lexes next token to lex input incrementally. Lexer must be initialized with Init() method
 // before the first call of this method. This method is stateful. Lexer advances offset by lexing
 // token. To get the offset, use Offset() method.
-func (lex *ExprLexer) Next() *Token {
+func (lex *Lexer) Next() *Token {
 	lex.skipWhite()
 
 	r := lex.scan.Peek()
@@ -515,20 +540,21 @@ func (lex *ExprLexer) Next() *Token {
 }
 
 // Offset returns the current offset (scanning position).
-func (lex *ExprLexer) Offset() int {
+func (lex *Lexer) Offset() int {
 	return lex.scan.Pos().Offset
 }
 
 // Err returns an error while lexing. When multiple errors occur, the first one is returned.
-func (lex *ExprLexer) Err() *ExprError {
+func (lex *Lexer) Err() *Error {
 	return lex.lexErr
 }
 
 // LexExpression lexes the given string as expression syntax. The parameter must contain '}}' which
 // represents end of expression. Otherwise this function will report an error that it encountered
 // unexpected EOF.
-func LexExpression(src string) ([]*Token, int, *ExprError) {
-	l := NewExprLexer(src)
+func LexExpression(src string) ([]*Token, int, *Error) {
+	l := NewLexer(src)
+	defer l.Release()
 	ts := []*Token{}
 	for {
 		t := l.Next()
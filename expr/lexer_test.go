@@ -1,4 +1,4 @@
-package actionlint
+package expr
 
 import (
 	"strings"
@@ -850,3 +850,40 @@ func TestLexTokenPos(t *testing.T) {
 		}
 	}
 }
+
+func TestLexReuseReleasedLexer(t *testing.T) {
+	l := NewLexer("foo ~ bar}}")
+	for l.Err() == nil {
+		if l.Next().Kind == TokenKindEnd {
+			break
+		}
+	}
+	if l.Err() == nil {
+		t.Fatal("error did not occur")
+	}
+	l.Release()
+
+	// State of the released lexer must not remain in a new lexer
+	for range 10 {
+		l = NewLexer("github.event }}")
+		kinds := []TokenKind{}
+		for {
+			tok := l.Next()
+			if err := l.Err(); err != nil {
+				t.Fatal(err)
+			}
+			kinds = append(kinds, tok.Kind)
+			if tok.Kind == TokenKindEnd {
+				break
+			}
+		}
+		want := []TokenKind{TokenKindIdent, TokenKindDot, TokenKindIdent, TokenKindEnd}
+		if !cmp.Equal(want, kinds) {
+			t.Fatalf("wanted %v but got %v", want, kinds)
+		}
+		if l.Offset() != len("github.event }}") {
+			t.Fatalf("unexpected offset %d", l.Offset())
+		}
+		l.Release()
+	}
+}
@@ -1,44 +1,49 @@
-package actionlint
+package expr
 
 import (
 	"fmt"
 	"strconv"
 	"strings"
+	"unicode/utf8"
 )
 
-func errorAtToken(t *Token, msg string) *ExprError {
-	return &ExprError{
+func errorAtToken(t *Token, msg string) *Error {
+	err := &Error{
 		Message: msg,
 		Offset:  t.Offset,
 		Line:    t.Line,
 		Column:  t.Column,
 	}
+	if t.Value != "" && !strings.ContainsRune(t.Value, '\n') {
+		err.EndColumn = t.Column + utf8.RuneCountInString(t.Value)
+	}
+	return err
 }
 
-// ExprParser is a parser for expression syntax. To know the details, see
+// Parser is a parser for expression syntax. To know the details, see
 // https://docs.github.com/en/actions/learn-github-actions/expressions
-type ExprParser struct {
+type Parser struct {
 	cur   *Token
-	lexer *ExprLexer
-	err   *ExprError
+	lexer *Lexer
+	err   *Error
 }
 
-// NewExprParser creates new ExprParser instance.
-func NewExprParser() *ExprParser {
-	return &ExprParser{}
+// NewParser creates new Parser instance.
+func NewParser() *Parser {
+	return &Parser{}
 }
 
-func (p *ExprParser) error(msg string) {
+func (p *Parser) error(msg string) {
 	if p.err == nil {
 		p.err = errorAtToken(p.cur, msg)
 	}
 }
 
-func (p *ExprParser) errorf(format string, args ...interface{}) {
+func (p *Parser) errorf(format string, args ...interface{}) {
 	p.error(fmt.Sprintf(format, args...))
 }
 
-func (p *ExprParser) unexpected(where string, expected []TokenKind) {
+func (p *Parser) unexpected(where string, expected []TokenKind) {
 	if p.err != nil {
 		return
 	}
@@ -56,17 +61,17 @@ func (p *ExprParser) unexpected(where string, expected []TokenKind) {
 	p.error(msg)
 }
 
-func (p *ExprParser) next() *Token {
+func (p *Parser) next() *Token {
 	ret := p.cur
 	p.cur = p.lexer.Next()
 	return ret
 }
 
-func (p *ExprParser) peek() *Token {
+func (p *Parser) peek() *Token {
 	return p.cur
 }
 
-func (p *ExprParser) parseIdent() ExprNode {
+func (p *Parser) parseIdent() Node {
 	ident := p.next() // eat ident
 	switch p.peek().Kind {
 	case TokenKindLeftParen:
@@ -75,7 +80,7 @@ func (p *ExprParser) parseIdent() ExprNode {
 		// expression syntax, meant that callee is always built-in function name, not a general
 		// expression.
 		p.next() // eat '('
-		args := []ExprNode{}
+		args := []Node{}
 		if p.peek().Kind == TokenKindRightParen {
 			// no arguments
 			p.next() // eat ')'
@@ -119,7 +124,7 @@ func (p *ExprParser) parseIdent() ExprNode {
 	}
 }
 
-func (p *ExprParser) parseNestedExpr() ExprNode {
+func (p *Parser) parseNestedExpr() Node {
 	p.next() // eat '('
 
 	nested := p.parseLogicalOr()
@@ -137,7 +142,7 @@ func (p *ExprParser) parseNestedExpr() ExprNode {
 	return nested
 }
 
-func (p *ExprParser) parseInt() ExprNode {
+func (p *Parser) parseInt() Node {
 	t := p.peek()
 	i, err := strconv.ParseInt(t.Value, 0, 32)
 	if err != nil {
@@ -150,7 +155,7 @@ func (p *ExprParser) parseInt() ExprNode {
 	return &IntNode{int(i), t}
 }
 
-func (p *ExprParser) parseFloat() ExprNode {
+func (p *Parser) parseFloat() Node {
 	t := p.peek()
 	f, err := strconv.ParseFloat(t.Value, 64)
 	if err != nil {
@@ -163,7 +168,7 @@ func (p *ExprParser) parseFloat() ExprNode {
 	return &FloatNode{f, t}
 }
 
-func (p *ExprParser) parseString() ExprNode {
+func (p *Parser) parseString() Node {
 	t := p.next() // eat string
 	s := t.Value
 	s = s[1 : len(s)-1]                  // strip first and last single quotes
@@ -171,7 +176,7 @@ func (p *ExprParser) parseString() ExprNode {
 	return &StringNode{s, t}
 }
 
-func (p *ExprParser) parsePrimaryExpr() ExprNode {
+func (p *Parser) parsePrimaryExpr() Node {
 	switch p.peek().Kind {
 	case TokenKindIdent:
 		return p.parseIdent()
@@ -198,7 +203,7 @@ func (p *ExprParser) parsePrimaryExpr() ExprNode {
 	}
 }
 
-func (p *ExprParser) parsePostfixOp() ExprNode {
+func (p *Parser) parsePostfixOp() Node {
 	ret := p.parsePrimaryExpr()
 	if ret == nil {
 		return nil
@@ -241,7 +246,7 @@ func (p *ExprParser) parsePostfixOp() ExprNode {
 	}
 }
 
-func (p *ExprParser) parsePrefixOp() ExprNode {
+func (p *Parser) parsePrefixOp() Node {
 	t := p.peek()
 	if t.Kind != TokenKindNot {
 		return p.parsePostfixOp()
@@ -256,7 +261,7 @@ func (p *ExprParser) parsePrefixOp() ExprNode {
 	return &NotOpNode{o, t}
 }
 
-func (p *ExprParser) parseCompareBinOp() ExprNode {
+func (p *Parser) parseCompareBinOp() Node {
 	l := p.parsePrefixOp()
 	if l == nil {
 		return nil
@@ -289,7 +294,7 @@ func (p *ExprParser) parseCompareBinOp() ExprNode {
 	return &CompareOpNode{k, l, r}
 }
 
-func (p *ExprParser) parseLogicalAnd() ExprNode {
+func (p *Parser) parseLogicalAnd() Node {
 	l := p.parseCompareBinOp()
 	if l == nil {
 		return nil
@@ -305,7 +310,7 @@ func (p *ExprParser) parseLogicalAnd() ExprNode {
 	return &LogicalOpNode{LogicalOpNodeKindAnd, l, r}
 }
 
-func (p *ExprParser) parseLogicalOr() ExprNode {
+func (p *Parser) parseLogicalOr() Node {
 	l := p.parseLogicalAnd()
 	if l == nil {
 		return nil
@@ -322,7 +327,7 @@ func (p *ExprParser) parseLogicalOr() ExprNode {
 }
 
 // Err returns an error which was caused while previous parsing.
-func (p *ExprParser) Err() *ExprError {
+func (p *Parser) Err() *Error {
 	if err := p.lexer.Err(); err != nil {
 		return err
 	}
@@ -330,7 +335,7 @@ func (p *ExprParser) Err() *ExprError {
 }
 
 // Parse parses token sequence lexed by a given lexer into syntax tree.
-func (p *ExprParser) Parse(l *ExprLexer) (ExprNode, *ExprError) {
+func (p *Parser) Parse(l *Lexer) (Node, *Error) {
 	// Init
 	p.err = nil
 	p.lexer = l
@@ -1,4 +1,4 @@
-package actionlint
+package expr
 
 import (
 	"fmt"
@@ -13,7 +13,7 @@ func TestParseExpressionSyntaxOK(t *testing.T) {
 	testCases := []struct {
 		what     string
 		input    string
-		expected ExprNode
+		expected Node
 	}{
 		// simple expressions
 		{
@@ -141,7 +141,7 @@ func TestParseExpressionSyntaxOK(t *testing.T) {
 			input: "success()",
 			expected: &FuncCallNode{
 				Callee: "success",
-				Args:   []ExprNode{},
+				Args:   []Node{},
 			},
 		},
 		{
@@ -149,7 +149,7 @@ func TestParseExpressionSyntaxOK(t *testing.T) {
 			input: "fromJSON(object)",
 			expected: &FuncCallNode{
 				Callee: "fromJSON",
-				Args: []ExprNode{
+				Args: []Node{
 					&VariableNode{Name: "object"},
 				},
 			},
@@ -159,7 +159,7 @@ func TestParseExpressionSyntaxOK(t *testing.T) {
 			input: "contains('hello, world', 'o, w')",
 			expected: &FuncCallNode{
 				Callee: "contains",
-				Args: []ExprNode{
+				Args: []Node{
 					&StringNode{Value: "hello, world"},
 					&StringNode{Value: "o, w"},
 				},
@@ -460,7 +460,7 @@ func TestParseExpressionSyntaxOK(t *testing.T) {
 				Left: &NotOpNode{
 					Operand: &FuncCallNode{
 						Callee: "contains",
-						Args: []ExprNode{
+						Args: []Node{
 							&ObjectDerefNode{
 								Receiver: &VariableNode{Name: "some"},
 								Property: "value",
@@ -471,10 +471,10 @@ func TestParseExpressionSyntaxOK(t *testing.T) {
 				},
 				Right: &FuncCallNode{
 					Callee: "endsWith",
-					Args: []ExprNode{
+					Args: []Node{
 						&FuncCallNode{
 							Callee: "join",
-							Args: []ExprNode{
+							Args: []Node{
 								&ObjectDerefNode{
 									Receiver: &ArrayDerefNode{
 										Receiver: &VariableNode{Name: "x"},
@@ -497,7 +497,7 @@ func TestParseExpressionSyntaxOK(t *testing.T) {
 					Kind: LogicalOpNodeKindAnd,
 					Left: &FuncCallNode{
 						Callee: "contains",
-						Args: []ExprNode{
+						Args: []Node{
 							&ObjectDerefNode{
 								Receiver: &VariableNode{Name: "some"},
 								Property: "value",
@@ -507,10 +507,10 @@ func TestParseExpressionSyntaxOK(t *testing.T) {
 					},
 					Right: &FuncCallNode{
 						Callee: "endsWith",
-						Args: []ExprNode{
+						Args: []Node{
 							&FuncCallNode{
 								Callee: "join",
-								Args: []ExprNode{
+								Args: []Node{
 									&ObjectDerefNode{
 										Receiver: &ArrayDerefNode{
 											Receiver: &VariableNode{Name: "x"},
@@ -531,7 +531,7 @@ func TestParseExpressionSyntaxOK(t *testing.T) {
 			input: "contains(github.event['issue'].labels.*.name, 'bug')",
 			expected: &FuncCallNode{
 				Callee: "contains",
-				Args: []ExprNode{
+				Args: []Node{
 					&ObjectDerefNode{
 						Property: "name",
 						Receiver: &ArrayDerefNode{
@@ -590,8 +590,8 @@ func TestParseExpressionSyntaxOK(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.what, func(t *testing.T) {
-			p := NewExprParser()
-			n, err := p.Parse(NewExprLexer(tc.input + "}}"))
+			p := NewParser()
+			n, err := p.Parse(NewLexer(tc.input + "}}"))
 			if err != nil {
 				t.Fatal("Parse error:", err)
 			}
@@ -738,8 +738,8 @@ func TestParseExpressionSyntaxError(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.what, func(t *testing.T) {
-			p := NewExprParser()
-			_, err := p.Parse(NewExprLexer(tc.input + "}}"))
+			p := NewParser()
+			_, err := p.Parse(NewLexer(tc.input + "}}"))
 			if err == nil {
 				t.Fatal("Parse error did not occur:", tc.input)
 			}
@@ -755,7 +755,7 @@ func TestParseExpressionNumberLiteralsError(t *testing.T) {
 	testCases := []struct {
 		what  string
 		tok   *Token
-		parse func(*ExprParser) ExprNode
+		parse func(*Parser) Node
 	}{
 		{
 			what: "integer literal",
@@ -763,7 +763,7 @@ func TestParseExpressionNumberLiteralsError(t *testing.T) {
 				Kind:  TokenKindInt,
 				Value: "abc",
 			},
-			parse: func(p *ExprParser) ExprNode {
+			parse: func(p *Parser) Node {
 				return p.parseInt()
 			},
 		},
@@ -773,7 +773,7 @@ func TestParseExpressionNumberLiteralsError(t *testing.T) {
 				Kind:  TokenKindFloat,
 				Value: "abc",
 			},
-			parse: func(p *ExprParser) ExprNode {
+			parse: func(p *Parser) Node {
 				return p.parseFloat()
 			},
 		},
@@ -784,7 +784,7 @@ func TestParseExpressionNumberLiteralsError(t *testing.T) {
 			// This is really hacky depending on internal structure of parser. It is necessary
 			// because parsing int/float token never fails. To check the error handling, we need to
 			// inject an invalid token.
-			p := NewExprParser()
+			p := NewParser()
 			p.cur = tc.tok
 			tc.parse(p)
 			err := p.err
@@ -884,8 +884,8 @@ func TestParseExpressionTokenPosition(t *testing.T) {
 	for _, tc := range testCases {
 		t.Run(tc.what, func(t *testing.T) {
 			// Add 2 spaces so start position of token must be offset 2
-			p := NewExprParser()
-			e, err := p.Parse(NewExprLexer("  " + tc.input + "}}"))
+			p := NewParser()
+			e, err := p.Parse(NewLexer("  " + tc.input + "}}"))
 			if err != nil {
 				t.Fatal("Parse error:", err)
 			}
@@ -910,8 +910,8 @@ func TestParseExpressionTokenPosition(t *testing.T) {
 }
 
 func TestParseReturnFirstErrorOnMultipleErrors(t *testing.T) {
-	p := NewExprParser()
-	_, want := p.Parse(NewExprLexer(".}}"))
+	p := NewParser()
+	_, want := p.Parse(NewLexer(".}}"))
 	if want == nil {
 		t.Fatal("error did not occur")
 	}
@@ -921,3 +921,27 @@ func TestParseReturnFirstErrorOnMultipleErrors(t *testing.T) {
 		t.Fatalf("first error %q was expected but got %q", want, have)
 	}
 }
+
+func TestParseExpressionErrorRange(t *testing.T) {
+	testCases := []struct {
+		input string
+		col   int
+		end   int
+	}{
+		{"foo(0]", 6, 7},
+		{"foo == 'bar' 'baz'", 14, 19},
+		{"true &&", 8, 10}, // End of input is at "}}"
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.input, func(t *testing.T) {
+			_, err := NewParser().Parse(NewLexer(tc.input + "}}"))
+			if err == nil {
+				t.Fatal("Parse error did not occur:", tc.input)
+			}
+			if err.Column != tc.col || err.EndColumn != tc.end {
+				t.Fatalf("wanted range %d-%d but got %d-%d: %v", tc.col, tc.end, err.Column, err.EndColumn, err)
+			}
+		})
+	}
+}
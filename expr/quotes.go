@@ -0,0 +1,58 @@
+package expr
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Note: These helpers are duplicated from the actionlint package since this package must not
+// depend on it.
+
+type quotesBuilder struct {
+	inner strings.Builder
+	buf   []byte
+	comma bool
+}
+
+func (b *quotesBuilder) append(s string) {
+	if b.comma {
+		b.inner.WriteString(", ")
+	} else {
+		b.comma = true
+	}
+	b.buf = strconv.AppendQuote(b.buf[:0], s)
+	b.inner.Write(b.buf)
+}
+
+func (b *quotesBuilder) build() string {
+	return b.inner.String()
+}
+
+func quotes(ss []string) string {
+	l := len(ss)
+	if l == 0 {
+		return ""
+	}
+	n, max := 0, 0
+	for _, s := range ss {
+		m := len(s) + 2 // 2 for delims
+		n += m
+		if m > max {
+			max = m
+		}
+	}
+	n += (l - 1) * 2 // comma
+	b := quotesBuilder{}
+	b.buf = make([]byte, 0, max)
+	b.inner.Grow(n)
+	for _, s := range ss {
+		b.append(s)
+	}
+	return b.build()
+}
+
+func sortedQuotes(ss []string) string {
+	sort.Strings(ss)
+	return quotes(ss)
+}
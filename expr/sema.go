@@ -1,8 +1,9 @@
-package actionlint
+package expr
 
 import (
 	"encoding/json"
 	"fmt"
+	"math"
 	"strconv"
 	"strings"
 )
@@ -95,6 +96,54 @@ func parseFormatFuncSpecifiers(f string, n int) map[int]struct{} {
 	return ret
 }
 
+// checkFormatFuncString checks the format string passed to `format()` calls is valid in the same way
+// as the runner evaluates it. An unescaped brace which is not a part of placeholder causes an error
+// at runtime. It returns the reason when the format string is invalid. Otherwise it returns an empty
+// string.
+// https://github.com/actions/runner/blob/main/src/Sdk/Expressions/Sdk/Functions/Format.cs
+func checkFormatFuncString(f string) string {
+	i := 0
+	for i < len(f) {
+		l := strings.IndexByte(f[i:], '{')
+		r := strings.IndexByte(f[i:], '}')
+
+		if l >= 0 && (r < 0 || l < r) {
+			l += i
+			if l+1 < len(f) && f[l+1] == '{' {
+				i = l + 2 // Escaped '{'
+				continue
+			}
+			j := l + 1
+			for j < len(f) && '0' <= f[j] && f[j] <= '9' {
+				j++
+			}
+			if j == l+1 {
+				return fmt.Sprintf("\"{\" at offset %d is not followed by an index of placeholder like \"{0}\"", l)
+			}
+			if j < len(f) && f[j] == ':' {
+				return fmt.Sprintf("placeholder at offset %d has format specifiers after \":\" which are not supported", l)
+			}
+			if j >= len(f) || f[j] != '}' {
+				return fmt.Sprintf("placeholder %q at offset %d is not closed with \"}\"", f[l:j], l)
+			}
+			i = j + 1
+			continue
+		}
+
+		if r >= 0 {
+			r += i
+			if r+1 < len(f) && f[r+1] == '}' {
+				i = r + 2 // Escaped '}'
+				continue
+			}
+			return fmt.Sprintf("\"}\" at offset %d is not escaped", r)
+		}
+
+		break
+	}
+	return ""
+}
+
 // Functions
 
 // FuncSignature is a signature of function, which holds return and arguments types.
@@ -102,10 +151,10 @@ type FuncSignature struct {
 	// Name is a name of the function.
 	Name string
 	// Ret is a return type of the function.
-	Ret ExprType
+	Ret Type
 	// Params is a list of parameter types of the function. The final element of this list might
 	// be repeated as variable length arguments.
-	Params []ExprType
+	Params []Type
 	// VariableLengthParams is a flag to handle variable length parameters. When this flag is set to
 	// true, it means that the last type of params might be specified multiple times (including zero
 	// times). Setting true implies length of Params is more than 0.
@@ -134,7 +183,7 @@ var BuiltinFuncSignatures = map[string][]*FuncSignature{
 		{
 			Name: "contains",
 			Ret:  BoolType{},
-			Params: []ExprType{
+			Params: []Type{
 				StringType{},
 				StringType{},
 			},
@@ -143,7 +192,7 @@ var BuiltinFuncSignatures = map[string][]*FuncSignature{
 		{
 			Name: "contains",
 			Ret:  BoolType{},
-			Params: []ExprType{
+			Params: []Type{
 				&ArrayType{Elem: AnyType{}},
 				AnyType{},
 			},
@@ -153,7 +202,7 @@ var BuiltinFuncSignatures = map[string][]*FuncSignature{
 	"startswith": {{
 		Name: "startsWith",
 		Ret:  BoolType{},
-		Params: []ExprType{
+		Params: []Type{
 			StringType{},
 			StringType{},
 		},
@@ -162,7 +211,7 @@ var BuiltinFuncSignatures = map[string][]*FuncSignature{
 	"endswith": {{
 		Name: "endsWith",
 		Ret:  BoolType{},
-		Params: []ExprType{
+		Params: []Type{
 			StringType{},
 			StringType{},
 		},
@@ -171,7 +220,7 @@ var BuiltinFuncSignatures = map[string][]*FuncSignature{
 	"format": {{
 		Name: "format",
 		Ret:  StringType{},
-		Params: []ExprType{
+		Params: []Type{
 			StringType{},
 			AnyType{}, // variable length
 		},
@@ -182,7 +231,7 @@ var BuiltinFuncSignatures = map[string][]*FuncSignature{
 		{
 			Name: "join",
 			Ret:  StringType{},
-			Params: []ExprType{
+			Params: []Type{
 				&ArrayType{Elem: StringType{}},
 				StringType{},
 			},
@@ -192,7 +241,7 @@ var BuiltinFuncSignatures = map[string][]*FuncSignature{
 		{
 			Name: "join",
 			Ret:  StringType{},
-			Params: []ExprType{
+			Params: []Type{
 				&ArrayType{Elem: StringType{}},
 			},
 			IsConstFunc: true,
@@ -201,7 +250,7 @@ var BuiltinFuncSignatures = map[string][]*FuncSignature{
 	"tojson": {{
 		Name: "toJSON",
 		Ret:  StringType{},
-		Params: []ExprType{
+		Params: []Type{
 			AnyType{},
 		},
 		IsConstFunc: true,
@@ -209,14 +258,14 @@ var BuiltinFuncSignatures = map[string][]*FuncSignature{
 	"fromjson": {{
 		Name: "fromJSON",
 		Ret:  AnyType{},
-		Params: []ExprType{
+		Params: []Type{
 			StringType{},
 		},
 	}},
 	"hashfiles": {{
 		Name: "hashFiles",
 		Ret:  StringType{},
-		Params: []ExprType{
+		Params: []Type{
 			StringType{},
 		},
 		VariableLengthParams: true,
@@ -224,27 +273,27 @@ var BuiltinFuncSignatures = map[string][]*FuncSignature{
 	"success": {{
 		Name:   "success",
 		Ret:    BoolType{},
-		Params: []ExprType{},
+		Params: []Type{},
 	}},
 	"always": {{
 		Name:   "always",
 		Ret:    BoolType{},
-		Params: []ExprType{},
+		Params: []Type{},
 	}},
 	"cancelled": {{
 		Name:   "cancelled",
 		Ret:    BoolType{},
-		Params: []ExprType{},
+		Params: []Type{},
 	}},
 	"failure": {{
 		Name:   "failure",
 		Ret:    BoolType{},
-		Params: []ExprType{},
+		Params: []Type{},
 	}},
 	"case": {{
 		Name: "case",
 		Ret:  AnyType{},
-		Params: []ExprType{
+		Params: []Type{
 			BoolType{},
 			AnyType{},
 			AnyType{},
@@ -256,11 +305,42 @@ var BuiltinFuncSignatures = map[string][]*FuncSignature{
 
 // Global variables
 
+// newEventPayloadType creates the type of `github.event` object. The payload depends on the event
+// which triggered the workflow so it is a loose object. Only commit objects of push event are typed
+// strictly to check properties after object filtering like `github.event.commits.*.message`.
+// https://docs.github.com/en/webhooks/webhook-events-and-payloads#push
+func newEventPayloadType() *ObjectType {
+	user := NewObjectType(map[string]Type{
+		"name":     StringType{},
+		"email":    StringType{},
+		"username": StringType{},
+		"date":     StringType{},
+	})
+	files := &ArrayType{Elem: StringType{}}
+	commit := NewStrictObjectType(map[string]Type{
+		"id":        StringType{},
+		"tree_id":   StringType{},
+		"distinct":  BoolType{},
+		"message":   StringType{},
+		"timestamp": StringType{},
+		"url":       StringType{},
+		"author":    user,
+		"committer": user,
+		"added":     files,
+		"removed":   files,
+		"modified":  files,
+	})
+	return NewObjectType(map[string]Type{
+		"commits":     &ArrayType{Elem: commit},
+		"head_commit": commit,
+	})
+}
+
 // BuiltinGlobalVariableTypes defines types of all global variables. All context variables are
 // documented at https://docs.github.com/en/actions/learn-github-actions/contexts
-var BuiltinGlobalVariableTypes = map[string]ExprType{
+var BuiltinGlobalVariableTypes = map[string]Type{
 	// https://docs.github.com/en/actions/writing-workflows/choosing-what-your-workflow-does/accessing-contextual-information-about-workflow-runs#github-context
-	"github": NewStrictObjectType(map[string]ExprType{
+	"github": NewStrictObjectType(map[string]Type{
 		"action":                    StringType{},
 		"action_path":               StringType{}, // Note: Composite actions only
 		"action_ref":                StringType{},
@@ -272,7 +352,7 @@ var BuiltinGlobalVariableTypes = map[string]ExprType{
 		"artifact_cache_size_limit": NumberType{}, // Note: Undocumented
 		"base_ref":                  StringType{},
 		"env":                       StringType{},
-		"event":                     NewEmptyObjectType(), // Note: Stricter type check for this payload would be possible
+		"event":                     newEventPayloadType(), // Note: Stricter type check for this payload would be possible
 		"event_name":                StringType{},
 		"event_path":                StringType{},
 		"graphql_url":               StringType{},
@@ -309,14 +389,14 @@ var BuiltinGlobalVariableTypes = map[string]ExprType{
 	// https://docs.github.com/en/actions/learn-github-actions/contexts#env-context
 	"env": NewMapObjectType(StringType{}), // env.<env_name>
 	// https://docs.github.com/en/actions/learn-github-actions/contexts#job-context
-	"job": NewStrictObjectType(map[string]ExprType{
+	"job": NewStrictObjectType(map[string]Type{
 		"check_run_id": NumberType{},
-		"container": NewStrictObjectType(map[string]ExprType{
+		"container": NewStrictObjectType(map[string]Type{
 			"id":      StringType{},
 			"network": StringType{},
 		}),
 		"services": NewMapObjectType(
-			NewStrictObjectType(map[string]ExprType{
+			NewStrictObjectType(map[string]Type{
 				"id":      StringType{}, // job.services.<service id>.id
 				"network": StringType{},
 				"ports":   NewMapObjectType(StringType{}),
@@ -327,7 +407,7 @@ var BuiltinGlobalVariableTypes = map[string]ExprType{
 	// https://docs.github.com/en/actions/learn-github-actions/contexts#steps-context
 	"steps": NewEmptyStrictObjectType(), // This value will be updated contextually
 	// https://docs.github.com/en/actions/learn-github-actions/contexts#runner-context
-	"runner": NewStrictObjectType(map[string]ExprType{
+	"runner": NewStrictObjectType(map[string]Type{
 		"name":        StringType{},
 		"os":          StringType{},
 		"arch":        StringType{},
@@ -339,7 +419,7 @@ var BuiltinGlobalVariableTypes = map[string]ExprType{
 	// https://docs.github.com/en/actions/learn-github-actions/contexts#secrets-context
 	"secrets": NewMapObjectType(StringType{}),
 	// https://docs.github.com/en/actions/learn-github-actions/contexts#strategy-context
-	"strategy": NewObjectType(map[string]ExprType{
+	"strategy": NewObjectType(map[string]Type{
 		"fail-fast":    BoolType{},
 		"job-index":    NumberType{},
 		"job-total":    NumberType{},
@@ -358,28 +438,29 @@ var BuiltinGlobalVariableTypes = map[string]ExprType{
 
 // Semantics checker
 
-// ExprSemanticsChecker is a semantics checker for expression syntax. It checks types of values
+// SemanticsChecker is a semantics checker for expression syntax. It checks types of values
 // in given expression syntax tree. It additionally checks other semantics like arguments of
 // format() built-in function. To know the details of the syntax, see
 //
 // - https://docs.github.com/en/actions/learn-github-actions/contexts
 // - https://docs.github.com/en/actions/learn-github-actions/expressions
-type ExprSemanticsChecker struct {
+type SemanticsChecker struct {
 	funcs                 map[string][]*FuncSignature
-	vars                  map[string]ExprType
-	errs                  []*ExprError
+	vars                  map[string]Type
+	errs                  []*Error
 	varsCopied            bool
 	githubVarCopied       bool
 	untrusted             *UntrustedInputChecker
 	availableContexts     []string
 	availableSpecialFuncs []string
 	configVars            []string
+	contextAliases        map[string]string
 }
 
-// NewExprSemanticsChecker creates new ExprSemanticsChecker instance. When checkUntrustedInput is
+// NewSemanticsChecker creates new SemanticsChecker instance. When checkUntrustedInput is
 // set to true, the checker will make use of possibly untrusted inputs error.
-func NewExprSemanticsChecker(checkUntrustedInput bool, configVars []string) *ExprSemanticsChecker {
-	c := &ExprSemanticsChecker{
+func NewSemanticsChecker(checkUntrustedInput bool, configVars []string) *SemanticsChecker {
+	c := &SemanticsChecker{
 		funcs:           BuiltinFuncSignatures,
 		vars:            BuiltinGlobalVariableTypes,
 		varsCopied:      false,
@@ -392,9 +473,9 @@ func NewExprSemanticsChecker(checkUntrustedInput bool, configVars []string) *Exp
 	return c
 }
 
-func errorAtExpr(e ExprNode, msg string) *ExprError {
+func errorAtExpr(e Node, msg string) *Error {
 	t := e.Token()
-	return &ExprError{
+	return &Error{
 		Message: msg,
 		Offset:  t.Offset,
 		Line:    t.Line,
@@ -402,21 +483,21 @@ func errorAtExpr(e ExprNode, msg string) *ExprError {
 	}
 }
 
-func errorfAtExpr(e ExprNode, format string, args ...interface{}) *ExprError {
+func errorfAtExpr(e Node, format string, args ...interface{}) *Error {
 	return errorAtExpr(e, fmt.Sprintf(format, args...))
 }
 
-func (sema *ExprSemanticsChecker) errorf(e ExprNode, format string, args ...interface{}) {
+func (sema *SemanticsChecker) errorf(e Node, format string, args ...interface{}) {
 	sema.errs = append(sema.errs, errorfAtExpr(e, format, args...))
 }
 
-func (sema *ExprSemanticsChecker) ensureVarsCopied() {
+func (sema *SemanticsChecker) ensureVarsCopied() {
 	if sema.varsCopied {
 		return
 	}
 
 	// Make shallow copy of current variables map not to pollute global variable
-	copied := make(map[string]ExprType, len(sema.vars))
+	copied := make(map[string]Type, len(sema.vars))
 	for k, v := range sema.vars {
 		copied[k] = v
 	}
@@ -424,7 +505,7 @@ func (sema *ExprSemanticsChecker) ensureVarsCopied() {
 	sema.varsCopied = true
 }
 
-func (sema *ExprSemanticsChecker) ensureGithubVarCopied() {
+func (sema *SemanticsChecker) ensureGithubVarCopied() {
 	if sema.githubVarCopied {
 		return
 	}
@@ -435,30 +516,30 @@ func (sema *ExprSemanticsChecker) ensureGithubVarCopied() {
 
 // UpdateMatrix updates matrix object to given object type. Since matrix values change according to
 // 'matrix' section of job configuration, the type needs to be updated.
-func (sema *ExprSemanticsChecker) UpdateMatrix(ty *ObjectType) {
+func (sema *SemanticsChecker) UpdateMatrix(ty *ObjectType) {
 	sema.ensureVarsCopied()
 	sema.vars["matrix"] = ty
 }
 
 // UpdateSteps updates 'steps' context object to given object type.
-func (sema *ExprSemanticsChecker) UpdateSteps(ty *ObjectType) {
+func (sema *SemanticsChecker) UpdateSteps(ty *ObjectType) {
 	sema.ensureVarsCopied()
 	sema.vars["steps"] = ty
 }
 
 // UpdateNeeds updates 'needs' context object to given object type.
-func (sema *ExprSemanticsChecker) UpdateNeeds(ty *ObjectType) {
+func (sema *SemanticsChecker) UpdateNeeds(ty *ObjectType) {
 	sema.ensureVarsCopied()
 	sema.vars["needs"] = ty
 }
 
 // UpdateSecrets updates 'secrets' context object to given object type.
-func (sema *ExprSemanticsChecker) UpdateSecrets(ty *ObjectType) {
+func (sema *SemanticsChecker) UpdateSecrets(ty *ObjectType) {
 	sema.ensureVarsCopied()
 
 	// Merges automatically supplied secrets with manually defined secrets.
 	// ACTIONS_STEP_DEBUG and ACTIONS_RUNNER_DEBUG seem supplied from caller of the workflow (#130)
-	copied := NewStrictObjectType(map[string]ExprType{
+	copied := NewStrictObjectType(map[string]Type{
 		"github_token":         StringType{},
 		"actions_step_debug":   StringType{},
 		"actions_runner_debug": StringType{},
@@ -470,7 +551,7 @@ func (sema *ExprSemanticsChecker) UpdateSecrets(ty *ObjectType) {
 }
 
 // UpdateInputs updates 'inputs' context object to given object type.
-func (sema *ExprSemanticsChecker) UpdateInputs(ty *ObjectType) {
+func (sema *SemanticsChecker) UpdateInputs(ty *ObjectType) {
 	sema.ensureVarsCopied()
 	o := sema.vars["inputs"].(*ObjectType)
 	if len(o.Props) == 0 && o.IsStrict() {
@@ -484,14 +565,14 @@ func (sema *ExprSemanticsChecker) UpdateInputs(ty *ObjectType) {
 
 // UpdateDispatchInputs updates 'github.event.inputs' and 'inputs' objects to given object type.
 // https://docs.github.com/en/actions/reference/workflows-and-actions/events-that-trigger-workflows
-func (sema *ExprSemanticsChecker) UpdateDispatchInputs(ty *ObjectType) {
+func (sema *SemanticsChecker) UpdateDispatchInputs(ty *ObjectType) {
 	sema.UpdateInputs(ty)
 
 	// Update `github.event.inputs`.
 	// Unlike `inputs.*`, type of `github.event.inputs.*` is always string unlike `inputs.*`. We need
 	// to create a new type from `ty` (e.g. {foo: boolean, bar: number} -> {foo: string, bar: string})
 
-	p := make(map[string]ExprType, len(ty.Props))
+	p := make(map[string]Type, len(ty.Props))
 	for n := range ty.Props {
 		p[n] = StringType{}
 	}
@@ -502,7 +583,7 @@ func (sema *ExprSemanticsChecker) UpdateDispatchInputs(ty *ObjectType) {
 }
 
 // UpdateJobs updates 'jobs' context object to given object type.
-func (sema *ExprSemanticsChecker) UpdateJobs(ty *ObjectType) {
+func (sema *SemanticsChecker) UpdateJobs(ty *ObjectType) {
 	sema.ensureVarsCopied()
 	sema.vars["jobs"] = ty
 }
@@ -513,15 +594,35 @@ func (sema *ExprSemanticsChecker) UpdateJobs(ty *ObjectType) {
 //
 // Elements of 'avail' parameter must be in lower case to check context names in case-insensitive.
 //
-// If this method is not called before checks, ExprSemanticsChecker considers any contexts are
+// If this method is not called before checks, SemanticsChecker considers any contexts are
 // available by default.
-// Available contexts for workflow keys can be obtained from actionlint.ContextAvailability.
-func (sema *ExprSemanticsChecker) SetContextAvailability(avail []string) {
+// Available contexts for workflow keys can be obtained from WorkflowKeyAvailability.
+func (sema *SemanticsChecker) SetContextAvailability(avail []string) {
 	sema.availableContexts = avail
 }
 
-func (sema *ExprSemanticsChecker) checkAvailableContext(n *VariableNode) {
-	ctx := strings.ToLower(n.Name)
+// SetContextAliases sets aliases of contexts. Keys are names of the aliases and values are names of
+// the aliased contexts. For example, Gitea Actions provides "gitea" context as an alias of "github"
+// context. Keys and values must be in lower case.
+func (sema *SemanticsChecker) SetContextAliases(aliases map[string]string) {
+	sema.contextAliases = aliases
+	if sema.untrusted == nil {
+		return
+	}
+	roots := make(UntrustedInputSearchRoots, len(sema.untrusted.roots)+len(aliases))
+	for k, v := range sema.untrusted.roots {
+		roots[k] = v
+	}
+	for a, c := range aliases {
+		if r, ok := sema.untrusted.roots[c]; ok {
+			roots[a] = r
+		}
+	}
+	sema.untrusted.roots = roots
+}
+
+func (sema *SemanticsChecker) checkAvailableContext(n *VariableNode, name string) {
+	ctx := strings.ToLower(name)
 	for _, c := range sema.availableContexts {
 		if c == ctx {
 			return
@@ -551,16 +652,16 @@ func (sema *ExprSemanticsChecker) checkAvailableContext(n *VariableNode) {
 //
 // Elements of 'avail' parameter must be in lower case to check function names in case-insensitive.
 //
-// If this method is not called before checks, ExprSemanticsChecker considers no special function is
-// allowed by default. Allowed functions can be obtained from actionlint.SpecialFunctionNames global
+// If this method is not called before checks, SemanticsChecker considers no special function is
+// allowed by default. Allowed functions can be obtained from SpecialFunctionNames global
 // constant.
 //
-// Available function names for workflow keys can be obtained from actionlint.ContextAvailability.
-func (sema *ExprSemanticsChecker) SetSpecialFunctionAvailability(avail []string) {
+// Available function names for workflow keys can be obtained from WorkflowKeyAvailability.
+func (sema *SemanticsChecker) SetSpecialFunctionAvailability(avail []string) {
 	sema.availableSpecialFuncs = avail
 }
 
-func (sema *ExprSemanticsChecker) checkSpecialFunctionAvailability(n *FuncCallNode) {
+func (sema *SemanticsChecker) checkSpecialFunctionAvailability(n *FuncCallNode) {
 	f := strings.ToLower(n.Callee)
 
 	allowed, ok := SpecialFunctionNames[f]
@@ -583,20 +684,24 @@ func (sema *ExprSemanticsChecker) checkSpecialFunctionAvailability(n *FuncCallNo
 	)
 }
 
-func (sema *ExprSemanticsChecker) visitUntrustedCheckerOnEnterNode(n ExprNode) {
+func (sema *SemanticsChecker) visitUntrustedCheckerOnEnterNode(n Node) {
 	if sema.untrusted != nil {
 		sema.untrusted.OnVisitNodeEnter(n)
 	}
 }
 
-func (sema *ExprSemanticsChecker) visitUntrustedCheckerOnLeaveNode(n ExprNode) {
+func (sema *SemanticsChecker) visitUntrustedCheckerOnLeaveNode(n Node) {
 	if sema.untrusted != nil {
 		sema.untrusted.OnVisitNodeLeave(n)
 	}
 }
 
-func (sema *ExprSemanticsChecker) checkVariable(n *VariableNode) ExprType {
-	v, ok := sema.vars[n.Name]
+func (sema *SemanticsChecker) checkVariable(n *VariableNode) Type {
+	name := n.Name
+	if c, ok := sema.contextAliases[name]; ok {
+		name = c
+	}
+	v, ok := sema.vars[name]
 	if !ok {
 		ss := make([]string, 0, len(sema.vars))
 		for n := range sema.vars {
@@ -606,11 +711,11 @@ func (sema *ExprSemanticsChecker) checkVariable(n *VariableNode) ExprType {
 		return AnyType{}
 	}
 
-	sema.checkAvailableContext(n)
+	sema.checkAvailableContext(n, name)
 	return v
 }
 
-func (sema *ExprSemanticsChecker) checkObjectDeref(n *ObjectDerefNode) ExprType {
+func (sema *SemanticsChecker) checkObjectDeref(n *ObjectDerefNode) Type {
 	switch ty := sema.check(n.Receiver).(type) {
 	case AnyType:
 		return AnyType{}
@@ -639,7 +744,7 @@ func (sema *ExprSemanticsChecker) checkObjectDeref(n *ObjectDerefNode) ExprType
 			return ty
 		case *ObjectType:
 			// Map element type of delererenced array
-			var elem ExprType = AnyType{}
+			var elem Type = AnyType{}
 			if t, ok := et.Props[n.Property]; ok {
 				elem = t
 			} else if et.Mapped != nil {
@@ -663,7 +768,7 @@ func (sema *ExprSemanticsChecker) checkObjectDeref(n *ObjectDerefNode) ExprType
 	}
 }
 
-func (sema *ExprSemanticsChecker) checkConfigVariables(n *ObjectDerefNode) {
+func (sema *SemanticsChecker) checkConfigVariables(n *ObjectDerefNode) {
 	// https://docs.github.com/en/actions/learn-github-actions/variables#naming-conventions-for-configuration-variables
 	if strings.HasPrefix(n.Property, "github_") {
 		sema.errorf(
@@ -713,7 +818,7 @@ func (sema *ExprSemanticsChecker) checkConfigVariables(n *ObjectDerefNode) {
 	)
 }
 
-func (sema *ExprSemanticsChecker) checkArrayDeref(n *ArrayDerefNode) ExprType {
+func (sema *SemanticsChecker) checkArrayDeref(n *ArrayDerefNode) Type {
 	switch ty := sema.check(n.Receiver).(type) {
 	case AnyType:
 		return &ArrayType{AnyType{}, true}
@@ -737,26 +842,33 @@ func (sema *ExprSemanticsChecker) checkArrayDeref(n *ArrayDerefNode) ExprType {
 		}
 
 		// For strict object at receiver of .*
-		found := false
+		// Element type is the merged type of all object elements. Elements which are not objects are
+		// ignored since dereferencing their properties results in nothing.
+		var elem Type
 		for _, t := range ty.Props {
-			if _, ok := t.(*ObjectType); ok {
-				found = true
-				break
+			o, ok := t.(*ObjectType)
+			if !ok {
+				continue
+			}
+			if elem == nil {
+				elem = o
+			} else {
+				elem = elem.Merge(o)
 			}
 		}
-		if !found {
+		if elem == nil {
 			sema.errorf(n, "object type %q cannot be filtered by object filtering `.*` since it has no object element", ty.String())
 			return AnyType{}
 		}
 
-		return &ArrayType{AnyType{}, true}
+		return &ArrayType{elem, true}
 	default:
 		sema.errorf(n, "receiver of object filtering `.*` must be type of array or object but got %q", ty.String())
 		return AnyType{}
 	}
 }
 
-func (sema *ExprSemanticsChecker) checkIndexAccess(n *IndexAccessNode) ExprType {
+func (sema *SemanticsChecker) checkIndexAccess(n *IndexAccessNode) Type {
 	// Note: Index must be visited before Index to make UntrustedInputChecker work correctly even if
 	// the expression has some nest like foo[aaa.bbb].bar. Nest happens in top-down order and
 	// properties/indices access check is done in bottom-up order. So, as far as we visit nested
@@ -805,7 +917,7 @@ func (sema *ExprSemanticsChecker) checkIndexAccess(n *IndexAccessNode) ExprType
 	}
 }
 
-func checkFuncSignature(n *FuncCallNode, sig *FuncSignature, args []ExprType) *ExprError {
+func checkFuncSignature(n *FuncCallNode, sig *FuncSignature, args []Type) *Error {
 	lp, la := len(sig.Params), len(args)
 	if sig.VariableLengthParams && (lp > la) || !sig.VariableLengthParams && lp != la {
 		atLeast := ""
@@ -858,16 +970,30 @@ func checkFuncSignature(n *FuncCallNode, sig *FuncSignature, args []ExprType) *E
 	return nil
 }
 
-func (sema *ExprSemanticsChecker) checkBuiltinFuncCall(n *FuncCallNode, sig *FuncSignature) ExprType {
+func (sema *SemanticsChecker) checkBuiltinFuncCall(n *FuncCallNode, sig *FuncSignature, args []Type) Type {
 	sema.checkSpecialFunctionAvailability(n)
 
 	// Special checks for specific built-in functions
 	switch strings.ToLower(n.Callee) {
 	case "format":
+		for i, a := range args[1:] {
+			// Objects and arrays are formatted as 'Object' and 'Array' at runtime
+			switch a.(type) {
+			case *ObjectType:
+				sema.errorf(n.Args[i+1], "%s argument of format() is object type %q. it is formatted as 'Object' at runtime. use toJSON() to format the object as JSON", ordinal(i+2), a.String())
+			case *ArrayType:
+				sema.errorf(n.Args[i+1], "%s argument of format() is array type %q. it is formatted as 'Array' at runtime. use join() or toJSON() to format the array", ordinal(i+2), a.String())
+			}
+		}
+
 		lit, ok := n.Args[0].(*StringNode)
 		if !ok {
 			return sig.Ret
 		}
+		if msg := checkFormatFuncString(lit.Value); msg != "" {
+			sema.errorf(lit, "format string %q is invalid. %s. format() fails at runtime. escape braces like \"{{\" and \"}}\" to write them as-is", lit.Value, msg)
+			return sig.Ret
+		}
 		l := len(n.Args) - 1 // -1 means removing first format string argument
 
 		holders := parseFormatFuncSpecifiers(lit.Value, l)
@@ -883,6 +1009,30 @@ func (sema *ExprSemanticsChecker) checkBuiltinFuncCall(n *FuncCallNode, sig *Fun
 		for i := range holders {
 			sema.errorf(n, "format string %q contains placeholder {%d} but only %d arguments are given to format", lit.Value, i, l)
 		}
+	case "contains":
+		if _, ok := sig.Params[0].(*ArrayType); !ok {
+			return sig.Ret
+		}
+		var elem Type = AnyType{}
+		if a, ok := args[0].(*ArrayType); ok {
+			elem = a.Elem
+		}
+		switch item := args[1].(type) {
+		case *ObjectType, *ArrayType:
+			// https://docs.github.com/en/actions/writing-workflows/choosing-what-your-workflow-does/evaluate-expressions-in-workflows-and-actions#operators
+			sema.errorf(n.Args[1], "2nd argument of contains() is %q value. contains() always returns false since objects and arrays are only equal when they are the same instance", item.String())
+		default:
+			if !validateCompareOpOperands(CompareOpNodeKindEq, elem, item) {
+				sema.errorf(n.Args[1], "element type %q of 1st argument of contains() cannot be compared to %q value of 2nd argument. contains() always returns false", elem.String(), item.String())
+			}
+		}
+	case "join":
+		if len(args) < 2 {
+			return sig.Ret
+		}
+		if _, ok := args[1].(NumberType); ok {
+			sema.errorf(n.Args[1], "separator of join() must be string but got %q value. use string literal like ', '", args[1].String())
+		}
 	case "fromjson":
 		lit, ok := n.Args[0].(*StringNode)
 		if !ok {
@@ -905,7 +1055,7 @@ func (sema *ExprSemanticsChecker) checkBuiltinFuncCall(n *FuncCallNode, sig *Fun
 	return sig.Ret
 }
 
-func (sema *ExprSemanticsChecker) checkFuncCall(n *FuncCallNode) ExprType {
+func (sema *SemanticsChecker) checkFuncCall(n *FuncCallNode) Type {
 	// Check function name in case insensitive. For example, toJson and toJSON are the same function.
 	callee := strings.ToLower(n.Callee)
 	sigs, ok := sema.funcs[callee]
@@ -918,18 +1068,18 @@ func (sema *ExprSemanticsChecker) checkFuncCall(n *FuncCallNode) ExprType {
 		return AnyType{}
 	}
 
-	tys := make([]ExprType, 0, len(n.Args))
+	tys := make([]Type, 0, len(n.Args))
 	for _, a := range n.Args {
 		tys = append(tys, sema.check(a))
 	}
 
 	// Check all overloads
-	errs := []*ExprError{}
+	errs := []*Error{}
 	for _, sig := range sigs {
 		err := checkFuncSignature(n, sig, tys)
 		if err == nil {
 			// When one of overload pass type check, overload was resolved correctly
-			return sema.checkBuiltinFuncCall(n, sig)
+			return sema.checkBuiltinFuncCall(n, sig, tys)
 		}
 		errs = append(errs, err)
 	}
@@ -940,7 +1090,7 @@ func (sema *ExprSemanticsChecker) checkFuncCall(n *FuncCallNode) ExprType {
 	return AnyType{}
 }
 
-func (sema *ExprSemanticsChecker) checkNotOp(n *NotOpNode) ExprType {
+func (sema *SemanticsChecker) checkNotOp(n *NotOpNode) Type {
 	ty := sema.check(n.Operand)
 	if !(BoolType{}).Assignable(ty) {
 		sema.errorf(n, "type of operand of ! operator %q is not assignable to type \"bool\"", ty.String())
@@ -948,7 +1098,7 @@ func (sema *ExprSemanticsChecker) checkNotOp(n *NotOpNode) ExprType {
 	return BoolType{}
 }
 
-func validateCompareOpOperands(op CompareOpNodeKind, l, r ExprType) bool {
+func validateCompareOpOperands(op CompareOpNodeKind, l, r Type) bool {
 	// Comparison behavior: https://docs.github.com/en/actions/learn-github-actions/expressions#operators
 	switch op {
 	case CompareOpNodeKindEq, CompareOpNodeKindNotEq:
@@ -1002,24 +1152,121 @@ func validateCompareOpOperands(op CompareOpNodeKind, l, r ExprType) bool {
 	}
 }
 
-func (sema *ExprSemanticsChecker) checkCompareOp(n *CompareOpNode) ExprType {
+func (sema *SemanticsChecker) checkCompareOp(n *CompareOpNode) Type {
 	l := sema.check(n.Left)
 	r := sema.check(n.Right)
 
 	if !validateCompareOpOperands(n.Kind, l, r) {
 		sema.errorf(n, "%q value cannot be compared to %q value with %q operator", l.String(), r.String(), n.Kind.String())
+	} else if n.Kind == CompareOpNodeKindEq || n.Kind == CompareOpNodeKindNotEq {
+		if !sema.checkLooseEquality(n, n.Left, l, n.Right) {
+			sema.checkLooseEquality(n, n.Right, r, n.Left)
+		}
 	}
 
 	return BoolType{}
 }
 
+// checkLooseEquality checks pitfalls of loose equality between the typed operand and the literal
+// operand. When types of operands mismatch, they are coerced to numbers. For example,
+// `inputs.flag == 'true'` is always false when the input is boolean since 'true' is coerced to NaN.
+// It returns true when some error was reported.
+// https://docs.github.com/en/actions/writing-workflows/choosing-what-your-workflow-does/evaluate-expressions-in-workflows-and-actions#operators
+func (sema *SemanticsChecker) checkLooseEquality(n *CompareOpNode, operand Node, ty Type, lit Node) bool {
+	switch operand.(type) {
+	case *StringNode, *IntNode, *FloatNode, *BoolNode, *NullNode:
+		return false // Comparison between literals is not a pitfall of types
+	}
+
+	always := "false"
+	if n.Kind == CompareOpNodeKindNotEq {
+		always = "true"
+	}
+
+	switch ty.(type) {
+	case BoolType:
+		s, ok := lit.(*StringNode)
+		if !ok || !math.IsNaN(CoerceStringToNumber(s.Value)) {
+			return false
+		}
+		hint := "compare the value with bool literal true or false"
+		if b := strings.ToLower(strings.TrimSpace(s.Value)); b == "true" || b == "false" {
+			hint = fmt.Sprintf("compare the value with bool literal like \"%s %s\"", n.Kind.String(), b)
+		}
+		sema.errorf(n, "comparing bool value with string %q by %q operator is always %s. both operands are coerced to numbers on loose equality and the string is coerced to NaN. %s", s.Value, n.Kind.String(), always, hint)
+		return true
+	case StringType:
+		b, ok := lit.(*BoolNode)
+		if !ok {
+			return false
+		}
+		sema.errorf(n, "comparing string value with bool literal %t by %q operator is always %s unless the string is a number. both operands are coerced to numbers on loose equality and a non-numeric string is coerced to NaN. compare the value with string literal like \"%s '%t'\"", b.Value, n.Kind.String(), always, n.Kind.String(), b.Value)
+		return true
+	case NumberType:
+		s, ok := lit.(*StringNode)
+		if !ok {
+			return false
+		}
+		if f := CoerceStringToNumber(s.Value); !math.IsNaN(f) {
+			sema.errorf(n, "number value is compared with numeric string %q by %q operator. the string is coerced to number %s on loose equality. compare the value with number literal like \"%s %s\" to make the intention clear", s.Value, n.Kind.String(), formatNumber(f), n.Kind.String(), formatNumber(f))
+		} else {
+			sema.errorf(n, "comparing number value with string %q by %q operator is always %s. the string is coerced to NaN on loose equality", s.Value, n.Kind.String(), always)
+		}
+		return true
+	default:
+		return false
+	}
+}
+
+func formatNumber(f float64) string {
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
+
+// CoerceStringToNumber coerces the string to a number in the same way as the runner. Surrounding
+// whitespaces are ignored and an empty string is coerced to 0. Strings which are not numbers are
+// coerced to NaN.
+// https://github.com/actions/runner/blob/main/src/Sdk/Expressions/ExpressionUtility.cs
+func CoerceStringToNumber(s string) float64 {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0
+	}
+	switch s {
+	case "Infinity":
+		return math.Inf(1)
+	case "-Infinity":
+		return math.Inf(-1)
+	}
+	if len(s) > 2 && s[0] == '0' && (s[1] == 'x' || s[1] == 'o') {
+		base := 16
+		if s[1] == 'o' {
+			base = 8
+		}
+		if i, err := strconv.ParseUint(s[2:], base, 32); err == nil {
+			return float64(i)
+		}
+		return math.NaN()
+	}
+	// Reject the formats which are accepted by Go but not by the runner such as "inf" or "1_000"
+	for _, r := range s {
+		if !('0' <= r && r <= '9') && r != '.' && r != '-' && r != '+' && r != 'e' && r != 'E' {
+			return math.NaN()
+		}
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return math.NaN()
+	}
+	return f
+}
+
 // checkWithNarrowing checks type of given expression with type narrowing. Type narrowing narrows
 // down the type of the expression by assuming its value. For example, `l && r` is typed as
 // `typeof(l) | typeof(r)` usually. However when the expression is assumed to be true, its type can
 // be narrowed down to `typeof(r)`.
 // This analysis is useful to make type checking more accurate. For example, `some_var && 60 || 20`
 // can be typed as `number` instead of `typeof(some_var) | number`. (#384)
-func (sema *ExprSemanticsChecker) checkWithNarrowing(n ExprNode, isTruthy bool) ExprType {
+func (sema *SemanticsChecker) checkWithNarrowing(n Node, isTruthy bool) Type {
 	switch n := n.(type) {
 	case *LogicalOpNode:
 		switch n.Kind {
@@ -1044,7 +1291,7 @@ func (sema *ExprSemanticsChecker) checkWithNarrowing(n ExprNode, isTruthy bool)
 	}
 }
 
-func (sema *ExprSemanticsChecker) checkLogicalOp(n *LogicalOpNode) ExprType {
+func (sema *SemanticsChecker) checkLogicalOp(n *LogicalOpNode) Type {
 	switch n.Kind {
 	case LogicalOpNodeKindAnd:
 		// When `l` is false in `l && r`, its type is `typeof(l)`. Otherwise `typeof(r)`.
@@ -1061,7 +1308,7 @@ func (sema *ExprSemanticsChecker) checkLogicalOp(n *LogicalOpNode) ExprType {
 	}
 }
 
-func (sema *ExprSemanticsChecker) check(expr ExprNode) ExprType {
+func (sema *SemanticsChecker) check(expr Node) Type {
 	sema.visitUntrustedCheckerOnEnterNode(expr)
 	defer sema.visitUntrustedCheckerOnLeaveNode(expr) // Call this method in bottom-up order
 
@@ -1098,8 +1345,8 @@ func (sema *ExprSemanticsChecker) check(expr ExprNode) ExprType {
 // Check checks semantics of given expression syntax tree. It returns the type of the expression as
 // the first return value when the check was successfully done. And it returns all errors found
 // while checking the expression as the second return value.
-func (sema *ExprSemanticsChecker) Check(expr ExprNode) (ExprType, []*ExprError) {
-	sema.errs = []*ExprError{}
+func (sema *SemanticsChecker) Check(expr Node) (Type, []*Error) {
+	sema.errs = []*Error{}
 	if sema.untrusted != nil {
 		sema.untrusted.Init()
 	}
@@ -1118,7 +1365,7 @@ func (sema *ExprSemanticsChecker) Check(expr ExprNode) (ExprType, []*ExprError)
 //   - (!true && 'foo') || 'bar'
 //   - startsWith('foobar', 'foo')
 //   - format('{} + {} = {}', 1, 2, 3)
-func (sema *ExprSemanticsChecker) IsConstant(expr ExprNode) bool {
+func (sema *SemanticsChecker) IsConstant(expr Node) bool {
 	switch e := expr.(type) {
 	case *NullNode, *BoolNode, *IntNode, *FloatNode, *StringNode:
 		return true
@@ -1,7 +1,8 @@
-package actionlint
+package expr
 
 import (
 	"fmt"
+	"math"
 	"strings"
 	"testing"
 	"unicode"
@@ -13,7 +14,7 @@ func TestExprSemanticsCheckOK(t *testing.T) {
 	testCases := []struct {
 		what          string
 		input         string
-		expected      ExprType
+		expected      Type
 		funcs         map[string][]*FuncSignature
 		matrix        *ObjectType
 		steps         *ObjectType
@@ -63,8 +64,8 @@ func TestExprSemanticsCheckOK(t *testing.T) {
 				"test": {
 					{
 						Name: "test",
-						Ret: NewObjectType(map[string]ExprType{
-							"bar": NewObjectType(map[string]ExprType{
+						Ret: NewObjectType(map[string]Type{
+							"bar": NewObjectType(map[string]Type{
 								"piyo": BoolType{},
 							}),
 						}),
@@ -90,7 +91,7 @@ func TestExprSemanticsCheckOK(t *testing.T) {
 				"test": {
 					{
 						Name: "test",
-						Ret: NewObjectType(map[string]ExprType{
+						Ret: NewObjectType(map[string]Type{
 							"bar": &ArrayType{Elem: BoolType{}},
 						}),
 					},
@@ -105,10 +106,10 @@ func TestExprSemanticsCheckOK(t *testing.T) {
 				"test": {
 					{
 						Name: "test",
-						Ret: NewObjectType(map[string]ExprType{
+						Ret: NewObjectType(map[string]Type{
 							"foo": &ArrayType{
-								Elem: NewObjectType(map[string]ExprType{
-									"bar": NewObjectType(map[string]ExprType{
+								Elem: NewObjectType(map[string]Type{
+									"bar": NewObjectType(map[string]Type{
 										"piyo": StringType{},
 									}),
 								}),
@@ -126,10 +127,10 @@ func TestExprSemanticsCheckOK(t *testing.T) {
 				"test": {
 					{
 						Name: "test",
-						Ret: NewStrictObjectType(map[string]ExprType{
+						Ret: NewStrictObjectType(map[string]Type{
 							"foo": &ArrayType{
-								Elem: NewStrictObjectType(map[string]ExprType{
-									"bar": NewStrictObjectType(map[string]ExprType{
+								Elem: NewStrictObjectType(map[string]Type{
+									"bar": NewStrictObjectType(map[string]Type{
 										"piyo": StringType{},
 									}),
 								}),
@@ -183,8 +184,8 @@ func TestExprSemanticsCheckOK(t *testing.T) {
 				"test": {
 					{
 						Name: "test",
-						Ret: NewObjectType(map[string]ExprType{
-							"bar": NewObjectType(map[string]ExprType{
+						Ret: NewObjectType(map[string]Type{
+							"bar": NewObjectType(map[string]Type{
 								"piyo": BoolType{},
 							}),
 						}),
@@ -223,9 +224,9 @@ func TestExprSemanticsCheckOK(t *testing.T) {
 				"test": {
 					{
 						Name: "test",
-						Ret: NewObjectType(map[string]ExprType{
+						Ret: NewObjectType(map[string]Type{
 							"foo": &ArrayType{
-								Elem: NewObjectType(map[string]ExprType{
+								Elem: NewObjectType(map[string]Type{
 									"bar": StringType{},
 								}),
 							},
@@ -243,7 +244,7 @@ func TestExprSemanticsCheckOK(t *testing.T) {
 					{
 						Name: "test",
 						Ret: &ArrayType{
-							Elem: NewObjectType(map[string]ExprType{
+							Elem: NewObjectType(map[string]Type{
 								"x": NumberType{},
 							}),
 						},
@@ -320,7 +321,7 @@ func TestExprSemanticsCheckOK(t *testing.T) {
 		{
 			what:  "coercing two objects on && operator",
 			input: "foo() && bar()",
-			expected: NewStrictObjectType(map[string]ExprType{
+			expected: NewStrictObjectType(map[string]Type{
 				"foo": NumberType{},
 				"bar": BoolType{},
 			}),
@@ -328,7 +329,7 @@ func TestExprSemanticsCheckOK(t *testing.T) {
 				"foo": {
 					{
 						Name: "foo",
-						Ret: NewStrictObjectType(map[string]ExprType{
+						Ret: NewStrictObjectType(map[string]Type{
 							"foo": NumberType{},
 						}),
 					},
@@ -336,7 +337,7 @@ func TestExprSemanticsCheckOK(t *testing.T) {
 				"bar": {
 					{
 						Name: "bar",
-						Ret: NewStrictObjectType(map[string]ExprType{
+						Ret: NewStrictObjectType(map[string]Type{
 							"bar": BoolType{},
 						}),
 					},
@@ -346,7 +347,7 @@ func TestExprSemanticsCheckOK(t *testing.T) {
 		{
 			what:  "coercing two objects on || operator",
 			input: "foo() || bar()",
-			expected: NewStrictObjectType(map[string]ExprType{
+			expected: NewStrictObjectType(map[string]Type{
 				"foo": NumberType{},
 				"bar": BoolType{},
 			}),
@@ -354,7 +355,7 @@ func TestExprSemanticsCheckOK(t *testing.T) {
 				"foo": {
 					{
 						Name: "foo",
-						Ret: NewStrictObjectType(map[string]ExprType{
+						Ret: NewStrictObjectType(map[string]Type{
 							"foo": NumberType{},
 						}),
 					},
@@ -362,7 +363,7 @@ func TestExprSemanticsCheckOK(t *testing.T) {
 				"bar": {
 					{
 						Name: "bar",
-						Ret: NewStrictObjectType(map[string]ExprType{
+						Ret: NewStrictObjectType(map[string]Type{
 							"bar": BoolType{},
 						}),
 					},
@@ -383,7 +384,7 @@ func TestExprSemanticsCheckOK(t *testing.T) {
 			what:     "matrix value with typed matrix values",
 			input:    "matrix.foooo",
 			expected: StringType{},
-			matrix: NewStrictObjectType(map[string]ExprType{
+			matrix: NewStrictObjectType(map[string]Type{
 				"foooo": StringType{},
 			}),
 		},
@@ -391,8 +392,8 @@ func TestExprSemanticsCheckOK(t *testing.T) {
 			what:     "step output value with typed steps outputs",
 			input:    "steps.foo.outputs",
 			expected: NewEmptyObjectType(),
-			steps: NewStrictObjectType(map[string]ExprType{
-				"foo": NewStrictObjectType(map[string]ExprType{
+			steps: NewStrictObjectType(map[string]Type{
+				"foo": NewStrictObjectType(map[string]Type{
 					"outputs":    NewEmptyObjectType(),
 					"conclusion": StringType{},
 					"outcome":    StringType{},
@@ -403,8 +404,8 @@ func TestExprSemanticsCheckOK(t *testing.T) {
 			what:     "step conclusion with typed steps outputs",
 			input:    "steps.foo.conclusion",
 			expected: StringType{},
-			steps: NewStrictObjectType(map[string]ExprType{
-				"foo": NewStrictObjectType(map[string]ExprType{
+			steps: NewStrictObjectType(map[string]Type{
+				"foo": NewStrictObjectType(map[string]Type{
 					"outputs":    NewEmptyObjectType(),
 					"conclusion": StringType{},
 					"outcome":    StringType{},
@@ -420,9 +421,9 @@ func TestExprSemanticsCheckOK(t *testing.T) {
 			what:     "output string in needs context object",
 			input:    "needs.foo.outputs.out1",
 			expected: StringType{},
-			needs: NewStrictObjectType(map[string]ExprType{
-				"foo": NewStrictObjectType(map[string]ExprType{
-					"outputs": NewStrictObjectType(map[string]ExprType{
+			needs: NewStrictObjectType(map[string]Type{
+				"foo": NewStrictObjectType(map[string]Type{
+					"outputs": NewStrictObjectType(map[string]Type{
 						"out1": StringType{},
 						"out2": StringType{},
 					}),
@@ -434,9 +435,9 @@ func TestExprSemanticsCheckOK(t *testing.T) {
 			what:     "result in needs context object",
 			input:    "needs.foo.result",
 			expected: StringType{},
-			needs: NewStrictObjectType(map[string]ExprType{
-				"foo": NewStrictObjectType(map[string]ExprType{
-					"outputs": NewStrictObjectType(map[string]ExprType{
+			needs: NewStrictObjectType(map[string]Type{
+				"foo": NewStrictObjectType(map[string]Type{
+					"outputs": NewStrictObjectType(map[string]Type{
 						"out1": StringType{},
 						"out2": StringType{},
 					}),
@@ -477,7 +478,7 @@ func TestExprSemanticsCheckOK(t *testing.T) {
 				"test": {
 					{
 						Name: "test",
-						Ret: NewObjectType(map[string]ExprType{
+						Ret: NewObjectType(map[string]Type{
 							"foo-bar_piyo": NullType{},
 						}),
 					},
@@ -500,8 +501,23 @@ func TestExprSemanticsCheckOK(t *testing.T) {
 			expected: StringType{},
 		},
 		{
-			what:     "braces not for placeholders in format string of format() call",
-			input:    "format('{0} {} {x} {', 1)",
+			what:     "string is searched in array of strings by contains()",
+			input:    "contains(github.event.commits.*.message, 'fix') && contains(fromJSON('[\"a\", 1]'), 1)",
+			expected: BoolType{},
+		},
+		{
+			what:     "value of any type is searched in array by contains()",
+			input:    "contains(fromJSON('[{\"a\": 1}]'), github.event.foo) && contains(github.event.foo, 'bar')",
+			expected: BoolType{},
+		},
+		{
+			what:     "loose equality without pitfalls",
+			input:    "fromJSON('true') == '1' && github.event_name == 'push' && fromJSON('true') == true && '42' == 42 && fromJSON('1') == 1",
+			expected: BoolType{},
+		},
+		{
+			what:     "object formatted with toJSON() in format() call",
+			input:    "format('{0} {1}', toJSON(github.event), join(github.event.commits.*.message))",
 			expected: StringType{},
 		},
 		{
@@ -549,6 +565,16 @@ func TestExprSemanticsCheckOK(t *testing.T) {
 			input:    "github.*.foo",
 			expected: &ArrayType{AnyType{}, true},
 		},
+		{
+			what:     "element types of strict object are merged at object filter",
+			input:    `fromJSON('{"a": {"x": 1}, "b": {"x": 2, "y": "s"}, "c": 3}').*.x`,
+			expected: &ArrayType{NumberType{}, true},
+		},
+		{
+			what:     "commit objects of push event payload at object filter",
+			input:    "github.event.commits.*.author.name",
+			expected: &ArrayType{StringType{}, true},
+		},
 		{
 			what:     "map object index access with string literal",
 			input:    "env['FOO']",
@@ -568,7 +594,7 @@ func TestExprSemanticsCheckOK(t *testing.T) {
 			what:     "narrowed inputs object",
 			input:    "inputs.hello",
 			expected: NumberType{},
-			inputs: NewStrictObjectType(map[string]ExprType{
+			inputs: NewStrictObjectType(map[string]Type{
 				"hello": NumberType{},
 			}),
 		},
@@ -576,7 +602,7 @@ func TestExprSemanticsCheckOK(t *testing.T) {
 			what:     "narrowed secrets object",
 			input:    "secrets.token",
 			expected: StringType{},
-			secrets: NewStrictObjectType(map[string]ExprType{
+			secrets: NewStrictObjectType(map[string]Type{
 				"token": StringType{},
 			}),
 		},
@@ -589,7 +615,7 @@ func TestExprSemanticsCheckOK(t *testing.T) {
 			what:     "automatically supplied secret",
 			input:    "secrets.github_token",
 			expected: StringType{},
-			secrets: NewStrictObjectType(map[string]ExprType{
+			secrets: NewStrictObjectType(map[string]Type{
 				"foo": StringType{},
 			}),
 		},
@@ -597,7 +623,7 @@ func TestExprSemanticsCheckOK(t *testing.T) {
 			what:     "automatically supplied secret",
 			input:    "secrets.ACTIONS_STEP_DEBUG",
 			expected: StringType{},
-			secrets: NewStrictObjectType(map[string]ExprType{
+			secrets: NewStrictObjectType(map[string]Type{
 				"foo": StringType{},
 			}),
 		},
@@ -605,7 +631,7 @@ func TestExprSemanticsCheckOK(t *testing.T) {
 			what:     "automatically supplied secret",
 			input:    "secrets.ACTIONS_RUNNER_DEBUG",
 			expected: StringType{},
-			secrets: NewStrictObjectType(map[string]ExprType{
+			secrets: NewStrictObjectType(map[string]Type{
 				"foo": StringType{},
 			}),
 		},
@@ -613,7 +639,7 @@ func TestExprSemanticsCheckOK(t *testing.T) {
 			what:     "jobs object",
 			input:    "jobs.some_job",
 			expected: NewEmptyObjectType(),
-			jobs: NewStrictObjectType(map[string]ExprType{
+			jobs: NewStrictObjectType(map[string]Type{
 				"some_job": NewEmptyObjectType(),
 			}),
 		},
@@ -732,7 +758,7 @@ func TestExprSemanticsCheckOK(t *testing.T) {
 		{
 			what:  "fromJSON with JSON constant value",
 			input: `fromJSON('{"foo":true,"bar":["foo", 12.3],"piyo":null}')`,
-			expected: NewStrictObjectType(map[string]ExprType{
+			expected: NewStrictObjectType(map[string]Type{
 				"foo":  BoolType{},
 				"bar":  &ArrayType{Elem: StringType{}}, // Element type was merged
 				"piyo": NullType{},
@@ -787,13 +813,13 @@ func TestExprSemanticsCheckOK(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.what, func(t *testing.T) {
-			p := NewExprParser()
-			e, err := p.Parse(NewExprLexer(tc.input + "}}"))
+			p := NewParser()
+			e, err := p.Parse(NewLexer(tc.input + "}}"))
 			if err != nil {
 				t.Fatal("Parse error:", tc.input)
 			}
 
-			c := NewExprSemanticsChecker(false, nil)
+			c := NewSemanticsChecker(false, nil)
 			c.SetContextAvailability([]string{"github", "job", "jobs", "matrix", "steps", "needs", "env", "inputs", "secrets", "vars", "runner"})
 			if tc.funcs != nil {
 				c.funcs = tc.funcs
@@ -881,7 +907,7 @@ func TestExprSemanticsCheckError(t *testing.T) {
 					{
 						Name: "test",
 						Ret: &ArrayType{
-							Elem: NewStrictObjectType(map[string]ExprType{
+							Elem: NewStrictObjectType(map[string]Type{
 								"foo": BoolType{},
 							}),
 						},
@@ -889,6 +915,20 @@ func TestExprSemanticsCheckError(t *testing.T) {
 				},
 			},
 		},
+		{
+			what:  "strict prop check at object filter for strict object",
+			input: `fromJSON('{"a": {"x": 1}, "b": {"y": "s"}}').*.z`,
+			expected: []string{
+				"property \"z\" is not defined in object type {x: number; y: string} as element of filtered array",
+			},
+		},
+		{
+			what:  "typo in property of commit objects of push event payload",
+			input: "github.event.commits.*.mesage",
+			expected: []string{
+				"property \"mesage\" is not defined in object type {added: array<string>;",
+			},
+		},
 		{
 			what:  "array element is not object for filtering array dereference",
 			input: "test().*.bar",
@@ -1026,6 +1066,77 @@ func TestExprSemanticsCheckError(t *testing.T) {
 				"1st argument of function call is not assignable. \"string\" cannot be assigned to \"array<any>\"",
 			},
 		},
+		{
+			what:  "object is passed to contains() to search string",
+			input: "contains(github.event_name, needs)",
+			expected: []string{
+				"2nd argument of function call is not assignable. \"{}\" cannot be assigned to \"string\"",
+				"1st argument of function call is not assignable. \"string\" cannot be assigned to \"array<any>\"",
+			},
+		},
+		{
+			what:  "object is searched in array by contains()",
+			input: "contains(fromJSON('[{\"a\": 1}]'), fromJSON('{\"a\": 1}'))",
+			expected: []string{
+				"2nd argument of contains() is \"{a: number}\" value. contains() always returns false since objects and arrays are only equal when they are the same instance",
+			},
+		},
+		{
+			what:  "array is searched in array by contains()",
+			input: "contains(github.event.commits, fromJSON('[1, 2]'))",
+			expected: []string{
+				"2nd argument of contains() is \"array<number>\" value",
+			},
+		},
+		{
+			what:  "string is searched in array of objects by contains()",
+			input: "contains(fromJSON('[{\"a\": 1}]'), 'foo')",
+			expected: []string{
+				"element type \"{a: number}\" of 1st argument of contains() cannot be compared to \"string\" value of 2nd argument. contains() always returns false",
+			},
+		},
+		{
+			what:  "number separator of join()",
+			input: "join(github.event.commits.*.message, 1)",
+			expected: []string{
+				"separator of join() must be string but got \"number\" value",
+			},
+		},
+		{
+			what:  "bool value is compared with string 'true'",
+			input: "fromJSON('true') == 'true'",
+			expected: []string{
+				`comparing bool value with string "true" by "==" operator is always false. both operands are coerced to numbers on loose equality and the string is coerced to NaN. compare the value with bool literal like "== true"`,
+			},
+		},
+		{
+			what:  "bool value is compared with non-numeric string with != operator",
+			input: "'yes' != fromJSON('false')",
+			expected: []string{
+				`comparing bool value with string "yes" by "!=" operator is always true. both operands are coerced to numbers on loose equality and the string is coerced to NaN. compare the value with bool literal true or false`,
+			},
+		},
+		{
+			what:  "string value is compared with bool literal",
+			input: "github.event_name == true",
+			expected: []string{
+				`comparing string value with bool literal true by "==" operator is always false unless the string is a number. both operands are coerced to numbers on loose equality and a non-numeric string is coerced to NaN. compare the value with string literal like "== 'true'"`,
+			},
+		},
+		{
+			what:  "number value is compared with non-numeric string",
+			input: "fromJSON('1') == 'one'",
+			expected: []string{
+				`comparing number value with string "one" by "==" operator is always false. the string is coerced to NaN on loose equality`,
+			},
+		},
+		{
+			what:  "number value is compared with numeric string",
+			input: "' 0x10 ' != fromJSON('16')",
+			expected: []string{
+				`number value is compared with numeric string " 0x10 " by "!=" operator. the string is coerced to number 16 on loose equality. compare the value with number literal like "!= 16" to make the intention clear`,
+			},
+		},
 		{
 			what:  "wrong type at rest parameter",
 			input: "hashFiles(null)",
@@ -1090,6 +1201,48 @@ func TestExprSemanticsCheckError(t *testing.T) {
 				"does not contain placeholder {0}",
 			},
 		},
+		{
+			what:  "braces not for placeholders in format string of format() call",
+			input: "format('{0} {} {x} {', 1)",
+			expected: []string{
+				`format string "{0} {} {x} {" is invalid. "{" at offset 4 is not followed by an index of placeholder like "{0}"`,
+			},
+		},
+		{
+			what:  "unescaped closing brace in format string of format() call",
+			input: "format('{0}} {1}', 1, 2)",
+			expected: []string{
+				`format string "{0}} {1}" is invalid. "}" at offset 3 is not escaped`,
+			},
+		},
+		{
+			what:  "placeholder is not closed in format string of format() call",
+			input: "format('{0} {1', 1, 2)",
+			expected: []string{
+				`format string "{0} {1" is invalid. placeholder "{1" at offset 4 is not closed with "}"`,
+			},
+		},
+		{
+			what:  "object argument of format() call",
+			input: "format('event: {0}', github.event)",
+			expected: []string{
+				`2nd argument of format() is object type "object". it is formatted as 'Object' at runtime`,
+			},
+		},
+		{
+			what:  "array argument of format() call",
+			input: "format('{0} {1}', 'messages', github.event.commits.*.message)",
+			expected: []string{
+				`3rd argument of format() is array type "array<string>". it is formatted as 'Array' at runtime`,
+			},
+		},
+		{
+			what:  "object argument of nested format() call",
+			input: "format('{0}', format('{0}', fromJSON('{\"foo\": 1}')))",
+			expected: []string{
+				`2nd argument of format() is object type "{foo: number}"`,
+			},
+		},
 		{
 			what:  "format specifier is still escaped",
 			input: "format('hello {{{{0}}', 'world')", // First {{ is escaped. {{0}} is still escaped
@@ -1103,7 +1256,7 @@ func TestExprSemanticsCheckError(t *testing.T) {
 			expected: []string{
 				"property \"bar\" is not defined in object type {foo: any}",
 			},
-			matrix: NewStrictObjectType(map[string]ExprType{
+			matrix: NewStrictObjectType(map[string]Type{
 				"foo": AnyType{},
 			}),
 		},
@@ -1113,7 +1266,7 @@ func TestExprSemanticsCheckError(t *testing.T) {
 			expected: []string{
 				"2nd argument of function call is not assignable. \"null\" cannot be assigned to \"string\"",
 			},
-			matrix: NewStrictObjectType(map[string]ExprType{
+			matrix: NewStrictObjectType(map[string]Type{
 				"foo": NullType{},
 			}),
 		},
@@ -1130,8 +1283,8 @@ func TestExprSemanticsCheckError(t *testing.T) {
 			expected: []string{
 				"property \"foo\" is not defined in object type {bar: ", // order of prop types in object type changes randomly so we cannot check it easily
 			},
-			steps: NewStrictObjectType(map[string]ExprType{
-				"bar": NewStrictObjectType(map[string]ExprType{
+			steps: NewStrictObjectType(map[string]Type{
+				"bar": NewStrictObjectType(map[string]Type{
 					"outputs":    NewEmptyObjectType(),
 					"conclusion": StringType{},
 					"outcome":    StringType{},
@@ -1144,8 +1297,8 @@ func TestExprSemanticsCheckError(t *testing.T) {
 			expected: []string{
 				"property \"foo\" is not defined in object type {", // order of prop types in object type changes randomly so we cannot check it easily
 			},
-			steps: NewStrictObjectType(map[string]ExprType{
-				"bar": NewStrictObjectType(map[string]ExprType{
+			steps: NewStrictObjectType(map[string]Type{
+				"bar": NewStrictObjectType(map[string]Type{
 					"outputs":    NewEmptyObjectType(),
 					"conclusion": StringType{},
 					"outcome":    StringType{},
@@ -1165,9 +1318,9 @@ func TestExprSemanticsCheckError(t *testing.T) {
 			expected: []string{
 				"property \"bar\" is not defined in object type ",
 			},
-			needs: NewStrictObjectType(map[string]ExprType{
-				"foo": NewStrictObjectType(map[string]ExprType{
-					"outputs": NewStrictObjectType(map[string]ExprType{
+			needs: NewStrictObjectType(map[string]Type{
+				"foo": NewStrictObjectType(map[string]Type{
+					"outputs": NewStrictObjectType(map[string]Type{
 						"out1": StringType{},
 						"out2": StringType{},
 					}),
@@ -1181,9 +1334,9 @@ func TestExprSemanticsCheckError(t *testing.T) {
 			expected: []string{
 				"property \"out3\" is not defined in object type ",
 			},
-			needs: NewStrictObjectType(map[string]ExprType{
-				"foo": NewStrictObjectType(map[string]ExprType{
-					"outputs": NewStrictObjectType(map[string]ExprType{
+			needs: NewStrictObjectType(map[string]Type{
+				"foo": NewStrictObjectType(map[string]Type{
+					"outputs": NewStrictObjectType(map[string]Type{
 						"out1": StringType{},
 						"out2": StringType{},
 					}),
@@ -1197,9 +1350,9 @@ func TestExprSemanticsCheckError(t *testing.T) {
 			expected: []string{
 				"property \"bar\" is not defined in object type ",
 			},
-			needs: NewStrictObjectType(map[string]ExprType{
-				"foo": NewStrictObjectType(map[string]ExprType{
-					"outputs": NewStrictObjectType(map[string]ExprType{
+			needs: NewStrictObjectType(map[string]Type{
+				"foo": NewStrictObjectType(map[string]Type{
+					"outputs": NewStrictObjectType(map[string]Type{
 						"out1": StringType{},
 						"out2": StringType{},
 					}),
@@ -1346,13 +1499,13 @@ func TestExprSemanticsCheckError(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.what, func(t *testing.T) {
-			p := NewExprParser()
-			e, err := p.Parse(NewExprLexer(tc.input + "}}"))
+			p := NewParser()
+			e, err := p.Parse(NewLexer(tc.input + "}}"))
 			if err != nil {
 				t.Fatal("Parse error:", tc.input)
 			}
 
-			c := NewExprSemanticsChecker(false, tc.configVars)
+			c := NewSemanticsChecker(false, tc.configVars)
 			if tc.funcs != nil {
 				c.funcs = tc.funcs // Set functions for testing
 			}
@@ -1546,14 +1699,14 @@ func TestExprCompareOperandsCheck(t *testing.T) {
 			for rhs, ok := range rest {
 				input := lhs + " " + op + " " + rhs
 				t.Run(input, func(t *testing.T) {
-					p := NewExprParser()
-					e, err := p.Parse(NewExprLexer(input + "}}"))
+					p := NewParser()
+					e, err := p.Parse(NewLexer(input + "}}"))
 					if err != nil {
 						t.Fatal("Parse error:", input)
 					}
 
-					c := NewExprSemanticsChecker(false, nil)
-					c.vars = map[string]ExprType{
+					c := NewSemanticsChecker(false, nil)
+					c.vars = map[string]Type{
 						"number": NumberType{},
 						"string": StringType{},
 						"bool":   BoolType{},
@@ -1640,7 +1793,7 @@ func TestExprBuiltinFunctionSignatures(t *testing.T) {
 }
 
 func TestExprSemanticsCheckerUpdateMatrix(t *testing.T) {
-	c := NewExprSemanticsChecker(false, nil)
+	c := NewSemanticsChecker(false, nil)
 	ty := NewEmptyObjectType()
 	prev := c.vars["matrix"]
 	c.UpdateMatrix(ty)
@@ -1655,7 +1808,7 @@ func TestExprSemanticsCheckerUpdateMatrix(t *testing.T) {
 }
 
 func TestExprSemanticsCheckerUpdateSteps(t *testing.T) {
-	c := NewExprSemanticsChecker(false, nil)
+	c := NewSemanticsChecker(false, nil)
 	ty := NewEmptyObjectType()
 	prev := c.vars["steps"]
 	c.UpdateSteps(ty)
@@ -1670,8 +1823,8 @@ func TestExprSemanticsCheckerUpdateSteps(t *testing.T) {
 }
 
 func TestExprSematincsCheckerUpdateDispatchInputsVarType(t *testing.T) {
-	ty := NewStrictObjectType(map[string]ExprType{"foo": NullType{}})
-	c := NewExprSemanticsChecker(false, nil)
+	ty := NewStrictObjectType(map[string]Type{"foo": NullType{}})
+	c := NewSemanticsChecker(false, nil)
 	c.UpdateDispatchInputs(ty)
 	o := c.vars["github"].(*ObjectType).Props["event"].(*ObjectType).Props["inputs"].(*ObjectType)
 	if _, ok := o.Props["foo"]; !ok {
@@ -1700,36 +1853,36 @@ func TestExprSemanticsCheckerUpdateInputsMultipleTimes(t *testing.T) {
 		want   *ObjectType
 	}{
 		{
-			first: NewStrictObjectType(map[string]ExprType{
+			first: NewStrictObjectType(map[string]Type{
 				"foo": StringType{},
 			}),
-			second: NewStrictObjectType(map[string]ExprType{
+			second: NewStrictObjectType(map[string]Type{
 				"bar": NumberType{},
 			}),
-			want: NewStrictObjectType(map[string]ExprType{
+			want: NewStrictObjectType(map[string]Type{
 				"foo": StringType{},
 				"bar": NumberType{},
 			}),
 		},
 		{
-			first: NewStrictObjectType(map[string]ExprType{
+			first: NewStrictObjectType(map[string]Type{
 				"foo": StringType{},
 			}),
-			second: NewStrictObjectType(map[string]ExprType{
+			second: NewStrictObjectType(map[string]Type{
 				"foo": StringType{},
 			}),
-			want: NewStrictObjectType(map[string]ExprType{
+			want: NewStrictObjectType(map[string]Type{
 				"foo": StringType{},
 			}),
 		},
 		{
-			first: NewStrictObjectType(map[string]ExprType{
+			first: NewStrictObjectType(map[string]Type{
 				"foo": BoolType{},
 			}),
-			second: NewStrictObjectType(map[string]ExprType{
+			second: NewStrictObjectType(map[string]Type{
 				"foo": NumberType{},
 			}),
-			want: NewStrictObjectType(map[string]ExprType{
+			want: NewStrictObjectType(map[string]Type{
 				"foo": AnyType{},
 			}),
 		},
@@ -1738,7 +1891,7 @@ func TestExprSemanticsCheckerUpdateInputsMultipleTimes(t *testing.T) {
 	for _, tc := range tests {
 		name := fmt.Sprintf("%v then %v", tc.first, tc.second)
 		t.Run(name, func(t *testing.T) {
-			c := NewExprSemanticsChecker(false, nil)
+			c := NewSemanticsChecker(false, nil)
 			c.UpdateInputs(tc.first)
 			c.UpdateDispatchInputs(tc.second)
 			have := c.vars["inputs"]
@@ -1749,7 +1902,7 @@ func TestExprSemanticsCheckerUpdateInputsMultipleTimes(t *testing.T) {
 	}
 }
 
-func testObjectPropertiesAreInLowerCase(t *testing.T, ty ExprType) {
+func testObjectPropertiesAreInLowerCase(t *testing.T, ty Type) {
 	t.Helper()
 	switch ty := ty.(type) {
 	case *ObjectType:
@@ -1964,6 +2117,63 @@ func TestParseFormatSpecifiers(t *testing.T) {
 	}
 }
 
+func TestCheckFormatFuncString(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string // Reason of invalid format string. Empty means the format string is valid
+	}{
+		{"", ""},
+		{"hello, world!", ""},
+		{"{0} {1}{2}", ""},
+		{"{{0}} {{{1}}}", ""},
+		{"{", `"{" at offset 0 is not followed by an index of placeholder like "{0}"`},
+		{"{}", `"{" at offset 0 is not followed by an index of placeholder like "{0}"`},
+		{"{{{x}", `"{" at offset 2 is not followed by an index of placeholder like "{0}"`},
+		{"}", `"}" at offset 0 is not escaped`},
+		{"{0}}", `"}" at offset 3 is not escaped`},
+		{"{{0}", `"}" at offset 3 is not escaped`},
+		{"{0", `placeholder "{0" at offset 0 is not closed with "}"`},
+		{"{12 }", `placeholder "{12" at offset 0 is not closed with "}"`},
+		{"{0:yyyyMMdd}", `placeholder at offset 0 has format specifiers after ":" which are not supported`},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.in, func(t *testing.T) {
+			if have := checkFormatFuncString(tc.in); have != tc.want {
+				t.Fatalf("wanted %q but got %q", tc.want, have)
+			}
+		})
+	}
+}
+
+func TestCoerceStringToNumber(t *testing.T) {
+	tests := []struct {
+		in   string
+		want float64
+	}{
+		{"", 0},
+		{"  ", 0},
+		{"42", 42},
+		{" -1.5 ", -1.5},
+		{"1e3", 1000},
+		{"0x1F", 31},
+		{"0o17", 15},
+		{"Infinity", math.Inf(1)},
+		{"-Infinity", math.Inf(-1)},
+	}
+	for _, tc := range tests {
+		if have := CoerceStringToNumber(tc.in); have != tc.want {
+			t.Errorf("%q was coerced to %v but wanted %v", tc.in, have, tc.want)
+		}
+	}
+
+	for _, in := range []string{"true", "one", "inf", "NaN", "1_000", "0xZ", "1.2.3"} {
+		if have := CoerceStringToNumber(in); !math.IsNaN(have) {
+			t.Errorf("%q should be coerced to NaN but got %v", in, have)
+		}
+	}
+}
+
 func TestExprIsConstant(t *testing.T) {
 	tests := []struct {
 		input   string
@@ -2006,13 +2216,13 @@ func TestExprIsConstant(t *testing.T) {
 
 	for _, tc := range tests {
 		t.Run(tc.input, func(t *testing.T) {
-			p := NewExprParser()
-			e, err := p.Parse(NewExprLexer(tc.input + "}}"))
+			p := NewParser()
+			e, err := p.Parse(NewLexer(tc.input + "}}"))
 			if err != nil {
 				t.Fatalf("Parse error for input %q: %s", tc.input, err)
 			}
 
-			c := NewExprSemanticsChecker(false, nil)
+			c := NewSemanticsChecker(false, nil)
 			b := c.IsConstant(e)
 			if b != tc.isConst {
 				t.Fatalf("wanted %v but got %v for input %q", tc.isConst, b, tc.input)
@@ -0,0 +1,110 @@
+package expr
+
+import (
+	"strings"
+)
+
+// SemanticTokenType is a type of token in expression syntax for syntax highlighting.
+type SemanticTokenType uint8
+
+const (
+	// SemanticTokenTypeVariable is a type of context names like `github` in `github.event`.
+	SemanticTokenTypeVariable SemanticTokenType = iota
+	// SemanticTokenTypeProperty is a type of property names like `event` in `github.event`.
+	SemanticTokenTypeProperty
+	// SemanticTokenTypeFunction is a type of function names like `contains` in `contains(a, b)`.
+	SemanticTokenTypeFunction
+	// SemanticTokenTypeKeyword is a type of `true`, `false`, and `null` literals.
+	SemanticTokenTypeKeyword
+	// SemanticTokenTypeString is a type of string literals.
+	SemanticTokenTypeString
+	// SemanticTokenTypeNumber is a type of integer and float literals.
+	SemanticTokenTypeNumber
+	// SemanticTokenTypeOperator is a type of operators like `==`, `&&`, and `*` of object filters.
+	SemanticTokenTypeOperator
+)
+
+// SemanticTokenTypes is the names of all semantic token types in order of their values. The names
+// are the standard token types of Language Server Protocol so this slice can be used for the
+// legend of semantic tokens as-is.
+// https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#semanticTokenTypes
+var SemanticTokenTypes = []string{
+	"variable",
+	"property",
+	"function",
+	"keyword",
+	"string",
+	"number",
+	"operator",
+}
+
+func (t SemanticTokenType) String() string {
+	if int(t) < len(SemanticTokenTypes) {
+		return SemanticTokenTypes[t]
+	}
+	panic("unreachable")
+}
+
+// SemanticToken is a token in expression syntax classified for syntax highlighting.
+type SemanticToken struct {
+	// Type is a type of the token.
+	Type SemanticTokenType
+	// Token is the classified token. Its position is relative to the lexed source.
+	Token *Token
+}
+
+// ClassifyTokens classifies the tokens lexed from expression syntax for syntax highlighting. Since
+// tokens are classified by their neighbors, this function works even if the tokens cannot be
+// parsed. Tokens which are not highlighted such as parentheses and commas are omitted from the
+// returned slice.
+func ClassifyTokens(tokens []*Token) []*SemanticToken {
+	ret := make([]*SemanticToken, 0, len(tokens))
+	for i, t := range tokens {
+		var ty SemanticTokenType
+		switch t.Kind {
+		case TokenKindIdent:
+			switch {
+			case i > 0 && tokens[i-1].Kind == TokenKindDot:
+				ty = SemanticTokenTypeProperty
+			case i+1 < len(tokens) && tokens[i+1].Kind == TokenKindLeftParen:
+				ty = SemanticTokenTypeFunction
+			default:
+				switch strings.ToLower(t.Value) {
+				case "true", "false", "null":
+					ty = SemanticTokenTypeKeyword
+				default:
+					ty = SemanticTokenTypeVariable
+				}
+			}
+		case TokenKindString:
+			ty = SemanticTokenTypeString
+		case TokenKindInt, TokenKindFloat:
+			ty = SemanticTokenTypeNumber
+		case TokenKindNot, TokenKindLess, TokenKindLessEq, TokenKindGreater, TokenKindGreaterEq,
+			TokenKindEq, TokenKindNotEq, TokenKindAnd, TokenKindOr, TokenKindStar:
+			ty = SemanticTokenTypeOperator
+		default:
+			continue
+		}
+		ret = append(ret, &SemanticToken{ty, t})
+	}
+	return ret
+}
+
+// LexSemanticTokens lexes the given string as expression syntax and classifies the tokens for
+// syntax highlighting. The parameter must contain '}}' as well as LexExpression. Unlike
+// LexExpression, the tokens lexed before an error are classified and returned with the error so
+// that incomplete expressions being edited can be highlighted. The second return value is the
+// offset where lexing stopped.
+func LexSemanticTokens(src string) ([]*SemanticToken, int, *Error) {
+	l := NewLexer(src)
+	defer l.Release()
+	ts := []*Token{}
+	for {
+		t := l.Next()
+		if l.lexErr != nil || t.Kind == TokenKindEnd {
+			return ClassifyTokens(ts), l.Offset(), l.lexErr
+		}
+		ts = append(ts, t)
+	}
+}
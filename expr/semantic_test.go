@@ -0,0 +1,83 @@
+package expr
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestLexSemanticTokens(t *testing.T) {
+	testCases := []struct {
+		what  string
+		input string
+		want  []string
+	}{
+		{
+			what:  "property access",
+			input: "github.event.pull_request }}",
+			want:  []string{"variable github", "property event", "property pull_request"},
+		},
+		{
+			what:  "function call",
+			input: "contains(github.ref, 'main') }}",
+			want:  []string{"function contains", "variable github", "property ref", "string 'main'"},
+		},
+		{
+			what:  "literals",
+			input: "true && null || 1.5 != -3 }}",
+			want:  []string{"keyword true", "operator &&", "keyword null", "operator ||", "number 1.5", "operator !=", "number -3"},
+		},
+		{
+			what:  "object filter and index",
+			input: "!github.event.commits.*.message[0] }}",
+			want: []string{
+				"operator !",
+				"variable github",
+				"property event",
+				"property commits",
+				"operator *",
+				"property message",
+				"number 0",
+			},
+		},
+		{
+			what:  "property named like function",
+			input: "matrix.contains }}",
+			want:  []string{"variable matrix", "property contains"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.what, func(t *testing.T) {
+			ts, offset, err := LexSemanticTokens(tc.input)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if offset != len(tc.input) {
+				t.Errorf("wanted offset %d but got %d", len(tc.input), offset)
+			}
+			have := []string{}
+			for _, tok := range ts {
+				have = append(have, tok.Type.String()+" "+tok.Token.Value)
+			}
+			if diff := cmp.Diff(tc.want, have); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+	}
+}
+
+func TestLexSemanticTokensError(t *testing.T) {
+	ts, _, err := LexSemanticTokens("github.ref == 'oops")
+	if err == nil {
+		t.Fatal("error did not occur")
+	}
+	have := []string{}
+	for _, tok := range ts {
+		have = append(have, tok.Type.String()+" "+tok.Token.Value)
+	}
+	want := []string{"variable github", "property ref", "operator =="}
+	if diff := cmp.Diff(want, have); diff != "" {
+		t.Fatal(diff)
+	}
+}
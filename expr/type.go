@@ -1,4 +1,4 @@
-package actionlint
+package expr
 
 import (
 	"fmt"
@@ -8,17 +8,17 @@ import (
 
 // Types
 
-// ExprType is interface for types of values in expression.
-type ExprType interface {
+// Type is interface for types of values in expression.
+type Type interface {
 	// String returns string representation of the type.
 	String() string
 	// Assignable returns if other type can be assignable to the type.
-	Assignable(other ExprType) bool
+	Assignable(other Type) bool
 	// Merge merges other type into this type. When other type conflicts with this type, the merged
 	// result is any type as fallback.
-	Merge(other ExprType) ExprType
+	Merge(other Type) Type
 	// DeepCopy duplicates itself. All its child types are copied recursively.
-	DeepCopy() ExprType
+	DeepCopy() Type
 }
 
 // AnyType represents type which can be any type. It also indicates that a value of the type cannot
@@ -30,18 +30,18 @@ func (ty AnyType) String() string {
 }
 
 // Assignable returns if other type can be assignable to the type.
-func (ty AnyType) Assignable(_ ExprType) bool {
+func (ty AnyType) Assignable(_ Type) bool {
 	return true
 }
 
 // Merge merges other type into this type. When other type conflicts with this type, the merged
 // result is any type as fallback.
-func (ty AnyType) Merge(other ExprType) ExprType {
+func (ty AnyType) Merge(other Type) Type {
 	return ty
 }
 
 // DeepCopy duplicates itself. All its child types are copied recursively.
-func (ty AnyType) DeepCopy() ExprType {
+func (ty AnyType) DeepCopy() Type {
 	return ty
 }
 
@@ -53,7 +53,7 @@ func (ty NullType) String() string {
 }
 
 // Assignable returns if other type can be assignable to the type.
-func (ty NullType) Assignable(other ExprType) bool {
+func (ty NullType) Assignable(other Type) bool {
 	switch other.(type) {
 	case NullType, AnyType:
 		return true
@@ -64,7 +64,7 @@ func (ty NullType) Assignable(other ExprType) bool {
 
 // Merge merges other type into this type. When other type conflicts with this type, the merged
 // result is any type as fallback.
-func (ty NullType) Merge(other ExprType) ExprType {
+func (ty NullType) Merge(other Type) Type {
 	if _, ok := other.(NullType); ok {
 		return ty
 	}
@@ -72,7 +72,7 @@ func (ty NullType) Merge(other ExprType) ExprType {
 }
 
 // DeepCopy duplicates itself. All its child types are copied recursively.
-func (ty NullType) DeepCopy() ExprType {
+func (ty NullType) DeepCopy() Type {
 	return ty
 }
 
@@ -84,7 +84,7 @@ func (ty NumberType) String() string {
 }
 
 // Assignable returns if other type can be assignable to the type.
-func (ty NumberType) Assignable(other ExprType) bool {
+func (ty NumberType) Assignable(other Type) bool {
 	// TODO: Is string of numbers corced into number?
 	switch other.(type) {
 	case NumberType, AnyType:
@@ -96,7 +96,7 @@ func (ty NumberType) Assignable(other ExprType) bool {
 
 // Merge merges other type into this type. When other type conflicts with this type, the merged
 // result is any type as fallback.
-func (ty NumberType) Merge(other ExprType) ExprType {
+func (ty NumberType) Merge(other Type) Type {
 	switch other.(type) {
 	case NumberType:
 		return ty
@@ -108,7 +108,7 @@ func (ty NumberType) Merge(other ExprType) ExprType {
 }
 
 // DeepCopy duplicates itself. All its child types are copied recursively.
-func (ty NumberType) DeepCopy() ExprType {
+func (ty NumberType) DeepCopy() Type {
 	return ty
 }
 
@@ -120,7 +120,7 @@ func (ty BoolType) String() string {
 }
 
 // Assignable returns if other type can be assignable to the type.
-func (ty BoolType) Assignable(other ExprType) bool {
+func (ty BoolType) Assignable(other Type) bool {
 	// Any type can be converted into bool..
 	// e.g.
 	//    if: ${{ steps.foo }}
@@ -129,7 +129,7 @@ func (ty BoolType) Assignable(other ExprType) bool {
 
 // Merge merges other type into this type. When other type conflicts with this type, the merged
 // result is any type as fallback.
-func (ty BoolType) Merge(other ExprType) ExprType {
+func (ty BoolType) Merge(other Type) Type {
 	switch other.(type) {
 	case BoolType:
 		return ty
@@ -141,7 +141,7 @@ func (ty BoolType) Merge(other ExprType) ExprType {
 }
 
 // DeepCopy duplicates itself. All its child types are copied recursively.
-func (ty BoolType) DeepCopy() ExprType {
+func (ty BoolType) DeepCopy() Type {
 	return ty
 }
 
@@ -153,7 +153,7 @@ func (ty StringType) String() string {
 }
 
 // Assignable returns if other type can be assignable to the type.
-func (ty StringType) Assignable(other ExprType) bool {
+func (ty StringType) Assignable(other Type) bool {
 	// Bool and null types also can be coerced into string. But in almost all case, those coercing
 	// would be mistakes.
 	switch other.(type) {
@@ -166,7 +166,7 @@ func (ty StringType) Assignable(other ExprType) bool {
 
 // Merge merges other type into this type. When other type conflicts with this type, the merged
 // result is any type as fallback.
-func (ty StringType) Merge(other ExprType) ExprType {
+func (ty StringType) Merge(other Type) Type {
 	switch other.(type) {
 	case StringType, NumberType, BoolType:
 		return ty
@@ -176,47 +176,47 @@ func (ty StringType) Merge(other ExprType) ExprType {
 }
 
 // DeepCopy duplicates itself. All its child types are copied recursively.
-func (ty StringType) DeepCopy() ExprType {
+func (ty StringType) DeepCopy() Type {
 	return ty
 }
 
 // ObjectType is type for objects, which can hold key-values.
 type ObjectType struct {
 	// Props is map from properties name to their type.
-	Props map[string]ExprType
+	Props map[string]Type
 	// Mapped is an element type of this object. This means all props have the type. For example,
 	// The element type of env context is string.
 	// AnyType means its property types can be any type so it shapes a loose object. Setting nil
 	// means properties are mapped to no type so it shapes a strict object.
 	//
 	// Invariant: All types in Props field must be assignable to this type.
-	Mapped ExprType
+	Mapped Type
 }
 
 // NewEmptyObjectType creates new loose ObjectType instance which allows unknown props. When
 // accessing to unknown props, their values will fall back to any.
 func NewEmptyObjectType() *ObjectType {
-	return &ObjectType{map[string]ExprType{}, AnyType{}}
+	return &ObjectType{map[string]Type{}, AnyType{}}
 }
 
 // NewObjectType creates new loose ObjectType instance which allows unknown props with given props.
-func NewObjectType(props map[string]ExprType) *ObjectType {
+func NewObjectType(props map[string]Type) *ObjectType {
 	return &ObjectType{props, AnyType{}}
 }
 
 // NewEmptyStrictObjectType creates new ObjectType instance which does not allow unknown props.
 func NewEmptyStrictObjectType() *ObjectType {
-	return &ObjectType{map[string]ExprType{}, nil}
+	return &ObjectType{map[string]Type{}, nil}
 }
 
 // NewStrictObjectType creates new ObjectType instance which does not allow unknown props with
 // given prop types.
-func NewStrictObjectType(props map[string]ExprType) *ObjectType {
+func NewStrictObjectType(props map[string]Type) *ObjectType {
 	return &ObjectType{props, nil}
 }
 
 // NewMapObjectType creates new ObjectType which maps keys to a specific type value.
-func NewMapObjectType(t ExprType) *ObjectType {
+func NewMapObjectType(t Type) *ObjectType {
 	return &ObjectType{nil, t}
 }
 
@@ -275,7 +275,7 @@ func (ty *ObjectType) String() string {
 
 // Assignable returns if other type can be assignable to the type.
 // In other words, rhs type is more strict than lhs (receiver) type.
-func (ty *ObjectType) Assignable(other ExprType) bool {
+func (ty *ObjectType) Assignable(other Type) bool {
 	switch other := other.(type) {
 	case AnyType:
 		return true
@@ -318,7 +318,7 @@ func (ty *ObjectType) Assignable(other ExprType) bool {
 // Merge merges two object types into one. When other object has unknown props, they are merged into
 // current object. When both have same property, when they are assignable, it remains as-is.
 // Otherwise, the property falls back to any type.
-func (ty *ObjectType) Merge(other ExprType) ExprType {
+func (ty *ObjectType) Merge(other Type) Type {
 	switch other := other.(type) {
 	case *ObjectType:
 		// Shortcuts
@@ -336,7 +336,7 @@ func (ty *ObjectType) Merge(other ExprType) ExprType {
 			mapped = mapped.Merge(other.Mapped)
 		}
 
-		props := make(map[string]ExprType, len(ty.Props))
+		props := make(map[string]Type, len(ty.Props))
 		for n, l := range ty.Props {
 			props[n] = l
 		}
@@ -361,8 +361,8 @@ func (ty *ObjectType) Merge(other ExprType) ExprType {
 }
 
 // DeepCopy duplicates itself. All its child types are copied recursively.
-func (ty *ObjectType) DeepCopy() ExprType {
-	p := make(map[string]ExprType, len(ty.Props))
+func (ty *ObjectType) DeepCopy() Type {
+	p := make(map[string]Type, len(ty.Props))
 	for n, t := range ty.Props {
 		p[n] = t.DeepCopy()
 	}
@@ -376,7 +376,7 @@ func (ty *ObjectType) DeepCopy() ExprType {
 // ArrayType is type for arrays.
 type ArrayType struct {
 	// Elem is type of element of the array.
-	Elem ExprType
+	Elem Type
 	// Deref is true when this type was derived from object filtering syntax (foo.*).
 	Deref bool
 }
@@ -386,7 +386,7 @@ func (ty *ArrayType) String() string {
 }
 
 // Assignable returns if other type can be assignable to the type.
-func (ty *ArrayType) Assignable(other ExprType) bool {
+func (ty *ArrayType) Assignable(other Type) bool {
 	switch other := other.(type) {
 	case AnyType:
 		return true
@@ -400,7 +400,7 @@ func (ty *ArrayType) Assignable(other ExprType) bool {
 // Merge merges two object types into one. When other object has unknown props, they are merged into
 // current object. When both have same property, when they are assignable, it remains as-is.
 // Otherwise, the property falls back to any type.
-func (ty *ArrayType) Merge(other ExprType) ExprType {
+func (ty *ArrayType) Merge(other Type) Type {
 	switch other := other.(type) {
 	case *ArrayType:
 		if _, ok := ty.Elem.(AnyType); ok {
@@ -419,12 +419,12 @@ func (ty *ArrayType) Merge(other ExprType) ExprType {
 }
 
 // DeepCopy duplicates itself. All its child types are copied recursively.
-func (ty *ArrayType) DeepCopy() ExprType {
+func (ty *ArrayType) DeepCopy() Type {
 	return &ArrayType{ty.Elem.DeepCopy(), ty.Deref}
 }
 
 // EqualTypes returns if the two types are equal.
-func EqualTypes(l, r ExprType) bool {
+func EqualTypes(l, r Type) bool {
 	return l.Assignable(r) && r.Assignable(l)
 }
 
@@ -438,7 +438,7 @@ func EqualTypes(l, r ExprType) bool {
 //   - []interface{}, for JSON arrays
 //   - map[string]interface{}, for JSON objects
 //   - nil for JSON null
-func typeOfJSONValue(v any) ExprType {
+func typeOfJSONValue(v any) Type {
 	switch v := v.(type) {
 	case bool:
 		return BoolType{}
@@ -447,7 +447,7 @@ func typeOfJSONValue(v any) ExprType {
 	case string:
 		return StringType{}
 	case []any:
-		var elem ExprType
+		var elem Type
 		for _, e := range v {
 			t := typeOfJSONValue(e)
 			if elem == nil {
@@ -461,7 +461,7 @@ func typeOfJSONValue(v any) ExprType {
 		}
 		return &ArrayType{Elem: elem}
 	case map[string]any:
-		props := make(map[string]ExprType, len(v))
+		props := make(map[string]Type, len(v))
 		for k, v := range v {
 			props[k] = typeOfJSONValue(v)
 		}
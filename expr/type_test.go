@@ -1,4 +1,4 @@
-package actionlint
+package expr
 
 import (
 	"testing"
@@ -36,14 +36,14 @@ func TestExprObjectTypeSetStrict(t *testing.T) {
 }
 
 func TestExprAssignableSimple(t *testing.T) {
-	testCases := []ExprType{
+	testCases := []Type{
 		AnyType{},
 		NullType{},
 		NumberType{},
 		BoolType{},
 		StringType{},
-		NewObjectType(map[string]ExprType{"n": NumberType{}}),
-		NewStrictObjectType(map[string]ExprType{"b": BoolType{}}),
+		NewObjectType(map[string]Type{"n": NumberType{}}),
+		NewStrictObjectType(map[string]Type{"b": BoolType{}}),
 		NewMapObjectType(NullType{}),
 		&ArrayType{Elem: StringType{}},
 	}
@@ -73,7 +73,7 @@ func TestExprAssignableSimple(t *testing.T) {
 
 func TestExprAssignableObject(t *testing.T) {
 	testCases := []struct {
-		from, to ExprType
+		from, to Type
 		no       bool
 	}{
 		{
@@ -89,44 +89,44 @@ func TestExprAssignableObject(t *testing.T) {
 			to:   NewEmptyObjectType(),
 		},
 		{
-			from: NewStrictObjectType(map[string]ExprType{
+			from: NewStrictObjectType(map[string]Type{
 				"a": NumberType{},
 				"b": StringType{},
 			}),
 			to: NewMapObjectType(StringType{}),
 		},
 		{
-			from: NewStrictObjectType(map[string]ExprType{"a": NullType{}}),
+			from: NewStrictObjectType(map[string]Type{"a": NullType{}}),
 			to:   NewMapObjectType(StringType{}),
 			no:   true,
 		},
 		{
 			from: NewMapObjectType(NumberType{}),
-			to: NewStrictObjectType(map[string]ExprType{
+			to: NewStrictObjectType(map[string]Type{
 				"a": AnyType{},
 				"b": StringType{},
 			}),
 		},
 		{
 			from: NewMapObjectType(NumberType{}),
-			to: NewStrictObjectType(map[string]ExprType{
+			to: NewStrictObjectType(map[string]Type{
 				"a": NullType{},
 				"b": StringType{},
 			}),
 			no: true,
 		},
 		{
-			from: NewStrictObjectType(map[string]ExprType{"a": NumberType{}}),
-			to:   NewStrictObjectType(map[string]ExprType{"a": StringType{}}),
+			from: NewStrictObjectType(map[string]Type{"a": NumberType{}}),
+			to:   NewStrictObjectType(map[string]Type{"a": StringType{}}),
 		},
 		{
-			from: NewStrictObjectType(map[string]ExprType{"a": StringType{}}),
-			to:   NewStrictObjectType(map[string]ExprType{"b": StringType{}}),
+			from: NewStrictObjectType(map[string]Type{"a": StringType{}}),
+			to:   NewStrictObjectType(map[string]Type{"b": StringType{}}),
 			no:   true,
 		},
 		{
-			from: NewStrictObjectType(map[string]ExprType{"a": NullType{}}),
-			to:   NewStrictObjectType(map[string]ExprType{"a": StringType{}}),
+			from: NewStrictObjectType(map[string]Type{"a": NullType{}}),
+			to:   NewStrictObjectType(map[string]Type{"a": StringType{}}),
 			no:   true,
 		},
 	}
@@ -148,9 +148,9 @@ func TestExprAssignableObject(t *testing.T) {
 func TestExprEqualTypes(t *testing.T) {
 	testCases := []struct {
 		what string
-		ty   ExprType
-		neq  ExprType
-		eq   ExprType
+		ty   Type
+		neq  Type
+		eq   Type
 	}{
 		{
 			what: "null",
@@ -184,8 +184,8 @@ func TestExprEqualTypes(t *testing.T) {
 		},
 		{
 			what: "nested object",
-			ty: NewObjectType(map[string]ExprType{
-				"foo": NewObjectType(map[string]ExprType{
+			ty: NewObjectType(map[string]Type{
+				"foo": NewObjectType(map[string]Type{
 					"bar": StringType{},
 				}),
 			}),
@@ -193,8 +193,8 @@ func TestExprEqualTypes(t *testing.T) {
 		},
 		{
 			what: "nested strict props object",
-			ty: NewStrictObjectType(map[string]ExprType{
-				"foo": NewStrictObjectType(map[string]ExprType{
+			ty: NewStrictObjectType(map[string]Type{
+				"foo": NewStrictObjectType(map[string]Type{
 					"bar": StringType{},
 				}),
 			}),
@@ -202,37 +202,37 @@ func TestExprEqualTypes(t *testing.T) {
 		},
 		{
 			what: "nested object prop name",
-			ty: NewStrictObjectType(map[string]ExprType{
+			ty: NewStrictObjectType(map[string]Type{
 				"foo": StringType{},
 			}),
-			neq: NewStrictObjectType(map[string]ExprType{
+			neq: NewStrictObjectType(map[string]Type{
 				"bar": StringType{},
 			}),
 		},
 		{
 			what: "nested object prop type",
-			ty: NewStrictObjectType(map[string]ExprType{
+			ty: NewStrictObjectType(map[string]Type{
 				"foo": StringType{},
 			}),
-			neq: NewStrictObjectType(map[string]ExprType{
+			neq: NewStrictObjectType(map[string]Type{
 				"foo": BoolType{},
 			}),
 		},
 		{
 			what: "strict props object and loose object",
-			ty: NewStrictObjectType(map[string]ExprType{
+			ty: NewStrictObjectType(map[string]Type{
 				"foo": NullType{},
 			}),
-			eq: NewObjectType(map[string]ExprType{
+			eq: NewObjectType(map[string]Type{
 				"foo": NullType{},
 			}),
 		},
 		{
 			what: "loose object and strict props object",
-			ty: NewObjectType(map[string]ExprType{
+			ty: NewObjectType(map[string]Type{
 				"foo": NullType{},
 			}),
-			eq: NewObjectType(map[string]ExprType{
+			eq: NewObjectType(map[string]Type{
 				"foo": NullType{},
 			}),
 		},
@@ -255,28 +255,28 @@ func TestExprEqualTypes(t *testing.T) {
 		{
 			what: "map object equals strict object",
 			ty:   NewMapObjectType(StringType{}),
-			eq: NewStrictObjectType(map[string]ExprType{
+			eq: NewStrictObjectType(map[string]Type{
 				"foo": StringType{},
 			}),
-			neq: NewStrictObjectType(map[string]ExprType{
+			neq: NewStrictObjectType(map[string]Type{
 				"foo": NullType{},
 			}),
 		},
 		{
 			what: "map object equals strict object including any prop",
 			ty:   NewMapObjectType(StringType{}),
-			eq: NewStrictObjectType(map[string]ExprType{
+			eq: NewStrictObjectType(map[string]Type{
 				"foo": StringType{},
 				"bar": AnyType{},
 			}),
-			neq: NewStrictObjectType(map[string]ExprType{
+			neq: NewStrictObjectType(map[string]Type{
 				"foo": NullType{},
 				"bar": AnyType{},
 			}),
 		},
 		{
 			what: "strict object equals map object",
-			ty: NewStrictObjectType(map[string]ExprType{
+			ty: NewStrictObjectType(map[string]Type{
 				"foo": StringType{},
 			}),
 			eq:  NewMapObjectType(StringType{}),
@@ -305,7 +305,7 @@ func TestExprEqualTypes(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.what, func(t *testing.T) {
-			var l, r ExprType
+			var l, r Type
 
 			l, r = tc.ty, tc.ty
 			if !EqualTypes(l, r) {
@@ -338,7 +338,7 @@ func TestExprEqualTypes(t *testing.T) {
 func TestExprTypeStringize(t *testing.T) {
 	testCases := []struct {
 		what string
-		ty   ExprType
+		ty   Type
 		want string
 	}{
 		{
@@ -378,28 +378,28 @@ func TestExprTypeStringize(t *testing.T) {
 		},
 		{
 			what: "strict object",
-			ty: NewStrictObjectType(map[string]ExprType{
+			ty: NewStrictObjectType(map[string]Type{
 				"foo": StringType{},
 			}),
 			want: "{foo: string}",
 		},
 		{
 			what: "loose object",
-			ty: NewObjectType(map[string]ExprType{
+			ty: NewObjectType(map[string]Type{
 				"foo": StringType{},
 			}),
 			want: "object",
 		},
 		{
 			what: "strict object",
-			ty: NewStrictObjectType(map[string]ExprType{
+			ty: NewStrictObjectType(map[string]Type{
 				"foo": StringType{},
 			}),
 			want: "{foo: string}",
 		},
 		{
 			what: "multiple props object",
-			ty: NewStrictObjectType(map[string]ExprType{
+			ty: NewStrictObjectType(map[string]Type{
 				"foo": StringType{},
 				"bar": NumberType{},
 			}),
@@ -407,9 +407,9 @@ func TestExprTypeStringize(t *testing.T) {
 		},
 		{
 			what: "nested objects",
-			ty: NewStrictObjectType(map[string]ExprType{
+			ty: NewStrictObjectType(map[string]Type{
 				"foo": StringType{},
-				"nested": NewStrictObjectType(map[string]ExprType{
+				"nested": NewStrictObjectType(map[string]Type{
 					"foo": StringType{},
 					"bar": NumberType{},
 				}),
@@ -428,9 +428,9 @@ func TestExprTypeStringize(t *testing.T) {
 		},
 		{
 			what: "array nested in object",
-			ty: NewStrictObjectType(map[string]ExprType{
+			ty: NewStrictObjectType(map[string]Type{
 				"foo": &ArrayType{
-					Elem: NewStrictObjectType(map[string]ExprType{
+					Elem: NewStrictObjectType(map[string]Type{
 						"bar": &ArrayType{
 							Elem: StringType{},
 						},
@@ -457,7 +457,7 @@ func TestExprTypeStringize(t *testing.T) {
 }
 
 func TestExprTypeMergeSimple(t *testing.T) {
-	testCases := []ExprType{
+	testCases := []Type{
 		AnyType{},
 		NullType{},
 		NumberType{},
@@ -487,7 +487,7 @@ func TestExprTypeMergeSimple(t *testing.T) {
 
 	for _, ty := range testCases {
 		t.Run("incompatible/"+ty.String(), func(t *testing.T) {
-			var in ExprType
+			var in Type
 			in = NullType{}
 			if ty == (NullType{}) {
 				in = StringType{} // null is compatible with null so use string instead
@@ -514,9 +514,9 @@ func TestExprTypeMergeSimple(t *testing.T) {
 func TestExprTypeMergeComplicated(t *testing.T) {
 	testCases := []struct {
 		what string
-		ty   ExprType
-		with ExprType
-		want ExprType
+		ty   Type
+		with Type
+		want Type
 	}{
 		{
 			what: "number merges with string",
@@ -544,88 +544,88 @@ func TestExprTypeMergeComplicated(t *testing.T) {
 		},
 		{
 			what: "object props",
-			ty: NewObjectType(map[string]ExprType{
+			ty: NewObjectType(map[string]Type{
 				"foo": NumberType{},
 			}),
-			with: NewObjectType(map[string]ExprType{
+			with: NewObjectType(map[string]Type{
 				"bar": StringType{},
 			}),
-			want: NewObjectType(map[string]ExprType{
+			want: NewObjectType(map[string]Type{
 				"foo": NumberType{},
 				"bar": StringType{},
 			}),
 		},
 		{
 			what: "loose object with strict object",
-			ty: NewObjectType(map[string]ExprType{
+			ty: NewObjectType(map[string]Type{
 				"foo": NumberType{},
 			}),
-			with: NewStrictObjectType(map[string]ExprType{
+			with: NewStrictObjectType(map[string]Type{
 				"bar": StringType{},
 			}),
-			want: NewObjectType(map[string]ExprType{
+			want: NewObjectType(map[string]Type{
 				"foo": NumberType{},
 				"bar": StringType{},
 			}),
 		},
 		{
 			what: "strict object with strict object",
-			ty: NewStrictObjectType(map[string]ExprType{
+			ty: NewStrictObjectType(map[string]Type{
 				"foo": NumberType{},
 			}),
-			with: NewStrictObjectType(map[string]ExprType{
+			with: NewStrictObjectType(map[string]Type{
 				"bar": StringType{},
 			}),
-			want: NewStrictObjectType(map[string]ExprType{
+			want: NewStrictObjectType(map[string]Type{
 				"foo": NumberType{},
 				"bar": StringType{},
 			}),
 		},
 		{
 			what: "compatible prop",
-			ty: NewObjectType(map[string]ExprType{
+			ty: NewObjectType(map[string]Type{
 				"foo": NumberType{},
 			}),
-			with: NewObjectType(map[string]ExprType{
+			with: NewObjectType(map[string]Type{
 				"foo": StringType{},
 			}),
-			want: NewObjectType(map[string]ExprType{
+			want: NewObjectType(map[string]Type{
 				"foo": StringType{},
 			}),
 		},
 		{
 			what: "any prop with prop",
-			ty: NewObjectType(map[string]ExprType{
+			ty: NewObjectType(map[string]Type{
 				"foo": AnyType{},
 			}),
-			with: NewObjectType(map[string]ExprType{
+			with: NewObjectType(map[string]Type{
 				"foo": StringType{},
 			}),
-			want: NewObjectType(map[string]ExprType{
+			want: NewObjectType(map[string]Type{
 				"foo": AnyType{},
 			}),
 		},
 		{
 			what: "prop with any prop",
-			ty: NewObjectType(map[string]ExprType{
+			ty: NewObjectType(map[string]Type{
 				"foo": StringType{},
 			}),
-			with: NewObjectType(map[string]ExprType{
+			with: NewObjectType(map[string]Type{
 				"foo": AnyType{},
 			}),
-			want: NewObjectType(map[string]ExprType{
+			want: NewObjectType(map[string]Type{
 				"foo": AnyType{},
 			}),
 		},
 		{
 			what: "incompatible prop",
-			ty: NewObjectType(map[string]ExprType{
+			ty: NewObjectType(map[string]Type{
 				"foo": NullType{},
 			}),
-			with: NewObjectType(map[string]ExprType{
+			with: NewObjectType(map[string]Type{
 				"foo": StringType{},
 			}),
-			want: NewObjectType(map[string]ExprType{
+			want: NewObjectType(map[string]Type{
 				"foo": AnyType{},
 			}),
 		},
@@ -698,20 +698,20 @@ func TestExprTypeMergeComplicated(t *testing.T) {
 		{
 			what: "object no prop at left hand side",
 			ty:   NewEmptyObjectType(),
-			with: NewObjectType(map[string]ExprType{
+			with: NewObjectType(map[string]Type{
 				"foo": StringType{},
 			}),
-			want: NewObjectType(map[string]ExprType{
+			want: NewObjectType(map[string]Type{
 				"foo": StringType{},
 			}),
 		},
 		{
 			what: "object no prop at right hand side",
-			ty: NewObjectType(map[string]ExprType{
+			ty: NewObjectType(map[string]Type{
 				"foo": StringType{},
 			}),
 			with: NewEmptyObjectType(),
-			want: NewObjectType(map[string]ExprType{
+			want: NewObjectType(map[string]Type{
 				"foo": StringType{},
 			}),
 		},
@@ -747,24 +747,24 @@ func TestExprTypeMergeComplicated(t *testing.T) {
 		},
 		{
 			what: "nested objects",
-			ty: NewObjectType(map[string]ExprType{
-				"foo": NewObjectType(map[string]ExprType{
+			ty: NewObjectType(map[string]Type{
+				"foo": NewObjectType(map[string]Type{
 					"foo":  NumberType{},
 					"piyo": NumberType{},
 				}),
 				"aaa": NumberType{},
 				"ccc": NumberType{},
 			}),
-			with: NewObjectType(map[string]ExprType{
-				"foo": NewObjectType(map[string]ExprType{
+			with: NewObjectType(map[string]Type{
+				"foo": NewObjectType(map[string]Type{
 					"bar":  StringType{},
 					"piyo": StringType{},
 				}),
 				"bbb": StringType{},
 				"ccc": StringType{},
 			}),
-			want: NewObjectType(map[string]ExprType{
-				"foo": NewObjectType(map[string]ExprType{
+			want: NewObjectType(map[string]Type{
+				"foo": NewObjectType(map[string]Type{
 					"foo":  NumberType{},
 					"bar":  StringType{},
 					"piyo": StringType{},
@@ -789,20 +789,20 @@ func TestExprTypeMergeComplicated(t *testing.T) {
 		{
 			what: "map object with compatible object",
 			ty:   NewMapObjectType(NumberType{}),
-			with: NewObjectType(map[string]ExprType{
+			with: NewObjectType(map[string]Type{
 				"foo": NumberType{},
 			}),
-			want: NewObjectType(map[string]ExprType{
+			want: NewObjectType(map[string]Type{
 				"foo": NumberType{},
 			}),
 		},
 		{
 			what: "map object with incompatible object",
 			ty:   NewMapObjectType(NumberType{}),
-			with: NewObjectType(map[string]ExprType{
+			with: NewObjectType(map[string]Type{
 				"foo": BoolType{},
 			}),
-			want: NewObjectType(map[string]ExprType{
+			want: NewObjectType(map[string]Type{
 				"foo": BoolType{},
 			}),
 		},
@@ -843,11 +843,11 @@ func TestExprTypeMergeCreateNewInstance(t *testing.T) {
 	}
 
 	{
-		ty := NewObjectType(map[string]ExprType{
+		ty := NewObjectType(map[string]Type{
 			"foo": NumberType{},
 		})
 		ty2 := ty.Merge(
-			NewObjectType(map[string]ExprType{
+			NewObjectType(map[string]Type{
 				"foo": StringType{},
 				"bar": BoolType{},
 			}),
@@ -865,7 +865,7 @@ func TestExprTypeMergeCreateNewInstance(t *testing.T) {
 }
 
 func TestExprTypeDeepCopy(t *testing.T) {
-	for _, ty := range []ExprType{
+	for _, ty := range []Type{
 		AnyType{},
 		NullType{},
 		BoolType{},
@@ -879,10 +879,10 @@ func TestExprTypeDeepCopy(t *testing.T) {
 	}
 
 	{
-		nested := NewObjectType(map[string]ExprType{
+		nested := NewObjectType(map[string]Type{
 			"piyo": StringType{},
 		})
-		o := NewObjectType(map[string]ExprType{
+		o := NewObjectType(map[string]Type{
 			"foo": NumberType{},
 			"bar": nested,
 		})
@@ -934,7 +934,7 @@ func TestExprTypeTypeOfJSONValue(t *testing.T) {
 	tests := []struct {
 		what  string
 		value any
-		want  ExprType
+		want  Type
 	}{
 		{
 			what:  "null",
@@ -1002,7 +1002,7 @@ func TestExprTypeTypeOfJSONValue(t *testing.T) {
 		{
 			what:  "object",
 			value: map[string]any{"hello": 1.0, "world": true},
-			want: NewStrictObjectType(map[string]ExprType{
+			want: NewStrictObjectType(map[string]Type{
 				"hello": NumberType{},
 				"world": BoolType{},
 			}),
@@ -1016,9 +1016,9 @@ func TestExprTypeTypeOfJSONValue(t *testing.T) {
 					"bar": "x",
 				},
 			},
-			want: NewStrictObjectType(map[string]ExprType{
+			want: NewStrictObjectType(map[string]Type{
 				"hello": &ArrayType{Elem: NumberType{}},
-				"world": NewStrictObjectType(map[string]ExprType{
+				"world": NewStrictObjectType(map[string]Type{
 					"foo": BoolType{},
 					"bar": StringType{},
 				}),
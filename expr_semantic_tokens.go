@@ -0,0 +1,129 @@
+package actionlint
+
+import (
+	"bytes"
+	"sort"
+	"unicode/utf16"
+	"unicode/utf8"
+
+	"github.com/rhysd/actionlint/expr"
+)
+
+// ExprSemanticToken is a token in ${{ }} placeholders of workflow source classified for syntax
+// highlighting. Unlike Token, the position is absolute in the source.
+type ExprSemanticToken struct {
+	// Type is a type of the token like variable, property, or function.
+	Type expr.SemanticTokenType
+	// Value is the source text of the token.
+	Value string
+	// Offset is a byte offset of the token in the source.
+	Offset int
+	// Line is a line number of the token. This value is 1-based.
+	Line int
+	// Column is a column number of the token counted in characters. This value is 1-based.
+	Column int
+}
+
+// exprSourceLines is an index of the lines in source to convert byte offsets into positions.
+type exprSourceLines struct {
+	src    []byte
+	starts []int
+}
+
+func newExprSourceLines(src []byte) *exprSourceLines {
+	starts := []int{0}
+	for i, b := range src {
+		if b == '\n' {
+			starts = append(starts, i+1)
+		}
+	}
+	return &exprSourceLines{src, starts}
+}
+
+// lineAt returns the 0-based line index and the start offset of the line containing the offset.
+func (l *exprSourceLines) lineAt(offset int) (int, int) {
+	i := sort.Search(len(l.starts), func(i int) bool { return l.starts[i] > offset }) - 1
+	return i, l.starts[i]
+}
+
+// isComment returns whether the offset is in a comment line. Comments at the end of lines are not
+// detected since '#' may be a part of the value.
+func (l *exprSourceLines) isComment(offset int) bool {
+	_, start := l.lineAt(offset)
+	return bytes.HasPrefix(bytes.TrimLeft(l.src[start:offset], " \t"), []byte{'#'})
+}
+
+// FindExprSemanticTokens finds all ${{ }} placeholders in the workflow source and returns the
+// classified tokens in them in order of their positions. Placeholders in comment lines are
+// ignored. This function does not parse the source as YAML so that it can be used for the source
+// being edited in editors. When an expression is broken, the tokens before the broken part are
+// returned.
+func FindExprSemanticTokens(src []byte) []*ExprSemanticToken {
+	lines := newExprSourceLines(src)
+	ret := []*ExprSemanticToken{}
+	for offset := 0; ; {
+		i := bytes.Index(src[offset:], []byte("${{"))
+		if i < 0 {
+			return ret
+		}
+		start := offset + i + 3
+		offset = start
+		if lines.isComment(start) {
+			continue
+		}
+
+		ts, end, err := expr.LexSemanticTokens(string(src[start:]))
+		for _, t := range ts {
+			o := start + t.Token.Offset
+			l, s := lines.lineAt(o)
+			ret = append(ret, &ExprSemanticToken{
+				Type:   t.Type,
+				Value:  t.Token.Value,
+				Offset: o,
+				Line:   l + 1,
+				Column: utf8.RuneCount(src[s:o]) + 1,
+			})
+		}
+		if err == nil {
+			// When lexing failed, the rest of source may be consumed by the broken expression. For
+			// example, a string literal without closing quote. Continue searching from the start of
+			// the broken expression to highlight the following placeholders.
+			offset += end
+		}
+	}
+}
+
+func utf16Len(b []byte) int {
+	n := 0
+	for len(b) > 0 {
+		r, s := utf8.DecodeRune(b)
+		n += utf16.RuneLen(r)
+		b = b[s:]
+	}
+	return n
+}
+
+// EncodeLSPSemanticTokens encodes the tokens returned from FindExprSemanticTokens into the integer
+// array of "data" field in the response of "textDocument/semanticTokens/full" request of Language
+// Server Protocol. Token types are the indices of expr.SemanticTokenTypes and no token modifier is
+// set. Columns are counted in UTF-16 code units, which is the default position encoding of LSP.
+// Tokens spanning multiple lines are omitted since most clients do not support them.
+// https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#textDocument_semanticTokens
+func EncodeLSPSemanticTokens(src []byte, tokens []*ExprSemanticToken) []uint32 {
+	lines := newExprSourceLines(src)
+	data := make([]uint32, 0, len(tokens)*5)
+	prevLine, prevChar := 0, 0
+	for _, t := range tokens {
+		if bytes.ContainsRune([]byte(t.Value), '\n') {
+			continue
+		}
+		l, s := lines.lineAt(t.Offset)
+		c := utf16Len(src[s:t.Offset])
+		if l != prevLine {
+			prevChar = 0
+		}
+		data = append(data, uint32(l-prevLine), uint32(c-prevChar), uint32(utf16Len([]byte(t.Value))), uint32(t.Type), 0)
+		prevLine, prevChar = l, c
+	}
+	return data
+}
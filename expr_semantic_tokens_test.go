@@ -0,0 +1,68 @@
+package actionlint
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestFindExprSemanticTokens(t *testing.T) {
+	src := `on: push
+# ${{ commented }}
+jobs:
+  test:
+    if: ${{ github.event_name == 'push' }}
+    runs-on: ubuntu-latest
+    steps:
+      - run: echo 'あ${{ matrix.os }}' ${{ format('{0}', env.FOO) }}
+`
+	have := []string{}
+	for _, t := range FindExprSemanticTokens([]byte(src)) {
+		have = append(have, t.Type.String()+" "+t.Value+" "+(&Pos{Line: t.Line, Col: t.Column}).String())
+		if src[t.Offset:t.Offset+len(t.Value)] != t.Value {
+			panic("offset mismatch: " + t.Value)
+		}
+	}
+	want := []string{
+		"variable github line:5,col:13",
+		"property event_name line:5,col:20",
+		"operator == line:5,col:31",
+		"string 'push' line:5,col:34",
+		"variable matrix line:8,col:25",
+		"property os line:8,col:32",
+		"function format line:8,col:43",
+		"string '{0}' line:8,col:50",
+		"variable env line:8,col:57",
+		"property FOO line:8,col:61",
+	}
+	if diff := cmp.Diff(want, have); diff != "" {
+		t.Fatal(diff)
+	}
+}
+
+func TestFindExprSemanticTokensBrokenExpression(t *testing.T) {
+	src := "x: ${{ github.ref == 'oops }}\ny: ${{ env.FOO }}\n"
+	have := []string{}
+	for _, t := range FindExprSemanticTokens([]byte(src)) {
+		have = append(have, t.Value)
+	}
+	want := []string{"github", "ref", "==", "env", "FOO"}
+	if diff := cmp.Diff(want, have); diff != "" {
+		t.Fatal(diff)
+	}
+}
+
+func TestEncodeLSPSemanticTokens(t *testing.T) {
+	src := "a: ${{ github.ref }}\nb: '🐶${{ true }}' ${{ 'あ' }}\n"
+	ts := FindExprSemanticTokens([]byte(src))
+	have := EncodeLSPSemanticTokens([]byte(src), ts)
+	want := []uint32{
+		0, 7, 6, 0, 0, // github
+		0, 7, 3, 1, 0, // ref
+		1, 10, 4, 3, 0, // true (the emoji is 2 UTF-16 code units)
+		0, 13, 3, 4, 0, // 'あ'
+	}
+	if diff := cmp.Diff(want, have); diff != "" {
+		t.Fatal(diff)
+	}
+}
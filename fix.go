@@ -0,0 +1,119 @@
+package actionlint
+
+import (
+	"bytes"
+	"fmt"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+// TextEdit is an edit of source text to fix an issue in a workflow file. The text in the range from
+// Start to End is replaced with NewText. End is exclusive. When Start and End are the same position,
+// NewText is inserted at the position. Columns of the positions are counted in characters as well
+// as Pos.
+type TextEdit struct {
+	// Start is the start position of the range to be replaced.
+	Start *Pos
+	// End is the end position of the range to be replaced. The character at this position is not
+	// replaced.
+	End *Pos
+	// NewText is the text to replace the range with.
+	NewText string
+}
+
+func (e *TextEdit) String() string {
+	return fmt.Sprintf("%s-%s %q", e.Start, e.End, e.NewText)
+}
+
+// newReplaceStringEdit creates an edit to replace the string in the source with the new value. Quotes
+// of the string are kept. It returns nil when the string cannot be replaced safely because the text
+// in the source may be different from its value due to escapes.
+func newReplaceStringEdit(s *String, v string) *TextEdit {
+	if s.Pos == nil || strings.ContainsAny(s.Value+v, "\\'\"\n") {
+		return nil
+	}
+	col := s.Pos.Col
+	if s.Quoted {
+		col++
+	}
+	return &TextEdit{
+		Start:   &Pos{Line: s.Pos.Line, Col: col},
+		End:     &Pos{Line: s.Pos.Line, Col: col + utf8.RuneCountInString(s.Value)},
+		NewText: v,
+	}
+}
+
+// ApplyTextEdits applies the edits to the source and returns the edited source. Order of the edits
+// does not matter. When some edits overlap, the edit which starts earlier is applied and the others
+// are ignored. When some position of the edits is out of the source, this function returns an error.
+func ApplyTextEdits(src []byte, edits []*TextEdit) ([]byte, error) {
+	if len(edits) == 0 {
+		return src, nil
+	}
+
+	lines := []int{0} // Offsets of starts of lines
+	for i, b := range src {
+		if b == '\n' {
+			lines = append(lines, i+1)
+		}
+	}
+	offset := func(p *Pos) (int, error) {
+		if p.Line < 1 || len(lines) < p.Line || p.Col < 1 {
+			return 0, fmt.Errorf("position %s of edit is out of source", p)
+		}
+		o, end := lines[p.Line-1], len(src)
+		if p.Line < len(lines) {
+			end = lines[p.Line] - 1 // Exclude '\n'
+		}
+		// Column next to the last character of the line is allowed to append text to the line
+		for c := 1; c < p.Col; c++ {
+			if o >= end {
+				return 0, fmt.Errorf("position %s of edit is out of source", p)
+			}
+			_, s := utf8.DecodeRune(src[o:end])
+			o += s
+		}
+		return o, nil
+	}
+
+	type span struct {
+		start, end int
+		text       string
+	}
+	spans := make([]span, 0, len(edits))
+	for _, e := range edits {
+		s, err := offset(e.Start)
+		if err != nil {
+			return nil, err
+		}
+		t, err := offset(e.End)
+		if err != nil {
+			return nil, err
+		}
+		if t < s {
+			return nil, fmt.Errorf("end position %s of edit is before its start position %s", e.End, e.Start)
+		}
+		spans = append(spans, span{s, t, e.NewText})
+	}
+	slices.SortStableFunc(spans, func(a, b span) int {
+		if a.start != b.start {
+			return a.start - b.start
+		}
+		return a.end - b.end
+	})
+
+	var b bytes.Buffer
+	b.Grow(len(src))
+	prev := 0
+	for _, s := range spans {
+		if s.start < prev {
+			continue // Overlapping with the previous edit
+		}
+		b.Write(src[prev:s.start])
+		b.WriteString(s.text)
+		prev = s.end
+	}
+	b.Write(src[prev:])
+	return b.Bytes(), nil
+}
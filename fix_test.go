@@ -0,0 +1,106 @@
+package actionlint
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFixApplyTextEdits(t *testing.T) {
+	edit := func(l1, c1, l2, c2 int, s string) *TextEdit {
+		return &TextEdit{&Pos{Line: l1, Col: c1}, &Pos{Line: l2, Col: c2}, s}
+	}
+	tests := []struct {
+		what  string
+		src   string
+		edits []*TextEdit
+		want  string
+	}{
+		{
+			what: "no edit",
+			src:  "foo\nbar\n",
+			want: "foo\nbar\n",
+		},
+		{
+			what:  "replace",
+			src:   "foo\nbar\n",
+			edits: []*TextEdit{edit(2, 2, 2, 3, "AA")},
+			want:  "foo\nbAAr\n",
+		},
+		{
+			what:  "insert at end of line",
+			src:   "foo\nbar\n",
+			edits: []*TextEdit{edit(1, 4, 1, 4, " # comment")},
+			want:  "foo # comment\nbar\n",
+		},
+		{
+			what:  "insert at end of source without newline",
+			src:   "foo",
+			edits: []*TextEdit{edit(1, 4, 1, 4, "bar")},
+			want:  "foobar",
+		},
+		{
+			what:  "replace across lines",
+			src:   "foo\nbar\nbaz\n",
+			edits: []*TextEdit{edit(1, 3, 3, 2, "-")},
+			want:  "fo-az\n",
+		},
+		{
+			what:  "edits in any order",
+			src:   "foo\nbar\n",
+			edits: []*TextEdit{edit(2, 1, 2, 4, "piyo"), edit(1, 1, 1, 2, "F"), edit(1, 4, 1, 4, "!")},
+			want:  "Foo!\npiyo\n",
+		},
+		{
+			what:  "overlapping edits",
+			src:   "foobar\n",
+			edits: []*TextEdit{edit(1, 3, 1, 6, "X"), edit(1, 1, 1, 4, "Y")},
+			want:  "Ybar\n",
+		},
+		{
+			what:  "multi-byte characters",
+			src:   "あいう: えお\n",
+			edits: []*TextEdit{edit(1, 6, 1, 8, "かき")},
+			want:  "あいう: かき\n",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.what, func(t *testing.T) {
+			have, err := ApplyTextEdits([]byte(tc.src), tc.edits)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(have) != tc.want {
+				t.Fatalf("wanted %q but got %q", tc.want, have)
+			}
+		})
+	}
+}
+
+func TestFixApplyTextEditsError(t *testing.T) {
+	edit := func(l1, c1, l2, c2 int) *TextEdit {
+		return &TextEdit{&Pos{Line: l1, Col: c1}, &Pos{Line: l2, Col: c2}, "x"}
+	}
+	tests := []struct {
+		what string
+		edit *TextEdit
+		want string
+	}{
+		{"line out of source", edit(4, 1, 4, 1), "position line:4,col:1 of edit is out of source"},
+		{"column out of line", edit(1, 5, 1, 5), "position line:1,col:5 of edit is out of source"},
+		{"zero column", edit(1, 0, 1, 1), "position line:1,col:0 of edit is out of source"},
+		{"end before start", edit(2, 2, 2, 1), "end position line:2,col:1 of edit is before its start position line:2,col:2"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.what, func(t *testing.T) {
+			_, err := ApplyTextEdits([]byte("foo\nbar\n"), []*TextEdit{tc.edit})
+			if err == nil {
+				t.Fatal("error did not occur")
+			}
+			if msg := err.Error(); !strings.Contains(msg, tc.want) {
+				t.Fatalf("error message %q does not contain %q", msg, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,347 @@
+package actionlint
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"slices"
+	"strings"
+	"unicode"
+
+	"go.yaml.in/yaml/v4"
+)
+
+// formatStepKeysOrder is the canonical order of keys in steps. Unknown keys are put after these keys
+// keeping their original order.
+var formatStepKeysOrder = []string{
+	"name",
+	"id",
+	"if",
+	"uses",
+	"with",
+	"run",
+	"shell",
+	"working-directory",
+	"env",
+	"continue-on-error",
+	"timeout-minutes",
+}
+
+// Blank lines are not preserved in YAML nodes. They are temporarily replaced with this comment
+// while encoding the nodes.
+const formatBlankLineMarker = "#actionlint-fmt-blank-line"
+
+// workflowFormatter formats workflow source in the canonical style. It is built on the YAML nodes of
+// go-yaml which preserve comments.
+type workflowFormatter struct {
+	lines []string
+	// placeholders is a mapping from characters out of BMP to their placeholders.
+	placeholders map[rune]rune
+	// used is a set of characters used in the source.
+	used map[rune]struct{}
+}
+
+// FormatWorkflow formats the given workflow source into the canonical style. Comments and blank
+// lines between entries are preserved.
+//
+//   - Indentation is 2 spaces. Sequences are indented in mappings.
+//   - Keys in steps are ordered like "name", "id", "if", "uses", "with", "run", ...
+//   - Quotes of strings are removed when they are not necessary. Otherwise single quotes are
+//     preferred. Strings like "yes" or "on" which are booleans in YAML 1.1 are always quoted.
+//
+// When the source cannot be parsed as YAML, this function returns an error.
+func FormatWorkflow(src []byte) ([]byte, error) {
+	var root yaml.Node
+	d := yaml.NewDecoder(bytes.NewReader(src))
+	if err := d.Decode(&root); err != nil {
+		if errors.Is(err, io.EOF) {
+			return src, nil // Empty source
+		}
+		return nil, fmt.Errorf("could not parse workflow source as YAML: %w", err)
+	}
+	var next yaml.Node
+	if err := d.Decode(&next); !errors.Is(err, io.EOF) {
+		return nil, errors.New("could not format workflow source since it contains multiple YAML documents")
+	}
+
+	f := &workflowFormatter{
+		lines:        strings.Split(string(src), "\n"),
+		placeholders: map[rune]rune{},
+		used:         map[rune]struct{}{},
+	}
+	for _, r := range string(src) {
+		f.used[r] = struct{}{}
+	}
+	f.markBlankLines(&root)
+	f.sortStepKeys(&root)
+	if err := f.format(&root, true); err != nil {
+		return nil, err
+	}
+
+	var b bytes.Buffer
+	e := yaml.NewEncoder(&b)
+	e.SetIndent(2)
+	if err := e.Encode(&root); err != nil {
+		return nil, fmt.Errorf("could not encode formatted workflow: %w", err)
+	}
+	if err := e.Close(); err != nil {
+		return nil, fmt.Errorf("could not encode formatted workflow: %w", err)
+	}
+
+	lines := strings.Split(b.String(), "\n")
+	for i, l := range lines {
+		if strings.TrimSpace(l) == formatBlankLineMarker {
+			lines[i] = ""
+		}
+	}
+	out := []byte(f.restore(strings.Join(lines, "\n")))
+
+	// Check the formatted source has the same content as the original one for safety
+	var want, have any
+	if err := yaml.Unmarshal(src, &want); err != nil {
+		return nil, fmt.Errorf("could not parse workflow source as YAML: %w", err)
+	}
+	if err := yaml.Unmarshal(out, &have); err != nil || !reflect.DeepEqual(want, have) {
+		return nil, errors.New("formatted workflow does not have the same content as the original. this is a bug of formatter. please report it at https://github.com/rhysd/actionlint/issues")
+	}
+
+	return out, nil
+}
+
+// sortStepKeys sorts keys in steps at "jobs.<job_id>.steps" in the canonical order.
+func (f *workflowFormatter) sortStepKeys(root *yaml.Node) {
+	if len(root.Content) == 0 {
+		return
+	}
+	jobs := formatMappingValue(root.Content[0], "jobs")
+	if jobs == nil || jobs.Kind != yaml.MappingNode {
+		return
+	}
+	for i := 1; i < len(jobs.Content); i += 2 {
+		steps := formatMappingValue(jobs.Content[i], "steps")
+		if steps == nil || steps.Kind != yaml.SequenceNode {
+			continue
+		}
+		for _, s := range steps.Content {
+			// Reordering keys may move aliases before their anchors
+			if s.Kind == yaml.MappingNode && !formatHasAnchor(s) {
+				formatSortMappingKeys(s, formatStepKeysOrder)
+			}
+		}
+	}
+}
+
+func formatHasAnchor(n *yaml.Node) bool {
+	return n.Anchor != "" || slices.ContainsFunc(n.Content, formatHasAnchor)
+}
+
+func formatMappingValue(n *yaml.Node, key string) *yaml.Node {
+	if n.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		if n.Content[i].Value == key {
+			return n.Content[i+1]
+		}
+	}
+	return nil
+}
+
+func formatSortMappingKeys(n *yaml.Node, order []string) {
+	type entry struct{ k, v *yaml.Node }
+	if len(n.Content) < 2 {
+		return
+	}
+	es := make([]entry, 0, len(n.Content)/2)
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		es = append(es, entry{n.Content[i], n.Content[i+1]})
+	}
+	rank := func(e entry) int {
+		if i := slices.Index(order, e.k.Value); i >= 0 {
+			return i
+		}
+		return len(order)
+	}
+	first := es[0].k
+
+	// Foot comment of the mapping is attached to the last key. Keep it at the end of the mapping
+	last := es[len(es)-1].k
+	foot := last.FootComment
+	last.FootComment = ""
+	slices.SortStableFunc(es, func(a, b entry) int { return rank(a) - rank(b) })
+	es[len(es)-1].k.FootComment = foot
+	for i, e := range es {
+		n.Content[i*2], n.Content[i*2+1] = e.k, e.v
+	}
+
+	// Head comment of the first key is put after "- " in sequences. When the key was moved to the
+	// first, put the comment before the mapping instead
+	if k := n.Content[0]; k != first && k.HeadComment != "" {
+		if n.HeadComment != "" {
+			n.HeadComment += "\n"
+		}
+		n.HeadComment += k.HeadComment
+		k.HeadComment = ""
+	}
+}
+
+// blankLineBefore returns whether the node is preceded by a blank line in the source. Comments
+// attached to the node are skipped.
+func (f *workflowFormatter) blankLineBefore(n *yaml.Node) bool {
+	l := n.Line - 1 - strings.Count(n.HeadComment, "\n") // 1-based line number of head comment
+	if n.HeadComment != "" {
+		l--
+	}
+	return l >= 1 && l <= len(f.lines) && strings.TrimSpace(f.lines[l-1]) == ""
+}
+
+// markBlankLines marks the entries of mappings and sequences preceded by blank lines. This must be
+// done before reordering the entries.
+func (f *workflowFormatter) markBlankLines(n *yaml.Node) {
+	block := n.Style&yaml.FlowStyle == 0
+	switch n.Kind {
+	case yaml.SequenceNode:
+		for i, c := range n.Content {
+			if i > 0 && block {
+				f.markBlankLine(c)
+			}
+		}
+	case yaml.MappingNode:
+		for i := 2; i < len(n.Content) && block; i += 2 {
+			f.markBlankLine(n.Content[i])
+		}
+	}
+	for _, c := range n.Content {
+		f.markBlankLines(c)
+	}
+}
+
+func (f *workflowFormatter) markBlankLine(n *yaml.Node) {
+	if !f.blankLineBefore(n) {
+		return
+	}
+	if n.HeadComment == "" {
+		n.HeadComment = formatBlankLineMarker
+	} else {
+		n.HeadComment = formatBlankLineMarker + "\n" + n.HeadComment
+	}
+}
+
+func (f *workflowFormatter) format(n *yaml.Node, top bool) error {
+	switch n.Kind {
+	case yaml.DocumentNode:
+		for _, c := range n.Content {
+			if err := f.format(c, true); err != nil {
+				return err
+			}
+		}
+	case yaml.SequenceNode:
+		for _, c := range n.Content {
+			if err := f.format(c, false); err != nil {
+				return err
+			}
+		}
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			k, v := n.Content[i], n.Content[i+1]
+			if top && k.Value == "on" && k.Kind == yaml.ScalarNode && k.Tag == "!!str" {
+				k.Style = 0 // `on:` at top level is never quoted
+			} else if err := f.format(k, false); err != nil {
+				return err
+			}
+			if err := f.format(v, false); err != nil {
+				return err
+			}
+		}
+	case yaml.ScalarNode:
+		f.quote(n)
+		v, err := f.replaceNonBMP(n.Value)
+		if err != nil {
+			return err
+		}
+		n.Value = v
+	}
+	return nil
+}
+
+// replaceNonBMP replaces characters out of BMP such as emoji with placeholders. go-yaml escapes
+// them in double-quoted strings even if they are in plain or block scalars. The placeholders are
+// characters in the private use area which are not used in the source.
+func (f *workflowFormatter) replaceNonBMP(s string) (string, error) {
+	if !strings.ContainsFunc(s, func(r rune) bool { return r > 0xffff }) {
+		return s, nil
+	}
+	var b strings.Builder
+	for _, r := range s {
+		if r <= 0xffff {
+			b.WriteRune(r)
+			continue
+		}
+		p, ok := f.placeholders[r]
+		if !ok {
+			for p = 0xe000 + rune(len(f.placeholders)); p <= 0xf8ff; p++ {
+				if _, ok := f.used[p]; !ok {
+					break
+				}
+			}
+			if p > 0xf8ff {
+				return "", errors.New("could not format workflow source since it contains too many kinds of characters out of BMP")
+			}
+			f.placeholders[r] = p
+			f.used[p] = struct{}{}
+		}
+		b.WriteRune(p)
+	}
+	return b.String(), nil
+}
+
+// restore replaces the placeholders in the output with the original characters.
+func (f *workflowFormatter) restore(s string) string {
+	if len(f.placeholders) == 0 {
+		return s
+	}
+	rs := make(map[rune]rune, len(f.placeholders))
+	for r, p := range f.placeholders {
+		rs[p] = r
+	}
+	return strings.Map(func(r rune) rune {
+		if o, ok := rs[r]; ok {
+			return o
+		}
+		return r
+	}, s)
+}
+
+// quote normalizes quotes of the string scalar.
+func (f *workflowFormatter) quote(n *yaml.Node) {
+	if n.Tag != "!!str" || n.Style&^(yaml.SingleQuotedStyle|yaml.DoubleQuotedStyle) != 0 || strings.Contains(n.Value, "\n") {
+		return // Tagged, literal, folded, or multi-line scalars keep their styles
+	}
+	switch {
+	case formatPlainSafe(n.Value):
+		n.Style = 0
+	case strings.ContainsFunc(n.Value, func(r rune) bool { return !unicode.IsPrint(r) }):
+		n.Style = yaml.DoubleQuotedStyle // Escape sequences are necessary
+	default:
+		n.Style = yaml.SingleQuotedStyle
+	}
+}
+
+// formatPlainSafe returns whether the string can be written as plain scalar without changing its
+// meaning.
+func formatPlainSafe(s string) bool {
+	if s == "" {
+		return false
+	}
+	if _, ok := yamlTruthyValues[s]; ok {
+		return false // Booleans in YAML 1.1
+	}
+	var v any
+	if err := yaml.Unmarshal([]byte(s), &v); err != nil {
+		return false
+	}
+	p, ok := v.(string)
+	return ok && p == s
+}
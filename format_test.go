@@ -0,0 +1,89 @@
+package actionlint
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestFormatWorkflowFiles(t *testing.T) {
+	dir := filepath.Join("testdata", "fmt")
+	fs, err := filepath.Glob(filepath.Join(dir, "*.yaml"))
+	if err != nil {
+		panic(err)
+	}
+
+	for _, f := range fs {
+		t.Run(filepath.Base(f), func(t *testing.T) {
+			src, err := os.ReadFile(f)
+			if err != nil {
+				panic(err)
+			}
+			// When .out file does not exist, the workflow is already formatted
+			want := src
+			if b, err := os.ReadFile(strings.TrimSuffix(f, ".yaml") + ".out"); err == nil {
+				want = b
+			}
+
+			have, err := FormatWorkflow(src)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(have) != string(want) {
+				t.Fatalf("formatted workflow is unexpected.\nwant:\n%s\nhave:\n%s", want, have)
+			}
+
+			again, err := FormatWorkflow(have)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(again) != string(have) {
+				t.Fatalf("formatting is not idempotent.\nfirst:\n%s\nsecond:\n%s", have, again)
+			}
+		})
+	}
+}
+
+func TestFormatWorkflowEmpty(t *testing.T) {
+	for _, src := range []string{"", "# only comment\n"} {
+		have, err := FormatWorkflow([]byte(src))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(have) != src {
+			t.Errorf("wanted %q but got %q", src, have)
+		}
+	}
+}
+
+func TestFormatWorkflowError(t *testing.T) {
+	tests := []struct {
+		what string
+		src  string
+		want string
+	}{
+		{
+			what: "broken YAML",
+			src:  "on: push\njobs:\n  test: [\n",
+			want: "could not parse workflow source as YAML",
+		},
+		{
+			what: "multiple documents",
+			src:  "on: push\n---\non: push\n",
+			want: "contains multiple YAML documents",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.what, func(t *testing.T) {
+			_, err := FormatWorkflow([]byte(tc.src))
+			if err == nil {
+				t.Fatal("error did not occur")
+			}
+			if !strings.Contains(err.Error(), tc.want) {
+				t.Fatalf("error %q does not contain %q", err.Error(), tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,110 @@
+package actionlint
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"go.yaml.in/yaml/v4"
+)
+
+// GHESVersion is a version of GitHub Enterprise Server like "3.12". When it is specified, actionlint
+// reports features which are not available in the version.
+// https://docs.github.com/en/enterprise-server/admin/all-releases
+type GHESVersion struct {
+	// Major is the major version number.
+	Major int
+	// Minor is the minor version number.
+	Minor int
+}
+
+// ParseGHESVersion parses the version of GitHub Enterprise Server in "{major}.{minor}" format like
+// "3.12". Patch version like "3.12.1" is also accepted but it is ignored.
+func ParseGHESVersion(s string) (*GHESVersion, error) {
+	ss := strings.Split(strings.TrimPrefix(s, "v"), ".")
+	if len(ss) < 2 || len(ss) > 3 {
+		return nil, fmt.Errorf("version of GitHub Enterprise Server must be in \"{major}.{minor}\" format like \"3.12\" but got %q", s)
+	}
+	ns := make([]int, 0, len(ss))
+	for _, n := range ss {
+		i, err := strconv.ParseUint(n, 10, 16)
+		if err != nil {
+			return nil, fmt.Errorf("version of GitHub Enterprise Server must be in \"{major}.{minor}\" format like \"3.12\" but got %q: %w", s, err)
+		}
+		ns = append(ns, int(i))
+	}
+	if ns[0] == 0 {
+		return nil, fmt.Errorf("major version of GitHub Enterprise Server must not be zero: %q", s)
+	}
+	return &GHESVersion{Major: ns[0], Minor: ns[1]}, nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (v *GHESVersion) UnmarshalYAML(n *yaml.Node) error {
+	if n.Kind != yaml.ScalarNode {
+		return fmt.Errorf("yaml: \"ghes\" must be a string like \"3.12\" at line:%d,col:%d", n.Line, n.Column)
+	}
+	// Note: Use the raw value since unquoted "3.10" is a float number 3.1 in YAML
+	p, err := ParseGHESVersion(n.Value)
+	if err != nil {
+		return fmt.Errorf("invalid \"ghes\" at line:%d,col:%d: %w", n.Line, n.Column, err)
+	}
+	*v = *p
+	return nil
+}
+
+func (v *GHESVersion) String() string {
+	return fmt.Sprintf("%d.%d", v.Major, v.Minor)
+}
+
+// supports returns whether the version supports the feature available since 'since'. Zero value of
+// 'since' means the feature is not available in any version.
+func (v *GHESVersion) supports(since GHESVersion) bool {
+	if since.Major == 0 {
+		return false
+	}
+	return v.Major > since.Major || v.Major == since.Major && v.Minor >= since.Minor
+}
+
+// unsupported returns an error message for the feature described by 'what' which is not available
+// in the version.
+func (v *GHESVersion) unsupported(what string, since GHESVersion) string {
+	if since.Major == 0 {
+		return fmt.Sprintf("%s is not available on GitHub Enterprise Server %s. no version of GitHub Enterprise Server supports it yet", what, v)
+	}
+	return fmt.Sprintf("%s is not available on GitHub Enterprise Server %s. it is available since GitHub Enterprise Server %s", what, v, &since)
+}
+
+// The following tables are the first versions of GitHub Enterprise Server which support features.
+// Features not listed in the tables are considered to be available in all versions. Zero value means
+// the feature is not available in any version of GitHub Enterprise Server.
+// https://docs.github.com/en/enterprise-server@latest/admin/release-notes
+// Versions which support webhook events are listed in "GHES" field of AllWebhookEvents.
+
+// ghesWorkflowKeys is a table of keys in workflow syntax.
+var ghesWorkflowKeys = map[string]GHESVersion{
+	"jobs.<job_id>.uses":             {3, 4},
+	"jobs.<job_id>.secrets: inherit": {3, 6},
+	"run-name":                       {3, 8},
+	"jobs.<job_id>.snapshot":         {},
+}
+
+// ghesPermissionScopes is a table of scopes in "permissions:".
+var ghesPermissionScopes = map[string]GHESVersion{
+	"id-token":          {3, 5},
+	"artifact-metadata": {},
+	"models":            {},
+}
+
+// ghesContexts is a table of contexts and their properties in ${{ }} expressions. Keys are in
+// lower case.
+var ghesContexts = map[string]GHESVersion{
+	"inputs":           {3, 4},
+	"vars":             {3, 8},
+	"job.check_run_id": {},
+}
+
+// ghesFuncs is a table of functions in ${{ }} expressions. Keys are in lower case.
+var ghesFuncs = map[string]GHESVersion{
+	"case": {},
+}
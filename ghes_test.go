@@ -0,0 +1,75 @@
+package actionlint
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGHESParseVersionOK(t *testing.T) {
+	tests := []struct {
+		input string
+		major int
+		minor int
+	}{
+		{"3.12", 3, 12},
+		{"3.4", 3, 4},
+		{"v3.10", 3, 10},
+		{"3.14.2", 3, 14},
+	}
+	for _, tc := range tests {
+		t.Run(tc.input, func(t *testing.T) {
+			v, err := ParseGHESVersion(tc.input)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if v.Major != tc.major || v.Minor != tc.minor {
+				t.Fatalf("wanted %d.%d but got %s", tc.major, tc.minor, v)
+			}
+		})
+	}
+}
+
+func TestGHESParseVersionError(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"", `format like "3.12" but got ""`},
+		{"3", `format like "3.12" but got "3"`},
+		{"3.12.1.0", `format like "3.12" but got "3.12.1.0"`},
+		{"3.x", `format like "3.12" but got "3.x"`},
+		{"-3.1", `format like "3.12" but got "-3.1"`},
+		{"0.1", "major version of GitHub Enterprise Server must not be zero"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.input, func(t *testing.T) {
+			_, err := ParseGHESVersion(tc.input)
+			if err == nil {
+				t.Fatal("error did not occur")
+			}
+			if msg := err.Error(); !strings.Contains(msg, tc.want) {
+				t.Fatalf("error message %q does not contain %q", msg, tc.want)
+			}
+		})
+	}
+}
+
+func TestGHESVersionSupports(t *testing.T) {
+	v := &GHESVersion{3, 12}
+	tests := []struct {
+		since GHESVersion
+		want  bool
+	}{
+		{GHESVersion{3, 4}, true},
+		{GHESVersion{3, 12}, true},
+		{GHESVersion{3, 13}, false},
+		{GHESVersion{2, 22}, true},
+		{GHESVersion{4, 0}, false},
+		{GHESVersion{}, false},
+	}
+	for _, tc := range tests {
+		if have := v.supports(tc.since); have != tc.want {
+			t.Errorf("wanted %v for supporting %s by %s but got %v", tc.want, &tc.since, v, have)
+		}
+	}
+}
@@ -0,0 +1,924 @@
+package actionlint
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.yaml.in/yaml/v4"
+)
+
+const defaultGitHubAPIURL = "https://api.github.com"
+
+// ghesAPIURL returns the base URL of REST API of the GitHub Enterprise Server instance at the URL
+// like "https://github.example.com". The API is served at "/api/v3" path of the instance.
+// https://docs.github.com/en/enterprise-server@latest/rest/quickstart
+func ghesAPIURL(u string) (string, error) {
+	p, err := url.Parse(strings.TrimSpace(u))
+	if err != nil || (p.Scheme != "https" && p.Scheme != "http") || p.Host == "" {
+		return "", fmt.Errorf("URL of GitHub Enterprise Server must start with \"https://\" or \"http://\" like \"https://github.example.com\" but got %q", u)
+	}
+	if p.Host == "github.com" || p.Host == "api.github.com" {
+		return "", fmt.Errorf("URL of GitHub Enterprise Server must not be github.com but got %q", u)
+	}
+	s := strings.TrimSuffix(p.Scheme+"://"+p.Host+p.Path, "/")
+	if !strings.HasSuffix(s, "/api/v3") {
+		s += "/api/v3"
+	}
+	return s, nil
+}
+
+// GitHubAPIError is an error returned from GitHub REST API.
+type GitHubAPIError struct {
+	// URL is the requested URL.
+	URL string
+	// StatusCode is the HTTP status code of the response.
+	StatusCode int
+	// Message is the error message in the response body.
+	Message string
+}
+
+// Error implements error interface.
+func (e *GitHubAPIError) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("GitHub API request to %s failed with status %d", e.URL, e.StatusCode)
+	}
+	return fmt.Sprintf("GitHub API request to %s failed with status %d: %s", e.URL, e.StatusCode, e.Message)
+}
+
+var linkNextPattern = regexp.MustCompile(`<([^>]+)>;\s*rel="next"`)
+
+// gitHubAPIClient is a minimal client of GitHub REST API used by online checks.
+type gitHubAPIClient struct {
+	base   string
+	token  string
+	client *http.Client
+	// cache caches responses on disk. It is nil when responses are not cached.
+	cache *remoteCache
+	dbg   io.Writer
+}
+
+func newGitHubAPIClient(base, token string, cache *remoteCache, dbg io.Writer) *gitHubAPIClient {
+	if base == "" {
+		base = defaultGitHubAPIURL
+	}
+	return &gitHubAPIClient{
+		base:   strings.TrimSuffix(base, "/"),
+		token:  token,
+		client: &http.Client{Timeout: 30 * time.Second},
+		cache:  cache,
+		dbg:    dbg,
+	}
+}
+
+func (c *gitHubAPIClient) debug(format string, args ...interface{}) {
+	if c.dbg == nil {
+		return
+	}
+	format = "[GitHubAPIClient] " + format + "\n"
+	fmt.Fprintf(c.dbg, format, args...)
+}
+
+// isTemporaryGitHubAPIError returns whether the request may succeed later. It is true for network
+// errors, server errors, and rate limit errors.
+func isTemporaryGitHubAPIError(err error) bool {
+	var e *GitHubAPIError
+	if !errors.As(err, &e) {
+		return true // Network error
+	}
+	return e.StatusCode >= 500 || e.StatusCode == http.StatusTooManyRequests || e.StatusCode == http.StatusForbidden && strings.Contains(e.Message, "rate limit")
+}
+
+func newGitHubAPIErrorFromBody(url string, status int, body []byte) *GitHubAPIError {
+	var e struct {
+		Message string `json:"message"`
+	}
+	json.Unmarshal(body, &e) // Ignore error since the body may not be JSON
+	return &GitHubAPIError{url, status, e.Message}
+}
+
+func (c *gitHubAPIClient) cachedResponse(url string, e *remoteCacheEntry) ([]byte, string, error) {
+	if e.Status != 0 {
+		return nil, "", newGitHubAPIErrorFromBody(url, e.Status, e.Body)
+	}
+	return e.Body, e.Next, nil
+}
+
+// request sends GET request to the URL and returns the response body and the URL of the next page.
+// Cached response is returned when it is not expired. When the request failed due to network error
+// or rate limit, expired cached response is returned as fallback.
+func (c *gitHubAPIClient) request(url string) ([]byte, string, error) {
+	if e, ok := c.cache.load(url, false); ok {
+		return c.cachedResponse(url, e)
+	}
+
+	body, next, err := c.fetch(url)
+	if err != nil && isTemporaryGitHubAPIError(err) {
+		if e, ok := c.cache.load(url, true); ok {
+			c.debug("Use expired cached response since request to %s failed: %v", url, err)
+			return c.cachedResponse(url, e)
+		}
+	}
+	return body, next, err
+}
+
+func (c *gitHubAPIClient) fetch(url string) ([]byte, string, error) {
+	c.debug("GET %s", url)
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, "", err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	res, err := c.client.Do(req)
+	if err != nil {
+		return nil, "", fmt.Errorf("could not send request to %s: %w", url, err)
+	}
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, "", fmt.Errorf("could not read response body from %s: %w", url, err)
+	}
+
+	if res.StatusCode == http.StatusNotFound {
+		// Cache "not found" so that missing resources such as deleted refs are not requested again
+		c.cache.store(url, &remoteCacheEntry{Body: body, Status: res.StatusCode})
+	}
+	if res.StatusCode < 200 || 300 <= res.StatusCode {
+		return nil, "", newGitHubAPIErrorFromBody(url, res.StatusCode, body)
+	}
+
+	next := ""
+	if m := linkNextPattern.FindStringSubmatch(res.Header.Get("Link")); m != nil {
+		next = m[1]
+	}
+	c.cache.store(url, &remoteCacheEntry{Body: body, Next: next})
+	return body, next, nil
+}
+
+// get sends GET request to the API endpoint like "/repos/{owner}/{repo}" and decodes the JSON
+// response into 'v'.
+func (c *gitHubAPIClient) get(endpoint string, v any) error {
+	body, _, err := c.request(c.base + endpoint)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(body, v); err != nil {
+		return fmt.Errorf("could not parse response from %s: %w", endpoint, err)
+	}
+	return nil
+}
+
+// send sends the request with the JSON body to the API endpoint and decodes the JSON response into
+// 'v' unless it is nil. Unlike GET requests, the response is never cached. This is used for the
+// APIs which create or update resources.
+func (c *gitHubAPIClient) send(method, endpoint string, body, v any) error {
+	url := c.base + endpoint
+	b, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("could not encode request body for %s: %w", url, err)
+	}
+
+	c.debug("%s %s", method, url)
+	req, err := http.NewRequest(method, url, bytes.NewReader(b))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	res, err := c.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("could not send request to %s: %w", url, err)
+	}
+	defer res.Body.Close()
+
+	b, err = io.ReadAll(res.Body)
+	if err != nil {
+		return fmt.Errorf("could not read response body from %s: %w", url, err)
+	}
+
+	if res.StatusCode < 200 || 300 <= res.StatusCode {
+		return newGitHubAPIErrorFromBody(url, res.StatusCode, b)
+	}
+
+	if v == nil {
+		return nil
+	}
+	if err := json.Unmarshal(b, v); err != nil {
+		return fmt.Errorf("could not parse response from %s: %w", endpoint, err)
+	}
+	return nil
+}
+
+// getAllPages sends GET requests to the API endpoint following pagination. 'each' is called with
+// the response body of each page.
+func (c *gitHubAPIClient) getAllPages(endpoint string, each func([]byte) error) error {
+	url := c.base + endpoint
+	if strings.Contains(url, "?") {
+		url += "&per_page=100"
+	} else {
+		url += "?per_page=100"
+	}
+	for url != "" {
+		body, next, err := c.request(url)
+		if err != nil {
+			return err
+		}
+		if err := each(body); err != nil {
+			return fmt.Errorf("could not parse response from %s: %w", endpoint, err)
+		}
+		url = next
+	}
+	return nil
+}
+
+// RemoteRepository is a repository on GitHub which a project corresponds to. It fetches the
+// information of the repository such as registered self-hosted runners via GitHub REST API for
+// online checks. Fetched information is cached in memory so each API endpoint is called at most
+// once. Calling methods of this struct is thread-safe.
+type RemoteRepository struct {
+	owner        string
+	name         string
+	api          *gitHubAPIClient
+	runnerLabels func() ([][]string, error)
+	lists        sync.Map // Endpoint -> func() ([]string, error)
+	actions      sync.Map // Action spec -> func() (*ActionMetadata, error)
+	workflows    sync.Map // Reusable workflow spec -> func() (*ReusableWorkflowMetadata, error)
+	refs         sync.Map // "owner/repo@ref" -> func() remoteRefStatus
+	shas         sync.Map // "owner/repo@ref" -> func() (string, error)
+	latest       sync.Map // "owner/repo" -> func() (string, error)
+	warn         io.Writer
+	warned       sync.Map
+	// enterprise is true when the repository is hosted on GitHub Enterprise Server.
+	enterprise bool
+	ghes       func() (*GHESVersion, error)
+}
+
+// NewRemoteRepository creates a new RemoteRepository instance for the GitHub repository 'owner/name'.
+// 'apiURL' is the base URL of GitHub REST API. When it is empty, "https://api.github.com" is used.
+// 'token' is an API token used for authentication. 'cacheDir' is a directory to cache API responses
+// on disk across runs. When it is empty, responses are not cached on disk. 'warn' is a writer to
+// output warnings on failing to fetch the information. 'dbg' is a writer for debug logs. They can be
+// nil.
+func NewRemoteRepository(owner, name, apiURL, token, cacheDir string, warn, dbg io.Writer) *RemoteRepository {
+	return newRemoteRepository(owner, name, apiURL, token, newRemoteCache(cacheDir, 0, dbg), warn, dbg)
+}
+
+func newRemoteRepository(owner, name, apiURL, token string, cache *remoteCache, warn, dbg io.Writer) *RemoteRepository {
+	r := &RemoteRepository{
+		owner: owner,
+		name:  name,
+		api:   newGitHubAPIClient(apiURL, token, cache, dbg),
+		warn:  warn,
+	}
+	r.runnerLabels = sync.OnceValues(r.fetchRunnerLabels)
+	r.ghes = sync.OnceValues(r.fetchGHESVersion)
+	return r
+}
+
+// NewEnterpriseRemoteRepository creates a new RemoteRepository instance for the repository 'owner/name'
+// hosted on GitHub Enterprise Server at 'serverURL' like "https://github.example.com". Differences of
+// the REST API from GitHub.com are respected. For example, GitHub-hosted runners are not fetched.
+// Other parameters are the same as NewRemoteRepository.
+func NewEnterpriseRemoteRepository(owner, name, serverURL, token, cacheDir string, warn, dbg io.Writer) (*RemoteRepository, error) {
+	u, err := ghesAPIURL(serverURL)
+	if err != nil {
+		return nil, err
+	}
+	r := NewRemoteRepository(owner, name, u, token, cacheDir, warn, dbg)
+	r.enterprise = true
+	return r, nil
+}
+
+// FullName returns the full name of the repository like "owner/repo".
+func (r *RemoteRepository) FullName() string {
+	return r.owner + "/" + r.name
+}
+
+// of returns the description of the information of the repository for warnings.
+func (r *RemoteRepository) of(what string) string {
+	return fmt.Sprintf("%s of repository %q", what, r.FullName())
+}
+
+// warnOnce outputs the warning once per the information.
+func (r *RemoteRepository) warnOnce(what string, err error) {
+	if r.warn == nil {
+		return
+	}
+	if _, loaded := r.warned.LoadOrStore(what, struct{}{}); loaded {
+		return
+	}
+	fmt.Fprintf(r.warn, "warning: could not fetch %s for online checks. the check is skipped: %v\n", what, err)
+}
+
+// warnRepoOnce outputs the warning on failing to fetch the repository 'owner/repo' once. On GitHub
+// Enterprise Server, repositories of actions may not exist on the instance since actions on GitHub.com
+// are available via GitHub Connect. Missing repositories are not warned in the case.
+// https://docs.github.com/en/enterprise-server@latest/admin/managing-github-actions-for-your-enterprise/managing-access-to-actions-from-githubcom/enabling-automatic-access-to-githubcom-actions-using-github-connect
+func (r *RemoteRepository) warnRepoOnce(owner, repo string, err error) {
+	if r.enterprise && isNotFoundAPIError(err) {
+		r.api.debug("Repository %s/%s was not found on GitHub Enterprise Server. It may be on GitHub.com: %v", owner, repo, err)
+		return
+	}
+	r.warnOnce(fmt.Sprintf("repository %q", owner+"/"+repo), err)
+}
+
+// https://docs.github.com/en/enterprise-server@latest/rest/meta/meta#get-github-enterprise-server-meta-information
+func (r *RemoteRepository) fetchGHESVersion() (*GHESVersion, error) {
+	var m struct {
+		InstalledVersion string `json:"installed_version"`
+	}
+	if err := r.api.get("/meta", &m); err != nil {
+		return nil, err
+	}
+	if m.InstalledVersion == "" {
+		return nil, errors.New("\"installed_version\" is missing in the response of /meta API")
+	}
+	return ParseGHESVersion(m.InstalledVersion)
+}
+
+// GHESVersion returns the version of GitHub Enterprise Server which hosts the repository. It returns
+// nil when the repository is not hosted on GitHub Enterprise Server or the version could not be
+// fetched.
+func (r *RemoteRepository) GHESVersion() *GHESVersion {
+	if !r.enterprise {
+		return nil
+	}
+	v, err := r.ghes()
+	if err != nil {
+		r.warnOnce("version of GitHub Enterprise Server", err)
+		return nil
+	}
+	return v
+}
+
+// https://docs.github.com/en/rest/actions/self-hosted-runners#list-self-hosted-runners-for-a-repository
+// https://docs.github.com/en/rest/actions/hosted-runners#list-github-hosted-runners-for-an-organization
+func (r *RemoteRepository) fetchRunnerLabels() ([][]string, error) {
+	type runners struct {
+		Runners []struct {
+			Name   string `json:"name"`
+			Labels []struct {
+				Name string `json:"name"`
+			} `json:"labels"`
+		} `json:"runners"`
+	}
+
+	ret := [][]string{}
+	collect := func(b []byte) error {
+		var rs runners
+		if err := json.Unmarshal(b, &rs); err != nil {
+			return err
+		}
+		for _, r := range rs.Runners {
+			if len(r.Labels) == 0 {
+				// Larger runners hosted by GitHub are specified by their names
+				ret = append(ret, []string{r.Name})
+				continue
+			}
+			ls := make([]string, 0, len(r.Labels))
+			for _, l := range r.Labels {
+				ls = append(ls, l.Name)
+			}
+			ret = append(ret, ls)
+		}
+		return nil
+	}
+
+	repoErr := r.api.getAllPages(fmt.Sprintf("/repos/%s/%s/actions/runners", r.owner, r.name), collect)
+	// Organization endpoints fail when the owner is a user account or the token does not have
+	// the permission. Runners of the repository are enough in the case.
+	orgErr := r.api.getAllPages(fmt.Sprintf("/orgs/%s/actions/runners", r.owner), collect)
+	// GitHub-hosted runners are not available on GitHub Enterprise Server
+	if orgErr == nil && !r.enterprise {
+		r.api.getAllPages(fmt.Sprintf("/orgs/%s/actions/hosted-runners", r.owner), collect)
+	}
+	if repoErr != nil && orgErr != nil {
+		return nil, repoErr
+	}
+	return ret, nil
+}
+
+// RunnerLabels returns the labels of all self-hosted runners registered to the repository and its
+// organization. Each element of the returned slice is the labels of one runner. GitHub-hosted larger
+// runners of the organization are included with their names as labels. When the information could
+// not be fetched, this method returns nil.
+func (r *RemoteRepository) RunnerLabels() [][]string {
+	ls, err := r.runnerLabels()
+	if err != nil {
+		r.warnOnce(r.of("self-hosted runners"), err)
+		return nil
+	}
+	return ls
+}
+
+// fetchNames fetches all names in the list API endpoint. The response is an object which has the
+// array of objects with "name" property at 'key' like {"secrets": [{"name": "FOO"}]}.
+func (r *RemoteRepository) fetchNames(endpoint, key string) ([]string, error) {
+	ret := []string{}
+	err := r.api.getAllPages(endpoint, func(b []byte) error {
+		var res map[string]json.RawMessage
+		if err := json.Unmarshal(b, &res); err != nil {
+			return err
+		}
+		var items []struct {
+			Name string `json:"name"`
+		}
+		if err := json.Unmarshal(res[key], &items); err != nil {
+			return err
+		}
+		for _, i := range items {
+			ret = append(ret, i.Name)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// names returns all names in the list API endpoint. The result is cached per endpoint.
+func (r *RemoteRepository) names(endpoint, key string) ([]string, error) {
+	f, ok := r.lists.Load(endpoint)
+	if !ok {
+		f, _ = r.lists.LoadOrStore(endpoint, sync.OnceValues(func() ([]string, error) {
+			return r.fetchNames(endpoint, key)
+		}))
+	}
+	return f.(func() ([]string, error))()
+}
+
+// namesInScopes returns all names in the repository, its organization, and the environment. 'kind'
+// is "secrets" or "variables". When 'env' is empty, the environment is not included.
+// https://docs.github.com/en/rest/actions/secrets
+// https://docs.github.com/en/rest/actions/variables
+func (r *RemoteRepository) namesInScopes(kind, env string) []string {
+	repo := fmt.Sprintf("/repos/%s/%s", r.owner, r.name)
+	endpoints := []string{
+		repo + "/actions/" + kind,
+		repo + "/actions/organization-" + kind,
+	}
+	if env != "" {
+		endpoints = append(endpoints, repo+"/environments/"+url.PathEscape(env)+"/"+kind)
+	}
+
+	ret := []string{}
+	for _, e := range endpoints {
+		ns, err := r.names(e, kind)
+		if err != nil {
+			r.warnOnce(r.of(kind), err)
+			return nil
+		}
+		ret = append(ret, ns...)
+	}
+	return ret
+}
+
+// Environments returns the names of the environments configured in the repository. When the
+// information could not be fetched, this method returns nil.
+// https://docs.github.com/en/rest/deployments/environments#list-environments
+func (r *RemoteRepository) Environments() []string {
+	envs, err := r.names(fmt.Sprintf("/repos/%s/%s/environments", r.owner, r.name), "environments")
+	if err != nil {
+		r.warnOnce(r.of("environments"), err)
+		return nil
+	}
+	return envs
+}
+
+// Secrets returns the names of the secrets available in the repository. Secrets of the repository's
+// organization visible to the repository are included. When 'env' is not empty, secrets of the
+// environment are also included. When the information could not be fetched, this method returns nil.
+func (r *RemoteRepository) Secrets(env string) []string {
+	return r.namesInScopes("secrets", env)
+}
+
+// Variables returns the names of the configuration variables available in the repository. Variables
+// of the repository's organization visible to the repository are included. When 'env' is not empty,
+// variables of the environment are also included. When the information could not be fetched, this
+// method returns nil.
+func (r *RemoteRepository) Variables(env string) []string {
+	return r.namesInScopes("variables", env)
+}
+
+// parseRepoActionSpec parses the action spec like "{owner}/{repo}@{ref}" or "{owner}/{repo}/{path}@{ref}".
+func parseRepoActionSpec(spec string) (owner, repo, path, ref string, ok bool) {
+	s, ref, ok := strings.Cut(spec, "@")
+	if !ok || ref == "" {
+		return "", "", "", "", false
+	}
+	owner, s, ok = strings.Cut(s, "/")
+	if !ok || owner == "" {
+		return "", "", "", "", false
+	}
+	repo, path, _ = strings.Cut(s, "/")
+	if repo == "" {
+		return "", "", "", "", false
+	}
+	return owner, repo, path, ref, true
+}
+
+// https://docs.github.com/en/rest/repos/contents#get-repository-content
+func (r *RemoteRepository) fetchActionMetadata(spec string) (*ActionMetadata, error) {
+	owner, repo, dir, ref, ok := parseRepoActionSpec(spec)
+	if !ok {
+		return nil, nil
+	}
+	if dir != "" {
+		dir += "/"
+	}
+
+	for _, f := range []string{"action.yml", "action.yaml"} {
+		var c struct {
+			Content  string `json:"content"`
+			Encoding string `json:"encoding"`
+		}
+		endpoint := fmt.Sprintf("/repos/%s/%s/contents/%s%s?ref=%s", owner, repo, dir, f, url.QueryEscape(ref))
+		if err := r.api.get(endpoint, &c); err != nil {
+			var apiErr *GitHubAPIError
+			if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+				continue
+			}
+			r.warnOnce("metadata of action "+strconv.Quote(spec), err)
+			return nil, nil
+		}
+		if c.Encoding != "base64" {
+			return nil, nil
+		}
+		b, err := base64.StdEncoding.DecodeString(strings.ReplaceAll(c.Content, "\n", ""))
+		if err != nil {
+			return nil, fmt.Errorf("could not decode content of %s in action %q: %w", f, spec, err)
+		}
+
+		var meta ActionMetadata
+		if err := yaml.Unmarshal(b, &meta); err != nil {
+			return nil, fmt.Errorf("could not parse action metadata %s of action %q: %s", f, spec, strings.ReplaceAll(err.Error(), "\n", " "))
+		}
+		meta.file = f
+		return &meta, nil
+	}
+
+	return nil, nil // Action metadata was not found. The ref may not exist
+}
+
+// ActionMetadata fetches the metadata (action.yml) of the action released on GitHub. 'spec' is the
+// action spec at "uses:" like "owner/repo@v1" or "owner/repo/path@v1". When the metadata was not
+// found or could not be fetched, this method returns nil. It returns an error when the fetched
+// metadata could not be parsed. The result is cached per action spec.
+func (r *RemoteRepository) ActionMetadata(spec string) (*ActionMetadata, error) {
+	f, ok := r.actions.Load(spec)
+	if !ok {
+		f, _ = r.actions.LoadOrStore(spec, sync.OnceValues(func() (*ActionMetadata, error) {
+			return r.fetchActionMetadata(spec)
+		}))
+	}
+	return f.(func() (*ActionMetadata, error))()
+}
+
+// https://docs.github.com/en/rest/repos/contents#get-repository-content
+func (r *RemoteRepository) fetchReusableWorkflowMetadata(spec string) (*ReusableWorkflowMetadata, error) {
+	owner, repo, path, ref, ok := parseRepoActionSpec(spec)
+	if !ok || path == "" {
+		return nil, nil
+	}
+
+	var c struct {
+		Content  string `json:"content"`
+		Encoding string `json:"encoding"`
+	}
+	endpoint := fmt.Sprintf("/repos/%s/%s/contents/%s?ref=%s", owner, repo, path, url.QueryEscape(ref))
+	if err := r.api.get(endpoint, &c); err != nil {
+		if !isNotFoundAPIError(err) {
+			r.warnOnce("reusable workflow "+strconv.Quote(spec), err)
+		}
+		return nil, nil // When not found, the ref may not exist
+	}
+	if c.Encoding != "base64" {
+		return nil, nil
+	}
+	b, err := base64.StdEncoding.DecodeString(strings.ReplaceAll(c.Content, "\n", ""))
+	if err != nil {
+		return nil, fmt.Errorf("could not decode content of reusable workflow %q: %w", spec, err)
+	}
+
+	m, err := parseReusableWorkflowMetadata(b)
+	if err != nil {
+		return nil, fmt.Errorf("error while parsing reusable workflow %q: %s", spec, strings.ReplaceAll(err.Error(), "\n", " "))
+	}
+	return m, nil
+}
+
+// ReusableWorkflowMetadata fetches the metadata of the reusable workflow in other repository. 'spec'
+// is the reusable workflow spec at "uses:" like "owner/repo/.github/workflows/ci.yml@v1". When the
+// workflow was not found or could not be fetched, this method returns nil. It returns an error when
+// the fetched workflow could not be parsed. The result is cached per workflow spec.
+func (r *RemoteRepository) ReusableWorkflowMetadata(spec string) (*ReusableWorkflowMetadata, error) {
+	f, ok := r.workflows.Load(spec)
+	if !ok {
+		f, _ = r.workflows.LoadOrStore(spec, sync.OnceValues(func() (*ReusableWorkflowMetadata, error) {
+			return r.fetchReusableWorkflowMetadata(spec)
+		}))
+	}
+	return f.(func() (*ReusableWorkflowMetadata, error))()
+}
+
+// remoteRefStatus is a status of the ref in other repository resolved via GitHub REST API.
+type remoteRefStatus uint8
+
+const (
+	// remoteRefUnknown means the ref could not be resolved due to some error.
+	remoteRefUnknown remoteRefStatus = iota
+	// remoteRefFound means the ref exists in the repository.
+	remoteRefFound
+	// remoteRefNotFound means no tag, branch, or commit exists for the ref.
+	remoteRefNotFound
+	// remoteRefUnreachable means the commit exists but it is not reachable from the default branch or
+	// any tag of the repository. It happens when the commit is in a fork of the repository.
+	remoteRefUnreachable
+)
+
+var fullCommitSHAPattern = regexp.MustCompile(`^[0-9a-f]{40}$`)
+
+func isNotFoundAPIError(err error) bool {
+	var apiErr *GitHubAPIError
+	// Getting commit returns 422 when the SHA is not found
+	return errors.As(err, &apiErr) && (apiErr.StatusCode == http.StatusNotFound || apiErr.StatusCode == http.StatusUnprocessableEntity)
+}
+
+// https://docs.github.com/en/rest/git/refs#get-a-reference
+// https://docs.github.com/en/rest/commits/commits#get-a-commit
+// https://docs.github.com/en/rest/commits/commits#compare-two-commits
+// https://docs.github.com/en/rest/repos/repos#list-repository-tags
+func (r *RemoteRepository) fetchRefStatus(owner, repo, ref string) remoteRefStatus {
+	base := fmt.Sprintf("/repos/%s/%s", owner, repo)
+	what := fmt.Sprintf("ref %q of repository %q", ref, owner+"/"+repo)
+
+	if !fullCommitSHAPattern.MatchString(ref) {
+		var v any
+		for _, kind := range []string{"tags", "heads"} {
+			err := r.api.get(base+"/git/ref/"+kind+"/"+ref, &v)
+			if err == nil {
+				return remoteRefFound
+			}
+			if !isNotFoundAPIError(err) {
+				r.warnOnce(what, err)
+				return remoteRefUnknown
+			}
+		}
+		// Confirm the repository is accessible. Private repositories are not found without permission
+		if err := r.api.get(base, &v); err != nil {
+			r.warnRepoOnce(owner, repo, err)
+			return remoteRefUnknown
+		}
+		return remoteRefNotFound
+	}
+
+	var info struct {
+		DefaultBranch string `json:"default_branch"`
+	}
+	if err := r.api.get(base, &info); err != nil {
+		r.warnRepoOnce(owner, repo, err)
+		return remoteRefUnknown
+	}
+
+	// Commits in forks can be fetched via the parent repository. They need to be checked with the
+	// history of the repository.
+	var cmp struct {
+		Status string `json:"status"`
+	}
+	if err := r.api.get(fmt.Sprintf("%s/compare/%s...%s?per_page=1", base, ref, url.PathEscape(info.DefaultBranch)), &cmp); err != nil {
+		if isNotFoundAPIError(err) {
+			return remoteRefNotFound
+		}
+		r.warnOnce(what, err)
+		return remoteRefUnknown
+	}
+	if cmp.Status == "ahead" || cmp.Status == "identical" {
+		return remoteRefFound // The commit is an ancestor of the default branch
+	}
+
+	// Commits of releases may not be in the default branch
+	found := false
+	err := r.api.getAllPages(base+"/tags", func(b []byte) error {
+		var tags []struct {
+			Commit struct {
+				SHA string `json:"sha"`
+			} `json:"commit"`
+		}
+		if err := json.Unmarshal(b, &tags); err != nil {
+			return err
+		}
+		for _, t := range tags {
+			if t.Commit.SHA == ref {
+				found = true
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		r.warnOnce(what, err)
+		return remoteRefUnknown
+	}
+	if found {
+		return remoteRefFound
+	}
+	return remoteRefUnreachable
+}
+
+// refStatus resolves the ref (tag, branch, or full commit SHA) in the repository 'owner/repo' and
+// returns its status. The result is cached per ref.
+func (r *RemoteRepository) refStatus(owner, repo, ref string) remoteRefStatus {
+	k := owner + "/" + repo + "@" + ref
+	f, ok := r.refs.Load(k)
+	if !ok {
+		f, _ = r.refs.LoadOrStore(k, sync.OnceValue(func() remoteRefStatus {
+			return r.fetchRefStatus(owner, repo, ref)
+		}))
+	}
+	return f.(func() remoteRefStatus)()
+}
+
+// https://docs.github.com/en/rest/commits/commits#get-a-commit
+func (r *RemoteRepository) fetchCommitSHA(owner, repo, ref string) (string, error) {
+	var c struct {
+		SHA string `json:"sha"`
+	}
+	if err := r.api.get(fmt.Sprintf("/repos/%s/%s/commits/%s", owner, repo, ref), &c); err != nil {
+		return "", err
+	}
+	if !fullCommitSHAPattern.MatchString(c.SHA) {
+		return "", fmt.Errorf("invalid commit SHA %q was returned for ref %q of repository %q", c.SHA, ref, owner+"/"+repo)
+	}
+	return c.SHA, nil
+}
+
+// commitSHA resolves the ref (tag or branch) in the repository 'owner/repo' to the full SHA of the
+// commit. The result is cached per ref.
+func (r *RemoteRepository) commitSHA(owner, repo, ref string) (string, error) {
+	k := owner + "/" + repo + "@" + ref
+	f, ok := r.shas.Load(k)
+	if !ok {
+		f, _ = r.shas.LoadOrStore(k, sync.OnceValues(func() (string, error) {
+			return r.fetchCommitSHA(owner, repo, ref)
+		}))
+	}
+	return f.(func() (string, error))()
+}
+
+// https://docs.github.com/en/rest/releases/releases#get-the-latest-release
+// https://docs.github.com/en/rest/repos/repos#list-repository-tags
+func (r *RemoteRepository) fetchLatestVersion(owner, repo string) (string, error) {
+	base := fmt.Sprintf("/repos/%s/%s", owner, repo)
+	var rel struct {
+		TagName string `json:"tag_name"`
+	}
+	err := r.api.get(base+"/releases/latest", &rel)
+	if err == nil {
+		if _, ok := parseActionVersion(rel.TagName); ok {
+			return rel.TagName, nil
+		}
+	} else if !isNotFoundAPIError(err) {
+		return "", err
+	}
+
+	// Some repositories only push tags without creating releases
+	var tags []struct {
+		Name string `json:"name"`
+	}
+	if err := r.api.get(base+"/tags?per_page=100", &tags); err != nil {
+		return "", err
+	}
+	latest := ""
+	var lv actionVersion
+	for _, t := range tags {
+		if v, ok := parseActionVersion(t.Name); ok && (latest == "" || v.compare(lv) > 0) {
+			latest, lv = t.Name, v
+		}
+	}
+	return latest, nil
+}
+
+// latestVersion returns the tag of the latest version of the repository 'owner/repo' like "v4.2.2".
+// The latest release is preferred and the greatest version in the tags is used when no release is
+// found. It returns an empty string when no version is found. The result is cached per repository.
+func (r *RemoteRepository) latestVersion(owner, repo string) (string, error) {
+	k := owner + "/" + repo
+	f, ok := r.latest.Load(k)
+	if !ok {
+		f, _ = r.latest.LoadOrStore(k, sync.OnceValues(func() (string, error) {
+			return r.fetchLatestVersion(owner, repo)
+		}))
+	}
+	return f.(func() (string, error))()
+}
+
+var gitRemoteURLPattern = regexp.MustCompile(`^(?:[a-z+]+://)?(?:[^@/]+@)?[^:/]+(?::\d+)?[:/]([^/]+)/([^/]+?)(?:\.git)?/?$`)
+
+// parseGitHubRemoteURL parses a remote URL of Git repository like "https://github.com/owner/repo.git"
+// or "git@github.com:owner/repo.git" and returns the owner and the repository name.
+func parseGitHubRemoteURL(u string) (string, string, bool) {
+	m := gitRemoteURLPattern.FindStringSubmatch(strings.TrimSpace(u))
+	if m == nil {
+		return "", "", false
+	}
+	return m[1], m[2], true
+}
+
+// gitDirOf returns the path to the ".git" directory of the Git repository at 'root'. In worktrees,
+// the ".git" directory of the main worktree is returned. It returns an empty string when the
+// directory could not be found.
+func gitDirOf(root string) string {
+	dot := filepath.Join(root, ".git")
+	s, err := os.Stat(dot)
+	if err != nil {
+		return ""
+	}
+	if s.IsDir() {
+		return dot
+	}
+
+	// .git is a file in worktrees and submodules: "gitdir: /path/to/.git/worktrees/foo"
+	b, err := os.ReadFile(dot)
+	if err != nil {
+		return ""
+	}
+	d, ok := strings.CutPrefix(strings.TrimSpace(string(b)), "gitdir:")
+	if !ok {
+		return ""
+	}
+	d = strings.TrimSpace(d)
+	if !filepath.IsAbs(d) {
+		d = filepath.Join(root, d)
+	}
+	if b, err := os.ReadFile(filepath.Join(d, "commondir")); err == nil {
+		c := strings.TrimSpace(string(b))
+		if !filepath.IsAbs(c) {
+			c = filepath.Join(d, c)
+		}
+		d = c
+	}
+	return d
+}
+
+// gitHubAPICacheDirOf returns the directory to cache responses of GitHub REST API for the project at
+// 'root'. The cache is put in the ".git" directory so that it is not committed to the repository.
+func gitHubAPICacheDirOf(root string) string {
+	d := gitDirOf(root)
+	if d == "" {
+		return ""
+	}
+	return filepath.Join(d, "actionlint", "cache")
+}
+
+// gitHubRepositoryOf returns the owner and the name of the GitHub repository from the URL of "origin"
+// remote of the Git repository at 'root'.
+func gitHubRepositoryOf(root string) (string, string, bool) {
+	d := gitDirOf(root)
+	if d == "" {
+		return "", "", false
+	}
+	b, err := os.ReadFile(filepath.Join(d, "config"))
+	if err != nil {
+		return "", "", false
+	}
+
+	inOrigin := false
+	for _, l := range strings.Split(string(b), "\n") {
+		l = strings.TrimSpace(l)
+		if strings.HasPrefix(l, "[") {
+			inOrigin = l == `[remote "origin"]`
+			continue
+		}
+		if !inOrigin {
+			continue
+		}
+		k, v, ok := strings.Cut(l, "=")
+		if ok && strings.TrimSpace(k) == "url" {
+			return parseGitHubRemoteURL(v)
+		}
+	}
+	return "", "", false
+}
@@ -0,0 +1,209 @@
+package actionlint
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Checks API accepts at most 50 annotations per request. Remaining annotations are added by
+// updating the check run.
+// https://docs.github.com/en/rest/checks/runs#update-a-check-run
+const maxGitHubCheckAnnotationsPerRequest = 50
+
+const gitHubCheckRunName = "actionlint"
+
+type gitHubCheckAnnotation struct {
+	Path            string `json:"path"`
+	StartLine       int    `json:"start_line"`
+	EndLine         int    `json:"end_line"`
+	StartColumn     int    `json:"start_column,omitempty"`
+	EndColumn       int    `json:"end_column,omitempty"`
+	AnnotationLevel string `json:"annotation_level"`
+	Message         string `json:"message"`
+	Title           string `json:"title"`
+}
+
+type gitHubCheckRunOutput struct {
+	Title       string                   `json:"title"`
+	Summary     string                   `json:"summary"`
+	Annotations []*gitHubCheckAnnotation `json:"annotations,omitempty"`
+}
+
+type gitHubCheckRun struct {
+	Name       string                `json:"name,omitempty"`
+	HeadSHA    string                `json:"head_sha,omitempty"`
+	Status     string                `json:"status,omitempty"`
+	Conclusion string                `json:"conclusion,omitempty"`
+	Output     *gitHubCheckRunOutput `json:"output"`
+}
+
+// gitHubChecksPublisher publishes errors as annotations of a check run via GitHub Checks API. Unlike
+// problem matchers, the number of annotations is not limited to 10 per type. It is configured with
+// the environment variables set on GitHub Actions.
+// https://docs.github.com/en/rest/checks/runs
+type gitHubChecksPublisher struct {
+	client *gitHubAPIClient
+	// repo is the repository in "owner/repo" format.
+	repo string
+	// sha is the commit SHA which the check run is associated with.
+	sha string
+	// workspace is the directory of the checked out repository. Paths of annotations are relative
+	// to this directory.
+	workspace string
+}
+
+// newGitHubChecksPublisherFromEnv creates a new gitHubChecksPublisher from the environment variables
+// such as GITHUB_REPOSITORY and GITHUB_SHA. The API token is read from GITHUB_TOKEN or GH_TOKEN.
+// https://docs.github.com/en/actions/reference/workflows-and-actions/variables#default-environment-variables
+func newGitHubChecksPublisherFromEnv(dbg io.Writer) (*gitHubChecksPublisher, error) {
+	token := os.Getenv("GITHUB_TOKEN")
+	if token == "" {
+		token = os.Getenv("GH_TOKEN")
+	}
+	if token == "" {
+		return nil, errors.New("API token is required to publish check run. set it to GITHUB_TOKEN or GH_TOKEN environment variable")
+	}
+
+	repo := os.Getenv("GITHUB_REPOSITORY")
+	if o, r, ok := strings.Cut(repo, "/"); !ok || o == "" || r == "" {
+		return nil, fmt.Errorf("GITHUB_REPOSITORY environment variable must be in \"owner/repo\" format to publish check run but got %q", repo)
+	}
+
+	sha, err := gitHubHeadSHAFromEnv()
+	if err != nil {
+		return nil, err
+	}
+
+	ws := os.Getenv("GITHUB_WORKSPACE")
+	if ws == "" {
+		if ws, err = os.Getwd(); err != nil {
+			return nil, fmt.Errorf("could not get current working directory: %w", err)
+		}
+	}
+
+	return &gitHubChecksPublisher{
+		client:    newGitHubAPIClient(os.Getenv("GITHUB_API_URL"), token, nil, dbg),
+		repo:      repo,
+		sha:       sha,
+		workspace: ws,
+	}, nil
+}
+
+// gitHubHeadSHAFromEnv returns the commit SHA to associate the check run with. On pull_request
+// events GITHUB_SHA is the SHA of the merge commit which is not shown in the pull request so the
+// SHA of the head commit is read from the event payload.
+func gitHubHeadSHAFromEnv() (string, error) {
+	if p := os.Getenv("GITHUB_EVENT_PATH"); p != "" {
+		if b, err := os.ReadFile(p); err == nil {
+			var e struct {
+				PullRequest *struct {
+					Head struct {
+						SHA string `json:"sha"`
+					} `json:"head"`
+				} `json:"pull_request"`
+			}
+			if err := json.Unmarshal(b, &e); err == nil && e.PullRequest != nil && e.PullRequest.Head.SHA != "" {
+				return e.PullRequest.Head.SHA, nil
+			}
+		}
+	}
+	if sha := os.Getenv("GITHUB_SHA"); sha != "" {
+		return sha, nil
+	}
+	return "", errors.New("GITHUB_SHA environment variable is required to publish check run")
+}
+
+func (p *gitHubChecksPublisher) path(file string) string {
+	if a, err := filepath.Abs(file); err == nil {
+		if r, err := filepath.Rel(p.workspace, a); err == nil && !strings.HasPrefix(r, "..") {
+			file = r
+		}
+	}
+	return filepath.ToSlash(file)
+}
+
+func (p *gitHubChecksPublisher) annotations(errs []*Error) []*gitHubCheckAnnotation {
+	as := make([]*gitHubCheckAnnotation, 0, len(errs))
+	for _, err := range errs {
+		line := max(err.Line, 1)
+		a := &gitHubCheckAnnotation{
+			Path:            p.path(err.Filepath),
+			StartLine:       line,
+			EndLine:         line,
+			StartColumn:     err.Column,
+			EndColumn:       err.Column,
+			AnnotationLevel: "failure",
+			Message:         err.Message,
+			Title:           fmt.Sprintf("%s [%s]", gitHubCheckRunName, err.Kind),
+		}
+		if err.EndLine > line {
+			// Columns are only available when the annotation starts and ends at the same line
+			a.EndLine = err.EndLine
+			a.StartColumn = 0
+			a.EndColumn = 0
+		} else if err.EndLine == line && err.EndColumn > err.Column+1 {
+			a.EndColumn = err.EndColumn - 1 // End column of annotation is inclusive
+		}
+		as = append(as, a)
+	}
+	return as
+}
+
+// publish creates a completed check run with the errors as its annotations. When there are more
+// than 50 errors, the check run is updated repeatedly to add the rest of annotations.
+func (p *gitHubChecksPublisher) publish(errs []*Error) error {
+	files := map[string]struct{}{}
+	for _, err := range errs {
+		files[err.Filepath] = struct{}{}
+	}
+
+	conclusion := "success"
+	summary := "No problem was found"
+	if len(errs) > 0 {
+		conclusion = "failure"
+		summary = fmt.Sprintf("%d error(s) were found in %d file(s)", len(errs), len(files))
+	}
+	as := p.annotations(errs)
+	chunk := func() []*gitHubCheckAnnotation {
+		n := min(len(as), maxGitHubCheckAnnotationsPerRequest)
+		c := as[:n]
+		as = as[n:]
+		return c
+	}
+
+	run := &gitHubCheckRun{
+		Name:       gitHubCheckRunName,
+		HeadSHA:    p.sha,
+		Status:     "completed",
+		Conclusion: conclusion,
+		Output: &gitHubCheckRunOutput{
+			Title:       summary,
+			Summary:     summary,
+			Annotations: chunk(),
+		},
+	}
+	var created struct {
+		ID int64 `json:"id"`
+	}
+	endpoint := fmt.Sprintf("/repos/%s/check-runs", p.repo)
+	if err := p.client.send(http.MethodPost, endpoint, run, &created); err != nil {
+		return fmt.Errorf("could not create check run: %w", err)
+	}
+
+	endpoint = fmt.Sprintf("%s/%d", endpoint, created.ID)
+	for len(as) > 0 {
+		// Output title and summary are required on updating annotations
+		u := &gitHubCheckRun{Output: &gitHubCheckRunOutput{Title: summary, Summary: summary, Annotations: chunk()}}
+		if err := p.client.send(http.MethodPatch, endpoint, u, nil); err != nil {
+			return fmt.Errorf("could not add annotations to check run %d: %w", created.ID, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,224 @@
+package actionlint
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+type testGitHubChecksServer struct {
+	mu       sync.Mutex
+	requests []string
+	runs     []*gitHubCheckRun
+}
+
+func (s *testGitHubChecksServer) start(t *testing.T) *httptest.Server {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.Header.Get("Authorization") != "Bearer test-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		var run gitHubCheckRun
+		if err := json.NewDecoder(req.Body).Decode(&run); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		s.mu.Lock()
+		s.requests = append(s.requests, req.Method+" "+req.URL.Path)
+		s.runs = append(s.runs, &run)
+		s.mu.Unlock()
+		switch {
+		case req.Method == http.MethodPost && req.URL.Path == "/repos/owner/repo/check-runs":
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprint(w, `{"id":42}`)
+		case req.Method == http.MethodPatch && req.URL.Path == "/repos/owner/repo/check-runs/42":
+			fmt.Fprint(w, `{"id":42}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func testSetGitHubChecksEnv(t *testing.T, apiURL, workspace string) {
+	t.Setenv("GITHUB_TOKEN", "test-token")
+	t.Setenv("GH_TOKEN", "")
+	t.Setenv("GITHUB_API_URL", apiURL)
+	t.Setenv("GITHUB_REPOSITORY", "owner/repo")
+	t.Setenv("GITHUB_SHA", "0123456789abcdef")
+	t.Setenv("GITHUB_EVENT_PATH", "")
+	t.Setenv("GITHUB_WORKSPACE", workspace)
+}
+
+func TestGitHubChecksPublishManyAnnotations(t *testing.T) {
+	s := &testGitHubChecksServer{}
+	srv := s.start(t)
+	ws := t.TempDir()
+	testSetGitHubChecksEnv(t, srv.URL, ws)
+
+	p, err := newGitHubChecksPublisherFromEnv(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	errs := make([]*Error, 0, 120)
+	for i := 0; i < 120; i++ {
+		errs = append(errs, &Error{
+			Message:  fmt.Sprintf("error %d", i),
+			Filepath: filepath.Join(ws, ".github", "workflows", "test.yaml"),
+			Line:     i + 1,
+			Column:   3,
+			Kind:     "syntax-check",
+		})
+	}
+	if err := p.publish(errs); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{
+		"POST /repos/owner/repo/check-runs",
+		"PATCH /repos/owner/repo/check-runs/42",
+		"PATCH /repos/owner/repo/check-runs/42",
+	}
+	if strings.Join(s.requests, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("wanted requests %v but got %v", want, s.requests)
+	}
+
+	created := s.runs[0]
+	if created.Name != "actionlint" || created.HeadSHA != "0123456789abcdef" || created.Status != "completed" || created.Conclusion != "failure" {
+		t.Fatalf("unexpected check run: %+v", created)
+	}
+	if s := created.Output.Summary; s != "120 error(s) were found in 1 file(s)" {
+		t.Fatalf("unexpected summary: %q", s)
+	}
+
+	for i, n := range []int{50, 50, 20} {
+		if l := len(s.runs[i].Output.Annotations); l != n {
+			t.Errorf("wanted %d annotations in request #%d but got %d", n, i, l)
+		}
+	}
+
+	a := s.runs[2].Output.Annotations[19]
+	if a.Path != ".github/workflows/test.yaml" || a.StartLine != 120 || a.EndLine != 120 || a.StartColumn != 3 || a.Message != "error 119" || a.Title != "actionlint [syntax-check]" || a.AnnotationLevel != "failure" {
+		t.Fatalf("unexpected annotation: %+v", a)
+	}
+}
+
+func TestGitHubChecksPublishNoError(t *testing.T) {
+	s := &testGitHubChecksServer{}
+	srv := s.start(t)
+	testSetGitHubChecksEnv(t, srv.URL, t.TempDir())
+
+	p, err := newGitHubChecksPublisherFromEnv(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := p.publish(nil); err != nil {
+		t.Fatal(err)
+	}
+	if len(s.runs) != 1 {
+		t.Fatalf("wanted only one request but got %v", s.requests)
+	}
+	if c := s.runs[0].Conclusion; c != "success" {
+		t.Fatalf("wanted success conclusion but got %q", c)
+	}
+	if len(s.runs[0].Output.Annotations) != 0 {
+		t.Fatalf("no annotation should be sent: %v", s.runs[0].Output.Annotations)
+	}
+}
+
+func TestGitHubChecksHeadSHAOfPullRequest(t *testing.T) {
+	testSetGitHubChecksEnv(t, "", t.TempDir())
+	ev := filepath.Join(t.TempDir(), "event.json")
+	if err := os.WriteFile(ev, []byte(`{"pull_request":{"head":{"sha":"fedcba9876543210"}}}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("GITHUB_EVENT_PATH", ev)
+
+	p, err := newGitHubChecksPublisherFromEnv(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.sha != "fedcba9876543210" {
+		t.Fatalf("wanted head SHA of pull request but got %q", p.sha)
+	}
+}
+
+func TestGitHubChecksMissingEnv(t *testing.T) {
+	tests := []struct {
+		env  string
+		want string
+	}{
+		{"GITHUB_TOKEN", "API token is required"},
+		{"GITHUB_REPOSITORY", "GITHUB_REPOSITORY environment variable must be in \"owner/repo\" format"},
+		{"GITHUB_SHA", "GITHUB_SHA environment variable is required"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.env, func(t *testing.T) {
+			testSetGitHubChecksEnv(t, "", t.TempDir())
+			t.Setenv(tc.env, "")
+			_, err := newGitHubChecksPublisherFromEnv(nil)
+			if err == nil {
+				t.Fatal("error did not occur")
+			}
+			if msg := err.Error(); !strings.Contains(msg, tc.want) {
+				t.Fatalf("error message %q does not contain %q", msg, tc.want)
+			}
+		})
+	}
+}
+
+func TestGitHubChecksCommandFlag(t *testing.T) {
+	s := &testGitHubChecksServer{}
+	srv := s.start(t)
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	testSetGitHubChecksEnv(t, srv.URL, wd)
+
+	var out bytes.Buffer
+	cmd := Command{Stdin: os.Stdin, Stdout: &out, Stderr: &out}
+	workflow := filepath.Join("testdata", "examples", "main.yaml")
+	status := cmd.Main([]string{"actionlint", "-shellcheck=", "-pyflakes=", "-github-checks", workflow})
+	if status != ExitStatusSuccessProblemFound {
+		t.Fatalf("exit status should be %d but got %d: %s", ExitStatusSuccessProblemFound, status, out.String())
+	}
+	if len(s.runs) != 1 {
+		t.Fatalf("wanted one request but got %v", s.requests)
+	}
+	as := s.runs[0].Output.Annotations
+	if len(as) == 0 || as[0].Path != "testdata/examples/main.yaml" {
+		t.Fatalf("unexpected annotations: %+v", as)
+	}
+}
+
+func TestGitHubChecksAnnotationRange(t *testing.T) {
+	p := &gitHubChecksPublisher{workspace: "."}
+	errs := []*Error{
+		{Filepath: "a.yaml", Line: 3, Column: 5},
+		{Filepath: "a.yaml", Line: 3, Column: 5, EndLine: 3, EndColumn: 11},
+		{Filepath: "a.yaml", Line: 3, Column: 5, EndLine: 5, EndColumn: 2},
+	}
+	as := p.annotations(errs)
+
+	want := [][4]int{
+		{3, 3, 5, 5},
+		{3, 3, 5, 10},
+		{3, 5, 0, 0},
+	}
+	for i, a := range as {
+		have := [4]int{a.StartLine, a.EndLine, a.StartColumn, a.EndColumn}
+		if have != want[i] {
+			t.Errorf("annotation #%d has unexpected range %v. wanted %v", i, have, want[i])
+		}
+	}
+}
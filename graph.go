@@ -0,0 +1,250 @@
+package actionlint
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// GraphFormat is a format of the dependency graph output by "actionlint graph" subcommand.
+type GraphFormat string
+
+const (
+	// GraphFormatDOT is DOT language of Graphviz.
+	GraphFormatDOT GraphFormat = "dot"
+	// GraphFormatMermaid is flowchart of Mermaid.
+	GraphFormatMermaid GraphFormat = "mermaid"
+	// GraphFormatJSON is JSON.
+	GraphFormatJSON GraphFormat = "json"
+)
+
+// ParseGraphFormat parses the name of graph format. Empty string is parsed as DOT.
+func ParseGraphFormat(s string) (GraphFormat, error) {
+	switch f := GraphFormat(strings.ToLower(s)); f {
+	case "":
+		return GraphFormatDOT, nil
+	case GraphFormatDOT, GraphFormatMermaid, GraphFormatJSON:
+		return f, nil
+	default:
+		return "", fmt.Errorf("graph format must be one of \"dot\", \"mermaid\", or \"json\" but got %q", s)
+	}
+}
+
+// GraphJob is a job node in the dependency graph.
+type GraphJob struct {
+	// ID is the ID of the job.
+	ID string `json:"id"`
+	// Name is the name of the job at "name:". It is empty when the name is not set.
+	Name string `json:"name,omitempty"`
+	// Needs is the IDs of jobs which this job depends on at "needs:".
+	Needs []string `json:"needs"`
+	// Workflow is the reusable workflow called by this job at "uses:". It is empty when this job
+	// does not call a reusable workflow.
+	Workflow string `json:"workflow,omitempty"`
+	// Actions is the local actions used by the steps of this job.
+	Actions []string `json:"actions,omitempty"`
+}
+
+// GraphWorkflow is a workflow in the dependency graph.
+type GraphWorkflow struct {
+	// Path is the file path of the workflow.
+	Path string `json:"path"`
+	// Name is the name of the workflow at "name:". It is empty when the name is not set.
+	Name string `json:"name,omitempty"`
+	// Jobs is the jobs in the workflow in order of their definitions.
+	Jobs []*GraphJob `json:"jobs"`
+}
+
+// Graph is a dependency graph of jobs, reusable workflow calls, and local action usages in
+// workflows.
+type Graph struct {
+	// Workflows is the workflows in the graph.
+	Workflows []*GraphWorkflow `json:"workflows"`
+}
+
+// Add adds the jobs of the workflow parsed from the file at the path to the graph.
+func (g *Graph) Add(path string, w *Workflow) {
+	gw := &GraphWorkflow{Path: path, Jobs: []*GraphJob{}}
+	if w.Name != nil {
+		gw.Name = w.Name.Value
+	}
+
+	jobs := make([]*Job, 0, len(w.Jobs))
+	ids := make(map[string]string, len(w.Jobs)) // Job IDs are case-insensitive
+	for id, j := range w.Jobs {
+		if j.ID == nil {
+			continue
+		}
+		jobs = append(jobs, j)
+		ids[id] = j.ID.Value
+	}
+	slices.SortFunc(jobs, func(a, b *Job) int { return a.Pos.Line - b.Pos.Line })
+
+	for _, j := range jobs {
+		gj := &GraphJob{ID: j.ID.Value, Needs: []string{}}
+		if j.Name != nil {
+			gj.Name = j.Name.Value
+		}
+		for _, n := range j.Needs {
+			if id, ok := ids[strings.ToLower(n.Value)]; ok && !slices.Contains(gj.Needs, id) {
+				gj.Needs = append(gj.Needs, id)
+			}
+		}
+		if j.WorkflowCall != nil && j.WorkflowCall.Uses != nil {
+			gj.Workflow = j.WorkflowCall.Uses.Value
+		}
+		for _, s := range j.Steps {
+			if e, ok := s.Exec.(*ExecAction); ok && e.Uses != nil && strings.HasPrefix(e.Uses.Value, "./") && !slices.Contains(gj.Actions, e.Uses.Value) {
+				gj.Actions = append(gj.Actions, e.Uses.Value)
+			}
+		}
+		gw.Jobs = append(gw.Jobs, gj)
+	}
+
+	g.Workflows = append(g.Workflows, gw)
+}
+
+// Write writes the graph to the writer in the format.
+func (g *Graph) Write(out io.Writer, f GraphFormat) error {
+	switch f {
+	case GraphFormatJSON:
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(g); err != nil {
+			return fmt.Errorf("could not encode graph into JSON: %w", err)
+		}
+		return nil
+	case GraphFormatMermaid:
+		return g.writeMermaid(out)
+	default:
+		return g.writeDOT(out)
+	}
+}
+
+// externals returns reusable workflows and local actions used by jobs in the graph in order of
+// their appearances.
+func (g *Graph) externals() (workflows []string, actions []string) {
+	for _, w := range g.Workflows {
+		for _, j := range w.Jobs {
+			if j.Workflow != "" && !slices.Contains(workflows, j.Workflow) {
+				workflows = append(workflows, j.Workflow)
+			}
+			for _, a := range j.Actions {
+				if !slices.Contains(actions, a) {
+					actions = append(actions, a)
+				}
+			}
+		}
+	}
+	return
+}
+
+func (g *Graph) writeDOT(out io.Writer) error {
+	var b strings.Builder
+	job := func(w *GraphWorkflow, j string) string { return strconv.Quote(w.Path + ":" + j) }
+
+	b.WriteString("digraph workflows {\n  rankdir=LR;\n")
+	for i, w := range g.Workflows {
+		label := w.Path
+		if w.Name != "" {
+			label = fmt.Sprintf("%s (%s)", w.Name, w.Path)
+		}
+		fmt.Fprintf(&b, "  subgraph \"cluster_%d\" {\n    label=%s;\n", i, strconv.Quote(label))
+		for _, j := range w.Jobs {
+			fmt.Fprintf(&b, "    %s [label=%s];\n", job(w, j.ID), strconv.Quote(j.ID))
+		}
+		b.WriteString("  }\n")
+	}
+
+	workflows, actions := g.externals()
+	for _, w := range workflows {
+		fmt.Fprintf(&b, "  %s [label=%s, shape=component];\n", strconv.Quote("workflow:"+w), strconv.Quote(w))
+	}
+	for _, a := range actions {
+		fmt.Fprintf(&b, "  %s [label=%s, shape=box];\n", strconv.Quote("action:"+a), strconv.Quote(a))
+	}
+
+	for _, w := range g.Workflows {
+		for _, j := range w.Jobs {
+			for _, n := range j.Needs {
+				fmt.Fprintf(&b, "  %s -> %s;\n", job(w, n), job(w, j.ID))
+			}
+			if j.Workflow != "" {
+				fmt.Fprintf(&b, "  %s -> %s [style=dashed];\n", job(w, j.ID), strconv.Quote("workflow:"+j.Workflow))
+			}
+			for _, a := range j.Actions {
+				fmt.Fprintf(&b, "  %s -> %s [style=dotted];\n", job(w, j.ID), strconv.Quote("action:"+a))
+			}
+		}
+	}
+	b.WriteString("}\n")
+
+	if _, err := io.WriteString(out, b.String()); err != nil {
+		return fmt.Errorf("could not write graph in DOT format: %w", err)
+	}
+	return nil
+}
+
+// mermaidLabel quotes the label of node in Mermaid flowchart. Double quotes cannot be escaped with
+// backslash in Mermaid.
+func mermaidLabel(s string) string {
+	return `"` + strings.ReplaceAll(s, `"`, "#quot;") + `"`
+}
+
+func (g *Graph) writeMermaid(out io.Writer) error {
+	var b strings.Builder
+	// Node IDs in Mermaid cannot contain some characters such as '.' or ':'. Assign sequential IDs
+	ids := map[string]string{}
+	id := func(key string) string {
+		if i, ok := ids[key]; ok {
+			return i
+		}
+		i := fmt.Sprintf("n%d", len(ids))
+		ids[key] = i
+		return i
+	}
+	job := func(w *GraphWorkflow, j string) string { return id(w.Path + ":" + j) }
+
+	b.WriteString("flowchart LR\n")
+	for i, w := range g.Workflows {
+		label := w.Path
+		if w.Name != "" {
+			label = fmt.Sprintf("%s (%s)", w.Name, w.Path)
+		}
+		fmt.Fprintf(&b, "  subgraph w%d [%s]\n", i, mermaidLabel(label))
+		for _, j := range w.Jobs {
+			fmt.Fprintf(&b, "    %s[%s]\n", job(w, j.ID), mermaidLabel(j.ID))
+		}
+		b.WriteString("  end\n")
+	}
+
+	workflows, actions := g.externals()
+	for _, w := range workflows {
+		fmt.Fprintf(&b, "  %s[[%s]]\n", id("workflow:"+w), mermaidLabel(w))
+	}
+	for _, a := range actions {
+		fmt.Fprintf(&b, "  %s([%s])\n", id("action:"+a), mermaidLabel(a))
+	}
+
+	for _, w := range g.Workflows {
+		for _, j := range w.Jobs {
+			for _, n := range j.Needs {
+				fmt.Fprintf(&b, "  %s --> %s\n", job(w, n), job(w, j.ID))
+			}
+			if j.Workflow != "" {
+				fmt.Fprintf(&b, "  %s -. calls .-> %s\n", job(w, j.ID), id("workflow:"+j.Workflow))
+			}
+			for _, a := range j.Actions {
+				fmt.Fprintf(&b, "  %s -. uses .-> %s\n", job(w, j.ID), id("action:"+a))
+			}
+		}
+	}
+
+	if _, err := io.WriteString(out, b.String()); err != nil {
+		return fmt.Errorf("could not write graph in Mermaid format: %w", err)
+	}
+	return nil
+}
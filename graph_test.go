@@ -0,0 +1,61 @@
+package actionlint
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestGraphWrite(t *testing.T) {
+	dir := filepath.Join("testdata", "graph")
+	g := &Graph{Workflows: []*GraphWorkflow{}}
+	for _, f := range []string{"test.yaml", "call.yaml"} {
+		src, err := os.ReadFile(filepath.Join(dir, f))
+		if err != nil {
+			panic(err)
+		}
+		w, errs := Parse(src)
+		if len(errs) > 0 {
+			t.Fatal(errs)
+		}
+		g.Add(f, w)
+	}
+
+	for _, f := range []GraphFormat{GraphFormatDOT, GraphFormatMermaid, GraphFormatJSON} {
+		t.Run(string(f), func(t *testing.T) {
+			want, err := os.ReadFile(filepath.Join(dir, "graph."+string(f)))
+			if err != nil {
+				panic(err)
+			}
+			var b bytes.Buffer
+			if err := g.Write(&b, f); err != nil {
+				t.Fatal(err)
+			}
+			if have := b.String(); have != string(want) {
+				t.Fatalf("unexpected graph.\nwant:\n%s\nhave:\n%s", want, have)
+			}
+		})
+	}
+}
+
+func TestGraphParseFormat(t *testing.T) {
+	for in, want := range map[string]GraphFormat{
+		"":        GraphFormatDOT,
+		"dot":     GraphFormatDOT,
+		"Mermaid": GraphFormatMermaid,
+		"json":    GraphFormatJSON,
+	} {
+		have, err := ParseGraphFormat(in)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if have != want {
+			t.Errorf("wanted %q for %q but got %q", want, in, have)
+		}
+	}
+
+	if _, err := ParseGraphFormat("svg"); err == nil {
+		t.Fatal("error did not occur")
+	}
+}
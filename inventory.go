@@ -0,0 +1,285 @@
+package actionlint
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	"go.yaml.in/yaml/v4"
+)
+
+// InventoryFormat is a format of the inventory output by "actionlint inventory" subcommand.
+type InventoryFormat string
+
+const (
+	// InventoryFormatText is human-readable table.
+	InventoryFormatText InventoryFormat = "text"
+	// InventoryFormatJSON is JSON.
+	InventoryFormatJSON InventoryFormat = "json"
+)
+
+// ParseInventoryFormat parses the name of inventory format. Empty string is parsed as text.
+func ParseInventoryFormat(s string) (InventoryFormat, error) {
+	switch f := InventoryFormat(strings.ToLower(s)); f {
+	case "":
+		return InventoryFormatText, nil
+	case InventoryFormatText, InventoryFormatJSON:
+		return f, nil
+	default:
+		return "", fmt.Errorf("inventory format must be \"text\" or \"json\" but got %q", s)
+	}
+}
+
+// Kinds of InventoryItem.
+const (
+	// InventoryKindAction is an action in other repository like "actions/checkout@v4".
+	InventoryKindAction = "action"
+	// InventoryKindWorkflow is a reusable workflow in other repository.
+	InventoryKindWorkflow = "reusable workflow"
+	// InventoryKindLocalAction is a local action like "./.github/actions/setup".
+	InventoryKindLocalAction = "local action"
+	// InventoryKindLocalWorkflow is a reusable workflow in the same repository.
+	InventoryKindLocalWorkflow = "local reusable workflow"
+	// InventoryKindDocker is a Docker image like "docker://alpine:3.20".
+	InventoryKindDocker = "docker"
+)
+
+// InventoryLocation is a location where an item of the inventory is used.
+type InventoryLocation struct {
+	// Path is the file path of the workflow or the action metadata file.
+	Path string `json:"path"`
+	// Line is the line number of the value at "uses:".
+	Line int `json:"line"`
+	// Col is the column number of the value at "uses:".
+	Col int `json:"column"`
+}
+
+// InventoryItem is an action, a reusable workflow, or a Docker image used at "uses:" at a specific
+// ref.
+type InventoryItem struct {
+	// Name is the name without ref such as "actions/checkout", "./.github/actions/setup", or
+	// "docker://alpine".
+	Name string `json:"name"`
+	// Kind is the kind of the item. It is one of InventoryKind* constants.
+	Kind string `json:"kind"`
+	// Owner is the owner of the repository. It is empty for local actions, local reusable
+	// workflows, and Docker images.
+	Owner string `json:"owner,omitempty"`
+	// Repo is the name of the repository. It is empty for local actions, local reusable workflows,
+	// and Docker images.
+	Repo string `json:"repo,omitempty"`
+	// Ref is the ref after "@" such as "v4" or a commit SHA. For Docker images, it is the tag or
+	// the digest. It is empty for local actions and local reusable workflows.
+	Ref string `json:"ref,omitempty"`
+	// Pinned is true when the ref is a full commit SHA or the digest of the Docker image. Local
+	// actions and local reusable workflows are always pinned since they are versioned with the
+	// repository.
+	Pinned bool `json:"pinned"`
+	// Files is the files using the item in the order of the added files.
+	Files []string `json:"files"`
+	// Locations is the call sites of the item in the order of the added files.
+	Locations []*InventoryLocation `json:"locations"`
+}
+
+// Inventory is an inventory of actions, reusable workflows, and Docker images used in workflows
+// and local composite actions. Specs containing expressions are ignored since they cannot be
+// resolved statically.
+type Inventory struct {
+	items map[string]*InventoryItem
+}
+
+// NewInventory creates a new empty Inventory instance.
+func NewInventory() *Inventory {
+	return &Inventory{map[string]*InventoryItem{}}
+}
+
+// AddWorkflow parses the workflow source at the path and adds the actions, the reusable workflows,
+// and the Docker images used in it to the inventory.
+func (inv *Inventory) AddWorkflow(path string, src []byte) error {
+	w, errs := Parse(src)
+	if w == nil {
+		if len(errs) > 0 {
+			return fmt.Errorf("could not parse workflow %q: %w", path, errs[0])
+		}
+		return fmt.Errorf("could not parse workflow %q", path)
+	}
+
+	ids := make([]string, 0, len(w.Jobs))
+	for id := range w.Jobs {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+
+	for _, id := range ids {
+		j := w.Jobs[id]
+		if j.WorkflowCall != nil && j.WorkflowCall.Uses != nil {
+			inv.add(path, j.WorkflowCall.Uses.Value, j.WorkflowCall.Uses.Pos, true)
+		}
+		for _, s := range j.Steps {
+			if e, ok := s.Exec.(*ExecAction); ok && e.Uses != nil {
+				inv.add(path, e.Uses.Value, e.Uses.Pos, false)
+			}
+		}
+	}
+	return nil
+}
+
+// AddAction parses the action metadata file (action.yml) at the path and adds the actions and the
+// Docker images used in the steps of the composite action to the inventory. Actions which are not
+// composite actions have nothing to add.
+func (inv *Inventory) AddAction(path string, src []byte) error {
+	var n yaml.Node
+	if err := yaml.Unmarshal(src, &n); err != nil {
+		return fmt.Errorf("could not parse action metadata file %q: %w", path, err)
+	}
+	steps := yamlMappingValue(yamlMappingValue(&n, "runs"), "steps")
+	if steps == nil || steps.Kind != yaml.SequenceNode {
+		return nil
+	}
+	for _, s := range steps.Content {
+		if u := yamlMappingValue(s, "uses"); u != nil && u.Kind == yaml.ScalarNode {
+			inv.add(path, u.Value, &Pos{Line: u.Line, Col: u.Column}, false)
+		}
+	}
+	return nil
+}
+
+// yamlMappingValue returns the value of the key in the mapping node. The document node is
+// unwrapped. It returns nil when the node is not a mapping or the key is not found.
+func yamlMappingValue(n *yaml.Node, key string) *yaml.Node {
+	if n != nil && n.Kind == yaml.DocumentNode && len(n.Content) > 0 {
+		n = n.Content[0]
+	}
+	if n == nil || n.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		if n.Content[i].Value == key {
+			return n.Content[i+1]
+		}
+	}
+	return nil
+}
+
+func (inv *Inventory) add(path, spec string, pos *Pos, workflow bool) {
+	if spec == "" || strings.Contains(spec, "${{") {
+		return
+	}
+
+	item := &InventoryItem{}
+	switch {
+	case strings.HasPrefix(spec, "./"):
+		item.Name = spec
+		item.Kind = InventoryKindLocalAction
+		if workflow {
+			item.Kind = InventoryKindLocalWorkflow
+		}
+		item.Pinned = true
+	case strings.HasPrefix(spec, "docker://"):
+		name, ref := spec, ""
+		if i := strings.Index(spec, "@"); i >= 0 {
+			name, ref = spec[:i], spec[i+1:]
+			item.Pinned = strings.HasPrefix(ref, "sha256:")
+		} else if i := strings.LastIndex(spec, ":"); i > strings.LastIndex(spec, "/") {
+			name, ref = spec[:i], spec[i+1:]
+		}
+		item.Name = name
+		item.Ref = ref
+		item.Kind = InventoryKindDocker
+	default:
+		name, ref, ok := strings.Cut(spec, "@")
+		if !ok || name == "" || ref == "" {
+			return
+		}
+		item.Name = name
+		item.Ref = ref
+		item.Kind = InventoryKindAction
+		if workflow {
+			item.Kind = InventoryKindWorkflow
+		}
+		if owner, rest, ok := strings.Cut(name, "/"); ok {
+			item.Owner = owner
+			item.Repo, _, _ = strings.Cut(rest, "/")
+		}
+		item.Pinned = fullCommitSHAPattern.MatchString(ref)
+	}
+
+	// Names of actions and reusable workflows are case-insensitive since owners and repositories are.
+	key := strings.ToLower(item.Name) + "@" + item.Ref
+	if i, ok := inv.items[key]; ok {
+		item = i
+	} else {
+		item.Files = []string{}
+		item.Locations = []*InventoryLocation{}
+		inv.items[key] = item
+	}
+	if !slices.Contains(item.Files, path) {
+		item.Files = append(item.Files, path)
+	}
+	item.Locations = append(item.Locations, &InventoryLocation{path, pos.Line, pos.Col})
+}
+
+// Items returns the items in the inventory sorted by their names and refs.
+func (inv *Inventory) Items() []*InventoryItem {
+	items := make([]*InventoryItem, 0, len(inv.items))
+	for _, i := range inv.items {
+		items = append(items, i)
+	}
+	slices.SortFunc(items, func(a, b *InventoryItem) int {
+		if c := cmp.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name)); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Ref, b.Ref)
+	})
+	return items
+}
+
+// LocalActions returns the names of the local actions in the inventory like
+// "./.github/actions/setup" in sorted order.
+func (inv *Inventory) LocalActions() []string {
+	ret := []string{}
+	for _, i := range inv.items {
+		if i.Kind == InventoryKindLocalAction {
+			ret = append(ret, i.Name)
+		}
+	}
+	slices.Sort(ret)
+	return ret
+}
+
+// Write writes the items in the inventory to the writer in the format. When unpinned is true, only
+// unpinned items are written.
+func (inv *Inventory) Write(out io.Writer, f InventoryFormat, unpinned bool) error {
+	items := inv.Items()
+	if unpinned {
+		items = slices.DeleteFunc(items, func(i *InventoryItem) bool { return i.Pinned })
+	}
+
+	if f == InventoryFormatJSON {
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(items); err != nil {
+			return fmt.Errorf("could not encode inventory into JSON: %w", err)
+		}
+		return nil
+	}
+
+	w := tabwriter.NewWriter(out, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(w, "Name\tKind\tRef\tPinned\tUses\tFiles")
+	for _, i := range items {
+		ref := i.Ref
+		if ref == "" {
+			ref = "-"
+		}
+		pinned := "no"
+		if i.Pinned {
+			pinned = "yes"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%d\t%s\n", i.Name, i.Kind, ref, pinned, len(i.Locations), strings.Join(i.Files, ", "))
+	}
+	return w.Flush()
+}
@@ -0,0 +1,165 @@
+package actionlint
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestInventoryItems(t *testing.T) {
+	inv := NewInventory()
+	for _, n := range []string{"ci.yaml", "release.yaml", "nightly.yaml"} {
+		src, err := os.ReadFile(filepath.Join("testdata", "drift", n))
+		if err != nil {
+			panic(err)
+		}
+		if err := inv.AddWorkflow(".github/workflows/"+n, src); err != nil {
+			t.Fatal(err)
+		}
+	}
+	action := `name: Release
+description: Release
+runs:
+  using: composite
+  steps:
+    - uses: Actions/Checkout@v4
+    - uses: softprops/action-gh-release@c062e08bd532815e2082a85e87e3ef29c3e6d191
+    - uses: ${{ inputs.action }}
+    - run: echo done
+      shell: bash
+`
+	if err := inv.AddAction(".github/actions/release/action.yml", []byte(action)); err != nil {
+		t.Fatal(err)
+	}
+
+	have := []string{}
+	for _, i := range inv.Items() {
+		have = append(have, i.Kind+" "+i.Name+" "+i.Owner+" "+i.Repo+" "+i.Ref+" "+map[bool]string{true: "pinned", false: "unpinned"}[i.Pinned]+" "+strings.Join(i.Files, ","))
+	}
+	want := []string{
+		"local action ./.github/actions/release    pinned .github/workflows/release.yaml,.github/workflows/nightly.yaml",
+		"local reusable workflow ./.github/workflows/lint.yaml    pinned .github/workflows/nightly.yaml",
+		"action actions/checkout actions checkout v3 unpinned .github/workflows/release.yaml",
+		"action actions/checkout actions checkout v4 unpinned .github/workflows/ci.yaml,.github/workflows/nightly.yaml,.github/actions/release/action.yml",
+		"action actions/setup-go actions setup-go v5 unpinned .github/workflows/ci.yaml,.github/workflows/release.yaml",
+		"docker docker://alpine   3.20 unpinned .github/workflows/release.yaml",
+		"reusable workflow rhysd/workflows/.github/workflows/lint.yaml rhysd workflows main unpinned .github/workflows/release.yaml",
+		"reusable workflow rhysd/workflows/.github/workflows/lint.yaml rhysd workflows v1 unpinned .github/workflows/ci.yaml",
+		"action softprops/action-gh-release softprops action-gh-release c062e08bd532815e2082a85e87e3ef29c3e6d191 pinned .github/actions/release/action.yml",
+	}
+	if diff := cmp.Diff(want, have); diff != "" {
+		t.Fatal(diff)
+	}
+
+	if diff := cmp.Diff([]string{"./.github/actions/release"}, inv.LocalActions()); diff != "" {
+		t.Fatal(diff)
+	}
+}
+
+func TestInventoryDockerImages(t *testing.T) {
+	src := `on: push
+jobs:
+  a:
+    runs-on: ubuntu-latest
+    steps:
+      - uses: docker://alpine
+      - uses: docker://ghcr.io/owner/image:1.2
+      - uses: docker://localhost:5000/image@sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef
+`
+	inv := NewInventory()
+	if err := inv.AddWorkflow("test.yaml", []byte(src)); err != nil {
+		t.Fatal(err)
+	}
+	have := []string{}
+	for _, i := range inv.Items() {
+		have = append(have, i.Name+" "+i.Ref+" "+map[bool]string{true: "pinned", false: "unpinned"}[i.Pinned])
+	}
+	want := []string{
+		"docker://alpine  unpinned",
+		"docker://ghcr.io/owner/image 1.2 unpinned",
+		"docker://localhost:5000/image sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef pinned",
+	}
+	if diff := cmp.Diff(want, have); diff != "" {
+		t.Fatal(diff)
+	}
+}
+
+func TestInventoryWrite(t *testing.T) {
+	src := `on: push
+jobs:
+  a:
+    runs-on: ubuntu-latest
+    steps:
+      - uses: actions/checkout@v4
+      - uses: actions/setup-go@0aaccfd150d50ccaeb58ebd88d36e91967a5f35b
+      - uses: actions/checkout@v4
+`
+	inv := NewInventory()
+	if err := inv.AddWorkflow("test.yaml", []byte(src)); err != nil {
+		t.Fatal(err)
+	}
+
+	var b bytes.Buffer
+	if err := inv.Write(&b, InventoryFormatText, false); err != nil {
+		t.Fatal(err)
+	}
+	want := `Name              Kind    Ref                                       Pinned  Uses  Files
+actions/checkout  action  v4                                        no      2     test.yaml
+actions/setup-go  action  0aaccfd150d50ccaeb58ebd88d36e91967a5f35b  yes     1     test.yaml
+`
+	if diff := cmp.Diff(want, b.String()); diff != "" {
+		t.Fatal(diff)
+	}
+
+	b.Reset()
+	if err := inv.Write(&b, InventoryFormatJSON, true); err != nil {
+		t.Fatal(err)
+	}
+	var items []*InventoryItem
+	if err := json.Unmarshal(b.Bytes(), &items); err != nil {
+		t.Fatal(err, b.String())
+	}
+	if len(items) != 1 || items[0].Name != "actions/checkout" || len(items[0].Locations) != 2 {
+		t.Fatalf("unexpected unpinned items: %s", b.String())
+	}
+	if l := items[0].Locations[1]; l.Path != "test.yaml" || l.Line != 8 || l.Col != 15 {
+		t.Fatalf("unexpected location: %#v", l)
+	}
+}
+
+func TestInventoryParseFormat(t *testing.T) {
+	for _, tc := range []struct {
+		in   string
+		want InventoryFormat
+	}{
+		{"", InventoryFormatText},
+		{"text", InventoryFormatText},
+		{"JSON", InventoryFormatJSON},
+	} {
+		f, err := ParseInventoryFormat(tc.in)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if f != tc.want {
+			t.Errorf("wanted %q for %q but got %q", tc.want, tc.in, f)
+		}
+	}
+	if _, err := ParseInventoryFormat("csv"); err == nil || !strings.Contains(err.Error(), `"csv"`) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestInventoryParseError(t *testing.T) {
+	inv := NewInventory()
+	if err := inv.AddWorkflow("test.yaml", []byte("foo: [")); err == nil || !strings.Contains(err.Error(), `"test.yaml"`) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := inv.AddAction("action.yml", []byte("foo: [")); err == nil || !strings.Contains(err.Error(), `"action.yml"`) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,332 @@
+package actionlint
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// lintCacheFormatVersion is the version of the format of the cache files. Increment this value when
+// the format is changed so that old cache files are not used.
+const lintCacheFormatVersion = 1
+
+// lintCacheDep is a file which affects the results of checking a workflow file other than the
+// workflow file itself, such as local actions and called reusable workflows.
+type lintCacheDep struct {
+	Path string `json:"path"`
+	// Hash is the SHA-256 hash of the file content. It is empty when the file does not exist.
+	Hash string `json:"hash"`
+}
+
+type lintCacheRule struct {
+	Name string `json:"name"`
+	Desc string `json:"desc"`
+}
+
+type lintCacheEntry struct {
+	Key    string          `json:"key"`
+	Deps   []*lintCacheDep `json:"deps"`
+	Rules  []lintCacheRule `json:"rules"`
+	Errors []*Error        `json:"errors"`
+}
+
+// lintCache caches the errors of workflow files on disk so that unchanged files are not checked
+// again on subsequent runs. The cache files are put in .git/actionlint/lint directory of the
+// repository. One cache file is created for each workflow file path and overwritten when the file
+// is checked again.
+type lintCache struct {
+	dir string
+	key string
+	dbg io.Writer
+}
+
+// newLintCache creates a cache of the project. The key argument is the hash of the inputs which
+// affect the results of all files. It returns nil when the project is not a Git repository.
+func newLintCache(project *Project, key string, dbg io.Writer) *lintCache {
+	d := gitDirOf(project.RootDir())
+	if d == "" {
+		return nil
+	}
+	return &lintCache{filepath.Join(d, "actionlint", "lint"), key, dbg}
+}
+
+func (c *lintCache) debug(format string, args ...interface{}) {
+	if c.dbg == nil {
+		return
+	}
+	format = "[LintCache] " + format + "\n"
+	fmt.Fprintf(c.dbg, format, args...)
+}
+
+func (c *lintCache) filePath(path string) string {
+	h := sha256.Sum256([]byte(path))
+	return filepath.Join(c.dir, hex.EncodeToString(h[:])+".json")
+}
+
+func (c *lintCache) keyOf(path string, content []byte) string {
+	h := sha256.New()
+	writeHashFields(h, c.key, path)
+	h.Write(content)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// load returns the cached entry of the workflow file. It returns false when the entry does not exist
+// or it is outdated.
+func (c *lintCache) load(path string, content []byte) (*lintCacheEntry, bool) {
+	p := c.filePath(path)
+	b, err := os.ReadFile(p)
+	if err != nil {
+		return nil, false
+	}
+	var e lintCacheEntry
+	if err := json.Unmarshal(b, &e); err != nil {
+		c.debug("Broken cache file %s is ignored: %v", p, err)
+		return nil, false
+	}
+	if e.Key != c.keyOf(path, content) {
+		c.debug("Cache of %s is outdated since the file or the configuration was changed", path)
+		return nil, false
+	}
+	for _, d := range e.Deps {
+		if hashFileContent(d.Path) != d.Hash {
+			c.debug("Cache of %s is outdated since the dependency %s was changed", path, d.Path)
+			return nil, false
+		}
+	}
+	c.debug("Use cached %d errors for %s at %s", len(e.Errors), path, p)
+	return &e, true
+}
+
+// store stores the errors of the workflow file on disk. Failing to store is not an error since it
+// only slows down the next run.
+func (c *lintCache) store(path string, content []byte, deps []string, rules []Rule, errs []*Error) {
+	e := &lintCacheEntry{
+		Key:    c.keyOf(path, content),
+		Deps:   make([]*lintCacheDep, 0, len(deps)),
+		Rules:  make([]lintCacheRule, 0, len(rules)),
+		Errors: errs,
+	}
+	for _, d := range deps {
+		e.Deps = append(e.Deps, &lintCacheDep{d, hashFileContent(d)})
+	}
+	for _, r := range rules {
+		e.Rules = append(e.Rules, lintCacheRule{r.Name(), r.Description()})
+	}
+	b, err := json.Marshal(e)
+	if err != nil {
+		c.debug("Could not encode cache of %s: %v", path, err)
+		return
+	}
+	if err := os.MkdirAll(c.dir, 0755); err != nil {
+		c.debug("Could not create cache directory %s: %v", c.dir, err)
+		return
+	}
+	p := c.filePath(path)
+	// Write to a temporary file and rename it so that a broken cache file is not read by other
+	// actionlint processes running at the same time
+	tmp := fmt.Sprintf("%s.%d.tmp", p, os.Getpid())
+	if err := os.WriteFile(tmp, b, 0644); err != nil {
+		c.debug("Could not write cache file %s: %v", tmp, err)
+		return
+	}
+	if err := os.Rename(tmp, p); err != nil {
+		c.debug("Could not write cache file %s: %v", p, err)
+		os.Remove(tmp)
+	}
+}
+
+func writeHashFields(h hash.Hash, fields ...string) {
+	for _, f := range fields {
+		h.Write([]byte(f))
+		h.Write([]byte{0})
+	}
+}
+
+// hashFileContent returns the SHA-256 hash of the file content. When the path is a directory, the
+// hash is computed from the paths of YAML files in it to detect added or removed files. It returns
+// an empty string when the file cannot be read.
+func hashFileContent(path string) string {
+	if s, err := os.Stat(path); err == nil && s.IsDir() {
+		fs, err := findYAMLFiles(path, nil)
+		if err != nil {
+			return ""
+		}
+		h := sha256.New()
+		writeHashFields(h, fs...)
+		return hex.EncodeToString(h.Sum(nil))
+	}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+	h := sha256.Sum256(b)
+	return hex.EncodeToString(h[:])
+}
+
+// executableVersionKey returns the string to identify the build of actionlint. Data generated by
+// other builds must not be used since the embedded datasets and the behavior of the rules differ.
+func executableVersionKey() string {
+	v := getCommandVersion()
+	if v == "(devel)" || v == "unknown" {
+		// The version is not available while developing actionlint. Use the modified time of the
+		// executable instead.
+		if p, err := os.Executable(); err == nil {
+			if s, err := os.Stat(p); err == nil {
+				v = fmt.Sprintf("%s %s %s", v, p, s.ModTime())
+			}
+		}
+	}
+	return v
+}
+
+// lintCacheDepsOf returns the files which affect the results of checking the workflow other than the
+// workflow file itself. The paths are absolute. The platform is used to find the workflows directory.
+func lintCacheDepsOf(w *Workflow, project *Project, cfg *Config, repoConfig bool, platform Platform) []string {
+	root := project.RootDir()
+	deps := []string{}
+	if repoConfig {
+		deps = append(deps, filepath.Join(root, ".github", "actionlint.yaml"), filepath.Join(root, ".github", "actionlint.yml"))
+	}
+	if cfg != nil {
+		for _, p := range cfg.ActionMetadata {
+			if !filepath.IsAbs(p) {
+				p = filepath.Join(root, p)
+			}
+			deps = append(deps, p)
+		}
+	}
+	if w == nil {
+		return deps
+	}
+	if readsOtherWorkflows(w) {
+		// Names and events of all workflows are read by "events" rule. The directory itself is also
+		// a dependency to detect added or removed workflow files
+		d := project.workflowsDirOf(platform)
+		deps = append(deps, d)
+		if fs, err := findYAMLFiles(d, nil); err == nil {
+			deps = append(deps, fs...)
+		}
+	}
+	calls := []string{}
+	for _, j := range w.Jobs {
+		if j.WorkflowCall != nil && j.WorkflowCall.Uses != nil && strings.HasPrefix(j.WorkflowCall.Uses.Value, "./") {
+			calls = append(calls, j.WorkflowCall.Uses.Value)
+		}
+		for _, s := range j.Steps {
+			if e, ok := s.Exec.(*ExecAction); ok && e.Uses != nil && strings.HasPrefix(e.Uses.Value, "./") {
+				d := filepath.Join(root, filepath.FromSlash(e.Uses.Value))
+				deps = append(deps, filepath.Join(d, "action.yml"), filepath.Join(d, "action.yaml"))
+			}
+		}
+	}
+	// The nesting depth and the cycles of the calls are checked by following the call chain so all
+	// local reusable workflows called transitively are dependencies
+	seen := map[string]struct{}{}
+	for len(calls) > 0 {
+		c := calls[len(calls)-1]
+		calls = calls[:len(calls)-1]
+		if _, ok := seen[c]; ok {
+			continue
+		}
+		seen[c] = struct{}{}
+		p := filepath.Join(root, filepath.FromSlash(c))
+		deps = append(deps, p)
+		if src, err := os.ReadFile(p); err == nil {
+			calls = append(calls, parseLocalReusableWorkflowCalls(src)...)
+		}
+	}
+	return deps
+}
+
+// readsOtherWorkflows returns true when checking the workflow reads the names and the events of
+// other workflows in the workflows directory. The workflow names at "workflows:" of "workflow_run"
+// event are checked, and the workflow triggered by only one of "pull_request" and
+// "pull_request_target" events is compared with other workflows which have the same name.
+func readsOtherWorkflows(w *Workflow) bool {
+	pr, target := false, false
+	for _, e := range w.On {
+		h, ok := e.(*WebhookEvent)
+		if !ok {
+			continue
+		}
+		switch h.Hook.Value {
+		case "workflow_run":
+			if len(h.Workflows) > 0 {
+				return true
+			}
+		case "pull_request":
+			pr = true
+		case "pull_request_target":
+			target = true
+		}
+	}
+	return pr != target
+}
+
+// externalCommandVersion returns the output of the command with --version option to detect the
+// update of the command. It returns an empty string when the command cannot be run.
+func externalCommandVersion(cmdline string) string {
+	exe, args, err := resolveExternalCommand(cmdline)
+	if err != nil {
+		return ""
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	out, err := exec.CommandContext(ctx, exe, append(args, "--version")...).Output()
+	if err != nil {
+		return ""
+	}
+	return exe + "\n" + string(out)
+}
+
+// lintCacheOf returns the cache of the project to check the workflow content. It returns nil when
+// the cache is not available. Fixes are not cached and results of online checks and custom rules
+// depend on the things which cannot be tracked.
+func (l *Linter) lintCacheOf(project *Project, content []byte, w *Workflow) *lintCache {
+	if l.cacheKey == nil || project == nil || content == nil || w != nil || l.fix || l.online != nil {
+		return nil
+	}
+	if project.fsys != nil || project.overlay != nil {
+		return nil // The files are not on the OS filesystem
+	}
+	if len(l.rules) > 0 || len(registeredRules()) > 0 || l.onRulesCreated != nil {
+		return nil
+	}
+	return newLintCache(project, l.cacheKey(), l.debugWriter())
+}
+
+// lintCacheKey computes the hash of the inputs which affect the results of all workflow files such
+// as the version of actionlint, the options, and the versions of external linters including hadolint.
+func (l *Linter) lintCacheKey(configFile string) string {
+	h := sha256.New()
+	writeHashFields(h, fmt.Sprint(lintCacheFormatVersion), executableVersionKey(), l.act, l.zizmor, l.psAnalyzer, string(l.platform), fmt.Sprint(l.ghes))
+	writeHashFields(h, l.hadolint)
+	writeHashFields(h, externalCommandVersion(l.shellcheck), externalCommandVersion(l.pyflakes), externalCommandVersion(l.hadolint))
+	for _, p := range l.ignorePats {
+		writeHashFields(h, p.String())
+	}
+	writeHashFields(h, l.allowedEnv...)
+	if l.parseLimits != nil {
+		writeHashFields(h, fmt.Sprintf("%+v", *l.parseLimits))
+	}
+	// shellcheck reads its options from the environment variable
+	writeHashFields(h, os.Getenv("SHELLCHECK_OPTS"))
+	if configFile != "" {
+		writeHashFields(h, hashFileContent(configFile))
+	}
+	if l.zizmorFindings != nil {
+		if b, err := json.Marshal(l.zizmorFindings); err == nil {
+			h.Write(b)
+		}
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
@@ -0,0 +1,311 @@
+package actionlint
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func testWriteFiles(t *testing.T, root string, files map[string]string) {
+	t.Helper()
+	for p, c := range files {
+		p = filepath.Join(root, filepath.FromSlash(p))
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(c), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+const testLintCacheWorkflow = `on: push
+jobs:
+  test:
+    runs-on: ubuntu-latest
+    steps:
+      - uses: ./my-action
+        with:
+          foo: bar
+`
+
+func testLintCacheRepo(t *testing.T) string {
+	root := t.TempDir()
+	testWriteFiles(t, root, map[string]string{
+		".git/HEAD":                    "ref: refs/heads/main\n",
+		".github/workflows/test.yaml":  testLintCacheWorkflow,
+		"my-action/index.js":           "",
+		"my-action/action.yml":         "name: My action\ndescription: My action\ninputs:\n  foo:\n    description: foo\nruns:\n  using: node20\n  main: index.js\n",
+		".github/workflows/other.yaml": "on: push\njobs:\n  test:\n    runs-on: linux-latest\n    steps:\n      - run: echo\n",
+	})
+	return root
+}
+
+func testLintCacheRun(t *testing.T, root string, opts *LinterOptions) []*Error {
+	t.Helper()
+	opts.Shellcheck = ""
+	opts.Pyflakes = ""
+	opts.Cache = true
+	l, err := NewLinter(io.Discard, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	errs, err := l.LintRepository(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return errs
+}
+
+// testTamperLintCache rewrites messages of all cached errors to detect the cache is used.
+func testTamperLintCache(t *testing.T, root string) {
+	t.Helper()
+	dir := filepath.Join(root, ".git", "actionlint", "lint")
+	es, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Two workflow files and one action metadata file
+	if len(es) != 3 {
+		t.Fatalf("wanted 3 cache files but got %d", len(es))
+	}
+	for _, e := range es {
+		p := filepath.Join(dir, e.Name())
+		b, err := os.ReadFile(p)
+		if err != nil {
+			t.Fatal(err)
+		}
+		b = []byte(strings.ReplaceAll(string(b), `"Message":"`, `"Message":"cached: `))
+		if err := os.WriteFile(p, b, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func testLintCacheMessages(errs []*Error) []string {
+	ms := make([]string, 0, len(errs))
+	for _, e := range errs {
+		ms = append(ms, e.Error())
+	}
+	return ms
+}
+
+func TestLintCacheSkipUnchangedFiles(t *testing.T) {
+	root := testLintCacheRepo(t)
+
+	errs := testLintCacheRun(t, root, &LinterOptions{})
+	if len(errs) != 1 || !strings.Contains(errs[0].Message, `label "linux-latest" is unknown`) {
+		t.Fatalf("unexpected errors: %v", testLintCacheMessages(errs))
+	}
+
+	testTamperLintCache(t, root)
+	errs = testLintCacheRun(t, root, &LinterOptions{})
+	if len(errs) != 1 || !strings.HasPrefix(errs[0].Message, "cached: ") {
+		t.Fatalf("cached errors were not used: %v", testLintCacheMessages(errs))
+	}
+
+	// Only the changed file is checked again
+	testWriteFiles(t, root, map[string]string{
+		".github/workflows/test.yaml": strings.Replace(testLintCacheWorkflow, "foo: bar", "fooo: bar", 1),
+	})
+	errs = testLintCacheRun(t, root, &LinterOptions{})
+	if len(errs) != 2 {
+		t.Fatalf("wanted 2 errors but got %v", testLintCacheMessages(errs))
+	}
+	for _, e := range errs {
+		cached := strings.HasPrefix(e.Message, "cached: ")
+		if want := strings.HasSuffix(e.Filepath, "other.yaml"); cached != want {
+			t.Errorf("error was cached=%v unexpectedly: %v", cached, e)
+		}
+	}
+}
+
+func TestLintCacheInvalidatedByDependencies(t *testing.T) {
+	root := testLintCacheRepo(t)
+	testLintCacheRun(t, root, &LinterOptions{})
+
+	// The input of the local action is renamed so the workflow has an error
+	testTamperLintCache(t, root)
+	testWriteFiles(t, root, map[string]string{
+		"my-action/action.yml": "name: My action\ndescription: My action\ninputs:\n  bar:\n    description: bar\nruns:\n  using: node20\n  main: index.js\n",
+	})
+	errs := testLintCacheRun(t, root, &LinterOptions{})
+	found := false
+	for _, e := range errs {
+		if strings.Contains(e.Message, `input "foo" is not defined in action "My action"`) {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatalf("change of local action was not detected: %v", testLintCacheMessages(errs))
+	}
+
+	// The configuration file makes the runner label known
+	testTamperLintCache(t, root)
+	testWriteFiles(t, root, map[string]string{
+		".github/actionlint.yaml": "self-hosted-runner:\n  labels: [linux-latest]\n",
+	})
+	errs = testLintCacheRun(t, root, &LinterOptions{})
+	for _, e := range errs {
+		if strings.HasPrefix(e.Message, "cached: ") || strings.Contains(e.Message, "linux-latest") {
+			t.Fatalf("change of configuration file was not detected: %v", testLintCacheMessages(errs))
+		}
+	}
+}
+
+func TestLintCacheInvalidatedByOptions(t *testing.T) {
+	root := testLintCacheRepo(t)
+	testLintCacheRun(t, root, &LinterOptions{})
+	testTamperLintCache(t, root)
+
+	errs := testLintCacheRun(t, root, &LinterOptions{IgnorePatterns: []string{"linux-latest"}})
+	if len(errs) != 0 {
+		t.Fatalf("change of options was not detected: %v", testLintCacheMessages(errs))
+	}
+}
+
+func TestLintCacheInvalidatedByParseLimits(t *testing.T) {
+	root := testLintCacheRepo(t)
+	testLintCacheRun(t, root, &LinterOptions{ParseLimits: &ParseLimits{MaxInputSize: 1000}})
+	testTamperLintCache(t, root)
+
+	errs := testLintCacheRun(t, root, &LinterOptions{ParseLimits: &ParseLimits{MaxInputSize: 10}})
+	if len(errs) == 0 || errs[0].Kind != "resource-limit" {
+		t.Fatalf("change of parse limits was not detected: %v", testLintCacheMessages(errs))
+	}
+}
+
+func TestLintCacheDisabled(t *testing.T) {
+	root := testLintCacheRepo(t)
+	testLintCacheRun(t, root, &LinterOptions{})
+	testTamperLintCache(t, root)
+
+	for _, tc := range []struct {
+		what string
+		opts *LinterOptions
+	}{
+		{"fix", &LinterOptions{Fix: true, Diff: true}},
+		{"custom rules", &LinterOptions{OnRulesCreated: func(rs []Rule) []Rule { return rs }}},
+	} {
+		t.Run(tc.what, func(t *testing.T) {
+			errs := testLintCacheRun(t, root, tc.opts)
+			for _, e := range errs {
+				if strings.HasPrefix(e.Message, "cached: ") {
+					t.Fatalf("cache was used: %v", e)
+				}
+			}
+		})
+	}
+}
+
+func TestLintCacheInvalidatedByOtherWorkflows(t *testing.T) {
+	root := t.TempDir()
+	testWriteFiles(t, root, map[string]string{
+		".git/HEAD":                     "ref: refs/heads/main\n",
+		".github/workflows/build.yaml":  "name: Build\non: push\njobs:\n  test:\n    runs-on: ubuntu-latest\n    steps:\n      - run: echo\n",
+		".github/workflows/deploy.yaml": "on:\n  workflow_run:\n    workflows: [Build]\n    types: [completed]\njobs:\n  test:\n    runs-on: ubuntu-latest\n    steps:\n      - run: echo\n",
+	})
+	if errs := testLintCacheRun(t, root, &LinterOptions{}); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", testLintCacheMessages(errs))
+	}
+
+	// The workflow triggering the other workflow is renamed
+	testWriteFiles(t, root, map[string]string{
+		".github/workflows/build.yaml": "name: CI\non: push\njobs:\n  test:\n    runs-on: ubuntu-latest\n    steps:\n      - run: echo\n",
+	})
+	errs := testLintCacheRun(t, root, &LinterOptions{})
+	if len(errs) != 1 || !strings.Contains(errs[0].Message, `workflow "Build" at "workflows" of "workflow_run" event is not found`) {
+		t.Fatalf("rename of the workflow was not detected: %v", testLintCacheMessages(errs))
+	}
+
+	// The workflow with the name is added
+	testWriteFiles(t, root, map[string]string{
+		".github/workflows/build2.yaml": "name: Build\non: push\njobs:\n  test:\n    runs-on: ubuntu-latest\n    steps:\n      - run: echo\n",
+	})
+	if errs := testLintCacheRun(t, root, &LinterOptions{}); len(errs) != 0 {
+		t.Fatalf("new workflow was not detected: %v", testLintCacheMessages(errs))
+	}
+}
+
+func TestLintCacheInvalidatedByTransitiveCalls(t *testing.T) {
+	root := t.TempDir()
+	const callee = "on:\n  workflow_call:\njobs:\n  test:\n    runs-on: ubuntu-latest\n    steps:\n      - run: echo\n"
+	testWriteFiles(t, root, map[string]string{
+		".git/HEAD":                "ref: refs/heads/main\n",
+		".github/workflows/a.yaml": "on:\n  workflow_call:\njobs:\n  call:\n    uses: ./.github/workflows/b.yaml\n",
+		".github/workflows/b.yaml": "on:\n  workflow_call:\njobs:\n  call:\n    uses: ./.github/workflows/c.yaml\n",
+		".github/workflows/c.yaml": callee,
+	})
+	if errs := testLintCacheRun(t, root, &LinterOptions{}); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", testLintCacheMessages(errs))
+	}
+
+	// The workflow called indirectly from a.yaml calls a.yaml. a.yaml and its direct callee b.yaml
+	// are not changed
+	testWriteFiles(t, root, map[string]string{
+		".github/workflows/c.yaml": callee + "  call:\n    uses: ./.github/workflows/a.yaml\n",
+	})
+	errs := testLintCacheRun(t, root, &LinterOptions{})
+	found := false
+	for _, e := range errs {
+		if strings.HasSuffix(e.Filepath, "a.yaml") && strings.Contains(e.Message, "forms a cycle") {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatalf("change of the workflow called transitively was not detected: %v", testLintCacheMessages(errs))
+	}
+}
+
+func TestLintCacheInvalidatedByHadolint(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake hadolint command is a shell script")
+	}
+
+	// Fake hadolint command reports untagged image when the Dockerfile given via stdin uses "latest"
+	bin := t.TempDir()
+	lenient := filepath.Join(bin, "hadolint")
+	strict := filepath.Join(bin, "hadolint-strict")
+	issue := `[{"code":"DL3007","column":1,"file":"-","level":"warning","line":1,"message":"Using latest is prone to errors."}]`
+	for p, s := range map[string]string{
+		lenient: "#!/bin/sh\nif grep -q ':latest' -; then echo '" + issue + "'; else echo '[]'; fi\n",
+		strict:  "#!/bin/sh\ncat > /dev/null\necho '" + issue + "'\n",
+	} {
+		if err := os.WriteFile(p, []byte(s), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	root := t.TempDir()
+	testWriteFiles(t, root, map[string]string{
+		".git/HEAD":                   "ref: refs/heads/main\n",
+		".github/workflows/test.yaml": "on: push\njobs:\n  test:\n    runs-on: ubuntu-latest\n    steps:\n      - uses: ./docker-action\n",
+		"docker-action/action.yml":    "name: Docker\ndescription: Docker\nruns:\n  using: docker\n  image: Dockerfile\n",
+		"docker-action/Dockerfile":    "FROM alpine:3.8\n",
+	})
+	if errs := testLintCacheRun(t, root, &LinterOptions{Hadolint: lenient}); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", testLintCacheMessages(errs))
+	}
+
+	testWriteFiles(t, root, map[string]string{
+		"docker-action/Dockerfile": "FROM alpine:latest\n",
+	})
+	errs := testLintCacheRun(t, root, &LinterOptions{Hadolint: lenient})
+	if len(errs) != 1 || errs[0].Kind != "hadolint" {
+		t.Fatalf("change of Dockerfile was not detected: %v", testLintCacheMessages(errs))
+	}
+
+	testWriteFiles(t, root, map[string]string{
+		"docker-action/Dockerfile": "FROM alpine:3.8\n",
+	})
+	if errs := testLintCacheRun(t, root, &LinterOptions{Hadolint: lenient}); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", testLintCacheMessages(errs))
+	}
+	errs = testLintCacheRun(t, root, &LinterOptions{Hadolint: strict})
+	if len(errs) != 1 || errs[0].Kind != "hadolint" {
+		t.Fatalf("change of hadolint command was not detected: %v", testLintCacheMessages(errs))
+	}
+}
@@ -1,11 +1,14 @@
 package actionlint
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"io/fs"
+	"log/slog"
 	"os"
 	"path/filepath"
 	"regexp"
@@ -13,6 +16,7 @@ import (
 	"slices"
 	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/fatih/color"
@@ -58,6 +62,10 @@ type LinterOptions struct {
 	// LogWriter is io.Writer object to use to print log outputs. Note that error outputs detected
 	// by the linter are not included in the log outputs.
 	LogWriter io.Writer
+	// LogFormat is a format of log outputs enabled by Verbose or Debug. It is one of LogFormatText
+	// ("text") and LogFormatJSON ("json"). Each log record is output in one line with its level,
+	// message, and attributes such as the file path and the rule name. Empty value means "text".
+	LogFormat string
 	// Color is option for colorizing error outputs. See ColorOptionKind document for each enum values.
 	Color ColorOptionKind
 	// Oneline is flag if one line output is enabled. When enabling it, one error is output per one
@@ -71,15 +79,51 @@ type LinterOptions struct {
 	// or file path like "/path/to/pyflakes", "path/to/pyflakes". When this value is empty, pyflakes
 	// won't run to check scripts in workflow file.
 	Pyflakes string
+	// PSScriptAnalyzer is executable of PowerShell for running PSScriptAnalyzer module to check
+	// PowerShell scripts in workflow file. It can be command name like "pwsh" or "powershell" or file
+	// path like "/path/to/pwsh". The PSScriptAnalyzer module must be installed. When this value is
+	// empty, PSScriptAnalyzer won't run. This is empty by default.
+	PSScriptAnalyzer string
+	// Hadolint is executable for running hadolint external command to check Dockerfile of Docker
+	// actions referenced from action metadata files. It can be command name like "hadolint" or file
+	// path like "/path/to/hadolint". When this value is empty, hadolint won't run. This is empty by
+	// default.
+	Hadolint string
+	// Act is executable for running nektos/act external command to run workflows in dry-run mode. It
+	// can be command name like "act" or file path like "/path/to/act". When this value is empty, act
+	// won't run. Unlike shellcheck and pyflakes, this is empty by default.
+	Act string
+	// Zizmor is executable for running zizmor external command to find security issues. It can be
+	// command name like "zizmor" or file path like "/path/to/zizmor". When this value is empty, zizmor
+	// won't run. This is empty by default.
+	Zizmor string
+	// ZizmorResults is a file path to the JSON output of "zizmor --format json". Findings in the file
+	// are merged into errors of actionlint. This value is ignored when Zizmor is not empty.
+	ZizmorResults string
 	// IgnorePatterns is list of regular expression to filter errors. The pattern is applied to error
 	// messages. When an error is matched, the error is ignored.
 	IgnorePatterns []string
 	// ConfigFile is a path to config file. Empty string means no config file path is given. In
 	// the case, actionlint will try to read config from .github/actionlint.yaml.
 	ConfigFile string
+	// TrustConfig is a flag to trust the config files. Config files are usually put in the linted
+	// repositories so they may be controlled by others. Plugins, containers, and remote machines to
+	// run external commands and credentials of Docker registries in config files are used only when
+	// this flag is true since they run arbitrary commands or send scripts and secrets to other hosts.
+	TrustConfig bool
+	// DataFile is the data file loaded by LoadDataFile. The config file and the action metadata files
+	// compiled in it are used without reading and parsing them again. When ConfigFile is set to other
+	// file than the config file compiled in the data file, ConfigFile has higher priority. Note that the datasets in the data file are not applied by the
+	// linter. Call DataFile.Apply method to apply them.
+	DataFile *DataFile
 	// Format is a custom template to format error messages. It must follow Go Template format and
 	// contain at least one {{ }} placeholder. https://pkg.go.dev/text/template
 	Format string
+	// Locale is a locale of error messages like "ja" or a file path to the JSON file of message
+	// catalog. See MessageCatalogForLocale for the details. Rule names and codes of errors are not
+	// translated. IgnorePatterns and "ignore" in config file are matched to the messages in English.
+	// Empty value means English.
+	Locale string
 	// StdinFileName is a file name when reading input from stdin. When this value is empty, "<stdin>"
 	// is used as the default value.
 	StdinFileName string
@@ -91,24 +135,226 @@ type LinterOptions struct {
 	// function should return the modified rules.
 	// Note that syntax errors may be reported even if this function returns nil or an empty slice.
 	OnRulesCreated func([]Rule) []Rule
+	// Online is flag to enable online checks. Online checks fetch the information of the GitHub
+	// repository such as registered self-hosted runners via GitHub REST API and validate workflows
+	// with it.
+	Online bool
+	// GitHubToken is an API token to call GitHub REST API in online checks.
+	GitHubToken string
+	// GitHubAPIURL is a base URL of GitHub REST API used in online checks. When this value is empty,
+	// "https://api.github.com" is used.
+	GitHubAPIURL string
+	// CacheDir is a directory to cache the data fetched over network in online checks such as
+	// responses of GitHub REST API and existence of Docker images. When this value is empty, the
+	// responses of GitHub REST API are cached in .git/actionlint/cache directory of the repository
+	// and the existence of Docker images is cached only in memory.
+	CacheDir string
+	// CacheTTL is the duration while the data cached on disk in online checks is used without
+	// fetching it again. Expired data is still used when fetching it failed due to network error or
+	// rate limit. Zero means 10 minutes.
+	CacheTTL time.Duration
+	// GitHubRepository is a GitHub repository checked in online checks in "owner/repo" format. When
+	// this value is empty, the repository is detected from the URL of "origin" remote of the project.
+	GitHubRepository string
+	// GHESURL is a URL of GitHub Enterprise Server instance like "https://github.example.com" which
+	// hosts the repository. When this value is not empty, online checks call REST API of the instance
+	// instead of GitHubAPIURL and differences of the API from GitHub.com are respected. When
+	// GHESVersion is empty, the version of the instance is fetched via the API.
+	GHESURL string
+	// GHESToken is an API token to call REST API of GitHub Enterprise Server at GHESURL. When this
+	// value is empty, GitHubToken is used.
+	GHESToken string
+	// OnlineRetries is the maximum number of retries of a request in online checks which failed due to
+	// network error, server error, or rate limit. The retries are done with exponential backoff. When
+	// a host is still unreachable after the retries, further requests to the host are skipped and the
+	// checks fall back to the cached data. Zero means no retry.
+	OnlineRetries int
+	// OnlineInterval is the minimum interval between requests sent in online checks. It is useful to
+	// avoid hitting the secondary rate limit of GitHub REST API. Zero means no limit.
+	OnlineInterval time.Duration
+	// GHESVersion is a version of GitHub Enterprise Server like "3.12". When this value is not empty,
+	// features not available in the version are reported. This value has higher priority than "ghes"
+	// in the configuration file.
+	GHESVersion string
+	// Platform is a platform which runs the workflows. One of "github", "gitea", or "forgejo". When
+	// this value is "gitea" or "forgejo", checks are adjusted to Gitea Actions or Forgejo Actions. This
+	// value has higher priority than "platform" in the configuration file.
+	Platform string
+	// Fix is flag to fix errors automatically. The fixes are applied to the workflow files in place.
+	// For example, deprecated workflow commands are rewritten with environment files and refs of
+	// third-party actions at "uses:" are pinned to commit SHAs in online checks. Fixes are not
+	// applied to the content given to Linter.Lint or Linter.LintStdin.
+	Fix bool
+	// Diff is flag to preview the fixes without modifying the workflow files. When this value is true
+	// with Fix, unified diff of the fixes is output instead of the errors. Unlike Fix, the diff is
+	// also output for the content given to Linter.Lint or Linter.LintStdin.
+	Diff bool
+	// OnFileStarted is a hook called when checking a workflow file starts. The path parameter is the
+	// file path of the workflow. Note that this function is called in parallel from multiple
+	// goroutines when linting multiple files.
+	OnFileStarted func(path string)
+	// OnFileFinished is a hook called when checking a workflow file finished. The errs parameter is
+	// the errors found in the file and the elapsed parameter is the time taken to check the file.
+	// This function is not called when checking the file failed with a fatal error. Note that this
+	// function is called in parallel from multiple goroutines when linting multiple files.
+	OnFileFinished func(path string, errs []*Error, elapsed time.Duration)
+	// OnFileParsed is a hook called when parsing a workflow file finished. The errs parameter is the
+	// number of syntax errors and the elapsed parameter is the time taken to parse the file. This
+	// function is not called when the result is found in the cache. Note that this function is called
+	// in parallel from multiple goroutines when linting multiple files.
+	OnFileParsed func(path string, errs int, elapsed time.Duration)
+	// OnRuleFinished is a hook called when a rule finished checking a workflow file. The rule
+	// parameter is the name of the rule, the errs parameter is the number of errors reported by the
+	// rule, and the elapsed parameter is the time taken by the rule including the time to wait for
+	// external commands. Note that this function is called in parallel from multiple goroutines when
+	// linting multiple files.
+	OnRuleFinished func(path, rule string, errs int, elapsed time.Duration)
+	// OnExternalCommandStarted is a hook called when an external command such as shellcheck starts.
+	// The exe parameter is the resolved executable path and the args parameter is the arguments of
+	// the command. Note that this function is called in parallel from multiple goroutines.
+	OnExternalCommandStarted func(exe string, args []string)
+	// OnExternalCommandFinished is a hook called when an external command such as shellcheck
+	// finished. The elapsed parameter is the time taken by the command. Time waiting for other
+	// processes to finish is not included. Note that this function is called in parallel from
+	// multiple goroutines.
+	OnExternalCommandFinished func(exe string, args []string, elapsed time.Duration)
+	// OnToolDiagnostic is a hook called when an external command such as shellcheck outputs something
+	// to stderr. It is called even if the command succeeded so that misconfiguration of the command
+	// can be diagnosed. The output is also printed in debug log. Note that this function is called in
+	// parallel from multiple goroutines.
+	OnToolDiagnostic func(d *ToolDiagnostic)
+	// CommandTimeout is the maximum duration of each external command execution such as shellcheck,
+	// pyflakes, and plugins. When a command does not finish within the duration, it is killed and
+	// linting fails with an error. Zero means no timeout.
+	CommandTimeout time.Duration
+	// CommandRetries is the maximum number of retries of an external command which failed to start
+	// due to a temporary shortage of OS resources such as "resource temporarily unavailable" or
+	// "text file busy". The retries are done with exponential backoff. Failures of the command itself
+	// are not retried. Zero means no retry.
+	CommandRetries int
+	// MaxProcesses is the maximum number of external command processes running at the same time.
+	// Zero means the number of CPUs.
+	MaxProcesses int
+	// Jobs is the maximum number of workflow files checked at the same time. Zero means the number of
+	// CPUs.
+	Jobs int
+	// RuleJobs is the number of goroutines to run rules in parallel while checking one workflow file.
+	// The rules are divided into groups and each group traverses the syntax tree concurrently. This
+	// reduces the latency of checking a very large workflow file. Custom rules must not share their
+	// states with other rules when this value is larger than 1. Zero or 1 means rules run serially.
+	RuleJobs int
+	// ProcessNice is the niceness of external command processes from -20 (highest priority) to 19
+	// (lowest priority). Zero means the niceness is not changed. This is not available on Windows.
+	// Like ProcessMemoryLimit, it is applied by running the process via "sh" and it is best-effort.
+	ProcessNice int
+	// ProcessMemoryLimit is the maximum size of virtual memory of each external command process in
+	// bytes. Zero means no limit. This is only available on Linux.
+	ProcessMemoryLimit uint64
+	// AllowEnv is the names of environment variables passed to external commands in addition to the
+	// default ones such as PATH, HOME, and locale variables. Other environment variables are not
+	// passed not to leak secrets on CI to the commands. A name ending with '*' matches the prefix
+	// like "LC_*". "*" passes all environment variables.
+	AllowEnv []string
+	// Cache enables caching errors of each workflow file in .git/actionlint/lint directory of the
+	// repository. Files are not checked again while the file content, the configuration, local
+	// actions and reusable workflows used by the file, the version of actionlint, and the versions of
+	// shellcheck and pyflakes are not changed. The cache is not used when fixing errors, when online
+	// checks are enabled, or when custom rules are added.
+	Cache bool
+	// Metrics collects runtime metrics such as latencies of checking workflow files, the number of
+	// external commands waiting for running, and hit rate of the lint cache. When this value is nil,
+	// no metric is collected.
+	Metrics *Metrics
+	// ParseLimits is the limits of resources used for parsing each workflow file such as the input
+	// size and the nesting depth of YAML values. When a limit is exceeded, the file is not checked and
+	// an error of "resource-limit" kind is reported. This is useful to check untrusted workflow files
+	// in services. When this value is nil, no limit is applied.
+	ParseLimits *ParseLimits
 	// More options will come here
 }
 
+// ToolDiagnostic is a diagnostic output from an external command such as shellcheck or pyflakes.
+type ToolDiagnostic struct {
+	// Executable is the resolved executable path of the command.
+	Executable string
+	// Args is the arguments of the command.
+	Args []string
+	// Stderr is the output of the command to stderr. When the command is a persistent plugin, this
+	// is a single line of the output.
+	Stderr string
+}
+
 // Linter is struct to lint workflow files.
 type Linter struct {
 	projects       *Projects
 	out            io.Writer
 	logOut         io.Writer
 	logLevel       LogLevel
+	logger         *slog.Logger
 	oneline        bool
 	shellcheck     string
 	pyflakes       string
+	act            string
+	zizmor         string
+	zizmorFindings []*zizmorFinding
 	ignorePats     IgnorePatterns
 	stdin          string
 	defaultConfig  *Config
 	errFmt         *ErrorFormatter
 	cwd            string
 	onRulesCreated func([]Rule) []Rule
+	online         *onlineOptions
+	ghes           *GHESVersion
+	platform       Platform
+	fix            bool
+	diff           bool
+	fixable        bool
+	rules          []func() Rule
+	hooks          linterHooks
+	actionMetadata *actionMetadataFiles
+	cmdTimeout     time.Duration
+	maxProcs       int
+	procLimits     *processLimits
+	cmdRetries     int
+	allowedEnv     []string
+	psAnalyzer     string
+	wslShellcheck  func() *wslExecutor
+	cacheKey       func() string
+	jobs           int
+	ruleJobs       int
+	metrics        *Metrics
+	parseLimits    *ParseLimits
+	hadolint       string
+	catalog        *MessageCatalog
+	trustConfig    bool
+	untrusted      sync.Once
+}
+
+// linterHooks is a set of the lifecycle hooks given via LinterOptions.
+type linterHooks struct {
+	fileStarted             func(path string)
+	fileFinished            func(path string, errs []*Error, elapsed time.Duration)
+	fileParsed              func(path string, errs int, elapsed time.Duration)
+	ruleFinished            func(path, rule string, errs int, elapsed time.Duration)
+	externalCommandStarted  func(exe string, args []string)
+	toolDiagnostic          func(d *ToolDiagnostic)
+	externalCommandFinished func(exe string, args []string, elapsed time.Duration)
+}
+
+type onlineOptions struct {
+	apiURL   string
+	token    string
+	repo     string
+	cacheDir string
+	cacheTTL time.Duration
+	mu       sync.Mutex
+	remote   map[string]*RemoteRepository
+	registry *dockerRegistryClient
+	// enterprise is true when apiURL is REST API of GitHub Enterprise Server.
+	enterprise bool
+	// transport is shared by all clients of online checks to limit the rate of requests and to skip
+	// requests to unavailable hosts.
+	transport *onlineTransport
 }
 
 // NewLinter creates a new Linter instance.
@@ -141,8 +387,12 @@ func NewLinter(out io.Writer, opts *LinterOptions) (*Linter, error) {
 	}
 
 	var cfg *Config
-	if opts.ConfigFile != "" {
-		c, err := ReadConfigFile(opts.ConfigFile)
+	cfgFile := opts.ConfigFile
+	if f := opts.DataFile; f != nil && f.index.ConfigFile != "" && (cfgFile == "" || absPath(cfgFile) == f.index.ConfigFile) {
+		cfg = f.config
+		cfgFile = f.index.ConfigFile
+	} else if cfgFile != "" {
+		c, err := ReadConfigFile(cfgFile)
 		if err != nil {
 			return nil, err
 		}
@@ -179,47 +429,264 @@ func NewLinter(out io.Writer, opts *LinterOptions) (*Linter, error) {
 		stdin = opts.StdinFileName
 	}
 
+	logger, err := newLogger(lout, level, opts.LogFormat)
+	if err != nil {
+		return nil, err
+	}
+
+	catalog, err := MessageCatalogForLocale(opts.Locale)
+	if err != nil {
+		return nil, err
+	}
+
 	l := &Linter{
-		NewProjects(),
-		out,
-		lout,
-		level,
-		opts.Oneline,
-		opts.Shellcheck,
-		opts.Pyflakes,
-		ignore,
-		stdin,
-		cfg,
-		formatter,
-		cwd,
-		opts.OnRulesCreated,
-	}
-
-	l.debug("Create a Linter instance with option %#v", opts)
+		projects:       NewProjects(),
+		out:            out,
+		logOut:         lout,
+		logLevel:       level,
+		logger:         logger,
+		oneline:        opts.Oneline,
+		shellcheck:     opts.Shellcheck,
+		pyflakes:       opts.Pyflakes,
+		act:            opts.Act,
+		zizmor:         opts.Zizmor,
+		ignorePats:     ignore,
+		stdin:          stdin,
+		defaultConfig:  cfg,
+		errFmt:         formatter,
+		cwd:            cwd,
+		onRulesCreated: opts.OnRulesCreated,
+		fix:            opts.Fix,
+		diff:           opts.Fix && opts.Diff,
+		hooks: linterHooks{
+			fileStarted:             opts.OnFileStarted,
+			fileFinished:            opts.OnFileFinished,
+			fileParsed:              opts.OnFileParsed,
+			ruleFinished:            opts.OnRuleFinished,
+			externalCommandStarted:  opts.OnExternalCommandStarted,
+			toolDiagnostic:          opts.OnToolDiagnostic,
+			externalCommandFinished: opts.OnExternalCommandFinished,
+		},
+		actionMetadata: &actionMetadataFiles{},
+		cmdTimeout:     opts.CommandTimeout,
+		maxProcs:       opts.MaxProcesses,
+		cmdRetries:     opts.CommandRetries,
+		allowedEnv:     append(slices.Clip(defaultAllowedEnv), opts.AllowEnv...),
+		psAnalyzer:     opts.PSScriptAnalyzer,
+		jobs:           opts.Jobs,
+		ruleJobs:       opts.RuleJobs,
+		metrics:        opts.Metrics,
+		parseLimits:    opts.ParseLimits,
+		hadolint:       opts.Hadolint,
+		catalog:        catalog,
+		trustConfig:    opts.TrustConfig,
+	}
+	l.wslShellcheck = sync.OnceValue(l.findShellcheckInWSL)
+	if opts.Cache {
+		l.cacheKey = sync.OnceValue(func() string { return l.lintCacheKey(cfgFile) })
+	}
+	if opts.DataFile != nil {
+		opts.DataFile.preload(l.projects, l.actionMetadata)
+	}
+
+	for _, n := range opts.AllowEnv {
+		if n == "" || strings.ContainsRune(n, '=') {
+			return nil, fmt.Errorf("invalid name of environment variable to pass to external commands: %q", n)
+		}
+	}
+
+	if opts.CommandRetries < 0 {
+		return nil, fmt.Errorf("number of retries of external commands must not be negative but got %d", opts.CommandRetries)
+	}
+	if opts.MaxProcesses < 0 {
+		return nil, fmt.Errorf("max number of processes must not be negative but got %d", opts.MaxProcesses)
+	}
+	if opts.Jobs < 0 {
+		return nil, fmt.Errorf("number of files checked in parallel must not be negative but got %d", opts.Jobs)
+	}
+	if opts.RuleJobs < 0 {
+		return nil, fmt.Errorf("number of rules run in parallel must not be negative but got %d", opts.RuleJobs)
+	}
+	if pl := opts.ParseLimits; pl != nil && (pl.MaxInputSize < 0 || pl.MaxNestingDepth < 0 || pl.MaxAliasExpansion < 0 || pl.MaxExpressionLength < 0) {
+		return nil, fmt.Errorf("limits of parsing workflow files must not be negative but got %+v", *pl)
+	}
+	pl, err := newProcessLimits(opts.ProcessNice, opts.ProcessMemoryLimit)
+	if err != nil {
+		return nil, err
+	}
+	l.procLimits = pl
+
+	if opts.Zizmor == "" && opts.ZizmorResults != "" {
+		fs, err := readZizmorFindings(opts.ZizmorResults)
+		if err != nil {
+			return nil, err
+		}
+		l.zizmorFindings = fs
+	}
+
+	if opts.Platform != "" {
+		p, err := ParsePlatform(opts.Platform)
+		if err != nil {
+			return nil, err
+		}
+		l.platform = p
+	}
+
+	if opts.GHESVersion != "" {
+		if l.platform.giteaCompatible() {
+			return nil, fmt.Errorf("version of GitHub Enterprise Server cannot be specified with %s platform", l.platform.displayName())
+		}
+		v, err := ParseGHESVersion(opts.GHESVersion)
+		if err != nil {
+			return nil, err
+		}
+		l.ghes = v
+	}
+
+	if opts.CacheTTL < 0 {
+		return nil, fmt.Errorf("TTL of cache of online checks must not be negative but got %s", opts.CacheTTL)
+	}
+	if opts.OnlineRetries < 0 {
+		return nil, fmt.Errorf("number of retries of requests in online checks must not be negative but got %d", opts.OnlineRetries)
+	}
+	if opts.OnlineInterval < 0 {
+		return nil, fmt.Errorf("interval of requests in online checks must not be negative but got %s", opts.OnlineInterval)
+	}
+	if opts.Online {
+		if opts.GitHubRepository != "" {
+			if o, r, ok := strings.Cut(opts.GitHubRepository, "/"); !ok || o == "" || r == "" || strings.Contains(r, "/") {
+				return nil, fmt.Errorf("GitHub repository %q must be in \"owner/repo\" format", opts.GitHubRepository)
+			}
+		}
+		apiURL, token, enterprise := opts.GitHubAPIURL, opts.GitHubToken, false
+		if opts.GHESURL != "" {
+			if l.platform.giteaCompatible() {
+				return nil, fmt.Errorf("URL of GitHub Enterprise Server cannot be specified with %s platform", l.platform.displayName())
+			}
+			u, err := ghesAPIURL(opts.GHESURL)
+			if err != nil {
+				return nil, err
+			}
+			apiURL, enterprise = u, true
+			if opts.GHESToken != "" {
+				token = opts.GHESToken
+			}
+		}
+		t := newOnlineTransport(opts.OnlineRetries, opts.OnlineInterval, l.debugWriter())
+		registry := newDockerRegistryClient(newRemoteCache(opts.CacheDir, opts.CacheTTL, l.debugWriter()), l.logOut, l.debugWriter())
+		registry.client = t.client()
+		l.online = &onlineOptions{
+			apiURL:     apiURL,
+			token:      token,
+			repo:       opts.GitHubRepository,
+			cacheDir:   opts.CacheDir,
+			cacheTTL:   opts.CacheTTL,
+			remote:     map[string]*RemoteRepository{},
+			registry:   registry,
+			enterprise: enterprise,
+			transport:  t,
+		}
+	}
+
+	l.debug("Created a Linter instance", "options", fmt.Sprintf("%#v", opts))
 	return l, nil
 }
 
-func (l *Linter) log(args ...interface{}) {
-	if l.logLevel < LogLevelVerbose {
+// log outputs the message with the attributes as verbose log. The attributes are key-value pairs
+// like slog.Logger.Info.
+func (l *Linter) log(msg string, attrs ...any) {
+	l.logger.Info(msg, attrs...)
+}
+
+// warnUntrustedConfig warns that the settings which run commands are ignored since the config file
+// is not trusted. The warning is output only once.
+func (l *Linter) warnUntrustedConfig(cfg *Config) {
+	ks := untrustedConfigKeys(cfg)
+	if len(ks) == 0 {
 		return
 	}
-	fmt.Fprint(l.logOut, "verbose: ")
-	fmt.Fprintln(l.logOut, args...)
+	l.untrusted.Do(func() {
+		fmt.Fprintf(l.logOut, "warning: %s in config file are ignored since the config file is not trusted. use -trust-config flag only when you trust the repository\n", quotes(ks))
+	})
 }
 
-func (l *Linter) debug(format string, args ...interface{}) {
-	if l.logLevel < LogLevelDebug {
-		return
+// configExecutor returns the executor configured in the config file to run the external command in
+// a container or on a remote machine. The executors are ignored unless the config file is trusted
+// since the config file can choose the container image to run or the host to send scripts.
+func (l *Linter) configExecutor(cfg *Config, name string) (commandExecutor, string, bool) {
+	if !l.trustConfig {
+		return nil, "", false
 	}
-	format = "[Linter] " + format + "\n"
-	fmt.Fprintf(l.logOut, format, args...)
+	return cfg.executor(name)
+}
+
+// debug outputs the message with the attributes as debug log. The attributes are key-value pairs
+// like slog.Logger.Debug.
+func (l *Linter) debug(msg string, attrs ...any) {
+	l.logger.Debug(msg, attrs...)
 }
 
+// remoteRepository returns the GitHub repository for online checks of the project. It returns nil
+// when online checks are disabled or the repository is unknown. This method is thread-safe.
+func (l *Linter) remoteRepository(project *Project) *RemoteRepository {
+	o := l.online
+	if o == nil {
+		return nil
+	}
+
+	owner, name, ok := strings.Cut(o.repo, "/")
+	key := o.repo
+	if !ok {
+		if project == nil {
+			return nil
+		}
+		key = project.RootDir()
+	}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if r, ok := o.remote[key]; ok {
+		return r
+	}
+
+	var r *RemoteRepository
+	if !ok {
+		owner, name, ok = gitHubRepositoryOf(project.RootDir())
+	}
+	if ok {
+		l.log("Online checks are enabled", "repository", owner+"/"+name, "api", o.apiURL)
+		dir := o.cacheDir
+		if dir == "" && project != nil {
+			dir = gitHubAPICacheDirOf(project.RootDir())
+		}
+		cache := newRemoteCache(dir, o.cacheTTL, l.debugWriter())
+		r = newRemoteRepository(owner, name, o.apiURL, o.token, cache, l.logOut, l.debugWriter())
+		r.api.client = o.transport.client()
+		r.enterprise = o.enterprise
+	} else {
+		l.log("Online checks are disabled since GitHub repository could not be detected from \"origin\" remote", "project", key)
+	}
+	o.remote[key] = r
+	return r
+}
+
+// debugWriter returns the writer for debug logs of other components such as caches and rules. Each
+// line written to it is output as a structured log record. It returns nil when debug log is disabled.
 func (l *Linter) debugWriter() io.Writer {
 	if l.logLevel < LogLevelDebug {
 		return nil
 	}
-	return l.logOut
+	return &logWriter{l.logger}
+}
+
+// AddRule adds the constructor of a custom rule to the linter. The custom rule is run alongside the
+// built-in rules. The constructor is called to create a new rule instance on checking each workflow
+// file. The rule can implement `Fixes() []*TextEdit` method (RuleBase implements it) to fix issues
+// with LinterOptions.Fix. This method must be called before starting to lint files. To add a custom
+// rule to all Linter instances, use RegisterRule instead.
+func (l *Linter) AddRule(newRule func() Rule) {
+	l.rules = append(l.rules, newRule)
 }
 
 // GenerateDefaultConfig generates default config file at ".github/actionlint.yaml" in the project
@@ -230,7 +697,7 @@ func (l *Linter) GenerateDefaultConfig(dir string) error {
 		dir = l.cwd
 	}
 
-	l.log("Generating default actionlint.yaml in repository:", dir)
+	l.log("Generating default actionlint.yaml", "repository", dir)
 
 	proj, err := l.projects.At(dir)
 	if err != nil {
@@ -259,14 +726,20 @@ func (l *Linter) GenerateDefaultConfig(dir string) error {
 
 // LintRepository lints YAML workflow files and outputs the errors to given writer. It finds the
 // nearest `.github/workflows` directory based on `dir` and applies lint rules to all YAML workflow
-// files under the directory. When the directory path is empty, the current working directory will
-// be used instead.
+// files under the directory. Workflow templates in `.github/workflow-templates` directory and action
+// metadata files such as "action.yml" in the repository are also checked. When the directory path is empty, the current working directory will be used instead.
 func (l *Linter) LintRepository(dir string) ([]*Error, error) {
+	return l.LintRepositoryContext(context.Background(), dir)
+}
+
+// LintRepositoryContext is the same as LintRepository but accepts a context. When the context is
+// cancelled, linting stops and the error of the context is returned.
+func (l *Linter) LintRepositoryContext(ctx context.Context, dir string) ([]*Error, error) {
 	if dir == "" {
 		dir = l.cwd
 	}
 
-	l.log("Linting all workflow files in repository:", dir)
+	l.log("Linting all workflow files in repository", "dir", dir)
 
 	p, err := l.projects.At(dir)
 	if err != nil {
@@ -276,77 +749,323 @@ func (l *Linter) LintRepository(dir string) ([]*Error, error) {
 		return nil, fmt.Errorf("no project was found in any parent directories of %q. check workflows directory is put correctly in your Git repository", dir)
 	}
 
-	l.log("Detected project:", p.RootDir())
-	wd := p.WorkflowsDir()
-	return l.LintDir(wd, p)
+	l.log("Detected project", "root", p.RootDir(), "workflows", p.WorkflowsDir())
+	return l.lintProject(ctx, p)
+}
+
+// LintFS lints YAML workflow files in the given file system instead of the OS filesystem. The root
+// parameter is a slash-separated path to the root directory of the repository in the file system
+// such as ".". It applies lint rules to all YAML workflow files under the workflows directory such
+// as ".github/workflows" in the root directory and to all action metadata files such as "action.yml"
+// in the file system. File paths in the errors are virtual paths in the file system. Since files in the file system cannot be modified, fixes are not applied.
+func (l *Linter) LintFS(fsys fs.FS, root string) ([]*Error, error) {
+	return l.LintFSContext(context.Background(), fsys, root)
+}
+
+// LintFSContext is the same as LintFS but accepts a context. When the context is cancelled, linting
+// stops and the error of the context is returned.
+func (l *Linter) LintFSContext(ctx context.Context, fsys fs.FS, root string) ([]*Error, error) {
+	l.log("Linting all workflow files in file system", "root", root)
+
+	p, err := NewProjectFS(fsys, root)
+	if err != nil {
+		return nil, err
+	}
+	return l.lintProject(ctx, p)
+}
+
+// lintProject lints all YAML workflow files in the workflows directory and the workflow templates
+// directory of the project and all action metadata files such as "action.yml" in the project.
+func (l *Linter) lintProject(ctx context.Context, p *Project) ([]*Error, error) {
+	cfg := l.defaultConfig
+	if cfg == nil {
+		cfg = p.Config()
+	}
+	files := []string{}
+	wd := p.workflowsDirOf(l.platformOf(cfg))
+	td := p.WorkflowTemplatesDir()
+	if p.isDir(td) {
+		ts, err := findYAMLFiles(td, p)
+		if err != nil {
+			return nil, err
+		}
+		files = ts
+	}
+	// ".github" repository of organization may only have workflow templates
+	if len(files) == 0 || p.isDir(wd) {
+		ws, err := findYAMLFiles(wd, p)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, ws...)
+	}
+	actions, err := findActionMetadataFiles(p)
+	if err != nil {
+		return nil, err
+	}
+	l.log("Collected workflow files", "workflows", len(files), "actions", len(actions))
+	files = append(files, actions...)
+	sort.Strings(files)
+	return l.LintFilesContext(ctx, files, p)
 }
 
 // LintDir lints all YAML workflow files in the given directory recursively.
 func (l *Linter) LintDir(dir string, project *Project) ([]*Error, error) {
+	return l.LintDirContext(context.Background(), dir, project)
+}
+
+// LintDirContext is the same as LintDir but accepts a context. When the context is cancelled,
+// linting stops and the error of the context is returned.
+func (l *Linter) LintDirContext(ctx context.Context, dir string, project *Project) ([]*Error, error) {
+	files, err := findYAMLFiles(dir, project)
+	if err != nil {
+		return nil, err
+	}
+	l.log("Collected workflow files", "workflows", len(files))
+	return l.LintFilesContext(ctx, files, project)
+}
+
+// findYAMLFiles finds all YAML files in the given directory recursively. When the project reads
+// files from fs.FS, the directory is searched in the file system. When no YAML file is found, it
+// returns an error.
+func findYAMLFiles(dir string, project *Project) ([]string, error) {
 	files := []string{}
-	if err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+	walk := func(path string, d fs.DirEntry, err error) error {
 		if err != nil {
 			return err
 		}
-		if info.IsDir() {
+		if d.IsDir() {
 			return nil
 		}
 		if strings.HasSuffix(path, ".yml") || strings.HasSuffix(path, ".yaml") {
 			files = append(files, path)
 		}
 		return nil
-	}); err != nil {
+	}
+	var err error
+	if project != nil && project.FS() != nil {
+		err = fs.WalkDir(project.FS(), filepath.ToSlash(dir), walk)
+	} else {
+		err = filepath.WalkDir(dir, walk)
+	}
+	if err != nil {
 		return nil, fmt.Errorf("could not read files in %q: %w", dir, err)
 	}
 
 	if len(files) == 0 {
 		return nil, fmt.Errorf("no YAML file was found in %q", dir)
 	}
-	l.log("Collected", len(files), "YAML files")
 
 	// To make output deterministic, sort order of file paths
 	sort.Strings(files)
 
-	return l.LintFiles(files, project)
+	return files, nil
+}
+
+// findActionMetadataFiles finds all action metadata files "action.yml" and "action.yaml" in the
+// project. Hidden directories except for ".github", ".gitea", and ".forgejo" are not searched. And
+// "node_modules", "testdata", and "vendor" directories are also not searched since they usually
+// contain files which are not maintained in the repository.
+func findActionMetadataFiles(project *Project) ([]string, error) {
+	root := project.RootDir()
+	files := []string{}
+	walk := func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if path == root {
+				return nil
+			}
+			switch n := d.Name(); n {
+			case ".github", ".gitea", ".forgejo":
+				return nil
+			case "node_modules", "testdata", "vendor":
+				return fs.SkipDir
+			default:
+				if strings.HasPrefix(n, ".") {
+					return fs.SkipDir
+				}
+				return nil
+			}
+		}
+		if isActionMetadataFile(path) {
+			files = append(files, path)
+		}
+		return nil
+	}
+	var err error
+	if project.FS() != nil {
+		err = fs.WalkDir(project.FS(), filepath.ToSlash(root), walk)
+	} else {
+		err = filepath.WalkDir(root, walk)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not find action metadata files in %q: %w", root, err)
+	}
+	return files, nil
 }
 
 // LintFiles lints YAML workflow files and outputs the errors to given writer. It applies lint
 // rules to all given files. The project parameter can be nil. In the case, a project is detected
 // from the file path.
 func (l *Linter) LintFiles(filepaths []string, project *Project) ([]*Error, error) {
-	n := len(filepaths)
-	switch n {
+	return l.LintFilesContext(context.Background(), filepaths, project)
+}
+
+// LintFilesContext is the same as LintFiles but accepts a context. When the context is cancelled,
+// files which are not checked yet are skipped, running external commands are killed, and the error
+// of the context is returned.
+func (l *Linter) LintFilesContext(ctx context.Context, filepaths []string, project *Project) ([]*Error, error) {
+	switch len(filepaths) {
 	case 0:
 		return []*Error{}, nil
 	case 1:
-		return l.LintFile(filepaths[0], project)
+		return l.LintFileContext(ctx, filepaths[0], project)
+	}
+
+	ws := make([]lintWorkspace, 0, len(filepaths))
+	for _, p := range filepaths {
+		ws = append(ws, lintWorkspace{path: p})
 	}
+	return l.lintFiles(ctx, ws, project, false)
+}
 
-	l.log("Linting", n, "files")
+// lintWorkspace is a state of linting one file in lintFiles.
+type lintWorkspace struct {
+	path string
+	errs []*Error
+	src  []byte
+	diff string
+}
+
+// lintOutputQueue prints the results of files in the order of the files as soon as the file and all
+// the files before it are checked. Users don't need to wait for all files being checked to see the
+// results and the output is still deterministic even if the files are checked in parallel.
+type lintOutputQueue struct {
+	mu    sync.Mutex
+	done  []bool
+	next  int
+	print func(i int) error
+}
+
+func newLintOutputQueue(n int, print func(i int) error) *lintOutputQueue {
+	return &lintOutputQueue{done: make([]bool, n), print: print}
+}
+
+// finish marks the i-th file as checked and prints the results which are ready to be printed.
+func (q *lintOutputQueue) finish(i int) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.done[i] = true
+	for q.next < len(q.done) && q.done[q.next] {
+		if err := q.print(q.next); err != nil {
+			return err
+		}
+		q.next++
+	}
+	return nil
+}
+
+func (l *Linter) newConcurrentProcess(ctx context.Context) *concurrentProcess {
+	par := l.maxProcs
+	if par == 0 {
+		par = runtime.NumCPU()
+	}
+	proc := newConcurrentProcess(ctx, par)
+	proc.onStart = l.hooks.externalCommandStarted
+	proc.onFinish = l.hooks.externalCommandFinished
+	proc.onStderr = l.externalCommandStderr
+	proc.timeout = l.cmdTimeout
+	proc.retries = l.cmdRetries
+	proc.limits = l.procLimits
+	proc.allowedEnv = l.allowedEnv
+	proc.metrics = l.metrics
+	if l.logLevel >= LogLevelDebug {
+		proc.logger = l.logger
+	}
+	return proc
+}
+
+// findShellcheckInWSL finds shellcheck in WSL on Windows. Bash scripts are checked by the shellcheck
+// in WSL when shellcheck is not installed on Windows. It returns nil when shellcheck is not available.
+func (l *Linter) findShellcheckInWSL() *wslExecutor {
+	if runtime.GOOS != "windows" {
+		return nil
+	}
+	e, err := findCommandInWSL(context.Background(), "wsl", "shellcheck")
+	if err != nil {
+		l.debug("shellcheck is not available in WSL", "error", err)
+		return nil
+	}
+	l.log("shellcheck in WSL is used since shellcheck was not found on Windows", "exe", "shellcheck")
+	return e
+}
+
+func (l *Linter) externalCommandStderr(exe string, args []string, stderr []byte) {
+	l.debug("External command output to stderr", "exe", exe, "args", args, "stderr", string(bytes.TrimRight(stderr, "\n")))
+	if l.hooks.toolDiagnostic != nil {
+		l.hooks.toolDiagnostic(&ToolDiagnostic{exe, args, string(stderr)})
+	}
+}
+
+// lintFiles lints the multiple files in parallel. When overlay is false, the sources are read from
+// the files. When overlay is true, the sources of the workspaces are given instead of reading the
+// files and they take precedence over the files on reading other files in the same project such as
+// called reusable workflows.
+func (l *Linter) lintFiles(ctx context.Context, ws []lintWorkspace, project *Project, overlay bool) ([]*Error, error) {
+	n := len(ws)
+	l.log("Linting files", "files", n)
 
 	cwd := l.cwd
 	cpus := runtime.NumCPU()
-	proc := newConcurrentProcess(cpus)
+	proc := l.newConcurrentProcess(ctx)
 	sema := semaphore.NewWeighted(int64(cpus))
-	ctx := context.Background()
 	dbg := l.debugWriter()
 	acf := NewLocalActionsCacheFactory(dbg)
 	rwcf := NewLocalReusableWorkflowCacheFactory(cwd, dbg)
 
-	type workspace struct {
-		path string
-		errs []*Error
-		src  []byte
+	var files map[string][]byte
+	var overlaid map[*Project]*Project
+	if overlay {
+		files = make(map[string][]byte, len(ws))
+		for i := range ws {
+			files[ws[i].path] = ws[i].src
+		}
+		overlaid = map[*Project]*Project{}
 	}
 
-	ws := make([]workspace, 0, len(filepaths))
-	for _, p := range filepaths {
-		ws = append(ws, workspace{path: p})
-	}
+	// Errors are printed as soon as they are ready unless the format template needs all errors at once
+	// such as '{{json .}}'.
+	stream := l.errFmt == nil || l.errFmt.streamable()
+	out := newLintOutputQueue(n, func(i int) error {
+		w := &ws[i]
+		switch {
+		case l.diff:
+			l.printDiff(w.diff)
+			w.diff = ""
+		case !stream:
+			return nil
+		case l.errFmt != nil:
+			if err := l.errFmt.PrintErrors(l.out, w.errs, w.src); err != nil {
+				return err
+			}
+		default:
+			l.printErrors(w.errs, w.src)
+		}
+		w.src = nil
+		return nil
+	})
 
+	jobs := l.jobs
+	if jobs == 0 {
+		jobs = cpus
+	}
 	eg := errgroup.Group{}
+	eg.SetLimit(jobs)
 	for i := range ws {
-		// Each element of ws is accessed by single goroutine so mutex is unnecessary
+		// Each element of ws is accessed by single goroutine until the results are printed so mutex is
+		// unnecessary
 		w := &ws[i]
 		proj := project
 		if proj == nil {
@@ -358,34 +1077,70 @@ func (l *Linter) LintFiles(filepaths []string, project *Project) ([]*Error, erro
 			}
 			proj = p
 		}
+		if overlay && proj != nil {
+			o, ok := overlaid[proj]
+			if !ok {
+				o = proj.withOverlay(files)
+				overlaid[proj] = o
+			}
+			proj = o
+		}
 		ac := acf.GetCache(proj) // #173
 		rwc := rwcf.GetCache(proj)
 
 		eg.Go(func() error {
 			// Bound concurrency on reading files to avoid "too many files to open" error (issue #3)
-			sema.Acquire(ctx, 1)
-			src, err := os.ReadFile(w.path)
-			sema.Release(1)
-			if err != nil {
-				return fmt.Errorf("could not read %q: %w", w.path, err)
+			src := w.src
+			if !overlay {
+				if err := sema.Acquire(ctx, 1); err != nil {
+					return err
+				}
+				b, err := proj.readFile(w.path)
+				sema.Release(1)
+				if err != nil {
+					return fmt.Errorf("could not read %q: %w", w.path, err)
+				}
+				src = b
 			}
 
-			if cwd != "" {
+			p := w.path
+			if cwd != "" && (proj == nil || proj.FS() == nil) {
 				if r, err := filepath.Rel(cwd, w.path); err == nil {
 					w.path = r // Use relative path if possible
 				}
 			}
-			errs, err := l.check(w.path, src, proj, proc, ac, rwc)
+			errs, fixes, err := l.check(ctx, w.path, src, nil, proj, proc, ac, rwc)
 			if err != nil {
 				return fmt.Errorf("fatal error while checking %s: %w", w.path, err)
 			}
-			w.src = src
+			if l.diff {
+				d, err := fixesDiff(w.path, src, fixes)
+				if err != nil {
+					return err
+				}
+				w.diff = d
+			} else if overlay {
+				if len(fixes) > 0 {
+					l.log("Fixes were not applied since the content is not read from file", "path", w.path)
+				}
+			} else if err := l.applyFixes(p, src, fixes, proj); err != nil {
+				return err
+			}
+			// Keep the source only when it is necessary to print the errors. Sources of all files are
+			// otherwise kept until all files are checked, which is a large part of memory usage on
+			// checking many files.
+			if len(errs) > 0 && (l.errFmt != nil || !l.oneline) {
+				w.src = src
+			} else {
+				w.src = nil
+			}
 			w.errs = errs
-			return nil
+			return out.finish(i)
 		})
 	}
 
 	if err := eg.Wait(); err != nil {
+		proc.wait()
 		return nil, err
 	}
 
@@ -403,27 +1158,23 @@ func (l *Linter) LintFiles(filepaths []string, project *Project) ([]*Error, erro
 	}
 
 	all := make([]*Error, 0, total)
-	if l.errFmt != nil {
+	if !stream && !l.diff {
 		temp := make([]*ErrorTemplateFields, 0, total)
 		for i := range ws {
 			w := &ws[i]
 			for _, err := range w.errs {
 				temp = append(temp, err.GetTemplateFields(w.src))
 			}
-			all = append(all, w.errs...)
 		}
 		if err := l.errFmt.Print(l.out, temp); err != nil {
 			return nil, err
 		}
-	} else {
-		for i := range ws {
-			w := &ws[i]
-			l.printErrors(w.errs, w.src)
-			all = append(all, w.errs...)
-		}
+	}
+	for i := range ws {
+		all = append(all, ws[i].errs...)
 	}
 
-	l.log("Found", total, "errors in", n, "files")
+	l.log("Found errors in files", "errors", total, "files", n)
 
 	return all, nil
 }
@@ -431,6 +1182,12 @@ func (l *Linter) LintFiles(filepaths []string, project *Project) ([]*Error, erro
 // LintFile lints one YAML workflow file and outputs the errors to given writer. The project
 // parameter can be nil. In the case, the project is detected from the given path.
 func (l *Linter) LintFile(path string, project *Project) ([]*Error, error) {
+	return l.LintFileContext(context.Background(), path, project)
+}
+
+// LintFileContext is the same as LintFile but accepts a context. When the context is cancelled,
+// linting stops and the error of the context is returned.
+func (l *Linter) LintFileContext(ctx context.Context, path string, project *Project) ([]*Error, error) {
 	if project == nil {
 		p, err := l.projects.At(path)
 		if err != nil {
@@ -439,26 +1196,38 @@ func (l *Linter) LintFile(path string, project *Project) ([]*Error, error) {
 		project = p
 	}
 
-	src, err := os.ReadFile(path)
+	src, err := project.readFile(path)
 	if err != nil {
 		return nil, fmt.Errorf("could not read %q: %w", path, err)
 	}
 
-	if l.cwd != "" {
+	p := path
+	if l.cwd != "" && (project == nil || project.FS() == nil) {
 		if r, err := filepath.Rel(l.cwd, path); err == nil {
 			path = r
 		}
 	}
 
-	proc := newConcurrentProcess(runtime.NumCPU())
+	proc := l.newConcurrentProcess(ctx)
 	dbg := l.debugWriter()
 	localActions := NewLocalActionsCache(project, dbg)
 	localReusableWorkflows := NewLocalReusableWorkflowCache(project, l.cwd, dbg)
-	errs, err := l.check(path, src, project, proc, localActions, localReusableWorkflows)
+	errs, fixes, err := l.check(ctx, path, src, nil, project, proc, localActions, localReusableWorkflows)
 	proc.wait()
 	if err != nil {
 		return nil, err
 	}
+	if l.diff {
+		d, err := fixesDiff(path, src, fixes)
+		if err != nil {
+			return nil, err
+		}
+		l.printDiff(d)
+		return errs, nil
+	}
+	if err := l.applyFixes(p, src, fixes, project); err != nil {
+		return nil, err
+	}
 
 	if l.errFmt != nil {
 		l.errFmt.PrintErrors(l.out, errs, src)
@@ -472,18 +1241,101 @@ func (l *Linter) LintFile(path string, project *Project) ([]*Error, error) {
 // which is usually os.Stdin. The file name is determined by LinterOptions.StdinFileName. When the
 // option is empty, "<stdin>" is the default value.
 func (l *Linter) LintStdin(stdin io.Reader) ([]*Error, error) {
+	return l.LintStdinContext(context.Background(), stdin)
+}
+
+// LintStdinContext is the same as LintStdin but accepts a context. When the context is cancelled,
+// linting stops and the error of the context is returned.
+func (l *Linter) LintStdinContext(ctx context.Context, stdin io.Reader) ([]*Error, error) {
 	l.log("Reading the input from stdin")
 	b, err := io.ReadAll(stdin)
 	if err != nil {
 		return nil, fmt.Errorf("could not read stdin: %w", err)
 	}
-	return l.Lint(l.stdin, b, nil)
+	return l.LintContext(ctx, l.stdin, b, nil)
+}
+
+// StdinBatchFile is one file in the batch input read by LintStdinBatch.
+type StdinBatchFile struct {
+	// Path is the file path of the content. It is used for finding the project and for the file
+	// path of the errors.
+	Path string `json:"path"`
+	// Content is the content of the file. It may be different from the content of the file on the
+	// filesystem such as an unsaved buffer of an editor.
+	Content string `json:"content"`
+}
+
+// LintStdinBatch lints multiple files read from STDIN at once. The input is a JSON array of objects
+// which have "path" and "content" properties like `[{"path": "a.yaml", "content": "on: push ..."}]`.
+// Each content is checked as the file at the path. The contents take precedence over the files on
+// the filesystem on reading other files in the same project such as called reusable workflows and
+// local actions. Fixes are not applied to the files since the contents are not read from them.
+func (l *Linter) LintStdinBatch(stdin io.Reader) ([]*Error, error) {
+	return l.LintStdinBatchContext(context.Background(), stdin)
+}
+
+// LintStdinBatchContext is the same as LintStdinBatch but accepts a context. When the context is
+// cancelled, files which are not checked yet are skipped and the error of the context is returned.
+func (l *Linter) LintStdinBatchContext(ctx context.Context, stdin io.Reader) ([]*Error, error) {
+	l.log("Reading the batch input from stdin")
+	var files []*StdinBatchFile
+	if err := json.NewDecoder(stdin).Decode(&files); err != nil {
+		return nil, fmt.Errorf("could not parse batch input from stdin as JSON array of {\"path\": ..., \"content\": ...} objects: %w", err)
+	}
+	if len(files) == 0 {
+		return []*Error{}, nil
+	}
+
+	ws := make([]lintWorkspace, 0, len(files))
+	seen := make(map[string]struct{}, len(files))
+	for i, f := range files {
+		if f == nil || f.Path == "" {
+			return nil, fmt.Errorf("\"path\" is missing in file #%d of batch input", i+1)
+		}
+		if _, ok := seen[f.Path]; ok {
+			return nil, fmt.Errorf("file %q is duplicated in batch input", f.Path)
+		}
+		seen[f.Path] = struct{}{}
+		ws = append(ws, lintWorkspace{path: f.Path, src: []byte(f.Content)})
+	}
+	return l.lintFiles(ctx, ws, nil, true)
 }
 
 // Lint lints YAML workflow file content given as byte slice. The path parameter is used as file
 // path where the content came from.
 // When nil is passed to the project parameter, it tries to find the project from the path parameter.
 func (l *Linter) Lint(path string, content []byte, project *Project) ([]*Error, error) {
+	return l.LintContext(context.Background(), path, content, project)
+}
+
+// LintContext is the same as Lint but accepts a context. When the context is cancelled, linting
+// stops and the error of the context is returned.
+func (l *Linter) LintContext(ctx context.Context, path string, content []byte, project *Project) ([]*Error, error) {
+	return l.lintSource(ctx, path, content, nil, project)
+}
+
+// LintWorkflowAST lints the workflow syntax tree which was already parsed. This is useful for tools
+// which already have the syntax tree of the workflow such as generators, migrators, or editors
+// keeping syntax trees in memory. The syntax tree can be obtained with Parse function. The path
+// parameter is used as file path of the workflow. The project is detected from the path.
+// Since the source of the workflow is not available, rules which need the source such as
+// "yaml-style" are not run, fixes are not applied, and code snippets are not printed in the output.
+func (l *Linter) LintWorkflowAST(path string, w *Workflow) ([]*Error, error) {
+	return l.LintWorkflowASTContext(context.Background(), path, w)
+}
+
+// LintWorkflowASTContext is the same as LintWorkflowAST but accepts a context. When the context is
+// cancelled, linting stops and the error of the context is returned.
+func (l *Linter) LintWorkflowASTContext(ctx context.Context, path string, w *Workflow) ([]*Error, error) {
+	if w == nil {
+		return nil, fmt.Errorf("workflow syntax tree for %q is nil", path)
+	}
+	return l.lintSource(ctx, path, nil, w, nil)
+}
+
+// lintSource lints the workflow content or the workflow syntax tree. When the syntax tree is
+// non-nil, the content is nil and the syntax tree is checked instead of parsing the content.
+func (l *Linter) lintSource(ctx context.Context, path string, content []byte, w *Workflow, project *Project) ([]*Error, error) {
 	if project == nil && path != "<stdin>" {
 		if _, err := os.Stat(path); !errors.Is(err, fs.ErrNotExist) {
 			p, err := l.projects.At(path)
@@ -493,15 +1345,30 @@ func (l *Linter) Lint(path string, content []byte, project *Project) ([]*Error,
 			project = p
 		}
 	}
-	proc := newConcurrentProcess(runtime.NumCPU())
+	proc := l.newConcurrentProcess(ctx)
 	dbg := l.debugWriter()
 	localActions := NewLocalActionsCache(project, dbg)
 	localReusableWorkflows := NewLocalReusableWorkflowCache(project, l.cwd, dbg)
-	errs, err := l.check(path, content, project, proc, localActions, localReusableWorkflows)
+	errs, fixes, err := l.check(ctx, path, content, w, project, proc, localActions, localReusableWorkflows)
 	proc.wait()
 	if err != nil {
 		return nil, err
 	}
+	if w != nil && len(fixes) > 0 {
+		l.log("Fixes were not applied since the source of the workflow is not available", "path", path)
+		fixes = nil
+	}
+	if l.diff {
+		d, err := fixesDiff(path, content, fixes)
+		if err != nil {
+			return nil, err
+		}
+		l.printDiff(d)
+		return errs, nil
+	}
+	if len(fixes) > 0 {
+		l.log("Fixes were not applied since the content is not read from file", "path", path)
+	}
 	if l.errFmt != nil {
 		l.errFmt.PrintErrors(l.out, errs, content)
 	} else {
@@ -510,97 +1377,464 @@ func (l *Linter) Lint(path string, content []byte, project *Project) ([]*Error,
 	return errs, nil
 }
 
+// applyFixes applies the edits to the source and overwrites the file at the path with the result.
+// It does nothing when fixing errors is disabled or there is no edit.
+func (l *Linter) applyFixes(path string, src []byte, fixes []*TextEdit, project *Project) error {
+	if !l.fix || len(fixes) == 0 {
+		return nil
+	}
+	if project != nil && project.FS() != nil {
+		l.log("Fixes were not applied since the content is not read from OS filesystem", "path", path)
+		return nil
+	}
+	w := NewWorkflowWriter(src)
+	w.Edit(fixes...)
+	fixed, err := w.Bytes()
+	if err != nil {
+		return fmt.Errorf("could not fix %q: %w", path, err)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return fmt.Errorf("could not fix %q: %w", path, err)
+	}
+	if err := os.WriteFile(path, fixed, info.Mode()); err != nil {
+		return fmt.Errorf("could not fix %q: %w", path, err)
+	}
+	l.log("Applied fixes", "path", path, "fixes", len(fixes))
+	return nil
+}
+
+// fixesDiff returns unified diff of the changes made by applying the edits to the source. It returns
+// an empty string when there is no change.
+func fixesDiff(path string, src []byte, fixes []*TextEdit) (string, error) {
+	if len(fixes) == 0 {
+		return "", nil
+	}
+	w := NewWorkflowWriter(src)
+	w.Edit(fixes...)
+	d, err := w.Diff(filepath.ToSlash(path))
+	if err != nil {
+		return "", fmt.Errorf("could not fix %q: %w", path, err)
+	}
+	return d, nil
+}
+
+// printDiff prints the diff of fixes to the output and remembers some fix is available.
+func (l *Linter) printDiff(diff string) {
+	if diff == "" {
+		return
+	}
+	l.fixable = true
+	io.WriteString(l.out, diff)
+}
+
+// platformOf returns the platform which runs the workflows. "-platform" flag has higher priority than
+// the configuration.
+func (l *Linter) platformOf(cfg *Config) Platform {
+	if l.platform != "" {
+		return l.platform
+	}
+	if cfg != nil && cfg.Platform != "" {
+		return cfg.Platform
+	}
+	return PlatformGitHub
+}
+
 func (l *Linter) check(
+	ctx context.Context,
 	path string,
 	content []byte,
+	w *Workflow, // When non-nil, this syntax tree is checked instead of parsing the content
 	project *Project,
 	proc *concurrentProcess,
 	localActions *LocalActionsCache,
 	localReusableWorkflows *LocalReusableWorkflowCache,
-) ([]*Error, error) {
+) ([]*Error, []*TextEdit, error) {
 	// Note: This method is called to check multiple files in parallel.
 	// It must be thread safe assuming fields of Linter are not modified while running.
 
 	var start time.Time
-	if l.logLevel >= LogLevelVerbose {
+	if l.logLevel >= LogLevelVerbose || l.hooks.fileFinished != nil || l.metrics != nil {
 		start = time.Now()
 	}
+	if l.hooks.fileStarted != nil {
+		l.hooks.fileStarted(path)
+	}
 
-	l.log("Linting", path)
+	l.log("Linting file", "path", path)
 	if project != nil {
-		l.log("Using project at", project.RootDir())
+		l.log("Using project", "path", path, "root", project.RootDir())
 	}
 
 	var cfg *Config
+	source := "none"
 	if l.defaultConfig != nil {
 		// `-config-file` option has higher priority than repository config file
 		cfg = l.defaultConfig
+		source = "config-file option"
 	} else if project != nil {
 		cfg = project.Config()
+		if cfg != nil {
+			source = "project"
+		}
 	}
 	if cfg != nil {
-		l.debug("Config: %#v", cfg)
+		l.debug("Resolved config", "path", path, "source", source, "path_configs", len(cfg.PathConfigs(path)), "config", fmt.Sprintf("%#v", cfg))
 	} else {
-		l.debug("No config was found")
+		l.debug("No config was found", "path", path)
+	}
+
+	cache := l.lintCacheOf(project, content, w)
+	if cache != nil {
+		e, ok := cache.load(path, content)
+		l.metrics.cacheLookup(ok)
+		if ok {
+			if l.errFmt != nil {
+				for _, r := range e.Rules {
+					b := NewRuleBase(r.Name, r.Desc)
+					l.errFmt.RegisterRule(&b)
+				}
+			}
+			l.log("Found errors in cache", "path", path, "errors", len(e.Errors))
+			l.catalog.translateErrors(e.Errors)
+			l.metrics.fileFinished(time.Since(start))
+			if l.hooks.fileFinished != nil {
+				l.hooks.fileFinished(path, e.Errors, time.Since(start))
+			}
+			return e.Errors, nil, nil
+		}
 	}
 
-	w, all := Parse(content)
+	actionMetadata, err := l.actionMetadata.load(cfg, project)
+	if err != nil {
+		return nil, nil, err
+	}
 
-	if l.logLevel >= LogLevelVerbose {
-		elapsed := time.Since(start)
-		l.log("Found", len(all), "parse errors in", elapsed.Milliseconds(), "ms for", path)
+	if err := ctx.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	var all []*Error
+	var fixes []*TextEdit
+	var rules []Rule
+	var composite *compositeAction
+	var meta []Rule
+	if w == nil && isActionMetadataFile(path) {
+		// Action metadata files are checked by the dedicated rule. Steps of composite action are
+		// checked by the workflow rules as the steps of a job
+		var err error
+		composite, all, meta, err = l.checkActionMetadata(path, content, project, proc)
+		if err != nil {
+			return nil, nil, err
+		}
+		rules = meta
+		if composite != nil {
+			w = composite.workflow
+		}
+	} else if w == nil {
+		var parseStart time.Time
+		if l.hooks.fileParsed != nil {
+			parseStart = time.Now()
+		}
+		w, all = ParseWithLimits(content, l.parseLimits)
+		if l.hooks.fileParsed != nil {
+			l.hooks.fileParsed(path, len(all), time.Since(parseStart))
+		}
+		if l.logLevel >= LogLevelVerbose {
+			elapsed := time.Since(start)
+			l.log("Parsed file", "path", path, "errors", len(all), "elapsed", elapsed)
+		}
+		if w == nil {
+			l.registerResourceLimit(all)
+		}
 	}
 
 	if w != nil {
 		dbg := l.debugWriter()
 
-		rules := []Rule{
+		remote := l.remoteRepository(project)
+		runnerLabel := NewRuleRunnerLabel()
+		runnerLabel.remote = remote
+		action := NewRuleAction(localActions)
+		action.remote = remote
+		action.metadata = actionMetadata
+		if l.online != nil {
+			action.registry = l.online.registry
+			action.credentials = l.trustConfig
+		}
+		var lines []string
+		if content != nil {
+			lines = strings.Split(string(content), "\n")
+		}
+		permissions := NewRulePermissions()
+		permissions.lines = lines
+		deprecatedCommands := NewRuleDeprecatedCommands()
+		if l.fix {
+			deprecatedCommands.lines = lines
+			if remote != nil {
+				action.pin = true
+				action.lines = lines
+			} else {
+				l.log("Actions are not pinned to commit SHAs since online checks are disabled", "path", path)
+			}
+		}
+		workflowCall := NewRuleWorkflowCall(path, localReusableWorkflows)
+		workflowCall.remote = remote
+		expr := NewRuleExpression(localActions, localReusableWorkflows)
+		expr.remote = remote
+		expr.actionMetadata = actionMetadata
+		ghes := l.ghes
+		if ghes == nil && cfg != nil {
+			ghes = cfg.GHES
+		}
+		if ghes == nil && remote != nil {
+			ghes = remote.GHESVersion() // Detect the version of GitHub Enterprise Server hosting the repository
+		}
+		runnerLabel.ghes = ghes
+		expr.ghes = ghes
+		platform := l.platformOf(cfg)
+		runnerLabel.platform = platform
+		action.platform = platform
+		expr.platform = platform
+		events := NewRuleEvents()
+		events.workflows = localReusableWorkflows
+		events.path = path
+		events.platform = platform
+		events.template = isWorkflowTemplateFile(path)
+		shellName := NewRuleShellName()
+		shellName.proj = project
+
+		rules = []Rule{
 			NewRuleMatrix(),
 			NewRuleCredentials(),
-			NewRuleShellName(),
-			NewRuleRunnerLabel(),
-			NewRuleEvents(),
+			shellName,
+			runnerLabel,
+			events,
 			NewRuleJobNeeds(),
-			NewRuleAction(localActions),
+			action,
 			NewRuleEnvVar(),
 			NewRuleID(),
 			NewRuleGlob(),
-			NewRulePermissions(),
-			NewRuleWorkflowCall(path, localReusableWorkflows),
-			NewRuleExpression(localActions, localReusableWorkflows),
-			NewRuleDeprecatedCommands(),
+			permissions,
+			workflowCall,
+			expr,
+			deprecatedCommands,
 			NewRuleIfCond(),
+			NewRuleLimits(),
+			NewRuleConcurrency(),
+			NewRuleEnvironmentURL(),
+			NewRuleEnvFile(),
+			NewRuleCmdScript(),
+		}
+		if remote != nil {
+			rules = append(rules, NewRuleEnvironment(remote))
+		}
+		if ghes != nil {
+			rules = append(rules, NewRuleGHES(ghes))
+		}
+		if platform.giteaCompatible() {
+			rules = append(rules, NewRulePlatform(platform))
+		}
+		if project != nil {
+			r := NewRuleProblemMatcher(project)
+			if composite != nil {
+				r.actionDir = filepath.Dir(path)
+			}
+			rules = append(rules, r)
+		}
+		if isWorkflowTemplateFile(path) {
+			rules = append(rules, NewRuleWorkflowTemplate(path, project))
+		}
+		if cfg != nil && cfg.TimeoutMinutes != nil {
+			rules = append(rules, NewRuleTimeoutMinutes(cfg.TimeoutMinutes))
+		}
+		if cfg != nil && cfg.InlineScript != nil {
+			rules = append(rules, NewRuleInlineScript(cfg.InlineScript))
+		}
+		if cfg != nil && cfg.NamingConvention != nil {
+			rules = append(rules, NewRuleNamingConvention(cfg.NamingConvention))
+		}
+		if cfg != nil && cfg.OSCommands {
+			rules = append(rules, NewRuleOSCommand())
+		}
+		if cfg != nil && cfg.DuplicateStepNames {
+			rules = append(rules, NewRuleStepName())
+		}
+		if cfg != nil && cfg.DeploymentEnvironment != nil {
+			rules = append(rules, NewRuleDeploymentEnvironment(cfg.DeploymentEnvironment))
+		}
+		if cfg != nil && cfg.OutdatedActions != nil {
+			if remote != nil {
+				rules = append(rules, NewRuleOutdatedAction(remote, cfg.OutdatedActions))
+			} else {
+				l.log("Rule was disabled since online checks are disabled", "rule", "outdated-action", "path", path)
+			}
+		}
+		if cfg != nil && cfg.YAMLStyle != nil {
+			if content != nil {
+				rules = append(rules, NewRuleYAMLStyle(cfg.YAMLStyle, content))
+			} else {
+				l.log("Rule was disabled since the source of the file is not available", "rule", "yaml-style", "path", path)
+			}
 		}
 		if l.shellcheck != "" {
-			r, err := NewRuleShellcheck(l.shellcheck, proc)
+			var r *RuleShellcheck
+			var err error
+			if e, exe, ok := l.configExecutor(cfg, "shellcheck"); ok {
+				var cmd *externalCommand
+				if cmd, err = proc.newCommandRunnerOn(e, exe, false); err == nil {
+					r = newRuleShellcheck(cmd)
+				}
+			} else {
+				r, err = NewRuleShellcheck(l.shellcheck, proc)
+				if err != nil {
+					if e := l.wslShellcheck(); e != nil {
+						var cmd *externalCommand
+						if cmd, err = proc.newCommandRunnerOn(e, "shellcheck", false); err == nil {
+							r = newRuleShellcheck(cmd)
+						}
+					}
+				}
+			}
 			if err == nil {
+				if l.fix {
+					r.lines = lines
+				}
 				rules = append(rules, r)
 			} else {
-				l.log("Rule \"shellcheck\" was disabled:", err)
+				l.log("Rule was disabled", "rule", "shellcheck", "reason", err)
 			}
 		} else {
-			l.log("Rule \"shellcheck\" was disabled since shellcheck command name was empty")
+			l.log("Rule was disabled since the command name was empty", "rule", "shellcheck")
 		}
 		if l.pyflakes != "" {
-			r, err := NewRulePyflakes(l.pyflakes, proc)
+			var r *RulePyflakes
+			var err error
+			if e, exe, ok := l.configExecutor(cfg, "pyflakes"); ok {
+				var cmd *externalCommand
+				if cmd, err = proc.newCommandRunnerOn(e, exe, true); err == nil {
+					r = newRulePyflakes(cmd)
+				}
+			} else {
+				r, err = NewRulePyflakes(l.pyflakes, proc)
+			}
 			if err == nil {
 				rules = append(rules, r)
 			} else {
-				l.log("Rule \"pyflakes\" was disabled:", err)
+				l.log("Rule was disabled", "rule", "pyflakes", "reason", err)
 			}
 		} else {
-			l.log("Rule \"pyflakes\" was disabled since pyflakes command name was empty")
+			l.log("Rule was disabled since the command name was empty", "rule", "pyflakes")
+		}
+		if l.psAnalyzer != "" {
+			r, err := NewRulePSScriptAnalyzer(l.psAnalyzer, proc)
+			if err == nil {
+				rules = append(rules, r)
+			} else {
+				l.log("Rule was disabled", "rule", "psscriptanalyzer", "reason", err)
+			}
+		}
+		if l.act != "" && content == nil {
+			l.log("Rule was disabled since the source of the file is not available", "rule", "act", "path", path)
+		} else if l.act != "" {
+			r, err := NewRuleAct(l.act, proc, path, content)
+			if err == nil {
+				rules = append(rules, r)
+			} else {
+				l.log("Rule was disabled", "rule", "act", "reason", err)
+			}
+		}
+		var zizmor *RuleZizmor
+		if l.zizmor != "" && content == nil {
+			l.log("Rule was disabled since the source of the file is not available", "rule", "zizmor", "path", path)
+		} else if l.zizmor != "" {
+			r, err := NewRuleZizmor(l.zizmor, proc, path, content)
+			if err == nil {
+				zizmor = r
+			} else {
+				l.log("Rule was disabled", "rule", "zizmor", "reason", err)
+			}
+		} else if l.zizmorFindings != nil {
+			zizmor = newRuleZizmorWithFindings(path, l.zizmorFindings)
+		}
+		if zizmor != nil {
+			rules = append(rules, zizmor)
+		}
+		if cfg != nil && !l.trustConfig {
+			l.warnUntrustedConfig(cfg)
+		}
+		if cfg != nil && l.trustConfig {
+			for _, p := range cfg.Plugins {
+				exe := p.Command
+				var executor commandExecutor = hostExecutor{}
+				if p.Container != nil {
+					executor = newContainerExecutor(p.Container)
+				} else if p.Remote != nil {
+					executor = newSSHExecutor(p.Remote)
+				} else if project != nil && (strings.HasPrefix(exe, "./") || strings.HasPrefix(exe, "../")) {
+					exe = filepath.Join(project.RootDir(), exe)
+				}
+				var cmd *externalCommand
+				var err error
+				if p.Persistent {
+					cmd, err = proc.newPersistentCommandRunner(executor, exe)
+				} else {
+					cmd, err = proc.newCommandRunnerOn(executor, exe, false)
+				}
+				var r *RulePlugin
+				if err == nil {
+					r = newRulePlugin(p.Name, cmd, path, content)
+				}
+				if err == nil {
+					rules = append(rules, r)
+				} else {
+					l.log("Rule was disabled", "rule", p.Name, "plugin", true, "reason", err)
+				}
+			}
+		}
+		for _, f := range slices.Concat(registeredRules(), l.rules) {
+			if r := f(); r != nil {
+				rules = append(rules, r)
+			}
+		}
+		if composite != nil {
+			rules = slices.DeleteFunc(rules, func(r Rule) bool {
+				return !slices.Contains(compositeActionRules, r.Name())
+			})
+			expr.composite = true
+			expr.inputsTy = composite.inputs
+			expr.inputDecls = composite.inputDecls
 		}
 		if l.onRulesCreated != nil {
 			rules = l.onRulesCreated(rules)
 		}
+		if dbg != nil {
+			names := make([]string, 0, len(rules))
+			for _, r := range rules {
+				names = append(names, r.Name())
+			}
+			l.debug("Enabled rules", "path", path, "rules", names)
+		}
 
-		v := NewVisitor()
+		// These rules share the caches of local actions and reusable workflows. An error on reading
+		// the cache is reported only by the rule which reads it first. So they must run in the same
+		// goroutine to report the errors deterministically.
+		sharing := []Rule{action, workflowCall, expr}
+
+		passes := make([]Pass, 0, len(rules))
+		shared := make([]bool, 0, len(rules))
+		var timed []*timedPass
 		for _, rule := range rules {
-			v.AddPass(rule)
+			if l.hooks.ruleFinished != nil {
+				p := &timedPass{pass: rule}
+				timed = append(timed, p)
+				passes = append(passes, p)
+			} else {
+				passes = append(passes, rule)
+			}
+			shared = append(shared, slices.Contains(sharing, rule))
 		}
 		if dbg != nil {
-			v.EnableDebug(dbg)
 			for _, r := range rules {
 				r.EnableDebug(dbg)
 			}
@@ -611,15 +1845,24 @@ func (l *Linter) check(
 			}
 		}
 
-		if err := v.Visit(w); err != nil {
-			l.debug("Error occurred while visiting workflow syntax tree: %v", err)
-			return nil, err
+		if err := l.visit(ctx, w, passes, shared, dbg); err != nil {
+			l.debug("Error occurred while visiting workflow syntax tree", "path", path, "error", err)
+			return nil, nil, err
 		}
 
-		for _, rule := range rules {
+		for i, rule := range rules {
 			errs := rule.Errs()
-			l.debug("%s found %d errors", rule.Name(), len(errs))
+			l.debug("Rule finished", "path", path, "rule", rule.Name(), "errors", len(errs))
+			if l.hooks.ruleFinished != nil {
+				l.hooks.ruleFinished(path, rule.Name(), len(errs), timed[i].elapsed)
+			}
 			all = append(all, errs...)
+			if r, ok := rule.(interface{ Fixes() []*TextEdit }); ok {
+				fixes = append(fixes, r.Fixes()...)
+			}
+		}
+		if zizmor != nil {
+			all = zizmor.removeDuplicates(all)
 		}
 
 		if l.errFmt != nil {
@@ -627,9 +1870,10 @@ func (l *Linter) check(
 				l.errFmt.RegisterRule(rule)
 			}
 		}
+		rules = append(meta, rules...)
 	}
 
-	all = l.filterErrors(all, cfg.PathConfigs(path))
+	all = l.filterErrors(path, all, cfg.PathConfigs(path))
 
 	for _, err := range all {
 		err.Filepath = path // Populate filename in the error
@@ -638,15 +1882,157 @@ func (l *Linter) check(
 	slices.SortFunc(all, compareErrors)
 	all = slices.CompactFunc(all, equalsErrors) // Alias may duplicate errors
 
+	for _, err := range all {
+		fixes = append(fixes, err.Fixes...) // Ignored errors are not fixed
+	}
+
+	if cache != nil {
+		deps := lintCacheDepsOf(w, project, cfg, l.defaultConfig == nil, l.platform)
+		if len(meta) > 0 {
+			// The local Dockerfile of Docker action is checked by hadolint
+			if r, ok := meta[0].(*RuleActionMetadata); ok && r.dockerfile != "" {
+				if p, err := filepath.Abs(r.dockerfile); err == nil {
+					deps = append(deps, p)
+				}
+			}
+		}
+		cache.store(path, content, deps, rules, all)
+	}
+	l.catalog.translateErrors(all) // Messages in cache are not translated to share them among locales
+
 	if l.logLevel >= LogLevelVerbose {
 		elapsed := time.Since(start)
-		l.log("Found total", len(all), "errors in", elapsed.Milliseconds(), "ms for", path)
+		l.log("Checked file", "path", path, "errors", len(all), "elapsed", elapsed)
+	}
+	l.metrics.fileFinished(time.Since(start))
+	if l.hooks.fileFinished != nil {
+		l.hooks.fileFinished(path, all, time.Since(start))
 	}
 
-	return all, nil
+	return all, fixes, nil
+}
+
+// registerResourceLimit registers "resource-limit" kind to the error formatter when the errors on
+// parsing a file are due to the resource limits.
+func (l *Linter) registerResourceLimit(errs []*Error) {
+	if l.errFmt != nil && len(errs) == 1 && errs[0].Kind == "resource-limit" {
+		// Register the kind only when the limit is exceeded not to change the list of rules
+		r := NewRuleBase("resource-limit", "Checks resources used for parsing workflow files are within the limits")
+		l.errFmt.RegisterRule(&r)
+	}
+}
+
+// checkActionMetadata checks the action metadata file such as "action.yml" with RuleActionMetadata.
+// It returns the composite action to be checked by the workflow rules, the errors, and the rules
+// which checked the file. The composite action is nil when the action is not a composite action.
+// When hadolint is enabled, the local Dockerfile of Docker action is also checked with RuleHadolint.
+func (l *Linter) checkActionMetadata(path string, content []byte, project *Project, proc *concurrentProcess) (*compositeAction, []*Error, []Rule, error) {
+	start := time.Now()
+	rule := NewRuleActionMetadata()
+	rule.dir = filepath.Dir(path)
+	rule.proj = project
+	composite, all := rule.checkSource(content, l.parseLimits)
+	l.registerResourceLimit(all)
+	rules := []Rule{rule}
+	elapsed := []time.Duration{time.Since(start)}
+
+	if l.hadolint != "" && rule.dockerfile != "" {
+		start := time.Now()
+		if r, err := l.runHadolint(rule.dir, rule.dockerfile, rule.dockerfilePos, project, proc); err != nil {
+			return nil, nil, nil, err
+		} else if r != nil {
+			rules = append(rules, r)
+			elapsed = append(elapsed, time.Since(start))
+		}
+	}
+
+	for i, r := range rules {
+		errs := r.Errs()
+		l.debug("Rule finished", "path", path, "rule", r.Name(), "errors", len(errs))
+		if l.hooks.ruleFinished != nil {
+			l.hooks.ruleFinished(path, r.Name(), len(errs), elapsed[i])
+		}
+		if l.errFmt != nil {
+			l.errFmt.RegisterRule(r)
+		}
+		all = append(all, errs...)
+	}
+	return composite, all, rules, nil
+}
+
+// runHadolint checks the Dockerfile at the path with hadolint and waits until the check finishes.
+// The dir parameter is the directory of the action. It returns nil rule when hadolint is not
+// available.
+func (l *Linter) runHadolint(dir, path string, pos *Pos, project *Project, proc *concurrentProcess) (*RuleHadolint, error) {
+	r, err := NewRuleHadolint(l.hadolint, proc)
+	if err != nil {
+		l.log("Rule was disabled", "rule", "hadolint", "reason", err)
+		return nil, nil
+	}
+	src, err := project.readFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not read Dockerfile %q: %w", path, err)
+	}
+	if dbg := l.debugWriter(); dbg != nil {
+		r.EnableDebug(dbg)
+	}
+	name, err := filepath.Rel(dir, path)
+	if err != nil {
+		name = path
+	}
+	r.checkDockerfile(src, name, pos)
+	if err := r.wait(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// visit traverses the workflow syntax tree with the passes. When rule-level parallelism is enabled,
+// the passes are divided into groups and each group traverses the tree in its own goroutine. This is
+// safe since rules do not modify the tree and each pass is called from only one goroutine. The
+// passes whose shared[i] is true are put in the first group since they share some state.
+func (l *Linter) visit(ctx context.Context, w *Workflow, passes []Pass, shared []bool, dbg io.Writer) error {
+	n := min(l.ruleJobs, len(passes))
+	if n <= 1 {
+		v := NewVisitor()
+		for _, p := range passes {
+			v.AddPass(p)
+		}
+		if dbg != nil {
+			v.EnableDebug(dbg)
+		}
+		return v.VisitContext(ctx, w)
+	}
+
+	l.debug("Running rules in parallel", "rules", len(passes), "groups", n)
+	vs := make([]*Visitor, n)
+	for i := range vs {
+		vs[i] = NewVisitor()
+		if dbg != nil {
+			vs[i].EnableDebug(dbg)
+		}
+	}
+	g := 0
+	for i, p := range passes {
+		if shared[i] {
+			vs[0].AddPass(p)
+			continue
+		}
+		// Other passes are distributed to the remaining groups in round-robin
+		vs[g%(n-1)+1].AddPass(p)
+		g++
+	}
+
+	eg, ctx := errgroup.WithContext(ctx)
+	for _, v := range vs {
+		eg.Go(func() error {
+			return v.VisitContext(ctx, w)
+		})
+	}
+	return eg.Wait()
 }
 
-func (l *Linter) filterErrors(errs []*Error, cfgs []PathConfig) []*Error {
+func (l *Linter) filterErrors(path string, errs []*Error, cfgs []PathConfig) []*Error {
 	if len(l.ignorePats) == 0 && len(cfgs) == 0 {
 		return errs
 	}
@@ -655,19 +2041,19 @@ func (l *Linter) filterErrors(errs []*Error, cfgs []PathConfig) []*Error {
 Loop:
 	for _, err := range errs {
 		if l.ignorePats.Match(err) {
-			l.debug("Error %q is ignored due to -ignore command line option", err.Message)
+			l.debug("Error was ignored due to -ignore command line option", "path", path, "line", err.Line, "column", err.Column, "rule", err.Kind, "message", err.Message)
 			continue Loop
 		}
 		for _, c := range cfgs {
 			if c.Ignore.Match(err) {
-				l.debug("Error %q is ignored due to the \"ignore\" config in the config file", err.Message)
+				l.debug("Error was ignored due to \"ignore\" configuration in the config file", "path", path, "line", err.Line, "column", err.Column, "rule", err.Kind, "message", err.Message)
 				continue Loop
 			}
 		}
 		filtered = append(filtered, err)
 	}
 	if len(filtered) != len(errs) {
-		l.log("Filtered", len(errs)-len(filtered), "error(s) due to \"-ignore\" command line option and \"ignore\" configuration")
+		l.log("Filtered errors due to \"-ignore\" command line option and \"ignore\" configuration", "path", path, "errors", len(errs)-len(filtered))
 	}
 	return filtered
 }
@@ -3,17 +3,25 @@ package actionlint
 import (
 	"bufio"
 	"bytes"
+	"context"
+	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"regexp"
 	"runtime"
 	"slices"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"testing/fstest"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"golang.org/x/sys/execabs"
@@ -215,6 +223,39 @@ func TestLinterLintError(t *testing.T) {
 	}
 }
 
+func TestLinterRunRulesInParallel(t *testing.T) {
+	dir, infiles, err := testFindAllWorkflowsInDir("examples")
+	if err != nil {
+		panic(err)
+	}
+	proj := &Project{root: dir}
+
+	l, err := NewLinter(io.Discard, &LinterOptions{RuleJobs: 4})
+	if err != nil {
+		t.Fatal(err)
+	}
+	l.defaultConfig = &Config{}
+
+	for _, infile := range infiles {
+		base := strings.TrimSuffix(infile, filepath.Ext(infile))
+		testName := filepath.Base(base)
+		if strings.Contains(testName, "shellcheck") || strings.Contains(testName, "pyflakes") {
+			continue
+		}
+		t.Run(testName, func(t *testing.T) {
+			b, err := os.ReadFile(infile)
+			if err != nil {
+				panic(err)
+			}
+			errs, err := l.Lint("test.yaml", b, proj)
+			if err != nil {
+				t.Fatal(err)
+			}
+			checkErrors(t, base+".out", errs)
+		})
+	}
+}
+
 func TestLinterLintAllErrorWorkflowsAtOnce(t *testing.T) {
 	shellcheck, err := execabs.LookPath("shellcheck")
 	if err != nil {
@@ -350,6 +391,60 @@ func TestLinterLintProject(t *testing.T) {
 	}
 }
 
+func TestLinterLocalActionInputWarnings(t *testing.T) {
+	repo := filepath.Join("testdata", "projects", "local_action_input_hints")
+	l, err := NewLinter(io.Discard, &LinterOptions{WorkingDir: repo})
+	if err != nil {
+		t.Fatal(err)
+	}
+	errs, err := l.LintDir(filepath.Join(repo, "workflows"), &Project{root: repo})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(errs) != 3 {
+		t.Fatalf("wanted 3 errors but got %v", errs)
+	}
+	// Type hints and deprecated inputs are reported as warnings
+	for _, err := range errs {
+		if err.Severity != SeverityWarning {
+			t.Errorf("wanted warning but got %s: %v", err.Severity, err)
+		}
+	}
+}
+
+func TestLinterWorkflowRunWorkflowNames(t *testing.T) {
+	repo := filepath.Join("testdata", "workflow_run")
+	l, err := NewLinter(io.Discard, &LinterOptions{WorkingDir: repo})
+	if err != nil {
+		t.Fatal(err)
+	}
+	proj := &Project{root: repo}
+	errs, err := l.LintDir(proj.WorkflowsDir(), proj)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkErrors(t, repo+".out", errs)
+}
+
+func TestLinterPullRequestEventsWorkflowNames(t *testing.T) {
+	repo := filepath.Join("testdata", "pull_request_events")
+	l, err := NewLinter(io.Discard, &LinterOptions{WorkingDir: repo})
+	if err != nil {
+		t.Fatal(err)
+	}
+	proj := &Project{root: repo}
+	errs, err := l.LintDir(proj.WorkflowsDir(), proj)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkErrors(t, repo+".out", errs)
+	for _, err := range errs {
+		if err.Severity != SeverityWarning {
+			t.Errorf("wanted warning but got %s: %v", err.Severity, err)
+		}
+	}
+}
+
 func TestLinterFormatErrorMessageOK(t *testing.T) {
 	tests := []struct {
 		file   string
@@ -500,216 +595,1529 @@ jobs:
 	}
 }
 
-func TestLinterLintStdinReadError(t *testing.T) {
-	l, err := NewLinter(io.Discard, &LinterOptions{})
-	if err != nil {
-		t.Fatal(err)
+func TestLinterLintCancelledContext(t *testing.T) {
+	dir := t.TempDir()
+	wd := filepath.Join(dir, ".github", "workflows")
+	for _, d := range []string{wd, filepath.Join(dir, ".git")} {
+		if err := os.MkdirAll(d, 0755); err != nil {
+			t.Fatal(err)
+		}
 	}
-	_, err = l.LintStdin(testErrorReader{})
-	if err == nil {
-		t.Fatal("error did not occur")
+	files := []string{
+		filepath.Join(wd, "test.yaml"),
+		filepath.Join(wd, "test2.yaml"),
 	}
-	want := "could not read stdin: dummy read error"
-	have := err.Error()
-	if want != have {
-		t.Fatalf("wanted error message %q but have %q", want, have)
+	for _, f := range files {
+		if err := os.WriteFile(f, []byte("on: push\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
 	}
-}
 
-func TestLinterPathsNotFound(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
 	l, err := NewLinter(io.Discard, &LinterOptions{})
 	if err != nil {
 		t.Fatal(err)
 	}
 
-	cwd, err := os.Getwd()
-	if err != nil {
-		panic(err)
+	if _, err := l.LintContext(ctx, "test.yaml", []byte("on: push"), nil); !errors.Is(err, context.Canceled) {
+		t.Errorf("LintContext should return context.Canceled but got %v", err)
 	}
-
-	paths := []string{
-		filepath.Join("testdata", "this-file-doesnt-exist.yaml"),                   // Relative file path (parent exists)
-		filepath.Join("this-dir-doesnt-exist", "this-file-doesnt-exist.yaml"),      // Relative file path (parent doesn't exist)
-		filepath.Join(cwd, "this-file-doesnt-exist.yaml"),                          // Absolute file path (parent exists)
-		filepath.Join(cwd, "this-dir-doesnt-exist", "this-file-doesnt-exist.yaml"), // Absolute file path (parent doesn't exist)
+	if _, err := l.LintStdinContext(ctx, strings.NewReader("on: push")); !errors.Is(err, context.Canceled) {
+		t.Errorf("LintStdinContext should return context.Canceled but got %v", err)
 	}
-
-	_, err = l.LintFiles(paths, nil)
-	if err == nil {
-		t.Fatal("no error happened")
+	if _, err := l.LintFileContext(ctx, files[0], nil); !errors.Is(err, context.Canceled) {
+		t.Errorf("LintFileContext should return context.Canceled but got %v", err)
 	}
-	msg := err.Error()
-	if !strings.Contains(msg, "could not read") {
-		t.Fatal("unexpected error:", msg)
+	if _, err := l.LintFilesContext(ctx, files, nil); !errors.Is(err, context.Canceled) {
+		t.Errorf("LintFilesContext should return context.Canceled but got %v", err)
 	}
-}
-
-type customRuleForTest struct {
-	RuleBase
-	count int
-}
-
-func (r *customRuleForTest) VisitStep(n *Step) error {
-	r.count++
-	if r.count > 1 {
-		r.Errorf(n.Pos, "only single step is allowed but got %d steps", r.count)
+	if _, err := l.LintRepositoryContext(ctx, dir); !errors.Is(err, context.Canceled) {
+		t.Errorf("LintRepositoryContext should return context.Canceled but got %v", err)
 	}
-	return nil
 }
 
-func TestLinterAddCustomRuleOnRulesCreatedHook(t *testing.T) {
-	o := &LinterOptions{
-		OnRulesCreated: func(rules []Rule) []Rule {
-			r := &customRuleForTest{
-				RuleBase: NewRuleBase("this-is-test", ""),
-			}
-			return append(rules, r)
-		},
+func TestLinterLintFS(t *testing.T) {
+	fsys := fstest.MapFS{
+		"repo/.github/workflows/test.yaml": {Data: []byte(`on: push
+jobs:
+  call:
+    uses: ./.github/workflows/reusable.yaml
+    with:
+      unknown: foo
+  test:
+    runs-on: ubuntu-latest
+    steps:
+      - uses: ./.github/actions/my-action
+        with:
+          unknown: bar
+`)},
+		"repo/.github/workflows/reusable.yaml": {Data: []byte(`on:
+  workflow_call:
+jobs:
+  test:
+    runs-on: ubuntu-latest
+    steps:
+      - run: echo
+`)},
+		"repo/.github/actions/my-action/action.yml": {Data: []byte(`name: My action
+description: My action
+runs:
+  using: node20
+  main: index.js
+`)},
+		"repo/.github/actions/my-action/index.js": {Data: []byte("")},
 	}
 
-	l, err := NewLinter(io.Discard, o)
+	l, err := NewLinter(io.Discard, &LinterOptions{Fix: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	errs, err := l.LintFS(fsys, "repo")
 	if err != nil {
 		t.Fatal(err)
 	}
-	l.defaultConfig = &Config{}
 
-	{
-		w := `on: push
+	want := []string{
+		`repo/.github/workflows/test.yaml:6:7: input "unknown" is not defined in "./.github/workflows/reusable.yaml" reusable workflow. no input is defined [workflow-call]`,
+		`repo/.github/workflows/test.yaml:12:11: input "unknown" is not defined in action "My action" defined at "./.github/actions/my-action". available inputs are  [action]`,
+	}
+	have := []string{}
+	for _, e := range errs {
+		have = append(have, e.Error())
+	}
+	if diff := cmp.Diff(want, have); diff != "" {
+		t.Fatal(diff)
+	}
+}
+
+func TestLinterLintFSWorkflowTemplates(t *testing.T) {
+	fsys := fstest.MapFS{
+		"repo/.github/workflow-templates/ci.yml": {Data: []byte(`name: CI
+on:
+  push:
+    branches: [$default-branch]
+  pull_request:
+    branches: [$protected-branches]
+  schedule:
+    - cron: $cron-daily
 jobs:
   test:
     runs-on: ubuntu-latest
     steps:
       - run: echo
-`
-		errs, err := l.Lint("test.yaml", []byte(w), nil)
-		if err != nil {
-			t.Fatal(err)
-		}
-		if len(errs) != 0 {
-			t.Fatal("wanted no error but have", errs)
-		}
-	}
-
-	{
-		w := `on: push
+`)},
+		"repo/.github/workflow-templates/ci.properties.json": {Data: []byte(`{
+  "name": "CI",
+  "description": "CI workflow of our organization",
+  "iconName": "octicon check",
+  "categories": ["Go"],
+  "filePatterns": ["go\\.mod$"]
+}`)},
+		"repo/.github/workflow-templates/release.yml": {Data: []byte(`on: push
 jobs:
-  test:
+  release:
     runs-on: ubuntu-latest
     steps:
       - run: echo
-      - run: echo
-`
-		errs, err := l.Lint("test.yaml", []byte(w), nil)
-		if err != nil {
-			t.Fatal(err)
-		}
-		if len(errs) != 1 {
-			t.Fatal("wanted 1 error but have", errs)
-		}
+`)},
+	}
 
-		var b strings.Builder
-		errs[0].PrettyPrint(&b, nil)
-		have := b.String()
-		want := "test.yaml:7:9: only single step is allowed but got 2 steps [this-is-test]\n"
-		if have != want {
-			t.Fatalf("wanted error message %q but have %q", want, have)
-		}
+	l, err := NewLinter(io.Discard, &LinterOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	errs, err := l.LintFS(fsys, "repo")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{
+		`repo/.github/workflow-templates/release.yml:1:1: metadata file "release.properties.json" of workflow template is not found in the same directory. the template is not shown when creating a new workflow without it [workflow-template]`,
+	}
+	have := []string{}
+	for _, e := range errs {
+		have = append(have, e.Error())
+	}
+	if diff := cmp.Diff(want, have); diff != "" {
+		t.Fatal(diff)
 	}
 }
 
-func TestLinterRemoveRuleOnRulesCreatedHook(t *testing.T) {
-	o := &LinterOptions{
-		OnRulesCreated: func(rules []Rule) []Rule {
-			for i, r := range rules {
-				if r.Name() == "runner-label" {
-					rules = append(rules[:i], rules[i+1:]...)
-					break
-				}
-			}
-			return rules
-		},
+func TestLinterLintWorkflowAST(t *testing.T) {
+	src := []byte(`on: push
+jobs:
+  test:
+    runs-on: ubuntu-latest
+    steps:
+      - run: echo ${{ unknown.context }}
+      - uses: actions/checkout@v4
+        with:
+          unknown: foo
+`)
+	w, errs := Parse(src)
+	if len(errs) > 0 {
+		t.Fatal(errs)
 	}
 
-	l, err := NewLinter(io.Discard, o)
+	l, err := NewLinter(io.Discard, &LinterOptions{})
 	if err != nil {
 		t.Fatal(err)
 	}
-	l.defaultConfig = &Config{}
 
-	f := filepath.Join("testdata", "err", "invalid_runner_labels.yaml")
-	errs, err := l.LintFile(f, nil)
+	want, err := l.Lint("test.yaml", src, nil)
 	if err != nil {
 		t.Fatal(err)
 	}
-	if len(errs) != 0 {
-		t.Fatal("no error was expected because runner-label rule was removed but got:", errs)
+	if len(want) == 0 {
+		t.Fatal("no error was found from the source")
 	}
-}
-
-func TestLinterGenerateDefaultConfigAlreadyExists(t *testing.T) {
-	l, err := NewLinter(io.Discard, &LinterOptions{})
+	have, err := l.LintWorkflowAST("test.yaml", w)
 	if err != nil {
 		t.Fatal(err)
 	}
+	if diff := cmp.Diff(want, have); diff != "" {
+		t.Fatal(diff)
+	}
 
-	for _, n := range []string{"ok", "yml"} {
-		d := filepath.Join("testdata", "config", "projects", n)
-		testEnsureDotGitDir(d)
-
-		err := l.GenerateDefaultConfig(d)
-		if err == nil {
-			t.Fatal("error did not occur for project", d)
-		}
-		want := "config file already exists at"
-		msg := err.Error()
-		if !strings.Contains(msg, want) {
-			t.Fatalf("error message %q does not contain expected text %q", msg, want)
-		}
+	if _, err := l.LintWorkflowAST("test.yaml", nil); err == nil {
+		t.Fatal("error did not occur for nil syntax tree")
 	}
 }
 
-func BenchmarkLintWorkflowFiles(b *testing.B) {
-	scripts := filepath.Join("testdata", "bench", "many_scripts.yaml")
-	small := filepath.Join("testdata", "bench", "small.yaml")
-	large := filepath.Join("testdata", "bench", "large.yaml")
-	min := filepath.Join("testdata", "bench", "minimal.yaml")
-	proj := &Project{root: "."}
-	shellcheck, err := execabs.LookPath("shellcheck")
-	if err != nil {
-		b.Skipf("shellcheck is not found: %s", err)
+// testNotifyWriter is a thread-safe writer which notifies that the written output contains the text.
+type testNotifyWriter struct {
+	mu   sync.Mutex
+	buf  strings.Builder
+	text string
+	once sync.Once
+	ch   chan struct{}
+}
+
+func (w *testNotifyWriter) Write(b []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.buf.Write(b)
+	if strings.Contains(w.buf.String(), w.text) {
+		w.once.Do(func() { close(w.ch) })
 	}
-	format := "{{range $ := .}}### Error at line {{$.Line}}, col {{$.Column}} of `{{$.Filepath}}`\\n\\n{{$.Message}}\\n\\n```\\n{{$.Snippet}}\\n```\\n\\n{{end}}"
+	return len(b), nil
+}
 
-	// Workflow files for this repository
-	ours := []string{}
-	{
-		d := filepath.Join(".github", "workflows")
-		es, err := os.ReadDir(d)
-		if err != nil {
-			panic(err)
-		}
-		for _, e := range es {
-			ours = append(ours, filepath.Join(d, e.Name()))
+func TestLinterStreamErrors(t *testing.T) {
+	dir := t.TempDir()
+	src := "on: push\njobs:\n  test:\n    steps:\n      - run: echo\n"
+	files := []string{filepath.Join(dir, "a.yaml"), filepath.Join(dir, "b.yaml")}
+	for _, f := range files {
+		if err := os.WriteFile(f, []byte(src), 0644); err != nil {
+			t.Fatal(err)
 		}
 	}
 
-	bms := []struct {
-		what       string
-		files      []string
-		shellcheck string
-		format     string
-	}{
-		{
-			what:  "minimal",
-			files: []string{min},
-		},
-		{
-			what:  "minimal",
-			files: []string{min, min, min, min, min},
-		},
-		{
-			what:  "minimal",
-			files: []string{min, min, min, min, min, min, min, min, min, min, min, min, min, min, min, min, min, min, min, min},
+	for _, format := range []string{"", "{{range $err := .}}{{json $err}}{{end}}"} {
+		t.Run(format, func(t *testing.T) {
+			out := &testNotifyWriter{text: "a.yaml", ch: make(chan struct{})}
+			opts := &LinterOptions{
+				Format: format,
+				Jobs:   2,
+				// Checking b.yaml waits for the errors in a.yaml being printed. The errors are never
+				// printed until all files are checked if they are not streamed.
+				OnFileStarted: func(path string) {
+					if filepath.Base(path) != "b.yaml" {
+						return
+					}
+					select {
+					case <-out.ch:
+					case <-time.After(10 * time.Second):
+						t.Error("errors in a.yaml were not printed before checking b.yaml")
+					}
+				},
+			}
+			l, err := NewLinter(out, opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			errs, err := l.LintFiles(files, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(errs) != 2 {
+				t.Fatalf("wanted 2 errors but got %d: %v", len(errs), errs)
+			}
+			have := out.buf.String()
+			if i, j := strings.Index(have, "a.yaml"), strings.Index(have, "b.yaml"); i < 0 || j < 0 || i > j {
+				t.Fatalf("errors were not printed in order of files: %q", have)
+			}
+		})
+	}
+}
+
+func TestLinterLifecycleHooks(t *testing.T) {
+	if _, err := execabs.LookPath("echo"); err != nil {
+		t.Skipf("echo command is necessary to run this test: %s", err)
+	}
+
+	cfg := filepath.Join(t.TempDir(), "actionlint.yaml")
+	if err := os.WriteFile(cfg, []byte("plugins:\n  - name: empty\n    command: echo {}\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	var mu sync.Mutex
+	events := []string{}
+	record := func(e string) {
+		mu.Lock()
+		events = append(events, e)
+		mu.Unlock()
+	}
+	rules := map[string]int{}
+	opts := &LinterOptions{
+		ConfigFile:  cfg,
+		TrustConfig: true,
+		OnFileStarted: func(path string) {
+			record("start " + path)
+		},
+		OnFileFinished: func(path string, errs []*Error, elapsed time.Duration) {
+			record(fmt.Sprintf("finish %s %d", path, len(errs)))
+		},
+		OnRuleFinished: func(path, rule string, errs int, elapsed time.Duration) {
+			mu.Lock()
+			rules[rule] = errs
+			mu.Unlock()
+		},
+		OnExternalCommandStarted: func(exe string, args []string) {
+			record(fmt.Sprintf("command %s %v", filepath.Base(exe), args))
+		},
+		OnExternalCommandFinished: func(exe string, args []string, elapsed time.Duration) {
+			record(fmt.Sprintf("command finished %s %v", filepath.Base(exe), args))
+		},
+	}
+	l, err := NewLinter(io.Discard, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	src := []byte(`on: push
+jobs:
+  test:
+    runs-on: ubuntu-latest
+    steps:
+      - run: echo ${{ unknown.context }}
+`)
+	if _, err := l.Lint("test.yaml", src, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{
+		"start test.yaml",
+		"command echo [{}]",
+		"command finished echo [{}]",
+		"finish test.yaml 1",
+	}
+	if diff := cmp.Diff(want, events); diff != "" {
+		t.Fatal(diff)
+	}
+	if n, ok := rules["expression"]; !ok || n != 1 {
+		t.Fatalf("expression rule should report 1 error but got %d (%v)", n, ok)
+	}
+	if n, ok := rules["empty"]; !ok || n != 0 {
+		t.Fatalf("plugin rule should report no error but got %d (%v)", n, ok)
+	}
+}
+
+func TestLinterToolDiagnostic(t *testing.T) {
+	if _, err := execabs.LookPath("sh"); err != nil {
+		t.Skipf("sh command is necessary to run this test: %s", err)
+	}
+
+	cfg := filepath.Join(t.TempDir(), "actionlint.yaml")
+	if err := os.WriteFile(cfg, []byte("plugins:\n  - name: noisy\n    command: sh -c 'echo \"plugin is misconfigured\" >&2; echo {}'\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	var mu sync.Mutex
+	diags := []*ToolDiagnostic{}
+	var log bytes.Buffer
+	opts := &LinterOptions{
+		ConfigFile:  cfg,
+		TrustConfig: true,
+		Debug:       true,
+		LogWriter:   &log,
+		OnToolDiagnostic: func(d *ToolDiagnostic) {
+			mu.Lock()
+			diags = append(diags, d)
+			mu.Unlock()
+		},
+	}
+	l, err := NewLinter(io.Discard, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	errs, err := l.Lint("test.yaml", []byte("on: push\njobs:\n  test:\n    runs-on: ubuntu-latest\n    steps:\n      - run: echo\n"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(errs) != 0 {
+		t.Fatalf("plugin should report no error but got %v", errs)
+	}
+	if len(diags) != 1 {
+		t.Fatalf("wanted 1 diagnostic but got %d: %v", len(diags), diags)
+	}
+	d := diags[0]
+	if filepath.Base(d.Executable) != "sh" || d.Stderr != "plugin is misconfigured\n" {
+		t.Fatalf("unexpected diagnostic: %#v", d)
+	}
+	if !strings.Contains(log.String(), "plugin is misconfigured") {
+		t.Fatalf("stderr of the command was not printed in debug log: %q", log.String())
+	}
+}
+
+func TestLinterLintStdinBatch(t *testing.T) {
+	d := t.TempDir()
+	testEnsureDotGitDir(d)
+	wd := filepath.Join(d, ".github", "workflows")
+	if err := os.MkdirAll(wd, 0750); err != nil {
+		t.Fatal(err)
+	}
+	// The reusable workflow on the filesystem is outdated. Its content in the batch input defines the input "new".
+	old := "on:\n  workflow_call:\n    inputs:\n      old:\n        type: string\njobs:\n  test:\n    runs-on: ubuntu-latest\n    steps:\n      - run: echo\n"
+	if err := os.WriteFile(filepath.Join(wd, "reusable.yaml"), []byte(old), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	reusable := "on:\n  workflow_call:\n    inputs:\n      new:\n        type: string\n        required: true\njobs:\n  test:\n    runs-on: ubuntu-latest\n    steps:\n      - run: echo\n"
+	caller := "on: push\njobs:\n  call:\n    uses: ./.github/workflows/reusable.yaml\n    with:\n      new: foo\n"
+	broken := "on: push\njobs:\n  test:\n    steps:\n      - run: echo\n"
+	files := []*StdinBatchFile{
+		{Path: filepath.Join(wd, "reusable.yaml"), Content: reusable},
+		{Path: filepath.Join(wd, "caller.yaml"), Content: caller},
+		{Path: filepath.Join(wd, "broken.yaml"), Content: broken},
+	}
+	in, err := json.Marshal(files)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	l, err := NewLinter(io.Discard, &LinterOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	errs, err := l.LintStdinBatch(bytes.NewReader(in))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(errs) != 1 {
+		t.Fatalf("wanted exactly one error but got %v", errs)
+	}
+	if e := errs[0]; !strings.HasSuffix(e.Filepath, "broken.yaml") || !strings.Contains(e.Message, `"runs-on" section is missing`) {
+		t.Fatalf("unexpected error: %v", e)
+	}
+
+	b, err := os.ReadFile(filepath.Join(wd, "reusable.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != old {
+		t.Fatalf("file on filesystem was modified: %q", b)
+	}
+
+	errs, err = l.LintStdinBatch(strings.NewReader(`[]`))
+	if err != nil || len(errs) != 0 {
+		t.Fatalf("empty batch caused errors: %v, %v", errs, err)
+	}
+}
+
+func TestLinterLintStdinBatchError(t *testing.T) {
+	tests := []struct {
+		what  string
+		input string
+		want  string
+	}{
+		{
+			what:  "broken JSON",
+			input: `[{"path": `,
+			want:  "could not parse batch input from stdin",
+		},
+		{
+			what:  "not an array",
+			input: `{"path": "a.yaml", "content": ""}`,
+			want:  "could not parse batch input from stdin",
+		},
+		{
+			what:  "missing path",
+			input: `[{"path": "a.yaml", "content": ""}, {"content": "on: push"}]`,
+			want:  `"path" is missing in file #2 of batch input`,
+		},
+		{
+			what:  "duplicate path",
+			input: `[{"path": "a.yaml", "content": ""}, {"path": "a.yaml", "content": ""}]`,
+			want:  `file "a.yaml" is duplicated in batch input`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.what, func(t *testing.T) {
+			l, err := NewLinter(io.Discard, &LinterOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			_, err = l.LintStdinBatch(strings.NewReader(tc.input))
+			if err == nil {
+				t.Fatal("error did not occur")
+			}
+			if msg := err.Error(); !strings.Contains(msg, tc.want) {
+				t.Fatalf("wanted error message %q to contain %q", msg, tc.want)
+			}
+		})
+	}
+}
+
+func TestLinterLintStdinReadError(t *testing.T) {
+	l, err := NewLinter(io.Discard, &LinterOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = l.LintStdin(testErrorReader{})
+	if err == nil {
+		t.Fatal("error did not occur")
+	}
+	want := "could not read stdin: dummy read error"
+	have := err.Error()
+	if want != have {
+		t.Fatalf("wanted error message %q but have %q", want, have)
+	}
+}
+
+func TestLinterPathsNotFound(t *testing.T) {
+	l, err := NewLinter(io.Discard, &LinterOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		panic(err)
+	}
+
+	paths := []string{
+		filepath.Join("testdata", "this-file-doesnt-exist.yaml"),                   // Relative file path (parent exists)
+		filepath.Join("this-dir-doesnt-exist", "this-file-doesnt-exist.yaml"),      // Relative file path (parent doesn't exist)
+		filepath.Join(cwd, "this-file-doesnt-exist.yaml"),                          // Absolute file path (parent exists)
+		filepath.Join(cwd, "this-dir-doesnt-exist", "this-file-doesnt-exist.yaml"), // Absolute file path (parent doesn't exist)
+	}
+
+	_, err = l.LintFiles(paths, nil)
+	if err == nil {
+		t.Fatal("no error happened")
+	}
+	msg := err.Error()
+	if !strings.Contains(msg, "could not read") {
+		t.Fatal("unexpected error:", msg)
+	}
+}
+
+type customRuleForTest struct {
+	RuleBase
+	count int
+}
+
+func (r *customRuleForTest) VisitStep(n *Step) error {
+	r.count++
+	if r.count > 1 {
+		r.Errorf(n.Pos, "only single step is allowed but got %d steps", r.count)
+	}
+	return nil
+}
+
+func TestLinterAddCustomRuleOnRulesCreatedHook(t *testing.T) {
+	o := &LinterOptions{
+		OnRulesCreated: func(rules []Rule) []Rule {
+			r := &customRuleForTest{
+				RuleBase: NewRuleBase("this-is-test", ""),
+			}
+			return append(rules, r)
+		},
+	}
+
+	l, err := NewLinter(io.Discard, o)
+	if err != nil {
+		t.Fatal(err)
+	}
+	l.defaultConfig = &Config{}
+
+	{
+		w := `on: push
+jobs:
+  test:
+    runs-on: ubuntu-latest
+    steps:
+      - run: echo
+`
+		errs, err := l.Lint("test.yaml", []byte(w), nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(errs) != 0 {
+			t.Fatal("wanted no error but have", errs)
+		}
+	}
+
+	{
+		w := `on: push
+jobs:
+  test:
+    runs-on: ubuntu-latest
+    steps:
+      - run: echo
+      - run: echo
+`
+		errs, err := l.Lint("test.yaml", []byte(w), nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(errs) != 1 {
+			t.Fatal("wanted 1 error but have", errs)
+		}
+
+		var b strings.Builder
+		errs[0].PrettyPrint(&b, nil)
+		have := b.String()
+		want := "test.yaml:7:9: only single step is allowed but got 2 steps [this-is-test]\n"
+		if have != want {
+			t.Fatalf("wanted error message %q but have %q", want, have)
+		}
+	}
+}
+
+func TestLinterAddRule(t *testing.T) {
+	l, err := NewLinter(io.Discard, &LinterOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	l.defaultConfig = &Config{}
+	created := 0
+	l.AddRule(func() Rule {
+		created++
+		return &customRuleForTest{RuleBase: NewRuleBase("this-is-test", "")}
+	})
+
+	w := `on: push
+jobs:
+  test:
+    runs-on: ubuntu-latest
+    steps:
+      - run: echo
+      - run: echo
+`
+	for i := 0; i < 2; i++ {
+		errs, err := l.Lint("test.yaml", []byte(w), nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// The count of steps is not carried over from the previous file since a new rule is created
+		if len(errs) != 1 {
+			t.Fatal("wanted exactly one error but have", errs)
+		}
+		if errs[0].Kind != "this-is-test" {
+			t.Fatalf("unexpected error kind %q: %v", errs[0].Kind, errs[0])
+		}
+	}
+	if created != 2 {
+		t.Fatalf("rule should be created for each file but created %d times", created)
+	}
+}
+
+func TestLinterRegisterRule(t *testing.T) {
+	saved := customRules
+	defer func() { customRules = saved }()
+
+	RegisterRule(func() Rule {
+		return &customRuleForTest{RuleBase: NewRuleBase("registered-rule", "")}
+	})
+
+	l, err := NewLinter(io.Discard, &LinterOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	l.defaultConfig = &Config{}
+
+	w := `on: push
+jobs:
+  test:
+    runs-on: ubuntu-latest
+    steps:
+      - run: echo
+      - run: echo
+`
+	errs, err := l.Lint("test.yaml", []byte(w), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(errs) != 1 || errs[0].Kind != "registered-rule" {
+		t.Fatal("wanted exactly one error from the registered rule but have", errs)
+	}
+}
+
+func TestLinterRegisterNilRule(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatal("RegisterRule did not panic with nil")
+		}
+	}()
+	RegisterRule(nil)
+}
+
+func TestLinterRemoveRuleOnRulesCreatedHook(t *testing.T) {
+	o := &LinterOptions{
+		OnRulesCreated: func(rules []Rule) []Rule {
+			for i, r := range rules {
+				if r.Name() == "runner-label" {
+					rules = append(rules[:i], rules[i+1:]...)
+					break
+				}
+			}
+			return rules
+		},
+	}
+
+	l, err := NewLinter(io.Discard, o)
+	if err != nil {
+		t.Fatal(err)
+	}
+	l.defaultConfig = &Config{}
+
+	f := filepath.Join("testdata", "err", "invalid_runner_labels.yaml")
+	errs, err := l.LintFile(f, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(errs) != 0 {
+		t.Fatal("no error was expected because runner-label rule was removed but got:", errs)
+	}
+}
+
+func TestLinterGenerateDefaultConfigAlreadyExists(t *testing.T) {
+	l, err := NewLinter(io.Discard, &LinterOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, n := range []string{"ok", "yml"} {
+		d := filepath.Join("testdata", "config", "projects", n)
+		testEnsureDotGitDir(d)
+
+		err := l.GenerateDefaultConfig(d)
+		if err == nil {
+			t.Fatal("error did not occur for project", d)
+		}
+		want := "config file already exists at"
+		msg := err.Error()
+		if !strings.Contains(msg, want) {
+			t.Fatalf("error message %q does not contain expected text %q", msg, want)
+		}
+	}
+}
+
+func TestLinterOnlineChecks(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path == "/repos/owner/repo/actions/runners" {
+			fmt.Fprint(w, `{"total_count":1,"runners":[{"name":"r1","labels":[{"name":"self-hosted"},{"name":"linux"}]}]}`)
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer srv.Close()
+
+	opts := &LinterOptions{
+		Online:           true,
+		GitHubAPIURL:     srv.URL,
+		GitHubRepository: "owner/repo",
+	}
+	l, err := NewLinter(io.Discard, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	src := `on: push
+jobs:
+  test:
+    runs-on: [self-hosted, linux, gpu]
+    steps:
+      - run: echo ...
+`
+	errs, err := l.Lint("test.yaml", []byte(src), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(errs) != 1 {
+		t.Fatalf("wanted one error but got %v", errs)
+	}
+	want := `label "gpu" is not found in any runner registered to repository "owner/repo"`
+	if msg := errs[0].Error(); !strings.Contains(msg, want) {
+		t.Fatalf("error message %q does not contain %q", msg, want)
+	}
+
+	// Online checks are disabled by default
+	l, err = NewLinter(io.Discard, &LinterOptions{GitHubAPIURL: srv.URL, GitHubRepository: "owner/repo"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	errs, err = l.Lint("test.yaml", []byte(src), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, err := range errs {
+		if strings.Contains(err.Error(), "this check was done with GitHub API") {
+			t.Fatalf("online check was run though it is disabled: %v", err)
+		}
+	}
+}
+
+func TestLinterOnlineSecretsAndVariables(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		switch req.URL.Path {
+		case "/repos/owner/repo/actions/secrets":
+			fmt.Fprint(w, `{"secrets":[{"name":"DEPLOY_KEY"}]}`)
+		case "/repos/owner/repo/environments/prod/secrets":
+			fmt.Fprint(w, `{"secrets":[{"name":"PROD_TOKEN"}]}`)
+		case "/repos/owner/repo/actions/variables":
+			fmt.Fprint(w, `{"variables":[{"name":"REGION"}]}`)
+		case "/repos/owner/repo/actions/organization-secrets", "/repos/owner/repo/actions/organization-variables", "/repos/owner/repo/environments/prod/variables":
+			fmt.Fprint(w, `{"secrets":[],"variables":[]}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	l, err := NewLinter(io.Discard, &LinterOptions{
+		Online:           true,
+		GitHubAPIURL:     srv.URL,
+		GitHubRepository: "owner/repo",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	src := `on: push
+jobs:
+  test:
+    runs-on: ubuntu-latest
+    steps:
+      - run: echo ${{ secrets.DEPLOY_KEY }} ${{ secrets.GITHUB_TOKEN }} ${{ vars.REGION }}
+      - run: echo ${{ secrets.DEPLOY_KY }}
+      - run: echo ${{ vars['ZONE'] }}
+      - run: echo ${{ secrets.PROD_TOKEN }}
+  deploy:
+    runs-on: ubuntu-latest
+    environment: prod
+    steps:
+      - run: echo ${{ secrets.PROD_TOKEN }} ${{ secrets.DEPLOY_KEY }}
+`
+	errs, err := l.Lint("test.yaml", []byte(src), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		`test.yaml:7:23: secret "deploy_ky" is not defined in repository "owner/repo" or its organization. it is evaluated to an empty string. did you mean "DEPLOY_KEY"?`,
+		`test.yaml:8:23: configuration variable "ZONE" is not defined in repository "owner/repo" or its organization. it is evaluated to an empty string. note:`,
+		`test.yaml:9:23: secret "prod_token" is not defined in repository "owner/repo" or its organization.`,
+	}
+	if len(errs) != len(want) {
+		t.Fatalf("wanted %d errors but got %v", len(want), errs)
+	}
+	for i, err := range errs {
+		if msg := err.Error(); !strings.Contains(msg, want[i]) {
+			t.Errorf("error message %q does not contain %q", msg, want[i])
+		}
+	}
+}
+
+func TestLinterOnlineRemoteActionMetadata(t *testing.T) {
+	meta := base64.StdEncoding.EncodeToString([]byte(`name: My action
+inputs:
+  token:
+    required: true
+  verbose:
+    required: false
+outputs:
+  result:
+    description: Result
+runs:
+  using: node20
+  main: index.js
+`))
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path == "/repos/someone/my-action/contents/action.yml" {
+			fmt.Fprintf(w, `{"encoding":"base64","content":%q}`, meta)
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer srv.Close()
+
+	l, err := NewLinter(io.Discard, &LinterOptions{
+		Online:           true,
+		GitHubAPIURL:     srv.URL,
+		GitHubRepository: "owner/repo",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	src := `on: push
+jobs:
+  test:
+    runs-on: ubuntu-latest
+    steps:
+      - uses: someone/my-action@v1
+        id: my
+        with:
+          verbos: true
+      - run: echo ${{ steps.my.outputs.result }} ${{ steps.my.outputs.reslt }}
+      - uses: someone/unknown-action@v1
+        id: unknown
+        with:
+          foo: bar
+      - run: echo ${{ steps.unknown.outputs.foo }}
+`
+	errs, err := l.Lint("test.yaml", []byte(src), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		`test.yaml:6:15: missing input "token" which is required by action "someone/my-action@v1"`,
+		`test.yaml:9:11: input "verbos" is not defined in action "someone/my-action@v1". available inputs are "token", "verbose"`,
+		`test.yaml:10:54: property "reslt" is not defined in object type {result: string}`,
+	}
+	if len(errs) != len(want) {
+		t.Fatalf("wanted %d errors but got %v", len(want), errs)
+	}
+	for i, err := range errs {
+		if msg := err.Error(); !strings.Contains(msg, want[i]) {
+			t.Errorf("error message %q does not contain %q", msg, want[i])
+		}
+	}
+}
+
+func TestLinterOnlineRemoteReusableWorkflow(t *testing.T) {
+	workflow := base64.StdEncoding.EncodeToString([]byte(`on:
+  workflow_call:
+    inputs:
+      dry-run:
+        type: boolean
+        required: true
+      count:
+        type: number
+    secrets:
+      token:
+        required: true
+    outputs:
+      version:
+        value: ${{ jobs.release.outputs.version }}
+jobs:
+  release:
+    runs-on: ubuntu-latest
+    outputs:
+      version: ${{ steps.release.outputs.version }}
+    steps:
+      - id: release
+        run: ./release.sh
+`))
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		switch req.URL.Path {
+		case "/repos/someone/workflows/contents/.github/workflows/release.yml":
+			fmt.Fprintf(w, `{"encoding":"base64","content":%q}`, workflow)
+		case "/repos/someone/workflows/git/ref/tags/v1":
+			fmt.Fprint(w, `{"ref":"refs/tags/v1"}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	l, err := NewLinter(io.Discard, &LinterOptions{
+		Online:           true,
+		GitHubAPIURL:     srv.URL,
+		GitHubRepository: "owner/repo",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	src := `on: push
+jobs:
+  release:
+    uses: someone/workflows/.github/workflows/release.yml@v1
+    with:
+      count: many
+      dry-rn: true
+    secrets:
+      tokn: ${{ secrets.TOKEN }}
+  check:
+    needs: [release]
+    runs-on: ubuntu-latest
+    steps:
+      - run: echo ${{ needs.release.outputs.version }} ${{ needs.release.outputs.verison }}
+`
+	errs, err := l.Lint("test.yaml", []byte(src), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		`test.yaml:4:11: input "dry-run" is required by "someone/workflows/.github/workflows/release.yml@v1" reusable workflow`,
+		`test.yaml:4:11: secret "token" is required by "someone/workflows/.github/workflows/release.yml@v1" reusable workflow`,
+		`test.yaml:6:14: input "count" is typed as number by reusable workflow "someone/workflows/.github/workflows/release.yml@v1". string value cannot be assigned`,
+		`test.yaml:7:7: input "dry-rn" is not defined in "someone/workflows/.github/workflows/release.yml@v1" reusable workflow. defined inputs are "count", "dry-run"`,
+		`test.yaml:9:7: secret "tokn" is not defined in "someone/workflows/.github/workflows/release.yml@v1" reusable workflow. defined secret is "token"`,
+		`test.yaml:14:60: property "verison" is not defined in object type {version: string}`,
+	}
+	if len(errs) != len(want) {
+		t.Fatalf("wanted %d errors but got %v", len(want), errs)
+	}
+	for i, err := range errs {
+		if msg := err.Error(); !strings.Contains(msg, want[i]) {
+			t.Errorf("error message %q does not contain %q", msg, want[i])
+		}
+	}
+}
+
+func TestLinterOnlineRefsAtUses(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		switch req.URL.Path {
+		case "/repos/someone/action", "/repos/someone/workflows":
+			fmt.Fprint(w, `{"default_branch":"main"}`)
+		case "/repos/someone/action/git/ref/tags/v1", "/repos/someone/workflows/git/ref/heads/main":
+			fmt.Fprint(w, `{"ref":"refs/tags/v1"}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	l, err := NewLinter(io.Discard, &LinterOptions{
+		Online:           true,
+		GitHubAPIURL:     srv.URL,
+		GitHubRepository: "owner/repo",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	src := `on: push
+jobs:
+  test:
+    runs-on: ubuntu-latest
+    steps:
+      - uses: someone/action@v1
+      - uses: someone/action@v0
+  call1:
+    uses: someone/workflows/.github/workflows/test.yaml@main
+  call2:
+    uses: someone/workflows/.github/workflows/test.yaml@mian
+`
+	errs, err := l.Lint("test.yaml", []byte(src), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		`test.yaml:7:15: ref "v0" of action "someone/action@v0" is not found. no tag or branch named "v0" exists in repository "someone/action"`,
+		`test.yaml:11:11: ref "mian" of reusable workflow "someone/workflows/.github/workflows/test.yaml@mian" is not found`,
+	}
+	if len(errs) != len(want) {
+		t.Fatalf("wanted %d errors but got %v", len(want), errs)
+	}
+	for i, err := range errs {
+		if msg := err.Error(); !strings.Contains(msg, want[i]) {
+			t.Errorf("error message %q does not contain %q", msg, want[i])
+		}
+	}
+}
+
+func TestLinterFixPinActions(t *testing.T) {
+	const sha = "0123456789abcdef0123456789abcdef01234567"
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		switch req.URL.Path {
+		case "/repos/someone/action/git/ref/tags/v1":
+			fmt.Fprint(w, `{"ref":"refs/tags/v1"}`)
+		case "/repos/someone/action/commits/v1":
+			fmt.Fprintf(w, `{"sha":%q}`, sha)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	src := `on: push
+jobs:
+  test:
+    runs-on: ubuntu-latest
+    steps:
+      - uses: actions/checkout@v4
+      - uses: someone/action@v1
+      - uses: 'someone/action@v1'
+      - uses: someone/action@v1 # keep this comment
+      - uses: someone/action@` + sha + `
+      - uses: someone/action@v0
+`
+	want := `on: push
+jobs:
+  test:
+    runs-on: ubuntu-latest
+    steps:
+      - uses: actions/checkout@v4
+      - uses: someone/action@` + sha + ` # v1
+      - uses: 'someone/action@` + sha + `' # v1
+      - uses: someone/action@` + sha + ` # keep this comment
+      - uses: someone/action@` + sha + `
+      - uses: someone/action@v0
+`
+
+	for _, fix := range []bool{true, false} {
+		t.Run(fmt.Sprint(fix), func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "test.yaml")
+			if err := os.WriteFile(path, []byte(src), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			l, err := NewLinter(io.Discard, &LinterOptions{
+				Online:           true,
+				GitHubAPIURL:     srv.URL,
+				GitHubRepository: "owner/repo",
+				Fix:              fix,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := l.LintFile(path, nil); err != nil {
+				t.Fatal(err)
+			}
+
+			b, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			expected := src
+			if fix {
+				expected = want
+			}
+			if diff := cmp.Diff(expected, string(b)); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+	}
+}
+
+func TestLinterFixTypos(t *testing.T) {
+	src := `on: [pul_request, workflow_dispatch]
+jobs:
+  test:
+    runs-on: 'ubuntu-latst'
+    steps:
+      - uses: actions/checkout@v4
+        with:
+          fetch-dept: 0
+          fetch-tag: true
+  other:
+    runs-on: [self-hosted, linx]
+    steps:
+      - run: echo hello
+`
+	want := `on: [pull_request, workflow_dispatch]
+jobs:
+  test:
+    runs-on: 'ubuntu-latest'
+    steps:
+      - uses: actions/checkout@v4
+        with:
+          fetch-depth: 0
+          fetch-tags: true
+  other:
+    runs-on: [self-hosted, linux]
+    steps:
+      - run: echo hello
+`
+
+	path := filepath.Join(t.TempDir(), "test.yaml")
+	if err := os.WriteFile(path, []byte(src), 0644); err != nil {
+		t.Fatal(err)
+	}
+	l, err := NewLinter(io.Discard, &LinterOptions{Fix: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	errs, err := l.LintFile(path, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(errs) == 0 {
+		t.Fatal("no error was reported")
+	}
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(want, string(b)); diff != "" {
+		t.Fatal(diff)
+	}
+}
+
+func TestLinterOnlineChecksInvalidRepository(t *testing.T) {
+	for _, r := range []string{"owner", "owner/", "/repo", "owner/repo/foo"} {
+		t.Run(r, func(t *testing.T) {
+			_, err := NewLinter(io.Discard, &LinterOptions{Online: true, GitHubRepository: r})
+			if err == nil {
+				t.Fatal("error did not occur")
+			}
+			if msg := err.Error(); !strings.Contains(msg, `must be in "owner/repo" format`) {
+				t.Fatalf("unexpected error message: %q", msg)
+			}
+		})
+	}
+}
+
+func TestLinterOnlineGHES(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if h := req.Header.Get("Authorization"); h != "Bearer ghes-token" {
+			t.Errorf("unexpected Authorization header: %q", h)
+		}
+		switch req.URL.Path {
+		case "/api/v3/meta":
+			fmt.Fprint(w, `{"installed_version":"3.11.4"}`)
+		case "/api/v3/repos/owner/repo/actions/runners":
+			fmt.Fprint(w, `{"total_count":1,"runners":[{"name":"r1","labels":[{"name":"self-hosted"},{"name":"linux"}]}]}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	l, err := NewLinter(io.Discard, &LinterOptions{
+		Online:           true,
+		GitHubToken:      "github-token",
+		GHESURL:          srv.URL,
+		GHESToken:        "ghes-token",
+		GitHubRepository: "owner/repo",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	src := `on: merge_group
+jobs:
+  test:
+    runs-on: [self-hosted, linux, gpu]
+    steps:
+      - run: echo ...
+`
+	errs, err := l.Lint("test.yaml", []byte(src), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		`"merge_group" event is not available on GitHub Enterprise Server 3.11`,
+		`label "gpu" is not found in any runner registered to repository "owner/repo"`,
+	}
+	if len(errs) != len(want) {
+		t.Fatalf("wanted %d errors but got %v", len(want), errs)
+	}
+	for i, w := range want {
+		if msg := errs[i].Error(); !strings.Contains(msg, w) {
+			t.Errorf("error message %q does not contain %q", msg, w)
+		}
+	}
+}
+
+func TestLinterInvalidGHESURL(t *testing.T) {
+	tests := []struct {
+		opts *LinterOptions
+		want string
+	}{
+		{&LinterOptions{Online: true, GHESURL: "github.example.com"}, `must start with "https://" or "http://"`},
+		{&LinterOptions{Online: true, GHESURL: "https://github.com"}, "must not be github.com"},
+		{&LinterOptions{Online: true, GHESURL: "https://github.example.com", Platform: "gitea"}, "cannot be specified with Gitea Actions platform"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.opts.GHESURL, func(t *testing.T) {
+			_, err := NewLinter(io.Discard, tc.opts)
+			if err == nil {
+				t.Fatal("error did not occur")
+			}
+			if msg := err.Error(); !strings.Contains(msg, tc.want) {
+				t.Fatalf("error message %q does not contain %q", msg, tc.want)
+			}
+		})
+	}
+}
+
+func TestLinterGHESCompatibility(t *testing.T) {
+	src := `on:
+  push:
+  merge_group:
+run-name: Test
+permissions:
+  id-token: write
+jobs:
+  test:
+    runs-on: ubuntu-latest
+    steps:
+      - run: echo ${{ vars.FOO }} ${{ job.check_run_id }}
+  call:
+    uses: owner/repo/.github/workflows/reusable.yaml@v1
+    secrets: inherit
+`
+	tests := []struct {
+		version string
+		want    []string
+	}{
+		{
+			version: "3.4",
+			want: []string{
+				`test.yaml:3:3: "merge_group" event is not available on GitHub Enterprise Server 3.4. it is available since GitHub Enterprise Server 3.12`,
+				`test.yaml:4:11: "run-name:" is not available on GitHub Enterprise Server 3.4. it is available since GitHub Enterprise Server 3.8`,
+				`test.yaml:6:3: permission scope "id-token" is not available on GitHub Enterprise Server 3.4. it is available since GitHub Enterprise Server 3.5`,
+				`test.yaml:9:14: label "ubuntu-latest" is for GitHub-hosted runners`,
+				`test.yaml:11:23: "vars" context is not available on GitHub Enterprise Server 3.4. it is available since GitHub Enterprise Server 3.8`,
+				`test.yaml:11:39: property "check_run_id" of "job" context is not available on GitHub Enterprise Server 3.4. no version of GitHub Enterprise Server supports it yet`,
+				`test.yaml:13:11: "secrets: inherit" is not available on GitHub Enterprise Server 3.4. it is available since GitHub Enterprise Server 3.6`,
+			},
+		},
+		{
+			version: "3.12",
+			want: []string{
+				`test.yaml:9:14: label "ubuntu-latest" is for GitHub-hosted runners`,
+				`test.yaml:11:39: property "check_run_id" of "job" context is not available on GitHub Enterprise Server 3.12`,
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.version, func(t *testing.T) {
+			l, err := NewLinter(io.Discard, &LinterOptions{GHESVersion: tc.version})
+			if err != nil {
+				t.Fatal(err)
+			}
+			errs, err := l.Lint("test.yaml", []byte(src), nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(errs) != len(tc.want) {
+				t.Fatalf("wanted %d errors but got %d: %v", len(tc.want), len(errs), errs)
+			}
+			for i, want := range tc.want {
+				if msg := errs[i].Error(); !strings.Contains(msg, want) {
+					t.Errorf("error message %q does not contain %q", msg, want)
+				}
+			}
+		})
+	}
+}
+
+func TestLinterGHESVersionFromConfig(t *testing.T) {
+	cfg, err := ParseConfig([]byte(`ghes: "3.12"`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	proj := &Project{root: ".", config: cfg}
+	src := "on: push\njobs:\n  test:\n    runs-on: ubuntu-latest\n    steps:\n      - run: echo\n"
+
+	l, err := NewLinter(io.Discard, &LinterOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	errs, err := l.Lint("test.yaml", []byte(src), proj)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(errs) != 1 || !strings.Contains(errs[0].Message, "not available on GitHub Enterprise Server 3.12") {
+		t.Fatalf("wanted one error for GitHub-hosted runner label but got %v", errs)
+	}
+}
+
+func TestLinterInvalidGHESVersion(t *testing.T) {
+	_, err := NewLinter(io.Discard, &LinterOptions{GHESVersion: "latest"})
+	if err == nil {
+		t.Fatal("error did not occur")
+	}
+	if msg := err.Error(); !strings.Contains(msg, `must be in "{major}.{minor}" format`) {
+		t.Fatalf("unexpected error message: %q", msg)
+	}
+}
+
+func TestLinterInvalidParallelism(t *testing.T) {
+	for _, opts := range []*LinterOptions{{Jobs: -1}, {RuleJobs: -1}} {
+		_, err := NewLinter(io.Discard, opts)
+		if err == nil {
+			t.Fatalf("error did not occur for %+v", opts)
+		}
+		if msg := err.Error(); !strings.Contains(msg, "must not be negative") {
+			t.Fatalf("unexpected error message: %q", msg)
+		}
+	}
+}
+
+func TestLinterInvalidCacheTTL(t *testing.T) {
+	_, err := NewLinter(io.Discard, &LinterOptions{Online: true, CacheTTL: -time.Second})
+	if err == nil {
+		t.Fatal("error did not occur")
+	}
+	if msg := err.Error(); !strings.Contains(msg, "TTL of cache of online checks must not be negative") {
+		t.Fatalf("unexpected error message: %q", msg)
+	}
+}
+
+func TestLinterInvalidAllowEnv(t *testing.T) {
+	for _, n := range []string{"", "FOO=bar"} {
+		_, err := NewLinter(io.Discard, &LinterOptions{AllowEnv: []string{n}})
+		if err == nil {
+			t.Fatalf("error did not occur for %q", n)
+		}
+		if msg := err.Error(); !strings.Contains(msg, "invalid name of environment variable") {
+			t.Fatalf("unexpected error message: %q", msg)
+		}
+	}
+}
+
+func TestLinterPlatformGitea(t *testing.T) {
+	src := `on:
+  push:
+  merge_group:
+jobs:
+  test:
+    runs-on: [docker, my-runner]
+    timeout-minutes: 10
+    steps:
+      - uses: https://gitea.com/actions/checkout@v4
+      - uses: https://github.com/actions/checkout@v4
+        with:
+          foo: bar
+      - uses: https://gitea.com/actions
+      - run: echo ${{ gitea.ref_name }} ${{ gitea.unknown }}
+`
+	want := []string{
+		`test.yaml:3:3: "merge_group" event is not supported by Gitea Actions`,
+		`test.yaml:7:22: "timeout-minutes:" at job level is not supported by Gitea Actions and it is ignored`,
+		`test.yaml:12:11: input "foo" is not defined in action "actions/checkout@v4"`,
+		`test.yaml:13:15: specifying action "https://gitea.com/actions" in invalid format`,
+		`test.yaml:14:45: property "unknown" is not defined in object type`,
+	}
+
+	l, err := NewLinter(io.Discard, &LinterOptions{Platform: "gitea"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	errs, err := l.Lint("test.yaml", []byte(src), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(errs) != len(want) {
+		t.Fatalf("wanted %d errors but got %d: %v", len(want), len(errs), errs)
+	}
+	for i, w := range want {
+		if msg := errs[i].Error(); !strings.Contains(msg, w) {
+			t.Errorf("error message %q does not contain %q", msg, w)
+		}
+	}
+
+	// Without the platform, Gitea-specific syntax is reported
+	l, err = NewLinter(io.Discard, &LinterOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	errs, err = l.Lint("test.yaml", []byte(src), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, err := range errs {
+		if err.Kind == "platform" {
+			t.Errorf("platform rule should be disabled by default: %v", err)
+		}
+	}
+}
+
+func TestLinterPlatformFromConfig(t *testing.T) {
+	d := t.TempDir()
+	testEnsureDotGitDir(d)
+	w := filepath.Join(d, ".forgejo", "workflows")
+	if err := os.MkdirAll(w, 0750); err != nil {
+		t.Fatal(err)
+	}
+	src := "on: page_build\njobs:\n  test:\n    runs-on: docker\n    steps:\n      - run: echo ${{ gitea.sha }}\n"
+	if err := os.WriteFile(filepath.Join(w, "test.yaml"), []byte(src), 0644); err != nil {
+		t.Fatal(err)
+	}
+	cfg := filepath.Join(d, "actionlint.yaml")
+	if err := os.WriteFile(cfg, []byte("platform: forgejo\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	l, err := NewLinter(io.Discard, &LinterOptions{ConfigFile: cfg})
+	if err != nil {
+		t.Fatal(err)
+	}
+	errs, err := l.LintRepository(d)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(errs) != 1 || !strings.Contains(errs[0].Message, `"page_build" event is not supported by Forgejo Actions`) {
+		t.Fatalf("wanted one error for unsupported event but got %v", errs)
+	}
+}
+
+func TestLinterPlatformWithGHESVersion(t *testing.T) {
+	_, err := NewLinter(io.Discard, &LinterOptions{Platform: "gitea", GHESVersion: "3.12"})
+	if err == nil {
+		t.Fatal("error did not occur")
+	}
+	if msg := err.Error(); !strings.Contains(msg, "cannot be specified with Gitea Actions platform") {
+		t.Fatalf("unexpected error message: %q", msg)
+	}
+}
+
+func BenchmarkLintWorkflowFiles(b *testing.B) {
+	scripts := filepath.Join("testdata", "bench", "many_scripts.yaml")
+	small := filepath.Join("testdata", "bench", "small.yaml")
+	large := filepath.Join("testdata", "bench", "large.yaml")
+	min := filepath.Join("testdata", "bench", "minimal.yaml")
+	proj := &Project{root: "."}
+	shellcheck, err := execabs.LookPath("shellcheck")
+	if err != nil {
+		b.Skipf("shellcheck is not found: %s", err)
+	}
+	format := "{{range $ := .}}### Error at line {{$.Line}}, col {{$.Column}} of `{{$.Filepath}}`\\n\\n{{$.Message}}\\n\\n```\\n{{$.Snippet}}\\n```\\n\\n{{end}}"
+
+	// Workflow files for this repository
+	ours := []string{}
+	{
+		d := filepath.Join(".github", "workflows")
+		es, err := os.ReadDir(d)
+		if err != nil {
+			panic(err)
+		}
+		for _, e := range es {
+			ours = append(ours, filepath.Join(d, e.Name()))
+		}
+	}
+
+	bms := []struct {
+		what       string
+		files      []string
+		shellcheck string
+		format     string
+	}{
+		{
+			what:  "minimal",
+			files: []string{min},
+		},
+		{
+			what:  "minimal",
+			files: []string{min, min, min, min, min},
+		},
+		{
+			what:  "minimal",
+			files: []string{min, min, min, min, min, min, min, min, min, min, min, min, min, min, min, min, min, min, min, min},
 		},
 		{
 			what: "minimal",
@@ -902,6 +2310,66 @@ func BenchmarkExamplesLintFiles(b *testing.B) {
 	}
 }
 
+// BenchmarkLintManyFilesMemory measures the memory usage of linting many workflow files like a huge
+// repository. "live-B" metric is the live heap size when all files were checked. It includes the
+// state of all files kept until the errors are printed.
+func BenchmarkLintManyFilesMemory(b *testing.B) {
+	src, err := os.ReadFile(filepath.Join("testdata", "bench", "large.yaml"))
+	if err != nil {
+		panic(err)
+	}
+	// Some files have an error so that their sources are needed to print the errors
+	bad := bytes.Replace(src, []byte("ubuntu-latest"), []byte("ubuntu-latset"), 1)
+
+	dir := b.TempDir()
+	files := make([]string, 0, 1000)
+	for i := range cap(files) {
+		f := filepath.Join(dir, fmt.Sprintf("workflow%d.yaml", i))
+		s := src
+		if i%10 == 0 {
+			s = bad
+		}
+		if err := os.WriteFile(f, s, 0644); err != nil {
+			b.Fatal(err)
+		}
+		files = append(files, f)
+	}
+	proj := &Project{root: dir}
+
+	var live uint64
+	for i := 0; i < b.N; i++ {
+		var before, after runtime.MemStats
+		runtime.GC()
+		runtime.ReadMemStats(&before)
+
+		var finished atomic.Int64
+		opts := LinterOptions{
+			OnFileFinished: func(string, []*Error, time.Duration) {
+				if finished.Add(1) == int64(len(files)) {
+					runtime.GC()
+					runtime.ReadMemStats(&after)
+				}
+			},
+		}
+		l, err := NewLinter(io.Discard, &opts)
+		if err != nil {
+			b.Fatal(err)
+		}
+		l.defaultConfig = &Config{}
+		errs, err := l.LintFiles(files, proj)
+		if err != nil {
+			b.Fatal(err)
+		}
+		if len(errs) != len(files)/10 {
+			b.Fatalf("wanted %d errors but got %d", len(files)/10, len(errs))
+		}
+		if after.HeapAlloc > before.HeapAlloc {
+			live += after.HeapAlloc - before.HeapAlloc
+		}
+	}
+	b.ReportMetric(float64(live)/float64(b.N), "live-B")
+}
+
 func BenchmarkLintRepository(b *testing.B) {
 	for i := 0; i < b.N; i++ {
 		opts := LinterOptions{}
@@ -918,3 +2386,166 @@ func BenchmarkLintRepository(b *testing.B) {
 		}
 	}
 }
+
+func TestLinterRunExternalCommandsInContainer(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake container runtime is a shell script")
+	}
+
+	dir := t.TempDir()
+	log := filepath.Join(dir, "log")
+
+	// Fake container runtime records its arguments and behaves as shellcheck or the plugin
+	rt := filepath.Join(dir, "runtime")
+	script := `#!/bin/sh
+echo "$@" >> "` + log + `"
+cat > /dev/null
+case "$6" in
+  shellcheck-image) echo '[{"file":"-","line":1,"column":6,"level":"warning","code":2086,"message":"Double quote to prevent globbing and word splitting."}]' ;;
+  plugin-image) echo '{"errors":[{"message":"found by plugin","line":1,"column":1}]}' ;;
+esac
+`
+	if err := os.WriteFile(rt, []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+	cfg := filepath.Join(dir, "actionlint.yaml")
+	conf := `containers:
+  shellcheck:
+    image: shellcheck-image
+    runtime: ` + rt + `
+plugins:
+  - name: in-container
+    command: check --strict
+    container:
+      image: plugin-image
+      runtime: ` + rt + `
+`
+	if err := os.WriteFile(cfg, []byte(conf), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// The executables on the host are not used
+	opts := &LinterOptions{ConfigFile: cfg, Shellcheck: "this-shellcheck-does-not-exist", Pyflakes: "", TrustConfig: true}
+	l, err := NewLinter(io.Discard, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	src := "on: push\njobs:\n  test:\n    runs-on: ubuntu-latest\n    steps:\n      - run: echo $FOO\n"
+	errs, err := l.Lint("test.yaml", []byte(src), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	kinds := []string{}
+	for _, e := range errs {
+		kinds = append(kinds, e.Kind)
+	}
+	slices.Sort(kinds)
+	if want := []string{"in-container", "shellcheck"}; !slices.Equal(want, kinds) {
+		t.Fatalf("wanted errors from %v but got %v", want, errs)
+	}
+
+	b, err := os.ReadFile(log)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"run --rm -i --network none shellcheck-image --norc",
+		"run --rm -i --network none plugin-image check --strict\n",
+	} {
+		if !strings.Contains(string(b), want) {
+			t.Errorf("container runtime was not run with %q: %q", want, b)
+		}
+	}
+
+	// Containers in the config file are not used unless the config file is trusted
+	if err := os.Remove(log); err != nil {
+		t.Fatal(err)
+	}
+	var out strings.Builder
+	opts = &LinterOptions{ConfigFile: cfg, Shellcheck: "this-shellcheck-does-not-exist", Pyflakes: "", LogWriter: &out}
+	l, err = NewLinter(io.Discard, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	errs, err = l.Lint("test.yaml", []byte(src), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(errs) != 0 {
+		t.Fatalf("wanted no error but got %v", errs)
+	}
+	if _, err := os.Stat(log); err == nil {
+		t.Fatal("container runtime was run though the config file is not trusted")
+	}
+	if want := `warning: "plugins", "containers" in config file are ignored`; !strings.Contains(out.String(), want) {
+		t.Fatalf("log %q does not contain %q", out.String(), want)
+	}
+}
+
+func TestLinterRunExternalCommandsOnRemote(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake SSH client is a shell script")
+	}
+
+	dir := t.TempDir()
+	log := filepath.Join(dir, "log")
+
+	// Fake SSH client records its arguments and behaves as pyflakes
+	ssh := filepath.Join(dir, "ssh")
+	script := `#!/bin/sh
+echo "$@" >> "` + log + `"
+cat > /dev/null
+echo "<stdin>:1:7: undefined name 'foo'"
+`
+	if err := os.WriteFile(ssh, []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+	cfg := filepath.Join(dir, "actionlint.yaml")
+	conf := "remotes:\n  pyflakes:\n    host: lint-farm\n    ssh: " + ssh + "\n"
+	if err := os.WriteFile(cfg, []byte(conf), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	opts := &LinterOptions{ConfigFile: cfg, Shellcheck: "", Pyflakes: "this-pyflakes-does-not-exist", TrustConfig: true}
+	l, err := NewLinter(io.Discard, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	src := "on: push\njobs:\n  test:\n    runs-on: ubuntu-latest\n    steps:\n      - run: print(foo)\n        shell: python\n"
+	errs, err := l.Lint("test.yaml", []byte(src), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(errs) != 1 || errs[0].Kind != "pyflakes" {
+		t.Fatalf("wanted one pyflakes error but got %v", errs)
+	}
+
+	b, err := os.ReadFile(log)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "-T -o BatchMode=yes -- lint-farm pyflakes\n"; string(b) != want {
+		t.Fatalf("wanted SSH client to be run with %q but got %q", want, b)
+	}
+
+	// Scripts are not sent to the remote machine unless the config file is trusted
+	if err := os.Remove(log); err != nil {
+		t.Fatal(err)
+	}
+	var out strings.Builder
+	opts = &LinterOptions{ConfigFile: cfg, Shellcheck: "", Pyflakes: "this-pyflakes-does-not-exist", LogWriter: &out}
+	l, err = NewLinter(io.Discard, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := l.Lint("test.yaml", []byte(src), nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(log); err == nil {
+		t.Fatal("SSH client was run though the config file is not trusted")
+	}
+	if want := `warning: "remotes" in config file are ignored`; !strings.Contains(out.String(), want) {
+		t.Fatalf("log %q does not contain %q", out.String(), want)
+	}
+}
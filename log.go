@@ -0,0 +1,62 @@
+package actionlint
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+)
+
+// Log formats of LinterOptions.LogFormat.
+const (
+	// LogFormatText outputs each log record in one line of "key=value" pairs.
+	LogFormatText = "text"
+	// LogFormatJSON outputs each log record in one line of JSON object.
+	LogFormatJSON = "json"
+)
+
+// newLogger creates a new structured logger which outputs log records at the level to the writer
+// in the format. The format is one of LogFormatText and LogFormatJSON. Empty format means
+// LogFormatText.
+func newLogger(out io.Writer, level LogLevel, format string) (*slog.Logger, error) {
+	if level == LogLevelNone {
+		return slog.New(slog.DiscardHandler), nil
+	}
+	lv := slog.LevelInfo
+	if level >= LogLevelDebug {
+		lv = slog.LevelDebug
+	}
+	opts := &slog.HandlerOptions{Level: lv}
+	switch format {
+	case "", LogFormatText:
+		return slog.New(slog.NewTextHandler(out, opts)), nil
+	case LogFormatJSON:
+		return slog.New(slog.NewJSONHandler(out, opts)), nil
+	default:
+		return nil, fmt.Errorf("log format must be %q or %q but got %q", LogFormatText, LogFormatJSON, format)
+	}
+}
+
+// logWriter is an io.Writer which converts debug logs written by components such as caches and
+// rules into structured log records. Components write each log message as one line with the prefix
+// of their names like "[LintCache] message". The name is recorded as "component" attribute.
+type logWriter struct {
+	logger *slog.Logger
+}
+
+// Write implements io.Writer interface. Each call is recorded as one log record.
+func (w *logWriter) Write(p []byte) (int, error) {
+	msg := string(bytes.TrimRight(p, "\n"))
+	if msg == "" {
+		return len(p), nil
+	}
+	if strings.HasPrefix(msg, "[") {
+		if c, m, ok := strings.Cut(msg[1:], "] "); ok && !strings.ContainsAny(c, " \n") {
+			w.logger.Debug(m, "component", c)
+			return len(p), nil
+		}
+	}
+	w.logger.Debug(msg)
+	return len(p), nil
+}
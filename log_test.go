@@ -0,0 +1,111 @@
+package actionlint
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestLogNewLogger(t *testing.T) {
+	var b strings.Builder
+	l, err := newLogger(&b, LogLevelVerbose, LogFormatText)
+	if err != nil {
+		t.Fatal(err)
+	}
+	l.Info("hello", "path", "test.yaml")
+	l.Debug("should not be output")
+	out := b.String()
+	if !strings.Contains(out, `level=INFO msg=hello path=test.yaml`) || strings.Contains(out, "should not") {
+		t.Fatalf("unexpected text log: %q", out)
+	}
+
+	b.Reset()
+	l, err = newLogger(&b, LogLevelDebug, LogFormatJSON)
+	if err != nil {
+		t.Fatal(err)
+	}
+	l.Debug("hello", "errors", 3)
+	var rec map[string]any
+	if err := json.Unmarshal([]byte(b.String()), &rec); err != nil {
+		t.Fatalf("log is not JSON: %q: %v", b.String(), err)
+	}
+	if rec["level"] != "DEBUG" || rec["msg"] != "hello" || rec["errors"] != 3.0 {
+		t.Fatalf("unexpected JSON log: %v", rec)
+	}
+
+	b.Reset()
+	l, err = newLogger(&b, LogLevelNone, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	l.Info("hello")
+	if b.Len() != 0 {
+		t.Fatalf("log was output with LogLevelNone: %q", b.String())
+	}
+
+	if _, err := newLogger(io.Discard, LogLevelDebug, "yaml"); err == nil || !strings.Contains(err.Error(), `"yaml"`) {
+		t.Fatalf("unexpected error for invalid format: %v", err)
+	}
+}
+
+func TestLogWriterComponent(t *testing.T) {
+	var b strings.Builder
+	l, err := newLogger(&b, LogLevelDebug, LogFormatText)
+	if err != nil {
+		t.Fatal(err)
+	}
+	w := &logWriter{l}
+	io.WriteString(w, "[LintCache] Cache hit for test.yaml\n")
+	io.WriteString(w, "[not a component] foo\n")
+	io.WriteString(w, "\n")
+
+	lines := strings.Split(strings.TrimSuffix(b.String(), "\n"), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("wanted 2 log records but got %q", lines)
+	}
+	if !strings.HasSuffix(lines[0], `msg="Cache hit for test.yaml" component=LintCache`) {
+		t.Errorf("component was not parsed: %q", lines[0])
+	}
+	if !strings.HasSuffix(lines[1], `msg="[not a component] foo"`) {
+		t.Errorf("message was modified: %q", lines[1])
+	}
+}
+
+func TestLogLinterJSONDebugLogs(t *testing.T) {
+	var b strings.Builder
+	opts := &LinterOptions{Debug: true, LogFormat: LogFormatJSON, LogWriter: &b, Shellcheck: "", Pyflakes: ""}
+	l, err := NewLinter(io.Discard, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := l.Lint("test.yaml", []byte("on: push\njobs:\n  test:\n    runs-on: ubuntu-latest\n    steps:\n      - run: echo\n"), nil); err != nil {
+		t.Fatal(err)
+	}
+
+	msgs := map[string]map[string]any{}
+	for _, line := range strings.Split(strings.TrimSpace(b.String()), "\n") {
+		var rec map[string]any
+		if err := json.Unmarshal([]byte(line), &rec); err != nil {
+			t.Fatalf("log line is not JSON: %q: %v", line, err)
+		}
+		msgs[rec["msg"].(string)] = rec
+	}
+	rec, ok := msgs["Enabled rules"]
+	if !ok {
+		t.Fatalf("enabled rules were not logged: %q", b.String())
+	}
+	if rec["level"] != "DEBUG" || rec["path"] != "test.yaml" {
+		t.Fatalf("unexpected record: %v", rec)
+	}
+	if _, ok := msgs["Checked file"]; !ok {
+		t.Fatalf("finishing file was not logged: %q", b.String())
+	}
+}
+
+func TestLogLinterInvalidFormat(t *testing.T) {
+	_, err := NewLinter(io.Discard, &LinterOptions{Verbose: true, LogFormat: "xml"})
+	if err == nil || !strings.Contains(err.Error(), "log format") {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,260 @@
+package actionlint
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"os"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// MessageCatalog is a catalog of translated error messages for a locale. Each entry maps the format
+// string of an error message in English like "job %q needs job %q which does not exist in this
+// workflow" to its translation. Verbs like %q in the translation are replaced with the texts of the
+// corresponding arguments in the original message. Explicit argument indexes like %[2]q can be used
+// to reorder the arguments. Rule names and codes of errors are not translated so that they are
+// stable across locales.
+type MessageCatalog struct {
+	locale  string
+	entries []*messageCatalogEntry
+}
+
+type messageCatalogEntry struct {
+	pattern *regexp.Regexp
+	// groups is the indexes of capture groups of the pattern for each argument. -1 means the argument
+	// does not appear in the original message.
+	groups []int
+	// pieces is the translation. Argument pieces are denoted by non-negative arg.
+	pieces []messagePiece
+	// literal is the length of literal texts in the original message. Entries with longer literals
+	// are matched first since they are more specific.
+	literal int
+}
+
+type messagePiece struct {
+	text string
+	arg  int
+}
+
+// messageVerb is a verb in a format string like %q or %[2]d.
+type messageVerb struct {
+	start int
+	end   int
+	arg   int
+}
+
+var reMessageVerb = regexp.MustCompile(`%[-+# 0]*(?:\[(\d+)\])?(?:\d+|\*)?(?:\.(?:\d+|\*)?)?([a-zA-Z%])`)
+
+// parseMessageVerbs parses verbs in the format string. Arguments of the verbs are 0-based. "%%" is
+// not included in the returned verbs.
+func parseMessageVerbs(format string) ([]messageVerb, error) {
+	vs := []messageVerb{}
+	next := 0
+	for _, m := range reMessageVerb.FindAllStringSubmatchIndex(format, -1) {
+		if format[m[4]] == '%' {
+			if m[1]-m[0] != 2 {
+				return nil, fmt.Errorf("invalid verb %q", format[m[0]:m[1]])
+			}
+			continue
+		}
+		if strings.Contains(format[m[0]:m[1]], "*") {
+			return nil, fmt.Errorf("verb %q with * is not supported", format[m[0]:m[1]])
+		}
+		arg := next
+		if m[2] >= 0 {
+			i, err := strconv.Atoi(format[m[2]:m[3]])
+			if err != nil || i == 0 {
+				return nil, fmt.Errorf("invalid argument index in verb %q", format[m[0]:m[1]])
+			}
+			arg = i - 1
+		}
+		vs = append(vs, messageVerb{m[0], m[1], arg})
+		next = arg + 1
+	}
+	return vs, nil
+}
+
+func newMessageCatalogEntry(format, translation string) (*messageCatalogEntry, error) {
+	vs, err := parseMessageVerbs(format)
+	if err != nil {
+		return nil, err
+	}
+
+	var b strings.Builder
+	b.WriteString(`(?s)^`)
+	literal := 0
+	prev := 0
+	args := 0
+	groups := []int{}
+	for i, v := range vs {
+		lit := strings.ReplaceAll(format[prev:v.start], "%%", "%")
+		literal += len(lit)
+		b.WriteString(regexp.QuoteMeta(lit))
+		b.WriteString(`(.*?)`)
+		for len(groups) <= v.arg {
+			groups = append(groups, -1)
+		}
+		if groups[v.arg] < 0 {
+			groups[v.arg] = i + 1
+		}
+		args = max(args, v.arg+1)
+		prev = v.end
+	}
+	lit := strings.ReplaceAll(format[prev:], "%%", "%")
+	literal += len(lit)
+	b.WriteString(regexp.QuoteMeta(lit))
+	b.WriteString(`$`)
+	if literal == 0 {
+		return nil, fmt.Errorf("message %q has no text other than verbs", format)
+	}
+
+	tvs, err := parseMessageVerbs(translation)
+	if err != nil {
+		return nil, fmt.Errorf("translation of message %q is invalid: %w", format, err)
+	}
+	pieces := []messagePiece{}
+	prev = 0
+	for _, v := range tvs {
+		if v.arg >= args || groups[v.arg] < 0 {
+			return nil, fmt.Errorf("verb %q in translation of message %q refers to argument #%d but the message has only %d arguments", translation[v.start:v.end], format, v.arg+1, args)
+		}
+		if prev < v.start {
+			pieces = append(pieces, messagePiece{strings.ReplaceAll(translation[prev:v.start], "%%", "%"), -1})
+		}
+		pieces = append(pieces, messagePiece{"", v.arg})
+		prev = v.end
+	}
+	if prev < len(translation) {
+		pieces = append(pieces, messagePiece{strings.ReplaceAll(translation[prev:], "%%", "%"), -1})
+	}
+
+	r, err := regexp.Compile(b.String())
+	if err != nil {
+		return nil, fmt.Errorf("could not compile message %q: %w", format, err)
+	}
+	return &messageCatalogEntry{r, groups, pieces, literal}, nil
+}
+
+func (e *messageCatalogEntry) translate(msg string) (string, bool) {
+	m := e.pattern.FindStringSubmatch(msg)
+	if m == nil {
+		return "", false
+	}
+	var b strings.Builder
+	for _, p := range e.pieces {
+		if p.arg < 0 {
+			b.WriteString(p.text)
+		} else {
+			b.WriteString(m[e.groups[p.arg]])
+		}
+	}
+	return b.String(), true
+}
+
+// NewMessageCatalog creates a new message catalog for the locale from the map of English format
+// strings of error messages to their translations. It returns an error when some translation refers
+// to an argument which does not exist in the original message.
+func NewMessageCatalog(locale string, messages map[string]string) (*MessageCatalog, error) {
+	c := &MessageCatalog{locale: locale}
+	for _, k := range slices.Sorted(maps.Keys(messages)) {
+		e, err := newMessageCatalogEntry(k, messages[k])
+		if err != nil {
+			return nil, fmt.Errorf("invalid message catalog for locale %q: %w", locale, err)
+		}
+		c.entries = append(c.entries, e)
+	}
+	slices.SortStableFunc(c.entries, func(a, b *messageCatalogEntry) int {
+		return b.literal - a.literal
+	})
+	return c, nil
+}
+
+// LoadMessageCatalog loads the message catalog from the JSON file. The file contains an object with
+// "locale" and "messages" keys. "messages" is an object which maps English format strings of error
+// messages to their translations.
+//
+//	{
+//	  "locale": "ja",
+//	  "messages": {
+//	    "job %q needs job %q which does not exist in this workflow": "ジョブ %q が依存しているジョブ %q はこのワークフローに存在しません"
+//	  }
+//	}
+func LoadMessageCatalog(path string) (*MessageCatalog, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not read message catalog file %q: %w", path, err)
+	}
+	var f struct {
+		Locale   string            `json:"locale"`
+		Messages map[string]string `json:"messages"`
+	}
+	if err := json.Unmarshal(b, &f); err != nil {
+		return nil, fmt.Errorf("could not parse message catalog file %q: %w", path, err)
+	}
+	if f.Locale == "" {
+		f.Locale = path
+	}
+	return NewMessageCatalog(f.Locale, f.Messages)
+}
+
+// builtinMessageCatalogs is the translations of error messages bundled with actionlint. Keys are
+// language codes of locales.
+var builtinMessageCatalogs = map[string]map[string]string{
+	"ja": messageCatalogJA,
+}
+
+// MessageCatalogForLocale returns the message catalog for the locale. The locale is a language code
+// like "ja" or a locale name like "ja_JP.UTF-8". When the locale is a path to a JSON file, the
+// catalog is loaded from the file by LoadMessageCatalog. It returns nil without error for empty
+// string and English locales since the original messages are in English.
+func MessageCatalogForLocale(locale string) (*MessageCatalog, error) {
+	if strings.HasSuffix(locale, ".json") {
+		return LoadMessageCatalog(locale)
+	}
+	lang := strings.ToLower(locale)
+	if i := strings.IndexAny(lang, "_-.@"); i >= 0 {
+		lang = lang[:i]
+	}
+	switch lang {
+	case "", "en", "c", "posix":
+		return nil, nil
+	}
+	m, ok := builtinMessageCatalogs[lang]
+	if !ok {
+		return nil, fmt.Errorf("message catalog for locale %q is not available. available locales are \"en\", %s. or specify the path to the JSON file of message catalog", locale, sortedQuotes(slices.Collect(maps.Keys(builtinMessageCatalogs))))
+	}
+	return NewMessageCatalog(lang, m)
+}
+
+// Locale returns the locale of the catalog.
+func (c *MessageCatalog) Locale() string {
+	return c.locale
+}
+
+// Translate translates the error message. It returns the message as-is when no translation for the
+// message is found in the catalog.
+func (c *MessageCatalog) Translate(msg string) string {
+	for _, e := range c.entries {
+		if t, ok := e.translate(msg); ok {
+			return t
+		}
+	}
+	return msg
+}
+
+// translateErrors translates messages of the errors and their related locations in place. It does
+// nothing when the receiver is nil.
+func (c *MessageCatalog) translateErrors(errs []*Error) {
+	if c == nil {
+		return
+	}
+	for _, err := range errs {
+		err.Message = c.Translate(err.Message)
+		for _, r := range err.Related {
+			r.Message = c.Translate(r.Message)
+		}
+	}
+}
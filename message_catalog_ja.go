@@ -0,0 +1,22 @@
+package actionlint
+
+// messageCatalogJA is the Japanese translations of error messages.
+var messageCatalogJA = map[string]string{
+	"\"if\" condition should be type \"bool\" but got type %q":                                                "\"if\" の条件は \"bool\" 型であるべきですが型 %q でした",
+	"\"runs-on\" section is missing in job %q":                                                                "ジョブ %q に \"runs-on\" セクションがありません",
+	"\"steps\" section is missing in job %q":                                                                  "ジョブ %q に \"steps\" セクションがありません",
+	"could not parse as YAML: %s":                                                                             "YAML としてパースできませんでした: %s",
+	"conflicting label is defined here":                                                                       "競合するラベルはここで定義されています",
+	"environment variable name %q is invalid. it must not start with a digit":                                 "環境変数名 %q は不正です。数字で始めることはできません",
+	"invalid %s ID %q. %s ID must start with a letter or _ and contain only alphanumeric characters, -, or _": "%[1]s ID %[2]q は不正です。%[1]s ID は英字または _ で始まり、英数字、-、_ のみを含む必要があります",
+	"invalid CRON format %q in schedule event: %s":                                                            "schedule イベントの CRON 形式 %q が不正です: %s",
+	"job %q needs job %q which does not exist in this workflow":                                               "ジョブ %q が依存しているジョブ %q はこのワークフローに存在しません",
+	"job ID %q duplicates in \"needs\" section. note that job ID is case insensitive":                         "ジョブ ID %q が \"needs\" セクションで重複しています。ジョブ ID は大文字と小文字を区別しないことに注意してください",
+	"label %q conflicts with label %q defined at %s. note: to run your job on each workers, use matrix":       "ラベル %[1]q は %[3]s で定義されたラベル %[2]q と競合しています。注: 各ワーカーでジョブを実行するには matrix を使ってください",
+	"property %q is not defined in object type %s":                                                            "プロパティ %q はオブジェクト型 %s に定義されていません",
+	"pyflakes reported issue in this script: %s":                                                              "pyflakes がこのスクリプトの問題を報告しました: %s",
+	"shellcheck reported issue in this script: SC%d:%s:%d:%d: %s":                                             "shellcheck がこのスクリプトの問題を報告しました: SC%d:%s:%d:%d: %s",
+	"type of expression must be bool but found type %s":                                                       "式の型は bool であるべきですが型 %s でした",
+	"undefined function %q. available functions are %s":                                                       "未定義の関数 %q です。利用可能な関数は %s です",
+	"undefined variable %q. available variables are %s":                                                       "未定義の変数 %q です。利用可能な変数は %s です",
+}
@@ -0,0 +1,179 @@
+package actionlint
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestMessageCatalogTranslate(t *testing.T) {
+	c, err := NewMessageCatalog("test", map[string]string{
+		"label %q conflicts with label %q defined at %s": "%[3]s: %[2]q <-> %[1]q",
+		"job %q needs job %q":                            "JOB %q NEEDS %q",
+		"%d%% done":                                      "%d%% DONE",
+		"constant message":                               "CONSTANT",
+		"value %q is invalid: %v":                        "INVALID: %[1]q",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Locale() != "test" {
+		t.Fatal("unexpected locale", c.Locale())
+	}
+
+	tests := []struct {
+		msg  string
+		want string
+	}{
+		{`label "a" conflicts with label "b" defined at line:3,col:5`, `line:3,col:5: "b" <-> "a"`},
+		{`job "foo" needs job "bar"`, `JOB "foo" NEEDS "bar"`},
+		{`job "foo" depends on job "bar"`, `job "foo" depends on job "bar"`},
+		{`50% done`, `50% DONE`},
+		{`constant message`, `CONSTANT`},
+		{`value "a\nb" is invalid: oops`, `INVALID: "a\nb"`},
+		{"multi\nline", "multi\nline"},
+	}
+	for _, tc := range tests {
+		if have := c.Translate(tc.msg); have != tc.want {
+			t.Errorf("wanted %q but have %q for message %q", tc.want, have, tc.msg)
+		}
+	}
+}
+
+func TestMessageCatalogInvalidTranslation(t *testing.T) {
+	tests := []struct {
+		msgs map[string]string
+		want string
+	}{
+		{map[string]string{"job %q": "%q %q"}, "refers to argument #2 but the message has only 1 arguments"},
+		{map[string]string{"job %q": "%[3]q"}, "refers to argument #3"},
+		{map[string]string{"%s%d": "%s"}, "has no text other than verbs"},
+		{map[string]string{"job %[0]q": "%q"}, "invalid argument index"},
+		{map[string]string{"job %*d": "%d"}, "is not supported"},
+	}
+	for _, tc := range tests {
+		_, err := NewMessageCatalog("test", tc.msgs)
+		if err == nil {
+			t.Errorf("error did not occur for %v", tc.msgs)
+			continue
+		}
+		if msg := err.Error(); !strings.Contains(msg, tc.want) {
+			t.Errorf("error message %q does not contain %q", msg, tc.want)
+		}
+	}
+}
+
+func TestMessageCatalogForLocale(t *testing.T) {
+	for _, l := range []string{"", "en", "en_US.UTF-8", "C", "POSIX"} {
+		c, err := MessageCatalogForLocale(l)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if c != nil {
+			t.Errorf("catalog was returned for locale %q", l)
+		}
+	}
+
+	for _, l := range []string{"ja", "ja_JP.UTF-8", "ja-JP"} {
+		c, err := MessageCatalogForLocale(l)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if c == nil || c.Locale() != "ja" {
+			t.Errorf("catalog for Japanese was not returned for locale %q: %v", l, c)
+		}
+	}
+
+	_, err := MessageCatalogForLocale("xx_YY")
+	if err == nil || !strings.Contains(err.Error(), `available locales are "en", "ja"`) {
+		t.Fatalf("unexpected error for unknown locale: %v", err)
+	}
+
+	p := filepath.Join(t.TempDir(), "catalog.json")
+	if err := os.WriteFile(p, []byte(`{"locale": "fr", "messages": {"job %q": "tâche %q"}}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	c, err := MessageCatalogForLocale(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Locale() != "fr" || c.Translate(`job "a"`) != `tâche "a"` {
+		t.Fatalf("unexpected catalog loaded from file: %v", c)
+	}
+
+	if err := os.WriteFile(p, []byte(`{"messages": `), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := MessageCatalogForLocale(p); err == nil || !strings.Contains(err.Error(), "could not parse message catalog file") {
+		t.Fatalf("unexpected error for broken file: %v", err)
+	}
+}
+
+// All messages in the built-in catalogs must be the format strings in the sources. Otherwise the
+// translations are never used.
+func TestMessageCatalogBuiltinMessagesExist(t *testing.T) {
+	var srcs strings.Builder
+	for _, pat := range []string{"*.go", filepath.Join("expr", "*.go")} {
+		fs, err := filepath.Glob(pat)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, f := range fs {
+			if strings.HasSuffix(f, "_test.go") || strings.HasPrefix(filepath.Base(f), "message_catalog") {
+				continue
+			}
+			b, err := os.ReadFile(f)
+			if err != nil {
+				t.Fatal(err)
+			}
+			srcs.Write(b)
+		}
+	}
+	src := srcs.String()
+
+	for lang, msgs := range builtinMessageCatalogs {
+		if _, err := NewMessageCatalog(lang, msgs); err != nil {
+			t.Errorf("built-in catalog for %q is broken: %v", lang, err)
+		}
+		for m := range msgs {
+			if !strings.Contains(src, strconv.Quote(m)) && !strings.Contains(src, "`"+m+"`") {
+				t.Errorf("message %q in catalog for %q is not found in sources", m, lang)
+			}
+		}
+	}
+}
+
+func TestMessageCatalogLinterLocale(t *testing.T) {
+	src := "on: push\njobs:\n  test:\n    needs: [foo]\n    runs-on: ubuntu-latest\n    steps:\n      - run: echo ${{ unknown }}\n"
+	opts := &LinterOptions{
+		Locale:         "ja",
+		IgnorePatterns: []string{`undefined variable "unknown"`},
+		Shellcheck:     "",
+		Pyflakes:       "",
+	}
+	l, err := NewLinter(io.Discard, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	errs, err := l.Lint("test.yaml", []byte(src), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(errs) != 1 {
+		t.Fatalf("wanted 1 error but got %v", errs)
+	}
+	err0 := errs[0]
+	if err0.Kind != "job-needs" {
+		t.Errorf("rule name should not be translated: %q", err0.Kind)
+	}
+	if want := `ジョブ "test" が依存しているジョブ "foo" はこのワークフローに存在しません`; err0.Message != want {
+		t.Errorf("wanted message %q but got %q", want, err0.Message)
+	}
+
+	if _, err := NewLinter(io.Discard, &LinterOptions{Locale: "xx"}); err == nil {
+		t.Fatal("error did not occur for unknown locale")
+	}
+}
@@ -0,0 +1,162 @@
+package actionlint
+
+import (
+	"encoding/json"
+	"net/http"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// maxMetricsLatencySamples is the number of the latest latencies kept to compute the percentiles.
+const maxMetricsLatencySamples = 1024
+
+// Metrics collects runtime metrics of Linter such as latencies of checking workflow files, the
+// number of external commands waiting for running, and hit rate of the lint cache. Set an instance
+// to LinterOptions.Metrics to collect the metrics. The same instance can be shared by multiple
+// Linter instances. All methods are thread safe.
+type Metrics struct {
+	files       atomic.Int64
+	cacheHits   atomic.Int64
+	cacheMisses atomic.Int64
+	cmdQueued   atomic.Int64
+	cmdRunning  atomic.Int64
+	cmdTotal    atomic.Int64
+
+	mu        sync.Mutex
+	latencies []time.Duration // Ring buffer of the latest latencies
+	next      int
+}
+
+// NewMetrics creates a new Metrics instance.
+func NewMetrics() *Metrics {
+	return &Metrics{latencies: make([]time.Duration, 0, maxMetricsLatencySamples)}
+}
+
+// MetricsSnapshot is a snapshot of the metrics collected by Metrics.
+type MetricsSnapshot struct {
+	// Files is the number of workflow files checked so far including the files whose results were
+	// read from the lint cache.
+	Files int64 `json:"files"`
+	// LatencyP50 is the median of the time taken to check a workflow file.
+	LatencyP50 time.Duration `json:"latency_p50_ns"`
+	// LatencyP90 is the 90th percentile of the time taken to check a workflow file.
+	LatencyP90 time.Duration `json:"latency_p90_ns"`
+	// LatencyP99 is the 99th percentile of the time taken to check a workflow file.
+	LatencyP99 time.Duration `json:"latency_p99_ns"`
+	// LatencyMax is the maximum time taken to check a workflow file.
+	LatencyMax time.Duration `json:"latency_max_ns"`
+	// CommandsQueued is the number of external commands waiting for other processes to finish.
+	CommandsQueued int64 `json:"commands_queued"`
+	// CommandsRunning is the number of external command processes running now.
+	CommandsRunning int64 `json:"commands_running"`
+	// CommandsTotal is the number of external commands run so far.
+	CommandsTotal int64 `json:"commands_total"`
+	// CacheHits is the number of workflow files whose results were read from the lint cache.
+	CacheHits int64 `json:"cache_hits"`
+	// CacheMisses is the number of workflow files checked since their results were not cached.
+	CacheMisses int64 `json:"cache_misses"`
+	// CacheHitRate is the ratio of CacheHits to the number of lookups of the lint cache. It is zero
+	// when the cache was never looked up.
+	CacheHitRate float64 `json:"cache_hit_rate"`
+}
+
+// Snapshot returns the current values of the metrics. The latency percentiles are computed from the
+// latest 1024 files.
+func (m *Metrics) Snapshot() *MetricsSnapshot {
+	s := &MetricsSnapshot{
+		Files:           m.files.Load(),
+		CommandsQueued:  m.cmdQueued.Load(),
+		CommandsRunning: m.cmdRunning.Load(),
+		CommandsTotal:   m.cmdTotal.Load(),
+		CacheHits:       m.cacheHits.Load(),
+		CacheMisses:     m.cacheMisses.Load(),
+	}
+	if n := s.CacheHits + s.CacheMisses; n > 0 {
+		s.CacheHitRate = float64(s.CacheHits) / float64(n)
+	}
+
+	m.mu.Lock()
+	ls := slices.Clone(m.latencies)
+	m.mu.Unlock()
+
+	if len(ls) > 0 {
+		slices.Sort(ls)
+		s.LatencyP50 = percentile(ls, 50)
+		s.LatencyP90 = percentile(ls, 90)
+		s.LatencyP99 = percentile(ls, 99)
+		s.LatencyMax = ls[len(ls)-1]
+	}
+	return s
+}
+
+// ServeHTTP implements http.Handler interface. It responds the snapshot of the metrics in JSON.
+func (m *Metrics) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(m.Snapshot())
+}
+
+// percentile returns the p-th percentile of the sorted durations with nearest-rank method.
+func percentile(sorted []time.Duration, p int) time.Duration {
+	i := (len(sorted)*p + 99) / 100 // ceil(len * p / 100)
+	if i > 0 {
+		i--
+	}
+	return sorted[i]
+}
+
+// Methods below are called by Linter. They do nothing when the receiver is nil so that callers don't
+// need to check whether metrics are enabled.
+
+func (m *Metrics) fileFinished(elapsed time.Duration) {
+	if m == nil {
+		return
+	}
+	m.files.Add(1)
+	m.mu.Lock()
+	if len(m.latencies) < maxMetricsLatencySamples {
+		m.latencies = append(m.latencies, elapsed)
+	} else {
+		m.latencies[m.next] = elapsed
+		m.next = (m.next + 1) % maxMetricsLatencySamples
+	}
+	m.mu.Unlock()
+}
+
+func (m *Metrics) cacheLookup(hit bool) {
+	if m == nil {
+		return
+	}
+	if hit {
+		m.cacheHits.Add(1)
+	} else {
+		m.cacheMisses.Add(1)
+	}
+}
+
+func (m *Metrics) commandQueued() {
+	if m != nil {
+		m.cmdQueued.Add(1)
+	}
+}
+
+func (m *Metrics) commandStarted() {
+	if m != nil {
+		m.cmdQueued.Add(-1)
+		m.cmdRunning.Add(1)
+		m.cmdTotal.Add(1)
+	}
+}
+
+func (m *Metrics) commandDequeued() {
+	if m != nil {
+		m.cmdQueued.Add(-1)
+	}
+}
+
+func (m *Metrics) commandFinished() {
+	if m != nil {
+		m.cmdRunning.Add(-1)
+	}
+}
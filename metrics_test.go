@@ -0,0 +1,178 @@
+package actionlint
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMetricsLatencyPercentiles(t *testing.T) {
+	m := NewMetrics()
+	if s := m.Snapshot(); s.Files != 0 || s.LatencyP50 != 0 || s.LatencyMax != 0 || s.CacheHitRate != 0 {
+		t.Fatalf("metrics are not empty initially: %+v", s)
+	}
+
+	for i := 100; i >= 1; i-- {
+		m.fileFinished(time.Duration(i) * time.Millisecond)
+	}
+	s := m.Snapshot()
+	if s.Files != 100 {
+		t.Errorf("wanted 100 files but got %d", s.Files)
+	}
+	for _, tc := range []struct {
+		what string
+		have time.Duration
+		want time.Duration
+	}{
+		{"p50", s.LatencyP50, 50 * time.Millisecond},
+		{"p90", s.LatencyP90, 90 * time.Millisecond},
+		{"p99", s.LatencyP99, 99 * time.Millisecond},
+		{"max", s.LatencyMax, 100 * time.Millisecond},
+	} {
+		if tc.have != tc.want {
+			t.Errorf("wanted %v for %s but got %v", tc.want, tc.what, tc.have)
+		}
+	}
+
+	// Old samples are dropped
+	for i := 0; i < maxMetricsLatencySamples; i++ {
+		m.fileFinished(time.Second)
+	}
+	s = m.Snapshot()
+	if s.LatencyP50 != time.Second || s.LatencyMax != time.Second {
+		t.Errorf("old samples were not dropped: %+v", s)
+	}
+	if want := int64(100 + maxMetricsLatencySamples); s.Files != want {
+		t.Errorf("wanted %d files but got %d", want, s.Files)
+	}
+}
+
+func TestMetricsNilReceiver(t *testing.T) {
+	var m *Metrics
+	m.fileFinished(time.Second)
+	m.cacheLookup(true)
+	m.commandQueued()
+	m.commandStarted()
+	m.commandFinished()
+	m.commandDequeued()
+}
+
+func TestMetricsLinterCacheHitRate(t *testing.T) {
+	root := testLintCacheRepo(t)
+	m := NewMetrics()
+	for i := 0; i < 2; i++ {
+		testLintCacheRun(t, root, &LinterOptions{Metrics: m})
+	}
+	s := m.Snapshot()
+	if s.Files != 6 {
+		t.Errorf("wanted 6 files but got %d", s.Files)
+	}
+	if s.CacheHits != 3 || s.CacheMisses != 3 || s.CacheHitRate != 0.5 {
+		t.Errorf("unexpected cache metrics: %+v", s)
+	}
+	if s.LatencyMax == 0 {
+		t.Errorf("latency was not recorded: %+v", s)
+	}
+}
+
+func TestMetricsCommandQueue(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("this test is flaky on Windows")
+	}
+
+	m := NewMetrics()
+	p := newConcurrentProcess(context.Background(), 1)
+	p.metrics = m
+	sleep := testSkipIfNoCommand(t, p, "sleep")
+	for i := 0; i < 3; i++ {
+		sleep.run([]string{"0.1"}, "", func(b []byte, err error) error { return err })
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		s := m.Snapshot()
+		if s.CommandsQueued == 2 && s.CommandsRunning == 1 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("wanted 2 queued and 1 running commands but got %+v", s)
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	if err := sleep.wait(); err != nil {
+		t.Fatal(err)
+	}
+	p.wait()
+
+	s := m.Snapshot()
+	if s.CommandsQueued != 0 || s.CommandsRunning != 0 || s.CommandsTotal != 3 {
+		t.Fatalf("unexpected command metrics after all commands finished: %+v", s)
+	}
+}
+
+func TestDebugHandler(t *testing.T) {
+	m := NewMetrics()
+	m.fileFinished(10 * time.Millisecond)
+	m.cacheLookup(true)
+	srv := httptest.NewServer(newDebugHandler(m))
+	defer srv.Close()
+
+	get := func(path string) (int, string) {
+		t.Helper()
+		res, err := http.Get(srv.URL + path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer res.Body.Close()
+		b, err := io.ReadAll(res.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return res.StatusCode, string(b)
+	}
+
+	code, body := get("/debug/metrics")
+	if code != http.StatusOK {
+		t.Fatalf("unexpected status %d: %s", code, body)
+	}
+	var s MetricsSnapshot
+	if err := json.Unmarshal([]byte(body), &s); err != nil {
+		t.Fatal(err)
+	}
+	if s.Files != 1 || s.LatencyP50 != 10*time.Millisecond || s.CacheHitRate != 1 {
+		t.Errorf("unexpected metrics: %+v", s)
+	}
+
+	code, body = get("/debug/pprof/")
+	if code != http.StatusOK || !strings.Contains(body, "heap\n") || !strings.Contains(body, "profile\n") {
+		t.Errorf("unexpected index of profiles with status %d: %q", code, body)
+	}
+
+	code, body = get("/debug/pprof/goroutine?debug=1")
+	if code != http.StatusOK || !strings.Contains(body, "goroutine profile:") {
+		t.Errorf("unexpected goroutine profile with status %d: %q", code, body)
+	}
+
+	code, body = get("/debug/pprof/heap")
+	if code != http.StatusOK || len(body) == 0 {
+		t.Errorf("unexpected heap profile with status %d", code)
+	}
+
+	if code, _ = get("/debug/pprof/unknown"); code != http.StatusNotFound {
+		t.Errorf("wanted status 404 for unknown profile but got %d", code)
+	}
+}
+
+func TestDebugServerInvalidAddress(t *testing.T) {
+	_, err := startDebugServer("not an address", NewMetrics())
+	if err == nil || !strings.Contains(err.Error(), "could not start debug server") {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
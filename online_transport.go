@@ -0,0 +1,201 @@
+package actionlint
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// maxOnlineRetryWait is the maximum duration to wait before retrying a request. When the server
+// requires a longer wait with "Retry-After" header, the request is not retried.
+const maxOnlineRetryWait = 10 * time.Second
+
+// onlineTransport is an HTTP transport shared by all clients of online checks such as GitHub REST API
+// and Docker registries. It limits the rate of requests, retries requests which failed temporarily,
+// and stops sending requests to a host once the host is found unreachable or its rate limit is
+// exceeded. Clients fall back to the cached data or skip the checks in the case so that online checks
+// degrade to offline quickly without network. Calling methods of this struct is thread-safe.
+type onlineTransport struct {
+	base http.RoundTripper
+	// retries is the maximum number of retries of each request. Only GET and HEAD requests are
+	// retried.
+	retries int
+	// interval is the minimum interval between requests. Zero means no limit.
+	interval time.Duration
+	// backoff is the duration to wait before the first retry. It is doubled on each retry.
+	backoff time.Duration
+	mu      sync.Mutex
+	next    time.Time
+	// unavailable is the mapping from hosts to the reasons why requests to them are not sent
+	unavailable map[string]*onlineHostUnavailable
+	dbg         io.Writer
+}
+
+// onlineHostUnavailable is the reason why requests to a host are not sent.
+type onlineHostUnavailable struct {
+	err error
+	// until is the time when the host becomes available again. Zero means the host is unavailable
+	// until the end of the process.
+	until time.Time
+}
+
+func newOnlineTransport(retries int, interval time.Duration, dbg io.Writer) *onlineTransport {
+	return &onlineTransport{
+		base:        http.DefaultTransport,
+		retries:     retries,
+		interval:    interval,
+		backoff:     time.Second,
+		unavailable: map[string]*onlineHostUnavailable{},
+		dbg:         dbg,
+	}
+}
+
+func (t *onlineTransport) debug(format string, args ...interface{}) {
+	if t.dbg == nil {
+		return
+	}
+	format = "[OnlineTransport] " + format + "\n"
+	fmt.Fprintf(t.dbg, format, args...)
+}
+
+// client returns a new HTTP client which sends requests via this transport.
+func (t *onlineTransport) client() *http.Client {
+	return &http.Client{Timeout: 30 * time.Second, Transport: t}
+}
+
+// RoundTrip implements http.RoundTripper interface.
+func (t *onlineTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	host := req.URL.Host
+	if err := t.availability(host); err != nil {
+		return nil, err
+	}
+
+	retryable := (req.Method == http.MethodGet || req.Method == http.MethodHead) && (req.Body == nil || req.GetBody != nil)
+	wait := t.backoff
+	for i := 0; ; i++ {
+		t.throttle()
+
+		r := req
+		if i > 0 && req.Body != nil {
+			b, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			r = req.Clone(req.Context())
+			r.Body = b
+		}
+
+		res, err := t.base.RoundTrip(r)
+		if err == nil {
+			t.checkRateLimit(host, res)
+		}
+		if !retryable || i >= t.retries || req.Context().Err() != nil {
+			if err != nil {
+				t.markUnavailable(host, err, time.Time{})
+			}
+			return res, err
+		}
+
+		if err == nil {
+			if res.StatusCode != http.StatusTooManyRequests && res.StatusCode < 500 {
+				return res, nil
+			}
+			if w, ok := retryAfter(res); ok {
+				if w > maxOnlineRetryWait {
+					return res, nil
+				}
+				wait = max(wait, w)
+			}
+			io.Copy(io.Discard, res.Body)
+			res.Body.Close()
+			t.debug("Retry %s %s in %s since server responded with status %d", req.Method, req.URL, wait, res.StatusCode)
+		} else {
+			t.debug("Retry %s %s in %s since request failed: %v", req.Method, req.URL, wait, err)
+		}
+
+		select {
+		case <-time.After(wait):
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		}
+		wait *= 2
+	}
+}
+
+// availability returns an error when requests to the host should not be sent.
+func (t *onlineTransport) availability(host string) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	u, ok := t.unavailable[host]
+	if !ok {
+		return nil
+	}
+	if !u.until.IsZero() && time.Now().After(u.until) {
+		delete(t.unavailable, host)
+		return nil
+	}
+	return u.err
+}
+
+func (t *onlineTransport) markUnavailable(host string, err error, until time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if _, ok := t.unavailable[host]; ok {
+		return
+	}
+	t.debug("Stop sending requests to %s: %v", host, err)
+	t.unavailable[host] = &onlineHostUnavailable{
+		err:   fmt.Errorf("requests to %s are skipped since the host is unavailable: %w", host, err),
+		until: until,
+	}
+}
+
+// throttle waits until the next request can be sent.
+func (t *onlineTransport) throttle() {
+	if t.interval <= 0 {
+		return
+	}
+	t.mu.Lock()
+	now := time.Now()
+	at := t.next
+	if at.Before(now) {
+		at = now
+	}
+	t.next = at.Add(t.interval)
+	t.mu.Unlock()
+	time.Sleep(at.Sub(now))
+}
+
+// checkRateLimit stops sending requests to the host until the rate limit is reset when no request
+// remains. GitHub REST API reports the rate limit with "X-RateLimit-Remaining" and
+// "X-RateLimit-Reset" headers.
+// https://docs.github.com/en/rest/using-the-rest-api/rate-limits-for-the-rest-api
+func (t *onlineTransport) checkRateLimit(host string, res *http.Response) {
+	if res.Header.Get("X-RateLimit-Remaining") != "0" {
+		return
+	}
+	reset, err := strconv.ParseInt(res.Header.Get("X-RateLimit-Reset"), 10, 64)
+	if err != nil {
+		return
+	}
+	until := time.Unix(reset, 0)
+	t.markUnavailable(host, fmt.Errorf("rate limit is exceeded until %s", until.Format(time.RFC3339)), until)
+}
+
+// retryAfter returns the duration to wait before retrying the request from "Retry-After" header.
+func retryAfter(res *http.Response) (time.Duration, bool) {
+	v := res.Header.Get("Retry-After")
+	if v == "" {
+		return 0, false
+	}
+	if s, err := strconv.Atoi(v); err == nil {
+		return time.Duration(s) * time.Second, true
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		return time.Until(t), true
+	}
+	return 0, false
+}
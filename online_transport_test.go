@@ -0,0 +1,221 @@
+package actionlint
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func testOnlineTransport(retries int) *onlineTransport {
+	t := newOnlineTransport(retries, 0, nil)
+	t.backoff = time.Millisecond
+	return t
+}
+
+func TestOnlineTransportRetryServerError(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		fmt.Fprint(w, "ok")
+	}))
+	defer srv.Close()
+
+	res, err := testOnlineTransport(2).client().Get(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+	b, err := io.ReadAll(res.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.StatusCode != http.StatusOK || string(b) != "ok" {
+		t.Fatalf("unexpected response: status=%d body=%q", res.StatusCode, b)
+	}
+	if n := calls.Load(); n != 3 {
+		t.Fatal("server should be called 3 times but got", n)
+	}
+}
+
+func TestOnlineTransportNoRetry(t *testing.T) {
+	tests := []struct {
+		what    string
+		retries int
+		method  string
+		status  int
+		header  map[string]string
+	}{
+		{"zero retries", 0, http.MethodGet, http.StatusServiceUnavailable, nil},
+		{"client error", 2, http.MethodGet, http.StatusNotFound, nil},
+		{"POST request", 2, http.MethodPost, http.StatusServiceUnavailable, nil},
+		{"too long Retry-After", 2, http.MethodGet, http.StatusTooManyRequests, map[string]string{"Retry-After": "3600"}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.what, func(t *testing.T) {
+			var calls atomic.Int32
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				calls.Add(1)
+				for k, v := range tc.header {
+					w.Header().Set(k, v)
+				}
+				w.WriteHeader(tc.status)
+			}))
+			defer srv.Close()
+
+			req, err := http.NewRequest(tc.method, srv.URL, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			res, err := testOnlineTransport(tc.retries).client().Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			res.Body.Close()
+			if res.StatusCode != tc.status {
+				t.Fatal("unexpected status:", res.StatusCode)
+			}
+			if n := calls.Load(); n != 1 {
+				t.Fatal("server should be called once but got", n)
+			}
+		})
+	}
+}
+
+func TestOnlineTransportSkipUnreachableHost(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {}))
+	url := srv.URL
+	srv.Close() // Make the host unreachable
+
+	var dbg strings.Builder
+	tr := testOnlineTransport(1)
+	tr.dbg = &dbg
+	c := tr.client()
+	if _, err := c.Get(url); err == nil {
+		t.Fatal("error did not occur")
+	}
+	if !strings.Contains(dbg.String(), "Retry GET") {
+		t.Fatalf("request was not retried: %q", dbg.String())
+	}
+
+	dbg.Reset()
+	_, err := c.Get(url + "/foo")
+	if err == nil {
+		t.Fatal("error did not occur")
+	}
+	if !strings.Contains(err.Error(), "the host is unavailable") {
+		t.Fatal("unexpected error:", err)
+	}
+	if strings.Contains(dbg.String(), "Retry") {
+		t.Fatalf("request to unavailable host was retried: %q", dbg.String())
+	}
+}
+
+func TestOnlineTransportRateLimit(t *testing.T) {
+	reset := time.Now().Add(time.Hour).Unix()
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		calls.Add(1)
+		w.Header().Set("X-RateLimit-Remaining", "0")
+		w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(reset, 10))
+		fmt.Fprint(w, "{}")
+	}))
+	defer srv.Close()
+
+	c := testOnlineTransport(2).client()
+	res, err := c.Get(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+
+	_, err = c.Get(srv.URL)
+	if err == nil {
+		t.Fatal("error did not occur")
+	}
+	if !strings.Contains(err.Error(), "rate limit is exceeded") {
+		t.Fatal("unexpected error:", err)
+	}
+	if n := calls.Load(); n != 1 {
+		t.Fatal("server should be called once but got", n)
+	}
+}
+
+func TestOnlineTransportRateLimitReset(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if calls.Add(1) == 1 {
+			w.Header().Set("X-RateLimit-Remaining", "0")
+			w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(time.Now().Add(-time.Second).Unix(), 10))
+		}
+		fmt.Fprint(w, "{}")
+	}))
+	defer srv.Close()
+
+	c := testOnlineTransport(0).client()
+	for i := 0; i < 2; i++ {
+		res, err := c.Get(srv.URL)
+		if err != nil {
+			t.Fatal(err)
+		}
+		res.Body.Close()
+	}
+	if n := calls.Load(); n != 2 {
+		t.Fatal("server should be called twice after the rate limit was reset but got", n)
+	}
+}
+
+func TestOnlineTransportInterval(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {}))
+	defer srv.Close()
+
+	tr := newOnlineTransport(0, 50*time.Millisecond, nil)
+	c := tr.client()
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		res, err := c.Get(srv.URL)
+		if err != nil {
+			t.Fatal(err)
+		}
+		res.Body.Close()
+	}
+	if d := time.Since(start); d < 100*time.Millisecond {
+		t.Fatal("requests were not throttled:", d)
+	}
+}
+
+func TestOnlineTransportGitHubAPIFallbackToCache(t *testing.T) {
+	var up atomic.Bool
+	up.Store(true)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if !up.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprint(w, `{"total_count":1,"runners":[{"name":"r1","labels":[{"name":"self-hosted"},{"name":"gpu"}]}]}`)
+	}))
+	defer srv.Close()
+
+	dir := t.TempDir()
+	r := newRemoteRepository("owner", "repo", srv.URL, "", newRemoteCache(dir, time.Nanosecond, nil), nil, nil)
+	r.api.client = testOnlineTransport(1).client()
+	if ls := r.RunnerLabels(); len(ls) == 0 {
+		t.Fatal("no label was fetched")
+	}
+
+	up.Store(false)
+	time.Sleep(time.Millisecond) // Expire the cache
+	r = newRemoteRepository("owner", "repo", srv.URL, "", newRemoteCache(dir, time.Nanosecond, nil), nil, nil)
+	r.api.client = testOnlineTransport(1).client()
+	if ls := r.RunnerLabels(); len(ls) == 0 {
+		t.Fatal("expired cache was not used on server error")
+	}
+}
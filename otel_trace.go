@@ -0,0 +1,396 @@
+package actionlint
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// otelMaxSpansPerExport is the maximum number of spans sent in one export request. Spans are exported
+// in background while linting when this number of spans are recorded so that long runs such as
+// -stdin-batch don't keep all spans in memory.
+const otelMaxSpansPerExport = 512
+
+// otelDefaultExportTimeout is the default timeout of an export request. It can be changed with
+// OTEL_EXPORTER_OTLP_TIMEOUT environment variable.
+const otelDefaultExportTimeout = 10 * time.Second
+
+// https://www.w3.org/TR/trace-context/#traceparent-header
+var otelTraceparentPattern = regexp.MustCompile(`^[0-9a-f]{2}-([0-9a-f]{32})-([0-9a-f]{16})-[0-9a-f]{2}$`)
+
+// otelValue is a value of an attribute in OTLP JSON encoding. 64-bit integers are encoded as strings.
+// https://opentelemetry.io/docs/specs/otlp/#json-protobuf-encoding
+type otelValue struct {
+	String *string `json:"stringValue,omitempty"`
+	Int    *int64  `json:"intValue,omitempty,string"`
+}
+
+type otelAttr struct {
+	Key   string    `json:"key"`
+	Value otelValue `json:"value"`
+}
+
+func otelString(k, v string) otelAttr {
+	return otelAttr{k, otelValue{String: &v}}
+}
+
+func otelInt(k string, v int) otelAttr {
+	i := int64(v)
+	return otelAttr{k, otelValue{Int: &i}}
+}
+
+type otelStatus struct {
+	Code    int    `json:"code"`
+	Message string `json:"message,omitempty"`
+}
+
+// otelSpan is a span in OTLP JSON encoding.
+// https://github.com/open-telemetry/opentelemetry-proto/blob/main/opentelemetry/proto/trace/v1/trace.proto
+type otelSpan struct {
+	TraceID    string      `json:"traceId"`
+	SpanID     string      `json:"spanId"`
+	ParentID   string      `json:"parentSpanId,omitempty"`
+	Name       string      `json:"name"`
+	Kind       int         `json:"kind"` // Always SPAN_KIND_INTERNAL (1)
+	Start      int64       `json:"startTimeUnixNano,string"`
+	End        int64       `json:"endTimeUnixNano,string"`
+	Attributes []otelAttr  `json:"attributes,omitempty"`
+	Status     *otelStatus `json:"status,omitempty"`
+}
+
+// otelTracer records spans of parsing workflow files, running each rule, and executing external
+// commands via the lifecycle hooks of LinterOptions, and exports them to an OpenTelemetry collector
+// with OTLP/HTTP in JSON encoding. It is configured with the standard environment variables of
+// OpenTelemetry SDK. This is the implementation of tracing of actionlint command. Failing to export
+// spans is reported as a warning and never fails linting.
+// https://opentelemetry.io/docs/specs/otel/protocol/exporter/
+type otelTracer struct {
+	endpoint string
+	headers  map[string]string
+	client   *http.Client
+	resource []otelAttr
+	version  string
+	root     *otelSpan
+	mu       sync.Mutex
+	files    map[string]*otelSpan // Spans of the files being checked
+	spans    []*otelSpan          // Finished spans which are not exported yet
+	wg       sync.WaitGroup
+	warn     io.Writer
+	warned   sync.Once
+}
+
+// newOTelTracerFromEnv creates a new otelTracer instance configured with the environment variables
+// read by 'getenv'. It returns nil when no OTLP endpoint is configured, tracing is disabled, or the
+// configured protocol is not supported. 'dbg' is a writer for debug logs. It can be nil.
+// https://opentelemetry.io/docs/specs/otel/configuration/sdk-environment-variables/
+// https://opentelemetry.io/docs/specs/otel/protocol/exporter/#configuration-options
+func newOTelTracerFromEnv(getenv func(string) string, version string, warn, dbg io.Writer) (*otelTracer, error) {
+	if strings.EqualFold(getenv("OTEL_SDK_DISABLED"), "true") {
+		return nil, nil
+	}
+	if e := getenv("OTEL_TRACES_EXPORTER"); e != "" && e != "otlp" {
+		return nil, nil // "none" or exporters not supported by actionlint
+	}
+
+	endpoint := getenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT")
+	if endpoint == "" {
+		e := getenv("OTEL_EXPORTER_OTLP_ENDPOINT")
+		if e == "" {
+			return nil, nil
+		}
+		endpoint = strings.TrimSuffix(e, "/") + "/v1/traces"
+	}
+	if u, err := url.Parse(endpoint); err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+		return nil, fmt.Errorf("OTLP endpoint must be a URL starting with \"https://\" or \"http://\" but got %q", endpoint)
+	}
+
+	p := getenv("OTEL_EXPORTER_OTLP_TRACES_PROTOCOL")
+	if p == "" {
+		p = getenv("OTEL_EXPORTER_OTLP_PROTOCOL")
+	}
+	switch p {
+	case "", "http/json":
+	case "http/protobuf":
+		// OTLP/HTTP servers must accept both binary and JSON encodings on the same endpoint. This is
+		// the default protocol so send the spans in JSON encoding instead of failing.
+		// https://opentelemetry.io/docs/specs/otlp/#otlphttp-request
+		otelDebug(dbg, "Spans are exported in JSON encoding though OTLP protocol is %q", p)
+	default:
+		otelDebug(dbg, "Tracing is disabled since OTLP protocol %q is not supported. only \"http/json\" and \"http/protobuf\" are supported", p)
+		return nil, nil
+	}
+
+	headers := map[string]string{}
+	for _, n := range []string{"OTEL_EXPORTER_OTLP_HEADERS", "OTEL_EXPORTER_OTLP_TRACES_HEADERS"} {
+		if err := parseOTelKeyValues(getenv(n), headers); err != nil {
+			return nil, fmt.Errorf("invalid %s environment variable: %w", n, err)
+		}
+	}
+
+	timeout := otelDefaultExportTimeout
+	for _, n := range []string{"OTEL_EXPORTER_OTLP_TIMEOUT", "OTEL_EXPORTER_OTLP_TRACES_TIMEOUT"} {
+		if v := getenv(n); v != "" {
+			ms, err := strconv.ParseUint(v, 10, 32)
+			if err != nil {
+				return nil, fmt.Errorf("invalid %s environment variable. it must be timeout in milliseconds but got %q", n, v)
+			}
+			timeout = time.Duration(ms) * time.Millisecond
+		}
+	}
+
+	res := map[string]string{}
+	if err := parseOTelKeyValues(getenv("OTEL_RESOURCE_ATTRIBUTES"), res); err != nil {
+		return nil, fmt.Errorf("invalid OTEL_RESOURCE_ATTRIBUTES environment variable: %w", err)
+	}
+	if n := getenv("OTEL_SERVICE_NAME"); n != "" {
+		res["service.name"] = n
+	} else if _, ok := res["service.name"]; !ok {
+		res["service.name"] = "actionlint"
+	}
+	if _, ok := res["service.version"]; !ok {
+		res["service.version"] = version
+	}
+	resource := make([]otelAttr, 0, len(res))
+	for _, k := range slices.Sorted(maps.Keys(res)) {
+		resource = append(resource, otelString(k, res[k]))
+	}
+
+	// Continue the trace of the parent process such as a CI job
+	root := &otelSpan{Name: "actionlint", Kind: 1, Start: time.Now().UnixNano()}
+	if m := otelTraceparentPattern.FindStringSubmatch(getenv("TRACEPARENT")); m != nil {
+		root.TraceID, root.ParentID = m[1], m[2]
+	} else {
+		root.TraceID = newOTelID(16)
+	}
+	root.SpanID = newOTelID(8)
+
+	return &otelTracer{
+		endpoint: endpoint,
+		headers:  headers,
+		client:   &http.Client{Timeout: timeout},
+		resource: resource,
+		version:  version,
+		root:     root,
+		files:    map[string]*otelSpan{},
+		warn:     warn,
+	}, nil
+}
+
+func otelDebug(dbg io.Writer, format string, args ...any) {
+	if dbg == nil {
+		return
+	}
+	fmt.Fprintf(dbg, "[OTelTracer] "+format+"\n", args...)
+}
+
+// parseOTelKeyValues parses the list of key-value pairs like "key1=value1,key2=value2" in the
+// environment variables such as OTEL_EXPORTER_OTLP_HEADERS. Values are percent-encoded.
+// https://opentelemetry.io/docs/specs/otel/protocol/exporter/#specifying-headers-via-environment-variables
+func parseOTelKeyValues(s string, m map[string]string) error {
+	for _, kv := range strings.Split(s, ",") {
+		if strings.TrimSpace(kv) == "" {
+			continue
+		}
+		k, v, ok := strings.Cut(kv, "=")
+		k = strings.TrimSpace(k)
+		if !ok || k == "" {
+			return fmt.Errorf("%q is not in \"key=value\" format", kv)
+		}
+		v, err := url.PathUnescape(strings.TrimSpace(v))
+		if err != nil {
+			return fmt.Errorf("value of %q is not percent-encoded correctly: %w", k, err)
+		}
+		m[k] = v
+	}
+	return nil
+}
+
+func newOTelID(size int) string {
+	b := make([]byte, size)
+	rand.Read(b) // Never returns an error
+	return hex.EncodeToString(b)
+}
+
+// span creates a new child span of the parent span which ends now. When the parent is nil, the root
+// span is used as the parent. The span is assumed to have started 'elapsed' ago. The end time of
+// spans which are still running is updated when they finish.
+func (t *otelTracer) span(name string, parent *otelSpan, elapsed time.Duration, attrs ...otelAttr) *otelSpan {
+	if parent == nil {
+		parent = t.root
+	}
+	now := time.Now().UnixNano()
+	return &otelSpan{
+		TraceID:    t.root.TraceID,
+		SpanID:     newOTelID(8),
+		ParentID:   parent.SpanID,
+		Name:       name,
+		Kind:       1,
+		Start:      now - int64(elapsed),
+		End:        now,
+		Attributes: attrs,
+	}
+}
+
+// finish records the finished span. When enough spans are recorded, they are exported in background.
+func (t *otelTracer) finish(s *otelSpan) {
+	t.mu.Lock()
+	t.spans = append(t.spans, s)
+	if len(t.spans) < otelMaxSpansPerExport {
+		t.mu.Unlock()
+		return
+	}
+	spans := t.spans
+	t.spans = nil
+	t.mu.Unlock()
+
+	t.wg.Add(1)
+	go func() {
+		defer t.wg.Done()
+		t.export(spans)
+	}()
+}
+
+func (t *otelTracer) fileSpan(path string) *otelSpan {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.files[path]
+}
+
+// setHooks sets the hooks to the options. Existing hooks in the options are still called.
+func (t *otelTracer) setHooks(opts *LinterOptions) {
+	onStart := opts.OnFileStarted
+	opts.OnFileStarted = func(path string) {
+		s := t.span("check "+filepath.Base(path), nil, 0, otelString("code.filepath", path))
+		t.mu.Lock()
+		t.files[path] = s
+		t.mu.Unlock()
+		if onStart != nil {
+			onStart(path)
+		}
+	}
+	onParse := opts.OnFileParsed
+	opts.OnFileParsed = func(path string, errs int, elapsed time.Duration) {
+		t.finish(t.span("parse", t.fileSpan(path), elapsed, otelInt("actionlint.errors", errs)))
+		if onParse != nil {
+			onParse(path, errs, elapsed)
+		}
+	}
+	onRule := opts.OnRuleFinished
+	opts.OnRuleFinished = func(path, rule string, errs int, elapsed time.Duration) {
+		// Rules visit the syntax tree alternately so the start time is approximated by the elapsed time
+		t.finish(t.span("rule "+rule, t.fileSpan(path), elapsed, otelString("actionlint.rule", rule), otelInt("actionlint.errors", errs)))
+		if onRule != nil {
+			onRule(path, rule, errs, elapsed)
+		}
+	}
+	onFile := opts.OnFileFinished
+	opts.OnFileFinished = func(path string, errs []*Error, elapsed time.Duration) {
+		t.mu.Lock()
+		s, ok := t.files[path]
+		delete(t.files, path)
+		t.mu.Unlock()
+		if ok {
+			s.End = time.Now().UnixNano()
+			s.Attributes = append(s.Attributes, otelInt("actionlint.errors", len(errs)))
+			t.finish(s)
+		}
+		if onFile != nil {
+			onFile(path, errs, elapsed)
+		}
+	}
+	onCmd := opts.OnExternalCommandFinished
+	opts.OnExternalCommandFinished = func(exe string, args []string, elapsed time.Duration) {
+		name := strings.TrimSuffix(filepath.Base(exe), ".exe")
+		t.finish(t.span("exec "+name, nil, elapsed, otelString("process.executable.path", exe), otelString("process.command_args", strings.Join(args, " "))))
+		if onCmd != nil {
+			onCmd(exe, args, elapsed)
+		}
+	}
+}
+
+// shutdown finishes the root span and exports all remaining spans. The 'errs' parameter is the number
+// of errors found by linting and the 'err' parameter is the fatal error of linting.
+func (t *otelTracer) shutdown(errs int, err error) {
+	t.root.End = time.Now().UnixNano()
+	t.root.Attributes = append(t.root.Attributes, otelInt("actionlint.errors", errs))
+	if err != nil {
+		t.root.Status = &otelStatus{Code: 2, Message: err.Error()} // STATUS_CODE_ERROR
+	}
+
+	t.mu.Lock()
+	spans := append(t.spans, t.root)
+	t.spans = nil
+	t.mu.Unlock()
+
+	t.export(spans)
+	t.wg.Wait()
+}
+
+// export sends the spans to the OTLP endpoint. Failure is reported as a warning only once.
+// https://opentelemetry.io/docs/specs/otlp/#otlphttp
+func (t *otelTracer) export(spans []*otelSpan) {
+	type scope struct {
+		Name    string `json:"name"`
+		Version string `json:"version"`
+	}
+	type scopeSpans struct {
+		Scope scope       `json:"scope"`
+		Spans []*otelSpan `json:"spans"`
+	}
+	type resource struct {
+		Attributes []otelAttr `json:"attributes"`
+	}
+	type resourceSpans struct {
+		Resource   resource     `json:"resource"`
+		ScopeSpans []scopeSpans `json:"scopeSpans"`
+	}
+	v := struct {
+		ResourceSpans []resourceSpans `json:"resourceSpans"`
+	}{
+		[]resourceSpans{{resource{t.resource}, []scopeSpans{{scope{"github.com/rhysd/actionlint", t.version}, spans}}}},
+	}
+
+	b, err := json.Marshal(&v)
+	if err != nil {
+		t.warnOnce(err)
+		return
+	}
+	req, err := http.NewRequest(http.MethodPost, t.endpoint, bytes.NewReader(b))
+	if err != nil {
+		t.warnOnce(err)
+		return
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range t.headers {
+		req.Header.Set(k, v)
+	}
+	res, err := t.client.Do(req)
+	if err != nil {
+		t.warnOnce(err)
+		return
+	}
+	defer res.Body.Close()
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(res.Body, 1024))
+		t.warnOnce(fmt.Errorf("status %d: %s", res.StatusCode, bytes.TrimSpace(body)))
+	}
+}
+
+func (t *otelTracer) warnOnce(err error) {
+	t.warned.Do(func() {
+		fmt.Fprintf(t.warn, "warning: could not export traces to %s: %v\n", t.endpoint, err)
+	})
+}
@@ -0,0 +1,279 @@
+package actionlint
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func testOTelEnv(m map[string]string) func(string) string {
+	return func(k string) string { return m[k] }
+}
+
+func TestOTelTracerFromEnv(t *testing.T) {
+	tr, err := newOTelTracerFromEnv(testOTelEnv(map[string]string{
+		"OTEL_EXPORTER_OTLP_ENDPOINT":       "http://localhost:4318/",
+		"OTEL_EXPORTER_OTLP_HEADERS":        "Authorization=Bearer%20foo, X-Tenant=a",
+		"OTEL_EXPORTER_OTLP_TRACES_HEADERS": "X-Tenant=b",
+		"OTEL_EXPORTER_OTLP_PROTOCOL":       "http/json",
+		"OTEL_EXPORTER_OTLP_TIMEOUT":        "3000",
+		"OTEL_RESOURCE_ATTRIBUTES":          "deployment.environment=ci,service.name=foo",
+		"TRACEPARENT":                       "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01",
+	}), "v1.2.3", io.Discard, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tr == nil {
+		t.Fatal("tracer was not created")
+	}
+	if tr.endpoint != "http://localhost:4318/v1/traces" {
+		t.Fatal("unexpected endpoint:", tr.endpoint)
+	}
+	if diff := cmp.Diff(map[string]string{"Authorization": "Bearer foo", "X-Tenant": "b"}, tr.headers); diff != "" {
+		t.Fatal(diff)
+	}
+	if tr.client.Timeout.Seconds() != 3 {
+		t.Fatal("unexpected timeout:", tr.client.Timeout)
+	}
+	res := map[string]string{}
+	for _, a := range tr.resource {
+		res[a.Key] = *a.Value.String
+	}
+	want := map[string]string{"deployment.environment": "ci", "service.name": "foo", "service.version": "v1.2.3"}
+	if diff := cmp.Diff(want, res); diff != "" {
+		t.Fatal(diff)
+	}
+	if tr.root.TraceID != "0af7651916cd43dd8448eb211c80319c" || tr.root.ParentID != "b7ad6b7169203331" {
+		t.Fatalf("trace context was not propagated from TRACEPARENT: %+v", tr.root)
+	}
+
+	tr, err = newOTelTracerFromEnv(testOTelEnv(map[string]string{
+		"OTEL_EXPORTER_OTLP_ENDPOINT":        "http://localhost:4318",
+		"OTEL_EXPORTER_OTLP_TRACES_ENDPOINT": "http://localhost:9999/traces",
+		"OTEL_SERVICE_NAME":                  "bar",
+	}), "v1.2.3", io.Discard, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tr.endpoint != "http://localhost:9999/traces" {
+		t.Fatal("unexpected endpoint:", tr.endpoint)
+	}
+	if len(tr.root.TraceID) != 32 || len(tr.root.SpanID) != 16 || tr.root.ParentID != "" {
+		t.Fatalf("unexpected root span: %+v", tr.root)
+	}
+}
+
+func TestOTelTracerDisabled(t *testing.T) {
+	for _, env := range []map[string]string{
+		{},
+		{"OTEL_EXPORTER_OTLP_ENDPOINT": "http://localhost:4318", "OTEL_SDK_DISABLED": "true"},
+		{"OTEL_EXPORTER_OTLP_ENDPOINT": "http://localhost:4318", "OTEL_TRACES_EXPORTER": "none"},
+	} {
+		tr, err := newOTelTracerFromEnv(testOTelEnv(env), "", io.Discard, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if tr != nil {
+			t.Fatalf("tracer was created with %v", env)
+		}
+	}
+}
+
+func TestOTelTracerProtocols(t *testing.T) {
+	tests := []struct {
+		protocol string
+		enabled  bool
+	}{
+		{"", true},
+		{"http/json", true},
+		{"http/protobuf", true},
+		{"grpc", false},
+		{"unknown", false},
+	}
+	for _, tc := range tests {
+		t.Run(tc.protocol, func(t *testing.T) {
+			var dbg strings.Builder
+			tr, err := newOTelTracerFromEnv(testOTelEnv(map[string]string{
+				"OTEL_EXPORTER_OTLP_ENDPOINT": "http://localhost:4318",
+				"OTEL_EXPORTER_OTLP_PROTOCOL": tc.protocol,
+			}), "", io.Discard, &dbg)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tc.enabled && tr == nil {
+				t.Fatal("tracer was not created")
+			}
+			if !tc.enabled {
+				if tr != nil {
+					t.Fatal("tracer was created")
+				}
+				if want := "Tracing is disabled since OTLP protocol"; !strings.Contains(dbg.String(), want) {
+					t.Fatalf("debug log %q does not contain %q", dbg.String(), want)
+				}
+			}
+		})
+	}
+}
+
+func TestOTelTracerInvalidEnv(t *testing.T) {
+	tests := []struct {
+		env  map[string]string
+		want string
+	}{
+		{map[string]string{"OTEL_EXPORTER_OTLP_ENDPOINT": "localhost:4318"}, "must be a URL"},
+		{map[string]string{"OTEL_EXPORTER_OTLP_ENDPOINT": "http://localhost:4318", "OTEL_EXPORTER_OTLP_HEADERS": "foo"}, "invalid OTEL_EXPORTER_OTLP_HEADERS"},
+		{map[string]string{"OTEL_EXPORTER_OTLP_ENDPOINT": "http://localhost:4318", "OTEL_EXPORTER_OTLP_TIMEOUT": "10s"}, "invalid OTEL_EXPORTER_OTLP_TIMEOUT"},
+		{map[string]string{"OTEL_EXPORTER_OTLP_ENDPOINT": "http://localhost:4318", "OTEL_RESOURCE_ATTRIBUTES": "a=%zz"}, "invalid OTEL_RESOURCE_ATTRIBUTES"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.want, func(t *testing.T) {
+			_, err := newOTelTracerFromEnv(testOTelEnv(tc.env), "", io.Discard, nil)
+			if err == nil {
+				t.Fatal("error did not occur")
+			}
+			if msg := err.Error(); !strings.Contains(msg, tc.want) {
+				t.Fatalf("error message %q does not contain %q", msg, tc.want)
+			}
+		})
+	}
+}
+
+func TestOTelTracerExportSpans(t *testing.T) {
+	type span struct {
+		TraceID      string `json:"traceId"`
+		SpanID       string `json:"spanId"`
+		ParentSpanID string `json:"parentSpanId"`
+		Name         string `json:"name"`
+		Start        string `json:"startTimeUnixNano"`
+		End          string `json:"endTimeUnixNano"`
+		Attributes   []struct {
+			Key   string `json:"key"`
+			Value struct {
+				StringValue string `json:"stringValue"`
+				IntValue    string `json:"intValue"`
+			} `json:"value"`
+		} `json:"attributes"`
+		Status *struct {
+			Code int `json:"code"`
+		} `json:"status"`
+	}
+	var mu sync.Mutex
+	var spans []span
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPost || req.URL.Path != "/v1/traces" {
+			t.Errorf("unexpected request: %s %s", req.Method, req.URL)
+		}
+		if h := req.Header.Get("Content-Type"); h != "application/json" {
+			t.Errorf("unexpected Content-Type header: %q", h)
+		}
+		if h := req.Header.Get("X-Token"); h != "foo" {
+			t.Errorf("unexpected X-Token header: %q", h)
+		}
+		var v struct {
+			ResourceSpans []struct {
+				ScopeSpans []struct {
+					Spans []span `json:"spans"`
+				} `json:"scopeSpans"`
+			} `json:"resourceSpans"`
+		}
+		if err := json.NewDecoder(req.Body).Decode(&v); err != nil {
+			t.Error(err)
+			return
+		}
+		mu.Lock()
+		spans = append(spans, v.ResourceSpans[0].ScopeSpans[0].Spans...)
+		mu.Unlock()
+	}))
+	defer srv.Close()
+
+	tr, err := newOTelTracerFromEnv(testOTelEnv(map[string]string{
+		"OTEL_EXPORTER_OTLP_ENDPOINT": srv.URL,
+		"OTEL_EXPORTER_OTLP_HEADERS":  "X-Token=foo",
+	}), "v1.2.3", io.Discard, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	opts := &LinterOptions{}
+	tr.setHooks(opts)
+	l, err := NewLinter(io.Discard, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	src := "on: push\njobs:\n  test:\n    runs-on: ubuntu-latest\n    steps:\n      - run: echo ${{ foo }}\n"
+	errs, err := l.Lint("test.yaml", []byte(src), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tr.shutdown(len(errs), errors.New("oops"))
+
+	byName := map[string]*span{}
+	for i := range spans {
+		s := &spans[i]
+		byName[s.Name] = s
+		if s.TraceID != tr.root.TraceID {
+			t.Errorf("trace ID of span %q is %q", s.Name, s.TraceID)
+		}
+		start, _ := strconv.ParseInt(s.Start, 10, 64)
+		end, _ := strconv.ParseInt(s.End, 10, 64)
+		if start == 0 || start > end {
+			t.Errorf("invalid start and end times of span %q: %s, %s", s.Name, s.Start, s.End)
+		}
+	}
+	for _, n := range []string{"actionlint", "check test.yaml", "parse", "rule expression", "rule runner-label"} {
+		if _, ok := byName[n]; !ok {
+			t.Fatalf("span %q was not exported: %v", n, spans)
+		}
+	}
+	root, file := byName["actionlint"], byName["check test.yaml"]
+	if root.ParentSpanID != "" || root.Status == nil || root.Status.Code != 2 {
+		t.Errorf("unexpected root span: %+v", root)
+	}
+	if file.ParentSpanID != root.SpanID {
+		t.Errorf("parent of file span is not root span: %+v", file)
+	}
+	for _, n := range []string{"parse", "rule expression"} {
+		if s := byName[n]; s.ParentSpanID != file.SpanID {
+			t.Errorf("parent of span %q is not file span: %+v", n, s)
+		}
+	}
+	found := false
+	for _, a := range byName["rule expression"].Attributes {
+		if a.Key == "actionlint.errors" {
+			found = a.Value.IntValue == "1"
+		}
+	}
+	if !found {
+		t.Errorf("number of errors was not recorded in span: %+v", byName["rule expression"])
+	}
+}
+
+func TestOTelTracerExportError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		io.WriteString(w, "bad request")
+	}))
+	defer srv.Close()
+
+	var warn strings.Builder
+	tr, err := newOTelTracerFromEnv(testOTelEnv(map[string]string{"OTEL_EXPORTER_OTLP_TRACES_ENDPOINT": srv.URL}), "", &warn, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tr.finish(tr.span("foo", nil, 0))
+	tr.shutdown(0, nil)
+	tr.export(nil)
+
+	msg := warn.String()
+	if strings.Count(msg, "warning:") != 1 || !strings.Contains(msg, "status 400: bad request") {
+		t.Fatalf("unexpected warning: %q", msg)
+	}
+}
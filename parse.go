@@ -1,12 +1,15 @@
 package actionlint
 
 import (
+	"bytes"
 	"fmt"
 	"iter"
 	"math"
 	"slices"
 	"strconv"
 	"strings"
+	"unicode/utf8"
+	"unique"
 
 	"go.yaml.in/yaml/v4"
 )
@@ -30,12 +33,71 @@ func nodeKindName(k yaml.Kind) string {
 }
 
 func posAt(n *yaml.Node) *Pos {
-	return &Pos{n.Line, n.Column}
+	return &Pos{Line: n.Line, Col: n.Column}
 }
 
-func newString(n *yaml.Node) *String {
-	quoted := n.Style&(yaml.DoubleQuotedStyle|yaml.SingleQuotedStyle) != 0
-	return &String{n.Value, quoted, posAt(n)}
+// scalarEnd returns the position where the scalar node ends in the source. The returned column is
+// exclusive. It returns zeros when the end cannot be determined. For example, the scalar spans
+// multiple lines.
+func scalarEnd(lines [][]byte, n *yaml.Node) (int, int) {
+	if n.Kind != yaml.ScalarNode || n.Line <= 0 || n.Line > len(lines) || n.Column <= 0 {
+		return 0, 0
+	}
+
+	// Column of YAML node is counted in characters
+	l := lines[n.Line-1]
+	start := 0
+	for c := 1; c < n.Column; c++ {
+		if start >= len(l) {
+			return 0, 0
+		}
+		_, w := utf8.DecodeRune(l[start:])
+		start += w
+	}
+	s := l[start:]
+
+	end := 0
+	switch {
+	case n.Style&yaml.DoubleQuotedStyle != 0:
+		if len(s) == 0 || s[0] != '"' {
+			return 0, 0
+		}
+		for i := 1; i < len(s); i++ {
+			if s[i] == '\\' {
+				i++
+			} else if s[i] == '"' {
+				end = i + 1
+				break
+			}
+		}
+	case n.Style&yaml.SingleQuotedStyle != 0:
+		if len(s) == 0 || s[0] != '\'' {
+			return 0, 0
+		}
+		for i := 1; i < len(s); i++ {
+			if s[i] != '\'' {
+				continue
+			}
+			if i+1 < len(s) && s[i+1] == '\'' {
+				i++ // Escaped quote ''
+				continue
+			}
+			end = i + 1
+			break
+		}
+	case n.Style&(yaml.LiteralStyle|yaml.FoldedStyle) != 0:
+		return 0, 0 // Block scalar spans multiple lines
+	default:
+		// Plain scalar. When the value does not match the source, the scalar is folded into one line
+		if len(s) >= len(n.Value) && string(s[:len(n.Value)]) == n.Value {
+			end = len(n.Value)
+		}
+	}
+	if end == 0 {
+		return 0, 0
+	}
+
+	return n.Line, n.Column + utf8.RuneCount(s[:end])
 }
 
 // workflowMappingEntry represents a key-value entry in YAML mapping.
@@ -68,14 +130,68 @@ func (l *delayedSprintf) String() string {
 
 type parser struct {
 	errors []*Error
+	// lines is the lines of the source. It is used to find the ends of scalars.
+	lines [][]byte
+	// strs is the chunk to allocate String nodes. See allocString.
+	strs []stringNode
+	// chunk is the size of the last chunk of String nodes.
+	chunk int
+}
+
+// stringNode is a String node and its position allocated at once.
+type stringNode struct {
+	str String
+	pos Pos
+}
+
+// maxStringNodeChunk is the maximum number of String nodes allocated at once. Since String nodes are
+// the most common nodes in workflows, allocating them in chunks reduces the number of allocations
+// and the overhead of memory per node. The chunks are released with the syntax tree. The size of
+// chunk starts from a small number not to waste memory for small workflows.
+const maxStringNodeChunk = 64
+
+// posAt returns the position of the node. When the node is a scalar, the end of the scalar is also
+// set to the position.
+func (p *parser) posAt(n *yaml.Node) *Pos {
+	pos := posAt(n)
+	pos.EndLine, pos.EndCol = scalarEnd(p.lines, n)
+	return pos
+}
+
+func (p *parser) newString(n *yaml.Node) *String {
+	quoted := n.Style&(yaml.DoubleQuotedStyle|yaml.SingleQuotedStyle) != 0
+	return p.allocString(n, n.Value, quoted)
+}
+
+func (p *parser) allocString(n *yaml.Node, v string, quoted bool) *String {
+	if len(p.strs) == 0 {
+		p.chunk = min(max(p.chunk*2, 8), maxStringNodeChunk)
+		p.strs = make([]stringNode, p.chunk)
+	}
+	s := &p.strs[0]
+	p.strs = p.strs[1:]
+	s.pos = Pos{Line: n.Line, Col: n.Column}
+	s.pos.EndLine, s.pos.EndCol = scalarEnd(p.lines, n)
+	s.str = String{v, quoted, &s.pos}
+	return &s.str
+}
+
+// internString interns the value of the String node. Values like action refs at "uses:" and runner
+// labels at "runs-on:" are repeated in many workflows. Interning them makes the syntax trees of the
+// workflows share the same string data.
+func internString(s *String) *String {
+	if s != nil {
+		s.Value = unique.Make(s.Value).Value()
+	}
+	return s
 }
 
 func (p *parser) error(n *yaml.Node, m string) {
-	p.errors = append(p.errors, &Error{m, "", n.Line, n.Column, "syntax-check"})
+	p.errorAt(p.posAt(n), m)
 }
 
 func (p *parser) errorAt(pos *Pos, m string) {
-	p.errors = append(p.errors, &Error{m, "", pos.Line, pos.Col, "syntax-check"})
+	p.errors = append(p.errors, errorAt(pos, "syntax-check", m))
 }
 
 func (p *parser) errorfAt(pos *Pos, format string, args ...interface{}) {
@@ -188,7 +304,7 @@ func (p *parser) parseExpression(n *yaml.Node, expecting string) *String {
 		p.missingExpression(n, expecting)
 		return nil
 	}
-	return newString(n)
+	return p.newString(n)
 }
 
 func (p *parser) mayParseExpression(n *yaml.Node) *String {
@@ -198,14 +314,14 @@ func (p *parser) mayParseExpression(n *yaml.Node) *String {
 	if !isExprAssigned(n.Value) {
 		return nil
 	}
-	return newString(n)
+	return p.newString(n)
 }
 
 func (p *parser) parseString(n *yaml.Node, allowEmpty bool) *String {
 	if !p.checkString(n, allowEmpty) {
-		return &String{"", false, posAt(n)}
+		return p.allocString(n, "", false)
 	}
-	return newString(n)
+	return p.newString(n)
 }
 
 func (p *parser) parseStringSequence(sec string, n *yaml.Node, allowEmpty bool, allowElemEmpty bool) []*String {
@@ -245,13 +361,13 @@ func (p *parser) parseBool(n *yaml.Node) *Bool {
 		e := p.parseExpression(n, "boolean literal \"true\" or \"false\"")
 		return &Bool{
 			Expression: e,
-			Pos:        posAt(n),
+			Pos:        p.posAt(n),
 		}
 	}
 
 	return &Bool{
 		Value: n.Value == "true",
-		Pos:   posAt(n),
+		Pos:   p.posAt(n),
 	}
 }
 
@@ -268,7 +384,7 @@ func (p *parser) parseInt(n *yaml.Node) *Int {
 		}
 		return &Int{
 			Expression: e,
-			Pos:        posAt(n),
+			Pos:        p.posAt(n),
 		}
 	}
 
@@ -280,7 +396,7 @@ func (p *parser) parseInt(n *yaml.Node) *Int {
 
 	return &Int{
 		Value: i,
-		Pos:   posAt(n),
+		Pos:   p.posAt(n),
 	}
 }
 
@@ -297,7 +413,7 @@ func (p *parser) parseFloat(n *yaml.Node) *Float {
 		}
 		return &Float{
 			Expression: e,
-			Pos:        posAt(n),
+			Pos:        p.posAt(n),
 		}
 	}
 
@@ -309,7 +425,7 @@ func (p *parser) parseFloat(n *yaml.Node) *Float {
 
 	return &Float{
 		Value: f,
-		Pos:   posAt(n),
+		Pos:   p.posAt(n),
 	}
 }
 
@@ -352,7 +468,9 @@ func (p *parser) parseMapping(where delayedSprintf, n *yaml.Node, allowEmpty, ca
 				if !caseSensitive {
 					note = ". note that this key is case insensitive"
 				}
-				p.errorfAt(k.Pos, "key %q is duplicated in %s. previously defined at %s%s", k.Value, where.String(), pos.String(), note)
+				err := errorfAt(k.Pos, "syntax-check", "key %q is duplicated in %s. previously defined at %s%s", k.Value, where.String(), pos.String(), note)
+				err.Related = relatedAt(pos, "previously defined here")
+				p.errors = append(p.errors, err)
 				continue
 			}
 
@@ -432,7 +550,7 @@ func (p *parser) parseWorkflowDispatchEventInput(name *String, n *yaml.Node) *Di
 		case "options":
 			ret.Options = p.parseStringSequence("options", e.val, false, false)
 		default:
-			p.unexpectedKey(e.key, "inputs", []string{"description", "required", "default"})
+			p.unexpectedKey(e.key, "inputs", []string{"description", "required", "default", "type", "options"})
 		}
 	}
 
@@ -666,15 +784,15 @@ func (p *parser) parseEventWithNoConfig(n *yaml.Node) Event {
 		p.error(n, "schedule event must be configured with mapping")
 		return nil
 	case "repository_dispatch":
-		return &RepositoryDispatchEvent{Pos: posAt(n)}
+		return &RepositoryDispatchEvent{Pos: p.posAt(n)}
 	case "workflow_dispatch":
-		return &WorkflowDispatchEvent{Pos: posAt(n)}
+		return &WorkflowDispatchEvent{Pos: p.posAt(n)}
 	case "workflow_call":
-		return &WorkflowCallEvent{Pos: posAt(n)}
+		return &WorkflowCallEvent{Pos: p.posAt(n)}
 	case "image_version":
-		return &ImageVersionEvent{Pos: posAt(n)}
+		return &ImageVersionEvent{Pos: p.posAt(n)}
 	default:
-		return &WebhookEvent{Hook: s, Pos: posAt(n)}
+		return &WebhookEvent{Hook: s, Pos: p.posAt(n)}
 	}
 }
 
@@ -862,7 +980,7 @@ func (p *parser) parseOutputs(n *yaml.Node) map[string]*Output {
 func (p *parser) parseRawYAMLValue(n *yaml.Node) RawYAMLValue {
 	switch n.Kind {
 	case yaml.ScalarNode:
-		return &RawYAMLString{n.Value, posAt(n)}
+		return &RawYAMLString{n.Value, p.posAt(n)}
 	case yaml.SequenceNode:
 		vs := make([]RawYAMLValue, 0, len(n.Content))
 		for _, c := range n.Content {
@@ -870,7 +988,7 @@ func (p *parser) parseRawYAMLValue(n *yaml.Node) RawYAMLValue {
 				vs = append(vs, v)
 			}
 		}
-		return &RawYAMLArray{vs, posAt(n)}
+		return &RawYAMLArray{vs, p.posAt(n)}
 	case yaml.MappingNode:
 		m := map[string]RawYAMLValue{}
 		for e := range p.parseMappingAt("matrix row value", n, true, false) {
@@ -878,7 +996,7 @@ func (p *parser) parseRawYAMLValue(n *yaml.Node) RawYAMLValue {
 				m[e.id] = v
 			}
 		}
-		return &RawYAMLObject{m, posAt(n)}
+		return &RawYAMLObject{m, p.posAt(n)}
 	default:
 		p.errorf(n, "unexpected %s node on parsing value in matrix row", nodeKindName(n.Kind))
 		return nil
@@ -923,7 +1041,7 @@ func (p *parser) parseMatrix(pos *Pos, n *yaml.Node) *Matrix {
 	if n.Kind == yaml.ScalarNode {
 		return &Matrix{
 			Expression: p.parseExpression(n, "matrix"),
-			Pos:        posAt(n),
+			Pos:        p.posAt(n),
 		}
 	}
 
@@ -1064,7 +1182,7 @@ func (p *parser) parseContainer(sec string, pos *Pos, n *yaml.Node) *Container {
 }
 
 func (p *parser) parseServices(n *yaml.Node) *Services {
-	ret := &Services{Pos: posAt(n)}
+	ret := &Services{Pos: p.posAt(n)}
 	if e := p.mayParseExpression(n); e != nil {
 		ret.Expression = e
 	} else {
@@ -1096,7 +1214,7 @@ func (p *parser) parseStepExecAction(entries []workflowMappingEntry, isDocker bo
 	for _, e := range entries {
 		switch e.id {
 		case "uses":
-			ret.Uses = p.parseString(e.val, false)
+			ret.Uses = internString(p.parseString(e.val, false))
 		case "with":
 			ret.Inputs = map[string]*Input{}
 			with := p.parseSectionMapping("with", e.val, false, false)
@@ -1105,9 +1223,17 @@ func (p *parser) parseStepExecAction(entries []workflowMappingEntry, isDocker bo
 					switch e.id {
 					case "entrypoint":
 						// https://docs.github.com/en/actions/learn-github-actions/workflow-syntax-for-github-actions#jobsjob_idstepswithentrypoint
+						if e.val.Kind == yaml.SequenceNode {
+							p.error(e.val, "\"entrypoint\" in \"with\" section of Docker action must be a string of single executable. put its arguments in \"args\" instead of using an array")
+							continue
+						}
 						ret.Entrypoint = p.parseString(e.val, false)
 					case "args":
 						// https://docs.github.com/en/actions/learn-github-actions/workflow-syntax-for-github-actions#jobsjob_idstepswithargs
+						if e.val.Kind == yaml.SequenceNode {
+							p.error(e.val, "\"args\" in \"with\" section of Docker action must be a string. separate the arguments with spaces like \"args: --foo bar\" instead of using an array")
+							continue
+						}
 						ret.Args = p.parseString(e.val, true)
 					default:
 						ret.Inputs[e.id] = &Input{e.key, p.parseString(e.val, true)}
@@ -1173,7 +1299,7 @@ func (p *parser) parseStepExecRun(entries []workflowMappingEntry) *ExecRun {
 
 // https://docs.github.com/en/actions/learn-github-actions/workflow-syntax-for-github-actions#jobsjob_idsteps
 func (p *parser) parseStep(n *yaml.Node) *Step {
-	ret := &Step{Pos: posAt(n)}
+	ret := &Step{Pos: p.posAt(n)}
 
 	const (
 		isUnknown = iota
@@ -1247,6 +1373,9 @@ func (p *parser) parseRunsOn(n *yaml.Node) *Runner {
 
 	if n.Kind == yaml.ScalarNode || n.Kind == yaml.SequenceNode {
 		labels := p.parseStringOrStringSequence("runs-on", n, false, false)
+		for _, l := range labels {
+			internString(l)
+		}
 		return &Runner{labels, nil, nil}
 	}
 
@@ -1259,6 +1388,9 @@ func (p *parser) parseRunsOn(n *yaml.Node) *Runner {
 				continue
 			}
 			r.Labels = p.parseStringOrStringSequence("labels", e.val, false, false)
+			for _, l := range r.Labels {
+				internString(l)
+			}
 		case "group":
 			r.Group = p.parseString(e.val, false)
 		default:
@@ -1369,7 +1501,7 @@ func (p *parser) parseJob(id *String, n *yaml.Node) *Job {
 		case "services":
 			ret.Services = p.parseServices(v)
 		case "uses":
-			call.Uses = p.parseString(v, false)
+			call.Uses = internString(p.parseString(v, false))
 			callOnlyKey = k
 		case "with":
 			call.Inputs = map[string]*WorkflowCallInput{}
@@ -1572,17 +1704,45 @@ func handleYAMLUnmarshalError(err error) []*Error {
 // detected while parsing the input. It means that detecting one error does not stop parsing. Even
 // if one or more errors are detected, parser will try to continue parsing and finding more errors.
 func Parse(b []byte) (*Workflow, []*Error) {
-	var n yaml.Node
+	return ParseWithLimits(b, nil)
+}
 
-	if err := yaml.Unmarshal(b, &n); err != nil {
-		return nil, handleYAMLUnmarshalError(err)
+// ParseWithLimits is the same as Parse but it stops parsing when the source exceeds the given
+// limits. In the case, the returned syntax tree is nil and the error of "resource-limit" kind is
+// returned. When the limits parameter is nil, this function is the same as Parse.
+func ParseWithLimits(b []byte, limits *ParseLimits) (*Workflow, []*Error) {
+	n, errs := unmarshalYAMLWithLimits(b, limits, "workflow file")
+	if len(errs) > 0 {
+		return nil, errs
 	}
 
 	// Uncomment for checking YAML tree
-	// dumpYAML(&n, 0)
+	// dumpYAML(n, 0)
 
-	p := &parser{}
-	w := p.parse(&n)
+	p := &parser{lines: bytes.Split(b, []byte{'\n'})}
+	w := p.parse(n)
 
 	return w, p.errors
 }
+
+// unmarshalYAMLWithLimits parses the source into YAML tree and checks the tree is within the limits.
+// The what parameter is the name of the source file used in the error message.
+func unmarshalYAMLWithLimits(b []byte, limits *ParseLimits, what string) (*yaml.Node, []*Error) {
+	if limits != nil && limits.MaxInputSize > 0 && len(b) > limits.MaxInputSize {
+		err := errorfAt(&Pos{Line: 1, Col: 1}, "resource-limit", "%s is too large. its size is %d bytes but the limit is %d bytes", what, len(b), limits.MaxInputSize)
+		return nil, []*Error{err}
+	}
+
+	var n yaml.Node
+	if err := yaml.Unmarshal(b, &n); err != nil {
+		return nil, handleYAMLUnmarshalError(err)
+	}
+
+	if limits != nil {
+		if err := checkParseLimits(&n, limits); err != nil {
+			return nil, []*Error{err}
+		}
+	}
+
+	return &n, nil
+}
@@ -0,0 +1,113 @@
+package actionlint
+
+import (
+	"strings"
+
+	"go.yaml.in/yaml/v4"
+)
+
+// ParseLimits is limits of resources used for parsing a workflow file. Hostile or pathological
+// workflow files such as very large files, deeply nested values, or "billion laughs" aliases can
+// exhaust memory and CPU when actionlint runs as a service like the playground. When a limit is
+// exceeded, parsing stops and an error of "resource-limit" kind is reported instead of the syntax
+// tree. Zero value of each field means no limit.
+type ParseLimits struct {
+	// MaxInputSize is the maximum size of a workflow file in bytes.
+	MaxInputSize int
+	// MaxNestingDepth is the maximum depth of nested YAML values. For example, the depth of the value
+	// at "jobs.test.steps" is 4 since the root mapping is counted.
+	MaxNestingDepth int
+	// MaxAliasExpansion is the maximum number of YAML nodes created by expanding aliases like
+	// "*anchor". A nested alias in an anchored value is also counted.
+	MaxAliasExpansion int
+	// MaxExpressionLength is the maximum length of the content of each ${{ }} placeholder in bytes.
+	MaxExpressionLength int
+}
+
+// maxParseLimitsNodes clamps the number of expanded nodes not to overflow int. Nested aliases
+// increase the number exponentially.
+const maxParseLimitsNodes = 1 << 40
+
+// parseLimitsChecker checks YAML nodes parsed from a workflow file against ParseLimits.
+type parseLimitsChecker struct {
+	limits *ParseLimits
+	// sizes is the number of nodes in the anchored values after expanding the aliases in them.
+	sizes    map[*yaml.Node]int
+	expanded int
+	err      *Error
+}
+
+func (c *parseLimitsChecker) errorf(n *yaml.Node, format string, args ...interface{}) {
+	c.err = errorfAt(posAt(n), "resource-limit", format, args...)
+}
+
+// check checks the node and its children recursively. It returns the number of nodes after expanding
+// aliases.
+func (c *parseLimitsChecker) check(n *yaml.Node, depth int) int {
+	if c.err != nil {
+		return 0
+	}
+
+	if l := c.limits.MaxNestingDepth; l > 0 && depth > l {
+		c.errorf(n, "nesting of YAML values is too deep. the depth exceeds the limit %d", l)
+		return 0
+	}
+
+	if n.Kind == yaml.AliasNode {
+		// Anchors always appear before their aliases. Size of a recursive alias is unknown here, but
+		// the alias is reported as an error on parsing.
+		s := c.sizes[n.Alias]
+		c.expanded = min(c.expanded+s, maxParseLimitsNodes)
+		if l := c.limits.MaxAliasExpansion; l > 0 && c.expanded > l {
+			c.errorf(n, "expanding YAML alias %q creates too many nodes. the number of created nodes exceeds the limit %d", n.Value, l)
+		}
+		return s
+	}
+
+	if n.Kind == yaml.ScalarNode {
+		c.checkExpressions(n)
+	}
+
+	size := 1
+	for _, child := range n.Content {
+		size = min(size+c.check(child, depth+1), maxParseLimitsNodes)
+	}
+	if n.Anchor != "" {
+		c.sizes[n] = size
+	}
+	return size
+}
+
+func (c *parseLimitsChecker) checkExpressions(n *yaml.Node) {
+	l := c.limits.MaxExpressionLength
+	if l <= 0 {
+		return
+	}
+	s := n.Value
+	for {
+		i := strings.Index(s, "${{")
+		if i < 0 {
+			return
+		}
+		s = s[i+3:]
+		e := strings.Index(s, "}}")
+		if e < 0 {
+			e = len(s)
+		}
+		if e > l {
+			c.errorf(n, "expression in ${{ }} is too long. its length is %d bytes but the limit is %d bytes", e, l)
+			return
+		}
+		s = s[e:]
+	}
+}
+
+// checkParseLimits checks the YAML tree against the limits. It returns nil when no limit is exceeded.
+func checkParseLimits(root *yaml.Node, limits *ParseLimits) *Error {
+	if limits.MaxNestingDepth <= 0 && limits.MaxAliasExpansion <= 0 && limits.MaxExpressionLength <= 0 {
+		return nil
+	}
+	c := &parseLimitsChecker{limits: limits, sizes: map[*yaml.Node]int{}}
+	c.check(root, 0)
+	return c.err
+}
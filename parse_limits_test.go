@@ -0,0 +1,183 @@
+package actionlint
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestParseWithLimitsExceeded(t *testing.T) {
+	billionLaughs := `a: &a ["lol", "lol", "lol", "lol", "lol", "lol", "lol", "lol", "lol"]
+b: &b [*a, *a, *a, *a, *a, *a, *a, *a, *a]
+c: &c [*b, *b, *b, *b, *b, *b, *b, *b, *b]
+d: &d [*c, *c, *c, *c, *c, *c, *c, *c, *c]
+e: &e [*d, *d, *d, *d, *d, *d, *d, *d, *d]
+f: &f [*e, *e, *e, *e, *e, *e, *e, *e, *e]
+g: &g [*f, *f, *f, *f, *f, *f, *f, *f, *f]
+h: &h [*g, *g, *g, *g, *g, *g, *g, *g, *g]
+i: &i [*h, *h, *h, *h, *h, *h, *h, *h, *h]
+`
+	testCases := []struct {
+		what   string
+		src    string
+		limits ParseLimits
+		want   string
+		line   int
+		col    int
+	}{
+		{
+			what:   "input size",
+			src:    "on: push\njobs:\n",
+			limits: ParseLimits{MaxInputSize: 10},
+			want:   "workflow file is too large. its size is 15 bytes but the limit is 10 bytes",
+			line:   1,
+			col:    1,
+		},
+		{
+			what:   "nesting depth",
+			src:    "on: push\njobs:\n  test:\n    steps:\n      - run: echo\n",
+			limits: ParseLimits{MaxNestingDepth: 4},
+			want:   "nesting of YAML values is too deep. the depth exceeds the limit 4",
+			line:   5,
+			col:    9,
+		},
+		{
+			what:   "deeply nested flow sequence",
+			src:    "on: " + strings.Repeat("[", 1000) + strings.Repeat("]", 1000),
+			limits: ParseLimits{MaxNestingDepth: 100},
+			want:   "nesting of YAML values is too deep. the depth exceeds the limit 100",
+			line:   1,
+			col:    104,
+		},
+		{
+			what:   "alias expansion",
+			src:    billionLaughs,
+			limits: ParseLimits{MaxAliasExpansion: 10000},
+			want:   `expanding YAML alias "d" creates too many nodes. the number of created nodes exceeds the limit 10000`,
+			line:   5,
+			col:    8,
+		},
+		{
+			what:   "expression length",
+			src:    "on: push\njobs:\n  test:\n    runs-on: ubuntu-latest\n    steps:\n      - run: echo ${{ github.sha }} ${{ " + strings.Repeat("a", 101) + " }}\n",
+			limits: ParseLimits{MaxExpressionLength: 100},
+			want:   "expression in ${{ }} is too long. its length is 103 bytes but the limit is 100 bytes",
+			line:   6,
+			col:    14,
+		},
+		{
+			what:   "unclosed expression",
+			src:    "on: push\nname: ${{ " + strings.Repeat("a", 101),
+			limits: ParseLimits{MaxExpressionLength: 100},
+			want:   "expression in ${{ }} is too long. its length is 102 bytes but the limit is 100 bytes",
+			line:   2,
+			col:    7,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.what, func(t *testing.T) {
+			w, errs := ParseWithLimits([]byte(tc.src), &tc.limits)
+			if w != nil {
+				t.Error("syntax tree was returned even if the limit was exceeded")
+			}
+			if len(errs) != 1 {
+				t.Fatalf("wanted one error but got %v", errs)
+			}
+			err := errs[0]
+			if err.Kind != "resource-limit" {
+				t.Errorf("wanted resource-limit error but got %q kind", err.Kind)
+			}
+			if err.Message != tc.want {
+				t.Errorf("wanted message %q but got %q", tc.want, err.Message)
+			}
+			if err.Line != tc.line || err.Column != tc.col {
+				t.Errorf("wanted position %d:%d but got %d:%d", tc.line, tc.col, err.Line, err.Column)
+			}
+		})
+	}
+}
+
+func TestParseWithLimitsNotExceeded(t *testing.T) {
+	src := `on: push
+x-anchors: &steps
+  - run: echo ${{ github.sha }}
+jobs:
+  test:
+    runs-on: ubuntu-latest
+    steps: *steps
+`
+	limits := &ParseLimits{
+		MaxInputSize:        len(src),
+		MaxNestingDepth:     5,
+		MaxAliasExpansion:   5,
+		MaxExpressionLength: len(" github.sha "),
+	}
+	_, errs := ParseWithLimits([]byte(src), limits)
+	for _, err := range errs {
+		if err.Kind == "resource-limit" {
+			t.Fatalf("limit should not be exceeded: %v", err)
+		}
+	}
+
+	// Zero values mean no limit
+	_, errs = ParseWithLimits([]byte(src), &ParseLimits{})
+	for _, err := range errs {
+		if err.Kind == "resource-limit" {
+			t.Fatalf("limit should not be exceeded: %v", err)
+		}
+	}
+}
+
+func TestLinterParseLimits(t *testing.T) {
+	src := "on: push\njobs:\n  test:\n    runs-on: ubuntu-latest\n    steps:\n      - run: echo ${{ unknown.context }}\n"
+	l, err := NewLinter(io.Discard, &LinterOptions{ParseLimits: &ParseLimits{MaxExpressionLength: 10}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	errs, err := l.Lint("test.yaml", []byte(src), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(errs) != 1 || errs[0].Kind != "resource-limit" {
+		t.Fatalf("wanted only one resource-limit error since rules should not run but got %v", errs)
+	}
+
+	_, err = NewLinter(io.Discard, &LinterOptions{ParseLimits: &ParseLimits{MaxNestingDepth: -1}})
+	if err == nil || !strings.Contains(err.Error(), "limits of parsing workflow files must not be negative") {
+		t.Fatalf("unexpected error for negative limit: %v", err)
+	}
+}
+
+func TestParseWithLimitsExponentialAliases(t *testing.T) {
+	// Number of expanded nodes is 2^64. It must not overflow
+	var sb strings.Builder
+	sb.WriteString("a0: &a0 [lol]\n")
+	for i := 1; i < 64; i++ {
+		fmt.Fprintf(&sb, "a%d: &a%d [*a%d, *a%d]\n", i, i, i-1, i-1)
+	}
+	sb.WriteString("b: [*a63, *a63, *a63, *a63]\n")
+	_, errs := ParseWithLimits([]byte(sb.String()), &ParseLimits{MaxAliasExpansion: maxParseLimitsNodes - 1})
+	if len(errs) != 1 || errs[0].Kind != "resource-limit" {
+		t.Fatalf("wanted one resource-limit error but got %v", errs)
+	}
+}
+
+func TestLinterParseLimitsFormatKind(t *testing.T) {
+	var b strings.Builder
+	opts := &LinterOptions{
+		Format:      "{{range $ := .}}{{$.Kind}}{{end}}{{range $ := allKinds}} {{$.Name}}{{end}}",
+		ParseLimits: &ParseLimits{MaxInputSize: 1},
+	}
+	l, err := NewLinter(&b, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := l.Lint("test.yaml", []byte("on: push"), nil); err != nil {
+		t.Fatal(err)
+	}
+	if want := "resource-limit resource-limit syntax-check"; b.String() != want {
+		t.Fatalf("wanted %q but got %q", want, b.String())
+	}
+}
@@ -72,3 +72,45 @@ func BenchmarkParseTestData(b *testing.B) {
 		})
 	}
 }
+
+func TestParseScalarEndPositions(t *testing.T) {
+	src := `name: "quoted \" name"
+run-name: 'it''s run'
+on: push
+env:
+  FOO: ほげ
+  BAR: |
+    multi
+    line
+  PIYO: folded
+    plain
+jobs:
+  test:
+    runs-on: ubuntu-latest
+    steps:
+      - run: echo
+`
+	w, errs := Parse([]byte(src))
+	if len(errs) > 0 {
+		t.Fatal(errs)
+	}
+
+	tests := []struct {
+		what string
+		pos  *Pos
+		want Pos
+	}{
+		{"double-quoted string", w.Name.Pos, Pos{Line: 1, Col: 7, EndLine: 1, EndCol: 23}},
+		{"single-quoted string", w.RunName.Pos, Pos{Line: 2, Col: 11, EndLine: 2, EndCol: 22}},
+		{"key", w.Env.Vars["foo"].Name.Pos, Pos{Line: 5, Col: 3, EndLine: 5, EndCol: 6}},
+		{"multi-byte string", w.Env.Vars["foo"].Value.Pos, Pos{Line: 5, Col: 8, EndLine: 5, EndCol: 10}},
+		{"block scalar", w.Env.Vars["bar"].Value.Pos, Pos{Line: 6, Col: 8}},
+		{"multi-line plain string", w.Env.Vars["piyo"].Value.Pos, Pos{Line: 9, Col: 9}},
+		{"plain string", w.Jobs["test"].RunsOn.Labels[0].Pos, Pos{Line: 13, Col: 14, EndLine: 13, EndCol: 27}},
+	}
+	for _, tc := range tests {
+		if *tc.pos != tc.want {
+			t.Errorf("position of %s is unexpected. wanted %+v but got %+v", tc.what, tc.want, *tc.pos)
+		}
+	}
+}
@@ -1,8 +1,11 @@
 package actionlint
 
 import (
+	"context"
 	"fmt"
 	"io"
+	"maps"
+	"slices"
 	"time"
 )
 
@@ -20,10 +23,89 @@ type Pass interface {
 	VisitWorkflowPost(node *Workflow) error
 }
 
+// The following interfaces are optional interfaces of Pass to visit finer nodes in a workflow
+// syntax tree. When a pass added to Visitor implements some of them, the callbacks are called in
+// addition to the methods of Pass. Callbacks for the nodes in a workflow or a job are called after
+// VisitWorkflowPre or VisitJobPre and before visiting the jobs or steps in it. Callbacks for the
+// nodes in a step are called after VisitStep. All callbacks return internal error when they cannot
+// continue the process.
+
+// EventPass is a pass to visit Event nodes at "on:" section in a workflow.
+type EventPass interface {
+	// VisitEvent is callback when visiting Event node.
+	VisitEvent(node Event) error
+}
+
+// PermissionsPass is a pass to visit Permissions nodes in a workflow and jobs.
+type PermissionsPass interface {
+	// VisitPermissions is callback when visiting Permissions node.
+	VisitPermissions(node *Permissions) error
+}
+
+// EnvPass is a pass to visit Env nodes in a workflow, jobs, steps, and containers.
+type EnvPass interface {
+	// VisitEnv is callback when visiting Env node.
+	VisitEnv(node *Env) error
+}
+
+// DefaultsPass is a pass to visit Defaults nodes in a workflow and jobs.
+type DefaultsPass interface {
+	// VisitDefaults is callback when visiting Defaults node.
+	VisitDefaults(node *Defaults) error
+}
+
+// ConcurrencyPass is a pass to visit Concurrency nodes in a workflow and jobs.
+type ConcurrencyPass interface {
+	// VisitConcurrency is callback when visiting Concurrency node.
+	VisitConcurrency(node *Concurrency) error
+}
+
+// EnvironmentPass is a pass to visit Environment nodes in jobs.
+type EnvironmentPass interface {
+	// VisitEnvironment is callback when visiting Environment node.
+	VisitEnvironment(node *Environment) error
+}
+
+// RunnerPass is a pass to visit Runner nodes at "runs-on:" in jobs.
+type RunnerPass interface {
+	// VisitRunner is callback when visiting Runner node.
+	VisitRunner(node *Runner) error
+}
+
+// StrategyPass is a pass to visit Strategy nodes in jobs.
+type StrategyPass interface {
+	// VisitStrategy is callback when visiting Strategy node.
+	VisitStrategy(node *Strategy) error
+}
+
+// MatrixRowPass is a pass to visit MatrixRow nodes in matrices of jobs. Rows are visited in order
+// of their names.
+type MatrixRowPass interface {
+	// VisitMatrixRow is callback when visiting MatrixRow node.
+	VisitMatrixRow(node *MatrixRow) error
+}
+
+// ContainerPass is a pass to visit Container nodes at "container:" and "services:" in jobs.
+// Services are visited in order of their names.
+type ContainerPass interface {
+	// VisitContainer is callback when visiting Container node.
+	VisitContainer(node *Container) error
+}
+
 // Visitor visits syntax tree from root in depth-first order
 type Visitor struct {
-	passes []Pass
-	dbg    io.Writer
+	passes       []Pass
+	events       []EventPass
+	permissions  []PermissionsPass
+	envs         []EnvPass
+	defaults     []DefaultsPass
+	concurrency  []ConcurrencyPass
+	environments []EnvironmentPass
+	runners      []RunnerPass
+	strategies   []StrategyPass
+	matrixRows   []MatrixRowPass
+	containers   []ContainerPass
+	dbg          io.Writer
 }
 
 // NewVisitor creates Visitor instance
@@ -31,9 +113,40 @@ func NewVisitor() *Visitor {
 	return &Visitor{}
 }
 
-// AddPass adds new pass which is called on traversing a syntax tree
+// AddPass adds new pass which is called on traversing a syntax tree. When the pass implements
+// optional interfaces like EventPass, their callbacks are also called.
 func (v *Visitor) AddPass(p Pass) {
 	v.passes = append(v.passes, p)
+	if p, ok := p.(EventPass); ok {
+		v.events = append(v.events, p)
+	}
+	if p, ok := p.(PermissionsPass); ok {
+		v.permissions = append(v.permissions, p)
+	}
+	if p, ok := p.(EnvPass); ok {
+		v.envs = append(v.envs, p)
+	}
+	if p, ok := p.(DefaultsPass); ok {
+		v.defaults = append(v.defaults, p)
+	}
+	if p, ok := p.(ConcurrencyPass); ok {
+		v.concurrency = append(v.concurrency, p)
+	}
+	if p, ok := p.(EnvironmentPass); ok {
+		v.environments = append(v.environments, p)
+	}
+	if p, ok := p.(RunnerPass); ok {
+		v.runners = append(v.runners, p)
+	}
+	if p, ok := p.(StrategyPass); ok {
+		v.strategies = append(v.strategies, p)
+	}
+	if p, ok := p.(MatrixRowPass); ok {
+		v.matrixRows = append(v.matrixRows, p)
+	}
+	if p, ok := p.(ContainerPass); ok {
+		v.containers = append(v.containers, p)
+	}
 }
 
 // EnableDebug enables debug output when non-nil io.Writer value is given. All debug outputs from
@@ -48,6 +161,16 @@ func (v *Visitor) reportElapsedTime(what string, start time.Time) {
 
 // Visit visits given syntax tree in depth-first order
 func (v *Visitor) Visit(n *Workflow) error {
+	return v.VisitContext(context.Background(), n)
+}
+
+// VisitContext visits given syntax tree in depth-first order as well as Visit. When the context is
+// cancelled, it stops visiting the remaining jobs and returns the error of the context.
+func (v *Visitor) VisitContext(ctx context.Context, n *Workflow) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
 	var t time.Time
 	if v.dbg != nil {
 		t = time.Now()
@@ -59,12 +182,19 @@ func (v *Visitor) Visit(n *Workflow) error {
 		}
 	}
 
+	if err := v.visitWorkflowNodes(n); err != nil {
+		return err
+	}
+
 	if v.dbg != nil {
 		v.reportElapsedTime("VisitWorkflowPre", t)
 		t = time.Now()
 	}
 
 	for _, j := range n.Jobs {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
 		if err := v.visitJob(j); err != nil {
 			return err
 		}
@@ -100,6 +230,10 @@ func (v *Visitor) visitJob(n *Job) error {
 		}
 	}
 
+	if err := v.visitJobNodes(n); err != nil {
+		return err
+	}
+
 	if v.dbg != nil {
 		v.reportElapsedTime(fmt.Sprintf("VisitWorkflowJobPre at job %q", n.ID.Value), t)
 		t = time.Now()
@@ -141,9 +275,181 @@ func (v *Visitor) visitStep(n *Step) error {
 		}
 	}
 
+	if err := visitNode(v.envs, n.Env, EnvPass.VisitEnv); err != nil {
+		return err
+	}
+
 	if v.dbg != nil {
 		v.reportElapsedTime(fmt.Sprintf("VisitStep at %s", n.Pos), t)
 	}
 
 	return nil
 }
+
+// visitNode calls the callback of the passes with the node. Nil node is not visited.
+func visitNode[P any, N comparable](passes []P, node N, visit func(P, N) error) error {
+	var zero N
+	if node == zero {
+		return nil
+	}
+	for _, p := range passes {
+		if err := visit(p, node); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (v *Visitor) visitWorkflowNodes(n *Workflow) error {
+	for _, e := range n.On {
+		if err := visitNode(v.events, e, EventPass.VisitEvent); err != nil {
+			return err
+		}
+	}
+	if err := visitNode(v.permissions, n.Permissions, PermissionsPass.VisitPermissions); err != nil {
+		return err
+	}
+	if err := visitNode(v.envs, n.Env, EnvPass.VisitEnv); err != nil {
+		return err
+	}
+	if err := visitNode(v.defaults, n.Defaults, DefaultsPass.VisitDefaults); err != nil {
+		return err
+	}
+	return visitNode(v.concurrency, n.Concurrency, ConcurrencyPass.VisitConcurrency)
+}
+
+func (v *Visitor) visitJobNodes(n *Job) error {
+	if err := visitNode(v.runners, n.RunsOn, RunnerPass.VisitRunner); err != nil {
+		return err
+	}
+	if err := visitNode(v.permissions, n.Permissions, PermissionsPass.VisitPermissions); err != nil {
+		return err
+	}
+	if err := visitNode(v.environments, n.Environment, EnvironmentPass.VisitEnvironment); err != nil {
+		return err
+	}
+	if err := visitNode(v.concurrency, n.Concurrency, ConcurrencyPass.VisitConcurrency); err != nil {
+		return err
+	}
+	if err := visitNode(v.envs, n.Env, EnvPass.VisitEnv); err != nil {
+		return err
+	}
+	if err := visitNode(v.defaults, n.Defaults, DefaultsPass.VisitDefaults); err != nil {
+		return err
+	}
+	if err := visitNode(v.strategies, n.Strategy, StrategyPass.VisitStrategy); err != nil {
+		return err
+	}
+	if n.Strategy != nil && n.Strategy.Matrix != nil && len(v.matrixRows) > 0 {
+		m := n.Strategy.Matrix
+		for _, name := range slices.Sorted(maps.Keys(m.Rows)) {
+			if err := visitNode(v.matrixRows, m.Rows[name], MatrixRowPass.VisitMatrixRow); err != nil {
+				return err
+			}
+		}
+	}
+	if err := v.visitContainer(n.Container); err != nil {
+		return err
+	}
+	if n.Services != nil {
+		for _, name := range slices.Sorted(maps.Keys(n.Services.Value)) {
+			if err := v.visitContainer(n.Services.Value[name].Container); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (v *Visitor) visitContainer(n *Container) error {
+	if n == nil {
+		return nil
+	}
+	if err := visitNode(v.containers, n, ContainerPass.VisitContainer); err != nil {
+		return err
+	}
+	return visitNode(v.envs, n.Env, EnvPass.VisitEnv)
+}
+
+// timedPass is a pass to measure the total time taken by the wrapped pass.
+type timedPass struct {
+	pass    Pass
+	elapsed time.Duration
+}
+
+// measure is called as p.measure(time.Now(), p.pass.VisitXxx(n)). Since arguments are evaluated
+// from left to right, the start time is taken before calling the wrapped pass.
+func (p *timedPass) measure(start time.Time, err error) error {
+	p.elapsed += time.Since(start)
+	return err
+}
+
+func (p *timedPass) VisitStep(n *Step) error {
+	return p.measure(time.Now(), p.pass.VisitStep(n))
+}
+
+func (p *timedPass) VisitJobPre(n *Job) error {
+	return p.measure(time.Now(), p.pass.VisitJobPre(n))
+}
+
+func (p *timedPass) VisitJobPost(n *Job) error {
+	return p.measure(time.Now(), p.pass.VisitJobPost(n))
+}
+
+func (p *timedPass) VisitWorkflowPre(n *Workflow) error {
+	return p.measure(time.Now(), p.pass.VisitWorkflowPre(n))
+}
+
+func (p *timedPass) VisitWorkflowPost(n *Workflow) error {
+	return p.measure(time.Now(), p.pass.VisitWorkflowPost(n))
+}
+
+// timedOptionalPass measures the time taken by the callback of the optional interface like EventPass
+// when the wrapped pass implements it.
+func timedOptionalPass[P any, N any](p *timedPass, node N, visit func(P, N) error) error {
+	o, ok := p.pass.(P)
+	if !ok {
+		return nil
+	}
+	return p.measure(time.Now(), visit(o, node))
+}
+
+func (p *timedPass) VisitEvent(n Event) error {
+	return timedOptionalPass(p, n, EventPass.VisitEvent)
+}
+
+func (p *timedPass) VisitPermissions(n *Permissions) error {
+	return timedOptionalPass(p, n, PermissionsPass.VisitPermissions)
+}
+
+func (p *timedPass) VisitEnv(n *Env) error {
+	return timedOptionalPass(p, n, EnvPass.VisitEnv)
+}
+
+func (p *timedPass) VisitDefaults(n *Defaults) error {
+	return timedOptionalPass(p, n, DefaultsPass.VisitDefaults)
+}
+
+func (p *timedPass) VisitConcurrency(n *Concurrency) error {
+	return timedOptionalPass(p, n, ConcurrencyPass.VisitConcurrency)
+}
+
+func (p *timedPass) VisitEnvironment(n *Environment) error {
+	return timedOptionalPass(p, n, EnvironmentPass.VisitEnvironment)
+}
+
+func (p *timedPass) VisitRunner(n *Runner) error {
+	return timedOptionalPass(p, n, RunnerPass.VisitRunner)
+}
+
+func (p *timedPass) VisitStrategy(n *Strategy) error {
+	return timedOptionalPass(p, n, StrategyPass.VisitStrategy)
+}
+
+func (p *timedPass) VisitMatrixRow(n *MatrixRow) error {
+	return timedOptionalPass(p, n, MatrixRowPass.VisitMatrixRow)
+}
+
+func (p *timedPass) VisitContainer(n *Container) error {
+	return timedOptionalPass(p, n, ContainerPass.VisitContainer)
+}
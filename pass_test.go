@@ -0,0 +1,220 @@
+package actionlint
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"testing"
+)
+
+type testRecordingPass struct {
+	visited []string
+	err     error
+	errAt   string
+}
+
+func (p *testRecordingPass) record(what string) error {
+	p.visited = append(p.visited, what)
+	if what == p.errAt {
+		return p.err
+	}
+	return nil
+}
+
+func (p *testRecordingPass) VisitStep(n *Step) error {
+	return p.record("step " + n.Name.Value)
+}
+func (p *testRecordingPass) VisitJobPre(n *Job) error {
+	return p.record("job pre " + n.ID.Value)
+}
+func (p *testRecordingPass) VisitJobPost(n *Job) error {
+	return p.record("job post " + n.ID.Value)
+}
+func (p *testRecordingPass) VisitWorkflowPre(n *Workflow) error {
+	return p.record("workflow pre")
+}
+func (p *testRecordingPass) VisitWorkflowPost(n *Workflow) error {
+	return p.record("workflow post")
+}
+func (p *testRecordingPass) VisitEvent(n Event) error {
+	return p.record("event " + n.EventName())
+}
+func (p *testRecordingPass) VisitPermissions(n *Permissions) error {
+	return p.record(fmt.Sprintf("permissions at line %d", n.Pos.Line))
+}
+func (p *testRecordingPass) VisitEnv(n *Env) error {
+	names := []string{}
+	for _, v := range n.Vars {
+		names = append(names, v.Name.Value)
+	}
+	slices.Sort(names)
+	return p.record(fmt.Sprintf("env %v", names))
+}
+func (p *testRecordingPass) VisitDefaults(n *Defaults) error {
+	return p.record(fmt.Sprintf("defaults at line %d", n.Pos.Line))
+}
+func (p *testRecordingPass) VisitConcurrency(n *Concurrency) error {
+	return p.record("concurrency " + n.Group.Value)
+}
+func (p *testRecordingPass) VisitEnvironment(n *Environment) error {
+	return p.record("environment " + n.Name.Value)
+}
+func (p *testRecordingPass) VisitRunner(n *Runner) error {
+	return p.record("runner " + n.Labels[0].Value)
+}
+func (p *testRecordingPass) VisitStrategy(n *Strategy) error {
+	return p.record(fmt.Sprintf("strategy at line %d", n.Pos.Line))
+}
+func (p *testRecordingPass) VisitMatrixRow(n *MatrixRow) error {
+	return p.record("matrix row " + n.Name.Value)
+}
+func (p *testRecordingPass) VisitContainer(n *Container) error {
+	return p.record("container " + n.Image.Value)
+}
+
+// testStepOnlyPass does not implement any optional interface
+type testStepOnlyPass struct {
+	RuleBase
+	steps int
+}
+
+func (p *testStepOnlyPass) VisitStep(n *Step) error {
+	p.steps++
+	return nil
+}
+
+const testVisitorWorkflow = `on:
+  push:
+  pull_request:
+permissions:
+  contents: read
+env:
+  FOO: foo
+defaults:
+  run:
+    shell: bash
+concurrency: wf
+jobs:
+  test:
+    runs-on: ubuntu-latest
+    permissions: read-all
+    environment: prod
+    concurrency: job
+    env:
+      BAR: bar
+    defaults:
+      run:
+        shell: pwsh
+    strategy:
+      matrix:
+        os: [linux, windows]
+        go: ['1.24', '1.25']
+    container:
+      image: golang
+      env:
+        PIYO: piyo
+    services:
+      redis:
+        image: redis
+      db:
+        image: postgres
+    steps:
+      - name: hello
+        run: echo hello
+        env:
+          BAZ: baz
+`
+
+func TestVisitorOptionalPasses(t *testing.T) {
+	w, errs := Parse([]byte(testVisitorWorkflow))
+	if len(errs) > 0 {
+		t.Fatal(errs)
+	}
+
+	p := &testRecordingPass{}
+	s := &testStepOnlyPass{}
+	v := NewVisitor()
+	v.AddPass(p)
+	v.AddPass(s)
+	if err := v.Visit(w); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{
+		"workflow pre",
+		"event push",
+		"event pull_request",
+		"permissions at line 4",
+		"env [FOO]",
+		"defaults at line 8",
+		"concurrency wf",
+		"job pre test",
+		"runner ubuntu-latest",
+		"permissions at line 15",
+		"environment prod",
+		"concurrency job",
+		"env [BAR]",
+		"defaults at line 20",
+		"strategy at line 23",
+		"matrix row go",
+		"matrix row os",
+		"container golang",
+		"env [PIYO]",
+		"container postgres",
+		"container redis",
+		"step hello",
+		"env [BAZ]",
+		"job post test",
+		"workflow post",
+	}
+	if !slices.Equal(want, p.visited) {
+		t.Fatalf("wanted %#v but got %#v", want, p.visited)
+	}
+	if s.steps != 1 {
+		t.Fatalf("pass without optional interfaces should visit 1 step but visited %d", s.steps)
+	}
+}
+
+func TestVisitorOptionalPassesTimed(t *testing.T) {
+	w, errs := Parse([]byte(testVisitorWorkflow))
+	if len(errs) > 0 {
+		t.Fatal(errs)
+	}
+
+	p := &testRecordingPass{}
+	tp := &timedPass{pass: p}
+	s := &timedPass{pass: &testStepOnlyPass{}}
+	v := NewVisitor()
+	v.EnableDebug(io.Discard)
+	v.AddPass(tp)
+	v.AddPass(s)
+	if err := v.Visit(w); err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Contains(p.visited, "matrix row os") || !slices.Contains(p.visited, "event push") {
+		t.Fatalf("optional callbacks were not called through timed pass: %v", p.visited)
+	}
+}
+
+func TestVisitorOptionalPassError(t *testing.T) {
+	w, errs := Parse([]byte(testVisitorWorkflow))
+	if len(errs) > 0 {
+		t.Fatal(errs)
+	}
+
+	for _, at := range []string{"event push", "matrix row go", "container redis", "env [BAZ]"} {
+		t.Run(at, func(t *testing.T) {
+			want := errors.New("dummy error")
+			p := &testRecordingPass{err: want, errAt: at}
+			v := NewVisitor()
+			v.AddPass(p)
+			if err := v.Visit(w); err != want {
+				t.Fatalf("wanted error %v but got %v", want, err)
+			}
+			if last := p.visited[len(p.visited)-1]; last != at {
+				t.Fatalf("visiting should stop at %q but stopped at %q", at, last)
+			}
+		})
+	}
+}
@@ -0,0 +1,176 @@
+package actionlint
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+)
+
+// perfMaxFilesInTable is the maximum number of the slowest files printed in the table of -perf flag.
+const perfMaxFilesInTable = 20
+
+// perfEntry is the time spent by a rule, a workflow file, or an external command.
+type perfEntry struct {
+	Name string `json:"name"`
+	// Count is the number of files checked by the rule or the number of runs of the command. It is
+	// always 1 for a file.
+	Count int `json:"count"`
+	// Errors is the number of errors reported by the rule or found in the file. It is always 0 for an
+	// external command.
+	Errors int `json:"errors"`
+	// Time is the total wall-clock time in nanoseconds.
+	Time time.Duration `json:"time_ns"`
+}
+
+// perfReport collects the time spent by each rule, workflow file, and external command via the
+// lifecycle hooks of LinterOptions. This is the implementation of -perf flag.
+type perfReport struct {
+	mu       sync.Mutex
+	total    time.Duration
+	rules    map[string]*perfEntry
+	files    []*perfEntry
+	commands map[string]*perfEntry
+}
+
+func newPerfReport() *perfReport {
+	return &perfReport{
+		rules:    map[string]*perfEntry{},
+		commands: map[string]*perfEntry{},
+	}
+}
+
+func (r *perfReport) add(m map[string]*perfEntry, name string, errs int, elapsed time.Duration) {
+	e, ok := m[name]
+	if !ok {
+		e = &perfEntry{Name: name}
+		m[name] = e
+	}
+	e.Count++
+	e.Errors += errs
+	e.Time += elapsed
+}
+
+// setHooks sets the hooks to the options. Existing hooks in the options are still called.
+func (r *perfReport) setHooks(opts *LinterOptions) {
+	onFile := opts.OnFileFinished
+	opts.OnFileFinished = func(path string, errs []*Error, elapsed time.Duration) {
+		r.mu.Lock()
+		r.files = append(r.files, &perfEntry{path, 1, len(errs), elapsed})
+		r.mu.Unlock()
+		if onFile != nil {
+			onFile(path, errs, elapsed)
+		}
+	}
+	onRule := opts.OnRuleFinished
+	opts.OnRuleFinished = func(path, rule string, errs int, elapsed time.Duration) {
+		r.mu.Lock()
+		r.add(r.rules, rule, errs, elapsed)
+		r.mu.Unlock()
+		if onRule != nil {
+			onRule(path, rule, errs, elapsed)
+		}
+	}
+	onCmd := opts.OnExternalCommandFinished
+	opts.OnExternalCommandFinished = func(exe string, args []string, elapsed time.Duration) {
+		name := strings.TrimSuffix(filepath.Base(exe), ".exe")
+		r.mu.Lock()
+		r.add(r.commands, name, 0, elapsed)
+		r.mu.Unlock()
+		if onCmd != nil {
+			onCmd(exe, args, elapsed)
+		}
+	}
+}
+
+// sortedPerfEntries returns the entries sorted by the time in descending order.
+func sortedPerfEntries(es []*perfEntry) []*perfEntry {
+	slices.SortStableFunc(es, func(a, b *perfEntry) int {
+		if c := cmp.Compare(b.Time, a.Time); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Name, b.Name)
+	})
+	return es
+}
+
+func (r *perfReport) sortedRules() []*perfEntry {
+	return sortedPerfEntries(slices.Collect(maps.Values(r.rules)))
+}
+
+func (r *perfReport) sortedCommands() []*perfEntry {
+	return sortedPerfEntries(slices.Collect(maps.Values(r.commands)))
+}
+
+func formatPerfDuration(d time.Duration) string {
+	return fmt.Sprintf("%.2fms", float64(d)/float64(time.Millisecond))
+}
+
+// printTable prints the report as human-readable tables sorted by the time in descending order.
+// Only the slowest files are printed.
+func (r *perfReport) printTable(out io.Writer) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	w := tabwriter.NewWriter(out, 0, 8, 2, ' ', 0)
+	fmt.Fprintf(w, "Total time: %s for %d files\n", formatPerfDuration(r.total), len(r.files))
+
+	if rules := r.sortedRules(); len(rules) > 0 {
+		fmt.Fprintln(w, "\nRule\tTime\tFiles\tErrors")
+		for _, e := range rules {
+			fmt.Fprintf(w, "%s\t%s\t%d\t%d\n", e.Name, formatPerfDuration(e.Time), e.Count, e.Errors)
+		}
+	}
+
+	if len(r.files) > 0 {
+		fmt.Fprintln(w, "\nFile\tTime\tErrors")
+		files := sortedPerfEntries(slices.Clone(r.files))
+		for i, e := range files {
+			if i == perfMaxFilesInTable {
+				fmt.Fprintf(w, "(%d more files)\n", len(files)-i)
+				break
+			}
+			fmt.Fprintf(w, "%s\t%s\t%d\n", e.Name, formatPerfDuration(e.Time), e.Errors)
+		}
+	}
+
+	if cmds := r.sortedCommands(); len(cmds) > 0 {
+		fmt.Fprintln(w, "\nExternal command\tTime\tRuns")
+		for _, e := range cmds {
+			fmt.Fprintf(w, "%s\t%s\t%d\n", e.Name, formatPerfDuration(e.Time), e.Count)
+		}
+	}
+
+	return w.Flush()
+}
+
+// printJSON prints the report as one JSON object. All files are included.
+func (r *perfReport) printJSON(out io.Writer) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	v := struct {
+		Total    time.Duration `json:"total_ns"`
+		Rules    []*perfEntry  `json:"rules"`
+		Files    []*perfEntry  `json:"files"`
+		Commands []*perfEntry  `json:"commands"`
+	}{
+		r.total,
+		r.sortedRules(),
+		sortedPerfEntries(slices.Clone(r.files)),
+		r.sortedCommands(),
+	}
+	enc := json.NewEncoder(out)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(&v); err != nil {
+		return fmt.Errorf("could not encode performance report into JSON: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,128 @@
+package actionlint
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func testPerfReport() *perfReport {
+	r := newPerfReport()
+	opts := &LinterOptions{}
+	r.setHooks(opts)
+	opts.OnRuleFinished("a.yaml", "expression", 2, 3*time.Millisecond)
+	opts.OnRuleFinished("b.yaml", "expression", 1, 2*time.Millisecond)
+	opts.OnRuleFinished("a.yaml", "shellcheck", 0, 10*time.Millisecond)
+	opts.OnFileFinished("a.yaml", []*Error{{}, {}}, 20*time.Millisecond)
+	opts.OnFileFinished("b.yaml", []*Error{{}}, 5*time.Millisecond)
+	opts.OnExternalCommandFinished("/usr/bin/shellcheck", nil, 8*time.Millisecond)
+	opts.OnExternalCommandFinished("/usr/bin/shellcheck", nil, 1*time.Millisecond)
+	r.total = 30 * time.Millisecond
+	return r
+}
+
+func TestPerfReportJSON(t *testing.T) {
+	var b bytes.Buffer
+	if err := testPerfReport().printJSON(&b); err != nil {
+		t.Fatal(err)
+	}
+	var have struct {
+		Total    time.Duration `json:"total_ns"`
+		Rules    []*perfEntry  `json:"rules"`
+		Files    []*perfEntry  `json:"files"`
+		Commands []*perfEntry  `json:"commands"`
+	}
+	if err := json.Unmarshal(b.Bytes(), &have); err != nil {
+		t.Fatal(err)
+	}
+	if have.Total != 30*time.Millisecond {
+		t.Errorf("wanted total 30ms but got %v", have.Total)
+	}
+	wantRules := []*perfEntry{
+		{"shellcheck", 1, 0, 10 * time.Millisecond},
+		{"expression", 2, 3, 5 * time.Millisecond},
+	}
+	if diff := cmp.Diff(wantRules, have.Rules); diff != "" {
+		t.Error(diff)
+	}
+	wantFiles := []*perfEntry{
+		{"a.yaml", 1, 2, 20 * time.Millisecond},
+		{"b.yaml", 1, 1, 5 * time.Millisecond},
+	}
+	if diff := cmp.Diff(wantFiles, have.Files); diff != "" {
+		t.Error(diff)
+	}
+	wantCmds := []*perfEntry{{"shellcheck", 2, 0, 9 * time.Millisecond}}
+	if diff := cmp.Diff(wantCmds, have.Commands); diff != "" {
+		t.Error(diff)
+	}
+}
+
+func TestPerfReportTable(t *testing.T) {
+	var b bytes.Buffer
+	if err := testPerfReport().printTable(&b); err != nil {
+		t.Fatal(err)
+	}
+	want := `Total time: 30.00ms for 2 files
+
+Rule        Time     Files  Errors
+shellcheck  10.00ms  1      0
+expression  5.00ms   2      3
+
+File    Time     Errors
+a.yaml  20.00ms  2
+b.yaml  5.00ms   1
+
+External command  Time    Runs
+shellcheck        9.00ms  2
+`
+	if diff := cmp.Diff(want, b.String()); diff != "" {
+		t.Fatal(diff)
+	}
+}
+
+func TestPerfReportTableSlowestFiles(t *testing.T) {
+	r := newPerfReport()
+	opts := &LinterOptions{}
+	r.setHooks(opts)
+	for i := 0; i < perfMaxFilesInTable+5; i++ {
+		opts.OnFileFinished(fmt.Sprintf("%02d.yaml", i), nil, time.Duration(i)*time.Millisecond)
+	}
+	var b bytes.Buffer
+	if err := r.printTable(&b); err != nil {
+		t.Fatal(err)
+	}
+	out := b.String()
+	if !strings.Contains(out, "(5 more files)") {
+		t.Errorf("number of omitted files is not printed: %q", out)
+	}
+	if strings.Contains(out, "04.yaml") || !strings.Contains(out, "05.yaml") {
+		t.Errorf("slowest files are not printed: %q", out)
+	}
+	if strings.Contains(out, "Rule") || strings.Contains(out, "External command") {
+		t.Errorf("empty tables should not be printed: %q", out)
+	}
+}
+
+func TestPerfReportCallsExistingHooks(t *testing.T) {
+	called := []string{}
+	opts := &LinterOptions{
+		OnFileFinished: func(string, []*Error, time.Duration) { called = append(called, "file") },
+		OnRuleFinished: func(string, string, int, time.Duration) { called = append(called, "rule") },
+		OnExternalCommandFinished: func(string, []string, time.Duration) {
+			called = append(called, "command")
+		},
+	}
+	newPerfReport().setHooks(opts)
+	opts.OnRuleFinished("a.yaml", "expression", 0, 0)
+	opts.OnExternalCommandFinished("shellcheck", nil, 0)
+	opts.OnFileFinished("a.yaml", nil, 0)
+	if diff := cmp.Diff([]string{"rule", "command", "file"}, called); diff != "" {
+		t.Fatal(diff)
+	}
+}
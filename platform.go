@@ -0,0 +1,109 @@
+package actionlint
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"go.yaml.in/yaml/v4"
+)
+
+// Platform is a kind of the service which runs workflows. Gitea Actions and Forgejo Actions reuse
+// the workflow syntax of GitHub Actions with some deviations. actionlint adjusts its checks to the
+// platform.
+// https://docs.gitea.com/usage/actions/comparison
+// https://forgejo.org/docs/latest/user/actions/
+type Platform string
+
+const (
+	// PlatformGitHub is GitHub Actions. This is the default platform.
+	PlatformGitHub Platform = "github"
+	// PlatformGitea is Gitea Actions.
+	PlatformGitea Platform = "gitea"
+	// PlatformForgejo is Forgejo Actions.
+	PlatformForgejo Platform = "forgejo"
+)
+
+// ParsePlatform parses the name of platform. Empty string is parsed as GitHub Actions.
+func ParsePlatform(s string) (Platform, error) {
+	switch p := Platform(strings.ToLower(s)); p {
+	case "":
+		return PlatformGitHub, nil
+	case PlatformGitHub, PlatformGitea, PlatformForgejo:
+		return p, nil
+	default:
+		return "", fmt.Errorf("platform must be one of \"github\", \"gitea\", or \"forgejo\" but got %q", s)
+	}
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (p *Platform) UnmarshalYAML(n *yaml.Node) error {
+	if n.Kind != yaml.ScalarNode {
+		return fmt.Errorf("yaml: \"platform\" must be a string at line:%d,col:%d", n.Line, n.Column)
+	}
+	v, err := ParsePlatform(n.Value)
+	if err != nil {
+		return fmt.Errorf("invalid \"platform\" at line:%d,col:%d: %w", n.Line, n.Column, err)
+	}
+	*p = v
+	return nil
+}
+
+// giteaCompatible returns whether the platform is Gitea Actions or its fork Forgejo Actions.
+func (p Platform) giteaCompatible() bool {
+	return p == PlatformGitea || p == PlatformForgejo
+}
+
+// displayName returns the name of the platform shown in error messages.
+func (p Platform) displayName() string {
+	switch p {
+	case PlatformGitea:
+		return "Gitea Actions"
+	case PlatformForgejo:
+		return "Forgejo Actions"
+	default:
+		return "GitHub Actions"
+	}
+}
+
+// workflowsDirs returns directories of workflow files relative to the repository root in order of
+// priority. Gitea and Forgejo read workflows from their own directories and fall back to
+// ".github/workflows" only when the directory does not exist.
+func (p Platform) workflowsDirs() []string {
+	gh := filepath.Join(".github", "workflows")
+	switch p {
+	case PlatformGitea:
+		return []string{filepath.Join(".gitea", "workflows"), gh}
+	case PlatformForgejo:
+		return []string{filepath.Join(".forgejo", "workflows"), gh}
+	default:
+		return []string{gh}
+	}
+}
+
+// The following tables are deviations of Gitea Actions and Forgejo Actions from GitHub Actions.
+
+// giteaContextAliases is a table of contexts only available on Gitea Actions and Forgejo Actions.
+// Values are the names of the aliased contexts.
+var giteaContextAliases = map[string]string{
+	"gitea": "github",
+}
+
+// giteaUnsupportedEvents is a set of webhook events which never trigger workflows on Gitea Actions
+// and Forgejo Actions since the events don't exist on them.
+var giteaUnsupportedEvents = map[string]struct{}{
+	"branch_protection_rule": {},
+	"check_run":              {},
+	"check_suite":            {},
+	"deployment":             {},
+	"deployment_status":      {},
+	"discussion":             {},
+	"discussion_comment":     {},
+	"image_version":          {},
+	"merge_group":            {},
+	"page_build":             {},
+	"project":                {},
+	"project_card":           {},
+	"project_column":         {},
+	"status":                 {},
+}
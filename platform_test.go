@@ -0,0 +1,62 @@
+package actionlint
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestPlatformParse(t *testing.T) {
+	tests := []struct {
+		input string
+		want  Platform
+	}{
+		{"", PlatformGitHub},
+		{"github", PlatformGitHub},
+		{"gitea", PlatformGitea},
+		{"Forgejo", PlatformForgejo},
+	}
+	for _, tc := range tests {
+		t.Run(tc.input, func(t *testing.T) {
+			p, err := ParsePlatform(tc.input)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if p != tc.want {
+				t.Fatalf("wanted %q but got %q", tc.want, p)
+			}
+		})
+	}
+
+	if _, err := ParsePlatform("gitlab"); err == nil {
+		t.Fatal("error did not occur for unknown platform")
+	}
+}
+
+func TestPlatformWorkflowsDirOfProject(t *testing.T) {
+	d := t.TempDir()
+	p := &Project{root: d}
+	gh := filepath.Join(d, ".github", "workflows")
+	gitea := filepath.Join(d, ".gitea", "workflows")
+
+	// Fall back to .github/workflows when no workflows directory exists
+	if w := p.workflowsDirOf(PlatformGitea); w != gh {
+		t.Fatalf("wanted %q but got %q", gh, w)
+	}
+
+	if err := os.MkdirAll(gitea, 0750); err != nil {
+		t.Fatal(err)
+	}
+	for _, tc := range []struct {
+		platform Platform
+		want     string
+	}{
+		{PlatformGitHub, gh},
+		{PlatformGitea, gitea},
+		{PlatformForgejo, gh},
+	} {
+		if w := p.workflowsDirOf(tc.platform); w != tc.want {
+			t.Errorf("wanted %q for platform %q but got %q", tc.want, tc.platform, w)
+		}
+	}
+}
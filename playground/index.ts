@@ -235,7 +235,12 @@ jobs:
         for (const error of errors) {
             const row = document.createElement('tr');
             row.addEventListener('click', () => {
-                editor.setCursor({ line: error.line - 1, ch: error.column - 1 });
+                const start = { line: error.line - 1, ch: error.column - 1 };
+                if (error.endLine > 0 && error.endColumn > 0) {
+                    editor.setSelection(start, { line: error.endLine - 1, ch: error.endColumn - 1 });
+                } else {
+                    editor.setCursor(start);
+                }
                 editor.focus();
             });
 
@@ -3,6 +3,9 @@ interface ActionlintError {
     message: string;
     line: number;
     column: number;
+    // 0 when the end of the error range is unknown
+    endLine: number;
+    endColumn: number;
 }
 
 interface Window {
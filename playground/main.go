@@ -18,16 +18,26 @@ func fail(err error, when string) {
 }
 
 func encodeErrorAsMap(err *actionlint.Error) map[string]interface{} {
-	obj := make(map[string]interface{}, 4)
+	obj := make(map[string]interface{}, 6)
 	obj["message"] = err.Message
 	obj["line"] = err.Line
 	obj["column"] = err.Column
+	obj["endLine"] = err.EndLine
+	obj["endColumn"] = err.EndColumn
 	obj["kind"] = err.Kind
 	return obj
 }
 
 func lint(source string) interface{} {
-	opts := actionlint.LinterOptions{}
+	opts := actionlint.LinterOptions{
+		// Prevent a pathological input from freezing the browser tab
+		ParseLimits: &actionlint.ParseLimits{
+			MaxInputSize:        1024 * 1024,
+			MaxNestingDepth:     100,
+			MaxAliasExpansion:   100000,
+			MaxExpressionLength: 10000,
+		},
+	}
 	linter, err := actionlint.NewLinter(io.Discard, &opts)
 	if err != nil {
 		fail(err, "creating linter instance")
@@ -0,0 +1,140 @@
+package actionlint
+
+import (
+	"cmp"
+	_ "embed"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+//go:embed popular_actions.jsonl
+var popularActionsData []byte
+
+// PopularActions is the data set of known popular actions. Keys are specs (owner/repo@ref) of
+// actions and values are their metadata.
+//
+// Deprecated: The data set is decoded lazily so that it does not slow down the startup. This
+// variable is nil until the data set is decoded. Use LoadPopularActions instead.
+var PopularActions map[string]*ActionMetadata
+
+// LoadPopularActions returns the data set of known popular actions. Keys are specs (owner/repo@ref)
+// of actions and values are their metadata. The data set is embedded in the executable as JSONL and
+// decoded on the first call so that it does not slow down the startup. The returned map is shared
+// by all callers. It returns an error when the data set could not be decoded.
+func LoadPopularActions() (map[string]*ActionMetadata, error) {
+	return popularActions()
+}
+
+var popularActions = sync.OnceValues(func() (map[string]*ActionMetadata, error) {
+	return decodePopularActions(popularActionsData)
+})
+
+func decodePopularActions(b []byte) (map[string]*ActionMetadata, error) {
+	s, err := ParseActionMetadataSet(b)
+	if err != nil {
+		return nil, fmt.Errorf("could not decode popular actions data set: %w", err)
+	}
+	PopularActions = s.Actions
+	return s.Actions, nil
+}
+
+// knownPopularActions returns the data set of known popular actions. It returns an empty map when
+// the data set could not be decoded so that the actions are treated as unknown ones. Callers which
+// can report errors should use LoadPopularActions instead.
+func knownPopularActions() map[string]*ActionMetadata {
+	m, err := popularActions()
+	if err != nil {
+		return map[string]*ActionMetadata{}
+	}
+	return m
+}
+
+// PopularAction is information of a popular action in the data set bundled with actionlint. The
+// data set is the same as LoadPopularActions(), OutdatedPopularActionSpecs, and DeprecatedPopularActions.
+type PopularAction struct {
+	// Spec is the spec of the action like "actions/checkout@v4".
+	Spec string
+	// Metadata is the metadata of the action such as inputs and outputs. It is nil when the version
+	// is outdated since the metadata of outdated versions is not maintained.
+	Metadata *ActionMetadata
+	// Outdated is true when the version is outdated. The word 'outdated' means that the runner used
+	// by the action is no longer available such as "node12", "node16".
+	Outdated bool
+	// Deprecated is the deprecation information of the action. It is nil when the action is not
+	// deprecated.
+	Deprecated *DeprecatedAction
+	// Refs is the refs of the action which are supported in the data set like ["v4", "v5", "v6"].
+	// They are sorted by their versions.
+	Refs []string
+	// Latest is the latest major version of the action in the data set like "v6". It is empty when
+	// the refs of the action are not major versions.
+	Latest string
+}
+
+// popularActionRefs returns the mapping from popular actions like "actions/checkout" to their refs
+// which are supported in the popular actions data set.
+var popularActionRefs = sync.OnceValue(collectPopularActionRefs)
+
+func collectPopularActionRefs() map[string][]string {
+	m := map[string][]string{}
+	for spec := range knownPopularActions() {
+		if name, ref, ok := strings.Cut(spec, "@"); ok {
+			m[name] = append(m[name], ref)
+		}
+	}
+	for _, refs := range m {
+		slices.SortFunc(refs, compareActionRefs)
+	}
+	return m
+}
+
+// compareActionRefs compares refs like "v3" and "v10" by their major versions. Refs which are not
+// major versions like "main" are sorted in lexical order after them.
+func compareActionRefs(a, b string) int {
+	ma, errA := strconv.Atoi(strings.TrimPrefix(a, "v"))
+	mb, errB := strconv.Atoi(strings.TrimPrefix(b, "v"))
+	switch {
+	case errA == nil && errB == nil:
+		return cmp.Compare(ma, mb)
+	case errA == nil:
+		return -1
+	case errB == nil:
+		return 1
+	default:
+		return strings.Compare(a, b)
+	}
+}
+
+// LookupPopularAction looks up the action specified by the owner, the repository, and the ref in
+// the popular actions data set bundled with actionlint. The repo parameter can contain the path to
+// the action in the repository like "repo/path/to/action". The second return value is false when
+// the action is not found in the data set.
+func LookupPopularAction(owner, repo, ref string) (*PopularAction, bool) {
+	name := fmt.Sprintf("%s/%s", owner, repo)
+	spec := fmt.Sprintf("%s@%s", name, ref)
+
+	meta := knownPopularActions()[spec]
+	_, outdated := OutdatedPopularActionSpecs[spec]
+	deprecated, ok := DeprecatedPopularActions[spec]
+	if !ok {
+		deprecated = DeprecatedPopularActions[name]
+	}
+	if meta == nil && !outdated && deprecated == nil {
+		return nil, false
+	}
+
+	a := &PopularAction{
+		Spec:       spec,
+		Metadata:   meta,
+		Outdated:   outdated,
+		Deprecated: deprecated,
+		Refs:       popularActionRefs()[name],
+	}
+	if v, ok := popularActionLatestMajors()[name]; ok {
+		a.Latest = fmt.Sprintf("v%d", v)
+	}
+	return a, true
+}
@@ -0,0 +1,60 @@
+package actionlint
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestLookupPopularAction(t *testing.T) {
+	a, ok := LookupPopularAction("actions", "checkout", "v4")
+	if !ok {
+		t.Fatal("actions/checkout@v4 was not found")
+	}
+	if a.Spec != "actions/checkout@v4" {
+		t.Errorf("unexpected spec %q", a.Spec)
+	}
+	if a.Metadata == nil || a.Metadata != knownPopularActions()["actions/checkout@v4"] {
+		t.Errorf("unexpected metadata %v", a.Metadata)
+	}
+	if _, ok := a.Metadata.Inputs["fetch-depth"]; !ok {
+		t.Errorf("input \"fetch-depth\" was not found in %v", a.Metadata.Inputs)
+	}
+	if a.Outdated || a.Deprecated != nil {
+		t.Errorf("actions/checkout@v4 should be neither outdated nor deprecated: %+v", a)
+	}
+	if !slices.Contains(a.Refs, "v4") || !slices.IsSortedFunc(a.Refs, compareActionRefs) {
+		t.Errorf("unexpected refs %v", a.Refs)
+	}
+	if a.Latest != a.Refs[len(a.Refs)-1] {
+		t.Errorf("latest version %q is not the last of refs %v", a.Latest, a.Refs)
+	}
+
+	a, ok = LookupPopularAction("actions", "checkout", "v2")
+	if !ok {
+		t.Fatal("actions/checkout@v2 was not found")
+	}
+	if !a.Outdated || a.Metadata != nil {
+		t.Errorf("actions/checkout@v2 should be outdated without metadata: %+v", a)
+	}
+
+	a, ok = LookupPopularAction("actions-rs", "toolchain", "v1")
+	if !ok {
+		t.Fatal("actions-rs/toolchain@v1 was not found")
+	}
+	if a.Deprecated == nil || a.Deprecated.Replacement == "" {
+		t.Errorf("actions-rs/toolchain should be deprecated with replacement: %+v", a.Deprecated)
+	}
+
+	if a, ok := LookupPopularAction("unknown", "action", "v1"); ok {
+		t.Errorf("unknown action was found: %+v", a)
+	}
+}
+
+func TestCompareActionRefs(t *testing.T) {
+	refs := []string{"main", "v10", "v2", "release", "v1"}
+	slices.SortFunc(refs, compareActionRefs)
+	want := []string{"v1", "v2", "v10", "main", "release"}
+	if !slices.Equal(refs, want) {
+		t.Fatalf("wanted %v but got %v", want, refs)
+	}
+}
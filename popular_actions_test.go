@@ -6,11 +6,18 @@ import (
 )
 
 func TestPopularActionsDataset(t *testing.T) {
-	if len(PopularActions) == 0 {
+	actions, err := LoadPopularActions()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(actions) == 0 {
 		t.Fatal("popular actions data set is empty")
 	}
+	if len(PopularActions) != len(actions) {
+		t.Fatalf("deprecated PopularActions variable was not set: %d vs %d", len(PopularActions), len(actions))
+	}
 
-	for n, meta := range PopularActions {
+	for n, meta := range actions {
 		if meta == nil {
 			t.Fatalf("metadata for %s is nil", n)
 		}
@@ -35,3 +42,13 @@ func TestPopularActionsDataset(t *testing.T) {
 		}
 	}
 }
+
+func TestPopularActionsDatasetDecodeError(t *testing.T) {
+	_, err := decodePopularActions([]byte(`{"spec": "actions/checkout@v4", "metadata": {"name": 42}}`))
+	if err == nil {
+		t.Fatal("error did not occur")
+	}
+	if want := "could not decode popular actions data set"; !strings.Contains(err.Error(), want) {
+		t.Fatalf("unexpected error %q", err)
+	}
+}
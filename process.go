@@ -1,11 +1,22 @@
 package actionlint
 
 import (
+	"bufio"
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"io"
+	"log/slog"
+	"os"
 	"os/exec"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"slices"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/mattn/go-shellwords"
 	"golang.org/x/sync/errgroup"
@@ -19,53 +30,68 @@ type cmdExecution struct {
 	args          []string
 	stdin         string
 	combineOutput bool
+	// persistent is a long-lived process to send the stdin to instead of spawning a new process. It
+	// may be nil.
+	persistent *persistentProcess
+	// limits is resource limits applied to the process. It may be nil.
+	limits *processLimits
+	// env is the environment variables of the process. When it is nil, the process inherits all
+	// environment variables of the current process.
+	env []string
 }
 
-func (e *cmdExecution) run() ([]byte, error) {
-	cmd := exec.Command(e.cmd, e.args...)
-	cmd.Stderr = nil
-
-	p, err := cmd.StdinPipe()
-	if err != nil {
-		return nil, fmt.Errorf("could not make stdin pipe for %s process: %w", e.cmd, err)
-	}
-	if _, err := io.WriteString(p, e.stdin); err != nil {
-		p.Close()
-		return nil, fmt.Errorf("could not write to stdin of %s process: %w", e.cmd, err)
+// run runs the command and returns its stdout and stderr. Stderr is empty when combineOutput is
+// true or the command is run by the persistent process.
+func (e *cmdExecution) run(ctx context.Context) ([]byte, []byte, error) {
+	if e.persistent != nil {
+		stdout, err := e.persistent.request(ctx, e.stdin)
+		return stdout, nil, err
 	}
-	p.Close()
 
-	var stdout []byte
+	exe, args := e.limits.command(e.cmd, e.args)
+	cmd := exec.CommandContext(ctx, exe, args...)
+	cmd.Env = e.env
+	// When the process is killed on cancellation, its child processes may still hold the stdout and
+	// stderr pipes. Don't wait for them forever.
+	cmd.WaitDelay = time.Second
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
 	if e.combineOutput {
-		stdout, err = cmd.CombinedOutput()
-	} else {
-		stdout, err = cmd.Output()
+		cmd.Stderr = &stdout
 	}
 
-	if err != nil {
+	cmd.Stdin = strings.NewReader(e.stdin)
+
+	if err := cmd.Start(); err != nil {
+		return nil, nil, err
+	}
+
+	if err := cmd.Wait(); err != nil {
 		if exitErr, ok := err.(*exec.ExitError); ok {
 			code := exitErr.ExitCode()
 
-			stderr := exitErr.Stderr
+			msg := stderr.Bytes()
 			if e.combineOutput {
-				stderr = stdout
+				msg = stdout.Bytes()
 			}
 
 			if code < 0 {
-				return nil, fmt.Errorf("%s was terminated. stderr: %q", e.cmd, stderr)
+				return nil, stderr.Bytes(), fmt.Errorf("%s was terminated. stderr: %q", e.cmd, msg)
 			}
 
-			if len(stdout) == 0 {
-				return nil, fmt.Errorf("%s exited with status %d but stdout was empty. stderr: %q", e.cmd, code, stderr)
+			if stdout.Len() == 0 {
+				return nil, stderr.Bytes(), fmt.Errorf("%s exited with status %d but stdout was empty. stderr: %q", e.cmd, code, msg)
 			}
 
 			// Reaches here when exit status is non-zero and stdout is not empty, shellcheck successfully found some errors
 		} else {
-			return nil, err
+			return nil, stderr.Bytes(), err
 		}
 	}
 
-	return stdout, nil
+	return stdout.Bytes(), stderr.Bytes(), nil
 }
 
 // concurrentProcess is a manager to run process concurrently. Since running process consumes OS
@@ -77,15 +103,52 @@ type concurrentProcess struct {
 	ctx  context.Context
 	sema *semaphore.Weighted
 	wg   sync.WaitGroup
+	// onStart is called when a process starts. It may be nil.
+	onStart func(exe string, args []string)
+	// onFinish is called when a process finishes with the time taken by the process including
+	// retries. Time waiting for other processes is not included. It may be nil.
+	onFinish func(exe string, args []string, elapsed time.Duration)
+	// onStderr is called with the output to stderr of a process when it is not empty, even if the
+	// process succeeded. For persistent processes, it is called for each line. It may be nil.
+	onStderr func(exe string, args []string, stderr []byte)
+	// timeout is the maximum duration of each command execution. When a command does not finish
+	// within the duration, it is killed and the callback receives an error. Zero means no timeout.
+	timeout time.Duration
+	// persistent is the long-lived processes shared by all the files checked with this instance.
+	// Keys are command lines of the processes.
+	persistent   map[string]*persistentProcess
+	persistentMu sync.Mutex
+	// limits is resource limits applied to all processes. It may be nil.
+	limits *processLimits
+	// allowedEnv is the names of environment variables passed to processes. A name ending with '*'
+	// matches the prefix. When it is nil, all environment variables are passed.
+	allowedEnv []string
+	// retries is the maximum number of retries of a command which failed to start with a transient
+	// error. See isTransientProcessError.
+	retries int
+	// retryBackoff is the duration to wait before the first retry. It is doubled on each retry.
+	retryBackoff time.Duration
+	// metrics records the number of queued and running processes. It may be nil.
+	metrics *Metrics
+	// logger outputs lifecycles of processes as debug log. It may be nil.
+	logger *slog.Logger
 }
 
 // newConcurrentProcess creates a new ConcurrentProcess instance. The `par` argument represents how
 // many processes can be run in parallel. It is recommended to use the value returned from
-// runtime.NumCPU() for the argument.
-func newConcurrentProcess(par int) *concurrentProcess {
+// runtime.NumCPU() for the argument. When the `ctx` argument is cancelled, running processes are
+// killed and no new process is started.
+func newConcurrentProcess(ctx context.Context, par int) *concurrentProcess {
 	return &concurrentProcess{
-		ctx:  context.Background(),
-		sema: semaphore.NewWeighted(int64(par)),
+		ctx:          ctx,
+		sema:         semaphore.NewWeighted(int64(par)),
+		retryBackoff: 100 * time.Millisecond,
+	}
+}
+
+func (proc *concurrentProcess) debug(msg string, attrs ...any) {
+	if proc.logger != nil {
+		proc.logger.Debug(msg, attrs...)
 	}
 }
 
@@ -93,25 +156,112 @@ func (proc *concurrentProcess) run(eg *errgroup.Group, exec *cmdExecution, callb
 	proc.wg.Add(1)
 	eg.Go(func() error {
 		defer proc.wg.Done()
+		proc.metrics.commandQueued()
 		if err := proc.sema.Acquire(proc.ctx, 1); err != nil {
+			proc.metrics.commandDequeued()
 			return fmt.Errorf("could not acquire semaphore to run %q: %w", exec.cmd, err)
 		}
-		stdout, err := exec.run()
+		proc.metrics.commandStarted()
+		if proc.onStart != nil {
+			proc.onStart(exec.cmd, exec.args)
+		}
+		proc.debug("External command started", "exe", exec.cmd, "args", exec.args)
+		start := time.Now()
+		stdout, stderr, err := proc.runWithRetry(exec)
+		elapsed := time.Since(start)
 		proc.sema.Release(1)
+		proc.metrics.commandFinished()
+		if err != nil {
+			proc.debug("External command failed", "exe", exec.cmd, "elapsed", elapsed, "error", err)
+		} else {
+			proc.debug("External command finished", "exe", exec.cmd, "elapsed", elapsed, "stdout_bytes", len(stdout))
+		}
+		if proc.onFinish != nil {
+			proc.onFinish(exec.cmd, exec.args, elapsed)
+		}
+		if len(stderr) > 0 && proc.onStderr != nil {
+			proc.onStderr(exec.cmd, exec.args, stderr)
+		}
+		if err := proc.ctx.Err(); err != nil {
+			return fmt.Errorf("%q was cancelled: %w", exec.cmd, err)
+		}
 		return callback(stdout, err)
 	})
 }
 
-// wait waits all goroutines started by this concurrentProcess instance finish.
+// isTransientProcessError returns whether the error is caused by a temporary shortage of OS resources
+// on starting a process. Such errors happen on busy machines running many processes in parallel
+// and the command will likely succeed when it is run again. Note that failures of the command
+// itself such as non-zero exit status are not transient.
+func isTransientProcessError(err error) bool {
+	for _, e := range transientProcessErrors {
+		if errors.Is(err, e) {
+			return true
+		}
+	}
+	return false
+}
+
+// runWithRetry runs the command and retries it with exponential backoff when it failed to start
+// with a transient error.
+func (proc *concurrentProcess) runWithRetry(exec *cmdExecution) ([]byte, []byte, error) {
+	backoff := proc.retryBackoff
+	for i := 0; ; i++ {
+		stdout, stderr, err := proc.runWithTimeout(exec)
+		if err == nil || i >= proc.retries || !isTransientProcessError(err) {
+			return stdout, stderr, err
+		}
+		proc.debug("Retrying external command which failed to start", "exe", exec.cmd, "retry", i+1, "backoff", backoff, "error", err)
+		t := time.NewTimer(backoff)
+		select {
+		case <-proc.ctx.Done():
+			t.Stop()
+			return stdout, stderr, err
+		case <-t.C:
+		}
+		backoff *= 2
+	}
+}
+
+func (proc *concurrentProcess) runWithTimeout(exec *cmdExecution) ([]byte, []byte, error) {
+	if proc.timeout <= 0 {
+		return exec.run(proc.ctx)
+	}
+
+	// Start the timer after acquiring the semaphore so that time waiting for other processes is not
+	// counted
+	ctx, cancel := context.WithTimeout(proc.ctx, proc.timeout)
+	defer cancel()
+	stdout, stderr, err := exec.run(ctx)
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) && proc.ctx.Err() == nil {
+		return nil, stderr, fmt.Errorf("%s did not finish within %s and was killed: %w", exec.cmd, proc.timeout, ctx.Err())
+	}
+	return stdout, stderr, err
+}
+
+// wait waits all goroutines started by this concurrentProcess instance finish. Long-lived
+// processes are stopped after that.
 func (proc *concurrentProcess) wait() {
 	proc.wg.Wait() // Wait for all goroutines completing to shutdown
+
+	proc.persistentMu.Lock()
+	defer proc.persistentMu.Unlock()
+	for _, p := range proc.persistent {
+		p.close()
+	}
+	proc.persistent = nil
 }
 
 // newCommandRunner creates new external command runner for given executable. The executable path
 // is resolved in this function.
 func (proc *concurrentProcess) newCommandRunner(exe string, combineOutput bool) (*externalCommand, error) {
-	var args []string
-	p, args, err := resolveExternalCommand(exe)
+	return proc.newCommandRunnerOn(hostExecutor{}, exe, combineOutput)
+}
+
+// newCommandRunnerOn creates new external command runner for given command line executed by the
+// executor.
+func (proc *concurrentProcess) newCommandRunnerOn(executor commandExecutor, exe string, combineOutput bool) (*externalCommand, error) {
+	p, args, err := executor.resolve(exe)
 	if err != nil {
 		return nil, err
 	}
@@ -121,9 +271,270 @@ func (proc *concurrentProcess) newCommandRunner(exe string, combineOutput bool)
 		args:          args,
 		combineOutput: combineOutput,
 	}
+	if q, ok := executor.(argsQuoter); ok {
+		cmd.quote = q.quoteArgs
+	}
+	cmd.env = proc.environ(executor)
+	return cmd, nil
+}
+
+// newPersistentCommandRunner creates new external command runner which sends inputs to a
+// long-lived process instead of spawning a new process for every input. The process is started on
+// the first input and shared by all runners created for the same command line until wait() is
+// called. See persistentProcess for the protocol.
+func (proc *concurrentProcess) newPersistentCommandRunner(executor commandExecutor, exe string) (*externalCommand, error) {
+	p, args, err := executor.resolve(exe)
+	if err != nil {
+		return nil, err
+	}
+
+	key := strings.Join(append([]string{p}, args...), " ")
+	proc.persistentMu.Lock()
+	defer proc.persistentMu.Unlock()
+	w, ok := proc.persistent[key]
+	if !ok {
+		w = &persistentProcess{ctx: proc.ctx, exe: p, args: args, limits: proc.limits, env: proc.environ(executor)}
+		if proc.onStderr != nil {
+			w.onStderr = func(b []byte) { proc.onStderr(p, args, b) }
+		}
+		if proc.persistent == nil {
+			proc.persistent = map[string]*persistentProcess{}
+		}
+		proc.persistent[key] = w
+	}
+
+	cmd := &externalCommand{
+		proc:       proc,
+		exe:        p,
+		args:       args,
+		persistent: w,
+	}
 	return cmd, nil
 }
 
+// defaultAllowedEnv is the names of environment variables passed to external commands by default.
+// Other variables are not passed since they may contain secrets like API tokens on CI.
+var defaultAllowedEnv = []string{
+	"PATH",
+	"HOME",
+	"USER",
+	"TMPDIR",
+	"TZ",
+	"LANG",
+	"LANGUAGE",
+	"LC_*",
+	"SHELLCHECK_OPTS",
+	// Windows needs these variables to run processes
+	"SYSTEMROOT",
+	"SYSTEMDRIVE",
+	"WINDIR",
+	"COMSPEC",
+	"PATHEXT",
+	"TEMP",
+	"TMP",
+	"USERPROFILE",
+	"APPDATA",
+	"LOCALAPPDATA",
+}
+
+// envRequirer is implemented by executors which need some environment variables to execute
+// commands such as a socket of SSH agent.
+type envRequirer interface {
+	requiredEnv() []string
+}
+
+// environ returns the environment variables of processes executed by the executor. It returns nil
+// when all environment variables are passed.
+func (proc *concurrentProcess) environ(executor commandExecutor) []string {
+	if proc.allowedEnv == nil {
+		return nil
+	}
+	allowed := proc.allowedEnv
+	if r, ok := executor.(envRequirer); ok {
+		allowed = append(slices.Clip(allowed), r.requiredEnv()...)
+	}
+	return filterEnv(os.Environ(), allowed)
+}
+
+// filterEnv returns the environment variables whose names match to one of the allowed names. A name
+// ending with '*' matches the prefix. Names are case-insensitive on Windows.
+func filterEnv(environ []string, allowed []string) []string {
+	ret := []string{}
+	for _, kv := range environ {
+		k, _, ok := strings.Cut(kv, "=")
+		if !ok {
+			continue
+		}
+		for _, a := range allowed {
+			if matchEnvName(k, a) {
+				ret = append(ret, kv)
+				break
+			}
+		}
+	}
+	return ret
+}
+
+func matchEnvName(name, pat string) bool {
+	if runtime.GOOS == "windows" {
+		name, pat = strings.ToUpper(name), strings.ToUpper(pat)
+	}
+	if p, ok := strings.CutSuffix(pat, "*"); ok {
+		return strings.HasPrefix(name, p)
+	}
+	return name == pat
+}
+
+// commandExecutor decides how external commands are executed. It resolves the command line of an
+// external command into the executable path on the host and the arguments passed to it.
+type commandExecutor interface {
+	resolve(cmdline string) (string, []string, error)
+}
+
+// hostExecutor executes external commands installed on the host.
+type hostExecutor struct{}
+
+func (e hostExecutor) resolve(cmdline string) (string, []string, error) {
+	return resolveExternalCommand(cmdline)
+}
+
+// containerExecutor executes external commands in a container with a container runtime like Docker
+// or Podman so that the commands don't need to be installed on the host. Inputs are passed via stdin
+// and the container cannot access files and network on the host.
+type containerExecutor struct {
+	runtime string
+	image   string
+}
+
+func newContainerExecutor(c *ContainerConfig) *containerExecutor {
+	rt := c.Runtime
+	if rt == "" {
+		rt = "docker"
+	}
+	return &containerExecutor{rt, c.Image}
+}
+
+func (e *containerExecutor) requiredEnv() []string {
+	return []string{"DOCKER_*", "CONTAINER_*", "XDG_RUNTIME_DIR", "XDG_CONFIG_HOME"}
+}
+
+// resolve returns the command line to run the command in the container. When the cmdline argument is
+// empty, the entrypoint of the image is run.
+func (e *containerExecutor) resolve(cmdline string) (string, []string, error) {
+	rt, err := execabs.LookPath(e.runtime)
+	if err != nil {
+		return "", nil, fmt.Errorf("container runtime %q to run image %q was not found: %w", e.runtime, e.image, err)
+	}
+	args := []string{"run", "--rm", "-i", "--network", "none", e.image}
+	if cmdline != "" {
+		a, err := shellwords.Parse(cmdline)
+		if err != nil {
+			return "", nil, fmt.Errorf("could not parse command %q to run in image %q: %w", cmdline, e.image, err)
+		}
+		args = append(args, a...)
+	}
+	return rt, args, nil
+}
+
+// sshExecutor executes external commands on a remote machine via SSH so that the commands can be
+// offloaded to dedicated machines. Inputs are passed via stdin. Since SSH runs the command with the
+// shell on the remote machine, all arguments are quoted.
+type sshExecutor struct {
+	ssh  string
+	host string
+}
+
+func newSSHExecutor(c *RemoteConfig) *sshExecutor {
+	ssh := c.SSH
+	if ssh == "" {
+		ssh = "ssh"
+	}
+	return &sshExecutor{ssh, c.Host}
+}
+
+func (e *sshExecutor) requiredEnv() []string {
+	return []string{"SSH_AUTH_SOCK"}
+}
+
+func (e *sshExecutor) resolve(cmdline string) (string, []string, error) {
+	ssh, args, err := resolveExternalCommand(e.ssh)
+	if err != nil {
+		return "", nil, fmt.Errorf("SSH client %q to run commands on %q was not found: %w", e.ssh, e.host, err)
+	}
+	words, err := shellwords.Parse(cmdline)
+	if err != nil {
+		return "", nil, fmt.Errorf("could not parse command %q to run on %q: %w", cmdline, e.host, err)
+	}
+	// -T: Don't allocate a TTY since stdin is used for the input
+	// BatchMode: Fail instead of asking a password interactively
+	args = append(args, "-T", "-o", "BatchMode=yes", "--", e.host)
+	return ssh, append(args, e.quoteArgs(words)...), nil
+}
+
+// quoteArgs quotes the arguments for the shell on the remote machine.
+func (e *sshExecutor) quoteArgs(args []string) []string {
+	ret := make([]string, 0, len(args))
+	for _, a := range args {
+		ret = append(ret, quotePOSIXShellArg(a))
+	}
+	return ret
+}
+
+// argsQuoter is implemented by executors which need to quote the arguments of commands.
+type argsQuoter interface {
+	quoteArgs(args []string) []string
+}
+
+var reShellSafeArg = regexp.MustCompile(`^[a-zA-Z0-9_@%+=:,./-]+$`)
+
+func quotePOSIXShellArg(s string) string {
+	if reShellSafeArg.MatchString(s) {
+		return s
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// wslExecutor executes external commands in the default distribution of Windows Subsystem for Linux
+// so that Linux tools like shellcheck can be used on Windows. Inputs are passed via stdin.
+type wslExecutor struct {
+	wsl string
+}
+
+func (e *wslExecutor) requiredEnv() []string {
+	return []string{"WSLENV"}
+}
+
+func (e *wslExecutor) resolve(cmdline string) (string, []string, error) {
+	wsl, args, err := resolveExternalCommand(e.wsl)
+	if err != nil {
+		return "", nil, fmt.Errorf("WSL command %q was not found: %w", e.wsl, err)
+	}
+	words, err := shellwords.Parse(cmdline)
+	if err != nil {
+		return "", nil, fmt.Errorf("could not parse command %q to run in WSL: %w", cmdline, err)
+	}
+	// -e: Execute the command directly without the shell of the distribution so that the arguments
+	// don't need to be quoted
+	args = append(args, "-e")
+	return wsl, append(args, words...), nil
+}
+
+// findCommandInWSL checks the command is available in WSL by running it with --version option. It
+// returns the executor to run the command in WSL when it is available.
+func findCommandInWSL(ctx context.Context, wsl, cmdline string) (*wslExecutor, error) {
+	e := &wslExecutor{wsl}
+	exe, args, err := e.resolve(cmdline)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	if err := exec.CommandContext(ctx, exe, append(args, "--version")...).Run(); err != nil {
+		return nil, fmt.Errorf("%q is not available in WSL: %w", cmdline, err)
+	}
+	return e, nil
+}
+
 func resolveExternalCommand(exe string) (string, []string, error) {
 	c, err := execabs.LookPath(exe)
 	if err == nil {
@@ -140,6 +551,26 @@ func resolveExternalCommand(exe string) (string, []string, error) {
 	return "", nil, err
 }
 
+// writeWorkflowToTempFile writes the workflow content to a file in a new temporary directory for
+// external commands which read workflows only from files. The file has the same name as the path.
+// It returns the temporary directory and the file path. The caller must remove the directory.
+func writeWorkflowToTempFile(path string, content []byte) (string, string, error) {
+	d, err := os.MkdirTemp("", "actionlint-")
+	if err != nil {
+		return "", "", fmt.Errorf("could not create temporary directory: %w", err)
+	}
+	name := filepath.Base(path)
+	if name == "." || name == string(filepath.Separator) || name == "<stdin>" {
+		name = "workflow.yaml"
+	}
+	f := filepath.Join(d, name)
+	if err := os.WriteFile(f, content, 0600); err != nil {
+		os.RemoveAll(d)
+		return "", "", fmt.Errorf("could not write workflow to temporary file: %w", err)
+	}
+	return d, f, nil
+}
+
 // externalCommand is struct to run specific command concurrently with concurrentProcess bounding
 // number of processes at the same time. This type manages fatal errors while running the command
 // by using errgroup.Group. The wait() method must be called at the end for checking if some fatal
@@ -150,19 +581,28 @@ type externalCommand struct {
 	exe           string
 	args          []string
 	combineOutput bool
+	persistent    *persistentProcess
+	// quote quotes the arguments given to run(). It may be nil.
+	quote func([]string) []string
+	// env is the environment variables of the processes. When it is nil, all environment variables
+	// are inherited.
+	env []string
 }
 
 // run runs the command with given arguments and stdin. The callback function is called after the
 // process runs. First argument is stdout and the second argument is an error while running the
 // process.
 func (cmd *externalCommand) run(args []string, stdin string, callback func([]byte, error) error) {
+	if cmd.quote != nil {
+		args = cmd.quote(args)
+	}
 	if len(cmd.args) > 0 {
 		var allArgs []string
 		allArgs = append(allArgs, cmd.args...)
 		allArgs = append(allArgs, args...)
 		args = allArgs
 	}
-	exec := &cmdExecution{cmd.exe, args, stdin, cmd.combineOutput}
+	exec := &cmdExecution{cmd.exe, args, stdin, cmd.combineOutput, cmd.persistent, cmd.proc.limits, cmd.env}
 	cmd.proc.run(&cmd.eg, exec, callback)
 }
 
@@ -171,3 +611,150 @@ func (cmd *externalCommand) run(args []string, stdin string, callback func([]byt
 func (cmd *externalCommand) wait() error {
 	return cmd.eg.Wait()
 }
+
+// persistentProcess is a long-lived process which handles multiple inputs. Each input is written
+// to stdin as a single line and the process must write its output to stdout as a single line.
+// Inputs are sent one by one. The process is started lazily on the first input and restarted on
+// the next input when it fails. Closing stdin tells the process to exit. Each line of stderr of the
+// process is passed to onStderr.
+type persistentProcess struct {
+	ctx      context.Context
+	exe      string
+	args     []string
+	mu       sync.Mutex
+	cmd      *exec.Cmd
+	stdin    io.WriteCloser
+	stdout   *bufio.Reader
+	stderr   *lineWriter
+	limits   *processLimits
+	env      []string
+	onStderr func([]byte)
+}
+
+func (p *persistentProcess) start() error {
+	exe, args := p.limits.command(p.exe, p.args)
+	cmd := exec.CommandContext(p.ctx, exe, args...)
+	cmd.Env = p.env
+	cmd.WaitDelay = time.Second
+	if p.onStderr != nil {
+		p.stderr = &lineWriter{callback: p.onStderr}
+		cmd.Stderr = p.stderr
+	}
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return fmt.Errorf("could not make stdin pipe for %s process: %w", p.exe, err)
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return fmt.Errorf("could not make stdout pipe for %s process: %w", p.exe, err)
+	}
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("could not start %s process: %w", p.exe, err)
+	}
+	p.cmd = cmd
+	p.stdin = stdin
+	p.stdout = bufio.NewReader(stdout)
+	return nil
+}
+
+// kill kills the process. The next request will start a new process.
+func (p *persistentProcess) kill() {
+	p.stdin.Close()
+	p.cmd.Process.Kill()
+	p.cmd.Wait()
+	p.stderr.flush()
+	p.cmd = nil
+}
+
+// request sends the input to the process and returns its output. When the context is done before
+// the output is returned, the process is killed.
+func (p *persistentProcess) request(ctx context.Context, in string) ([]byte, error) {
+	if strings.ContainsRune(in, '\n') {
+		return nil, fmt.Errorf("input to %s process must not contain newlines", p.exe)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.cmd == nil {
+		if err := p.start(); err != nil {
+			return nil, err
+		}
+	}
+
+	type result struct {
+		out []byte
+		err error
+	}
+	done := make(chan result, 1)
+	stdin, stdout := p.stdin, p.stdout // The fields are updated when the process is restarted
+	go func() {
+		if _, err := io.WriteString(stdin, in+"\n"); err != nil {
+			done <- result{nil, fmt.Errorf("could not write to stdin of %s process: %w", p.exe, err)}
+			return
+		}
+		out, err := stdout.ReadBytes('\n')
+		if err != nil {
+			done <- result{nil, fmt.Errorf("could not read a line from stdout of %s process: %w", p.exe, err)}
+			return
+		}
+		done <- result{out, nil}
+	}()
+
+	select {
+	case r := <-done:
+		if r.err != nil {
+			p.kill()
+		}
+		return r.out, r.err
+	case <-ctx.Done():
+		p.kill() // Killing the process makes the goroutine above stop
+		return nil, ctx.Err()
+	}
+}
+
+// close closes stdin of the process to tell it to exit and waits for the exit. When the process does
+// not exit in time, it is killed.
+func (p *persistentProcess) close() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.cmd == nil {
+		return
+	}
+	cmd := p.cmd
+	p.stdin.Close()
+	t := time.AfterFunc(time.Second, func() { cmd.Process.Kill() })
+	cmd.Wait()
+	t.Stop()
+	p.stderr.flush()
+	p.cmd = nil
+}
+
+// lineWriter is an io.Writer which calls the callback with each line written to it. The line
+// includes the trailing newline.
+type lineWriter struct {
+	buf      []byte
+	callback func([]byte)
+}
+
+func (w *lineWriter) Write(b []byte) (int, error) {
+	w.buf = append(w.buf, b...)
+	for {
+		i := bytes.IndexByte(w.buf, '\n')
+		if i < 0 {
+			return len(b), nil
+		}
+		w.callback(bytes.Clone(w.buf[:i+1]))
+		w.buf = w.buf[i+1:]
+	}
+}
+
+// flush calls the callback with the rest of the written bytes which does not end with a newline.
+func (w *lineWriter) flush() {
+	if w == nil || len(w.buf) == 0 {
+		return
+	}
+	w.callback(w.buf)
+	w.buf = nil
+}
@@ -0,0 +1,17 @@
+//go:build !js
+
+package actionlint
+
+import (
+	"syscall"
+)
+
+// transientProcessErrors is the errors on starting a process which will likely not happen when the
+// process is started again. See isTransientProcessError.
+var transientProcessErrors = []error{
+	syscall.EAGAIN,  // fork: resource temporarily unavailable
+	syscall.ENOMEM,  // fork: cannot allocate memory
+	syscall.EMFILE,  // pipe: too many open files
+	syscall.ENFILE,  // pipe: too many open files in system
+	syscall.ETXTBSY, // exec: text file busy
+}
@@ -0,0 +1,80 @@
+//go:build !js && !windows
+
+package actionlint
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"syscall"
+	"testing"
+	"time"
+)
+
+// syscall.ETXTBSY is not defined on js/wasm. See process_errors_js.go.
+
+func TestProcessTransientErrorTextFileBusy(t *testing.T) {
+	err := &os.PathError{Op: "fork/exec", Path: "/bin/foo", Err: syscall.ETXTBSY}
+	if !isTransientProcessError(err) {
+		t.Errorf("%v should be transient", err)
+	}
+}
+
+func TestProcessRetryTransientError(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("executing file opened for writing causes \"text file busy\" error only on Linux")
+	}
+
+	// Executing the script fails with ETXTBSY while it is opened for writing
+	exe := filepath.Join(t.TempDir(), "script")
+	f, err := os.OpenFile(exe, os.O_CREATE|os.O_WRONLY, 0755)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if _, err := f.WriteString("#!/bin/sh\necho ok\n"); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, retries := range []int{0, 5} {
+		t.Run(fmt.Sprint("retries=", retries), func(t *testing.T) {
+			p := newConcurrentProcess(context.Background(), 1)
+			p.retries = retries
+			p.retryBackoff = 50 * time.Millisecond
+			c, err := p.newCommandRunner(exe, false)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var out string
+			var runErr error
+			c.run(nil, "", func(b []byte, err error) error {
+				out, runErr = string(b), err
+				return nil
+			})
+			if retries > 0 {
+				time.AfterFunc(100*time.Millisecond, func() { f.Close() })
+			}
+			if err := c.wait(); err != nil {
+				t.Fatal(err)
+			}
+			p.wait()
+
+			if retries == 0 {
+				if !errors.Is(runErr, syscall.ETXTBSY) {
+					t.Fatalf("wanted ETXTBSY error without retry but got %v", runErr)
+				}
+				return
+			}
+			if runErr != nil {
+				t.Fatal(runErr)
+			}
+			if out != "ok\n" {
+				t.Fatalf("unexpected output %q", out)
+			}
+		})
+	}
+}
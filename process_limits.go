@@ -0,0 +1,66 @@
+package actionlint
+
+import (
+	"fmt"
+	"strings"
+)
+
+// processLimits is resource limits applied to child processes such as shellcheck. Shared machines
+// like CI runners can be destabilized by bursts of the processes.
+type processLimits struct {
+	// nice is the niceness of the processes from -20 (highest priority) to 19 (lowest priority).
+	// Zero means the niceness is not changed.
+	nice int
+	// memory is the maximum size of virtual memory of each process in bytes. Zero means no limit.
+	memory uint64
+	// script is the shell script to apply the limits and then replace the shell with the command.
+	script string
+}
+
+func newProcessLimits(nice int, memory uint64) (*processLimits, error) {
+	if nice == 0 && memory == 0 {
+		return nil, nil
+	}
+	if nice < -20 || 19 < nice {
+		return nil, fmt.Errorf("niceness of child processes must be in range from -20 to 19 but got %d", nice)
+	}
+	l := &processLimits{nice: nice, memory: memory}
+	if err := l.check(); err != nil {
+		return nil, err
+	}
+
+	var b strings.Builder
+	if memory > 0 {
+		// `ulimit -v` takes the size in KiB
+		fmt.Fprintf(&b, "ulimit -v %d && ", max(memory/1024, 1))
+	}
+	b.WriteString("exec ")
+	if nice != 0 {
+		// `nice -n` takes the increment from the current niceness
+		cur, err := currentNiceness()
+		if err != nil {
+			return nil, fmt.Errorf("could not get niceness of current process: %w", err)
+		}
+		if inc := nice - cur; inc != 0 {
+			fmt.Fprintf(&b, "nice -n %d ", inc)
+		}
+	}
+	b.WriteString(`"$0" "$@"`)
+	l.script = b.String()
+
+	return l, nil
+}
+
+// command returns the command and its arguments to run the executable with the limits. The limits
+// are applied by `sh` before it replaces itself with the executable so that the process never runs
+// without them. Applying the limits is best-effort. For example, the `nice` command only warns when
+// the niceness cannot be changed due to the lack of permission.
+func (l *processLimits) command(exe string, args []string) (string, []string) {
+	if l == nil {
+		return exe, args
+	}
+	a := make([]string, 0, len(args)+3)
+	a = append(a, "-c", l.script, exe)
+	a = append(a, args...)
+	return "sh", a
+}
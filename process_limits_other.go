@@ -0,0 +1,15 @@
+//go:build !unix
+
+package actionlint
+
+import (
+	"errors"
+)
+
+func (l *processLimits) check() error {
+	return errors.New("resource limits of child processes are only supported on Unix-like systems")
+}
+
+func currentNiceness() (int, error) {
+	return 0, nil
+}
@@ -0,0 +1,21 @@
+//go:build unix && !linux
+
+package actionlint
+
+import (
+	"errors"
+
+	"golang.org/x/sys/unix"
+)
+
+func (l *processLimits) check() error {
+	if l.memory > 0 {
+		// `ulimit -v` is not effective on macOS and it is not available on some BSDs
+		return errors.New("memory limit of child processes is only supported on Linux")
+	}
+	return nil
+}
+
+func currentNiceness() (int, error) {
+	return unix.Getpriority(unix.PRIO_PROCESS, 0)
+}
@@ -1,13 +1,21 @@
 package actionlint
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
 	"runtime"
+	"slices"
 	"strings"
+	"sync"
 	"sync/atomic" // Note: atomic.Bool was added at Go 1.19
+	"syscall"
 	"testing"
 	"time"
 
+	"github.com/google/go-cmp/cmp"
 	"golang.org/x/sys/execabs"
 )
 
@@ -41,7 +49,7 @@ func TestProcessRunConcurrently(t *testing.T) {
 		t.Skip("this test is flaky on Windows")
 	}
 
-	p := newConcurrentProcess(5)
+	p := newConcurrentProcess(context.Background(), 5)
 	sleep := testSkipIfNoCommand(t, p, "sleep")
 
 	start := time.Now()
@@ -65,13 +73,45 @@ func TestProcessRunConcurrently(t *testing.T) {
 	}
 }
 
+func TestProcessRunCancelledByContext(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("this test is flaky on Windows")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	p := newConcurrentProcess(ctx, 1)
+	sleep := testSkipIfNoCommand(t, p, "sleep")
+
+	start := time.Now()
+	var called atomic.Bool
+	for i := 0; i < 3; i++ {
+		sleep.run([]string{"10"}, "", func(b []byte, err error) error {
+			called.Store(true)
+			return nil
+		})
+	}
+	time.AfterFunc(100*time.Millisecond, cancel)
+
+	err := sleep.wait()
+	p.wait()
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("context.Canceled error was expected but got %v", err)
+	}
+	if called.Load() {
+		t.Error("callback was called after the context was cancelled")
+	}
+	if sec := time.Since(start).Seconds(); sec >= 5 {
+		t.Fatalf("running commands were not killed on cancellation. it took %v seconds", sec)
+	}
+}
+
 func TestProcessRunWithArgs(t *testing.T) {
 	if _, err := execabs.LookPath("echo"); err != nil {
 		t.Skipf("echo command is necessary to run this test: %s", err)
 	}
 
 	var done atomic.Bool
-	p := newConcurrentProcess(1)
+	p := newConcurrentProcess(context.Background(), 1)
 	echo, err := p.newCommandRunner("echo hello", false)
 	if err != nil {
 		t.Fatalf(`parsing "echo hello" failed: %v`, err)
@@ -95,7 +135,7 @@ func TestProcessRunWithArgs(t *testing.T) {
 }
 
 func TestProcessRunMultipleCommandsConcurrently(t *testing.T) {
-	p := newConcurrentProcess(3)
+	p := newConcurrentProcess(context.Background(), 3)
 
 	done := make([]bool, 5)
 	cmds := make([]*externalCommand, 0, 5)
@@ -127,7 +167,7 @@ func TestProcessRunMultipleCommandsConcurrently(t *testing.T) {
 }
 
 func TestProcessWaitMultipleCommandsFinish(t *testing.T) {
-	p := newConcurrentProcess(2)
+	p := newConcurrentProcess(context.Background(), 2)
 
 	done := make([]bool, 3)
 	for i := 0; i < 3; i++ {
@@ -153,7 +193,7 @@ func TestProcessWaitMultipleCommandsFinish(t *testing.T) {
 }
 
 func TestProcessInputStdin(t *testing.T) {
-	p := newConcurrentProcess(1)
+	p := newConcurrentProcess(context.Background(), 1)
 	cat := testSkipIfNoCommand(t, p, "cat")
 	out := ""
 
@@ -177,7 +217,7 @@ func TestProcessInputStdin(t *testing.T) {
 }
 
 func TestProcessErrorCommandNotFound(t *testing.T) {
-	p := newConcurrentProcess(1)
+	p := newConcurrentProcess(context.Background(), 1)
 	c := &externalCommand{
 		proc: p,
 		exe:  "this-command-does-not-exist",
@@ -207,7 +247,7 @@ func TestProcessErrorCommandNotFound(t *testing.T) {
 }
 
 func TestProcessErrorInCallback(t *testing.T) {
-	p := newConcurrentProcess(1)
+	p := newConcurrentProcess(context.Background(), 1)
 	echo := testSkipIfNoCommand(t, p, "echo")
 
 	echo.run([]string{}, "", func(b []byte, err error) error {
@@ -234,7 +274,7 @@ func TestProcessErrorInCallback(t *testing.T) {
 }
 
 func TestProcessErrorLinterFailed(t *testing.T) {
-	p := newConcurrentProcess(1)
+	p := newConcurrentProcess(context.Background(), 1)
 	ls := testSkipIfNoCommand(t, p, "ls")
 
 	// Running ls with directory which does not exist emulates external liter's failure.
@@ -268,7 +308,7 @@ func TestProcessErrorLinterFailed(t *testing.T) {
 }
 
 func TestProcessRunConcurrentlyAndWait(t *testing.T) {
-	p := newConcurrentProcess(2)
+	p := newConcurrentProcess(context.Background(), 2)
 	echo := testSkipIfNoCommand(t, p, "echo")
 
 	c := make(chan struct{})
@@ -291,7 +331,7 @@ func TestProcessRunConcurrentlyAndWait(t *testing.T) {
 }
 
 func TestProcessCombineStdoutAndStderr(t *testing.T) {
-	p := newConcurrentProcess(1)
+	p := newConcurrentProcess(context.Background(), 1)
 	bash := testSkipIfNoCommand(t, p, "bash")
 	bash.combineOutput = true
 	script := "echo 'hello stdout'; echo 'hello stderr' >&2"
@@ -321,7 +361,7 @@ func TestProcessCombineStdoutAndStderr(t *testing.T) {
 }
 
 func TestProcessCommandExitStatusNonZero(t *testing.T) {
-	p := newConcurrentProcess(1)
+	p := newConcurrentProcess(context.Background(), 1)
 	bash := testSkipIfNoCommand(t, p, "false")
 	done := make(chan error)
 
@@ -363,7 +403,7 @@ func TestProcessCommandlineParseError(t *testing.T) {
 		},
 	}
 
-	p := newConcurrentProcess(1)
+	p := newConcurrentProcess(context.Background(), 1)
 	for _, tc := range tests {
 		t.Run(tc.what, func(t *testing.T) {
 			_, err := p.newCommandRunner(tc.cmd, true)
@@ -373,3 +413,550 @@ func TestProcessCommandlineParseError(t *testing.T) {
 		})
 	}
 }
+
+func TestProcessRunTimeout(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("this test is flaky on Windows")
+	}
+
+	p := newConcurrentProcess(context.Background(), 1)
+	p.timeout = 100 * time.Millisecond
+	sleep := testSkipIfNoCommand(t, p, "sleep")
+
+	start := time.Now()
+	var timedOut atomic.Int32
+	for i := 0; i < 2; i++ {
+		sleep.run([]string{"10"}, "", func(b []byte, err error) error {
+			if !errors.Is(err, context.DeadlineExceeded) {
+				t.Errorf("context.DeadlineExceeded error was expected but got %v", err)
+			} else if !strings.Contains(err.Error(), "did not finish within 100ms") {
+				t.Errorf("unexpected error message: %v", err)
+			}
+			timedOut.Add(1)
+			return nil
+		})
+	}
+
+	if err := sleep.wait(); err != nil {
+		t.Fatal(err)
+	}
+	p.wait()
+	if n := timedOut.Load(); n != 2 {
+		t.Fatalf("callback should be called twice with timeout error but called %d times", n)
+	}
+	if sec := time.Since(start).Seconds(); sec >= 5 {
+		t.Fatalf("running commands were not killed on timeout. it took %v seconds", sec)
+	}
+}
+
+func TestProcessRunWithinTimeout(t *testing.T) {
+	p := newConcurrentProcess(context.Background(), 1)
+	p.timeout = 10 * time.Second
+	echo := testSkipIfNoCommand(t, p, "echo")
+
+	var out string
+	echo.run([]string{"hello"}, "", func(b []byte, err error) error {
+		if err != nil {
+			t.Error(err)
+			return err
+		}
+		out = string(b)
+		return nil
+	})
+	if err := echo.wait(); err != nil {
+		t.Fatal(err)
+	}
+	p.wait()
+	if out != "hello\n" {
+		t.Fatalf("unexpected output %q", out)
+	}
+}
+
+func TestProcessPersistentCommand(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("this test uses shell script")
+	}
+	if _, err := execabs.LookPath("sh"); err != nil {
+		t.Skipf("sh command is necessary to run this test: %s", err)
+	}
+
+	p := newConcurrentProcess(context.Background(), 2)
+	// Echo back each line with PID. Exit on "exit" line to test restarting the process.
+	script := `while IFS= read -r l; do if [ "$l" = exit ]; then exit 1; fi; echo "$$ $l"; done`
+	c1, err := p.newPersistentCommandRunner(hostExecutor{}, "sh -c '"+script+"'")
+	if err != nil {
+		t.Fatal(err)
+	}
+	c2, err := p.newPersistentCommandRunner(hostExecutor{}, "sh -c '"+script+"'")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c1.persistent != c2.persistent {
+		t.Fatal("persistent process is not shared by runners of the same command")
+	}
+
+	request := func(c *externalCommand, in string) (string, error) {
+		var out string
+		var runErr error
+		c.run(nil, in, func(b []byte, err error) error {
+			out, runErr = string(b), err
+			return nil
+		})
+		if err := c.wait(); err != nil {
+			t.Fatal(err)
+		}
+		return out, runErr
+	}
+
+	pids := map[string]struct{}{}
+	for i, c := range []*externalCommand{c1, c2, c1} {
+		in := fmt.Sprintf("input%d", i)
+		out, err := request(c, in)
+		if err != nil {
+			t.Fatal(err)
+		}
+		pid, l, ok := strings.Cut(strings.TrimSuffix(out, "\n"), " ")
+		if !ok || l != in {
+			t.Fatalf("unexpected output %q for input %q", out, in)
+		}
+		pids[pid] = struct{}{}
+	}
+	if len(pids) != 1 {
+		t.Fatalf("process was started multiple times: %v", pids)
+	}
+
+	if _, err := request(c1, "exit"); err == nil || !strings.Contains(err.Error(), "could not read a line from stdout") {
+		t.Fatalf("unexpected error when the process exited: %v", err)
+	}
+	out, err := request(c1, "again")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pid, _, _ := strings.Cut(out, " "); pid == "" {
+		t.Fatalf("unexpected output after restart %q", out)
+	} else if _, ok := pids[pid]; ok {
+		t.Fatalf("process was not restarted after exit: %q", out)
+	}
+
+	if _, err := request(c1, "foo\nbar"); err == nil || !strings.Contains(err.Error(), "must not contain newlines") {
+		t.Fatalf("unexpected error for input containing newline: %v", err)
+	}
+
+	p.wait()
+	if p.persistent != nil {
+		t.Fatal("persistent processes were not stopped")
+	}
+}
+
+func TestProcessPersistentCommandTimeout(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("this test uses shell script")
+	}
+	if _, err := execabs.LookPath("sh"); err != nil {
+		t.Skipf("sh command is necessary to run this test: %s", err)
+	}
+
+	p := newConcurrentProcess(context.Background(), 1)
+	p.timeout = 100 * time.Millisecond
+	c, err := p.newPersistentCommandRunner(hostExecutor{}, "sh -c 'read l; sleep 10'")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	start := time.Now()
+	var runErr error
+	c.run(nil, "hello", func(b []byte, err error) error {
+		runErr = err
+		return nil
+	})
+	if err := c.wait(); err != nil {
+		t.Fatal(err)
+	}
+	p.wait()
+	if !errors.Is(runErr, context.DeadlineExceeded) {
+		t.Fatalf("context.DeadlineExceeded error was expected but got %v", runErr)
+	}
+	if sec := time.Since(start).Seconds(); sec >= 5 {
+		t.Fatalf("persistent process was not killed on timeout. it took %v seconds", sec)
+	}
+}
+
+func TestProcessResourceLimits(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("memory limit is only supported on Linux")
+	}
+
+	l, err := newProcessLimits(7, 64*1024*1024)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := newConcurrentProcess(context.Background(), 1)
+	p.limits = l
+	// `ulimit -v` outputs the limit of virtual memory in KiB. The limits are applied before the process
+	// starts so no sleep is necessary.
+	sh := testSkipIfNoCommand(t, p, "sh")
+
+	var out string
+	sh.run([]string{"-c", "nice; ulimit -v"}, "", func(b []byte, err error) error {
+		if err != nil {
+			t.Error(err)
+			return err
+		}
+		out = string(b)
+		return nil
+	})
+	if err := sh.wait(); err != nil {
+		t.Fatal(err)
+	}
+	p.wait()
+	if want := "7\n65536\n"; out != want {
+		t.Fatalf("wanted output %q but got %q", want, out)
+	}
+}
+
+func TestProcessResourceLimitsInvalid(t *testing.T) {
+	if l, err := newProcessLimits(0, 0); l != nil || err != nil {
+		t.Fatalf("no limit should be created without error: %v, %v", l, err)
+	}
+	for _, n := range []int{-21, 20} {
+		_, err := newProcessLimits(n, 0)
+		if err == nil || !strings.Contains(err.Error(), "must be in range from -20 to 19") {
+			t.Fatalf("unexpected error for niceness %d: %v", n, err)
+		}
+	}
+}
+
+func TestProcessResourceLimitsCommand(t *testing.T) {
+	var l *processLimits
+	exe, args := l.command("shellcheck", []string{"-f", "json"})
+	if exe != "shellcheck" || !slices.Equal(args, []string{"-f", "json"}) {
+		t.Fatalf("command should not be wrapped without limits: %q %q", exe, args)
+	}
+
+	if runtime.GOOS != "linux" {
+		t.Skip("memory limit is only supported on Linux")
+	}
+	l, err := newProcessLimits(0, 512*1024*1024)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exe, args = l.command("shellcheck", []string{"-f", "json"})
+	want := []string{"-c", `ulimit -v 524288 && exec "$0" "$@"`, "shellcheck", "-f", "json"}
+	if exe != "sh" || !slices.Equal(args, want) {
+		t.Fatalf("wanted command sh %q but got %s %q", want, exe, args)
+	}
+}
+
+func TestProcessCaptureStderr(t *testing.T) {
+	p := newConcurrentProcess(context.Background(), 1)
+	var stderr []string
+	p.onStderr = func(exe string, args []string, b []byte) {
+		stderr = append(stderr, string(b))
+	}
+	bash := testSkipIfNoCommand(t, p, "bash")
+
+	for _, script := range []string{"echo warn >&2; echo ok", "echo fail >&2; exit 1", "echo quiet"} {
+		bash.run([]string{"-c", script}, "", func(b []byte, err error) error { return nil })
+		if err := bash.wait(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	p.wait()
+
+	want := []string{"warn\n", "fail\n"}
+	if diff := cmp.Diff(want, stderr); diff != "" {
+		t.Fatal(diff)
+	}
+}
+
+func TestProcessCaptureStderrOfPersistentCommand(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("this test uses shell script")
+	}
+	if _, err := execabs.LookPath("sh"); err != nil {
+		t.Skipf("sh command is necessary to run this test: %s", err)
+	}
+
+	var mu sync.Mutex
+	var stderr []string
+	p := newConcurrentProcess(context.Background(), 1)
+	p.onStderr = func(exe string, args []string, b []byte) {
+		mu.Lock()
+		stderr = append(stderr, string(b))
+		mu.Unlock()
+	}
+	c, err := p.newPersistentCommandRunner(hostExecutor{}, `sh -c 'while IFS= read -r l; do echo "got $l" >&2; echo ok; done; printf bye >&2'`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, in := range []string{"foo", "bar"} {
+		c.run(nil, in, func(b []byte, err error) error { return err })
+		if err := c.wait(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	p.wait()
+
+	want := []string{"got foo\n", "got bar\n", "bye"}
+	if diff := cmp.Diff(want, stderr); diff != "" {
+		t.Fatal(diff)
+	}
+}
+
+func TestProcessTransientError(t *testing.T) {
+	testCases := []struct {
+		err  error
+		want bool
+	}{
+		{syscall.EAGAIN, true},
+		{fmt.Errorf("could not start foo process: %w", syscall.EMFILE), true},
+		{syscall.ENOENT, false},
+		{errors.New("foo exited with status 1 but stdout was empty"), false},
+	}
+	for _, tc := range testCases {
+		if have := isTransientProcessError(tc.err); have != tc.want {
+			t.Errorf("wanted %v for %v but got %v", tc.want, tc.err, have)
+		}
+	}
+}
+
+func TestProcessContainerExecutor(t *testing.T) {
+	// Fake container runtime which outputs its arguments
+	if _, err := execabs.LookPath("echo"); err != nil {
+		t.Skipf("echo command is necessary to run this test: %s", err)
+	}
+
+	p := newConcurrentProcess(context.Background(), 1)
+	for _, tc := range []struct {
+		cmdline string
+		want    string
+	}{
+		{"", "run --rm -i --network none koalaman/shellcheck:v0.10.0 --norc -\n"},
+		{"python3 -m 'pyflakes'", "run --rm -i --network none koalaman/shellcheck:v0.10.0 python3 -m pyflakes --norc -\n"},
+	} {
+		e := newContainerExecutor(&ContainerConfig{Image: "koalaman/shellcheck:v0.10.0", Runtime: "echo"})
+		c, err := p.newCommandRunnerOn(e, tc.cmdline, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var out string
+		c.run([]string{"--norc", "-"}, "", func(b []byte, err error) error {
+			out = string(b)
+			return err
+		})
+		if err := c.wait(); err != nil {
+			t.Fatal(err)
+		}
+		if out != tc.want {
+			t.Errorf("wanted %q but got %q for command %q", tc.want, out, tc.cmdline)
+		}
+	}
+	p.wait()
+
+	e := newContainerExecutor(&ContainerConfig{Image: "foo", Runtime: "this-runtime-does-not-exist"})
+	if _, err := p.newCommandRunnerOn(e, "", false); err == nil || !strings.Contains(err.Error(), `container runtime "this-runtime-does-not-exist" to run image "foo" was not found`) {
+		t.Fatalf("unexpected error for unknown runtime: %v", err)
+	}
+	if e := newContainerExecutor(&ContainerConfig{Image: "foo"}); e.runtime != "docker" {
+		t.Fatalf("default runtime should be docker but got %q", e.runtime)
+	}
+}
+
+func TestProcessSSHExecutor(t *testing.T) {
+	// Fake SSH client which outputs its arguments
+	if _, err := execabs.LookPath("echo"); err != nil {
+		t.Skipf("echo command is necessary to run this test: %s", err)
+	}
+
+	p := newConcurrentProcess(context.Background(), 1)
+	e := newSSHExecutor(&RemoteConfig{Host: "lint-farm", SSH: "echo -v"})
+	c, err := p.newCommandRunnerOn(e, "/opt/bin/shellcheck --severity 'style x'", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var out string
+	c.run([]string{"-e", "it's", "$HOME"}, "", func(b []byte, err error) error {
+		out = string(b)
+		return err
+	})
+	if err := c.wait(); err != nil {
+		t.Fatal(err)
+	}
+	p.wait()
+
+	want := `-v -T -o BatchMode=yes -- lint-farm /opt/bin/shellcheck --severity 'style x' -e 'it'\''s' '$HOME'` + "\n"
+	if out != want {
+		t.Fatalf("wanted %q but got %q", want, out)
+	}
+
+	e = newSSHExecutor(&RemoteConfig{Host: "lint-farm", SSH: "this-ssh-does-not-exist"})
+	if _, err := p.newCommandRunnerOn(e, "shellcheck", false); err == nil || !strings.Contains(err.Error(), `SSH client "this-ssh-does-not-exist" to run commands on "lint-farm" was not found`) {
+		t.Fatalf("unexpected error for unknown SSH client: %v", err)
+	}
+	if e := newSSHExecutor(&RemoteConfig{Host: "lint-farm"}); e.ssh != "ssh" {
+		t.Fatalf("default SSH client should be ssh but got %q", e.ssh)
+	}
+}
+
+func TestProcessQuotePOSIXShellArg(t *testing.T) {
+	for in, want := range map[string]string{
+		"shellcheck":              "shellcheck",
+		"--exclude=SC1091,SC2194": "--exclude=SC1091,SC2194",
+		"":                        "''",
+		"a b":                     "'a b'",
+		"it's":                    `'it'\''s'`,
+		"$(rm -rf /)":             "'$(rm -rf /)'",
+		"*.sh":                    "'*.sh'",
+	} {
+		if have := quotePOSIXShellArg(in); have != want {
+			t.Errorf("wanted %q for %q but got %q", want, in, have)
+		}
+	}
+}
+
+func TestProcessFilterEnvironmentVariables(t *testing.T) {
+	if _, err := execabs.LookPath("sh"); err != nil {
+		t.Skipf("sh command is necessary to run this test: %s", err)
+	}
+	t.Setenv("ACTIONLINT_TEST_SECRET", "secret")
+	t.Setenv("ACTIONLINT_TEST_OPT_FOO", "foo")
+	t.Setenv("ACTIONLINT_TEST_OPT_BAR", "bar")
+
+	script := `sh -c 'echo "$ACTIONLINT_TEST_SECRET,$ACTIONLINT_TEST_OPT_FOO,$ACTIONLINT_TEST_OPT_BAR"'`
+	testCases := []struct {
+		what    string
+		allowed []string
+		want    string
+	}{
+		{"inherit all", nil, "secret,foo,bar"},
+		{"default", defaultAllowedEnv, ",,"},
+		{"name", []string{"PATH", "ACTIONLINT_TEST_OPT_FOO"}, ",foo,"},
+		{"prefix", []string{"PATH", "ACTIONLINT_TEST_OPT_*"}, ",foo,bar"},
+		{"all", []string{"*"}, "secret,foo,bar"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.what, func(t *testing.T) {
+			p := newConcurrentProcess(context.Background(), 1)
+			p.allowedEnv = tc.allowed
+			c, err := p.newCommandRunner(script, false)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var out string
+			c.run(nil, "", func(b []byte, err error) error {
+				out = strings.TrimSpace(string(b))
+				return err
+			})
+			if err := c.wait(); err != nil {
+				t.Fatal(err)
+			}
+			p.wait()
+			if out != tc.want {
+				t.Fatalf("wanted %q but got %q", tc.want, out)
+			}
+		})
+	}
+}
+
+func TestProcessFilterEnvironmentVariablesOfPersistentCommand(t *testing.T) {
+	if _, err := execabs.LookPath("sh"); err != nil {
+		t.Skipf("sh command is necessary to run this test: %s", err)
+	}
+	t.Setenv("ACTIONLINT_TEST_SECRET", "secret")
+
+	p := newConcurrentProcess(context.Background(), 1)
+	p.allowedEnv = defaultAllowedEnv
+	c, err := p.newPersistentCommandRunner(hostExecutor{}, `sh -c 'while IFS= read -r l; do echo "[$ACTIONLINT_TEST_SECRET]"; done'`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var out string
+	c.run(nil, "{}", func(b []byte, err error) error {
+		out = strings.TrimSpace(string(b))
+		return err
+	})
+	if err := c.wait(); err != nil {
+		t.Fatal(err)
+	}
+	p.wait()
+	if out != "[]" {
+		t.Fatalf("secret was passed to persistent command: %q", out)
+	}
+}
+
+func TestProcessRequiredEnvironmentVariablesOfExecutor(t *testing.T) {
+	t.Setenv("SSH_AUTH_SOCK", "/tmp/agent.sock")
+	t.Setenv("DOCKER_HOST", "unix:///tmp/docker.sock")
+
+	p := newConcurrentProcess(context.Background(), 1)
+	p.allowedEnv = []string{"PATH"}
+	defer p.wait()
+
+	for _, tc := range []struct {
+		executor commandExecutor
+		want     string
+		notWant  string
+	}{
+		{newSSHExecutor(&RemoteConfig{Host: "lint-farm"}), "SSH_AUTH_SOCK=/tmp/agent.sock", "DOCKER_HOST="},
+		{newContainerExecutor(&ContainerConfig{Image: "koalaman/shellcheck"}), "DOCKER_HOST=unix:///tmp/docker.sock", "SSH_AUTH_SOCK="},
+		{hostExecutor{}, "PATH=", "SSH_AUTH_SOCK="},
+	} {
+		env := p.environ(tc.executor)
+		if !slices.ContainsFunc(env, func(kv string) bool { return strings.HasPrefix(kv, tc.want) }) {
+			t.Errorf("%q is not passed to %T: %v", tc.want, tc.executor, env)
+		}
+		if slices.ContainsFunc(env, func(kv string) bool { return strings.HasPrefix(kv, tc.notWant) }) {
+			t.Errorf("%q is passed to %T: %v", tc.notWant, tc.executor, env)
+		}
+	}
+}
+
+func TestProcessWSLExecutor(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake wsl command is a shell script")
+	}
+
+	// Fake wsl command which outputs its arguments and the input, and fails for unknown commands
+	wsl := filepath.Join(t.TempDir(), "wsl")
+	script := `#!/bin/sh
+[ "$1" = "-e" ] || exit 2
+[ "$2" = "shellcheck" ] || exit 1
+echo "$@"
+cat
+`
+	if err := os.WriteFile(wsl, []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	e, err := findCommandInWSL(context.Background(), wsl, "shellcheck")
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := newConcurrentProcess(context.Background(), 1)
+	c, err := p.newCommandRunnerOn(e, "shellcheck", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var out string
+	c.run([]string{"-f", "json", "it's"}, "echo hi\n", func(b []byte, err error) error {
+		out = string(b)
+		return err
+	})
+	if err := c.wait(); err != nil {
+		t.Fatal(err)
+	}
+	p.wait()
+
+	want := "-e shellcheck -f json it's\necho hi\n"
+	if out != want {
+		t.Fatalf("wanted %q but got %q", want, out)
+	}
+
+	if _, err := findCommandInWSL(context.Background(), wsl, "pyflakes"); err == nil || !strings.Contains(err.Error(), `"pyflakes" is not available in WSL`) {
+		t.Fatalf("unexpected error for command not in WSL: %v", err)
+	}
+	if _, err := findCommandInWSL(context.Background(), "this-wsl-does-not-exist", "shellcheck"); err == nil || !strings.Contains(err.Error(), `WSL command "this-wsl-does-not-exist" was not found`) {
+		t.Fatalf("unexpected error for unknown wsl command: %v", err)
+	}
+}
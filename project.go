@@ -1,6 +1,9 @@
 package actionlint
 
 import (
+	"errors"
+	"fmt"
+	"io/fs"
 	"os"
 	"path/filepath"
 	"strings"
@@ -10,6 +13,11 @@ import (
 type Project struct {
 	root   string
 	config *Config
+	fsys   fs.FS // nil means the OS filesystem
+	// overlay is contents of files which take precedence over the files in the filesystem. Keys
+	// are absolute paths on the OS filesystem or cleaned virtual paths in fsys. This is used for
+	// linting unsaved buffers of editors. nil means no overlay.
+	overlay map[string][]byte
 }
 
 func absPath(path string) string {
@@ -19,15 +27,32 @@ func absPath(path string) string {
 	return path
 }
 
+func isDir(path string) bool {
+	s, err := os.Stat(path)
+	return err == nil && s.IsDir()
+}
+
+func (p *Project) isDir(path string) bool {
+	s, err := p.stat(path)
+	return err == nil && s.IsDir()
+}
+
 // findProject creates new Project instance by finding a project which the given path belongs to.
-// A project must be a Git repository and have ".github/workflows" directory.
+// A project must be a Git repository and have ".github/workflows" directory. ".gitea/workflows" and
+// ".forgejo/workflows" directories for Gitea Actions and Forgejo Actions are also accepted.
+// ".github/workflow-templates" directory is also accepted for ".github" repositories of
+// organizations which only have workflow templates.
 func findProject(path string) (*Project, error) {
 	d := absPath(path)
 	for {
-		if s, err := os.Stat(filepath.Join(d, ".github", "workflows")); err == nil && s.IsDir() {
+		for _, w := range []string{".github", ".gitea", ".forgejo"} {
+			if !isDir(filepath.Join(d, w, "workflows")) && (w != ".github" || !isDir(filepath.Join(d, w, "workflow-templates"))) {
+				continue
+			}
 			if _, err := os.Stat(filepath.Join(d, ".git")); err == nil { // Note: .git may be a file
 				return NewProject(d)
 			}
+			break
 		}
 
 		p := filepath.Dir(d)
@@ -45,7 +70,88 @@ func NewProject(root string) (*Project, error) {
 	if err != nil {
 		return nil, err
 	}
-	return &Project{root, c}, nil
+	return &Project{root, c, nil, nil}, nil
+}
+
+// NewProjectFS creates a new instance which reads files from the given file system instead of the
+// OS filesystem. This is useful to lint workflows in in-memory trees, archives, or embedded files.
+// The root parameter is a slash-separated path to the root directory of the repository in the file
+// system such as ".". Paths of files in the project are virtual paths in the file system.
+// This function returns an error when failing to parse an actionlint config file in the repository.
+func NewProjectFS(fsys fs.FS, root string) (*Project, error) {
+	if !fs.ValidPath(root) {
+		return nil, fmt.Errorf("invalid root directory path %q in file system. it must be an unrooted slash-separated path such as \".\"", root)
+	}
+	p := &Project{root: root, fsys: fsys}
+	for _, f := range []string{"actionlint.yaml", "actionlint.yml"} {
+		path := filepath.Join(root, ".github", f)
+		b, err := p.readFile(path)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("could not read config file %q: %w", path, err)
+		}
+		c, err := ParseConfig(b)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse config file %q: %w", path, err)
+		}
+		p.config = c
+		break
+	}
+	return p, nil
+}
+
+// FS returns the file system which the project reads files from. It returns nil when the project
+// reads files from the OS filesystem.
+func (p *Project) FS() fs.FS {
+	return p.fsys
+}
+
+// overlayKey returns the key of the path in the overlay.
+func (p *Project) overlayKey(path string) string {
+	if p.fsys == nil {
+		return absPath(path)
+	}
+	return filepath.ToSlash(filepath.Clean(path))
+}
+
+// withOverlay returns a copy of the project which reads the given file contents instead of the files
+// in the filesystem. Keys of the files map are file paths as well as readFile. Files not in the map
+// are read from the filesystem.
+func (p *Project) withOverlay(files map[string][]byte) *Project {
+	o := make(map[string][]byte, len(files))
+	for f, b := range files {
+		o[p.overlayKey(f)] = b
+	}
+	c := *p
+	c.overlay = o
+	return &c
+}
+
+// readFile reads the file at the path in the project. When the project reads files from fs.FS, the
+// path is a virtual path in the file system. This method can be called on nil.
+func (p *Project) readFile(path string) ([]byte, error) {
+	if p == nil {
+		return os.ReadFile(path)
+	}
+	if p.overlay != nil {
+		if b, ok := p.overlay[p.overlayKey(path)]; ok {
+			return b, nil
+		}
+	}
+	if p.fsys == nil {
+		return os.ReadFile(path)
+	}
+	return fs.ReadFile(p.fsys, filepath.ToSlash(path))
+}
+
+// stat returns the file information of the path in the project as well as readFile.
+func (p *Project) stat(path string) (fs.FileInfo, error) {
+	if p == nil || p.fsys == nil {
+		return os.Stat(path)
+	}
+	return fs.Stat(p.fsys, filepath.ToSlash(path))
 }
 
 // RootDir returns a root directory path of the GitHub project repository.
@@ -59,9 +165,32 @@ func (p *Project) WorkflowsDir() string {
 	return filepath.Join(p.root, ".github", "workflows")
 }
 
+// WorkflowTemplatesDir returns a ".github/workflow-templates" directory path of the GitHub project
+// repository. Workflow templates of an organization are put in the directory of its ".github"
+// repository. This method does not check if the directory exists.
+func (p *Project) WorkflowTemplatesDir() string {
+	return filepath.Join(p.root, ".github", "workflow-templates")
+}
+
+// workflowsDirOf returns the workflows directory path of the project for the platform. Gitea Actions
+// and Forgejo Actions prefer their own directories like ".gitea/workflows". When none of them
+// exists, it returns the ".github/workflows" directory path.
+func (p *Project) workflowsDirOf(platform Platform) string {
+	for _, d := range platform.workflowsDirs() {
+		if d := filepath.Join(p.root, d); p.isDir(d) {
+			return d
+		}
+	}
+	return p.WorkflowsDir()
+}
+
 // Knows returns true when the project knows the given file. When a file is included in the
 // project's directory, the project knows the file.
 func (p *Project) Knows(path string) bool {
+	if p.fsys != nil {
+		path = filepath.ToSlash(filepath.Clean(path))
+		return p.root == "." || path == p.root || strings.HasPrefix(path, p.root+"/")
+	}
 	// TODO: strings.HasPrefix is not perfect to check file path
 	return strings.HasPrefix(absPath(path), p.root)
 }
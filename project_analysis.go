@@ -0,0 +1,102 @@
+package actionlint
+
+import (
+	"io"
+	"slices"
+)
+
+// ProjectAnalysis is a cross-workflow analysis of a project. It indexes the workflow files in the
+// workflows directory of the project and builds the call graph of local reusable workflows. It
+// also resolves the metadata of local reusable workflows and local actions across files with the
+// same caches used by Linter.
+//
+// Workflows are identified by their specs relative to the project root such as
+// "./.github/workflows/ci.yaml", which is the same format as "jobs.<job_id>.uses".
+type ProjectAnalysis struct {
+	proj      *Project
+	workflows []string
+	calls     map[string][]string
+	callers   map[string][]string
+	reusable  *LocalReusableWorkflowCache
+	actions   *LocalActionsCache
+}
+
+// AnalyzeProject analyzes workflow files in the given project. The dbg parameter is a writer to
+// output debug logs. It can be nil. This function returns an error when no workflow file is found
+// in the project.
+func AnalyzeProject(proj *Project, dbg io.Writer) (*ProjectAnalysis, error) {
+	var platform Platform
+	if c := proj.Config(); c != nil {
+		platform = c.Platform
+	}
+	files, err := findYAMLFiles(proj.workflowsDirOf(platform), proj)
+	if err != nil {
+		return nil, err
+	}
+
+	a := &ProjectAnalysis{
+		proj:      proj,
+		workflows: make([]string, 0, len(files)),
+		calls:     map[string][]string{},
+		callers:   map[string][]string{},
+		reusable:  NewLocalReusableWorkflowCache(proj, "", dbg),
+		actions:   NewLocalActionsCache(proj, dbg),
+	}
+
+	for _, f := range files {
+		spec, ok := a.reusable.convWorkflowPathToSpec(f)
+		if !ok {
+			continue
+		}
+		a.workflows = append(a.workflows, spec)
+		calls := a.reusable.findLocalCalls(spec)
+		a.calls[spec] = calls
+		for _, c := range calls {
+			a.callers[c] = append(a.callers[c], spec)
+		}
+	}
+	for _, cs := range a.callers {
+		slices.Sort(cs)
+	}
+
+	return a, nil
+}
+
+// Project returns the analyzed project.
+func (a *ProjectAnalysis) Project() *Project {
+	return a.proj
+}
+
+// Workflows returns the specs of all workflow files in the project in sorted order.
+func (a *ProjectAnalysis) Workflows() []string {
+	return a.workflows
+}
+
+// Calls returns the specs of the local reusable workflows called by the workflow specified by the
+// spec. It returns nil when the workflow calls no local reusable workflow.
+func (a *ProjectAnalysis) Calls(spec string) []string {
+	if cs := a.calls[spec]; len(cs) > 0 {
+		return cs
+	}
+	return nil
+}
+
+// Callers returns the specs of the workflows which call the local reusable workflow specified by
+// the spec. It returns nil when no workflow calls it.
+func (a *ProjectAnalysis) Callers(spec string) []string {
+	return a.callers[spec]
+}
+
+// ReusableWorkflow returns the metadata of the local reusable workflow specified by the spec such
+// as "./.github/workflows/reusable.yaml". The metadata contains the inputs, outputs, and secrets of
+// the workflow. It returns an error when the workflow cannot be read or is not a reusable workflow.
+func (a *ProjectAnalysis) ReusableWorkflow(spec string) (*ReusableWorkflowMetadata, error) {
+	return a.reusable.FindMetadata(spec)
+}
+
+// LocalAction returns the metadata of the local action specified by the spec such as
+// "./.github/actions/my-action". It returns nil without an error when the action is not found.
+func (a *ProjectAnalysis) LocalAction(spec string) (*ActionMetadata, error) {
+	m, _, err := a.actions.FindMetadata(spec)
+	return m, err
+}
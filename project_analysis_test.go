@@ -0,0 +1,134 @@
+package actionlint
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestProjectAnalysisCallGraph(t *testing.T) {
+	fsys := fstest.MapFS{
+		".github/workflows/ci.yaml": {Data: []byte(`on: push
+jobs:
+  build:
+    uses: ./.github/workflows/build.yaml
+    with:
+      target: linux
+  test:
+    uses: ./.github/workflows/test.yaml
+`)},
+		".github/workflows/release.yaml": {Data: []byte(`on: push
+jobs:
+  build:
+    uses: ./.github/workflows/build.yaml
+    with:
+      target: linux
+  remote:
+    uses: owner/repo/.github/workflows/remote.yaml@v1
+`)},
+		".github/workflows/build.yaml": {Data: []byte(`on:
+  workflow_call:
+    inputs:
+      target:
+        type: string
+        required: true
+    outputs:
+      artifact:
+        value: ${{ jobs.build.outputs.artifact }}
+jobs:
+  build:
+    runs-on: ubuntu-latest
+    steps:
+      - uses: ./.github/actions/setup
+`)},
+		".github/workflows/test.yaml": {Data: []byte(`on: workflow_call
+jobs:
+  test:
+    uses: ./.github/workflows/build.yaml
+    with:
+      target: linux
+`)},
+		".github/actions/setup/action.yml": {Data: []byte(`name: Setup
+description: Setup
+inputs:
+  version:
+    description: Version
+runs:
+  using: composite
+  steps:
+    - run: echo
+      shell: bash
+`)},
+	}
+
+	p, err := NewProjectFS(fsys, ".")
+	if err != nil {
+		t.Fatal(err)
+	}
+	a, err := AnalyzeProject(p, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{
+		"./.github/workflows/build.yaml",
+		"./.github/workflows/ci.yaml",
+		"./.github/workflows/release.yaml",
+		"./.github/workflows/test.yaml",
+	}
+	if diff := cmp.Diff(want, a.Workflows()); diff != "" {
+		t.Fatalf("unexpected workflows: %s", diff)
+	}
+
+	want = []string{
+		"./.github/workflows/ci.yaml",
+		"./.github/workflows/release.yaml",
+		"./.github/workflows/test.yaml",
+	}
+	if diff := cmp.Diff(want, a.Callers("./.github/workflows/build.yaml")); diff != "" {
+		t.Fatalf("unexpected callers of build.yaml: %s", diff)
+	}
+	if cs := a.Callers("./.github/workflows/ci.yaml"); cs != nil {
+		t.Fatalf("ci.yaml should not be called by any workflow but got %v", cs)
+	}
+
+	want = []string{
+		"./.github/workflows/build.yaml",
+		"./.github/workflows/test.yaml",
+	}
+	if diff := cmp.Diff(want, a.Calls("./.github/workflows/ci.yaml")); diff != "" {
+		t.Fatalf("unexpected calls from ci.yaml: %s", diff)
+	}
+	if cs := a.Calls("./.github/workflows/build.yaml"); cs != nil {
+		t.Fatalf("build.yaml should not call any workflow but got %v", cs)
+	}
+
+	m, err := a.ReusableWorkflow("./.github/workflows/build.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if i, ok := m.Inputs["target"]; !ok || !i.Required {
+		t.Fatalf("required input \"target\" was not found in %v", m.Inputs)
+	}
+	if _, ok := m.Outputs["artifact"]; !ok {
+		t.Fatalf("output \"artifact\" was not found in %v", m.Outputs)
+	}
+	if _, err := a.ReusableWorkflow("./.github/workflows/ci.yaml"); err == nil {
+		t.Fatal("ci.yaml is not a reusable workflow but no error occurred")
+	}
+
+	act, err := a.LocalAction("./.github/actions/setup")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if act == nil || act.Name != "Setup" {
+		t.Fatalf("unexpected local action metadata: %v", act)
+	}
+	if _, ok := act.Inputs["version"]; !ok {
+		t.Fatalf("input \"version\" was not found in %v", act.Inputs)
+	}
+	if act, err := a.LocalAction("./.github/actions/missing"); act != nil || err != nil {
+		t.Fatalf("missing action should return nil but got %v, %v", act, err)
+	}
+}
@@ -3,8 +3,10 @@ package actionlint
 import (
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"testing"
+	"testing/fstest"
 )
 
 // Create `.git` directory since actionlint finds the directory to detect the repository root.
@@ -99,6 +101,28 @@ func TestProjectsDoesNotFindProjectFromOutside(t *testing.T) {
 	}
 }
 
+func TestProjectsFindProjectWithWorkflowTemplates(t *testing.T) {
+	d := t.TempDir()
+	testEnsureDotGitDir(d)
+	if err := os.MkdirAll(filepath.Join(d, ".github", "workflow-templates"), 0750); err != nil {
+		t.Fatal(err)
+	}
+
+	p, err := NewProjects().At(filepath.Join(d, ".github", "workflow-templates", "ci.yml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p == nil {
+		t.Fatal("project was not found")
+	}
+	if p.RootDir() != d {
+		t.Fatalf("root directory of project should be %q but got %q", d, p.RootDir())
+	}
+	if want, have := filepath.Join(d, ".github", "workflow-templates"), p.WorkflowTemplatesDir(); have != want {
+		t.Fatalf("workflow templates directory should be %q but got %q", want, have)
+	}
+}
+
 func TestProjectsLoadProjectConfig(t *testing.T) {
 	for _, n := range []string{"ok", "yml"} {
 		d := filepath.Join("testdata", "config", "projects", n)
@@ -149,3 +173,52 @@ func TestProjectsLoadingBrokenProjectConfig(t *testing.T) {
 		t.Fatalf("wanted error %q but have error %q", want, msg)
 	}
 }
+
+func TestProjectFSLoadConfig(t *testing.T) {
+	fsys := fstest.MapFS{
+		"repo/.github/actionlint.yml":      {Data: []byte("self-hosted-runner:\n  labels: [foo]\n")},
+		"repo/.github/workflows/test.yaml": {Data: []byte("on: push\n")},
+	}
+
+	p, err := NewProjectFS(fsys, "repo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.FS() == nil {
+		t.Fatal("file system was not set to the project")
+	}
+	c := p.Config()
+	if c == nil {
+		t.Fatal("config was not found in the file system")
+	}
+	if !slices.Equal(c.SelfHostedRunner.Labels, []string{"foo"}) {
+		t.Fatalf("unexpected labels in config: %v", c.SelfHostedRunner.Labels)
+	}
+	if d := p.workflowsDirOf(PlatformGitHub); d != filepath.Join("repo", ".github", "workflows") {
+		t.Fatalf("unexpected workflows directory %q", d)
+	}
+
+	for path, want := range map[string]bool{
+		"repo/.github/workflows/test.yaml":  true,
+		"repo":                              true,
+		"other/.github/workflows/test.yaml": false,
+		"repository/test.yaml":              false,
+	} {
+		if have := p.Knows(path); have != want {
+			t.Errorf("Knows(%q) should be %v but got %v", path, want, have)
+		}
+	}
+}
+
+func TestProjectFSErrors(t *testing.T) {
+	if _, err := NewProjectFS(fstest.MapFS{}, "/repo"); err == nil || !strings.Contains(err.Error(), "invalid root directory path") {
+		t.Fatalf("unexpected error for invalid root: %v", err)
+	}
+
+	fsys := fstest.MapFS{
+		".github/actionlint.yaml": {Data: []byte("self-hosted-runner: 42\n")},
+	}
+	if _, err := NewProjectFS(fsys, "."); err == nil || !strings.Contains(err.Error(), "could not parse config file") {
+		t.Fatalf("unexpected error for broken config: %v", err)
+	}
+}
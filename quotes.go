@@ -1,6 +1,7 @@
 package actionlint
 
 import (
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
@@ -59,7 +60,10 @@ func quotes(ss []string) string {
 	return b.build()
 }
 
+// sortedQuotes is the same as quotes but sorts the strings. The given slice is not modified since
+// it may be a shared table like AllWebhookEvents.
 func sortedQuotes(ss []string) string {
+	ss = slices.Clone(ss)
 	sort.Strings(ss)
 	return quotes(ss)
 }
@@ -0,0 +1,154 @@
+package actionlint
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// RefDriftLocation is a location where an action or a reusable workflow is referenced.
+type RefDriftLocation struct {
+	// Path is the file path of the workflow.
+	Path string
+	// Pos is the position of the value at "uses:".
+	Pos *Pos
+}
+
+// RefDriftVersion is a ref of an action or a reusable workflow and the locations where it is
+// referenced at the ref.
+type RefDriftVersion struct {
+	// Ref is the ref after "@" such as "v4" or a commit SHA.
+	Ref string
+	// Locations is the locations referencing the ref in the order of the added workflows.
+	Locations []*RefDriftLocation
+}
+
+// RefDrift is an action or a reusable workflow which is referenced at different refs across
+// workflows.
+type RefDrift struct {
+	// Name is the action or the reusable workflow without ref such as "actions/checkout" or
+	// "owner/repo/.github/workflows/ci.yml".
+	Name string
+	// Workflow is true when the drift is of a reusable workflow. Otherwise it is of an action.
+	Workflow bool
+	// Versions is the refs in use. The most referenced ref comes first.
+	Versions []*RefDriftVersion
+}
+
+// Kind returns "action" or "reusable workflow" depending on what is referenced.
+func (d *RefDrift) Kind() string {
+	if d.Workflow {
+		return "reusable workflow"
+	}
+	return "action"
+}
+
+// Summary returns the short description of the refs in use such as `"v4" (3 places), "v3" (1 place)`.
+func (d *RefDrift) Summary() string {
+	ss := make([]string, 0, len(d.Versions))
+	for _, v := range d.Versions {
+		p := "places"
+		if len(v.Locations) == 1 {
+			p = "place"
+		}
+		ss = append(ss, fmt.Sprintf("%q (%d %s)", v.Ref, len(v.Locations), p))
+	}
+	return strings.Join(ss, ", ")
+}
+
+type refDriftTarget struct {
+	name     string // Name which appears first
+	workflow bool
+	refs     map[string][]*RefDriftLocation
+	order    []string // Refs in the order of appearance
+}
+
+// RefDriftFinder finds actions and reusable workflows which are referenced at different refs across
+// workflows. Local actions, local reusable workflows, Docker images, and specs containing
+// expressions are ignored since they have no ref.
+type RefDriftFinder struct {
+	targets map[string]*refDriftTarget
+}
+
+// NewRefDriftFinder creates a new RefDriftFinder instance.
+func NewRefDriftFinder() *RefDriftFinder {
+	return &RefDriftFinder{map[string]*refDriftTarget{}}
+}
+
+// Add parses the workflow source at the path and adds the actions and the reusable workflows
+// referenced in it to the targets to find drifts of refs.
+func (f *RefDriftFinder) Add(path string, src []byte) error {
+	w, errs := Parse(src)
+	if w == nil {
+		if len(errs) > 0 {
+			return fmt.Errorf("could not parse workflow %q: %w", path, errs[0])
+		}
+		return fmt.Errorf("could not parse workflow %q", path)
+	}
+
+	ids := make([]string, 0, len(w.Jobs))
+	for id := range w.Jobs {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+
+	for _, id := range ids {
+		j := w.Jobs[id]
+		if j.WorkflowCall != nil {
+			f.add(path, j.WorkflowCall.Uses, true)
+		}
+		for _, s := range j.Steps {
+			if e, ok := s.Exec.(*ExecAction); ok {
+				f.add(path, e.Uses, false)
+			}
+		}
+	}
+	return nil
+}
+
+func (f *RefDriftFinder) add(path string, uses *String, workflow bool) {
+	if uses == nil || strings.HasPrefix(uses.Value, "./") || strings.HasPrefix(uses.Value, "docker://") || strings.Contains(uses.Value, "${{") {
+		return
+	}
+	name, ref, ok := strings.Cut(uses.Value, "@")
+	if !ok || name == "" || ref == "" {
+		return
+	}
+	// Names of actions and reusable workflows are case-insensitive since owners and repositories are.
+	key := strings.ToLower(name)
+
+	t, ok := f.targets[key]
+	if !ok {
+		t = &refDriftTarget{name: name, workflow: workflow, refs: map[string][]*RefDriftLocation{}}
+		f.targets[key] = t
+	}
+	if _, ok := t.refs[ref]; !ok {
+		t.order = append(t.order, ref)
+	}
+	t.refs[ref] = append(t.refs[ref], &RefDriftLocation{Path: path, Pos: uses.Pos})
+}
+
+// Find returns the actions and the reusable workflows referenced at two or more different refs in
+// the added workflows. They are sorted by their names.
+func (f *RefDriftFinder) Find() []*RefDrift {
+	ds := []*RefDrift{}
+	for _, t := range f.targets {
+		if len(t.order) < 2 {
+			continue
+		}
+		vs := make([]*RefDriftVersion, 0, len(t.order))
+		for _, r := range t.order {
+			vs = append(vs, &RefDriftVersion{Ref: r, Locations: t.refs[r]})
+		}
+		// Stable sort keeps the order of appearance among the refs referenced in the same number of places
+		slices.SortStableFunc(vs, func(a, b *RefDriftVersion) int {
+			return cmp.Compare(len(b.Locations), len(a.Locations))
+		})
+		ds = append(ds, &RefDrift{Name: t.name, Workflow: t.workflow, Versions: vs})
+	}
+	slices.SortFunc(ds, func(a, b *RefDrift) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	return ds
+}
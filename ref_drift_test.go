@@ -0,0 +1,97 @@
+package actionlint
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestRefDriftFind(t *testing.T) {
+	f := NewRefDriftFinder()
+	for _, n := range []string{"ci.yaml", "release.yaml", "nightly.yaml"} {
+		src, err := os.ReadFile(filepath.Join("testdata", "drift", n))
+		if err != nil {
+			panic(err)
+		}
+		if err := f.Add(".github/workflows/"+n, src); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	have := []string{}
+	for _, d := range f.Find() {
+		have = append(have, d.Kind()+" "+d.Name+": "+d.Summary())
+		for _, v := range d.Versions {
+			for _, l := range v.Locations {
+				have = append(have, "  "+l.Path+":"+l.Pos.String()+":"+v.Ref)
+			}
+		}
+	}
+	// actions/setup-go is not reported since it is referenced at the same ref. Local actions, local
+	// reusable workflows, and Docker images are ignored.
+	want := []string{
+		`action actions/checkout: "v4" (2 places), "v3" (1 place)`,
+		"  .github/workflows/ci.yaml:line:6,col:15:v4",
+		"  .github/workflows/nightly.yaml:line:8,col:15:v4",
+		"  .github/workflows/release.yaml:line:8,col:15:v3",
+		`reusable workflow rhysd/workflows/.github/workflows/lint.yaml: "v1" (1 place), "main" (1 place)`,
+		"  .github/workflows/ci.yaml:line:10,col:11:v1",
+		"  .github/workflows/release.yaml:line:13,col:11:main",
+	}
+	if diff := cmp.Diff(want, have); diff != "" {
+		t.Fatal(diff)
+	}
+}
+
+func TestRefDriftFindNoDrift(t *testing.T) {
+	src := `on: push
+jobs:
+  a:
+    runs-on: ubuntu-latest
+    steps:
+      - uses: actions/checkout@v4
+      - uses: Actions/Checkout@v4
+      - uses: ${{ matrix.action }}@v1
+      - uses: ${{ matrix.action }}@v2
+`
+	f := NewRefDriftFinder()
+	if err := f.Add("test.yaml", []byte(src)); err != nil {
+		t.Fatal(err)
+	}
+	if ds := f.Find(); len(ds) > 0 {
+		t.Fatalf("unexpected drift: %s %q: %s", ds[0].Kind(), ds[0].Name, ds[0].Summary())
+	}
+}
+
+func TestRefDriftFindCaseInsensitive(t *testing.T) {
+	src := `on: push
+jobs:
+  a:
+    runs-on: ubuntu-latest
+    steps:
+      - uses: actions/checkout@v4
+      - uses: Actions/Checkout@v3
+`
+	f := NewRefDriftFinder()
+	if err := f.Add("test.yaml", []byte(src)); err != nil {
+		t.Fatal(err)
+	}
+	ds := f.Find()
+	if len(ds) != 1 {
+		t.Fatalf("wanted 1 drift but got %d drifts", len(ds))
+	}
+	if ds[0].Name != "actions/checkout" {
+		t.Fatalf("name of the first reference should be used but got %q", ds[0].Name)
+	}
+}
+
+func TestRefDriftAddParseError(t *testing.T) {
+	f := NewRefDriftFinder()
+	err := f.Add("test.yaml", []byte("{"))
+	if err == nil || !strings.Contains(err.Error(), `could not parse workflow "test.yaml"`) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,117 @@
+package actionlint
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// defaultRemoteCacheTTL is the default duration while the data fetched over network is used
+// without fetching it again.
+const defaultRemoteCacheTTL = 10 * time.Minute
+
+// remoteCacheEntry is the data fetched over network cached on disk.
+type remoteCacheEntry struct {
+	Body json.RawMessage `json:"body"`
+	// Next is the URL of the next page of the paginated response.
+	Next string `json:"next,omitempty"`
+	// Status is the HTTP status of the error response such as 404. Zero means the request succeeded.
+	// Caching error responses avoids requesting the resources which don't exist again and again.
+	Status int `json:"status,omitempty"`
+}
+
+// remoteCache caches the data fetched over network in online checks such as responses of GitHub
+// REST API on disk across runs. Entries older than the TTL are not used, but they are still used as
+// fallback when the network is not available. Calling methods of nil instance is allowed and does
+// nothing so that callers don't need to check whether the cache is enabled.
+type remoteCache struct {
+	dir string
+	ttl time.Duration
+	dbg io.Writer
+}
+
+// newRemoteCache creates a new cache in the directory. When the directory is empty, it returns nil.
+// When the ttl is zero, the default TTL (10 minutes) is used.
+func newRemoteCache(dir string, ttl time.Duration, dbg io.Writer) *remoteCache {
+	if dir == "" {
+		return nil
+	}
+	if ttl == 0 {
+		ttl = defaultRemoteCacheTTL
+	}
+	return &remoteCache{dir, ttl, dbg}
+}
+
+func (c *remoteCache) debug(format string, args ...interface{}) {
+	if c.dbg == nil {
+		return
+	}
+	format = "[RemoteCache] " + format + "\n"
+	fmt.Fprintf(c.dbg, format, args...)
+}
+
+func (c *remoteCache) path(key string) string {
+	h := sha256.Sum256([]byte(key))
+	return filepath.Join(c.dir, hex.EncodeToString(h[:])+".json")
+}
+
+// load returns the entry cached for the key like URL. When 'stale' is false, entries older than the
+// TTL are ignored.
+func (c *remoteCache) load(key string, stale bool) (*remoteCacheEntry, bool) {
+	if c == nil {
+		return nil, false
+	}
+	p := c.path(key)
+	s, err := os.Stat(p)
+	if err != nil || !stale && time.Since(s.ModTime()) > c.ttl {
+		return nil, false
+	}
+	b, err := os.ReadFile(p)
+	if err != nil {
+		return nil, false
+	}
+	var e remoteCacheEntry
+	if err := json.Unmarshal(b, &e); err != nil {
+		c.debug("Broken cache file %s is ignored: %v", p, err)
+		return nil, false
+	}
+	if stale {
+		c.debug("Use cached data for %s at %s which was fetched at %s", key, p, s.ModTime().Format(time.RFC3339))
+	} else {
+		c.debug("Use cached data for %s at %s", key, p)
+	}
+	return &e, true
+}
+
+// store stores the entry on disk. Failing to store is not an error since it only slows down the
+// next run.
+func (c *remoteCache) store(key string, e *remoteCacheEntry) {
+	if c == nil || !json.Valid(e.Body) {
+		return
+	}
+	b, err := json.Marshal(e)
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(c.dir, 0755); err != nil {
+		c.debug("Could not create cache directory %s: %v", c.dir, err)
+		return
+	}
+	p := c.path(key)
+	// Write to a temporary file and rename it so that a broken cache file is not read by other
+	// actionlint processes running at the same time
+	tmp := fmt.Sprintf("%s.%d.tmp", p, os.Getpid())
+	if err := os.WriteFile(tmp, b, 0644); err != nil {
+		c.debug("Could not write cache file %s: %v", tmp, err)
+		return
+	}
+	if err := os.Rename(tmp, p); err != nil {
+		c.debug("Could not write cache file %s: %v", p, err)
+		os.Remove(tmp)
+	}
+}
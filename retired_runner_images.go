@@ -0,0 +1,22 @@
+// Code generated by actionlint/scripts/generate-retired-runner-images. DO NOT EDIT.
+
+package actionlint
+
+// RetiredRunnerImages is a table of GitHub-hosted runner images which were retired. The keys are
+// the labels of the images at "runs-on:". This variable was generated by script at
+// ./scripts/generate-retired-runner-images based on ./scripts/generate-retired-runner-images/retired_runner_images.json
+var RetiredRunnerImages = map[string]*RetiredRunnerImage{
+	"macos-10.15":     {Replacement: "macos-15-intel", Retired: "2022-12-01"},
+	"macos-11":        {Replacement: "macos-15-intel", Retired: "2024-06-28"},
+	"macos-12":        {Replacement: "macos-15-intel", Retired: "2024-12-03"},
+	"macos-12-large":  {Replacement: "macos-15-large", Retired: "2024-12-03"},
+	"macos-12-xl":     {Replacement: "macos-15-large", Retired: "2024-12-03"},
+	"macos-13":        {Replacement: "macos-15-intel", Retired: "2025-12-04"},
+	"macos-13-large":  {Replacement: "macos-15-large", Retired: "2025-12-04"},
+	"macos-13-xlarge": {Replacement: "macos-14-xlarge", Retired: "2025-12-04"},
+	"ubuntu-16.04":    {Replacement: "ubuntu-22.04", Retired: "2021-09-20"},
+	"ubuntu-18.04":    {Replacement: "ubuntu-22.04", Retired: "2023-04-03"},
+	"ubuntu-20.04":    {Replacement: "ubuntu-22.04", Retired: "2025-04-15"},
+	"windows-2016":    {Replacement: "windows-2022", Retired: "2022-03-15"},
+	"windows-2019":    {Replacement: "windows-2022", Retired: "2025-06-30"},
+}
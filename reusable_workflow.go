@@ -3,8 +3,8 @@ package actionlint
 import (
 	"fmt"
 	"io"
-	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"sync"
 
@@ -170,8 +170,19 @@ type LocalReusableWorkflowCache struct {
 	mu    sync.RWMutex
 	proj  *Project // maybe nil
 	cache map[string]*ReusableWorkflowMetadata
-	cwd   string
-	dbg   io.Writer
+	calls map[string][]string // Local reusable workflows called by each workflow. This forms the call graph
+	// Comparisons of inputs with string literals in each workflow. Keys of the inner map are input
+	// names in lower case
+	comparisons map[string]map[string][]*reusableWorkflowInputComparison
+	cwd         string
+	dbg         io.Writer
+	// Names and specs of all workflows in the project. They are collected lazily by findWorkflowNames
+	namesOnce sync.Once
+	names     []string
+	workflows []string
+	// Name and events of each workflow. Keys are specs of the workflows
+	workflowNames  map[string]string
+	workflowEvents map[string][]string
 }
 
 func (c *LocalReusableWorkflowCache) debug(format string, args ...interface{}) {
@@ -215,12 +226,14 @@ func (c *LocalReusableWorkflowCache) FindMetadata(spec string) (*ReusableWorkflo
 	}
 
 	file := filepath.Join(c.proj.RootDir(), filepath.FromSlash(spec))
-	src, err := os.ReadFile(file)
+	src, err := c.proj.readFile(file)
 	if err != nil {
 		c.writeCache(spec, nil) // Remember the workflow file was not found
 		return nil, fmt.Errorf("could not read reusable workflow file for %q: %w", spec, err)
 	}
 
+	c.writeCalls(spec, parseLocalReusableWorkflowCalls(src))
+
 	m, err := parseReusableWorkflowMetadata(src)
 	if err != nil {
 		c.writeCache(spec, nil) // Remember the workflow file was invalid
@@ -233,11 +246,219 @@ func (c *LocalReusableWorkflowCache) FindMetadata(spec string) (*ReusableWorkflo
 	return m, nil
 }
 
+func (c *LocalReusableWorkflowCache) writeCalls(spec string, calls []string) {
+	c.mu.Lock()
+	if _, ok := c.calls[spec]; !ok {
+		c.calls[spec] = calls
+	}
+	c.mu.Unlock()
+}
+
+// findLocalCalls returns the local reusable workflows called by the workflow specified by 'spec'
+// such as "./.github/workflows/reusable.yaml". It returns nil when the workflow calls no local
+// reusable workflow or the workflow file could not be read.
+// Calling this method is thread-safe.
+func (c *LocalReusableWorkflowCache) findLocalCalls(spec string) []string {
+	if c.proj == nil || !strings.HasPrefix(spec, "./") || ContainsExpression(spec) {
+		return nil
+	}
+
+	c.mu.RLock()
+	calls, ok := c.calls[spec]
+	c.mu.RUnlock()
+	if ok {
+		return calls
+	}
+
+	file := filepath.Join(c.proj.RootDir(), filepath.FromSlash(spec))
+	src, err := c.proj.readFile(file)
+	if err != nil {
+		c.writeCalls(spec, nil)
+		return nil
+	}
+	calls = parseLocalReusableWorkflowCalls(src)
+	c.debug("Local reusable workflow calls in %s: %v", spec, calls)
+	c.writeCalls(spec, calls)
+	return calls
+}
+
+// findInputComparisons returns the comparisons of the inputs with string literals like
+// `inputs.env == 'prod'` in the local reusable workflow specified by 'spec'. Keys of the returned map
+// are input names in lower case. It returns nil when the workflow file could not be read.
+// Calling this method is thread-safe.
+func (c *LocalReusableWorkflowCache) findInputComparisons(spec string) map[string][]*reusableWorkflowInputComparison {
+	if c.proj == nil || !strings.HasPrefix(spec, "./") || ContainsExpression(spec) {
+		return nil
+	}
+
+	c.mu.RLock()
+	cmps, ok := c.comparisons[spec]
+	c.mu.RUnlock()
+	if ok {
+		return cmps
+	}
+
+	file := filepath.Join(c.proj.RootDir(), filepath.FromSlash(spec))
+	if src, err := c.proj.readFile(file); err == nil {
+		cmps = parseReusableWorkflowInputComparisons(src)
+		c.debug("Comparisons of inputs in %s: %v", spec, cmps)
+	}
+	c.mu.Lock()
+	c.comparisons[spec] = cmps
+	c.mu.Unlock()
+	return cmps
+}
+
+// WriteLocalCalls registers the local reusable workflows called by the jobs of the workflow to the
+// call graph. The 'wpath' parameter is a path to the workflow file of the AST, which is a relative
+// to the project root directory or an absolute path.
+// This method does nothing when (1) no project is set, (2) it could not convert the workflow path
+// to workflow call spec, (3) the calls of the workflow are already registered.
+// This method is thread safe.
+func (c *LocalReusableWorkflowCache) WriteLocalCalls(wpath string, jobs map[string]*Job) {
+	spec, ok := c.convWorkflowPathToSpec(wpath)
+	if !ok {
+		return
+	}
+
+	calls := []string{}
+	for _, j := range jobs {
+		if j.WorkflowCall != nil && j.WorkflowCall.Uses != nil && isWorkflowCallUsesLocalFormat(j.WorkflowCall.Uses.Value) && !j.WorkflowCall.Uses.ContainsExpression() {
+			calls = append(calls, j.WorkflowCall.Uses.Value)
+		}
+	}
+	slices.Sort(calls)
+	calls = slices.Compact(calls)
+
+	c.writeCalls(spec, calls)
+	c.debug("Local reusable workflow calls from workflow %s: %v", wpath, calls)
+}
+
+// findWorkflowNames returns the names of all workflows in the workflows directory of the project.
+// The name of a workflow without "name:" is its file path relative to the project root such as
+// ".github/workflows/ci.yaml" since GitHub uses the path as its name. The 'wpath' parameter is a path
+// to the workflow file being checked. It returns false when the workflow at 'wpath' is not in the
+// workflows directory or when the names could not be collected completely, for example, some workflow
+// file could not be parsed. The names are collected only once and reused.
+// Calling this method is thread-safe.
+func (c *LocalReusableWorkflowCache) findWorkflowNames(wpath string, platform Platform) ([]string, bool) {
+	if c.proj == nil {
+		return nil, false
+	}
+	c.collectWorkflowNamesOnce(platform)
+	if c.workflows == nil {
+		return nil, false
+	}
+	spec, ok := c.convWorkflowPathToSpec(wpath)
+	if !ok || !slices.Contains(c.workflows, spec) {
+		return nil, false
+	}
+	return c.names, true
+}
+
+func (c *LocalReusableWorkflowCache) collectWorkflowNamesOnce(platform Platform) {
+	c.namesOnce.Do(func() {
+		c.names, c.workflows = c.collectWorkflowNames(platform)
+		c.debug("Names of workflows in %v: %v", c.workflows, c.names)
+	})
+}
+
+// findSameNameWorkflows returns the name of the workflow at the path and the specs of other workflows
+// in the project which have the same name and are triggered by the event. It returns nil specs when
+// no such workflow is found or the workflows could not be collected.
+func (c *LocalReusableWorkflowCache) findSameNameWorkflows(wpath, event string, platform Platform) (string, []string) {
+	if c.proj == nil {
+		return "", nil
+	}
+	c.collectWorkflowNamesOnce(platform)
+	spec, ok := c.convWorkflowPathToSpec(wpath)
+	if !ok {
+		return "", nil
+	}
+	name, ok := c.workflowNames[spec]
+	if !ok {
+		return "", nil
+	}
+	var found []string
+	for _, w := range c.workflows {
+		if w != spec && c.workflowNames[w] == name && slices.Contains(c.workflowEvents[w], event) {
+			found = append(found, w)
+		}
+	}
+	return name, found
+}
+
+func (c *LocalReusableWorkflowCache) collectWorkflowNames(platform Platform) ([]string, []string) {
+	files, err := findYAMLFiles(c.proj.workflowsDirOf(platform), c.proj)
+	if err != nil {
+		c.debug("Could not find workflow files: %s", err)
+		return nil, nil
+	}
+
+	names := make([]string, 0, len(files))
+	specs := make([]string, 0, len(files))
+	c.workflowNames = make(map[string]string, len(files))
+	c.workflowEvents = make(map[string][]string, len(files))
+	for _, f := range files {
+		r, err := filepath.Rel(c.proj.RootDir(), f)
+		if err != nil {
+			return nil, nil // Unreachable
+		}
+		spec := "./" + filepath.ToSlash(r)
+		specs = append(specs, spec)
+
+		src, err := c.proj.readFile(f)
+		if err != nil {
+			return nil, nil
+		}
+		var w struct {
+			Name yaml.Node `yaml:"name"`
+			On   yaml.Node `yaml:"on"`
+		}
+		if err := yaml.Unmarshal(src, &w); err != nil {
+			c.debug("Could not parse name of workflow %s: %s", f, err)
+			return nil, nil
+		}
+		name := strings.TrimPrefix(spec, "./")
+		if w.Name.Kind == yaml.ScalarNode && w.Name.Value != "" {
+			name = w.Name.Value
+		}
+		names = append(names, name)
+		c.workflowNames[spec] = name
+		c.workflowEvents[spec] = workflowEventNames(&w.On)
+	}
+	slices.Sort(names)
+	return slices.Compact(names), specs
+}
+
+// workflowEventNames returns the event names at "on:" of the workflow. "on:" can be a string, a
+// sequence of strings, or a mapping whose keys are event names.
+func workflowEventNames(n *yaml.Node) []string {
+	switch n.Kind {
+	case yaml.ScalarNode:
+		return []string{n.Value}
+	case yaml.SequenceNode:
+		ns := make([]string, 0, len(n.Content))
+		for _, c := range n.Content {
+			ns = append(ns, c.Value)
+		}
+		return ns
+	case yaml.MappingNode:
+		ns := make([]string, 0, len(n.Content)/2)
+		for i := 0; i < len(n.Content); i += 2 {
+			ns = append(ns, n.Content[i].Value)
+		}
+		return ns
+	default:
+		return nil
+	}
+}
+
 func (c *LocalReusableWorkflowCache) convWorkflowPathToSpec(p string) (string, bool) {
 	if c.proj == nil {
 		return "", false
 	}
-	if !filepath.IsAbs(p) {
+	if !filepath.IsAbs(p) && c.proj.FS() == nil {
 		p = filepath.Join(c.cwd, p)
 	}
 	r := c.proj.RootDir()
@@ -320,6 +541,142 @@ func (c *LocalReusableWorkflowCache) WriteWorkflowCallEvent(wpath string, event
 	c.debug("Workflow call metadata from workflow %s: %v", wpath, m)
 }
 
+// parseLocalReusableWorkflowCalls parses the workflow source and returns the local reusable
+// workflows called at "jobs.<job_id>.uses". Errors are ignored since they are reported when the
+// workflow itself is linted.
+func parseLocalReusableWorkflowCalls(src []byte) []string {
+	var w struct {
+		Jobs map[string]yaml.Node `yaml:"jobs"`
+	}
+	if err := yaml.Unmarshal(src, &w); err != nil {
+		return nil
+	}
+
+	calls := []string{}
+	for _, j := range w.Jobs {
+		if j.Kind != yaml.MappingNode {
+			continue
+		}
+		for i := 0; i+1 < len(j.Content); i += 2 {
+			k, v := j.Content[i], j.Content[i+1]
+			if k.Value == "uses" && v.Kind == yaml.ScalarNode && isWorkflowCallUsesLocalFormat(v.Value) && !ContainsExpression(v.Value) {
+				calls = append(calls, v.Value)
+			}
+		}
+	}
+	slices.Sort(calls)
+	return slices.Compact(calls)
+}
+
+// reusableWorkflowInputComparison is a comparison of an input with a string literal like
+// `inputs.env == 'prod'` in a reusable workflow.
+type reusableWorkflowInputComparison struct {
+	// value is the string literal compared with the input.
+	value string
+	// pos is the position of the YAML value which contains the comparison.
+	pos *Pos
+}
+
+func (c *reusableWorkflowInputComparison) String() string {
+	return fmt.Sprintf("%q at %s", c.value, c.pos)
+}
+
+// parseReusableWorkflowInputComparisons parses the workflow source and collects comparisons of
+// inputs with string literals in the expressions. Conditions at "if:" are also parsed as expressions
+// even if they are not enclosed with ${{ }}. Errors are ignored since they are reported when the
+// workflow itself is linted.
+func parseReusableWorkflowInputComparisons(src []byte) map[string][]*reusableWorkflowInputComparison {
+	var n yaml.Node
+	if err := yaml.Unmarshal(src, &n); err != nil {
+		return nil
+	}
+
+	ret := map[string][]*reusableWorkflowInputComparison{}
+	add := func(e ExprNode, pos *Pos) {
+		VisitExprNode(e, func(n, _ ExprNode, entering bool) {
+			c, ok := n.(*CompareOpNode)
+			if !ok || !entering || c.Kind != CompareOpNodeKindEq && c.Kind != CompareOpNodeKindNotEq {
+				return
+			}
+			name, ok := inputNameOfExprNode(c.Left)
+			lit, lok := c.Right.(*StringNode)
+			if !ok || !lok {
+				name, ok = inputNameOfExprNode(c.Right)
+				lit, lok = c.Left.(*StringNode)
+			}
+			if ok && lok {
+				k := strings.ToLower(name)
+				ret[k] = append(ret[k], &reusableWorkflowInputComparison{lit.Value, pos})
+			}
+		})
+	}
+	parse := func(s string, pos *Pos) {
+		l := NewExprLexer(s)
+		e, err := NewExprParser().Parse(l)
+		l.Release()
+		if err == nil {
+			add(e, pos)
+		}
+	}
+
+	var walk func(n *yaml.Node, cond bool)
+	walk = func(n *yaml.Node, cond bool) {
+		switch n.Kind {
+		case yaml.DocumentNode, yaml.SequenceNode:
+			for _, c := range n.Content {
+				walk(c, false)
+			}
+		case yaml.MappingNode:
+			for i := 0; i+1 < len(n.Content); i += 2 {
+				walk(n.Content[i+1], n.Content[i].Value == "if")
+			}
+		case yaml.ScalarNode:
+			pos := &Pos{Line: n.Line, Col: n.Column}
+			s := n.Value
+			if cond && !strings.Contains(s, "${{") {
+				parse(s+"}}", pos) // }} is necessary since lexer lexes it as end of tokens
+				return
+			}
+			for {
+				i := strings.Index(s, "${{")
+				if i < 0 {
+					return
+				}
+				s = s[i+3:]
+				l := NewExprLexer(s)
+				e, err := NewExprParser().Parse(l)
+				o := l.Offset()
+				l.Release()
+				if err != nil {
+					return
+				}
+				add(e, pos)
+				s = s[o:]
+			}
+		}
+	}
+	walk(&n, false)
+	return ret
+}
+
+// inputNameOfExprNode returns the input name when the node accesses a property of "inputs" context
+// like `inputs.foo` or `inputs['foo']`.
+func inputNameOfExprNode(n ExprNode) (string, bool) {
+	switch n := n.(type) {
+	case *ObjectDerefNode:
+		if v, ok := n.Receiver.(*VariableNode); ok && strings.EqualFold(v.Name, "inputs") {
+			return n.Property, true
+		}
+	case *IndexAccessNode:
+		if v, ok := n.Operand.(*VariableNode); ok && strings.EqualFold(v.Name, "inputs") {
+			if s, ok := n.Index.(*StringNode); ok {
+				return s.Value, true
+			}
+		}
+	}
+	return "", false
+}
+
 func parseReusableWorkflowMetadata(src []byte) (*ReusableWorkflowMetadata, error) {
 	type workflow struct {
 		On yaml.Node `yaml:"on"`
@@ -372,10 +729,12 @@ func parseReusableWorkflowMetadata(src []byte) (*ReusableWorkflowMetadata, error
 // the cache instance is project-local. It is not available across multiple projects.
 func NewLocalReusableWorkflowCache(proj *Project, cwd string, dbg io.Writer) *LocalReusableWorkflowCache {
 	return &LocalReusableWorkflowCache{
-		proj:  proj,
-		cache: map[string]*ReusableWorkflowMetadata{},
-		cwd:   cwd,
-		dbg:   dbg,
+		proj:        proj,
+		cache:       map[string]*ReusableWorkflowMetadata{},
+		calls:       map[string][]string{},
+		comparisons: map[string]map[string][]*reusableWorkflowInputComparison{},
+		cwd:         cwd,
+		dbg:         dbg,
 	}
 }
 
@@ -356,7 +356,7 @@ var testReusableWorkflowWantedMetadata *ReusableWorkflowMetadata = &ReusableWork
 }
 
 func TestReusableWorkflowCacheFindMetadataOK(t *testing.T) {
-	proj := &Project{filepath.Join("testdata", "reusable_workflow_metadata"), nil}
+	proj := &Project{filepath.Join("testdata", "reusable_workflow_metadata"), nil, nil, nil}
 	c := NewLocalReusableWorkflowCache(proj, "", nil)
 
 	m, err := c.FindMetadata("./ok.yaml")
@@ -416,7 +416,7 @@ func TestReusableWorkflowCacheFindMetadataError(t *testing.T) {
 
 	for _, tc := range tests {
 		t.Run(tc.what, func(t *testing.T) {
-			proj := &Project{filepath.Join("testdata", "reusable_workflow_metadata"), nil}
+			proj := &Project{filepath.Join("testdata", "reusable_workflow_metadata"), nil, nil, nil}
 			c := NewLocalReusableWorkflowCache(proj, "", nil)
 			_, err := c.FindMetadata(tc.spec)
 			if err == nil {
@@ -439,7 +439,7 @@ func TestReusableWorkflowCacheFindMetadataError(t *testing.T) {
 }
 
 func TestReusableWorkflowCacheFindMetadataSkipParsing(t *testing.T) {
-	p := &Project{filepath.Join("testdata", "reusable_workflow_metadata"), nil}
+	p := &Project{filepath.Join("testdata", "reusable_workflow_metadata"), nil, nil, nil}
 	tests := []struct {
 		what string
 		proj *Project
@@ -484,7 +484,7 @@ func TestReusableWorkflowCacheFindMetadataSkipParsing(t *testing.T) {
 }
 
 func TestReusableWorkflowConvertWorkflowPathToSpec(t *testing.T) {
-	p := &Project{filepath.Join("path", "to", "project"), nil}
+	p := &Project{filepath.Join("path", "to", "project"), nil, nil, nil}
 	cwd := filepath.Join("path", "to", "project", "cwd")
 	tests := []struct {
 		what string
@@ -521,7 +521,7 @@ func TestReusableWorkflowConvertWorkflowPathToSpec(t *testing.T) {
 		},
 		{
 			what: "other project",
-			proj: &Project{filepath.Join("path", "to", "other-project"), nil},
+			proj: &Project{filepath.Join("path", "to", "other-project"), nil, nil, nil},
 			ok:   false,
 		},
 	}
@@ -614,7 +614,7 @@ func TestReusableWorkflowMetadataFromASTNodeInputs(t *testing.T) {
 	for _, tc := range tests {
 		t.Run(tc.what, func(t *testing.T) {
 			cwd := filepath.Join("path", "to", "project")
-			proj := &Project{cwd, nil}
+			proj := &Project{cwd, nil, nil, nil}
 			c := NewLocalReusableWorkflowCache(proj, cwd, nil)
 			e := &WorkflowCallEvent{Inputs: []*WorkflowCallEventInput{}}
 			for n, i := range tc.inputs {
@@ -653,7 +653,7 @@ func TestReusableWorkflowMetadataFromASTNodeOutputs(t *testing.T) {
 	for _, outputs := range tests {
 		t.Run(fmt.Sprintf("%s", outputs), func(t *testing.T) {
 			cwd := filepath.Join("path", "to", "project")
-			proj := &Project{cwd, nil}
+			proj := &Project{cwd, nil, nil, nil}
 			c := NewLocalReusableWorkflowCache(proj, cwd, nil)
 			e := &WorkflowCallEvent{Outputs: map[string]*WorkflowCallEventOutput{}}
 			for _, o := range outputs {
@@ -708,7 +708,7 @@ func TestReusableWorkflowMetadataFromASTNodeSecrets(t *testing.T) {
 	for _, secrets := range tests {
 		t.Run(fmt.Sprintf("%s", secrets), func(t *testing.T) {
 			cwd := filepath.Join("path", "to", "project")
-			proj := &Project{cwd, nil}
+			proj := &Project{cwd, nil, nil, nil}
 			c := NewLocalReusableWorkflowCache(proj, cwd, nil)
 			e := &WorkflowCallEvent{Secrets: map[string]*WorkflowCallEventSecret{}}
 			for n, r := range secrets {
@@ -755,7 +755,7 @@ func TestReusableWorkflowMetadataFromASTNodeDoNothing(t *testing.T) {
 		t.Fatal("Metadata created:", m)
 	}
 
-	proj := &Project{cwd, nil}
+	proj := &Project{cwd, nil, nil, nil}
 	c = NewLocalReusableWorkflowCache(proj, filepath.Join("path", "to", "another-project"), nil)
 	c.WriteWorkflowCallEvent("workflow.yaml", &WorkflowCallEvent{})
 	m, ok = c.readCache("./workflow.yaml")
@@ -779,7 +779,7 @@ func TestReusableWorkflowMetadataFromASTNodeDoNothing(t *testing.T) {
 func TestReusableWorkflowMetadataCacheFindOneMetadataConcurrently(t *testing.T) {
 	n := 10
 	cwd := filepath.Join("testdata", "reusable_workflow_metadata")
-	proj := &Project{cwd, nil}
+	proj := &Project{cwd, nil, nil, nil}
 	c := NewLocalReusableWorkflowCache(proj, cwd, nil)
 	ret := make(chan *ReusableWorkflowMetadata)
 	err := make(chan error)
@@ -831,7 +831,7 @@ func TestReusableWorkflowMetadataCacheFindOneMetadataConcurrently(t *testing.T)
 func TestReusableWorkflowMetadataCacheWriteFromFileAndASTNodeConcurrently(t *testing.T) {
 	n := 10
 	cwd := filepath.Join("testdata", "reusable_workflow_metadata")
-	proj := &Project{cwd, nil}
+	proj := &Project{cwd, nil, nil, nil}
 	c := NewLocalReusableWorkflowCache(proj, cwd, nil)
 	ret := make(chan struct{})
 	err := make(chan error)
@@ -901,10 +901,10 @@ func TestReusableWorkflowCacheFactory(t *testing.T) {
 	cwd := filepath.Join("path", "to", "project1")
 	f := NewLocalReusableWorkflowCacheFactory(cwd, nil)
 
-	p1 := &Project{cwd, nil}
+	p1 := &Project{cwd, nil, nil, nil}
 	c1 := f.GetCache(p1)
 
-	p2 := &Project{filepath.Join("path", "to", "project2"), nil}
+	p2 := &Project{filepath.Join("path", "to", "project2"), nil, nil, nil}
 	c2 := f.GetCache(p2)
 	if c1 == c2 {
 		t.Errorf("Different cache was not created: %v", c1)
@@ -920,3 +920,33 @@ func TestReusableWorkflowCacheFactory(t *testing.T) {
 		t.Errorf("Null cache should be returned when project is nil: %v", c4)
 	}
 }
+
+func TestReusableWorkflowParseInputComparisons(t *testing.T) {
+	src := `on: workflow_call
+jobs:
+  test:
+    if: inputs.env == 'prod' && 'us' != inputs['Region']
+    runs-on: ubuntu-latest
+    steps:
+      - run: echo ${{ inputs.env == 'dev' }} ${{ github.ref == 'main' }}
+        if: ${{ inputs.debug }}
+      - run: echo inputs.env == 'not-expression'
+`
+	have := map[string][]string{}
+	for n, cs := range parseReusableWorkflowInputComparisons([]byte(src)) {
+		for _, c := range cs {
+			have[n] = append(have[n], c.String())
+		}
+	}
+	want := map[string][]string{
+		"env":    {`"prod" at line:4,col:9`, `"dev" at line:7,col:14`},
+		"region": {`"us" at line:4,col:9`},
+	}
+	if diff := cmp.Diff(want, have); diff != "" {
+		t.Fatal(diff)
+	}
+
+	if cs := parseReusableWorkflowInputComparisons([]byte("{")); cs != nil {
+		t.Fatalf("comparisons should not be returned for broken YAML: %v", cs)
+	}
+}
@@ -3,6 +3,7 @@ package actionlint
 import (
 	"fmt"
 	"io"
+	"sync"
 )
 
 // RuleBase is a struct to be a base of rule structs. Embed this struct to define default methods
@@ -11,6 +12,7 @@ type RuleBase struct {
 	name   string
 	desc   string
 	errs   []*Error
+	fixes  []*TextEdit
 	dbg    io.Writer
 	config *Config
 }
@@ -53,6 +55,35 @@ func (r *RuleBase) Errorf(pos *Pos, format string, args ...interface{}) {
 	r.errs = append(r.errs, err)
 }
 
+// ErrorfWithFixes reports a new error with the formatted error message and the edits to fix it. The
+// edits are attached to the error and applied when fixing errors is enabled by LinterOptions.Fix.
+func (r *RuleBase) ErrorfWithFixes(pos *Pos, fixes []*TextEdit, format string, args ...interface{}) {
+	err := errorfAt(pos, r.name, format, args...)
+	err.Fixes = fixes
+	r.errs = append(r.errs, err)
+}
+
+// ErrorfWithRelated reports a new error with the formatted error message and the locations related
+// to the error such as the location where the duplicated name was previously defined.
+func (r *RuleBase) ErrorfWithRelated(pos *Pos, related []*RelatedLocation, format string, args ...interface{}) {
+	err := errorfAt(pos, r.name, format, args...)
+	err.Related = related
+	r.errs = append(r.errs, err)
+}
+
+// Report stores the given error in the rule instance. Kind of the error is set to the rule name. This
+// method is useful to report an error with structured fields such as Code and Severity.
+func (r *RuleBase) Report(err *Error) {
+	err.Kind = r.name
+	r.errs = append(r.errs, err)
+}
+
+// Fix adds a new edit to fix an issue in the source. The edits can be accessed by Fixes method. They
+// are applied to the file when fixing errors is enabled by LinterOptions.Fix.
+func (r *RuleBase) Fix(edit *TextEdit) {
+	r.fixes = append(r.fixes, edit)
+}
+
 // Debug prints debug log to the output. The output is specified by the argument of EnableDebug method.
 // By default, no output is set so debug log is not printed.
 func (r *RuleBase) Debug(format string, args ...interface{}) {
@@ -68,6 +99,11 @@ func (r *RuleBase) Errs() []*Error {
 	return r.errs
 }
 
+// Fixes returns edits added by Fix method. Edits attached to errors are not included.
+func (r *RuleBase) Fixes() []*TextEdit {
+	return r.fixes
+}
+
 // Name returns the name of the rule.
 func (r *RuleBase) Name() string {
 	return r.name
@@ -106,3 +142,30 @@ type Rule interface {
 	SetConfig(cfg *Config)
 	Config() *Config
 }
+
+var (
+	customRulesMu sync.Mutex
+	customRules   []func() Rule
+)
+
+// RegisterRule registers the constructor of a custom rule. The custom rule is run by all Linter
+// instances alongside the built-in rules. Since rules have states while checking a workflow, the
+// constructor is called to create a new rule instance on checking each workflow file. The name of
+// the rule is used as the kind of its errors so it should not conflict with the built-in rules.
+// This function is usually called in init() function of your package. To add a custom rule to
+// the specific Linter instance, use Linter.AddRule instead.
+func RegisterRule(newRule func() Rule) {
+	if newRule == nil {
+		panic("actionlint: constructor of custom rule passed to RegisterRule is nil")
+	}
+	customRulesMu.Lock()
+	defer customRulesMu.Unlock()
+	customRules = append(customRules, newRule)
+}
+
+// registeredRules returns the constructors of custom rules registered by RegisterRule.
+func registeredRules() []func() Rule {
+	customRulesMu.Lock()
+	defer customRulesMu.Unlock()
+	return append([]func() Rule(nil), customRules...)
+}
@@ -0,0 +1,182 @@
+package actionlint
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Positions in error messages of act are in "Line: 6 Column 5" format (workflow schema errors) or in
+// "line 6" format (YAML syntax errors).
+var (
+	actLineColumnPattern = regexp.MustCompile(`Line: (\d+) Column (\d+): `)
+	actYAMLLinePattern   = regexp.MustCompile(`\byaml: line (\d+): `)
+	// Failures of steps in dry-run are logged like "[CI/test]   ❌  Failure - Main actions/checkout@v4 [1.2ms]"
+	actStepFailurePattern = regexp.MustCompile(`^\[([^\]]+)\]\s+❌\s+Failure - (.+?)(?:\s+\[[^\]]+\])?$`)
+	// act outputs "Error: Job 'test' failed" after the failures of the job
+	actJobFailedPattern = regexp.MustCompile(`^Job '(.+)' failed$`)
+)
+
+type actError struct {
+	line    int
+	column  int
+	message string
+	// job is the label of the job which failed like "CI/test". It is empty when the error is not
+	// related to a specific job.
+	job string
+}
+
+// parseActErrors parses the output of act and extracts errors. act outputs a fatal error with
+// "Error: " prefix and a failure of step with "❌" mark.
+func parseActErrors(out []byte) []*actError {
+	errs := []*actError{}
+	s := bufio.NewScanner(bytes.NewReader(out))
+	for s.Scan() {
+		l := strings.TrimSpace(s.Text())
+		if m := actStepFailurePattern.FindStringSubmatch(l); m != nil {
+			errs = append(errs, &actError{message: fmt.Sprintf("step %q failed", m[2]), job: m[1]})
+			continue
+		}
+		msg, ok := strings.CutPrefix(l, "Error: ")
+		if !ok {
+			continue
+		}
+		if m := actJobFailedPattern.FindStringSubmatch(msg); m != nil {
+			// The failure was already reported with the failed step
+			if !slices.ContainsFunc(errs, func(e *actError) bool { return actJobLabelMatches(e.job, m[1]) }) {
+				errs = append(errs, &actError{message: msg, job: m[1]})
+			}
+			continue
+		}
+		e := &actError{message: msg}
+		if ms := actLineColumnPattern.FindAllStringSubmatchIndex(msg, -1); ms != nil {
+			// Nested errors are reported like "Line: 3 Column 3: Failed to match job-factory: Line: 4
+			// Column 5: Unknown Property nedds". The innermost one is the most precise.
+			m := ms[len(ms)-1]
+			e.line, _ = strconv.Atoi(msg[m[2]:m[3]])
+			e.column, _ = strconv.Atoi(msg[m[4]:m[5]])
+			e.message = msg[m[1]:]
+		} else if m := actYAMLLinePattern.FindStringSubmatch(msg); m != nil {
+			e.line, _ = strconv.Atoi(m[1])
+		}
+		errs = append(errs, e)
+	}
+	return errs
+}
+
+// RuleAct is a rule to check workflows by running them with act in dry-run mode. It surfaces errors
+// which static analysis alone cannot catch such as unresolvable jobs or actions. This rule is
+// disabled by default and enabled by "-act" flag.
+// https://github.com/nektos/act
+type RuleAct struct {
+	RuleBase
+	cmd     *externalCommand
+	path    string
+	content []byte
+	// dir is a temporary directory to put the workflow file while act is running.
+	dir string
+	mu  sync.Mutex
+}
+
+func newRuleAct(cmd *externalCommand, path string, content []byte) *RuleAct {
+	return &RuleAct{
+		RuleBase: RuleBase{
+			name: "act",
+			desc: "Checks for errors while running workflows in dry-run mode with nektos/act",
+		},
+		cmd:     cmd,
+		path:    path,
+		content: content,
+	}
+}
+
+// NewRuleAct creates new RuleAct instance to check the workflow file at the path with the content.
+// Parameter executable can be command name or relative/absolute file path. When the given executable
+// is not found in system, it returns an error.
+func NewRuleAct(executable string, proc *concurrentProcess, path string, content []byte) (*RuleAct, error) {
+	// Combine output because act outputs errors to stderr
+	cmd, err := proc.newCommandRunner(executable, true)
+	if err != nil {
+		return nil, err
+	}
+	return newRuleAct(cmd, path, content), nil
+}
+
+// VisitWorkflowPre is callback when visiting Workflow node before visiting its children.
+func (rule *RuleAct) VisitWorkflowPre(n *Workflow) error {
+	// act reads workflows only from files. Write the content to a temporary file since the workflow
+	// may be read from stdin or may not be saved yet.
+	d, f, err := writeWorkflowToTempFile(rule.path, rule.content)
+	if err != nil {
+		return fmt.Errorf("could not prepare workflow file to run act: %w", err)
+	}
+	rule.dir = d
+
+	// --dryrun plans and runs the jobs without creating any container. --detect-event runs the jobs
+	// triggered by the first event in "on:" instead of "push" event.
+	args := []string{"--dryrun", "--detect-event", "--workflows", f}
+	rule.Debug("Running %s command with %s", rule.cmd.exe, args)
+
+	rule.cmd.run(args, "", func(out []byte, err error) error {
+		if err != nil {
+			rule.Debug("Command %s %s failed: %v", rule.cmd.exe, args, err)
+			return fmt.Errorf("`%s %s` did not run successfully while checking workflow %s: %w", rule.cmd.exe, strings.Join(args, " "), rule.path, err)
+		}
+
+		errs := parseActErrors(out)
+		if len(errs) == 0 {
+			return nil
+		}
+
+		// Synchronize rule.Errorf calls
+		rule.mu.Lock()
+		defer rule.mu.Unlock()
+		for _, e := range errs {
+			pos := &Pos{Line: max(e.line, 1), Col: max(e.column, 1)}
+			if e.job != "" {
+				if j := findActJob(n, e.job); j != nil {
+					pos = j.Pos
+				}
+			}
+			msg := strings.ReplaceAll(e.message, f, rule.path) // Replace the temporary file path
+			rule.Errorf(pos, "act reported an error while running workflow in dry-run mode: %s", strings.TrimSuffix(msg, "."))
+		}
+		return nil
+	})
+
+	return nil
+}
+
+// findActJob finds the job from the label of job in outputs of act. The label is in "{workflow}/{job}"
+// format where {workflow} is the workflow name and {job} is the job name or the job ID.
+func findActJob(w *Workflow, label string) *Job {
+	for _, j := range w.Jobs {
+		if j.ID != nil && actJobLabelMatches(label, j.ID.Value) {
+			return j
+		}
+		if j.Name != nil && actJobLabelMatches(label, j.Name.Value) {
+			return j
+		}
+	}
+	return nil
+}
+
+func actJobLabelMatches(label, job string) bool {
+	return label != "" && (label == job || strings.HasSuffix(label, "/"+job))
+}
+
+// VisitWorkflowPost is callback when visiting Workflow node after visiting its children.
+func (rule *RuleAct) VisitWorkflowPost(n *Workflow) error {
+	err := rule.cmd.wait() // Wait until the process running for this rule finishes
+	if rule.dir != "" {
+		os.RemoveAll(rule.dir)
+		rule.dir = ""
+	}
+	return err
+}
@@ -0,0 +1,166 @@
+package actionlint
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestRuleActParseErrors(t *testing.T) {
+	tests := []struct {
+		what   string
+		output string
+		want   []actError
+	}{
+		{
+			what:   "no error",
+			output: "Stage  Job ID  Job name  Workflow name  Workflow file  Events\n0      test    test      CI             test.yaml      push\n",
+			want:   []actError{},
+		},
+		{
+			what:   "schema error",
+			output: "Error: workflow is not valid. 'test.yaml': Line: 3 Column 3: Failed to match job-factory: Line: 5 Column 5: Unknown Property nedds\n",
+			want:   []actError{{5, 5, "Unknown Property nedds", ""}},
+		},
+		{
+			what:   "yaml error",
+			output: "time=\"2024-01-01T00:00:00Z\" level=warning msg=\"unable to get git repo\"\nError: yaml: line 4: mapping values are not allowed in this context\n",
+			want:   []actError{{4, 0, "yaml: line 4: mapping values are not allowed in this context", ""}},
+		},
+		{
+			what:   "error without position",
+			output: "Error: unable to build dependency graph for test.yaml: job 'build' not found\n",
+			want:   []actError{{0, 0, "unable to build dependency graph for test.yaml: job 'build' not found", ""}},
+		},
+		{
+			what:   "step failure",
+			output: "*DRYRUN* [CI/test] ⭐ Run Main actions/checkoutt@v4\n[CI/test]   ❌  Failure - Main actions/checkoutt@v4 [1.2ms]\nError: Job 'test' failed\n",
+			want:   []actError{{0, 0, `step "Main actions/checkoutt@v4" failed`, "CI/test"}},
+		},
+		{
+			what:   "job failure",
+			output: "Error: Job 'test' failed\n",
+			want:   []actError{{0, 0, "Job 'test' failed", "test"}},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.what, func(t *testing.T) {
+			errs := parseActErrors([]byte(tc.output))
+			if len(errs) != len(tc.want) {
+				t.Fatalf("wanted %d errors but got %d: %v", len(tc.want), len(errs), errs)
+			}
+			for i, want := range tc.want {
+				if *errs[i] != want {
+					t.Errorf("wanted %+v but got %+v", want, *errs[i])
+				}
+			}
+		})
+	}
+}
+
+func TestRuleActRunCommand(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake act command is a shell script")
+	}
+
+	// Fake act command outputs an error with the workflow file path given to --workflows
+	exe := filepath.Join(t.TempDir(), "act")
+	script := `#!/bin/sh
+[ "$1" = "--dryrun" ] && [ "$2" = "--detect-event" ] && [ "$3" = "--workflows" ] || exit 2
+if grep -q nedds "$4"; then
+  echo "Error: workflow is not valid. '$4': Line: 3 Column 3: Failed to match job-factory: Line: 5 Column 5: Unknown Property nedds" >&2
+  exit 1
+fi
+if grep -q checkoutt "$4"; then
+  echo "[CI/test]   ❌  Failure - Main actions/checkoutt@v4 [1.2ms]"
+  echo "Error: Job 'test' failed" >&2
+  exit 1
+fi
+`
+	if err := os.WriteFile(exe, []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	l, err := NewLinter(io.Discard, &LinterOptions{Act: exe, Shellcheck: "", Pyflakes: ""})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	src := "on: push\njobs:\n  test:\n    runs-on: ubuntu-latest\n    nedds: []\n    steps:\n      - run: echo\n"
+	errs, err := l.Lint("test.yaml", []byte(src), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var found *Error
+	for _, e := range errs {
+		if e.Kind == "act" {
+			if found != nil {
+				t.Fatalf("act error was reported twice: %v", errs)
+			}
+			found = e
+		}
+	}
+	if found == nil {
+		t.Fatalf("act error was not reported: %v", errs)
+	}
+	if found.Line != 5 || found.Column != 5 {
+		t.Errorf("wanted position 5:5 but got %d:%d", found.Line, found.Column)
+	}
+	if want := "act reported an error while running workflow in dry-run mode: Unknown Property nedds"; found.Message != want {
+		t.Errorf("wanted message %q but got %q", want, found.Message)
+	}
+
+	// Failure of step is reported at the failed job
+	src = "on: push\nname: CI\njobs:\n  build:\n    runs-on: ubuntu-latest\n    steps:\n      - run: echo\n  test:\n    runs-on: ubuntu-latest\n    steps:\n      - uses: actions/checkoutt@v4\n"
+	errs, err = l.Lint("test.yaml", []byte(src), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	found = nil
+	for _, e := range errs {
+		if e.Kind == "act" {
+			if found != nil {
+				t.Fatalf("act error was reported twice: %v", errs)
+			}
+			found = e
+		}
+	}
+	if found == nil {
+		t.Fatalf("act error was not reported: %v", errs)
+	}
+	if found.Line != 8 || found.Column != 3 {
+		t.Errorf("wanted position 8:3 but got %d:%d", found.Line, found.Column)
+	}
+	if want := `act reported an error while running workflow in dry-run mode: step "Main actions/checkoutt@v4" failed`; found.Message != want {
+		t.Errorf("wanted message %q but got %q", want, found.Message)
+	}
+
+	src = "on: push\njobs:\n  test:\n    runs-on: ubuntu-latest\n    steps:\n      - run: echo\n"
+	errs, err = l.Lint("test.yaml", []byte(src), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(errs) != 0 {
+		t.Fatalf("no error should be reported: %v", errs)
+	}
+}
+
+func TestRuleActNotFound(t *testing.T) {
+	l, err := NewLinter(io.Discard, &LinterOptions{Act: "this-command-does-not-exist-for-test"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	errs, err := l.Lint("test.yaml", []byte("on: push\njobs:\n  test:\n    runs-on: ubuntu-latest\n    steps:\n      - run: echo\n"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, e := range errs {
+		if strings.Contains(e.Kind, "act") {
+			t.Fatalf("act rule should be disabled: %v", e)
+		}
+	}
+}
@@ -3,12 +3,14 @@ package actionlint
 import (
 	"errors"
 	"fmt"
-	"net/url"
+	"maps"
 	"os"
 	"path/filepath"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
+	"unicode/utf8"
 )
 
 // BrandingColors is a set of colors allowed at branding.color in action.yaml.
@@ -287,6 +289,16 @@ var BrandingIcons = map[string]struct{}{
 	"zoom-out":           {},
 }
 
+// brandingHint returns the hint for the incorrect icon name or color at branding. It suggests the
+// names in the set which are similar to the name. It returns an empty string when no similar name
+// is found.
+func brandingHint(name string, set map[string]struct{}) string {
+	if s := similarNames(name, slices.Sorted(maps.Keys(set))); len(s) > 0 {
+		return " did you mean " + quotes(s) + "?"
+	}
+	return ""
+}
+
 // https://docs.github.com/en/actions/creating-actions/metadata-syntax-for-github-actions#runsimage
 func isImageOnDockerRegistry(image string) bool {
 	return strings.HasPrefix(image, "docker://") ||
@@ -301,6 +313,26 @@ func isImageOnDockerRegistry(image string) bool {
 type RuleAction struct {
 	RuleBase
 	cache *LocalActionsCache
+	// remote is the GitHub repository for online checks. It is nil when online checks are disabled.
+	remote *RemoteRepository
+	// registry is the client of Docker registries for online checks. It is nil when online checks are
+	// disabled.
+	registry *dockerRegistryClient
+	// credentials is true when credentials of Docker registries at "docker-registries" in config can
+	// be used. The config may choose any host and environment variable so they are only used when
+	// the config is trusted.
+	credentials bool
+	// platform is the platform which runs the workflow. Gitea Actions and Forgejo Actions accept
+	// actions specified with absolute URLs.
+	platform Platform
+	// pin is true when refs of third-party actions should be pinned to commit SHAs. This requires
+	// online checks.
+	pin bool
+	// lines is the lines of the workflow source. It is used to edit "uses:" on pinning actions.
+	lines []string
+	// metadata is the action metadata read from the files at "action-metadata" in config. It is nil
+	// when no file is set.
+	metadata *ActionMetadataSet
 }
 
 // NewRuleAction creates new RuleAction instance.
@@ -339,10 +371,43 @@ func (rule *RuleAction) VisitStep(n *Step) error {
 		return nil
 	}
 
+	if rule.platform.giteaCompatible() {
+		if host, s, ok := cutActionURL(spec); ok {
+			rule.checkActionURL(spec, host, s, e)
+			return nil
+		}
+	}
+
 	rule.checkRepoAction(spec, e)
 	return nil
 }
 
+// cutActionURL cuts the action specified with absolute URL like "https://gitea.com/owner/repo@ref"
+// into the host and the rest part like "owner/repo@ref".
+func cutActionURL(spec string) (string, string, bool) {
+	for _, p := range []string{"https://", "http://"} {
+		if s, ok := strings.CutPrefix(spec, p); ok {
+			return strings.Cut(s, "/")
+		}
+	}
+	return "", "", false
+}
+
+// checkActionURL checks the action specified with absolute URL. Gitea Actions and Forgejo Actions
+// can fetch actions from any Git hosting service with the URL.
+// https://docs.gitea.com/usage/actions/design#act-runner
+func (rule *RuleAction) checkActionURL(spec, host, s string, exec *ExecAction) {
+	if _, _, _, _, ok := parseRepoActionSpec(s); !ok || host == "" {
+		rule.Errorf(exec.Uses.Pos, "specifying action %q in invalid format. available formats of action URL are \"https://{host}/{owner}/{repo}@{ref}\" or \"https://{host}/{owner}/{repo}/{path}@{ref}\"", spec)
+		return
+	}
+	if host == "github.com" {
+		rule.checkRepoAction(s, exec) // Actions on GitHub can be checked in the same way as "{owner}/{repo}@{ref}"
+		return
+	}
+	rule.Debug("Skip checking action at %q since it is hosted on %q", spec, host)
+}
+
 // Parse {owner}/{repo}@{ref} or {owner}/{repo}/{path}@{ref}
 func (rule *RuleAction) checkRepoAction(spec string, exec *ExecAction) {
 	s := spec
@@ -353,6 +418,7 @@ func (rule *RuleAction) checkRepoAction(spec string, exec *ExecAction) {
 	}
 	ref := s[idx+1:]
 	s = s[:idx] // remove {ref}
+	name := s
 
 	idx = strings.IndexRune(s, '/')
 	if idx == -1 {
@@ -372,13 +438,30 @@ func (rule *RuleAction) checkRepoAction(spec string, exec *ExecAction) {
 		rule.invalidActionFormat(exec.Uses.Pos, spec, "owner and repo and ref should not be empty")
 	}
 
-	meta, ok := PopularActions[spec]
+	deprecated := rule.checkDeprecatedAction(spec, name, exec.Uses.Pos)
+
+	meta, ok := knownPopularActions()[spec]
+	if !ok {
+		meta, ok = rule.metadata.metadata(spec)
+	}
+	if !ok && rule.remote != nil && owner != "" && repo != "" && ref != "" {
+		if !checkRemoteRef(&rule.RuleBase, rule.remote, "action", spec, owner, repo, ref, exec.Uses.Pos) {
+			return
+		}
+	}
+	if rule.pin && owner != "" && repo != "" && ref != "" {
+		rule.pinAction(spec, owner, repo, ref, exec.Uses)
+	}
 	if !ok {
-		if _, ok := OutdatedPopularActionSpecs[spec]; ok {
+		if _, ok := OutdatedPopularActionSpecs[spec]; ok || rule.metadata.outdated(spec) {
+			if deprecated {
+				return // The deprecation was already reported with its replacement
+			}
 			rule.Errorf(exec.Uses.Pos, "the runner of %q action is too old to run on GitHub Actions. update the action's version to fix this issue", spec)
 			return
 		}
 		rule.Debug("This action is not found in popular actions data set: %s", spec)
+		rule.checkRemoteAction(spec, exec)
 		return
 	}
 	if meta.SkipInputs {
@@ -391,6 +474,143 @@ func (rule *RuleAction) checkRepoAction(spec string, exec *ExecAction) {
 	})
 }
 
+// pinAction adds the edits to pin the ref of the third-party action at "uses:" to the full commit
+// SHA like "owner/repo@<sha> # v1.2.3". The original ref is kept as a comment since SHAs are not
+// human-readable. Actions owned by GitHub are trusted and not pinned.
+func (rule *RuleAction) pinAction(spec, owner, repo, ref string, uses *String) {
+	if owner == "actions" || owner == "github" || fullCommitSHAPattern.MatchString(ref) {
+		return
+	}
+	line := uses.Pos.Line
+	if line < 1 || len(rule.lines) < line {
+		return
+	}
+	src := []rune(rule.lines[line-1])
+
+	// The ref is at the end of the value of "uses:"
+	start := uses.Pos.Col + utf8.RuneCountInString(uses.Value) - utf8.RuneCountInString(ref)
+	if uses.Quoted {
+		start++
+	}
+	end := start + utf8.RuneCountInString(ref)
+	if start < 1 || end-1 > len(src) || string(src[start-1:end-1]) != ref {
+		// The value is written in multiple lines or contains escapes in quoted string
+		rule.Debug("Could not pin action %q since its ref was not found in the source at line %d", spec, line)
+		return
+	}
+
+	sha, err := rule.remote.commitSHA(owner, repo, ref)
+	if err != nil {
+		rule.Debug("Could not resolve ref of action %q to commit SHA: %v", spec, err)
+		return
+	}
+
+	rule.Debug("Pin action %q to commit %s", spec, sha)
+	rule.Fix(&TextEdit{&Pos{Line: line, Col: start}, &Pos{Line: line, Col: end}, sha})
+
+	if uses.Quoted {
+		end++
+	}
+	// Existing comment in the line is kept as-is
+	if end-1 <= len(src) && strings.TrimSpace(string(src[end-1:])) == "" {
+		p := &Pos{Line: line, Col: end}
+		rule.Fix(&TextEdit{p, p, " # " + ref})
+	}
+}
+
+// checkDeprecatedAction checks the action is not deprecated or archived. 'name' is the action
+// without ref like "owner/repo" or "owner/repo/path". It returns true when the action is deprecated.
+func (rule *RuleAction) checkDeprecatedAction(spec, name string, pos *Pos) bool {
+	d, ok := DeprecatedPopularActions[spec]
+	if !ok {
+		d, ok = DeprecatedPopularActions[name]
+	}
+	if !ok {
+		d, ok = rule.metadata.deprecated(spec, name)
+	}
+	if !ok {
+		return false
+	}
+
+	var b strings.Builder
+	if d.Archived {
+		fmt.Fprintf(&b, "repository of action %q is archived and the action is no longer maintained", spec)
+	} else {
+		fmt.Fprintf(&b, "action %q is deprecated", spec)
+	}
+	if d.Message != "" {
+		b.WriteString(". ")
+		b.WriteString(d.Message)
+	}
+	if d.Replacement != "" {
+		fmt.Fprintf(&b, ". use %q instead", d.Replacement)
+	}
+	rule.Error(pos, b.String())
+	return true
+}
+
+// checkRemoteAction checks the action call with the action metadata fetched from GitHub. This check
+// is done only in online mode.
+func (rule *RuleAction) checkRemoteAction(spec string, exec *ExecAction) {
+	if rule.remote == nil {
+		return
+	}
+	meta, err := rule.remote.ActionMetadata(spec)
+	if err != nil {
+		rule.Error(exec.Uses.Pos, err.Error())
+		return
+	}
+	if meta == nil {
+		rule.Debug("Action metadata was not fetched from GitHub: %s", spec)
+		return
+	}
+	rule.checkAction(meta, exec, func(m *ActionMetadata) string {
+		return strconv.Quote(spec)
+	})
+}
+
+// checkRemoteRef checks the ref at "uses:" exists in the repository 'owner/repo' via GitHub API. It
+// returns false when the ref does not exist. 'kind' is "action" or "reusable workflow". This check is
+// done only in online mode.
+func checkRemoteRef(rule *RuleBase, remote *RemoteRepository, kind, spec, owner, repo, ref string, pos *Pos) bool {
+	switch remote.refStatus(owner, repo, ref) {
+	case remoteRefNotFound:
+		if fullCommitSHAPattern.MatchString(ref) {
+			rule.Errorf(
+				pos,
+				"commit %q of %s %q is not found in repository %q. note: this check was done with GitHub API",
+				ref,
+				kind,
+				spec,
+				owner+"/"+repo,
+			)
+		} else {
+			rule.Errorf(
+				pos,
+				"ref %q of %s %q is not found. no tag or branch named %q exists in repository %q. the tag or branch may have been deleted. note: this check was done with GitHub API",
+				ref,
+				kind,
+				spec,
+				ref,
+				owner+"/"+repo,
+			)
+		}
+		return false
+	case remoteRefUnreachable:
+		rule.Errorf(
+			pos,
+			"commit %q of %s %q is not reachable from the default branch or any tag of repository %q. it may be a commit in a fork of the repository. note: this check was done with GitHub API",
+			ref,
+			kind,
+			spec,
+			owner+"/"+repo,
+		)
+		return false
+	default:
+		return true
+	}
+}
+
 func (rule *RuleAction) invalidActionFormat(pos *Pos, spec string, why string) {
 	rule.Errorf(pos, "specifying action %q in invalid format because %s. available formats are \"{owner}/{repo}@{ref}\" or \"{owner}/{repo}/{path}@{ref}\"", spec, why)
 }
@@ -426,7 +646,7 @@ func (rule *RuleAction) checkRunsFileExists(file, dir, prop, name string, pos *P
 		return
 	}
 	p := filepath.Join(dir, f)
-	if _, err := os.Stat(p); errors.Is(err, os.ErrNotExist) {
+	if _, err := rule.cache.proj.stat(p); errors.Is(err, os.ErrNotExist) {
 		rule.Errorf(pos, `file %q does not exist in %q. it is specified at %q key in "runs" section in %q action`, f, dir, prop, name)
 	}
 }
@@ -513,29 +733,53 @@ func (rule *RuleAction) checkLocalActionRuns(meta *ActionMetadata, pos *Pos) {
 
 // https://docs.github.com/en/actions/learn-github-actions/workflow-syntax-for-github-actions#example-using-the-github-packages-container-registry
 func (rule *RuleAction) checkDockerAction(uri string, exec *ExecAction) {
-	tag := ""
-	tagExists := false
-	if idx := strings.IndexRune(uri[len("docker://"):], ':'); idx != -1 {
-		idx += len("docker://")
-		if idx < len(uri) {
-			tag = uri[idx+1:]
-			uri = uri[:idx]
-			tagExists = true
-		}
+	image := strings.TrimPrefix(uri, "docker://")
+	if strings.HasSuffix(image, ":") && !strings.Contains(image, "@") {
+		rule.Errorf(exec.Uses.Pos, "tag of Docker action should not be empty: %q", strings.TrimSuffix(uri, ":"))
+		return
 	}
 
-	if _, err := url.Parse(uri); err != nil {
+	img, err := parseDockerImageRef(image)
+	if err != nil {
+		rule.Errorf(exec.Uses.Pos, "Docker image reference %q at \"uses:\" is invalid: %s", uri, err.Error())
+		return
+	}
+	if img.tag == "" && img.digest == "" {
 		rule.Errorf(
 			exec.Uses.Pos,
-			"URI for Docker container %q is invalid: %s (tag=%s)",
+			"tag of Docker image %q is not specified. \"latest\" tag is implicitly used and the image may change without notice. specify the tag or the digest explicitly like \"%s:{tag}\"",
+			uri,
 			uri,
-			err.Error(),
-			tag,
 		)
 	}
 
-	if tagExists && tag == "" {
-		rule.Errorf(exec.Uses.Pos, "tag of Docker action should not be empty: %q", uri)
+	if e := exec.Entrypoint; e != nil && !e.ContainsExpression() && strings.ContainsAny(strings.TrimSpace(e.Value), " \t\n") {
+		rule.Errorf(
+			e.Pos,
+			"\"entrypoint\" of Docker action must be a single executable but it contains spaces: %q. put its arguments in \"args\" instead",
+			e.Value,
+		)
+	}
+
+	if rule.registry == nil {
+		return
+	}
+	var cfg *Config
+	if rule.credentials {
+		cfg = rule.config
+	}
+	ok, err := rule.registry.manifestExists(img, cfg)
+	if err != nil {
+		rule.registry.warnOnce(img, err)
+		return
+	}
+	if !ok {
+		rule.Errorf(
+			exec.Uses.Pos,
+			"Docker image %q is not found in registry %q. the tag or the digest may be wrong. note: this check was done with the registry API",
+			img.String(),
+			img.registry(),
+		)
 	}
 }
 
@@ -551,10 +795,11 @@ func (rule *RuleAction) checkLocalActionMetadata(meta *ActionMetadata, action *E
 		if _, ok := BrandingIcons[strings.ToLower(meta.Branding.Icon)]; !ok {
 			rule.Errorf(
 				action.Uses.Pos,
-				"incorrect icon name %q at branding.icon in metadata of %q action at %q. see the official document to know the exhaustive list of supported icons: https://docs.github.com/en/actions/creating-actions/metadata-syntax-for-github-actions#brandingicon",
+				"incorrect icon name %q at branding.icon in metadata of %q action at %q.%s see the official document to know the exhaustive list of supported icons: https://docs.github.com/en/actions/creating-actions/metadata-syntax-for-github-actions#brandingicon",
 				meta.Branding.Icon,
 				meta.Name,
 				meta.Path(),
+				brandingHint(meta.Branding.Icon, BrandingIcons),
 			)
 		}
 	}
@@ -562,10 +807,11 @@ func (rule *RuleAction) checkLocalActionMetadata(meta *ActionMetadata, action *E
 		if _, ok := BrandingColors[strings.ToLower(meta.Branding.Color)]; !ok {
 			rule.Errorf(
 				action.Uses.Pos,
-				"incorrect color %q at branding.icon in metadata of %q action at %q. see the official document to know the exhaustive list of supported colors: https://docs.github.com/en/actions/creating-actions/metadata-syntax-for-github-actions#brandingcolor",
+				"incorrect color %q at branding.icon in metadata of %q action at %q.%s see the official document to know the exhaustive list of supported colors: https://docs.github.com/en/actions/creating-actions/metadata-syntax-for-github-actions#brandingcolor",
 				meta.Branding.Color,
 				meta.Name,
 				meta.Path(),
+				brandingHint(meta.Branding.Color, BrandingColors),
 			)
 		}
 	}
@@ -589,9 +835,42 @@ func (rule *RuleAction) checkLocalAction(spec string, action *ExecAction) {
 		rule.checkLocalActionMetadata(meta, action)
 	}
 
-	rule.checkAction(meta, action, func(m *ActionMetadata) string {
+	describe := func(m *ActionMetadata) string {
 		return fmt.Sprintf("%q defined at %q", m.Name, spec)
-	})
+	}
+	rule.checkAction(meta, action, describe)
+	rule.checkInputTypeHints(meta, action, describe)
+}
+
+// checkInputTypeHints checks the values of the inputs given to the action are consistent with the
+// types hinted by their default values. For example, an input whose default value is "true" likely
+// expects a boolean value. This check is done only for local actions because some actions accept
+// other values than the hinted type such as "recursive" for "submodules" input of actions/checkout.
+func (rule *RuleAction) checkInputTypeHints(meta *ActionMetadata, exec *ExecAction, describe func(*ActionMetadata) string) {
+	for id, i := range exec.Inputs {
+		m, ok := meta.Inputs[id]
+		if !ok || i.Value == nil || i.Value.ContainsExpression() {
+			continue
+		}
+		d := strings.ToLower(m.Default)
+		if d != "true" && d != "false" {
+			continue
+		}
+		if v := strings.ToLower(strings.TrimSpace(i.Value.Value)); v == "true" || v == "false" {
+			continue
+		}
+		e := errorfAt(
+			i.Value.Pos,
+			rule.name,
+			"input %q of action %s likely expects boolean value \"true\" or \"false\" since its default value is %q but got %q",
+			i.Name.Value,
+			describe(meta),
+			m.Default,
+			i.Value.Value,
+		)
+		e.Severity = SeverityWarning
+		rule.Report(e)
+	}
 }
 
 var reNewlineWithIndent = regexp.MustCompile(`\s*\r?\n\s*`)
@@ -605,8 +884,9 @@ func (rule *RuleAction) checkAction(meta *ActionMetadata, exec *ExecAction, desc
 			for _, i := range meta.Inputs {
 				ns = append(ns, i.Name)
 			}
-			rule.Errorf(
+			rule.ErrorfWithFixes(
 				i.Name.Pos,
+				typoFixes(i.Name, ns),
 				"input %q is not defined in action %s. available inputs are %s",
 				i.Name.Value,
 				describe(meta),
@@ -624,7 +904,10 @@ func (rule *RuleAction) checkAction(meta *ActionMetadata, exec *ExecAction, desc
 			if d != "" {
 				msg += ": " + d
 			}
-			rule.Error(i.Name.Pos, msg)
+			// Deprecated inputs still work so this is not an error
+			e := errorAt(i.Name.Pos, rule.name, msg)
+			e.Severity = SeverityWarning
+			rule.Report(e)
 		}
 	}
 
@@ -0,0 +1,752 @@
+package actionlint
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"go.yaml.in/yaml/v4"
+)
+
+// actionMetadataIDPattern is the pattern of input and output IDs in action metadata.
+// https://docs.github.com/en/actions/creating-actions/metadata-syntax-for-github-actions#inputsinput_id
+var actionMetadataIDPattern = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_-]*$`)
+
+// actionMetadataRunsKeys is a map from action type to the keys allowed in "runs" section.
+// https://docs.github.com/en/actions/creating-actions/metadata-syntax-for-github-actions#runs
+var actionMetadataRunsKeys = map[string][]string{
+	"JavaScript": {"using", "main", "pre", "pre-if", "post", "post-if"},
+	"Composite":  {"using", "steps"},
+	"Docker":     {"using", "image", "env", "args", "pre-entrypoint", "pre-if", "entrypoint", "post-entrypoint", "post-if"},
+}
+
+// compositeActionRules is the names of the workflow rules which check the steps of composite
+// actions. Other rules check workflow-level or job-level configurations which composite actions
+// don't have.
+var compositeActionRules = []string{
+	"action",
+	"cmd-script",
+	"deprecated-commands",
+	"env-var",
+	"expression",
+	"ghes",
+	"id",
+	"if-cond",
+	"limits",
+	"platform",
+	"problem-matcher",
+	"psscriptanalyzer",
+	"pyflakes",
+	"shell-name",
+	"shellcheck",
+}
+
+// actionMetadataRunsIfContexts is the contexts available at "pre-if" and "post-if" in "runs"
+// section. "steps" context is not available since they are evaluated outside of steps.
+// https://docs.github.com/en/actions/creating-actions/metadata-syntax-for-github-actions#runspre-if
+var actionMetadataRunsIfContexts = []string{"github", "inputs", "job", "matrix", "runner", "strategy"}
+
+// actionMetadataRunsIfFuncs is the special functions available at "pre-if" and "post-if" in "runs"
+// section.
+var actionMetadataRunsIfFuncs = []string{"always", "cancelled", "failure", "success"}
+
+// actionMetadataDockerContexts is the contexts available in "args" and "env" in "runs" section of
+// Docker action.
+var actionMetadataDockerContexts = []string{"inputs"}
+
+// dockerImageRefPattern is the pattern of Docker image reference such as "alpine:3.8" or
+// "ghcr.io/owner/image@sha256:...". The domain part is optional.
+// https://github.com/distribution/reference/blob/main/regexp.go
+var dockerImageRefPattern = regexp.MustCompile(`^(?:[a-zA-Z0-9](?:[a-zA-Z0-9-]*[a-zA-Z0-9])?(?:\.[a-zA-Z0-9](?:[a-zA-Z0-9-]*[a-zA-Z0-9])?)*(?::[0-9]+)?/)?[a-z0-9]+(?:(?:[._]|__|-+)[a-z0-9]+)*(?:/[a-z0-9]+(?:(?:[._]|__|-+)[a-z0-9]+)*)*(?::[a-zA-Z0-9_][a-zA-Z0-9_.-]{0,127})?(?:@[A-Za-z][A-Za-z0-9]*(?:[-_+.][A-Za-z][A-Za-z0-9]*)*:[0-9a-fA-F]{32,})?$`)
+
+// isActionMetadataFile returns true when the file at the path is an action metadata file. Files in
+// workflows directories are workflow files even if they are named "action.yml".
+func isActionMetadataFile(path string) bool {
+	if b := filepath.Base(path); b != "action.yml" && b != "action.yaml" {
+		return false
+	}
+	d := filepath.Dir(path)
+	if filepath.Base(d) != "workflows" {
+		return true
+	}
+	switch filepath.Base(filepath.Dir(d)) {
+	case ".github", ".gitea", ".forgejo":
+		return false
+	default:
+		return true
+	}
+}
+
+// RuleActionMetadata is a rule to check action metadata files "action.yml" and "action.yaml".
+// Unlike other rules, this rule checks the YAML tree of the metadata file instead of workflows. The
+// linter runs this rule instead of the workflow rules for action metadata files. Steps of composite
+// actions are checked by the workflow rules in compositeActionRules additionally.
+// https://docs.github.com/en/actions/creating-actions/metadata-syntax-for-github-actions
+type RuleActionMetadata struct {
+	RuleBase
+	// kind is the type of the action such as "JavaScript". It is empty when "runs.using" is invalid.
+	kind string
+	// steps is "runs.steps" section of composite action. It is nil when the action is not a composite
+	// action or the section is invalid.
+	steps *yaml.Node
+	// inputs is the key nodes of the valid input IDs.
+	inputs []*yaml.Node
+	// outputs is the pairs of the valid output IDs and their "value" nodes of composite action.
+	outputs [][2]*yaml.Node
+	// dir is the directory of the action metadata file. Files referenced in "runs" section are
+	// checked to exist only when this field is not empty.
+	dir string
+	// proj is the project of the action metadata file. It is used for checking files referenced in
+	// "runs" section exist. It can be nil.
+	proj *Project
+	// dockerfile is the path of the local Dockerfile at "runs.image" of Docker action. It is empty
+	// when the action does not use a local Dockerfile or the file does not exist.
+	dockerfile string
+	// dockerfilePos is the position of "runs.image" which references the local Dockerfile.
+	dockerfilePos *Pos
+}
+
+// compositeAction is a composite action converted into a workflow so that its steps are checked by
+// the rules for workflows. The workflow has one job whose steps are "runs.steps" and whose outputs
+// are "outputs" of the action.
+type compositeAction struct {
+	workflow *Workflow
+	// inputs is the type of "inputs" context in the steps.
+	inputs *ObjectType
+	// inputDecls is the IDs of the inputs declared in "inputs" section.
+	inputDecls []*String
+}
+
+// NewRuleActionMetadata creates a new RuleActionMetadata instance.
+func NewRuleActionMetadata() *RuleActionMetadata {
+	return &RuleActionMetadata{
+		RuleBase: RuleBase{
+			name: "action-metadata",
+			desc: "Checks for schema of action metadata files action.yml and action.yaml",
+		},
+	}
+}
+
+func resolveYAMLAlias(n *yaml.Node) *yaml.Node {
+	for n.Kind == yaml.AliasNode && n.Alias != nil {
+		n = n.Alias
+	}
+	return n
+}
+
+func (rule *RuleActionMetadata) unexpectedKey(k *yaml.Node, sec string, expected []string) {
+	rule.Errorf(posAt(k), "unexpected key %q for %s. expected one of %s", k.Value, sec, sortedQuotes(expected))
+}
+
+// mapping checks the node is a mapping and returns the pairs of its keys and values.
+func (rule *RuleActionMetadata) mapping(n *yaml.Node, sec string) ([][2]*yaml.Node, bool) {
+	n = resolveYAMLAlias(n)
+	if n.Kind != yaml.MappingNode {
+		rule.Errorf(posAt(n), "%s must be mapping but got %s node", sec, nodeKindName(n.Kind))
+		return nil, false
+	}
+	kvs := make([][2]*yaml.Node, 0, len(n.Content)/2)
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		kvs = append(kvs, [2]*yaml.Node{n.Content[i], resolveYAMLAlias(n.Content[i+1])})
+	}
+	return kvs, true
+}
+
+// str checks the node is a non-empty string and returns its value.
+func (rule *RuleActionMetadata) str(n *yaml.Node, sec string) (string, bool) {
+	if n.Kind != yaml.ScalarNode {
+		rule.Errorf(posAt(n), "%s must be string but got %s node", sec, nodeKindName(n.Kind))
+		return "", false
+	}
+	if n.Tag == "!!null" || strings.TrimSpace(n.Value) == "" {
+		rule.Errorf(posAt(n), "%s must not be empty", sec)
+		return "", false
+	}
+	return n.Value, true
+}
+
+func (rule *RuleActionMetadata) checkBool(n *yaml.Node, sec string) {
+	if n.Kind == yaml.ScalarNode && (n.Tag == "!!bool" || n.Value == "true" || n.Value == "false") {
+		return
+	}
+	rule.Errorf(posAt(n), "%s must be boolean but got %q", sec, n.Value)
+}
+
+// checkSource parses the source of the action metadata file and checks it. Errors found by this
+// rule are stored in the rule instance. When the action is a composite action, its steps are parsed
+// as steps of workflow and the converted composite action is returned. The errors on parsing the
+// source and the steps are returned as the second return value.
+func (rule *RuleActionMetadata) checkSource(b []byte, limits *ParseLimits) (*compositeAction, []*Error) {
+	n, errs := unmarshalYAMLWithLimits(b, limits, "action metadata file")
+	if len(errs) > 0 {
+		return nil, errs
+	}
+	p := &parser{lines: bytes.Split(b, []byte{'\n'})}
+	p.resolveAliases(n)
+	rule.check(n)
+	if rule.steps == nil {
+		return nil, p.errors
+	}
+
+	pos := posAt(rule.steps)
+	job := &Job{
+		ID:      &String{Value: "composite", Pos: pos},
+		Steps:   p.parseSteps(rule.steps),
+		Outputs: make(map[string]*Output, len(rule.outputs)),
+		Pos:     pos,
+	}
+	for _, o := range rule.outputs {
+		job.Outputs[strings.ToLower(o[0].Value)] = &Output{p.newString(o[0]), p.parseString(o[1], false)}
+	}
+	w := &Workflow{Jobs: map[string]*Job{"composite": job}}
+	decls := make([]*String, 0, len(rule.inputs))
+	for _, i := range rule.inputs {
+		decls = append(decls, p.newString(i))
+	}
+	return &compositeAction{w, rule.inputsType(), decls}, p.errors
+}
+
+// inputsType returns the type of "inputs" context of the action.
+func (rule *RuleActionMetadata) inputsType() *ObjectType {
+	ty := NewEmptyStrictObjectType()
+	for _, id := range rule.inputs {
+		ty.Props[strings.ToLower(id.Value)] = StringType{}
+	}
+	return ty
+}
+
+func (rule *RuleActionMetadata) check(n *yaml.Node) {
+	if n.Kind == yaml.DocumentNode && len(n.Content) > 0 {
+		n = n.Content[0]
+	}
+	if n.Kind == 0 || n.Kind == yaml.DocumentNode || n.Kind == yaml.ScalarNode && n.Tag == "!!null" {
+		rule.Error(&Pos{Line: 1, Col: 1}, "action metadata is empty")
+		return
+	}
+	kvs, ok := rule.mapping(n, "action metadata")
+	if !ok {
+		return
+	}
+
+	var name, desc, inputs, outputs, runs, branding *yaml.Node
+	for _, kv := range kvs {
+		k, v := kv[0], kv[1]
+		switch k.Value {
+		case "name":
+			name = v
+		case "author":
+			if v.Kind != yaml.ScalarNode {
+				rule.Errorf(posAt(v), `"author" must be string but got %s node`, nodeKindName(v.Kind))
+			}
+		case "description":
+			desc = v
+		case "inputs":
+			inputs = v
+		case "outputs":
+			outputs = v
+		case "runs":
+			runs = v
+		case "branding":
+			branding = v
+		default:
+			rule.unexpectedKey(k, "action metadata", []string{"name", "author", "description", "inputs", "outputs", "runs", "branding"})
+		}
+	}
+
+	if name == nil {
+		rule.Error(posAt(n), `"name" is required in action metadata`)
+	} else {
+		rule.str(name, `"name"`)
+	}
+	if desc == nil {
+		rule.Error(posAt(n), `"description" is required in action metadata`)
+	} else {
+		rule.str(desc, `"description"`)
+	}
+	// Check inputs before "runs" section since "inputs" context is available in expressions in it
+	if inputs != nil {
+		rule.checkInputs(inputs)
+	}
+	if runs == nil {
+		rule.Error(posAt(n), `"runs" section is required in action metadata`)
+	} else {
+		rule.checkRuns(runs)
+	}
+	if outputs != nil {
+		rule.checkOutputs(outputs)
+	}
+	if branding != nil {
+		rule.checkBranding(branding)
+	}
+}
+
+// checkIDs checks IDs of inputs or outputs. It returns the pairs of the valid IDs and their
+// definitions.
+func (rule *RuleActionMetadata) checkIDs(n *yaml.Node, sec, what string) [][2]*yaml.Node {
+	kvs, ok := rule.mapping(n, fmt.Sprintf("%q section", sec))
+	if !ok {
+		return nil
+	}
+	seen := make(map[string]*yaml.Node, len(kvs))
+	ret := make([][2]*yaml.Node, 0, len(kvs))
+	for _, kv := range kvs {
+		k := kv[0]
+		if !actionMetadataIDPattern.MatchString(k.Value) {
+			rule.Errorf(posAt(k), "invalid %s ID %q. %s ID must start with a letter or _ and contain only alphanumeric characters, -, or _", what, k.Value, what)
+			continue
+		}
+		id := strings.ToLower(k.Value)
+		if prev, ok := seen[id]; ok {
+			rule.Errorf(posAt(k), "%s %q is duplicated. it was previously defined at line:%d, col:%d. note that %s ID is case-insensitive", what, k.Value, prev.Line, prev.Column, what)
+			continue
+		}
+		seen[id] = k
+		ret = append(ret, kv)
+	}
+	return ret
+}
+
+// https://docs.github.com/en/actions/creating-actions/metadata-syntax-for-github-actions#inputs
+func (rule *RuleActionMetadata) checkInputs(n *yaml.Node) {
+	for _, kv := range rule.checkIDs(n, "inputs", "input") {
+		id, v := kv[0].Value, kv[1]
+		rule.inputs = append(rule.inputs, kv[0])
+		sec := fmt.Sprintf("input %q", id)
+		kvs, ok := rule.mapping(v, sec)
+		if !ok {
+			continue
+		}
+		desc := false
+		for _, kv := range kvs {
+			k, v := kv[0], kv[1]
+			switch k.Value {
+			case "description":
+				desc = true
+				rule.str(v, fmt.Sprintf(`"description" of %s`, sec))
+			case "required":
+				rule.checkBool(v, fmt.Sprintf(`"required" of %s`, sec))
+			case "default", "deprecationMessage":
+				if v.Kind != yaml.ScalarNode {
+					rule.Errorf(posAt(v), "%q of %s must be string but got %s node", k.Value, sec, nodeKindName(v.Kind))
+				} else if k.Value == "deprecationMessage" && strings.TrimSpace(v.Value) == "" {
+					rule.Errorf(posAt(v), "%s is deprecated but \"deprecationMessage\" is empty", sec)
+				}
+			default:
+				rule.unexpectedKey(k, sec, []string{"description", "required", "default", "deprecationMessage"})
+			}
+		}
+		if !desc {
+			rule.Errorf(posAt(kv[0]), `"description" is required in %s`, sec)
+		}
+	}
+}
+
+// https://docs.github.com/en/actions/creating-actions/metadata-syntax-for-github-actions#outputs-for-docker-container-and-javascript-actions
+// https://docs.github.com/en/actions/creating-actions/metadata-syntax-for-github-actions#outputs-for-composite-actions
+func (rule *RuleActionMetadata) checkOutputs(n *yaml.Node) {
+	for _, kv := range rule.checkIDs(n, "outputs", "output") {
+		name, v := kv[0], kv[1]
+		sec := fmt.Sprintf("output %q", name.Value)
+		kvs, ok := rule.mapping(v, sec)
+		if !ok {
+			continue
+		}
+		desc, value := false, false
+		for _, kv := range kvs {
+			k, v := kv[0], kv[1]
+			switch k.Value {
+			case "description":
+				desc = true
+				rule.str(v, fmt.Sprintf(`"description" of %s`, sec))
+			case "value":
+				value = true
+				if rule.kind != "" && rule.kind != "Composite" {
+					rule.Errorf(posAt(k), `"value" is not allowed in %s because the action is a %s action. "value" is available only in composite actions`, sec, rule.kind)
+				} else if _, ok := rule.str(v, fmt.Sprintf(`"value" of %s`, sec)); ok {
+					rule.outputs = append(rule.outputs, [2]*yaml.Node{name, v})
+				}
+			default:
+				rule.unexpectedKey(k, sec, []string{"description", "value"})
+			}
+		}
+		if !desc {
+			rule.Errorf(posAt(name), `"description" is required in %s`, sec)
+		}
+		if !value && rule.kind == "Composite" {
+			rule.Errorf(posAt(name), `"value" is required in %s because the action is a composite action`, sec)
+		}
+	}
+}
+
+// https://docs.github.com/en/actions/creating-actions/metadata-syntax-for-github-actions#runs
+func (rule *RuleActionMetadata) checkRuns(n *yaml.Node) {
+	kvs, ok := rule.mapping(n, `"runs" section`)
+	if !ok {
+		return
+	}
+
+	var using *yaml.Node
+	props := make(map[string]*yaml.Node, len(kvs))
+	for _, kv := range kvs {
+		if kv[0].Value == "using" {
+			using = kv[1]
+		}
+		props[kv[0].Value] = kv[1]
+	}
+	if using == nil {
+		rule.Error(posAt(n), `"using" is required in "runs" section`)
+		return
+	}
+	u, ok := rule.str(using, `"runs.using"`)
+	if !ok {
+		return
+	}
+
+	switch u {
+	case "docker":
+		rule.kind = "Docker"
+	case "composite":
+		rule.kind = "Composite"
+	case "node20", "node24":
+		rule.kind = "JavaScript"
+	default:
+		if v, ok := strings.CutPrefix(u, "node"); ok && isOutdatedNodeVersion(v) {
+			rule.Errorf(posAt(using), `Node.js runtime %q at runs.using is no longer supported by GitHub Actions. use "node24" instead. see https://docs.github.com/en/actions/creating-actions/metadata-syntax-for-github-actions#runs-for-javascript-actions`, u)
+		} else {
+			rule.Errorf(posAt(using), `invalid runner name %q at runs.using. valid runners are "composite", "docker", "node20", and "node24". see https://docs.github.com/en/actions/creating-actions/metadata-syntax-for-github-actions#runs`, u)
+		}
+		// Probably invalid version of Node.js runner. Assume it is JavaScript action to find as many errors as possible
+		if !strings.HasPrefix(u, "node") {
+			return
+		}
+		rule.kind = "JavaScript"
+	}
+
+	allowed := actionMetadataRunsKeys[rule.kind]
+	for _, kv := range kvs {
+		k := kv[0]
+		if slices.Contains(allowed, k.Value) {
+			continue
+		}
+		invalid := false
+		for _, keys := range actionMetadataRunsKeys {
+			if slices.Contains(keys, k.Value) {
+				invalid = true
+				break
+			}
+		}
+		if invalid {
+			rule.Errorf(posAt(k), `%q is not allowed in "runs" section because the action is a %s action`, k.Value, rule.kind)
+		} else {
+			rule.unexpectedKey(k, `"runs" section`, allowed)
+		}
+	}
+
+	required := func(prop string) *yaml.Node {
+		v, ok := props[prop]
+		if !ok {
+			rule.Errorf(posAt(n), `%q is required in "runs" section because the action is a %s action`, prop, rule.kind)
+			return nil
+		}
+		return v
+	}
+
+	switch rule.kind {
+	case "JavaScript":
+		// https://docs.github.com/en/actions/creating-actions/metadata-syntax-for-github-actions#runs-for-javascript-actions
+		if v := required("main"); v != nil {
+			if f, ok := rule.str(v, `"runs.main"`); ok {
+				rule.checkFileExists(v, f, "main")
+			}
+		}
+		for _, p := range []string{"pre", "post"} {
+			if v, ok := props[p]; ok {
+				if f, ok := rule.str(v, fmt.Sprintf(`"runs.%s"`, p)); ok {
+					rule.checkFileExists(v, f, p)
+				}
+			}
+			if c, ok := props[p+"-if"]; ok {
+				if _, ok := props[p]; !ok {
+					rule.Errorf(posAt(c), `%q is required when "%s-if" is specified in "runs" section`, p, p)
+				}
+			}
+		}
+	case "Composite":
+		// https://docs.github.com/en/actions/creating-actions/metadata-syntax-for-github-actions#runs-for-composite-actions
+		if v := required("steps"); v != nil {
+			rule.checkSteps(v)
+		}
+	case "Docker":
+		// https://docs.github.com/en/actions/creating-actions/metadata-syntax-for-github-actions#runs-for-docker-container-actions
+		if v := required("image"); v != nil {
+			if img, ok := rule.str(v, `"runs.image"`); ok {
+				rule.checkImage(v, img)
+			}
+		}
+		if v, ok := props["args"]; ok {
+			if v.Kind != yaml.SequenceNode {
+				rule.Errorf(posAt(v), `"runs.args" must be sequence but got %s node`, nodeKindName(v.Kind))
+			} else {
+				for _, a := range v.Content {
+					rule.checkExprsIn(resolveYAMLAlias(a), actionMetadataDockerContexts)
+				}
+			}
+		}
+		if v, ok := props["env"]; ok {
+			if kvs, ok := rule.mapping(v, `"runs.env"`); ok {
+				for _, kv := range kvs {
+					rule.checkExprsIn(kv[1], actionMetadataDockerContexts)
+				}
+			}
+		}
+		// Expressions are not evaluated at entrypoints so no context is available
+		for _, k := range []string{"pre-entrypoint", "entrypoint", "post-entrypoint"} {
+			if v, ok := props[k]; ok {
+				rule.checkExprsIn(v, nil)
+			}
+		}
+		for _, p := range []string{"pre", "post"} {
+			if c, ok := props[p+"-if"]; ok {
+				if _, ok := props[p+"-entrypoint"]; !ok {
+					rule.Errorf(posAt(c), `"%s-entrypoint" is required when "%s-if" is specified in "runs" section`, p, p)
+				}
+			}
+		}
+	}
+
+	if rule.kind != "Composite" {
+		for _, k := range []string{"pre-if", "post-if"} {
+			if v, ok := props[k]; ok {
+				rule.checkRunsIf(v, k)
+			}
+		}
+	}
+}
+
+// isOutdatedNodeVersion returns true when the major version of Node.js is older than the versions
+// supported by GitHub Actions such as "16" of "node16".
+func isOutdatedNodeVersion(v string) bool {
+	i, err := strconv.Atoi(v)
+	return err == nil && i < 20
+}
+
+// checkFileExists checks the file at the key in "runs" section exists in the action directory. It
+// returns the path of the file when it exists.
+func (rule *RuleActionMetadata) checkFileExists(n *yaml.Node, file, key string) (string, bool) {
+	if rule.dir == "" {
+		return "", false
+	}
+	f := filepath.FromSlash(file)
+	p := filepath.Join(rule.dir, f)
+	if _, err := rule.proj.stat(p); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			rule.Errorf(posAt(n), `file %q at "runs.%s" does not exist in %q`, f, key, rule.dir)
+		}
+		return "", false
+	}
+	return p, true
+}
+
+// checkImage checks "runs.image" of Docker action. The image is a Docker image on registry like
+// "docker://alpine:3.8" or a path to the local Dockerfile.
+func (rule *RuleActionMetadata) checkImage(n *yaml.Node, image string) {
+	if isImageOnDockerRegistry(image) {
+		ref := strings.TrimPrefix(image, "docker://")
+		if !dockerImageRefPattern.MatchString(ref) {
+			rule.Errorf(posAt(n), `invalid Docker image reference %q at "runs.image". the format is "{name}:{tag}" or "{name}@{digest}" such as "docker://alpine:3.8"`, ref)
+		}
+		return
+	}
+	if filepath.Base(filepath.FromSlash(image)) != "Dockerfile" {
+		rule.Errorf(posAt(n), `the local file %q at "runs.image" must be named "Dockerfile"`, image)
+		return
+	}
+	if p, ok := rule.checkFileExists(n, image, "image"); ok {
+		rule.dockerfile = p
+		rule.dockerfilePos = posAt(n)
+	}
+}
+
+// checkExprsIn checks the expressions enclosed in ${{ }} in the string node. Only the given
+// contexts are available in the expressions.
+func (rule *RuleActionMetadata) checkExprsIn(n *yaml.Node, ctxs []string) {
+	if n.Kind != yaml.ScalarNode {
+		return
+	}
+	pos := posAt(n)
+	line, col := pos.Line, pos.Col
+	if n.Style&(yaml.DoubleQuotedStyle|yaml.SingleQuotedStyle) != 0 {
+		col++ // when the string is quoted like 'foo' or "foo", column should be incremented
+	}
+	s := n.Value
+	offset := 0
+	for {
+		idx := strings.Index(s, "${{")
+		if idx == -1 {
+			return
+		}
+		start := idx + len("${{")
+		s = s[start:]
+		offset += start
+		_, after, ok := rule.checkExpr(s, line, col+offset, ctxs, nil)
+		if !ok || after == 0 {
+			return
+		}
+		s = s[after:]
+		offset += after
+	}
+}
+
+// checkExpr checks the expression at the start of the source. It returns the type of the
+// expression and the offset of the end of the expression.
+func (rule *RuleActionMetadata) checkExpr(src string, line, col int, ctxs, funcs []string) (ExprType, int, bool) {
+	l := NewExprLexer(src)
+	defer l.Release()
+	e, err := NewExprParser().Parse(l)
+	if err != nil {
+		rule.Error(convertExprLineColToPos(err.Line, err.Column, line, col), err.Message)
+		return nil, l.Offset(), false
+	}
+
+	c := NewExprSemanticsChecker(false, nil)
+	c.UpdateInputs(rule.inputsType())
+	c.SetContextAvailability(ctxs)
+	c.SetSpecialFunctionAvailability(funcs)
+	ty, errs := c.Check(e)
+	for _, err := range errs {
+		rule.Error(convertExprLineColToPos(err.Line, err.Column, line, col), err.Message)
+	}
+	return ty, l.Offset(), len(errs) == 0
+}
+
+// checkRunsIf checks the condition at "pre-if" or "post-if" in "runs" section. Like "if:" in
+// workflows, the condition is an expression even if it is not enclosed in ${{ }}.
+// https://docs.github.com/en/actions/creating-actions/metadata-syntax-for-github-actions#runspre-if
+func (rule *RuleActionMetadata) checkRunsIf(n *yaml.Node, key string) {
+	src, ok := rule.str(n, fmt.Sprintf(`"runs.%s"`, key))
+	if !ok {
+		return
+	}
+	pos := posAt(n)
+	line, col := pos.Line, pos.Col
+	if s := strings.TrimSpace(src); strings.HasPrefix(s, "${{") && strings.HasSuffix(s, "}}") {
+		src = s[len("${{") : len(s)-len("}}")]
+		col += len("${{")
+	}
+
+	// }} is necessary since lexer lexes it as end of tokens
+	ty, _, ok := rule.checkExpr(src+"}}", line, col, actionMetadataRunsIfContexts, actionMetadataRunsIfFuncs)
+	if !ok {
+		return
+	}
+	if !(BoolType{}).Assignable(ty) {
+		rule.Errorf(pos, `condition at "runs.%s" should be type "bool" but got type %q`, key, ty.String())
+	}
+
+	// Like "if:" in workflows, success() is implicitly added to the condition when it does not call
+	// any status check function. It is a common pitfall that a post entry point for cleanup is
+	// skipped when some step failed though post-if defaults to always().
+	if key == "post-if" && !callsStatusCheckFunction(src+"}}") {
+		err := errorfAt(
+			pos,
+			rule.name,
+			`condition %q at "runs.post-if" does not call any status check function so "success() && " is implicitly prepended. the post entry point does not run when some step failed. use "always() && ..." to run it regardless of the job status`,
+			strings.TrimSpace(src),
+		)
+		err.Severity = SeverityWarning
+		rule.Report(err)
+	}
+}
+
+// callsStatusCheckFunction returns true when the expression calls any status check function such as
+// always() or failure().
+func callsStatusCheckFunction(src string) bool {
+	l := NewExprLexer(src)
+	defer l.Release()
+	e, err := NewExprParser().Parse(l)
+	if err != nil {
+		return true // Cannot determine
+	}
+	found := false
+	VisitExprNode(e, func(n, _ ExprNode, entering bool) {
+		if c, ok := n.(*FuncCallNode); ok && entering && slices.Contains(actionMetadataRunsIfFuncs, strings.ToLower(c.Callee)) {
+			found = true
+		}
+	})
+	return found
+}
+
+// checkSteps checks "runs.steps" section of composite action. Each step is parsed and checked as a
+// step of workflow later so only the restrictions specific to composite actions are checked here.
+// https://docs.github.com/en/actions/creating-actions/metadata-syntax-for-github-actions#runssteps
+func (rule *RuleActionMetadata) checkSteps(n *yaml.Node) {
+	n = resolveYAMLAlias(n)
+	if n.Kind != yaml.SequenceNode {
+		rule.Errorf(posAt(n), `"runs.steps" must be sequence but got %s node`, nodeKindName(n.Kind))
+		return
+	}
+	if len(n.Content) == 0 {
+		rule.Error(posAt(n), `"runs.steps" must not be empty`)
+		return
+	}
+	rule.steps = n
+	for i, s := range n.Content {
+		if s = resolveYAMLAlias(s); s.Kind != yaml.MappingNode {
+			continue
+		}
+		var run, shell *yaml.Node
+		for j := 0; j+1 < len(s.Content); j += 2 {
+			switch k := s.Content[j]; k.Value {
+			case "run":
+				run = k
+			case "shell":
+				shell = k
+			case "timeout-minutes":
+				rule.Errorf(posAt(k), `"timeout-minutes" is not available in step #%d in "runs.steps" of composite action`, i+1)
+			}
+		}
+		if run != nil && shell == nil {
+			rule.Errorf(posAt(run), `"shell" is required for "run" in step #%d in "runs.steps" of composite action`, i+1)
+		}
+	}
+}
+
+// https://docs.github.com/en/actions/creating-actions/metadata-syntax-for-github-actions#branding
+// brandingFixes returns the edits to fix the typo of the icon name or color at the node with the
+// closest name in the set.
+func brandingFixes(n *yaml.Node, set map[string]struct{}) []*TextEdit {
+	return typoFixes((&parser{}).newString(n), slices.Sorted(maps.Keys(set)))
+}
+
+func (rule *RuleActionMetadata) checkBranding(n *yaml.Node) {
+	kvs, ok := rule.mapping(n, `"branding" section`)
+	if !ok {
+		return
+	}
+	for _, kv := range kvs {
+		k, v := kv[0], kv[1]
+		switch k.Value {
+		case "icon":
+			if s, ok := rule.str(v, `"branding.icon"`); ok {
+				if _, ok := BrandingIcons[strings.ToLower(s)]; !ok {
+					rule.ErrorfWithFixes(posAt(v), brandingFixes(v, BrandingIcons), "incorrect icon name %q at branding.icon.%s see the official document to know the exhaustive list of supported icons: https://docs.github.com/en/actions/creating-actions/metadata-syntax-for-github-actions#brandingicon", s, brandingHint(s, BrandingIcons))
+				}
+			}
+		case "color":
+			if s, ok := rule.str(v, `"branding.color"`); ok {
+				if _, ok := BrandingColors[strings.ToLower(s)]; !ok {
+					rule.ErrorfWithFixes(posAt(v), brandingFixes(v, BrandingColors), "incorrect color %q at branding.color.%s see the official document to know the exhaustive list of supported colors: https://docs.github.com/en/actions/creating-actions/metadata-syntax-for-github-actions#brandingcolor", s, brandingHint(s, BrandingColors))
+				}
+			}
+		default:
+			rule.unexpectedKey(k, `"branding" section`, []string{"icon", "color"})
+		}
+	}
+}
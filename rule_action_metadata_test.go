@@ -0,0 +1,830 @@
+package actionlint
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestRuleActionMetadataIsActionMetadataFile(t *testing.T) {
+	tests := []struct {
+		path string
+		want bool
+	}{
+		{"action.yml", true},
+		{"action.yaml", true},
+		{"path/to/action.yml", true},
+		{".github/actions/foo/action.yaml", true},
+		{"workflows/action.yml", true},
+		{".github/workflows/action.yml", false},
+		{".gitea/workflows/action.yaml", false},
+		{".github/workflows/ci.yaml", false},
+		{"actions.yml", false},
+		{"action.json", false},
+	}
+	for _, tc := range tests {
+		if have := isActionMetadataFile(filepath.FromSlash(tc.path)); have != tc.want {
+			t.Errorf("wanted %v for %q but got %v", tc.want, tc.path, have)
+		}
+	}
+}
+
+func TestRuleActionMetadataOK(t *testing.T) {
+	tests := []struct {
+		what  string
+		input string
+	}{
+		{
+			"JavaScript action",
+			`name: Test
+author: rhysd
+description: Test action
+inputs:
+  foo:
+    description: foo
+    required: true
+    default: bar
+  old_input:
+    description: old
+    required: 'false'
+    deprecationMessage: use foo instead
+outputs:
+  result:
+    description: result
+runs:
+  using: node24
+  main: index.js
+  pre: pre.js
+  pre-if: runner.os == 'Linux'
+  post: post.js
+  post-if: always()
+branding:
+  icon: Activity
+  color: blue
+`,
+		},
+		{
+			"composite action",
+			`name: Test
+description: Test action
+outputs:
+  result:
+    description: result
+    value: ${{ steps.foo.outputs.result }}
+runs:
+  using: composite
+  steps:
+    - id: foo
+      run: echo "result=ok" >> "$GITHUB_OUTPUT"
+      shell: bash
+      working-directory: ./foo
+    - uses: actions/checkout@v5
+      with:
+        fetch-depth: 0
+`,
+		},
+		{
+			"Docker action",
+			`name: Test
+description: Test action
+inputs:
+  foo:
+    description: foo
+runs:
+  using: docker
+  image: docker://ghcr.io/owner/image:v1.2.3
+  pre-entrypoint: pre.sh
+  pre-if: always()
+  entrypoint: main.sh
+  post-entrypoint: post.sh
+  args: [foo, '${{ inputs.foo }}']
+  env:
+    FOO: ${{ inputs.foo }}
+`,
+		},
+		{
+			"alias",
+			`name: Test
+description: Test action
+runs:
+  using: composite
+  steps:
+    - run: echo
+      shell: bash
+inputs:
+  foo: &input
+    description: foo
+  bar: *input
+`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.what, func(t *testing.T) {
+			r := NewRuleActionMetadata()
+			if _, errs := r.checkSource([]byte(tc.input), nil); len(errs) > 0 {
+				t.Fatal(errs)
+			}
+			if errs := r.Errs(); len(errs) > 0 {
+				t.Fatal(errs)
+			}
+		})
+	}
+}
+
+func TestRuleActionMetadataErrors(t *testing.T) {
+	tests := []struct {
+		what  string
+		input string
+		want  []string
+	}{
+		{
+			"empty",
+			"",
+			[]string{"1:1: action metadata is empty"},
+		},
+		{
+			"not mapping",
+			"- foo\n",
+			[]string{"1:1: action metadata must be mapping but got sequence node"},
+		},
+		{
+			"missing required keys",
+			"author: me\n",
+			[]string{
+				`1:1: "name" is required in action metadata`,
+				`1:1: "description" is required in action metadata`,
+				`1:1: "runs" section is required in action metadata`,
+			},
+		},
+		{
+			"empty name and unknown key",
+			"name:\ndescription: [foo]\nfoo: bar\nruns:\n  using: node24\n  main: index.js\n",
+			[]string{
+				`3:1: unexpected key "foo" for action metadata`,
+				`1:6: "name" must not be empty`,
+				`2:14: "description" must be string but got sequence node`,
+			},
+		},
+		{
+			"invalid inputs",
+			`name: Test
+description: Test
+runs:
+  using: node24
+  main: index.js
+inputs:
+  1foo:
+    description: foo
+  bar:
+    required: yes
+    typo: foo
+  BAR:
+    description: dup
+  piyo:
+    description: piyo
+    default: [foo]
+    deprecationMessage: ''
+  qux: foo
+`,
+			[]string{
+				`7:3: invalid input ID "1foo"`,
+				`12:3: input "BAR" is duplicated. it was previously defined at line:9, col:3`,
+				`10:15: "required" of input "bar" must be boolean but got "yes"`,
+				`11:5: unexpected key "typo" for input "bar"`,
+				`9:3: "description" is required in input "bar"`,
+				`16:14: "default" of input "piyo" must be string but got sequence node`,
+				`17:25: input "piyo" is deprecated but "deprecationMessage" is empty`,
+				`18:8: input "qux" must be mapping but got scalar node`,
+			},
+		},
+		{
+			"invalid outputs of composite action",
+			`name: Test
+description: Test
+outputs:
+  foo:
+    description: foo
+  bar:
+    value: bar
+    typo: bar
+runs:
+  using: composite
+  steps:
+    - run: echo
+      shell: bash
+`,
+			[]string{
+				`4:3: "value" is required in output "foo" because the action is a composite action`,
+				`8:5: unexpected key "typo" for output "bar"`,
+				`6:3: "description" is required in output "bar"`,
+			},
+		},
+		{
+			"value in output of JavaScript action",
+			`name: Test
+description: Test
+outputs:
+  foo:
+    description: foo
+    value: foo
+runs:
+  using: node24
+  main: index.js
+`,
+			[]string{`6:5: "value" is not allowed in output "foo" because the action is a JavaScript action`},
+		},
+		{
+			"missing runs.using",
+			"name: Test\ndescription: Test\nruns:\n  main: index.js\n",
+			[]string{`4:3: "using" is required in "runs" section`},
+		},
+		{
+			"invalid runner",
+			"name: Test\ndescription: Test\nruns:\n  using: node16\n  image: Dockerfile\n",
+			[]string{
+				`4:10: Node.js runtime "node16" at runs.using is no longer supported by GitHub Actions`,
+				`5:3: "image" is not allowed in "runs" section because the action is a JavaScript action`,
+				`4:3: "main" is required in "runs" section because the action is a JavaScript action`,
+			},
+		},
+		{
+			"unknown Node.js runner",
+			"name: Test\ndescription: Test\nruns:\n  using: node99\n  main: index.js\n",
+			[]string{`4:10: invalid runner name "node99" at runs.using`},
+		},
+		{
+			"unknown runner",
+			"name: Test\ndescription: Test\nruns:\n  using: python\n  main: main.py\n",
+			[]string{`4:10: invalid runner name "python" at runs.using`},
+		},
+		{
+			"JavaScript action",
+			"name: Test\ndescription: Test\nruns:\n  using: node24\n  pre-if: true\n  post-if: true\n  foo: bar\n",
+			[]string{
+				`7:3: unexpected key "foo" for "runs" section`,
+				`4:3: "main" is required in "runs" section because the action is a JavaScript action`,
+				`5:11: "pre" is required when "pre-if" is specified in "runs" section`,
+				`6:12: "post" is required when "post-if" is specified in "runs" section`,
+				`6:12: condition "true" at "runs.post-if" does not call any status check function`,
+			},
+		},
+		{
+			"conditions of JavaScript action",
+			`name: Test
+description: Test
+inputs:
+  foo:
+    description: foo
+runs:
+  using: node24
+  main: index.js
+  pre: pre.js
+  pre-if: ${{ inputs.foo == 'x' && steps.foo.outcome }}
+  post: post.js
+  post-if: inputs.bar && success(
+`,
+			[]string{
+				`10:36: context "steps" is not allowed here`,
+				`10:36: property "foo" is not defined in object type {}`,
+				`12:34: unexpected end of input while parsing`,
+			},
+		},
+		{
+			"function not available in condition",
+			"name: Test\ndescription: Test\nruns:\n  using: node24\n  main: index.js\n  post: post.js\n  post-if: always()\n  pre: pre.js\n  pre-if: hashFiles('foo') != ''\n",
+			[]string{`9:11: calling function "hashFiles" is not allowed here`},
+		},
+		{
+			"post-if without status check function",
+			"name: Test\ndescription: Test\nruns:\n  using: node24\n  main: index.js\n  post: post.js\n  post-if: ${{ runner.os == 'Linux' }}\n  pre: pre.js\n  pre-if: runner.os == 'Linux'\n",
+			[]string{`7:12: condition "runner.os == 'Linux'" at "runs.post-if" does not call any status check function so "success() && " is implicitly prepended`},
+		},
+		{
+			"conditions of Docker action",
+			"name: Test\ndescription: Test\nruns:\n  using: docker\n  image: docker://alpine\n  pre-if: always()\n  post-entrypoint: cleanup.sh\n  post-if: Always() && secrets.FOO\n",
+			[]string{
+				`6:11: "pre-entrypoint" is required when "pre-if" is specified in "runs" section`,
+				`8:24: context "secrets" is not allowed here`,
+			},
+		},
+		{
+			"Docker action",
+			"name: Test\ndescription: Test\nruns:\n  using: docker\n  main: index.js\n  args: foo\n  env: [foo]\n",
+			[]string{
+				`5:3: "main" is not allowed in "runs" section because the action is a Docker action`,
+				`4:3: "image" is required in "runs" section because the action is a Docker action`,
+				`6:9: "runs.args" must be sequence but got scalar node`,
+				`7:8: "runs.env" must be mapping but got sequence node`,
+			},
+		},
+		{
+			"composite action steps",
+			`name: Test
+description: Test
+runs:
+  using: composite
+  steps:
+    - run: echo
+    - uses: actions/checkout@v5
+      run: echo
+      shell: bash
+    - uses: actions/checkout@v5
+      shell: bash
+      timeout-minutes: 5
+    - name: foo
+    - echo
+`,
+			[]string{
+				`7:7: unexpected key "uses" for step to run shell command`,
+				`11:7: unexpected key "shell" for step to execute action`,
+				`13:7: step must run script with "run" section or run action with "uses" section`,
+				`14:7: element of "steps" section is scalar node but mapping node is expected`,
+				`14:7: step must run script with "run" section or run action with "uses" section`,
+				`6:7: "shell" is required for "run" in step #1 in "runs.steps" of composite action`,
+				`12:7: "timeout-minutes" is not available in step #3 in "runs.steps" of composite action`,
+			},
+		},
+		{
+			"missing composite action steps",
+			"name: Test\ndescription: Test\nruns:\n  using: composite\n  steps: []\n",
+			[]string{`5:10: "runs.steps" must not be empty`},
+		},
+		{
+			"Docker image and expressions",
+			`name: Test
+description: Test
+inputs:
+  foo:
+    description: foo
+runs:
+  using: docker
+  image: docker://Alpine:latest
+  entrypoint: ${{ inputs.foo }}
+  args:
+    - ${{ inputs.foo }}
+    - "--bar=${{ github.ref }}"
+  env:
+    BAR: ${{ secrets.TOKEN }}
+`,
+			[]string{
+				`8:10: invalid Docker image reference "Alpine:latest" at "runs.image"`,
+				`12:18: context "github" is not allowed here. available context is "inputs"`,
+				`14:14: context "secrets" is not allowed here. available context is "inputs"`,
+				`9:19: context "inputs" is not allowed here. no context is available here`,
+			},
+		},
+		{
+			"Docker image not named Dockerfile",
+			"name: Test\ndescription: Test\nruns:\n  using: docker\n  image: docker/Containerfile\n",
+			[]string{`5:10: the local file "docker/Containerfile" at "runs.image" must be named "Dockerfile"`},
+		},
+		{
+			"branding",
+			"name: Test\ndescription: Test\nruns:\n  using: node24\n  main: index.js\nbranding:\n  icon: foo\n  color: rainbow\n  size: large\n",
+			[]string{
+				`7:9: incorrect icon name "foo" at branding.icon`,
+				`8:10: incorrect color "rainbow" at branding.color`,
+				`9:3: unexpected key "size" for "branding" section`,
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.what, func(t *testing.T) {
+			r := NewRuleActionMetadata()
+			_, errs := r.checkSource([]byte(tc.input), nil)
+			for _, err := range errs {
+				if err.Kind != "syntax-check" {
+					t.Errorf("unexpected kind %q of parse error %v", err.Kind, err)
+				}
+			}
+			for _, err := range r.Errs() {
+				if err.Kind != "action-metadata" {
+					t.Errorf("unexpected kind %q of error %v", err.Kind, err)
+				}
+			}
+			errs = append(errs, r.Errs()...)
+			if len(errs) != len(tc.want) {
+				t.Fatalf("wanted %d errors but got %d: %v", len(tc.want), len(errs), errs)
+			}
+			for i, err := range errs {
+				want := tc.want[i]
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("error #%d %q does not contain %q", i, err.Error(), want)
+				}
+			}
+		})
+	}
+}
+
+func TestRuleActionMetadataParseError(t *testing.T) {
+	r := NewRuleActionMetadata()
+	_, errs := r.checkSource([]byte("name: [\n"), nil)
+	if len(errs) != 1 || errs[0].Kind != "syntax-check" || !strings.Contains(errs[0].Message, "could not parse as YAML") {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+
+	_, errs = r.checkSource([]byte("name: foo\n"), &ParseLimits{MaxInputSize: 3})
+	if len(errs) != 1 || errs[0].Kind != "resource-limit" || !strings.Contains(errs[0].Message, "action metadata file is too large") {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+}
+
+func TestLinterLintActionMetadataFiles(t *testing.T) {
+	files := map[string]string{
+		".github/workflows/test.yaml":      "on: push\njobs:\n  test:\n    runs-on: ubuntu-latest\n    steps:\n      - uses: ./.github/actions/local\n",
+		".github/workflows/action.yml":     "on: push\njobs:\n  test:\n    runs-on: ubuntu-latest\n    steps:\n      - run: echo\n",
+		".github/actions/local/action.yml": "name: Local\ndescription: Local action\nruns:\n  using: composite\n  steps:\n    - run: echo\n      shell: bash\n",
+		"action.yaml":                      "name: Root\nruns:\n  using: node24\n  main: index.js\n",
+		"index.js":                         "",
+		"node_modules/foo/action.yml":      "name: Ignored\n",
+		"testdata/action.yml":              "name: Ignored\n",
+		".hidden/action.yml":               "name: Ignored\n",
+	}
+	fsys := fstest.MapFS{}
+	for p, c := range files {
+		fsys[p] = &fstest.MapFile{Data: []byte(c)}
+	}
+	root := t.TempDir()
+	files[".git/HEAD"] = "ref: refs/heads/main\n"
+	testWriteFiles(t, root, files)
+
+	check := func(t *testing.T, errs []*Error, err error) {
+		t.Helper()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(errs) != 1 {
+			t.Fatalf("wanted one error but got %v", errs)
+		}
+		if e := errs[0]; e.Kind != "action-metadata" || filepath.Base(e.Filepath) != "action.yaml" || !strings.Contains(e.Message, `"description" is required`) {
+			t.Fatalf("unexpected error: %v", e)
+		}
+	}
+
+	t.Run("repository", func(t *testing.T) {
+		l, err := NewLinter(io.Discard, &LinterOptions{Shellcheck: "", Pyflakes: ""})
+		if err != nil {
+			t.Fatal(err)
+		}
+		errs, err := l.LintRepository(root)
+		check(t, errs, err)
+	})
+
+	t.Run("file system", func(t *testing.T) {
+		l, err := NewLinter(io.Discard, &LinterOptions{Shellcheck: "", Pyflakes: ""})
+		if err != nil {
+			t.Fatal(err)
+		}
+		errs, err := l.LintFS(fsys, ".")
+		check(t, errs, err)
+	})
+
+	t.Run("file", func(t *testing.T) {
+		l, err := NewLinter(io.Discard, &LinterOptions{Shellcheck: "", Pyflakes: ""})
+		if err != nil {
+			t.Fatal(err)
+		}
+		errs, err := l.LintFiles([]string{filepath.Join(root, "action.yaml"), filepath.Join(root, "node_modules", "foo", "action.yml")}, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// Files given explicitly are checked even if they are in the directories which are not searched
+		if len(errs) != 3 {
+			t.Fatalf("wanted 3 errors but got %v", errs)
+		}
+	})
+}
+
+func TestLinterLintCompositeActionSteps(t *testing.T) {
+	src := `name: Test
+description: Test
+inputs:
+  foo:
+    description: foo
+outputs:
+  out:
+    description: out
+    value: ${{ steps.bar.outputs.out }}
+  typo:
+    description: typo
+    value: ${{ steps.unknown.outputs.out }}
+runs:
+  using: composite
+  steps:
+    - id: bar
+      run: echo "${{ inputs.foo }} ${{ inputs.unknown }}"
+      shell: bash
+    - run: echo "$TOKEN $FOO $NEEDS"
+      shell: bash
+      env:
+        TOKEN: ${{ secrets.TOKEN }}
+        FOO: ${{ vars.FOO }}
+        NEEDS: ${{ toJSON(needs) }}
+    - run: echo "${{ matrix.os }} ${{ runner.os }} ${{ github.ref }}"
+      shell: bash
+    - uses: actions/checkout@v5
+      with:
+        typo: true
+    - uses: foo
+`
+	l, err := NewLinter(io.Discard, &LinterOptions{Shellcheck: "", Pyflakes: ""})
+	if err != nil {
+		t.Fatal(err)
+	}
+	errs, err := l.Lint("action.yml", []byte(src), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		`12:16: property "unknown" is not defined in object type`,
+		`17:40: property "unknown" is not defined in object type`,
+		`22:20: context "secrets" is not allowed here`,
+		`23:18: context "vars" is not allowed here`,
+		`24:27: context "needs" is not allowed here`,
+		`29:9: input "typo" is not defined in action "actions/checkout@v5"`,
+		`30:13: specifying action "foo" in invalid format`,
+	}
+	if len(errs) != len(want) {
+		t.Fatalf("wanted %d errors but got %d: %v", len(want), len(errs), errs)
+	}
+	for i, err := range errs {
+		if !strings.Contains(err.Error(), want[i]) {
+			t.Errorf("error #%d %q does not contain %q", i, err.Error(), want[i])
+		}
+	}
+}
+
+func TestLinterLintCompositeActionRunStepOutputs(t *testing.T) {
+	src := `name: Test
+description: Test
+outputs:
+  ok:
+    description: ok
+    value: ${{ steps.set.outputs.version }}-${{ steps.set.outputs.Sha }}
+  typo:
+    description: typo
+    value: ${{ steps.set.outputs.verison }}
+  unknown:
+    description: unknown
+    value: ${{ steps.other.outputs.anything }}
+  script:
+    description: script
+    value: ${{ steps.script.outputs.anything }}
+runs:
+  using: composite
+  steps:
+    - id: set
+      run: |
+        echo "version=$(cat VERSION)" >> "$GITHUB_OUTPUT"
+        echo sha=${GITHUB_SHA} >> $GITHUB_OUTPUT
+      shell: bash
+    - id: other
+      run: |
+        {
+          echo 'anything<<EOF'
+          cat foo.txt
+          echo EOF
+        } >> "$GITHUB_OUTPUT"
+      shell: bash
+    - id: script
+      run: ./set-outputs.sh
+      shell: bash
+`
+	l, err := NewLinter(io.Discard, &LinterOptions{Shellcheck: "", Pyflakes: ""})
+	if err != nil {
+		t.Fatal(err)
+	}
+	errs, err := l.Lint("action.yml", []byte(src), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Outputs of "other" and "script" steps cannot be detected statically
+	if len(errs) != 1 || !strings.Contains(errs[0].Error(), `9:16: property "verison" is not defined in object type {sha: string; version: string}`) {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+}
+
+func TestLinterLintCompositeActionUnusedInputs(t *testing.T) {
+	inputs := `name: Test
+description: Test
+inputs:
+  foo:
+    description: foo
+  bar:
+    description: bar
+  piyo:
+    description: piyo
+  unused:
+    description: unused
+outputs:
+  out:
+    description: out
+    value: ${{ inputs.piyo }}
+runs:
+  using: composite
+  steps:
+`
+	for _, tc := range []struct {
+		what  string
+		steps string
+		want  []string
+	}{
+		{
+			what:  "unused input",
+			steps: "    - run: echo \"${{ inputs.FOO }}\"\n      shell: bash\n      if: inputs['bar'] != ''\n",
+			want:  []string{`10:3: input "unused" is defined but never used via "inputs" context in the composite action`},
+		},
+		{
+			what:  "unused and undefined inputs",
+			steps: "    - run: echo \"${{ inputs.foo }} ${{ inputs.unknown }}\"\n      shell: bash\n",
+			want: []string{
+				`6:3: input "bar" is defined but never used via "inputs" context in the composite action`,
+				`10:3: input "unused" is defined but never used via "inputs" context in the composite action`,
+				`19:40: property "unknown" is not defined in object type`,
+			},
+		},
+		{
+			what:  "entire inputs object",
+			steps: "    - run: echo \"$INPUTS\"\n      shell: bash\n      env:\n        INPUTS: ${{ toJSON(inputs) }}\n",
+			want:  []string{},
+		},
+	} {
+		t.Run(tc.what, func(t *testing.T) {
+			l, err := NewLinter(io.Discard, &LinterOptions{Shellcheck: "", Pyflakes: ""})
+			if err != nil {
+				t.Fatal(err)
+			}
+			errs, err := l.Lint("action.yml", []byte(inputs+tc.steps), nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(errs) != len(tc.want) {
+				t.Fatalf("wanted %d errors but got %d: %v", len(tc.want), len(errs), errs)
+			}
+			for i, err := range errs {
+				if !strings.Contains(err.Error(), tc.want[i]) {
+					t.Errorf("error #%d %q does not contain %q", i, err.Error(), tc.want[i])
+				}
+				if strings.Contains(tc.want[i], "never used") && err.Severity != SeverityWarning {
+					t.Errorf("error #%d should be warning: %v", i, err)
+				}
+			}
+		})
+	}
+}
+
+func TestRuleActionMetadataBrandingSuggestions(t *testing.T) {
+	src := "name: Test\ndescription: Test\nruns:\n  using: node24\n  main: index.js\nbranding:\n  icon: actvity\n  color: 'grey-dark'\n"
+	r := NewRuleActionMetadata()
+	if _, errs := r.checkSource([]byte(src), nil); len(errs) > 0 {
+		t.Fatal(errs)
+	}
+	errs := r.Errs()
+	if len(errs) != 2 {
+		t.Fatalf("wanted 2 errors but got %v", errs)
+	}
+	for i, want := range []string{
+		`7:9: incorrect icon name "actvity" at branding.icon. did you mean "activity"? see`,
+		`8:10: incorrect color "grey-dark" at branding.color. did you mean "gray-dark"? see`,
+	} {
+		if !strings.Contains(errs[i].Error(), want) {
+			t.Errorf("error #%d %q does not contain %q", i, errs[i].Error(), want)
+		}
+	}
+
+	var fixes []*TextEdit
+	for _, err := range errs {
+		fixes = append(fixes, err.Fixes...)
+	}
+	b, err := ApplyTextEdits([]byte(src), fixes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := strings.NewReplacer("actvity", "activity", "grey-dark", "gray-dark").Replace(src)
+	if string(b) != want {
+		t.Fatalf("wanted %q but got %q", want, b)
+	}
+}
+
+func TestRuleActionMetadataDockerImageRef(t *testing.T) {
+	for _, ref := range []string{
+		"alpine",
+		"alpine:3.8",
+		"library/alpine:latest",
+		"ghcr.io/owner/image:v1",
+		"localhost:5000/foo/bar_baz-qux",
+		"alpine@sha256:" + strings.Repeat("a", 64),
+	} {
+		if !dockerImageRefPattern.MatchString(ref) {
+			t.Errorf("%q should be valid image reference", ref)
+		}
+	}
+	for _, ref := range []string{
+		"",
+		"Alpine",
+		"alpine:",
+		"alpine@sha256:xyz",
+		"-alpine",
+		"foo//bar",
+	} {
+		if dockerImageRefPattern.MatchString(ref) {
+			t.Errorf("%q should be invalid image reference", ref)
+		}
+	}
+}
+
+func TestRuleActionMetadataFilesNotFound(t *testing.T) {
+	dir := t.TempDir()
+	testWriteFiles(t, dir, map[string]string{
+		"dist/index.js": "",
+		"dist/post.js":  "",
+	})
+	src := "name: Test\ndescription: Test\nruns:\n  using: node24\n  main: dist/index.js\n  pre: dist/pre.js\n  post: dist/post.js\n"
+
+	r := NewRuleActionMetadata()
+	r.dir = dir
+	if _, errs := r.checkSource([]byte(src), nil); len(errs) > 0 {
+		t.Fatal(errs)
+	}
+	errs := r.Errs()
+	if len(errs) != 1 {
+		t.Fatalf("wanted one error but got %v", errs)
+	}
+	want := fmt.Sprintf(`6:8: file %q at "runs.pre" does not exist in %q`, filepath.Join("dist", "pre.js"), dir)
+	if !strings.Contains(errs[0].Error(), want) {
+		t.Fatalf("error %q does not contain %q", errs[0].Error(), want)
+	}
+
+	// Files are not checked when the directory is unknown
+	r = NewRuleActionMetadata()
+	if _, errs := r.checkSource([]byte(src), nil); len(errs) > 0 {
+		t.Fatal(errs)
+	}
+	if errs := r.Errs(); len(errs) > 0 {
+		t.Fatal(errs)
+	}
+}
+
+func TestLinterLintDockerActionWithHadolint(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake hadolint command is a shell script")
+	}
+
+	// Fake hadolint command reports untagged image when the Dockerfile given via stdin uses "latest"
+	exe := filepath.Join(t.TempDir(), "hadolint")
+	script := `#!/bin/sh
+[ "$1" = "--format" ] && [ "$2" = "json" ] && [ "$4" = "-" ] || exit 2
+if grep -q ':latest' -; then
+  echo '[{"code":"DL3007","column":1,"file":"-","level":"warning","line":1,"message":"Using latest is prone to errors."}]'
+else
+  echo '[]'
+fi
+`
+	if err := os.WriteFile(exe, []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	dir := t.TempDir()
+	testWriteFiles(t, dir, map[string]string{
+		"ok/action.yml":         "name: OK\ndescription: OK\nruns:\n  using: docker\n  image: Dockerfile\n",
+		"ok/Dockerfile":         "FROM alpine:3.8\n",
+		"bad/action.yml":        "name: Bad\ndescription: Bad\nruns:\n  using: docker\n  image: ./docker/Dockerfile\n",
+		"bad/docker/Dockerfile": "FROM alpine:latest\n",
+		"missing/action.yml":    "name: Missing\ndescription: Missing\nruns:\n  using: docker\n  image: Dockerfile\n",
+	})
+
+	l, err := NewLinter(io.Discard, &LinterOptions{Hadolint: exe, Shellcheck: "", Pyflakes: ""})
+	if err != nil {
+		t.Fatal(err)
+	}
+	errs, err := l.LintFiles([]string{
+		filepath.Join(dir, "ok", "action.yml"),
+		filepath.Join(dir, "bad", "action.yml"),
+		filepath.Join(dir, "missing", "action.yml"),
+	}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		`5:10: hadolint reported issue in Dockerfile ` + fmt.Sprintf("%q", filepath.Join("docker", "Dockerfile")) + `: DL3007:warning:1:1: Using latest is prone to errors [hadolint]`,
+		`5:10: file "Dockerfile" at "runs.image" does not exist in `,
+	}
+	if len(errs) != len(want) {
+		t.Fatalf("wanted %d errors but got %v", len(want), errs)
+	}
+	for i, err := range errs {
+		if !strings.Contains(err.Error(), want[i]) {
+			t.Errorf("error #%d %q does not contain %q", i, err.Error(), want[i])
+		}
+	}
+	if errs[0].Code != "DL3007" || errs[0].Severity != SeverityWarning {
+		t.Errorf("unexpected code and severity: %#v", errs[0])
+	}
+}
@@ -0,0 +1,135 @@
+package actionlint
+
+import (
+	"regexp"
+	"strings"
+)
+
+// `set NAME = value` defines variable "NAME " with value " value" in cmd.exe. "set /a" is excluded
+// since it allows spaces around "=".
+var cmdSetWithSpacePattern = regexp.MustCompile(`(?i)^@?set\s+"?([A-Za-z_][\w.-]*)\s+=`)
+
+// Variable references in Bash or PowerShell syntax like $FOO, ${FOO}, or $env:FOO.
+var cmdForeignVarRefPattern = regexp.MustCompile(`\$(?:env:([A-Za-z_]\w*)|\{([A-Za-z_]\w*)\}|([A-Z_][A-Z0-9_]*)\b)`)
+
+// Commands which run other interpreters. Their arguments may contain the syntax of the interpreters.
+var cmdForeignInterpreters = map[string]struct{}{
+	"bash":       {},
+	"sh":         {},
+	"wsl":        {},
+	"pwsh":       {},
+	"powershell": {},
+	"python":     {},
+	"python3":    {},
+	"node":       {},
+	"perl":       {},
+	"ruby":       {},
+}
+
+// RuleCmdScript is a rule to check scripts at 'run:' run by cmd.exe with "shell: cmd". Since no
+// linter is widely available for batch files, this rule checks some common mistakes such as using
+// the syntax of Bash in cmd.exe scripts.
+type RuleCmdScript struct {
+	RuleBase
+	workflowShell string
+	jobShell      string
+}
+
+// NewRuleCmdScript creates new RuleCmdScript instance.
+func NewRuleCmdScript() *RuleCmdScript {
+	return &RuleCmdScript{
+		RuleBase: RuleBase{
+			name: "cmd-script",
+			desc: "Checks for common mistakes in \"run:\" scripts run by cmd.exe with \"shell: cmd\"",
+		},
+	}
+}
+
+// VisitWorkflowPre is callback when visiting Workflow node before visiting its children.
+func (rule *RuleCmdScript) VisitWorkflowPre(n *Workflow) error {
+	if n.Defaults != nil && n.Defaults.Run != nil && n.Defaults.Run.Shell != nil {
+		rule.workflowShell = n.Defaults.Run.Shell.Value
+	}
+	return nil
+}
+
+// VisitWorkflowPost is callback when visiting Workflow node after visiting its children.
+func (rule *RuleCmdScript) VisitWorkflowPost(n *Workflow) error {
+	rule.workflowShell = ""
+	return nil
+}
+
+// VisitJobPre is callback when visiting Job node before visiting its children.
+func (rule *RuleCmdScript) VisitJobPre(n *Job) error {
+	if n.Defaults != nil && n.Defaults.Run != nil && n.Defaults.Run.Shell != nil {
+		rule.jobShell = n.Defaults.Run.Shell.Value
+	}
+	return nil
+}
+
+// VisitJobPost is callback when visiting Job node after visiting its children.
+func (rule *RuleCmdScript) VisitJobPost(n *Job) error {
+	rule.jobShell = ""
+	return nil
+}
+
+// VisitStep is callback when visiting Step node.
+func (rule *RuleCmdScript) VisitStep(n *Step) error {
+	e, ok := n.Exec.(*ExecRun)
+	if !ok || e.Run == nil {
+		return nil
+	}
+
+	shell := rule.workflowShell
+	if e.Shell != nil {
+		shell = e.Shell.Value
+	} else if rule.jobShell != "" {
+		shell = rule.jobShell
+	}
+	if shell != "cmd" {
+		return nil
+	}
+
+	lines := strings.Split(sanitizeExpressionsInScript(e.Run.Value), "\n")
+	for i, line := range lines {
+		line = strings.TrimSpace(line)
+		if line == "" || line[0] == ':' || strings.HasPrefix(strings.ToUpper(line), "REM ") || strings.EqualFold(line, "REM") {
+			continue
+		}
+		rule.checkLine(line, i+1, i+1 < len(lines) && strings.TrimSpace(lines[i+1]) != "", e.Run.Pos)
+	}
+	return nil
+}
+
+func (rule *RuleCmdScript) checkLine(line string, lnum int, continued bool, pos *Pos) {
+	if m := cmdSetWithSpacePattern.FindStringSubmatch(line); m != nil {
+		rule.Errorf(
+			pos,
+			"spaces around \"=\" at line %d are included in the name and the value of variable %q with \"set\" command in cmd.exe. remove the spaces like \"set %s=...\"",
+			lnum,
+			m[1],
+			m[1],
+		)
+	}
+
+	interpreter := false
+	for i, c := range commandNamesInLine(line) { // Defined at rule_os_command.go
+		if _, ok := cmdForeignInterpreters[c]; ok {
+			interpreter = true
+		}
+		if i == 0 && c == "export" {
+			rule.Errorf(pos, "\"export\" command at line %d is not available in cmd.exe. use \"set NAME=value\" to set variables", lnum)
+		}
+	}
+
+	if !interpreter {
+		if m := cmdForeignVarRefPattern.FindStringSubmatch(line); m != nil {
+			name := m[1] + m[2] + m[3]
+			rule.Errorf(pos, "variable reference %q at line %d is not available in cmd.exe. use \"%%%s%%\" to refer the variable", m[0], lnum, name)
+		}
+	}
+
+	if continued && strings.HasSuffix(line, ` \`) {
+		rule.Errorf(pos, "\"\\\" at end of line %d is not a line continuation in cmd.exe. use \"^\" instead", lnum)
+	}
+}
@@ -0,0 +1,164 @@
+package actionlint
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Properties of "github" context which have the same values across runs for different pull
+// requests.
+var concurrencySharedGitHubProps = map[string]struct{}{
+	"action":           {},
+	"action_path":      {},
+	"api_url":          {},
+	"base_ref":         {},
+	"event_name":       {},
+	"graphql_url":      {},
+	"job":              {},
+	"repository":       {},
+	"repository_id":    {},
+	"repository_owner": {},
+	"server_url":       {},
+	"workflow":         {},
+	"workflow_ref":     {},
+	"workflow_sha":     {},
+}
+
+// Contexts which have the same values across runs for different pull requests.
+var concurrencySharedContexts = map[string]struct{}{
+	"inputs":   {},
+	"matrix":   {},
+	"strategy": {},
+	"vars":     {},
+}
+
+var reConcurrencyPlaceholder = regexp.MustCompile(`\$\{\{\s*(.*?)\s*\}\}`)
+
+// RuleConcurrency is a rule to check "concurrency:" configurations of workflows and jobs. Job-level
+// concurrency groups which are the same as the workflow-level group cause deadlock. And concurrency
+// groups which don't distinguish pull requests cancel runs for other pull requests.
+// https://docs.github.com/en/actions/writing-workflows/choosing-what-your-workflow-does/control-the-concurrency-of-workflows-and-jobs
+type RuleConcurrency struct {
+	RuleBase
+	workflow *Concurrency
+	prEvent  string
+}
+
+// NewRuleConcurrency creates a new RuleConcurrency instance.
+func NewRuleConcurrency() *RuleConcurrency {
+	return &RuleConcurrency{
+		RuleBase: RuleBase{
+			name: "concurrency",
+			desc: "Checks for deadlock of concurrency groups and concurrency groups which cancel runs for other pull requests",
+		},
+	}
+}
+
+// VisitWorkflowPre is callback when visiting Workflow node before visiting its children.
+func (rule *RuleConcurrency) VisitWorkflowPre(n *Workflow) error {
+	rule.workflow = n.Concurrency
+	rule.prEvent = ""
+	for _, e := range n.On {
+		if w, ok := e.(*WebhookEvent); ok && w.Hook != nil {
+			if h := w.Hook.Value; h == "pull_request" || h == "pull_request_target" {
+				rule.prEvent = h
+				break
+			}
+		}
+	}
+	rule.checkPullRequestGroup(n.Concurrency)
+	return nil
+}
+
+// VisitJobPre is callback when visiting Job node before visiting its children.
+func (rule *RuleConcurrency) VisitJobPre(n *Job) error {
+	c := n.Concurrency
+	if c == nil || c.Group == nil {
+		return nil
+	}
+
+	if w := rule.workflow; w != nil && w.Group != nil && normalizeConcurrencyGroup(w.Group.Value) == normalizeConcurrencyGroup(c.Group.Value) {
+		rule.ErrorfWithRelated(
+			c.Group.Pos,
+			relatedAt(w.Group.Pos, "concurrency group of the workflow is here"),
+			"concurrency group %q of job %q is the same as the concurrency group of the workflow at %s. the job waits for the workflow run containing the job itself and GitHub cancels it as deadlock. use a different group for the job",
+			c.Group.Value,
+			n.ID.Value,
+			w.Group.Pos,
+		)
+		return nil
+	}
+
+	rule.checkPullRequestGroup(c)
+	return nil
+}
+
+func (rule *RuleConcurrency) checkPullRequestGroup(c *Concurrency) {
+	if rule.prEvent == "" || c == nil || c.Group == nil || concurrencyGroupDistinguishesRuns(c.Group.Value) {
+		return
+	}
+	rule.Errorf(
+		c.Group.Pos,
+		"concurrency group %q does not contain anything distinguishing pull requests though the workflow is triggered by %q event. runs for different pull requests share the group and cancel each other. add the ref to the group like \"%s-${{ github.ref }}\"",
+		c.Group.Value,
+		rule.prEvent,
+		strings.TrimSpace(c.Group.Value),
+	)
+}
+
+// normalizeConcurrencyGroup normalizes the group name to compare it with other group names. Group
+// names are case-insensitive.
+func normalizeConcurrencyGroup(g string) string {
+	g = reConcurrencyPlaceholder.ReplaceAllString(strings.TrimSpace(g), "${{ $1 }}")
+	return strings.ToLower(g)
+}
+
+// concurrencyGroupDistinguishesRuns returns whether the group name may have different values for
+// runs of different pull requests. It returns true when it is not sure.
+func concurrencyGroupDistinguishesRuns(g string) bool {
+	for {
+		i := strings.Index(g, "${{")
+		if i < 0 {
+			return false
+		}
+		g = g[i+3:]
+		l := NewExprLexer(g)
+		e, err := NewExprParser().Parse(l)
+		o := l.Offset()
+		l.Release()
+		if err != nil {
+			return true
+		}
+
+		found := false
+		VisitExprNode(e, func(n, p ExprNode, entering bool) {
+			v, ok := n.(*VariableNode)
+			if !ok || !entering {
+				return
+			}
+			ctx := strings.ToLower(v.Name)
+			if _, ok := concurrencySharedContexts[ctx]; ok {
+				return
+			}
+			if ctx == "github" {
+				prop := ""
+				switch p := p.(type) {
+				case *ObjectDerefNode:
+					prop = p.Property
+				case *IndexAccessNode:
+					if s, ok := p.Index.(*StringNode); ok {
+						prop = s.Value
+					}
+				}
+				if _, ok := concurrencySharedGitHubProps[strings.ToLower(prop)]; ok {
+					return
+				}
+			}
+			found = true
+		})
+		if found {
+			return true
+		}
+		g = g[o:]
+	}
+}
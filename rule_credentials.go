@@ -2,6 +2,7 @@ package actionlint
 
 import (
 	"fmt"
+	"strings"
 )
 
 // RuleCredentials is a rule to check credentials in workflows
@@ -14,7 +15,7 @@ func NewRuleCredentials() *RuleCredentials {
 	return &RuleCredentials{
 		RuleBase: RuleBase{
 			name: "credentials",
-			desc: "Checks for credentials in \"services:\" configuration",
+			desc: "Checks for credentials in \"container:\" and \"services:\" configurations",
 		},
 	}
 }
@@ -40,5 +41,52 @@ func (rule *RuleCredentials) checkContainer(where string, n *Container) {
 	p := n.Credentials.Password
 	if !p.IsExpressionAssigned() {
 		rule.Errorf(p.Pos, "\"password\" section in %s should be specified via secrets. do not put password value directly", where)
+		return
+	}
+
+	v := strings.TrimSpace(p.Value)
+	l := NewExprLexer(strings.TrimSpace(v[3:len(v)-2]) + "}}")
+	e, err := NewExprParser().Parse(l)
+	l.Release()
+	if err != nil {
+		return // Syntax error is reported by "expression" rule
 	}
+	// Constant expressions like ${{ 'dummy' }} are not checked since they are usually placeholders
+	if refersContexts(e) && !refersSecrets(e) {
+		rule.Errorf(p.Pos, "\"password\" section in %s should be specified via secrets but expression %q does not refer to \"secrets\" context. put the password in secrets and refer to it like \"${{ secrets.REGISTRY_PASSWORD }}\"", where, p.Value)
+	}
+}
+
+// refersContexts returns true when the expression refers to any context.
+func refersContexts(e ExprNode) bool {
+	found := false
+	VisitExprNode(e, func(n, p ExprNode, entering bool) {
+		if _, ok := n.(*VariableNode); ok {
+			found = true
+		}
+	})
+	return found
+}
+
+// refersSecrets returns true when the expression refers to "secrets" context or "github.token".
+func refersSecrets(e ExprNode) bool {
+	found := false
+	VisitExprNode(e, func(n, p ExprNode, entering bool) {
+		if !entering {
+			return
+		}
+		v, ok := n.(*VariableNode)
+		if !ok {
+			return
+		}
+		switch strings.ToLower(v.Name) {
+		case "secrets":
+			found = true
+		case "github":
+			if d, ok := p.(*ObjectDerefNode); ok && strings.EqualFold(d.Property, "token") {
+				found = true
+			}
+		}
+	})
+	return found
 }
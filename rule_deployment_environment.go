@@ -0,0 +1,106 @@
+package actionlint
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Actions which deploy something to cloud services or hosting services. Names are lower-cased.
+var deployActions = map[string]struct{}{
+	"actions/deploy-pages":                          {},
+	"akhileshns/heroku-deploy":                      {},
+	"amondnet/vercel-action":                        {},
+	"aws-actions/amazon-ecs-deploy-task-definition": {},
+	"aws-actions/aws-cloudformation-github-deploy":  {},
+	"azure/arm-deploy":                              {},
+	"azure/functions-action":                        {},
+	"azure/k8s-deploy":                              {},
+	"azure/static-web-apps-deploy":                  {},
+	"azure/webapps-deploy":                          {},
+	"cloudflare/pages-action":                       {},
+	"cloudflare/wrangler-action":                    {},
+	"google-github-actions/deploy-appengine":        {},
+	"google-github-actions/deploy-cloud-functions":  {},
+	"google-github-actions/deploy-cloudrun":         {},
+	"jamesives/github-pages-deploy-action":          {},
+	"nwtgck/actions-netlify":                        {},
+	"peaceiris/actions-gh-pages":                    {},
+}
+
+// "deploy" at start of a word like "Deploy to production" or "cf-deploy". "undeploy" does not match.
+var reDeployName = regexp.MustCompile(`(?i)(^|[^a-z])deploy`)
+
+// RuleDeploymentEnvironment is a rule to check jobs which look deploying something without
+// "environment:". Environments protect deployments with protection rules such as required reviewers
+// and the job bypasses them when "environment:" is missing. This rule is opt-in. It is enabled only
+// when "deployment-environment" is configured in the configuration file.
+// https://docs.github.com/en/actions/writing-workflows/workflow-syntax-for-github-actions#jobsjob_idenvironment
+type RuleDeploymentEnvironment struct {
+	RuleBase
+	actions map[string]struct{}
+}
+
+// NewRuleDeploymentEnvironment creates new RuleDeploymentEnvironment instance with the given
+// configuration.
+func NewRuleDeploymentEnvironment(cfg *DeploymentEnvironmentConfig) *RuleDeploymentEnvironment {
+	as := make(map[string]struct{}, len(deployActions)+len(cfg.Actions))
+	for a := range deployActions {
+		as[a] = struct{}{}
+	}
+	for _, a := range cfg.Actions {
+		as[strings.ToLower(a)] = struct{}{}
+	}
+	return &RuleDeploymentEnvironment{
+		RuleBase: RuleBase{
+			name: "deployment-environment",
+			desc: "Checks for jobs deploying something without \"environment:\". This rule is enabled by \"deployment-environment\" configuration",
+		},
+		actions: as,
+	}
+}
+
+// VisitJobPre is callback when visiting Job node before visiting its children.
+func (rule *RuleDeploymentEnvironment) VisitJobPre(n *Job) error {
+	if n.WorkflowCall != nil {
+		return nil // "environment:" is not available on calling a reusable workflow
+	}
+	if n.Environment != nil {
+		return nil
+	}
+
+	for _, s := range n.Steps {
+		what := rule.deployingStep(s)
+		if what == "" {
+			continue
+		}
+		rule.ErrorfWithRelated(
+			n.Pos,
+			relatedAt(s.Pos, "deploying step is here"),
+			"job %q looks deploying something with %s at %s but \"environment:\" is not set. the deployment bypasses protection rules of environments such as required reviewers. set \"environment:\" to the job",
+			n.ID.Value,
+			what,
+			s.Pos,
+		)
+		return nil
+	}
+	return nil
+}
+
+// deployingStep returns the description of the step when the step looks deploying something.
+// Otherwise it returns an empty string.
+func (rule *RuleDeploymentEnvironment) deployingStep(s *Step) string {
+	if e, ok := s.Exec.(*ExecAction); ok && e.Uses != nil && !e.Uses.ContainsExpression() {
+		name, _, _ := strings.Cut(e.Uses.Value, "@")
+		if _, ok := rule.actions[strings.ToLower(name)]; ok {
+			return fmt.Sprintf("action %q", name)
+		}
+	}
+	if s.Name != nil && reDeployName.MatchString(s.Name.Value) {
+		return fmt.Sprintf("step %q", s.Name.Value)
+	}
+	if s.ID != nil && reDeployName.MatchString(s.ID.Value) {
+		return fmt.Sprintf("step %q", s.ID.Value)
+	}
+	return ""
+}
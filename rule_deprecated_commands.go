@@ -1,9 +1,27 @@
 package actionlint
 
-import "regexp"
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
 
 var deprecatedCommandsPattern = regexp.MustCompile(`(?:::(save-state|set-output|set-env)\s+name=[a-zA-Z][a-zA-Z_-]*::\S+|::(add-path)::\S+)`)
 
+// Statement to print a deprecated command like `echo "::set-output name=foo::"`. It is followed by
+// the value of the command and the closing quote.
+var deprecatedCommandsEchoPattern = regexp.MustCompile(`(?:(?:^|[;&|({])[ \t]*|\b(?:then|do|else)[ \t]+)(echo|Write-Output|Write-Host)[ \t]+(["']?)::(?:(save-state|set-output|set-env)[ \t]+name=([a-zA-Z][a-zA-Z_-]*)|(add-path))::`)
+
+// deprecatedCommandsEnvFiles is a mapping from the deprecated commands to the environment variables
+// of the environment files which replace them.
+var deprecatedCommandsEnvFiles = map[string]string{
+	"set-output": "GITHUB_OUTPUT",
+	"save-state": "GITHUB_STATE",
+	"set-env":    "GITHUB_ENV",
+	"add-path":   "GITHUB_PATH",
+}
+
 // RuleDeprecatedCommands is a rule checker to detect deprecated workflow commands. Currently
 // 'set-state', 'set-output', `set-env' and 'add-path' are detected as deprecated.
 //
@@ -11,6 +29,12 @@ var deprecatedCommandsPattern = regexp.MustCompile(`(?:::(save-state|set-output|
 // - https://github.blog/changelog/2022-10-11-github-actions-deprecating-save-state-and-set-output-commands/
 type RuleDeprecatedCommands struct {
 	RuleBase
+	// lines is the lines of the workflow source. It is set only when fixing errors to rewrite the
+	// deprecated commands with environment files.
+	lines         []string
+	workflowShell string
+	jobShell      string
+	runnerShell   string
 }
 
 // NewRuleDeprecatedCommands creates a new RuleDeprecatedCommands instance.
@@ -26,6 +50,7 @@ func NewRuleDeprecatedCommands() *RuleDeprecatedCommands {
 // VisitStep is callback when visiting Step node.
 func (rule *RuleDeprecatedCommands) VisitStep(n *Step) error {
 	if r, ok := n.Exec.(*ExecRun); ok && r.Run != nil {
+		found := false
 		for _, m := range deprecatedCommandsPattern.FindAllStringSubmatch(r.Run.Value, -1) {
 			c := m[1]
 			if len(c) == 0 {
@@ -52,7 +77,188 @@ func (rule *RuleDeprecatedCommands) VisitStep(n *Step) error {
 				c,
 				a,
 			)
+			found = true
+		}
+		if found && rule.lines != nil {
+			rule.fixCommands(r)
 		}
 	}
 	return nil
 }
+
+// VisitJobPre is callback when visiting Job node before visiting its children.
+func (rule *RuleDeprecatedCommands) VisitJobPre(n *Job) error {
+	if n.Defaults != nil && n.Defaults.Run != nil && n.Defaults.Run.Shell != nil {
+		rule.jobShell = n.Defaults.Run.Shell.Value
+	}
+	if n.RunsOn != nil {
+		for _, label := range n.RunsOn.Labels {
+			// Default shell on Windows is PowerShell
+			if os, ok := runnerOSOfLabel(label.Value); ok && os == RunnerOSWindows {
+				rule.runnerShell = "pwsh"
+				break
+			}
+		}
+	}
+	return nil
+}
+
+// VisitJobPost is callback when visiting Job node after visiting its children.
+func (rule *RuleDeprecatedCommands) VisitJobPost(n *Job) error {
+	rule.jobShell = ""
+	rule.runnerShell = ""
+	return nil
+}
+
+// VisitWorkflowPre is callback when visiting Workflow node before visiting its children.
+func (rule *RuleDeprecatedCommands) VisitWorkflowPre(n *Workflow) error {
+	if n.Defaults != nil && n.Defaults.Run != nil && n.Defaults.Run.Shell != nil {
+		rule.workflowShell = n.Defaults.Run.Shell.Value
+	}
+	return nil
+}
+
+// VisitWorkflowPost is callback when visiting Workflow node after visiting its children.
+func (rule *RuleDeprecatedCommands) VisitWorkflowPost(n *Workflow) error {
+	rule.workflowShell = ""
+	return nil
+}
+
+func (rule *RuleDeprecatedCommands) shellName(exec *ExecRun) string {
+	s := "bash"
+	if exec.Shell != nil {
+		s = exec.Shell.Value
+	} else if rule.jobShell != "" {
+		s = rule.jobShell
+	} else if rule.workflowShell != "" {
+		s = rule.workflowShell
+	} else if rule.runnerShell != "" {
+		s = rule.runnerShell
+	}
+	if f := strings.Fields(s); len(f) > 0 {
+		return f[0] // Custom shell like "bash -e {0}"
+	}
+	return s
+}
+
+// fixCommands adds the edits to rewrite statements printing the deprecated commands like
+// `echo "::set-output name=foo::bar"` with environment files like `echo "foo=bar" >> "$GITHUB_OUTPUT"`.
+// Only the statements in bash, sh, pwsh, or powershell scripts are rewritten.
+func (rule *RuleDeprecatedCommands) fixCommands(exec *ExecRun) {
+	shell := rule.shellName(exec)
+	switch shell {
+	case "bash", "sh", "pwsh", "powershell":
+	default:
+		rule.Debug("Deprecated commands are not fixed since shell %q is not supported", shell)
+		return
+	}
+
+	run := exec.Run
+	for i, l := range strings.Split(run.Value, "\n") {
+		// Find the line in the source. Body of block scalar starts at the next line of "run:"
+		line, off := run.Pos.Line+1+i, -1
+		if i == 0 && 1 <= run.Pos.Line && run.Pos.Line <= len(rule.lines) {
+			if o := strings.Index(rule.lines[run.Pos.Line-1], l); o >= 0 {
+				line, off = run.Pos.Line, o
+			}
+		}
+		if off < 0 && line <= len(rule.lines) {
+			off = strings.Index(rule.lines[line-1], l)
+		}
+		if off < 0 {
+			continue // The line is folded or contains escapes in the source
+		}
+		src := rule.lines[line-1]
+
+		for _, m := range deprecatedCommandsEchoPattern.FindAllStringSubmatchIndex(l, -1) {
+			cmd, quote := l[m[2]:m[3]], l[m[4]:m[5]]
+			end, ok := deprecatedCommandsValueEnd(l, m[1], quote, shell)
+			if !ok {
+				continue
+			}
+			name := ""
+			var kind string
+			if m[6] >= 0 {
+				kind, name = l[m[6]:m[7]], l[m[8]:m[9]]
+			} else {
+				kind = l[m[10]:m[11]]
+			}
+			value := l[m[1]:end]
+			if quote != "" {
+				value = value[:len(value)-1] // Remove the closing quote
+			}
+			if name != "" {
+				value = name + "=" + value
+			}
+
+			file := deprecatedCommandsEnvFiles[kind]
+			if cmd == "Write-Host" {
+				cmd = "Write-Output" // Output of Write-Host cannot be redirected to file
+			}
+			var b strings.Builder
+			switch shell {
+			case "pwsh":
+				fmt.Fprintf(&b, "%s %s%s%s >> $env:%s", cmd, quote, value, quote, file)
+			case "powershell":
+				// Redirection with >> in Windows PowerShell writes the file in UTF-16
+				fmt.Fprintf(&b, "%s %s%s%s | Out-File -FilePath $env:%s -Encoding utf8 -Append", cmd, quote, value, quote, file)
+			default:
+				fmt.Fprintf(&b, "%s %s%s%s >> \"$%s\"", cmd, quote, value, quote, file)
+			}
+
+			col := utf8.RuneCountInString(src[:off+m[2]]) + 1
+			rule.Fix(&TextEdit{
+				Start:   &Pos{Line: line, Col: col},
+				End:     &Pos{Line: line, Col: col + utf8.RuneCountInString(l[m[2]:end])},
+				NewText: b.String(),
+			})
+		}
+	}
+}
+
+// deprecatedCommandsValueEnd returns the end offset of the value of the deprecated command which
+// starts at 'start' in the line. The offset includes the closing quote. It returns false when the
+// value is too complicated to rewrite or the statement does not end after the value, for example
+// when the output is piped to another command.
+func deprecatedCommandsValueEnd(l string, start int, quote, shell string) (int, bool) {
+	i := start
+	switch quote {
+	case "":
+		for i < len(l) && !strings.ContainsRune(" \t;&|", rune(l[i])) {
+			i++
+		}
+		if i == start || strings.ContainsAny(l[start:i], "()'\"\\`") {
+			return 0, false
+		}
+	case "'":
+		j := strings.IndexByte(l[i:], '\'')
+		if j < 0 {
+			return 0, false
+		}
+		i += j + 1
+	default:
+		esc := byte('\\')
+		if shell == "pwsh" || shell == "powershell" {
+			esc = '`'
+		}
+		for ; i < len(l) && l[i] != '"'; i++ {
+			if l[i] == esc {
+				i++
+			}
+		}
+		if i >= len(l) {
+			return 0, false
+		}
+		i++ // Eat the closing quote
+	}
+
+	rest := strings.TrimLeft(l[i:], " \t")
+	switch {
+	case rest == "", strings.HasPrefix(rest, ";"), strings.HasPrefix(rest, "&&"), strings.HasPrefix(rest, "||"):
+		return i, true
+	case strings.HasPrefix(rest, "#") && len(rest) < len(l[i:]):
+		return i, true // Comment
+	default:
+		return 0, false
+	}
+}
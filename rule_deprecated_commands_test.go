@@ -2,6 +2,7 @@ package actionlint
 
 import (
 	"regexp"
+	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -123,3 +124,111 @@ func TestRuleDeprecatedCommandsDetectTargetCommands(t *testing.T) {
 		})
 	}
 }
+
+func TestRuleDeprecatedCommandsFix(t *testing.T) {
+	tests := []struct {
+		what string
+		src  string
+		want string
+	}{
+		{
+			what: "bash",
+			src: `on: push
+jobs:
+  test:
+    runs-on: ubuntu-latest
+    steps:
+      - run: |
+          echo "::set-output name=foo::$FOO"
+          echo '::save-state name=bar::42' # comment
+          if true; then echo ::set-env name=BAZ::baz; fi
+          echo "::add-path::/path/to/bin" && echo done
+      - run: echo "::set-output name=foo::42"
+`,
+			want: `on: push
+jobs:
+  test:
+    runs-on: ubuntu-latest
+    steps:
+      - run: |
+          echo "foo=$FOO" >> "$GITHUB_OUTPUT"
+          echo 'bar=42' >> "$GITHUB_STATE" # comment
+          if true; then echo BAZ=baz >> "$GITHUB_ENV"; fi
+          echo "/path/to/bin" >> "$GITHUB_PATH" && echo done
+      - run: echo "foo=42" >> "$GITHUB_OUTPUT"
+`,
+		},
+		{
+			what: "pwsh on Windows",
+			src: `on: push
+jobs:
+  test:
+    runs-on: windows-latest
+    steps:
+      - run: |
+          Write-Host "::set-output name=foo::$env:FOO"
+          echo "::set-output name=bar::42"
+      - run: echo "::set-output name=foo::42"
+        shell: powershell
+`,
+			want: `on: push
+jobs:
+  test:
+    runs-on: windows-latest
+    steps:
+      - run: |
+          Write-Output "foo=$env:FOO" >> $env:GITHUB_OUTPUT
+          echo "bar=42" >> $env:GITHUB_OUTPUT
+      - run: echo "foo=42" | Out-File -FilePath $env:GITHUB_OUTPUT -Encoding utf8 -Append
+        shell: powershell
+`,
+		},
+		{
+			what: "not rewritten",
+			src: `on: push
+jobs:
+  test:
+    runs-on: ubuntu-latest
+    steps:
+      - run: |
+          echo "::set-output name=foo::42" | tee out.txt
+          echo ::set-output name=foo::$(date)
+          echo -n "::set-output name=foo::42"
+          printf '::set-output name=foo::%s\n' 42
+      - run: print('::set-output name=foo::42')
+        shell: python
+`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.what, func(t *testing.T) {
+			w, errs := Parse([]byte(tc.src))
+			if len(errs) > 0 {
+				t.Fatal(errs)
+			}
+			r := NewRuleDeprecatedCommands()
+			r.lines = strings.Split(tc.src, "\n")
+			v := NewVisitor()
+			v.AddPass(r)
+			if err := v.Visit(w); err != nil {
+				t.Fatal(err)
+			}
+			if len(r.Errs()) == 0 {
+				t.Fatal("no error was reported")
+			}
+
+			have, err := ApplyTextEdits([]byte(tc.src), r.Fixes())
+			if err != nil {
+				t.Fatal(err)
+			}
+			want := tc.want
+			if want == "" {
+				want = tc.src
+			}
+			if diff := cmp.Diff(want, string(have)); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,450 @@
+package actionlint
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+)
+
+// RuleDocOption is a configuration option of a rule described in its documentation.
+type RuleDocOption struct {
+	// Name is the name of the option like "timeout-minutes.max".
+	Name string `yaml:"name"`
+	// Description is the description of the option.
+	Description string `yaml:"description"`
+	// Default is the default value of the option. Empty string means no default value.
+	Default string `yaml:"default"`
+}
+
+// RuleDocExample is an example of workflow checked by a rule described in its documentation.
+type RuleDocExample struct {
+	// Title is the title of the example.
+	Title string `yaml:"title"`
+	// Workflow is the source of the example workflow.
+	Workflow string `yaml:"workflow"`
+	// Output is the output of actionlint for the example workflow. Empty string means the output is
+	// not shown.
+	Output string `yaml:"output"`
+}
+
+// RuleDoc is documentation of a rule rendered by "actionlint docs" subcommand.
+type RuleDoc struct {
+	// Name is the name of the rule like "expression".
+	Name string `yaml:"-"`
+	// Description is the one-line description of the rule.
+	Description string `yaml:"description"`
+	// Details is the details of the rule in Markdown.
+	Details string `yaml:"details"`
+	// URL is the URL of the external document of the rule.
+	URL string `yaml:"url"`
+	// Options is the configuration options of the rule.
+	Options []*RuleDocOption `yaml:"options"`
+	// Examples is the examples of workflows checked by the rule.
+	Examples []*RuleDocExample `yaml:"examples"`
+	// Builtin is true when the rule is built in actionlint.
+	Builtin bool `yaml:"-"`
+}
+
+// RuleDocumenter is an interface for rules which provide their documentation. Custom rules can
+// implement this interface to document the details, the configuration options, and the examples.
+// Name and Description in the returned documentation are filled with Rule.Name and
+// Rule.Description when they are empty.
+type RuleDocumenter interface {
+	Doc() *RuleDoc
+}
+
+const builtinRuleDocsURL = "https://github.com/rhysd/actionlint/blob/main/docs/checks.md#"
+
+// builtinRuleDocs is the documentation of built-in rules. The descriptions must be the same as
+// Rule.Description of the rules. The details of each rule are described in docs/checks.md.
+var builtinRuleDocs = []*RuleDoc{
+	{
+		Name:        "act",
+		Description: "Checks for errors while running workflows in dry-run mode with nektos/act",
+		Details:     "This rule is enabled by `-act` flag.",
+		URL:         "check-act-integ",
+	},
+	{
+		Name:        "action",
+		Description: "Checks for popular actions released on GitHub, local actions, and action calls at \"uses:\"",
+		URL:         "check-popular-action-inputs",
+		Options: []*RuleDocOption{
+			{"action-metadata", "File paths of action metadata files describing actions which are not in the popular actions data set", ""},
+		},
+	},
+	{
+		Name:        "action-metadata",
+		Description: "Checks for schema of action metadata files action.yml and action.yaml",
+		URL:         "action-metadata-syntax",
+	},
+	{
+		Name:        "cmd-script",
+		Description: "Checks for common mistakes in \"run:\" scripts run by cmd.exe with \"shell: cmd\"",
+		URL:         "check-cmd-scripts",
+	},
+	{
+		Name:        "concurrency",
+		Description: "Checks for deadlock of concurrency groups and concurrency groups which cancel runs for other pull requests",
+		URL:         "check-concurrency-groups",
+	},
+	{
+		Name:        "credentials",
+		Description: "Checks for credentials in \"container:\" and \"services:\" configurations",
+		URL:         "check-hardcoded-credentials",
+	},
+	{
+		Name:        "deployment-environment",
+		Description: "Checks for jobs deploying something without \"environment:\". This rule is enabled by \"deployment-environment\" configuration",
+		URL:         "check-deployment-environment",
+		Options: []*RuleDocOption{
+			{"deployment-environment.actions", "Names of actions which deploy something in addition to the well-known deploy actions", ""},
+		},
+	},
+	{
+		Name:        "deprecated-commands",
+		Description: "Checks for deprecated \"set-output\", \"save-state\", \"set-env\", and \"add-path\" commands at \"run:\"",
+		URL:         "check-deprecated-workflow-commands",
+	},
+	{
+		Name:        "env-file",
+		Description: "Checks for syntax of writes to \"$GITHUB_ENV\" and \"$GITHUB_PATH\" environment files at \"run:\"",
+		URL:         "check-env-files",
+	},
+	{
+		Name:        "env-var",
+		Description: "Checks for environment variables configuration at \"env:\"",
+		URL:         "check-env-var-names",
+	},
+	{
+		Name:        "environment",
+		Description: "Checks for environment names at \"environment:\" configured in the repository. This rule is enabled by online checks",
+		Details:     "This rule is enabled by `-online` flag.",
+		URL:         "online-checks",
+	},
+	{
+		Name:        "environment-url",
+		Description: "Checks for URLs at \"environment.url\" of jobs",
+		URL:         "check-environment-url",
+	},
+	{
+		Name:        "events",
+		Description: "Checks for workflow trigger events at \"on:\"",
+		URL:         "check-webhook-events",
+	},
+	{
+		Name:        "expression",
+		Description: "Syntax and semantics checks for expressions embedded with ${{ }} syntax",
+		URL:         "check-type-check-expression",
+		Options: []*RuleDocOption{
+			{"config-variables", "Names of configuration variables. When this is not set, property names of `vars` context are not checked", ""},
+			{"environment-config-variables", "Names of configuration variables defined in each environment", ""},
+		},
+	},
+	{
+		Name:        "ghes",
+		Description: "Checks for features not available in the version of GitHub Enterprise Server. This rule is enabled by \"-ghes\" flag or \"ghes\" configuration",
+		URL:         "check-ghes-compatibility",
+		Options: []*RuleDocOption{
+			{"ghes", "Version of GitHub Enterprise Server which runs the workflows", ""},
+		},
+	},
+	{
+		Name:        "glob",
+		Description: "Checks for glob syntax used in branch names, tags, and paths",
+		URL:         "check-glob-pattern",
+	},
+	{
+		Name:        "hadolint",
+		Description: "Checks for Dockerfile of Docker actions using hadolint",
+		Details:     "This rule is enabled by `-hadolint` flag.",
+		URL:         "action-metadata-syntax",
+	},
+	{
+		Name:        "id",
+		Description: "Checks for duplication and naming convention of job/step IDs",
+		URL:         "check-job-step-ids",
+	},
+	{
+		Name:        "if-cond",
+		Description: "Checks for if: conditions which are always true/false",
+		URL:         "if-cond-constant",
+	},
+	{
+		Name:        "inline-script",
+		Description: "Checks for large scripts at \"run:\" which should be moved to script files. This rule is enabled by \"inline-script\" configuration",
+		URL:         "check-inline-scripts",
+		Options: []*RuleDocOption{
+			{"inline-script.max-lines", "Maximum number of lines of a script at `run:`. 0 means no maximum", "0"},
+			{"inline-script.max-bytes", "Maximum size of a script at `run:` in bytes. 0 means no maximum", "0"},
+		},
+	},
+	{
+		Name:        "job-needs",
+		Description: "Checks for job IDs in \"needs:\". Undefined IDs, cyclic dependencies, and jobs which never run are checked",
+		URL:         "check-job-deps",
+	},
+	{
+		Name:        "limits",
+		Description: "Checks for the documented limits of GitHub Actions such as the number of matrix jobs, length of expressions, or length of names",
+		URL:         "check-platform-limits",
+	},
+	{
+		Name:        "matrix",
+		Description: "Checks for matrix combinations in \"matrix:\"",
+		URL:         "check-matrix-values",
+	},
+	{
+		Name:        "naming-convention",
+		Description: "Checks for names of workflows, jobs, steps, inputs, and outputs following naming convention. This rule is enabled by \"naming-convention\" configuration",
+		URL:         "check-naming-convention",
+		Options: []*RuleDocOption{
+			{"naming-convention.workflow-name", "Regular expression which workflow names at `name:` must match", ""},
+			{"naming-convention.job-id", "Regular expression which job IDs must match", ""},
+			{"naming-convention.step-id", "Regular expression which step IDs at `id:` must match", ""},
+			{"naming-convention.input-name", "Regular expression which input names must match", ""},
+			{"naming-convention.output-name", "Regular expression which output names must match", ""},
+		},
+	},
+	{
+		Name:        "os-command",
+		Description: "Checks for OS-specific commands in \"run:\" scripts which are not available on the OS of the runner. This rule is enabled by \"os-commands\" configuration",
+		URL:         "check-os-specific-commands",
+		Options: []*RuleDocOption{
+			{"os-commands", "Report commands in `run:` scripts which are not available on the OS of the runner", "false"},
+		},
+	},
+	{
+		Name:        "outdated-action",
+		Description: "Checks for actions at \"uses:\" behind their latest versions. This rule is enabled by \"outdated-actions\" configuration and online checks",
+		Details:     "This rule is enabled by `-online` flag with `outdated-actions` configuration.",
+		URL:         "check-outdated-actions",
+		Options: []*RuleDocOption{
+			{"outdated-actions.major-only", "Report only actions whose major versions are behind the latest ones", "false"},
+			{"outdated-actions.allow", "Patterns of actions which are not checked like \"my-org/*\"", ""},
+		},
+	},
+	{
+		Name:        "permissions",
+		Description: "Checks for permissions configuration in \"permissions:\". Permission names and permission scopes are checked",
+		URL:         "permissions",
+		Options: []*RuleDocOption{
+			{"require-permissions", "Report workflows whose jobs don't set `permissions:` at both workflow level and job level", "false"},
+		},
+	},
+	{
+		Name:        "platform",
+		Description: "Checks for features not supported by Gitea Actions or Forgejo Actions. This rule is enabled by \"-platform\" flag or \"platform\" configuration",
+		URL:         "check-platform-compatibility",
+		Options: []*RuleDocOption{
+			{"platform", "Platform which runs the workflows. One of \"github\", \"gitea\", or \"forgejo\"", "github"},
+		},
+	},
+	{
+		Name:        "problem-matcher",
+		Description: "Checks for problem matcher files added by \"::add-matcher::\" command at \"run:\"",
+		URL:         "check-problem-matchers",
+	},
+	{
+		Name:        "psscriptanalyzer",
+		Description: "Checks for PowerShell script sources in \"run:\" using PSScriptAnalyzer",
+		Details:     "This rule is enabled by `-psscriptanalyzer` flag.",
+		URL:         "check-psscriptanalyzer-integ",
+	},
+	{
+		Name:        "pyflakes",
+		Description: "Checks for Python script when \"shell: python\" is configured using Pyflakes",
+		URL:         "check-pyflakes-integ",
+	},
+	{
+		Name:        "runner-label",
+		Description: "Checks for GitHub-hosted and preset self-hosted runner labels in \"runs-on:\"",
+		URL:         "check-runner-labels",
+		Options: []*RuleDocOption{
+			{"self-hosted-runner.labels", "Label names of self-hosted runners", ""},
+			{"self-hosted-runner.groups", "Names of runner groups available for the repository", ""},
+		},
+	},
+	{
+		Name:        "shell-name",
+		Description: "Checks for shell names used for scripts in \"run:\"",
+		URL:         "check-shell-names",
+	},
+	{
+		Name:        "shellcheck",
+		Description: "Checks for shell script sources in \"run:\" using shellcheck",
+		URL:         "check-shellcheck-integ",
+	},
+	{
+		Name:        "step-name",
+		Description: "Checks for duplicate step names in a job. This rule is enabled by \"duplicate-step-names\" configuration",
+		URL:         "check-duplicate-step-names",
+		Options: []*RuleDocOption{
+			{"duplicate-step-names", "Report steps sharing the same name in a job", "false"},
+		},
+	},
+	{
+		Name:        "timeout-minutes",
+		Description: "Checks for jobs and steps without \"timeout-minutes:\". This rule is enabled by \"timeout-minutes\" configuration",
+		URL:         "check-timeout-minutes",
+		Options: []*RuleDocOption{
+			{"timeout-minutes.max", "Maximum value of `timeout-minutes:` allowed for jobs and steps. 0 means no maximum", "0"},
+			{"timeout-minutes.run-lines", "Steps whose scripts have this number of lines or more must set `timeout-minutes:`. 0 means steps are not checked", "0"},
+		},
+	},
+	{
+		Name:        "workflow-call",
+		Description: "Checks for reusable workflow calls. Inputs and outputs of called reusable workflow are checked",
+		URL:         "check-reusable-workflows",
+		Options: []*RuleDocOption{
+			{"secrets", "Names of secrets available in the repository. Secrets inherited by `secrets: inherit` are checked with them", ""},
+		},
+	},
+	{
+		Name:        "workflow-template",
+		Description: "Checks for metadata files of workflow templates in \".github/workflow-templates\" directory",
+		Details:     "This rule is enabled for workflow templates in `.github/workflow-templates` directory.",
+		URL:         "check-workflow-templates",
+	},
+	{
+		Name:        "yaml-style",
+		Description: "Checks for style of YAML such as indentation, unquoted booleans, and anchors. This rule is enabled by \"yaml-style\" configuration",
+		URL:         "check-yaml-style",
+		Options: []*RuleDocOption{
+			{"yaml-style.indent", "Number of spaces of one indentation level. 0 means any consistent indentation is allowed", "0"},
+			{"yaml-style.forbid-anchors", "Report YAML anchors and aliases", "false"},
+		},
+	},
+	{
+		Name:        "zizmor",
+		Description: "Checks for security issues reported by zizmor",
+		Details:     "This rule is enabled by `-zizmor` flag or `-zizmor-results` flag.",
+		URL:         "check-zizmor-integ",
+	},
+}
+
+// ruleDocOf returns the documentation of the rule. When the rule implements RuleDocumenter, its
+// documentation is used.
+func ruleDocOf(r Rule) *RuleDoc {
+	d := &RuleDoc{}
+	if dr, ok := r.(RuleDocumenter); ok {
+		if v := dr.Doc(); v != nil {
+			c := *v
+			d = &c
+		}
+	}
+	if d.Name == "" {
+		d.Name = r.Name()
+	}
+	if d.Description == "" {
+		d.Description = r.Description()
+	}
+	return d
+}
+
+// RuleDocs returns the documentation of all rules sorted by their names. It includes the built-in
+// rules, the custom rules registered by RegisterRule, the custom rules created by the given
+// constructors, and the plugins declared in the configuration. The configuration can be nil.
+// Documentation of a plugin is written at "docs" in its configuration.
+func RuleDocs(cfg *Config, rules ...func() Rule) []*RuleDoc {
+	docs := make([]*RuleDoc, 0, len(builtinRuleDocs))
+	for _, d := range builtinRuleDocs {
+		c := *d
+		c.URL = builtinRuleDocsURL + d.URL
+		c.Builtin = true
+		docs = append(docs, &c)
+	}
+	for _, f := range append(registeredRules(), rules...) {
+		docs = append(docs, ruleDocOf(f()))
+	}
+	if cfg != nil {
+		for _, p := range cfg.Plugins {
+			d := &RuleDoc{}
+			if p.Docs != nil {
+				c := *p.Docs
+				d = &c
+			}
+			d.Name = p.Name
+			if d.Description == "" {
+				d.Description = fmt.Sprintf("Checks with plugin command %q declared in the configuration file", p.Command)
+			}
+			docs = append(docs, d)
+		}
+	}
+	slices.SortStableFunc(docs, func(a, b *RuleDoc) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	return docs
+}
+
+// markdownTableCell escapes the text to put it in a cell of Markdown table.
+func markdownTableCell(s string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(s, "|", `\|`), "\n", " ")
+}
+
+// markdownCodeBlock writes the text as a fenced code block. The fence is longer than backquotes
+// in the text.
+func markdownCodeBlock(w io.Writer, lang, text string) {
+	fence := "```"
+	for strings.Contains(text, fence) {
+		fence += "`"
+	}
+	fmt.Fprintf(w, "%s%s\n%s\n%s\n\n", fence, lang, strings.TrimRight(text, "\n"), fence)
+}
+
+// WriteRuleDocs renders the documentation of the rules in Markdown with the title. The document
+// starts with the list of the rules followed by the section of each rule.
+func WriteRuleDocs(out io.Writer, title string, docs []*RuleDoc) error {
+	w := bufio.NewWriter(out)
+
+	fmt.Fprintf(w, "%s\n%s\n\n", title, strings.Repeat("=", max(len(title), 1)))
+	for _, d := range docs {
+		fmt.Fprintf(w, "- [`%s`](#%s): %s\n", d.Name, d.Name, d.Description)
+	}
+	w.WriteString("\n")
+
+	for _, d := range docs {
+		fmt.Fprintf(w, "<a id=\"%s\"></a>\n## `%s`\n\n%s\n\n", d.Name, d.Name, d.Description)
+		if d.Builtin {
+			w.WriteString("This is a built-in rule of actionlint.\n\n")
+		}
+		if s := strings.TrimSpace(d.Details); s != "" {
+			fmt.Fprintf(w, "%s\n\n", s)
+		}
+		if d.URL != "" {
+			fmt.Fprintf(w, "See [the document](%s) for more details.\n\n", d.URL)
+		}
+		if len(d.Options) > 0 {
+			w.WriteString("### Configuration\n\n| Option | Description | Default |\n|--------|-------------|---------|\n")
+			for _, o := range d.Options {
+				def := ""
+				if o.Default != "" {
+					def = "`" + o.Default + "`"
+				}
+				fmt.Fprintf(w, "| `%s` | %s | %s |\n", o.Name, markdownTableCell(o.Description), markdownTableCell(def))
+			}
+			w.WriteString("\n")
+		}
+		if len(d.Examples) > 0 {
+			w.WriteString("### Examples\n\n")
+			for i, e := range d.Examples {
+				t := e.Title
+				if t == "" {
+					t = fmt.Sprintf("Example %d", i+1)
+				}
+				fmt.Fprintf(w, "#### %s\n\n", t)
+				markdownCodeBlock(w, "yaml", e.Workflow)
+				if e.Output != "" {
+					w.WriteString("Output:\n\n")
+					markdownCodeBlock(w, "", e.Output)
+				}
+			}
+		}
+	}
+
+	if err := w.Flush(); err != nil {
+		return fmt.Errorf("could not write documentation of rules: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,170 @@
+package actionlint
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// Descriptions of built-in rules in the documentation must be the same as the rules
+func TestRuleDocBuiltinRules(t *testing.T) {
+	cfg := strings.Join([]string{
+		"timeout-minutes:",
+		"  max: 60",
+		"yaml-style:",
+		"  indent: 2",
+		"inline-script:",
+		"  max-lines: 100",
+		"deployment-environment:",
+		"  actions: []",
+		"naming-convention:",
+		"  job-id: '^[a-z]+$'",
+	}, "\n")
+	p := filepath.Join(t.TempDir(), "actionlint.yaml")
+	if err := os.WriteFile(p, []byte(cfg), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	docs := map[string]*RuleDoc{}
+	for _, d := range builtinRuleDocs {
+		docs[d.Name] = d
+	}
+
+	for _, platform := range []string{"", "gitea"} {
+		ghes := "3.11"
+		if platform != "" {
+			ghes = ""
+		}
+		created := []Rule{}
+		opts := &LinterOptions{
+			ConfigFile:  p,
+			Shellcheck:  "",
+			Pyflakes:    "",
+			Platform:    platform,
+			GHESVersion: ghes,
+			OnRulesCreated: func(rs []Rule) []Rule {
+				created = append(created, rs...)
+				return rs
+			},
+		}
+		l, err := NewLinter(io.Discard, opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := l.Lint("test.yaml", []byte("on: push\njobs:\n  test:\n    runs-on: ubuntu-latest\n    steps:\n      - run: echo\n"), nil); err != nil {
+			t.Fatal(err)
+		}
+		if len(created) == 0 {
+			t.Fatal("no rule was created")
+		}
+		for _, r := range created {
+			d, ok := docs[r.Name()]
+			if !ok {
+				t.Errorf("documentation of rule %q is missing", r.Name())
+				continue
+			}
+			if d.Description != r.Description() {
+				t.Errorf("description of rule %q is outdated. wanted %q but got %q", r.Name(), r.Description(), d.Description)
+			}
+		}
+	}
+}
+
+type testRuleWithDoc struct {
+	RuleBase
+}
+
+func (r *testRuleWithDoc) Doc() *RuleDoc {
+	return &RuleDoc{
+		Details: "Steps must have names.",
+		Options: []*RuleDocOption{{Name: "test.enabled", Description: "Enable | disable the rule", Default: "true"}},
+		Examples: []*RuleDocExample{
+			{Workflow: "steps:\n  - run: |\n      echo '```'\n", Output: "test.yaml:2:5: step must have name [test-with-doc]"},
+		},
+	}
+}
+
+func TestRuleDocCustomRulesAndPlugins(t *testing.T) {
+	cfg, err := ParseConfig([]byte(`
+plugins:
+  - name: org-policy
+    command: ./org-policy
+    docs:
+      description: Checks for our organization's policies
+      url: https://example.com/org-policy
+      examples:
+        - title: Unpinned runner
+          workflow: "runs-on: ubuntu-latest"
+  - name: no-docs
+    command: ./no-docs
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	docs := RuleDocs(cfg,
+		func() Rule { return &testRuleWithDoc{NewRuleBase("test-with-doc", "Checks names of steps")} },
+		func() Rule { r := NewRuleBase("test-no-doc", "Checks nothing"); return &r },
+	)
+	byName := map[string]*RuleDoc{}
+	for i, d := range docs {
+		byName[d.Name] = d
+		if i > 0 && docs[i-1].Name > d.Name {
+			t.Errorf("documentation is not sorted: %q and %q", docs[i-1].Name, d.Name)
+		}
+	}
+	if d := byName["expression"]; d == nil || !d.Builtin || !strings.HasPrefix(d.URL, builtinRuleDocsURL) {
+		t.Errorf("unexpected documentation of built-in rule: %+v", d)
+	}
+	if d := byName["test-with-doc"]; d == nil || d.Builtin || d.Description != "Checks names of steps" || d.Details != "Steps must have names." {
+		t.Errorf("unexpected documentation of custom rule: %+v", d)
+	}
+	if d := byName["test-no-doc"]; d == nil || d.Description != "Checks nothing" {
+		t.Errorf("unexpected documentation of custom rule without doc: %+v", d)
+	}
+	if d := byName["org-policy"]; d == nil || d.Description != "Checks for our organization's policies" || len(d.Examples) != 1 {
+		t.Errorf("unexpected documentation of plugin: %+v", d)
+	}
+	if d := byName["no-docs"]; d == nil || d.Description != `Checks with plugin command "./no-docs" declared in the configuration file` {
+		t.Errorf("unexpected documentation of plugin without docs: %+v", d)
+	}
+
+	var b strings.Builder
+	if err := WriteRuleDocs(&b, "Our rules", []*RuleDoc{byName["org-policy"], byName["test-with-doc"]}); err != nil {
+		t.Fatal(err)
+	}
+	out := b.String()
+	for _, want := range []string{
+		"Our rules\n=========\n\n",
+		"- [`org-policy`](#org-policy): Checks for our organization's policies\n",
+		"<a id=\"org-policy\"></a>\n## `org-policy`\n\nChecks for our organization's policies\n\nSee [the document](https://example.com/org-policy) for more details.\n\n",
+		"#### Unpinned runner\n\n```yaml\nruns-on: ubuntu-latest\n```\n\n",
+		"| `test.enabled` | Enable \\| disable the rule | `true` |\n",
+		"#### Example 1\n\n````yaml\nsteps:\n  - run: |\n      echo '```'\n````\n\n",
+		"Output:\n\n```\ntest.yaml:2:5: step must have name [test-with-doc]\n```\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("%q is not included in the output:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "built-in rule") {
+		t.Errorf("custom rules are rendered as built-in rules:\n%s", out)
+	}
+}
+
+func TestRuleDocInvalidPluginDocs(t *testing.T) {
+	for _, tc := range []struct {
+		cfg  string
+		want string
+	}{
+		{"plugins:\n  - name: foo\n    command: foo\n    docs:\n      options:\n        - description: bar\n", `"name" is missing in option #1 in "docs" of plugin "foo"`},
+		{"plugins:\n  - name: foo\n    command: foo\n    docs:\n      examples:\n        - title: bar\n", `"workflow" is missing in example #1 in "docs" of plugin "foo"`},
+	} {
+		_, err := ParseConfig([]byte(tc.cfg))
+		if err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("wanted error %q but got %v", tc.want, err)
+		}
+	}
+}
@@ -0,0 +1,636 @@
+package actionlint
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Redirection or "tee" command to write an environment file in bash or sh scripts. The 1st group
+// captures the operator and the 2nd group captures the file name.
+var reEnvFileShellTarget = regexp.MustCompile(`(>>?|\btee\s+(?:-a\s+|--append\s+)?)\s*"?\$\{?(GITHUB_ENV|GITHUB_PATH)\b\}?`)
+
+// Here document in bash or sh scripts like `<<EOF`, `<<-'EOF'`, or `<< "EOF"`. The 1st group captures
+// the delimiter.
+var reEnvFileHeredoc = regexp.MustCompile(`^<<-?\s*['"]?([A-Za-z_][A-Za-z0-9_]*)['"]?`)
+
+// Statement to print the values with echo or printf in bash or sh scripts. The 1st group captures the
+// command and the 2nd group captures its arguments.
+var reEnvFileShellEcho = regexp.MustCompile(`(?s)^\s*(echo|printf)(\s.*?)?\s*(?:>>?\s*"?\$\{?(?:GITHUB_ENV|GITHUB_PATH)\b\}?"?\s*)?$`)
+
+// Environment file in PowerShell scripts like `$env:GITHUB_ENV`. The 1st group captures the file name.
+var reEnvFilePowerShellTarget = regexp.MustCompile(`(?i)\$env:(GITHUB_ENV|GITHUB_PATH)\b`)
+
+// Redirection with ">" to overwrite an environment file in PowerShell scripts.
+var reEnvFilePowerShellOverwrite = regexp.MustCompile(`(?i)(?:^|[^>])>\s*\$env:`)
+
+// Reference to $PATH environment variable in bash, sh, or PowerShell scripts.
+var reEnvFilePathVar = regexp.MustCompile(`\$\{?PATH\b|(?i)\$env:PATH\b`)
+
+// Environment variable name written to $GITHUB_ENV.
+var reEnvFileVarName = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// String literal at the start of the statement to write an environment file in PowerShell scripts. The
+// 1st group captures the literal.
+var reEnvFilePowerShellValue = regexp.MustCompile("(?i)^\\s*(?:(?:echo|Write-Output)\\s+)?(\"(?:[^\"`]|`.)*\"|'(?:[^']|'')*')\\s*(?:\\||>)")
+
+// String literal at "-Value" parameter of Add-Content in PowerShell scripts. The 1st group captures
+// the literal.
+var reEnvFilePowerShellAddContent = regexp.MustCompile("(?i)\\bAdd-Content\\b.*\\s-Value\\s+(\"(?:[^\"`]|`.)*\"|'(?:[^']|'')*')")
+
+// envFileLine is a line written to an environment file. When the content of the line cannot be known
+// statically, for example the output of some command, known is false.
+type envFileLine struct {
+	text  string
+	known bool
+	// cont is true when the line is printed by the same command as the previous line. It means the
+	// value printed by the command contains newline.
+	cont bool
+}
+
+// envFileWrite is a write to an environment file in a script.
+type envFileWrite struct {
+	// file is the name of the environment file. "GITHUB_ENV" or "GITHUB_PATH".
+	file  string
+	lines []envFileLine
+	// heredoc is the delimiter of the here document when the content is written with it.
+	heredoc string
+}
+
+// envFileStmt is a statement in bash or sh scripts. Comments are removed from the text.
+type envFileStmt struct {
+	text     string
+	heredocs []envFileHeredoc
+	// line is the line number in the script where the statement starts.
+	line int
+}
+
+type envFileHeredoc struct {
+	delim string
+	body  []string
+}
+
+// RuleEnvFile is a rule to check writes to environment files "$GITHUB_ENV" and "$GITHUB_PATH" in
+// scripts at "run:". Broken lines in the environment files don't cause an error in the step writing
+// them. Instead, the following steps get wrong environment variables or fail with confusing errors.
+// https://docs.github.com/en/actions/using-workflows/workflow-commands-for-github-actions#environment-files
+type RuleEnvFile struct {
+	RuleBase
+	workflowShell string
+	jobShell      string
+	runnerShell   string
+}
+
+// NewRuleEnvFile creates a new RuleEnvFile instance.
+func NewRuleEnvFile() *RuleEnvFile {
+	return &RuleEnvFile{
+		RuleBase: RuleBase{
+			name: "env-file",
+			desc: "Checks for syntax of writes to \"$GITHUB_ENV\" and \"$GITHUB_PATH\" environment files at \"run:\"",
+		},
+	}
+}
+
+// VisitStep is callback when visiting Step node.
+func (rule *RuleEnvFile) VisitStep(n *Step) error {
+	r, ok := n.Exec.(*ExecRun)
+	if !ok || r.Run == nil || !strings.Contains(r.Run.Value, "GITHUB_") {
+		return nil
+	}
+
+	shell := rule.shellName(r)
+	var writes []*envFileWrite
+	switch shell {
+	case "bash", "sh":
+		writes = rule.checkShellScript(r.Run)
+	case "pwsh", "powershell":
+		writes = rule.checkPowerShellScript(r.Run, shell)
+	default:
+		return nil
+	}
+
+	rule.checkEnvLines(r.Run, writes)
+	rule.checkPathLines(r.Run, writes)
+	return nil
+}
+
+// VisitJobPre is callback when visiting Job node before visiting its children.
+func (rule *RuleEnvFile) VisitJobPre(n *Job) error {
+	if n.Defaults != nil && n.Defaults.Run != nil && n.Defaults.Run.Shell != nil {
+		rule.jobShell = n.Defaults.Run.Shell.Value
+	}
+	if n.RunsOn != nil {
+		for _, label := range n.RunsOn.Labels {
+			// Default shell on Windows is PowerShell
+			if os, ok := runnerOSOfLabel(label.Value); ok && os == RunnerOSWindows {
+				rule.runnerShell = "pwsh"
+				break
+			}
+		}
+	}
+	return nil
+}
+
+// VisitJobPost is callback when visiting Job node after visiting its children.
+func (rule *RuleEnvFile) VisitJobPost(n *Job) error {
+	rule.jobShell = ""
+	rule.runnerShell = ""
+	return nil
+}
+
+// VisitWorkflowPre is callback when visiting Workflow node before visiting its children.
+func (rule *RuleEnvFile) VisitWorkflowPre(n *Workflow) error {
+	if n.Defaults != nil && n.Defaults.Run != nil && n.Defaults.Run.Shell != nil {
+		rule.workflowShell = n.Defaults.Run.Shell.Value
+	}
+	return nil
+}
+
+// VisitWorkflowPost is callback when visiting Workflow node after visiting its children.
+func (rule *RuleEnvFile) VisitWorkflowPost(n *Workflow) error {
+	rule.workflowShell = ""
+	return nil
+}
+
+func (rule *RuleEnvFile) shellName(exec *ExecRun) string {
+	s := "bash"
+	if exec.Shell != nil {
+		s = exec.Shell.Value
+	} else if rule.jobShell != "" {
+		s = rule.jobShell
+	} else if rule.workflowShell != "" {
+		s = rule.workflowShell
+	} else if rule.runnerShell != "" {
+		s = rule.runnerShell
+	}
+	if f := strings.Fields(s); len(f) > 0 {
+		return f[0] // Custom shell like "bash -e {0}"
+	}
+	return s
+}
+
+// checkShellScript checks how the environment files are written in the bash or sh script and returns
+// the writes to them in order.
+func (rule *RuleEnvFile) checkShellScript(run *String) []*envFileWrite {
+	var writes []*envFileWrite
+	var group []*envFileStmt
+	inGroup := false
+
+	for _, s := range envFileShellStmts(run.Value) {
+		t := strings.TrimSpace(s.text)
+		if t == "{" {
+			group, inGroup = nil, true
+			continue
+		}
+
+		m := reEnvFileShellTarget.FindStringSubmatch(t)
+		if m != nil && m[1] == ">" {
+			l, c := envFileScriptPos(run.Value, s.line, m[0])
+			rule.Errorf(
+				run.Pos,
+				"environment file $%s is overwritten by redirection with \">\" in %q at %d:%d of the script. values written by previous steps are lost. use \">>\" to append to the file",
+				m[2],
+				t,
+				l,
+				c,
+			)
+		}
+
+		if inGroup && !strings.HasPrefix(t, "}") {
+			group = append(group, s)
+			continue
+		}
+
+		var w *envFileWrite
+		switch {
+		case m == nil:
+		case inGroup:
+			w = &envFileWrite{file: m[2]}
+			for _, s := range group {
+				w.lines = append(w.lines, envFileShellEchoLines(s.text)...)
+			}
+		case len(s.heredocs) > 0 && strings.HasPrefix(t, "cat"):
+			h := s.heredocs[0]
+			w = &envFileWrite{file: m[2], heredoc: h.delim}
+			for _, l := range h.body {
+				w.lines = append(w.lines, envFileLine{l, !strings.ContainsAny(l, "$`"), false})
+			}
+		default:
+			w = &envFileWrite{file: m[2], lines: envFileShellEchoLines(t)}
+		}
+		if w != nil {
+			writes = append(writes, w)
+		}
+		if strings.HasPrefix(t, "}") {
+			group, inGroup = nil, false
+		}
+	}
+
+	return writes
+}
+
+// checkPowerShellScript checks how the environment files are written in the PowerShell script and
+// returns the writes to them in order.
+func (rule *RuleEnvFile) checkPowerShellScript(run *String, shell string) []*envFileWrite {
+	var writes []*envFileWrite
+
+	for l := range strings.Lines(run.Value) {
+		l = strings.TrimSpace(l)
+		m := reEnvFilePowerShellTarget.FindStringSubmatch(l)
+		if m == nil || strings.HasPrefix(l, "#") {
+			continue
+		}
+		file := strings.ToUpper(m[1])
+		lower := strings.ToLower(l)
+
+		switch {
+		case strings.Contains(lower, "set-content"):
+			rule.Errorf(
+				run.Pos,
+				"\"Set-Content\" overwrites $env:%s in %q. values written by previous steps are lost. use \"Add-Content\" to append to the file",
+				file,
+				l,
+			)
+		case strings.Contains(lower, "out-file"):
+			if !strings.Contains(lower, "-append") {
+				rule.Errorf(
+					run.Pos,
+					"\"Out-File\" overwrites $env:%s without \"-Append\" option in %q. values written by previous steps are lost",
+					file,
+					l,
+				)
+			}
+			if shell == "powershell" && !strings.Contains(lower, "-encoding") {
+				rule.Errorf(
+					run.Pos,
+					"\"Out-File\" writes $env:%s in UTF-16 on Windows PowerShell in %q. specify the encoding like \"Out-File -FilePath $env:%s -Encoding utf8 -Append\"",
+					file,
+					l,
+					file,
+				)
+			}
+		case reEnvFilePowerShellOverwrite.MatchString(l):
+			rule.Errorf(
+				run.Pos,
+				"environment file $env:%s is overwritten by redirection with \">\" in %q. values written by previous steps are lost. use \">>\" to append to the file",
+				file,
+				l,
+			)
+		case shell == "powershell" && strings.Contains(l, ">>"):
+			rule.Errorf(
+				run.Pos,
+				"redirection with \">>\" writes $env:%s in UTF-16 on Windows PowerShell in %q. use \"| Out-File -FilePath $env:%s -Encoding utf8 -Append\" instead",
+				file,
+				l,
+				file,
+			)
+		}
+
+		w := &envFileWrite{file: file}
+		v := reEnvFilePowerShellValue.FindStringSubmatch(l)
+		if v == nil {
+			v = reEnvFilePowerShellAddContent.FindStringSubmatch(l)
+		}
+		if v == nil {
+			w.lines = []envFileLine{{}}
+		} else {
+			w.lines = envFileSplitLines(envFilePowerShellString(v[1]))
+		}
+		writes = append(writes, w)
+	}
+
+	return writes
+}
+
+// checkEnvLines checks lines written to $GITHUB_ENV follow "{name}={value}" or "{name}<<{delimiter}"
+// format.
+func (rule *RuleEnvFile) checkEnvLines(run *String, writes []*envFileWrite) {
+	delim, name := "", ""
+	for _, w := range writes {
+		if w.file != "GITHUB_ENV" {
+			continue
+		}
+		for i, l := range w.lines {
+			if delim != "" {
+				if l.known && l.text == delim {
+					delim = ""
+				}
+				continue
+			}
+			if !l.known {
+				if strings.HasPrefix(l.text, "$") || strings.HasPrefix(l.text, "`") || l.text == "" {
+					continue
+				}
+			}
+
+			static := l.text
+			if j := strings.IndexAny(static, "$`"); j >= 0 {
+				static = static[:j]
+			}
+
+			if n, d, ok := strings.Cut(static, "<<"); ok && !strings.Contains(n, "=") {
+				if !l.known || d == "" {
+					return // The delimiter is generated dynamically
+				}
+				if d == w.heredoc {
+					rule.Errorf(
+						run.Pos,
+						"delimiter %q of multi-line value of %q written to $GITHUB_ENV is the same as the delimiter of the here document. the here document ends at the first %q line and the value is not closed. use a different delimiter for the here document",
+						d,
+						n,
+						d,
+					)
+					return
+				}
+				delim, name = d, n
+				continue
+			}
+
+			if n, _, ok := strings.Cut(static, "="); ok {
+				if i+1 < len(w.lines) && w.lines[i+1].cont {
+					rule.Errorf(
+						run.Pos,
+						"value of %q written to $GITHUB_ENV contains newline but \"{name}={value}\" format only supports single-line value. use the delimiter format like \"%s<<EOF\" for multi-line value. see https://docs.github.com/en/actions/using-workflows/workflow-commands-for-github-actions#multiline-strings",
+						n,
+						n,
+					)
+					break
+				}
+				continue
+			}
+
+			if l.known {
+				rule.Errorf(
+					run.Pos,
+					"line %q written to $GITHUB_ENV is not in \"{name}={value}\" format nor \"{name}<<{delimiter}\" format",
+					l.text,
+				)
+			}
+		}
+	}
+
+	if delim != "" {
+		rule.Errorf(
+			run.Pos,
+			"delimiter %q of multi-line value of %q written to $GITHUB_ENV is never written to close the value. the following steps fail to read the environment file",
+			delim,
+			name,
+		)
+	}
+}
+
+// checkPathLines checks lines written to $GITHUB_PATH are directory paths.
+func (rule *RuleEnvFile) checkPathLines(run *String, writes []*envFileWrite) {
+	for _, w := range writes {
+		if w.file != "GITHUB_PATH" {
+			continue
+		}
+		for _, l := range w.lines {
+			if n, _, ok := strings.Cut(l.text, "="); ok && reEnvFileVarName.MatchString(n) {
+				rule.Errorf(
+					run.Pos,
+					"line %q written to $GITHUB_PATH is in \"{name}={value}\" format of $GITHUB_ENV. $GITHUB_PATH takes a directory path per line",
+					l.text,
+				)
+				continue
+			}
+			if reEnvFilePathVar.MatchString(l.text) {
+				rule.Errorf(
+					run.Pos,
+					"line %q written to $GITHUB_PATH contains $PATH. write only the directory to add since the runner prepends the directory to $PATH",
+					l.text,
+				)
+			}
+		}
+	}
+}
+
+// envFileShellStmts splits the bash or sh script into statements. Statements are separated by
+// newlines, ";", "&&", and "||" outside quotes. Here documents are attached to the statements which
+// start them.
+func envFileShellStmts(src string) []*envFileStmt {
+	lines := strings.Split(src, "\n")
+	stmts := []*envFileStmt{}
+	var b strings.Builder
+	var heredocs []envFileHeredoc
+	quote := byte(0)
+	start, cur := 0, 0
+
+	flush := func() {
+		if t := strings.TrimSpace(b.String()); t != "" || len(heredocs) > 0 {
+			stmts = append(stmts, &envFileStmt{t, heredocs, start})
+		}
+		b.Reset()
+		heredocs = nil
+		start = cur
+	}
+
+	for i := 0; i < len(lines); i++ {
+		l := lines[i]
+		cur = i + 1
+		if b.Len() == 0 {
+			start = cur
+		}
+	Line:
+		for j := 0; j < len(l); j++ {
+			c := l[j]
+			switch {
+			case quote == '\'':
+				if c == '\'' {
+					quote = 0
+				}
+			case quote == '"':
+				if c == '\\' && j+1 < len(l) {
+					b.WriteByte(c)
+					j++
+					c = l[j]
+				} else if c == '"' {
+					quote = 0
+				}
+			case c == '\'' || c == '"':
+				quote = c
+			case c == '\\':
+				if j+1 == len(l) {
+					continue Line // Line continuation
+				}
+				b.WriteByte(c)
+				j++
+				c = l[j]
+			case c == '#' && (j == 0 || strings.IndexByte(" \t;&|(", l[j-1]) >= 0):
+				break Line // Comment
+			case c == ';' || (c == '&' || c == '|') && j+1 < len(l) && l[j+1] == c:
+				if c != ';' {
+					j++
+				}
+				flush()
+				continue
+			case strings.HasPrefix(l[j:], "<<<"):
+				b.WriteString("<<") // Here string
+				j += 2
+			case strings.HasPrefix(l[j:], "<<"):
+				if m := reEnvFileHeredoc.FindStringSubmatch(l[j:]); m != nil {
+					heredocs = append(heredocs, envFileHeredoc{delim: m[1]})
+				}
+			}
+			b.WriteByte(c)
+		}
+		if quote != 0 || strings.HasSuffix(l, "\\") && !strings.HasSuffix(l, "\\\\") {
+			b.WriteByte('\n')
+			continue // The statement continues to the next line
+		}
+
+		// Read the bodies of here documents
+		for k := range heredocs {
+			h := &heredocs[k]
+			for i+1 < len(lines) {
+				i++
+				if t := strings.TrimLeft(lines[i], "\t"); t == h.delim {
+					break
+				}
+				h.body = append(h.body, strings.TrimLeft(lines[i], "\t"))
+			}
+		}
+		flush()
+	}
+
+	return stmts
+}
+
+// envFileScriptPos returns the 1-based line and column of the text in the script searching from the
+// line. When the text is not found, for example it is split by line continuation, the position of
+// the line is returned.
+func envFileScriptPos(src string, line int, text string) (int, int) {
+	lines := strings.Split(src, "\n")
+	for i := max(line, 1) - 1; i < len(lines); i++ {
+		if c := strings.Index(lines[i], text); c >= 0 {
+			return i + 1, c + 1
+		}
+	}
+	return line, 1
+}
+
+// envFileShellEchoLines returns the lines printed by the echo or printf statement. When the statement
+// is not echo or printf, it returns one unknown line.
+func envFileShellEchoLines(stmt string) []envFileLine {
+	m := reEnvFileShellEcho.FindStringSubmatch(stmt)
+	if m == nil {
+		return []envFileLine{{}}
+	}
+
+	args := envFileShellWords(m[2])
+	if args == nil {
+		return []envFileLine{{}}
+	}
+
+	escape, newline := m[1] == "printf", m[1] == "echo"
+	if m[1] == "echo" {
+		for len(args) > 0 && (args[0] == "-e" || args[0] == "-n" || args[0] == "-E" || args[0] == "-en" || args[0] == "-ne") {
+			if strings.Contains(args[0], "e") {
+				escape = true
+			}
+			if strings.Contains(args[0], "n") {
+				newline = false
+			}
+			args = args[1:]
+		}
+	} else if len(args) != 1 || strings.Contains(args[0], "%") {
+		return []envFileLine{{}} // Format string with arguments
+	}
+
+	s := strings.Join(args, " ")
+	if escape {
+		s = strings.ReplaceAll(s, `\n`, "\n")
+	}
+	if newline {
+		s += "\n"
+	}
+	return envFileSplitLines(s)
+}
+
+// envFileShellWords splits the arguments into words removing quotes. Variables and command
+// substitutions remain as they are. It returns nil when the arguments contain pipes or redirections.
+func envFileShellWords(s string) []string {
+	words := []string{}
+	var b strings.Builder
+	quote, inWord := byte(0), false
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case quote == '\'':
+			if c == '\'' {
+				quote = 0
+			} else {
+				b.WriteByte(c)
+			}
+		case quote == '"':
+			if c == '\\' && i+1 < len(s) && strings.IndexByte("\"\\$`", s[i+1]) >= 0 {
+				i++
+				b.WriteByte(s[i])
+			} else if c == '"' {
+				quote = 0
+			} else {
+				b.WriteByte(c)
+			}
+		case c == '\'' || c == '"':
+			quote, inWord = c, true
+		case c == ' ' || c == '\t':
+			if inWord {
+				words = append(words, b.String())
+				b.Reset()
+				inWord = false
+			}
+		case c == '|' || c == '>' || c == '<' || c == '&':
+			return nil
+		case c == '\\' && i+1 < len(s):
+			i++
+			b.WriteByte(s[i])
+			inWord = true
+		default:
+			b.WriteByte(c)
+			inWord = true
+		}
+	}
+	if inWord {
+		words = append(words, b.String())
+	}
+	return words
+}
+
+// envFilePowerShellString returns the value of the PowerShell string literal with a newline printed
+// after the value.
+func envFilePowerShellString(lit string) string {
+	v := lit[1 : len(lit)-1]
+	if lit[0] == '\'' {
+		return strings.ReplaceAll(v, "''", "'") + "\n"
+	}
+	var b strings.Builder
+	for i := 0; i < len(v); i++ {
+		c := v[i]
+		if c == '`' && i+1 < len(v) {
+			i++
+			switch v[i] {
+			case 'n':
+				b.WriteByte('\n')
+			case 'r':
+			case 't':
+				b.WriteByte('\t')
+			default:
+				b.WriteByte(v[i])
+			}
+			continue
+		}
+		b.WriteByte(c)
+	}
+	b.WriteByte('\n')
+	return b.String()
+}
+
+// envFileSplitLines splits the printed text into lines. Lines containing variables or command
+// substitutions are not known statically.
+func envFileSplitLines(s string) []envFileLine {
+	s = strings.TrimSuffix(s, "\n")
+	ls := []envFileLine{}
+	for i, l := range strings.Split(s, "\n") {
+		ls = append(ls, envFileLine{l, !strings.ContainsAny(l, "$`"), i > 0})
+	}
+	return ls
+}
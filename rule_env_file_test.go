@@ -0,0 +1,72 @@
+package actionlint
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestRuleEnvFileSplitShellStatements(t *testing.T) {
+	tests := []struct {
+		what string
+		src  string
+		want []*envFileStmt
+	}{
+		{
+			what: "separators",
+			src:  "echo a; echo b && echo c || echo d\necho e",
+			want: []*envFileStmt{{text: "echo a", line: 1}, {text: "echo b", line: 1}, {text: "echo c", line: 1}, {text: "echo d", line: 1}, {text: "echo e", line: 2}},
+		},
+		{
+			what: "separators in quotes",
+			src:  `echo 'a; b' "c && d"`,
+			want: []*envFileStmt{{text: `echo 'a; b' "c && d"`, line: 1}},
+		},
+		{
+			what: "comment",
+			src:  "# echo a\necho b # c\necho '#d'",
+			want: []*envFileStmt{{text: "echo b", line: 2}, {text: "echo '#d'", line: 3}},
+		},
+		{
+			what: "multi-line quote",
+			src:  "echo \"a\nb\" >> \"$GITHUB_ENV\"",
+			want: []*envFileStmt{{text: "echo \"a\nb\" >> \"$GITHUB_ENV\"", line: 1}},
+		},
+		{
+			what: "line continuation",
+			src:  "echo a \\\n  b",
+			want: []*envFileStmt{{text: "echo a \n  b", line: 1}},
+		},
+		{
+			what: "here documents",
+			src:  "cat <<EOF >> \"$GITHUB_ENV\"\nA<<END\nb\nEND\nEOF\ncat <<-'X'\n\ty\n\tX\necho z",
+			want: []*envFileStmt{
+				{
+					text:     "cat <<EOF >> \"$GITHUB_ENV\"",
+					heredocs: []envFileHeredoc{{"EOF", []string{"A<<END", "b", "END"}}},
+					line:     1,
+				},
+				{
+					text:     "cat <<-'X'",
+					heredocs: []envFileHeredoc{{"X", []string{"y"}}},
+					line:     6,
+				},
+				{text: "echo z", line: 9},
+			},
+		},
+		{
+			what: "here string",
+			src:  "cat <<< 'a'",
+			want: []*envFileStmt{{text: "cat <<< 'a'", line: 1}},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.what, func(t *testing.T) {
+			have := envFileShellStmts(tc.src)
+			if !cmp.Equal(tc.want, have, cmp.AllowUnexported(envFileStmt{}, envFileHeredoc{})) {
+				t.Fatal(cmp.Diff(tc.want, have, cmp.AllowUnexported(envFileStmt{}, envFileHeredoc{})))
+			}
+		})
+	}
+}
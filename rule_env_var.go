@@ -1,10 +1,81 @@
 package actionlint
 
-import "strings"
+import (
+	"strings"
+)
+
+// Default environment variables set by GitHub Actions. Their values cannot be overwritten by "env:".
+// Note that "CI" and "RUNNER_DEBUG" are not included since they are often set intentionally.
+// https://docs.github.com/en/actions/reference/workflows-and-actions/variables#default-environment-variables
+var defaultEnvVarNames = map[string]struct{}{
+	"GITHUB_ACTION":              {},
+	"GITHUB_ACTION_PATH":         {},
+	"GITHUB_ACTION_REPOSITORY":   {},
+	"GITHUB_ACTIONS":             {},
+	"GITHUB_ACTOR":               {},
+	"GITHUB_ACTOR_ID":            {},
+	"GITHUB_API_URL":             {},
+	"GITHUB_BASE_REF":            {},
+	"GITHUB_ENV":                 {},
+	"GITHUB_EVENT_NAME":          {},
+	"GITHUB_EVENT_PATH":          {},
+	"GITHUB_GRAPHQL_URL":         {},
+	"GITHUB_HEAD_REF":            {},
+	"GITHUB_JOB":                 {},
+	"GITHUB_OUTPUT":              {},
+	"GITHUB_PATH":                {},
+	"GITHUB_REF":                 {},
+	"GITHUB_REF_NAME":            {},
+	"GITHUB_REF_PROTECTED":       {},
+	"GITHUB_REF_TYPE":            {},
+	"GITHUB_REPOSITORY":          {},
+	"GITHUB_REPOSITORY_ID":       {},
+	"GITHUB_REPOSITORY_OWNER":    {},
+	"GITHUB_REPOSITORY_OWNER_ID": {},
+	"GITHUB_RETENTION_DAYS":      {},
+	"GITHUB_RUN_ATTEMPT":         {},
+	"GITHUB_RUN_ID":              {},
+	"GITHUB_RUN_NUMBER":          {},
+	"GITHUB_SERVER_URL":          {},
+	"GITHUB_SHA":                 {},
+	"GITHUB_STATE":               {},
+	"GITHUB_STEP_SUMMARY":        {},
+	"GITHUB_TRIGGERING_ACTOR":    {},
+	"GITHUB_WORKFLOW":            {},
+	"GITHUB_WORKFLOW_REF":        {},
+	"GITHUB_WORKFLOW_SHA":        {},
+	"GITHUB_WORKSPACE":           {},
+	"RUNNER_ARCH":                {},
+	"RUNNER_ENVIRONMENT":         {},
+	"RUNNER_NAME":                {},
+	"RUNNER_OS":                  {},
+	"RUNNER_TEMP":                {},
+	"RUNNER_TOOL_CACHE":          {},
+}
+
+// Prefixes of environment variable names reserved by GitHub Actions.
+// https://docs.github.com/en/actions/reference/workflows-and-actions/variables#naming-conventions-for-environment-variables
+var reservedEnvVarPrefixes = []string{"GITHUB_", "RUNNER_"}
+
+// Environment variables with the reserved prefixes which are often set intentionally. For example,
+// many tools read the token from "GITHUB_TOKEN".
+var allowedReservedEnvVarNames = map[string]struct{}{
+	"GITHUB_TOKEN": {},
+	"RUNNER_DEBUG": {},
+}
 
 // RuleEnvVar is a rule checker to check environment variables setup.
 type RuleEnvVar struct {
 	RuleBase
+	// windows is true when the environment variables are set on Windows runners where their names
+	// are case-insensitive. At workflow level, it is true when some job runs on Windows.
+	windows bool
+	// unknownOS is true when the OS of the runner running the job cannot be determined.
+	unknownOS bool
+	// Environment variables defined at upper levels. Keys are lower-cased names to detect names
+	// which differ only in case.
+	workflowVars map[string]*String
+	jobVars      map[string]*String
 }
 
 // NewRuleEnvVar creates new RuleEnvVar instance.
@@ -20,30 +91,105 @@ func NewRuleEnvVar() *RuleEnvVar {
 // VisitStep is callback when visiting Step node.
 func (rule *RuleEnvVar) VisitStep(n *Step) error {
 	rule.checkEnv(n.Env)
+	rule.checkCaseCollision(n.Env, rule.jobVars, rule.workflowVars)
 	return nil
 }
 
 // VisitJobPre is callback when visiting Job node before visiting its children.
 func (rule *RuleEnvVar) VisitJobPre(n *Job) error {
+	rule.windows, rule.unknownOS = jobRunsOnWindows(n)
 	rule.checkEnv(n.Env)
+	rule.checkCaseCollision(n.Env, rule.workflowVars)
+	rule.jobVars = collectEnvVarNames(n.Env)
+	// Default environment variables are not set in containers so they are not checked
 	if n.Container != nil {
-		rule.checkEnv(n.Container.Env)
+		rule.checkContainerEnv(n.Container.Env)
 	}
 	if n.Services != nil {
 		for _, s := range n.Services.Value {
-			rule.checkEnv(s.Container.Env)
+			rule.checkContainerEnv(s.Container.Env)
 		}
 	}
 	return nil
 }
 
+// VisitJobPost is callback when visiting Job node after visiting its children.
+func (rule *RuleEnvVar) VisitJobPost(n *Job) error {
+	rule.jobVars = nil
+	rule.windows = false
+	rule.unknownOS = false
+	return nil
+}
+
 // VisitWorkflowPre is callback when visiting Workflow node before visiting its children.
 func (rule *RuleEnvVar) VisitWorkflowPre(n *Workflow) error {
+	rule.windows = false
+	for _, j := range n.Jobs {
+		if w, _ := jobRunsOnWindows(j); w {
+			rule.windows = true
+			break
+		}
+	}
 	rule.checkEnv(n.Env)
+	rule.windows = false
+	rule.workflowVars = collectEnvVarNames(n.Env)
 	return nil
 }
 
+// jobRunsOnWindows returns true when the job may run on Windows runners. Labels in matrix are also
+// considered. Jobs in containers run on Linux. The second return value is true when the OS of the
+// runner cannot be determined from the labels.
+func jobRunsOnWindows(n *Job) (bool, bool) {
+	if n.Container != nil {
+		return false, false
+	}
+	if n.RunsOn == nil {
+		return false, true
+	}
+	var m *Matrix
+	if n.Strategy != nil {
+		m = n.Strategy.Matrix
+	}
+	known := false
+	for _, l := range n.RunsOn.Labels {
+		ls := []*String{l}
+		if l.ContainsExpression() {
+			ls = getRunnerLabelsInMatrix(l, m)
+		}
+		for _, l := range ls {
+			if os, ok := runnerOSOfLabel(l.Value); ok {
+				if os == RunnerOSWindows {
+					return true, false
+				}
+				known = true
+			}
+		}
+	}
+	return false, !known
+}
+
+func collectEnvVarNames(env *Env) map[string]*String {
+	if env == nil || env.Expression != nil || len(env.Vars) == 0 {
+		return nil
+	}
+	m := make(map[string]*String, len(env.Vars))
+	for _, v := range env.Vars {
+		if !v.Name.ContainsExpression() {
+			m[strings.ToLower(v.Name.Value)] = v.Name
+		}
+	}
+	return m
+}
+
 func (rule *RuleEnvVar) checkEnv(env *Env) {
+	rule.checkEnvVars(env, true)
+}
+
+func (rule *RuleEnvVar) checkContainerEnv(env *Env) {
+	rule.checkEnvVars(env, false)
+}
+
+func (rule *RuleEnvVar) checkEnvVars(env *Env, runner bool) {
 	if env == nil || env.Expression != nil {
 		return
 	}
@@ -51,12 +197,84 @@ func (rule *RuleEnvVar) checkEnv(env *Env) {
 		if v.Name.ContainsExpression() {
 			continue // Key name can contain expressions (#312)
 		}
-		if strings.ContainsAny(v.Name.Value, "&= 	") {
-			rule.Errorf(
-				v.Name.Pos,
-				"environment variable name %q is invalid. '&', '=' and spaces should not be contained",
-				v.Name.Value,
+		rule.checkName(v.Name, runner)
+	}
+}
+
+func (rule *RuleEnvVar) checkName(n *String, runner bool) {
+	name := n.Value
+	if strings.ContainsAny(name, "&= \t\r\n\x00") {
+		rule.Errorf(
+			n.Pos,
+			"environment variable name %q is invalid. '&', '=' and spaces should not be contained",
+			name,
+		)
+		return
+	}
+	if name != "" && '0' <= name[0] && name[0] <= '9' {
+		rule.Errorf(n.Pos, "environment variable name %q is invalid. it must not start with a digit", name)
+		return
+	}
+	if !runner {
+		return
+	}
+	// Environment variable names are case-sensitive except on Windows
+	key := name
+	if rule.windows {
+		key = strings.ToUpper(name)
+	}
+	if _, ok := defaultEnvVarNames[key]; ok {
+		rule.Errorf(
+			n.Pos,
+			"environment variable %q is a default environment variable set by GitHub Actions. its value cannot be overwritten with \"env:\". see https://docs.github.com/en/actions/reference/workflows-and-actions/variables#default-environment-variables",
+			name,
+		)
+		return
+	}
+	if _, ok := allowedReservedEnvVarNames[key]; ok {
+		return
+	}
+	for _, p := range reservedEnvVarPrefixes {
+		if strings.HasPrefix(key, p) {
+			e := errorfAt(
+				n.Pos,
+				rule.name,
+				"environment variable name %q starts with prefix %q reserved by GitHub Actions. the variable may conflict with variables set by GitHub Actions or the runner in the future. see https://docs.github.com/en/actions/reference/workflows-and-actions/variables#naming-conventions-for-environment-variables",
+				name,
+				p,
 			)
+			e.Severity = SeverityWarning
+			rule.Report(e)
+			return
+		}
+	}
+}
+
+// checkCaseCollision checks environment variable names which differ only in case from names
+// defined at upper levels. Environment variable names are case-sensitive on Linux and macOS but
+// case-insensitive on Windows. So they are checked only when the job may run on Windows or the OS
+// of the runner is unknown. Names in the same "env:" mapping are checked by parser.
+func (rule *RuleEnvVar) checkCaseCollision(env *Env, uppers ...map[string]*String) {
+	if env == nil || env.Expression != nil || !rule.windows && !rule.unknownOS {
+		return
+	}
+	for _, v := range env.Vars {
+		if v.Name.ContainsExpression() {
+			continue
+		}
+		k := strings.ToLower(v.Name.Value)
+		for _, vars := range uppers {
+			if u, ok := vars[k]; ok && u.Value != v.Name.Value {
+				rule.ErrorfWithRelated(
+					v.Name.Pos,
+					relatedAt(u.Pos, "environment variable is defined here"),
+					"environment variable %q differs only in case from %q defined at %s. they are different variables on Linux and macOS, but the same variable on Windows",
+					v.Name.Value,
+					u.Value,
+					u.Pos,
+				)
+				break
+			}
 		}
 	}
 }
@@ -1,6 +1,9 @@
 package actionlint
 
-import "testing"
+import (
+	"strings"
+	"testing"
+)
 
 func testValidateEnvVarName(t *testing.T, name string) []*Error {
 	t.Helper()
@@ -25,6 +28,12 @@ func TestRuleEnvVarCheckValidName(t *testing.T) {
 		"foo-bar",
 		"_",
 		"-",
+		"GITHUB_TOKEN",
+		"RUNNER_DEBUG",
+		"CI",
+		"FOO1",
+		"github_workspace",
+		"runner_os",
 	}
 
 	for _, n := range valids {
@@ -41,6 +50,10 @@ func TestRuleEnvVarCheckInvalidName(t *testing.T) {
 		"a b",
 		"a=b",
 		"a=b",
+		"a\nb",
+		"1FOO",
+		"GITHUB_SHA",
+		"RUNNER_OS",
 	}
 
 	for _, n := range invalids {
@@ -52,6 +65,139 @@ func TestRuleEnvVarCheckInvalidName(t *testing.T) {
 	}
 }
 
+func TestRuleEnvVarCaseInsensitiveOnWindows(t *testing.T) {
+	testCases := []struct {
+		what   string
+		labels []string
+		matrix []string
+		err    bool
+	}{
+		{"linux", []string{"ubuntu-latest"}, nil, false},
+		{"windows", []string{"windows-latest"}, nil, true},
+		{"self-hosted windows", []string{"self-hosted", "windows"}, nil, true},
+		{"windows in matrix", []string{"${{ matrix.os }}"}, []string{"ubuntu-latest", "windows-latest"}, true},
+		{"linux in matrix", []string{"${{ matrix.os }}"}, []string{"ubuntu-latest", "macos-latest"}, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.what, func(t *testing.T) {
+			labels := []*String{}
+			for _, l := range tc.labels {
+				labels = append(labels, &String{Value: l, Pos: &Pos{}})
+			}
+			j := &Job{
+				RunsOn: &Runner{Labels: labels},
+				Env: &Env{
+					Vars: map[string]*EnvVar{
+						"github_workspace": {&String{Value: "github_workspace", Pos: &Pos{}}, &String{Value: "", Pos: &Pos{}}},
+					},
+				},
+			}
+			if tc.matrix != nil {
+				vs := []RawYAMLValue{}
+				for _, v := range tc.matrix {
+					vs = append(vs, &RawYAMLString{Value: v})
+				}
+				j.Strategy = &Strategy{
+					Matrix: &Matrix{
+						Rows: map[string]*MatrixRow{
+							"os": {Name: &String{Value: "os", Pos: &Pos{}}, Values: vs},
+						},
+					},
+				}
+			}
+
+			r := NewRuleEnvVar()
+			if err := r.VisitJobPre(j); err != nil {
+				t.Fatal(err)
+			}
+			errs := r.Errs()
+			if tc.err && len(errs) == 0 {
+				t.Fatal("error should be reported on Windows but got no error")
+			}
+			if !tc.err && len(errs) > 0 {
+				t.Fatal("no error should be reported but got", errs)
+			}
+		})
+	}
+}
+
+func TestRuleEnvVarCaseCollisionDependsOnRunnerOS(t *testing.T) {
+	testCases := []struct {
+		what      string
+		labels    []string
+		container bool
+		err       bool
+	}{
+		{"linux", []string{"ubuntu-latest"}, false, false},
+		{"macos", []string{"macos-latest"}, false, false},
+		{"windows", []string{"windows-latest"}, false, true},
+		{"unknown", []string{"self-hosted", "my-runner"}, false, true},
+		{"expression", []string{"${{ vars.RUNNER }}"}, false, true},
+		{"container", []string{"self-hosted", "my-runner"}, true, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.what, func(t *testing.T) {
+			labels := []*String{}
+			for _, l := range tc.labels {
+				labels = append(labels, &String{Value: l, Pos: &Pos{}})
+			}
+			j := &Job{
+				RunsOn: &Runner{Labels: labels},
+				Env: &Env{
+					Vars: map[string]*EnvVar{
+						"foo": {&String{Value: "foo", Pos: &Pos{}}, &String{Value: "", Pos: &Pos{}}},
+					},
+				},
+			}
+			if tc.container {
+				j.Container = &Container{Image: &String{Value: "alpine", Pos: &Pos{}}}
+			}
+
+			r := NewRuleEnvVar()
+			w := &Workflow{
+				Env: &Env{
+					Vars: map[string]*EnvVar{
+						"foo": {&String{Value: "FOO", Pos: &Pos{}}, &String{Value: "", Pos: &Pos{}}},
+					},
+				},
+				Jobs: map[string]*Job{"test": j},
+			}
+			if err := r.VisitWorkflowPre(w); err != nil {
+				t.Fatal(err)
+			}
+			if err := r.VisitJobPre(j); err != nil {
+				t.Fatal(err)
+			}
+			errs := r.Errs()
+			if tc.err && len(errs) == 0 {
+				t.Fatal("error should be reported but got no error")
+			}
+			if !tc.err && len(errs) > 0 {
+				t.Fatal("no error should be reported but got", errs)
+			}
+		})
+	}
+}
+
+func TestRuleEnvVarWarnReservedPrefix(t *testing.T) {
+	for _, n := range []string{"GITHUB_FOO", "RUNNER_FOO"} {
+		t.Run(n, func(t *testing.T) {
+			errs := testValidateEnvVarName(t, n)
+			if len(errs) != 1 {
+				t.Fatalf("one warning should be reported for %q but got %v", n, errs)
+			}
+			if errs[0].Severity != SeverityWarning {
+				t.Fatalf("warning should be reported but got %v", errs[0])
+			}
+			if !strings.Contains(errs[0].Message, "reserved by GitHub Actions") {
+				t.Fatalf("unexpected message: %q", errs[0].Message)
+			}
+		})
+	}
+}
+
 func TestRuleEnvVarSkipEdgeCaseEnv(t *testing.T) {
 	w := &Workflow{Env: nil}
 	r := NewRuleEnvVar()
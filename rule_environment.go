@@ -0,0 +1,103 @@
+package actionlint
+
+import (
+	"slices"
+	"strings"
+)
+
+// RuleEnvironment is a rule to check environment names at "environment:" of jobs and default values
+// of "environment" type inputs of "workflow_dispatch" event. Names are checked
+// against the environments configured in the GitHub repository so this rule is enabled only in online
+// mode.
+// https://docs.github.com/en/actions/writing-workflows/workflow-syntax-for-github-actions#jobsjob_idenvironment
+type RuleEnvironment struct {
+	RuleBase
+	remote *RemoteRepository
+}
+
+// NewRuleEnvironment creates new RuleEnvironment instance. 'remote' is the GitHub repository which
+// the workflows belong to.
+func NewRuleEnvironment(remote *RemoteRepository) *RuleEnvironment {
+	return &RuleEnvironment{
+		RuleBase: RuleBase{
+			name: "environment",
+			desc: "Checks for environment names at \"environment:\" configured in the repository. This rule is enabled by online checks",
+		},
+		remote: remote,
+	}
+}
+
+// VisitWorkflowPre is callback when visiting Workflow node before visiting its children.
+func (rule *RuleEnvironment) VisitWorkflowPre(n *Workflow) error {
+	for _, e := range n.On {
+		d, ok := e.(*WorkflowDispatchEvent)
+		if !ok {
+			continue
+		}
+		for _, i := range d.Inputs {
+			// The value of "environment" type input is selected from the environments in the repository
+			if i.Type != WorkflowDispatchEventInputTypeEnvironment || i.Default == nil {
+				continue
+			}
+			if envs, hint, ok := rule.unknown(i.Default); ok {
+				rule.ErrorfWithFixes(
+					i.Default.Pos,
+					typoFixes(i.Default, envs),
+					"default value %q of \"environment\" type input %q is not an environment configured in repository %q. %s note: this check was done with GitHub API",
+					i.Default.Value,
+					i.Name.Value,
+					rule.remote.FullName(),
+					hint,
+				)
+			}
+		}
+	}
+	return nil
+}
+
+// VisitJobPre is callback when visiting Job node before visiting its children.
+func (rule *RuleEnvironment) VisitJobPre(n *Job) error {
+	if n.Environment == nil {
+		return nil
+	}
+	name := n.Environment.Name
+	if envs, hint, ok := rule.unknown(name); ok {
+		rule.ErrorfWithFixes(
+			name.Pos,
+			typoFixes(name, envs),
+			"environment %q is not configured in repository %q. GitHub creates a new environment without any protection rules and secrets when the job runs. %s note: this check was done with GitHub API",
+			name.Value,
+			rule.remote.FullName(),
+			hint,
+		)
+	}
+	return nil
+}
+
+// unknown returns the environments in the repository and the hint for the error message when the
+// name is not configured in the repository.
+func (rule *RuleEnvironment) unknown(name *String) ([]string, string, bool) {
+	if name == nil || name.ContainsExpression() {
+		return nil, "", false
+	}
+
+	envs := rule.remote.Environments()
+	if envs == nil {
+		return nil, "", false // Could not fetch environments
+	}
+
+	for _, e := range envs {
+		// Environment names are case-insensitive
+		if strings.EqualFold(e, name.Value) {
+			return nil, "", false
+		}
+	}
+
+	hint := "no environment is configured in the repository."
+	if s := similarNames(name.Value, envs); len(s) > 0 {
+		hint = "did you mean " + quotes(s) + "?"
+	} else if len(envs) > 0 {
+		hint = "available environments are " + sortedQuotes(slices.Clone(envs)) + "."
+	}
+	return envs, hint, true
+}
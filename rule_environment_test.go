@@ -0,0 +1,140 @@
+package actionlint
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestRuleEnvironmentConfiguredInRepository(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path != "/repos/owner/repo/environments" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		fmt.Fprint(w, `{"total_count":3,"environments":[{"name":"production"},{"name":"staging"},{"name":"github-pages"}]}`)
+	}))
+	defer srv.Close()
+
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"production", ""},
+		{"Production", ""},
+		{"${{ inputs.env }}", ""},
+		{"prodution", `environment "prodution" is not configured in repository "owner/repo". GitHub creates a new environment without any protection rules and secrets when the job runs. did you mean "production"?`},
+		{"test", `environment "test" is not configured in repository "owner/repo". GitHub creates a new environment without any protection rules and secrets when the job runs. available environments are "github-pages", "production", "staging".`},
+	}
+
+	remote := NewRemoteRepository("owner", "repo", srv.URL, "", "", nil, nil)
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			j := &Job{
+				ID: &String{Value: "test", Pos: &Pos{}},
+				Environment: &Environment{
+					Name: &String{Value: tc.name, Pos: &Pos{}},
+				},
+			}
+			r := NewRuleEnvironment(remote)
+			if err := r.VisitJobPre(j); err != nil {
+				t.Fatal(err)
+			}
+			errs := r.Errs()
+			if tc.want == "" {
+				if len(errs) > 0 {
+					t.Fatalf("wanted no error but got %v", errs)
+				}
+				return
+			}
+			if len(errs) != 1 {
+				t.Fatalf("wanted one error but got %v", errs)
+			}
+			if msg := errs[0].Message; !strings.Contains(msg, tc.want) {
+				t.Fatalf("error message %q does not contain %q", msg, tc.want)
+			}
+		})
+	}
+}
+
+func TestRuleEnvironmentNoEnvironmentInRepository(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		fmt.Fprint(w, `{"total_count":0,"environments":[]}`)
+	}))
+	defer srv.Close()
+
+	j := &Job{
+		ID:          &String{Value: "test", Pos: &Pos{}},
+		Environment: &Environment{Name: &String{Value: "production", Pos: &Pos{}}},
+	}
+	r := NewRuleEnvironment(NewRemoteRepository("owner", "repo", srv.URL, "", "", nil, nil))
+	if err := r.VisitJobPre(j); err != nil {
+		t.Fatal(err)
+	}
+	errs := r.Errs()
+	if len(errs) != 1 {
+		t.Fatalf("wanted one error but got %v", errs)
+	}
+	if msg := errs[0].Message; !strings.Contains(msg, "no environment is configured in the repository") {
+		t.Fatalf("unexpected error message: %q", msg)
+	}
+}
+
+func TestRuleEnvironmentCouldNotFetch(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer srv.Close()
+
+	j := &Job{
+		ID:          &String{Value: "test", Pos: &Pos{}},
+		Environment: &Environment{Name: &String{Value: "production", Pos: &Pos{}}},
+	}
+	r := NewRuleEnvironment(NewRemoteRepository("owner", "repo", srv.URL, "", "", nil, nil))
+	if err := r.VisitJobPre(j); err != nil {
+		t.Fatal(err)
+	}
+	if errs := r.Errs(); len(errs) > 0 {
+		t.Fatalf("wanted no error when environments could not be fetched but got %v", errs)
+	}
+}
+
+func TestRuleEnvironmentDefaultOfDispatchInput(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		fmt.Fprint(w, `{"total_count":2,"environments":[{"name":"production"},{"name":"staging"}]}`)
+	}))
+	defer srv.Close()
+
+	input := func(name, def string, ty WorkflowDispatchEventInputType) *DispatchInput {
+		return &DispatchInput{
+			Name:    &String{Value: name, Pos: &Pos{}},
+			Default: &String{Value: def, Pos: &Pos{}},
+			Type:    ty,
+		}
+	}
+	w := &Workflow{
+		On: []Event{
+			&WorkflowDispatchEvent{
+				Inputs: map[string]*DispatchInput{
+					"ok":     input("ok", "Staging", WorkflowDispatchEventInputTypeEnvironment),
+					"typo":   input("typo", "prodction", WorkflowDispatchEventInputTypeEnvironment),
+					"string": input("string", "prodction", WorkflowDispatchEventInputTypeString),
+				},
+			},
+		},
+	}
+	r := NewRuleEnvironment(NewRemoteRepository("owner", "repo", srv.URL, "", "", nil, nil))
+	if err := r.VisitWorkflowPre(w); err != nil {
+		t.Fatal(err)
+	}
+	errs := r.Errs()
+	if len(errs) != 1 {
+		t.Fatalf("wanted one error but got %v", errs)
+	}
+	want := `default value "prodction" of "environment" type input "typo" is not an environment configured in repository "owner/repo". did you mean "production"?`
+	if msg := errs[0].Message; !strings.Contains(msg, want) {
+		t.Fatalf("error message %q does not contain %q", msg, want)
+	}
+}
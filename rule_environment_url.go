@@ -0,0 +1,208 @@
+package actionlint
+
+import (
+	"net/url"
+	"strings"
+)
+
+// RuleEnvironmentURL is a rule to check URLs at "environment.url" of jobs. The URL is shown as the
+// link to the deployment. GitHub silently ignores the invalid URL so the link is broken without any
+// error.
+// https://docs.github.com/en/actions/writing-workflows/workflow-syntax-for-github-actions#jobsjob_idenvironment
+type RuleEnvironmentURL struct {
+	RuleBase
+}
+
+// NewRuleEnvironmentURL creates new RuleEnvironmentURL instance.
+func NewRuleEnvironmentURL() *RuleEnvironmentURL {
+	return &RuleEnvironmentURL{
+		RuleBase: RuleBase{
+			name: "environment-url",
+			desc: "Checks for URLs at \"environment.url\" of jobs",
+		},
+	}
+}
+
+// VisitJobPre is callback when visiting Job node before visiting its children.
+func (rule *RuleEnvironmentURL) VisitJobPre(n *Job) error {
+	if n.Environment == nil || n.Environment.URL == nil {
+		return nil
+	}
+	u := n.Environment.URL
+
+	v := strings.TrimSpace(u.Value)
+	if !u.IsExpressionAssigned() {
+		if i := strings.Index(v, "${{"); i >= 0 {
+			rule.checkSkippedSteps(u, n.Steps)
+			v = v[:i] // Check the literal prefix like "https://${{ steps.deploy.outputs.host }}"
+			if v == "" {
+				return nil
+			}
+			if strings.HasPrefix("https://", v) || strings.HasPrefix("http://", v) {
+				return nil // Scheme continues in the expression
+			}
+		}
+		if !isHTTPURL(v) {
+			rule.Errorf(
+				u.Pos,
+				"\"url\" of environment must be a URL starting with \"https://\" or \"http://\" but got %q. the URL is shown on the deployment",
+				u.Value,
+			)
+		}
+		return nil
+	}
+
+	src := strings.TrimSpace(v[3:len(v)-2]) + "}}"
+	l := NewExprLexer(src)
+	e, err := NewExprParser().Parse(l)
+	l.Release()
+	if err != nil {
+		return nil // Syntax error is reported by "expression" rule
+	}
+
+	ty := ""
+	switch e := e.(type) {
+	case *BoolNode, *CompareOpNode, *NotOpNode:
+		ty = "bool"
+	case *IntNode, *FloatNode:
+		ty = "number"
+	case *NullNode:
+		ty = "null"
+	case *StringNode:
+		if !isHTTPURL(e.Value) {
+			rule.Errorf(
+				u.Pos,
+				"\"url\" of environment must be a URL starting with \"https://\" or \"http://\" but expression %q produces string %q",
+				u.Value,
+				e.Value,
+			)
+		}
+		return nil
+	case *FuncCallNode:
+		switch strings.ToLower(e.Callee) {
+		case "contains", "startswith", "endswith", "success", "failure", "always", "cancelled":
+			ty = "bool"
+		}
+	}
+	if ty != "" {
+		rule.Errorf(
+			u.Pos,
+			"\"url\" of environment must be a URL but expression %q produces %s value. use an expression producing URL string like \"${{ steps.deploy.outputs.url }}\"",
+			u.Value,
+			ty,
+		)
+		return nil
+	}
+
+	rule.checkSkippedSteps(u, n.Steps)
+	return nil
+}
+
+// checkSkippedSteps checks outputs of steps referenced in the URL. When the step may be skipped by
+// its "if:" condition, the output is empty and the URL is broken. References with fallback values
+// like `steps.deploy.outputs.url || 'https://example.com'` are not reported.
+func (rule *RuleEnvironmentURL) checkSkippedSteps(u *String, steps []*Step) {
+	conds := map[string]*String{}
+	for _, s := range steps {
+		if s.ID != nil && s.If != nil && !s.ID.ContainsExpression() {
+			conds[strings.ToLower(s.ID.Value)] = s.If
+		}
+	}
+	if len(conds) == 0 {
+		return
+	}
+
+	reported := map[string]struct{}{}
+	s := u.Value
+	for {
+		i := strings.Index(s, "${{")
+		if i < 0 {
+			return
+		}
+		s = s[i+3:]
+		l := NewExprLexer(s)
+		e, err := NewExprParser().Parse(l)
+		o := l.Offset()
+		l.Release()
+		if err != nil {
+			return
+		}
+		s = s[o:]
+
+		fallback := false
+		VisitExprNode(e, func(n, _ ExprNode, entering bool) {
+			if o, ok := n.(*LogicalOpNode); ok && entering && o.Kind == LogicalOpNodeKindOr {
+				fallback = true
+			}
+		})
+		if fallback {
+			continue
+		}
+
+		VisitExprNode(e, func(n, _ ExprNode, entering bool) {
+			if !entering {
+				return
+			}
+			id, out := stepOutputRef(n)
+			if id == "" {
+				return
+			}
+			cond, ok := conds[id]
+			if !ok || isAlwaysTrueCond(cond) {
+				return
+			}
+			if _, ok := reported[id]; ok {
+				return
+			}
+			reported[id] = struct{}{}
+			rule.Errorf(
+				u.Pos,
+				"\"url\" of environment refers to output %q of step %q but the step may be skipped by its \"if:\" condition %q. the URL is broken when the step is skipped. add a fallback value with || operator like \"${{ steps.%s.outputs.%s || '...' }}\"",
+				out,
+				id,
+				cond.Value,
+				id,
+				out,
+			)
+		})
+	}
+}
+
+// stepOutputRef returns the step ID and the output name when the node accesses an output of a step
+// like `steps.deploy.outputs.url`. Otherwise it returns empty strings.
+func stepOutputRef(n ExprNode) (string, string) {
+	d, ok := n.(*ObjectDerefNode)
+	if !ok {
+		return "", ""
+	}
+	outs, ok := d.Receiver.(*ObjectDerefNode)
+	if !ok || outs.Property != "outputs" {
+		return "", ""
+	}
+	step, ok := outs.Receiver.(*ObjectDerefNode)
+	if !ok {
+		return "", ""
+	}
+	if v, ok := step.Receiver.(*VariableNode); !ok || v.Name != "steps" {
+		return "", ""
+	}
+	return strings.ToLower(step.Property), strings.ToLower(d.Property)
+}
+
+// isAlwaysTrueCond returns true when the "if:" condition is always() or a constant expression which
+// is truthy.
+func isAlwaysTrueCond(cond *String) bool {
+	e := parseIfCondExpr(cond)
+	if e == nil {
+		return false
+	}
+	if f, ok := e.(*FuncCallNode); ok && strings.EqualFold(f.Callee, "always") {
+		return true
+	}
+	return !isAlwaysFalseCond(e) && NewExprSemanticsChecker(false, nil).IsConstant(e)
+}
+
+func isHTTPURL(s string) bool {
+	u, err := url.Parse(s)
+	return err == nil && (u.Scheme == "https" || u.Scheme == "http") && u.Host != ""
+}
@@ -1,6 +1,9 @@
 package actionlint
 
 import (
+	"fmt"
+	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -9,11 +12,23 @@ import (
 )
 
 //go:generate go run ./scripts/generate-webhook-events ./all_webhooks.go
+//go:generate go run ./scripts/generate-webhook-events ./scripts/generate-webhook-events/webhook_events.json
 
 // RuleEvents is a rule to check 'on' field in workflow.
 // https://docs.github.com/en/actions/reference/workflows-and-actions/events-that-trigger-workflows
 type RuleEvents struct {
 	RuleBase
+	// workflows is used to find names of workflows in the project for "workflow_run" event. This
+	// field is optional
+	workflows *LocalReusableWorkflowCache
+	path      string
+	platform  Platform
+	// template is true when the workflow is a workflow template. Placeholders like "$cron-daily"
+	// are accepted in the template.
+	template bool
+	// now returns the current time. Fire times of CRON schedules in error messages are computed
+	// from the time. This field can be replaced for testing.
+	now func() time.Time
 }
 
 // NewRuleEvents creates new RuleEvents instance.
@@ -23,27 +38,141 @@ func NewRuleEvents() *RuleEvents {
 			name: "events",
 			desc: "Checks for workflow trigger events at \"on:\"",
 		},
+		now: time.Now,
 	}
 }
 
 // VisitWorkflowPre is callback when visiting Workflow node before visiting its children.
 func (rule *RuleEvents) VisitWorkflowPre(n *Workflow) error {
+	// Events in a mapping cannot be duplicated since duplicate keys are reported by parser. But
+	// events in a sequence like `on: [push, push]` can be duplicated.
+	seen := make(map[string]*Pos, len(n.On))
 	for _, e := range n.On {
+		name := e.EventName()
+		pos := eventPos(e)
+		if prev, ok := seen[name]; ok {
+			rule.ErrorfWithRelated(pos, relatedAt(prev, "previously defined here"), "event %q is duplicated in \"on\" section. previously defined at %s", name, prev)
+			continue
+		}
+		seen[name] = pos
 		rule.checkEvent(e)
 	}
+	rule.checkPullRequestEvents(seen, n)
 	return nil
 }
 
+var rePullRequestHeadRef = regexp.MustCompile(`\bgithub\.(event\.pull_request\.head\.(sha|ref)|head_ref)\b`)
+
+// findPullRequestHeadCheckout returns the position of "ref:" input of actions/checkout which checks
+// out the head of the pull request. It returns nil when no such step is found or some condition at
+// "if:" distinguishes the events by "github.event_name".
+func findPullRequestHeadCheckout(w *Workflow) *Pos {
+	var found *Pos
+	distinguished := false
+	cond := func(s *String) {
+		if s != nil && strings.Contains(s.Value, "github.event_name") {
+			distinguished = true
+		}
+	}
+	for _, j := range w.Jobs {
+		cond(j.If)
+		for _, s := range j.Steps {
+			cond(s.If)
+			e, ok := s.Exec.(*ExecAction)
+			if !ok || e.Uses == nil || !strings.HasPrefix(strings.ToLower(e.Uses.Value), "actions/checkout@") {
+				continue
+			}
+			if i, ok := e.Inputs["ref"]; ok && i.Value != nil && rePullRequestHeadRef.MatchString(i.Value.Value) {
+				if found == nil || i.Value.Pos.IsBefore(found) {
+					found = i.Value.Pos
+				}
+			}
+		}
+	}
+	if distinguished {
+		return nil
+	}
+	return found
+}
+
+// checkPullRequestEvents checks the workflow triggered by both "pull_request" and "pull_request_target"
+// events does not check out the head of the pull request. The checkout is usual on "pull_request"
+// event but on "pull_request_target" event the untrusted code runs with write permissions and secrets
+// of the base repository. Workflows which distinguish the events with conditions are not reported.
+// The workflows which have the same name in the project are also checked since they are not
+// distinguished in the GitHub UI.
+// https://docs.github.com/en/actions/reference/workflows-and-actions/events-that-trigger-workflows#pull_request_target
+func (rule *RuleEvents) checkPullRequestEvents(seen map[string]*Pos, w *Workflow) {
+	pr, hasPR := seen["pull_request"]
+	target, hasTarget := seen["pull_request_target"]
+	if hasPR && hasTarget {
+		checkout := findPullRequestHeadCheckout(w)
+		if checkout == nil {
+			return
+		}
+		e := errorfAt(
+			target,
+			rule.name,
+			"workflow is triggered by both \"pull_request\" and \"pull_request_target\" events and checks out the head of the pull request at %s. the checkout is usual for \"pull_request\" event but on \"pull_request_target\" event the untrusted code runs with write permissions and secrets of the base repository. split the workflow into separate workflows for each event or distinguish them by \"github.event_name\"",
+			checkout,
+		)
+		e.Related = relatedAt(pr, "\"pull_request\" event is here")
+		e.Severity = SeverityWarning
+		rule.Report(e)
+		return
+	}
+
+	if rule.workflows == nil || hasPR == hasTarget {
+		return
+	}
+	event, other, pos := "pull_request", "pull_request_target", pr
+	if hasTarget {
+		event, other, pos = other, event, target
+	}
+	name, ws := rule.workflows.findSameNameWorkflows(rule.path, other, rule.platform)
+	if len(ws) == 0 {
+		return
+	}
+	e := errorfAt(
+		pos,
+		rule.name,
+		"workflow is triggered by %q event but other workflow %s with the same name %q is triggered by %q event. runs of the two workflows are not distinguished in the GitHub UI and share concurrency groups using \"github.workflow\" though they run with different permissions and secrets. give the workflows different names",
+		event,
+		quotes(ws),
+		name,
+		other,
+	)
+	e.Severity = SeverityWarning
+	rule.Report(e)
+}
+
+func eventPos(event Event) *Pos {
+	switch e := event.(type) {
+	case *ScheduledEvent:
+		return e.Pos
+	case *WorkflowDispatchEvent:
+		return e.Pos
+	case *RepositoryDispatchEvent:
+		return e.Pos
+	case *WorkflowCallEvent:
+		return e.Pos
+	case *WebhookEvent:
+		return e.Pos
+	case *ImageVersionEvent:
+		return e.Pos
+	default:
+		panic("unreachable")
+	}
+}
+
 func (rule *RuleEvents) checkEvent(event Event) {
 	switch e := event.(type) {
 	case *ScheduledEvent:
-		for _, c := range e.Cron {
-			rule.checkCron(c)
-		}
+		rule.checkScheduledEvent(e)
 	case *WorkflowDispatchEvent:
 		rule.checkWorkflowDispatchEvent(e)
 	case *RepositoryDispatchEvent:
-		// Nothing to do
+		rule.checkDuplicateTypes("repository_dispatch", e.Types)
 	case *WorkflowCallEvent:
 		rule.checkWorkflowCallEvent(e)
 	case *WebhookEvent:
@@ -55,8 +184,25 @@ func (rule *RuleEvents) checkEvent(event Event) {
 	}
 }
 
+func (rule *RuleEvents) checkScheduledEvent(event *ScheduledEvent) {
+	seen := make(map[string]*Pos, len(event.Cron))
+	for _, c := range event.Cron {
+		// Normalize spaces between fields to detect duplicates like "0 0 * * *" and "0  0 * * *"
+		key := strings.Join(strings.Fields(c.Value), " ")
+		if prev, ok := seen[key]; ok {
+			rule.ErrorfWithRelated(c.Pos, relatedAt(prev, "previously defined here"), "CRON schedule %q is duplicated in schedule event. previously defined at %s", c.Value, prev)
+			continue
+		}
+		seen[key] = c.Pos
+		rule.checkCron(c)
+	}
+}
+
 // https://docs.github.com/en/actions/learn-github-actions/workflow-syntax-for-github-actions#onschedule
 func (rule *RuleEvents) checkCron(spec *String) {
+	if rule.template && spec.Value == "$cron-daily" {
+		return // The placeholder is replaced with a daily schedule when the template is used
+	}
 	p := cron.NewParser(cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow)
 	sched, err := p.Parse(spec.Value)
 	if err != nil {
@@ -64,7 +210,14 @@ func (rule *RuleEvents) checkCron(spec *String) {
 		return
 	}
 
+	// Next() returns zero time when no time matching to the schedule is found within 5 years. For
+	// example, "0 0 30 2 *" (Feb 30) never matches.
 	start := sched.Next(time.Unix(0, 0))
+	if start.IsZero() {
+		rule.Errorf(spec.Pos, "CRON schedule %q never fires since no date matches to it. check the day of month and the month", spec.Value)
+		return
+	}
+	rule.Debug("CRON schedule %q: %s", spec.Value, rule.cronFireTimes(sched))
 	next := sched.Next(start)
 	diff := next.Sub(start).Seconds()
 
@@ -72,11 +225,48 @@ func (rule *RuleEvents) checkCron(spec *String) {
 	//
 	// > The shortest interval you can run scheduled workflows is once every 5 minutes.
 	if diff < 60.0*5 {
-		rule.Errorf(spec.Pos, "scheduled job runs too frequently. it runs once per %g seconds. the shortest interval is once every 5 minutes", diff)
+		rule.Errorf(spec.Pos, "scheduled job runs too frequently. it runs once per %g seconds. the shortest interval is once every 5 minutes. %s", diff, rule.cronFireTimes(sched))
+	}
+
+	// When both day of month and day of week are restricted, the schedule matches when either of
+	// them matches (OR), not both (AND). This is a surprising behavior of POSIX cron.
+	// https://pubs.opengroup.org/onlinepubs/9699919799/utilities/crontab.html#tag_20_25_07
+	// The parsed schedule is checked in the same way as robfig/cron matches days so that the error
+	// is consistent with the fire times. Note that a field with step like "*/2" is not a wildcard.
+	if s, ok := sched.(*cron.SpecSchedule); ok && s.Dom&cronStarBit == 0 && s.Dow&cronStarBit == 0 {
+		fs := strings.Fields(spec.Value)
+		rule.Errorf(
+			spec.Pos,
+			"both day of month %q and day of week %q are specified in CRON schedule %q. the job runs when either of them matches, not both. %s",
+			fs[2],
+			fs[4],
+			spec.Value,
+			rule.cronFireTimes(sched),
+		)
 	}
 }
 
-func (rule *RuleEvents) filterNotAvailable(pos *Pos, filter, hook string, available []string) {
+// cronStarBit is the bit set to the fields of cron.SpecSchedule when the field is "*" or "?". It is
+// the same as the unexported starBit constant in robfig/cron.
+const cronStarBit = 1 << 63
+
+// cronFireTimes describes the next few fire times of the schedule to help users confirm the
+// schedule behaves as intended.
+func (rule *RuleEvents) cronFireTimes(sched cron.Schedule) string {
+	var b strings.Builder
+	t := rule.now().UTC()
+	for i := 0; i < 3; i++ {
+		t = sched.Next(t)
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		b.WriteString(t.Format("2006-01-02 15:04 Mon"))
+	}
+	return fmt.Sprintf("next fire times are %s in UTC", b.String())
+}
+
+func (rule *RuleEvents) filterNotAvailable(pos *Pos, filter, hook string) {
+	available := webhookEventsWithFilter(filter)
 	e := "events"
 	if len(available) < 2 {
 		e = "event"
@@ -84,16 +274,17 @@ func (rule *RuleEvents) filterNotAvailable(pos *Pos, filter, hook string, availa
 	rule.Errorf(pos, "%q filter is not available for %s event. it is only for %s %s", filter, hook, strings.Join(available, ", "), e)
 }
 
-func (rule *RuleEvents) checkExclusiveFilters(filter, ignore *WebhookEventFilter, hook string, available []string) {
-	ok := false
-	for _, a := range available {
-		if a == hook {
-			ok = true
-			break
-		}
+func (rule *RuleEvents) checkExclusiveFilters(filter, ignore *WebhookEventFilter, hook string, meta *WebhookEventMetadata) {
+	if filter.IsEmpty() && ignore.IsEmpty() {
+		return
 	}
 
-	if ok {
+	// Filters and their "-ignore" variants are available for the same events
+	f := filter
+	if f.IsEmpty() {
+		f = ignore
+	}
+	if slices.Contains(meta.Filters, f.Name.Value) {
 		if !filter.IsEmpty() && !ignore.IsEmpty() {
 			p := filter.Name.Pos
 			if p.IsBefore(ignore.Name.Pos) {
@@ -103,11 +294,42 @@ func (rule *RuleEvents) checkExclusiveFilters(filter, ignore *WebhookEventFilter
 		}
 	} else {
 		if !filter.IsEmpty() {
-			rule.filterNotAvailable(filter.Name.Pos, filter.Name.Value, hook, available)
+			rule.filterNotAvailable(filter.Name.Pos, filter.Name.Value, hook)
 		}
 		if !ignore.IsEmpty() {
-			rule.filterNotAvailable(ignore.Name.Pos, ignore.Name.Value, hook, available)
+			rule.filterNotAvailable(ignore.Name.Pos, ignore.Name.Value, hook)
+		}
+	}
+}
+
+// checkWorkflowRunWorkflows checks the workflow names at "workflows:" of "workflow_run" event match to
+// names of the workflows in the project. Renaming a workflow silently breaks the workflows triggered
+// by it.
+// https://docs.github.com/en/actions/reference/workflows-and-actions/events-that-trigger-workflows#workflow_run
+func (rule *RuleEvents) checkWorkflowRunWorkflows(workflows []*String) {
+	if rule.workflows == nil {
+		return
+	}
+	names, ok := rule.workflows.findWorkflowNames(rule.path, rule.platform)
+	if !ok {
+		return
+	}
+
+	for _, w := range workflows {
+		if w.ContainsExpression() || slices.Contains(names, w.Value) {
+			continue
+		}
+		hint := "available workflows are " + quotes(names) + "."
+		if s := similarNames(w.Value, names); len(s) > 0 {
+			hint = "did you mean " + quotes(s) + "? note that workflow names are case-sensitive."
 		}
+		rule.ErrorfWithFixes(
+			w.Pos,
+			typoFixes(w, names),
+			"workflow %q at \"workflows\" of \"workflow_run\" event is not found in the project. %s",
+			w.Value,
+			hint,
+		)
 	}
 }
 
@@ -115,17 +337,23 @@ func (rule *RuleEvents) checkExclusiveFilters(filter, ignore *WebhookEventFilter
 func (rule *RuleEvents) checkWebhookEvent(event *WebhookEvent) {
 	hook := event.Hook.Value
 
-	types, ok := AllWebhookTypes[hook]
+	meta, ok := AllWebhookEvents[hook]
 	if !ok {
-		rule.Errorf(event.Pos, "unknown Webhook event %q. see https://docs.github.com/en/actions/reference/workflows-and-actions/events-that-trigger-workflows#webhook-events for list of all Webhook event names", hook)
+		names := make([]string, 0, len(AllWebhookEvents))
+		for n := range AllWebhookEvents {
+			names = append(names, n)
+		}
+		rule.ErrorfWithFixes(event.Pos, typoFixes(event.Hook, names), "unknown Webhook event %q. see https://docs.github.com/en/actions/reference/workflows-and-actions/events-that-trigger-workflows#webhook-events for list of all Webhook event names", hook)
 		return
 	}
 
-	rule.checkTypes(event.Hook, event.Types, types)
+	rule.checkTypes(event.Hook, event.Types, meta.Types)
 
 	if hook == "workflow_run" {
 		if len(event.Workflows) == 0 {
 			rule.Error(event.Pos, "no workflow is configured for \"workflow_run\" event")
+		} else {
+			rule.checkWorkflowRunWorkflows(event.Workflows)
 		}
 	} else {
 		if len(event.Workflows) != 0 {
@@ -133,30 +361,28 @@ func (rule *RuleEvents) checkWebhookEvent(event *WebhookEvent) {
 		}
 	}
 
-	// Some filters are available with specific events and exclusive
-	// - on.merge_group.<branches|branches-ignore>
-	// - on.<push|pull_request|pull_request_target>.<paths|paths-ignore>
-	// - on.push.<branches|tags|branches-ignore|tags-ignore>
-	// - on.<pull_request|pull_request_target>.<branches|branches-ignore>
-	// - on.workflow_run.<branches|branches-ignore>
-	rule.checkExclusiveFilters(
-		event.Paths,
-		event.PathsIgnore,
-		hook,
-		[]string{"push", "pull_request", "pull_request_target"},
-	)
-	rule.checkExclusiveFilters(
-		event.Branches,
-		event.BranchesIgnore,
-		hook,
-		[]string{"merge_group", "push", "pull_request", "pull_request_target", "workflow_run"},
-	)
-	rule.checkExclusiveFilters(
-		event.Tags,
-		event.TagsIgnore,
-		hook,
-		[]string{"push"},
-	)
+	// Some filters are available with specific events and exclusive. Available filters of each
+	// event are listed in AllWebhookEvents.
+	rule.checkExclusiveFilters(event.Paths, event.PathsIgnore, hook, meta)
+	rule.checkExclusiveFilters(event.Branches, event.BranchesIgnore, hook, meta)
+	rule.checkExclusiveFilters(event.Tags, event.TagsIgnore, hook, meta)
+
+	// > Path filters are not evaluated for pushes of tags.
+	//
+	// When only tag filters are configured, only pushes of tags trigger the workflow. Path filters
+	// are dead in the case.
+	// https://docs.github.com/en/actions/reference/workflows-and-actions/workflow-syntax#onpushpull_requestpull_request_targetpathspaths-ignore
+	if hook == "push" && event.Branches == nil && event.BranchesIgnore == nil && (event.Tags != nil || event.TagsIgnore != nil) {
+		for _, f := range []*WebhookEventFilter{event.Paths, event.PathsIgnore} {
+			if !f.IsEmpty() {
+				rule.Errorf(
+					f.Name.Pos,
+					"%q filter of \"push\" event is never evaluated because only tag filters are configured. path filters are not evaluated for pushes of tags",
+					f.Name.Value,
+				)
+			}
+		}
+	}
 }
 
 func (rule *RuleEvents) checkTypes(hook *String, types []*String, expected []string) {
@@ -165,6 +391,8 @@ func (rule *RuleEvents) checkTypes(hook *String, types []*String, expected []str
 		return
 	}
 
+	rule.checkDuplicateTypes(hook.Value, types)
+
 	for _, ty := range types {
 		valid := false
 		for _, e := range expected {
@@ -185,6 +413,27 @@ func (rule *RuleEvents) checkTypes(hook *String, types []*String, expected []str
 	}
 }
 
+// checkDuplicateTypes reports duplicate activity types in "types" of the event. All duplicates are
+// reported in one error.
+func (rule *RuleEvents) checkDuplicateTypes(event string, types []*String) {
+	var pos *Pos
+	var dups quotesBuilder
+	count := make(map[string]int, len(types))
+	for _, ty := range types {
+		count[ty.Value]++
+		if count[ty.Value] != 2 {
+			continue
+		}
+		if pos == nil {
+			pos = ty.Pos
+		}
+		dups.append(ty.Value)
+	}
+	if pos != nil {
+		rule.Errorf(pos, "activity type(s) %s are duplicated in \"types\" of %q event", dups.build(), event)
+	}
+}
+
 // https://docs.github.com/en/actions/learn-github-actions/reusing-workflows
 func (rule *RuleEvents) checkWorkflowCallEvent(event *WorkflowCallEvent) {
 	for _, i := range event.Inputs {
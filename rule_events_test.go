@@ -0,0 +1,53 @@
+package actionlint
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRuleEventsCronFireTimesFromNow(t *testing.T) {
+	r := NewRuleEvents()
+	r.now = func() time.Time { return time.Date(2030, time.January, 1, 0, 0, 0, 0, time.UTC) }
+	r.checkCron(&String{Value: "* * * * *", Pos: &Pos{}})
+
+	errs := r.Errs()
+	if len(errs) != 1 {
+		t.Fatalf("wanted one error but got %v", errs)
+	}
+	want := "next fire times are 2030-01-01 00:01 Tue, 2030-01-01 00:02 Tue, 2030-01-01 00:03 Tue in UTC"
+	if msg := errs[0].Message; !strings.Contains(msg, want) {
+		t.Fatalf("%q is not contained in error message %q", want, msg)
+	}
+}
+
+func TestRuleEventsCronBothDayOfMonthAndDayOfWeek(t *testing.T) {
+	testCases := []struct {
+		cron string
+		err  bool
+	}{
+		{"0 0 1 * 1", true},
+		{"0 0 */2 * 1", true},
+		{"0 0 1-31 * 1", true},
+		{"0 0 1 * 1-5/2", true},
+		{"0 0 * * 1", false},
+		{"0 0 ? * 1", false},
+		{"0 0 1 * *", false},
+		{"0 0 1 * ?", false},
+		{"0 0 */1 * 1", false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.cron, func(t *testing.T) {
+			r := NewRuleEvents()
+			r.checkCron(&String{Value: tc.cron, Pos: &Pos{}})
+			errs := r.Errs()
+			if tc.err && len(errs) == 0 {
+				t.Fatal("error should be reported but got no error")
+			}
+			if !tc.err && len(errs) > 0 {
+				t.Fatal("no error should be reported but got", errs)
+			}
+		})
+	}
+}
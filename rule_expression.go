@@ -1,11 +1,14 @@
 package actionlint
 
 import (
+	"fmt"
+	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 )
 
-//go:generate go run ./scripts/generate-availability ./availability.go
+//go:generate go run ./scripts/generate-availability ./expr/availability.go
 
 type typedExpr struct {
 	ty  ExprType
@@ -19,7 +22,10 @@ type typedExpr struct {
 // - https://docs.github.com/en/actions/learn-github-actions/expressions
 type RuleExpression struct {
 	RuleBase
-	matrixTy         *ObjectType
+	matrixTy *ObjectType
+	// matrix is the matrix of the current job. Values in it are used to check types of inputs of
+	// reusable workflow call in each matrix combination.
+	matrix           *Matrix
 	stepsTy          *ObjectType
 	needsTy          *ObjectType
 	secretsTy        *ObjectType
@@ -29,6 +35,39 @@ type RuleExpression struct {
 	workflow         *Workflow
 	localActions     *LocalActionsCache
 	localWorkflows   *LocalReusableWorkflowCache
+	// remote is the GitHub repository for online checks. It is nil when online checks are disabled.
+	remote *RemoteRepository
+	// reusable is true when the workflow is a reusable workflow. Secrets and variables in reusable
+	// workflows are provided by the caller so they are not checked with the remote repository.
+	reusable bool
+	// environment is the environment name of the current job. Secrets and variables of the
+	// environment are available in the job.
+	environment *String
+	// ghes is the version of GitHub Enterprise Server. Contexts and functions not available in the
+	// version are reported. It is nil when the version is not specified.
+	ghes *GHESVersion
+	// platform is the platform which runs the workflow. Contexts specific to Gitea Actions and
+	// Forgejo Actions are available when it is compatible with Gitea Actions.
+	platform Platform
+	// actionMetadata is the action metadata read from the files at "action-metadata" in config. It
+	// is nil when no file is set.
+	actionMetadata *ActionMetadataSet
+	// composite is true when the steps of a composite action are checked. "secrets", "vars", and
+	// "needs" contexts are not available in composite actions.
+	composite bool
+	// inputDecls is the inputs declared in the composite action. Inputs which are never referenced
+	// in the action are reported.
+	inputDecls []*String
+	// inputsUsed is the set of input names referenced via "inputs" context in the composite action.
+	// It is nil when all inputs may be referenced like `toJSON(inputs)`.
+	inputsUsed map[string]struct{}
+	// runnerValues is the possible values of "runner.os" and "runner.arch" in the current job resolved
+	// from the labels at "runs-on:". Keys are the property names.
+	runnerValues map[string][]string
+	// dispatchChoices is the options of "choice" type inputs of "workflow_dispatch" event. Keys are
+	// the input names in lower case. Comparisons of the inputs with values not in the options are
+	// reported.
+	dispatchChoices map[string][]string
 }
 
 // NewRuleExpression creates new RuleExpression instance.
@@ -53,28 +92,32 @@ func NewRuleExpression(actionsCache *LocalActionsCache, workflowCache *LocalReus
 
 // VisitWorkflowPre is callback when visiting Workflow node before visiting its children.
 func (rule *RuleExpression) VisitWorkflowPre(n *Workflow) error {
-	rule.checkString(n.Name, "")
+	_, rule.reusable = n.FindWorkflowCallEvent()
+	if rule.composite {
+		rule.inputsUsed = map[string]struct{}{}
+	}
+	rule.checkNotEvaluated(n.Name, "name")
 
 	for _, e := range n.On {
 		switch e := e.(type) {
 		case *WebhookEvent:
-			rule.checkStrings(e.Types, "")
+			rule.checkNotEvaluatedStrings(e.Types, "on.<event_name>.types")
 			rule.checkWebhookEventFilter(e.Branches)
 			rule.checkWebhookEventFilter(e.BranchesIgnore)
 			rule.checkWebhookEventFilter(e.Tags)
 			rule.checkWebhookEventFilter(e.TagsIgnore)
 			rule.checkWebhookEventFilter(e.Paths)
 			rule.checkWebhookEventFilter(e.PathsIgnore)
-			rule.checkStrings(e.Workflows, "")
+			rule.checkNotEvaluatedStrings(e.Workflows, "on.workflow_run.workflows")
 		case *ScheduledEvent:
-			rule.checkStrings(e.Cron, "")
+			rule.checkNotEvaluatedStrings(e.Cron, "on.schedule.cron")
 		case *WorkflowDispatchEvent:
 			ity := NewEmptyStrictObjectType()
 			for id, i := range e.Inputs {
-				rule.checkString(i.Description, "")
-				rule.checkString(i.Default, "")
+				rule.checkNotEvaluated(i.Description, "on.workflow_dispatch.inputs.<inputs_id>.description")
+				rule.checkNotEvaluated(i.Default, "on.workflow_dispatch.inputs.<inputs_id>.default")
 				rule.checkBool(i.Required, "")
-				rule.checkStrings(i.Options, "")
+				rule.checkNotEvaluatedStrings(i.Options, "on.workflow_dispatch.inputs.<inputs_id>.options")
 
 				var ty ExprType
 				switch i.Type {
@@ -88,10 +131,17 @@ func (rule *RuleExpression) VisitWorkflowPre(n *Workflow) error {
 					ty = AnyType{}
 				}
 				ity.Props[id] = ty
+
+				if i.Type == WorkflowDispatchEventInputTypeChoice && len(i.Options) > 0 {
+					if rule.dispatchChoices == nil {
+						rule.dispatchChoices = map[string][]string{}
+					}
+					rule.dispatchChoices[id] = choiceOptions(i.Options)
+				}
 			}
 			rule.dispatchInputsTy = ity
 		case *RepositoryDispatchEvent:
-			rule.checkStrings(e.Types, "")
+			rule.checkNotEvaluatedStrings(e.Types, "on.repository_dispatch.types")
 		case *WorkflowCallEvent:
 			ity := NewEmptyStrictObjectType()
 
@@ -105,7 +155,7 @@ func (rule *RuleExpression) VisitWorkflowPre(n *Workflow) error {
 			rule.inputsTy = ity
 
 			for _, i := range e.Inputs {
-				rule.checkString(i.Description, "")
+				rule.checkNotEvaluated(i.Description, "on.workflow_call.inputs.<inputs_id>.description")
 				// Check default value before setting type to `ity` because referring myself should cause an error.
 				//   inputs:
 				//     recursive:
@@ -150,18 +200,18 @@ func (rule *RuleExpression) VisitWorkflowPre(n *Workflow) error {
 				sty := NewEmptyStrictObjectType()
 				for id, s := range e.Secrets {
 					sty.Props[id] = StringType{}
-					rule.checkString(s.Description, "")
+					rule.checkNotEvaluated(s.Description, "on.workflow_call.secrets.<secret_id>.description")
 				}
 				rule.secretsTy = sty
 			}
 
 			for _, o := range e.Outputs {
-				rule.checkString(o.Description, "")
+				rule.checkNotEvaluated(o.Description, "on.workflow_call.outputs.<output_id>.description")
 				// o.Value will be checked in VisitWorkflowPost
 			}
 		case *ImageVersionEvent:
-			rule.checkStrings(e.Names, "")
-			rule.checkStrings(e.Versions, "")
+			rule.checkNotEvaluatedStrings(e.Names, "on.image_version.names")
+			rule.checkNotEvaluatedStrings(e.Versions, "on.image_version.versions")
 		}
 	}
 
@@ -180,15 +230,60 @@ func (rule *RuleExpression) VisitWorkflowPost(n *Workflow) error {
 	if e, ok := n.FindWorkflowCallEvent(); ok {
 		rule.checkWorkflowCallOutputs(e.Outputs, n.Jobs)
 	}
+	if rule.composite {
+		rule.checkUnusedInputs()
+	}
 	rule.workflow = nil
 	return nil
 }
 
+// checkUnusedInputs reports the inputs of the composite action which are never referenced in its
+// steps and outputs.
+func (rule *RuleExpression) checkUnusedInputs() {
+	if rule.inputsUsed == nil {
+		return
+	}
+	for _, i := range rule.inputDecls {
+		if _, ok := rule.inputsUsed[strings.ToLower(i.Value)]; ok {
+			continue
+		}
+		err := errorfAt(i.Pos, rule.name, "input %q is defined but never used via \"inputs\" context in the composite action. remove the input if it is no longer necessary", i.Value)
+		err.Severity = SeverityWarning
+		rule.Report(err)
+	}
+}
+
+// trackInputsUsage records the inputs referenced via "inputs" context in the expression.
+func (rule *RuleExpression) trackInputsUsage(expr ExprNode) {
+	VisitExprNode(expr, func(n, p ExprNode, entering bool) {
+		if v, ok := n.(*VariableNode); !ok || !entering || !strings.EqualFold(v.Name, "inputs") || rule.inputsUsed == nil {
+			return
+		}
+		var name string
+		switch p := p.(type) {
+		case *ObjectDerefNode:
+			name = p.Property
+		case *IndexAccessNode:
+			if s, ok := p.Index.(*StringNode); ok && p.Operand == n {
+				name = s.Value
+			}
+		}
+		if name == "" {
+			rule.inputsUsed = nil // The entire object is used like `toJSON(inputs)` or `inputs[matrix.name]`
+			return
+		}
+		rule.inputsUsed[strings.ToLower(name)] = struct{}{}
+	})
+}
+
 // VisitJobPre is callback when visiting Job node before visiting its children.
 func (rule *RuleExpression) VisitJobPre(n *Job) error {
 	// Type of needs must be resolved before resolving type of matrix because `needs` context can
 	// be used in matrix configuration.
 	rule.needsTy = rule.calcNeedsType(n)
+	if n.Environment != nil {
+		rule.environment = n.Environment.Name
+	}
 
 	// Set matrix type at start of VisitJobPre() because matrix values are available in
 	// jobs.<job_id> section. For example:
@@ -201,10 +296,18 @@ func (rule *RuleExpression) VisitJobPre(n *Job) error {
 	if n.Strategy != nil && n.Strategy.Matrix != nil {
 		// Check and guess type of the matrix
 		rule.matrixTy = rule.checkMatrix(n.Strategy.Matrix)
+		rule.matrix = n.Strategy.Matrix
+	} else if rule.composite {
+		// Composite action can be used in any job so its matrix is unknown
+		rule.matrixTy = NewEmptyObjectType()
+	}
+
+	if !rule.composite {
+		rule.runnerValues = runnerContextValues(n)
 	}
 
 	rule.checkString(n.Name, "jobs.<job_id>.name")
-	rule.checkStrings(n.Needs, "")
+	rule.checkNotEvaluatedStrings(n.Needs, "jobs.<job_id>.needs")
 
 	if n.RunsOn != nil {
 		if n.RunsOn.LabelsExpr != nil {
@@ -268,8 +371,11 @@ func (rule *RuleExpression) VisitJobPost(n *Job) error {
 	}
 
 	rule.matrixTy = nil
+	rule.matrix = nil
 	rule.stepsTy = nil
 	rule.needsTy = nil
+	rule.environment = nil
+	rule.runnerValues = nil
 
 	return nil
 }
@@ -280,13 +386,19 @@ func (rule *RuleExpression) VisitStep(n *Step) error {
 	rule.checkIfCondition(n.If, "jobs.<job_id>.steps.if")
 
 	var spec *String
+	var outputs *ObjectType
 	switch e := n.Exec.(type) {
 	case *ExecRun:
 		rule.checkScriptString(e.Run, "jobs.<job_id>.steps.run")
 		rule.checkString(e.Shell, "")
 		rule.checkString(e.WorkingDirectory, "jobs.<job_id>.steps.working-directory")
+		if rule.composite && e.Run != nil {
+			// Outputs of composite actions are usually set by "run:" steps. Detect the output names
+			// to check the references in "outputs" section of the action metadata
+			outputs = typeOfRunStepOutputs(e.Run.Value)
+		}
 	case *ExecAction:
-		rule.checkString(e.Uses, "")
+		rule.checkNotEvaluated(e.Uses, "jobs.<job_id>.steps.uses")
 		for n, i := range e.Inputs {
 			if e.Uses != nil && strings.HasPrefix(e.Uses.Value, "actions/github-script@") && n == "script" {
 				rule.checkScriptString(i.Value, "jobs.<job_id>.steps.with")
@@ -305,13 +417,16 @@ func (rule *RuleExpression) VisitStep(n *Step) error {
 
 	if n.ID != nil {
 		if n.ID.ContainsExpression() {
-			rule.checkString(n.ID, "")
+			rule.checkNotEvaluated(n.ID, "jobs.<job_id>.steps.id")
 			rule.stepsTy.Loose()
 		}
 		// Step ID is case insensitive
 		id := strings.ToLower(n.ID.Value)
+		if outputs == nil {
+			outputs = rule.getActionOutputsType(spec)
+		}
 		rule.stepsTy.Props[id] = NewStrictObjectType(map[string]ExprType{
-			"outputs":    rule.getActionOutputsType(spec),
+			"outputs":    outputs,
 			"conclusion": StringType{},
 			"outcome":    StringType{},
 		})
@@ -320,6 +435,31 @@ func (rule *RuleExpression) VisitStep(n *Step) error {
 	return nil
 }
 
+// reGitHubOutputEcho is a pattern of a line in script to set a step output like
+// `echo "name=value" >> "$GITHUB_OUTPUT"`. One of the 1st, 2nd, and 3rd groups captures the name.
+var reGitHubOutputEcho = regexp.MustCompile(`^\s*echo\s+(?:"([a-zA-Z_][a-zA-Z0-9_-]*)=.*"|'([a-zA-Z_][a-zA-Z0-9_-]*)=.*'|([a-zA-Z_][a-zA-Z0-9_-]*)=\S*)\s*>>\s*(?:"\$GITHUB_OUTPUT"|"\$\{GITHUB_OUTPUT\}"|\$GITHUB_OUTPUT|\$\{GITHUB_OUTPUT\})\s*$`)
+
+// typeOfRunStepOutputs returns the type of outputs of the "run:" step by finding the lines to set
+// outputs in the script. It returns nil when the outputs cannot be detected statically. For example,
+// outputs set by other scripts or outputs with multi-line values.
+func typeOfRunStepOutputs(script string) *ObjectType {
+	ty := NewEmptyStrictObjectType()
+	for l := range strings.Lines(script) {
+		if !strings.Contains(l, "GITHUB_OUTPUT") {
+			continue
+		}
+		m := reGitHubOutputEcho.FindStringSubmatch(strings.TrimRight(l, "\r\n"))
+		if m == nil {
+			return nil
+		}
+		ty.Props[strings.ToLower(m[1]+m[2]+m[3])] = StringType{}
+	}
+	if len(ty.Props) == 0 {
+		return nil // Outputs may be set by other scripts
+	}
+	return ty
+}
+
 // Get type of `outputs.<output name>`
 func (rule *RuleExpression) getActionOutputsType(spec *String) *ObjectType {
 	if spec == nil {
@@ -347,10 +487,21 @@ func (rule *RuleExpression) getActionOutputsType(spec *String) *ObjectType {
 
 	// When the action run at this step is a popular action, we know what outputs are set by it.
 	// Set the output names to `steps.{step_id}.outputs.{name}`.
-	if meta, ok := PopularActions[spec.Value]; ok {
+	if meta, ok := knownPopularActions()[spec.Value]; ok {
+		return typeOfActionOutputs(meta)
+	}
+	if meta, ok := rule.actionMetadata.metadata(spec.Value); ok {
 		return typeOfActionOutputs(meta)
 	}
 
+	// In online mode, outputs are known from the action metadata fetched from GitHub. Errors on
+	// fetching the metadata are reported by RuleAction.
+	if rule.remote != nil && !spec.ContainsExpression() && !strings.HasPrefix(spec.Value, "docker://") {
+		if meta, err := rule.remote.ActionMetadata(spec.Value); err == nil && meta != nil {
+			return typeOfActionOutputs(meta)
+		}
+	}
+
 	return NewMapObjectType(StringType{})
 }
 
@@ -364,6 +515,9 @@ func (rule *RuleExpression) getWorkflowCallOutputsType(call *WorkflowCall) *Obje
 		rule.Error(call.Uses.Pos, err.Error())
 		return NewMapObjectType(StringType{})
 	}
+	if m == nil {
+		m = rule.remoteWorkflowMetadata(call.Uses)
+	}
 	if m == nil {
 		return NewMapObjectType(StringType{})
 	}
@@ -375,6 +529,19 @@ func (rule *RuleExpression) getWorkflowCallOutputsType(call *WorkflowCall) *Obje
 	return NewStrictObjectType(p)
 }
 
+// remoteWorkflowMetadata returns the metadata of the reusable workflow in other repository fetched
+// in online mode. Errors on fetching the workflow are reported by RuleWorkflowCall.
+func (rule *RuleExpression) remoteWorkflowMetadata(uses *String) *ReusableWorkflowMetadata {
+	if rule.remote == nil || uses.ContainsExpression() || !isWorkflowCallUsesRepoFormat(uses.Value) {
+		return nil
+	}
+	m, err := rule.remote.ReusableWorkflowMetadata(uses.Value)
+	if err != nil {
+		return nil
+	}
+	return m
+}
+
 func (rule *RuleExpression) checkOneExpression(s *String, what, workflowKey string) ExprType {
 	// checkString is not available since it checks types for embedding values into a string
 	if s == nil {
@@ -520,11 +687,13 @@ func (rule *RuleExpression) checkWorkflowCall(c *WorkflowCall) {
 		return
 	}
 
-	rule.checkString(c.Uses, "")
+	rule.checkNotEvaluated(c.Uses, "jobs.<job_id>.uses")
 
 	m, err := rule.localWorkflows.FindMetadata(c.Uses.Value)
 	if err != nil {
 		rule.Error(c.Uses.Pos, err.Error())
+	} else if m == nil {
+		m = rule.remoteWorkflowMetadata(c.Uses)
 	}
 
 	for n, i := range c.Inputs {
@@ -547,15 +716,9 @@ func (rule *RuleExpression) checkWorkflowCall(c *WorkflowCall) {
 		var ty ExprType = StringType{}
 		switch len(ts) {
 		case 0:
-			switch v {
-			case "null":
-				ty = NullType{}
-			case "true", "false":
-				ty = BoolType{}
-			default:
-				if _, err := strconv.ParseFloat(v, 64); err == nil {
-					ty = NumberType{}
-				}
+			if !i.Value.Quoted {
+				// Quoted literal is always a string like 'true' or "42"
+				ty = typeOfWorkflowCallInputLiteral(v)
 			}
 		case 1:
 			if i.Value.IsExpressionAssigned() {
@@ -563,15 +726,26 @@ func (rule *RuleExpression) checkWorkflowCall(c *WorkflowCall) {
 			}
 		}
 
-		if !mi.Type.Assignable(ty) {
+		if !isAssignableToWorkflowCallInput(mi.Type, ty) {
+			hint := ""
+			if len(ts) == 0 && i.Value.Quoted && isAssignableToWorkflowCallInput(mi.Type, typeOfWorkflowCallInputLiteral(v)) {
+				hint = ". remove the quotes to pass the value as " + mi.Type.String()
+			} else if _, ok := mi.Type.(BoolType); ok && len(ts) == 1 && i.Value.IsExpressionAssigned() {
+				if _, ok := ty.(StringType); ok {
+					hint = ". compare the string with 'true' like \"${{ <expr> == 'true' }}\" to pass bool value"
+				}
+			}
 			rule.Errorf(
 				i.Value.Pos,
-				"input %q is typed as %s by reusable workflow %q. %s value cannot be assigned",
+				"input %q is typed as %s by reusable workflow %q. %s value cannot be assigned%s",
 				mi.Name,
 				mi.Type.String(),
 				c.Uses.Value,
 				ty.String(),
+				hint,
 			)
+		} else if len(ts) == 1 {
+			rule.checkWorkflowCallInputMatrixValues(i.Value, mi, c.Uses)
 		}
 	}
 
@@ -580,6 +754,120 @@ func (rule *RuleExpression) checkWorkflowCall(c *WorkflowCall) {
 	}
 }
 
+var reMatrixPropertyAccess = regexp.MustCompile(`^\$\{\{\s*matrix\.([a-zA-Z_][a-zA-Z0-9_-]*)\s*\}\}$`)
+
+// checkWorkflowCallInputMatrixValues checks the type of each value of the matrix property passed to
+// the input of reusable workflow like `input: ${{ matrix.foo }}`. The type of the matrix property
+// merges the types of all its values so a value which cannot be assigned to the input in some matrix
+// combination is missed by checking the merged type.
+func (rule *RuleExpression) checkWorkflowCallInputMatrixValues(v *String, input *ReusableWorkflowMetadataInput, uses *String) {
+	m := reMatrixPropertyAccess.FindStringSubmatch(strings.TrimSpace(v.Value))
+	if m == nil {
+		return
+	}
+	name := m[1]
+
+	for _, mv := range rule.matrixValuesOf(name) {
+		ty := typeOfMatrixValue(mv)
+		if isAssignableToWorkflowCallInput(input.Type, ty) {
+			continue
+		}
+		rule.ErrorfWithRelated(
+			v.Pos,
+			relatedAt(mv.Pos(), "matrix value is defined here"),
+			"input %q is typed as %s by reusable workflow %q. %s value %s of \"matrix.%s\" at %s cannot be assigned in the matrix combination",
+			input.Name,
+			input.Type.String(),
+			uses.Value,
+			ty.String(),
+			mv.String(),
+			name,
+			mv.Pos().String(),
+		)
+	}
+}
+
+// matrixValuesOf returns all values which the matrix property can take in the combinations of the
+// current job. Values in "include" section are also included. It returns nil when the values cannot
+// be known statically due to expressions like `matrix: ${{ fromJSON(...) }}`.
+func (rule *RuleExpression) matrixValuesOf(name string) []RawYAMLValue {
+	m := rule.matrix
+	if m == nil || m.Expression != nil {
+		return nil
+	}
+	name = strings.ToLower(name)
+
+	vs := []RawYAMLValue{}
+	if r, ok := m.Rows[name]; ok {
+		if r.Expression != nil {
+			return nil
+		}
+		vs = append(vs, r.Values...)
+	}
+	if m.Include != nil {
+		if m.Include.Expression != nil {
+			return nil
+		}
+		for _, c := range m.Include.Combinations {
+			if c.Expression != nil {
+				return nil
+			}
+			if a, ok := c.Assigns[name]; ok {
+				vs = append(vs, a.Value)
+			}
+		}
+	}
+	return vs
+}
+
+// typeOfMatrixValue returns the type of the raw YAML value in matrix. Unlike checkRawYAMLValue,
+// expressions in the value are not checked. Their types are unknown.
+func typeOfMatrixValue(v RawYAMLValue) ExprType {
+	switch v := v.(type) {
+	case *RawYAMLObject:
+		return NewEmptyObjectType()
+	case *RawYAMLArray:
+		return &ArrayType{Elem: AnyType{}}
+	case *RawYAMLString:
+		if ContainsExpression(v.Value) {
+			return AnyType{}
+		}
+		return typeOfWorkflowCallInputLiteral(strings.TrimSpace(v.Value))
+	default:
+		panic("unreachable")
+	}
+}
+
+// typeOfWorkflowCallInputLiteral returns the type of the unquoted literal value at "with:" of
+// reusable workflow call.
+func typeOfWorkflowCallInputLiteral(v string) ExprType {
+	switch v {
+	case "null":
+		return NullType{}
+	case "true", "false":
+		return BoolType{}
+	}
+	if _, err := strconv.ParseFloat(v, 64); err == nil {
+		return NumberType{}
+	}
+	return StringType{}
+}
+
+// isAssignableToWorkflowCallInput returns whether the value of the type can be passed to the input
+// of reusable workflow. Unlike expressions, values are not converted into boolean implicitly. For
+// example, passing string "true" to boolean input causes an error at runtime.
+func isAssignableToWorkflowCallInput(input, value ExprType) bool {
+	if _, ok := input.(BoolType); ok {
+		switch value.(type) {
+		case BoolType, AnyType:
+			return true
+		default:
+			return false
+		}
+	}
+	return input.Assignable(value)
+}
+
 func (rule *RuleExpression) checkSnapshot(s *Snapshot) {
 	if s == nil {
 		return
@@ -591,7 +879,32 @@ func (rule *RuleExpression) checkWebhookEventFilter(f *WebhookEventFilter) {
 	if f == nil {
 		return
 	}
-	rule.checkStrings(f.Values, "")
+	rule.checkNotEvaluatedStrings(f.Values, "on.<event_name>."+f.Name.Value)
+}
+
+// checkNotEvaluated reports ${{ }} placeholders in the string at the workflow key which GitHub
+// Actions never evaluates. Such string is used as-is literally. Whether the key accepts expressions
+// is decided by WorkflowKeyAvailability which is generated from the official document.
+func (rule *RuleExpression) checkNotEvaluated(str *String, workflowKey string) {
+	if str == nil || !str.ContainsExpression() {
+		return
+	}
+	if ctx, _ := WorkflowKeyAvailability(workflowKey); ctx != nil {
+		rule.checkString(str, workflowKey)
+		return
+	}
+	rule.Errorf(
+		str.Pos,
+		"expression in %q is not evaluated since \"%s\" does not accept ${{ }}. the value is used as-is literally at runtime. see https://docs.github.com/en/actions/learn-github-actions/contexts#context-availability",
+		str.Value,
+		workflowKey,
+	)
+}
+
+func (rule *RuleExpression) checkNotEvaluatedStrings(ss []*String, workflowKey string) {
+	for _, s := range ss {
+		rule.checkNotEvaluated(s, workflowKey)
+	}
 }
 
 func (rule *RuleExpression) checkStrings(ss []*String, workflowKey string) {
@@ -640,8 +953,9 @@ func (rule *RuleExpression) checkIfCondition(str *String, workflowKey string) {
 		src := str.Value + "}}" // }} is necessary since lexer lexes it as end of tokens
 		line, col := str.Pos.Line, str.Pos.Col
 
-		p := NewExprParser()
-		expr, err := p.Parse(NewExprLexer(src))
+		l := NewExprLexer(src)
+		expr, err := NewExprParser().Parse(l)
+		l.Release()
 		if err != nil {
 			rule.exprError(err, line, col)
 			return
@@ -752,7 +1066,7 @@ func (rule *RuleExpression) checkExprsIn(s string, pos *Pos, quoted, checkUntrus
 		if ty == nil || offsetAfter == 0 {
 			return nil, true
 		}
-		ts = append(ts, typedExpr{ty, Pos{line, col - 3}})
+		ts = append(ts, typedExpr{ty, Pos{Line: line, Col: col - 3}})
 
 		s = s[offsetAfter:]
 		offset += offsetAfter
@@ -763,6 +1077,10 @@ func (rule *RuleExpression) checkExprsIn(s string, pos *Pos, quoted, checkUntrus
 
 func (rule *RuleExpression) exprError(err *ExprError, lineBase, colBase int) {
 	pos := convertExprLineColToPos(err.Line, err.Column, lineBase, colBase)
+	if err.EndColumn > err.Column {
+		pos.EndLine = pos.Line
+		pos.EndCol = pos.Col + err.EndColumn - err.Column
+	}
 	rule.Error(pos, err.Message)
 }
 
@@ -770,6 +1088,17 @@ func (rule *RuleExpression) checkSemanticsOfExprNode(expr ExprNode, line, col in
 	var v []string
 	if rule.config != nil {
 		v = rule.config.ConfigVariables
+		if v != nil && len(rule.config.EnvironmentConfigVariables) > 0 {
+			// Which environment provides the variable is checked by checkEnvironmentConfigVars
+			v = slices.Clone(v)
+			for _, vs := range rule.config.EnvironmentConfigVariables {
+				for _, n := range vs {
+					if !slices.Contains(v, n) {
+						v = append(v, n)
+					}
+				}
+			}
+		}
 	}
 	c := NewExprSemanticsChecker(checkUntrusted, v)
 	if rule.matrixTy != nil {
@@ -793,11 +1122,17 @@ func (rule *RuleExpression) checkSemanticsOfExprNode(expr ExprNode, line, col in
 	if rule.jobsTy != nil {
 		c.UpdateJobs(rule.jobsTy)
 	}
+	if rule.platform.giteaCompatible() {
+		c.SetContextAliases(giteaContextAliases)
+	}
 	if workflowKey != "" {
 		ctx, sp := WorkflowKeyAvailability(workflowKey)
 		if len(ctx) == 0 {
 			rule.Debug("No context availability was found for workflow key %q", workflowKey)
 		}
+		if rule.composite {
+			ctx = compositeActionContexts(ctx)
+		}
 		c.SetContextAvailability(ctx)
 		c.SetSpecialFunctionAvailability(sp)
 	}
@@ -806,12 +1141,526 @@ func (rule *RuleExpression) checkSemanticsOfExprNode(expr ExprNode, line, col in
 	for _, err := range errs {
 		rule.exprError(err, line, col)
 	}
+	if rule.composite {
+		rule.trackInputsUsage(expr)
+	}
+	if len(errs) == 0 && rule.config != nil && rule.config.ConfigVariables != nil && len(rule.config.EnvironmentConfigVariables) > 0 {
+		rule.checkEnvironmentConfigVars(expr, line, col)
+	}
+	if len(errs) == 0 {
+		rule.checkRunnerContextComparisons(expr, line, col)
+	}
+	if len(errs) == 0 && rule.dispatchChoices != nil {
+		rule.checkChoiceInputComparisons(expr, line, col)
+	}
+	if len(errs) == 0 && rule.remote != nil {
+		rule.checkRemoteSecretsAndVars(expr, line, col)
+	}
+	if len(errs) == 0 && rule.ghes != nil {
+		rule.checkGHESCompatibility(expr, line, col)
+	}
 
 	return ty, len(errs) == 0
 }
 
+// compositeActionContexts removes the contexts which are not available in composite actions from
+// the available contexts of a workflow key.
+func compositeActionContexts(ctx []string) []string {
+	return slices.DeleteFunc(slices.Clone(ctx), func(c string) bool {
+		return c == "secrets" || c == "vars" || c == "needs"
+	})
+}
+
+// checkRemoteSecretsAndVars checks secrets and configuration variables used in the expression are
+// defined in the GitHub repository. This check is done only in online mode.
+func (rule *RuleExpression) checkRemoteSecretsAndVars(expr ExprNode, line, col int) {
+	if rule.reusable {
+		return
+	}
+	env := ""
+	if rule.environment != nil {
+		if rule.environment.ContainsExpression() {
+			return // Available secrets and variables cannot be known
+		}
+		env = rule.environment.Value
+	}
+
+	VisitExprNode(expr, func(n, _ ExprNode, entering bool) {
+		if !entering {
+			return
+		}
+
+		var recv ExprNode
+		var name string
+		switch n := n.(type) {
+		case *ObjectDerefNode:
+			recv, name = n.Receiver, n.Property
+		case *IndexAccessNode:
+			s, ok := n.Index.(*StringNode)
+			if !ok {
+				return
+			}
+			recv, name = n.Operand, s.Value
+		default:
+			return
+		}
+		v, ok := recv.(*VariableNode)
+		if !ok {
+			return
+		}
+
+		var what string
+		var defined []string
+		switch v.Name {
+		case "secrets":
+			switch strings.ToLower(name) {
+			case "github_token", "actions_step_debug", "actions_runner_debug":
+				return // Automatically supplied secrets
+			}
+			what, defined = "secret", rule.remote.Secrets(env)
+		case "vars":
+			what, defined = "configuration variable", rule.remote.Variables(env)
+		default:
+			return
+		}
+		if defined == nil {
+			return // Could not fetch secrets or variables
+		}
+		for _, d := range defined {
+			if strings.EqualFold(d, name) {
+				return
+			}
+		}
+
+		scope := fmt.Sprintf("repository %q or its organization", rule.remote.FullName())
+		if env != "" {
+			scope = fmt.Sprintf("repository %q, its organization, or environment %q", rule.remote.FullName(), env)
+		}
+		hint := ""
+		if s := similarNames(name, defined); len(s) > 0 {
+			hint = " did you mean " + quotes(s) + "?"
+		}
+		err := errorfAtExpr(
+			n,
+			"%s %q is not defined in %s. it is evaluated to an empty string.%s note: this check was done with GitHub API",
+			what,
+			name,
+			scope,
+			hint,
+		)
+		rule.exprError(err, line, col)
+	})
+}
+
+// Possible values of properties of "runner" context.
+// https://docs.github.com/en/actions/reference/workflows-and-actions/contexts#runner-context
+var runnerContextDomains = map[string][]string{
+	"os":          {"Linux", "Windows", "macOS"},
+	"arch":        {"X86", "X64", "ARM", "ARM64"},
+	"environment": {"github-hosted", "self-hosted"},
+	"debug":       {"1", ""}, // Empty string when debug logging is not enabled
+}
+
+// runnerContextValuesOfLabel returns the values of "runner.os" and "runner.arch" of the runner
+// selected by the label. Empty string is returned when the value is unknown.
+func runnerContextValuesOfLabel(label string) map[string]string {
+	ret := map[string]string{}
+	if os, ok := runnerOSOfLabel(label); ok {
+		switch os {
+		case RunnerOSLinux:
+			ret["os"] = "Linux"
+		case RunnerOSMacOS:
+			ret["os"] = "macOS"
+		case RunnerOSWindows:
+			ret["os"] = "Windows"
+		}
+	}
+	arch := ""
+	if r, ok := LookupGitHubHostedRunner(label); ok {
+		arch = string(r.Arch)
+	} else {
+		arch = strings.ToLower(label) // Default labels of self-hosted runners
+	}
+	switch RunnerArch(arch) {
+	case RunnerArchX64:
+		ret["arch"] = "X64"
+	case RunnerArchARM64:
+		ret["arch"] = "ARM64"
+	}
+	return ret
+}
+
+// runnerContextValues returns the possible values of "runner.os" and "runner.arch" in the job
+// resolved from the labels at "runs-on:". Keys are the property names. A property is not included
+// when its values cannot be known statically.
+func runnerContextValues(n *Job) map[string][]string {
+	if n.RunsOn == nil {
+		return nil
+	}
+	var m *Matrix
+	if n.Strategy != nil {
+		m = n.Strategy.Matrix
+	}
+
+	labels := n.RunsOn.Labels
+	if n.RunsOn.LabelsExpr != nil {
+		labels = []*String{n.RunsOn.LabelsExpr}
+	}
+
+	static := []map[string]string{}
+	var dynamic [][]map[string]string
+	for _, l := range labels {
+		if !l.ContainsExpression() {
+			static = append(static, runnerContextValuesOfLabel(l.Value))
+			continue
+		}
+		// Labels resolved from matrix are alternatives for each combination of the matrix
+		alts := []map[string]string{}
+		for _, s := range getRunnerLabelsInMatrix(l, m) {
+			alts = append(alts, runnerContextValuesOfLabel(s.Value))
+		}
+		dynamic = append(dynamic, alts)
+	}
+
+	ret := map[string][]string{}
+	for _, prop := range []string{"os", "arch"} {
+		vs := []string{}
+		for _, v := range static {
+			if s, ok := v[prop]; ok && !slices.Contains(vs, s) {
+				vs = append(vs, s)
+			}
+		}
+		if len(vs) > 1 {
+			continue // Conflicting labels are reported by runner-label rule
+		}
+		if len(vs) == 0 && len(dynamic) == 1 {
+			for _, v := range dynamic[0] {
+				s, ok := v[prop]
+				if !ok {
+					vs = nil // Some label in the matrix is unknown
+					break
+				}
+				if !slices.Contains(vs, s) {
+					vs = append(vs, s)
+				}
+			}
+		}
+		if len(vs) > 0 {
+			slices.Sort(vs)
+			ret[prop] = vs
+		}
+	}
+	return ret
+}
+
+// checkRunnerContextComparisons checks properties of "runner" context are compared with their
+// possible values. The values of "runner.os" and "runner.arch" are also narrowed by the labels at
+// "runs-on:" of the job.
+func (rule *RuleExpression) checkRunnerContextComparisons(expr ExprNode, line, col int) {
+	VisitExprNode(expr, func(n, _ ExprNode, entering bool) {
+		if !entering {
+			return
+		}
+		c, ok := n.(*CompareOpNode)
+		if !ok || c.Kind != CompareOpNodeKindEq && c.Kind != CompareOpNodeKindNotEq {
+			return
+		}
+
+		prop, lit := runnerContextProperty(c.Left), c.Right
+		if prop == "" {
+			prop, lit = runnerContextProperty(c.Right), c.Left
+			if prop == "" {
+				return
+			}
+		}
+		s, ok := lit.(*StringNode)
+		if !ok {
+			return
+		}
+
+		always := "false"
+		if c.Kind == CompareOpNodeKindNotEq {
+			always = "true"
+		}
+
+		domain := runnerContextDomains[prop]
+		// Strings are compared in case-insensitive
+		if !slices.ContainsFunc(domain, func(v string) bool { return strings.EqualFold(v, s.Value) }) {
+			err := errorfAtExpr(
+				lit,
+				"%q is not a value of \"runner.%s\". possible values are %s. this comparison is always %s",
+				s.Value,
+				prop,
+				quotes(domain),
+				always,
+			)
+			rule.exprError(err, line, col)
+			return
+		}
+
+		vs, ok := rule.runnerValues[prop]
+		if !ok || slices.ContainsFunc(vs, func(v string) bool { return strings.EqualFold(v, s.Value) }) {
+			return
+		}
+		what := "always " + quotes(vs)
+		if len(vs) > 1 {
+			what = "one of " + quotes(vs)
+		}
+		err := errorfAtExpr(
+			lit,
+			"\"runner.%s\" is %s in this job due to the labels at \"runs-on:\". comparing it with %q is always %s",
+			prop,
+			what,
+			s.Value,
+			always,
+		)
+		rule.exprError(err, line, col)
+	})
+}
+
+// runnerContextProperty returns the property name of "runner" context when the node accesses it
+// like `runner.os`. Otherwise it returns an empty string.
+func runnerContextProperty(n ExprNode) string {
+	d, ok := n.(*ObjectDerefNode)
+	if !ok {
+		return ""
+	}
+	if v, ok := d.Receiver.(*VariableNode); !ok || v.Name != "runner" {
+		return ""
+	}
+	if _, ok := runnerContextDomains[d.Property]; !ok {
+		return ""
+	}
+	return d.Property
+}
+
+// checkEnvironmentConfigVars checks configuration variables defined only in some environments are
+// used in jobs which set the environments at "environment:".
+func (rule *RuleExpression) checkEnvironmentConfigVars(expr ExprNode, line, col int) {
+	env := ""
+	if rule.environment != nil {
+		if rule.environment.ContainsExpression() {
+			return // Available variables cannot be known
+		}
+		env = rule.environment.Value
+	}
+
+	VisitExprNode(expr, func(n, _ ExprNode, entering bool) {
+		if !entering {
+			return
+		}
+
+		var recv ExprNode
+		var name string
+		switch n := n.(type) {
+		case *ObjectDerefNode:
+			recv, name = n.Receiver, n.Property
+		case *IndexAccessNode:
+			s, ok := n.Index.(*StringNode)
+			if !ok {
+				return
+			}
+			recv, name = n.Operand, s.Value
+		default:
+			return
+		}
+		if v, ok := recv.(*VariableNode); !ok || v.Name != "vars" {
+			return
+		}
+
+		for _, v := range rule.config.ConfigVariables {
+			if strings.EqualFold(v, name) {
+				return // Defined in the repository or its organization
+			}
+		}
+
+		envs := []string{}
+		for e, vs := range rule.config.EnvironmentConfigVariables {
+			if !slices.ContainsFunc(vs, func(v string) bool { return strings.EqualFold(v, name) }) {
+				continue
+			}
+			// Environment names are case-insensitive
+			if env != "" && strings.EqualFold(e, env) {
+				return
+			}
+			envs = append(envs, e)
+		}
+		if len(envs) == 0 {
+			return // Undefined variable is reported by the semantics checker
+		}
+
+		what := "environment " + quotes(envs)
+		if len(envs) > 1 {
+			what = "environments " + sortedQuotes(envs)
+		}
+		where := "\"environment:\" is not set to the job"
+		if env != "" {
+			where = fmt.Sprintf("the job uses environment %q", env)
+		}
+		err := errorfAtExpr(
+			n,
+			"configuration variable %q is only defined in %s in actionlint.yaml but %s. it is evaluated to an empty string",
+			name,
+			what,
+			where,
+		)
+		rule.exprError(err, line, col)
+	})
+}
+
+// choiceOptions returns the values of the options of "choice" type input. It returns nil when some
+// option contains an expression since the actual options cannot be known.
+func choiceOptions(opts []*String) []string {
+	ret := make([]string, 0, len(opts))
+	for _, o := range opts {
+		if o.ContainsExpression() {
+			return nil
+		}
+		ret = append(ret, o.Value)
+	}
+	return ret
+}
+
+// dispatchChoiceInput returns the name of "choice" type input of "workflow_dispatch" event when the
+// node accesses it like `inputs.foo`, `inputs['foo']`, or `github.event.inputs.foo`.
+func (rule *RuleExpression) dispatchChoiceInput(n ExprNode) (string, []string) {
+	var recv ExprNode
+	var name string
+	switch n := n.(type) {
+	case *ObjectDerefNode:
+		recv, name = n.Receiver, n.Property
+	case *IndexAccessNode:
+		s, ok := n.Index.(*StringNode)
+		if !ok {
+			return "", nil
+		}
+		recv, name = n.Operand, strings.ToLower(s.Value)
+	default:
+		return "", nil
+	}
+
+	switch r := recv.(type) {
+	case *VariableNode:
+		if r.Name != "inputs" {
+			return "", nil
+		}
+		// `inputs` context may be given by the caller when the workflow is also triggered by
+		// "workflow_call" event
+		if rule.inputsTy != nil {
+			if _, ok := rule.inputsTy.Props[name]; ok {
+				return "", nil
+			}
+		}
+	case *ObjectDerefNode:
+		e, ok := r.Receiver.(*ObjectDerefNode)
+		if !ok || r.Property != "inputs" || e.Property != "event" {
+			return "", nil
+		}
+		if v, ok := e.Receiver.(*VariableNode); !ok || v.Name != "github" {
+			return "", nil
+		}
+	default:
+		return "", nil
+	}
+
+	opts, ok := rule.dispatchChoices[name]
+	if !ok || opts == nil {
+		return "", nil
+	}
+	return name, opts
+}
+
+// checkChoiceInputComparisons checks "choice" type inputs of "workflow_dispatch" event are compared
+// with values in their options. Comparing with other values is always false (or always true with
+// "!=") since the input value is one of the options.
+func (rule *RuleExpression) checkChoiceInputComparisons(expr ExprNode, line, col int) {
+	VisitExprNode(expr, func(n, _ ExprNode, entering bool) {
+		if !entering {
+			return
+		}
+		c, ok := n.(*CompareOpNode)
+		if !ok || c.Kind != CompareOpNodeKindEq && c.Kind != CompareOpNodeKindNotEq {
+			return
+		}
+
+		input, lit := c.Left, c.Right
+		name, opts := rule.dispatchChoiceInput(input)
+		if name == "" {
+			input, lit = c.Right, c.Left
+			name, opts = rule.dispatchChoiceInput(input)
+			if name == "" {
+				return
+			}
+		}
+		s, ok := lit.(*StringNode)
+		if !ok {
+			return
+		}
+		for _, o := range opts {
+			// Strings are compared in case-insensitive
+			if strings.EqualFold(o, s.Value) {
+				return
+			}
+		}
+
+		always := "false"
+		if c.Kind == CompareOpNodeKindNotEq {
+			always = "true"
+		}
+		hint := ""
+		if ss := similarNames(s.Value, opts); len(ss) > 0 {
+			hint = " did you mean " + quotes(ss) + "?"
+		}
+		err := errorfAtExpr(
+			lit,
+			"%q is not included in the options %s of \"choice\" type input %q of \"workflow_dispatch\" event. this comparison is always %s.%s",
+			s.Value,
+			quotes(opts),
+			name,
+			always,
+			hint,
+		)
+		rule.exprError(err, line, col)
+	})
+}
+
+// checkGHESCompatibility checks contexts and functions used in the expression are available in the
+// version of GitHub Enterprise Server.
+func (rule *RuleExpression) checkGHESCompatibility(expr ExprNode, line, col int) {
+	VisitExprNode(expr, func(n, _ ExprNode, entering bool) {
+		if !entering {
+			return
+		}
+
+		var what, key string
+		var table map[string]GHESVersion
+		switch n := n.(type) {
+		case *VariableNode:
+			what, key, table = fmt.Sprintf("%q context", n.Name), strings.ToLower(n.Name), ghesContexts
+		case *ObjectDerefNode:
+			v, ok := n.Receiver.(*VariableNode)
+			if !ok {
+				return
+			}
+			what = fmt.Sprintf("property %q of %q context", n.Property, v.Name)
+			key, table = strings.ToLower(v.Name+"."+n.Property), ghesContexts
+		case *FuncCallNode:
+			what, key, table = fmt.Sprintf("%s() function", n.Callee), strings.ToLower(n.Callee), ghesFuncs
+		default:
+			return
+		}
+
+		since, ok := table[key]
+		if !ok || rule.ghes.supports(since) {
+			return
+		}
+		rule.exprError(errorAtExpr(n, rule.ghes.unsupported(what, since)), line, col)
+	})
+}
+
 func (rule *RuleExpression) checkSemantics(src string, line, col int, checkUntrusted bool, workflowKey string) (ExprType, int, bool) {
 	l := NewExprLexer(src)
+	defer l.Release()
 	p := NewExprParser()
 	expr, err := p.Parse(l)
 	if err != nil {
@@ -1000,8 +1849,9 @@ func (rule *RuleExpression) checkWorkflowCallOutputs(outputs map[string]*Workflo
 	for n, j := range jobs {
 		var o *ObjectType
 		if j.WorkflowCall != nil {
-			// Outputs are not defined in jobs.<job_id> section when it is reusable workflow call.
-			o = NewEmptyObjectType()
+			// Outputs are not defined in jobs.<job_id> section when it is reusable workflow call. They
+			// are defined in the called workflow.
+			o = rule.getWorkflowCallOutputsType(j.WorkflowCall)
 		} else {
 			p := make(map[string]ExprType, len(j.Outputs))
 			for n := range j.Outputs {
@@ -1011,6 +1861,7 @@ func (rule *RuleExpression) checkWorkflowCallOutputs(outputs map[string]*Workflo
 		}
 		props[n] = NewStrictObjectType(map[string]ExprType{
 			"outputs": o,
+			"result":  StringType{},
 		})
 	}
 	rule.jobsTy = NewStrictObjectType(props)
@@ -1030,13 +1881,13 @@ func (rule *RuleExpression) checkRawYAMLValue(v RawYAMLValue) ExprType {
 		return NewStrictObjectType(m)
 	case *RawYAMLArray:
 		if len(v.Elems) == 0 {
-			return &ArrayType{AnyType{}, false}
+			return &ArrayType{Elem: AnyType{}}
 		}
 		elem := rule.checkRawYAMLValue(v.Elems[0])
 		for _, v := range v.Elems[1:] {
 			elem = elem.Merge(rule.checkRawYAMLValue(v))
 		}
-		return &ArrayType{elem, false}
+		return &ArrayType{Elem: elem}
 	case *RawYAMLString:
 		return rule.checkRawYAMLString(v)
 	default:
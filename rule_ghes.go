@@ -0,0 +1,88 @@
+package actionlint
+
+import "fmt"
+
+// RuleGHES is a rule to check workflows are compatible with the specific version of GitHub
+// Enterprise Server. It reports webhook events and workflow syntax which are not available in the
+// version. This rule is enabled only when the version is specified by "-ghes" flag or "ghes" in the
+// configuration file.
+// https://docs.github.com/en/enterprise-server@latest/admin/overview/about-github-enterprise-server
+type RuleGHES struct {
+	RuleBase
+	version *GHESVersion
+}
+
+// NewRuleGHES creates new RuleGHES instance for the given version of GitHub Enterprise Server.
+func NewRuleGHES(v *GHESVersion) *RuleGHES {
+	return &RuleGHES{
+		RuleBase: RuleBase{
+			name: "ghes",
+			desc: "Checks for features not available in the version of GitHub Enterprise Server. This rule is enabled by \"-ghes\" flag or \"ghes\" configuration",
+		},
+		version: v,
+	}
+}
+
+func (rule *RuleGHES) check(what, key string, table map[string]GHESVersion, pos *Pos) {
+	since, ok := table[key]
+	if !ok || rule.version.supports(since) {
+		return
+	}
+	rule.Error(pos, rule.version.unsupported(what, since))
+}
+
+func (rule *RuleGHES) checkPermissions(p *Permissions) {
+	if p == nil {
+		return
+	}
+	for _, s := range p.Scopes {
+		rule.check(fmt.Sprintf("permission scope %q", s.Name.Value), s.Name.Value, ghesPermissionScopes, s.Name.Pos)
+	}
+}
+
+// VisitWorkflowPre is callback when visiting Workflow node before visiting its children.
+func (rule *RuleGHES) VisitWorkflowPre(n *Workflow) error {
+	if n.RunName != nil {
+		rule.check("\"run-name:\"", "run-name", ghesWorkflowKeys, n.RunName.Pos)
+	}
+
+	for _, e := range n.On {
+		var pos *Pos
+		switch e := e.(type) {
+		case *WebhookEvent:
+			pos = e.Hook.Pos
+		case *WorkflowCallEvent:
+			pos = e.Pos
+		case *ImageVersionEvent:
+			pos = e.Pos
+		default:
+			continue
+		}
+		name := e.EventName()
+		if m, ok := AllWebhookEvents[name]; ok && m.GHES != nil && !rule.version.supports(*m.GHES) {
+			rule.Error(pos, rule.version.unsupported(fmt.Sprintf("%q event", name), *m.GHES))
+		}
+	}
+
+	rule.checkPermissions(n.Permissions)
+	return nil
+}
+
+// VisitJobPre is callback when visiting Job node before visiting its children.
+func (rule *RuleGHES) VisitJobPre(n *Job) error {
+	if c := n.WorkflowCall; c != nil {
+		rule.check("calling reusable workflow at \"uses:\"", "jobs.<job_id>.uses", ghesWorkflowKeys, c.Uses.Pos)
+		if c.InheritSecrets {
+			rule.check("\"secrets: inherit\"", "jobs.<job_id>.secrets: inherit", ghesWorkflowKeys, c.Uses.Pos)
+		}
+	}
+	if n.Snapshot != nil {
+		pos := n.Pos
+		if n.Snapshot.ImageName != nil {
+			pos = n.Snapshot.ImageName.Pos
+		}
+		rule.check("\"snapshot:\" for custom images", "jobs.<job_id>.snapshot", ghesWorkflowKeys, pos)
+	}
+	rule.checkPermissions(n.Permissions)
+	return nil
+}
@@ -1,5 +1,7 @@
 package actionlint
 
+import "strings"
+
 // RuleGlob is a rule to check glob syntax.
 // https://docs.github.com/en/actions/using-workflows/workflow-syntax-for-github-actions#filter-pattern-cheat-sheet
 type RuleGlob struct {
@@ -27,6 +29,9 @@ func (rule *RuleGlob) VisitWorkflowPre(n *Workflow) error {
 			rule.checkGitRefGlobs(e.TagsIgnore)
 			rule.checkFilePathGlobs(e.Paths)
 			rule.checkFilePathGlobs(e.PathsIgnore)
+			for _, f := range []*WebhookEventFilter{e.Branches, e.BranchesIgnore, e.Tags, e.TagsIgnore, e.Paths, e.PathsIgnore} {
+				rule.checkFilterPatterns(f, e.Hook.Value)
+			}
 		case *ImageVersionEvent:
 			for _, v := range e.Versions {
 				rule.checkRefGlob(v)
@@ -85,3 +90,137 @@ func (rule *RuleGlob) globErrors(errs []InvalidGlobPattern, pos *Pos, quoted boo
 		rule.Errorf(&p, "%s. note: filter pattern syntax is explained at https://docs.github.com/en/actions/using-workflows/workflow-syntax-for-github-actions#filter-pattern-cheat-sheet", err.Message)
 	}
 }
+
+// checkFilterPatterns checks patterns in the filter which can never take effect or never match any
+// ref name. Patterns in a filter are evaluated in order and the last matching pattern wins.
+// https://docs.github.com/en/actions/using-workflows/workflow-syntax-for-github-actions#filter-pattern-cheat-sheet
+func (rule *RuleGlob) checkFilterPatterns(filter *WebhookEventFilter, hook string) {
+	if filter == nil || len(filter.Values) == 0 {
+		return
+	}
+
+	name := filter.Name.Value
+	isRef := !strings.HasPrefix(name, "paths")
+	isIgnore := strings.HasSuffix(name, "-ignore")
+	what := "file paths"
+	if strings.HasPrefix(name, "branches") {
+		what = "branches"
+	} else if strings.HasPrefix(name, "tags") {
+		what = "tags"
+	}
+
+	seen := make(map[string]*String, len(filter.Values))
+	pats := make([]*String, 0, len(filter.Values))
+	for _, v := range filter.Values {
+		// Empty or invalid pattern is already reported. Analyzing the filter with them would cause
+		// confusing errors
+		if v.Value == "" || isRef && len(ValidateRefGlob(v.Value)) > 0 || !isRef && len(ValidatePathGlob(v.Value)) > 0 {
+			return
+		}
+		if prev, ok := seen[v.Value]; ok {
+			rule.ErrorfWithRelated(v.Pos, relatedAt(prev.Pos, "previously defined here"), "pattern %q is duplicated in %q filter of %q event. previously defined at %s", v.Value, name, hook, prev.Pos)
+			continue
+		}
+		seen[v.Value] = v
+		pats = append(pats, v)
+		if isRef {
+			rule.checkRefPatternNeverMatch(v, name, what)
+		}
+	}
+
+	if len(pats) == 0 {
+		return
+	}
+
+	// When all patterns are negated, nothing is included by the filter
+	if !isIgnore {
+		negOnly := true
+		for _, p := range pats {
+			if !strings.HasPrefix(p.Value, "!") {
+				negOnly = false
+				break
+			}
+		}
+		if negOnly {
+			rule.Errorf(
+				filter.Name.Pos,
+				"all patterns in %q filter of %q event are negated with '!'. this filter matches no %s. at least one pattern without '!' is necessary",
+				name,
+				hook,
+				what,
+			)
+			return
+		}
+	}
+
+	// Check a pattern which is canceled by a later negated pattern
+	for i, p := range pats {
+		if strings.HasPrefix(p.Value, "!") {
+			continue
+		}
+		for _, later := range pats[i+1:] {
+			if later.Value == "!"+p.Value || later.Value == "!**" {
+				rule.Errorf(
+					p.Pos,
+					"pattern %q in %q filter of %q event never takes effect because it is negated by the later pattern %q at %s",
+					p.Value,
+					name,
+					hook,
+					later.Value,
+					later.Pos,
+				)
+				break
+			}
+		}
+	}
+
+	// Check an ignore filter which ignores everything. '**' matches all paths and all ref names
+	if isIgnore {
+		for i, p := range pats {
+			if p.Value != "**" {
+				continue
+			}
+			negated := false
+			for _, later := range pats[i+1:] {
+				if strings.HasPrefix(later.Value, "!") {
+					negated = true
+					break
+				}
+			}
+			if !negated {
+				rule.Errorf(
+					p.Pos,
+					"pattern %q in %q filter of %q event ignores all %s. no change in %s triggers the workflow via this event",
+					p.Value,
+					name,
+					hook,
+					what,
+					what,
+				)
+			}
+			break
+		}
+	}
+}
+
+// checkRefPatternNeverMatch checks the glob pattern for branch names or tag names never matches
+// any ref name. See `man git-check-ref-format` for the rules of valid ref names.
+func (rule *RuleGlob) checkRefPatternNeverMatch(pat *String, filter, what string) {
+	p := strings.TrimPrefix(pat.Value, "!")
+	reason := ""
+	switch {
+	case strings.HasPrefix(p, "refs/heads/") || strings.HasPrefix(p, "refs/tags/"):
+		reason = "branch names and tag names are matched without \"refs/heads/\" or \"refs/tags/\" prefix"
+	case strings.Contains(p, ".."):
+		reason = "ref name cannot contain \"..\""
+	case strings.Contains(p, "@{"):
+		reason = "ref name cannot contain \"@{\""
+	case strings.Contains(p, "//"):
+		reason = "ref name cannot contain consecutive slashes \"//\""
+	case strings.HasSuffix(p, ".lock"):
+		reason = "ref name cannot end with \".lock\""
+	default:
+		return
+	}
+	rule.Errorf(pat.Pos, "pattern %q in %q filter never matches any %s because %s", pat.Value, filter, what, reason)
+}
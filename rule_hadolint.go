@@ -0,0 +1,97 @@
+package actionlint
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+type hadolintError struct {
+	Code    string `json:"code"`
+	Level   string `json:"level"`
+	Line    int    `json:"line"`
+	Column  int    `json:"column"`
+	Message string `json:"message"`
+}
+
+func (err *hadolintError) severity() Severity {
+	switch err.Level {
+	case "info", "style":
+		return SeverityInfo
+	case "warning":
+		return SeverityWarning
+	default:
+		return SeverityError
+	}
+}
+
+// RuleHadolint is a rule to check Dockerfile of Docker actions using hadolint. Unlike other rules
+// for external commands, this rule checks Dockerfile referenced at "runs.image" in action metadata
+// files instead of workflows.
+// https://github.com/hadolint/hadolint
+type RuleHadolint struct {
+	RuleBase
+	cmd *externalCommand
+	mu  sync.Mutex
+}
+
+func newRuleHadolint(cmd *externalCommand) *RuleHadolint {
+	return &RuleHadolint{
+		RuleBase: RuleBase{
+			name: "hadolint",
+			desc: "Checks for Dockerfile of Docker actions using hadolint",
+		},
+		cmd: cmd,
+	}
+}
+
+// NewRuleHadolint creates new RuleHadolint instance. The executable argument can be command name
+// like "hadolint" or relative/absolute file path. When the given executable is not found in system,
+// it returns an error as 2nd return value.
+func NewRuleHadolint(executable string, proc *concurrentProcess) (*RuleHadolint, error) {
+	cmd, err := proc.newCommandRunner(executable, false)
+	if err != nil {
+		return nil, err
+	}
+	return newRuleHadolint(cmd), nil
+}
+
+// checkDockerfile runs hadolint for the source of Dockerfile at the path relative to the action
+// directory. The errors are reported at the position where the Dockerfile is referenced. Call wait
+// method to wait until the check finishes.
+func (rule *RuleHadolint) checkDockerfile(src []byte, path string, pos *Pos) {
+	rule.Debug("%s: Running %s for Dockerfile %q", pos, rule.cmd.exe, path)
+
+	// --no-fail makes the exit status zero even if some issues are found
+	args := []string{"--format", "json", "--no-fail", "-"}
+	rule.cmd.run(args, string(src), func(stdout []byte, err error) error {
+		if err != nil {
+			rule.Debug("Command %s failed: %v", rule.cmd.exe, err)
+			return fmt.Errorf("hadolint with `%s` did not run successfully while checking Dockerfile %q: %w", rule.cmd.exe, path, err)
+		}
+
+		errs := []hadolintError{}
+		if err := json.Unmarshal(stdout, &errs); err != nil {
+			return fmt.Errorf("could not parse JSON output from hadolint: %w: stdout=%q", err, stdout)
+		}
+
+		// Synchronize rule.Report calls
+		rule.mu.Lock()
+		defer rule.mu.Unlock()
+		for _, err := range errs {
+			msg := strings.TrimSuffix(strings.TrimSpace(err.Message), ".") // Trim period aligning style of error message
+			e := errorfAt(pos, rule.name, "hadolint reported issue in Dockerfile %q: %s:%s:%d:%d: %s", path, err.Code, err.Level, err.Line, err.Column, msg)
+			e.Code = err.Code
+			e.Severity = err.severity()
+			rule.Report(e)
+		}
+
+		return nil
+	})
+}
+
+// wait waits until all checks by hadolint finish.
+func (rule *RuleHadolint) wait() error {
+	return rule.cmd.wait()
+}
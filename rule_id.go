@@ -51,7 +51,7 @@ func (rule *RuleID) VisitStep(n *Step) error {
 
 	id := strings.ToLower(n.ID.Value)
 	if prev, ok := rule.seen[id]; ok {
-		rule.Errorf(n.ID.Pos, "step ID %q duplicates. previously defined at %s. step ID must be unique within a job. note that step ID is case insensitive", n.ID.Value, prev.String())
+		rule.ErrorfWithRelated(n.ID.Pos, relatedAt(prev, "previously defined here"), "step ID %q duplicates. previously defined at %s. step ID must be unique within a job. note that step ID is case insensitive", n.ID.Value, prev.String())
 		return nil
 	}
 	rule.seen[id] = n.ID.Pos
@@ -104,3 +104,26 @@ func TestCheckValidJobNames(t *testing.T) {
 		})
 	}
 }
+
+func TestCheckDuplicateStepIDRelated(t *testing.T) {
+	job := &Job{
+		ID: &String{Value: "test", Pos: &Pos{}},
+		Steps: []*Step{
+			{ID: &String{Value: "foo", Pos: &Pos{Line: 3, Col: 13}}},
+			{ID: &String{Value: "FOO", Pos: &Pos{Line: 5, Col: 13}}},
+		},
+	}
+	r := NewRuleID()
+	r.VisitJobPre(job)
+	for _, s := range job.Steps {
+		r.VisitStep(s)
+	}
+	errs := r.Errs()
+	if len(errs) != 1 {
+		t.Fatalf("wanted 1 error but got %d: %v", len(errs), errs)
+	}
+	rel := errs[0].Related
+	if len(rel) != 1 || rel[0].Line != 3 || rel[0].Column != 13 {
+		t.Fatalf("related location was not reported: %#v", rel)
+	}
+}
@@ -1,12 +1,24 @@
 package actionlint
 
 import (
+	"regexp"
 	"strings"
 )
 
+// Names of steps and jobs which clean up resources such as "Cleanup" or "Tear down".
+var reCleanupName = regexp.MustCompile(`(?i)(^|[^a-z])(clean[ _-]?up|tear[ _-]?down)($|[^a-z])`)
+
 // RuleIfCond is a rule to check if: conditions.
 type RuleIfCond struct {
 	RuleBase
+	jobs map[string]*Job
+	// inJob is true while visiting steps of a job.
+	inJob bool
+	// prevSteps is the number of the previous steps in the current job.
+	prevSteps int
+	// stepMayFail is true when some previous step in the current job may fail the job. Steps with
+	// "continue-on-error: true" never fail the job.
+	stepMayFail bool
 }
 
 // NewRuleIfCond creates new RuleIfCond instance.
@@ -19,9 +31,40 @@ func NewRuleIfCond() *RuleIfCond {
 	}
 }
 
+// VisitWorkflowPre is callback when visiting Workflow node before visiting its children.
+func (rule *RuleIfCond) VisitWorkflowPre(n *Workflow) error {
+	rule.jobs = make(map[string]*Job, len(n.Jobs))
+	for id, j := range n.Jobs {
+		rule.jobs[id] = j
+	}
+	return nil
+}
+
 // VisitStep is callback when visiting Step node.
 func (rule *RuleIfCond) VisitStep(n *Step) error {
 	rule.checkIfCond(n.If)
+
+	if rule.inJob {
+		what := "step"
+		if n.Name != nil {
+			what = "step \"" + n.Name.Value + "\""
+		} else if n.ID != nil {
+			what = "step \"" + n.ID.Value + "\""
+		}
+		if rule.prevSteps == 0 {
+			rule.checkFailureFunc(n.If, what, "there is no previous step")
+		} else if !rule.stepMayFail {
+			rule.checkFailureFunc(n.If, what, "all previous steps have \"continue-on-error: true\" so their failures never propagate")
+		}
+		if rule.stepMayFail && isCleanupStep(n) {
+			rule.checkAlwaysOmitted(n.If, n.Pos, what, "some previous step fails")
+		}
+
+		rule.prevSteps++
+		if !continuesOnError(n.ContinueOnError) {
+			rule.stepMayFail = true
+		}
+	}
 	return nil
 }
 
@@ -31,9 +74,147 @@ func (rule *RuleIfCond) VisitJobPre(n *Job) error {
 	if n.Snapshot != nil {
 		rule.checkIfCond(n.Snapshot.If)
 	}
+
+	if rule.jobs != nil && n.ID != nil {
+		what := "job \"" + n.ID.Value + "\""
+		if len(n.Needs) == 0 {
+			rule.checkFailureFunc(n.If, what, "the job has no dependency at \"needs:\"")
+		} else if fails, ok := rule.upstreamMayFail(n, map[string]struct{}{}); ok && !fails {
+			rule.checkFailureFunc(n.If, what, "all upstream jobs have \"continue-on-error: true\" so their failures never propagate")
+		}
+		if len(n.Needs) > 0 && (reCleanupName.MatchString(n.ID.Value) || n.Name != nil && reCleanupName.MatchString(n.Name.Value)) {
+			rule.checkAlwaysOmitted(n.If, n.ID.Pos, what, "some job at \"needs:\" fails")
+		}
+	}
+
+	rule.inJob = true
+	rule.prevSteps = 0
+	rule.stepMayFail = false
+	return nil
+}
+
+// VisitJobPost is callback when visiting Job node after visiting its children.
+func (rule *RuleIfCond) VisitJobPost(n *Job) error {
+	rule.inJob = false
 	return nil
 }
 
+// upstreamMayFail returns whether some upstream job of the job may fail. The 2nd return value is
+// false when it cannot be known due to undefined jobs or cyclic dependencies.
+func (rule *RuleIfCond) upstreamMayFail(n *Job, visited map[string]struct{}) (bool, bool) {
+	for _, id := range n.Needs {
+		k := strings.ToLower(id.Value)
+		if _, ok := visited[k]; ok {
+			continue
+		}
+		visited[k] = struct{}{}
+		j, ok := rule.jobs[k]
+		if !ok {
+			return false, false // Undefined job is reported by job-needs rule
+		}
+		if !continuesOnError(j.ContinueOnError) {
+			return true, true
+		}
+		// When the upstream job is skipped due to failure of its upstream, failure() is still true
+		if fails, ok := rule.upstreamMayFail(j, visited); !ok || fails {
+			return fails, ok
+		}
+	}
+	return false, true
+}
+
+func continuesOnError(b *Bool) bool {
+	return b != nil && b.Expression == nil && b.Value
+}
+
+func isCleanupStep(n *Step) bool {
+	return n.Name != nil && reCleanupName.MatchString(n.Name.Value) || n.ID != nil && reCleanupName.MatchString(n.ID.Value)
+}
+
+// checkFailureFunc reports the condition which requires failure() to be true even if failure() is
+// always false.
+func (rule *RuleIfCond) checkFailureFunc(n *String, what, reason string) {
+	e := parseIfCondExpr(n)
+	if e == nil || !requiresFailureFunc(e) {
+		return
+	}
+	rule.Errorf(
+		n.Pos,
+		"if: condition %q of %s is always false because failure() is always false. %s",
+		n.Value,
+		what,
+		reason,
+	)
+}
+
+// checkAlwaysOmitted reports the condition of cleanup step or job which does not use any status
+// check function. Such step or job is skipped on failures though it should run regardless.
+func (rule *RuleIfCond) checkAlwaysOmitted(n *String, pos *Pos, what, when string) {
+	if n != nil {
+		e := parseIfCondExpr(n)
+		if e == nil || usesStatusFunc(e) {
+			return
+		}
+		pos = n.Pos
+	}
+	rule.Errorf(
+		pos,
+		"%s looks cleaning up resources but it is skipped when %s since its if: condition does not contain status check function like always(). add \"always()\" to the condition like \"if: always()\" to run it regardless",
+		what,
+		when,
+	)
+}
+
+// parseIfCondExpr parses the expression of if: condition. It returns nil when the condition cannot
+// be parsed.
+func parseIfCondExpr(n *String) ExprNode {
+	if n == nil {
+		return nil
+	}
+	v := strings.TrimSpace(n.Value)
+	if strings.HasPrefix(v, "${{") && strings.HasSuffix(v, "}}") {
+		v = v[len("${{") : len(v)-len("}}")]
+	}
+	if strings.Contains(v, "${{") {
+		return nil
+	}
+	l := NewExprLexer(v + "}}")
+	defer l.Release()
+	e, err := NewExprParser().Parse(l)
+	if err != nil {
+		return nil
+	}
+	return e
+}
+
+// requiresFailureFunc returns true when the expression is never true unless failure() is true like
+// `failure() && steps.foo.outcome == 'failure'`.
+func requiresFailureFunc(e ExprNode) bool {
+	switch e := e.(type) {
+	case *FuncCallNode:
+		return strings.EqualFold(e.Callee, "failure") && len(e.Args) == 0
+	case *LogicalOpNode:
+		return e.Kind == LogicalOpNodeKindAnd && (requiresFailureFunc(e.Left) || requiresFailureFunc(e.Right))
+	default:
+		return false
+	}
+}
+
+// usesStatusFunc returns true when the expression calls status check functions which make the
+// step or job run on failures.
+func usesStatusFunc(e ExprNode) bool {
+	found := false
+	VisitExprNode(e, func(n, _ ExprNode, entering bool) {
+		if f, ok := n.(*FuncCallNode); ok && entering {
+			switch strings.ToLower(f.Callee) {
+			case "always", "failure", "cancelled":
+				found = true
+			}
+		}
+	})
+	return found
+}
+
 func (rule *RuleIfCond) checkIfCond(n *String) {
 	if n == nil {
 		return
@@ -62,6 +243,7 @@ func (rule *RuleIfCond) checkPlaceholder(n *String, start, end int) {
 func (rule *RuleIfCond) checkExpression(pos *Pos, input string) {
 	i := strings.TrimSpace(input)
 	l := NewExprLexer(i + "}}")
+	defer l.Release()
 	if e, err := NewExprParser().Parse(l); err == nil {
 		if NewExprSemanticsChecker(false, nil).IsConstant(e) {
 			rule.Errorf(pos, "constant expression %q in condition. remove the if: section", i)
@@ -73,3 +73,37 @@ func TestRuleIfCond(t *testing.T) {
 		})
 	}
 }
+
+func TestRuleIfCondStatusFunctionsInCondition(t *testing.T) {
+	tests := []struct {
+		cond     string
+		failure  bool
+		statusFn bool
+	}{
+		{"failure()", true, true},
+		{"${{ failure() }}", true, true},
+		{"failure() && github.ref_name == 'main'", true, true},
+		{"github.event_name == 'push' && (FAILURE() && true)", true, true},
+		{"failure() || cancelled()", false, true},
+		{"!failure()", false, true},
+		{"always()", false, true},
+		{"!cancelled()", false, true},
+		{"success()", false, false},
+		{"github.ref_name == 'main'", false, false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.cond, func(t *testing.T) {
+			e := parseIfCondExpr(&String{Value: tc.cond, Pos: &Pos{}})
+			if e == nil {
+				t.Fatal("could not parse condition")
+			}
+			if have := requiresFailureFunc(e); have != tc.failure {
+				t.Errorf("wanted %v for requiring failure() but got %v", tc.failure, have)
+			}
+			if have := usesStatusFunc(e); have != tc.statusFn {
+				t.Errorf("wanted %v for using status function but got %v", tc.statusFn, have)
+			}
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package actionlint
+
+import (
+	"fmt"
+	"strings"
+)
+
+// RuleInlineScript is a rule to check large scripts at "run:". Hundreds of lines of inline scripts
+// are hard to review and external linters like shellcheck check them without full context. They
+// should be moved to script files checked in the repository. This rule is opt-in. It is enabled
+// only when "inline-script" is configured in the configuration file.
+type RuleInlineScript struct {
+	RuleBase
+	maxLines int
+	maxBytes int
+}
+
+// NewRuleInlineScript creates new RuleInlineScript instance with the given configuration.
+func NewRuleInlineScript(cfg *InlineScriptConfig) *RuleInlineScript {
+	return &RuleInlineScript{
+		RuleBase: RuleBase{
+			name: "inline-script",
+			desc: "Checks for large scripts at \"run:\" which should be moved to script files. This rule is enabled by \"inline-script\" configuration",
+		},
+		maxLines: cfg.MaxLines,
+		maxBytes: cfg.MaxBytes,
+	}
+}
+
+// VisitStep is callback when visiting Step node.
+func (rule *RuleInlineScript) VisitStep(n *Step) error {
+	e, ok := n.Exec.(*ExecRun)
+	if !ok || e.Run == nil {
+		return nil
+	}
+
+	src := strings.TrimRight(e.Run.Value, "\n")
+	size := ""
+	if l := strings.Count(src, "\n") + 1; rule.maxLines > 0 && l > rule.maxLines {
+		size = fmt.Sprintf("%d lines, which is more than %d lines configured at \"max-lines\"", l, rule.maxLines)
+	} else if b := len(src); rule.maxBytes > 0 && b > rule.maxBytes {
+		size = fmt.Sprintf("%d bytes, which is more than %d bytes configured at \"max-bytes\"", b, rule.maxBytes)
+	}
+	if size == "" {
+		return nil
+	}
+
+	rule.Errorf(
+		e.Run.Pos,
+		"script at \"run:\" has %s in \"inline-script\". move the script to a file in the repository and run it by path like \"run: %s\". the file is easier to review and linters can check it with full context",
+		size,
+		scriptFileExample(e.Shell),
+	)
+	return nil
+}
+
+// scriptFileExample returns an example file path of the script run by the shell.
+func scriptFileExample(shell *String) string {
+	if shell != nil {
+		name, _, _ := strings.Cut(strings.TrimSpace(shell.Value), " ")
+		switch strings.ToLower(name) {
+		case "pwsh", "powershell":
+			return "./scripts/build.ps1"
+		case "python":
+			return "python ./scripts/build.py"
+		case "cmd":
+			return "./scripts/build.cmd"
+		}
+	}
+	return "./scripts/build.sh"
+}
@@ -1,8 +1,11 @@
 package actionlint
 
 import (
+	"fmt"
 	"strconv"
 	"strings"
+
+	"github.com/rhysd/actionlint/expr"
 )
 
 type nodeStatus int
@@ -19,6 +22,12 @@ type jobNode struct {
 	resolved []*jobNode
 	status   nodeStatus
 	pos      *Pos
+	// cond is the "if:" condition of the job. Nil means it is not set.
+	cond *String
+	// alwaysFalse is true when the "if:" condition of the job is statically false.
+	alwaysFalse bool
+	// runsOnSkip is true when the job may run even if some job at "needs:" is skipped.
+	runsOnSkip bool
 }
 
 type edge struct {
@@ -38,7 +47,7 @@ func NewRuleJobNeeds() *RuleJobNeeds {
 	return &RuleJobNeeds{
 		RuleBase: RuleBase{
 			name: "job-needs",
-			desc: "Checks for job IDs in \"needs:\". Undefined IDs and cyclic dependencies are checked",
+			desc: "Checks for job IDs in \"needs:\". Undefined IDs, cyclic dependencies, and jobs which never run are checked",
 		},
 		nodes: map[string]*jobNode{},
 	}
@@ -74,15 +83,21 @@ func (rule *RuleJobNeeds) VisitJobPre(n *Job) error {
 		return nil
 	}
 	if prev, ok := rule.nodes[id]; ok {
-		rule.Errorf(n.Pos, "job ID %q duplicates. previously defined at %s. note that job ID is case insensitive", n.ID.Value, prev.pos.String())
+		rule.ErrorfWithRelated(n.Pos, relatedAt(prev.pos, "previously defined here"), "job ID %q duplicates. previously defined at %s. note that job ID is case insensitive", n.ID.Value, prev.pos.String())
 	}
 
-	rule.nodes[id] = &jobNode{
+	node := &jobNode{
 		id:     id,
 		needs:  needs,
 		status: nodeStatusNew,
 		pos:    n.ID.Pos,
+		cond:   n.If,
+	}
+	if e := parseIfCondExpr(n.If); e != nil {
+		node.alwaysFalse = isAlwaysFalseCond(e) || len(needs) == 0 && requiresFailureFunc(e)
+		node.runsOnSkip = runsOnSkippedNeeds(e)
 	}
+	rule.nodes[id] = node
 
 	return nil
 }
@@ -136,11 +151,94 @@ func (rule *RuleJobNeeds) VisitWorkflowPost(n *Workflow) error {
 		}
 
 		rule.Error(start.pos, msg.String())
+		return nil
 	}
 
+	rule.checkNeverRun()
 	return nil
 }
 
+// checkNeverRun reports jobs which never run since some jobs at "needs:" never run. Jobs whose "if:"
+// conditions are always false are not reported here since the "if-cond" rule reports them.
+func (rule *RuleJobNeeds) checkNeverRun() {
+	never := map[*jobNode]*jobNode{} // Map from the job which never runs to its cause at "needs:"
+	var check func(n *jobNode) bool
+	check = func(n *jobNode) bool {
+		if n.alwaysFalse {
+			return true
+		}
+		if cause, ok := never[n]; ok {
+			return cause != nil
+		}
+		never[n] = nil
+		if !n.runsOnSkip {
+			for _, d := range n.resolved {
+				if check(d) {
+					never[n] = d
+					return true
+				}
+			}
+		}
+		return false
+	}
+
+	for _, n := range rule.nodes {
+		if !check(n) || n.alwaysFalse {
+			continue
+		}
+		cause := never[n]
+		why := ""
+		if cause.alwaysFalse {
+			why = fmt.Sprintf(" due to its \"if:\" condition %q", cause.cond.Value)
+		}
+		rule.Errorf(
+			n.pos,
+			"job %q never runs since job %q at \"needs:\" never runs%s. a job is skipped when some job at \"needs:\" is skipped unless its \"if:\" condition contains status check function like always(). remove the dead jobs or fix their conditions",
+			n.id,
+			cause.id,
+			why,
+		)
+	}
+}
+
+// isAlwaysFalseCond returns true when the condition is a constant expression evaluated to falsy value.
+func isAlwaysFalseCond(e ExprNode) bool {
+	if !NewExprSemanticsChecker(false, nil).IsConstant(e) {
+		return false
+	}
+	v, err := expr.Evaluate(e, nil)
+	if err != nil {
+		return false
+	}
+	switch v := v.(type) {
+	case nil:
+		return true
+	case bool:
+		return !v
+	case float64:
+		return v == 0 || v != v // NaN is falsy
+	case string:
+		return v == ""
+	default:
+		return false
+	}
+}
+
+// runsOnSkippedNeeds returns true when the condition may be true even if some job at "needs:" is
+// skipped. success() is false and failure() is false in the case.
+func runsOnSkippedNeeds(e ExprNode) bool {
+	found := false
+	VisitExprNode(e, func(n, _ ExprNode, entering bool) {
+		if f, ok := n.(*FuncCallNode); ok && entering {
+			switch strings.ToLower(f.Callee) {
+			case "always", "cancelled":
+				found = true
+			}
+		}
+	})
+	return found
+}
+
 func collectCycle(src *jobNode, edges map[*jobNode]*jobNode) bool {
 	for _, dest := range src.resolved {
 		if dest.status != nodeStatusActive {
@@ -0,0 +1,312 @@
+package actionlint
+
+import (
+	"strings"
+)
+
+const (
+	// https://docs.github.com/en/actions/writing-workflows/choosing-what-your-workflow-does/running-variations-of-jobs-in-a-workflow
+	maxMatrixJobs = 256
+	// Maximum length of expression in ${{ }} enforced by the runner. Longer expression causes
+	// "Exceeded max expression length" error.
+	maxExpressionLength = 21000
+	// Maximum length of single environment variable on Linux (MAX_ARG_STRLEN).
+	maxEnvVarValueLength = 128 * 1024
+	// https://docs.github.com/en/actions/reference/limits
+	maxJobExecMinutesGitHubHosted = 6 * 60
+	maxJobExecMinutesSelfHosted   = 5 * 24 * 60
+	// https://docs.github.com/en/actions/sharing-automations/reusing-workflows#limitations
+	maxReusableWorkflowsInWorkflow = 50
+	// Maximum length of concurrency group name. Longer name is rejected when the workflow run starts.
+	maxConcurrencyGroupLength = 400
+	// https://docs.github.com/en/actions/managing-workflow-runs-and-deployments/managing-deployments/managing-environments-for-deployment#creating-an-environment
+	maxEnvironmentNameLength = 255
+)
+
+// RuleLimits is a rule to check the documented limits of GitHub Actions. Exceeding these limits
+// causes opaque errors on GitHub so they should be detected statically.
+// https://docs.github.com/en/actions/reference/limits
+type RuleLimits struct {
+	RuleBase
+	reusableWorkflows map[string]struct{}
+}
+
+// NewRuleLimits creates new RuleLimits instance.
+func NewRuleLimits() *RuleLimits {
+	return &RuleLimits{
+		RuleBase: RuleBase{
+			name: "limits",
+			desc: "Checks for the documented limits of GitHub Actions such as the number of matrix jobs, length of expressions, or length of names",
+		},
+		reusableWorkflows: map[string]struct{}{},
+	}
+}
+
+// VisitWorkflowPre is callback when visiting Workflow node before visiting its children.
+func (rule *RuleLimits) VisitWorkflowPre(n *Workflow) error {
+	rule.checkExprLength(n.RunName)
+	rule.checkEnv(n.Env)
+	rule.checkConcurrency(n.Concurrency)
+	return nil
+}
+
+// VisitJobPre is callback when visiting Job node before visiting its children.
+func (rule *RuleLimits) VisitJobPre(n *Job) error {
+	rule.checkExprLength(n.Name)
+	rule.checkExprLength(n.If)
+	rule.checkEnv(n.Env)
+	for _, o := range n.Outputs {
+		rule.checkExprLength(o.Value)
+	}
+	rule.checkConcurrency(n.Concurrency)
+	rule.checkEnvironmentName(n.Environment)
+	if n.Strategy != nil && n.Strategy.Matrix != nil {
+		rule.checkMatrixJobs(n.Strategy.Matrix)
+	}
+	rule.checkTimeoutMinutes(n)
+
+	if n.WorkflowCall != nil && n.WorkflowCall.Uses != nil && !n.WorkflowCall.Uses.ContainsExpression() {
+		u := n.WorkflowCall.Uses
+		if _, ok := rule.reusableWorkflows[u.Value]; !ok {
+			rule.reusableWorkflows[u.Value] = struct{}{}
+			if len(rule.reusableWorkflows) == maxReusableWorkflowsInWorkflow+1 {
+				rule.Errorf(
+					u.Pos,
+					"too many reusable workflows are called in this workflow. at most %d unique reusable workflows can be called but %q is the %dth one. see https://docs.github.com/en/actions/sharing-automations/reusing-workflows#limitations",
+					maxReusableWorkflowsInWorkflow,
+					u.Value,
+					len(rule.reusableWorkflows),
+				)
+			}
+		}
+		for _, i := range n.WorkflowCall.Inputs {
+			rule.checkExprLength(i.Value)
+		}
+	}
+	return nil
+}
+
+// VisitStep is callback when visiting Step node.
+func (rule *RuleLimits) VisitStep(n *Step) error {
+	rule.checkExprLength(n.Name)
+	rule.checkExprLength(n.If)
+	rule.checkEnv(n.Env)
+	switch e := n.Exec.(type) {
+	case *ExecRun:
+		rule.checkExprLength(e.Run)
+		rule.checkExprLength(e.WorkingDirectory)
+	case *ExecAction:
+		for _, i := range e.Inputs {
+			rule.checkExprLength(i.Value)
+		}
+	}
+	return nil
+}
+
+func (rule *RuleLimits) checkExprLength(s *String) {
+	if s == nil {
+		return
+	}
+	v := s.Value
+	offset := 0
+	for {
+		start := strings.Index(v[offset:], "${{")
+		if start < 0 {
+			return
+		}
+		start += offset
+		end := strings.Index(v[start:], "}}")
+		if end < 0 {
+			return
+		}
+		end += start
+		if l := end - start - 3; l > maxExpressionLength {
+			rule.Errorf(
+				s.Pos,
+				"expression in ${{ }} is too long. its length is %d but the maximum length is %d",
+				l,
+				maxExpressionLength,
+			)
+			return
+		}
+		offset = end + 2
+	}
+}
+
+func (rule *RuleLimits) checkEnv(env *Env) {
+	if env == nil || env.Expression != nil {
+		return
+	}
+	for _, v := range env.Vars {
+		if v.Value == nil {
+			continue
+		}
+		rule.checkExprLength(v.Value)
+		if l := len(v.Value.Value); l > maxEnvVarValueLength && !v.Value.ContainsExpression() {
+			rule.Errorf(
+				v.Value.Pos,
+				"value of environment variable %q is too large. its size is %d bytes but the maximum size of one environment variable is %d bytes on Linux",
+				v.Name.Value,
+				l,
+				maxEnvVarValueLength,
+			)
+		}
+	}
+}
+
+// staticLength returns the length of the string without the ${{ }} placeholders. It is the lower
+// bound of the length of the string after evaluating the placeholders.
+func staticLength(s string) int {
+	l := 0
+	for {
+		start := strings.Index(s, "${{")
+		if start < 0 {
+			return l + len(s)
+		}
+		end := strings.Index(s[start:], "}}")
+		if end < 0 {
+			return l + len(s)
+		}
+		l += start
+		s = s[start+end+2:]
+	}
+}
+
+func (rule *RuleLimits) checkConcurrency(c *Concurrency) {
+	if c == nil || c.Group == nil {
+		return
+	}
+	rule.checkExprLength(c.Group)
+	if l := staticLength(c.Group.Value); l > maxConcurrencyGroupLength {
+		rule.Errorf(
+			c.Group.Pos,
+			"concurrency group is too long. its length is at least %d but the maximum length is %d",
+			l,
+			maxConcurrencyGroupLength,
+		)
+	}
+}
+
+func (rule *RuleLimits) checkEnvironmentName(e *Environment) {
+	if e == nil || e.Name == nil {
+		return
+	}
+	if l := staticLength(e.Name.Value); l > maxEnvironmentNameLength {
+		rule.Errorf(
+			e.Name.Pos,
+			"environment name is too long. its length is at least %d but the maximum length is %d. see https://docs.github.com/en/actions/managing-workflow-runs-and-deployments/managing-deployments/managing-environments-for-deployment#creating-an-environment",
+			l,
+			maxEnvironmentNameLength,
+		)
+	}
+}
+
+// countMatrixJobsLowerBound calculates the lower bound of number of jobs generated by the matrix.
+// It returns false when the number cannot be calculated statically.
+func countMatrixJobsLowerBound(m *Matrix) (int, bool) {
+	if m.Expression != nil || len(m.Rows) == 0 {
+		return 0, false
+	}
+
+	total := 1
+	for _, r := range m.Rows {
+		if r.Expression != nil {
+			return 0, false
+		}
+		total *= len(r.Values)
+		if total == 0 {
+			return 0, true
+		}
+	}
+
+	// Subtracting all combinations excluded by each "exclude" item gives a lower bound since some
+	// combination may be excluded by multiple items. "include" items only add jobs or extend existing
+	// jobs so they don't decrease the number.
+	if m.Exclude != nil {
+		if m.Exclude.Expression != nil {
+			return 0, false
+		}
+		for _, c := range m.Exclude.Combinations {
+			if c.Expression != nil {
+				return 0, false
+			}
+			excluded := 1
+			for name, r := range m.Rows {
+				a, ok := c.Assigns[name]
+				if !ok {
+					excluded *= len(r.Values)
+					continue
+				}
+				matched := 0
+				for _, v := range r.Values {
+					if isYAMLValueSubset(v, a.Value) {
+						matched++
+					}
+				}
+				excluded *= matched
+			}
+			total -= excluded
+		}
+	}
+
+	return total, true
+}
+
+func (rule *RuleLimits) checkMatrixJobs(m *Matrix) {
+	n, ok := countMatrixJobsLowerBound(m)
+	if !ok || n <= maxMatrixJobs {
+		return
+	}
+	rule.Errorf(
+		m.Pos,
+		"matrix generates at least %d jobs but a matrix can generate a maximum of %d jobs per workflow run. see https://docs.github.com/en/actions/writing-workflows/choosing-what-your-workflow-does/running-variations-of-jobs-in-a-workflow",
+		n,
+		maxMatrixJobs,
+	)
+}
+
+func isGitHubHostedRunner(r *Runner) bool {
+	if r == nil || r.LabelsExpr != nil {
+		return false
+	}
+	hosted := false
+	for _, l := range r.Labels {
+		if l.ContainsExpression() {
+			return false
+		}
+		n := strings.ToLower(l.Value)
+		if n == "self-hosted" {
+			return false
+		}
+		if isGitHubHostedLabel(n) {
+			hosted = true
+		}
+	}
+	return hosted
+}
+
+func (rule *RuleLimits) checkTimeoutMinutes(n *Job) {
+	t := n.TimeoutMinutes
+	if t == nil || t.Expression != nil {
+		return
+	}
+	if isGitHubHostedRunner(n.RunsOn) {
+		if t.Value > maxJobExecMinutesGitHubHosted {
+			rule.Errorf(
+				t.Pos,
+				"timeout-minutes %g exceeds the maximum execution time of a job on GitHub-hosted runners. the job is terminated after %d minutes. see https://docs.github.com/en/actions/reference/limits",
+				t.Value,
+				maxJobExecMinutesGitHubHosted,
+			)
+		}
+		return
+	}
+	if t.Value > maxJobExecMinutesSelfHosted {
+		rule.Errorf(
+			t.Pos,
+			"timeout-minutes %g exceeds the maximum execution time of a job. the job is terminated after %d minutes even on self-hosted runners. see https://docs.github.com/en/actions/reference/limits",
+			t.Value,
+			maxJobExecMinutesSelfHosted,
+		)
+	}
+}
@@ -0,0 +1,152 @@
+package actionlint
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestRuleLimitsExpressionLength(t *testing.T) {
+	tests := []struct {
+		what  string
+		input string
+		errs  int
+	}{
+		{"short", "echo ${{ github.sha }}", 0},
+		{"at limit", "${{ '" + strings.Repeat("a", maxExpressionLength-4) + "' }}", 0},
+		{"too long", "${{ '" + strings.Repeat("a", maxExpressionLength) + "' }}", 1},
+		{"second expression too long", "${{ 'a' }} ${{ '" + strings.Repeat("a", maxExpressionLength) + "' }}", 1},
+		{"long plain string", strings.Repeat("a", maxExpressionLength*2), 0},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.what, func(t *testing.T) {
+			s := &Step{
+				Exec: &ExecRun{Run: &String{Value: tc.input, Pos: &Pos{}}},
+				Pos:  &Pos{},
+			}
+			r := NewRuleLimits()
+			if err := r.VisitStep(s); err != nil {
+				t.Fatal(err)
+			}
+			errs := r.Errs()
+			if len(errs) != tc.errs {
+				t.Fatalf("wanted %d errors but got %v", tc.errs, errs)
+			}
+			for _, err := range errs {
+				if !strings.Contains(err.Message, "expression in ${{ }} is too long") {
+					t.Fatalf("unexpected error message: %q", err.Message)
+				}
+			}
+		})
+	}
+}
+
+func TestRuleLimitsEnvVarSize(t *testing.T) {
+	env := &Env{
+		Vars: map[string]*EnvVar{
+			"small": {
+				Name:  &String{Value: "SMALL", Pos: &Pos{}},
+				Value: &String{Value: "small", Pos: &Pos{}},
+			},
+			"large": {
+				Name:  &String{Value: "LARGE", Pos: &Pos{}},
+				Value: &String{Value: strings.Repeat("a", maxEnvVarValueLength+1), Pos: &Pos{}},
+			},
+		},
+	}
+	r := NewRuleLimits()
+	if err := r.VisitWorkflowPre(&Workflow{Env: env}); err != nil {
+		t.Fatal(err)
+	}
+	errs := r.Errs()
+	if len(errs) != 1 {
+		t.Fatalf("wanted one error but got %v", errs)
+	}
+	if !strings.Contains(errs[0].Message, `"LARGE" is too large`) {
+		t.Fatalf("unexpected error message: %q", errs[0].Message)
+	}
+}
+
+func TestRuleLimitsTooManyReusableWorkflows(t *testing.T) {
+	r := NewRuleLimits()
+	for i := 0; i <= maxReusableWorkflowsInWorkflow; i++ {
+		for j := 0; j < 2; j++ { // Calling the same workflow twice is counted once
+			j := &Job{
+				ID: &String{Value: fmt.Sprintf("job%d_%d", i, j), Pos: &Pos{}},
+				WorkflowCall: &WorkflowCall{
+					Uses: &String{Value: fmt.Sprintf("./.github/workflows/w%d.yaml", i), Pos: &Pos{}},
+				},
+				Pos: &Pos{},
+			}
+			if err := r.VisitJobPre(j); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	errs := r.Errs()
+	if len(errs) != 1 {
+		t.Fatalf("wanted one error but got %v", errs)
+	}
+	want := fmt.Sprintf(`"./.github/workflows/w%d.yaml" is the %dth one`, maxReusableWorkflowsInWorkflow, maxReusableWorkflowsInWorkflow+1)
+	if !strings.Contains(errs[0].Message, want) {
+		t.Fatalf("error message %q does not contain %q", errs[0].Message, want)
+	}
+}
+
+func TestRuleLimitsStaticLength(t *testing.T) {
+	tests := []struct {
+		input string
+		want  int
+	}{
+		{"", 0},
+		{"abc", 3},
+		{"${{ github.ref }}", 0},
+		{"ci-${{ github.ref }}", 3},
+		{"ci-${{ github.workflow }}-${{ github.ref }}-x", 6},
+		{"ci-${{ github.ref", 17},
+	}
+	for _, tc := range tests {
+		if have := staticLength(tc.input); have != tc.want {
+			t.Errorf("static length of %q should be %d but got %d", tc.input, tc.want, have)
+		}
+	}
+}
+
+func TestRuleLimitsConcurrencyGroupAndEnvironmentNameLength(t *testing.T) {
+	tests := []struct {
+		what  string
+		group string
+		env   string
+		want  []string
+	}{
+		{"ok", strings.Repeat("g", maxConcurrencyGroupLength), strings.Repeat("e", maxEnvironmentNameLength), nil},
+		{"long group", strings.Repeat("g", maxConcurrencyGroupLength+1), "prod", []string{"concurrency group is too long"}},
+		{"long environment", "ci", strings.Repeat("e", maxEnvironmentNameLength+1), []string{"environment name is too long"}},
+		{"placeholders are not counted", "${{ github.workflow }}-" + strings.Repeat("g", maxConcurrencyGroupLength-1), "${{ inputs.env }}", nil},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.what, func(t *testing.T) {
+			j := &Job{
+				ID:          &String{Value: "test", Pos: &Pos{}},
+				Concurrency: &Concurrency{Group: &String{Value: tc.group, Pos: &Pos{}}, Pos: &Pos{}},
+				Environment: &Environment{Name: &String{Value: tc.env, Pos: &Pos{}}, Pos: &Pos{}},
+				Pos:         &Pos{},
+			}
+			r := NewRuleLimits()
+			if err := r.VisitJobPre(j); err != nil {
+				t.Fatal(err)
+			}
+			errs := r.Errs()
+			if len(errs) != len(tc.want) {
+				t.Fatalf("wanted %d errors but got %v", len(tc.want), errs)
+			}
+			for i, err := range errs {
+				if !strings.Contains(err.Message, tc.want[i]) {
+					t.Errorf("error message %q does not contain %q", err.Message, tc.want[i])
+				}
+			}
+		})
+	}
+}
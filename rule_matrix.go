@@ -52,8 +52,9 @@ func (rule *RuleMatrix) checkDuplicateInRow(row *MatrixRow) {
 		ok := true
 		for _, p := range seen {
 			if p.Equals(v) {
-				rule.Errorf(
+				rule.ErrorfWithRelated(
 					v.Pos(),
+					relatedAt(p.Pos(), "the same value is here"),
 					"duplicate value %s is found in matrix %q. the same value is at %s",
 					v.String(),
 					row.Name.Value,
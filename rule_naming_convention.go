@@ -0,0 +1,108 @@
+package actionlint
+
+import (
+	"fmt"
+	"regexp"
+)
+
+type namingPattern struct {
+	re  *regexp.Regexp
+	key string
+}
+
+// RuleNamingConvention is a rule to check names in workflows follow the naming convention configured
+// with regular expressions. Workflow names, job IDs, step IDs, input names, and output names are
+// checked. This rule is opt-in. It is enabled only when "naming-convention" is configured in the
+// configuration file.
+type RuleNamingConvention struct {
+	RuleBase
+	workflowName *namingPattern
+	jobID        *namingPattern
+	stepID       *namingPattern
+	inputName    *namingPattern
+	outputName   *namingPattern
+}
+
+// NewRuleNamingConvention creates new RuleNamingConvention instance with the given configuration.
+// Patterns in the configuration which are empty or invalid are ignored. Patterns in the
+// configuration file are validated by ParseConfig.
+func NewRuleNamingConvention(cfg *NamingConventionConfig) *RuleNamingConvention {
+	return &RuleNamingConvention{
+		RuleBase: RuleBase{
+			name: "naming-convention",
+			desc: "Checks for names of workflows, jobs, steps, inputs, and outputs following naming convention. This rule is enabled by \"naming-convention\" configuration",
+		},
+		workflowName: compileNamingPattern(cfg.WorkflowName, "workflow-name"),
+		jobID:        compileNamingPattern(cfg.JobID, "job-id"),
+		stepID:       compileNamingPattern(cfg.StepID, "step-id"),
+		inputName:    compileNamingPattern(cfg.InputName, "input-name"),
+		outputName:   compileNamingPattern(cfg.OutputName, "output-name"),
+	}
+}
+
+func compileNamingPattern(pat, key string) *namingPattern {
+	if pat == "" {
+		return nil
+	}
+	r, err := regexp.Compile(pat)
+	if err != nil {
+		return nil
+	}
+	return &namingPattern{r, key}
+}
+
+// VisitWorkflowPre is callback when visiting Workflow node before visiting its children.
+func (rule *RuleNamingConvention) VisitWorkflowPre(n *Workflow) error {
+	rule.check(rule.workflowName, n.Name, "workflow name %q")
+
+	for _, e := range n.On {
+		switch e := e.(type) {
+		case *WorkflowDispatchEvent:
+			for _, i := range e.Inputs {
+				rule.check(rule.inputName, i.Name, "input %q of \"workflow_dispatch\" event")
+			}
+		case *WorkflowCallEvent:
+			for _, i := range e.Inputs {
+				rule.check(rule.inputName, i.Name, "input %q of \"workflow_call\" event")
+			}
+			for _, o := range e.Outputs {
+				rule.check(rule.outputName, o.Name, "output %q of \"workflow_call\" event")
+			}
+		}
+	}
+
+	return nil
+}
+
+// VisitJobPre is callback when visiting Job node before visiting its children.
+func (rule *RuleNamingConvention) VisitJobPre(n *Job) error {
+	rule.check(rule.jobID, n.ID, "job ID %q")
+	for _, o := range n.Outputs {
+		rule.check(rule.outputName, o.Name, fmt.Sprintf("output %%q of job %q", n.ID.Value))
+	}
+	return nil
+}
+
+// VisitStep is callback when visiting Step node.
+func (rule *RuleNamingConvention) VisitStep(n *Step) error {
+	rule.check(rule.stepID, n.ID, "step ID %q")
+	return nil
+}
+
+// check checks the name matches the pattern. The format describes the name and has one %q for the
+// name like "job ID %q".
+func (rule *RuleNamingConvention) check(p *namingPattern, name *String, format string) {
+	if p == nil || name == nil || name.Value == "" || name.ContainsExpression() {
+		return
+	}
+	if p.re.MatchString(name.Value) {
+		return
+	}
+	rule.Errorf(
+		name.Pos,
+		"%s does not follow the naming convention. it must match the pattern %q configured at %q in \"naming-convention\"",
+		fmt.Sprintf(format, name.Value),
+		p.re.String(),
+		p.key,
+	)
+}
@@ -0,0 +1,255 @@
+package actionlint
+
+import (
+	"regexp"
+	"strings"
+)
+
+type runnerOSKind uint8
+
+const (
+	runnerOSKindLinux runnerOSKind = 1 << iota
+	runnerOSKindMacOS
+	runnerOSKindWindows
+)
+
+func (k runnerOSKind) String() string {
+	switch k {
+	case runnerOSKindLinux:
+		return "Linux"
+	case runnerOSKindMacOS:
+		return "macOS"
+	case runnerOSKindWindows:
+		return "Windows"
+	case runnerOSKindLinux | runnerOSKindMacOS:
+		return "macOS or Linux"
+	default:
+		return "unknown OS"
+	}
+}
+
+// Commands which are only available on specific OSes. The keys are command names in lower case.
+// Note that Homebrew is also installed on GitHub-hosted Linux runners.
+var osSpecificCommands = map[string]runnerOSKind{
+	"apt":            runnerOSKindLinux,
+	"apt-get":        runnerOSKindLinux,
+	"dpkg":           runnerOSKindLinux,
+	"yum":            runnerOSKindLinux,
+	"dnf":            runnerOSKindLinux,
+	"apk":            runnerOSKindLinux,
+	"snap":           runnerOSKindLinux,
+	"systemctl":      runnerOSKindLinux,
+	"brew":           runnerOSKindLinux | runnerOSKindMacOS,
+	"sudo":           runnerOSKindLinux | runnerOSKindMacOS,
+	"xcodebuild":     runnerOSKindMacOS,
+	"xcrun":          runnerOSKindMacOS,
+	"hdiutil":        runnerOSKindMacOS,
+	"softwareupdate": runnerOSKindMacOS,
+	"choco":          runnerOSKindWindows,
+	"winget":         runnerOSKindWindows,
+	"msiexec":        runnerOSKindWindows,
+	"powershell":     runnerOSKindWindows,
+	"cmd":            runnerOSKindWindows,
+}
+
+// Detects Windows-style paths like C:\path\to\file or .\path\to\file
+var windowsStylePathPattern = regexp.MustCompile(`(?:^|[\s=(])((?:[A-Za-z]:|\.{1,2})\\[\w.-]+(?:\\[\w.-]*)*)`)
+
+// Scripts referring these names likely branch by OS of the runner.
+var osBranchInScriptPattern = regexp.MustCompile(`(?i)\brunner\.os\b|\$RUNNER_OS\b|\$\{RUNNER_OS\}|\$env:RUNNER_OS\b|\buname\b|\$IsWindows\b|\$IsLinux\b|\$IsMacOS\b`)
+
+// Conditions at "if:" referring these names likely select the OS of the runner.
+var osBranchInCondPattern = regexp.MustCompile(`(?i)\brunner\.os\b|\bmatrix\.`)
+
+type runnerOSCandidate struct {
+	label *String
+	os    runnerOSKind
+}
+
+// RuleOSCommand is a rule to check commands in "run:" scripts which are only available on specific
+// OS. For example, "apt-get" is not available on Windows runners. This rule heuristically detects
+// such commands and reports them when they don't match to the OS of the runner.
+type RuleOSCommand struct {
+	RuleBase
+	runners []runnerOSCandidate
+}
+
+// NewRuleOSCommand creates new RuleOSCommand instance.
+func NewRuleOSCommand() *RuleOSCommand {
+	return &RuleOSCommand{
+		RuleBase: RuleBase{
+			name: "os-command",
+			desc: "Checks for OS-specific commands in \"run:\" scripts which are not available on the OS of the runner. This rule is enabled by \"os-commands\" configuration",
+		},
+	}
+}
+
+func getRunnerOSKindFromLabel(l string) (runnerOSKind, bool) {
+	os, ok := runnerOSOfLabel(l)
+	if !ok {
+		return 0, false
+	}
+	switch os {
+	case RunnerOSLinux:
+		return runnerOSKindLinux, true
+	case RunnerOSMacOS:
+		return runnerOSKindMacOS, true
+	default:
+		return runnerOSKindWindows, true
+	}
+}
+
+// VisitJobPre is callback when visiting Job node before visiting its children.
+func (rule *RuleOSCommand) VisitJobPre(n *Job) error {
+	rule.runners = nil
+	if n.RunsOn == nil || n.Container != nil {
+		return nil
+	}
+
+	var m *Matrix
+	if n.Strategy != nil {
+		m = n.Strategy.Matrix
+	}
+
+	labels := n.RunsOn.Labels
+	if n.RunsOn.LabelsExpr != nil {
+		labels = []*String{n.RunsOn.LabelsExpr}
+	}
+
+	static := []runnerOSCandidate{}
+	matrix := []runnerOSCandidate{}
+	for _, l := range labels {
+		if l.ContainsExpression() {
+			for _, s := range getRunnerLabelsInMatrix(l, m) {
+				if k, ok := getRunnerOSKindFromLabel(s.Value); ok {
+					matrix = append(matrix, runnerOSCandidate{s, k})
+				}
+			}
+			continue
+		}
+		if k, ok := getRunnerOSKindFromLabel(l.Value); ok {
+			static = append(static, runnerOSCandidate{l, k})
+		}
+	}
+
+	if len(static) > 0 {
+		for _, c := range static[1:] {
+			if c.os != static[0].os {
+				return nil // Conflicts are reported by runner-label rule
+			}
+		}
+		rule.runners = static[:1]
+	} else {
+		rule.runners = matrix
+	}
+	return nil
+}
+
+// VisitJobPost is callback when visiting Job node after visiting its children.
+func (rule *RuleOSCommand) VisitJobPost(n *Job) error {
+	rule.runners = nil
+	return nil
+}
+
+// VisitStep is callback when visiting Step node.
+func (rule *RuleOSCommand) VisitStep(n *Step) error {
+	if len(rule.runners) == 0 {
+		return nil
+	}
+	e, ok := n.Exec.(*ExecRun)
+	if !ok || e.Run == nil {
+		return nil
+	}
+	if n.If != nil && osBranchInCondPattern.MatchString(n.If.Value) {
+		return nil
+	}
+	if osBranchInScriptPattern.MatchString(e.Run.Value) {
+		return nil
+	}
+
+	reported := map[string]struct{}{}
+	for _, line := range strings.Split(e.Run.Value, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "REM ") {
+			continue
+		}
+		for _, cmd := range commandNamesInLine(line) {
+			if _, ok := reported[cmd]; ok {
+				continue
+			}
+			if avail, ok := osSpecificCommands[cmd]; ok {
+				if c, ok := rule.findMismatch(avail); ok {
+					reported[cmd] = struct{}{}
+					rule.Errorf(
+						e.Run.Pos,
+						"command %q is only available on %s but this step runs on %s with runner label %q. run this step only on %s with \"if:\" or use a command available on %s",
+						cmd,
+						avail,
+						c.os,
+						c.label.Value,
+						avail,
+						c.os,
+					)
+				}
+			}
+		}
+		if m := windowsStylePathPattern.FindStringSubmatch(line); m != nil {
+			if _, ok := reported[m[1]]; ok {
+				continue
+			}
+			if c, ok := rule.findMismatch(runnerOSKindWindows); ok {
+				reported[m[1]] = struct{}{}
+				rule.Errorf(
+					e.Run.Pos,
+					"Windows-style path %q is used but this step runs on %s with runner label %q. use \"/\" as path separator",
+					m[1],
+					c.os,
+					c.label.Value,
+				)
+			}
+		}
+	}
+	return nil
+}
+
+func (rule *RuleOSCommand) findMismatch(avail runnerOSKind) (runnerOSCandidate, bool) {
+	for _, c := range rule.runners {
+		if c.os&avail == 0 {
+			return c, true
+		}
+	}
+	return runnerOSCandidate{}, false
+}
+
+// commandNamesInLine heuristically extracts command names in the line of the script. Command names
+// are in lower case and ".exe" suffix is removed.
+func commandNamesInLine(line string) []string {
+	cmds := []string{}
+	for _, c := range strings.FieldsFunc(line, func(r rune) bool {
+		return r == ';' || r == '|' || r == '&' || r == '(' || r == ')' || r == '`'
+	}) {
+		words := strings.Fields(c)
+		for len(words) > 0 {
+			w := words[0]
+			// Skip environment variable assignments and keywords before the command
+			if strings.Contains(w, "=") || w == "then" || w == "do" || w == "else" || w == "!" {
+				words = words[1:]
+				continue
+			}
+			if i := strings.LastIndexAny(w, `/\`); i >= 0 {
+				w = w[i+1:]
+			}
+			w = strings.TrimSuffix(strings.ToLower(w), ".exe")
+			cmds = append(cmds, w)
+			if w != "sudo" {
+				break
+			}
+			// Check the command run by sudo as well
+			words = words[1:]
+			for len(words) > 0 && strings.HasPrefix(words[0], "-") {
+				words = words[1:]
+			}
+		}
+	}
+	return cmds
+}
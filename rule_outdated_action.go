@@ -0,0 +1,161 @@
+package actionlint
+
+import (
+	"cmp"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// actionVersion is a version of an action in a ref like "v4" or "v4.2.1". Refs which specify only
+// the major version like "v4" are usually moving tags which point to the latest release in the major
+// version.
+type actionVersion struct {
+	nums [3]int
+	// len is the number of components in the ref. 1 for "v4", 2 for "v4.2", and 3 for "v4.2.1".
+	len int
+}
+
+// parseActionVersion parses the ref like "v4", "v4.2", "4.2.1". Pre-releases like "v5.0.0-beta.1"
+// and other refs such as branch names and commit SHAs are not versions.
+func parseActionVersion(ref string) (actionVersion, bool) {
+	var v actionVersion
+	ss := strings.Split(strings.TrimPrefix(ref, "v"), ".")
+	if len(ss) > 3 {
+		return v, false
+	}
+	for i, s := range ss {
+		if s == "" || strings.TrimLeft(s, "0123456789") != "" {
+			return v, false
+		}
+		n, err := strconv.Atoi(s)
+		if err != nil {
+			return v, false
+		}
+		v.nums[i] = n
+	}
+	v.len = len(ss)
+	return v, true
+}
+
+// compare compares the versions. Missing components are compared as 0.
+func (v actionVersion) compare(other actionVersion) int {
+	for i := range v.nums {
+		if c := cmp.Compare(v.nums[i], other.nums[i]); c != 0 {
+			return c
+		}
+	}
+	return 0
+}
+
+// behind returns true when the version is behind the latest version. Components not specified in
+// the version are not compared since the ref moves with the releases. For example, "v4" is not
+// behind "v4.2.1". When majorOnly is true, only major versions are compared.
+func (v actionVersion) behind(latest actionVersion, majorOnly bool) bool {
+	n := v.len
+	if majorOnly {
+		n = 1
+	}
+	for i := range n {
+		if c := cmp.Compare(v.nums[i], latest.nums[i]); c != 0 {
+			return c < 0
+		}
+	}
+	return false
+}
+
+// RuleOutdatedAction is a rule to check actions at "uses:" are not behind their latest versions
+// released on GitHub. This rule is opt-in. It is enabled only when "outdated-actions" is configured
+// in the configuration file and online checks are enabled.
+type RuleOutdatedAction struct {
+	RuleBase
+	remote    *RemoteRepository
+	majorOnly bool
+	allow     []string
+}
+
+// NewRuleOutdatedAction creates new RuleOutdatedAction instance. 'remote' is used to fetch the
+// latest versions of actions.
+func NewRuleOutdatedAction(remote *RemoteRepository, cfg *OutdatedActionsConfig) *RuleOutdatedAction {
+	allow := make([]string, 0, len(cfg.Allow))
+	for _, p := range cfg.Allow {
+		allow = append(allow, strings.ToLower(p))
+	}
+	return &RuleOutdatedAction{
+		RuleBase: RuleBase{
+			name: "outdated-action",
+			desc: "Checks for actions at \"uses:\" behind their latest versions. This rule is enabled by \"outdated-actions\" configuration and online checks",
+		},
+		remote:    remote,
+		majorOnly: cfg.MajorOnly,
+		allow:     allow,
+	}
+}
+
+func (rule *RuleOutdatedAction) allowed(name string) bool {
+	name = strings.ToLower(name)
+	for _, p := range rule.allow {
+		if ok, _ := path.Match(p, name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// VisitStep is callback when visiting Step node.
+func (rule *RuleOutdatedAction) VisitStep(n *Step) error {
+	e, ok := n.Exec.(*ExecAction)
+	if !ok || e.Uses == nil || e.Uses.ContainsExpression() {
+		return nil
+	}
+	spec := e.Uses.Value
+	if strings.HasPrefix(spec, "./") || strings.HasPrefix(spec, "docker://") {
+		return nil
+	}
+	name, ref, ok := strings.Cut(spec, "@")
+	if !ok {
+		return nil
+	}
+	owner, rest, ok := strings.Cut(name, "/")
+	if !ok || owner == "" {
+		return nil
+	}
+	repo, _, _ := strings.Cut(rest, "/")
+	if repo == "" || rule.allowed(name) {
+		return nil
+	}
+
+	cur, ok := parseActionVersion(ref)
+	if !ok {
+		rule.Debug("Ref of action %q is not a version. It is not compared with the latest version", spec)
+		return nil
+	}
+
+	tag, err := rule.remote.latestVersion(owner, repo)
+	if err != nil {
+		rule.remote.warnRepoOnce(owner, repo, err)
+		return nil
+	}
+	if tag == "" {
+		rule.Debug("No version was found in repository %s/%s", owner, repo)
+		return nil
+	}
+	latest, _ := parseActionVersion(tag)
+	if !cur.behind(latest, rule.majorOnly) {
+		return nil
+	}
+
+	what := "version"
+	if cur.nums[0] < latest.nums[0] {
+		what = "major version"
+	}
+	rule.Errorf(
+		e.Uses.Pos,
+		"action %q is outdated. newer %s %q is available in repository %q. note: this check was done with GitHub API",
+		spec,
+		what,
+		tag,
+		owner+"/"+repo,
+	)
+	return nil
+}
@@ -0,0 +1,141 @@
+package actionlint
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestRuleOutdatedActionParseVersion(t *testing.T) {
+	tests := []struct {
+		ref  string
+		want actionVersion
+		ok   bool
+	}{
+		{"v4", actionVersion{[3]int{4, 0, 0}, 1}, true},
+		{"v4.2", actionVersion{[3]int{4, 2, 0}, 2}, true},
+		{"4.2.1", actionVersion{[3]int{4, 2, 1}, 3}, true},
+		{"v10.0.12", actionVersion{[3]int{10, 0, 12}, 3}, true},
+		{"v1.2.3.4", actionVersion{}, false},
+		{"v5.0.0-beta.1", actionVersion{}, false},
+		{"main", actionVersion{}, false},
+		{"v", actionVersion{}, false},
+		{"v1..2", actionVersion{}, false},
+		{"v+1", actionVersion{}, false},
+		{"0aaccfd150d50ccaeb58ebd88d36e91967a5f35b", actionVersion{}, false},
+	}
+	for _, tc := range tests {
+		v, ok := parseActionVersion(tc.ref)
+		if ok != tc.ok {
+			t.Errorf("wanted %v for %q but got %v", tc.ok, tc.ref, ok)
+			continue
+		}
+		if ok && v != tc.want {
+			t.Errorf("wanted %v for %q but got %v", tc.want, tc.ref, v)
+		}
+	}
+}
+
+func TestRuleOutdatedActionBehind(t *testing.T) {
+	tests := []struct {
+		ref       string
+		latest    string
+		majorOnly bool
+		want      bool
+	}{
+		{"v3", "v4.2.2", false, true},
+		{"v4", "v4.2.2", false, false},
+		{"v4.1", "v4.2.2", false, true},
+		{"v4.2", "v4.2.2", false, false},
+		{"v4.2.1", "v4.2.2", false, true},
+		{"v4.2.2", "v4.2.2", false, false},
+		{"v5", "v4.2.2", false, false},
+		{"v4.1.0", "v4.2.2", true, false},
+		{"v3.9.9", "v4.0.0", true, true},
+	}
+	for _, tc := range tests {
+		v, _ := parseActionVersion(tc.ref)
+		l, _ := parseActionVersion(tc.latest)
+		if have := v.behind(l, tc.majorOnly); have != tc.want {
+			t.Errorf("wanted %v for %q and %q (major-only=%v) but got %v", tc.want, tc.ref, tc.latest, tc.majorOnly, have)
+		}
+	}
+}
+
+func TestRuleOutdatedActionCheckVersions(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		switch req.URL.Path {
+		case "/repos/actions/checkout/releases/latest":
+			fmt.Fprint(w, `{"tag_name":"v4.2.2"}`)
+		case "/repos/owner/tags-only/releases/latest":
+			w.WriteHeader(http.StatusNotFound)
+		case "/repos/owner/tags-only/tags":
+			fmt.Fprint(w, `[{"name":"v3.0.0-rc.1"},{"name":"v2.1.0"},{"name":"latest"},{"name":"v2.0.3"}]`)
+		case "/repos/owner/broken/releases/latest":
+			w.WriteHeader(http.StatusInternalServerError)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	tests := []struct {
+		what      string
+		uses      string
+		majorOnly bool
+		allow     []string
+		want      string
+	}{
+		{"major version behind", "actions/checkout@v3", false, nil, `action "actions/checkout@v3" is outdated. newer major version "v4.2.2" is available in repository "actions/checkout"`},
+		{"minor version behind", "actions/checkout@v4.1.0", false, nil, `action "actions/checkout@v4.1.0" is outdated. newer version "v4.2.2" is available in repository "actions/checkout"`},
+		{"minor version behind with major-only", "actions/checkout@v4.1.0", true, nil, ""},
+		{"major version ref", "actions/checkout@v4", false, nil, ""},
+		{"latest", "actions/checkout@v4.2.2", false, nil, ""},
+		{"action in sub directory", "actions/checkout/sub@v2", false, nil, `newer major version "v4.2.2" is available in repository "actions/checkout"`},
+		{"allowed", "actions/checkout@v3", false, []string{"Actions/*"}, ""},
+		{"not allowed", "actions/checkout@v3", false, []string{"actions/cache"}, `"actions/checkout@v3" is outdated`},
+		{"no release", "owner/tags-only@v1", false, nil, `newer major version "v2.1.0" is available in repository "owner/tags-only"`},
+		{"branch", "actions/checkout@main", false, nil, ""},
+		{"commit SHA", "actions/checkout@0aaccfd150d50ccaeb58ebd88d36e91967a5f35b", false, nil, ""},
+		{"expression", "actions/checkout@${{ inputs.ref }}", false, nil, ""},
+		{"local action", "./.github/actions/setup", false, nil, ""},
+		{"docker", "docker://alpine:3.1", false, nil, ""},
+		{"unknown repository", "owner/unknown@v1", false, nil, ""},
+		{"API error", "owner/broken@v1", false, nil, ""},
+	}
+
+	var warn strings.Builder
+	remote := NewRemoteRepository("owner", "repo", srv.URL, "", "", &warn, nil)
+	for _, tc := range tests {
+		t.Run(tc.what, func(t *testing.T) {
+			s := &Step{
+				Exec: &ExecAction{
+					Uses: &String{Value: tc.uses, Pos: &Pos{}},
+				},
+			}
+			r := NewRuleOutdatedAction(remote, &OutdatedActionsConfig{MajorOnly: tc.majorOnly, Allow: tc.allow})
+			if err := r.VisitStep(s); err != nil {
+				t.Fatal(err)
+			}
+			errs := r.Errs()
+			if tc.want == "" {
+				if len(errs) > 0 {
+					t.Fatalf("wanted no error but got %v", errs)
+				}
+				return
+			}
+			if len(errs) != 1 {
+				t.Fatalf("wanted one error but got %v", errs)
+			}
+			if msg := errs[0].Message; !strings.Contains(msg, tc.want) {
+				t.Fatalf("error message %q does not contain %q", msg, tc.want)
+			}
+		})
+	}
+
+	if !strings.Contains(warn.String(), `"owner/broken"`) {
+		t.Errorf("API error was not warned: %q", warn.String())
+	}
+}
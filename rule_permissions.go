@@ -1,6 +1,11 @@
 package actionlint
 
-import "slices"
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
 
 var allPermissionScopes = map[string][]string{
 	"actions":             {"read", "write", "none"},
@@ -21,10 +26,47 @@ var allPermissionScopes = map[string][]string{
 	"statuses":            {"read", "write", "none"},
 }
 
+// permissionLevels is the order of access levels of permission scopes.
+var permissionLevels = map[string]int{
+	"":      0,
+	"none":  1,
+	"read":  2,
+	"write": 3,
+}
+
+// popularActionPermissions is a mapping from popular actions to the permissions of GITHUB_TOKEN they
+// require. The keys are action names without ref like "owner/repo" or "owner/repo/path" in lower
+// case. Actions which don't use GITHUB_TOKEN are not listed.
+var popularActionPermissions = map[string]map[string]string{
+	"actions/attest":                         {"attestations": "write", "id-token": "write"},
+	"actions/attest-build-provenance":        {"attestations": "write", "id-token": "write"},
+	"actions/attest-sbom":                    {"attestations": "write", "id-token": "write"},
+	"actions/checkout":                       {"contents": "read"},
+	"actions/dependency-review-action":       {"contents": "read"},
+	"actions/deploy-pages":                   {"pages": "write", "id-token": "write"},
+	"actions/first-interaction":              {"issues": "write", "pull-requests": "write"},
+	"actions/labeler":                        {"contents": "read", "pull-requests": "write"},
+	"actions/stale":                          {"issues": "write", "pull-requests": "write"},
+	"amannn/action-semantic-pull-request":    {"pull-requests": "read"},
+	"github/codeql-action/analyze":           {"actions": "read", "contents": "read", "security-events": "write"},
+	"github/codeql-action/upload-sarif":      {"security-events": "write"},
+	"marocchino/sticky-pull-request-comment": {"pull-requests": "write"},
+	"ncipollo/release-action":                {"contents": "write"},
+	"peaceiris/actions-gh-pages":             {"contents": "write"},
+	"peter-evans/create-pull-request":        {"contents": "write", "pull-requests": "write"},
+	"softprops/action-gh-release":            {"contents": "write"},
+	"stefanzweifel/git-auto-commit-action":   {"contents": "write"},
+}
+
+var permissionsJobsKeyPattern = regexp.MustCompile(`^jobs[ \t]*:`)
+
 // RulePermissions is a rule checker to check permission configurations in a workflow.
 // https://docs.github.com/en/actions/reference/workflows-and-actions/workflow-syntax#defining-access-for-the-github_token-scopes
 type RulePermissions struct {
 	RuleBase
+	// lines is the lines of the workflow source. It is used to insert "permissions:" to fix missing
+	// permissions. When this value is nil, the fix is not suggested.
+	lines []string
 }
 
 // NewRulePermissions creates new RulePermissions instance.
@@ -37,15 +79,97 @@ func NewRulePermissions() *RulePermissions {
 	}
 }
 
-// VisitJobPre is callback when visiting Job node before visiting its children.
-func (rule *RulePermissions) VisitJobPre(n *Job) error {
-	rule.checkPermissions(n.Permissions)
+// VisitPermissions is callback when visiting Permissions node in workflow or job.
+func (rule *RulePermissions) VisitPermissions(n *Permissions) error {
+	rule.checkPermissions(n)
 	return nil
 }
 
 // VisitWorkflowPre is callback when visiting Workflow node before visiting its children.
 func (rule *RulePermissions) VisitWorkflowPre(n *Workflow) error {
-	rule.checkPermissions(n.Permissions)
+	if rule.config != nil && rule.config.RequirePermissions && n.Permissions == nil {
+		rule.checkMissingPermissions(n)
+	}
+	return nil
+}
+
+// checkMissingPermissions reports jobs which set "permissions:" at neither workflow level nor job
+// level. Minimal permissions are suggested from the actions used in the jobs.
+func (rule *RulePermissions) checkMissingPermissions(w *Workflow) {
+	jobs := []*Job{}
+	for _, j := range w.Jobs {
+		if j.Permissions == nil && j.Pos != nil {
+			jobs = append(jobs, j)
+		}
+	}
+	if len(jobs) == 0 {
+		return
+	}
+	slices.SortFunc(jobs, func(a, b *Job) int { return a.Pos.Line - b.Pos.Line })
+
+	perms := map[string]string{"contents": "read"}
+	ids := make([]string, 0, len(jobs))
+	for _, j := range jobs {
+		ids = append(ids, j.ID.Value)
+		for _, s := range j.Steps {
+			e, ok := s.Exec.(*ExecAction)
+			if !ok || e.Uses == nil {
+				continue
+			}
+			name, _, _ := strings.Cut(e.Uses.Value, "@")
+			for k, v := range popularActionPermissions[strings.ToLower(name)] {
+				if permissionLevels[v] > permissionLevels[perms[k]] {
+					perms[k] = v
+				}
+			}
+		}
+	}
+	scopes := make([]string, 0, len(perms))
+	for k := range perms {
+		scopes = append(scopes, k)
+	}
+	slices.Sort(scopes)
+	suggested := make([]string, 0, len(scopes))
+	for _, k := range scopes {
+		suggested = append(suggested, k+": "+perms[k])
+	}
+
+	what := "job"
+	if len(ids) > 1 {
+		what = "jobs"
+	}
+	rule.ErrorfWithFixes(
+		jobs[0].Pos,
+		rule.insertPermissions(jobs[0].Pos, suggested),
+		"\"permissions:\" is set at neither workflow level nor job level of %s %s. default permissions of GITHUB_TOKEN may be broader than necessary. set minimal permissions like {%s} at \"permissions:\"",
+		what,
+		quotes(ids),
+		strings.Join(suggested, ", "),
+	)
+}
+
+// insertPermissions returns edits to insert "permissions:" with the scopes before "jobs:" section.
+// 'job' is the position of the first job in "jobs:" section.
+func (rule *RulePermissions) insertPermissions(job *Pos, scopes []string) []*TextEdit {
+	for l := min(job.Line, len(rule.lines)); l >= 1; l-- {
+		if !permissionsJobsKeyPattern.MatchString(rule.lines[l-1]) {
+			continue
+		}
+		indent := job.Col - 1
+		if l == job.Line || indent < 1 {
+			indent = 2 // Flow style mapping like "jobs: {test: ...}"
+		}
+		var b strings.Builder
+		b.WriteString("permissions:\n")
+		for _, s := range scopes {
+			fmt.Fprintf(&b, "%s%s\n", strings.Repeat(" ", indent), s)
+		}
+		if l > 1 && strings.TrimSpace(rule.lines[l-2]) == "" {
+			b.WriteString("\n") // Separate sections with a blank line as the source does
+		}
+		p := &Pos{Line: l, Col: 1}
+		return []*TextEdit{{Start: p, End: p, NewText: b.String()}}
+	}
 	return nil
 }
 
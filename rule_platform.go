@@ -0,0 +1,77 @@
+package actionlint
+
+// RulePlatform is a rule to check workflows are compatible with the platform other than GitHub
+// Actions. Gitea Actions and Forgejo Actions reuse the workflow syntax but some webhook events and
+// features are not supported. This rule is enabled only when the platform is specified by
+// "-platform" flag or "platform" in the configuration file.
+// https://docs.gitea.com/usage/actions/comparison
+type RulePlatform struct {
+	RuleBase
+	platform Platform
+}
+
+// NewRulePlatform creates new RulePlatform instance for the given platform.
+func NewRulePlatform(p Platform) *RulePlatform {
+	return &RulePlatform{
+		RuleBase: RuleBase{
+			name: "platform",
+			desc: "Checks for features not supported by Gitea Actions or Forgejo Actions. This rule is enabled by \"-platform\" flag or \"platform\" configuration",
+		},
+		platform: p,
+	}
+}
+
+func (rule *RulePlatform) ignored(pos *Pos, what string) {
+	rule.Errorf(pos, "%s is not supported by %s and it is ignored", what, rule.platform.displayName())
+}
+
+// VisitWorkflowPre is callback when visiting Workflow node before visiting its children.
+func (rule *RulePlatform) VisitWorkflowPre(n *Workflow) error {
+	for _, e := range n.On {
+		var pos *Pos
+		switch e := e.(type) {
+		case *WebhookEvent:
+			pos = e.Hook.Pos
+		case *ImageVersionEvent:
+			pos = e.Pos
+		default:
+			continue
+		}
+		name := e.EventName()
+		if _, ok := giteaUnsupportedEvents[name]; ok {
+			rule.Errorf(pos, "%q event is not supported by %s. the workflow is never triggered by the event", name, rule.platform.displayName())
+		}
+	}
+
+	if n.Permissions != nil {
+		rule.ignored(n.Permissions.Pos, "\"permissions:\"")
+	}
+	return nil
+}
+
+// VisitJobPre is callback when visiting Job node before visiting its children.
+func (rule *RulePlatform) VisitJobPre(n *Job) error {
+	if n.RunsOn != nil && n.RunsOn.Group != nil {
+		rule.Errorf(n.RunsOn.Group.Pos, "runner group at \"runs-on:\" is not supported by %s. select runners by labels instead", rule.platform.displayName())
+	}
+	if n.Snapshot != nil {
+		pos := n.Pos
+		if n.Snapshot.ImageName != nil {
+			pos = n.Snapshot.ImageName.Pos
+		}
+		rule.Errorf(pos, "\"snapshot:\" for custom images is not supported by %s", rule.platform.displayName())
+	}
+	if n.Permissions != nil {
+		rule.ignored(n.Permissions.Pos, "\"permissions:\"")
+	}
+	if n.Environment != nil {
+		rule.ignored(n.Environment.Pos, "\"environment:\"")
+	}
+	if n.ContinueOnError != nil {
+		rule.ignored(n.ContinueOnError.Pos, "\"continue-on-error:\" at job level")
+	}
+	if n.TimeoutMinutes != nil {
+		rule.ignored(n.TimeoutMinutes.Pos, "\"timeout-minutes:\" at job level")
+	}
+	return nil
+}
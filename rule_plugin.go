@@ -0,0 +1,153 @@
+package actionlint
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// PluginProtocolVersion is a version of the protocol between actionlint and plugin commands. It is
+// sent to plugins as "version" field of the input so that plugins can reject unsupported inputs.
+const PluginProtocolVersion = 1
+
+// pluginInput is a JSON value written to stdin of a plugin command.
+type pluginInput struct {
+	Version  int       `json:"version"`
+	Path     string    `json:"path"`
+	Source   string    `json:"source"`
+	Workflow *Workflow `json:"workflow"`
+}
+
+// pluginFinding is an error reported by a plugin command. Line and column are 1-based. When they
+// are omitted, the finding is reported at the beginning of the file.
+type pluginFinding struct {
+	Message   string                           `json:"message"`
+	Line      int                              `json:"line"`
+	Column    int                              `json:"column"`
+	EndLine   int                              `json:"end_line"`
+	EndColumn int                              `json:"end_column"`
+	Code      string                           `json:"code"`
+	Severity  string                           `json:"severity"`
+	Related   []*RelatedLocationTemplateFields `json:"related"`
+	Fixes     []*TextEditTemplateFields        `json:"fixes"`
+}
+
+// pluginOutput is a JSON value which a plugin command must write to stdout.
+type pluginOutput struct {
+	Errors []*pluginFinding `json:"errors"`
+}
+
+func parsePluginOutput(b []byte) ([]*pluginFinding, error) {
+	var out pluginOutput
+	if err := json.Unmarshal(b, &out); err != nil {
+		return nil, fmt.Errorf("could not parse JSON output from plugin: %w", err)
+	}
+	for _, f := range out.Errors {
+		if f.Message == "" {
+			return nil, fmt.Errorf("plugin reported an error without \"message\" at line:%d,col:%d", f.Line, f.Column)
+		}
+		if _, ok := ParseSeverity(f.Severity); f.Severity != "" && !ok {
+			return nil, fmt.Errorf("plugin reported an error with unknown severity %q at line:%d,col:%d. it must be one of \"error\", \"warning\", or \"info\"", f.Severity, f.Line, f.Column)
+		}
+	}
+	return out.Errors, nil
+}
+
+// RulePlugin is a rule to check workflows by running an external plugin command declared at
+// "plugins" in the configuration file. The parsed workflow is passed to stdin of the command as JSON
+// and the command outputs errors in JSON to stdout. The name of the rule is the name of the plugin.
+type RulePlugin struct {
+	RuleBase
+	cmd     *externalCommand
+	path    string
+	content []byte
+	mu      sync.Mutex
+}
+
+func newRulePlugin(name string, cmd *externalCommand, path string, content []byte) *RulePlugin {
+	return &RulePlugin{
+		RuleBase: RuleBase{
+			name: name,
+			desc: fmt.Sprintf("Checks with plugin command %q declared in the configuration file", cmd.exe),
+		},
+		cmd:     cmd,
+		path:    path,
+		content: content,
+	}
+}
+
+// NewRulePlugin creates new RulePlugin instance to check the workflow file at the path with the
+// content. Parameter name is the name of the plugin used as the rule name. Parameter executable can
+// be command name or relative/absolute file path. When the given executable is not found in system,
+// it returns an error.
+func NewRulePlugin(name, executable string, proc *concurrentProcess, path string, content []byte) (*RulePlugin, error) {
+	cmd, err := proc.newCommandRunner(executable, false)
+	if err != nil {
+		return nil, err
+	}
+	return newRulePlugin(name, cmd, path, content), nil
+}
+
+// VisitWorkflowPre is callback when visiting Workflow node before visiting its children.
+func (rule *RulePlugin) VisitWorkflowPre(n *Workflow) error {
+	in, err := json.Marshal(&pluginInput{
+		Version:  PluginProtocolVersion,
+		Path:     rule.path,
+		Source:   string(rule.content),
+		Workflow: n,
+	})
+	if err != nil {
+		return fmt.Errorf("could not encode workflow %s into JSON for plugin %q: %w", rule.path, rule.name, err)
+	}
+
+	rule.Debug("Running plugin command %s for %s", rule.cmd.exe, rule.path)
+
+	rule.cmd.run(nil, string(in), func(out []byte, err error) error {
+		if err != nil {
+			rule.Debug("Plugin command %s failed: %v", rule.cmd.exe, err)
+			return fmt.Errorf("plugin %q did not run successfully while checking workflow %s: %w", rule.name, rule.path, err)
+		}
+
+		fs, err := parsePluginOutput(out)
+		if err != nil {
+			return fmt.Errorf("plugin %q output invalid result while checking workflow %s: %w", rule.name, rule.path, err)
+		}
+
+		// Synchronize rule.Errorf calls
+		rule.mu.Lock()
+		defer rule.mu.Unlock()
+		for _, f := range fs {
+			pos := &Pos{Line: max(f.Line, 1), Col: max(f.Column, 1)}
+			e := errorAt(pos, rule.name, strings.TrimSuffix(f.Message, "."))
+			e.Code = f.Code
+			e.Severity, _ = ParseSeverity(f.Severity)
+			e.EndLine = f.EndLine
+			e.EndColumn = f.EndColumn
+			for _, r := range f.Related {
+				e.Related = append(e.Related, &RelatedLocation{
+					Filepath: r.Filepath,
+					Line:     r.Line,
+					Column:   r.Column,
+					Message:  r.Message,
+				})
+			}
+			for _, x := range f.Fixes {
+				e.Fixes = append(e.Fixes, &TextEdit{
+					Start:   &Pos{Line: x.Line, Col: x.Column},
+					End:     &Pos{Line: x.EndLine, Col: x.EndColumn},
+					NewText: x.NewText,
+				})
+			}
+			rule.Report(e)
+		}
+		return nil
+	})
+
+	return nil
+}
+
+// VisitWorkflowPost is callback when visiting Workflow node after visiting its children.
+func (rule *RulePlugin) VisitWorkflowPost(n *Workflow) error {
+	return rule.cmd.wait() // Wait until the process running for this rule finishes
+}
@@ -0,0 +1,289 @@
+package actionlint
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestRulePluginParseOutput(t *testing.T) {
+	fs, err := parsePluginOutput([]byte(`{"errors":[{"message":"foo","line":3,"column":5,"fixes":[{"line":3,"column":5,"end_line":3,"end_column":8,"new_text":"bar"}]},{"message":"no position"}]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []*pluginFinding{
+		{
+			Message: "foo",
+			Line:    3,
+			Column:  5,
+			Fixes:   []*TextEditTemplateFields{{Line: 3, Column: 5, EndLine: 3, EndColumn: 8, NewText: "bar"}},
+		},
+		{Message: "no position"},
+	}
+	if diff := cmp.Diff(want, fs); diff != "" {
+		t.Fatal(diff)
+	}
+
+	fs, err = parsePluginOutput([]byte(`{}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(fs) != 0 {
+		t.Fatalf("no error should be parsed: %v", fs)
+	}
+}
+
+func TestRulePluginParseOutputError(t *testing.T) {
+	for _, tc := range []struct {
+		what string
+		out  string
+		want string
+	}{
+		{"not JSON", "hello", "could not parse JSON output from plugin"},
+		{"no message", `{"errors":[{"line":1,"column":2}]}`, `plugin reported an error without "message" at line:1,col:2`},
+		{"unknown severity", `{"errors":[{"message":"foo","line":1,"column":2,"severity":"fatal"}]}`, `plugin reported an error with unknown severity "fatal" at line:1,col:2`},
+	} {
+		t.Run(tc.what, func(t *testing.T) {
+			_, err := parsePluginOutput([]byte(tc.out))
+			if err == nil {
+				t.Fatal("error did not occur")
+			}
+			if !strings.Contains(err.Error(), tc.want) {
+				t.Fatalf("error %q does not contain %q", err.Error(), tc.want)
+			}
+		})
+	}
+}
+
+func TestRulePluginRunCommand(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake plugin command is a shell script")
+	}
+
+	dir := t.TempDir()
+	input := filepath.Join(dir, "input.json")
+
+	// Fake plugin command saves its input and reports "ubuntu-latest" label
+	exe := filepath.Join(dir, "plugin")
+	script := `#!/bin/sh
+cat > "` + input + `"
+if grep -q ubuntu-latest "` + input + `"; then
+  echo '{"errors":[{"message":"use pinned runner image.","line":4,"column":14,"end_line":4,"end_column":27,"code":"R001","severity":"warning","related":[{"line":1,"column":1,"message":"workflow starts here"}],"fixes":[{"line":4,"column":14,"end_line":4,"end_column":27,"new_text":"ubuntu-24.04"}]}]}'
+else
+  echo '{"errors":[]}'
+fi
+`
+	if err := os.WriteFile(exe, []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+	cfg := filepath.Join(dir, "actionlint.yaml")
+	if err := os.WriteFile(cfg, []byte("plugins:\n  - name: pinned-runner\n    command: "+exe+"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	l, err := NewLinter(io.Discard, &LinterOptions{ConfigFile: cfg, Shellcheck: "", Pyflakes: "", TrustConfig: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	src := "on: push\njobs:\n  test:\n    runs-on: ubuntu-latest\n    steps:\n      - run: echo\n"
+	errs, err := l.Lint("test.yaml", []byte(src), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(errs) != 1 {
+		t.Fatalf("wanted 1 error but got %d: %v", len(errs), errs)
+	}
+	e := errs[0]
+	if e.Kind != "pinned-runner" {
+		t.Errorf("wanted rule name %q but got %q", "pinned-runner", e.Kind)
+	}
+	if e.Line != 4 || e.Column != 14 {
+		t.Errorf("wanted position 4:14 but got %d:%d", e.Line, e.Column)
+	}
+	if want := "use pinned runner image"; e.Message != want {
+		t.Errorf("wanted message %q but got %q", want, e.Message)
+	}
+	if e.Code != "R001" || e.Severity != SeverityWarning || e.EndLine != 4 || e.EndColumn != 27 {
+		t.Errorf("unexpected code %q, severity %v, or end position %d:%d", e.Code, e.Severity, e.EndLine, e.EndColumn)
+	}
+	wantRelated := []*RelatedLocation{{Line: 1, Column: 1, Message: "workflow starts here"}}
+	if diff := cmp.Diff(wantRelated, e.Related); diff != "" {
+		t.Error(diff)
+	}
+	wantFix := []*TextEdit{{Start: &Pos{Line: 4, Col: 14}, End: &Pos{Line: 4, Col: 27}, NewText: "ubuntu-24.04"}}
+	if diff := cmp.Diff(wantFix, e.Fixes); diff != "" {
+		t.Error(diff)
+	}
+
+	b, err := os.ReadFile(input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var in struct {
+		Version  int    `json:"version"`
+		Path     string `json:"path"`
+		Source   string `json:"source"`
+		Workflow struct {
+			Jobs map[string]struct {
+				ID struct {
+					Value string
+				}
+			}
+		} `json:"workflow"`
+	}
+	if err := json.Unmarshal(b, &in); err != nil {
+		t.Fatal(err)
+	}
+	if in.Version != PluginProtocolVersion || in.Path != "test.yaml" || in.Source != src {
+		t.Fatalf("unexpected input to plugin: %s", b)
+	}
+	if in.Workflow.Jobs["test"].ID.Value != "test" {
+		t.Fatalf("workflow AST was not passed to plugin: %s", b)
+	}
+}
+
+func TestRulePluginNotLoadedFromUntrustedConfig(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake plugin command is a shell script")
+	}
+
+	dir := t.TempDir()
+	marker := filepath.Join(dir, "marker")
+
+	// Fake plugin command creates a marker file when it is run
+	exe := filepath.Join(dir, "plugin")
+	script := "#!/bin/sh\ntouch \"" + marker + "\"\necho '{\"errors\":[]}'\n"
+	if err := os.WriteFile(exe, []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+	cfg := filepath.Join(dir, "actionlint.yaml")
+	if err := os.WriteFile(cfg, []byte("plugins:\n  - name: untrusted\n    command: "+exe+"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	var log strings.Builder
+	l, err := NewLinter(io.Discard, &LinterOptions{ConfigFile: cfg, Shellcheck: "", Pyflakes: "", LogWriter: &log})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	src := "on: push\njobs:\n  test:\n    runs-on: ubuntu-latest\n    steps:\n      - run: echo\n"
+	for range 2 {
+		errs, err := l.Lint("test.yaml", []byte(src), nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(errs) != 0 {
+			t.Fatalf("wanted no error but got %v", errs)
+		}
+	}
+	if _, err := os.Stat(marker); err == nil {
+		t.Fatal("plugin command was run though the config file is not trusted")
+	}
+	want := `warning: "plugins" in config file are ignored since the config file is not trusted`
+	if !strings.Contains(log.String(), want) {
+		t.Fatalf("log %q does not contain %q", log.String(), want)
+	}
+	if n := strings.Count(log.String(), "warning:"); n != 1 {
+		t.Fatalf("warning should be output once but output %d times: %q", n, log.String())
+	}
+}
+
+func TestRulePluginCommandFailed(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake plugin command is a shell script")
+	}
+
+	dir := t.TempDir()
+	exe := filepath.Join(dir, "plugin")
+	if err := os.WriteFile(exe, []byte("#!/bin/sh\necho oops\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	cfg := filepath.Join(dir, "actionlint.yaml")
+	if err := os.WriteFile(cfg, []byte("plugins:\n  - name: broken\n    command: "+exe+"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	l, err := NewLinter(io.Discard, &LinterOptions{ConfigFile: cfg, Shellcheck: "", Pyflakes: "", TrustConfig: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = l.Lint("test.yaml", []byte("on: push\njobs:\n  test:\n    runs-on: ubuntu-latest\n    steps:\n      - run: echo\n"), nil)
+	if err == nil {
+		t.Fatal("error did not occur")
+	}
+	if want := `plugin "broken" output invalid result while checking workflow test.yaml`; !strings.Contains(err.Error(), want) {
+		t.Fatalf("error %q does not contain %q", err.Error(), want)
+	}
+}
+
+func TestRulePluginPersistentCommand(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake plugin command is a shell script")
+	}
+
+	dir := t.TempDir()
+	started := filepath.Join(dir, "started")
+
+	// Fake plugin command records its start and reports "ubuntu-latest" label for each input line
+	exe := filepath.Join(dir, "plugin")
+	script := `#!/bin/sh
+echo started >> "` + started + `"
+while IFS= read -r line; do
+  case "$line" in
+    *ubuntu-latest*) echo '{"errors":[{"message":"use pinned runner image","line":4,"column":14}]}' ;;
+    *) echo '{"errors":[]}' ;;
+  esac
+done
+`
+	if err := os.WriteFile(exe, []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+	cfg := filepath.Join(dir, "actionlint.yaml")
+	if err := os.WriteFile(cfg, []byte("plugins:\n  - name: pinned-runner\n    command: "+exe+"\n    persistent: true\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	files := []string{}
+	for i, label := range []string{"ubuntu-latest", "ubuntu-24.04", "ubuntu-latest"} {
+		f := filepath.Join(dir, fmt.Sprintf("test%d.yaml", i))
+		src := "on: push\njobs:\n  test:\n    runs-on: " + label + "\n    steps:\n      - run: echo\n"
+		if err := os.WriteFile(f, []byte(src), 0644); err != nil {
+			t.Fatal(err)
+		}
+		files = append(files, f)
+	}
+
+	l, err := NewLinter(io.Discard, &LinterOptions{ConfigFile: cfg, Shellcheck: "", Pyflakes: "", TrustConfig: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	errs, err := l.LintFiles(files, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(errs) != 2 {
+		t.Fatalf("wanted 2 errors but got %d: %v", len(errs), errs)
+	}
+	for _, e := range errs {
+		if e.Kind != "pinned-runner" || e.Line != 4 || e.Column != 14 {
+			t.Errorf("unexpected error: %v", e)
+		}
+	}
+
+	b, err := os.ReadFile(started)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := strings.Count(string(b), "started"); n != 1 {
+		t.Fatalf("persistent plugin command should be started once but started %d times", n)
+	}
+}
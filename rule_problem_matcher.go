@@ -0,0 +1,265 @@
+package actionlint
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"regexp/syntax"
+	"slices"
+	"strings"
+)
+
+// `::add-matcher::{path}` workflow command in scripts. The path may contain ${{ }} placeholders.
+var problemMatcherCommandPattern = regexp.MustCompile("::add-matcher::((?:\\$\\{\\{[^}]*\\}\\}|[^\\s\"'`])+)")
+
+// Prefixes of the paths of problem matcher files which refer the workspace or the directory of the
+// composite action.
+var (
+	problemMatcherWorkspacePattern  = regexp.MustCompile(`^(?:\$\{\{\s*github\.workspace\s*\}\}|\$GITHUB_WORKSPACE|\$\{GITHUB_WORKSPACE\})/`)
+	problemMatcherActionPathPattern = regexp.MustCompile(`^(?:\$\{\{\s*github\.action_path\s*\}\}|\$GITHUB_ACTION_PATH|\$\{GITHUB_ACTION_PATH\})/`)
+)
+
+// problemMatcherSyntaxErrors is the error codes of the regular expressions which are also syntax
+// errors in .NET regular expressions used by the runner. Other errors such as lookahead assertions
+// are not reported since they are available in .NET.
+var problemMatcherSyntaxErrors = []syntax.ErrorCode{
+	syntax.ErrMissingParen,
+	syntax.ErrMissingBracket,
+	syntax.ErrUnexpectedParen,
+	syntax.ErrMissingRepeatArgument,
+	syntax.ErrInvalidCharRange,
+	syntax.ErrTrailingBackslash,
+}
+
+type problemMatcherPattern struct {
+	Regexp   *string `json:"regexp"`
+	File     *int    `json:"file"`
+	FromPath *int    `json:"fromPath"`
+	Line     *int    `json:"line"`
+	Column   *int    `json:"column"`
+	Severity *int    `json:"severity"`
+	Code     *int    `json:"code"`
+	Message  *int    `json:"message"`
+	Loop     bool    `json:"loop"`
+}
+
+type problemMatcherGroup struct {
+	name string
+	idx  int
+}
+
+// groups returns the properties which refer capture groups of the regular expression in the pattern.
+func (p *problemMatcherPattern) groups() []problemMatcherGroup {
+	ret := []problemMatcherGroup{}
+	for _, g := range []struct {
+		name string
+		idx  *int
+	}{
+		{"file", p.File},
+		{"fromPath", p.FromPath},
+		{"line", p.Line},
+		{"column", p.Column},
+		{"severity", p.Severity},
+		{"code", p.Code},
+		{"message", p.Message},
+	} {
+		if g.idx != nil {
+			ret = append(ret, problemMatcherGroup{g.name, *g.idx})
+		}
+	}
+	return ret
+}
+
+type problemMatcher struct {
+	Owner    string                   `json:"owner"`
+	Severity string                   `json:"severity"`
+	Pattern  []*problemMatcherPattern `json:"pattern"`
+}
+
+// problemMatcherFile is the content of problem matcher file. The schema is defined in the runner.
+// https://github.com/actions/toolkit/blob/main/docs/problem-matchers.md
+type problemMatcherFile struct {
+	ProblemMatcher []*problemMatcher `json:"problemMatcher"`
+}
+
+// RuleProblemMatcher is a rule to check problem matcher files added by `::add-matcher::` workflow
+// command in "run:" scripts. Broken problem matchers are silently ignored by the runner.
+// https://github.com/actions/toolkit/blob/main/docs/problem-matchers.md
+type RuleProblemMatcher struct {
+	RuleBase
+	proj *Project
+	// actionDir is the directory of the composite action whose steps are checked. It is empty when
+	// checking a workflow.
+	actionDir string
+	// owners is the mapping from the owners of problem matchers added in the current job to the
+	// files which define them. The first element of each value is the path in the command and the
+	// second one is the resolved file path.
+	owners map[string][2]string
+}
+
+// NewRuleProblemMatcher creates a new RuleProblemMatcher instance. Relative paths of problem
+// matcher files are resolved from the root directory of the project.
+func NewRuleProblemMatcher(proj *Project) *RuleProblemMatcher {
+	return &RuleProblemMatcher{
+		RuleBase: RuleBase{
+			name: "problem-matcher",
+			desc: "Checks for problem matcher files added by \"::add-matcher::\" command at \"run:\"",
+		},
+		proj:   proj,
+		owners: map[string][2]string{},
+	}
+}
+
+// VisitJobPre is callback when visiting Job node before visiting its children.
+func (rule *RuleProblemMatcher) VisitJobPre(n *Job) error {
+	clear(rule.owners)
+	return nil
+}
+
+// VisitStep is callback when visiting Step node.
+func (rule *RuleProblemMatcher) VisitStep(n *Step) error {
+	e, ok := n.Exec.(*ExecRun)
+	if !ok || e.Run == nil {
+		return nil
+	}
+	for _, m := range problemMatcherCommandPattern.FindAllStringSubmatch(e.Run.Value, -1) {
+		if p, ok := rule.resolvePath(m[1], e.WorkingDirectory); ok {
+			rule.checkFile(e.Run.Pos, m[1], p)
+		}
+	}
+	return nil
+}
+
+// resolvePath resolves the path of the problem matcher file in the command. It returns false when
+// the path cannot be resolved statically.
+func (rule *RuleProblemMatcher) resolvePath(path string, wd *String) (string, bool) {
+	root := rule.proj.RootDir()
+	if loc := problemMatcherWorkspacePattern.FindStringIndex(path); loc != nil {
+		path = path[loc[1]:]
+	} else if loc := problemMatcherActionPathPattern.FindStringIndex(path); loc != nil {
+		if rule.actionDir == "" {
+			return "", false
+		}
+		root, path = rule.actionDir, path[loc[1]:]
+	} else if wd != nil {
+		if wd.ContainsExpression() || strings.Contains(wd.Value, "$") || filepath.IsAbs(wd.Value) {
+			return "", false
+		}
+		root = filepath.Join(root, filepath.FromSlash(wd.Value))
+	}
+	if strings.ContainsAny(path, "$%") || strings.HasPrefix(path, "~") || filepath.IsAbs(path) || strings.HasPrefix(path, "/") {
+		return "", false
+	}
+	return filepath.Join(root, filepath.FromSlash(path)), true
+}
+
+func (rule *RuleProblemMatcher) checkFile(pos *Pos, path, file string) {
+	b, err := rule.proj.readFile(file)
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			rule.Errorf(pos, "could not read problem matcher file %q: %v", path, err)
+		} else {
+			// The file may be generated by previous steps
+			rule.Debug("Problem matcher file %q does not exist at %q", path, file)
+		}
+		return
+	}
+
+	var f problemMatcherFile
+	if err := json.Unmarshal(b, &f); err != nil {
+		rule.Errorf(pos, "could not parse problem matcher file %q added by \"::add-matcher::\" command: %v", path, err)
+		return
+	}
+	for _, msg := range rule.validate(path, file, &f) {
+		rule.Errorf(pos, "problem matcher file %q added by \"::add-matcher::\" command is invalid: %s", path, msg)
+	}
+}
+
+// validate validates the problem matchers in the file and returns the error messages.
+func (rule *RuleProblemMatcher) validate(path, file string, f *problemMatcherFile) []string {
+	errs := []string{}
+	if len(f.ProblemMatcher) == 0 {
+		return append(errs, `no problem matcher is defined in "problemMatcher" array`)
+	}
+
+	owners := map[string]struct{}{}
+	for i, m := range f.ProblemMatcher {
+		at := fmt.Sprintf("problemMatcher[%d]", i)
+		if m == nil {
+			errs = append(errs, fmt.Sprintf("%s must be object but got null", at))
+			continue
+		}
+
+		if m.Owner == "" {
+			errs = append(errs, fmt.Sprintf(`"owner" is required at %s`, at))
+		} else {
+			o := strings.ToLower(m.Owner)
+			if _, ok := owners[o]; ok {
+				errs = append(errs, fmt.Sprintf("owner %q at %s is duplicated in the file. problem matchers with the same owner overwrite each other", m.Owner, at))
+			} else if prev, ok := rule.owners[o]; ok && prev[1] != file {
+				errs = append(errs, fmt.Sprintf("owner %q at %s was already added by problem matcher file %q in the same job. problem matchers with the same owner overwrite each other", m.Owner, at, prev[0]))
+			}
+			owners[o] = struct{}{}
+			rule.owners[o] = [2]string{path, file}
+		}
+
+		if m.Severity != "" {
+			if s := strings.ToLower(m.Severity); s != "error" && s != "warning" {
+				errs = append(errs, fmt.Sprintf(`"severity" at %s must be "error" or "warning" but got %q`, at, m.Severity))
+			}
+		}
+
+		if len(m.Pattern) == 0 {
+			errs = append(errs, fmt.Sprintf(`"pattern" at %s must contain at least one pattern`, at))
+			continue
+		}
+
+		set := map[string]int{}
+		for j, p := range m.Pattern {
+			at := fmt.Sprintf("%s.pattern[%d]", at, j)
+			if p == nil {
+				errs = append(errs, fmt.Sprintf("%s must be object but got null", at))
+				continue
+			}
+
+			numGroups := -1
+			if p.Regexp == nil || *p.Regexp == "" {
+				errs = append(errs, fmt.Sprintf(`"regexp" is required at %s`, at))
+			} else if r, err := regexp.Compile(*p.Regexp); err == nil {
+				numGroups = r.NumSubexp()
+			} else if serr := (*syntax.Error)(nil); errors.As(err, &serr) && slices.Contains(problemMatcherSyntaxErrors, serr.Code) {
+				errs = append(errs, fmt.Sprintf(`regular expression %q at %s is invalid: %s`, *p.Regexp, at, serr.Code))
+			}
+
+			for _, g := range p.groups() {
+				if prev, ok := set[g.name]; ok {
+					errs = append(errs, fmt.Sprintf(`%q at %s is already set at pattern[%d]`, g.name, at, prev))
+				} else {
+					set[g.name] = j
+				}
+				if numGroups >= 0 && (g.idx < 0 || g.idx > numGroups) {
+					errs = append(errs, fmt.Sprintf(`%q at %s refers to capture group %d but regular expression %q has %d capture groups`, g.name, at, g.idx, *p.Regexp, numGroups))
+				}
+			}
+
+			if p.Loop {
+				switch {
+				case len(m.Pattern) == 1:
+					errs = append(errs, fmt.Sprintf(`"loop" at %s cannot be set in a single pattern matcher`, at))
+				case j != len(m.Pattern)-1:
+					errs = append(errs, fmt.Sprintf(`"loop" at %s can be set only in the last pattern`, at))
+				case p.Message == nil:
+					errs = append(errs, fmt.Sprintf(`"message" must be set at %s since it sets "loop"`, at))
+				}
+			}
+		}
+		if _, ok := set["message"]; !ok {
+			errs = append(errs, fmt.Sprintf(`"message" must be set in some pattern at %s`, at))
+		}
+	}
+
+	return errs
+}
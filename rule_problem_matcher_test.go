@@ -0,0 +1,70 @@
+package actionlint
+
+import (
+	"io"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRuleProblemMatcherCompositeAction(t *testing.T) {
+	root := t.TempDir()
+	testWriteFiles(t, root, map[string]string{
+		".git/HEAD":               "ref: refs/heads/main\n",
+		".github/workflows/.keep": "",
+		"action/action.yml": `name: Test
+description: Test
+runs:
+  using: composite
+  steps:
+    - run: echo "::add-matcher::${{ github.action_path }}/matcher.json"
+      shell: bash
+    - run: echo "::add-matcher::$GITHUB_ACTION_PATH/matcher.json"
+      shell: bash
+`,
+		"action/matcher.json": `{"problemMatcher": [{"owner": "foo", "pattern": [{"regexp": "^(.+)$", "file": 1}, {"regexp": "^(.+)$", "code": 1, "loop": true}]}]}`,
+	})
+
+	l, err := NewLinter(io.Discard, &LinterOptions{Shellcheck: "", Pyflakes: ""})
+	if err != nil {
+		t.Fatal(err)
+	}
+	errs, err := l.LintFiles([]string{filepath.Join(root, "action", "action.yml")}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{
+		`6:12: problem matcher file "${{ github.action_path }}/matcher.json" added by "::add-matcher::" command is invalid: "message" must be set at problemMatcher[0].pattern[1] since it sets "loop"`,
+		`6:12: problem matcher file "${{ github.action_path }}/matcher.json" added by "::add-matcher::" command is invalid: "message" must be set in some pattern at problemMatcher[0]`,
+		`8:12: problem matcher file "$GITHUB_ACTION_PATH/matcher.json" added by "::add-matcher::" command is invalid: "message" must be set at problemMatcher[0].pattern[1] since it sets "loop"`,
+		`8:12: problem matcher file "$GITHUB_ACTION_PATH/matcher.json" added by "::add-matcher::" command is invalid: "message" must be set in some pattern at problemMatcher[0]`,
+	}
+	if len(errs) != len(want) {
+		t.Fatalf("wanted %d errors but got %d: %v", len(want), len(errs), errs)
+	}
+	for i, err := range errs {
+		if !strings.Contains(err.Error(), want[i]) {
+			t.Errorf("error #%d %q does not contain %q", i, err.Error(), want[i])
+		}
+	}
+}
+
+func TestRuleProblemMatcherUnresolvedPaths(t *testing.T) {
+	r := NewRuleProblemMatcher(&Project{root: "root"})
+	for _, p := range []string{
+		"$MATCHER",
+		"${{ runner.temp }}/matcher.json",
+		"/path/to/matcher.json",
+		"~/matcher.json",
+		"%GITHUB_WORKSPACE%/matcher.json",
+		"${{ github.action_path }}/matcher.json", // Only available in composite actions
+	} {
+		if f, ok := r.resolvePath(p, nil); ok {
+			t.Errorf("path %q should not be resolved but got %q", p, f)
+		}
+	}
+	if _, ok := r.resolvePath("matcher.json", &String{Value: "${{ inputs.dir }}"}); ok {
+		t.Error("path should not be resolved when working directory contains an expression")
+	}
+}
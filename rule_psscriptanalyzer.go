@@ -0,0 +1,174 @@
+package actionlint
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// psScriptAnalyzerScript is a PowerShell script to run PSScriptAnalyzer for the script given via
+// stdin. The results are output as JSON array. Severity is converted to string since enum values are
+// output as integers by ConvertTo-Json. PSAvoidUsingWriteHost rule is excluded since Write-Host is
+// commonly used to output logs in workflow steps.
+const psScriptAnalyzerScript = `$ErrorActionPreference = 'Stop'
+Import-Module PSScriptAnalyzer
+$src = [Console]::In.ReadToEnd()
+$res = @(Invoke-ScriptAnalyzer -ScriptDefinition $src -ExcludeRule PSAvoidUsingWriteHost | ForEach-Object {
+  [pscustomobject]@{ RuleName = $_.RuleName; Severity = [string]$_.Severity; Line = $_.Line; Column = $_.Column; Message = $_.Message }
+})
+ConvertTo-Json -InputObject $res -Compress`
+
+type psScriptAnalyzerError struct {
+	RuleName string `json:"RuleName"`
+	Severity string `json:"Severity"`
+	Line     int    `json:"Line"`
+	Column   int    `json:"Column"`
+	Message  string `json:"Message"`
+}
+
+func (err *psScriptAnalyzerError) severity() Severity {
+	switch err.Severity {
+	case "Information":
+		return SeverityInfo
+	case "Warning":
+		return SeverityWarning
+	default:
+		return SeverityError // "Error" and "ParseError"
+	}
+}
+
+// RulePSScriptAnalyzer is a rule to check PowerShell scripts at 'run:' using PSScriptAnalyzer.
+// https://github.com/PowerShell/PSScriptAnalyzer
+type RulePSScriptAnalyzer struct {
+	RuleBase
+	cmd           *externalCommand
+	workflowShell string
+	jobShell      string
+	runnerShell   string
+	mu            sync.Mutex
+}
+
+func newRulePSScriptAnalyzer(cmd *externalCommand) *RulePSScriptAnalyzer {
+	return &RulePSScriptAnalyzer{
+		RuleBase: RuleBase{
+			name: "psscriptanalyzer",
+			desc: "Checks for PowerShell script sources in \"run:\" using PSScriptAnalyzer",
+		},
+		cmd: cmd,
+	}
+}
+
+// NewRulePSScriptAnalyzer creates new RulePSScriptAnalyzer instance. The executable argument is
+// PowerShell to run PSScriptAnalyzer module. It can be command name like "pwsh" or "powershell", or
+// relative/absolute file path. When the given executable is not found in system, it returns an
+// error as 2nd return value.
+func NewRulePSScriptAnalyzer(executable string, proc *concurrentProcess) (*RulePSScriptAnalyzer, error) {
+	cmd, err := proc.newCommandRunner(executable, false)
+	if err != nil {
+		return nil, err
+	}
+	return newRulePSScriptAnalyzer(cmd), nil
+}
+
+// VisitStep is callback when visiting Step node.
+func (rule *RulePSScriptAnalyzer) VisitStep(n *Step) error {
+	run, ok := n.Exec.(*ExecRun)
+	if !ok || run.Run == nil {
+		return nil
+	}
+
+	shell := rule.runnerShell
+	if run.Shell != nil {
+		shell = run.Shell.Value
+	} else if rule.jobShell != "" {
+		shell = rule.jobShell
+	} else if rule.workflowShell != "" {
+		shell = rule.workflowShell
+	}
+	if isPowerShell(shell) {
+		rule.runPSScriptAnalyzer(run.Run.Value, run.RunPos)
+	}
+	return nil
+}
+
+// VisitJobPre is callback when visiting Job node before visiting its children.
+func (rule *RulePSScriptAnalyzer) VisitJobPre(n *Job) error {
+	if n.Defaults != nil && n.Defaults.Run != nil && n.Defaults.Run.Shell != nil {
+		rule.jobShell = n.Defaults.Run.Shell.Value
+	}
+
+	if n.RunsOn != nil {
+		for _, label := range n.RunsOn.Labels {
+			// Default shell on Windows is PowerShell.
+			if os, ok := runnerOSOfLabel(label.Value); ok && os == RunnerOSWindows {
+				rule.runnerShell = "pwsh"
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// VisitJobPost is callback when visiting Job node after visiting its children.
+func (rule *RulePSScriptAnalyzer) VisitJobPost(n *Job) error {
+	rule.jobShell = ""
+	rule.runnerShell = ""
+	return nil
+}
+
+// VisitWorkflowPre is callback when visiting Workflow node before visiting its children.
+func (rule *RulePSScriptAnalyzer) VisitWorkflowPre(n *Workflow) error {
+	if n.Defaults != nil && n.Defaults.Run != nil && n.Defaults.Run.Shell != nil {
+		rule.workflowShell = n.Defaults.Run.Shell.Value
+	}
+	return nil
+}
+
+// VisitWorkflowPost is callback when visiting Workflow node after visiting its children.
+func (rule *RulePSScriptAnalyzer) VisitWorkflowPost(n *Workflow) error {
+	rule.workflowShell = ""
+	return rule.cmd.wait() // Wait until all processes running for this rule
+}
+
+// isPowerShell returns whether the shell at "shell:" is PowerShell.
+func isPowerShell(shell string) bool {
+	for _, s := range []string{"pwsh", "powershell"} {
+		if shell == s || strings.HasPrefix(shell, s+" ") {
+			return true
+		}
+	}
+	return false
+}
+
+func (rule *RulePSScriptAnalyzer) runPSScriptAnalyzer(src string, pos *Pos) {
+	src = sanitizeExpressionsInScript(src) // Defined at rule_shellcheck.go
+	rule.Debug("%s: Running PSScriptAnalyzer with %s for PowerShell script:\n%s", pos, rule.cmd.exe, src)
+
+	args := []string{"-NoProfile", "-NonInteractive", "-Command", psScriptAnalyzerScript}
+	rule.cmd.run(args, src, func(stdout []byte, err error) error {
+		if err != nil {
+			rule.Debug("Command %s failed: %v", rule.cmd.exe, err)
+			return fmt.Errorf("PSScriptAnalyzer with `%s` did not run successfully while checking script at %s: %w", rule.cmd.exe, pos, err)
+		}
+
+		errs := []psScriptAnalyzerError{}
+		if err := json.Unmarshal(stdout, &errs); err != nil {
+			return fmt.Errorf("could not parse JSON output from PSScriptAnalyzer: %w: stdout=%q", err, stdout)
+		}
+
+		// Synchronize rule.Report calls
+		rule.mu.Lock()
+		defer rule.mu.Unlock()
+		for _, err := range errs {
+			msg := strings.TrimSuffix(strings.TrimSpace(err.Message), ".") // Trim period aligning style of error message
+			e := errorfAt(pos, rule.name, "PSScriptAnalyzer reported issue in this script: %s:%s:%d:%d: %s", err.RuleName, strings.ToLower(err.Severity), err.Line, err.Column, msg)
+			e.Code = err.RuleName
+			e.Severity = err.severity()
+			rule.Report(e)
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,118 @@
+package actionlint
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestRulePSScriptAnalyzerDetectPowerShell(t *testing.T) {
+	for shell, want := range map[string]bool{
+		"pwsh":                    true,
+		"powershell":              true,
+		"pwsh -command \". {0}\"": true,
+		"powershell {0}":          true,
+		"pwsh2":                   false,
+		"bash":                    false,
+		"cmd":                     false,
+		"":                        false,
+	} {
+		if have := isPowerShell(shell); have != want {
+			t.Errorf("wanted %v for %q but got %v", want, shell, have)
+		}
+	}
+}
+
+func TestRulePSScriptAnalyzerSeverity(t *testing.T) {
+	for s, want := range map[string]Severity{
+		"Information": SeverityInfo,
+		"Warning":     SeverityWarning,
+		"Error":       SeverityError,
+		"ParseError":  SeverityError,
+	} {
+		e := &psScriptAnalyzerError{Severity: s}
+		if have := e.severity(); have != want {
+			t.Errorf("wanted %v for %q but got %v", want, s, have)
+		}
+	}
+}
+
+func TestRulePSScriptAnalyzerRunCommand(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake pwsh command is a shell script")
+	}
+
+	// Fake pwsh command reports an alias usage when the script given via stdin contains "ls"
+	exe := filepath.Join(t.TempDir(), "pwsh")
+	script := `#!/bin/sh
+[ "$1" = "-NoProfile" ] && [ "$3" = "-Command" ] || exit 2
+if grep -q '^ls' -; then
+  cat <<'EOS'
+[{"RuleName":"PSAvoidUsingCmdletAliases","Severity":"Warning","Line":1,"Column":1,"Message":"'ls' is an alias of 'Get-ChildItem'."}]
+EOS
+else
+  echo '[]'
+fi
+`
+	if err := os.WriteFile(exe, []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	src := `on: push
+jobs:
+  windows:
+    runs-on: windows-latest
+    steps:
+      - run: ls -Recurse
+      - run: Get-ChildItem -Recurse
+      - run: ls -la
+        shell: bash
+  linux:
+    runs-on: ubuntu-latest
+    steps:
+      - run: ls -la
+      - run: ls ${{ github.workspace }}
+        shell: pwsh
+`
+	l, err := NewLinter(io.Discard, &LinterOptions{PSScriptAnalyzer: exe, Shellcheck: "", Pyflakes: ""})
+	if err != nil {
+		t.Fatal(err)
+	}
+	errs, err := l.Lint("test.yaml", []byte(src), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{
+		`test.yaml:6:9: PSScriptAnalyzer reported issue in this script: PSAvoidUsingCmdletAliases:warning:1:1: 'ls' is an alias of 'Get-ChildItem' [psscriptanalyzer]`,
+		`test.yaml:14:9: PSScriptAnalyzer reported issue in this script: PSAvoidUsingCmdletAliases:warning:1:1: 'ls' is an alias of 'Get-ChildItem' [psscriptanalyzer]`,
+	}
+	if len(errs) != len(want) {
+		t.Fatalf("wanted %d errors but got %d: %v", len(want), len(errs), errs)
+	}
+	for i, w := range want {
+		if msg := errs[i].Error(); msg != w {
+			t.Errorf("wanted %q but got %q", w, msg)
+		}
+	}
+	if e := errs[0]; e.Code != "PSAvoidUsingCmdletAliases" || e.Severity != SeverityWarning {
+		t.Errorf("unexpected code %q and severity %v", e.Code, e.Severity)
+	}
+}
+
+func TestRulePSScriptAnalyzerCommandNotFound(t *testing.T) {
+	var logs strings.Builder
+	l, err := NewLinter(io.Discard, &LinterOptions{PSScriptAnalyzer: "this-pwsh-does-not-exist", Shellcheck: "", Pyflakes: "", Verbose: true, LogWriter: &logs})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := l.Lint("test.yaml", []byte("on: push\njobs:\n  test:\n    runs-on: windows-latest\n    steps:\n      - run: ls\n"), nil); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(logs.String(), `msg="Rule was disabled" rule=psscriptanalyzer`) {
+		t.Fatalf("rule was not disabled: %q", logs.String())
+	}
+}
@@ -2,9 +2,120 @@ package actionlint
 
 import (
 	"path"
+	"slices"
 	"strings"
+	"sync"
 )
 
+//go:generate go run ./scripts/generate-retired-runner-images ./retired_runner_images.go
+//go:generate go run ./scripts/generate-runner-labels ./runner_labels.go
+
+// RunnerOS is an OS of runners. The values are the same as the OS labels of self-hosted runners.
+type RunnerOS string
+
+const (
+	// RunnerOSLinux is Linux OS.
+	RunnerOSLinux RunnerOS = "linux"
+	// RunnerOSMacOS is macOS.
+	RunnerOSMacOS RunnerOS = "macos"
+	// RunnerOSWindows is Windows OS.
+	RunnerOSWindows RunnerOS = "windows"
+)
+
+// RunnerArch is a CPU architecture of runners.
+type RunnerArch string
+
+const (
+	// RunnerArchX64 is x86_64 architecture.
+	RunnerArchX64 RunnerArch = "x64"
+	// RunnerArchARM64 is 64-bit ARM architecture.
+	RunnerArchARM64 RunnerArch = "arm64"
+)
+
+// GitHubHostedRunner is a label of GitHub-hosted runners. The catalog of the labels is
+// GitHubHostedRunners.
+// https://docs.github.com/en/actions/using-github-hosted-runners/about-github-hosted-runners
+type GitHubHostedRunner struct {
+	// Label is the label of the runner in "runs-on:" like "ubuntu-latest".
+	Label string
+	// OS is the OS of the runner.
+	OS RunnerOS
+	// Arch is the CPU architecture of the runner.
+	Arch RunnerArch
+	// Image is the label of the runner image which the label points to. For example, "ubuntu-latest"
+	// is an alias of "ubuntu-24.04". It is the same as Label when the label is not an alias.
+	Image string
+	// Retired is the retirement information when the runner image was retired. It is nil when the
+	// runner is available. Retired runners are not listed in GitHubHostedRunners.
+	Retired *RetiredRunnerImage
+}
+
+// IsAlias returns true when the label is an alias of other runner image like "ubuntu-latest".
+func (r *GitHubHostedRunner) IsAlias() bool {
+	return r.Label != r.Image
+}
+
+var gitHubHostedRunnerIndex = sync.OnceValue(indexGitHubHostedRunners)
+
+func indexGitHubHostedRunners() map[string]*GitHubHostedRunner {
+	m := make(map[string]*GitHubHostedRunner, len(GitHubHostedRunners))
+	for _, r := range GitHubHostedRunners {
+		m[r.Label] = r
+	}
+	return m
+}
+
+// LookupGitHubHostedRunner looks up the GitHub-hosted runner by the label. The label is matched in
+// case-insensitive. When the label is for the retired runner image, the returned value has the
+// Retired field and its OS and architecture are the same as the replacement. The second return
+// value is false when the label is not for GitHub-hosted runners.
+func LookupGitHubHostedRunner(label string) (*GitHubHostedRunner, bool) {
+	l := strings.ToLower(label)
+	if r, ok := gitHubHostedRunnerIndex()[l]; ok {
+		return r, true
+	}
+	ret, ok := RetiredRunnerImages[l]
+	if !ok {
+		return nil, false
+	}
+	r := &GitHubHostedRunner{Label: l, Image: l, Retired: ret}
+	if rep, ok := gitHubHostedRunnerIndex()[ret.Replacement]; ok {
+		r.OS = rep.OS
+		r.Arch = rep.Arch
+	}
+	return r, true
+}
+
+// runnerOSOfLabel returns the OS of the runner label. GitHub-hosted runner labels, preset OS labels
+// of self-hosted runners, and labels which start with the OS name like "ubuntu-custom" are
+// recognized. The second return value is false when the OS cannot be determined.
+func runnerOSOfLabel(label string) (RunnerOS, bool) {
+	if r, ok := LookupGitHubHostedRunner(label); ok && r.OS != "" {
+		return r.OS, true
+	}
+	l := strings.ToLower(label)
+	switch {
+	case l == "linux" || strings.HasPrefix(l, "ubuntu-"):
+		return RunnerOSLinux, true
+	case l == "macos" || strings.HasPrefix(l, "macos-"):
+		return RunnerOSMacOS, true
+	case l == "windows" || strings.HasPrefix(l, "windows-"):
+		return RunnerOSWindows, true
+	default:
+		return "", false
+	}
+}
+
+// RetiredRunnerImage is a GitHub-hosted runner image which was retired. The table of the retired
+// images is RetiredRunnerImages.
+type RetiredRunnerImage struct {
+	// Replacement is the label of the nearest supported image. The same architecture is preferred.
+	// For example, Intel macOS images are replaced with "macos-15-intel".
+	Replacement string
+	// Retired is the date when the image was retired in "YYYY-MM-DD" format.
+	Retired string
+}
+
 type runnerOSCompat uint
 
 const (
@@ -28,37 +139,16 @@ const (
 	compatWindows11Arm
 )
 
-// https://docs.github.com/en/actions/using-github-hosted-runners/about-github-hosted-runners
-var allGitHubHostedRunnerLabels = []string{
-	"windows-latest",
-	"windows-latest-8-cores",
-	"windows-2025",
-	"windows-2025-vs2026",
-	"windows-2022",
-	"windows-11-arm",
-	"ubuntu-slim",
-	"ubuntu-latest",
-	"ubuntu-latest-4-cores",
-	"ubuntu-latest-8-cores",
-	"ubuntu-latest-16-cores",
-	"ubuntu-24.04",
-	"ubuntu-24.04-arm",
-	"ubuntu-22.04",
-	"ubuntu-22.04-arm",
-	"macos-latest",
-	"macos-latest-xlarge",
-	"macos-latest-large",
-	"macos-26-intel",
-	"macos-26-xlarge",
-	"macos-26-large",
-	"macos-26",
-	"macos-15-intel",
-	"macos-15-xlarge",
-	"macos-15-large",
-	"macos-15",
-	"macos-14-xlarge",
-	"macos-14-large",
-	"macos-14",
+// allGitHubHostedRunnerLabels is the list of labels of GitHub-hosted runners in the order of
+// GitHubHostedRunners. It is used for error messages and typo fixes.
+var allGitHubHostedRunnerLabels = gitHubHostedRunnerLabels(GitHubHostedRunners)
+
+func gitHubHostedRunnerLabels(runners []*GitHubHostedRunner) []string {
+	ls := make([]string, 0, len(runners))
+	for _, r := range runners {
+		ls = append(ls, r.Label)
+	}
+	return ls
 }
 
 // https://docs.github.com/en/actions/hosting-your-own-runners/using-self-hosted-runners-in-a-workflow#using-default-labels-to-route-jobs
@@ -76,39 +166,45 @@ var selfHostedRunnerPresetOtherLabels = []string{
 	"arm64",
 }
 
-var defaultRunnerOSCompats = map[string]runnerOSCompat{
-	"ubuntu-slim":            compatUbuntu2404,
-	"ubuntu-latest":          compatUbuntu2404,
-	"ubuntu-latest-4-cores":  compatUbuntu2404,
-	"ubuntu-latest-8-cores":  compatUbuntu2404,
-	"ubuntu-latest-16-cores": compatUbuntu2404,
-	"ubuntu-24.04":           compatUbuntu2404,
-	"ubuntu-24.04-arm":       compatUbuntu2404,
-	"ubuntu-22.04":           compatUbuntu2204,
-	"ubuntu-22.04-arm":       compatUbuntu2204,
-	"macos-latest-xlarge":    compatMacOS150XL,
-	"macos-latest-large":     compatMacOS150L,
-	"macos-latest":           compatMacOS150,
-	"macos-26-intel":         compatMacOS260Intel,
-	"macos-26-xlarge":        compatMacOS260XL,
-	"macos-26-large":         compatMacOS260L,
-	"macos-26":               compatMacOS260,
-	"macos-15-intel":         compatMacOS150Intel,
-	"macos-15-xlarge":        compatMacOS150XL,
-	"macos-15-large":         compatMacOS150L,
-	"macos-15":               compatMacOS150,
-	"macos-14-xlarge":        compatMacOS140XL,
-	"macos-14-large":         compatMacOS140L,
-	"macos-14":               compatMacOS140,
-	"windows-latest":         compatWindows2022,
-	"windows-latest-8-cores": compatWindows2022,
-	"windows-2025":           compatWindows2025,
-	"windows-2025-vs2026":    compatWindows2025VS2026,
-	"windows-2022":           compatWindows2022,
-	"windows-11-arm":         compatWindows11Arm,
-	"linux":                  compatUbuntu2404 | compatUbuntu2204, // Note: "linux" does not always indicate Ubuntu. It might be Fedora or Arch or ...
-	"macos":                  compatMacOS260 | compatMacOS260Intel | compatMacOS260L | compatMacOS260XL | compatMacOS150 | compatMacOS150Intel | compatMacOS150L | compatMacOS150XL | compatMacOS140 | compatMacOS140L | compatMacOS140XL,
-	"windows":                compatWindows2025VS2026 | compatWindows2025 | compatWindows2022 | compatWindows11Arm,
+// runnerImageOSCompats is the compatibility of the runner images. The keys are the images of
+// GitHubHostedRunners and the preset OS labels of self-hosted runners.
+var runnerImageOSCompats = map[string]runnerOSCompat{
+	"ubuntu-slim":         compatUbuntu2404,
+	"ubuntu-24.04":        compatUbuntu2404,
+	"ubuntu-24.04-arm":    compatUbuntu2404,
+	"ubuntu-22.04":        compatUbuntu2204,
+	"ubuntu-22.04-arm":    compatUbuntu2204,
+	"macos-26-intel":      compatMacOS260Intel,
+	"macos-26-xlarge":     compatMacOS260XL,
+	"macos-26-large":      compatMacOS260L,
+	"macos-26":            compatMacOS260,
+	"macos-15-intel":      compatMacOS150Intel,
+	"macos-15-xlarge":     compatMacOS150XL,
+	"macos-15-large":      compatMacOS150L,
+	"macos-15":            compatMacOS150,
+	"macos-14-xlarge":     compatMacOS140XL,
+	"macos-14-large":      compatMacOS140L,
+	"macos-14":            compatMacOS140,
+	"windows-2025":        compatWindows2025,
+	"windows-2025-vs2026": compatWindows2025VS2026,
+	"windows-2022":        compatWindows2022,
+	"windows-11-arm":      compatWindows11Arm,
+	"linux":               compatUbuntu2404 | compatUbuntu2204, // Note: "linux" does not always indicate Ubuntu. It might be Fedora or Arch or ...
+	"macos":               compatMacOS260 | compatMacOS260Intel | compatMacOS260L | compatMacOS260XL | compatMacOS150 | compatMacOS150Intel | compatMacOS150L | compatMacOS150XL | compatMacOS140 | compatMacOS140L | compatMacOS140XL,
+	"windows":             compatWindows2025VS2026 | compatWindows2025 | compatWindows2022 | compatWindows11Arm,
+}
+
+// runnerOSCompatOfLabel returns the compatibility of the GitHub-hosted runner label or the preset
+// OS label of self-hosted runners. Aliases like "ubuntu-latest" are resolved to their images. The
+// compatibility of images newer than this table, which come from the refreshed dataset, is
+// compatInvalid so that they are never reported as conflicts.
+func runnerOSCompatOfLabel(label string) (runnerOSCompat, bool) {
+	l := strings.ToLower(label)
+	if r, ok := gitHubHostedRunnerIndex()[l]; ok {
+		return runnerImageOSCompats[r.Image], true
+	}
+	c, ok := runnerImageOSCompats[l]
+	return c, ok
 }
 
 // RuleRunnerLabel is a rule to check runner label like "ubuntu-latest". There are two types of
@@ -122,6 +218,14 @@ type RuleRunnerLabel struct {
 	// all past compatibility values here for better error message. If accumulating all compatibility
 	// values into one integer, we can no longer know what labels are conflicting.
 	compats map[runnerOSCompat]*String
+	// remote is the GitHub repository for online checks. It is nil when online checks are disabled.
+	remote *RemoteRepository
+	// ghes is the version of GitHub Enterprise Server. GitHub-hosted runners are not available on
+	// GitHub Enterprise Server. It is nil when the version is not specified.
+	ghes *GHESVersion
+	// platform is the platform which runs the workflow. Labels of runners on Gitea Actions and
+	// Forgejo Actions are freely defined when registering the runners so they are not checked.
+	platform Platform
 }
 
 // NewRuleRunnerLabel creates new RuleRunnerLabel instance.
@@ -137,7 +241,7 @@ func NewRuleRunnerLabel() *RuleRunnerLabel {
 
 // VisitJobPre is callback when visiting Job node before visiting its children.
 func (rule *RuleRunnerLabel) VisitJobPre(n *Job) error {
-	if n.RunsOn == nil {
+	if n.RunsOn == nil || rule.platform.giteaCompatible() {
 		return nil
 	}
 
@@ -146,11 +250,20 @@ func (rule *RuleRunnerLabel) VisitJobPre(n *Job) error {
 		m = n.Strategy.Matrix
 	}
 
+	if n.RunsOn.Group != nil {
+		rule.checkGroup(n.RunsOn.Group)
+	}
+	if rule.remote != nil {
+		rule.checkRegisteredRunners(n.RunsOn, m)
+	}
+
 	if len(n.RunsOn.Labels) == 1 {
 		rule.checkLabel(n.RunsOn.Labels[0], m)
 		return nil
 	}
 
+	rule.checkSelfHostedWithGitHubHostedLabels(n.RunsOn.Labels)
+
 	rule.compats = map[runnerOSCompat]*String{}
 	if n.RunsOn.LabelsExpr != nil {
 		rule.checkLabelAndConflict(n.RunsOn.LabelsExpr, m)
@@ -167,35 +280,46 @@ func (rule *RuleRunnerLabel) VisitJobPre(n *Job) error {
 // https://docs.github.com/en/actions/using-github-hosted-runners/about-github-hosted-runners
 func (rule *RuleRunnerLabel) checkLabelAndConflict(l *String, m *Matrix) {
 	if l.ContainsExpression() {
-		ss := rule.tryToGetLabelsInMatrix(l, m)
+		ss := getRunnerLabelsInMatrix(l, m)
 		cs := make([]runnerOSCompat, 0, len(ss))
 		for _, s := range ss {
-			comp := rule.verifyRunnerLabel(s)
+			comp := rule.verifyRunnerLabel(s, false)
 			cs = append(cs, comp)
 		}
 		rule.checkCombiCompat(cs, ss)
 		return
 	}
 
-	comp := rule.verifyRunnerLabel(l)
+	comp := rule.verifyRunnerLabel(l, true)
 	rule.checkCompat(comp, l)
 }
 
 func (rule *RuleRunnerLabel) checkLabel(l *String, m *Matrix) {
 	if l.ContainsExpression() {
-		ss := rule.tryToGetLabelsInMatrix(l, m)
+		ss := getRunnerLabelsInMatrix(l, m)
 		for _, s := range ss {
-			rule.verifyRunnerLabel(s)
+			rule.verifyRunnerLabel(s, false)
 		}
 		return
 	}
 
-	rule.verifyRunnerLabel(l)
+	rule.verifyRunnerLabel(l, true)
 }
 
-func (rule *RuleRunnerLabel) verifyRunnerLabel(label *String) runnerOSCompat {
+// verifyRunnerLabel verifies the runner label. When fixable is true, a typo in the label is fixed
+// with the closest known label. Labels expanded from matrix are not fixable since their quotes in
+// the source are unknown.
+func (rule *RuleRunnerLabel) verifyRunnerLabel(label *String, fixable bool) runnerOSCompat {
 	l := label.Value
-	if c, ok := defaultRunnerOSCompats[strings.ToLower(l)]; ok {
+	if c, ok := runnerOSCompatOfLabel(l); ok {
+		if rule.ghes != nil && isGitHubHostedLabel(l) && !rule.isKnownLabel(l) {
+			rule.Errorf(
+				label.Pos,
+				"label %q is for GitHub-hosted runners which are not available on GitHub Enterprise Server %s. use labels of your self-hosted runners instead",
+				l,
+				rule.ghes,
+			)
+		}
 		return c
 	}
 
@@ -217,12 +341,50 @@ func (rule *RuleRunnerLabel) verifyRunnerLabel(label *String) runnerOSCompat {
 		}
 	}
 
-	rule.Errorf(
+	if r, ok := RetiredRunnerImages[strings.ToLower(l)]; ok && rule.ghes == nil {
+		var fixes []*TextEdit
+		if fixable {
+			if e := newReplaceStringEdit(label, r.Replacement); e != nil {
+				fixes = []*TextEdit{e}
+			}
+		}
+		rule.ErrorfWithFixes(
+			label.Pos,
+			fixes,
+			"label %q is for GitHub-hosted runner image which was retired on %s. use %q instead. see https://github.com/actions/runner-images for available images",
+			l,
+			r.Retired,
+			r.Replacement,
+		)
+		return compatInvalid
+	}
+
+	// Labels of self-hosted runners are checked with the registered runners in online mode
+	if rule.remote != nil && rule.remote.RunnerLabels() != nil {
+		return compatInvalid
+	}
+
+	hosted := allGitHubHostedRunnerLabels
+	if rule.ghes != nil {
+		hosted = nil // GitHub-hosted runners are not available on GitHub Enterprise Server
+	}
+	var fixes []*TextEdit
+	if fixable {
+		cs := slices.Concat(hosted, selfHostedRunnerPresetOtherLabels, selfHostedRunnerPresetOSLabels)
+		for _, k := range known {
+			if !strings.ContainsAny(k, "*?[") {
+				cs = append(cs, k) // Glob patterns are not candidates
+			}
+		}
+		fixes = typoFixes(label, cs)
+	}
+	rule.ErrorfWithFixes(
 		label.Pos,
+		fixes,
 		"label %q is unknown. available labels are %s. if it is a custom label for self-hosted runner, set list of labels in actionlint.yaml config file",
 		label.Value,
 		quotesAll(
-			allGitHubHostedRunnerLabels,
+			hosted,
 			selfHostedRunnerPresetOtherLabels,
 			selfHostedRunnerPresetOSLabels,
 			known,
@@ -232,7 +394,9 @@ func (rule *RuleRunnerLabel) verifyRunnerLabel(label *String) runnerOSCompat {
 	return compatInvalid
 }
 
-func (rule *RuleRunnerLabel) tryToGetLabelsInMatrix(label *String, m *Matrix) []*String {
+// getRunnerLabelsInMatrix tries to resolve the runner label like "${{ matrix.os }}" with the values
+// defined in the matrix. It returns nil when the label cannot be resolved statically.
+func getRunnerLabelsInMatrix(label *String, m *Matrix) []*String {
 	if m == nil {
 		return nil
 	}
@@ -243,8 +407,9 @@ func (rule *RuleRunnerLabel) tryToGetLabelsInMatrix(label *String, m *Matrix) []
 	}
 
 	l := strings.TrimSpace(label.Value)
-	p := NewExprParser()
-	expr, err := p.Parse(NewExprLexer(l[3:])) // 3 means omit first "${{"
+	lex := NewExprLexer(l[3:]) // 3 means omit first "${{"
+	expr, err := NewExprParser().Parse(lex)
+	lex.Release()
 	if err != nil {
 		return nil
 	}
@@ -292,7 +457,7 @@ func (rule *RuleRunnerLabel) tryToGetLabelsInMatrix(label *String, m *Matrix) []
 func (rule *RuleRunnerLabel) checkConflict(comp runnerOSCompat, label *String) bool {
 	for c, l := range rule.compats {
 		if c&comp == 0 {
-			rule.Errorf(label.Pos, "label %q conflicts with label %q defined at %s. note: to run your job on each workers, use matrix", label.Value, l.Value, l.Pos)
+			rule.ErrorfWithRelated(label.Pos, relatedAt(l.Pos, "conflicting label is defined here"), "label %q conflicts with label %q defined at %s. note: to run your job on each workers, use matrix", label.Value, l.Value, l.Pos)
 			return false
 		}
 	}
@@ -325,9 +490,171 @@ func (rule *RuleRunnerLabel) checkCombiCompat(comps []runnerOSCompat, labels []*
 	}
 }
 
+// https://docs.github.com/en/actions/using-jobs/choosing-the-runner-for-a-job#choosing-runners-in-a-group
+func (rule *RuleRunnerLabel) checkGroup(group *String) {
+	if group.ContainsExpression() {
+		return
+	}
+	known := rule.getKnownGroups()
+	if known == nil {
+		return
+	}
+	for _, k := range known {
+		m, err := path.Match(k, group.Value)
+		if err != nil {
+			rule.Errorf(group.Pos, "runner group pattern %q is an invalid glob. kindly check list of groups in actionlint.yaml config file: %v", k, err)
+			return
+		}
+		if m {
+			return
+		}
+	}
+	rule.Errorf(
+		group.Pos,
+		"runner group %q is unknown. available groups are %s. if it is a runner group available for the repository, set list of groups in actionlint.yaml config file",
+		group.Value,
+		sortedQuotes(known),
+	)
+}
+
+// checkSelfHostedWithGitHubHostedLabels reports labels of GitHub-hosted runners used with
+// "self-hosted" label. Self-hosted runners don't have such labels unless they are explicitly added.
+// This check is done only when labels of self-hosted runners are configured since actionlint cannot
+// know what labels are added to self-hosted runners otherwise.
+func (rule *RuleRunnerLabel) checkSelfHostedWithGitHubHostedLabels(labels []*String) {
+	known := rule.getKnownLabels()
+	if known == nil || rule.ghes != nil {
+		return // GitHub-hosted labels are always reported on GitHub Enterprise Server
+	}
+	if !slices.ContainsFunc(labels, func(l *String) bool { return strings.EqualFold(l.Value, "self-hosted") }) {
+		return
+	}
+Labels:
+	for _, l := range labels {
+		if !isGitHubHostedLabel(l.Value) {
+			continue
+		}
+		for _, k := range known {
+			if m, _ := path.Match(k, l.Value); m {
+				continue Labels
+			}
+		}
+		rule.Errorf(
+			l.Pos,
+			"label %q is for GitHub-hosted runners but it is used with \"self-hosted\" label. self-hosted runners don't have this label unless it is added explicitly. if it is a custom label for self-hosted runner, add it to list of labels in actionlint.yaml config file",
+			l.Value,
+		)
+	}
+}
+
+func isGitHubHostedLabel(l string) bool {
+	_, ok := gitHubHostedRunnerIndex()[strings.ToLower(l)]
+	return ok
+}
+
+func isGitHubHostedOrPresetLabel(l string) bool {
+	for _, ls := range [][]string{allGitHubHostedRunnerLabels, selfHostedRunnerPresetOSLabels, selfHostedRunnerPresetOtherLabels} {
+		for _, p := range ls {
+			if strings.EqualFold(l, p) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// checkRegisteredRunners checks at least one runner registered to the repository has all the labels
+// at "runs-on:". This check is done only in online mode.
+func (rule *RuleRunnerLabel) checkRegisteredRunners(r *Runner, m *Matrix) {
+	candidates := [][]*String{}
+	switch {
+	case r.LabelsExpr != nil:
+		for _, l := range getRunnerLabelsInMatrix(r.LabelsExpr, m) {
+			candidates = append(candidates, []*String{l})
+		}
+	case len(r.Labels) == 1 && r.Labels[0].ContainsExpression():
+		for _, l := range getRunnerLabelsInMatrix(r.Labels[0], m) {
+			candidates = append(candidates, []*String{l})
+		}
+	default:
+		for _, l := range r.Labels {
+			if l.ContainsExpression() {
+				return
+			}
+		}
+		candidates = append(candidates, r.Labels)
+	}
+
+	for _, labels := range candidates {
+		// Jobs run on GitHub-hosted runners don't need any registered runner
+		if !slices.ContainsFunc(labels, func(l *String) bool {
+			return !isGitHubHostedOrPresetLabel(l.Value) || strings.EqualFold(l.Value, "self-hosted")
+		}) {
+			continue
+		}
+
+		runners := rule.remote.RunnerLabels()
+		if runners == nil {
+			return // Could not fetch runners
+		}
+		rule.checkLabelsOnRunners(labels, runners)
+	}
+}
+
+func (rule *RuleRunnerLabel) checkLabelsOnRunners(labels []*String, runners [][]string) {
+	has := func(runner []string, label string) bool {
+		return slices.ContainsFunc(runner, func(l string) bool { return strings.EqualFold(l, label) })
+	}
+
+	for _, r := range runners {
+		if !slices.ContainsFunc(labels, func(l *String) bool { return !has(r, l.Value) }) {
+			return // Found a runner which has all labels
+		}
+	}
+
+	for _, l := range labels {
+		if !slices.ContainsFunc(runners, func(r []string) bool { return has(r, l.Value) }) {
+			rule.Errorf(
+				l.Pos,
+				"label %q is not found in any runner registered to repository %q or its organization. the job will wait for a runner with the label forever. note: this check was done with GitHub API",
+				l.Value,
+				rule.remote.FullName(),
+			)
+			return
+		}
+	}
+
+	vs := make([]string, 0, len(labels))
+	for _, l := range labels {
+		vs = append(vs, l.Value)
+	}
+	rule.Errorf(
+		labels[0].Pos,
+		"no runner registered to repository %q or its organization has all labels %s. the job will wait for such a runner forever. note: this check was done with GitHub API",
+		rule.remote.FullName(),
+		quotes(vs),
+	)
+}
+
+func (rule *RuleRunnerLabel) getKnownGroups() []string {
+	if rule.config == nil {
+		return nil
+	}
+	return rule.config.SelfHostedRunner.Groups
+}
+
 func (rule *RuleRunnerLabel) getKnownLabels() []string {
 	if rule.config == nil {
 		return nil
 	}
 	return rule.config.SelfHostedRunner.Labels
 }
+
+func (rule *RuleRunnerLabel) isKnownLabel(l string) bool {
+	for _, k := range rule.getKnownLabels() {
+		if m, _ := path.Match(k, l); m {
+			return true
+		}
+	}
+	return false
+}
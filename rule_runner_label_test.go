@@ -1,8 +1,13 @@
 package actionlint
 
 import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"strings"
 	"testing"
+
+	"github.com/google/go-cmp/cmp"
 )
 
 func TestRuleRunnerLabelCheckLabels(t *testing.T) {
@@ -202,7 +207,11 @@ func TestRuleRunnerLabelCheckLabels(t *testing.T) {
 			what:   "GH-hosted labels conflict mixed with self-hosted runner labels",
 			labels: []string{"self-hosted", "ubuntu-latest", "x64", "windows-latest", "foo"},
 			known:  []string{"foo"},
-			errs:   []string{`label "windows-latest" conflicts with label "ubuntu-latest"`},
+			errs: []string{
+				`label "ubuntu-latest" is for GitHub-hosted runners but it is used with "self-hosted" label`,
+				`label "windows-latest" is for GitHub-hosted runners but it is used with "self-hosted" label`,
+				`label "windows-latest" conflicts with label "ubuntu-latest"`,
+			},
 		},
 		{
 			what:   "GH-hosted labels conflict ignore case",
@@ -284,6 +293,49 @@ func TestRuleRunnerLabelCheckLabels(t *testing.T) {
 			known:  []string{"INSTANCE_TYPE=["},
 			errs:   []string{`label pattern "INSTANCE_TYPE=[" is an invalid glob. kindly check list of labels in actionlint.yaml config file: syntax error in pattern`},
 		},
+		{
+			what:   "GH-hosted label with self-hosted label when self-hosted labels are configured",
+			labels: []string{"self-hosted", "linux", "ubuntu-latest"},
+			known:  []string{"gpu"},
+			errs:   []string{`label "ubuntu-latest" is for GitHub-hosted runners but it is used with "self-hosted" label`},
+		},
+		{
+			what:   "GH-hosted label with self-hosted label when self-hosted labels are empty",
+			labels: []string{"self-hosted", "ubuntu-latest"},
+			known:  []string{},
+			errs:   []string{`label "ubuntu-latest" is for GitHub-hosted runners but it is used with "self-hosted" label`},
+		},
+		{
+			what:   "GH-hosted label configured as self-hosted runner label",
+			labels: []string{"self-hosted", "ubuntu-latest"},
+			known:  []string{"ubuntu-*"},
+		},
+		{
+			what:   "GH-hosted label without self-hosted label when self-hosted labels are configured",
+			labels: []string{"ubuntu-latest", "gpu"},
+			known:  []string{"gpu"},
+		},
+		{
+			what:   "retired GH-hosted runner image",
+			labels: []string{"ubuntu-20.04"},
+			errs:   []string{`label "ubuntu-20.04" is for GitHub-hosted runner image which was retired on 2025-04-15. use "ubuntu-22.04" instead`},
+		},
+		{
+			what:   "retired GH-hosted runner image in upper case",
+			labels: []string{"Windows-2019"},
+			errs:   []string{`label "Windows-2019" is for GitHub-hosted runner image which was retired on 2025-06-30. use "windows-2022" instead`},
+		},
+		{
+			what:   "retired GH-hosted runner image in matrix",
+			labels: []string{"${{matrix.os}}"},
+			matrix: []string{"ubuntu-latest", "macos-13"},
+			errs:   []string{`label "macos-13" is for GitHub-hosted runner image which was retired on 2025-12-04. use "macos-15-intel" instead`},
+		},
+		{
+			what:   "retired GH-hosted runner image label configured as self-hosted runner label",
+			labels: []string{"self-hosted", "ubuntu-20.04"},
+			known:  []string{"ubuntu-20.04"},
+		},
 		// TODO: Add error tests for 'include:'
 	}
 
@@ -342,6 +394,84 @@ func TestRuleRunnerLabelCheckLabels(t *testing.T) {
 	}
 }
 
+func TestRuleRunnerLabelCheckGroup(t *testing.T) {
+	testCases := []struct {
+		what  string
+		group string
+		known []string
+		err   string
+	}{
+		{
+			what:  "no group configuration",
+			group: "my-group",
+		},
+		{
+			what:  "known group",
+			group: "my-group",
+			known: []string{"other-group", "my-group"},
+		},
+		{
+			what:  "known group pattern",
+			group: "linux-runners",
+			known: []string{"*-runners"},
+		},
+		{
+			what:  "group with expression",
+			group: "${{ vars.RUNNER_GROUP }}",
+			known: []string{},
+		},
+		{
+			what:  "unknown group",
+			group: "my-grop",
+			known: []string{"my-group", "other-group"},
+			err:   `runner group "my-grop" is unknown. available groups are "my-group", "other-group"`,
+		},
+		{
+			what:  "no group is available",
+			group: "my-group",
+			known: []string{},
+			err:   `runner group "my-group" is unknown`,
+		},
+		{
+			what:  "invalid glob pattern",
+			group: "my-group",
+			known: []string{"my-["},
+			err:   `runner group pattern "my-[" is an invalid glob`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.what, func(t *testing.T) {
+			node := &Job{
+				RunsOn: &Runner{
+					Group: &String{Value: tc.group, Pos: &Pos{}},
+				},
+			}
+			rule := NewRuleRunnerLabel()
+			cfg := Config{}
+			cfg.SelfHostedRunner.Groups = tc.known
+			rule.SetConfig(&cfg)
+			if err := rule.VisitJobPre(node); err != nil {
+				t.Fatal(err)
+			}
+
+			errs := rule.Errs()
+			if tc.err == "" {
+				if len(errs) > 0 {
+					t.Fatalf("wanted no error but got %v", errs)
+				}
+				return
+			}
+			if len(errs) != 1 {
+				t.Fatalf("wanted one error but got %v", errs)
+			}
+			if msg := errs[0].Error(); !strings.Contains(msg, tc.err) {
+				t.Fatalf("%q is not contained in error message %q", tc.err, msg)
+			}
+		})
+	}
+}
+
 func TestRuleRunnerLabelDoNothingOnNoRunsOn(t *testing.T) {
 	rule := NewRuleRunnerLabel()
 	if err := rule.VisitJobPre(&Job{}); err != nil {
@@ -353,19 +483,229 @@ func TestRuleRunnerLabelDoNothingOnNoRunsOn(t *testing.T) {
 }
 
 func TestRuleRunnerLabelAllGitHubHostedRunnerLabels(t *testing.T) {
-	all := []string{}
-	all = append(all, allGitHubHostedRunnerLabels...)
-	all = append(all, selfHostedRunnerPresetOSLabels...)
+	if len(allGitHubHostedRunnerLabels) != len(GitHubHostedRunners) {
+		t.Errorf("%d elements in allGitHubHostedRunnerLabels but %d elements in GitHubHostedRunners", len(allGitHubHostedRunnerLabels), len(GitHubHostedRunners))
+	}
+
+	images := map[string]struct{}{}
+	for i, r := range GitHubHostedRunners {
+		if r.Label != allGitHubHostedRunnerLabels[i] {
+			t.Errorf("label %q at index %d in allGitHubHostedRunnerLabels does not match to %q in GitHubHostedRunners", allGitHubHostedRunnerLabels[i], i, r.Label)
+		}
+		if r.Label != strings.ToLower(r.Label) {
+			t.Errorf("label %q in GitHubHostedRunners is not in lower-case", r.Label)
+		}
+		if _, ok := runnerImageOSCompats[r.Image]; !ok {
+			t.Errorf("%q is included in GitHubHostedRunners but compatibility of its image %q is unknown", r.Label, r.Image)
+		}
+		images[r.Image] = struct{}{}
+	}
+	for _, l := range selfHostedRunnerPresetOSLabels {
+		images[l] = struct{}{}
+	}
+
+	for l := range runnerImageOSCompats {
+		if _, ok := images[l]; !ok {
+			t.Errorf("%q is included in runnerImageOSCompats but it is neither an image of GitHub-hosted runners nor a preset OS label", l)
+		}
+	}
+}
+
+func TestLookupGitHubHostedRunner(t *testing.T) {
+	r, ok := LookupGitHubHostedRunner("Ubuntu-Latest")
+	if !ok {
+		t.Fatal("ubuntu-latest was not found")
+	}
+	if r.Label != "ubuntu-latest" || r.OS != RunnerOSLinux || r.Arch != RunnerArchX64 || !r.IsAlias() || r.Retired != nil {
+		t.Errorf("unexpected runner for ubuntu-latest: %+v", r)
+	}
+	if img, ok := LookupGitHubHostedRunner(r.Image); !ok || img.IsAlias() {
+		t.Errorf("image %q of ubuntu-latest is not a concrete label: %+v", r.Image, img)
+	}
 
-	if len(all) != len(defaultRunnerOSCompats) {
-		t.Errorf("%d elements in allGitHubHostedRunnerLabels but %d elements in githubHostedRunnerCompats", len(all), len(defaultRunnerOSCompats))
+	r, ok = LookupGitHubHostedRunner("windows-11-arm")
+	if !ok {
+		t.Fatal("windows-11-arm was not found")
+	}
+	if r.OS != RunnerOSWindows || r.Arch != RunnerArchARM64 || r.IsAlias() {
+		t.Errorf("unexpected runner for windows-11-arm: %+v", r)
+	}
+
+	r, ok = LookupGitHubHostedRunner("ubuntu-18.04")
+	if !ok {
+		t.Fatal("retired ubuntu-18.04 was not found")
+	}
+	if r.Retired == nil || r.Retired != RetiredRunnerImages["ubuntu-18.04"] || r.OS != RunnerOSLinux {
+		t.Errorf("unexpected runner for retired ubuntu-18.04: %+v", r)
 	}
-	for _, l := range all {
+
+	for _, l := range []string{"linux", "self-hosted", "my-runner"} {
+		if r, ok := LookupGitHubHostedRunner(l); ok {
+			t.Errorf("%q should not be a GitHub-hosted runner but got %+v", l, r)
+		}
+	}
+}
+
+func TestRunnerOSOfLabel(t *testing.T) {
+	tests := []struct {
+		label string
+		want  RunnerOS
+	}{
+		{"ubuntu-latest", RunnerOSLinux},
+		{"ubuntu-slim", RunnerOSLinux},
+		{"macos-latest", RunnerOSMacOS},
+		{"Windows-Latest", RunnerOSWindows},
+		{"windows-2019", RunnerOSWindows},
+		{"linux", RunnerOSLinux},
+		{"macos", RunnerOSMacOS},
+		{"windows", RunnerOSWindows},
+		{"ubuntu-custom", RunnerOSLinux},
+		{"self-hosted", ""},
+		{"my-runner", ""},
+	}
+	for _, tc := range tests {
+		os, ok := runnerOSOfLabel(tc.label)
+		if ok != (tc.want != "") || os != tc.want {
+			t.Errorf("wanted OS %q for label %q but got %q (ok=%v)", tc.want, tc.label, os, ok)
+		}
+	}
+}
+
+func TestRuleRunnerLabelRetiredRunnerImages(t *testing.T) {
+	for l, r := range RetiredRunnerImages {
 		if l != strings.ToLower(l) {
-			t.Errorf("label %q in allGitHubHostedRunnerLabels is not in lower-case", l)
+			t.Errorf("retired label %q is not in lower-case", l)
+		}
+		if isGitHubHostedLabel(l) {
+			t.Errorf("retired label %q is included in allGitHubHostedRunnerLabels", l)
+		}
+		if !isGitHubHostedLabel(r.Replacement) {
+			t.Errorf("replacement %q of retired label %q is not included in allGitHubHostedRunnerLabels", r.Replacement, l)
 		}
-		if _, ok := defaultRunnerOSCompats[l]; !ok {
-			t.Errorf("%q is included in allGitHubHostedRunnerLabels but not included in githubHostedRunnerCompats", l)
+	}
+}
+
+func TestRuleRunnerLabelFixRetiredRunnerImage(t *testing.T) {
+	rule := NewRuleRunnerLabel()
+	label := &String{Value: "ubuntu-18.04", Quoted: true, Pos: &Pos{Line: 4, Col: 14}}
+	if err := rule.VisitJobPre(&Job{RunsOn: &Runner{Labels: []*String{label}}}); err != nil {
+		t.Fatal(err)
+	}
+	errs := rule.Errs()
+	if len(errs) != 1 {
+		t.Fatalf("wanted one error but got %v", errs)
+	}
+	fixes := errs[0].Fixes
+	if len(fixes) != 1 {
+		t.Fatalf("wanted one fix but got %v", fixes)
+	}
+	want := &TextEdit{Start: &Pos{Line: 4, Col: 15}, End: &Pos{Line: 4, Col: 27}, NewText: "ubuntu-22.04"}
+	if diff := cmp.Diff(want, fixes[0]); diff != "" {
+		t.Fatal(diff)
+	}
+}
+
+func TestRuleRunnerLabelRegisteredRunners(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		switch req.URL.Path {
+		case "/repos/owner/repo/actions/runners":
+			fmt.Fprint(w, `{"total_count":2,"runners":[{"name":"r1","labels":[{"name":"self-hosted"},{"name":"Linux"},{"name":"gpu"}]},{"name":"r2","labels":[{"name":"self-hosted"},{"name":"windows"},{"name":"large"}]}]}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
 		}
+	}))
+	defer srv.Close()
+	remote := NewRemoteRepository("owner", "repo", srv.URL, "", "", nil, nil)
+
+	testCases := []struct {
+		what   string
+		labels []string
+		matrix []string
+		err    string
+	}{
+		{
+			what:   "all labels on one runner",
+			labels: []string{"self-hosted", "linux", "gpu"},
+		},
+		{
+			what:   "single custom label",
+			labels: []string{"large"},
+		},
+		{
+			what:   "GitHub-hosted runner",
+			labels: []string{"ubuntu-latest"},
+		},
+		{
+			what:   "labels with expression",
+			labels: []string{"self-hosted", "${{ vars.LABEL }}"},
+		},
+		{
+			what:   "unknown label",
+			labels: []string{"self-hosted", "linux", "tpu"},
+			err:    `label "tpu" is not found in any runner registered to repository "owner/repo" or its organization`,
+		},
+		{
+			what:   "no runner has all labels",
+			labels: []string{"self-hosted", "linux", "large"},
+			err:    `no runner registered to repository "owner/repo" or its organization has all labels "self-hosted", "linux", "large"`,
+		},
+		{
+			what:   "no runner has preset labels",
+			labels: []string{"self-hosted", "macos"},
+			err:    `label "macos" is not found in any runner`,
+		},
+		{
+			what:   "labels in matrix",
+			labels: []string{"${{ matrix.os }}"},
+			matrix: []string{"ubuntu-latest", "gpu", "tpu"},
+			err:    `label "tpu" is not found in any runner`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.what, func(t *testing.T) {
+			pos := &Pos{}
+			labels := make([]*String, 0, len(tc.labels))
+			for _, l := range tc.labels {
+				labels = append(labels, &String{l, false, pos})
+			}
+			node := &Job{RunsOn: &Runner{Labels: labels}}
+			if tc.matrix != nil {
+				row := make([]RawYAMLValue, 0, len(tc.matrix))
+				for _, m := range tc.matrix {
+					row = append(row, &RawYAMLString{m, pos})
+				}
+				node.Strategy = &Strategy{
+					Matrix: &Matrix{
+						Rows: map[string]*MatrixRow{
+							"os": {Name: &String{"os", false, pos}, Values: row},
+						},
+					},
+				}
+			}
+
+			rule := NewRuleRunnerLabel()
+			rule.remote = remote
+			cfg := Config{}
+			cfg.SelfHostedRunner.Labels = []string{"gpu", "tpu", "large"}
+			rule.SetConfig(&cfg)
+			if err := rule.VisitJobPre(node); err != nil {
+				t.Fatal(err)
+			}
+
+			errs := rule.Errs()
+			if tc.err == "" {
+				if len(errs) > 0 {
+					t.Fatalf("wanted no error but got %v", errs)
+				}
+				return
+			}
+			if len(errs) != 1 {
+				t.Fatalf("wanted one error but got %v", errs)
+			}
+			if msg := errs[0].Error(); !strings.Contains(msg, tc.err) {
+				t.Fatalf("%q is not contained in error message %q", tc.err, msg)
+			}
+		})
 	}
 }
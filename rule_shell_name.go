@@ -1,9 +1,18 @@
 package actionlint
 
 import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
 	"strings"
 )
 
+// Placeholder in custom shell other than {0} like {1} or {}.
+var reShellNameOtherPlaceholder = regexp.MustCompile(`\{[0-9]*\}`)
+
 type platformKind int
 
 const (
@@ -17,6 +26,11 @@ const (
 type RuleShellName struct {
 	RuleBase
 	platform platformKind
+	// proj is the project to resolve executables of custom shells with relative paths. It is nil when
+	// the workflow is not in a project.
+	proj       *Project
+	workflowWD *String
+	jobWD      *String
 }
 
 // NewRuleShellName creates new RuleShellName instance.
@@ -33,7 +47,11 @@ func NewRuleShellName() *RuleShellName {
 // VisitStep is callback when visiting Step node.
 func (rule *RuleShellName) VisitStep(n *Step) error {
 	if run, ok := n.Exec.(*ExecRun); ok {
-		rule.checkShellName(run.Shell)
+		wd := run.WorkingDirectory
+		if wd == nil {
+			wd = rule.jobWD
+		}
+		rule.checkShellName(run.Shell, wd)
 	}
 	return nil
 }
@@ -44,8 +62,12 @@ func (rule *RuleShellName) VisitJobPre(n *Job) error {
 		return nil
 	}
 	rule.platform = rule.getPlatformFromRunner(n.RunsOn)
-	if n.Defaults != nil && n.Defaults.Run != nil {
-		rule.checkShellName(n.Defaults.Run.Shell)
+	rule.jobWD = rule.workflowWD
+	if n.Defaults != nil && n.Defaults.Run != nil && n.Defaults.Run.WorkingDirectory != nil {
+		rule.jobWD = n.Defaults.Run.WorkingDirectory
+	}
+	if n.Defaults != nil && n.Defaults.Run != nil && n.Defaults.Run.Shell != nil {
+		rule.checkShellName(n.Defaults.Run.Shell, rule.jobWD)
 	}
 	return nil
 }
@@ -53,30 +75,43 @@ func (rule *RuleShellName) VisitJobPre(n *Job) error {
 // VisitJobPost is callback when visiting Job node after visiting its children.
 func (rule *RuleShellName) VisitJobPost(n *Job) error {
 	rule.platform = platformKindAny // Clear
+	rule.jobWD = nil
 	return nil
 }
 
 // VisitWorkflowPre is callback when visiting Workflow node before visiting its children.
 func (rule *RuleShellName) VisitWorkflowPre(n *Workflow) error {
 	if n.Defaults != nil && n.Defaults.Run != nil {
-		rule.checkShellName(n.Defaults.Run.Shell)
+		rule.workflowWD = n.Defaults.Run.WorkingDirectory
+		rule.checkShellName(n.Defaults.Run.Shell, rule.workflowWD)
+		rule.checkWorkflowShellOnJobs(n.Defaults.Run.Shell, n.Jobs)
 	}
 	return nil
 }
 
-func (rule *RuleShellName) checkShellName(node *String) {
+func (rule *RuleShellName) checkShellName(node *String, wd *String) {
 	if node == nil {
 		return
 	}
 
-	// Ignore custom shell
+	// Ignore dynamic shell name
+	if node.ContainsExpression() {
+		return
+	}
+
+	// Custom shell
 	// https://docs.github.com/en/actions/learn-github-actions/workflow-syntax-for-github-actions#custom-shell
 	if strings.Contains(node.Value, "{0}") {
+		rule.checkCustomShell(node, wd)
+		rule.checkCustomShellOnPlatform(node, rule.platform)
 		return
 	}
-
-	// Ignore dynamic shell name
-	if node.ContainsExpression() {
+	if strings.ContainsAny(strings.TrimSpace(node.Value), " \t") {
+		rule.Errorf(
+			node.Pos,
+			"custom shell %q must contain {0} placeholder which is replaced with the path to the script file. see https://docs.github.com/en/actions/writing-workflows/workflow-syntax-for-github-actions#custom-shell",
+			node.Value,
+		)
 		return
 	}
 
@@ -114,6 +149,182 @@ func (rule *RuleShellName) checkShellName(node *String) {
 	)
 }
 
+// checkCustomShell checks the template of the custom shell. The runner runs the first word of the
+// template as the executable and replaces {0} in the rest with the path to the script file.
+func (rule *RuleShellName) checkCustomShell(node *String, wd *String) {
+	for _, p := range reShellNameOtherPlaceholder.FindAllString(node.Value, -1) {
+		if p != "{0}" {
+			rule.Errorf(
+				node.Pos,
+				"placeholder %s in custom shell %q is not supported. only {0} is replaced with the path to the script file",
+				p,
+				node.Value,
+			)
+			break
+		}
+	}
+
+	if q, ok := unbalancedQuote(node.Value); ok {
+		rule.Errorf(
+			node.Pos,
+			"quote %s is not closed in custom shell %q. the arguments of the command are broken",
+			q,
+			node.Value,
+		)
+	}
+
+	exe := strings.Fields(node.Value)[0]
+	if strings.Contains(exe, "{0}") || strings.HasPrefix(exe, "-") {
+		rule.Errorf(
+			node.Pos,
+			"custom shell %q must start with the command to run the script like \"bash -e {0}\" but the first word is %q",
+			node.Value,
+			exe,
+		)
+		return
+	}
+	if strings.Contains(exe, "$") || strings.Contains(exe, "%") {
+		rule.Errorf(
+			node.Pos,
+			"environment variables in the command %q of custom shell %q are not expanded. use the command name in $PATH or the path to the command",
+			exe,
+			node.Value,
+		)
+		return
+	}
+
+	rule.checkCustomShellExecutable(node, exe, wd)
+}
+
+// checkCustomShellExecutable checks the executable of the custom shell exists in the project when it
+// is specified with a relative path like "./scripts/shell.sh".
+func (rule *RuleShellName) checkCustomShellExecutable(node *String, exe string, wd *String) {
+	if rule.proj == nil || !strings.HasPrefix(exe, "./") && !strings.HasPrefix(exe, "../") {
+		return
+	}
+	dir := rule.proj.RootDir()
+	if wd != nil {
+		if wd.ContainsExpression() || strings.Contains(wd.Value, "$") || filepath.IsAbs(wd.Value) || strings.HasPrefix(wd.Value, "/") {
+			return
+		}
+		dir = filepath.Join(dir, filepath.FromSlash(wd.Value))
+	}
+	p := filepath.Join(dir, filepath.FromSlash(exe))
+	if _, err := rule.proj.stat(p); errors.Is(err, os.ErrNotExist) {
+		rule.Errorf(
+			node.Pos,
+			"command %q of custom shell %q does not exist in the repository. the path is resolved from the working directory of the step",
+			exe,
+			node.Value,
+		)
+	}
+}
+
+// unbalancedQuote returns the quote which is not closed in the string.
+func unbalancedQuote(s string) (string, bool) {
+	quote := rune(0)
+	for _, c := range s {
+		switch {
+		case quote == 0 && (c == '\'' || c == '"'):
+			quote = c
+		case quote == c:
+			quote = 0
+		}
+	}
+	if quote == 0 {
+		return "", false
+	}
+	return string(quote), true
+}
+
+// checkCustomShellOnPlatform checks the command of the custom shell template is available on the
+// platform.
+func (rule *RuleShellName) checkCustomShellOnPlatform(node *String, platform platformKind) {
+	if platform != platformKindMacOrLinux {
+		return
+	}
+	fs := strings.Fields(node.Value)
+	if len(fs) == 0 {
+		return
+	}
+	cmd := strings.ToLower(fs[0])
+	if cmd == "cmd" || cmd == "cmd.exe" || cmd == "powershell" || cmd == "powershell.exe" {
+		rule.Errorf(
+			node.Pos,
+			"custom shell %q runs %q command but it is not available on macOS or Linux",
+			node.Value,
+			fs[0],
+		)
+	}
+}
+
+// checkWorkflowShellOnJobs checks the default shell at workflow-level "defaults.run.shell" is
+// available on the platforms of the jobs which inherit it. Shell names invalid on all platforms were
+// already reported by checkShellName. The shell is reported at most once per platform even if many
+// jobs inherit it.
+func (rule *RuleShellName) checkWorkflowShellOnJobs(node *String, jobs map[string]*Job) {
+	if node == nil || node.ContainsExpression() {
+		return
+	}
+
+	var windows, macOrLinux []string
+	for _, j := range jobs {
+		if j.RunsOn == nil || j.ID == nil {
+			continue
+		}
+		if j.Defaults != nil && j.Defaults.Run != nil && j.Defaults.Run.Shell != nil {
+			continue // The job overrides the default shell
+		}
+		switch rule.getPlatformFromRunner(j.RunsOn) {
+		case platformKindWindows:
+			windows = append(windows, j.ID.Value)
+		case platformKindMacOrLinux:
+			macOrLinux = append(macOrLinux, j.ID.Value)
+		}
+	}
+
+	if strings.Contains(node.Value, "{0}") {
+		if len(macOrLinux) > 0 {
+			rule.checkCustomShellOnPlatform(node, platformKindMacOrLinux)
+		}
+		return
+	}
+
+	name := strings.ToLower(node.Value)
+	if !slices.Contains(getAvailableShellNames(platformKindAny), name) {
+		return
+	}
+	rule.checkWorkflowShellOnPlatform(node, name, platformKindWindows, windows)
+	rule.checkWorkflowShellOnPlatform(node, name, platformKindMacOrLinux, macOrLinux)
+}
+
+func (rule *RuleShellName) checkWorkflowShellOnPlatform(node *String, name string, platform platformKind, jobs []string) {
+	if len(jobs) == 0 {
+		return
+	}
+	available := getAvailableShellNames(platform)
+	if slices.Contains(available, name) {
+		return
+	}
+
+	on := "macOS or Linux"
+	if platform == platformKindWindows {
+		on = "Windows"
+	}
+	where := fmt.Sprintf("job %s runs", sortedQuotes(jobs))
+	if len(jobs) > 1 {
+		where = fmt.Sprintf("jobs %s run", sortedQuotes(jobs))
+	}
+	rule.Errorf(
+		node.Pos,
+		"shell name %q at workflow-level \"defaults.run.shell\" is invalid on %s where %s. available names are %s",
+		node.Value,
+		on,
+		where,
+		sortedQuotes(available),
+	)
+}
+
 func getAvailableShellNames(kind platformKind) []string {
 	// https://docs.github.com/en/actions/learn-github-actions/workflow-syntax-for-github-actions#using-a-specific-shell
 	switch kind {
@@ -7,12 +7,33 @@ import (
 	"sync"
 )
 
+type shellcheckReplacement struct {
+	Line        int    `json:"line"`
+	EndLine     int    `json:"endLine"`
+	Column      int    `json:"column"`
+	EndColumn   int    `json:"endColumn"`
+	Replacement string `json:"replacement"`
+}
+
+type shellcheckFix struct {
+	Replacements []shellcheckReplacement `json:"replacements"`
+}
+
 type shellcheckError struct {
-	Line    int    `json:"line"`
-	Column  int    `json:"column"`
-	Level   string `json:"level"`
-	Code    int    `json:"code"`
-	Message string `json:"message"`
+	Line    int            `json:"line"`
+	Column  int            `json:"column"`
+	Level   string         `json:"level"`
+	Code    int            `json:"code"`
+	Message string         `json:"message"`
+	Fix     *shellcheckFix `json:"fix"`
+}
+
+// severity returns the severity of the error. "style" level of shellcheck is treated as info.
+func (err *shellcheckError) severity() Severity {
+	if s, ok := ParseSeverity(err.Level); ok {
+		return s
+	}
+	return SeverityInfo
 }
 
 // RuleShellcheck is a rule to check shell scripts at 'run:' using shellcheck.
@@ -23,7 +44,10 @@ type RuleShellcheck struct {
 	workflowShell string
 	jobShell      string
 	runnerShell   string
-	mu            sync.Mutex
+	// lines is the lines of the workflow source. It is set only when fixing errors to apply the
+	// fixes suggested by shellcheck.
+	lines []string
+	mu    sync.Mutex
 }
 
 func newRuleShellcheck(cmd *externalCommand) *RuleShellcheck {
@@ -57,7 +81,7 @@ func (rule *RuleShellcheck) VisitStep(n *Step) error {
 		return nil
 	}
 
-	rule.runShellcheck(run.Run.Value, rule.getShellName(run), run.RunPos)
+	rule.runShellcheck(run.Run, rule.getShellName(run), run.RunPos)
 	return nil
 }
 
@@ -69,10 +93,9 @@ func (rule *RuleShellcheck) VisitJobPre(n *Job) error {
 
 	if n.RunsOn != nil {
 		for _, label := range n.RunsOn.Labels {
-			l := strings.ToLower(label.Value)
 			// Default shell on Windows is PowerShell.
 			// https://docs.github.com/en/actions/learn-github-actions/workflow-syntax-for-github-actions#using-a-specific-shell
-			if l == "windows" || strings.HasPrefix(l, "windows-") {
+			if os, ok := runnerOSOfLabel(label.Value); ok && os == RunnerOSWindows {
 				rule.runnerShell = "pwsh"
 				break
 			}
@@ -160,7 +183,53 @@ func sanitizeExpressionsInScript(src string) string {
 	}
 }
 
-func (rule *RuleShellcheck) runShellcheck(src, shell string, pos *Pos) {
+// shellcheckFixEdits converts the replacements of the fix suggested by shellcheck into the edits of
+// the workflow source. The script is mapped to the source only when it is written in a literal block
+// scalar ('|') since other styles may fold lines or contain escapes. Lines containing ${{ }} or tabs
+// are not fixed since their columns in the checked script may be different from the source. It
+// returns nil when some replacement cannot be mapped to the source.
+func shellcheckFixEdits(fix *shellcheckFix, run *String, sanitized string, lines []string) []*TextEdit {
+	if fix == nil || len(fix.Replacements) == 0 || run.Pos == nil {
+		return nil
+	}
+	l, c := run.Pos.Line, run.Pos.Col
+	if l < 1 || len(lines) < l {
+		return nil
+	}
+	if r := []rune(lines[l-1]); len(r) < c || r[c-1] != '|' {
+		return nil
+	}
+
+	orig := strings.Split(run.Value, "\n")
+	san := strings.Split(sanitized, "\n")
+	if len(orig) != len(san) {
+		return nil
+	}
+	pos := func(line, col int) *Pos {
+		i := line - 2 // Line 1 is the setup line added before the script
+		if i < 0 || len(orig) <= i || orig[i] != san[i] || strings.ContainsRune(orig[i], '\t') || len(lines) < l+1+i {
+			return nil
+		}
+		src := lines[l+i] // Body of the block scalar starts at the next line of "run:"
+		indent := len(src) - len(orig[i])
+		if indent < 0 || src[indent:] != orig[i] || strings.Trim(src[:indent], " ") != "" {
+			return nil
+		}
+		return &Pos{Line: l + 1 + i, Col: indent + col}
+	}
+
+	edits := make([]*TextEdit, 0, len(fix.Replacements))
+	for _, r := range fix.Replacements {
+		s, e := pos(r.Line, r.Column), pos(r.EndLine, r.EndColumn)
+		if s == nil || e == nil {
+			return nil
+		}
+		edits = append(edits, &TextEdit{Start: s, End: e, NewText: r.Replacement})
+	}
+	return edits
+}
+
+func (rule *RuleShellcheck) runShellcheck(run *String, shell string, pos *Pos) {
 	var sh string
 	if shell == "bash" || shell == "sh" {
 		sh = shell
@@ -172,7 +241,7 @@ func (rule *RuleShellcheck) runShellcheck(src, shell string, pos *Pos) {
 		return // Skip checking this shell script since shellcheck doesn't support it
 	}
 
-	src = sanitizeExpressionsInScript(src)
+	src := sanitizeExpressionsInScript(run.Value)
 	rule.Debug("%s: Run shellcheck for %s script:\n%s", pos, sh, src)
 
 	// Reasons to exclude the rules:
@@ -228,7 +297,15 @@ func (rule *RuleShellcheck) runShellcheck(src, shell string, pos *Pos) {
 			// Consider the first line is setup for running shell which was implicitly added for better check
 			line := err.Line - 1
 			msg := strings.TrimSuffix(err.Message, ".") // Trim period aligning style of error message
-			rule.Errorf(pos, "shellcheck reported issue in this script: SC%d:%s:%d:%d: %s", err.Code, err.Level, line, err.Column, msg)
+			var fixes []*TextEdit
+			if rule.lines != nil {
+				fixes = shellcheckFixEdits(err.Fix, run, src, rule.lines)
+			}
+			e := errorfAt(pos, rule.name, "shellcheck reported issue in this script: SC%d:%s:%d:%d: %s", err.Code, err.Level, line, err.Column, msg)
+			e.Code = fmt.Sprintf("SC%d", err.Code)
+			e.Severity = err.severity()
+			e.Fixes = fixes
+			rule.Report(e)
 		}
 
 		return nil
@@ -1,7 +1,9 @@
 package actionlint
 
 import (
+	"encoding/json"
 	"fmt"
+	"strings"
 	"testing"
 )
 
@@ -197,3 +199,73 @@ func TestRuleShellcheckDetectShell(t *testing.T) {
 		})
 	}
 }
+
+func TestRuleShellcheckFixEdits(t *testing.T) {
+	// Fix of SC2086 suggested by shellcheck for `echo $FOO` at the 2nd line of the script
+	out := `[{"line":3,"endLine":3,"column":6,"endColumn":10,"level":"info","code":2086,"message":"Double quote to prevent globbing and word splitting.","fix":{"replacements":[{"line":3,"endLine":3,"column":6,"endColumn":6,"insertionPoint":"afterEnd","precedence":7,"replacement":"\""},{"line":3,"endLine":3,"column":10,"endColumn":10,"insertionPoint":"beforeStart","precedence":7,"replacement":"\""}]}}]`
+	errs := []shellcheckError{}
+	if err := json.Unmarshal([]byte(out), &errs); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		what string
+		run  string
+		want string // Empty when the fix is not applied
+	}{
+		{
+			what: "literal block",
+			run:  "|\n          echo hello\n          echo $FOO\n",
+			want: "|\n          echo hello\n          echo \"$FOO\"\n",
+		},
+		{
+			what: "literal block with comment",
+			run:  "| # comment\n          echo hello\n          echo $FOO\n",
+			want: "| # comment\n          echo hello\n          echo \"$FOO\"\n",
+		},
+		{
+			what: "folded block",
+			run:  ">\n          echo hello\n\n          echo $FOO\n",
+		},
+		{
+			what: "expression in line",
+			run:  "|\n          echo hello\n          echo $FOO${{ env.BAR }}\n",
+		},
+		{
+			what: "tab in line",
+			run:  "|\n          echo hello\n          echo $FOO\t# comment\n",
+		},
+		{
+			what: "plain scalar",
+			run:  "echo hello; echo $FOO",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.what, func(t *testing.T) {
+			src := "on: push\njobs:\n  test:\n    runs-on: ubuntu-latest\n    steps:\n      - run: " + tc.run
+			w, perrs := Parse([]byte(src))
+			if len(perrs) > 0 {
+				t.Fatal(perrs)
+			}
+			run := w.Jobs["test"].Steps[0].Exec.(*ExecRun).Run
+			lines := strings.Split(src, "\n")
+
+			edits := shellcheckFixEdits(errs[0].Fix, run, sanitizeExpressionsInScript(run.Value), lines)
+			if tc.want == "" {
+				if edits != nil {
+					t.Fatalf("edits should not be created but got %v", edits)
+				}
+				return
+			}
+			b, err := ApplyTextEdits([]byte(src), edits)
+			if err != nil {
+				t.Fatal(err)
+			}
+			want := "on: push\njobs:\n  test:\n    runs-on: ubuntu-latest\n    steps:\n      - run: " + tc.want
+			if have := string(b); have != want {
+				t.Fatalf("wanted %q but got %q", want, have)
+			}
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package actionlint
+
+// RuleStepName is a rule to check duplicate step names in a job. Steps sharing the same name are
+// hard to distinguish in logs of the workflow run. The errors reported by this rule have the info
+// severity. This rule is enabled only when "duplicate-step-names" is configured.
+// https://docs.github.com/en/actions/writing-workflows/workflow-syntax-for-github-actions#jobsjob_idstepsname
+type RuleStepName struct {
+	RuleBase
+	seen map[string]*Pos
+}
+
+// NewRuleStepName creates a new RuleStepName instance.
+func NewRuleStepName() *RuleStepName {
+	return &RuleStepName{
+		RuleBase: RuleBase{
+			name: "step-name",
+			desc: "Checks for duplicate step names in a job. This rule is enabled by \"duplicate-step-names\" configuration",
+		},
+	}
+}
+
+// VisitJobPre is callback when visiting Job node before visiting its children.
+func (rule *RuleStepName) VisitJobPre(n *Job) error {
+	rule.seen = map[string]*Pos{}
+	return nil
+}
+
+// VisitJobPost is callback when visiting Job node after visiting its children.
+func (rule *RuleStepName) VisitJobPost(n *Job) error {
+	rule.seen = nil
+	return nil
+}
+
+// VisitStep is callback when visiting Step node.
+func (rule *RuleStepName) VisitStep(n *Step) error {
+	if n.Name == nil || n.Name.Value == "" {
+		return nil
+	}
+
+	if prev, ok := rule.seen[n.Name.Value]; ok {
+		e := errorfAt(
+			n.Name.Pos,
+			rule.name,
+			"step name %q duplicates. previously defined at %s. steps with the same name are hard to distinguish in logs of the workflow run",
+			n.Name.Value,
+			prev.String(),
+		)
+		e.Related = relatedAt(prev, "previously defined here")
+		e.Severity = SeverityInfo
+		rule.Report(e)
+		return nil
+	}
+	rule.seen[n.Name.Value] = n.Name.Pos
+	return nil
+}
@@ -50,6 +50,32 @@ func TestRuleBaseErrorfAndErrs(t *testing.T) {
 	}
 }
 
+func TestRuleBaseErrorfWithRelatedAndReport(t *testing.T) {
+	r := NewRuleBase("dummy-name", "")
+	r.ErrorfWithRelated(&Pos{Line: 3, Col: 4}, relatedAt(&Pos{Line: 1, Col: 2}, "previously defined here"), "this is test %d", 1)
+	r.Report(&Error{Message: "this is test 2", Line: 5, Column: 6, Code: "T002", Severity: SeverityInfo, Kind: "overwritten"})
+	want := []*Error{
+		{
+			Message: "this is test 1",
+			Line:    3,
+			Column:  4,
+			Kind:    "dummy-name",
+			Related: []*RelatedLocation{{Line: 1, Column: 2, Message: "previously defined here"}},
+		},
+		{
+			Message:  "this is test 2",
+			Line:     5,
+			Column:   6,
+			Kind:     "dummy-name",
+			Code:     "T002",
+			Severity: SeverityInfo,
+		},
+	}
+	if diff := cmp.Diff(want, r.Errs()); diff != "" {
+		t.Error("unexpected errors from Errs() method:", diff)
+	}
+}
+
 func TestRuleBaseDebugOutput(t *testing.T) {
 	r := NewRuleBase("dummy-name", "")
 	r.Debug("this %s output", "is not")
@@ -0,0 +1,96 @@
+package actionlint
+
+import (
+	"fmt"
+	"strings"
+)
+
+// RuleTimeoutMinutes is a rule to check jobs and steps without "timeout-minutes:". When a job hangs,
+// it keeps running until the default timeout (6 hours) and wastes the runner minutes. This rule is
+// opt-in. It is enabled only when "timeout-minutes" is configured in the configuration file.
+// https://docs.github.com/en/actions/writing-workflows/workflow-syntax-for-github-actions#jobsjob_idtimeout-minutes
+type RuleTimeoutMinutes struct {
+	RuleBase
+	max      float64
+	runLines int
+}
+
+// NewRuleTimeoutMinutes creates new RuleTimeoutMinutes instance with the given configuration.
+func NewRuleTimeoutMinutes(cfg *TimeoutMinutesConfig) *RuleTimeoutMinutes {
+	return &RuleTimeoutMinutes{
+		RuleBase: RuleBase{
+			name: "timeout-minutes",
+			desc: "Checks for jobs and steps without \"timeout-minutes:\". This rule is enabled by \"timeout-minutes\" configuration",
+		},
+		max:      cfg.Max,
+		runLines: cfg.RunLines,
+	}
+}
+
+func (rule *RuleTimeoutMinutes) hint() string {
+	if rule.max > 0 {
+		return fmt.Sprintf(" up to %g minutes", rule.max)
+	}
+	return ""
+}
+
+// VisitJobPre is callback when visiting Job node before visiting its children.
+func (rule *RuleTimeoutMinutes) VisitJobPre(n *Job) error {
+	if n.WorkflowCall != nil {
+		return nil // "timeout-minutes:" is not available on calling a reusable workflow
+	}
+	if n.TimeoutMinutes == nil {
+		rule.Errorf(
+			n.Pos,
+			"\"timeout-minutes:\" is not set for job %q. the job keeps running for %d minutes by default when something hangs. set \"timeout-minutes:\"%s to terminate the job earlier",
+			n.ID.Value,
+			maxJobExecMinutesGitHubHosted,
+			rule.hint(),
+		)
+		return nil
+	}
+	rule.checkMax(n.TimeoutMinutes, fmt.Sprintf("job %q", n.ID.Value))
+	return nil
+}
+
+// VisitStep is callback when visiting Step node.
+func (rule *RuleTimeoutMinutes) VisitStep(n *Step) error {
+	if n.TimeoutMinutes != nil {
+		what := "step"
+		if n.ID != nil {
+			what = fmt.Sprintf("step %q", n.ID.Value)
+		}
+		rule.checkMax(n.TimeoutMinutes, what)
+		return nil
+	}
+	if rule.runLines <= 0 {
+		return nil
+	}
+	e, ok := n.Exec.(*ExecRun)
+	if !ok || e.Run == nil {
+		return nil
+	}
+	if l := strings.Count(strings.TrimRight(e.Run.Value, "\n"), "\n") + 1; l >= rule.runLines {
+		rule.Errorf(
+			n.Pos,
+			"\"timeout-minutes:\" is not set for step whose script has %d lines. steps whose scripts have %d lines or more are required to set \"timeout-minutes:\"%s by the configuration",
+			l,
+			rule.runLines,
+			rule.hint(),
+		)
+	}
+	return nil
+}
+
+func (rule *RuleTimeoutMinutes) checkMax(t *Float, what string) {
+	if rule.max <= 0 || t.Expression != nil || t.Value <= rule.max {
+		return
+	}
+	rule.Errorf(
+		t.Pos,
+		"\"timeout-minutes:\" of %s is %g but it must be %g or less by the configuration",
+		what,
+		t.Value,
+		rule.max,
+	)
+}
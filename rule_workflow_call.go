@@ -2,15 +2,22 @@ package actionlint
 
 import (
 	"fmt"
+	"slices"
 	"strings"
 )
 
+// https://docs.github.com/en/actions/sharing-automations/reusing-workflows#nesting-reusable-workflows
+const maxReusableWorkflowNestingLevels = 10
+
 // RuleWorkflowCall is a rule checker to check workflow call at jobs.<job_id>.
 type RuleWorkflowCall struct {
 	RuleBase
 	workflowCallEventPos *Pos
 	workflowPath         string
 	cache                *LocalReusableWorkflowCache
+	callChains           map[string][]string
+	// remote is the GitHub repository for online checks. It is nil when online checks are disabled.
+	remote *RemoteRepository
 }
 
 // NewRuleWorkflowCall creates a new RuleWorkflowCall instance. 'workflowPath' is a file path to
@@ -24,6 +31,7 @@ func NewRuleWorkflowCall(workflowPath string, cache *LocalReusableWorkflowCache)
 		workflowCallEventPos: nil,
 		workflowPath:         workflowPath,
 		cache:                cache,
+		callChains:           map[string][]string{},
 	}
 }
 
@@ -38,6 +46,7 @@ func (rule *RuleWorkflowCall) VisitWorkflowPre(n *Workflow) error {
 			break
 		}
 	}
+	rule.cache.WriteLocalCalls(rule.workflowPath, n.Jobs)
 	return nil
 }
 
@@ -54,10 +63,18 @@ func (rule *RuleWorkflowCall) VisitJobPre(n *Job) error {
 
 	if isWorkflowCallUsesLocalFormat(u.Value) {
 		rule.checkWorkflowCallUsesLocal(n.WorkflowCall)
+		rule.checkCircularCall(u)
+		rule.checkNestingLevels(u)
 		return nil
 	}
 
 	if isWorkflowCallUsesRepoFormat(u.Value) {
+		if rule.remote != nil {
+			if owner, repo, _, ref, ok := parseRepoActionSpec(u.Value); ok {
+				checkRemoteRef(&rule.RuleBase, rule.remote, "reusable workflow", u.Value, owner, repo, ref, u.Pos)
+			}
+			rule.checkWorkflowCallUsesRemote(n.WorkflowCall)
+		}
 		return nil
 	}
 
@@ -87,6 +104,94 @@ func (rule *RuleWorkflowCall) checkWorkflowCallUsesLocal(call *WorkflowCall) {
 		rule.Debug("Skip workflow call %q since no metadata was found", u.Value)
 		return
 	}
+	rule.checkWorkflowCallInterface(call, m)
+	rule.checkInputValuesInCallee(call, m)
+}
+
+// checkInputValuesInCallee checks the literal values passed to the inputs of the local reusable
+// workflow with the comparisons of the inputs in the workflow like `if: inputs.env == 'prod'`. When
+// the value matches to none of the compared strings but it is close to one of them, the value is
+// likely a typo and the conditions depending on it never behave as intended.
+func (rule *RuleWorkflowCall) checkInputValuesInCallee(call *WorkflowCall, m *ReusableWorkflowMetadata) {
+	var cmps map[string][]*reusableWorkflowInputComparison
+	for n, i := range call.Inputs {
+		if i.Value == nil || i.Value.ContainsExpression() {
+			continue
+		}
+		if mi, ok := m.Inputs[n]; !ok || mi == nil {
+			continue
+		} else if _, ok := mi.Type.(StringType); !ok {
+			continue
+		}
+
+		if cmps == nil {
+			cmps = rule.cache.findInputComparisons(call.Uses.Value)
+			if cmps == nil {
+				return
+			}
+		}
+		cs := cmps[n]
+		if len(cs) == 0 {
+			continue
+		}
+
+		v := i.Value.Value
+		lits := make([]string, 0, len(cs))
+		for _, c := range cs {
+			// Comparison of strings is case-insensitive in expressions
+			if strings.EqualFold(c.value, v) {
+				lits = nil
+				break
+			}
+			lits = append(lits, strings.ToLower(c.value))
+		}
+		if lits == nil {
+			continue
+		}
+		s := similarNames(strings.ToLower(v), lits)
+		if len(s) == 0 {
+			continue
+		}
+		c := cs[slices.IndexFunc(cs, func(c *reusableWorkflowInputComparison) bool { return strings.EqualFold(c.value, s[0]) })]
+		var fixes []*TextEdit
+		if e := newReplaceStringEdit(i.Value, c.value); e != nil {
+			fixes = []*TextEdit{e}
+		}
+		rule.ErrorfWithFixes(
+			i.Value.Pos,
+			fixes,
+			"value %q passed to input %q of reusable workflow %q never matches the strings compared with the input in the workflow. did you mean %q compared at %s:%d:%d?",
+			v,
+			i.Name.Value,
+			call.Uses.Value,
+			c.value,
+			call.Uses.Value,
+			c.pos.Line,
+			c.pos.Col,
+		)
+	}
+}
+
+// checkWorkflowCallUsesRemote checks the reusable workflow call to other repository with the
+// workflow fetched via GitHub REST API in online mode.
+func (rule *RuleWorkflowCall) checkWorkflowCallUsesRemote(call *WorkflowCall) {
+	u := call.Uses
+	m, err := rule.remote.ReusableWorkflowMetadata(u.Value)
+	if err != nil {
+		rule.Error(u.Pos, err.Error())
+		return
+	}
+	if m == nil {
+		rule.Debug("Skip workflow call %q since the workflow could not be fetched", u.Value)
+		return
+	}
+	rule.checkWorkflowCallInterface(call, m)
+}
+
+// checkWorkflowCallInterface checks inputs and secrets of the reusable workflow call with the
+// metadata of the called workflow.
+func (rule *RuleWorkflowCall) checkWorkflowCallInterface(call *WorkflowCall, m *ReusableWorkflowMetadata) {
+	u := call.Uses
 
 	// Validate inputs
 	for n, i := range m.Inputs {
@@ -99,18 +204,18 @@ func (rule *RuleWorkflowCall) checkWorkflowCallUsesLocal(call *WorkflowCall) {
 	for n, i := range call.Inputs {
 		if _, ok := m.Inputs[n]; !ok {
 			note := "no input is defined"
-			if len(m.Inputs) > 0 {
-				is := make([]string, 0, len(m.Inputs))
-				for _, i := range m.Inputs {
-					is = append(is, i.Name)
-				}
+			is := make([]string, 0, len(m.Inputs))
+			for _, i := range m.Inputs {
+				is = append(is, i.Name)
+			}
+			if len(is) > 0 {
 				if len(is) == 1 {
 					note = fmt.Sprintf("defined input is %q", is[0])
 				} else {
 					note = "defined inputs are " + sortedQuotes(is)
 				}
 			}
-			rule.Errorf(i.Name.Pos, "input %q is not defined in %q reusable workflow. %s", i.Name.Value, u.Value, note)
+			rule.ErrorfWithFixes(i.Name.Pos, typoFixes(i.Name, is), "input %q is not defined in %q reusable workflow. %s", i.Name.Value, u.Value, note)
 		}
 	}
 
@@ -125,6 +230,10 @@ func (rule *RuleWorkflowCall) checkWorkflowCallUsesLocal(call *WorkflowCall) {
 		}
 		for n, s := range call.Secrets {
 			if _, ok := m.Secrets[n]; !ok {
+				if n == "inherit" {
+					rule.Errorf(s.Name.Pos, "\"secrets: inherit\" cannot be combined with explicit secrets. \"inherit\" is not a key of \"secrets:\" mapping but a string value. remove the explicit secrets to inherit all secrets, or list all secrets explicitly")
+					continue
+				}
 				note := "no secret is defined"
 				if len(m.Secrets) > 0 {
 					ss := make([]string, 0, len(m.Secrets))
@@ -140,11 +249,147 @@ func (rule *RuleWorkflowCall) checkWorkflowCallUsesLocal(call *WorkflowCall) {
 				rule.Errorf(s.Name.Pos, "secret %q is not defined in %q reusable workflow. %s", s.Name.Value, u.Value, note)
 			}
 		}
+	} else {
+		rule.checkInheritedSecrets(call, m)
 	}
 
 	rule.Debug("Validated reusable workflow %q", u.Value)
 }
 
+// checkInheritedSecrets checks the secrets required by the reusable workflow called with
+// "secrets: inherit" are defined in the caller's repository. The available secrets are taken from
+// "secrets" in the configuration file, or fetched via GitHub API in online mode.
+func (rule *RuleWorkflowCall) checkInheritedSecrets(call *WorkflowCall, m *ReusableWorkflowMetadata) {
+	if rule.workflowCallEventPos != nil {
+		return // Secrets inherited by this reusable workflow cannot be known
+	}
+
+	var defined []string
+	var where string
+	if rule.config != nil && rule.config.Secrets != nil {
+		defined, where = rule.config.Secrets, `"secrets" in the configuration file`
+	} else if rule.remote != nil {
+		defined, where = rule.remote.Secrets(""), fmt.Sprintf("repository %q or its organization. note: this check was done with GitHub API", rule.remote.FullName())
+	}
+	if defined == nil {
+		return
+	}
+
+	required := []string{}
+	for n, s := range m.Secrets {
+		if s.Required && n != "github_token" && !slices.ContainsFunc(defined, func(d string) bool { return strings.EqualFold(d, n) }) {
+			required = append(required, s.Name)
+		}
+	}
+	slices.Sort(required)
+
+	for _, n := range required {
+		e := errorfAt(
+			call.Uses.Pos,
+			rule.name,
+			"secret %q is required by %q reusable workflow but it is not defined in %s. the secret is inherited with \"secrets: inherit\" and is evaluated to an empty string",
+			n,
+			call.Uses.Value,
+			where,
+		)
+		e.Severity = SeverityWarning
+		rule.Report(e)
+	}
+}
+
+// longestCallChain returns the longest chain of local reusable workflow calls starting from the
+// workflow specified by 'spec'. Workflows in 'visiting' are skipped to stop at recursive calls.
+func (rule *RuleWorkflowCall) longestCallChain(spec string, visiting map[string]struct{}) []string {
+	if c, ok := rule.callChains[spec]; ok {
+		return c
+	}
+
+	visiting[spec] = struct{}{}
+	var longest []string
+	for _, c := range rule.cache.findLocalCalls(spec) {
+		if _, ok := visiting[c]; ok {
+			continue
+		}
+		if chain := rule.longestCallChain(c, visiting); len(chain) > len(longest) {
+			longest = chain
+		}
+	}
+	delete(visiting, spec)
+
+	chain := append([]string{spec}, longest...)
+	rule.callChains[spec] = chain
+	return chain
+}
+
+func (rule *RuleWorkflowCall) checkNestingLevels(uses *String) {
+	self, ok := rule.cache.convWorkflowPathToSpec(rule.workflowPath)
+	if !ok {
+		return
+	}
+
+	visiting := map[string]struct{}{self: {}}
+	chain := append([]string{self}, rule.longestCallChain(uses.Value, visiting)...)
+	if len(chain) <= maxReusableWorkflowNestingLevels {
+		return
+	}
+
+	rule.Errorf(
+		uses.Pos,
+		"reusable workflows are nested too deeply. at most %d levels of workflows can be connected but calling %q connects %d levels: %s. see https://docs.github.com/en/actions/sharing-automations/reusing-workflows#nesting-reusable-workflows",
+		maxReusableWorkflowNestingLevels,
+		uses.Value,
+		len(chain),
+		strings.Join(chain, " -> "),
+	)
+}
+
+// findCallCycle returns the shortest chain of local reusable workflow calls which starts from the
+// workflow specified by 'self', goes through the workflow specified by 'spec', and returns to 'self'.
+// It returns nil when calling 'spec' from 'self' does not form a cycle.
+func (rule *RuleWorkflowCall) findCallCycle(self, spec string) []string {
+	prev := map[string]string{spec: ""}
+	queue := []string{spec}
+	for len(queue) > 0 {
+		s := queue[0]
+		queue = queue[1:]
+		if s == self {
+			chain := []string{}
+			for ; s != ""; s = prev[s] {
+				chain = append(chain, s)
+			}
+			chain = append(chain, self)
+			slices.Reverse(chain)
+			return chain
+		}
+		for _, c := range rule.cache.findLocalCalls(s) {
+			if _, ok := prev[c]; !ok {
+				prev[c] = s
+				queue = append(queue, c)
+			}
+		}
+	}
+	return nil
+}
+
+func (rule *RuleWorkflowCall) checkCircularCall(uses *String) {
+	self, ok := rule.cache.convWorkflowPathToSpec(rule.workflowPath)
+	if !ok {
+		return
+	}
+
+	chain := rule.findCallCycle(self, uses.Value)
+	if chain == nil {
+		return
+	}
+
+	rule.Errorf(
+		uses.Pos,
+		"calling reusable workflow %q forms a cycle: %s. GitHub rejects circular calls of reusable workflows at runtime",
+		uses.Value,
+		strings.Join(chain, " -> "),
+	)
+}
+
 // Parse ./{path/{filename}
 // https://docs.github.com/en/actions/learn-github-actions/reusing-workflows#calling-a-reusable-workflow
 func isWorkflowCallUsesLocalFormat(u string) bool {
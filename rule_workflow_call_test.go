@@ -123,7 +123,7 @@ func TestRuleWorkflowCallWriteEventNodeToMetadataCache(t *testing.T) {
 	}
 
 	cwd := filepath.Join("path", "to", "project")
-	c := NewLocalReusableWorkflowCache(&Project{cwd, nil}, cwd, nil)
+	c := NewLocalReusableWorkflowCache(&Project{cwd, nil, nil, nil}, cwd, nil)
 	r := NewRuleWorkflowCall("test-workflow.yaml", c)
 
 	if err := r.VisitWorkflowPre(w); err != nil {
@@ -159,7 +159,7 @@ func TestRuleWorkflowCallWriteEventNodeToMetadataCache(t *testing.T) {
 
 func TestRuleWorkflowCallCheckReusableWorkflowCall(t *testing.T) {
 	cwd := filepath.Join("testdata", "reusable_workflow_metadata")
-	cache := NewLocalReusableWorkflowCache(&Project{cwd, nil}, cwd, nil)
+	cache := NewLocalReusableWorkflowCache(&Project{cwd, nil, nil, nil}, cwd, nil)
 
 	for i, md := range []*ReusableWorkflowMetadata{
 		// workflow0.yaml
@@ -256,6 +256,15 @@ func TestRuleWorkflowCallCheckReusableWorkflowCall(t *testing.T) {
 			secrets:        []string{"unknown_secret", "optional_secret"},
 			inheritSecrets: true,
 		},
+		{
+			what:    "inherit key mixed with explicit secrets",
+			uses:    "./workflow0.yaml",
+			inputs:  []string{"required_input"},
+			secrets: []string{"inherit", "required_secret"},
+			errs: []string{
+				"\"secrets: inherit\" cannot be combined with explicit secrets",
+			},
+		},
 		{
 			what:    "read workflow",
 			uses:    "./ok.yaml", // Defined in testdata/reusable_workflow_metadata/ok.yaml
@@ -383,3 +392,96 @@ func TestRuleWorkflowCallCheckReusableWorkflowCall(t *testing.T) {
 		})
 	}
 }
+
+func TestRuleWorkflowCallInheritedSecrets(t *testing.T) {
+	cwd := filepath.Join("testdata", "reusable_workflow_metadata")
+	cache := NewLocalReusableWorkflowCache(&Project{cwd, nil, nil, nil}, cwd, nil)
+	cache.writeCache("./workflow.yaml", &ReusableWorkflowMetadata{
+		Inputs:  ReusableWorkflowMetadataInputs{},
+		Outputs: ReusableWorkflowMetadataOutputs{},
+		Secrets: ReusableWorkflowMetadataSecrets{
+			"optional_secret": {"optional_secret", false},
+			"required_secret": {"REQUIRED_SECRET", true},
+			"other_secret":    {"other_secret", true},
+		},
+	})
+
+	tests := []struct {
+		what     string
+		secrets  []string
+		reusable bool
+		errs     []string
+	}{
+		{
+			what:    "all required secrets are defined",
+			secrets: []string{"required_secret", "OTHER_SECRET"},
+		},
+		{
+			what:    "missing required secrets",
+			secrets: []string{"optional_secret"},
+			errs: []string{
+				"secret \"REQUIRED_SECRET\" is required by \"./workflow.yaml\" reusable workflow but it is not defined in \"secrets\" in the configuration file",
+				"secret \"other_secret\" is required by \"./workflow.yaml\" reusable workflow but it is not defined in \"secrets\" in the configuration file",
+			},
+		},
+		{
+			what:    "no secret is defined",
+			secrets: []string{},
+			errs: []string{
+				"secret \"REQUIRED_SECRET\" is required",
+				"secret \"other_secret\" is required",
+			},
+		},
+		{
+			what: "check is disabled",
+		},
+		{
+			what:     "caller is reusable workflow",
+			secrets:  []string{},
+			reusable: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.what, func(t *testing.T) {
+			r := NewRuleWorkflowCall("this-workflow.yaml", cache)
+			r.SetConfig(&Config{Secrets: tc.secrets})
+
+			w := &Workflow{}
+			if tc.reusable {
+				w.On = []Event{&WorkflowCallEvent{Pos: &Pos{}}}
+			}
+			if err := r.VisitWorkflowPre(w); err != nil {
+				t.Fatal(err)
+			}
+
+			j := &Job{
+				WorkflowCall: &WorkflowCall{
+					Uses:           &String{Value: "./workflow.yaml", Pos: &Pos{}},
+					InheritSecrets: true,
+				},
+			}
+			if err := r.VisitJobPre(j); err != nil {
+				t.Fatal(err)
+			}
+
+			errs := []string{}
+			for _, err := range r.Errs() {
+				if err.Severity != SeverityWarning {
+					t.Errorf("severity of %q should be warning but got %v", err.Message, err.Severity)
+				}
+				errs = append(errs, err.Error())
+			}
+			sort.Strings(errs)
+
+			if len(errs) != len(tc.errs) {
+				t.Fatalf("%d errors were expected but got %d errors: %v", len(tc.errs), len(errs), errs)
+			}
+			for i, have := range errs {
+				if want := tc.errs[i]; !strings.Contains(have, want) {
+					t.Errorf("%d-th error is unexpected. %q should be contained in error message %q", i, want, have)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,146 @@
+package actionlint
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"regexp"
+	"regexp/syntax"
+	"slices"
+	"strings"
+)
+
+// isWorkflowTemplateFile returns true when the file is a workflow template in
+// ".github/workflow-templates" directory.
+// https://docs.github.com/en/actions/sharing-automations/creating-workflow-templates-for-your-organization
+func isWorkflowTemplateFile(path string) bool {
+	if e := filepath.Ext(path); e != ".yml" && e != ".yaml" {
+		return false
+	}
+	d := filepath.Dir(path)
+	return filepath.Base(d) == "workflow-templates" && filepath.Base(filepath.Dir(d)) == ".github"
+}
+
+// workflowTemplateProperties is the keys of the metadata file of workflow template and whether
+// their values are arrays of strings. Other values are strings.
+var workflowTemplateProperties = map[string]bool{
+	"name":         false,
+	"description":  false,
+	"iconName":     false,
+	"creator":      false,
+	"categories":   true,
+	"filePatterns": true,
+	"labels":       true,
+}
+
+// RuleWorkflowTemplate is a rule to check the metadata file "{name}.properties.json" of workflow
+// template in ".github/workflow-templates" directory. Workflow templates without valid metadata
+// files are not shown when creating a new workflow.
+// https://docs.github.com/en/actions/sharing-automations/creating-workflow-templates-for-your-organization
+type RuleWorkflowTemplate struct {
+	RuleBase
+	path string
+	proj *Project
+}
+
+// NewRuleWorkflowTemplate creates a new RuleWorkflowTemplate instance. 'path' is the file path of
+// the workflow template. 'proj' is used to read the metadata file and can be nil.
+func NewRuleWorkflowTemplate(path string, proj *Project) *RuleWorkflowTemplate {
+	return &RuleWorkflowTemplate{
+		RuleBase: RuleBase{
+			name: "workflow-template",
+			desc: "Checks for metadata files of workflow templates in \".github/workflow-templates\" directory",
+		},
+		path: path,
+		proj: proj,
+	}
+}
+
+// VisitWorkflowPre is callback when visiting Workflow node before visiting its children.
+func (rule *RuleWorkflowTemplate) VisitWorkflowPre(n *Workflow) error {
+	pos := &Pos{Line: 1, Col: 1}
+	dir := filepath.Dir(rule.path)
+	name := strings.TrimSuffix(filepath.Base(rule.path), filepath.Ext(rule.path)) + ".properties.json"
+
+	b, err := rule.proj.readFile(filepath.Join(dir, name))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			rule.Errorf(pos, "metadata file %q of workflow template is not found in the same directory. the template is not shown when creating a new workflow without it", name)
+		} else {
+			rule.Errorf(pos, "could not read metadata file %q of workflow template: %v", name, err)
+		}
+		return nil
+	}
+
+	var props map[string]json.RawMessage
+	if err := json.Unmarshal(b, &props); err != nil {
+		rule.Errorf(pos, "could not parse metadata file %q of workflow template: %v", name, err)
+		return nil
+	}
+	for _, msg := range rule.validate(dir, props) {
+		rule.Errorf(pos, "metadata file %q of workflow template is invalid: %s", name, msg)
+	}
+	return nil
+}
+
+// validate validates the properties in the metadata file and returns the error messages.
+func (rule *RuleWorkflowTemplate) validate(dir string, props map[string]json.RawMessage) []string {
+	errs := []string{}
+	for _, k := range []string{"name", "description"} {
+		if _, ok := props[k]; !ok {
+			errs = append(errs, fmt.Sprintf("%q is required", k))
+		}
+	}
+
+	strs := map[string]string{}
+	arrs := map[string][]string{}
+	for _, k := range slices.Sorted(maps.Keys(props)) {
+		arr, ok := workflowTemplateProperties[k]
+		if !ok {
+			errs = append(errs, fmt.Sprintf("unexpected key %q. expected one of %s", k, sortedQuotes(slices.Collect(maps.Keys(workflowTemplateProperties)))))
+			continue
+		}
+		if arr {
+			var v []string
+			if err := json.Unmarshal(props[k], &v); err != nil {
+				errs = append(errs, fmt.Sprintf("%q must be an array of strings", k))
+				continue
+			}
+			arrs[k] = v
+		} else {
+			var v string
+			if err := json.Unmarshal(props[k], &v); err != nil {
+				errs = append(errs, fmt.Sprintf("%q must be a string", k))
+				continue
+			}
+			strs[k] = v
+		}
+	}
+
+	for _, k := range []string{"name", "description"} {
+		if v, ok := strs[k]; ok && strings.TrimSpace(v) == "" {
+			errs = append(errs, fmt.Sprintf("%q must not be empty", k))
+		}
+	}
+
+	// Icon is an SVG file in the same directory or an octicon like "octicon person"
+	if icon, ok := strs["iconName"]; ok && icon != "" && !strings.HasPrefix(icon, "octicon ") {
+		f := icon + ".svg"
+		if _, err := rule.proj.stat(filepath.Join(dir, f)); err != nil {
+			errs = append(errs, fmt.Sprintf("icon file %q for \"iconName\" is not found in the same directory. put the SVG file or use octicon like \"octicon smiley\"", f))
+		}
+	}
+
+	for _, p := range arrs["filePatterns"] {
+		if _, err := regexp.Compile(p); err != nil {
+			if serr := (*syntax.Error)(nil); errors.As(err, &serr) && slices.Contains(problemMatcherSyntaxErrors, serr.Code) {
+				errs = append(errs, fmt.Sprintf("regular expression %q in \"filePatterns\" is invalid: %s", p, serr.Code))
+			}
+		}
+	}
+
+	return errs
+}
@@ -0,0 +1,134 @@
+package actionlint
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestRuleWorkflowTemplateIsTemplateFile(t *testing.T) {
+	for _, tc := range []struct {
+		path string
+		want bool
+	}{
+		{filepath.Join(".github", "workflow-templates", "ci.yml"), true},
+		{filepath.Join("repo", ".github", "workflow-templates", "ci.yaml"), true},
+		{filepath.Join(".github", "workflow-templates", "ci.properties.json"), false},
+		{filepath.Join(".github", "workflows", "ci.yml"), false},
+		{filepath.Join("workflow-templates", "ci.yml"), false},
+	} {
+		if have := isWorkflowTemplateFile(tc.path); have != tc.want {
+			t.Errorf("wanted %v for %q but got %v", tc.want, tc.path, have)
+		}
+	}
+}
+
+func TestRuleWorkflowTemplateMetadata(t *testing.T) {
+	tests := []struct {
+		what  string
+		props string
+		want  []string
+	}{
+		{
+			what:  "valid",
+			props: `{"name": "CI", "description": "CI", "iconName": "ci", "categories": ["Go"], "filePatterns": ["go\\.mod$"], "creator": "me", "labels": ["preview"]}`,
+		},
+		{
+			what:  "octicon",
+			props: `{"name": "CI", "description": "CI", "iconName": "octicon check"}`,
+		},
+		{
+			what:  "missing required keys",
+			props: `{}`,
+			want:  []string{`"name" is required`, `"description" is required`},
+		},
+		{
+			what:  "empty values",
+			props: `{"name": "", "description": " "}`,
+			want:  []string{`"name" must not be empty`, `"description" must not be empty`},
+		},
+		{
+			what:  "invalid types",
+			props: `{"name": 1, "description": "CI", "categories": "Go"}`,
+			want:  []string{`"categories" must be an array of strings`, `"name" must be a string`},
+		},
+		{
+			what:  "unexpected key",
+			props: `{"name": "CI", "description": "CI", "icon": "ci"}`,
+			want:  []string{`unexpected key "icon". expected one of "categories", "creator", "description", "filePatterns", "iconName", "labels", "name"`},
+		},
+		{
+			what:  "missing icon",
+			props: `{"name": "CI", "description": "CI", "iconName": "missing"}`,
+			want:  []string{`icon file "missing.svg" for "iconName" is not found in the same directory`},
+		},
+		{
+			what:  "invalid file pattern",
+			props: `{"name": "CI", "description": "CI", "filePatterns": ["(foo", "(?=lookahead)"]}`,
+			want:  []string{`regular expression "(foo" in "filePatterns" is invalid: missing closing )`},
+		},
+		{
+			what:  "broken JSON",
+			props: `{"name": `,
+			want:  []string{`could not parse metadata file "ci.properties.json" of workflow template`},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.what, func(t *testing.T) {
+			fsys := fstest.MapFS{
+				".github/workflow-templates/ci.properties.json": {Data: []byte(tc.props)},
+				".github/workflow-templates/ci.svg":             {Data: []byte("<svg></svg>")},
+			}
+			p, err := NewProjectFS(fsys, ".")
+			if err != nil {
+				t.Fatal(err)
+			}
+			r := NewRuleWorkflowTemplate(filepath.Join(".github", "workflow-templates", "ci.yml"), p)
+			if err := r.VisitWorkflowPre(&Workflow{}); err != nil {
+				t.Fatal(err)
+			}
+			errs := r.Errs()
+			if len(errs) != len(tc.want) {
+				t.Fatalf("wanted %d errors but got %v", len(tc.want), errs)
+			}
+			for i, want := range tc.want {
+				if msg := errs[i].Message; !strings.Contains(msg, want) {
+					t.Errorf("error message %q does not contain %q", msg, want)
+				}
+			}
+		})
+	}
+}
+
+func TestRuleWorkflowTemplateMissingMetadata(t *testing.T) {
+	p, err := NewProjectFS(fstest.MapFS{}, ".")
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := NewRuleWorkflowTemplate(filepath.Join(".github", "workflow-templates", "release.yaml"), p)
+	if err := r.VisitWorkflowPre(&Workflow{}); err != nil {
+		t.Fatal(err)
+	}
+	errs := r.Errs()
+	if len(errs) != 1 || !strings.Contains(errs[0].Message, `metadata file "release.properties.json" of workflow template is not found`) {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+}
+
+func TestRuleEventsCronPlaceholderInWorkflowTemplate(t *testing.T) {
+	cron := &String{Value: "$cron-daily", Pos: &Pos{}}
+	r := NewRuleEvents()
+	r.template = true
+	r.checkCron(cron)
+	if errs := r.Errs(); len(errs) > 0 {
+		t.Fatalf("placeholder should be accepted in workflow template: %v", errs)
+	}
+
+	r = NewRuleEvents()
+	r.checkCron(cron)
+	if errs := r.Errs(); len(errs) != 1 || !strings.Contains(errs[0].Message, "invalid CRON format") {
+		t.Fatalf("placeholder should not be accepted outside workflow template: %v", errs)
+	}
+}
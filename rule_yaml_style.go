@@ -0,0 +1,230 @@
+package actionlint
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"go.yaml.in/yaml/v4"
+)
+
+// yamlTruthyValues is a set of plain scalars which are parsed as booleans in YAML 1.1. GitHub Actions
+// parses them as strings since it follows YAML 1.2, but other YAML tools may not.
+// https://yaml.org/type/bool.html
+var yamlTruthyValues = map[string]struct{}{
+	"y":   {},
+	"Y":   {},
+	"yes": {},
+	"Yes": {},
+	"YES": {},
+	"n":   {},
+	"N":   {},
+	"no":  {},
+	"No":  {},
+	"NO":  {},
+	"on":  {},
+	"On":  {},
+	"ON":  {},
+	"off": {},
+	"Off": {},
+	"OFF": {},
+}
+
+// Start of heredoc like `<<EOS`, `<<-EOS`, `<< 'EOS'`, or `<<"EOS"`. Here strings `<<<` are excluded.
+var yamlStyleHeredocPattern = regexp.MustCompile(`(?:^|[^<])<<(-?)[ \t]*['"]?([A-Za-z_][A-Za-z0-9_]*)['"]?`)
+
+// RuleYAMLStyle is a rule to check the style of YAML in workflow files. It checks the consistency
+// of indentation, unquoted YAML 1.1 booleans like `yes` or `on`, anchors and aliases, and trailing
+// whitespaces in "run:" scripts which change the behavior of the scripts. Duplicate keys are not
+// checked by this rule since they are always reported as syntax errors. This rule is opt-in. It is
+// enabled only when "yaml-style" is configured in the configuration file.
+type RuleYAMLStyle struct {
+	RuleBase
+	content []byte
+	lines   []string
+	// indent is the number of spaces of one indentation level. 0 means it is not determined yet.
+	indent        int
+	fixedIndent   bool
+	forbidAnchors bool
+}
+
+// NewRuleYAMLStyle creates new RuleYAMLStyle instance with the given configuration to check the
+// workflow content.
+func NewRuleYAMLStyle(cfg *YAMLStyleConfig, content []byte) *RuleYAMLStyle {
+	return &RuleYAMLStyle{
+		RuleBase: RuleBase{
+			name: "yaml-style",
+			desc: "Checks for style of YAML such as indentation, unquoted booleans, and anchors. This rule is enabled by \"yaml-style\" configuration",
+		},
+		content:       content,
+		indent:        cfg.Indent,
+		fixedIndent:   cfg.Indent > 0,
+		forbidAnchors: cfg.ForbidAnchors,
+	}
+}
+
+// VisitWorkflowPre is callback when visiting Workflow node before visiting its children.
+func (rule *RuleYAMLStyle) VisitWorkflowPre(n *Workflow) error {
+	// Workflow AST does not preserve styles of YAML nodes so parse the content again
+	var root yaml.Node
+	if err := yaml.Unmarshal(rule.content, &root); err != nil {
+		return nil // Syntax errors were already reported by parser
+	}
+	rule.lines = strings.Split(string(rule.content), "\n")
+	for _, c := range root.Content {
+		rule.checkNode(c, true)
+	}
+	return nil
+}
+
+func (rule *RuleYAMLStyle) checkNode(n *yaml.Node, top bool) {
+	if n.Anchor != "" && rule.forbidAnchors {
+		rule.Errorf(posAt(n), "anchor %q is not allowed by the configuration. anchors and aliases make workflows hard to read", n.Anchor)
+	}
+
+	switch n.Kind {
+	case yaml.AliasNode:
+		// Don't visit the aliased node not to report the same errors twice
+		if rule.forbidAnchors {
+			rule.Errorf(posAt(n), "alias %q is not allowed by the configuration. anchors and aliases make workflows hard to read", n.Value)
+		}
+	case yaml.ScalarNode:
+		rule.checkTruthy(n, false)
+	case yaml.SequenceNode:
+		for _, c := range n.Content {
+			rule.checkNode(c, false)
+		}
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			k, v := n.Content[i], n.Content[i+1]
+			// `on:` at top level is a well-known key of workflow. It is not confusing
+			if !top || k.Value != "on" {
+				rule.checkTruthy(k, true)
+			}
+			if n.Style&yaml.FlowStyle == 0 {
+				rule.checkIndent(k, v)
+			}
+			if k.Value == "run" {
+				rule.checkScript(v)
+			}
+			rule.checkNode(v, false)
+		}
+	}
+}
+
+func (rule *RuleYAMLStyle) checkTruthy(n *yaml.Node, key bool) {
+	if n.Kind != yaml.ScalarNode || n.Style != 0 {
+		return // Quoted or tagged scalars are not confusing
+	}
+	if _, ok := yamlTruthyValues[n.Value]; !ok {
+		return
+	}
+	what := "value"
+	if key {
+		what = "key"
+	}
+	rule.Errorf(
+		posAt(n),
+		"unquoted %s %q is a string on GitHub Actions but it is a boolean in YAML 1.1. quote it like '%s' to avoid confusion, or use true or false if a boolean is intended",
+		what,
+		n.Value,
+		n.Value,
+	)
+}
+
+// checkIndent checks the indentation of the block mapping or block sequence at the value of the
+// key.
+func (rule *RuleYAMLStyle) checkIndent(k, v *yaml.Node) {
+	if v.Style&yaml.FlowStyle != 0 || v.Line <= k.Line {
+		return
+	}
+	seq := false
+	switch v.Kind {
+	case yaml.MappingNode:
+	case yaml.SequenceNode:
+		seq = true
+	default:
+		return
+	}
+
+	width := v.Column - k.Column
+	if seq && width == 0 {
+		return // Sequences are allowed not to be indented like "steps:\n- run: ..."
+	}
+	if rule.indent == 0 {
+		rule.indent = width // Determine the indentation by the first indented block
+		return
+	}
+	if width == rule.indent {
+		return
+	}
+
+	expected := fmt.Sprintf("%d spaces", rule.indent)
+	if seq {
+		expected = fmt.Sprintf("%d or 0 spaces", rule.indent)
+	}
+	reason := "by the configuration"
+	if !rule.fixedIndent {
+		reason = "to be consistent with other blocks in the file"
+	}
+	rule.Errorf(posAt(v), "indentation of this block is %d spaces but it must be %s %s", width, expected, reason)
+}
+
+// checkScript checks trailing whitespaces in the block scalar at "run:" which change the behavior
+// of the script. The trailing whitespaces are preserved in the script but they are hard to notice.
+func (rule *RuleYAMLStyle) checkScript(n *yaml.Node) {
+	if n.Kind != yaml.ScalarNode || n.Style&yaml.LiteralStyle == 0 {
+		return
+	}
+
+	heredocs := []string{} // Delimiters of heredocs which are not terminated yet
+	indented := []bool{}   // Whether the heredoc started with `<<-`
+	for i, l := range strings.Split(n.Value, "\n") {
+		line := n.Line + 1 + i // The body of block scalar starts at the next line of its header
+
+		if len(heredocs) > 0 {
+			d := heredocs[0]
+			t := l
+			if indented[0] {
+				t = strings.TrimLeft(t, "\t")
+			}
+			if t == d {
+				heredocs, indented = heredocs[1:], indented[1:]
+				continue
+			}
+			if strings.TrimRight(t, " \t") == d {
+				rule.Errorf(
+					rule.posInLine(line, d),
+					"delimiter %q of heredoc has trailing whitespaces. the heredoc is not terminated at this line",
+					d,
+				)
+				heredocs, indented = heredocs[1:], indented[1:]
+			}
+			continue
+		}
+
+		for _, m := range yamlStyleHeredocPattern.FindAllStringSubmatch(l, -1) {
+			heredocs = append(heredocs, m[2])
+			indented = append(indented, m[1] == "-")
+		}
+
+		if t := strings.TrimRight(l, " \t"); t != l && strings.HasSuffix(t, "\\") {
+			rule.Errorf(
+				rule.posInLine(line, "\\ "),
+				"backslash at end of line is followed by trailing whitespaces. the line is not continued to the next line in the script",
+			)
+		}
+	}
+}
+
+// posInLine returns the position of the last occurrence of the substring in the line. When the
+// substring is not found, the position of the line is returned.
+func (rule *RuleYAMLStyle) posInLine(line int, sub string) *Pos {
+	col := 1
+	if line-1 < len(rule.lines) {
+		if i := strings.LastIndex(strings.ReplaceAll(rule.lines[line-1], "\t", " "), sub); i >= 0 {
+			col = i + 1
+		}
+	}
+	return &Pos{Line: line, Col: col}
+}
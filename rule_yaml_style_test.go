@@ -0,0 +1,118 @@
+package actionlint
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRuleYAMLStyleConsistentIndent(t *testing.T) {
+	tests := []struct {
+		what string
+		src  string
+		want []string
+	}{
+		{
+			what: "consistent",
+			src: `on: push
+jobs:
+    test:
+        runs-on: ubuntu-latest
+        steps:
+        -   run: echo hi
+`,
+		},
+		{
+			what: "inconsistent mapping",
+			src: `on: push
+jobs:
+  test:
+    runs-on: ubuntu-latest
+    env:
+       FOO: bar
+`,
+			want: []string{"6:8: indentation of this block is 3 spaces but it must be 2 spaces to be consistent"},
+		},
+		{
+			what: "inconsistent sequence",
+			src: `on: push
+jobs:
+  test:
+    runs-on: ubuntu-latest
+    steps:
+        - run: echo hi
+`,
+			want: []string{"6:9: indentation of this block is 4 spaces but it must be 2 or 0 spaces to be consistent"},
+		},
+		{
+			what: "flow style is not checked",
+			src: `on: push
+jobs:
+  test:
+    runs-on: [
+          ubuntu-latest
+    ]
+    steps: [{run: echo hi}]
+`,
+		},
+		{
+			what: "quoted and tagged booleans",
+			src: `"on": push
+jobs:
+  test:
+    runs-on: ubuntu-latest
+    env:
+      A: "yes"
+      B: !!str no
+      C: true
+      D: onward
+`,
+		},
+		{
+			what: "anchors are allowed by default",
+			src: `on: push
+jobs:
+  test:
+    runs-on: ubuntu-latest
+    env: &env
+      A: B
+  test2:
+    runs-on: ubuntu-latest
+    env: *env
+`,
+		},
+		{
+			what: "terminated heredocs",
+			src: `on: push
+jobs:
+  test:
+    runs-on: ubuntu-latest
+    steps:
+      - run: |
+          cat <<EOS
+          \
+          EOS
+          cat <<< 'here string'
+          echo ok
+`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.what, func(t *testing.T) {
+			r := NewRuleYAMLStyle(&YAMLStyleConfig{}, []byte(tc.src))
+			if err := r.VisitWorkflowPre(&Workflow{}); err != nil {
+				t.Fatal(err)
+			}
+			errs := r.Errs()
+			if len(errs) != len(tc.want) {
+				t.Fatalf("wanted %d errors but got %d: %v", len(tc.want), len(errs), errs)
+			}
+			for i, want := range tc.want {
+				have := errs[i].Error()
+				if !strings.Contains(have, want) {
+					t.Errorf("error %q does not contain %q", have, want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,263 @@
+package actionlint
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// zizmorFinding is a finding in JSON output of zizmor.
+// https://docs.zizmor.sh/usage/#output-formats
+type zizmorFinding struct {
+	Ident          string `json:"ident"`
+	Desc           string `json:"desc"`
+	URL            string `json:"url"`
+	Determinations struct {
+		Confidence string `json:"confidence"`
+		Severity   string `json:"severity"`
+	} `json:"determinations"`
+	Locations []*zizmorLocation `json:"locations"`
+	Ignored   bool              `json:"ignored"`
+}
+
+type zizmorLocation struct {
+	Symbolic struct {
+		Key struct {
+			Local *struct {
+				GivenPath string `json:"given_path"`
+			} `json:"Local"`
+		} `json:"key"`
+		Annotation string `json:"annotation"`
+		Kind       string `json:"kind"`
+	} `json:"symbolic"`
+	Concrete struct {
+		Location struct {
+			// Note: Row and column are 0-based
+			StartPoint struct {
+				Row    int `json:"row"`
+				Column int `json:"column"`
+			} `json:"start_point"`
+		} `json:"location"`
+	} `json:"concrete"`
+}
+
+// severity returns the severity of the finding. High and medium severities of zizmor are treated as
+// errors.
+func (f *zizmorFinding) severity() Severity {
+	switch strings.ToLower(f.Determinations.Severity) {
+	case "high", "medium":
+		return SeverityError
+	case "low":
+		return SeverityWarning
+	default:
+		return SeverityInfo
+	}
+}
+
+// primaryLocation returns the location where the finding should be reported.
+func (f *zizmorFinding) primaryLocation() *zizmorLocation {
+	for _, l := range f.Locations {
+		if l.Symbolic.Kind == "Primary" {
+			return l
+		}
+	}
+	if len(f.Locations) > 0 {
+		return f.Locations[0]
+	}
+	return nil
+}
+
+func (l *zizmorLocation) path() string {
+	if l.Symbolic.Key.Local == nil {
+		return ""
+	}
+	return l.Symbolic.Key.Local.GivenPath
+}
+
+func parseZizmorFindings(b []byte) ([]*zizmorFinding, error) {
+	var fs []*zizmorFinding
+	if err := json.Unmarshal(b, &fs); err != nil {
+		return nil, fmt.Errorf("could not parse JSON output from zizmor: %w", err)
+	}
+	return fs, nil
+}
+
+// readZizmorFindings reads the findings from JSON file output by "zizmor --format json".
+func readZizmorFindings(path string) ([]*zizmorFinding, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not read zizmor results file %q: %w", path, err)
+	}
+	fs, err := parseZizmorFindings(b)
+	if err != nil {
+		return nil, fmt.Errorf("%w: file=%q", err, path)
+	}
+	return fs, nil
+}
+
+// zizmorDuplicates is a table from identifiers of zizmor audits to the predicates to know the
+// actionlint's error reports the same issue. Findings of zizmor are not reported when actionlint
+// reports the same issue at the same line.
+// https://docs.zizmor.sh/audits/
+var zizmorDuplicates = map[string]func(*Error) bool{
+	"template-injection": func(err *Error) bool {
+		return err.Kind == "expression" && strings.Contains(err.Message, "is potentially untrusted")
+	},
+	"archived-uses": func(err *Error) bool {
+		return err.Kind == "action" && strings.Contains(err.Message, "is archived")
+	},
+}
+
+// RuleZizmor is a rule to merge security findings of zizmor into actionlint's errors. zizmor is run
+// for each workflow file, or the findings are read from JSON file output by zizmor in advance.
+// Findings which overlap with actionlint's checks are de-duplicated. This rule is disabled by
+// default and enabled by "-zizmor" or "-zizmor-results" flag.
+// https://github.com/zizmorcore/zizmor
+type RuleZizmor struct {
+	RuleBase
+	cmd     *externalCommand
+	path    string
+	content []byte
+	// findings is the findings read from the JSON file. It is nil when zizmor is run.
+	findings []*zizmorFinding
+	// dir is a temporary directory to put the workflow file while zizmor is running.
+	dir string
+	mu  sync.Mutex
+}
+
+func newRuleZizmor(cmd *externalCommand, path string, content []byte, findings []*zizmorFinding) *RuleZizmor {
+	return &RuleZizmor{
+		RuleBase: RuleBase{
+			name: "zizmor",
+			desc: "Checks for security issues reported by zizmor",
+		},
+		cmd:      cmd,
+		path:     path,
+		content:  content,
+		findings: findings,
+	}
+}
+
+// NewRuleZizmor creates new RuleZizmor instance which runs zizmor to check the workflow file at the
+// path with the content. Parameter executable can be command name or relative/absolute file path.
+// When the given executable is not found in system, it returns an error.
+func NewRuleZizmor(executable string, proc *concurrentProcess, path string, content []byte) (*RuleZizmor, error) {
+	cmd, err := proc.newCommandRunner(executable, false)
+	if err != nil {
+		return nil, err
+	}
+	return newRuleZizmor(cmd, path, content, nil), nil
+}
+
+// newRuleZizmorWithFindings creates new RuleZizmor instance which reports the findings of zizmor
+// read in advance. Only findings for the workflow file at the path are reported.
+func newRuleZizmorWithFindings(path string, findings []*zizmorFinding) *RuleZizmor {
+	return newRuleZizmor(nil, path, nil, findings)
+}
+
+func isSameFilePath(a, b string) bool {
+	if a == b {
+		return true
+	}
+	a, errA := filepath.Abs(a)
+	b, errB := filepath.Abs(b)
+	return errA == nil && errB == nil && a == b
+}
+
+func (rule *RuleZizmor) report(fs []*zizmorFinding, path string) {
+	for _, f := range fs {
+		if f.Ignored {
+			continue
+		}
+		l := f.primaryLocation()
+		if l == nil || path != "" && !isSameFilePath(l.path(), path) {
+			continue
+		}
+		pos := &Pos{Line: l.Concrete.Location.StartPoint.Row + 1, Col: l.Concrete.Location.StartPoint.Column + 1}
+		msg := f.Desc
+		if a := l.Symbolic.Annotation; a != "" {
+			msg += ": " + a
+		}
+		e := errorfAt(pos, rule.name, "zizmor reported %q finding with %s severity: %s. see %s", f.Ident, strings.ToLower(f.Determinations.Severity), msg, f.URL)
+		e.Code = f.Ident // Identifier of the audit like "template-injection"
+		e.Severity = f.severity()
+		rule.Report(e)
+	}
+}
+
+// VisitWorkflowPre is callback when visiting Workflow node before visiting its children.
+func (rule *RuleZizmor) VisitWorkflowPre(n *Workflow) error {
+	if rule.cmd == nil {
+		rule.report(rule.findings, rule.path)
+		return nil
+	}
+
+	// zizmor reads workflows only from files. Write the content to a temporary file since the
+	// workflow may be read from stdin or may not be saved yet.
+	d, f, err := writeWorkflowToTempFile(rule.path, rule.content)
+	if err != nil {
+		return fmt.Errorf("could not prepare workflow file to run zizmor: %w", err)
+	}
+	rule.dir = d
+
+	args := []string{"--format", "json", f}
+	rule.Debug("Running %s command with %s", rule.cmd.exe, args)
+
+	rule.cmd.run(args, "", func(stdout []byte, err error) error {
+		if err != nil {
+			rule.Debug("Command %s %s failed: %v", rule.cmd.exe, args, err)
+			return fmt.Errorf("`%s %s` did not run successfully while checking workflow %s: %w", rule.cmd.exe, strings.Join(args, " "), rule.path, err)
+		}
+		fs, err := parseZizmorFindings(stdout)
+		if err != nil {
+			return fmt.Errorf("%w: stdout=%q", err, stdout)
+		}
+
+		// Synchronize rule.Errorf calls
+		rule.mu.Lock()
+		defer rule.mu.Unlock()
+		rule.report(fs, "") // All findings are for the temporary workflow file
+		return nil
+	})
+
+	return nil
+}
+
+// VisitWorkflowPost is callback when visiting Workflow node after visiting its children.
+func (rule *RuleZizmor) VisitWorkflowPost(n *Workflow) error {
+	if rule.cmd == nil {
+		return nil
+	}
+	err := rule.cmd.wait() // Wait until the process running for this rule finishes
+	if rule.dir != "" {
+		os.RemoveAll(rule.dir)
+		rule.dir = ""
+	}
+	return err
+}
+
+// removeDuplicates removes errors reported by this rule which overlap with the errors reported by
+// other rules at the same line.
+func (rule *RuleZizmor) removeDuplicates(errs []*Error) []*Error {
+	if len(rule.errs) == 0 {
+		return errs
+	}
+	ret := make([]*Error, 0, len(errs))
+	for _, err := range errs {
+		if err.Kind != rule.name {
+			ret = append(ret, err)
+			continue
+		}
+		if dup, ok := zizmorDuplicates[err.Code]; ok && slices.ContainsFunc(errs, func(e *Error) bool {
+			return e.Line == err.Line && dup(e)
+		}) {
+			continue
+		}
+		ret = append(ret, err)
+	}
+	return ret
+}
@@ -0,0 +1,168 @@
+package actionlint
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+const testZizmorWorkflow = `on: pull_request_target
+jobs:
+  test:
+    runs-on: ubuntu-latest
+    steps:
+      - uses: actions/checkout@v4
+      - run: echo '${{ github.event.pull_request.title }}'
+`
+
+func testZizmorFindingJSON(ident, path string, row, col int) string {
+	return fmt.Sprintf(`{
+  "ident": %q,
+  "desc": "description of %s",
+  "url": "https://docs.zizmor.sh/audits/#%s",
+  "determinations": {"confidence": "High", "severity": "High", "persona": "Regular"},
+  "locations": [
+    {
+      "symbolic": {"key": {"Local": {"prefix": ".", "given_path": %q}}, "annotation": "related", "kind": "Related"},
+      "concrete": {"location": {"start_point": {"row": 0, "column": 0}, "end_point": {"row": 0, "column": 3}}}
+    },
+    {
+      "symbolic": {"key": {"Local": {"prefix": ".", "given_path": %q}}, "annotation": "annotation of %s", "kind": "Primary"},
+      "concrete": {"location": {"start_point": {"row": %d, "column": %d}, "end_point": {"row": %d, "column": %d}}}
+    }
+  ],
+  "ignored": false
+}`, ident, ident, ident, path, path, ident, row, col, row, col+1)
+}
+
+func TestRuleZizmorParseFindings(t *testing.T) {
+	src := "[" + testZizmorFindingJSON("dangerous-triggers", "test.yaml", 0, 0) + "]"
+	fs, err := parseZizmorFindings([]byte(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(fs) != 1 {
+		t.Fatalf("wanted 1 finding but got %d", len(fs))
+	}
+	f := fs[0]
+	if f.Ident != "dangerous-triggers" || f.Determinations.Severity != "High" || len(f.Locations) != 2 {
+		t.Fatalf("unexpected finding: %+v", f)
+	}
+	l := f.primaryLocation()
+	if l.Symbolic.Annotation != "annotation of dangerous-triggers" || l.path() != "test.yaml" {
+		t.Fatalf("primary location was not selected: %+v", l)
+	}
+
+	if _, err := parseZizmorFindings([]byte(`{"not": "array"}`)); err == nil {
+		t.Fatal("error did not occur for broken JSON")
+	}
+}
+
+func TestRuleZizmorMergeResultsFile(t *testing.T) {
+	findings := []string{
+		testZizmorFindingJSON("dangerous-triggers", "test.yaml", 0, 4),
+		testZizmorFindingJSON("template-injection", "test.yaml", 6, 19),    // Overlaps with actionlint's untrusted inputs check
+		testZizmorFindingJSON("artipacked", "test.yaml", 5, 8),             // Not checked by actionlint
+		testZizmorFindingJSON("excessive-permissions", "other.yaml", 0, 0), // Other file
+	}
+	results := filepath.Join(t.TempDir(), "zizmor.json")
+	if err := os.WriteFile(results, []byte("["+strings.Join(findings, ",")+"]"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	l, err := NewLinter(io.Discard, &LinterOptions{ZizmorResults: results, Shellcheck: "", Pyflakes: ""})
+	if err != nil {
+		t.Fatal(err)
+	}
+	errs, err := l.Lint("test.yaml", []byte(testZizmorWorkflow), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{
+		`test.yaml:1:5: zizmor reported "dangerous-triggers" finding with high severity: description of dangerous-triggers: annotation of dangerous-triggers. see https://docs.zizmor.sh/audits/#dangerous-triggers [zizmor]`,
+		`test.yaml:6:9: zizmor reported "artipacked" finding with high severity`,
+		`test.yaml:7:24: "github.event.pull_request.title" is potentially untrusted`,
+	}
+	if len(errs) != len(want) {
+		t.Fatalf("wanted %d errors but got %d: %v", len(want), len(errs), errs)
+	}
+	for i, w := range want {
+		if msg := errs[i].Error(); !strings.Contains(msg, w) {
+			t.Errorf("error message %q does not contain %q", msg, w)
+		}
+	}
+	if e := errs[1]; e.Code != "artipacked" || e.Severity != SeverityError {
+		t.Errorf("unexpected code %q and severity %v", e.Code, e.Severity)
+	}
+}
+
+func TestRuleZizmorSeverity(t *testing.T) {
+	for s, want := range map[string]Severity{
+		"High":          SeverityError,
+		"Medium":        SeverityError,
+		"Low":           SeverityWarning,
+		"Informational": SeverityInfo,
+		"Unknown":       SeverityInfo,
+	} {
+		f := &zizmorFinding{}
+		f.Determinations.Severity = s
+		if have := f.severity(); have != want {
+			t.Errorf("wanted %v for %q but got %v", want, s, have)
+		}
+	}
+}
+
+func TestRuleZizmorResultsFileError(t *testing.T) {
+	_, err := NewLinter(io.Discard, &LinterOptions{ZizmorResults: filepath.Join("testdata", "this-file-does-not-exist.json")})
+	if err == nil {
+		t.Fatal("error did not occur")
+	}
+	if msg := err.Error(); !strings.Contains(msg, "could not read zizmor results file") {
+		t.Fatalf("unexpected error message: %q", msg)
+	}
+}
+
+func TestRuleZizmorRunCommand(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake zizmor command is a shell script")
+	}
+
+	// Fake zizmor command outputs a finding for the workflow file given as the last argument
+	exe := filepath.Join(t.TempDir(), "zizmor")
+	script := `#!/bin/sh
+[ "$1" = "--format" ] && [ "$2" = "json" ] || exit 2
+cat <<EOS
+[` + testZizmorFindingJSON("artipacked", "$3", 5, 8) + `]
+EOS
+exit 14
+`
+	if err := os.WriteFile(exe, []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	l, err := NewLinter(io.Discard, &LinterOptions{Zizmor: exe, Shellcheck: "", Pyflakes: ""})
+	if err != nil {
+		t.Fatal(err)
+	}
+	errs, err := l.LintStdin(strings.NewReader(testZizmorWorkflow))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var found []*Error
+	for _, e := range errs {
+		if e.Kind == "zizmor" {
+			found = append(found, e)
+		}
+	}
+	if len(found) != 1 {
+		t.Fatalf("wanted one error from zizmor but got %v", errs)
+	}
+	if found[0].Line != 6 || found[0].Column != 9 || !strings.Contains(found[0].Message, `"artipacked" finding`) {
+		t.Fatalf("unexpected error: %v", found[0])
+	}
+}
@@ -0,0 +1,37 @@
+// Code generated by actionlint/scripts/generate-runner-labels. DO NOT EDIT.
+
+package actionlint
+
+// GitHubHostedRunners is a catalog of the labels of GitHub-hosted runners. This variable was
+// generated by script at ./scripts/generate-runner-labels based on ./scripts/generate-runner-labels/runner_labels.json
+var GitHubHostedRunners = []*GitHubHostedRunner{
+	{Label: "windows-latest", OS: RunnerOSWindows, Arch: RunnerArchX64, Image: "windows-2022"},
+	{Label: "windows-latest-8-cores", OS: RunnerOSWindows, Arch: RunnerArchX64, Image: "windows-2022"},
+	{Label: "windows-2025", OS: RunnerOSWindows, Arch: RunnerArchX64, Image: "windows-2025"},
+	{Label: "windows-2025-vs2026", OS: RunnerOSWindows, Arch: RunnerArchX64, Image: "windows-2025-vs2026"},
+	{Label: "windows-2022", OS: RunnerOSWindows, Arch: RunnerArchX64, Image: "windows-2022"},
+	{Label: "windows-11-arm", OS: RunnerOSWindows, Arch: RunnerArchARM64, Image: "windows-11-arm"},
+	{Label: "ubuntu-slim", OS: RunnerOSLinux, Arch: RunnerArchX64, Image: "ubuntu-slim"},
+	{Label: "ubuntu-latest", OS: RunnerOSLinux, Arch: RunnerArchX64, Image: "ubuntu-24.04"},
+	{Label: "ubuntu-latest-4-cores", OS: RunnerOSLinux, Arch: RunnerArchX64, Image: "ubuntu-24.04"},
+	{Label: "ubuntu-latest-8-cores", OS: RunnerOSLinux, Arch: RunnerArchX64, Image: "ubuntu-24.04"},
+	{Label: "ubuntu-latest-16-cores", OS: RunnerOSLinux, Arch: RunnerArchX64, Image: "ubuntu-24.04"},
+	{Label: "ubuntu-24.04", OS: RunnerOSLinux, Arch: RunnerArchX64, Image: "ubuntu-24.04"},
+	{Label: "ubuntu-24.04-arm", OS: RunnerOSLinux, Arch: RunnerArchARM64, Image: "ubuntu-24.04-arm"},
+	{Label: "ubuntu-22.04", OS: RunnerOSLinux, Arch: RunnerArchX64, Image: "ubuntu-22.04"},
+	{Label: "ubuntu-22.04-arm", OS: RunnerOSLinux, Arch: RunnerArchARM64, Image: "ubuntu-22.04-arm"},
+	{Label: "macos-latest", OS: RunnerOSMacOS, Arch: RunnerArchARM64, Image: "macos-15"},
+	{Label: "macos-latest-xlarge", OS: RunnerOSMacOS, Arch: RunnerArchARM64, Image: "macos-15-xlarge"},
+	{Label: "macos-latest-large", OS: RunnerOSMacOS, Arch: RunnerArchX64, Image: "macos-15-large"},
+	{Label: "macos-26-intel", OS: RunnerOSMacOS, Arch: RunnerArchX64, Image: "macos-26-intel"},
+	{Label: "macos-26-xlarge", OS: RunnerOSMacOS, Arch: RunnerArchARM64, Image: "macos-26-xlarge"},
+	{Label: "macos-26-large", OS: RunnerOSMacOS, Arch: RunnerArchX64, Image: "macos-26-large"},
+	{Label: "macos-26", OS: RunnerOSMacOS, Arch: RunnerArchARM64, Image: "macos-26"},
+	{Label: "macos-15-intel", OS: RunnerOSMacOS, Arch: RunnerArchX64, Image: "macos-15-intel"},
+	{Label: "macos-15-xlarge", OS: RunnerOSMacOS, Arch: RunnerArchARM64, Image: "macos-15-xlarge"},
+	{Label: "macos-15-large", OS: RunnerOSMacOS, Arch: RunnerArchX64, Image: "macos-15-large"},
+	{Label: "macos-15", OS: RunnerOSMacOS, Arch: RunnerArchARM64, Image: "macos-15"},
+	{Label: "macos-14-xlarge", OS: RunnerOSMacOS, Arch: RunnerArchARM64, Image: "macos-14-xlarge"},
+	{Label: "macos-14-large", OS: RunnerOSMacOS, Arch: RunnerArchX64, Image: "macos-14-large"},
+	{Label: "macos-14", OS: RunnerOSMacOS, Arch: RunnerArchARM64, Image: "macos-14"},
+}
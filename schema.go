@@ -0,0 +1,332 @@
+package actionlint
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+)
+
+// workflowSchemaDraft is the JSON Schema dialect of the workflow schema. Draft-07 is the most
+// widely supported dialect by editors including yaml-language-server.
+const workflowSchemaDraft = "http://json-schema.org/draft-07/schema#"
+
+func schemaRef(def string) map[string]any {
+	return map[string]any{"$ref": "#/definitions/" + def}
+}
+
+func schemaAnyOf(schemas ...map[string]any) map[string]any {
+	return map[string]any{"anyOf": schemas}
+}
+
+func schemaEnum(values []string) map[string]any {
+	return map[string]any{"type": "string", "enum": values}
+}
+
+func schemaArrayOf(items map[string]any) map[string]any {
+	return map[string]any{"type": "array", "items": items}
+}
+
+// schemaObject returns the schema of a mapping which only allows the given properties.
+func schemaObject(props map[string]any, required ...string) map[string]any {
+	o := map[string]any{
+		"type":                 "object",
+		"properties":           props,
+		"additionalProperties": false,
+	}
+	if len(required) > 0 {
+		o["required"] = required
+	}
+	return o
+}
+
+// schemaMapOf returns the schema of a mapping whose keys are arbitrary names.
+func schemaMapOf(values map[string]any) map[string]any {
+	return map[string]any{"type": "object", "additionalProperties": values}
+}
+
+// schemaNullable returns the schema which also accepts null. Events like `on: { push: }` are
+// configured with null.
+func schemaNullable(s map[string]any) map[string]any {
+	return schemaAnyOf(map[string]any{"type": "null"}, s)
+}
+
+func schemaStringOrSequence(items map[string]any) map[string]any {
+	return schemaAnyOf(items, schemaArrayOf(items))
+}
+
+func workflowSchemaEventNames() []string {
+	names := make([]string, 0, len(AllWebhookEvents))
+	for n := range AllWebhookEvents {
+		names = append(names, n)
+	}
+	slices.Sort(names)
+	return names
+}
+
+func workflowSchemaWebhookEvent(name string, meta *WebhookEventMetadata) map[string]any {
+	props := map[string]any{}
+	if len(meta.Types) > 0 {
+		props["types"] = schemaStringOrSequence(schemaEnum(meta.Types))
+	}
+	for _, f := range meta.Filters {
+		props[f] = schemaStringOrSequence(map[string]any{"type": "string"})
+	}
+	if name == "workflow_run" {
+		props["workflows"] = schemaStringOrSequence(map[string]any{"type": "string"})
+	}
+	s := schemaObject(props)
+	s["description"] = meta.URL
+	return schemaNullable(s)
+}
+
+func workflowSchemaEvents() map[string]any {
+	events := map[string]any{}
+	for name, meta := range AllWebhookEvents {
+		switch name {
+		case "schedule":
+			events[name] = schemaArrayOf(schemaRef("scheduleEvent"))
+		case "workflow_dispatch":
+			events[name] = schemaNullable(schemaRef("workflowDispatchEvent"))
+		case "repository_dispatch":
+			events[name] = schemaNullable(schemaRef("repositoryDispatchEvent"))
+		case "workflow_call":
+			events[name] = schemaNullable(schemaRef("workflowCallEvent"))
+		case "image_version":
+			events[name] = schemaNullable(schemaRef("imageVersionEvent"))
+		default:
+			events[name] = workflowSchemaWebhookEvent(name, meta)
+		}
+	}
+	return events
+}
+
+func workflowSchemaPermissions() map[string]any {
+	scopes := map[string]any{}
+	for s, levels := range allPermissionScopes {
+		scopes[s] = schemaEnum(levels)
+	}
+	return schemaAnyOf(schemaEnum([]string{"read-all", "write-all"}), schemaObject(scopes))
+}
+
+func workflowSchemaShell() map[string]any {
+	shells := slices.Clone(getAvailableShellNames(platformKindAny))
+	slices.Sort(shells)
+	// Custom shells like "perl {0}" are also available
+	return schemaAnyOf(schemaEnum(shells), map[string]any{"type": "string"})
+}
+
+func workflowSchemaRunnerLabel() map[string]any {
+	return schemaAnyOf(schemaEnum(allGitHubHostedRunnerLabels), map[string]any{"type": "string"})
+}
+
+func workflowSchemaDefinitions() map[string]any {
+	str := map[string]any{"type": "string"}
+	scalar := map[string]any{"type": []string{"string", "number", "boolean"}}
+	expr := schemaRef("expression")
+	boolean := schemaAnyOf(map[string]any{"type": "boolean"}, expr)
+	number := schemaAnyOf(map[string]any{"type": "number"}, expr)
+	strings := schemaStringOrSequence(str)
+
+	return map[string]any{
+		"expression": map[string]any{
+			"type":    "string",
+			"pattern": `^\$\{\{(.|[\r\n])*\}\}$`,
+		},
+		"scheduleEvent": schemaObject(map[string]any{"cron": str}, "cron"),
+		"workflowDispatchEvent": schemaObject(map[string]any{
+			"inputs": schemaMapOf(schemaRef("workflowDispatchInput")),
+		}),
+		"workflowDispatchInput": schemaObject(map[string]any{
+			"description": scalar,
+			"required":    boolean,
+			"default":     scalar,
+			"type":        schemaEnum([]string{"string", "number", "boolean", "choice", "environment"}),
+			"options":     schemaArrayOf(scalar),
+		}),
+		"repositoryDispatchEvent": schemaObject(map[string]any{"types": strings}),
+		"workflowCallEvent": schemaObject(map[string]any{
+			"inputs":  schemaMapOf(schemaRef("workflowCallInput")),
+			"secrets": schemaMapOf(schemaNullable(schemaRef("workflowCallSecret"))),
+			"outputs": schemaMapOf(schemaRef("workflowCallOutput")),
+		}),
+		"workflowCallInput": schemaObject(map[string]any{
+			"description": scalar,
+			"required":    boolean,
+			"default":     scalar,
+			"type":        schemaEnum([]string{"boolean", "number", "string"}),
+		}, "type"),
+		"workflowCallSecret": schemaObject(map[string]any{
+			"description": scalar,
+			"required":    boolean,
+		}),
+		"workflowCallOutput": schemaObject(map[string]any{
+			"description": scalar,
+			"value":       str,
+		}, "value"),
+		"imageVersionEvent": schemaObject(map[string]any{
+			"names":    schemaArrayOf(str),
+			"versions": schemaArrayOf(str),
+		}),
+		"events": schemaObject(workflowSchemaEvents()),
+		"on": schemaAnyOf(
+			schemaEnum(workflowSchemaEventNames()),
+			schemaArrayOf(schemaEnum(workflowSchemaEventNames())),
+			schemaRef("events"),
+		),
+		"permissions": workflowSchemaPermissions(),
+		"env":         schemaAnyOf(schemaMapOf(scalar), expr),
+		"shell":       workflowSchemaShell(),
+		"defaults":    schemaObject(map[string]any{"run": schemaRef("defaultsRun")}, "run"),
+		"defaultsRun": schemaObject(map[string]any{
+			"shell":             schemaRef("shell"),
+			"working-directory": str,
+		}),
+		"concurrency": schemaAnyOf(str, schemaObject(map[string]any{
+			"group":              str,
+			"cancel-in-progress": boolean,
+		}, "group")),
+		"environment": schemaAnyOf(str, schemaObject(map[string]any{
+			"name": str,
+			"url":  str,
+		}, "name")),
+		"runnerLabel": workflowSchemaRunnerLabel(),
+		"runsOn": schemaAnyOf(
+			schemaRef("runnerLabel"),
+			schemaArrayOf(schemaRef("runnerLabel")),
+			schemaObject(map[string]any{
+				"labels": schemaAnyOf(schemaStringOrSequence(schemaRef("runnerLabel")), expr),
+				"group":  str,
+			}),
+		),
+		"strategy": schemaObject(map[string]any{
+			"matrix":       schemaAnyOf(map[string]any{"type": "object"}, expr),
+			"fail-fast":    boolean,
+			"max-parallel": number,
+		}),
+		"credentials": schemaAnyOf(schemaObject(map[string]any{
+			"username": str,
+			"password": str,
+		}, "username", "password"), expr),
+		"container": schemaAnyOf(str, schemaObject(map[string]any{
+			"image":       str,
+			"credentials": schemaRef("credentials"),
+			"env":         schemaRef("env"),
+			"ports":       schemaArrayOf(map[string]any{"type": []string{"string", "number"}}),
+			"volumes":     schemaArrayOf(str),
+			"options":     str,
+		}, "image")),
+		"snapshot": schemaAnyOf(str, schemaObject(map[string]any{
+			"image-name": str,
+			"version":    str,
+			"if":         schemaRef("if"),
+		}, "image-name")),
+		"if": scalar,
+		"step": map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"id":                str,
+				"if":                schemaRef("if"),
+				"name":              scalar,
+				"env":               schemaRef("env"),
+				"continue-on-error": boolean,
+				"timeout-minutes":   number,
+				"uses":              str,
+				"with":              schemaMapOf(scalar),
+				"run":               str,
+				"shell":             schemaRef("shell"),
+				"working-directory": str,
+			},
+			"additionalProperties": false,
+			"oneOf": []map[string]any{
+				{"required": []string{"uses"}, "not": map[string]any{"anyOf": []map[string]any{
+					{"required": []string{"run"}},
+					{"required": []string{"shell"}},
+					{"required": []string{"working-directory"}},
+				}}},
+				{"required": []string{"run"}, "not": map[string]any{"anyOf": []map[string]any{
+					{"required": []string{"uses"}},
+					{"required": []string{"with"}},
+				}}},
+			},
+		},
+		"job": map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"name":              scalar,
+				"needs":             strings,
+				"runs-on":           schemaAnyOf(schemaRef("runsOn"), expr),
+				"permissions":       schemaRef("permissions"),
+				"environment":       schemaRef("environment"),
+				"concurrency":       schemaRef("concurrency"),
+				"outputs":           schemaMapOf(scalar),
+				"env":               schemaRef("env"),
+				"defaults":          schemaRef("defaults"),
+				"if":                schemaRef("if"),
+				"steps":             schemaArrayOf(schemaRef("step")),
+				"timeout-minutes":   number,
+				"strategy":          schemaRef("strategy"),
+				"continue-on-error": boolean,
+				"container":         schemaRef("container"),
+				"services":          schemaAnyOf(schemaMapOf(schemaRef("container")), expr),
+				"uses":              str,
+				"with":              schemaMapOf(scalar),
+				"secrets":           schemaAnyOf(map[string]any{"const": "inherit"}, schemaMapOf(scalar)),
+				"snapshot":          schemaRef("snapshot"),
+			},
+			"additionalProperties": false,
+			"anyOf": []map[string]any{
+				{"required": []string{"runs-on", "steps"}},
+				{"required": []string{"uses"}},
+			},
+		},
+	}
+}
+
+// WorkflowSchema returns JSON Schema of workflow files. The schema is derived from the syntax
+// knowledge of actionlint such as keys of each section, webhook events and their activity types,
+// permission scopes, shell names, and labels of GitHub-hosted runners. It is useful for editors
+// which validate YAML files with JSON Schema like yaml-language-server. Note that the schema is
+// looser than actionlint. For example, expressions in `${{ }}` are not checked.
+func WorkflowSchema() map[string]any {
+	return map[string]any{
+		"$schema":     workflowSchemaDraft,
+		"title":       "GitHub Actions workflow",
+		"description": "Schema of GitHub Actions workflow files generated by actionlint",
+		"type":        "object",
+		"properties": map[string]any{
+			"name":        map[string]any{"type": "string"},
+			"run-name":    map[string]any{"type": "string"},
+			"on":          schemaRef("on"),
+			"permissions": schemaRef("permissions"),
+			"env":         schemaRef("env"),
+			"defaults":    schemaRef("defaults"),
+			"concurrency": schemaRef("concurrency"),
+			"jobs": map[string]any{
+				"type": "object",
+				"patternProperties": map[string]any{
+					jobIDPattern.String(): schemaRef("job"),
+				},
+				"additionalProperties": false,
+				"minProperties":        1,
+			},
+		},
+		"additionalProperties": false,
+		"required":             []string{"on", "jobs"},
+		"definitions":          workflowSchemaDefinitions(),
+	}
+}
+
+// WriteWorkflowSchema writes JSON Schema of workflow files returned from WorkflowSchema to the
+// writer in indented JSON.
+func WriteWorkflowSchema(out io.Writer) error {
+	b, err := json.MarshalIndent(WorkflowSchema(), "", "  ")
+	if err != nil {
+		return fmt.Errorf("could not encode workflow schema into JSON: %w", err)
+	}
+	b = append(b, '\n')
+	if _, err := out.Write(b); err != nil {
+		return fmt.Errorf("could not write workflow schema: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,309 @@
+package actionlint
+
+import (
+	"bytes"
+	"encoding/json"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func testDecodeWorkflowSchema(t *testing.T) map[string]any {
+	t.Helper()
+	var b bytes.Buffer
+	if err := WriteWorkflowSchema(&b); err != nil {
+		t.Fatal(err)
+	}
+	var s map[string]any
+	if err := json.Unmarshal(b.Bytes(), &s); err != nil {
+		t.Fatalf("schema is not valid JSON: %v: %s", err, b.String())
+	}
+	return s
+}
+
+// testSchemaProps returns the property names of the object schema. $ref and anyOf are followed to
+// find the object schema.
+func testSchemaProps(t *testing.T, root, s map[string]any) []string {
+	t.Helper()
+	if r, ok := s["$ref"].(string); ok {
+		name := strings.TrimPrefix(r, "#/definitions/")
+		d, ok := root["definitions"].(map[string]any)[name].(map[string]any)
+		if !ok {
+			t.Fatalf("definition %q referred by %q does not exist", name, r)
+		}
+		return testSchemaProps(t, root, d)
+	}
+	if ps, ok := s["properties"].(map[string]any); ok {
+		names := make([]string, 0, len(ps))
+		for n := range ps {
+			names = append(names, n)
+		}
+		slices.Sort(names)
+		return names
+	}
+	if ss, ok := s["anyOf"].([]any); ok {
+		for _, s := range ss {
+			s := s.(map[string]any)
+			if _, ok := s["type"]; ok && s["type"] != "object" {
+				continue
+			}
+			if ps := testSchemaProps(t, root, s); ps != nil {
+				return ps
+			}
+		}
+	}
+	return nil
+}
+
+func testSchemaDef(t *testing.T, root map[string]any, path ...string) map[string]any {
+	t.Helper()
+	s := root["definitions"].(map[string]any)[path[0]].(map[string]any)
+	for _, p := range path[1:] {
+		ps := testSchemaProps(t, root, s)
+		if !slices.Contains(ps, p) {
+			t.Fatalf("property %q is not found in %v at path %v", p, ps, path)
+		}
+		// Follow the object schema which has the property
+		for {
+			if r, ok := s["$ref"].(string); ok {
+				s = root["definitions"].(map[string]any)[strings.TrimPrefix(r, "#/definitions/")].(map[string]any)
+				continue
+			}
+			if _, ok := s["properties"]; ok {
+				break
+			}
+			for _, c := range s["anyOf"].([]any) {
+				c := c.(map[string]any)
+				if testSchemaProps(t, root, c) != nil {
+					s = c
+					break
+				}
+			}
+		}
+		s = s["properties"].(map[string]any)[p].(map[string]any)
+	}
+	return s
+}
+
+var testReExpectedKeys = regexp.MustCompile(`expected one of (.+) \[syntax-check\]$|expected "([^"]+)" key for`)
+
+// testParserExpectedKeys parses the workflow which has unexpected key "bogus" and returns the keys
+// listed in the error message as expected ones.
+func testParserExpectedKeys(t *testing.T, src string) []string {
+	t.Helper()
+	_, errs := Parse([]byte(src))
+	for _, err := range errs {
+		if !strings.Contains(err.Message, `"bogus"`) {
+			continue
+		}
+		m := testReExpectedKeys.FindStringSubmatch(err.Error())
+		if m == nil {
+			t.Fatalf("expected keys are not found in error %q", err.Error())
+		}
+		if m[2] != "" {
+			return []string{m[2]}
+		}
+		keys := []string{}
+		for _, k := range strings.Split(m[1], ", ") {
+			keys = append(keys, strings.Trim(k, `"`))
+		}
+		slices.Sort(keys)
+		return keys
+	}
+	t.Fatalf("error for unexpected key was not found in %v", errs)
+	return nil
+}
+
+func TestWorkflowSchemaKeysConsistentWithParser(t *testing.T) {
+	root := testDecodeWorkflowSchema(t)
+	job := "jobs:\n  test:\n    runs-on: ubuntu-latest\n    steps:\n      - run: echo\n"
+
+	tests := []struct {
+		what string
+		src  string
+		path []string
+	}{
+		{
+			what: "workflow",
+			src:  "bogus: 1\non: push\n" + job,
+		},
+		{
+			what: "job",
+			src:  "on: push\njobs:\n  test:\n    bogus: 1\n",
+			path: []string{"job"},
+		},
+		{
+			what: "container",
+			src:  "on: push\n" + job + "    container:\n      image: foo\n      bogus: 1\n",
+			path: []string{"job", "container"},
+		},
+		{
+			what: "credentials",
+			src:  "on: push\n" + job + "    container:\n      image: foo\n      credentials:\n        bogus: 1\n",
+			path: []string{"job", "container", "credentials"},
+		},
+		{
+			what: "strategy",
+			src:  "on: push\n" + job + "    strategy:\n      bogus: 1\n",
+			path: []string{"job", "strategy"},
+		},
+		{
+			what: "defaults",
+			src:  "on: push\ndefaults:\n  bogus: 1\n" + job,
+			path: []string{"defaults"},
+		},
+		{
+			what: "defaults.run",
+			src:  "on: push\ndefaults:\n  run:\n    bogus: 1\n" + job,
+			path: []string{"defaults", "run"},
+		},
+		{
+			what: "concurrency",
+			src:  "on: push\nconcurrency:\n  group: foo\n  bogus: 1\n" + job,
+			path: []string{"concurrency"},
+		},
+		{
+			what: "environment",
+			src:  "on: push\n" + job + "    environment:\n      name: foo\n      bogus: 1\n",
+			path: []string{"job", "environment"},
+		},
+		{
+			what: "runs-on",
+			src:  "on: push\njobs:\n  test:\n    runs-on:\n      bogus: 1\n    steps:\n      - run: echo\n",
+			path: []string{"runsOn"},
+		},
+		{
+			what: "snapshot",
+			src:  "on: push\n" + job + "    snapshot:\n      image-name: foo\n      bogus: 1\n",
+			path: []string{"job", "snapshot"},
+		},
+		{
+			what: "schedule",
+			src:  "on:\n  schedule:\n    - cron: '0 0 * * *'\n      bogus: 1\n" + job,
+			path: []string{"scheduleEvent"},
+		},
+		{
+			what: "workflow_dispatch",
+			src:  "on:\n  workflow_dispatch:\n    bogus: 1\n" + job,
+			path: []string{"workflowDispatchEvent"},
+		},
+		{
+			what: "workflow_dispatch inputs",
+			src:  "on:\n  workflow_dispatch:\n    inputs:\n      foo:\n        bogus: 1\n" + job,
+			path: []string{"workflowDispatchInput"},
+		},
+		{
+			what: "repository_dispatch",
+			src:  "on:\n  repository_dispatch:\n    bogus: 1\n" + job,
+			path: []string{"repositoryDispatchEvent"},
+		},
+		{
+			what: "workflow_call",
+			src:  "on:\n  workflow_call:\n    bogus: 1\n" + job,
+			path: []string{"workflowCallEvent"},
+		},
+		{
+			what: "workflow_call inputs",
+			src:  "on:\n  workflow_call:\n    inputs:\n      foo:\n        type: string\n        bogus: 1\n" + job,
+			path: []string{"workflowCallInput"},
+		},
+		{
+			what: "workflow_call secrets",
+			src:  "on:\n  workflow_call:\n    secrets:\n      foo:\n        bogus: 1\n" + job,
+			path: []string{"workflowCallSecret"},
+		},
+		{
+			what: "workflow_call outputs",
+			src:  "on:\n  workflow_call:\n    outputs:\n      foo:\n        value: foo\n        bogus: 1\n" + job,
+			path: []string{"workflowCallOutput"},
+		},
+		{
+			what: "image_version",
+			src:  "on:\n  image_version:\n    bogus: 1\n" + job,
+			path: []string{"imageVersionEvent"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.what, func(t *testing.T) {
+			want := testParserExpectedKeys(t, tc.src)
+			s := root
+			if len(tc.path) > 0 {
+				s = testSchemaDef(t, root, tc.path...)
+			}
+			have := testSchemaProps(t, root, s)
+			if !slices.Equal(want, have) {
+				t.Fatalf("keys in schema %v are different from keys in parser %v", have, want)
+			}
+		})
+	}
+}
+
+func TestWorkflowSchemaStepKeysConsistentWithParser(t *testing.T) {
+	root := testDecodeWorkflowSchema(t)
+	src := "on: push\njobs:\n  test:\n    runs-on: ubuntu-latest\n    steps:\n      - %s\n        bogus: 1\n"
+
+	want := testParserExpectedKeys(t, strings.Replace(src, "%s", "uses: actions/checkout@v4", 1))
+	want = append(want, testParserExpectedKeys(t, strings.Replace(src, "%s", "run: echo", 1))...)
+	slices.Sort(want)
+	want = slices.Compact(want)
+
+	have := testSchemaProps(t, root, testSchemaDef(t, root, "step"))
+	if !slices.Equal(want, have) {
+		t.Fatalf("keys in schema %v are different from keys in parser %v", have, want)
+	}
+}
+
+func TestWorkflowSchemaWebhookEvents(t *testing.T) {
+	root := testDecodeWorkflowSchema(t)
+
+	have := testSchemaProps(t, root, testSchemaDef(t, root, "events"))
+	want := make([]string, 0, len(AllWebhookEvents))
+	for n := range AllWebhookEvents {
+		want = append(want, n)
+	}
+	slices.Sort(want)
+	if !slices.Equal(want, have) {
+		t.Fatalf("events in schema %v are different from known events %v", have, want)
+	}
+
+	src := "on:\n  push:\n    bogus: 1\n" + "jobs:\n  test:\n    runs-on: ubuntu-latest\n    steps:\n      - run: echo\n"
+	keys := testParserExpectedKeys(t, src)
+	for _, e := range []string{"push", "pull_request", "workflow_run", "issues"} {
+		for _, k := range testSchemaProps(t, root, testSchemaDef(t, root, "events", e)) {
+			if !slices.Contains(keys, k) {
+				t.Errorf("key %q of event %q in schema is not accepted by parser: %v", k, e, keys)
+			}
+		}
+	}
+
+	types := testSchemaDef(t, root, "events", "issues")["anyOf"].([]any)[1].(map[string]any)["properties"].(map[string]any)["types"]
+	enum := types.(map[string]any)["anyOf"].([]any)[0].(map[string]any)["enum"].([]any)
+	if len(enum) != len(AllWebhookEvents["issues"].Types) {
+		t.Fatalf("activity types of issues event in schema are unexpected: %v", enum)
+	}
+}
+
+func TestWorkflowSchemaPermissions(t *testing.T) {
+	root := testDecodeWorkflowSchema(t)
+	have := testSchemaProps(t, root, testSchemaDef(t, root, "permissions"))
+	want := make([]string, 0, len(allPermissionScopes))
+	for s := range allPermissionScopes {
+		want = append(want, s)
+	}
+	slices.Sort(want)
+	if !slices.Equal(want, have) {
+		t.Fatalf("permission scopes in schema %v are different from known scopes %v", have, want)
+	}
+}
+
+func TestWorkflowSchemaJobIDPattern(t *testing.T) {
+	root := testDecodeWorkflowSchema(t)
+	jobs := root["properties"].(map[string]any)["jobs"].(map[string]any)
+	for p := range jobs["patternProperties"].(map[string]any) {
+		if p != jobIDPattern.String() {
+			t.Fatalf("job ID pattern %q is different from %q", p, jobIDPattern.String())
+		}
+	}
+}
@@ -1,6 +1,6 @@
 // Code generated by actionlint/scripts/generate-availability. DO NOT EDIT.
 
-package actionlint
+package expr
 
 // WorkflowKeyAvailability returns contexts and special functions availability of the given workflow key.
 // 1st return value indicates what contexts are available. Empty slice means any contexts are available.
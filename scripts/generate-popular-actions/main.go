@@ -29,9 +29,51 @@ var outdatedActions = []string{
 }
 
 type actionOutput struct {
-	Spec     string                     `json:"spec"`
-	Meta     *actionlint.ActionMetadata `json:"metadata"`
-	Outdated bool                       `json:"outdated"`
+	Spec        string                     `json:"spec"`
+	Meta        *actionlint.ActionMetadata `json:"metadata"`
+	Outdated    bool                       `json:"outdated"`
+	Deprecation *deprecation               `json:"deprecation,omitempty"`
+}
+
+// dataLine is a line of the data set embedded in actionlint. It is a subset of actionOutput which
+// omits empty fields so that the data set is small enough to be embedded in the executable.
+type dataLine struct {
+	Spec string        `json:"spec"`
+	Meta *dataMetadata `json:"metadata"`
+}
+
+type dataMetadata struct {
+	Name        string                 `json:"name"`
+	Inputs      map[string]*dataInput  `json:"inputs,omitempty"`
+	Outputs     map[string]*dataOutput `json:"outputs,omitempty"`
+	SkipInputs  bool                   `json:"skip_inputs,omitempty"`
+	SkipOutputs bool                   `json:"skip_outputs,omitempty"`
+}
+
+type dataInput struct {
+	Name               string `json:"name"`
+	Required           bool   `json:"required,omitempty"`
+	Deprecated         bool   `json:"deprecated,omitempty"`
+	DeprecationMessage string `json:"deprecation-message,omitempty"`
+	Default            string `json:"default,omitempty"`
+}
+
+type dataOutput struct {
+	Name string `json:"name"`
+}
+
+type deprecation struct {
+	actionlint.DeprecatedAction
+	// AllVersions is true when all versions of the action are deprecated. It is true when the
+	// repository is archived.
+	AllVersions bool `json:"all_versions,omitempty"`
+}
+
+type registryDeprecation struct {
+	// Tags deprecated in the registry. Empty means all versions of the action are deprecated.
+	Tags        []string `json:"tags"`
+	Message     string   `json:"message"`
+	Replacement string   `json:"replacement"`
 }
 
 type registry struct {
@@ -47,6 +89,9 @@ type registry struct {
 	// may or may not exist. And they are not listed in action.yml metadata. actionlint cannot check
 	// such outputs and fallback into allowing to set any outputs. (#18)
 	SkipOutputs bool `json:"skip_outputs"`
+	// Deprecation notice of the action announced by its authors. Use of the deprecated action is
+	// reported with the recommended replacement.
+	Deprecated *registryDeprecation `json:"deprecated"`
 }
 
 func (r *registry) rawURL(tag string) string {
@@ -65,6 +110,10 @@ func (r *registry) spec(tag string) string {
 	return fmt.Sprintf("%s%s@%s", r.Slug, r.Path, tag)
 }
 
+func (r *registry) repoAPIURL() string {
+	return fmt.Sprintf("%s/repos/%s", githubAPIURL, r.Slug)
+}
+
 // Note: Actions used by top 1000 public repositories at GitHub sorted by number of occurrences:
 // https://gist.github.com/rhysd/1db81fa80096b699b9c045f435d0cace
 
@@ -73,6 +122,8 @@ var defaultPopularActionsJSON []byte
 
 const minNodeRunnerVersion = 20
 
+const githubAPIURL = "https://api.github.com"
+
 func isOutdated(spec, runs string) bool {
 	for _, s := range outdatedActions {
 		if s == spec {
@@ -135,7 +186,7 @@ func (g *gen) fetchRemote() (map[string]*actionlint.ActionMetadata, error) {
 				case req := <-reqs:
 					url := req.action.rawURL(req.tag)
 					g.log.Println("Start fetching", url)
-					res, err := c.Get(url)
+					res, err := g.getRaw(&c, url)
 					if err != nil {
 						ret <- &fetched{err: fmt.Errorf("could not fetch %s: %w", url, err)}
 						break
@@ -226,10 +277,107 @@ func (g *gen) fetchRemote() (map[string]*actionlint.ActionMetadata, error) {
 	return ret, nil
 }
 
-func (g *gen) writeJSONL(out io.Writer, actions map[string]*actionlint.ActionMetadata) error {
+// getRaw fetches the raw file content on GitHub. When GITHUB_TOKEN environment variable is set, it is
+// used for the API token so that action metadata in private repositories can be fetched.
+func (g *gen) getRaw(c *http.Client, url string) (*http.Response, error) {
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	if t := os.Getenv("GITHUB_TOKEN"); t != "" {
+		req.Header.Set("Authorization", "Bearer "+t)
+	}
+	return c.Do(req)
+}
+
+func (g *gen) fetchArchived(c *http.Client, r *registry) (bool, error) {
+	url := r.repoAPIURL()
+	g.log.Println("Start fetching", url)
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return false, fmt.Errorf("could not create request for %s: %w", url, err)
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	if t := os.Getenv("GITHUB_TOKEN"); t != "" {
+		req.Header.Set("Authorization", "Bearer "+t)
+	}
+	res, err := c.Do(req)
+	if err != nil {
+		return false, fmt.Errorf("could not fetch %s: %w", url, err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode < 200 || 300 <= res.StatusCode {
+		return false, fmt.Errorf("request was not successful %s: %s", url, res.Status)
+	}
+	var repo struct {
+		Archived bool `json:"archived"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&repo); err != nil {
+		return false, fmt.Errorf("could not parse repository information for %s: %w", url, err)
+	}
+	return repo.Archived, nil
+}
+
+// fetchDeprecations collects deprecated actions. The deprecation notices are defined in the registry
+// and archived repositories are detected via GitHub API. When GITHUB_TOKEN environment variable is
+// set, it is used for the API token to avoid the rate limit.
+func (g *gen) fetchDeprecations() (map[string]*deprecation, error) {
+	actions, err := g.registry()
+	if err != nil {
+		return nil, err
+	}
+
+	var c http.Client
+	archived := map[string]bool{}
+	ret := map[string]*deprecation{}
+	for _, a := range actions {
+		ar, ok := archived[a.Slug]
+		if !ok {
+			ar, err = g.fetchArchived(&c, a)
+			if err != nil {
+				return nil, err
+			}
+			archived[a.Slug] = ar
+		}
+		if !ar && a.Deprecated == nil {
+			continue
+		}
+
+		d := &deprecation{AllVersions: ar}
+		d.Archived = ar
+		tags := a.Tags
+		if a.Deprecated != nil {
+			d.Message = a.Deprecated.Message
+			d.Replacement = a.Deprecated.Replacement
+			if len(a.Deprecated.Tags) == 0 {
+				d.AllVersions = true
+			} else if !ar {
+				tags = a.Deprecated.Tags
+			}
+		}
+		for _, t := range tags {
+			ret[a.spec(t)] = d
+		}
+	}
+
+	g.log.Printf("Found %d deprecated action specs", len(ret))
+	return ret, nil
+}
+
+// writeJSONL writes the action metadata as JSONL. The lines are sorted by the action specs so that
+// the output is stable. This format is also read by actionlint as the action metadata files set at
+// "action-metadata" in the configuration file.
+func (g *gen) writeJSONL(out io.Writer, actions map[string]*actionlint.ActionMetadata, deprecations map[string]*deprecation) error {
+	specs := make([]string, 0, len(actions))
+	for s := range actions {
+		specs = append(specs, s)
+	}
+	sort.Strings(specs)
+
 	enc := json.NewEncoder(out)
-	for spec, meta := range actions {
-		j := actionOutput{spec, meta, isOutdated(spec, meta.Runs.Using)}
+	for _, spec := range specs {
+		meta := actions[spec]
+		j := actionOutput{spec, meta, isOutdated(spec, meta.Runs.Using), deprecations[spec]}
 		if err := enc.Encode(&j); err != nil {
 			return fmt.Errorf("could not encode action %q data into JSON: %w", spec, err)
 		}
@@ -238,15 +386,12 @@ func (g *gen) writeJSONL(out io.Writer, actions map[string]*actionlint.ActionMet
 	return nil
 }
 
-func (g *gen) writeGo(out io.Writer, actions map[string]*actionlint.ActionMetadata) error {
+func (g *gen) writeGo(out io.Writer, actions map[string]*actionlint.ActionMetadata, deprecations map[string]*deprecation) error {
 	b := &bytes.Buffer{}
 	fmt.Fprint(b, `// Code generated by actionlint/scripts/generate-popular-actions. DO NOT EDIT.
 
 package actionlint
 
-// PopularActions is data set of known popular actions. Keys are specs (owner/repo@ref) of actions
-// and values are their metadata.
-var PopularActions = map[string]*ActionMetadata{
 `)
 
 	specs := make([]string, 0, len(actions))
@@ -257,58 +402,11 @@ var PopularActions = map[string]*ActionMetadata{
 
 	outdated := []string{}
 	for _, spec := range specs {
-		meta := actions[spec]
-		if isOutdated(spec, meta.Runs.Using) {
+		if isOutdated(spec, actions[spec].Runs.Using) {
 			outdated = append(outdated, spec)
-			continue
 		}
-
-		fmt.Fprintf(b, "%q: {\n", spec)
-		fmt.Fprintf(b, "Name: %q,\n", meta.Name)
-
-		if meta.SkipInputs {
-			fmt.Fprintf(b, "SkipInputs: true,\n")
-		}
-
-		if len(meta.Inputs) > 0 && !meta.SkipInputs {
-			ids := make([]string, 0, len(meta.Inputs))
-			for n := range meta.Inputs {
-				ids = append(ids, n)
-			}
-			sort.Strings(ids)
-
-			fmt.Fprintf(b, "Inputs: ActionMetadataInputs{\n")
-			for _, id := range ids {
-				i := meta.Inputs[id]
-				fmt.Fprintf(b, "%q: {%q, %v, %v, %q},\n", id, i.Name, i.Required, i.Deprecated, i.DeprecationMessage)
-			}
-			fmt.Fprintf(b, "},\n")
-		}
-
-		if meta.SkipOutputs {
-			fmt.Fprintf(b, "SkipOutputs: true,\n")
-		}
-
-		if len(meta.Outputs) > 0 && !meta.SkipOutputs {
-			ids := make([]string, 0, len(meta.Outputs))
-			for n := range meta.Outputs {
-				ids = append(ids, n)
-			}
-			sort.Strings(ids)
-
-			fmt.Fprintf(b, "Outputs: ActionMetadataOutputs{\n")
-			for _, id := range ids {
-				o := meta.Outputs[id]
-				fmt.Fprintf(b, "%q: {%q},\n", id, o.Name)
-			}
-			fmt.Fprintf(b, "},\n")
-		}
-
-		fmt.Fprintf(b, "},\n")
 	}
 
-	fmt.Fprintln(b, "}")
-
 	fmt.Fprintln(b, `// OutdatedPopularActionSpecs is a spec set of known outdated popular actions. The word 'outdated'
 // means that the runner used by the action is no longer available such as "node12", "node16".
 var OutdatedPopularActionSpecs = map[string]struct{}{`)
@@ -317,6 +415,35 @@ var OutdatedPopularActionSpecs = map[string]struct{}{`)
 	}
 	fmt.Fprintln(b, "}")
 
+	// When all versions are deprecated, the key is the action name without ref
+	keys := []string{}
+	deprecated := map[string]*deprecation{}
+	for _, spec := range specs {
+		d, ok := deprecations[spec]
+		if !ok {
+			continue
+		}
+		k := spec
+		if d.AllVersions {
+			k = spec[:strings.LastIndexByte(spec, '@')]
+		}
+		if _, ok := deprecated[k]; !ok {
+			keys = append(keys, k)
+			deprecated[k] = d
+		}
+	}
+	sort.Strings(keys)
+
+	fmt.Fprintln(b, `// DeprecatedPopularActions is a data set of known deprecated popular actions. Keys are specs
+// (owner/repo@ref) of actions, or names of actions without ref (owner/repo) when all versions of
+// the actions are deprecated.
+var DeprecatedPopularActions = map[string]*DeprecatedAction{`)
+	for _, k := range keys {
+		d := deprecated[k]
+		fmt.Fprintf(b, "%q: {%v, %q, %q},\n", k, d.Archived, d.Message, d.Replacement)
+	}
+	fmt.Fprintln(b, "}")
+
 	// Format the generated source with checking Go syntax
 	gen := b.Bytes()
 	src, err := format.Source(gen)
@@ -328,36 +455,86 @@ var OutdatedPopularActionSpecs = map[string]struct{}{`)
 		return fmt.Errorf("could not output generated Go source to stdout: %w", err)
 	}
 
-	g.log.Printf("Wrote %d action metadata and %d outdated action specs as Go", len(actions)-len(outdated), len(outdated))
+	g.log.Printf("Wrote %d outdated action specs and %d deprecated actions as Go", len(outdated), len(keys))
 	return nil
 }
 
-func (g *gen) readJSONL(file string) (map[string]*actionlint.ActionMetadata, error) {
+// writeData writes the data set of popular actions embedded in actionlint as JSONL. Only the fields
+// used for checking workflows are included and outdated actions are omitted since they are listed
+// in the generated Go source. The lines are sorted by the action specs so that the output is stable.
+func (g *gen) writeData(out io.Writer, actions map[string]*actionlint.ActionMetadata) error {
+	specs := make([]string, 0, len(actions))
+	for s := range actions {
+		specs = append(specs, s)
+	}
+	sort.Strings(specs)
+
+	enc := json.NewEncoder(out)
+	enc.SetEscapeHTML(false)
+	n := 0
+	for _, spec := range specs {
+		meta := actions[spec]
+		if isOutdated(spec, meta.Runs.Using) {
+			continue
+		}
+
+		m := &dataMetadata{
+			Name:        meta.Name,
+			SkipInputs:  meta.SkipInputs,
+			SkipOutputs: meta.SkipOutputs,
+		}
+		if !meta.SkipInputs && len(meta.Inputs) > 0 {
+			m.Inputs = make(map[string]*dataInput, len(meta.Inputs))
+			for id, i := range meta.Inputs {
+				m.Inputs[id] = &dataInput{i.Name, i.Required, i.Deprecated, i.DeprecationMessage, i.Default}
+			}
+		}
+		if !meta.SkipOutputs && len(meta.Outputs) > 0 {
+			m.Outputs = make(map[string]*dataOutput, len(meta.Outputs))
+			for id, o := range meta.Outputs {
+				m.Outputs[id] = &dataOutput{o.Name}
+			}
+		}
+
+		if err := enc.Encode(&dataLine{spec, m}); err != nil {
+			return fmt.Errorf("could not encode action %q data into JSON: %w", spec, err)
+		}
+		n++
+	}
+	g.log.Printf("Wrote %d action metadata as data set", n)
+	return nil
+}
+
+func (g *gen) readJSONL(file string) (map[string]*actionlint.ActionMetadata, map[string]*deprecation, error) {
 	if !strings.HasSuffix(file, ".jsonl") {
-		return nil, fmt.Errorf("JSONL file name must end with \".jsonl\": %s", file)
+		return nil, nil, fmt.Errorf("JSONL file name must end with \".jsonl\": %s", file)
 	}
 
 	f, err := os.Open(file)
 	if err != nil {
-		return nil, fmt.Errorf("could not read file %s: %w", file, err)
+		return nil, nil, fmt.Errorf("could not read file %s: %w", file, err)
 	}
 	defer f.Close()
 
 	r := bufio.NewReader(f)
 	ret := map[string]*actionlint.ActionMetadata{}
+	deprecations := map[string]*deprecation{}
 	for {
 		l, err := r.ReadBytes('\n')
 		if err == io.EOF {
 			g.log.Printf("Read %d action metadata from %s", len(ret), file)
-			return ret, nil
+			return ret, deprecations, nil
 		} else if err != nil {
-			return nil, fmt.Errorf("could not read line in file %s: %w", file, err)
+			return nil, nil, fmt.Errorf("could not read line in file %s: %w", file, err)
 		}
 		var j actionOutput
 		if err := json.Unmarshal(l, &j); err != nil {
-			return nil, fmt.Errorf("could not parse line as JSON for action metadata in file %s: %w", file, err)
+			return nil, nil, fmt.Errorf("could not parse line as JSON for action metadata in file %s: %w", file, err)
 		}
 		ret[j.Spec] = j.Meta
+		if j.Deprecation != nil {
+			deprecations[j.Spec] = j.Deprecation
+		}
 	}
 }
 
@@ -452,7 +629,7 @@ func (g *gen) run(args []string) int {
 
 	flags := flag.NewFlagSet(args[0], flag.ContinueOnError)
 	flags.StringVar(&source, "s", "", "source of actions as local jsonl file path instead of fetching actions metadata from github.com")
-	flags.StringVar(&format, "f", "go", `format of generated code output to stdout. "go" or "jsonl"`)
+	flags.StringVar(&format, "f", "go", `format of generated code output to stdout. "go", "jsonl", or "data"`)
 	flags.StringVar(&registry, "r", "", "registry of actions as local JSON file path. when this flag is not given, the default popular actions registry will be used")
 	flags.BoolVar(&detect, "d", false, "detect new version of actions are released")
 	flags.BoolVar(&quiet, "q", false, "disable log output to stderr")
@@ -465,11 +642,16 @@ func (g *gen) run(args []string) int {
   generated code to stdout.
 
   It can fetch data from remote GitHub repositories and from local JSONL file
-  (-s option). And it can output Go code or JSONL serialized data (-f option).
+  (-s option). And it can output Go code, JSONL serialized data, or JSONL data
+  set embedded in actionlint (-f option).
 
   What actions to be included is defined in the popular actions registry embedded
   in the executable. To use your own registry JSON file, use -r option.
 
+  Deprecated actions are collected from the registry and from archived flags of
+  the repositories fetched via GitHub API. GITHUB_TOKEN environment variable is
+  used for the API token.
+
   When -d flag is given, it tries to detect new release for popular actions.
   When detecting some new releases, it shows their URLs to stdout and returns
   non-zero exit status.
@@ -521,12 +703,13 @@ Flags:`)
 		return 2
 	}
 
-	if format != "go" && format != "jsonl" {
+	if format != "go" && format != "jsonl" && format != "data" {
 		fmt.Fprintf(g.stderr, "invalid value for -f option: %s\n", format)
 		return 1
 	}
 
 	var actions map[string]*actionlint.ActionMetadata
+	var deprecations map[string]*deprecation
 	if source == "" {
 		g.log.Println("Fetching data from https://github.com")
 		m, err := g.fetchRemote()
@@ -534,15 +717,22 @@ Flags:`)
 			fmt.Fprintln(g.stderr, err)
 			return 1
 		}
+		d, err := g.fetchDeprecations()
+		if err != nil {
+			fmt.Fprintln(g.stderr, err)
+			return 1
+		}
 		actions = m
+		deprecations = d
 	} else {
 		g.log.Println("Fetching data from", source)
-		m, err := g.readJSONL(source)
+		m, d, err := g.readJSONL(source)
 		if err != nil {
 			fmt.Fprintln(g.stderr, err)
 			return 1
 		}
 		actions = m
+		deprecations = d
 	}
 
 	where := "stdout"
@@ -561,13 +751,19 @@ Flags:`)
 	switch format {
 	case "go":
 		g.log.Println("Generating Go source code to", where)
-		if err := g.writeGo(out, actions); err != nil {
+		if err := g.writeGo(out, actions, deprecations); err != nil {
 			fmt.Fprintln(g.stderr, err)
 			return 1
 		}
 	case "jsonl":
 		g.log.Println("Generating JSONL source to", where)
-		if err := g.writeJSONL(out, actions); err != nil {
+		if err := g.writeJSONL(out, actions, deprecations); err != nil {
+			fmt.Fprintln(g.stderr, err)
+			return 1
+		}
+	case "data":
+		g.log.Println("Generating JSONL data set to", where)
+		if err := g.writeData(out, actions); err != nil {
 			fmt.Fprintln(g.stderr, err)
 			return 1
 		}
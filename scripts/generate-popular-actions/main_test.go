@@ -10,6 +10,7 @@ import (
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/rhysd/actionlint"
 )
 
 // Normal cases
@@ -57,6 +58,17 @@ func TestDefaultPopularActions(t *testing.T) {
 		if a.FileExt != "yaml" && a.FileExt != "yml" && a.FileExt != "" {
 			t.Errorf(`file ext of action %q is neither "yml" nor "yaml": %q`, a.Slug, a.FileExt)
 		}
+
+		if d := a.Deprecated; d != nil {
+			for _, tag := range d.Tags {
+				if _, ok := tags[tag]; !ok {
+					t.Errorf("deprecated tag %q at action %q is not included in its tags", tag, a.Slug)
+				}
+			}
+			if d.Message == "" && d.Replacement == "" {
+				t.Errorf("neither message nor replacement is set for deprecated action %q", a.Slug)
+			}
+		}
 	}
 }
 
@@ -70,6 +82,9 @@ func TestReadWriteJSONL(t *testing.T) {
 		"outdated.jsonl",
 		"known_outdated.jsonl",
 		"deprecated.jsonl",
+		"deprecated_action.jsonl",
+		"archived_action.jsonl",
+		"multiple_actions.jsonl",
 	}
 
 	for _, file := range files {
@@ -126,6 +141,14 @@ func TestWriteGoToStdout(t *testing.T) {
 			in:   "deprecated.jsonl",
 			want: "deprecated.go",
 		},
+		{
+			in:   "deprecated_action.jsonl",
+			want: "deprecated_action.go",
+		},
+		{
+			in:   "archived_action.jsonl",
+			want: "archived_action.go",
+		},
 	}
 
 	for _, tc := range testCases {
@@ -209,6 +232,33 @@ func TestWriteGoFile(t *testing.T) {
 	}
 }
 
+func TestWriteDataToStdout(t *testing.T) {
+	for _, name := range []string{"no_new_version", "skip_inputs", "skip_outputs", "skip_both", "outdated"} {
+		t.Run(name, func(t *testing.T) {
+			stdout := &bytes.Buffer{}
+			stderr := &bytes.Buffer{}
+			in := filepath.Join("testdata", "jsonl", name+".jsonl")
+			status := newGen(stdout, stderr, io.Discard).run([]string{"test", "-f", "data", "-s", in})
+			if status != 0 {
+				t.Fatalf("exit status is non-zero: %d: %s", status, stderr.Bytes())
+			}
+
+			b, err := os.ReadFile(filepath.Join("testdata", "data", name+".jsonl"))
+			if err != nil {
+				panic(err)
+			}
+			if diff := cmp.Diff(string(b), stdout.String()); diff != "" {
+				t.Fatalf("read content and output content differ\n%s", diff)
+			}
+
+			// The data set must be readable by actionlint
+			if _, err := actionlint.ParseActionMetadataSet(stdout.Bytes()); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
 func TestFetchRemoteYAML(t *testing.T) {
 	tests := []struct {
 		registry string
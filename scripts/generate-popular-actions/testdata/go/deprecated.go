@@ -2,23 +2,11 @@
 
 package actionlint
 
-// PopularActions is data set of known popular actions. Keys are specs (owner/repo@ref) of actions
-// and values are their metadata.
-var PopularActions = map[string]*ActionMetadata{
-	"rhysd/action-setup-vim@v1": {
-		Name: "Setup Vim",
-		Inputs: ActionMetadataInputs{
-			"configure-args": {"configure-args", false, true, "test"},
-			"neovim":         {"neovim", false, true, "test"},
-			"token":          {"token", false, true, "test"},
-			"version":        {"version", false, true, "test"},
-		},
-		Outputs: ActionMetadataOutputs{
-			"executable": {"executable"},
-		},
-	},
-}
-
 // OutdatedPopularActionSpecs is a spec set of known outdated popular actions. The word 'outdated'
 // means that the runner used by the action is no longer available such as "node12", "node16".
 var OutdatedPopularActionSpecs = map[string]struct{}{}
+
+// DeprecatedPopularActions is a data set of known deprecated popular actions. Keys are specs
+// (owner/repo@ref) of actions, or names of actions without ref (owner/repo) when all versions of
+// the actions are deprecated.
+var DeprecatedPopularActions = map[string]*DeprecatedAction{}
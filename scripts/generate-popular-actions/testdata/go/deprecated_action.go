@@ -0,0 +1,14 @@
+// Code generated by actionlint/scripts/generate-popular-actions. DO NOT EDIT.
+
+package actionlint
+
+// OutdatedPopularActionSpecs is a spec set of known outdated popular actions. The word 'outdated'
+// means that the runner used by the action is no longer available such as "node12", "node16".
+var OutdatedPopularActionSpecs = map[string]struct{}{}
+
+// DeprecatedPopularActions is a data set of known deprecated popular actions. Keys are specs
+// (owner/repo@ref) of actions, or names of actions without ref (owner/repo) when all versions of
+// the actions are deprecated.
+var DeprecatedPopularActions = map[string]*DeprecatedAction{
+	"rhysd/action-setup-vim@v1": {false, "test message", "rhysd/action-setup-vim@v2"},
+}
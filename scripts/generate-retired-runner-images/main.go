@@ -0,0 +1,156 @@
+package main
+
+// This is a script to generate a Go source that contains the retired GitHub-hosted runner images.
+// Run the following command from the root of this repository to apply manually.
+// This script is usually run via `go generate`.
+// ```
+// go run ./scripts/generate-retired-runner-images retired_runner_images.go
+// ```
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go/format"
+	"io"
+	"log"
+	"os"
+	"regexp"
+	"sort"
+	"time"
+)
+
+//go:embed retired_runner_images.json
+var defaultSource []byte
+
+var dbg = log.New(io.Discard, "", log.LstdFlags)
+
+var labelPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9.-]*$`)
+
+type image struct {
+	Label       string `json:"label"`
+	Replacement string `json:"replacement"`
+	Retired     string `json:"retired"`
+}
+
+// parse parses the JSON data source and validates the entries. Each entry must have a lower case
+// label, a replacement which is not retired, and the date when the image was retired.
+func parse(src []byte) ([]*image, error) {
+	var images []*image
+	if err := json.Unmarshal(src, &images); err != nil {
+		return nil, fmt.Errorf("could not parse JSON data source: %w", err)
+	}
+	if len(images) == 0 {
+		return nil, errors.New("no retired runner image was found in the data source")
+	}
+
+	seen := map[string]struct{}{}
+	for _, i := range images {
+		if !labelPattern.MatchString(i.Label) {
+			return nil, fmt.Errorf("label %q is invalid. it must be lower case and consist of alphabets, digits, '.', and '-'", i.Label)
+		}
+		if _, ok := seen[i.Label]; ok {
+			return nil, fmt.Errorf("label %q is duplicated", i.Label)
+		}
+		seen[i.Label] = struct{}{}
+		if !labelPattern.MatchString(i.Replacement) {
+			return nil, fmt.Errorf("replacement %q of label %q is invalid", i.Replacement, i.Label)
+		}
+		if _, err := time.Parse(time.DateOnly, i.Retired); err != nil {
+			return nil, fmt.Errorf("retired date %q of label %q is not in YYYY-MM-DD format: %w", i.Retired, i.Label, err)
+		}
+		dbg.Printf("Found retired image %q replaced with %q", i.Label, i.Replacement)
+	}
+	for _, i := range images {
+		if _, ok := seen[i.Replacement]; ok {
+			return nil, fmt.Errorf("replacement %q of label %q is also retired", i.Replacement, i.Label)
+		}
+	}
+
+	sort.Slice(images, func(a, b int) bool { return images[a].Label < images[b].Label })
+	return images, nil
+}
+
+func write(images []*image, out io.Writer) error {
+	buf := &bytes.Buffer{}
+	fmt.Fprintln(buf, `// Code generated by actionlint/scripts/generate-retired-runner-images. DO NOT EDIT.
+
+package actionlint
+
+// RetiredRunnerImages is a table of GitHub-hosted runner images which were retired. The keys are
+// the labels of the images at "runs-on:". This variable was generated by script at
+// ./scripts/generate-retired-runner-images based on ./scripts/generate-retired-runner-images/retired_runner_images.json
+var RetiredRunnerImages = map[string]*RetiredRunnerImage{`)
+	for _, i := range images {
+		fmt.Fprintf(buf, "\t%q: {Replacement: %q, Retired: %q},\n", i.Label, i.Replacement, i.Retired)
+	}
+	fmt.Fprintln(buf, "}")
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return fmt.Errorf("could not format Go source: %w", err)
+	}
+
+	if _, err := out.Write(src); err != nil {
+		return fmt.Errorf("could not write output: %w", err)
+	}
+
+	return nil
+}
+
+func run(args []string, stdout, dbgout io.Writer) error {
+	dbg.SetOutput(dbgout)
+
+	if len(args) > 2 {
+		return errors.New("usage: generate-retired-runner-images [[srcfile] dstfile]")
+	}
+
+	dbg.Println("Start generate-retired-runner-images script")
+
+	src := defaultSource
+	if len(args) == 2 {
+		b, err := os.ReadFile(args[0])
+		if err != nil {
+			return err
+		}
+		src = b
+	}
+
+	images, err := parse(src)
+	if err != nil {
+		return err
+	}
+
+	var out io.Writer
+	var dst string
+	if len(args) == 0 || args[len(args)-1] == "-" {
+		out = stdout
+		dst = "stdout"
+	} else {
+		dst = args[len(args)-1]
+		f, err := os.Create(dst)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		out = f
+	}
+
+	if err := write(images, out); err != nil {
+		return err
+	}
+
+	dbg.Println("Wrote the output to", dst)
+	dbg.Println("Done generate-retired-runner-images script successfully")
+
+	return nil
+}
+
+func main() {
+	if err := run(os.Args[1:], os.Stdout, os.Stderr); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
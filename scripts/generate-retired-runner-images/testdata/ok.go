@@ -0,0 +1,11 @@
+// Code generated by actionlint/scripts/generate-retired-runner-images. DO NOT EDIT.
+
+package actionlint
+
+// RetiredRunnerImages is a table of GitHub-hosted runner images which were retired. The keys are
+// the labels of the images at "runs-on:". This variable was generated by script at
+// ./scripts/generate-retired-runner-images based on ./scripts/generate-retired-runner-images/retired_runner_images.json
+var RetiredRunnerImages = map[string]*RetiredRunnerImage{
+	"ubuntu-18.04": {Replacement: "ubuntu-22.04", Retired: "2023-04-03"},
+	"windows-2016": {Replacement: "windows-2022", Retired: "2022-03-15"},
+}
@@ -0,0 +1,169 @@
+package main
+
+// This is a script to generate a Go source that contains the labels of GitHub-hosted runners.
+// Run the following command from the root of this repository to apply manually.
+// This script is usually run via `go generate`.
+// ```
+// go run ./scripts/generate-runner-labels runner_labels.go
+// ```
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go/format"
+	"io"
+	"log"
+	"os"
+	"regexp"
+)
+
+//go:embed runner_labels.json
+var defaultSource []byte
+
+var dbg = log.New(io.Discard, "", log.LstdFlags)
+
+var labelPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9.-]*$`)
+
+var osNames = map[string]string{
+	"linux":   "RunnerOSLinux",
+	"macos":   "RunnerOSMacOS",
+	"windows": "RunnerOSWindows",
+}
+
+var archNames = map[string]string{
+	"x64":   "RunnerArchX64",
+	"arm64": "RunnerArchARM64",
+}
+
+type label struct {
+	Label string `json:"label"`
+	OS    string `json:"os"`
+	Arch  string `json:"arch"`
+	Image string `json:"image"`
+}
+
+// parse parses the JSON data source and validates the entries. Each entry must have a lower case
+// label, known OS and architecture, and the image which is also listed as a label. The order of
+// the entries is kept since it is used in error messages.
+func parse(src []byte) ([]*label, error) {
+	var labels []*label
+	if err := json.Unmarshal(src, &labels); err != nil {
+		return nil, fmt.Errorf("could not parse JSON data source: %w", err)
+	}
+	if len(labels) == 0 {
+		return nil, errors.New("no runner label was found in the data source")
+	}
+
+	seen := map[string]*label{}
+	for _, l := range labels {
+		if !labelPattern.MatchString(l.Label) {
+			return nil, fmt.Errorf("label %q is invalid. it must be lower case and consist of alphabets, digits, '.', and '-'", l.Label)
+		}
+		if _, ok := seen[l.Label]; ok {
+			return nil, fmt.Errorf("label %q is duplicated", l.Label)
+		}
+		seen[l.Label] = l
+		if _, ok := osNames[l.OS]; !ok {
+			return nil, fmt.Errorf("OS %q of label %q is unknown. it must be one of \"linux\", \"macos\", or \"windows\"", l.OS, l.Label)
+		}
+		if _, ok := archNames[l.Arch]; !ok {
+			return nil, fmt.Errorf("architecture %q of label %q is unknown. it must be one of \"x64\" or \"arm64\"", l.Arch, l.Label)
+		}
+		dbg.Printf("Found runner label %q (%s, %s) for image %q", l.Label, l.OS, l.Arch, l.Image)
+	}
+	for _, l := range labels {
+		i, ok := seen[l.Image]
+		if !ok || i.Image != i.Label {
+			return nil, fmt.Errorf("image %q of label %q is not listed as a label of the image itself", l.Image, l.Label)
+		}
+		if i.OS != l.OS || i.Arch != l.Arch {
+			return nil, fmt.Errorf("OS and architecture of label %q mismatch with its image %q", l.Label, l.Image)
+		}
+	}
+
+	return labels, nil
+}
+
+func write(labels []*label, out io.Writer) error {
+	buf := &bytes.Buffer{}
+	fmt.Fprintln(buf, `// Code generated by actionlint/scripts/generate-runner-labels. DO NOT EDIT.
+
+package actionlint
+
+// GitHubHostedRunners is a catalog of the labels of GitHub-hosted runners. This variable was
+// generated by script at ./scripts/generate-runner-labels based on ./scripts/generate-runner-labels/runner_labels.json
+var GitHubHostedRunners = []*GitHubHostedRunner{`)
+	for _, l := range labels {
+		fmt.Fprintf(buf, "\t{Label: %q, OS: %s, Arch: %s, Image: %q},\n", l.Label, osNames[l.OS], archNames[l.Arch], l.Image)
+	}
+	fmt.Fprintln(buf, "}")
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return fmt.Errorf("could not format Go source: %w", err)
+	}
+
+	if _, err := out.Write(src); err != nil {
+		return fmt.Errorf("could not write output: %w", err)
+	}
+
+	return nil
+}
+
+func run(args []string, stdout, dbgout io.Writer) error {
+	dbg.SetOutput(dbgout)
+
+	if len(args) > 2 {
+		return errors.New("usage: generate-runner-labels [[srcfile] dstfile]")
+	}
+
+	dbg.Println("Start generate-runner-labels script")
+
+	src := defaultSource
+	if len(args) == 2 {
+		b, err := os.ReadFile(args[0])
+		if err != nil {
+			return err
+		}
+		src = b
+	}
+
+	labels, err := parse(src)
+	if err != nil {
+		return err
+	}
+
+	var out io.Writer
+	var dst string
+	if len(args) == 0 || args[len(args)-1] == "-" {
+		out = stdout
+		dst = "stdout"
+	} else {
+		dst = args[len(args)-1]
+		f, err := os.Create(dst)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		out = f
+	}
+
+	if err := write(labels, out); err != nil {
+		return err
+	}
+
+	dbg.Println("Wrote the output to", dst)
+	dbg.Println("Done generate-runner-labels script successfully")
+
+	return nil
+}
+
+func main() {
+	if err := run(os.Args[1:], os.Stdout, os.Stderr); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"errors"
+	"go/parser"
+	"go/token"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestWriteStdoutOK(t *testing.T) {
+	in := filepath.Join("testdata", "ok.json")
+	stdout := &strings.Builder{}
+	if err := run([]string{in, "-"}, stdout, io.Discard); err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := os.ReadFile(filepath.Join("testdata", "ok.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := strings.ReplaceAll(string(b), "\r\n", "\n")
+	have := strings.ReplaceAll(stdout.String(), "\r\n", "\n")
+	if diff := cmp.Diff(want, have); diff != "" {
+		t.Fatal(diff)
+	}
+
+	if _, err := parser.ParseFile(token.NewFileSet(), "", have, parser.AllErrors); err != nil {
+		t.Fatalf("Input is not valid as Go. Error: %s\nSource: %s", err, have)
+	}
+}
+
+func TestWriteFileOK(t *testing.T) {
+	in := filepath.Join("testdata", "ok.json")
+	out := filepath.Join(t.TempDir(), "out.go")
+	if err := run([]string{in, out}, io.Discard, io.Discard); err != nil {
+		t.Fatal(err)
+	}
+
+	want, err := os.ReadFile(filepath.Join("testdata", "ok.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	have, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(string(want), string(have)); diff != "" {
+		t.Fatal(diff)
+	}
+}
+
+func TestDefaultSourceOK(t *testing.T) {
+	stdout := &strings.Builder{}
+	if err := run([]string{}, stdout, io.Discard); err != nil {
+		t.Fatal(err)
+	}
+	b, err := os.ReadFile(filepath.Join("..", "..", "runner_labels.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(string(b), stdout.String()); diff != "" {
+		t.Fatalf("runner_labels.go is outdated. run `go generate`: %s", diff)
+	}
+}
+
+type errWriter struct{}
+
+func (errWriter) Write([]byte) (int, error) {
+	return 0, errors.New("dummy write error")
+}
+
+func TestWriteError(t *testing.T) {
+	in := filepath.Join("testdata", "ok.json")
+	err := run([]string{in, "-"}, errWriter{}, io.Discard)
+	if err == nil {
+		t.Fatal("error did not occur")
+	}
+	if !strings.Contains(err.Error(), "could not write output") {
+		t.Fatalf("unexpected error: %q", err)
+	}
+}
+
+func TestInvalidCommandArgs(t *testing.T) {
+	err := run([]string{"a", "b", "c"}, io.Discard, io.Discard)
+	if err == nil {
+		t.Fatal("error did not occur")
+	}
+	if !strings.Contains(err.Error(), "usage: generate-runner-labels [[srcfile] dstfile]") {
+		t.Fatalf("unexpected error: %q", err)
+	}
+}
+
+func TestParseError(t *testing.T) {
+	tests := []struct {
+		file string
+		want string
+	}{
+		{"broken.json", "could not parse JSON data source"},
+		{"empty.json", "no runner label was found"},
+		{"upper_case_label.json", `label "Ubuntu-24.04" is invalid`},
+		{"duplicate_label.json", `label "ubuntu-24.04" is duplicated`},
+		{"unknown_os.json", `OS "freebsd" of label "ubuntu-24.04" is unknown`},
+		{"unknown_arch.json", `architecture "riscv" of label "ubuntu-24.04" is unknown`},
+		{"unknown_image.json", `image "ubuntu-24.04" of label "ubuntu-latest" is not listed as a label of the image itself`},
+		{"mismatched_os.json", `OS and architecture of label "ubuntu-latest" mismatch with its image "ubuntu-24.04"`},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.file, func(t *testing.T) {
+			err := run([]string{filepath.Join("testdata", tc.file), "-"}, io.Discard, io.Discard)
+			if err == nil {
+				t.Fatal("error did not occur")
+			}
+			if !strings.Contains(err.Error(), tc.want) {
+				t.Fatalf("wanted %q in error %q", tc.want, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,11 @@
+// Code generated by actionlint/scripts/generate-runner-labels. DO NOT EDIT.
+
+package actionlint
+
+// GitHubHostedRunners is a catalog of the labels of GitHub-hosted runners. This variable was
+// generated by script at ./scripts/generate-runner-labels based on ./scripts/generate-runner-labels/runner_labels.json
+var GitHubHostedRunners = []*GitHubHostedRunner{
+	{Label: "ubuntu-latest", OS: RunnerOSLinux, Arch: RunnerArchX64, Image: "ubuntu-24.04"},
+	{Label: "ubuntu-24.04", OS: RunnerOSLinux, Arch: RunnerArchX64, Image: "ubuntu-24.04"},
+	{Label: "windows-11-arm", OS: RunnerOSWindows, Arch: RunnerArchARM64, Image: "windows-11-arm"},
+}
@@ -9,6 +9,7 @@ package main
 
 import (
 	"bytes"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"go/format"
@@ -16,6 +17,7 @@ import (
 	"log"
 	"net/http"
 	"os"
+	"path/filepath"
 	"sort"
 	"strings"
 
@@ -26,6 +28,29 @@ const theURL = "https://docs.github.com/en/actions/reference/workflows-and-actio
 
 var dbg = log.New(io.Discard, "", log.LstdFlags)
 
+// filters is a table of filters available at "on.<event>" for each webhook event. They are described
+// in the workflow syntax document instead of the events document.
+// https://docs.github.com/en/actions/reference/workflows-and-actions/workflow-syntax#onpushpull_requestpull_request_targetpathspaths-ignore
+var filters = map[string][]string{
+	"merge_group":         {"branches", "branches-ignore"},
+	"pull_request":        {"branches", "branches-ignore", "paths", "paths-ignore"},
+	"pull_request_target": {"branches", "branches-ignore", "paths", "paths-ignore"},
+	"push":                {"branches", "branches-ignore", "paths", "paths-ignore", "tags", "tags-ignore"},
+	"workflow_run":        {"branches", "branches-ignore"},
+}
+
+// ghes is a table of the first versions of GitHub Enterprise Server which support webhook events.
+// Events not listed in the table are available in all versions. Empty string means the event is not
+// available in any version.
+// https://docs.github.com/en/enterprise-server@latest/admin/release-notes
+var ghes = map[string]string{
+	"discussion":         "3.6",
+	"discussion_comment": "3.6",
+	"image_version":      "",
+	"merge_group":        "3.12",
+	"workflow_call":      "3.4",
+}
+
 // Parse the activity types of each webhook event. The keys of the map are names of the webhook events
 // like "pull_request", and the values are arrays of names of their activity types.
 // The HTML input is assumed to be fetched from the following page.
@@ -301,6 +326,36 @@ var AllWebhookTypes = map[string][]string{`)
 	}
 	fmt.Fprintln(buf, "}")
 
+	fmt.Fprintln(buf, `
+// AllWebhookEvents is a table of all webhook events with their metadata. This variable was
+// generated by script at ./scripts/generate-webhook-events based on
+// https://docs.github.com/en/actions/reference/workflows-and-actions/events-that-trigger-workflows
+var AllWebhookEvents = map[string]*WebhookEventMetadata{`)
+
+	for _, k := range keys {
+		ts := parsed[k]
+		fmt.Fprintf(buf, "\t%q: {\n", k)
+		if ts == nil {
+			fmt.Fprintln(buf, "\t\tTypes: nil,")
+		} else {
+			fmt.Fprintf(buf, "\t\tTypes: %s,\n", stringsLiteral(ts))
+		}
+		if fs, ok := filters[k]; ok {
+			fmt.Fprintf(buf, "\t\tFilters: %s,\n", stringsLiteral(fs))
+		}
+		fmt.Fprintf(buf, "\t\tURL: %q,\n", theURL+"#"+k)
+		if v, ok := ghes[k]; ok {
+			if v == "" {
+				fmt.Fprintln(buf, "\t\tGHES: &GHESVersion{},")
+			} else {
+				major, minor, _ := strings.Cut(v, ".")
+				fmt.Fprintf(buf, "\t\tGHES: &GHESVersion{Major: %s, Minor: %s},\n", major, minor)
+			}
+		}
+		fmt.Fprintln(buf, "\t},")
+	}
+	fmt.Fprintln(buf, "}")
+
 	src, err := format.Source(buf.Bytes())
 	if err != nil {
 		return fmt.Errorf("could not format Go source: %w", err)
@@ -313,6 +368,53 @@ var AllWebhookTypes = map[string][]string{`)
 	return nil
 }
 
+// webhookEventJSON is an entry of the JSON dataset of webhook events. The format is the same as the
+// one read by actionlint when refreshing the datasets at runtime.
+type webhookEventJSON struct {
+	Types   []string `json:"types"`
+	Filters []string `json:"filters,omitempty"`
+	URL     string   `json:"url"`
+	GHES    *string  `json:"ghes,omitempty"`
+}
+
+// writeJSON writes the webhook events as JSON dataset. This format is used when the output file
+// name ends with ".json".
+func writeJSON(parsed map[string][]string, out io.Writer) error {
+	events := make(map[string]*webhookEventJSON, len(parsed))
+	for k, ts := range parsed {
+		e := &webhookEventJSON{Types: ts, Filters: filters[k], URL: theURL + "#" + k}
+		if v, ok := ghes[k]; ok {
+			e.GHES = &v
+		}
+		events[k] = e
+	}
+
+	b, err := json.MarshalIndent(events, "", "  ")
+	if err != nil {
+		return fmt.Errorf("could not encode webhook events into JSON: %w", err)
+	}
+	b = append(b, '\n')
+
+	if _, err := out.Write(b); err != nil {
+		return fmt.Errorf("could not write output: %w", err)
+	}
+
+	return nil
+}
+
+func stringsLiteral(ss []string) string {
+	var b strings.Builder
+	b.WriteString("[]string{")
+	for i, s := range ss {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		fmt.Fprintf(&b, "%q", s)
+	}
+	b.WriteString("}")
+	return b.String()
+}
+
 func fetch(url string) ([]byte, error) {
 	var c http.Client
 
@@ -375,7 +477,11 @@ func run(args []string, stdout, dbgout io.Writer, srcURL string) error {
 		return err
 	}
 
-	if err := write(p, out); err != nil {
+	w := write
+	if filepath.Ext(dst) == ".json" {
+		w = writeJSON
+	}
+	if err := w(p, out); err != nil {
 		return err
 	}
 
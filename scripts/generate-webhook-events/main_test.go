@@ -110,3 +110,24 @@ func TestFetchURL(t *testing.T) {
 		t.Fatal("Fetched source is empty")
 	}
 }
+
+func TestWriteJSONOK(t *testing.T) {
+	in := filepath.Join("testdata", "ok.html")
+	out := filepath.Join(t.TempDir(), "out.json")
+	if err := run([]string{in, out}, io.Discard, io.Discard, ""); err != nil {
+		t.Fatal(err)
+	}
+
+	have, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, err := os.ReadFile(filepath.Join("testdata", "ok.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if diff := cmp.Diff(strings.ReplaceAll(string(want), "\r\n", "\n"), string(have)); diff != "" {
+		t.Fatal(diff)
+	}
+}
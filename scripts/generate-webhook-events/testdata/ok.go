@@ -12,3 +12,21 @@ var AllWebhookTypes = map[string][]string{
 	"fork":                {},
 	"repository_dispatch": nil,
 }
+
+// AllWebhookEvents is a table of all webhook events with their metadata. This variable was
+// generated by script at ./scripts/generate-webhook-events based on
+// https://docs.github.com/en/actions/reference/workflows-and-actions/events-that-trigger-workflows
+var AllWebhookEvents = map[string]*WebhookEventMetadata{
+	"check_run": {
+		Types: []string{"created", "rerequested", "completed", "requested_action"},
+		URL:   "https://docs.github.com/en/actions/reference/workflows-and-actions/events-that-trigger-workflows#check_run",
+	},
+	"fork": {
+		Types: []string{},
+		URL:   "https://docs.github.com/en/actions/reference/workflows-and-actions/events-that-trigger-workflows#fork",
+	},
+	"repository_dispatch": {
+		Types: nil,
+		URL:   "https://docs.github.com/en/actions/reference/workflows-and-actions/events-that-trigger-workflows#repository_dispatch",
+	},
+}
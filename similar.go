@@ -0,0 +1,75 @@
+package actionlint
+
+import (
+	"sort"
+	"strings"
+)
+
+// editDistance returns the Levenshtein distance between the two strings. Letters are compared in
+// case-insensitive.
+func editDistance(a, b string) int {
+	r, s := []rune(strings.ToLower(a)), []rune(strings.ToLower(b))
+	prev := make([]int, len(s)+1)
+	cur := make([]int, len(s)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(r); i++ {
+		cur[0] = i
+		for j := 1; j <= len(s); j++ {
+			c := prev[j-1]
+			if r[i-1] != s[j-1] {
+				c++
+			}
+			cur[j] = min(c, prev[j]+1, cur[j-1]+1)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(s)]
+}
+
+// similarNames returns the candidates which are close to the name. They are likely what the name
+// was intended to be when it is a typo. The returned names are sorted by the edit distance from the
+// name.
+func similarNames(name string, candidates []string) []string {
+	limit := max(len([]rune(name))/3, 1)
+	type similar struct {
+		name string
+		dist int
+	}
+	found := []similar{}
+	for _, c := range candidates {
+		if d := editDistance(name, c); d <= limit {
+			found = append(found, similar{c, d})
+		}
+	}
+	sort.SliceStable(found, func(i, j int) bool { return found[i].dist < found[j].dist })
+	ret := make([]string, 0, len(found))
+	for _, s := range found {
+		ret = append(ret, s.name)
+	}
+	return ret
+}
+
+// closestName returns the candidate which is the closest to the name. It returns false when no
+// candidate is close to the name or multiple candidates are equally close to the name.
+func closestName(name string, candidates []string) (string, bool) {
+	s := similarNames(name, candidates)
+	if len(s) == 0 || len(s) > 1 && s[0] != s[1] && editDistance(name, s[0]) == editDistance(name, s[1]) {
+		return "", false
+	}
+	return s[0], true
+}
+
+// typoFixes returns the edits to fix the typo in the string with the closest candidate. It returns
+// nil when the closest candidate is not determined.
+func typoFixes(s *String, candidates []string) []*TextEdit {
+	c, ok := closestName(s.Value, candidates)
+	if !ok {
+		return nil
+	}
+	if e := newReplaceStringEdit(s, c); e != nil {
+		return []*TextEdit{e}
+	}
+	return nil
+}
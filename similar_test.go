@@ -0,0 +1,75 @@
+package actionlint
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestSimilarEditDistance(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"", "", 0},
+		{"abc", "", 3},
+		{"", "abc", 3},
+		{"production", "production", 0},
+		{"production", "Production", 0},
+		{"prodution", "production", 1},
+		{"staging", "stagign", 2},
+		{"kitten", "sitting", 3},
+		{"テスト", "テキスト", 1},
+	}
+	for _, tc := range tests {
+		if have := editDistance(tc.a, tc.b); have != tc.want {
+			t.Errorf("distance between %q and %q was %d but wanted %d", tc.a, tc.b, have, tc.want)
+		}
+	}
+}
+
+func TestSimilarNames(t *testing.T) {
+	cands := []string{"production", "staging", "development", "prod"}
+	tests := []struct {
+		name string
+		want []string
+	}{
+		{"prodction", []string{"production"}},
+		{"Staging", []string{"staging"}},
+		{"prd", []string{"prod"}},
+		{"develop", []string{}},
+		{"test", []string{}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, similarNames(tc.name, cands)); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+	}
+}
+
+func TestSimilarClosestName(t *testing.T) {
+	cands := []string{"production", "staging", "stage", "prod", "prom"}
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"prodction", "production"},
+		{"stagin", "staging"},
+		{"stag", "stage"},
+		{"pro", ""}, // Both "prod" and "prom" are equally close
+		{"test", ""},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			have, ok := closestName(tc.name, cands)
+			if ok != (tc.want != "") {
+				t.Fatalf("wanted %q but got %q (%v)", tc.want, have, ok)
+			}
+			if have != tc.want {
+				t.Fatalf("wanted %q but got %q", tc.want, have)
+			}
+		})
+	}
+}
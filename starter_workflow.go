@@ -0,0 +1,288 @@
+package actionlint
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+// StarterWorkflowParam is a parameter of a starter workflow such as the branch name or the Go version.
+type StarterWorkflowParam struct {
+	// Name is the name of the parameter like "branch".
+	Name string
+	// Description is the description of the parameter.
+	Description string
+	// Default is the value used when the parameter is not given.
+	Default string
+}
+
+// StarterWorkflow is a template of workflow generated by "actionlint new" subcommand. The generated
+// workflows have no error reported by actionlint. Actions in them are pinned to their latest major
+// versions in the popular actions data set and permissions of GITHUB_TOKEN are minimal.
+type StarterWorkflow struct {
+	// Name is the name of the starter workflow like "go-test".
+	Name string
+	// Description is the description of the starter workflow.
+	Description string
+	// Params is the parameters of the starter workflow.
+	Params []*StarterWorkflowParam
+	src    string
+}
+
+// starterWorkflows is the list of starter workflows. Templates use "{%" and "%}" as delimiters
+// instead of "{{" and "}}" not to conflict with ${{ }} expressions.
+var starterWorkflows = []*StarterWorkflow{
+	{
+		Name:        "go-test",
+		Description: "Run tests of Go project on Linux, macOS, and Windows",
+		Params: []*StarterWorkflowParam{
+			{"name", "Name of the workflow", "CI"},
+			{"branch", "Branch to run the tests on push", "main"},
+			{"go-version", "Version of Go passed to actions/setup-go", "stable"},
+		},
+		src: `name: {% param "name" %}
+
+on:
+  push:
+    branches: [{% param "branch" %}]
+  pull_request:
+
+permissions:
+  contents: read
+
+jobs:
+  test:
+    strategy:
+      fail-fast: false
+      matrix:
+        os: [ubuntu-latest, macos-latest, windows-latest]
+    runs-on: ${{ matrix.os }}
+    steps:
+      - uses: {% uses "actions/checkout" %}
+        with:
+          persist-credentials: false
+      - uses: {% uses "actions/setup-go" %}
+        with:
+          go-version: {% param "go-version" %}
+      - run: go test -v ./...
+`,
+	},
+	{
+		Name:        "release",
+		Description: "Create GitHub release with generated release notes when a version tag is pushed",
+		Params: []*StarterWorkflowParam{
+			{"name", "Name of the workflow", "Release"},
+			{"tag", "Glob pattern of tags which trigger the release", "v*.*.*"},
+		},
+		src: `name: {% param "name" %}
+
+on:
+  push:
+    tags: [{% param "tag" %}]
+
+permissions: {}
+
+jobs:
+  release:
+    runs-on: ubuntu-latest
+    permissions:
+      contents: write
+    steps:
+      - uses: {% uses "actions/checkout" %}
+        with:
+          persist-credentials: false
+      - uses: {% uses "softprops/action-gh-release" %}
+        with:
+          generate_release_notes: true
+`,
+	},
+	{
+		Name:        "container",
+		Description: "Build container image and push it to container registry except on pull requests",
+		Params: []*StarterWorkflowParam{
+			{"name", "Name of the workflow", "Container"},
+			{"branch", "Branch to push the image on push", "main"},
+			{"registry", "Host of container registry", "ghcr.io"},
+			{"image", "Name of the image in the registry", "${{ github.repository }}"},
+		},
+		src: `name: {% param "name" %}
+
+on:
+  push:
+    branches: [{% param "branch" %}]
+    tags: ['v*']
+  pull_request:
+
+permissions:
+  contents: read
+
+jobs:
+  build:
+    runs-on: ubuntu-latest
+    permissions:
+      contents: read
+      packages: write
+    steps:
+      - uses: {% uses "actions/checkout" %}
+        with:
+          persist-credentials: false
+      - uses: {% uses "docker/setup-buildx-action" %}
+      - uses: {% uses "docker/login-action" %}
+        if: github.event_name != 'pull_request'
+        with:
+          registry: {% param "registry" %}
+          username: ${{ github.actor }}
+          password: ${{ secrets.GITHUB_TOKEN }}
+      - id: meta
+        uses: {% uses "docker/metadata-action" %}
+        with:
+          images: {% join "/" "registry" "image" %}
+      - uses: {% uses "docker/build-push-action" %}
+        with:
+          context: .
+          push: ${{ github.event_name != 'pull_request' }}
+          tags: ${{ steps.meta.outputs.tags }}
+          labels: ${{ steps.meta.outputs.labels }}
+`,
+	},
+	{
+		Name:        "reusable",
+		Description: "Skeleton of reusable workflow with an input and an output",
+		Params: []*StarterWorkflowParam{
+			{"name", "Name of the workflow", "Reusable workflow"},
+			{"runner", "Default label of the runner to run the job", "ubuntu-latest"},
+		},
+		src: `name: {% param "name" %}
+
+on:
+  workflow_call:
+    inputs:
+      runner:
+        description: Label of the runner to run the job
+        type: string
+        default: {% param "runner" %}
+    outputs:
+      result:
+        description: Result of the job
+        value: ${{ jobs.main.outputs.result }}
+
+permissions:
+  contents: read
+
+jobs:
+  main:
+    runs-on: ${{ inputs.runner }}
+    outputs:
+      result: ${{ steps.main.outputs.result }}
+    steps:
+      - uses: {% uses "actions/checkout" %}
+        with:
+          persist-credentials: false
+      - id: main
+        run: echo 'result=done' >> "$GITHUB_OUTPUT"
+`,
+	},
+}
+
+// StarterWorkflows returns the list of all starter workflows.
+func StarterWorkflows() []*StarterWorkflow {
+	return slices.Clone(starterWorkflows)
+}
+
+// FindStarterWorkflow finds the starter workflow by its name. It returns false as the 2nd return
+// value when no starter workflow is found.
+func FindStarterWorkflow(name string) (*StarterWorkflow, bool) {
+	for _, w := range starterWorkflows {
+		if w.Name == name {
+			return w, true
+		}
+	}
+	return nil, false
+}
+
+// StarterWorkflowPinFunc resolves the ref of the action like "v4" to the full commit SHA. The
+// action is a repository like "actions/checkout".
+type StarterWorkflowPinFunc func(action, ref string) (string, error)
+
+// reYAMLPlainScalar matches strings which can be written as plain scalars in YAML without being
+// parsed as other types such as numbers and booleans.
+var reYAMLPlainScalar = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_./*-]*$`)
+
+// yamlScalar returns the string as a YAML scalar. The string is quoted when it cannot be written as
+// a plain scalar.
+func yamlScalar(s string) string {
+	switch strings.ToLower(s) {
+	case "true", "false", "yes", "no", "on", "off", "null", "y", "n":
+	default:
+		if reYAMLPlainScalar.MatchString(s) {
+			return s
+		}
+	}
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
+
+// Generate generates the workflow source with the parameters. Parameters which are not given are
+// set to their default values. It returns an error when some parameter is unknown. When the pin
+// function is not nil, actions are pinned to the commit SHAs resolved by it and their versions are
+// put in comments.
+func (w *StarterWorkflow) Generate(params map[string]string, pin StarterWorkflowPinFunc) ([]byte, error) {
+	vals := make(map[string]string, len(w.Params))
+	for _, p := range w.Params {
+		vals[p.Name] = p.Default
+	}
+	for k, v := range params {
+		if _, ok := vals[k]; !ok {
+			names := make([]string, 0, len(w.Params))
+			for _, p := range w.Params {
+				names = append(names, p.Name)
+			}
+			return nil, fmt.Errorf("unknown parameter %q for starter workflow %q. available parameters are %s", k, w.Name, quotes(names))
+		}
+		if strings.ContainsAny(v, "\n\r") {
+			return nil, fmt.Errorf("value of parameter %q must not contain newlines: %q", k, v)
+		}
+		vals[k] = v
+	}
+
+	latest := popularActionLatestMajors()
+	funcs := template.FuncMap{
+		"param": func(name string) string {
+			return yamlScalar(vals[name])
+		},
+		"join": func(sep string, names ...string) string {
+			ss := make([]string, 0, len(names))
+			for _, n := range names {
+				ss = append(ss, vals[n])
+			}
+			return yamlScalar(strings.Join(ss, sep))
+		},
+		"uses": func(action string) (string, error) {
+			v, ok := latest[action]
+			if !ok {
+				return "", fmt.Errorf("version of action %q is unknown", action)
+			}
+			ref := "v" + strconv.Itoa(v)
+			if pin == nil {
+				return action + "@" + ref, nil
+			}
+			sha, err := pin(action, ref)
+			if err != nil {
+				return "", fmt.Errorf("could not pin action %q at %q to commit SHA: %w", action, ref, err)
+			}
+			return action + "@" + sha + " # " + ref, nil
+		},
+	}
+	t, err := template.New(w.Name).Delims("{%", "%}").Funcs(funcs).Parse(w.src)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse starter workflow %q: %w", w.Name, err)
+	}
+	var b bytes.Buffer
+	if err := t.Execute(&b, nil); err != nil {
+		return nil, fmt.Errorf("could not generate starter workflow %q: %w", w.Name, err)
+	}
+	return b.Bytes(), nil
+}
@@ -0,0 +1,115 @@
+package actionlint
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+)
+
+func testLintStarterWorkflow(t *testing.T, name string, src []byte) {
+	t.Helper()
+	l, err := NewLinter(io.Discard, &LinterOptions{Shellcheck: "", Pyflakes: ""})
+	if err != nil {
+		t.Fatal(err)
+	}
+	errs, err := l.Lint(name+".yaml", src, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(errs) > 0 {
+		t.Fatalf("generated workflow has errors: %v\n%s", errs, src)
+	}
+}
+
+func TestStarterWorkflowsAreLintClean(t *testing.T) {
+	for _, w := range StarterWorkflows() {
+		t.Run(w.Name, func(t *testing.T) {
+			src, err := w.Generate(nil, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			testLintStarterWorkflow(t, w.Name, src)
+			if !strings.Contains(string(src), "permissions:") {
+				t.Errorf("permissions are not set:\n%s", src)
+			}
+			if !strings.Contains(string(src), "actions/checkout@v") {
+				t.Errorf("version of actions/checkout is not pinned:\n%s", src)
+			}
+
+			// Values which need to be quoted in YAML
+			params := map[string]string{}
+			for _, p := range w.Params {
+				params[p.Name] = "1.0"
+			}
+			params["name"] = "1.0 'quoted' #comment"
+			if w.Name == "reusable" {
+				params["runner"] = "true"
+			}
+			src, err = w.Generate(params, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			testLintStarterWorkflow(t, w.Name, src)
+			wf, _ := Parse(src)
+			if wf.Name == nil || wf.Name.Value != params["name"] {
+				t.Errorf("name of workflow is not %q: %v", params["name"], wf.Name)
+			}
+		})
+	}
+}
+
+func TestStarterWorkflowParams(t *testing.T) {
+	w, ok := FindStarterWorkflow("go-test")
+	if !ok {
+		t.Fatal("go-test starter workflow was not found")
+	}
+	src, err := w.Generate(map[string]string{"go-version": "1.24", "branch": "develop"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"go-version: '1.24'", "branches: [develop]", "name: CI\n"} {
+		if !strings.Contains(string(src), want) {
+			t.Errorf("%q is not included in generated workflow:\n%s", want, src)
+		}
+	}
+
+	if _, err := w.Generate(map[string]string{"foo": "bar"}, nil); err == nil || !strings.Contains(err.Error(), `unknown parameter "foo"`) {
+		t.Fatalf("unexpected error for unknown parameter: %v", err)
+	}
+	if _, err := w.Generate(map[string]string{"name": "a\nb"}, nil); err == nil || !strings.Contains(err.Error(), "must not contain newlines") {
+		t.Fatalf("unexpected error for multi-line parameter: %v", err)
+	}
+
+	if _, ok := FindStarterWorkflow("unknown"); ok {
+		t.Fatal("unknown starter workflow was found")
+	}
+}
+
+func TestStarterWorkflowPin(t *testing.T) {
+	w, _ := FindStarterWorkflow("release")
+	pinned := map[string]string{}
+	pin := func(action, ref string) (string, error) {
+		pinned[action] = ref
+		return strings.Repeat("a", 40), nil
+	}
+	src, err := w.Generate(nil, pin)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := fmt.Sprintf("uses: actions/checkout@%s # %s\n", strings.Repeat("a", 40), pinned["actions/checkout"])
+	if !strings.Contains(string(src), want) {
+		t.Fatalf("%q is not included in generated workflow:\n%s", want, src)
+	}
+	if len(pinned) != 2 {
+		t.Fatalf("unexpected pinned actions: %v", pinned)
+	}
+	testLintStarterWorkflow(t, w.Name, src)
+
+	_, err = w.Generate(nil, func(action, ref string) (string, error) {
+		return "", fmt.Errorf("oops")
+	})
+	if err == nil || !strings.Contains(err.Error(), `could not pin action "actions/checkout"`) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,235 @@
+package actionlint
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// upgradeMajorRefPattern matches refs of versions like "v3", "v3.1", or "v3.1.0".
+var upgradeMajorRefPattern = regexp.MustCompile(`^v(\d+)(?:\.\d+){0,2}$`)
+
+// upgradeMaxRenameDistance is the maximum edit distance between the names of an old input and its
+// renamed input. Renames between majors are usually small like "app_id" to "app-id". Larger distance
+// is likely a different input.
+const upgradeMaxRenameDistance = 2
+
+// popularActionLatestMajors returns the mapping from popular actions like "actions/checkout" to
+// their latest major versions in the popular actions data set.
+var popularActionLatestMajors = sync.OnceValue(collectPopularActionLatestMajors)
+
+func collectPopularActionLatestMajors() map[string]int {
+	m := map[string]int{}
+	for spec := range knownPopularActions() {
+		name, ref, ok := strings.Cut(spec, "@")
+		if !ok || !strings.HasPrefix(ref, "v") {
+			continue
+		}
+		v, err := strconv.Atoi(ref[1:])
+		if err != nil {
+			continue // Refs like "v3-node20"
+		}
+		m[name] = max(m[name], v)
+	}
+	return m
+}
+
+// ActionUpgrade is an upgrade of a popular action at "uses:" to its latest major version.
+type ActionUpgrade struct {
+	// Pos is the position of the "uses:" value.
+	Pos *Pos
+	// From is the action spec before the upgrade like "actions/checkout@v3".
+	From string
+	// To is the action spec after the upgrade like "actions/checkout@v6".
+	To string
+	// RenamedInputs is the mapping from the old input names to the new input names.
+	RenamedInputs map[string]string
+	// RemovedInputs is the names of inputs removed since they are no longer available.
+	RemovedInputs []string
+	// RequiredInputs is the names of inputs which are newly required in the new version. They need
+	// to be set manually.
+	RequiredInputs []string
+}
+
+func (u *ActionUpgrade) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "upgrade %q to %q", u.From, u.To)
+	olds := make([]string, 0, len(u.RenamedInputs))
+	for o := range u.RenamedInputs {
+		olds = append(olds, o)
+	}
+	slices.Sort(olds)
+	for _, o := range olds {
+		fmt.Fprintf(&b, ". renamed input %q to %q", o, u.RenamedInputs[o])
+	}
+	for _, i := range u.RemovedInputs {
+		fmt.Fprintf(&b, ". removed input %q since it is not available", i)
+	}
+	for _, i := range u.RequiredInputs {
+		fmt.Fprintf(&b, ". input %q is newly required. set it manually", i)
+	}
+	return b.String()
+}
+
+// UpgradeActions upgrades popular actions at "uses:" in the workflow source to their latest major
+// versions in the popular actions data set (LoadPopularActions()). Version bumps of major versions may
+// break the workflow due to the changes of inputs. So the inputs are also migrated:
+//
+//   - Inputs which are no longer available are renamed to the new inputs when their names are very
+//     close like "app_id" and "app-id". Otherwise they are removed.
+//   - Inputs which are newly required are reported in RequiredInputs of the returned upgrades.
+//
+// Actions whose refs are not versions like "v3" or "v3.1.0" (e.g. commit SHAs or branches) are not
+// upgraded. The source is rewritten with WorkflowWriter so comments and formatting are preserved.
+// It returns the rewritten source and the applied upgrades.
+func UpgradeActions(src []byte) ([]byte, []*ActionUpgrade, error) {
+	if _, err := LoadPopularActions(); err != nil {
+		return nil, nil, err
+	}
+
+	w, errs := Parse(src)
+	if w == nil {
+		if len(errs) > 0 {
+			return nil, nil, fmt.Errorf("could not parse workflow: %w", errs[0])
+		}
+		return nil, nil, errors.New("could not parse workflow")
+	}
+
+	ww := NewWorkflowWriter(src)
+	upgrades := []*ActionUpgrade{}
+	for _, j := range w.Jobs {
+		for _, s := range j.Steps {
+			if e, ok := s.Exec.(*ExecAction); ok {
+				if u := upgradeAction(ww, e); u != nil {
+					upgrades = append(upgrades, u)
+				}
+			}
+		}
+	}
+	slices.SortFunc(upgrades, func(a, b *ActionUpgrade) int {
+		if a.Pos.Line != b.Pos.Line {
+			return a.Pos.Line - b.Pos.Line
+		}
+		return a.Pos.Col - b.Pos.Col
+	})
+
+	b, err := ww.Bytes()
+	if err != nil {
+		return nil, nil, err
+	}
+	return b, upgrades, nil
+}
+
+func upgradeAction(ww *WorkflowWriter, e *ExecAction) *ActionUpgrade {
+	if e.Uses == nil || e.Uses.ContainsExpression() {
+		return nil
+	}
+	name, ref, ok := strings.Cut(e.Uses.Value, "@")
+	if !ok {
+		return nil
+	}
+	m := upgradeMajorRefPattern.FindStringSubmatch(ref)
+	if m == nil {
+		return nil
+	}
+	cur, _ := strconv.Atoi(m[1])
+	latest, ok := popularActionLatestMajors()[name]
+	if !ok || latest <= cur {
+		return nil
+	}
+
+	to := fmt.Sprintf("%s@v%d", name, latest)
+	if err := ww.ReplaceString(e.Uses, to); err != nil {
+		return nil
+	}
+	u := &ActionUpgrade{Pos: e.Uses.Pos, From: e.Uses.Value, To: to, RenamedInputs: map[string]string{}}
+
+	actions := knownPopularActions()
+	meta := actions[to]
+	if meta.SkipInputs {
+		return u
+	}
+	old := actions[fmt.Sprintf("%s@v%d", name, cur)] // This may be nil when the version is outdated
+
+	// Inputs of the new version which are not set yet are the candidates of the renamed inputs
+	cands := []string{}
+	for id, i := range meta.Inputs {
+		if _, ok := e.Inputs[id]; !ok {
+			cands = append(cands, i.Name)
+		}
+	}
+	slices.Sort(cands)
+
+	ids := make([]string, 0, len(e.Inputs))
+	for id := range e.Inputs {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+
+	removed := 0
+	for _, id := range ids {
+		if _, ok := meta.Inputs[id]; ok {
+			continue
+		}
+		i := e.Inputs[id]
+		if n, ok := closestName(i.Name.Value, cands); ok && editDistance(i.Name.Value, n) <= upgradeMaxRenameDistance && ww.ReplaceString(i.Name, n) == nil {
+			u.RenamedInputs[i.Name.Value] = n
+			cands = slices.DeleteFunc(cands, func(c string) bool { return c == n })
+			continue
+		}
+		if ww.DeleteMappingEntry(i.Name) == nil {
+			u.RemovedInputs = append(u.RemovedInputs, i.Name.Value)
+			removed++
+		}
+	}
+	if removed > 0 && removed == len(e.Inputs) && e.Entrypoint == nil && e.Args == nil {
+		upgradeDeleteWithSection(ww, e)
+	}
+
+	for id, i := range meta.Inputs {
+		if !i.Required {
+			continue
+		}
+		if _, ok := e.Inputs[id]; ok {
+			continue
+		}
+		if old != nil {
+			if o, ok := old.Inputs[id]; ok && o.Required {
+				continue // Already missing in the old version
+			}
+		}
+		if slices.Contains(slices.Collect(maps.Values(u.RenamedInputs)), i.Name) {
+			continue
+		}
+		u.RequiredInputs = append(u.RequiredInputs, i.Name)
+	}
+	slices.Sort(u.RequiredInputs)
+
+	return u
+}
+
+// upgradeDeleteWithSection deletes "with:" line of the step when all inputs in it were removed. Empty
+// "with:" section is null in YAML and causes a syntax error.
+func upgradeDeleteWithSection(ww *WorkflowWriter, e *ExecAction) {
+	first := 0
+	for _, i := range e.Inputs {
+		if first == 0 || i.Name.Pos.Line < first {
+			first = i.Name.Pos.Line
+		}
+	}
+	if first < 2 {
+		return
+	}
+	l := strings.TrimSpace(ww.lines[first-2])
+	if c := strings.Index(l, "#"); c >= 0 {
+		l = strings.TrimSpace(l[:c])
+	}
+	if l == "with:" {
+		ww.DeleteLines(first-1, first-1)
+	}
+}
@@ -0,0 +1,84 @@
+package actionlint
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestUpgradeActions(t *testing.T) {
+	src, err := os.ReadFile(filepath.Join("testdata", "upgrade", "basic.yaml"))
+	if err != nil {
+		panic(err)
+	}
+	want, err := os.ReadFile(filepath.Join("testdata", "upgrade", "basic.out"))
+	if err != nil {
+		panic(err)
+	}
+
+	have, us, err := UpgradeActions(src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(string(want), string(have)); diff != "" {
+		t.Fatal(diff)
+	}
+
+	msgs := make([]string, 0, len(us))
+	for _, u := range us {
+		msgs = append(msgs, u.Pos.String()+": "+u.String())
+	}
+	wantMsgs := []string{
+		`line:7,col:15: upgrade "actions/checkout@v3" to "actions/checkout@v6"`,
+		`line:9,col:15: upgrade "actions/first-interaction@v1" to "actions/first-interaction@v3". renamed input "issue-message" to "issue_message". renamed input "pr-message" to "pr_message". renamed input "repo-token" to "repo_token"`,
+		`line:16,col:15: upgrade "docker/setup-buildx-action@v3" to "docker/setup-buildx-action@v4". removed input "config-inline" since it is not available. removed input "install" since it is not available`,
+		`line:23,col:15: upgrade "actions/setup-node@v4.1.0" to "actions/setup-node@v6". removed input "always-auth" since it is not available`,
+	}
+	if diff := cmp.Diff(wantMsgs, msgs); diff != "" {
+		t.Fatal(diff)
+	}
+
+	// Upgraded workflow has no error
+	if _, _, err := UpgradeActions(have); err != nil {
+		t.Fatal(err)
+	}
+	if _, errs := Parse(have); len(errs) > 0 {
+		t.Fatal(errs)
+	}
+}
+
+func TestUpgradeActionsNothing(t *testing.T) {
+	src := []byte("on: push\njobs:\n  test:\n    runs-on: ubuntu-latest\n    steps:\n      - uses: actions/checkout@v6\n")
+	have, us, err := UpgradeActions(src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(us) > 0 {
+		t.Fatal("unexpected upgrades:", us)
+	}
+	if string(have) != string(src) {
+		t.Fatalf("source was modified: %q", have)
+	}
+}
+
+func TestUpgradeActionUpgradeString(t *testing.T) {
+	u := &ActionUpgrade{
+		From:           "foo/bar@v1",
+		To:             "foo/bar@v2",
+		RenamedInputs:  map[string]string{"b_b": "b-b", "a_a": "a-a"},
+		RemovedInputs:  []string{"c"},
+		RequiredInputs: []string{"d"},
+	}
+	want := `upgrade "foo/bar@v1" to "foo/bar@v2". renamed input "a_a" to "a-a". renamed input "b_b" to "b-b". removed input "c" since it is not available. input "d" is newly required. set it manually`
+	if have := u.String(); have != want {
+		t.Fatalf("wanted %q but got %q", want, have)
+	}
+}
+
+func TestUpgradeActionsError(t *testing.T) {
+	if _, _, err := UpgradeActions([]byte("on: [")); err == nil {
+		t.Fatal("error did not occur")
+	}
+}
@@ -0,0 +1,63 @@
+/** Location related to an error such as the location of the previous definition of a duplicate ID. */
+export interface RelatedLocation {
+    /** File path of the location. This is omitted when the location is in the same file as the error. */
+    filepath?: string;
+    /** 1-based line number of the location. */
+    line: number;
+    /** 1-based column number of the location. */
+    column: number;
+    /** Message to describe the location. */
+    message?: string;
+}
+
+/** Edit to fix an error. */
+export interface TextEdit {
+    /** 1-based line number where the replaced range starts. */
+    line: number;
+    /** 1-based column number where the replaced range starts. */
+    column: number;
+    end_line: number;
+    /** 1-based column number where the replaced range ends. The character at this column is not replaced. */
+    end_column: number;
+    new_text: string;
+}
+
+/** Error found by actionlint. This is the same as the output of `actionlint -format '{{json .}}'`. */
+export interface Finding {
+    /** Error message. */
+    message: string;
+    /** File path passed to `lint()`. */
+    filepath?: string;
+    /** 1-based line number of the error position. */
+    line: number;
+    /** 1-based column number of the error position. */
+    column: number;
+    /** Rule name which found the error such as "expression". */
+    kind: string;
+    /** Diagnostic code of the error within the rule such as "SC2086". */
+    code?: string;
+    severity: 'error' | 'warning' | 'info';
+    /** Code snippet with an indicator of the error position. */
+    snippet?: string;
+    /** 1-based line number where the range of the error ends. */
+    end_line: number;
+    /** 1-based column number where the range of the error ends. */
+    end_column: number;
+    related?: RelatedLocation[];
+    fixes?: TextEdit[];
+}
+
+export interface Actionlint {
+    /**
+     * Lint the workflow file content and return the found errors. `fileName` is used for the file path
+     * of the errors. `configYAML` is the content of actionlint.yaml. This method throws an error when
+     * the config is invalid.
+     */
+    lint(fileName: string, content: string, configYAML?: string): Finding[];
+}
+
+/**
+ * Load actionlint.wasm and return the API. When `source` is omitted, actionlint.wasm is fetched from
+ * the same location as this module.
+ */
+export function load(source?: BufferSource | Response | Promise<Response>): Promise<Actionlint>;
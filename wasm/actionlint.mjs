@@ -0,0 +1,41 @@
+// ES module to load actionlint.wasm and lint workflow files with it. See README.md for the usage.
+import './wasm_exec.js';
+
+async function readWasm(source) {
+    if (source === undefined) {
+        source = fetch(new URL('./actionlint.wasm', import.meta.url));
+    }
+    source = await source;
+    if (source instanceof ArrayBuffer || ArrayBuffer.isView(source)) {
+        return source;
+    }
+    if (!source.ok) {
+        throw new Error(`could not fetch actionlint.wasm: ${source.status} ${source.statusText}`);
+    }
+    return source.arrayBuffer();
+}
+
+export async function load(source) {
+    const bin = await readWasm(source);
+    const go = new Go();
+    const { instance } = await WebAssembly.instantiate(bin, go.importObject);
+
+    // Do not `await` this method call since it is never settled. The API is registered synchronously
+    // before the Go main function starts to wait forever.
+    void go.run(instance);
+
+    const api = globalThis.actionlint;
+    if (typeof api?.lint !== 'function') {
+        throw new Error('actionlint API was not registered by actionlint.wasm');
+    }
+
+    return {
+        lint(fileName, content, configYAML) {
+            const ret = api.lint(fileName, content, configYAML ?? '');
+            if (ret instanceof Error) {
+                throw ret;
+            }
+            return JSON.parse(ret);
+        },
+    };
+}
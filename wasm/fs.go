@@ -0,0 +1,114 @@
+//go:build wasm
+
+package main
+
+import (
+	"bytes"
+	"io"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+	"time"
+)
+
+// memFS is a read-only in-memory filesystem. Keys are slash-separated paths of regular files.
+// Directories are not stored explicitly. They exist when some file is put under them.
+type memFS map[string][]byte
+
+func (m memFS) Open(name string) (fs.File, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrInvalid}
+	}
+
+	if b, ok := m[name]; ok {
+		return &memFile{info: memFileInfo{name: path.Base(name), size: int64(len(b))}, r: bytes.NewReader(b)}, nil
+	}
+
+	prefix := name + "/"
+	if name == "." {
+		prefix = ""
+	}
+	seen := map[string]bool{}
+	entries := []fs.DirEntry{}
+	for p, b := range m {
+		rest, ok := strings.CutPrefix(p, prefix)
+		if !ok {
+			continue
+		}
+		child, _, isDir := strings.Cut(rest, "/")
+		if seen[child] {
+			continue
+		}
+		seen[child] = true
+		info := memFileInfo{name: child, dir: isDir}
+		if !isDir {
+			info.size = int64(len(b))
+		}
+		entries = append(entries, info)
+	}
+	if len(entries) == 0 && name != "." {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name() < entries[j].Name() })
+
+	return &memDir{info: memFileInfo{name: path.Base(name), dir: true}, entries: entries}, nil
+}
+
+// memFileInfo implements both fs.FileInfo and fs.DirEntry for files and directories in memFS.
+type memFileInfo struct {
+	name string
+	size int64
+	dir  bool
+}
+
+func (i memFileInfo) Name() string { return i.name }
+func (i memFileInfo) Size() int64  { return i.size }
+func (i memFileInfo) Mode() fs.FileMode {
+	if i.dir {
+		return fs.ModeDir | 0555
+	}
+	return 0444
+}
+func (i memFileInfo) ModTime() time.Time         { return time.Time{} }
+func (i memFileInfo) IsDir() bool                { return i.dir }
+func (i memFileInfo) Sys() any                   { return nil }
+func (i memFileInfo) Type() fs.FileMode          { return i.Mode().Type() }
+func (i memFileInfo) Info() (fs.FileInfo, error) { return i, nil }
+
+type memFile struct {
+	info memFileInfo
+	r    *bytes.Reader
+}
+
+func (f *memFile) Stat() (fs.FileInfo, error) { return f.info, nil }
+func (f *memFile) Read(b []byte) (int, error) { return f.r.Read(b) }
+func (f *memFile) Close() error               { return nil }
+
+type memDir struct {
+	info    memFileInfo
+	entries []fs.DirEntry
+	offset  int
+}
+
+func (d *memDir) Stat() (fs.FileInfo, error) { return d.info, nil }
+func (d *memDir) Close() error               { return nil }
+func (d *memDir) Read([]byte) (int, error) {
+	return 0, &fs.PathError{Op: "read", Path: d.info.name, Err: fs.ErrInvalid}
+}
+
+func (d *memDir) ReadDir(n int) ([]fs.DirEntry, error) {
+	rest := d.entries[d.offset:]
+	if n <= 0 {
+		d.offset = len(d.entries)
+		return rest, nil
+	}
+	if len(rest) == 0 {
+		return nil, io.EOF
+	}
+	if n > len(rest) {
+		n = len(rest)
+	}
+	d.offset += n
+	return rest[:n], nil
+}
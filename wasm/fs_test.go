@@ -0,0 +1,30 @@
+//go:build wasm
+
+package main
+
+import (
+	"errors"
+	"io/fs"
+	"testing"
+	"testing/fstest"
+)
+
+func TestMemFS(t *testing.T) {
+	fsys := memFS{
+		".github/actionlint.yaml":    []byte("self-hosted-runner:\n  labels: []\n"),
+		".github/workflows/test.yml": []byte("on: push\n"),
+		"README.md":                  []byte("hello"),
+	}
+	if err := fstest.TestFS(fsys, ".github/actionlint.yaml", ".github/workflows/test.yml", "README.md"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestMemFSEmpty(t *testing.T) {
+	if err := fstest.TestFS(memFS{}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := fs.Stat(memFS{}, ".github/actionlint.yaml"); !errors.Is(err, fs.ErrNotExist) {
+		t.Fatalf("wanted fs.ErrNotExist but got %v", err)
+	}
+}
@@ -0,0 +1,52 @@
+//go:build wasm
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/rhysd/actionlint"
+)
+
+// lint checks the workflow content with the configuration in YAML format and returns the found
+// errors encoded in JSON. The JSON is the same as the output of `actionlint -format '{{json .}}'`.
+// An empty config means no configuration. fileName is used for the file path of the errors.
+func lint(fileName, content, config string) ([]byte, error) {
+	if fileName == "" {
+		fileName = "workflow.yaml"
+	}
+
+	// Always lint the content in an in-memory project. Otherwise the linter looks for the project in
+	// the host filesystem, which blocks forever when the filesystem API of the host is asynchronous.
+	fsys := memFS{}
+	if config != "" {
+		fsys[".github/actionlint.yaml"] = []byte(config)
+	}
+	proj, err := actionlint.NewProjectFS(fsys, ".")
+	if err != nil {
+		return nil, err
+	}
+
+	l, err := actionlint.NewLinter(io.Discard, &actionlint.LinterOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("could not create linter: %w", err)
+	}
+
+	src := []byte(content)
+	errs, err := l.Lint(fileName, src, proj)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := make([]*actionlint.ErrorTemplateFields, 0, len(errs))
+	for _, e := range errs {
+		fields = append(fields, e.GetTemplateFields(src))
+	}
+	b, err := json.Marshal(fields)
+	if err != nil {
+		return nil, fmt.Errorf("could not encode errors into JSON: %w", err)
+	}
+	return b, nil
+}
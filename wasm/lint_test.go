@@ -0,0 +1,86 @@
+//go:build wasm
+
+package main
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+type testFinding struct {
+	Message  string `json:"message"`
+	Filepath string `json:"filepath"`
+	Line     int    `json:"line"`
+	Column   int    `json:"column"`
+	Kind     string `json:"kind"`
+	Severity string `json:"severity"`
+}
+
+func testLint(t *testing.T, fileName, content, config string) []*testFinding {
+	t.Helper()
+	b, err := lint(fileName, content, config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var fs []*testFinding
+	if err := json.Unmarshal(b, &fs); err != nil {
+		t.Fatalf("could not parse output %q: %v", b, err)
+	}
+	return fs
+}
+
+func TestLintOK(t *testing.T) {
+	src := "on: push\njobs:\n  test:\n    runs-on: ubuntu-latest\n    steps:\n      - run: echo hi\n"
+	fs := testLint(t, "test.yaml", src, "")
+	if len(fs) != 0 {
+		t.Fatalf("wanted no error but got %v", fs)
+	}
+}
+
+func TestLintErrors(t *testing.T) {
+	src := "on: foo\njobs:\n  test:\n    steps:\n      - run: echo hi\n"
+	fs := testLint(t, ".github/workflows/test.yaml", src, "")
+	if len(fs) != 2 {
+		t.Fatalf("wanted 2 errors but got %d: %v", len(fs), fs)
+	}
+
+	f := fs[0]
+	if f.Filepath != ".github/workflows/test.yaml" || f.Line != 1 || f.Column != 5 || f.Kind != "events" || f.Severity != "error" {
+		t.Errorf("unexpected first error: %+v", f)
+	}
+	if !strings.Contains(f.Message, `unknown Webhook event "foo"`) {
+		t.Errorf("unexpected message: %q", f.Message)
+	}
+	if f := fs[1]; f.Kind != "syntax-check" || !strings.Contains(f.Message, `"runs-on" section is missing`) {
+		t.Errorf("unexpected second error: %+v", f)
+	}
+}
+
+func TestLintDefaultFileName(t *testing.T) {
+	fs := testLint(t, "", "on: foo\njobs:\n  test:\n    runs-on: ubuntu-latest\n    steps:\n      - run: echo\n", "")
+	if len(fs) != 1 || fs[0].Filepath != "workflow.yaml" {
+		t.Fatalf("unexpected errors: %v", fs)
+	}
+}
+
+func TestLintWithConfig(t *testing.T) {
+	src := "on: push\njobs:\n  test:\n    runs-on: my-runner\n    steps:\n      - run: echo hi\n"
+	if fs := testLint(t, "test.yaml", src, ""); len(fs) != 1 || fs[0].Kind != "runner-label" {
+		t.Fatalf("unknown runner label should be reported without config: %v", fs)
+	}
+	cfg := "self-hosted-runner:\n  labels:\n    - my-runner\n"
+	if fs := testLint(t, "test.yaml", src, cfg); len(fs) != 0 {
+		t.Fatalf("runner label in config should be accepted: %v", fs)
+	}
+}
+
+func TestLintConfigError(t *testing.T) {
+	_, err := lint("test.yaml", "on: push\n", "self-hosted-runner: [")
+	if err == nil {
+		t.Fatal("error did not occur")
+	}
+	if msg := err.Error(); !strings.Contains(msg, "could not parse config file") {
+		t.Fatalf("unexpected error: %q", msg)
+	}
+}
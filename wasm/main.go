@@ -0,0 +1,32 @@
+//go:build wasm
+
+// This is the entry point of actionlint.wasm, which provides actionlint as WebAssembly for embedding
+// it in web-based editors or other JavaScript applications. See README.md for the JavaScript API.
+package main
+
+import (
+	"syscall/js"
+)
+
+func jsLint(_this js.Value, args []js.Value) any {
+	if len(args) < 2 {
+		return js.Global().Get("Error").New("lint() requires at least 2 arguments: fileName and content")
+	}
+	config := ""
+	if len(args) > 2 && args[2].Type() == js.TypeString {
+		config = args[2].String()
+	}
+
+	b, err := lint(args[0].String(), args[1].String(), config)
+	if err != nil {
+		return js.Global().Get("Error").New(err.Error())
+	}
+	return string(b)
+}
+
+func main() {
+	api := js.Global().Get("Object").New()
+	api.Set("lint", js.FuncOf(jsLint))
+	js.Global().Set("actionlint", api)
+	select {}
+}
@@ -0,0 +1,34 @@
+import { test } from 'node:test';
+import { strict as assert } from 'node:assert';
+import { readFile } from 'node:fs/promises';
+import { load } from './actionlint.mjs';
+
+const actionlint = await load(await readFile(new URL('./actionlint.wasm', import.meta.url)));
+
+test('lint() returns no error for valid workflow', function () {
+    const src = 'on: push\njobs:\n  test:\n    runs-on: ubuntu-latest\n    steps:\n      - run: echo hi\n';
+    assert.deepEqual(actionlint.lint('test.yaml', src), []);
+});
+
+test('lint() returns structured findings', function () {
+    const src = 'on: foo\njobs:\n  test:\n    runs-on: ubuntu-latest\n    steps:\n      - run: echo hi\n';
+    const errs = actionlint.lint('.github/workflows/test.yaml', src);
+    assert.equal(errs.length, 1, JSON.stringify(errs));
+    const err = errs[0];
+    assert.ok(err.message.includes('unknown Webhook event "foo"'), err.message);
+    assert.equal(err.filepath, '.github/workflows/test.yaml');
+    assert.equal(err.line, 1);
+    assert.equal(err.column, 5);
+    assert.equal(err.kind, 'events');
+    assert.equal(err.severity, 'error');
+});
+
+test('lint() applies config', function () {
+    const src = 'on: push\njobs:\n  test:\n    runs-on: my-runner\n    steps:\n      - run: echo hi\n';
+    assert.equal(actionlint.lint('test.yaml', src).length, 1);
+    assert.deepEqual(actionlint.lint('test.yaml', src, 'self-hosted-runner:\n  labels: [my-runner]\n'), []);
+});
+
+test('lint() throws an error on invalid config', function () {
+    assert.throws(() => actionlint.lint('test.yaml', 'on: push\n', 'self-hosted-runner: ['), /could not parse config file/);
+});
@@ -0,0 +1,35 @@
+package actionlint
+
+import (
+	"slices"
+)
+
+// WebhookEventMetadata is metadata of a webhook event which triggers workflows. The table of all
+// webhook events is AllWebhookEvents.
+type WebhookEventMetadata struct {
+	// Types is the activity types of the event which can be specified at "types". The value is nil
+	// when the activity types cannot be determined. For example repository_dispatch event can contain
+	// arbitrary types that are customized by user. The value is an empty slice when the event has no
+	// activity type.
+	Types []string
+	// Filters is the filters which are available at "on.<event>" like "branches", "paths-ignore".
+	Filters []string
+	// URL is the URL of the document of the event.
+	URL string
+	// GHES is the first version of GitHub Enterprise Server which supports the event. The value is
+	// nil when all versions support the event. Zero value means no version supports the event.
+	GHES *GHESVersion
+}
+
+// webhookEventsWithFilter returns names of the webhook events which accept the filter like
+// "branches" in sorted order.
+func webhookEventsWithFilter(filter string) []string {
+	names := []string{}
+	for n, m := range AllWebhookEvents {
+		if slices.Contains(m.Filters, filter) {
+			names = append(names, n)
+		}
+	}
+	slices.Sort(names)
+	return names
+}
@@ -0,0 +1,39 @@
+package actionlint
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestWebhookEventsConsistentWithWebhookTypes(t *testing.T) {
+	if len(AllWebhookEvents) != len(AllWebhookTypes) {
+		t.Fatalf("number of events mismatch: %d v.s. %d", len(AllWebhookEvents), len(AllWebhookTypes))
+	}
+	for name, types := range AllWebhookTypes {
+		m, ok := AllWebhookEvents[name]
+		if !ok {
+			t.Errorf("event %q is missing in AllWebhookEvents", name)
+			continue
+		}
+		if (types == nil) != (m.Types == nil) || !slices.Equal(types, m.Types) {
+			t.Errorf("types of event %q mismatch: %v v.s. %v", name, types, m.Types)
+		}
+		if !strings.HasSuffix(m.URL, "#"+name) {
+			t.Errorf("URL of event %q is unexpected: %q", name, m.URL)
+		}
+	}
+}
+
+func TestWebhookEventsWithFilter(t *testing.T) {
+	for filter, want := range map[string][]string{
+		"branches":    {"merge_group", "pull_request", "pull_request_target", "push", "workflow_run"},
+		"paths":       {"pull_request", "pull_request_target", "push"},
+		"tags-ignore": {"push"},
+		"unknown":     {},
+	} {
+		if have := webhookEventsWithFilter(filter); !slices.Equal(want, have) {
+			t.Errorf("events with filter %q should be %v but got %v", filter, want, have)
+		}
+	}
+}
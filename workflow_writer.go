@@ -0,0 +1,188 @@
+package actionlint
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// WorkflowWriter rewrites workflow source with edits. The edits are collected by its methods and
+// applied to the source text directly when calling Bytes or Diff. So comments, order of keys, and
+// formatting of the parts which are not edited are preserved. The nodes of the syntax tree parsed by
+// Parse can be used to locate the edits. Note that the positions of the edits are based on the
+// original source. Edits which overlap with earlier edits are ignored.
+type WorkflowWriter struct {
+	src   []byte
+	lines []string
+	edits []*TextEdit
+}
+
+// NewWorkflowWriter creates a new WorkflowWriter instance to rewrite the workflow source.
+func NewWorkflowWriter(src []byte) *WorkflowWriter {
+	return &WorkflowWriter{
+		src:   src,
+		lines: strings.Split(string(src), "\n"),
+	}
+}
+
+// Edit adds the text edits.
+func (w *WorkflowWriter) Edit(edits ...*TextEdit) {
+	w.edits = append(w.edits, edits...)
+}
+
+// Edits returns the text edits added to the writer.
+func (w *WorkflowWriter) Edits() []*TextEdit {
+	return w.edits
+}
+
+// Insert adds an edit to insert the text at the position.
+func (w *WorkflowWriter) Insert(pos *Pos, text string) {
+	w.Edit(&TextEdit{Start: pos, End: pos, NewText: text})
+}
+
+// InsertLines adds an edit to insert the lines before the line. The line number is 1-based. When
+// the line number is next to the last line, the lines are appended to the end of the source. A
+// newline is added to the end of the text when it does not end with a newline.
+func (w *WorkflowWriter) InsertLines(line int, text string) {
+	if !strings.HasSuffix(text, "\n") {
+		text += "\n"
+	}
+	w.Insert(&Pos{Line: line, Col: 1}, text)
+}
+
+// DeleteLines adds an edit to delete the lines from the start line to the end line. Both the start
+// and the end are inclusive and 1-based.
+func (w *WorkflowWriter) DeleteLines(start, end int) {
+	if end != len(w.lines) {
+		w.Edit(&TextEdit{Start: &Pos{Line: start, Col: 1}, End: &Pos{Line: end + 1, Col: 1}})
+		return
+	}
+	// The last line is not followed by a newline. Delete the newline before the start line instead
+	s := &Pos{Line: start, Col: 1}
+	if start > 1 {
+		s = &Pos{Line: start - 1, Col: utf8.RuneCountInString(w.lines[start-2]) + 1}
+	}
+	w.Edit(&TextEdit{Start: s, End: &Pos{Line: end, Col: utf8.RuneCountInString(w.lines[end-1]) + 1}})
+}
+
+// DeleteMappingEntry adds an edit to delete the entry of the key in block mapping. The lines of its
+// value are also deleted. The key must be at the start of line. Entries in flow mappings like
+// `{foo: bar}` or entries following "- " in sequences cannot be deleted.
+func (w *WorkflowWriter) DeleteMappingEntry(key *String) error {
+	if key.Pos == nil || key.Pos.Line < 1 || len(w.lines) < key.Pos.Line {
+		return fmt.Errorf("position of key %q is out of source", key.Value)
+	}
+	indent := key.Pos.Col - 1
+	l := w.lines[key.Pos.Line-1]
+	if len(l) < indent || strings.Trim(l[:indent], " ") != "" {
+		return fmt.Errorf("entry of key %q at %s cannot be deleted since it is not at the start of line", key.Value, key.Pos)
+	}
+
+	// Lines which are more indented than the key or blank are the value of the entry
+	end := key.Pos.Line
+	for i := key.Pos.Line; i < len(w.lines); i++ {
+		l := w.lines[i]
+		if strings.TrimSpace(l) == "" {
+			continue
+		}
+		if len(l)-len(strings.TrimLeft(l, " ")) <= indent {
+			break
+		}
+		end = i + 1
+	}
+	w.DeleteLines(key.Pos.Line, end)
+	return nil
+}
+
+// ReplaceString adds an edit to replace the string node with the new value. The quote style of the
+// string is preserved as much as possible. When the string is not quoted and the new value needs to
+// be quoted, the value is quoted with single quotes. Strings in block style ('|' or '>') or strings
+// spanning multiple lines cannot be replaced.
+func (w *WorkflowWriter) ReplaceString(s *String, v string) error {
+	if s.Pos == nil || s.Pos.Line < 1 || len(w.lines) < s.Pos.Line {
+		return fmt.Errorf("position of string %q is out of source", s.Value)
+	}
+	l := w.lines[s.Pos.Line-1]
+	start := 0
+	for c := 1; c < s.Pos.Col && start < len(l); c++ {
+		_, n := utf8.DecodeRuneInString(l[start:])
+		start += n
+	}
+	rest := l[start:]
+
+	n, quote := 0, byte(0)
+	switch {
+	case strings.HasPrefix(rest, "'"):
+		quote = '\''
+		n = workflowWriterQuotedLen(rest, quote)
+	case strings.HasPrefix(rest, `"`):
+		quote = '"'
+		n = workflowWriterQuotedLen(rest, quote)
+	case strings.HasPrefix(rest, s.Value) && !strings.Contains(s.Value, "\n"):
+		n = len(s.Value)
+	}
+	if n <= 0 {
+		return fmt.Errorf("string %q at %s cannot be replaced since it is in block style or spans multiple lines", s.Value, s.Pos)
+	}
+
+	var text string
+	switch {
+	case quote == '"', strings.ContainsFunc(v, func(r rune) bool { return !unicode.IsPrint(r) }):
+		text = strconv.Quote(v) // Escape sequences in Go are compatible with YAML's double quoted strings
+	case quote == '\'', !workflowWriterPlainSafe(v):
+		text = "'" + strings.ReplaceAll(v, "'", "''") + "'"
+	default:
+		text = v
+	}
+
+	col := s.Pos.Col
+	w.Edit(&TextEdit{
+		Start:   &Pos{Line: s.Pos.Line, Col: col},
+		End:     &Pos{Line: s.Pos.Line, Col: col + utf8.RuneCountInString(rest[:n])},
+		NewText: text,
+	})
+	return nil
+}
+
+// workflowWriterQuotedLen returns the length of the quoted string at the start of the text including
+// the quotes. It returns 0 when the closing quote is not found.
+func workflowWriterQuotedLen(text string, quote byte) int {
+	for i := 1; i < len(text); i++ {
+		switch text[i] {
+		case '\\':
+			if quote == '"' {
+				i++ // Skip escaped character
+			}
+		case quote:
+			if quote == '\'' && i+1 < len(text) && text[i+1] == '\'' {
+				i++ // '' is an escaped single quote
+				continue
+			}
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// workflowWriterPlainSafe returns whether the value can be written as plain scalar in both block
+// and flow styles.
+func workflowWriterPlainSafe(v string) bool {
+	return !strings.ContainsAny(v, ",[]{}") && formatPlainSafe(v)
+}
+
+// Bytes applies the edits to the source and returns the rewritten source.
+func (w *WorkflowWriter) Bytes() ([]byte, error) {
+	return ApplyTextEdits(w.src, w.edits)
+}
+
+// Diff applies the edits to the source and returns unified diff of the changes. The path is used in
+// the header of the diff. It returns an empty string when there is no change.
+func (w *WorkflowWriter) Diff(path string) (string, error) {
+	b, err := w.Bytes()
+	if err != nil {
+		return "", err
+	}
+	return unifiedDiff(path, w.src, b), nil
+}
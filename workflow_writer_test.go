@@ -0,0 +1,153 @@
+package actionlint
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func testWorkflowWriterSource(runsOn string) string {
+	return "on: push\njobs:\n  test:\n    runs-on: " + runsOn + "\n    steps:\n      - run: echo hello\n"
+}
+
+func TestWorkflowWriterReplaceString(t *testing.T) {
+	tests := []struct {
+		what   string
+		runsOn string
+		to     string
+		want   string
+	}{
+		{"plain", "foo # comment", "bar", "bar # comment"},
+		{"plain to quoted", "foo", "a: b", "'a: b'"},
+		{"plain to boolean", "foo", "yes", "'yes'"},
+		{"plain in flow sequence", "[self-hosted, foo]", "x,y", "[self-hosted, 'x,y']"},
+		{"single quoted", "'foo'", "it's", "'it''s'"},
+		{"single quoted with escape", "'it''s' # comment", "bar", "'bar' # comment"},
+		{"double quoted", `"foo"`, `say "hi"`, `"say \"hi\""`},
+		{"double quoted with escape", `"a\"b" # comment`, "c", `"c" # comment`},
+		{"newline", "foo", "a\nb", `"a\nb"`},
+		{"multi-byte characters", "あいう", "えお", "えお"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.what, func(t *testing.T) {
+			src := testWorkflowWriterSource(tc.runsOn)
+			w, errs := Parse([]byte(src))
+			if len(errs) > 0 {
+				t.Fatal(errs)
+			}
+			labels := w.Jobs["test"].RunsOn.Labels
+
+			ww := NewWorkflowWriter([]byte(src))
+			if err := ww.ReplaceString(labels[len(labels)-1], tc.to); err != nil {
+				t.Fatal(err)
+			}
+			b, err := ww.Bytes()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(testWorkflowWriterSource(tc.want), string(b)); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+	}
+}
+
+func TestWorkflowWriterReplaceStringError(t *testing.T) {
+	src := "on: push\njobs:\n  test:\n    runs-on: ubuntu-latest\n    steps:\n      - run: |\n          echo hello\n"
+	w, errs := Parse([]byte(src))
+	if len(errs) > 0 {
+		t.Fatal(errs)
+	}
+	run := w.Jobs["test"].Steps[0].Exec.(*ExecRun).Run
+	err := NewWorkflowWriter([]byte(src)).ReplaceString(run, "echo bye")
+	if err == nil {
+		t.Fatal("error did not occur")
+	}
+	if want := "cannot be replaced since it is in block style"; !strings.Contains(err.Error(), want) {
+		t.Fatalf("error %q does not contain %q", err.Error(), want)
+	}
+}
+
+func TestWorkflowWriterLines(t *testing.T) {
+	tests := []struct {
+		what string
+		src  string
+		edit func(w *WorkflowWriter)
+		want string
+	}{
+		{
+			what: "insert lines",
+			src:  "a\nb\n",
+			edit: func(w *WorkflowWriter) { w.InsertLines(2, "x\ny") },
+			want: "a\nx\ny\nb\n",
+		},
+		{
+			what: "append lines",
+			src:  "a\nb\n",
+			edit: func(w *WorkflowWriter) { w.InsertLines(3, "x\n") },
+			want: "a\nb\nx\n",
+		},
+		{
+			what: "delete lines",
+			src:  "a\nb\nc\nd\n",
+			edit: func(w *WorkflowWriter) { w.DeleteLines(2, 3) },
+			want: "a\nd\n",
+		},
+		{
+			what: "delete last line",
+			src:  "a\nb\n",
+			edit: func(w *WorkflowWriter) { w.DeleteLines(2, 2) },
+			want: "a\n",
+		},
+		{
+			what: "delete last line without newline",
+			src:  "a\nb\nc",
+			edit: func(w *WorkflowWriter) { w.DeleteLines(2, 3) },
+			want: "a",
+		},
+		{
+			what: "delete all lines without newline",
+			src:  "a\nb",
+			edit: func(w *WorkflowWriter) { w.DeleteLines(1, 2) },
+			want: "",
+		},
+		{
+			what: "insert and delete",
+			src:  "a\nb\nc\n",
+			edit: func(w *WorkflowWriter) {
+				w.DeleteLines(1, 1)
+				w.Insert(&Pos{Line: 3, Col: 2}, "!")
+			},
+			want: "b\nc!\n",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.what, func(t *testing.T) {
+			w := NewWorkflowWriter([]byte(tc.src))
+			tc.edit(w)
+			b, err := w.Bytes()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tc.want, string(b)); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+	}
+}
+
+func TestWorkflowWriterDiff(t *testing.T) {
+	w := NewWorkflowWriter([]byte("a\nb\n"))
+	w.InsertLines(2, "x")
+	have, err := w.Diff("test.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "--- a/test.yaml\n+++ b/test.yaml\n@@ -1,2 +1,3 @@\n a\n+x\n b\n"
+	if diff := cmp.Diff(want, have); diff != "" {
+		t.Fatal(diff)
+	}
+}